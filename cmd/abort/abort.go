@@ -9,8 +9,9 @@ import (
 
 func Command(app *app.App) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "abort",
-		Short: "Abort the current session",
+		Use:     "abort",
+		Example: "abort",
+		Short:   "Abort the current session",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			logger := log.New(cmd.OutOrStdout(), "", 0)
 			err := app.AbortFlowSessionUseCase.Execute()
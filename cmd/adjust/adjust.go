@@ -0,0 +1,66 @@
+package adjust
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/TristanShz/flow/cmd/completion"
+	"github.com/TristanShz/flow/cmd/history"
+	app "github.com/TristanShz/flow/internal/application/usecases"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/adjustsession"
+	"github.com/TristanShz/flow/utils"
+	"github.com/spf13/cobra"
+)
+
+func Command(app *app.App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "adjust [session_id (optional) (default: last session)] --start [offset] --end [offset]",
+		Example: "adjust --start +10m --end -5m",
+		Short:   "Move the start or end of a flow session by a duration",
+		Long:    "Move the start or end of a flow session by a duration, e.g. +10m or -1h30m. The session can't overlap another session after the adjustment, and every adjustment is recorded in the audit log of the flow folder",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 1 {
+				return errors.New("too many arguments")
+			}
+			if len(args) == 1 && !utils.IsIDValid(args[0]) {
+				return fmt.Errorf("invalid ID %v", args[0])
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logger := log.New(cmd.OutOrStdout(), "", 0)
+
+			command := adjustsession.Command{}
+			if len(args) == 1 {
+				command.SessionId = args[0]
+			}
+
+			command.StartOffset, _ = cmd.Flags().GetDuration("start")
+			command.EndOffset, _ = cmd.Flags().GetDuration("end")
+			command.LockedBefore = history.LockedBefore(cmd, app)
+
+			adjusted, err := app.AdjustSessionUseCase.Execute(command)
+			if errors.Is(err, adjustsession.ErrSessionNotFound) {
+				logger.Println("Session not found")
+				return nil
+			}
+			if err != nil && !errors.Is(err, adjustsession.ErrAuditLog) {
+				return err
+			}
+
+			logger.Printf("Session %v adjusted: %v - %v", adjusted.Id, adjusted.GetFormattedStartTime(), adjusted.GetFormattedEndTime())
+
+			return err
+		},
+	}
+
+	cmd.Flags().Duration("start", 0, "Duration to move the start time by, e.g. +10m or -5m")
+	cmd.Flags().Duration("end", 0, "Duration to move the end time by, e.g. +10m or -5m")
+	history.AddFlag(cmd)
+
+	cmd.RegisterFlagCompletionFunc("start", completion.Durations(completion.ShiftDurations))
+	cmd.RegisterFlagCompletionFunc("end", completion.Durations(completion.ShiftDurations))
+
+	return cmd
+}
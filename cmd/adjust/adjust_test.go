@@ -0,0 +1,78 @@
+package adjust_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/cmd/adjust"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/adjustsession"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/TristanShz/flow/test"
+	"github.com/matryer/is"
+)
+
+func TestAdjustCommand(t *testing.T) {
+	tt := []struct {
+		error error
+		name  string
+		want  string
+		args  []string
+	}{
+		{
+			name: "Last session",
+			args: []string{"--start", "+10m", "--end", "-5m"},
+			want: "Session abcdefg adjusted: 2024-04-12 19:10:00 - 2024-04-12 19:55:00",
+		},
+		{
+			name: "Session by id",
+			args: []string{"1234567", "--start", "-1h30m"},
+			want: "Session 1234567 adjusted: 2024-04-12 07:30:00 - 2024-04-12 10:00:00",
+		},
+		{
+			name: "Session not found",
+			args: []string{"7654321", "--start", "10m"},
+			want: "Session not found",
+		},
+		{
+			name:  "Overlapping another session",
+			args:  []string{"--start", "-10h"},
+			error: adjustsession.ErrOverlap,
+		},
+		{
+			name:  "Nothing to adjust",
+			args:  []string{},
+			error: adjustsession.ErrNoAdjustment,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			sessionRepository := &infra.InMemorySessionRepository{Sessions: []session.Session{
+				{
+					Id:        "1234567",
+					StartTime: time.Date(2024, time.April, 12, 9, 0, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.April, 12, 10, 0, 0, 0, time.UTC),
+					Project:   "Flow",
+				},
+				{
+					Id:        "abcdefg",
+					StartTime: time.Date(2024, time.April, 12, 19, 0, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.April, 12, 20, 0, 0, 0, time.UTC),
+					Project:   "Flow",
+				},
+			}}
+			app := test.InitializeApp(sessionRepository, infra.NewStubDateProvider())
+
+			got, err := test.ExecuteCmd(t, adjust.Command(app), tc.args...)
+
+			is.Equal(tc.error, err)
+
+			if tc.error == nil {
+				is.Equal(got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package backup
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/TristanShz/flow/cmd/history"
+	"github.com/TristanShz/flow/internal/application"
+	app "github.com/TristanShz/flow/internal/application/usecases"
+	"github.com/TristanShz/flow/internal/application/usecases/backup/createbackup"
+	"github.com/TristanShz/flow/internal/application/usecases/backup/restorebackup"
+	"github.com/spf13/cobra"
+)
+
+func formatBackup(backup application.Backup) string {
+	return fmt.Sprintf("%v %v session(s), %v file(s) %v", backup.Manifest.CreatedAt.Format(time.DateTime), backup.Manifest.Sessions, backup.Manifest.Files, backup.Path)
+}
+
+func createCommand(app *app.App) *cobra.Command {
+	return &cobra.Command{
+		Use:     "create",
+		Example: "backup create",
+		Short:   "Back up the flow folder now",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			logger := log.New(cmd.OutOrStdout(), "", 0)
+
+			result, err := app.CreateBackupUseCase.Execute(createbackup.Command{Keep: app.Config.Backups.KeepLimit()})
+			if err != nil {
+				return err
+			}
+
+			text := "Backed up: " + formatBackup(*result.Backup) + "\n"
+			for _, removed := range result.Removed {
+				text += "Removed the old backup " + removed + "\n"
+			}
+
+			logger.Print(text)
+
+			return nil
+		},
+	}
+}
+
+func listCommand(app *app.App) *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Example: "backup list",
+		Short:   "List the backups, the newest last",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			logger := log.New(cmd.OutOrStdout(), "", 0)
+
+			backups, err := app.ListBackupsUseCase.Execute()
+			if err != nil {
+				return err
+			}
+
+			if len(backups) == 0 {
+				logger.Println("There is no backup yet, run 'flow backup create'")
+				return nil
+			}
+
+			for _, backup := range backups {
+				logger.Println(formatBackup(backup))
+			}
+
+			return nil
+		},
+	}
+}
+
+func restoreCommand(app *app.App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "restore [backup]",
+		Example: "backup restore\nbackup restore ~/.flow-backups/flow-backup-20240417-190000.tar.gz --conflicts merge --dry-run",
+		Short:   "Restore the sessions of a backup, the latest one by default",
+		Long:    "Restore the sessions of a backup, the latest one by default. The sessions of the backup missing from the flow folder are restored, the conflicts tell what becomes of the ones whose id is already there: skip keeps the session of the flow folder, merge replaces it with the session of the backup, and overwrite replaces it and deletes the sessions which aren't in the backup. The restore can be undone with 'flow undo'",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logger := log.New(cmd.OutOrStdout(), "", 0)
+
+			conflictsFlag, _ := cmd.Flags().GetString("conflicts")
+			dryRunFlag, _ := cmd.Flags().GetBool("dry-run")
+
+			command := restorebackup.Command{
+				Conflicts:    conflictsFlag,
+				DryRun:       dryRunFlag,
+				LockedBefore: history.LockedBefore(cmd, app),
+			}
+			if len(args) == 1 {
+				command.Path = args[0]
+			}
+
+			result, err := app.RestoreBackupUseCase.Execute(command)
+			if errors.Is(err, application.ErrUnsupportedBackup) {
+				return fmt.Errorf("%v can't be restored: %w", result.Backup.Path, err)
+			}
+			if err != nil {
+				return err
+			}
+
+			text := ""
+			if dryRunFlag {
+				text += "Dry run, nothing was restored\n"
+			}
+			text += fmt.Sprintf("Backup of %v: %v\n", result.Backup.Manifest.CreatedAt.Format(time.DateTime), result.Backup.Path)
+			text += fmt.Sprintf("Restored: %v\n", result.Restored)
+			text += fmt.Sprintf("Replaced: %v\n", result.Replaced)
+			text += fmt.Sprintf("Unchanged: %v\n", result.Unchanged)
+			text += fmt.Sprintf("Skipped: %v\n", result.Skipped)
+			if conflictsFlag == restorebackup.ConflictsOverwrite {
+				text += fmt.Sprintf("Deleted: %v\n", result.Deleted)
+			}
+
+			logger.Print(text)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("conflicts", restorebackup.ConflictsSkip, "What becomes of the sessions already in the flow folder: "+strings.Join(restorebackup.Conflicts, ", "))
+	cmd.Flags().Bool("dry-run", false, "Count the sessions which would be restored without restoring them")
+	history.AddFlag(cmd)
+
+	return cmd
+}
+
+func Command(app *app.App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Back up the flow folder and restore the backups",
+		Long:  fmt.Sprintf("The backups are gzipped tar files of the flow folder with a manifest, written to the folder of [backup] in the configuration, the flow folder with a -backups suffix by default. The %v latest ones are kept by default, and 'flow daemon' backs up the flow folder on its own when [backup] has an every duration", application.DefaultBackupKeep),
+	}
+
+	cmd.AddCommand(createCommand(app))
+	cmd.AddCommand(listCommand(app))
+	cmd.AddCommand(restoreCommand(app))
+
+	return cmd
+}
@@ -0,0 +1,102 @@
+package backup_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/cmd/backup"
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/application/usecases/backup/createbackup"
+	"github.com/TristanShz/flow/internal/application/usecases/backup/listbackups"
+	"github.com/TristanShz/flow/internal/application/usecases/backup/restorebackup"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/TristanShz/flow/test"
+	"github.com/matryer/is"
+)
+
+func TestBackupCommand(t *testing.T) {
+	at := func(day int, hour int) time.Time {
+		return time.Date(2024, time.April, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	kept := session.Session{Id: "1", StartTime: at(15, 9), EndTime: at(15, 10), Project: "Flow"}
+	lost := session.Session{Id: "2", StartTime: at(15, 11), EndTime: at(15, 12), Project: "Flow"}
+	old := application.Backup{
+		Path:     "flow-backup-20240416-190000.tar.gz",
+		Manifest: application.BackupManifest{FormatVersion: application.BackupFormatVersion, CreatedAt: at(16, 19), Sessions: 2, Files: 3},
+	}
+	newer := application.Backup{
+		Path:     "flow-backup-20240417-190000.tar.gz",
+		Manifest: application.BackupManifest{FormatVersion: application.BackupFormatVersion + 1, CreatedAt: at(17, 19)},
+	}
+
+	tt := []struct {
+		name         string
+		args         []string
+		givenBackups []application.Backup
+		want         string
+		wantErr      bool
+	}{
+		{
+			name:         "Create",
+			args:         []string{"create"},
+			givenBackups: []application.Backup{old},
+			want:         "Backed up: 2024-04-17 19:00:00 0 session(s), 0 file(s) flow-backup-20240417-190000.tar.gz",
+		},
+		{
+			name:         "List",
+			args:         []string{"list"},
+			givenBackups: []application.Backup{old},
+			want:         "2024-04-16 19:00:00 2 session(s), 3 file(s) flow-backup-20240416-190000.tar.gz",
+		},
+		{
+			name: "List without backups",
+			args: []string{"list"},
+			want: "There is no backup yet, run 'flow backup create'",
+		},
+		{
+			name:         "Restore the latest backup",
+			args:         []string{"restore", "--dry-run"},
+			givenBackups: []application.Backup{old},
+			want:         "Dry run, nothing was restored\nBackup of 2024-04-16 19:00:00: flow-backup-20240416-190000.tar.gz\nRestored: 1\nReplaced: 0\nUnchanged: 1\nSkipped: 0",
+		},
+		{
+			name:         "Restore a newer backup",
+			args:         []string{"restore", newer.Path},
+			givenBackups: []application.Backup{old, newer},
+			wantErr:      true,
+		},
+		{
+			name:         "Invalid conflicts",
+			args:         []string{"restore", "--conflicts", "ignore"},
+			givenBackups: []application.Backup{old},
+			wantErr:      true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			sessionRepository := &infra.InMemorySessionRepository{Sessions: []session.Session{kept}}
+			dateProvider := infra.NewStubDateProvider()
+			dateProvider.Now = at(17, 19)
+			app := test.InitializeApp(sessionRepository, dateProvider)
+			backupStore := &infra.InMemoryBackupStore{
+				Backups:  tc.givenBackups,
+				Sessions: map[string][]session.Session{old.Path: {kept, lost}},
+			}
+			app.CreateBackupUseCase = createbackup.NewCreateBackupUseCase(backupStore, dateProvider)
+			app.ListBackupsUseCase = listbackups.NewListBackupsUseCase(backupStore)
+			app.RestoreBackupUseCase = restorebackup.NewRestoreBackupUseCase(sessionRepository, &infra.InMemoryActiveSessionLock{}, backupStore)
+
+			got, err := test.ExecuteCmd(t, backup.Command(app), tc.args...)
+
+			is.Equal(err != nil, tc.wantErr)
+			if err == nil {
+				is.Equal(got, tc.want)
+			}
+		})
+	}
+}
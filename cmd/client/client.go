@@ -0,0 +1,143 @@
+package client
+
+import (
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/TristanShz/flow/cmd/clipboard"
+	"github.com/TristanShz/flow/internal/application"
+	app "github.com/TristanShz/flow/internal/application/usecases"
+	"github.com/TristanShz/flow/internal/application/usecases/client/setclient"
+	"github.com/TristanShz/flow/internal/infra/presenter"
+	"github.com/TristanShz/flow/utils"
+	"github.com/spf13/cobra"
+)
+
+func setCommand(app *app.App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "set [client]",
+		Example: "client set acme --contact \"jane@acme.com\" --address \"1 Main Street, Springfield\" --po PO-42\nclient set acme --billing-profile support",
+		Short:   "Create or update the metadata of a client",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("the client name is required")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logger := log.New(cmd.OutOrStdout(), "", 0)
+
+			command := setclient.Command{Name: args[0]}
+
+			if cmd.Flags().Changed("contact") {
+				contact, _ := cmd.Flags().GetString("contact")
+				command.Contact = &contact
+			}
+
+			if cmd.Flags().Changed("address") {
+				address, _ := cmd.Flags().GetString("address")
+				command.Address = &address
+			}
+
+			if cmd.Flags().Changed("po") {
+				poNumber, _ := cmd.Flags().GetString("po")
+				command.PONumber = &poNumber
+			}
+
+			if cmd.Flags().Changed("billing-profile") {
+				billingProfile, _ := cmd.Flags().GetString("billing-profile")
+				if err := app.Config.CheckBillingProfile(billingProfile); err != nil {
+					return err
+				}
+				command.BillingProfile = &billingProfile
+			}
+
+			client, err := app.SetClientUseCase.Execute(command)
+			if err != nil {
+				return err
+			}
+
+			lines := client.HeaderLines()
+			if client.BillingProfile != "" {
+				lines = append(lines, "Billing profile: "+client.BillingProfile)
+			}
+
+			logger.Println(strings.Join(lines, "\n"))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("contact", "", "Contact of the client (name, email...)")
+	cmd.Flags().String("address", "", "Postal address of the client")
+	cmd.Flags().String("po", "", "Purchase order number to reference in exports")
+	cmd.Flags().String("billing-profile", "", "Billing profile of the config file applied to the sessions billed to the client in invoices and exports, unless their project has its own. An empty value removes it")
+
+	return cmd
+}
+
+func listCommand(app *app.App, systemClipboard application.Clipboard) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "list",
+		Example: "client list\nclient list --porcelain",
+		Short:   "List all the clients and their metadata",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			out, copied := clipboard.Output(cmd)
+			logger := log.New(out, "", 0)
+
+			clients, err := app.ListClientsUseCase.Execute()
+			if err != nil {
+				return err
+			}
+
+			porcelainFlag, _ := cmd.Flags().GetBool("porcelain")
+			output, err := presenter.ResolveOutput(cmd.OutOrStdout(), presenter.OutputText, false, porcelainFlag)
+			if err != nil {
+				return err
+			}
+
+			// a tab-separated row per client: name, contact, address and
+			// purchase order number
+			if output == presenter.OutputPlain {
+				for _, client := range clients {
+					logger.Println(strings.Join([]string{client.Name, client.Contact, client.Address, client.PONumber}, "\t"))
+				}
+				return clipboard.Copy(cmd, systemClipboard, copied)
+			}
+
+			if len(clients) == 0 {
+				logger.Println("No clients found")
+				return nil
+			}
+
+			texts := []string{}
+			for _, client := range clients {
+				lines := client.HeaderLines()
+				lines[0] = utils.ProjectColor(client.Name)
+				texts = append(texts, strings.Join(lines, "\n    "))
+			}
+
+			logger.Println(strings.Join(texts, "\n\n"))
+
+			return clipboard.Copy(cmd, systemClipboard, copied)
+		},
+	}
+
+	cmd.Flags().Bool("porcelain", false, "Print a tab-separated line per client, whose format never changes, for scripts")
+	clipboard.AddFlag(cmd)
+
+	return cmd
+}
+
+func Command(app *app.App, systemClipboard application.Clipboard) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "client",
+		Short: "Manage the clients metadata used in exports headers",
+	}
+
+	cmd.AddCommand(setCommand(app))
+	cmd.AddCommand(listCommand(app, systemClipboard))
+
+	return cmd
+}
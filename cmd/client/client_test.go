@@ -0,0 +1,84 @@
+package client_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/cmd/client"
+	"github.com/TristanShz/flow/internal/domain/billing"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/TristanShz/flow/test"
+	"github.com/matryer/is"
+)
+
+func TestClientCommand(t *testing.T) {
+	sessionRepository := &infra.InMemorySessionRepository{}
+	dateProvider := infra.NewStubDateProvider()
+	app := test.InitializeApp(sessionRepository, dateProvider)
+	app.Config.BillingProfiles = map[string]billing.Profile{"support": {Minimum: 30 * time.Minute}}
+
+	tt := []struct {
+		error error
+		name  string
+		want  string
+		args  []string
+	}{
+		{
+			name: "List without clients",
+			args: []string{"list"},
+			want: "No clients found",
+		},
+		{
+			name:  "Set without name",
+			args:  []string{"set"},
+			error: errors.New("the client name is required"),
+		},
+		{
+			name: "Set new client",
+			args: []string{"set", "Acme", "--contact", "jane@acme.com"},
+			want: "Client: Acme\nContact: jane@acme.com",
+		},
+		{
+			name: "Update client",
+			args: []string{"set", "Acme", "--po", "PO-42"},
+			want: "Client: Acme\nContact: jane@acme.com\nPO Number: PO-42",
+		},
+		{
+			name: "Set billing profile",
+			args: []string{"set", "Acme", "--billing-profile", "support"},
+			want: "Client: Acme\nContact: jane@acme.com\nPO Number: PO-42\nBilling profile: support",
+		},
+		{
+			name:  "Unknown billing profile",
+			args:  []string{"set", "Acme", "--billing-profile", "retainer"},
+			error: errors.New("unknown billing profile retainer. possible values: [support]"),
+		},
+		{
+			name: "List clients",
+			args: []string{"list"},
+			want: "Acme\n    Contact: jane@acme.com\n    PO Number: PO-42",
+		},
+		{
+			name: "List clients for scripts",
+			args: []string{"list", "--porcelain"},
+			want: "Acme\tjane@acme.com\t\tPO-42",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			c := client.Command(app, &infra.InMemoryClipboard{})
+
+			got, err := test.ExecuteCmd(t, c, tc.args...)
+
+			is.Equal(tc.error, err)
+
+			if tc.error == nil {
+				is.Equal(tc.want, got)
+			}
+		})
+	}
+}
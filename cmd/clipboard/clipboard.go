@@ -0,0 +1,56 @@
+package clipboard
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/spf13/cobra"
+)
+
+// ansiPattern matches the escape sequences of the colors of the text output
+var ansiPattern = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// AddFlag adds the --copy flag to a command printing a report or a list
+func AddFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool("copy", false, "Copy the output to the clipboard too")
+}
+
+// Output returns the writer of the output of the command, and the buffer
+// keeping a copy of the output when --copy is given, nil otherwise
+func Output(cmd *cobra.Command) (io.Writer, *bytes.Buffer) {
+	copyFlag, _ := cmd.Flags().GetBool("copy")
+	if !copyFlag {
+		return cmd.OutOrStdout(), nil
+	}
+
+	copied := new(bytes.Buffer)
+
+	return io.MultiWriter(cmd.OutOrStdout(), copied), copied
+}
+
+// Copy puts the output kept by Output on the clipboard, as text without
+// colors and as a preformatted HTML block keeping the alignment of the
+// columns when pasted in an email or a chat. The confirmation goes to the
+// error output so that the output can still be piped.
+func Copy(cmd *cobra.Command, clipboard application.Clipboard, copied *bytes.Buffer) error {
+	if copied == nil {
+		return nil
+	}
+
+	text := ansiPattern.ReplaceAllString(copied.String(), "")
+	err := clipboard.Copy(application.ClipboardContent{
+		Text: text,
+		HTML: "<pre>" + html.EscapeString(text) + "</pre>",
+	})
+	if err != nil {
+		return fmt.Errorf("the output can't be copied to the clipboard: %w", err)
+	}
+
+	fmt.Fprintln(cmd.ErrOrStderr(), "Copied to the clipboard")
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package completion
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	app "github.com/TristanShz/flow/internal/application/usecases"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/listsessionmetadata"
+	"github.com/TristanShz/flow/internal/application/usecases/project/listtags"
+	"github.com/spf13/cobra"
+)
+
+// CompleteFunc is the signature of the dynamic completions of cobra
+type CompleteFunc = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+func withPrefix(values []string, prefix string) []string {
+	completions := []string{}
+	for _, value := range values {
+		if strings.HasPrefix(value, prefix) {
+			completions = append(completions, value)
+		}
+	}
+
+	return completions
+}
+
+// Projects completes the names of the projects having sessions
+func Projects(app *app.App) CompleteFunc {
+	return func(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		projects, err := app.ListProjectsUseCase.Execute()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+
+		return withPrefix(projects, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// RegisterProjectFlag completes the --project flag of the command with the
+// names of the projects
+func RegisterProjectFlag(cmd *cobra.Command, app *app.App) {
+	cmd.RegisterFlagCompletionFunc("project", Projects(app))
+}
+
+// ProjectAndTags completes the arguments of `flow start`: the project then
+// its tags prefixed with a +. When the first argument is a tag, the tags are
+// the ones of the default project, or of all the projects when it's empty.
+func ProjectAndTags(app *app.App, defaultProject func() string) CompleteFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 && !strings.HasPrefix(toComplete, "+") {
+			return Projects(app)(cmd, args, toComplete)
+		}
+
+		project := defaultProject()
+		if len(args) > 0 && !strings.HasPrefix(args[0], "+") {
+			project = args[0]
+		}
+
+		tags, err := app.ListProjectTagsUseCase.Execute(listtags.Command{Project: project})
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+
+		completions := []string{}
+		for _, tag := range tags {
+			if !slices.Contains(args, "+"+tag) {
+				completions = append(completions, "+"+tag)
+			}
+		}
+
+		return withPrefix(completions, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// Sessions completes the ids of the sessions, the newest first, described by
+// their project and start time. The session files aren't read, only their
+// names. maxArgs is the number of ids the command takes, 0 for any number.
+func Sessions(app *app.App, maxArgs int) CompleteFunc {
+	return func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if maxArgs > 0 && len(args) >= maxArgs {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		completions := []string{}
+		for _, metadata := range app.ListSessionMetadataUseCase.Execute(listsessionmetadata.Command{}) {
+			if !strings.HasPrefix(metadata.Id, toComplete) || slices.Contains(args, metadata.Id) {
+				continue
+			}
+
+			completions = append(completions, metadata.Id+"\t"+metadata.Project+" "+metadata.StartTime.Format(time.DateTime))
+		}
+
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+}
@@ -0,0 +1,42 @@
+package completion
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/spf13/cobra"
+)
+
+var Shells = []string{"bash", "zsh", "fish"}
+
+func Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "completion [bash|zsh|fish]",
+		Example:               "completion bash > /etc/bash_completion.d/flow\ncompletion zsh > \"${fpath[1]}/_flow\"\ncompletion fish > ~/.config/fish/completions/flow.fish",
+		Short:                 "Print the completion script of a shell",
+		Long:                  "Print the completion script of a shell. Projects and tags are completed from the existing sessions, so `flow start <TAB>` suggests the projects. Ranges, dates, times and durations are completed with the values their flags accept",
+		DisableFlagsInUseLine: true,
+		ValidArgs:             Shells,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 || !slices.Contains(Shells, args[0]) {
+				return fmt.Errorf("a shell is required. possible values: %v", Shells)
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := cmd.OutOrStdout()
+
+			switch args[0] {
+			case "zsh":
+				return cmd.Root().GenZshCompletion(out)
+			case "fish":
+				return cmd.Root().GenFishCompletion(out, true)
+			default:
+				return cmd.Root().GenBashCompletionV2(out, true)
+			}
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,169 @@
+package completion_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/cmd/adjust"
+	"github.com/TristanShz/flow/cmd/completion"
+	"github.com/TristanShz/flow/cmd/flowlog"
+	"github.com/TristanShz/flow/cmd/merge"
+	"github.com/TristanShz/flow/cmd/report"
+	"github.com/TristanShz/flow/cmd/show"
+	"github.com/TristanShz/flow/cmd/start"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/TristanShz/flow/test"
+	"github.com/matryer/is"
+	"github.com/spf13/cobra"
+)
+
+func TestCompletion(t *testing.T) {
+	sessionRepository := &infra.InMemorySessionRepository{
+		Sessions: []session.Session{
+			{
+				Id:        "1",
+				StartTime: time.Date(2024, 4, 17, 9, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2024, 4, 17, 10, 0, 0, 0, time.UTC),
+				Project:   "flow",
+				Tags:      []string{"cli", "completion"},
+			},
+			{
+				Id:        "2",
+				StartTime: time.Date(2024, 4, 18, 9, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2024, 4, 18, 10, 0, 0, 0, time.UTC),
+				Project:   "my-todo",
+				Tags:      []string{"add-todo"},
+			},
+		},
+	}
+	dateProvider := infra.NewStubDateProvider()
+	dateProvider.Now = time.Date(2024, 4, 18, 10, 20, 0, 0, time.UTC)
+	app := test.InitializeApp(sessionRepository, dateProvider)
+
+	tt := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{
+			name: "Projects",
+			args: []string{"__complete", "start", ""},
+			want: []string{"flow", "my-todo"},
+		},
+		{
+			name: "Projects with a prefix",
+			args: []string{"__complete", "start", "my"},
+			want: []string{"my-todo"},
+		},
+		{
+			name: "Tags of the project",
+			args: []string{"__complete", "start", "flow", "+"},
+			want: []string{"+cli", "+completion"},
+		},
+		{
+			name: "Tags already given are left out",
+			args: []string{"__complete", "start", "flow", "+cli", "+c"},
+			want: []string{"+completion"},
+		},
+		{
+			name: "Project flag",
+			args: []string{"__complete", "report", "--project", "f"},
+			want: []string{"flow"},
+		},
+		{
+			name: "Range flag",
+			args: []string{"__complete", "report", "--range", "last"},
+			want: []string{"last-week", "last-month"},
+		},
+		{
+			name: "Range flag since a day",
+			args: []string{"__complete", "report", "--range", "since 2024-04-1"},
+			want: []string{"since 2024-04-18", "since 2024-04-17", "since 2024-04-16", "since 2024-04-15", "since 2024-04-14", "since 2024-04-13", "since 2024-04-12"},
+		},
+		{
+			name: "Date flag",
+			args: []string{"__complete", "report", "--since", "2024-04-1"},
+			want: []string{"2024-04-18", "2024-04-17", "2024-04-16", "2024-04-15", "2024-04-14", "2024-04-13", "2024-04-12"},
+		},
+		{
+			name: "Time flag",
+			args: []string{"__complete", "log", "add", "--start", ""},
+			want: []string{"10:20", "10:15", "10:00", "09:45", "09:30", "09:15", "09:00", "08:45", "08:30"},
+		},
+		{
+			name: "Duration flag",
+			args: []string{"__complete", "report", "--gap-threshold", "1"},
+			want: []string{"15m", "1h", "1h30m"},
+		},
+		{
+			name: "Session ids, the newest first",
+			args: []string{"__complete", "show", ""},
+			want: []string{"2\tmy-todo 2024-04-18 09:00:00", "1\tflow 2024-04-17 09:00:00"},
+		},
+		{
+			name: "Session ids already given are left out",
+			args: []string{"__complete", "merge", "2", ""},
+			want: []string{"1\tflow 2024-04-17 09:00:00"},
+		},
+		{
+			name: "Shift flag",
+			args: []string{"__complete", "adjust", "--start", "+1"},
+			want: []string{"+10m", "+15m"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			rootCmd := &cobra.Command{Use: "flow"}
+			rootCmd.AddCommand(start.Command(app), report.Command(app, &infra.InMemoryClipboard{}), flowlog.Command(app), adjust.Command(app), show.Command(app), merge.Command(app), completion.Command())
+
+			got, err := test.ExecuteCmd(t, rootCmd, tc.args...)
+			is.NoErr(err)
+
+			// the completions are followed by the directive of the shell
+			lines := strings.Split(got, "\n")
+			is.Equal(lines[:len(lines)-2], tc.want)
+			is.Equal(lines[len(lines)-2], ":4")
+		})
+	}
+}
+
+func TestCompletionCommand(t *testing.T) {
+	tt := []struct {
+		shell string
+		want  string
+	}{
+		{shell: "bash", want: "bash completion V2 for flow"},
+		{shell: "zsh", want: "#compdef flow"},
+		{shell: "fish", want: "fish completion for flow"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.shell, func(t *testing.T) {
+			is := is.New(t)
+
+			rootCmd := &cobra.Command{Use: "flow"}
+			rootCmd.AddCommand(completion.Command())
+
+			got, err := test.ExecuteCmd(t, rootCmd, "completion", tc.shell)
+			is.NoErr(err)
+
+			is.True(strings.Contains(got, tc.want))
+		})
+	}
+}
+
+func TestCompletionCommand_InvalidShell(t *testing.T) {
+	is := is.New(t)
+
+	rootCmd := &cobra.Command{Use: "flow"}
+	rootCmd.AddCommand(completion.Command())
+
+	_, err := test.ExecuteCmd(t, rootCmd, "completion", "powershell")
+
+	is.True(err != nil)
+}
@@ -0,0 +1,89 @@
+package completion
+
+import (
+	"time"
+
+	app "github.com/TristanShz/flow/internal/application/usecases"
+	"github.com/TristanShz/flow/pkg/timerange"
+	"github.com/TristanShz/flow/utils"
+	"github.com/spf13/cobra"
+)
+
+// Durations suggested for the flags of lengths of time and for the ones
+// moving a time
+var (
+	CommonDurations = []string{"15m", "30m", "45m", "1h", "1h30m", "2h", "4h", "8h"}
+	ShiftDurations  = []string{"+5m", "+10m", "+15m", "-5m", "-10m", "-15m"}
+)
+
+const (
+	// suggestedDays is the number of dates suggested, today included
+	suggestedDays = 7
+	// suggestedTimes is the number of times of the day suggested, every
+	// quarter of an hour before now
+	suggestedTimes = 8
+)
+
+// Ranges completes the ranges read by timerange.Parse, like last-week or
+// "since monday"
+func Ranges(app *app.App) CompleteFunc {
+	return func(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return timerange.Suggest(toComplete, app.DateProvider.GetNow()), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// Dates completes the dates of the last days, as YYYY-MM-DD
+func Dates(app *app.App) CompleteFunc {
+	return func(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		now := app.DateProvider.GetNow()
+
+		dates := []string{}
+		for i := 0; i < suggestedDays; i++ {
+			dates = append(dates, now.AddDate(0, 0, -i).Format("2006-01-02"))
+		}
+
+		return withPrefix(dates, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// Times completes the times read by utils.ParseDateTime: now, then every
+// quarter of an hour before it, as HH:MM
+func Times(app *app.App) CompleteFunc {
+	return func(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		now := app.DateProvider.GetNow()
+
+		times := []string{now.Format("15:04")}
+		quarter := now.Truncate(15 * time.Minute)
+		if quarter.Equal(now.Truncate(time.Minute)) {
+			quarter = quarter.Add(-15 * time.Minute)
+		}
+		for i := 0; i < suggestedTimes && quarter.Day() == now.Day(); i++ {
+			times = append(times, quarter.Format("15:04"))
+			quarter = quarter.Add(-15 * time.Minute)
+		}
+
+		completions := []string{}
+		for _, value := range withPrefix(times, toComplete) {
+			if _, err := utils.ParseDateTime(value, now); err == nil {
+				completions = append(completions, value)
+			}
+		}
+
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// Durations completes the durations read by time.ParseDuration among the
+// given ones
+func Durations(durations []string) CompleteFunc {
+	return func(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		completions := []string{}
+		for _, value := range withPrefix(durations, toComplete) {
+			if _, err := time.ParseDuration(value); err == nil {
+				completions = append(completions, value)
+			}
+		}
+
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+}
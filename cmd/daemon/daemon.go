@@ -0,0 +1,211 @@
+package daemon
+
+import (
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	app "github.com/TristanShz/flow/internal/application/usecases"
+	"github.com/TristanShz/flow/internal/application/usecases/backup/createbackup"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/autostop"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/meetingpause"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/reminders"
+	"github.com/spf13/cobra"
+)
+
+const timeFormat = "2006-01-02 15:04:05"
+
+// remindersInterval is how often the reminders of the notifications are
+// checked
+const remindersInterval = time.Minute
+
+// backupsInterval is how often the daemon checks if a backup is due, the
+// backups themselves are made every [backup] every of the config
+const backupsInterval = 10 * time.Minute
+
+// Command watches the screen lock, and the meetings of the calendar when
+// meetingWatcher isn't nil. It shows the reminders of the [notifications]
+// of the config with the notifier, publishing the events of the reminders
+// having one, and serves the sessions to the other flow commands when
+// sessionServer isn't nil.
+func Command(app *app.App, lockWatcher application.LockWatcher, meetingWatcher application.MeetingWatcher, notifier application.Notifier, eventPublisher application.EventPublisher, sessionServer application.SessionServer) *cobra.Command {
+	return &cobra.Command{
+		Use:     "daemon",
+		Example: "daemon",
+		Short:   "Stop or pause the flow sessions when the screen is locked or a meeting starts",
+		Long:    "Watch the screen lock, the lid and the sleep of the system, and apply the on lock action of the project of the current session, see 'flow project set'. When a calendar is configured, apply the on meeting action of the project when a meeting of the calendar starts. When the [notifications] of the config have a long session or work hours, show a desktop notification when a session flows for too long, or when no session flows during the work hours. When they have an untracked after, alert once that much working time went by without a session, with a notification and a working_hours.untracked event posted to the webhooks. When [backup] has an every duration, back up the flow folder that often, see 'flow backup'. While it runs, the other flow commands get the sessions and the current session from the daemon, through a socket of the flow folder, instead of reading the flow folder themselves",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			logger := log.New(cmd.OutOrStdout(), "", 0)
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			lockEvents := make(chan application.LockEvent)
+			lockErr := make(chan error, 1)
+			go func() {
+				lockErr <- lockWatcher.Watch(ctx, lockEvents)
+				close(lockEvents)
+			}()
+
+			logger.Println("Watching the screen lock")
+
+			// a nil channel is never ready, without calendar only the lock
+			// events are received
+			var meetingEvents chan application.MeetingEvent
+			meetingErr := make(chan error, 1)
+			if meetingWatcher != nil {
+				meetingEvents = make(chan application.MeetingEvent)
+				go func() {
+					meetingErr <- meetingWatcher.Watch(ctx, meetingEvents)
+					close(meetingEvents)
+				}()
+
+				logger.Println("Watching the meetings of the calendar")
+			}
+
+			// the server stops with the daemon, or right away when another
+			// daemon is serving the sessions
+			var served chan error
+			if sessionServer != nil {
+				served = make(chan error, 1)
+				go func() {
+					served <- sessionServer.Serve(ctx)
+				}()
+
+				logger.Println("Serving the sessions to the flow commands")
+			}
+
+			// the reminders are checked until the daemon is interrupted,
+			// once when it starts and then every remindersInterval
+			var remindersTicks <-chan time.Time
+			var interrupted <-chan struct{}
+			shown := map[string]bool{}
+			if app.Config.Notifications.Reminds() {
+				ticker := time.NewTicker(remindersInterval)
+				defer ticker.Stop()
+				remindersTicks = ticker.C
+				interrupted = ctx.Done()
+
+				logger.Println("Reminding of the long sessions and the work hours")
+				remind(app, notifier, eventPublisher, logger, shown)
+			}
+
+			// a backup is made when the last one is older than the every of
+			// the config, so restarting the daemon doesn't back up again
+			var backupsTicks <-chan time.Time
+			var backupsInterrupted <-chan struct{}
+			if app.Config.Backups.Every > 0 {
+				ticker := time.NewTicker(backupsInterval)
+				defer ticker.Stop()
+				backupsTicks = ticker.C
+				backupsInterrupted = ctx.Done()
+
+				logger.Printf("Backing up the flow folder every %v", app.Config.Backups.Every)
+				backUp(app, logger)
+			}
+
+			for lockEvents != nil || meetingEvents != nil || remindersTicks != nil || backupsTicks != nil || served != nil {
+				select {
+				case err := <-served:
+					served = nil
+					if err != nil {
+						logger.Printf("The sessions can't be served: %v", err)
+					}
+				case <-remindersTicks:
+					remind(app, notifier, eventPublisher, logger, shown)
+				case <-interrupted:
+					remindersTicks = nil
+					interrupted = nil
+				case <-backupsTicks:
+					backUp(app, logger)
+				case <-backupsInterrupted:
+					backupsTicks = nil
+					backupsInterrupted = nil
+				case event, ok := <-lockEvents:
+					if !ok {
+						lockEvents = nil
+						continue
+					}
+
+					action, err := app.AutostopUseCase.Execute(autostop.Command{Locked: event.Locked, At: event.At})
+					if err != nil {
+						logger.Printf("Error while handling the screen lock: %v", err)
+						continue
+					}
+
+					if action != autostop.ActionNone {
+						logger.Printf("%v Session %v", event.At.Format(timeFormat), action)
+					}
+				case event, ok := <-meetingEvents:
+					if !ok {
+						meetingEvents = nil
+						continue
+					}
+
+					action, err := app.MeetingPauseUseCase.Execute(meetingpause.Command{Started: event.Started, At: event.At, Title: event.Title})
+					if err != nil {
+						logger.Printf("Error while handling the meeting %v: %v", event.Title, err)
+						continue
+					}
+
+					if action != meetingpause.ActionNone {
+						logger.Printf("%v Session %v", event.At.Format(timeFormat), action)
+					}
+				}
+			}
+
+			if err := <-lockErr; err != nil {
+				logger.Printf("Some lock events can't be watched: %v", err)
+			}
+
+			if meetingWatcher != nil {
+				if err := <-meetingErr; err != nil {
+					logger.Printf("The meetings can't be watched: %v", err)
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+// backUp makes the backup of the flow folder when it's due
+func backUp(app *app.App, logger *log.Logger) {
+	result, err := app.CreateBackupUseCase.Execute(createbackup.Command{
+		Every: app.Config.Backups.Every,
+		Keep:  app.Config.Backups.KeepLimit(),
+	})
+	if err != nil {
+		logger.Printf("The flow folder can't be backed up: %v", err)
+		return
+	}
+
+	if result.Backup != nil {
+		logger.Printf("%v Backed up to %v", result.Backup.Manifest.CreatedAt.Format(timeFormat), result.Backup.Path)
+	}
+}
+
+// remind shows the reminders due which weren't shown yet, and publishes
+// their event, a reminder failing to show isn't retried
+func remind(app *app.App, notifier application.Notifier, eventPublisher application.EventPublisher, logger *log.Logger, shown map[string]bool) {
+	for _, reminder := range app.RemindersUseCase.Execute(reminders.Command{Settings: app.Config.Notifications}) {
+		if shown[reminder.Key] {
+			continue
+		}
+
+		shown[reminder.Key] = true
+		if reminder.Event != nil {
+			eventPublisher.Publish(*reminder.Event)
+		}
+
+		if err := notifier.Notify(reminder.Notification); err != nil {
+			logger.Printf("The reminder can't be shown: %v", err)
+			continue
+		}
+
+		logger.Printf("%v %v", app.DateProvider.GetNow().Format(timeFormat), reminder.Notification.Title)
+	}
+}
@@ -0,0 +1,220 @@
+package daemon_test
+
+import (
+	"context"
+	"io"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/cmd/daemon"
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/application/usecases/backup/createbackup"
+	"github.com/TristanShz/flow/internal/application/usecases/project/setproject"
+	"github.com/TristanShz/flow/internal/domain/project"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/TristanShz/flow/internal/infra/socket"
+	"github.com/TristanShz/flow/test"
+	"github.com/matryer/is"
+)
+
+func TestDaemonCommand(t *testing.T) {
+	is := is.New(t)
+
+	sessionRepository := &infra.InMemorySessionRepository{}
+	dateProvider := infra.NewStubDateProvider()
+	app := test.InitializeApp(sessionRepository, dateProvider)
+
+	onLock := project.OnLockStop
+	_, err := app.SetProjectUseCase.Execute(setproject.Command{Name: "Flow", OnLock: &onLock})
+	is.NoErr(err)
+
+	sessionRepository.Sessions = []session.Session{{
+		Id:        "1",
+		StartTime: time.Date(2024, time.April, 13, 9, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+	}}
+
+	lockTime := time.Date(2024, time.April, 13, 10, 0, 0, 0, time.UTC)
+	lockWatcher := &infra.StubLockWatcher{Events: []application.LockEvent{
+		{Locked: true, At: lockTime},
+		{Locked: false, At: lockTime.Add(time.Hour)},
+	}}
+
+	c := daemon.Command(app, lockWatcher, nil, &infra.InMemoryNotifier{}, &infra.InMemoryEventPublisher{}, nil)
+
+	got, err := test.ExecuteCmd(t, c)
+
+	is.NoErr(err)
+	is.Equal(got, "Watching the screen lock\n2024-04-13 10:00:00 Session stopped")
+	is.Equal(sessionRepository.Sessions[0].EndTime, lockTime)
+}
+
+func TestDaemonCommand_Meetings(t *testing.T) {
+	is := is.New(t)
+
+	sessionRepository := &infra.InMemorySessionRepository{}
+	dateProvider := infra.NewStubDateProvider()
+	app := test.InitializeApp(sessionRepository, dateProvider)
+
+	onMeeting := project.OnMeetingPause
+	_, err := app.SetProjectUseCase.Execute(setproject.Command{Name: "Flow", OnMeeting: &onMeeting})
+	is.NoErr(err)
+
+	sessionRepository.Sessions = []session.Session{{
+		Id:        "1",
+		StartTime: time.Date(2024, time.April, 13, 9, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+	}}
+
+	meetingStart := time.Date(2024, time.April, 13, 10, 0, 0, 0, time.UTC)
+	meetingWatcher := &infra.StubMeetingWatcher{Events: []application.MeetingEvent{
+		{Started: true, At: meetingStart, Title: "Daily standup"},
+	}}
+
+	c := daemon.Command(app, &infra.StubLockWatcher{}, meetingWatcher, &infra.InMemoryNotifier{}, &infra.InMemoryEventPublisher{}, nil)
+
+	got, err := test.ExecuteCmd(t, c)
+
+	is.NoErr(err)
+	is.Equal(got, "Watching the screen lock\nWatching the meetings of the calendar\n2024-04-13 10:00:00 Session paused")
+	is.Equal(sessionRepository.Sessions[0].EndTime, meetingStart)
+}
+
+func TestDaemonCommand_Reminders(t *testing.T) {
+	is := is.New(t)
+
+	sessionRepository := &infra.InMemorySessionRepository{}
+	dateProvider := infra.NewStubDateProvider()
+	dateProvider.Now = time.Date(2024, time.April, 13, 12, 30, 0, 0, time.UTC)
+	app := test.InitializeApp(sessionRepository, dateProvider)
+	app.Config.Notifications = application.Notifications{LongSession: 3 * time.Hour}
+
+	sessionRepository.Sessions = []session.Session{{
+		Id:        "1",
+		StartTime: time.Date(2024, time.April, 13, 9, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+	}}
+
+	notifier := &infra.InMemoryNotifier{}
+	c := daemon.Command(app, &infra.StubLockWatcher{}, nil, notifier, &infra.InMemoryEventPublisher{}, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	c.SetContext(ctx)
+
+	got, err := test.ExecuteCmd(t, c)
+
+	is.NoErr(err)
+	is.Equal(got, "Watching the screen lock\nReminding of the long sessions and the work hours\n2024-04-13 12:30:00 Session flowing for 3h30m0s")
+	is.Equal(notifier.Notifications, []application.Notification{{
+		Title:   "Session flowing for 3h30m0s",
+		Message: "Flow since 9:00AM, stop it with 'flow stop' if it's over",
+	}})
+}
+
+func TestDaemonCommand_UntrackedWorkingHours(t *testing.T) {
+	is := is.New(t)
+
+	sessionRepository := &infra.InMemorySessionRepository{}
+	dateProvider := infra.NewStubDateProvider()
+	dateProvider.Now = time.Date(2024, time.April, 15, 12, 0, 0, 0, time.UTC)
+	app := test.InitializeApp(sessionRepository, dateProvider)
+	workHours, err := session.ParseTagRule("", "mon-fri after 09:00 before 18:00")
+	is.NoErr(err)
+	app.Config.Notifications = application.Notifications{WorkHours: &workHours, NoSessionAfter: 4 * time.Hour, UntrackedAfter: 3 * time.Hour}
+
+	lastSession := session.Session{
+		Id:        "1",
+		StartTime: time.Date(2024, time.April, 12, 14, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, time.April, 12, 18, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+	}
+	sessionRepository.Sessions = []session.Session{lastSession}
+
+	notifier := &infra.InMemoryNotifier{}
+	eventPublisher := &infra.InMemoryEventPublisher{}
+	c := daemon.Command(app, &infra.StubLockWatcher{}, nil, notifier, eventPublisher, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	c.SetContext(ctx)
+
+	got, err := test.ExecuteCmd(t, c)
+
+	is.NoErr(err)
+	is.Equal(got, "Watching the screen lock\nReminding of the long sessions and the work hours\n2024-04-15 12:00:00 3h0m0s of working hours untracked")
+	is.Equal(eventPublisher.Events, []application.Event{{
+		Type:      application.EventWorkingHoursUntracked,
+		At:        dateProvider.Now,
+		Session:   lastSession,
+		Untracked: 3 * time.Hour,
+	}})
+}
+
+func TestDaemonCommand_Backups(t *testing.T) {
+	is := is.New(t)
+
+	dateProvider := infra.NewStubDateProvider()
+	dateProvider.Now = time.Date(2024, time.April, 13, 12, 30, 0, 0, time.UTC)
+	app := test.InitializeApp(&infra.InMemorySessionRepository{}, dateProvider)
+	app.Config.Backups = application.Backups{Every: 24 * time.Hour, Keep: 1}
+
+	old := application.Backup{Path: "flow-backup-20240411-123000.tar.gz", Manifest: application.BackupManifest{CreatedAt: dateProvider.Now.Add(-48 * time.Hour)}}
+	backupStore := &infra.InMemoryBackupStore{Backups: []application.Backup{old}}
+	app.CreateBackupUseCase = createbackup.NewCreateBackupUseCase(backupStore, dateProvider)
+
+	c := daemon.Command(app, &infra.StubLockWatcher{}, nil, &infra.InMemoryNotifier{}, &infra.InMemoryEventPublisher{}, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	c.SetContext(ctx)
+
+	got, err := test.ExecuteCmd(t, c)
+
+	is.NoErr(err)
+	is.Equal(got, "Watching the screen lock\nBacking up the flow folder every 24h0m0s\n2024-04-13 12:30:00 Backed up to flow-backup-20240413-123000.tar.gz")
+	is.Equal(len(backupStore.Backups), 1) // the old backup is removed
+	is.Equal(backupStore.Backups[0].Manifest.CreatedAt, dateProvider.Now)
+}
+
+func TestDaemonCommand_ServesTheSessions(t *testing.T) {
+	is := is.New(t)
+
+	sessionRepository := &infra.InMemorySessionRepository{Sessions: []session.Session{{
+		Id:        "1",
+		StartTime: time.Date(2024, time.April, 13, 9, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+	}}}
+	app := test.InitializeApp(sessionRepository, infra.NewStubDateProvider())
+
+	path := socket.Path(t.TempDir())
+	server := socket.NewServer(path, sessionRepository, &infra.InMemoryActiveSessionLock{})
+	c := daemon.Command(app, &infra.StubLockWatcher{}, nil, &infra.InMemoryNotifier{}, &infra.InMemoryEventPublisher{}, server)
+	ctx, cancel := context.WithCancel(context.Background())
+	c.SetContext(ctx)
+
+	type result struct {
+		err error
+		got string
+	}
+	done := make(chan result)
+	go func() {
+		got, err := test.ExecuteCmd(t, c)
+		done <- result{err: err, got: got}
+	}()
+
+	var client *socket.Client
+	for start := time.Now(); client == nil && time.Since(start) < time.Second; time.Sleep(10 * time.Millisecond) {
+		client, _ = socket.Dial(path, log.New(io.Discard, "", 0))
+	}
+	is.True(client != nil) // the daemon listens on the socket
+	defer client.Close()
+
+	last, err := client.FindLastSession()
+	is.NoErr(err)
+	is.Equal(last.Project, "Flow")
+
+	cancel()
+	r := <-done
+	is.NoErr(r.err)
+	is.Equal(r.got, "Watching the screen lock\nServing the sessions to the flow commands")
+}
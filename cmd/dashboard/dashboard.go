@@ -0,0 +1,32 @@
+package dashboard
+
+import (
+	app "github.com/TristanShz/flow/internal/application/usecases"
+	"github.com/TristanShz/flow/internal/infra/tui"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+)
+
+func Command(app *app.App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "dashboard",
+		Aliases: []string{"ui"},
+		Example: "dashboard",
+		Short:   "Browse and edit the flow sessions in an interactive dashboard",
+		Long:    "Browse the flow sessions in an interactive dashboard, filter them by project, tag or range, edit their project, tags and note, and start or stop a session while the timer of the current session runs live",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			program := tea.NewProgram(
+				tui.NewDashboard(app),
+				tea.WithAltScreen(),
+				tea.WithInput(cmd.InOrStdin()),
+				tea.WithOutput(cmd.OutOrStdout()),
+			)
+
+			_, err := program.Run()
+
+			return err
+		},
+	}
+
+	return cmd
+}
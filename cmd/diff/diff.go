@@ -0,0 +1,99 @@
+package diff
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/TristanShz/flow/cmd/completion"
+	app "github.com/TristanShz/flow/internal/application/usecases"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/diffperiods"
+	"github.com/TristanShz/flow/pkg/timerange"
+	"github.com/TristanShz/flow/utils"
+	"github.com/spf13/cobra"
+)
+
+// signed prints a positive difference with a plus sign, negative ones already
+// have a minus sign
+func signed[T time.Duration | int](value T) string {
+	if value >= 0 {
+		return fmt.Sprintf("+%v", value)
+	}
+	return fmt.Sprint(value)
+}
+
+func Command(app *app.App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "diff",
+		Example: "diff --project my-todo --a last-month --b this-month\ndiff --a 2024-03 --b 2024-04",
+		Short:   "Compare the sessions of two periods",
+		Long:    "Compare the sessions of two periods: which tags gained or lost time, and how the number and the average length of sessions changed",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logger := log.New(cmd.OutOrStdout(), "", 0)
+
+			projectFlag, _ := cmd.Flags().GetString("project")
+			aFlag, _ := cmd.Flags().GetString("a")
+			bFlag, _ := cmd.Flags().GetString("b")
+
+			now := app.DateProvider.GetNow()
+
+			a, err := timerange.ParsePeriod(aFlag, now)
+			if err != nil {
+				return err
+			}
+
+			b, err := timerange.ParsePeriod(bFlag, now)
+			if err != nil {
+				return err
+			}
+
+			diff, err := app.DiffPeriodsUseCase.Execute(diffperiods.Command{
+				Project: projectFlag,
+				A:       a,
+				B:       b,
+			})
+			if err != nil {
+				return err
+			}
+
+			title := "All projects"
+			if projectFlag != "" {
+				title = utils.ProjectColor(projectFlag)
+			}
+
+			lines := []string{
+				fmt.Sprintf("%v: %v vs %v", title, aFlag, bFlag),
+				"",
+				fmt.Sprintf("Sessions: %v -> %v (%v)", diff.A.Sessions, diff.B.Sessions, signed(diff.B.Sessions-diff.A.Sessions)),
+				fmt.Sprintf("Average length: %v -> %v (%v)", utils.TimeColor(diff.A.AverageDuration().String()), utils.TimeColor(diff.B.AverageDuration().String()), signed(diff.B.AverageDuration()-diff.A.AverageDuration())),
+				fmt.Sprintf("Total: %v -> %v (%v)", utils.TimeColor(diff.A.Duration.String()), utils.TimeColor(diff.B.Duration.String()), signed(diff.B.Duration-diff.A.Duration)),
+			}
+
+			tagLines := []string{}
+			for _, tag := range diff.Tags {
+				if tag.Delta() == 0 {
+					continue
+				}
+				tagLines = append(tagLines, fmt.Sprintf("    %v %v (%v -> %v)", signed(tag.Delta()), utils.TagColor(tag.Tag), tag.A, tag.B))
+			}
+
+			if len(tagLines) > 0 {
+				lines = append(lines, "", "Tags:")
+				lines = append(lines, tagLines...)
+			}
+
+			logger.Println(strings.Join(lines, "\n"))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("project", "p", "", "Only compare the sessions of the given project")
+	cmd.Flags().String("a", timerange.PeriodLastMonth, "First period. Possible values: today, yesterday, this-week, last-week, this-month, last-month or a month like 2024-04")
+	cmd.Flags().String("b", timerange.PeriodThisMonth, "Second period, same values as --a")
+
+	completion.RegisterProjectFlag(cmd, app)
+
+	return cmd
+}
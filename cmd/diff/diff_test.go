@@ -0,0 +1,88 @@
+package diff_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/cmd/diff"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/TristanShz/flow/pkg/timerange"
+	"github.com/TristanShz/flow/test"
+	"github.com/matryer/is"
+)
+
+func TestDiffCommand(t *testing.T) {
+	tt := []struct {
+		error error
+		name  string
+		want  string
+		args  []string
+	}{
+		{
+			name: "Last month and this month of a project",
+			args: []string{"--project", "Flow"},
+			want: "Flow: last-month vs this-month\n\nSessions: 2 -> 1 (-1)\nAverage length: 1h30m0s -> 3h0m0s (+1h30m0s)\nTotal: 3h0m0s -> 3h0m0s (+0s)\n\nTags:\n    +2h0m0s docs (1h0m0s -> 3h0m0s)\n    -2h0m0s cli (2h0m0s -> 0s)",
+		},
+		{
+			name: "Months of all projects",
+			args: []string{"--a", "2024-03", "--b", "2024-04"},
+			want: "All projects: 2024-03 vs 2024-04\n\nSessions: 3 -> 1 (-2)\nAverage length: 1h20m0s -> 3h0m0s (+1h40m0s)\nTotal: 4h0m0s -> 3h0m0s (-1h0m0s)\n\nTags:\n    +2h0m0s docs (1h0m0s -> 3h0m0s)\n    -3h0m0s cli (3h0m0s -> 0s)",
+		},
+		{
+			name:  "Invalid period",
+			args:  []string{"--a", "next-month"},
+			error: timerange.ErrInvalidPeriod,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			sessionRepository := &infra.InMemorySessionRepository{Sessions: []session.Session{
+				{
+					Id:        "1",
+					StartTime: time.Date(2024, time.March, 4, 9, 0, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.March, 4, 11, 0, 0, 0, time.UTC),
+					Project:   "Flow",
+					Tags:      []string{"cli"},
+				},
+				{
+					Id:        "2",
+					StartTime: time.Date(2024, time.March, 5, 9, 0, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.March, 5, 10, 0, 0, 0, time.UTC),
+					Project:   "Flow",
+					Tags:      []string{"docs"},
+				},
+				{
+					Id:        "3",
+					StartTime: time.Date(2024, time.March, 6, 9, 0, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.March, 6, 10, 0, 0, 0, time.UTC),
+					Project:   "MyTodo",
+					Tags:      []string{"cli"},
+				},
+				{
+					Id:        "4",
+					StartTime: time.Date(2024, time.April, 2, 9, 0, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.April, 2, 12, 0, 0, 0, time.UTC),
+					Project:   "Flow",
+					Tags:      []string{"docs"},
+				},
+			}}
+			dateProvider := infra.NewStubDateProvider()
+			dateProvider.Now = time.Date(2024, time.April, 17, 12, 0, 0, 0, time.UTC)
+			app := test.InitializeApp(sessionRepository, dateProvider)
+
+			c := diff.Command(app)
+
+			got, err := test.ExecuteCmd(t, c, tc.args...)
+
+			is.Equal(tc.error, err)
+
+			if tc.error == nil {
+				is.Equal(got, tc.want)
+			}
+		})
+	}
+}
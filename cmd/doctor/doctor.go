@@ -0,0 +1,73 @@
+package doctor
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	app "github.com/TristanShz/flow/internal/application/usecases"
+	storedoctor "github.com/TristanShz/flow/internal/application/usecases/store/doctor"
+	"github.com/TristanShz/flow/utils"
+	"github.com/spf13/cobra"
+)
+
+func Command(app *app.App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "doctor",
+		Example: "doctor\ndoctor --repair",
+		Short:   "Find and repair corrupted session files",
+		Long:    "Find session files that can't be read and sessions that were never stopped. With --repair, files holding a readable session are renamed and the others are moved to the quarantine folder",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			logger := log.New(cmd.OutOrStdout(), "", 0)
+
+			repairFlag, _ := cmd.Flags().GetBool("repair")
+
+			report, err := app.DoctorUseCase.Execute(storedoctor.Command{Repair: repairFlag})
+			if err != nil {
+				return err
+			}
+
+			if len(report.Issues) == 0 && len(report.Unstopped) == 0 {
+				logger.Println("No corrupted session files found")
+				return nil
+			}
+
+			sections := []string{}
+
+			if len(report.Issues) > 0 {
+				text := fmt.Sprintf("%v corrupted session file(s) found\n", len(report.Issues))
+				for _, issue := range report.Issues {
+					text += fmt.Sprintf("    %v %v %v\n", issue.Filename, utils.TagColor(issue.Kind), utils.Faint(issue.Reason))
+				}
+
+				if !repairFlag {
+					text += "\nRun 'flow doctor --repair' to repair them"
+				} else {
+					text += fmt.Sprintf("\n%v repaired, %v moved to quarantine", len(report.Repaired), len(report.Quarantined))
+				}
+
+				sections = append(sections, text)
+			}
+
+			if len(report.Unstopped) > 0 {
+				text := fmt.Sprintf("%v unstopped session(s) found\n", len(report.Unstopped))
+				for _, s := range report.Unstopped {
+					text += fmt.Sprintf("    %v %v %v\n", s.Id, utils.TimeColor(s.GetFormattedStartTime()), utils.ProjectColor(s.Project))
+				}
+				text += "\nSet their end time with 'flow edit [session-id] --end [time]'"
+
+				sections = append(sections, text)
+			}
+
+			text := strings.Join(sections, "\n\n")
+
+			logger.Println(text)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool("repair", false, "Repair the corrupted files or move them to quarantine")
+
+	return cmd
+}
@@ -0,0 +1,81 @@
+package doctor_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/cmd/doctor"
+	"github.com/TristanShz/flow/internal/application"
+	storedoctor "github.com/TristanShz/flow/internal/application/usecases/store/doctor"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/TristanShz/flow/test"
+	"github.com/matryer/is"
+)
+
+func TestDoctorCommand(t *testing.T) {
+	sessionRepository := &infra.InMemorySessionRepository{}
+	dateProvider := infra.NewStubDateProvider()
+	app := test.InitializeApp(sessionRepository, dateProvider)
+
+	issues := []application.SessionFileIssue{
+		{Filename: "1-my-project-1713380400.json", Kind: application.InvalidFilenameIssue, Reason: "invalid session file name"},
+		{Filename: "2-Flow-1713387600.json", Kind: application.CorruptedDataIssue, Reason: "unexpected end of JSON input"},
+	}
+
+	unstoppedSessions := []session.Session{
+		{
+			Id:        "3",
+			StartTime: time.Date(2024, time.April, 13, 9, 0, 0, 0, time.UTC),
+			Project:   "Flow",
+		},
+		{
+			Id:        "4",
+			StartTime: time.Date(2024, time.April, 14, 9, 0, 0, 0, time.UTC),
+			Project:   "Flow",
+		},
+	}
+
+	tt := []struct {
+		name          string
+		args          []string
+		givenIssues   []application.SessionFileIssue
+		givenSessions []session.Session
+		want          string
+	}{
+		{
+			name: "No issues",
+			want: "No corrupted session files found",
+		},
+		{
+			name:        "Issues found",
+			givenIssues: issues,
+			want:        "2 corrupted session file(s) found\n    1-my-project-1713380400.json invalid-filename invalid session file name\n    2-Flow-1713387600.json corrupted-data unexpected end of JSON input\n\nRun 'flow doctor --repair' to repair them",
+		},
+		{
+			name:        "Repair",
+			args:        []string{"--repair"},
+			givenIssues: issues,
+			want:        "2 corrupted session file(s) found\n    1-my-project-1713380400.json invalid-filename invalid session file name\n    2-Flow-1713387600.json corrupted-data unexpected end of JSON input\n\n1 repaired, 1 moved to quarantine",
+		},
+		{
+			name:          "Unstopped sessions",
+			givenSessions: unstoppedSessions,
+			want:          "1 unstopped session(s) found\n    3 2024-04-13 09:00:00 Flow\n\nSet their end time with 'flow edit [session-id] --end [time]'",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			sessionRepository.Sessions = tc.givenSessions
+			app.DoctorUseCase = storedoctor.NewDoctorUseCase(&infra.StubSessionFilesDoctor{Issues: tc.givenIssues}, sessionRepository)
+
+			got, err := test.ExecuteCmd(t, doctor.Command(app), tc.args...)
+
+			is.NoErr(err)
+			is.Equal(tc.want, got)
+		})
+	}
+}
@@ -1,14 +1,21 @@
 package edit
 
 import (
+	"cmp"
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"strings"
+	"time"
 
+	"github.com/TristanShz/flow/cmd/completion"
+	"github.com/TristanShz/flow/cmd/history"
 	app "github.com/TristanShz/flow/internal/application/usecases"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/editsession"
 	"github.com/TristanShz/flow/internal/domain/session"
 	"github.com/TristanShz/flow/internal/infra/filesystem"
 	"github.com/TristanShz/flow/utils"
@@ -36,11 +43,26 @@ func getOpenCommand(filePath string) *exec.Cmd {
 	return command
 }
 
+// warnDeprecatedTags warns about the deprecated tags of the config among the
+// tags
+func warnDeprecatedTags(logger *log.Logger, tags []string, deprecations []session.TagDeprecation) {
+	for _, deprecation := range session.DeprecatedTags(tags, deprecations) {
+		if deprecation.Replacement == "" {
+			logger.Printf("Warning: the tag %v is deprecated", utils.TagColor(deprecation.Tag))
+			continue
+		}
+
+		logger.Printf("Warning: the tag %v is deprecated, use %v instead", utils.TagColor(deprecation.Tag), utils.TagColor(deprecation.Replacement))
+	}
+}
+
 func Command(app *app.App, sessionsPath string) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "edit [session_id (optional) (default: last session)]",
-		Short: "Open the flow session in the default editor",
-		Long:  "Open the flow session in the default editor, if no session_id is provided, the last session will be opened",
+		Use:               "edit [session_id (optional) (default: last session)]",
+		Example:           "edit --project my-todo --tag add-todo --start 09:30\nedit abc1234 --end \"2024-04-12 19:00\"\nedit --continues abc1234 --blocked-by \"waiting for the review\"",
+		Short:             "Edit a flow session",
+		Long:              "Edit a flow session with the given flags, or open it in the default editor when no flag is given. If no session_id is provided, the last session is edited",
+		ValidArgsFunction: completion.Sessions(app, 1),
 		Args: func(cmd *cobra.Command, args []string) error {
 			if len(args) == 0 {
 				return nil
@@ -58,30 +80,84 @@ func Command(app *app.App, sessionsPath string) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			logger := log.New(cmd.OutOrStdout(), "", 0)
 
-			var session *session.Session
+			var existing *session.Session
+			var err error
 
 			if len(args) == 0 {
-				session = app.SessionRepository.FindLastSession()
+				existing, err = app.SessionRepository.FindLastSession()
 			} else {
-				session = app.SessionRepository.FindById(args[0])
+				existing, err = app.SessionRepository.FindById(args[0])
+			}
+			if err != nil {
+				return err
 			}
 
-			if session == nil {
+			if existing == nil {
 				logger.Println("Session not found")
 				return nil
 			}
 
+			lockedBefore := history.LockedBefore(cmd, app)
+
+			// --unlock-history alone opens the session in the editor
+			changes := cmd.Flags().NFlag()
+			if cmd.Flags().Changed("unlock-history") {
+				changes--
+			}
+
+			if changes > 0 {
+				command, err := editCommand(cmd, existing.Id, app.DateProvider.GetNow(), app.Config.Overlap)
+				if err != nil {
+					return err
+				}
+				command.LockedBefore = lockedBefore
+
+				// the session is saved despite the overlaps it's warned about
+				edited, err := app.EditSessionUseCase.Execute(command)
+				var overlapWarning *session.OverlapWarning
+				if err != nil && !errors.As(err, &overlapWarning) {
+					return err
+				}
+
+				logger.Printf("Session %v updated: %v %v - %v", edited.Id, utils.ProjectColor(edited.Project), edited.GetFormattedStartTime(), edited.GetFormattedEndTime())
+
+				if overlapWarning != nil {
+					logger.Printf("Warning: %v", overlapWarning)
+				}
+
+				if command.Tags != nil {
+					warnDeprecatedTags(logger, *command.Tags, app.Config.TagDeprecations)
+				}
+
+				return nil
+			}
+
+			if existing.IsLocked(lockedBefore) {
+				return session.ErrLockedHistory
+			}
+
 			sessionFilename := filesystem.SessionFilename{
-				Id:        session.Id,
-				Project:   session.Project,
-				StartTime: session.StartTime,
+				Id:        existing.Id,
+				Project:   existing.Project,
+				StartTime: existing.StartTime,
 			}
 
-			filePath := filepath.Join(sessionsPath, sessionFilename.String())
+			// sessions that weren't migrated yet still use an older filename
+			var filePath string
+			for _, filename := range []string{sessionFilename.String(), sessionFilename.EncodedString(), sessionFilename.LegacyString()} {
+				filePath = filepath.Join(sessionsPath, filename)
+				if _, err := os.Stat(filePath); err == nil {
+					break
+				}
+			}
+
+			if content, err := os.ReadFile(filePath); err == nil && filesystem.IsEncrypted(content) {
+				return fmt.Errorf("the session file is encrypted, edit the session with the flags instead")
+			}
 
 			command := getOpenCommand(filePath)
 
-			err := command.Run()
+			err = command.Run()
 			if err != nil {
 				fmt.Printf("Error whilte opening the file: %v\n", err)
 				return nil
@@ -91,5 +167,103 @@ func Command(app *app.App, sessionsPath string) *cobra.Command {
 		},
 	}
 
+	cmd.Flags().StringP("project", "p", "", "Change the project of the session")
+	cmd.Flags().StringSliceP("tag", "t", []string{}, "Replace the tags of the session")
+	cmd.Flags().String("start", "", "Change the start time of the session (YYYY-MM-DD HH:MM or HH:MM)")
+	cmd.Flags().String("end", "", "Change the end time of the session (YYYY-MM-DD HH:MM or HH:MM)")
+	cmd.Flags().StringP("note", "n", "", "Change the note of the session")
+	cmd.Flags().Bool("no-overlap", false, "Refuse the changes if the session would overlap another one, like --overlap reject")
+	cmd.Flags().String("overlap", "", "What to do when the session would overlap another one: allow, warn, reject or adjust (default from the config, else allow)")
+	cmd.Flags().Bool("billable", false, "Override whether the session is billable, use --billable=false for a non-billable session")
+	cmd.Flags().Float64("rate", 0, "Override the hourly rate of the session")
+	cmd.Flags().String("client", "", "Override the client of the session, an empty value removes the override")
+	cmd.Flags().String("continues", "", "Link the session to the session it continues, an empty value removes the link")
+	cmd.Flags().String("blocked-by", "", "Describe the external event blocking the session, an empty value removes it")
+	history.AddFlag(cmd)
+
+	completion.RegisterProjectFlag(cmd, app)
+	cmd.RegisterFlagCompletionFunc("start", completion.Times(app))
+	cmd.RegisterFlagCompletionFunc("end", completion.Times(app))
+
 	return cmd
 }
+
+// editCommand builds the changes from the flags, the session is opened in the
+// editor when none is given
+func editCommand(cmd *cobra.Command, id string, now time.Time, configuredOverlap string) (editsession.Command, error) {
+	command := editsession.Command{Id: id}
+
+	if cmd.Flags().Changed("project") {
+		project, _ := cmd.Flags().GetString("project")
+		command.Project = &project
+	}
+
+	if cmd.Flags().Changed("tag") {
+		tags, _ := cmd.Flags().GetStringSlice("tag")
+		command.Tags = &tags
+	}
+
+	if cmd.Flags().Changed("note") {
+		note, _ := cmd.Flags().GetString("note")
+		command.Note = &note
+	}
+
+	if cmd.Flags().Changed("billable") {
+		billable, _ := cmd.Flags().GetBool("billable")
+		command.Billable = &billable
+	}
+
+	if cmd.Flags().Changed("rate") {
+		rate, _ := cmd.Flags().GetFloat64("rate")
+		command.HourlyRate = &rate
+	}
+
+	if cmd.Flags().Changed("client") {
+		client, _ := cmd.Flags().GetString("client")
+		command.Client = &client
+	}
+
+	if cmd.Flags().Changed("continues") {
+		continues, _ := cmd.Flags().GetString("continues")
+		command.Continues = &continues
+	}
+
+	if cmd.Flags().Changed("blocked-by") {
+		blockedBy, _ := cmd.Flags().GetString("blocked-by")
+		command.BlockedBy = &blockedBy
+	}
+
+	var err error
+	if command.StartTime, err = parseTimeFlag(cmd, "start", now); err != nil {
+		return editsession.Command{}, err
+	}
+
+	if command.EndTime, err = parseTimeFlag(cmd, "end", now); err != nil {
+		return editsession.Command{}, err
+	}
+
+	overlapFlag, _ := cmd.Flags().GetString("overlap")
+	if noOverlap, _ := cmd.Flags().GetBool("no-overlap"); noOverlap {
+		overlapFlag = session.OverlapReject
+	}
+	command.Overlap = cmp.Or(overlapFlag, configuredOverlap)
+	if command.Overlap != "" && !session.IsOverlapPolicyValid(command.Overlap) {
+		return editsession.Command{}, fmt.Errorf("invalid overlap %v. possible values: %v", command.Overlap, strings.Join(session.OverlapPolicies, ", "))
+	}
+
+	return command, nil
+}
+
+func parseTimeFlag(cmd *cobra.Command, name string, now time.Time) (*time.Time, error) {
+	if !cmd.Flags().Changed(name) {
+		return nil, nil
+	}
+
+	value, _ := cmd.Flags().GetString(name)
+	parsed, err := utils.ParseDateTime(value, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return &parsed, nil
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/TristanShz/flow/cmd/edit"
 	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/editsession"
 	"github.com/TristanShz/flow/internal/domain/session"
 	"github.com/TristanShz/flow/internal/infra"
 	"github.com/TristanShz/flow/internal/infra/filesystem"
@@ -34,6 +35,14 @@ func (m *mockSessionRepository) FindAllSessions(filters *application.SessionsFil
 	return []session.Session{}
 }
 
+func (m *mockSessionRepository) ForEachSession(filters *application.SessionsFilters, fn func(session.Session) error) error {
+	return nil
+}
+
+func (m *mockSessionRepository) FindAllSessionMetadata(filters *application.SessionsFilters) []application.SessionMetadata {
+	return []application.SessionMetadata{}
+}
+
 func (m *mockSessionRepository) FindAllProjects() []string {
 	return []string{}
 }
@@ -42,12 +51,12 @@ func (m *mockSessionRepository) FindAllProjectTags(project string) []string {
 	return []string{}
 }
 
-func (m *mockSessionRepository) FindById(id string) *session.Session {
-	return m.FindByIdFn(id)
+func (m *mockSessionRepository) FindById(id string) (*session.Session, error) {
+	return m.FindByIdFn(id), nil
 }
 
-func (m *mockSessionRepository) FindLastSession() *session.Session {
-	return m.FindLastSessionFn()
+func (m *mockSessionRepository) FindLastSession() (*session.Session, error) {
+	return m.FindLastSessionFn(), nil
 }
 
 func TestEditCommand(t *testing.T) {
@@ -137,3 +146,100 @@ func TestEditCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestEditCommand_Flags(t *testing.T) {
+	dateProvider := infra.NewStubDateProvider()
+	dateProvider.Now = time.Date(2021, 1, 1, 18, 0, 0, 0, time.Local)
+
+	tt := []struct {
+		error error
+		name  string
+		want  string
+		args  []string
+	}{
+		{
+			name: "Project and start time",
+			args: []string{"1234567", "--project", "flow", "--start", "08:30"},
+			want: "Session 1234567 updated: flow 2021-01-01 08:30:00 - 2021-01-01 10:00:00",
+		},
+		{
+			name: "Last session",
+			args: []string{"--end", "2021-01-01 12:30"},
+			want: "Session 7654321 updated: project 2021-01-01 10:30:00 - 2021-01-01 12:30:00",
+		},
+		{
+			name:  "Overlap",
+			args:  []string{"1234567", "--end", "11:00", "--no-overlap"},
+			error: editsession.ErrOverlap,
+		},
+		{
+			name: "Overlap adjusted",
+			args: []string{"1234567", "--end", "11:00", "--overlap", "adjust"},
+			want: "Session 1234567 updated: project 2021-01-01 08:00:00 - 2021-01-01 10:30:00",
+		},
+		{
+			name: "Overlap warned",
+			args: []string{"1234567", "--end", "11:00", "--overlap", "warn"},
+			want: "Session 1234567 updated: project 2021-01-01 08:00:00 - 2021-01-01 11:00:00\nWarning: the session overlaps 1 other session(s): 7654321",
+		},
+		{
+			name: "Billing overrides",
+			args: []string{"1234567", "--billable=false", "--rate", "120", "--client", "Globex"},
+			want: "Session 1234567 updated: project 2021-01-01 08:00:00 - 2021-01-01 10:00:00",
+		},
+		{
+			name: "Links",
+			args: []string{"--continues", "1234567", "--blocked-by", "waiting for the review"},
+			want: "Session 7654321 updated: project 2021-01-01 10:30:00 - 2021-01-01 12:00:00",
+		},
+		{
+			name: "Deprecated tags",
+			args: []string{"1234567", "--tag", "wip,cli,old"},
+			want: "Session 1234567 updated: project 2021-01-01 08:00:00 - 2021-01-01 10:00:00\nWarning: the tag old is deprecated\nWarning: the tag wip is deprecated, use in-progress instead",
+		},
+		{
+			name:  "Continued session not found",
+			args:  []string{"--continues", "abcdefg"},
+			error: editsession.ErrContinuedSessionNotFound,
+		},
+		{
+			name:  "Invalid time",
+			args:  []string{"1234567", "--end", "tomorrow"},
+			error: errors.New("invalid date tomorrow. expected format: YYYY-MM-DD HH:MM or HH:MM"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			sessionRepository := &infra.InMemorySessionRepository{Sessions: []session.Session{
+				{
+					Id:        "1234567",
+					Project:   "project",
+					StartTime: time.Date(2021, 1, 1, 8, 0, 0, 0, time.Local),
+					EndTime:   time.Date(2021, 1, 1, 10, 0, 0, 0, time.Local),
+				},
+				{
+					Id:        "7654321",
+					Project:   "project",
+					StartTime: time.Date(2021, 1, 1, 10, 30, 0, 0, time.Local),
+					EndTime:   time.Date(2021, 1, 1, 12, 0, 0, 0, time.Local),
+				},
+			}}
+			app := test.InitializeApp(sessionRepository, dateProvider)
+			app.Config = application.Config{
+				TagDeprecations: []session.TagDeprecation{{Tag: "old"}, {Tag: "wip", Replacement: "in-progress"}},
+			}
+			c := edit.Command(app, t.TempDir())
+
+			got, err := test.ExecuteCmd(t, c, tc.args...)
+
+			is.Equal(tc.error, err)
+
+			if tc.error == nil {
+				is.Equal(got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,348 @@
+package export
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/TristanShz/flow/cmd/completion"
+	"github.com/TristanShz/flow/internal/application"
+	app "github.com/TristanShz/flow/internal/application/usecases"
+	"github.com/TristanShz/flow/internal/application/usecases/export/exportsessions"
+	"github.com/TristanShz/flow/internal/infra/exporter"
+	"github.com/TristanShz/flow/internal/infra/toggl"
+	"github.com/TristanShz/flow/pkg/timerange"
+	"github.com/spf13/cobra"
+)
+
+const defaultMaxSizeMB = 50
+
+func parseDateFlag(cmd *cobra.Command, name string) (time.Time, error) {
+	flag, _ := cmd.Flags().GetString(name)
+	if flag == "" {
+		return time.Time{}, nil
+	}
+
+	parsedTime, err := time.Parse("2006-01-02", flag)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%v is not a valid time format", flag)
+	}
+
+	return parsedTime, nil
+}
+
+func formatSize(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%v B", bytes)
+	}
+
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
+
+// promptPassword reads the password from the input, the prompt goes to the
+// error output as the report may be written to the standard output
+func promptPassword(out io.Writer, in io.Reader) (string, error) {
+	fmt.Fprint(out, "Password: ")
+
+	scanner := bufio.NewScanner(in)
+	if !scanner.Scan() || strings.TrimSpace(scanner.Text()) == "" {
+		fmt.Fprintln(out)
+		return "", errors.New("the password can't be empty")
+	}
+
+	return scanner.Text(), nil
+}
+
+// exportHTML writes the sessions to a single html report, which is never
+// split in several files
+func exportHTML(cmd *cobra.Command, app *app.App, command exportsessions.Command, out string) error {
+	logger := log.New(cmd.OutOrStdout(), "", 0)
+
+	titleFlag, _ := cmd.Flags().GetString("title")
+	htmlExporter := exporter.HTMLExporter{Writer: cmd.OutOrStdout(), Title: titleFlag}
+
+	encryptFlag, _ := cmd.Flags().GetBool("encrypt")
+	if encryptFlag {
+		password, err := promptPassword(cmd.ErrOrStderr(), cmd.InOrStdin())
+		if err != nil {
+			return err
+		}
+		htmlExporter.Password = password
+	}
+
+	if out == "" {
+		return app.ExportSessionsUseCase.Execute(command, htmlExporter)
+	}
+
+	if filepath.Ext(out) == "" {
+		out += ".html"
+	}
+
+	file, err := os.Create(out)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	htmlExporter.Writer = file
+	if err := app.ExportSessionsUseCase.Execute(command, htmlExporter); err != nil {
+		return err
+	}
+
+	logger.Printf("Sessions exported to %v", out)
+
+	return nil
+}
+
+// exportRollup writes the monthly totals of the projects to a single file,
+// the totals are small enough to never be split
+func exportRollup(cmd *cobra.Command, app *app.App, command exportsessions.Command, format string, out string) error {
+	logger := log.New(cmd.OutOrStdout(), "", 0)
+
+	rollupExporter, err := exporter.NewRollupExporter(cmd.OutOrStdout(), format)
+	if err != nil {
+		return err
+	}
+
+	if out == "" {
+		return app.ExportSessionsUseCase.Execute(command, rollupExporter)
+	}
+
+	if filepath.Ext(out) == "" {
+		out += rollupExporter.Extension()
+	}
+
+	file, err := os.Create(out)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	rollupExporter.Writer = file
+	if err := app.ExportSessionsUseCase.Execute(command, rollupExporter); err != nil {
+		return err
+	}
+
+	logger.Printf("Sessions exported to %v", out)
+
+	return nil
+}
+
+// ErrSandboxExport keeps the throwaway sessions of 'flow sandbox' out of
+// Toggl Track
+var ErrSandboxExport = errors.New("the sessions of the sandbox aren't exported to Toggl")
+
+// exportToToggl creates the time entries of the sessions in Toggl Track
+func exportToToggl(cmd *cobra.Command, app *app.App, command exportsessions.Command) error {
+	logger := log.New(cmd.OutOrStdout(), "", 0)
+
+	if app.Config.Sandbox {
+		return ErrSandboxExport
+	}
+
+	if app.Config.Toggl.APIToken == "" || app.Config.Toggl.WorkspaceID == 0 {
+		return errors.New("the export to Toggl needs the api_token and the workspace_id of the [toggl] table of the config file")
+	}
+
+	togglExporter := &toggl.APIExporter{
+		Client:      toggl.NewClient(app.Config.Toggl.APIToken),
+		WorkspaceID: app.Config.Toggl.WorkspaceID,
+		Projects:    toggl.Invert(app.Config.Toggl.Projects),
+		Tags:        toggl.Invert(app.Config.Toggl.Tags),
+	}
+	if err := app.ExportSessionsUseCase.Execute(command, togglExporter); err != nil {
+		return err
+	}
+
+	logger.Printf("%v session(s) exported to Toggl, %v skipped", togglExporter.Exported, togglExporter.Skipped)
+
+	return nil
+}
+
+func Command(app *app.App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "export",
+		Example: "export --since 2024-01-01 --out sessions.csv\nexport --format jsonl --project my-todo\nexport --range last-month --out march.csv\nexport --format ics --range last-month --out flow.ics\nexport --format html --project my-todo --since 2024-04-01 --out april.html --encrypt\nexport --preset accountant --since 2024-01-01 --out totals.csv\nexport --where 'duration >= 2h or note ~ release' --out long.csv\nexport --format toggl --range last-month --out toggl.csv\nexport --to toggl --since 2024-04-01",
+		Short:   "Export sessions to a file",
+		Long:    "Export sessions to a file, or to the standard output when no file is given. Exports bigger than --max-size are split in several files",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			logger := log.New(cmd.OutOrStdout(), "", 0)
+
+			formatFlag, _ := cmd.Flags().GetString("format")
+			presetFlag, _ := cmd.Flags().GetString("preset")
+			if presetFlag != "" && !exporter.IsPresetValid(presetFlag) {
+				return fmt.Errorf("invalid preset %v. possible values: %v", presetFlag, exporter.Presets)
+			}
+
+			var encoder exporter.Encoder
+			var err error
+			if formatFlag != exporter.FormatHTML && presetFlag == "" {
+				if encoder, err = exporter.NewEncoder(formatFlag); err != nil {
+					return err
+				}
+			}
+			if formatFlag == exporter.FormatToggl {
+				encoder = toggl.CSVEncoder{
+					Projects: toggl.Invert(app.Config.Toggl.Projects),
+					Tags:     toggl.Invert(app.Config.Toggl.Tags),
+				}
+			}
+
+			projectFlag, _ := cmd.Flags().GetString("project")
+			tagFlag, _ := cmd.Flags().GetStringSlice("tag")
+			command := exportsessions.Command{
+				Project: projectFlag,
+				Tags:    tagFlag,
+			}
+
+			allTagsFlag, _ := cmd.Flags().GetBool("all-tags")
+			if allTagsFlag {
+				command.TagsMatch = application.TagsMatchAll
+			}
+
+			whereFlag, _ := cmd.Flags().GetString("where")
+			if whereFlag != "" {
+				where, err := application.ParseWhere(whereFlag)
+				if err != nil {
+					return err
+				}
+
+				command.Where = where
+			}
+
+			rangeFlag, _ := cmd.Flags().GetString("range")
+			if rangeFlag != "" {
+				timeRange, err := timerange.Parse(rangeFlag, app.DateProvider.GetNow())
+				if err != nil {
+					return err
+				}
+
+				command.Since = timeRange.Since
+				command.Until = timeRange.Until
+			}
+
+			since, err := parseDateFlag(cmd, "since")
+			if err != nil {
+				return err
+			}
+			if !since.IsZero() {
+				command.Since = since
+			}
+
+			until, err := parseDateFlag(cmd, "until")
+			if err != nil {
+				return err
+			}
+			if !until.IsZero() {
+				command.Until = until
+			}
+
+			toFlag, _ := cmd.Flags().GetString("to")
+			if toFlag != "" {
+				if toFlag != exporter.FormatToggl {
+					return fmt.Errorf("invalid destination %v. possible values: [%v]", toFlag, exporter.FormatToggl)
+				}
+				return exportToToggl(cmd, app, command)
+			}
+
+			noRoundingFlag, _ := cmd.Flags().GetBool("no-rounding")
+			if !noRoundingFlag {
+				command.Billing = app.Config.BillingRules()
+			}
+
+			outFlag, _ := cmd.Flags().GetString("out")
+			estimateFlag, _ := cmd.Flags().GetBool("estimate")
+
+			if presetFlag == exporter.PresetAccountant {
+				if estimateFlag {
+					return errors.New("the accountant preset can't be estimated")
+				}
+				return exportRollup(cmd, app, command, formatFlag, outFlag)
+			}
+
+			if formatFlag == exporter.FormatHTML {
+				if estimateFlag {
+					return errors.New("the html format can't be estimated")
+				}
+				return exportHTML(cmd, app, command, outFlag)
+			}
+
+			maxSizeFlag, _ := cmd.Flags().GetInt64("max-size")
+			maxChunkSize := maxSizeFlag * 1024 * 1024
+			if outFlag == "" {
+				// the standard output can't be split
+				maxChunkSize = 0
+			}
+
+			if estimateFlag {
+				estimate := &exporter.EstimateExporter{Encoder: encoder, MaxChunkSize: maxChunkSize}
+				if err := app.ExportSessionsUseCase.Execute(command, estimate); err != nil {
+					return err
+				}
+
+				logger.Printf("%v rows, about %v in %v file(s)", estimate.Rows, formatSize(estimate.Bytes), estimate.Files)
+				return nil
+			}
+
+			if outFlag == "" {
+				return app.ExportSessionsUseCase.Execute(command, exporter.WriterExporter{
+					Writer:  cmd.OutOrStdout(),
+					Encoder: encoder,
+				})
+			}
+
+			fileExporter := &exporter.FileExporter{
+				Path:         outFlag,
+				Encoder:      encoder,
+				MaxChunkSize: maxChunkSize,
+				Progress:     cmd.ErrOrStderr(),
+			}
+			if err := app.ExportSessionsUseCase.Execute(command, fileExporter); err != nil {
+				return err
+			}
+
+			for _, file := range fileExporter.Files {
+				logger.Printf("Sessions exported to %v", file)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("project", "p", "", "Only export the sessions of the given project")
+	cmd.Flags().StringSliceP("tag", "t", []string{}, "Only export the sessions having one of the given tags")
+	cmd.Flags().Bool("all-tags", false, "Only export the sessions having all the given tags")
+	cmd.Flags().String("where", "", "Only export the sessions matching an expression like 'project = \"Flow\" and duration > 1h and tag in (deep, review)'")
+	cmd.Flags().StringP("since", "s", "", "Only export the sessions since the given date")
+	cmd.Flags().StringP("until", "u", "", "Only export the sessions until the given date")
+	cmd.Flags().StringP("range", "r", "", "Only export the sessions of a range like last-week, 2024-04, -7d or \"since monday\"")
+	cmd.Flags().StringP("format", "f", exporter.FormatCSV, fmt.Sprintf("Format of the export. Possible values: %v", exporter.Formats))
+	cmd.Flags().StringP("out", "O", "", "File to export to, the standard output when empty")
+	cmd.Flags().String("to", "", "Service to export to instead of a file. Possible values: [toggl]")
+	cmd.Flags().Bool("estimate", false, "Print the number of rows and the size of the export without running it")
+	cmd.Flags().Int64("max-size", defaultMaxSizeMB, "Maximum size of an export file in MiB, bigger exports are split in several files")
+	cmd.Flags().String("preset", "", fmt.Sprintf("Export a preset instead of the sessions. Possible values: %v", exporter.Presets))
+	cmd.Flags().Bool("no-rounding", false, "Export the tracked durations, without the rounding nor the billing profiles of the config file")
+	cmd.Flags().String("title", "Timesheet", "Title of the html report")
+	cmd.Flags().Bool("encrypt", false, "Ask for a password protecting the html report")
+
+	completion.RegisterProjectFlag(cmd, app)
+	cmd.RegisterFlagCompletionFunc("range", completion.Ranges(app))
+	cmd.RegisterFlagCompletionFunc("since", completion.Dates(app))
+	cmd.RegisterFlagCompletionFunc("until", completion.Dates(app))
+
+	return cmd
+}
@@ -0,0 +1,180 @@
+package export_test
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/cmd/export"
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/TristanShz/flow/test"
+	"github.com/matryer/is"
+)
+
+func TestExportCommand(t *testing.T) {
+	sessionRepository := &infra.InMemorySessionRepository{
+		Sessions: []session.Session{
+			{
+				Id:        "1",
+				StartTime: time.Date(2024, 4, 17, 9, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2024, 4, 17, 10, 0, 0, 0, time.UTC),
+				Project:   "Flow",
+				Tags:      []string{"export"},
+			},
+			{
+				Id:        "2",
+				StartTime: time.Date(2024, 4, 18, 9, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2024, 4, 18, 10, 0, 0, 0, time.UTC),
+				Project:   "Other",
+			},
+		},
+	}
+	dateProvider := infra.NewStubDateProvider()
+	dateProvider.Now = time.Date(2024, 4, 18, 12, 0, 0, 0, time.UTC)
+	app := test.InitializeApp(sessionRepository, dateProvider)
+
+	outPath := filepath.Join(t.TempDir(), "sessions.csv")
+	htmlPath := filepath.Join(t.TempDir(), "april")
+	rollupPath := filepath.Join(t.TempDir(), "totals")
+
+	tt := []struct {
+		name      string
+		args      []string
+		stdin     string
+		want      string
+		wantError bool
+	}{
+		{
+			name: "Standard output",
+			args: []string{"--project", "Flow"},
+			want: "id,project,tags,start_time,end_time,duration_seconds,note\n1,Flow,export,2024-04-17T09:00:00Z,2024-04-17T10:00:00Z,3600,",
+		},
+		{
+			name: "Range",
+			args: []string{"--range", "today"},
+			want: "id,project,tags,start_time,end_time,duration_seconds,note\n2,Other,,2024-04-18T09:00:00Z,2024-04-18T10:00:00Z,3600,",
+		},
+		{
+			name: "Estimate",
+			args: []string{"--estimate"},
+			want: "2 rows, about 177 B in 1 file(s)",
+		},
+		{
+			name: "File",
+			args: []string{"--out", outPath},
+			want: "Sessions exported to " + outPath,
+		},
+		{
+			name: "HTML file",
+			args: []string{"--format", "html", "--out", htmlPath},
+			want: "Sessions exported to " + htmlPath + ".html",
+		},
+		{
+			name:  "Encrypted HTML file",
+			args:  []string{"--format", "html", "--out", htmlPath + ".html", "--encrypt"},
+			stdin: "secret\n",
+			want:  "Password: Sessions exported to " + htmlPath + ".html",
+		},
+		{
+			name:      "Encrypted HTML file without password",
+			args:      []string{"--format", "html", "--encrypt"},
+			stdin:     "\n",
+			wantError: true,
+		},
+		{
+			name:      "HTML estimate",
+			args:      []string{"--format", "html", "--estimate"},
+			wantError: true,
+		},
+		{
+			name: "Accountant preset",
+			args: []string{"--preset", "accountant"},
+			want: "month,project,duration_seconds,duration_hours\n2024-04,Flow,3600,1.00\n2024-04,Other,3600,1.00",
+		},
+		{
+			name: "Accountant preset in jsonl",
+			args: []string{"--preset", "accountant", "--format", "jsonl", "--project", "Flow"},
+			want: `{"month":"2024-04","project":"Flow","duration_seconds":3600,"duration_hours":1}`,
+		},
+		{
+			name: "Accountant preset file",
+			args: []string{"--preset", "accountant", "--out", rollupPath},
+			want: "Sessions exported to " + rollupPath + ".csv",
+		},
+		{
+			name:      "Accountant preset in html",
+			args:      []string{"--preset", "accountant", "--format", "html"},
+			wantError: true,
+		},
+		{
+			name:      "Accountant preset estimate",
+			args:      []string{"--preset", "accountant", "--estimate"},
+			wantError: true,
+		},
+		{
+			name:      "Invalid preset",
+			args:      []string{"--preset", "auditor"},
+			wantError: true,
+		},
+		{
+			name:      "Invalid format",
+			args:      []string{"--format", "xlsx"},
+			wantError: true,
+		},
+		{
+			name:      "Toggl without token",
+			args:      []string{"--to", "toggl"},
+			wantError: true,
+		},
+		{
+			name:      "Invalid destination",
+			args:      []string{"--to", "harvest"},
+			wantError: true,
+		},
+		{
+			name:      "Invalid range",
+			args:      []string{"--range", "soon"},
+			wantError: true,
+		},
+		{
+			name:      "Invalid date",
+			args:      []string{"--since", "yesterday"},
+			wantError: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			c := export.Command(app)
+			c.SetIn(strings.NewReader(tc.stdin))
+
+			got, err := test.ExecuteCmd(t, c, tc.args...)
+
+			if tc.wantError {
+				is.True(err != nil)
+				return
+			}
+
+			is.NoErr(err)
+			is.Equal(got, tc.want)
+		})
+	}
+}
+
+func TestExportCommand_Sandbox(t *testing.T) {
+	is := is.New(t)
+
+	app := test.InitializeApp(&infra.InMemorySessionRepository{}, infra.NewStubDateProvider())
+	app.Config.Toggl = application.Toggl{APIToken: "token", WorkspaceID: 42}
+	app.Config = app.Config.Sandboxed(t.TempDir())
+
+	_, err := test.ExecuteCmd(t, export.Command(app), "--to", "toggl")
+
+	is.True(errors.Is(err, export.ErrSandboxExport))
+}
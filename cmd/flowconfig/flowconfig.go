@@ -0,0 +1,130 @@
+package flowconfig
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/infra/config"
+	"github.com/TristanShz/flow/pkg/linediff"
+	"github.com/spf13/cobra"
+)
+
+// diffContext is the number of unchanged lines shown around the changes
+const diffContext = 3
+
+// editAgain asks to fix an invalid config, anything but "n" or "no" is a yes
+func editAgain(out io.Writer, scanner *bufio.Scanner) bool {
+	fmt.Fprint(out, "Edit again? [Y/n] ")
+	if !scanner.Scan() {
+		fmt.Fprintln(out)
+		return false
+	}
+
+	answer := strings.ToLower(strings.TrimSpace(scanner.Text()))
+	return answer != "n" && answer != "no"
+}
+
+// readConfig returns the content of the config file, a missing file is empty
+func readConfig(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+
+	return string(content), err
+}
+
+func editCommand(configPath string, editor application.Editor) *cobra.Command {
+	return &cobra.Command{
+		Use:     "edit",
+		Example: "config edit\nEDITOR=vim flow config edit",
+		Short:   "Edit the config file in your editor",
+		Long:    "Open the config file in $VISUAL or $EDITOR. The edited config is validated when the editor is closed: invalid values are reported with their line and the config can be edited again, otherwise the changes are shown and saved",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			logger := log.New(cmd.OutOrStdout(), "", 0)
+
+			if configPath == "" {
+				return errors.New("the config directory of the user is unknown, set FLOW_CONFIG to the path of the config file")
+			}
+
+			original, err := readConfig(configPath)
+			if err != nil {
+				return err
+			}
+
+			// the config file is only written once valid, the editor works on
+			// a copy with the same extension, for its syntax highlighting
+			file, err := os.CreateTemp("", "flow-config-*.toml")
+			if err != nil {
+				return err
+			}
+			defer os.Remove(file.Name())
+
+			_, err = file.WriteString(original)
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				return err
+			}
+
+			scanner := bufio.NewScanner(cmd.InOrStdin())
+			edited := ""
+			for {
+				if err := editor.Edit(file.Name()); err != nil {
+					return fmt.Errorf("error while opening the editor: %w", err)
+				}
+
+				if edited, err = readConfig(file.Name()); err != nil {
+					return err
+				}
+
+				_, err := config.Parse(strings.NewReader(edited))
+				if err == nil {
+					break
+				}
+
+				logger.Printf("Invalid config: %v", err)
+				if !editAgain(cmd.OutOrStdout(), scanner) {
+					return errors.New("the config was not changed")
+				}
+			}
+
+			changes := linediff.Unified(original, edited, diffContext)
+			if changes == "" {
+				logger.Println("No changes")
+				return nil
+			}
+
+			if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+				return err
+			}
+			if err := os.WriteFile(configPath, []byte(edited), 0644); err != nil {
+				return err
+			}
+
+			logger.Printf("--- %v\n+++ %v\n%v", configPath, configPath, changes)
+			logger.Printf("Saved %v", configPath)
+
+			return nil
+		},
+	}
+}
+
+func Command(configPath string, editor application.Editor) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage the config file",
+	}
+
+	cmd.AddCommand(editCommand(configPath, editor))
+
+	return cmd
+}
@@ -0,0 +1,105 @@
+package flowconfig_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TristanShz/flow/cmd/flowconfig"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/TristanShz/flow/test"
+	"github.com/matryer/is"
+)
+
+func TestConfigEditCommand(t *testing.T) {
+	original := "output = \"json\"\n"
+
+	tt := []struct {
+		name       string
+		edits      []string
+		stdin      string
+		wantOutput []string
+		wantConfig string
+		wantErr    bool
+	}{
+		{
+			name:       "Valid changes",
+			edits:      []string{"output = \"text\"\n"},
+			wantOutput: []string{"-output = \"json\"\n+output = \"text\"", "Saved"},
+			wantConfig: "output = \"text\"\n",
+		},
+		{
+			name:       "No changes",
+			edits:      []string{original},
+			wantOutput: []string{"No changes"},
+			wantConfig: original,
+		},
+		{
+			name:       "Invalid value fixed",
+			edits:      []string{"output = \"json\"\nweek_start = \"someday\"\n", "output = \"json\"\nweek_start = \"sunday\"\n"},
+			stdin:      "\n",
+			wantOutput: []string{"Invalid config: line 2: invalid week start someday", "Edit again? [Y/n]", "+week_start = \"sunday\""},
+			wantConfig: "output = \"json\"\nweek_start = \"sunday\"\n",
+		},
+		{
+			name:       "Invalid value kept",
+			edits:      []string{"output = \"yaml\"\n"},
+			stdin:      "n\n",
+			wantOutput: []string{"Invalid config: line 1: invalid output yaml"},
+			wantConfig: original,
+			wantErr:    true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			path := filepath.Join(t.TempDir(), "config.toml")
+			is.NoErr(os.WriteFile(path, []byte(original), 0644))
+			editor := &infra.StubEditor{Contents: tc.edits}
+
+			c := flowconfig.Command(path, editor)
+			c.SetIn(strings.NewReader(tc.stdin))
+			got, err := test.ExecuteCmd(t, c, "edit")
+
+			is.Equal(err != nil, tc.wantErr)
+			for _, output := range tc.wantOutput {
+				is.True(strings.Contains(got, output))
+			}
+			config, err := os.ReadFile(path)
+			is.NoErr(err)
+			is.Equal(string(config), tc.wantConfig)
+			is.Equal(editor.Edits, len(tc.edits))
+		})
+	}
+}
+
+func TestConfigEditCommand_NewFile(t *testing.T) {
+	is := is.New(t)
+
+	path := filepath.Join(t.TempDir(), "flow", "config.toml")
+	editor := &infra.StubEditor{Contents: []string{"week_start = \"monday\"\n"}}
+
+	_, err := test.ExecuteCmd(t, flowconfig.Command(path, editor), "edit")
+
+	is.NoErr(err)
+	config, err := os.ReadFile(path)
+	is.NoErr(err)
+	is.Equal(string(config), "week_start = \"monday\"\n")
+}
+
+func TestConfigEditCommand_EditorError(t *testing.T) {
+	is := is.New(t)
+
+	path := filepath.Join(t.TempDir(), "config.toml")
+	editor := &infra.StubEditor{Err: errors.New("vim: command not found")}
+
+	_, err := test.ExecuteCmd(t, flowconfig.Command(path, editor), "edit")
+
+	is.True(err != nil)
+	_, err = os.Stat(path)
+	is.True(errors.Is(err, os.ErrNotExist))
+}
@@ -0,0 +1,92 @@
+package flowdelete
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/TristanShz/flow/cmd/completion"
+	"github.com/TristanShz/flow/cmd/history"
+	"github.com/TristanShz/flow/internal/application"
+	app "github.com/TristanShz/flow/internal/application/usecases"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/deletesessions"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/utils"
+	"github.com/spf13/cobra"
+)
+
+func formatSession(s session.Session) string {
+	text := fmt.Sprintf("%v %v %v %v", s.Id, utils.TimeColor(s.GetFormattedStartTime()), utils.TimeColor(s.Duration().String()), utils.ProjectColor(s.Project))
+
+	if len(s.Tags) > 0 {
+		text += fmt.Sprintf(" [%v]", utils.TagColor(strings.Join(s.Tags, ", ")))
+	}
+
+	return text
+}
+
+func Command(app *app.App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "delete",
+		Example: "delete --project test --dry-run\ndelete --before 2023-01-01\ndelete --project Flow --tag draft --all-tags",
+		Short:   "Delete the sessions matching filters",
+		Long:    "Delete the sessions matching all the given filters, to prune old or test data. The deleted sessions go to the trash, see 'flow trash', and the command can be undone with 'flow undo'.",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			logger := log.New(cmd.OutOrStdout(), "", 0)
+
+			projectFlag, _ := cmd.Flags().GetString("project")
+			tagFlag, _ := cmd.Flags().GetStringSlice("tag")
+			dryRunFlag, _ := cmd.Flags().GetBool("dry-run")
+			command := deletesessions.Command{
+				Project:      projectFlag,
+				Tags:         tagFlag,
+				DryRun:       dryRunFlag,
+				LockedBefore: history.LockedBefore(cmd, app),
+			}
+
+			allTagsFlag, _ := cmd.Flags().GetBool("all-tags")
+			if allTagsFlag {
+				command.TagsMatch = application.TagsMatchAll
+			}
+
+			beforeFlag, _ := cmd.Flags().GetString("before")
+			if beforeFlag != "" {
+				before, err := time.ParseInLocation(time.DateOnly, beforeFlag, time.Local)
+				if err != nil {
+					return fmt.Errorf("%v is not a valid date, expected a date like 2024-01-31", beforeFlag)
+				}
+				command.Before = before
+			}
+
+			result, err := app.DeleteSessionsUseCase.Execute(command)
+			if err != nil {
+				return err
+			}
+
+			for _, s := range result.Sessions {
+				logger.Println(formatSession(s))
+			}
+
+			if dryRunFlag {
+				logger.Printf("%v session(s) would be deleted", len(result.Sessions))
+			} else {
+				logger.Printf("%v session(s) deleted", result.Deleted)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("project", "p", "", "Only delete the sessions of the project")
+	cmd.Flags().StringSliceP("tag", "t", []string{}, "Only delete the sessions having one of the tags")
+	cmd.Flags().Bool("all-tags", false, "Only delete the sessions having all the given tags")
+	cmd.Flags().StringP("before", "b", "", "Only delete the sessions started before the date")
+	cmd.Flags().Bool("dry-run", false, "List the sessions which would be deleted without deleting them")
+	history.AddFlag(cmd)
+
+	completion.RegisterProjectFlag(cmd, app)
+	cmd.RegisterFlagCompletionFunc("before", completion.Dates(app))
+
+	return cmd
+}
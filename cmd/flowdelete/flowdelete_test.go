@@ -0,0 +1,76 @@
+package flowdelete_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/cmd/flowdelete"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/deletesessions"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/TristanShz/flow/test"
+	"github.com/matryer/is"
+)
+
+func TestDeleteCommand(t *testing.T) {
+	old := session.Session{
+		Id:        "abc",
+		StartTime: time.Date(2024, time.March, 15, 9, 0, 0, 0, time.Local),
+		EndTime:   time.Date(2024, time.March, 15, 10, 0, 0, 0, time.Local),
+		Project:   "Flow",
+		Tags:      []string{"test"},
+	}
+	recent := session.Session{
+		Id:        "def",
+		StartTime: time.Date(2024, time.April, 15, 9, 0, 0, 0, time.Local),
+		EndTime:   time.Date(2024, time.April, 15, 9, 30, 0, 0, time.Local),
+		Project:   "Flow",
+	}
+	sessionRepository := &infra.InMemorySessionRepository{Sessions: []session.Session{old, recent}}
+	dateProvider := infra.NewStubDateProvider()
+	dateProvider.Now = time.Date(2024, time.April, 17, 12, 0, 0, 0, time.Local)
+	app := test.InitializeApp(sessionRepository, dateProvider)
+
+	tt := []struct {
+		error error
+		name  string
+		want  string
+		args  []string
+	}{
+		{
+			name:  "Without filter",
+			error: deletesessions.ErrNoFilter,
+		},
+		{
+			name: "Dry run",
+			args: []string{"--project", "Flow", "--dry-run"},
+			want: "abc 2024-03-15 09:00:00 1h0m0s Flow [test]\ndef 2024-04-15 09:00:00 30m0s Flow\n2 session(s) would be deleted",
+		},
+		{
+			name: "Before a date",
+			args: []string{"--before", "2024-04-01"},
+			want: "abc 2024-03-15 09:00:00 1h0m0s Flow [test]\n1 session(s) deleted",
+		},
+		{
+			name: "Nothing matching",
+			args: []string{"--tag", "test"},
+			want: "0 session(s) deleted",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			got, err := test.ExecuteCmd(t, flowdelete.Command(app), tc.args...)
+
+			is.Equal(tc.error, err)
+			if tc.error == nil {
+				is.Equal(tc.want, got)
+			}
+		})
+	}
+
+	is := is.New(t)
+	is.Equal(sessionRepository.FindAllSessions(nil), []session.Session{recent})
+}
@@ -0,0 +1,255 @@
+package flowimport
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/TristanShz/flow/cmd/completion"
+	"github.com/TristanShz/flow/internal/application"
+	app "github.com/TristanShz/flow/internal/application/usecases"
+	"github.com/TristanShz/flow/internal/application/usecases/import/importsessions"
+	"github.com/TristanShz/flow/internal/application/usecases/import/resolveconflicts"
+	"github.com/TristanShz/flow/internal/infra/config"
+	"github.com/TristanShz/flow/internal/infra/timewarrior"
+	"github.com/TristanShz/flow/internal/infra/toggl"
+	"github.com/TristanShz/flow/internal/infra/watson"
+	"github.com/spf13/cobra"
+)
+
+func parseDateFlag(cmd *cobra.Command, name string) (time.Time, error) {
+	flag, _ := cmd.Flags().GetString(name)
+	if flag == "" {
+		return time.Time{}, nil
+	}
+
+	parsedTime, err := time.ParseInLocation("2006-01-02", flag, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%v is not a valid time format", flag)
+	}
+
+	return parsedTime, nil
+}
+
+// importSessions runs the import and prints what it did
+func importSessions(cmd *cobra.Command, app *app.App, conflictsPath string, command importsessions.Command, importer application.SessionsImporter) error {
+	logger := log.New(cmd.OutOrStdout(), "", 0)
+
+	defaultProjectFlag, _ := cmd.Flags().GetString("default-project")
+	dryRunFlag, _ := cmd.Flags().GetBool("dry-run")
+	command.DefaultProject = defaultProjectFlag
+	command.DryRun = dryRunFlag
+
+	result, err := app.ImportSessionsUseCase.Execute(command, importer)
+	if err != nil {
+		return err
+	}
+
+	summary := fmt.Sprintf("%v session(s) imported, %v updated, %v unchanged", result.Imported, result.Updated, result.Unchanged)
+	if result.Skipped > 0 {
+		summary += fmt.Sprintf(", %v running skipped", result.Skipped)
+	}
+	if overlapping := len(result.Conflicts) - result.Skipped; overlapping > 0 {
+		summary += fmt.Sprintf(", %v overlapping skipped", overlapping)
+	}
+	if dryRunFlag {
+		summary = "Dry run: " + summary
+	}
+	logger.Println(summary)
+
+	if !dryRunFlag && len(result.Conflicts) > 0 {
+		logger.Printf("%v conflict(s) written to %v, choose their resolution and run 'flow import resolve'", len(result.Conflicts), conflictsPath)
+	}
+
+	return nil
+}
+
+func togglCommand(app *app.App, conflictsPath string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "toggl [file.csv (optional)]",
+		Example: "import toggl Toggl_time_entries_2024-01-01_to_2024-03-31.csv\nimport toggl --since 2024-04-01\nimport toggl --dry-run",
+		Short:   "Import the time entries of Toggl Track",
+		Long:    fmt.Sprintf("Import the time entries of a CSV detailed report of Toggl Track, or of its API when no file is given. The API needs the api_token of the [toggl] table of the config file, or the %v environment variable. The projects and tags of Toggl are renamed with the [toggl.projects] and [toggl.tags] tables, importing again updates the sessions imported before.", config.EnvTogglAPIToken),
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 1 {
+				return errors.New("only one file can be imported at a time")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			command := importsessions.Command{
+				Projects: app.Config.Toggl.Projects,
+				Tags:     app.Config.Toggl.Tags,
+			}
+
+			if len(args) == 1 {
+				file, err := os.Open(args[0])
+				if err != nil {
+					return err
+				}
+				defer file.Close()
+
+				return importSessions(cmd, app, conflictsPath, command, toggl.CSVImporter{Reader: file})
+			}
+
+			if app.Config.Toggl.APIToken == "" {
+				return fmt.Errorf("the API of Toggl needs a token, set api_token in the [toggl] table of the config file or %v", config.EnvTogglAPIToken)
+			}
+
+			since, err := parseDateFlag(cmd, "since")
+			if err != nil {
+				return err
+			}
+			until, err := parseDateFlag(cmd, "until")
+			if err != nil {
+				return err
+			}
+			if !until.IsZero() {
+				// the until date is included
+				until = until.AddDate(0, 0, 1)
+			}
+
+			return importSessions(cmd, app, conflictsPath, command, toggl.APIImporter{
+				Client: toggl.NewClient(app.Config.Toggl.APIToken),
+				Since:  since,
+				Until:  until,
+			})
+		},
+	}
+
+	cmd.Flags().StringP("since", "s", "", "Only import the time entries since the given date, Toggl returns the last 3 months without it")
+	cmd.Flags().StringP("until", "u", "", "Only import the time entries until the given date")
+	addImportFlags(cmd)
+
+	cmd.RegisterFlagCompletionFunc("since", completion.Dates(app))
+	cmd.RegisterFlagCompletionFunc("until", completion.Dates(app))
+
+	return cmd
+}
+
+func watsonCommand(app *app.App, conflictsPath string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "watson [frames (optional)]",
+		Example: "import watson\nimport watson ~/backup/watson/frames --dry-run",
+		Short:   "Import the frames of Watson",
+		Long:    "Import the frames of Watson with their projects and tags, from the frames file of the Watson folder when no file is given. Importing again updates the sessions imported before.",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 1 {
+				return errors.New("only one file can be imported at a time")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := ""
+			if len(args) == 1 {
+				path = args[0]
+			} else {
+				configDir, err := os.UserConfigDir()
+				if err != nil {
+					return err
+				}
+				path = watson.FramesPath(os.Getenv, configDir)
+			}
+
+			file, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+
+			return importSessions(cmd, app, conflictsPath, importsessions.Command{}, watson.FramesImporter{Reader: file})
+		},
+	}
+
+	addImportFlags(cmd)
+
+	return cmd
+}
+
+func timewarriorCommand(app *app.App, conflictsPath string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "timewarrior [data folder or file (optional)]",
+		Example: "import timewarrior\nimport timewarrior ~/.timewarrior/data/2024-04.data",
+		Short:   "Import the intervals of Timewarrior",
+		Long:    "Import the intervals of the data files of Timewarrior, from its data folder when none is given. Timewarrior has no projects: the first tag of an interval is the project of the session, the other tags are its tags and the annotation is its note. Importing again updates the sessions imported before.",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 1 {
+				return errors.New("only one folder or file can be imported at a time")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := ""
+			if len(args) == 1 {
+				path = args[0]
+			} else {
+				homeDir, err := os.UserHomeDir()
+				if err != nil {
+					return err
+				}
+				path = timewarrior.DataPath(os.Getenv, homeDir)
+			}
+
+			return importSessions(cmd, app, conflictsPath, importsessions.Command{}, timewarrior.DataImporter{Path: path})
+		},
+	}
+
+	addImportFlags(cmd)
+
+	return cmd
+}
+
+func resolveCommand(app *app.App, conflictsPath string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "resolve",
+		Example: "import resolve\nimport resolve --all skip",
+		Short:   "Resolve the conflicts of the last import",
+		Long:    fmt.Sprintf("Resolve the conflicts of the last import, written to %v: the sessions still running in the tracker and the new sessions overlapping flow sessions. Each conflict comes with a suggested resolution, change the resolution of a conflict in the file to %v before resolving them. A running session is imported once its endTime is set. The conflicts which can't be resolved stay in the file.", conflictsPath, strings.Join(application.Resolutions, ", ")),
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logger := log.New(cmd.OutOrStdout(), "", 0)
+
+			allFlag, _ := cmd.Flags().GetString("all")
+
+			result, err := app.ResolveConflictsUseCase.Execute(resolveconflicts.Command{Resolution: allFlag})
+			if err != nil {
+				return err
+			}
+
+			logger.Printf("%v session(s) imported, %v adjusted, %v skipped", result.Imported, result.Adjusted, result.Skipped)
+			if result.Remaining > 0 {
+				logger.Printf("%v conflict(s) left in %v", result.Remaining, conflictsPath)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("all", "", fmt.Sprintf("Resolution of every conflict instead of their own one: %v", strings.Join(application.Resolutions, ", ")))
+
+	return cmd
+}
+
+func addImportFlags(cmd *cobra.Command) {
+	cmd.Flags().String("default-project", "", "Project of the imported sessions without one")
+	cmd.Flags().Bool("dry-run", false, "Count the sessions which would be imported without saving them")
+}
+
+// Command takes the path of the conflicts report to tell the user where to
+// choose the resolutions
+func Command(app *app.App, conflictsPath string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import sessions from other time trackers",
+	}
+
+	cmd.AddCommand(togglCommand(app, conflictsPath))
+	cmd.AddCommand(watsonCommand(app, conflictsPath))
+	cmd.AddCommand(timewarriorCommand(app, conflictsPath))
+	cmd.AddCommand(resolveCommand(app, conflictsPath))
+
+	return cmd
+}
@@ -0,0 +1,137 @@
+package flowimport_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/cmd/flowimport"
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/application/usecases/import/resolveconflicts"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/TristanShz/flow/test"
+	"github.com/matryer/is"
+)
+
+func TestImportTogglCommand(t *testing.T) {
+	is := is.New(t)
+
+	sessionRepository := &infra.InMemorySessionRepository{}
+	dateProvider := infra.NewStubDateProvider()
+	app := test.InitializeApp(sessionRepository, dateProvider)
+	app.Config = application.Config{Toggl: application.Toggl{
+		Projects: map[string]string{"Flow CLI": "flow"},
+		Tags:     map[string]string{"Deep Work": "deep"},
+	}}
+
+	path := filepath.Join(t.TempDir(), "toggl.csv")
+	err := os.WriteFile(path, []byte("ID,Project,Description,Start date,Start time,End date,End time,Duration,Tags\n"+
+		"42,Flow CLI,Import,2024-04-12,09:00:00,2024-04-12,10:30:00,01:30:00,Deep Work\n"), 0o644)
+	is.NoErr(err)
+
+	got, err := test.ExecuteCmd(t, flowimport.Command(app, "import-conflicts.json"), "toggl", path, "--dry-run")
+
+	is.NoErr(err)
+	is.Equal(got, "Dry run: 1 session(s) imported, 0 updated, 0 unchanged")
+	is.Equal(len(sessionRepository.Sessions), 0)
+
+	got, err = test.ExecuteCmd(t, flowimport.Command(app, "import-conflicts.json"), "toggl", path)
+
+	is.NoErr(err)
+	is.Equal(got, "1 session(s) imported, 0 updated, 0 unchanged")
+	is.Equal(len(sessionRepository.Sessions), 1)
+	is.Equal(sessionRepository.Sessions[0].Project, "flow")
+	is.Equal(sessionRepository.Sessions[0].Tags, []string{"deep"})
+
+	got, err = test.ExecuteCmd(t, flowimport.Command(app, "import-conflicts.json"), "toggl", path)
+
+	is.NoErr(err)
+	is.Equal(got, "0 session(s) imported, 0 updated, 1 unchanged")
+}
+
+func TestImportTogglCommand_NoToken(t *testing.T) {
+	is := is.New(t)
+
+	app := test.InitializeApp(&infra.InMemorySessionRepository{}, infra.NewStubDateProvider())
+
+	_, err := test.ExecuteCmd(t, flowimport.Command(app, "import-conflicts.json"), "toggl")
+
+	is.True(err != nil)
+}
+
+func TestImportWatsonCommand(t *testing.T) {
+	is := is.New(t)
+
+	sessionRepository := &infra.InMemorySessionRepository{}
+	app := test.InitializeApp(sessionRepository, infra.NewStubDateProvider())
+
+	path := filepath.Join(t.TempDir(), "frames")
+	err := os.WriteFile(path, []byte(`[[1712912400, 1712917800, "flow", "3f2a", ["cli"], 1712917800]]`), 0o644)
+	is.NoErr(err)
+
+	got, err := test.ExecuteCmd(t, flowimport.Command(app, "import-conflicts.json"), "watson", path)
+
+	is.NoErr(err)
+	is.Equal(got, "1 session(s) imported, 0 updated, 0 unchanged")
+	is.Equal(sessionRepository.Sessions[0].Project, "flow")
+	is.Equal(sessionRepository.Sessions[0].Tags, []string{"cli"})
+}
+
+func TestImportTimewarriorCommand(t *testing.T) {
+	is := is.New(t)
+
+	sessionRepository := &infra.InMemorySessionRepository{}
+	app := test.InitializeApp(sessionRepository, infra.NewStubDateProvider())
+
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "2024-04.data"), []byte("inc 20240412T090000Z - 20240412T100000Z # flow cli\ninc 20240412T110000Z # flow\n"), 0o644)
+	is.NoErr(err)
+
+	got, err := test.ExecuteCmd(t, flowimport.Command(app, "import-conflicts.json"), "timewarrior", dir)
+
+	is.NoErr(err)
+	is.Equal(got, "1 session(s) imported, 0 updated, 0 unchanged, 1 running skipped\n"+
+		"1 conflict(s) written to import-conflicts.json, choose their resolution and run 'flow import resolve'")
+	is.Equal(sessionRepository.Sessions[0].Project, "flow")
+	is.Equal(sessionRepository.Sessions[0].Tags, []string{"cli"})
+}
+
+func TestImportResolveCommand(t *testing.T) {
+	is := is.New(t)
+
+	sessionRepository := &infra.InMemorySessionRepository{Sessions: []session.Session{{
+		Id:        "flow1",
+		StartTime: time.Date(2024, 4, 12, 9, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, 4, 12, 10, 0, 0, 0, time.UTC),
+		Project:   "flow",
+	}}}
+	app := test.InitializeApp(sessionRepository, infra.NewStubDateProvider())
+
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "2024-04.data"), []byte("inc 20240412T083000Z - 20240412T093000Z # flow cli\ninc 20240412T110000Z # flow\n"), 0o644)
+	is.NoErr(err)
+
+	got, err := test.ExecuteCmd(t, flowimport.Command(app, "import-conflicts.json"), "timewarrior", dir)
+
+	is.NoErr(err)
+	is.Equal(got, "0 session(s) imported, 0 updated, 0 unchanged, 1 running skipped, 1 overlapping skipped\n"+
+		"2 conflict(s) written to import-conflicts.json, choose their resolution and run 'flow import resolve'")
+
+	_, err = test.ExecuteCmd(t, flowimport.Command(app, "import-conflicts.json"), "resolve", "--all", "merge")
+
+	is.True(errors.Is(err, resolveconflicts.ErrInvalidResolution))
+
+	got, err = test.ExecuteCmd(t, flowimport.Command(app, "import-conflicts.json"), "resolve")
+
+	is.NoErr(err)
+	is.Equal(got, "0 session(s) imported, 1 adjusted, 1 skipped")
+	is.Equal(len(sessionRepository.Sessions), 2)
+	is.Equal(sessionRepository.Sessions[1].EndTime, time.Date(2024, 4, 12, 9, 0, 0, 0, time.UTC))
+
+	_, err = test.ExecuteCmd(t, flowimport.Command(app, "import-conflicts.json"), "resolve")
+
+	is.Equal(err, resolveconflicts.ErrNoConflicts)
+}
@@ -0,0 +1,198 @@
+package flowlog
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/TristanShz/flow/cmd/completion"
+	"github.com/TristanShz/flow/cmd/history"
+	app "github.com/TristanShz/flow/internal/application/usecases"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/listsessionmetadata"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/listsessions"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/logsession"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/utils"
+	"github.com/spf13/cobra"
+)
+
+func addCommand(app *app.App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "add [project] [+tag1 +tag2...]",
+		Example: `log add my-todo +add-todo --start "2024-04-13 09:00" --end "2024-04-13 11:30"`,
+		Short:   "Save a past session, for work done without starting a session",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 || strings.HasPrefix(args[0], "+") {
+				return errors.New("the first argument must be the project name")
+			}
+
+			for _, arg := range args[1:] {
+				if !strings.HasPrefix(arg, "+") {
+					return fmt.Errorf("invalid tag %v (must start with '+')", arg)
+				}
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logger := log.New(cmd.OutOrStdout(), "", 0)
+			now := app.DateProvider.GetNow()
+
+			startFlag, _ := cmd.Flags().GetString("start")
+			endFlag, _ := cmd.Flags().GetString("end")
+			durationFlag, _ := cmd.Flags().GetDuration("duration")
+			noteFlag, _ := cmd.Flags().GetString("note")
+			overlapFlag, _ := cmd.Flags().GetString("overlap")
+
+			overlap := cmp.Or(overlapFlag, app.Config.Overlap)
+			if overlap != "" && !session.IsOverlapPolicyValid(overlap) {
+				return fmt.Errorf("invalid overlap %v. possible values: %v", overlap, strings.Join(session.OverlapPolicies, ", "))
+			}
+
+			if startFlag == "" {
+				return errors.New("the start time is required")
+			}
+			if (endFlag == "") == (durationFlag == 0) {
+				return errors.New("either the end time or the duration is required")
+			}
+
+			startTime, err := utils.ParseDateTime(startFlag, now)
+			if err != nil {
+				return err
+			}
+
+			endTime := startTime.Add(durationFlag)
+			if endFlag != "" {
+				if endTime, err = utils.ParseDateTime(endFlag, startTime); err != nil {
+					return err
+				}
+			}
+
+			tags := []string{}
+			for _, tag := range args[1:] {
+				tags = append(tags, strings.TrimPrefix(tag, "+"))
+			}
+
+			logged, err := app.LogSessionUseCase.Execute(logsession.Command{
+				Project:      args[0],
+				Tags:         tags,
+				Note:         noteFlag,
+				StartTime:    startTime,
+				EndTime:      endTime,
+				Overlap:      overlap,
+				LockedBefore: history.LockedBefore(cmd, app),
+			})
+			// the session is saved despite the overlaps it's warned about
+			var overlapWarning *session.OverlapWarning
+			if err != nil && !errors.As(err, &overlapWarning) {
+				return err
+			}
+
+			logger.Printf(
+				"Session logged for the project %v from %v to %v (%v)",
+				utils.ProjectColor(logged.Project),
+				logged.GetFormattedStartTime(),
+				logged.GetFormattedEndTime(),
+				utils.TimeColor(logged.Duration().String()),
+			)
+
+			if overlapWarning != nil {
+				logger.Printf("Warning: %v", overlapWarning)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("start", "", "Start time of the session (YYYY-MM-DD HH:MM or HH:MM)")
+	cmd.Flags().String("end", "", "End time of the session (YYYY-MM-DD HH:MM or HH:MM, on the day of the start)")
+	cmd.Flags().Duration("duration", 0, "Duration of the session instead of its end time, e.g. 1h30m")
+	cmd.Flags().StringP("note", "n", "", "Note describing what was done during the session")
+	cmd.Flags().String("overlap", "", "What to do when the session overlaps another one: allow, warn, reject or adjust (default from the config, else reject)")
+	history.AddFlag(cmd)
+
+	cmd.RegisterFlagCompletionFunc("start", completion.Times(app))
+	cmd.RegisterFlagCompletionFunc("end", completion.Times(app))
+	cmd.RegisterFlagCompletionFunc("duration", completion.Durations(completion.CommonDurations))
+
+	return cmd
+}
+
+func formatSession(s session.Session) string {
+	text := fmt.Sprintf("%v %v %v %v", s.Id, utils.TimeColor(s.GetFormattedStartTime()), utils.TimeColor(s.Duration().String()), utils.ProjectColor(s.Project))
+
+	if len(s.Tags) > 0 {
+		text += fmt.Sprintf(" [%v]", utils.TagColor(strings.Join(s.Tags, ", ")))
+	}
+
+	return text
+}
+
+func Command(app *app.App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "log",
+		Example: "log -n 20\nlog --project my-todo --skip 10",
+		Short:   "Manage the sessions log, list the last sessions without a subcommand",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			logger := log.New(cmd.OutOrStdout(), "", 0)
+
+			countFlag, _ := cmd.Flags().GetInt("count")
+			skipFlag, _ := cmd.Flags().GetInt("skip")
+			projectFlag, _ := cmd.Flags().GetString("project")
+			shortFlag, _ := cmd.Flags().GetBool("short")
+			if countFlag <= 0 || skipFlag < 0 {
+				return errors.New("the count must be positive and the skipped sessions can't be negative")
+			}
+
+			if shortFlag {
+				metadata := app.ListSessionMetadataUseCase.Execute(listsessionmetadata.Command{
+					Count:   countFlag,
+					Skip:    skipFlag,
+					Project: projectFlag,
+				})
+
+				if len(metadata) == 0 {
+					logger.Println("No sessions")
+					return nil
+				}
+
+				for _, m := range metadata {
+					logger.Printf("%v %v %v", m.Id, utils.TimeColor(m.StartTime.Format(time.DateTime)), utils.ProjectColor(m.Project))
+				}
+
+				return nil
+			}
+
+			sessions := app.ListSessionsUseCase.Execute(listsessions.Command{
+				Count:   countFlag,
+				Skip:    skipFlag,
+				Project: projectFlag,
+			})
+
+			if len(sessions) == 0 {
+				logger.Println("No sessions")
+				return nil
+			}
+
+			for _, s := range sessions {
+				logger.Println(formatSession(s))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().IntP("count", "n", listsessions.DefaultCount, "Number of sessions listed, the newest first")
+	cmd.Flags().Int("skip", 0, "Skip the newest sessions, to list the older ones")
+	cmd.Flags().StringP("project", "p", "", "Only list the sessions of the project")
+	cmd.Flags().Bool("short", false, "Only list the ids, start times and projects, which doesn't read the sessions")
+	completion.RegisterProjectFlag(cmd, app)
+
+	cmd.AddCommand(addCommand(app))
+
+	return cmd
+}
@@ -0,0 +1,203 @@
+package flowlog_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/cmd/flowlog"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/logsession"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/TristanShz/flow/test"
+	"github.com/matryer/is"
+)
+
+func TestLogAddCommand(t *testing.T) {
+	tt := []struct {
+		error error
+		name  string
+		want  string
+		args  []string
+	}{
+		{
+			name: "Start and end",
+			args: []string{"add", "my-todo", "+docs", "--start", "2024-04-13 09:00", "--end", "11:30"},
+			want: "Session logged for the project my-todo from 2024-04-13 09:00:00 to 2024-04-13 11:30:00 (2h30m0s)",
+		},
+		{
+			name: "Start and duration",
+			args: []string{"add", "my-todo", "--start", "13:00", "--duration", "45m"},
+			want: "Session logged for the project my-todo from 2024-04-14 13:00:00 to 2024-04-14 13:45:00 (45m0s)",
+		},
+		{
+			name:  "Overlap",
+			args:  []string{"add", "my-todo", "--start", "07:00", "--end", "08:30"},
+			error: logsession.ErrOverlap,
+		},
+		{
+			name: "Overlap warned",
+			args: []string{"add", "my-todo", "--start", "07:00", "--end", "08:30", "--overlap", "warn"},
+			want: "Session logged for the project my-todo from 2024-04-14 07:00:00 to 2024-04-14 08:30:00 (1h30m0s)\nWarning: the session overlaps 1 other session(s): 1",
+		},
+		{
+			name: "Overlap adjusted",
+			args: []string{"add", "my-todo", "--start", "07:00", "--end", "08:30", "--overlap", "adjust"},
+			want: "Session logged for the project my-todo from 2024-04-14 07:00:00 to 2024-04-14 08:00:00 (1h0m0s)",
+		},
+		{
+			name:  "Invalid overlap",
+			args:  []string{"add", "my-todo", "--start", "07:00", "--end", "08:30", "--overlap", "merge"},
+			error: errors.New("invalid overlap merge. possible values: allow, warn, reject, adjust"),
+		},
+		{
+			name:  "Missing project",
+			args:  []string{"add", "+docs", "--start", "07:00", "--end", "08:30"},
+			error: errors.New("the first argument must be the project name"),
+		},
+		{
+			name:  "Missing end",
+			args:  []string{"add", "my-todo", "--start", "07:00"},
+			error: errors.New("either the end time or the duration is required"),
+		},
+		{
+			name:  "Missing start",
+			args:  []string{"add", "my-todo", "--end", "07:00"},
+			error: errors.New("the start time is required"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			sessionRepository := &infra.InMemorySessionRepository{Sessions: []session.Session{{
+				Id:        "1",
+				StartTime: time.Date(2024, time.April, 14, 8, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2024, time.April, 14, 10, 0, 0, 0, time.UTC),
+				Project:   "my-todo",
+			}}}
+			dateProvider := infra.NewStubDateProvider()
+			dateProvider.Now = time.Date(2024, time.April, 14, 18, 0, 0, 0, time.UTC)
+			app := test.InitializeApp(sessionRepository, dateProvider)
+
+			got, err := test.ExecuteCmd(t, flowlog.Command(app), tc.args...)
+
+			is.Equal(tc.error, err)
+
+			if tc.error == nil {
+				is.Equal(got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLogAddCommand_LockedHistory(t *testing.T) {
+	tt := []struct {
+		error error
+		name  string
+		want  string
+		args  []string
+	}{
+		{
+			name:  "Read-only month",
+			args:  []string{"add", "my-todo", "--start", "2024-02-20 09:00", "--end", "11:00"},
+			error: session.ErrLockedHistory,
+		},
+		{
+			name: "History unlocked",
+			args: []string{"add", "my-todo", "--start", "2024-02-20 09:00", "--end", "11:00", "--unlock-history"},
+			want: "Session logged for the project my-todo from 2024-02-20 09:00:00 to 2024-02-20 11:00:00 (2h0m0s)",
+		},
+		{
+			name: "Last month",
+			args: []string{"add", "my-todo", "--start", "2024-03-20 09:00", "--end", "11:00"},
+			want: "Session logged for the project my-todo from 2024-03-20 09:00:00 to 2024-03-20 11:00:00 (2h0m0s)",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			dateProvider := infra.NewStubDateProvider()
+			dateProvider.Now = time.Date(2024, time.April, 14, 18, 0, 0, 0, time.UTC)
+			app := test.InitializeApp(&infra.InMemorySessionRepository{}, dateProvider)
+			app.Config.HistoryLockMonths = 1
+
+			got, err := test.ExecuteCmd(t, flowlog.Command(app), tc.args...)
+
+			is.Equal(tc.error, err)
+
+			if tc.error == nil {
+				is.Equal(got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLogCommand(t *testing.T) {
+	tt := []struct {
+		error error
+		name  string
+		want  string
+		args  []string
+	}{
+		{
+			name: "Last sessions",
+			args: []string{},
+			want: "3 2024-04-14 14:00:00 30m0s Flow\n2 2024-04-14 11:00:00 1h0m0s my-todo [docs]\n1 2024-04-14 08:00:00 2h0m0s my-todo",
+		},
+		{
+			name: "Count and skip",
+			args: []string{"-n", "1", "--skip", "1"},
+			want: "2 2024-04-14 11:00:00 1h0m0s my-todo [docs]",
+		},
+		{
+			name: "Project",
+			args: []string{"--project", "Flow"},
+			want: "3 2024-04-14 14:00:00 30m0s Flow",
+		},
+		{
+			name: "Short",
+			args: []string{"--short", "-n", "2"},
+			want: "3 2024-04-14 14:00:00 Flow\n2 2024-04-14 11:00:00 my-todo",
+		},
+		{
+			name: "No sessions",
+			args: []string{"--project", "Unknown"},
+			want: "No sessions",
+		},
+		{
+			name:  "Invalid count",
+			args:  []string{"-n", "0"},
+			error: errors.New("the count must be positive and the skipped sessions can't be negative"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			at := func(hour int) time.Time {
+				return time.Date(2024, time.April, 14, hour, 0, 0, 0, time.UTC)
+			}
+			sessionRepository := &infra.InMemorySessionRepository{Sessions: []session.Session{
+				{Id: "1", StartTime: at(8), EndTime: at(10), Project: "my-todo"},
+				{Id: "2", StartTime: at(11), EndTime: at(12), Project: "my-todo", Tags: []string{"docs"}},
+				{Id: "3", StartTime: at(14), EndTime: at(14).Add(30 * time.Minute), Project: "Flow"},
+			}}
+			dateProvider := infra.NewStubDateProvider()
+			dateProvider.Now = at(18)
+			app := test.InitializeApp(sessionRepository, dateProvider)
+
+			got, err := test.ExecuteCmd(t, flowlog.Command(app), tc.args...)
+
+			is.Equal(tc.error, err)
+
+			if tc.error == nil {
+				is.Equal(got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package forecast
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/TristanShz/flow/cmd/completion"
+	app "github.com/TristanShz/flow/internal/application/usecases"
+	"github.com/TristanShz/flow/internal/application/usecases/project/forecast"
+	"github.com/TristanShz/flow/utils"
+	"github.com/spf13/cobra"
+)
+
+// formatCompletion prints when the remaining time is spent, and how many
+// weeks from now
+func formatCompletion(at time.Time, now time.Time) string {
+	if at.IsZero() {
+		return "never at this pace"
+	}
+
+	weeks := at.Sub(now).Hours() / (7 * 24)
+	return fmt.Sprintf("%v, in %.1f weeks", at.Format("Mon 2006-01-02"), weeks)
+}
+
+func Command(app *app.App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "forecast",
+		Example: "forecast --project my-todo --remaining 30h\nforecast --project my-todo --remaining 30h --weeks 4",
+		Short:   "Estimate when the remaining time of a project will be spent",
+		Long:    "Estimate when the remaining time of a project will be spent at the pace of the last complete weeks, the optimistic and pessimistic dates are at the pace of the best and worst quarters of the weeks",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			logger := log.New(cmd.OutOrStdout(), "", 0)
+
+			projectFlag, _ := cmd.Flags().GetString("project")
+			remainingFlag, _ := cmd.Flags().GetDuration("remaining")
+			weeksFlag, _ := cmd.Flags().GetInt("weeks")
+
+			if projectFlag == "" {
+				return errors.New("the project is required")
+			}
+
+			result, err := app.ForecastUseCase.Execute(forecast.Command{
+				Project:   projectFlag,
+				Remaining: remainingFlag,
+				Weeks:     weeksFlag,
+				WeekStart: app.Config.FirstDayOfWeek(),
+			})
+			if err != nil {
+				return err
+			}
+
+			now := app.DateProvider.GetNow()
+
+			text := fmt.Sprintf("Forecast for %v, %v remaining\n", utils.ProjectColor(projectFlag), utils.TimeColor(remainingFlag.String()))
+			text += fmt.Sprintf("Pace: %v a week over the last %v week(s)\n\n", utils.TimeColor(result.Velocity.String()), result.Weeks)
+			text += fmt.Sprintf("Expected: %v\n", formatCompletion(result.Expected, now))
+			text += fmt.Sprintf("Optimistic: %v, at %v a week\n", formatCompletion(result.Optimistic, now), utils.TimeColor(result.OptimisticVelocity.String()))
+			text += fmt.Sprintf("Pessimistic: %v, at %v a week", formatCompletion(result.Pessimistic, now), utils.TimeColor(result.PessimisticVelocity.String()))
+
+			logger.Println(text)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("project", "p", "", "Project to forecast")
+	cmd.Flags().Duration("remaining", 0, "Time left to spend on the project, e.g. 30h")
+	cmd.Flags().Int("weeks", forecast.DefaultWeeks, "Number of complete weeks the pace is measured on")
+
+	completion.RegisterProjectFlag(cmd, app)
+	cmd.RegisterFlagCompletionFunc("remaining", completion.Durations([]string{"10h", "20h", "40h", "80h"}))
+
+	return cmd
+}
@@ -0,0 +1,67 @@
+package forecast_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/cmd/forecast"
+	forecastproject "github.com/TristanShz/flow/internal/application/usecases/project/forecast"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/TristanShz/flow/test"
+	"github.com/matryer/is"
+)
+
+func TestForecastCommand(t *testing.T) {
+	tt := []struct {
+		name  string
+		args  []string
+		want  string
+		error error
+	}{
+		{
+			name: "Forecast",
+			args: []string{"--project", "flow", "--remaining", "8h"},
+			want: "Forecast for flow, 8h0m0s remaining\nPace: 2h0m0s a week over the last 2 week(s)\n\n" +
+				"Expected: Wed 2024-05-15, in 4.0 weeks\n" +
+				"Optimistic: Mon 2024-05-06, in 2.7 weeks, at 3h0m0s a week\n" +
+				"Pessimistic: Wed 2024-06-12, in 8.0 weeks, at 1h0m0s a week",
+		},
+		{
+			name:  "Missing project",
+			args:  []string{"--remaining", "8h"},
+			error: errors.New("the project is required"),
+		},
+		{
+			name:  "Missing remaining time",
+			args:  []string{"--project", "flow"},
+			error: forecastproject.ErrNoRemainingTime,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			sessionRepository := &infra.InMemorySessionRepository{Sessions: []session.Session{
+				{
+					Id:        "1",
+					StartTime: time.Date(2024, time.April, 2, 9, 0, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.April, 2, 13, 0, 0, 0, time.UTC),
+					Project:   "flow",
+				},
+			}}
+			dateProvider := infra.NewStubDateProvider()
+			dateProvider.Now = time.Date(2024, time.April, 17, 12, 0, 0, 0, time.UTC)
+			app := test.InitializeApp(sessionRepository, dateProvider)
+
+			got, err := test.ExecuteCmd(t, forecast.Command(app), tc.args...)
+
+			is.Equal(tc.error, err)
+			if tc.error == nil {
+				is.Equal(got, tc.want)
+			}
+		})
+	}
+}
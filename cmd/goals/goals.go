@@ -0,0 +1,97 @@
+package goals
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/TristanShz/flow/cmd/completion"
+	app "github.com/TristanShz/flow/internal/application/usecases"
+	"github.com/TristanShz/flow/internal/application/usecases/project/goals"
+	"github.com/TristanShz/flow/internal/domain/project"
+	"github.com/TristanShz/flow/utils"
+	"github.com/spf13/cobra"
+)
+
+// formatProgress prints the time tracked against the goal and whether it's
+// on track
+func formatProgress(g project.GoalProgress) string {
+	text := fmt.Sprintf("this %v: %v of %v (%v%%), ", g.Period, utils.TimeColor(g.Tracked.String()), utils.TimeColor(g.Goal.String()), g.Percent())
+
+	switch {
+	case g.Reached():
+		text += "reached"
+	case g.Behind() > 0:
+		text += fmt.Sprintf("behind by %v", utils.TimeColor(g.Behind().String()))
+	default:
+		text += fmt.Sprintf("on track, %v left", utils.TimeColor(g.Remaining().String()))
+	}
+
+	return text
+}
+
+// Warnings returns a warning for every goal of the current week or month
+// which is behind, of the project or of every project when it's empty
+func Warnings(app *app.App, projectName string) []string {
+	progress, err := app.GoalsUseCase.Execute(goals.Command{
+		Project:   projectName,
+		WeekStart: app.Config.FirstDayOfWeek(),
+	})
+	if err != nil {
+		return nil
+	}
+
+	warnings := []string{}
+	for _, g := range progress {
+		if g.Behind() > 0 {
+			warnings = append(warnings, fmt.Sprintf("Warning: %v is behind its goal by %v, %v of %v this %v", utils.ProjectColor(g.Project), utils.TimeColor(g.Behind().String()), utils.TimeColor(g.Tracked.String()), utils.TimeColor(g.Goal.String()), g.Period))
+		}
+	}
+
+	return warnings
+}
+
+func Command(app *app.App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "goals",
+		Example: "goals\ngoals --project my-todo",
+		Short:   "Show the progress of the weekly and monthly goals of the projects",
+		Long:    "Show the time tracked on the projects this week and this month against their goals, set with 'flow projects set [project] --weekly-goal 10h --monthly-goal 40h'. A goal is behind when less than its share of the time elapsed in the week or month is tracked.",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			logger := log.New(cmd.OutOrStdout(), "", 0)
+
+			projectFlag, _ := cmd.Flags().GetString("project")
+
+			progress, err := app.GoalsUseCase.Execute(goals.Command{
+				Project:   projectFlag,
+				WeekStart: app.Config.FirstDayOfWeek(),
+			})
+			if errors.Is(err, goals.ErrNoGoals) {
+				logger.Println("No goals, set them with 'flow projects set [project] --weekly-goal 10h --monthly-goal 40h'")
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			lines := []string{}
+			for i, g := range progress {
+				if i == 0 || progress[i-1].Project != g.Project {
+					lines = append(lines, utils.ProjectColor(g.Project))
+				}
+				lines = append(lines, "    "+formatProgress(g))
+			}
+
+			logger.Println(strings.Join(lines, "\n"))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("project", "p", "", "Only show the goals of the project")
+
+	completion.RegisterProjectFlag(cmd, app)
+
+	return cmd
+}
@@ -0,0 +1,70 @@
+package goals_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/cmd/goals"
+	"github.com/TristanShz/flow/internal/application/usecases/project/setproject"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/TristanShz/flow/test"
+	"github.com/matryer/is"
+)
+
+func durationPtr(d time.Duration) *time.Duration {
+	return &d
+}
+
+func TestGoalsCommand(t *testing.T) {
+	is := is.New(t)
+
+	sessionRepository := &infra.InMemorySessionRepository{Sessions: []session.Session{
+		{
+			Id:        "1",
+			StartTime: time.Date(2024, time.April, 2, 9, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, time.April, 2, 17, 0, 0, 0, time.UTC),
+			Project:   "Flow",
+		},
+		{
+			Id:        "2",
+			StartTime: time.Date(2024, time.April, 8, 9, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, time.April, 8, 11, 0, 0, 0, time.UTC),
+			Project:   "Flow",
+		},
+		{
+			Id:        "3",
+			StartTime: time.Date(2024, time.April, 9, 9, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, time.April, 9, 14, 0, 0, 0, time.UTC),
+			Project:   "MyTodo",
+		},
+	}}
+	dateProvider := infra.NewStubDateProvider()
+	dateProvider.Now = time.Date(2024, time.April, 10, 12, 0, 0, 0, time.UTC)
+	app := test.InitializeApp(sessionRepository, dateProvider)
+
+	got, err := test.ExecuteCmd(t, goals.Command(app))
+
+	is.NoErr(err)
+	is.Equal(got, "No goals, set them with 'flow projects set [project] --weekly-goal 10h --monthly-goal 40h'")
+
+	_, err = app.SetProjectUseCase.Execute(setproject.Command{Name: "Flow", WeeklyGoal: durationPtr(10 * time.Hour), MonthlyGoal: durationPtr(30 * time.Hour)})
+	is.NoErr(err)
+	_, err = app.SetProjectUseCase.Execute(setproject.Command{Name: "MyTodo", WeeklyGoal: durationPtr(4 * time.Hour)})
+	is.NoErr(err)
+
+	got, err = test.ExecuteCmd(t, goals.Command(app))
+
+	is.NoErr(err)
+	is.Equal(got, "Flow\n"+
+		"    this week: 2h0m0s of 10h0m0s (20%), behind by 1h34m0s\n"+
+		"    this month: 10h0m0s of 30h0m0s (33%), on track, 20h0m0s left\n"+
+		"MyTodo\n"+
+		"    this week: 5h0m0s of 4h0m0s (125%), reached")
+
+	got, err = test.ExecuteCmd(t, goals.Command(app), "--project", "MyTodo")
+
+	is.NoErr(err)
+	is.Equal(got, "MyTodo\n    this week: 5h0m0s of 4h0m0s (125%), reached")
+	is.Equal(goals.Warnings(app, ""), []string{"Warning: Flow is behind its goal by 1h34m0s, 2h0m0s of 10h0m0s this week"})
+}
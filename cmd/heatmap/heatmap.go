@@ -0,0 +1,113 @@
+package heatmap
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/TristanShz/flow/cmd/completion"
+	app "github.com/TristanShz/flow/internal/application/usecases"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/heatmap"
+	"github.com/TristanShz/flow/internal/domain/sessionsreport"
+	"github.com/TristanShz/flow/utils"
+	"github.com/spf13/cobra"
+)
+
+// labelWidth is the width of the weekdays on the left of the heatmap
+const labelWidth = 4
+
+// monthsLine labels the columns of the weeks starting a month, skipping a
+// month when the previous label takes its place
+func monthsLine(result sessionsreport.Heatmap) string {
+	line := []rune(strings.Repeat(" ", labelWidth))
+	previous := ""
+	for index, week := range result.Weeks {
+		month := week[0].Day.Format("Jan")
+		column := labelWidth + 2*index
+		if month != previous && column >= len(line) {
+			line = append(line, []rune(strings.Repeat(" ", column-len(line))+month+" ")...)
+		}
+		previous = month
+	}
+
+	return strings.TrimRight(string(line), " ")
+}
+
+func Command(app *app.App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "heatmap",
+		Example: "heatmap\nheatmap --weeks 52\nheatmap --project my-todo",
+		Short:   "Show the time tracked each day of the last weeks as a heatmap",
+		Long:    "Show the time tracked each day of the last weeks as a heatmap like the contributions of GitHub: a column per week and a row per weekday, each day colored by its time compared to the busiest day",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			logger := log.New(cmd.OutOrStdout(), "", 0)
+
+			weeksFlag, _ := cmd.Flags().GetInt("weeks")
+			projectFlag, _ := cmd.Flags().GetString("project")
+
+			result, err := app.HeatmapUseCase.Execute(heatmap.Command{
+				Weeks:     weeksFlag,
+				WeekStart: app.Config.FirstDayOfWeek(),
+				Project:   projectFlag,
+			})
+			if err != nil {
+				return err
+			}
+
+			total, trackedDays := result.Total()
+			if total == 0 {
+				logger.Println("No sessions found")
+				return nil
+			}
+
+			days := 0
+			for _, week := range result.Weeks {
+				days += len(week)
+			}
+
+			title := fmt.Sprintf("Time tracked each day of the last %v week(s)", weeksFlag)
+			if projectFlag != "" {
+				title += " on " + utils.ProjectColor(projectFlag)
+			}
+
+			lines := []string{title, "", monthsLine(result)}
+			for weekday := range 7 {
+				cells := []string{}
+				for _, week := range result.Weeks {
+					if weekday < len(week) {
+						cells = append(cells, utils.HeatmapCell(result.Level(week[weekday], utils.HeatmapLevels)))
+					}
+				}
+
+				label := result.Weeks[0][weekday].Day.Format("Mon")
+				lines = append(lines, fmt.Sprintf("%-*v%v", labelWidth, label, strings.Join(cells, " ")))
+			}
+
+			legend := []string{}
+			for level := range utils.HeatmapLevels {
+				legend = append(legend, utils.HeatmapCell(level))
+			}
+			lines = append(lines, "", fmt.Sprintf("%vLess %v More", strings.Repeat(" ", labelWidth), strings.Join(legend, " ")))
+
+			lines = append(lines, "", fmt.Sprintf(
+				"Tracked %v on %v of %v day(s), the busiest is %v with %v",
+				utils.TimeColor(total.String()),
+				trackedDays,
+				days,
+				result.Busiest.Day.Format("Mon 2006-01-02"),
+				utils.TimeColor(result.Busiest.Duration.String()),
+			))
+
+			logger.Println(strings.Join(lines, "\n"))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().IntP("weeks", "w", 12, fmt.Sprintf("Number of weeks of the heatmap, up to the current one, at most %v", heatmap.MaxWeeks))
+	cmd.Flags().StringP("project", "p", "", "Only count the sessions of the given project")
+
+	completion.RegisterProjectFlag(cmd, app)
+
+	return cmd
+}
@@ -0,0 +1,96 @@
+package heatmap_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/cmd/heatmap"
+	flowheatmap "github.com/TristanShz/flow/internal/application/usecases/flowsession/heatmap"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/TristanShz/flow/test"
+	"github.com/matryer/is"
+)
+
+func TestHeatmapCommand(t *testing.T) {
+	sessionRepository := &infra.InMemorySessionRepository{}
+	dateProvider := infra.NewStubDateProvider()
+	dateProvider.Now = time.Date(2024, time.April, 17, 15, 0, 0, 0, time.UTC)
+	app := test.InitializeApp(sessionRepository, dateProvider)
+
+	at := func(day int, hour int, minute int) time.Time {
+		return time.Date(2024, time.April, day, hour, minute, 0, 0, time.UTC)
+	}
+	sessions := []session.Session{
+		{Id: "1", StartTime: at(2, 9, 0), EndTime: at(2, 13, 0), Project: "Flow"},
+		{Id: "2", StartTime: at(5, 9, 0), EndTime: at(5, 10, 0), Project: "MyTodo"},
+		{Id: "3", StartTime: at(9, 9, 0), EndTime: at(9, 11, 0), Project: "Flow"},
+		{Id: "4", StartTime: at(16, 9, 0), EndTime: at(16, 12, 0), Project: "Flow"},
+		{Id: "5", StartTime: at(17, 9, 0), EndTime: at(17, 9, 30), Project: "Flow"},
+	}
+
+	tt := []struct {
+		name          string
+		args          []string
+		givenSessions []session.Session
+		want          string
+		error         error
+	}{
+		{
+			name:          "Last weeks",
+			args:          []string{"--weeks", "3"},
+			givenSessions: sessions,
+			want: "Time tracked each day of the last 3 week(s)\n\n" +
+				"    Apr\n" +
+				"Mon · · ·\n" +
+				"Tue █ ▒ ▓\n" +
+				"Wed · · ░\n" +
+				"Thu · ·\n" +
+				"Fri ░ ·\n" +
+				"Sat · ·\n" +
+				"Sun · ·\n\n" +
+				"    Less · ░ ▒ ▓ █ More\n\n" +
+				"Tracked 10h30m0s on 5 of 17 day(s), the busiest is Tue 2024-04-02 with 4h0m0s",
+		},
+		{
+			name:          "Sessions of a project over two months",
+			args:          []string{"--weeks", "6", "--project", "MyTodo"},
+			givenSessions: sessions,
+			want: "Time tracked each day of the last 6 week(s) on MyTodo\n\n" +
+				"    Mar   Apr\n" +
+				"Mon · · · · · ·\n" +
+				"Tue · · · · · ·\n" +
+				"Wed · · · · · ·\n" +
+				"Thu · · · · ·\n" +
+				"Fri · · · █ ·\n" +
+				"Sat · · · · ·\n" +
+				"Sun · · · · ·\n\n" +
+				"    Less · ░ ▒ ▓ █ More\n\n" +
+				"Tracked 1h0m0s on 1 of 38 day(s), the busiest is Fri 2024-04-05 with 1h0m0s",
+		},
+		{
+			name: "No sessions",
+			want: "No sessions found",
+		},
+		{
+			name:  "Invalid number of weeks",
+			args:  []string{"--weeks", "0"},
+			error: flowheatmap.ErrInvalidWeeks,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			sessionRepository.Sessions = tc.givenSessions
+
+			got, err := test.ExecuteCmd(t, heatmap.Command(app), tc.args...)
+
+			is.Equal(err, tc.error)
+			if tc.error == nil {
+				is.Equal(got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package help
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// AddExamplesFlag adds the --examples flag to the given command and its
+// subcommands, it prints the examples of the command instead of running it
+func AddExamplesFlag(root *cobra.Command) {
+	root.PersistentFlags().Bool("examples", false, "Show examples of the command instead of running it")
+
+	wrapCommands(root)
+}
+
+func wrapCommands(cmd *cobra.Command) {
+	for _, subCmd := range cmd.Commands() {
+		wrapCommands(subCmd)
+	}
+
+	if !cmd.Runnable() {
+		return
+	}
+
+	if args := cmd.Args; args != nil {
+		cmd.Args = func(cmd *cobra.Command, a []string) error {
+			if showExamples(cmd) {
+				return nil
+			}
+			return args(cmd, a)
+		}
+	}
+
+	run := cmd.Run
+	runE := cmd.RunE
+	cmd.Run = nil
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if showExamples(cmd) {
+			printExamples(cmd)
+			return nil
+		}
+
+		if runE != nil {
+			return runE(cmd, args)
+		}
+
+		run(cmd, args)
+		return nil
+	}
+}
+
+func showExamples(cmd *cobra.Command) bool {
+	examples, _ := cmd.Flags().GetBool("examples")
+	return examples
+}
+
+// printExamples prints the examples of the command as complete command lines,
+// ready to be copied
+func printExamples(cmd *cobra.Command) {
+	if cmd.Example == "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "No examples for '%v'\n", cmd.CommandPath())
+		return
+	}
+
+	rootName := cmd.Root().Name()
+	for _, example := range strings.Split(cmd.Example, "\n") {
+		example = strings.TrimSpace(example)
+		if example == "" {
+			continue
+		}
+
+		if !strings.HasPrefix(example, rootName+" ") {
+			example = rootName + " " + example
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), example)
+	}
+}
@@ -0,0 +1,159 @@
+package help
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/TristanShz/flow/utils"
+	"github.com/spf13/cobra"
+)
+
+//go:embed guides/*.md
+var guides embed.FS
+
+// Topics returns the names of the guides, which are the names of their files
+func Topics() []string {
+	entries, _ := guides.ReadDir("guides")
+
+	topics := []string{}
+	for _, entry := range entries {
+		topics = append(topics, strings.TrimSuffix(entry.Name(), ".md"))
+	}
+	sort.Strings(topics)
+
+	return topics
+}
+
+// Guide returns the markdown of the guide of the given topic
+func Guide(topic string) (string, error) {
+	content, err := guides.ReadFile(path.Join("guides", topic+".md"))
+	if err != nil {
+		return "", fmt.Errorf("unknown help topic %q. available topics: %v", topic, strings.Join(Topics(), ", "))
+	}
+
+	return string(content), nil
+}
+
+// title is the first heading of a guide
+func title(guide string) string {
+	firstLine, _, _ := strings.Cut(guide, "\n")
+	return strings.TrimPrefix(firstLine, "# ")
+}
+
+// render styles the headings and the code blocks of a guide for the terminal
+func render(guide string) string {
+	lines := []string{}
+	inCode := false
+
+	for _, line := range strings.Split(strings.TrimSpace(guide), "\n") {
+		switch {
+		case strings.HasPrefix(line, "```"):
+			inCode = !inCode
+		case inCode:
+			lines = append(lines, "    "+utils.TimeColor(line))
+		case strings.HasPrefix(line, "#"):
+			lines = append(lines, utils.HeaderStyle.Render(strings.TrimLeft(line, "# ")))
+		default:
+			lines = append(lines, line)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func isTerminal(file *os.File) bool {
+	fileInfo, err := file.Stat()
+	return err == nil && fileInfo.Mode()&os.ModeCharDevice != 0
+}
+
+// page shows the text in $PAGER, or less, when the output is a terminal. The
+// text is printed as is otherwise, or when the pager can't be started.
+func page(cmd *cobra.Command, text string) {
+	out, ok := cmd.OutOrStdout().(*os.File)
+	noPager, _ := cmd.Flags().GetBool("no-pager")
+	if !ok || noPager || !isTerminal(out) {
+		fmt.Fprintln(cmd.OutOrStdout(), text)
+		return
+	}
+
+	pager := strings.Fields(os.Getenv("PAGER"))
+	if len(pager) == 0 {
+		pager = []string{"less"}
+	}
+
+	pagerCmd := exec.Command(pager[0], pager[1:]...)
+	pagerCmd.Stdin = strings.NewReader(text + "\n")
+	pagerCmd.Stdout = out
+	pagerCmd.Stderr = cmd.ErrOrStderr()
+	// less exits right away when the text fits the screen and keeps the colors
+	pagerCmd.Env = append(os.Environ(), "LESS=FRX")
+	if os.Getenv("LESS") != "" {
+		pagerCmd.Env = os.Environ()
+	}
+
+	if err := pagerCmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			fmt.Fprintln(out, text)
+		}
+	}
+}
+
+// Command replaces the help command of cobra, it shows the guide of a topic or
+// the help of a command
+func Command(root *cobra.Command) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "help [command | topic]",
+		Example: "help tracking\nhelp report",
+		Short:   "Help about any command, or guides about flow",
+		Long:    "Help about any command, or long-form guides about flow. Topics: " + strings.Join(Topics(), ", "),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 1 && !isCommand(root, args) {
+				guide, err := Guide(args[0])
+				if err != nil {
+					return err
+				}
+
+				page(cmd, render(guide))
+				return nil
+			}
+
+			target, _, err := root.Find(args)
+			if err != nil || target == nil {
+				return fmt.Errorf("unknown help topic %q", strings.Join(args, " "))
+			}
+
+			target.SetOut(cmd.OutOrStdout())
+			if err := target.Help(); err != nil {
+				return err
+			}
+
+			if target == root {
+				text := "\nGuides:\n"
+				for _, topic := range Topics() {
+					guide, _ := Guide(topic)
+					text += fmt.Sprintf("  %-10v %v\n", topic, title(guide))
+				}
+				text += "\nUse \"flow help [topic]\" to read a guide."
+				fmt.Fprintln(cmd.OutOrStdout(), text)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool("no-pager", false, "Print the guide without a pager")
+
+	return cmd
+}
+
+func isCommand(root *cobra.Command, args []string) bool {
+	found, _, err := root.Find(args)
+	return err == nil && found != nil && found != root
+}
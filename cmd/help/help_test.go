@@ -0,0 +1,138 @@
+package help_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/TristanShz/flow/cmd/help"
+	"github.com/TristanShz/flow/test"
+	"github.com/matryer/is"
+	"github.com/spf13/cobra"
+)
+
+func newRootCommand() *cobra.Command {
+	root := &cobra.Command{Use: "flow"}
+
+	root.AddCommand(&cobra.Command{
+		Use:     "start [project]",
+		Short:   "Start a session",
+		Example: "start my-project +tag\nflow start other-project",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.Println("Session started for " + args[0])
+			return nil
+		},
+	})
+	root.AddCommand(&cobra.Command{
+		Use:   "stop",
+		Short: "Stop the session",
+		Run: func(cmd *cobra.Command, _ []string) {
+			cmd.Println("Session stopped")
+		},
+	})
+
+	root.SetHelpCommand(help.Command(root))
+	help.AddExamplesFlag(root)
+
+	return root
+}
+
+func TestHelpCommand(t *testing.T) {
+	tt := []struct {
+		error        string
+		name         string
+		args         []string
+		wantContains []string
+	}{
+		{
+			name:         "Guide",
+			args:         []string{"help", "storage"},
+			wantContains: []string{"Storage layout", "    v3.k3x7a2q.bXktcHJvamVjdA.1713380400.json"},
+		},
+		{
+			name:         "Command help",
+			args:         []string{"help", "start"},
+			wantContains: []string{"Start a session", "flow start [project]"},
+		},
+		{
+			name:         "Topics listed with the root help",
+			args:         []string{"help"},
+			wantContains: []string{"Guides:", "tracking   Tracking model", "billing    Billing"},
+		},
+		{
+			name:  "Unknown topic",
+			args:  []string{"help", "nope"},
+			error: `unknown help topic "nope". available topics: billing, storage, sync, tracking`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			got, err := test.ExecuteCmd(t, newRootCommand(), tc.args...)
+
+			if tc.error != "" {
+				is.Equal(err.Error(), tc.error)
+				return
+			}
+
+			is.NoErr(err)
+			for _, want := range tc.wantContains {
+				is.True(strings.Contains(got, want)) // output contains the expected text
+			}
+		})
+	}
+}
+
+func TestExamplesFlag(t *testing.T) {
+	tt := []struct {
+		name string
+		want string
+		args []string
+	}{
+		{
+			name: "Examples",
+			args: []string{"start", "--examples"},
+			want: "flow start my-project +tag\nflow start other-project",
+		},
+		{
+			name: "No examples",
+			args: []string{"stop", "--examples"},
+			want: "No examples for 'flow stop'",
+		},
+		{
+			name: "Without the flag",
+			args: []string{"start", "my-project"},
+			want: "Session started for my-project",
+		},
+		{
+			name: "Run without the flag",
+			args: []string{"stop"},
+			want: "Session stopped",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			got, err := test.ExecuteCmd(t, newRootCommand(), tc.args...)
+
+			is.NoErr(err)
+			is.Equal(got, tc.want)
+		})
+	}
+}
+
+func TestGuides(t *testing.T) {
+	is := is.New(t)
+
+	is.Equal(help.Topics(), []string{"billing", "storage", "sync", "tracking"})
+
+	for _, topic := range help.Topics() {
+		guide, err := help.Guide(topic)
+		is.NoErr(err)
+		is.True(strings.HasPrefix(guide, "# ")) // guides start with their title
+	}
+}
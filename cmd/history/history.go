@@ -0,0 +1,24 @@
+package history
+
+import (
+	"time"
+
+	app "github.com/TristanShz/flow/internal/application/usecases"
+	"github.com/spf13/cobra"
+)
+
+// AddFlag adds the --unlock-history flag to a command changing sessions
+func AddFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool("unlock-history", false, "Allow changing the sessions of the read-only months, see history_lock_months in the configuration")
+}
+
+// LockedBefore returns the start of the first month whose sessions the
+// command can change, the zero time when --unlock-history is given
+func LockedBefore(cmd *cobra.Command, app *app.App) time.Time {
+	unlockFlag, _ := cmd.Flags().GetBool("unlock-history")
+	if unlockFlag {
+		return time.Time{}
+	}
+
+	return app.Config.LockedBefore(app.DateProvider.GetNow())
+}
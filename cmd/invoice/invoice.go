@@ -0,0 +1,164 @@
+package invoice
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/TristanShz/flow/cmd/completion"
+	app "github.com/TristanShz/flow/internal/application/usecases"
+	"github.com/TristanShz/flow/internal/application/usecases/invoice/createinvoices"
+	"github.com/TristanShz/flow/internal/domain/invoice"
+	"github.com/TristanShz/flow/internal/infra/exporter"
+	"github.com/TristanShz/flow/pkg/timerange"
+	"github.com/TristanShz/flow/utils"
+	"github.com/spf13/cobra"
+)
+
+func parseDateFlag(cmd *cobra.Command, name string) (time.Time, error) {
+	flag, _ := cmd.Flags().GetString(name)
+	if flag == "" {
+		return time.Time{}, nil
+	}
+
+	parsedTime, err := time.Parse("2006-01-02", flag)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%v is not a valid time format", flag)
+	}
+
+	return parsedTime, nil
+}
+
+// fileName names the file of the invoice after its number and its client,
+// like INV-2024-005-acme-corp.pdf
+func fileName(i invoice.Invoice, format string) string {
+	client := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return '-'
+	}, i.Client.Name)
+
+	return i.Number + "-" + client + exporter.InvoiceExtension(format)
+}
+
+func writeInvoice(i invoice.Invoice, format string, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := exporter.RenderInvoice(file, format, i); err != nil {
+		return err
+	}
+
+	return file.Close()
+}
+
+func Command(app *app.App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "invoice",
+		Example: "invoice --range last-month\ninvoice --client Acme --range last-month --format pdf --out-dir ~/invoices\ninvoice --range last-month --draft",
+		Short:   "Invoice the billable sessions of the clients",
+		Long:    "Write an invoice for each client having billable sessions not invoiced yet, with a line for each project and hourly rate. The invoices are numbered by year and kept in the flow folder, so that their sessions aren't invoiced twice. The issuer, the currency and the numbering come from the [invoice] table of the config file, and the durations are billed with the billing profiles of the projects and clients, or rounded with its [rounding] table.",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			logger := log.New(cmd.OutOrStdout(), "", 0)
+
+			formatFlag, _ := cmd.Flags().GetString("format")
+			if !slices.Contains(exporter.InvoiceFormats, formatFlag) {
+				return fmt.Errorf("invalid invoice format %v. possible values: %v", formatFlag, exporter.InvoiceFormats)
+			}
+
+			clientFlag, _ := cmd.Flags().GetString("client")
+			draftFlag, _ := cmd.Flags().GetBool("draft")
+			command := createinvoices.Command{
+				Client:    clientFlag,
+				Invoicing: app.Config.Invoicing,
+				Draft:     draftFlag,
+			}
+
+			noRoundingFlag, _ := cmd.Flags().GetBool("no-rounding")
+			if !noRoundingFlag {
+				command.Billing = app.Config.BillingRules()
+			}
+
+			rangeFlag, _ := cmd.Flags().GetString("range")
+			if rangeFlag != "" {
+				timeRange, err := timerange.Parse(rangeFlag, app.DateProvider.GetNow())
+				if err != nil {
+					return err
+				}
+
+				command.Since = timeRange.Since
+				command.Until = timeRange.Until
+			}
+
+			since, err := parseDateFlag(cmd, "since")
+			if err != nil {
+				return err
+			}
+			if !since.IsZero() {
+				command.Since = since
+			}
+
+			until, err := parseDateFlag(cmd, "until")
+			if err != nil {
+				return err
+			}
+			if !until.IsZero() {
+				command.Until = until
+			}
+
+			// the invoices are numbered before they're written, a missing
+			// directory would skip numbers
+			outDirFlag, _ := cmd.Flags().GetString("out-dir")
+			if info, err := os.Stat(outDirFlag); err != nil || !info.IsDir() {
+				return fmt.Errorf("the directory %v doesn't exist", outDirFlag)
+			}
+
+			result, err := app.CreateInvoicesUseCase.Execute(command)
+			if errors.Is(err, createinvoices.ErrNothingToInvoice) {
+				logger.Println("No billable session left to invoice")
+			} else if err != nil {
+				return err
+			}
+
+			for _, i := range result.Invoices {
+				path := filepath.Join(outDirFlag, fileName(i, formatFlag))
+				if err := writeInvoice(i, formatFlag, path); err != nil {
+					return err
+				}
+
+				logger.Printf("Invoice %v for %v: %v, %v, written to %v", i.Number, i.Client.Name, utils.TimeColor(i.Duration.String()), strings.TrimSpace(fmt.Sprintf("%.2f %v", i.Total, i.Currency)), path)
+			}
+
+			if result.WithoutClient > 0 && clientFlag == "" {
+				logger.Printf("Warning: %v billable session(s) billed to no client weren't invoiced, see 'flow projects set [project] --client'", result.WithoutClient)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("client", "c", "", "Only invoice the sessions billed to the given client")
+	cmd.Flags().StringP("range", "r", "", "Only invoice the sessions of a range like last-month, 2024-04 or -30d")
+	cmd.Flags().StringP("since", "s", "", "Only invoice the sessions since the given date")
+	cmd.Flags().StringP("until", "u", "", "Only invoice the sessions until the given date")
+	cmd.Flags().StringP("format", "f", exporter.InvoiceFormatMarkdown, fmt.Sprintf("Format of the invoices. Possible values: %v", exporter.InvoiceFormats))
+	cmd.Flags().StringP("out-dir", "O", ".", "Directory the invoices are written to")
+	cmd.Flags().Bool("draft", false, "Write the invoices without numbering them, their sessions can still be invoiced")
+	cmd.Flags().Bool("no-rounding", false, "Invoice the tracked durations, without the rounding nor the billing profiles of the config file")
+
+	cmd.RegisterFlagCompletionFunc("range", completion.Ranges(app))
+	cmd.RegisterFlagCompletionFunc("since", completion.Dates(app))
+	cmd.RegisterFlagCompletionFunc("until", completion.Dates(app))
+
+	return cmd
+}
@@ -0,0 +1,82 @@
+package invoice_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/cmd/invoice"
+	"github.com/TristanShz/flow/internal/application/usecases/project/setproject"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/TristanShz/flow/test"
+	"github.com/matryer/is"
+)
+
+func TestInvoiceCommand(t *testing.T) {
+	is := is.New(t)
+	outDir := t.TempDir()
+
+	sessionRepository := &infra.InMemorySessionRepository{Sessions: []session.Session{
+		{
+			Id:        "1",
+			StartTime: time.Date(2024, time.April, 15, 9, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, time.April, 15, 10, 50, 0, 0, time.UTC),
+			Project:   "website",
+		},
+		{
+			Id:        "2",
+			StartTime: time.Date(2024, time.April, 16, 9, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, time.April, 16, 10, 0, 0, 0, time.UTC),
+			Project:   "side-project",
+		},
+	}}
+	dateProvider := infra.NewStubDateProvider()
+	dateProvider.Now = time.Date(2024, time.May, 2, 12, 0, 0, 0, time.UTC)
+	app := test.InitializeApp(sessionRepository, dateProvider)
+	app.Config.Invoicing.Currency = "EUR"
+	app.Config.Invoicing.Prefix = "INV-"
+	app.Config.Rounding = session.Rounding{Increment: 15 * time.Minute, Method: session.RoundUp}
+
+	client := "Acme Corp"
+	billable := true
+	rate := 60.0
+	_, err := app.SetProjectUseCase.Execute(setproject.Command{Name: "website", Client: &client, Billable: &billable, HourlyRate: &rate})
+	is.NoErr(err)
+	_, err = app.SetProjectUseCase.Execute(setproject.Command{Name: "side-project", Billable: &billable, HourlyRate: &rate})
+	is.NoErr(err)
+
+	got, err := test.ExecuteCmd(t, invoice.Command(app), "--range", "2024-04", "--out-dir", outDir, "--draft")
+
+	is.NoErr(err)
+	draftPath := filepath.Join(outDir, "DRAFT-acme-corp.md")
+	is.Equal(got, "Invoice DRAFT for Acme Corp: 2h0m0s, 120.00 EUR, written to "+draftPath+"\nWarning: 1 billable session(s) billed to no client weren't invoiced, see 'flow projects set [project] --client'")
+
+	got, err = test.ExecuteCmd(t, invoice.Command(app), "--range", "2024-04", "--out-dir", outDir, "--format", "pdf", "--client", "Acme Corp")
+
+	is.NoErr(err)
+	path := filepath.Join(outDir, "INV-2024-001-acme-corp.pdf")
+	is.Equal(got, "Invoice INV-2024-001 for Acme Corp: 2h0m0s, 120.00 EUR, written to "+path)
+	written, err := os.ReadFile(path)
+	is.NoErr(err)
+	is.True(strings.HasPrefix(string(written), "%PDF-"))
+
+	got, err = test.ExecuteCmd(t, invoice.Command(app), "--range", "2024-04", "--out-dir", outDir, "--client", "Acme Corp")
+
+	is.NoErr(err)
+	is.Equal(got, "No billable session left to invoice")
+}
+
+func TestInvoiceCommand_Errors(t *testing.T) {
+	is := is.New(t)
+	app := test.InitializeApp(&infra.InMemorySessionRepository{}, infra.NewStubDateProvider())
+
+	_, err := test.ExecuteCmd(t, invoice.Command(app), "--format", "docx")
+	is.Equal(err.Error(), "invalid invoice format docx. possible values: [markdown html pdf]")
+
+	missing := filepath.Join(t.TempDir(), "missing")
+	_, err = test.ExecuteCmd(t, invoice.Command(app), "--out-dir", missing)
+	is.Equal(err.Error(), "the directory "+missing+" doesn't exist")
+}
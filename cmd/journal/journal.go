@@ -0,0 +1,98 @@
+package journal
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/TristanShz/flow/cmd/clipboard"
+	"github.com/TristanShz/flow/cmd/completion"
+	"github.com/TristanShz/flow/internal/application"
+	app "github.com/TristanShz/flow/internal/application/usecases"
+	"github.com/TristanShz/flow/internal/application/usecases/journal/addjournalentry"
+	"github.com/TristanShz/flow/internal/application/usecases/journal/listjournal"
+	"github.com/TristanShz/flow/pkg/timerange"
+	"github.com/TristanShz/flow/utils"
+	"github.com/spf13/cobra"
+)
+
+const defaultRange = "-7d"
+
+// Command adds a note to the journal, or lists the notes and copies them to
+// the clipboard with --copy
+func Command(app *app.App, systemClipboard application.Clipboard) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "journal [note]",
+		Example: "journal \"Demo went well, the client wants the export next week\"\njournal --date 2024-04-16 \"Blocked by the API outage all afternoon\"\njournal\njournal --range last-week",
+		Short:   "Write or read the notes of the days",
+		Long:    "Write a note about the day, which isn't tied to a session, or list the notes of a period, the last 7 days by default. The notes are shown in the reports by day and in the HTML exports.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out, copied := clipboard.Output(cmd)
+			logger := log.New(out, "", 0)
+
+			if len(args) > 0 {
+				command := addjournalentry.Command{Note: strings.Join(args, " ")}
+
+				dateFlag, _ := cmd.Flags().GetString("date")
+				if dateFlag != "" {
+					day, err := time.ParseInLocation(time.DateOnly, dateFlag, time.Local)
+					if err != nil {
+						return fmt.Errorf("%v is not a valid date, expected YYYY-MM-DD", dateFlag)
+					}
+					command.Day = day
+				}
+
+				entry, err := app.AddJournalEntryUseCase.Execute(command)
+				if err != nil {
+					return err
+				}
+
+				logger.Printf("Note added to the journal of %v", entry.Day.Format("Mon, 02 Jan 2006"))
+				return nil
+			}
+
+			rangeFlag, _ := cmd.Flags().GetString("range")
+			timeRange, err := timerange.Parse(rangeFlag, app.DateProvider.GetNow())
+			if err != nil {
+				return err
+			}
+
+			entries, err := app.ListJournalUseCase.Execute(listjournal.Command{
+				Since: timeRange.Since,
+				Until: timeRange.Until,
+			})
+			if err != nil {
+				return err
+			}
+
+			if len(entries) == 0 {
+				logger.Println("No journal notes found")
+				return nil
+			}
+
+			text := ""
+			for i, entry := range entries {
+				if i == 0 || !entries[i-1].SameDay(entry.Day) {
+					if i > 0 {
+						text += "\n"
+					}
+					text += utils.HeaderStyle.Render(entry.Day.Format("Mon, 02 Jan 2006")) + "\n"
+				}
+				text += "    " + entry.Note + "\n"
+			}
+
+			logger.Print(text)
+
+			return clipboard.Copy(cmd, systemClipboard, copied)
+		},
+	}
+
+	cmd.Flags().StringP("date", "d", "", "Add the note to another day than today, as YYYY-MM-DD")
+	clipboard.AddFlag(cmd)
+	cmd.Flags().StringP("range", "r", defaultRange, "List the notes of a range like today, last-week, 2024-04, -7d or \"since monday\"")
+
+	cmd.RegisterFlagCompletionFunc("range", completion.Ranges(app))
+
+	return cmd
+}
@@ -0,0 +1,81 @@
+package journal_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/cmd/journal"
+	"github.com/TristanShz/flow/internal/application/usecases/journal/addjournalentry"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/TristanShz/flow/test"
+	"github.com/matryer/is"
+)
+
+func TestJournalCommand(t *testing.T) {
+	tt := []struct {
+		error error
+		name  string
+		want  string
+		notes [][]string
+		args  []string
+	}{
+		{
+			name: "Add a note to today",
+			args: []string{"Demo", "went", "well"},
+			want: "Note added to the journal of Wed, 17 Apr 2024",
+		},
+		{
+			name: "Add a note to another day",
+			args: []string{"--date", "2024-04-15", "Blocked by the API outage"},
+			want: "Note added to the journal of Mon, 15 Apr 2024",
+		},
+		{
+			name:  "Add a note to a day in the future",
+			args:  []string{"--date", "2024-04-20", "Holidays"},
+			error: addjournalentry.ErrFutureDay,
+		},
+		{
+			name:  "Add an empty note",
+			args:  []string{" "},
+			error: addjournalentry.ErrEmptyNote,
+		},
+		{
+			name: "List the notes of the last 7 days",
+			notes: [][]string{
+				{"--date", "2024-04-01", "Too old to be listed"},
+				{"--date", "2024-04-15", "Blocked by the API outage"},
+				{"Demo went well"},
+				{"The client wants the export next week"},
+			},
+			want: "Mon, 15 Apr 2024\n    Blocked by the API outage\n\nWed, 17 Apr 2024\n    Demo went well\n    The client wants the export next week",
+		},
+		{
+			name: "No notes",
+			want: "No journal notes found",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			dateProvider := infra.NewStubDateProvider()
+			dateProvider.Now = time.Date(2024, time.April, 17, 12, 0, 0, 0, time.UTC)
+			app := test.InitializeApp(&infra.InMemorySessionRepository{}, dateProvider)
+
+			for _, note := range tc.notes {
+				_, err := test.ExecuteCmd(t, journal.Command(app, &infra.InMemoryClipboard{}), note...)
+				is.NoErr(err)
+			}
+
+			got, err := test.ExecuteCmd(t, journal.Command(app, &infra.InMemoryClipboard{}), tc.args...)
+
+			if tc.error != nil {
+				is.Equal(err, tc.error)
+			} else {
+				is.NoErr(err)
+				is.Equal(got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package merge
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/TristanShz/flow/cmd/completion"
+	"github.com/TristanShz/flow/cmd/history"
+	app "github.com/TristanShz/flow/internal/application/usecases"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/mergesessions"
+	"github.com/TristanShz/flow/utils"
+	"github.com/spf13/cobra"
+)
+
+func Command(app *app.App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "merge [session_id] [session_id...]",
+		Example:           "merge abc1234 def5678",
+		Short:             "Merge adjacent flow sessions of the same project",
+		Long:              "Merge adjacent flow sessions of the same project into the first one, which gets the tags and notes of all of them",
+		ValidArgsFunction: completion.Sessions(app, 0),
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 2 {
+				return errors.New("at least two session ids are required")
+			}
+			for _, arg := range args {
+				if !utils.IsIDValid(arg) {
+					return fmt.Errorf("invalid ID %v", arg)
+				}
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logger := log.New(cmd.OutOrStdout(), "", 0)
+
+			merged, err := app.MergeSessionsUseCase.Execute(mergesessions.Command{
+				Ids:          args,
+				LockedBefore: history.LockedBefore(cmd, app),
+			})
+			if err != nil {
+				return err
+			}
+
+			logger.Printf(
+				"Sessions merged in %v %v - %v (%v)",
+				merged.Id,
+				merged.GetFormattedStartTime(),
+				merged.GetFormattedEndTime(),
+				utils.TimeColor(merged.Duration().String()),
+			)
+
+			return nil
+		},
+	}
+
+	history.AddFlag(cmd)
+
+	return cmd
+}
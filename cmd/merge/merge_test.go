@@ -0,0 +1,74 @@
+package merge_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/cmd/merge"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/mergesessions"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/TristanShz/flow/test"
+	"github.com/matryer/is"
+)
+
+func TestMergeCommand(t *testing.T) {
+	tt := []struct {
+		error error
+		name  string
+		want  string
+		args  []string
+	}{
+		{
+			name: "Adjacent sessions",
+			args: []string{"1234567", "abcdefg"},
+			want: "Sessions merged in 1234567 2024-04-13 09:00:00 - 2024-04-13 12:00:00 (3h0m0s)",
+		},
+		{
+			name:  "Single session",
+			args:  []string{"1234567"},
+			error: errors.New("at least two session ids are required"),
+		},
+		{
+			name:  "Invalid id",
+			args:  []string{"1234567", "abc"},
+			error: errors.New("invalid ID abc"),
+		},
+		{
+			name:  "Session not found",
+			args:  []string{"1234567", "7654321"},
+			error: mergesessions.ErrSessionNotFound,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			sessionRepository := &infra.InMemorySessionRepository{Sessions: []session.Session{
+				{
+					Id:        "1234567",
+					StartTime: time.Date(2024, time.April, 13, 9, 0, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.April, 13, 10, 0, 0, 0, time.UTC),
+					Project:   "Flow",
+				},
+				{
+					Id:        "abcdefg",
+					StartTime: time.Date(2024, time.April, 13, 10, 30, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.April, 13, 12, 0, 0, 0, time.UTC),
+					Project:   "Flow",
+				},
+			}}
+			app := test.InitializeApp(sessionRepository, infra.NewStubDateProvider())
+
+			got, err := test.ExecuteCmd(t, merge.Command(app), tc.args...)
+
+			is.Equal(tc.error, err)
+
+			if tc.error == nil {
+				is.Equal(got, tc.want)
+			}
+		})
+	}
+}
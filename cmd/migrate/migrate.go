@@ -0,0 +1,51 @@
+package migrate
+
+import (
+	"fmt"
+	"log"
+
+	app "github.com/TristanShz/flow/internal/application/usecases"
+	storemigrate "github.com/TristanShz/flow/internal/application/usecases/store/migrate"
+	"github.com/spf13/cobra"
+)
+
+func Command(app *app.App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "migrate",
+		Example: "migrate --dry-run\nmigrate",
+		Short:   "Rewrite session files with the current filename scheme and format",
+		Long:    "Rename the session files created by older versions of flow with the current filename scheme, which keeps hyphens and special characters of project names and ids, and with the current format of the session files",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			logger := log.New(cmd.OutOrStdout(), "", 0)
+
+			dryRunFlag, _ := cmd.Flags().GetBool("dry-run")
+
+			report, err := app.MigrateUseCase.Execute(storemigrate.Command{DryRun: dryRunFlag})
+			if err != nil {
+				return err
+			}
+
+			if len(report.LegacyFiles) == 0 {
+				logger.Println("Session files are up to date")
+				return nil
+			}
+
+			if dryRunFlag {
+				text := fmt.Sprintf("%v session file(s) to migrate\n", len(report.LegacyFiles))
+				for _, legacyFile := range report.LegacyFiles {
+					text += fmt.Sprintf("    %v\n", legacyFile)
+				}
+				logger.Print(text)
+				return nil
+			}
+
+			logger.Printf("%v session file(s) migrated\n", len(report.Migrated))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool("dry-run", false, "List the session files to migrate without rewriting them")
+
+	return cmd
+}
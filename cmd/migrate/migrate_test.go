@@ -0,0 +1,53 @@
+package migrate_test
+
+import (
+	"testing"
+
+	"github.com/TristanShz/flow/cmd/migrate"
+	storemigrate "github.com/TristanShz/flow/internal/application/usecases/store/migrate"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/TristanShz/flow/test"
+	"github.com/matryer/is"
+)
+
+func TestMigrateCommand(t *testing.T) {
+	sessionRepository := &infra.InMemorySessionRepository{}
+	dateProvider := infra.NewStubDateProvider()
+	app := test.InitializeApp(sessionRepository, dateProvider)
+
+	tt := []struct {
+		name             string
+		args             []string
+		givenLegacyFiles []string
+		want             string
+	}{
+		{
+			name: "Up to date",
+			want: "Session files are up to date",
+		},
+		{
+			name:             "Dry run",
+			args:             []string{"--dry-run"},
+			givenLegacyFiles: []string{"1-Flow-1713380400.json", "2-myproject-1713387600.json"},
+			want:             "2 session file(s) to migrate\n    1-Flow-1713380400.json\n    2-myproject-1713387600.json",
+		},
+		{
+			name:             "Migrate",
+			givenLegacyFiles: []string{"1-Flow-1713380400.json", "2-myproject-1713387600.json"},
+			want:             "2 session file(s) migrated",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			app.MigrateUseCase = storemigrate.NewMigrateUseCase(&infra.StubSessionFilesMigrator{LegacyFiles: tc.givenLegacyFiles})
+
+			got, err := test.ExecuteCmd(t, migrate.Command(app), tc.args...)
+
+			is.NoErr(err)
+			is.Equal(tc.want, got)
+		})
+	}
+}
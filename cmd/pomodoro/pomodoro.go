@@ -0,0 +1,163 @@
+package pomodoro
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/TristanShz/flow/cmd/completion"
+	"github.com/TristanShz/flow/internal/application"
+	app "github.com/TristanShz/flow/internal/application/usecases"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/pomodoro"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/stopsession"
+	"github.com/TristanShz/flow/utils"
+	"github.com/spf13/cobra"
+)
+
+// settings returns the lengths of the config, overridden by the flags
+func settings(cmd *cobra.Command, app *app.App) application.Pomodoro {
+	settings := app.Config.Pomodoro
+
+	if cmd.Flags().Changed("work") {
+		settings.Work, _ = cmd.Flags().GetDuration("work")
+	}
+	if cmd.Flags().Changed("break") {
+		settings.ShortBreak, _ = cmd.Flags().GetDuration("break")
+	}
+	if cmd.Flags().Changed("long-break") {
+		settings.LongBreak, _ = cmd.Flags().GetDuration("long-break")
+	}
+
+	return settings
+}
+
+// Command runs the pomodoro mode until it's interrupted, or until the given
+// count of pomodoros is done, and notifies the end of each interval
+func Command(app *app.App, notifier application.Notifier) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "pomodoro [project] [+tag1 +tag2...]",
+		Example:           "pomodoro my-todo +deep\npomodoro my-todo --work 50m --break 10m --count 4",
+		Short:             "Work in pomodoros, intervals of work separated by breaks",
+		Long:              "Track the work intervals of the pomodoro technique as sessions of the project, separated by short breaks and a long break every 4 pomodoros. A desktop notification is shown at the end of each interval. The lengths come from the [pomodoro] table of the config, or the flags. Interrupting the command stops the current work interval, which then isn't counted as a pomodoro in the reports.",
+		ValidArgsFunction: completion.ProjectAndTags(app, func() string { return "" }),
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 || strings.HasPrefix(args[0], "+") {
+				return errors.New("the first argument must be the project name")
+			}
+
+			for _, arg := range args[1:] {
+				if !strings.HasPrefix(arg, "+") {
+					return fmt.Errorf("invalid tag %v (must start with '+')", arg)
+				}
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logger := log.New(cmd.OutOrStdout(), "", 0)
+
+			command := pomodoro.Command{
+				Project:  args[0],
+				Settings: settings(cmd, app),
+				TagRules: app.Config.TagRules,
+			}
+			for _, tag := range args[1:] {
+				command.Tags = append(command.Tags, strings.TrimPrefix(tag, "+"))
+			}
+			if len(command.Tags) == 0 {
+				command.Tags = app.Config.DefaultTags
+			}
+
+			for _, length := range []time.Duration{command.Settings.Work, command.Settings.ShortBreak, command.Settings.LongBreak} {
+				if length < 0 {
+					return errors.New("the lengths of the intervals must be positive")
+				}
+			}
+
+			countFlag, _ := cmd.Flags().GetInt("count")
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			notificationFailed := false
+			notify := func(title string, message string) {
+				if app.Config.Notifications.MutePomodoro {
+					return
+				}
+
+				err := notifier.Notify(application.Notification{Title: title, Message: message})
+				if err != nil && !notificationFailed {
+					notificationFailed = true
+					logger.Printf("Warning: the notifications can't be shown: %v", err)
+				}
+			}
+
+			done := 0
+			for {
+				interval, err := app.PomodoroUseCase.Execute(command)
+				if err != nil {
+					return err
+				}
+
+				ends := interval.Ends.Format(time.Kitchen)
+				if interval.Kind == pomodoro.IntervalWork {
+					logger.Printf("Pomodoro #%v of %v until %v", interval.Number, utils.ProjectColor(command.Project), utils.TimeColor(ends))
+				} else {
+					done++
+					breakKind := "short break"
+					if interval.LongBreak {
+						breakKind = "long break"
+					}
+
+					logger.Printf("Pomodoro #%v done, %v until %v", interval.Number, breakKind, utils.TimeColor(ends))
+					notify(fmt.Sprintf("Pomodoro #%v done", interval.Number), fmt.Sprintf("Take a %v until %v", breakKind, ends))
+
+					if countFlag > 0 && done >= countFlag {
+						logger.Printf("%v pomodoros done", done)
+						return nil
+					}
+				}
+
+				timer := time.NewTimer(interval.Ends.Sub(app.DateProvider.GetNow()))
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return interrupted(app, logger, interval)
+				case <-timer.C:
+				}
+
+				if interval.Kind == pomodoro.IntervalBreak {
+					notify("Back to work", fmt.Sprintf("Pomodoro #%v of %v", interval.Number+1, command.Project))
+				}
+			}
+		},
+	}
+
+	cmd.Flags().Duration("work", 0, "Length of the work intervals, 25m by default")
+	cmd.Flags().Duration("break", 0, "Length of the short breaks, 5m by default")
+	cmd.Flags().Duration("long-break", 0, "Length of the long breaks, 15m by default")
+	cmd.Flags().IntP("count", "n", 0, "Number of pomodoros to do, until interrupted by default")
+
+	return cmd
+}
+
+// interrupted stops the work interval in progress
+func interrupted(app *app.App, logger *log.Logger, interval pomodoro.Interval) error {
+	if interval.Kind == pomodoro.IntervalBreak {
+		logger.Println("Pomodoro mode stopped during the break")
+		return nil
+	}
+
+	duration, err := app.StopFlowSessionUseCase.Execute(stopsession.Command{TagRules: app.Config.TagRules})
+	if err != nil && err != stopsession.ErrClockWentBackwards {
+		return err
+	}
+
+	logger.Printf("Pomodoro #%v interrupted, the session was stopped after %v", interval.Number, utils.TimeColor(duration.Round(time.Second).String()))
+	return nil
+}
@@ -0,0 +1,73 @@
+package pomodoro_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/cmd/pomodoro"
+	"github.com/TristanShz/flow/internal/application"
+	pomodorosession "github.com/TristanShz/flow/internal/application/usecases/flowsession/pomodoro"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/TristanShz/flow/test"
+	"github.com/matryer/is"
+)
+
+func TestPomodoroCommand(t *testing.T) {
+	is := is.New(t)
+
+	sessionRepository := &infra.InMemorySessionRepository{}
+	app := test.InitializeApp(sessionRepository, &infra.RealDateProvider{})
+	notifier := &infra.InMemoryNotifier{}
+
+	got, err := test.ExecuteCmd(t, pomodoro.Command(app, notifier), "Flow", "+deep", "--work", "20ms", "--break", "20ms", "--count", "2")
+
+	is.NoErr(err)
+	is.True(strings.Contains(got, "Pomodoro #1 done, short break until")) // the break follows the work interval
+	is.True(strings.HasSuffix(got, "2 pomodoros done"))                   // stops after the count
+	last, err := sessionRepository.FindLastSession()
+	is.NoErr(err)
+	is.True(last.IsCompletedPomodoro()) // the work interval lasted its length
+	is.Equal(last.Tags, []string{"deep"})
+	is.Equal(notifier.Notifications[0].Title, "Pomodoro #1 done")
+	is.Equal(notifier.Notifications[1].Title, "Back to work")
+	is.Equal(len(notifier.Notifications), 3)
+}
+
+func TestPomodoroCommand_NotificationsUnsupported(t *testing.T) {
+	is := is.New(t)
+
+	app := test.InitializeApp(&infra.InMemorySessionRepository{}, &infra.RealDateProvider{})
+	notifier := &infra.InMemoryNotifier{Err: application.ErrNotificationsUnsupported}
+
+	got, err := test.ExecuteCmd(t, pomodoro.Command(app, notifier), "Flow", "--work", "10ms", "--count", "1")
+
+	is.NoErr(err)
+	is.True(strings.Contains(got, "Warning: the notifications can't be shown: "+application.ErrNotificationsUnsupported.Error()))
+}
+
+func TestPomodoroCommand_NotificationsMuted(t *testing.T) {
+	is := is.New(t)
+
+	app := test.InitializeApp(&infra.InMemorySessionRepository{}, &infra.RealDateProvider{})
+	app.Config.Notifications.MutePomodoro = true
+	notifier := &infra.InMemoryNotifier{}
+
+	_, err := test.ExecuteCmd(t, pomodoro.Command(app, notifier), "Flow", "--work", "10ms", "--count", "1")
+
+	is.NoErr(err)
+	is.Equal(len(notifier.Notifications), 0)
+}
+
+func TestPomodoroCommand_SessionInProgress(t *testing.T) {
+	is := is.New(t)
+
+	sessionRepository := &infra.InMemorySessionRepository{Sessions: []session.Session{{Id: "1", StartTime: time.Now(), Project: "Flow"}}}
+	app := test.InitializeApp(sessionRepository, &infra.RealDateProvider{})
+
+	_, err := test.ExecuteCmd(t, pomodoro.Command(app, &infra.InMemoryNotifier{}), "Flow")
+
+	is.True(errors.Is(err, pomodorosession.ErrSessionAlreadyStarted))
+}
@@ -0,0 +1,255 @@
+package projects
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/TristanShz/flow/cmd/clipboard"
+	"github.com/TristanShz/flow/internal/application"
+	app "github.com/TristanShz/flow/internal/application/usecases"
+	"github.com/TristanShz/flow/internal/application/usecases/project/renameproject"
+	"github.com/TristanShz/flow/internal/application/usecases/project/setproject"
+	"github.com/TristanShz/flow/internal/domain/project"
+	"github.com/TristanShz/flow/internal/infra/presenter"
+	"github.com/spf13/cobra"
+)
+
+// Command lists the projects, and copies the list to the clipboard with --copy
+func Command(app *app.App, systemClipboard application.Clipboard) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "projects",
+		Example: "projects\nprojects --output json\nprojects --porcelain",
+		Short:   "List all the projects",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			out, copied := clipboard.Output(cmd)
+			logger := log.New(out, "", 0)
+
+			outputFlag, _ := cmd.Flags().GetString("output")
+			if !presenter.IsOutputValid(outputFlag) {
+				return errors.New("invalid output flag. possible values: text, json, plain")
+			}
+
+			porcelainFlag, _ := cmd.Flags().GetBool("porcelain")
+			output, err := presenter.ResolveOutput(cmd.OutOrStdout(), outputFlag, cmd.Flags().Changed("output"), porcelainFlag)
+			if err != nil {
+				return err
+			}
+
+			var projectsPresenter application.ProjectsPresenter = presenter.ProjectsCLIPresenter{Logger: logger}
+			switch output {
+			case presenter.OutputJSON:
+				projectsPresenter = presenter.ProjectsJSONPresenter{Logger: logger}
+			case presenter.OutputPlain:
+				projectsPresenter = presenter.ProjectsPlainPresenter{Logger: logger}
+			}
+
+			projects, err := app.ListProjectsUseCase.Execute()
+			if err != nil {
+				return err
+			}
+
+			projectsPresenter.ShowProjects(projects)
+
+			return clipboard.Copy(cmd, systemClipboard, copied)
+		},
+	}
+
+	cmd.Flags().StringP("output", "o", presenter.DefaultOutput(app.Config.Output), "Output format. Possible values: text, json, plain")
+	cmd.Flags().Bool("porcelain", false, "Print the plain output, whose format never changes, for scripts")
+	clipboard.AddFlag(cmd)
+
+	cmd.AddCommand(setCommand(app))
+	cmd.AddCommand(renameCommand(app))
+
+	return cmd
+}
+
+func setCommand(app *app.App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "set [project]",
+		Example: "projects set my-project --on-lock pause\nprojects set my-project --client acme --billable --rate 80\nprojects set my-project --billing-profile retainer\nprojects set my-project --on-meeting switch --meeting-project standups\nprojects set my-project --break-every 2h --break-duration 10m\nprojects set my-project --weekly-goal 10h --monthly-goal 40h",
+		Short:   "Update the settings of a project",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("the project name is required")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logger := log.New(cmd.OutOrStdout(), "", 0)
+
+			command := setproject.Command{Name: args[0]}
+
+			if cmd.Flags().Changed("on-lock") {
+				onLock, _ := cmd.Flags().GetString("on-lock")
+				command.OnLock = &onLock
+			}
+
+			if cmd.Flags().Changed("do-not-track") {
+				doNotTrack, _ := cmd.Flags().GetStringArray("do-not-track")
+				command.DoNotTrack = &doNotTrack
+			}
+
+			if cmd.Flags().Changed("on-do-not-track") {
+				onDoNotTrack, _ := cmd.Flags().GetString("on-do-not-track")
+				command.OnDoNotTrack = &onDoNotTrack
+			}
+
+			if cmd.Flags().Changed("on-meeting") {
+				onMeeting, _ := cmd.Flags().GetString("on-meeting")
+				command.OnMeeting = &onMeeting
+			}
+
+			if cmd.Flags().Changed("meeting-project") {
+				meetingProject, _ := cmd.Flags().GetString("meeting-project")
+				command.MeetingProject = &meetingProject
+			}
+
+			if cmd.Flags().Changed("client") {
+				client, _ := cmd.Flags().GetString("client")
+				command.Client = &client
+			}
+
+			if cmd.Flags().Changed("billable") {
+				billable, _ := cmd.Flags().GetBool("billable")
+				command.Billable = &billable
+			}
+
+			if cmd.Flags().Changed("rate") {
+				rate, _ := cmd.Flags().GetFloat64("rate")
+				command.HourlyRate = &rate
+			}
+
+			if cmd.Flags().Changed("billing-profile") {
+				billingProfile, _ := cmd.Flags().GetString("billing-profile")
+				if err := app.Config.CheckBillingProfile(billingProfile); err != nil {
+					return err
+				}
+				command.BillingProfile = &billingProfile
+			}
+
+			if cmd.Flags().Changed("break-every") {
+				breakEvery, _ := cmd.Flags().GetDuration("break-every")
+				command.BreakEvery = &breakEvery
+			}
+
+			if cmd.Flags().Changed("break-duration") {
+				breakDuration, _ := cmd.Flags().GetDuration("break-duration")
+				command.BreakDuration = &breakDuration
+			}
+
+			if cmd.Flags().Changed("weekly-goal") {
+				weeklyGoal, _ := cmd.Flags().GetDuration("weekly-goal")
+				command.WeeklyGoal = &weeklyGoal
+			}
+
+			if cmd.Flags().Changed("monthly-goal") {
+				monthlyGoal, _ := cmd.Flags().GetDuration("monthly-goal")
+				command.MonthlyGoal = &monthlyGoal
+			}
+
+			p, err := app.SetProjectUseCase.Execute(command)
+			if err != nil {
+				return err
+			}
+
+			lines := []string{
+				fmt.Sprintf("Project: %v", p.Name),
+				fmt.Sprintf("On lock: %v", p.OnLockAction()),
+			}
+			if len(p.DoNotTrack) > 0 {
+				windows := []string{}
+				for _, window := range p.DoNotTrack {
+					windows = append(windows, window.String())
+				}
+				lines = append(lines, fmt.Sprintf("Do not track: %v (%v)", strings.Join(windows, ", "), p.OnDoNotTrackAction()))
+			}
+			switch p.OnMeetingAction() {
+			case project.OnMeetingPause:
+				lines = append(lines, "On meeting: pause")
+			case project.OnMeetingSwitch:
+				lines = append(lines, fmt.Sprintf("On meeting: switch to %v", p.MeetingProjectName()))
+			}
+			if p.Client != "" {
+				lines = append(lines, fmt.Sprintf("Client: %v", p.Client))
+			}
+			if p.Billable {
+				lines = append(lines, fmt.Sprintf("Billable: %.2f/h", p.HourlyRate))
+			}
+			if p.BillingProfile != "" {
+				lines = append(lines, fmt.Sprintf("Billing profile: %v", p.BillingProfile))
+			}
+			if p.HasBreaks() {
+				lines = append(lines, fmt.Sprintf("Breaks: %v every %v", p.BreakDuration, p.BreakEvery))
+			}
+			if p.WeeklyGoal > 0 {
+				lines = append(lines, fmt.Sprintf("Weekly goal: %v", p.WeeklyGoal))
+			}
+			if p.MonthlyGoal > 0 {
+				lines = append(lines, fmt.Sprintf("Monthly goal: %v", p.MonthlyGoal))
+			}
+
+			logger.Println(strings.Join(lines, "\n"))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("on-lock", project.OnLockNone, "What to do with a session of the project when the screen is locked, see 'flow daemon'. Possible values: none, stop, pause")
+	cmd.Flags().String("on-meeting", project.OnMeetingNone, "What to do with a session of the project when a meeting of the calendar starts, see 'flow daemon'. Possible values: none, pause, switch")
+	cmd.Flags().String("meeting-project", "", "Project of the sessions tracking the meetings when --on-meeting is switch (default \"meetings\")")
+	cmd.Flags().StringArray("do-not-track", []string{}, "Time window when sessions of the project shouldn't be started, e.g. 'sat,sun' or 'mon-fri 22:00-07:00'. An empty value removes the windows")
+	cmd.Flags().String("on-do-not-track", project.DoNotTrackConfirm, "What to do when a session is started during a do-not-track window. Possible values: confirm, block")
+	cmd.Flags().String("client", "", "Client the project is billed to, an empty value removes it")
+	cmd.Flags().Bool("billable", false, "Whether the sessions of the project are billable, use --billable=false to stop billing them")
+	cmd.Flags().Float64("rate", 0, "Hourly rate of the billable sessions of the project")
+	cmd.Flags().String("billing-profile", "", "Billing profile of the config file applied to the sessions of the project in invoices and exports, an empty value removes it")
+	cmd.Flags().Duration("break-every", 0, "Time worked before a break is taken out of a session of the project when it's stopped, 0 removes the breaks")
+	cmd.Flags().Duration("break-duration", 0, "Duration of the breaks taken out of the sessions of the project")
+	cmd.Flags().Duration("weekly-goal", 0, "Time to spend on the project every week, see 'flow goals', 0 removes the goal")
+	cmd.Flags().Duration("monthly-goal", 0, "Time to spend on the project every month, see 'flow goals', 0 removes the goal")
+
+	return cmd
+}
+
+func renameCommand(app *app.App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "rename [project] [new-name]",
+		Example: "projects rename my-project my-new-project",
+		Short:   "Rename a project in all its sessions, or merge it into another project",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 2 {
+				return errors.New("the project name and its new name are required")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logger := log.New(cmd.OutOrStdout(), "", 0)
+
+			merge, _ := cmd.Flags().GetBool("merge")
+
+			updatedSessions, err := app.RenameProjectUseCase.Execute(renameproject.Command{
+				Name:    args[0],
+				NewName: args[1],
+				Merge:   merge,
+			})
+			if err != nil {
+				return err
+			}
+
+			action := "renamed to"
+			if merge {
+				action = "merged into"
+			}
+			logger.Printf("Project %v %v %v, %v session(s) updated", args[0], action, args[1], updatedSessions)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool("merge", false, "Merge the project into the project with the new name if it already exists")
+
+	return cmd
+}
@@ -0,0 +1,262 @@
+package projects_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/cmd/projects"
+	"github.com/TristanShz/flow/internal/application/usecases/project/renameproject"
+	"github.com/TristanShz/flow/internal/application/usecases/project/setproject"
+	"github.com/TristanShz/flow/internal/domain/billing"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/TristanShz/flow/test"
+	"github.com/matryer/is"
+)
+
+func TestProjectsCommand(t *testing.T) {
+	sessionRepository := &infra.InMemorySessionRepository{}
+	dateProvider := infra.NewStubDateProvider()
+	app := test.InitializeApp(sessionRepository, dateProvider)
+
+	givenSessions := []session.Session{
+		{
+			Id:        "1",
+			StartTime: time.Date(2024, time.April, 14, 10, 12, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, time.April, 14, 13, 10, 0, 0, time.UTC),
+			Project:   "MyTodo",
+		},
+		{
+			Id:        "2",
+			StartTime: time.Date(2024, time.April, 14, 14, 12, 0, 0, time.UTC),
+			Project:   "Flow",
+		},
+	}
+
+	tt := []struct {
+		error         error
+		name          string
+		want          string
+		args          []string
+		givenSessions []session.Session
+	}{
+		{
+			name: "No projects",
+			want: "No projects found",
+		},
+		{
+			name:          "Text output",
+			givenSessions: givenSessions,
+			want:          "MyTodo\nFlow",
+		},
+		{
+			name:          "JSON output",
+			args:          []string{"--output", "json"},
+			givenSessions: givenSessions,
+			want:          "{\n  \"projects\": [\n    \"MyTodo\",\n    \"Flow\"\n  ]\n}",
+		},
+		{
+			name:          "Porcelain",
+			args:          []string{"--porcelain"},
+			givenSessions: givenSessions,
+			want:          "MyTodo\nFlow",
+		},
+		{
+			name: "Porcelain without projects",
+			args: []string{"--porcelain"},
+			want: "",
+		},
+		{
+			name:  "Invalid output",
+			args:  []string{"--output", "xml"},
+			error: errors.New("invalid output flag. possible values: text, json, plain"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			sessionRepository.Sessions = tc.givenSessions
+
+			c := projects.Command(app, &infra.InMemoryClipboard{})
+
+			got, err := test.ExecuteCmd(t, c, tc.args...)
+
+			is.Equal(tc.error, err)
+
+			if tc.error == nil {
+				is.Equal(tc.want, got)
+			}
+		})
+	}
+}
+
+func TestProjectsSetCommand(t *testing.T) {
+	sessionRepository := &infra.InMemorySessionRepository{}
+	dateProvider := infra.NewStubDateProvider()
+	app := test.InitializeApp(sessionRepository, dateProvider)
+	app.Config.BillingProfiles = map[string]billing.Profile{
+		"retainer": {DailyCap: 8 * time.Hour},
+		"support":  {Minimum: 30 * time.Minute},
+	}
+
+	tt := []struct {
+		error error
+		name  string
+		want  string
+		args  []string
+	}{
+		{
+			name:  "Set without name",
+			args:  []string{"set"},
+			error: errors.New("the project name is required"),
+		},
+		{
+			name: "Set on lock action",
+			args: []string{"set", "Flow", "--on-lock", "pause"},
+			want: "Project: Flow\nOn lock: pause",
+		},
+		{
+			name: "Set without flags keeps settings",
+			args: []string{"set", "Flow"},
+			want: "Project: Flow\nOn lock: pause",
+		},
+		{
+			name: "Set do-not-track windows",
+			args: []string{"set", "Flow", "--do-not-track", "sat,sun", "--do-not-track", "mon-fri 22:00-07:00", "--on-do-not-track", "block"},
+			want: "Project: Flow\nOn lock: pause\nDo not track: sat,sun, mon,tue,wed,thu,fri 22:00-07:00 (block)",
+		},
+		{
+			name:  "Invalid on lock action",
+			args:  []string{"set", "Flow", "--on-lock", "hibernate"},
+			error: setproject.ErrInvalidOnLock,
+		},
+		{
+			name: "Set on meeting action",
+			args: []string{"set", "Standup", "--on-meeting", "switch", "--meeting-project", "meetings"},
+			want: "Project: Standup\nOn lock: none\nOn meeting: switch to meetings",
+		},
+		{
+			name:  "Invalid on meeting action",
+			args:  []string{"set", "Standup", "--on-meeting", "leave"},
+			error: setproject.ErrInvalidOnMeeting,
+		},
+		{
+			name: "Set billing settings",
+			args: []string{"set", "Website", "--client", "Acme", "--billable", "--rate", "80"},
+			want: "Project: Website\nOn lock: none\nClient: Acme\nBillable: 80.00/h",
+		},
+		{
+			name:  "Negative hourly rate",
+			args:  []string{"set", "Website", "--rate", "-10"},
+			error: setproject.ErrNegativeRate,
+		},
+		{
+			name: "Set billing profile",
+			args: []string{"set", "Website", "--billing-profile", "retainer"},
+			want: "Project: Website\nOn lock: none\nClient: Acme\nBillable: 80.00/h\nBilling profile: retainer",
+		},
+		{
+			name:  "Unknown billing profile",
+			args:  []string{"set", "Website", "--billing-profile", "fixed"},
+			error: errors.New("unknown billing profile fixed. possible values: [retainer support]"),
+		},
+		{
+			name: "Set break schedule",
+			args: []string{"set", "Compliance", "--break-every", "2h", "--break-duration", "10m"},
+			want: "Project: Compliance\nOn lock: none\nBreaks: 10m0s every 2h0m0s",
+		},
+		{
+			name:  "Negative break",
+			args:  []string{"set", "Compliance", "--break-duration", "-10m"},
+			error: setproject.ErrNegativeBreak,
+		},
+		{
+			name: "Set goals",
+			args: []string{"set", "Research", "--weekly-goal", "10h", "--monthly-goal", "40h"},
+			want: "Project: Research\nOn lock: none\nWeekly goal: 10h0m0s\nMonthly goal: 40h0m0s",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			c := projects.Command(app, &infra.InMemoryClipboard{})
+
+			got, err := test.ExecuteCmd(t, c, tc.args...)
+
+			is.Equal(tc.error, err)
+
+			if tc.error == nil {
+				is.Equal(tc.want, got)
+			}
+		})
+	}
+}
+
+func TestProjectsRenameCommand(t *testing.T) {
+	tt := []struct {
+		error        error
+		name         string
+		want         string
+		args         []string
+		wantProjects []string
+	}{
+		{
+			name:  "Rename without new name",
+			args:  []string{"rename", "MyTodo"},
+			error: errors.New("the project name and its new name are required"),
+		},
+		{
+			name:         "Rename",
+			args:         []string{"rename", "MyTodo", "Todo"},
+			want:         "Project MyTodo renamed to Todo, 2 session(s) updated",
+			wantProjects: []string{"Todo", "Flow", "Todo"},
+		},
+		{
+			name:  "Rename to an existing project",
+			args:  []string{"rename", "MyTodo", "Flow"},
+			error: renameproject.ErrProjectExists,
+		},
+		{
+			name:         "Merge",
+			args:         []string{"rename", "MyTodo", "Flow", "--merge"},
+			want:         "Project MyTodo merged into Flow, 2 session(s) updated",
+			wantProjects: []string{"Flow", "Flow", "Flow"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			sessionRepository := &infra.InMemorySessionRepository{
+				Sessions: []session.Session{
+					{Id: "1", StartTime: time.Date(2024, time.April, 14, 10, 0, 0, 0, time.UTC), Project: "MyTodo"},
+					{Id: "2", StartTime: time.Date(2024, time.April, 14, 11, 0, 0, 0, time.UTC), Project: "Flow"},
+					{Id: "3", StartTime: time.Date(2024, time.April, 14, 12, 0, 0, 0, time.UTC), Project: "MyTodo"},
+				},
+			}
+			app := test.InitializeApp(sessionRepository, infra.NewStubDateProvider())
+
+			c := projects.Command(app, &infra.InMemoryClipboard{})
+
+			got, err := test.ExecuteCmd(t, c, tc.args...)
+
+			is.Equal(tc.error, err)
+
+			if tc.error == nil {
+				is.Equal(tc.want, got)
+
+				gotProjects := []string{}
+				for _, s := range sessionRepository.Sessions {
+					gotProjects = append(gotProjects, s.Project)
+				}
+				is.Equal(tc.wantProjects, gotProjects)
+			}
+		})
+	}
+}
@@ -6,8 +6,14 @@ import (
 	"log"
 	"time"
 
+	"github.com/TristanShz/flow/cmd/clipboard"
+	"github.com/TristanShz/flow/cmd/completion"
+	"github.com/TristanShz/flow/cmd/goals"
+	"github.com/TristanShz/flow/internal/application"
 	app "github.com/TristanShz/flow/internal/application/usecases"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/federatedreport"
 	"github.com/TristanShz/flow/internal/application/usecases/flowsession/viewsessionsreport"
+	"github.com/TristanShz/flow/internal/domain/session"
 	"github.com/TristanShz/flow/internal/domain/sessionsreport"
 	"github.com/TristanShz/flow/internal/infra/presenter"
 	"github.com/TristanShz/flow/pkg/timerange"
@@ -15,7 +21,30 @@ import (
 )
 
 func isFormatFlagValid(flag string) bool {
-	return flag == sessionsreport.FormatByDay || flag == sessionsreport.FormatByProject
+	return flag == sessionsreport.FormatByDay || flag == sessionsreport.FormatByProject || flag == sessionsreport.FormatByClient || flag == sessionsreport.FormatEarnings || flag == sessionsreport.FormatGaps
+}
+
+// defaultWorkingHours are the hours of the gaps report when neither
+// --working-hours nor the work hours of the notifications are set
+const defaultWorkingHours = "mon-fri after 09:00 before 18:00"
+
+// parseWorkingHours reads --working-hours, falling back on the work hours of
+// the notifications
+func parseWorkingHours(cmd *cobra.Command, app *app.App) (session.TagRule, error) {
+	workingHoursFlag, _ := cmd.Flags().GetString("working-hours")
+	if workingHoursFlag == "" {
+		if app.Config.Notifications.WorkHours != nil {
+			return *app.Config.Notifications.WorkHours, nil
+		}
+		workingHoursFlag = defaultWorkingHours
+	}
+
+	workingHours, err := session.ParseTagRule("", workingHoursFlag)
+	if err != nil {
+		return session.TagRule{}, fmt.Errorf("invalid working hours: %w", err)
+	}
+
+	return workingHours, nil
 }
 
 func parseTimeFlag(flag string) (time.Time, error) {
@@ -53,25 +82,71 @@ func parseUntilFlag(cmd *cobra.Command) (time.Time, error) {
 	return time.Time{}, nil
 }
 
-func Command(app *app.App) *cobra.Command {
+// Command prints the reports, and copies them to the clipboard with --copy
+func Command(app *app.App, systemClipboard application.Clipboard) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "report",
-		Short: "Report",
+		Use:     "report",
+		Example: "report --day\nreport --week --format by-project\nreport --format by-client --client acme\nreport --since 2024-04-01 --until 2024-04-30 --project my-todo\nreport --format earnings --since 2024-04-01 --until 2024-05-01\nreport --format gaps --week --gap-threshold 45m\nreport --range -7d\nreport --range \"since monday\" --format by-project\nreport --week --format by-project --porcelain\nreport --range this-week --compare last-week\nreport --range last-week --output markdown\nreport --where 'project = \"Flow\" and duration > 1h and tag in (deep, review)'\nreport --week --format by-project --all-stores\nreport --range last-month --stores work,local",
+		Short:   "Report",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			logger := log.New(cmd.OutOrStdout(), "", 0)
+			out, copied := clipboard.Output(cmd)
+			logger := log.New(out, "", 0)
 
-			presenter := presenter.SessionsReportCLIPresenter{Logger: logger}
+			outputFlag, _ := cmd.Flags().GetString("output")
+			if !presenter.IsReportOutputValid(outputFlag) {
+				return errors.New("invalid output flag. possible values: text, json, plain, markdown")
+			}
+
+			porcelainFlag, _ := cmd.Flags().GetBool("porcelain")
+			output, err := presenter.ResolveOutput(cmd.OutOrStdout(), outputFlag, cmd.Flags().Changed("output"), porcelainFlag)
+			if err != nil {
+				return err
+			}
 
 			formatFlag, _ := cmd.Flags().GetString("format")
 
 			if formatFlag != "" && !isFormatFlagValid(formatFlag) {
-				return errors.New("invalid format flag. possible values: by-day, by-project")
+				return errors.New("invalid format flag. possible values: by-day, by-project, by-client, earnings, gaps")
 			}
 
 			projectFlag, _ := cmd.Flags().GetString("project")
+			clientFlag, _ := cmd.Flags().GetString("client")
+			tagFlag, _ := cmd.Flags().GetStringSlice("tag")
 			command := viewsessionsreport.Command{
 				Project: projectFlag,
+				Client:  clientFlag,
 				Format:  formatFlag,
+				Tags:    tagFlag,
+			}
+
+			noRoundingFlag, _ := cmd.Flags().GetBool("no-rounding")
+			if !noRoundingFlag {
+				command.Rounding = app.Config.Rounding
+			}
+
+			if formatFlag == sessionsreport.FormatGaps {
+				workingHours, err := parseWorkingHours(cmd, app)
+				if err != nil {
+					return err
+				}
+
+				command.WorkingHours = workingHours
+				command.GapThreshold, _ = cmd.Flags().GetDuration("gap-threshold")
+			}
+
+			allTagsFlag, _ := cmd.Flags().GetBool("all-tags")
+			if allTagsFlag {
+				command.TagsMatch = application.TagsMatchAll
+			}
+
+			whereFlag, _ := cmd.Flags().GetString("where")
+			if whereFlag != "" {
+				where, err := application.ParseWhere(whereFlag)
+				if err != nil {
+					return err
+				}
+
+				command.Where = where
 			}
 
 			dayFlag, _ := cmd.Flags().GetBool("day")
@@ -84,7 +159,18 @@ func Command(app *app.App) *cobra.Command {
 
 			weekFlag, _ := cmd.Flags().GetBool("week")
 			if weekFlag {
-				timeRange := timerange.NewWeekTimeRange(app.DateProvider.GetNow())
+				timeRange := timerange.NewWeekTimeRangeFrom(app.DateProvider.GetNow(), app.Config.FirstDayOfWeek())
+
+				command.Since = timeRange.Since
+				command.Until = timeRange.Until
+			}
+
+			rangeFlag, _ := cmd.Flags().GetString("range")
+			if rangeFlag != "" {
+				timeRange, err := timerange.Parse(rangeFlag, app.DateProvider.GetNow())
+				if err != nil {
+					return err
+				}
 
 				command.Since = timeRange.Since
 				command.Until = timeRange.Until
@@ -108,21 +194,79 @@ func Command(app *app.App) *cobra.Command {
 				command.Until = untilFlag
 			}
 
-			err := app.ViewSessionsReportUseCase.Execute(command, presenter)
-			if err != nil {
+			compareFlag, _ := cmd.Flags().GetString("compare")
+			if compareFlag != "" {
+				compared, err := timerange.Parse(compareFlag, app.DateProvider.GetNow())
+				if err != nil {
+					return err
+				}
+
+				command.CompareSince = compared.Since
+				command.CompareUntil = compared.Until
+			}
+
+			storesFlag, _ := cmd.Flags().GetStringSlice("stores")
+			allStoresFlag, _ := cmd.Flags().GetBool("all-stores")
+			if len(storesFlag) > 0 || allStoresFlag {
+				reports := &presenter.StoreReports{Output: output}
+				if err := app.FederatedReportUseCase.Execute(federatedreport.Command{Report: command, Stores: storesFlag}, reports.NewPresenter); err != nil {
+					return err
+				}
+				reports.Print(logger)
+
+				return clipboard.Copy(cmd, systemClipboard, copied)
+			}
+
+			if err := app.ViewSessionsReportUseCase.Execute(command, presenter.NewSessionsReportPresenter(output, logger)); err != nil {
 				return err
 			}
 
-			return nil
+			// the goals are about the current week and month, they're only
+			// worth a warning in a report of a range running until now, and
+			// they're left out of the copied report
+			now := app.DateProvider.GetNow()
+			inRange := (command.Since.IsZero() || !command.Since.After(now)) && (command.Until.IsZero() || !command.Until.Before(now))
+			if output == presenter.OutputText && compareFlag == "" && inRange {
+				warningLogger := log.New(cmd.OutOrStdout(), "", 0)
+				for _, warning := range goals.Warnings(app, projectFlag) {
+					warningLogger.Println(warning)
+				}
+			}
+
+			return clipboard.Copy(cmd, systemClipboard, copied)
 		},
 	}
 
 	cmd.Flags().StringP("project", "p", "", "get a report for all flow sessions of given project")
-	cmd.Flags().StringP("format", "f", "", "Specify the format of the report. Possible values: by-day, by-project, total-duration")
+	cmd.Flags().StringP("client", "c", "", "get a report for all flow sessions billed to the given client")
+	cmd.Flags().StringSliceP("tag", "t", []string{}, "get a report for flow sessions having one of the given tags")
+	cmd.Flags().Bool("all-tags", false, "Only keep sessions having all the given tags")
+	cmd.Flags().String("where", "", "Only keep sessions matching an expression like 'project = \"Flow\" and duration > 1h and tag in (deep, review)'")
+	cmd.Flags().StringP("format", "f", "", "Specify the format of the report. Possible values: by-day, by-project, by-client, earnings, gaps")
+	cmd.Flags().String("working-hours", "", "Working hours in which the gaps report looks for untracked time, like \"mon-fri after 09:00 before 18:00\". Defaults to the work hours of the notifications")
+	cmd.Flags().Duration("gap-threshold", 30*time.Minute, "Shortest untracked time listed by the gaps report")
+	cmd.Flags().StringP("output", "o", presenter.DefaultOutput(app.Config.Output), "Output format. Possible values: text, json, plain, markdown")
+	cmd.Flags().Bool("porcelain", false, "Print the plain output, whose format never changes, for scripts")
 	cmd.Flags().StringP("since", "s", "", "Specify the start date of the report")
 	cmd.Flags().StringP("until", "u", "", "Specify the end date of the report")
 	cmd.Flags().BoolP("day", "d", false, "Get a report for all flow sessions of the day")
 	cmd.Flags().BoolP("week", "w", false, "Get a report for all flow sessions of the week")
+	cmd.Flags().StringP("range", "r", "", "Get a report for a range like today, last-week, 2024-04, -7d or \"since monday\"")
+	cmd.Flags().Bool("no-rounding", false, "Report the tracked durations, without the rounding of the config file")
+	cmd.Flags().StringSlice("stores", []string{}, "Report the given stores of the config file, each one on its own, local being the flow folder in use")
+	cmd.Flags().Bool("all-stores", false, "Report every store of the config file, each one on its own, after the flow folder in use")
+	cmd.Flags().String("compare", "", "Compare the report to another range, like last-week, showing the time gained or lost by each project and tag")
+
+	clipboard.AddFlag(cmd)
+	completion.RegisterProjectFlag(cmd, app)
+	cmd.RegisterFlagCompletionFunc("range", completion.Ranges(app))
+	cmd.RegisterFlagCompletionFunc("compare", completion.Ranges(app))
+	cmd.RegisterFlagCompletionFunc("since", completion.Dates(app))
+	cmd.RegisterFlagCompletionFunc("until", completion.Dates(app))
+	cmd.RegisterFlagCompletionFunc("stores", func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		return app.FederatedReportUseCase.Names(), cobra.ShellCompDirectiveNoFileComp
+	})
+	cmd.RegisterFlagCompletionFunc("gap-threshold", completion.Durations(completion.CommonDurations))
 
 	return cmd
 }
@@ -2,14 +2,27 @@ package report_test
 
 import (
 	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/TristanShz/flow/cmd/report"
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/federatedreport"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/viewsessionsreport"
+	"github.com/TristanShz/flow/internal/application/usecases/project/setproject"
 	"github.com/TristanShz/flow/internal/domain/session"
 	"github.com/TristanShz/flow/internal/infra"
+	"github.com/TristanShz/flow/internal/infra/filesystem"
+	"github.com/TristanShz/flow/internal/infra/presenter"
+	"github.com/TristanShz/flow/pkg/timerange"
 	"github.com/TristanShz/flow/test"
 	is "github.com/matryer/is"
+	"github.com/spf13/cobra"
 )
 
 func TestReportCommand(t *testing.T) {
@@ -19,6 +32,9 @@ func TestReportCommand(t *testing.T) {
 	dateProvider := infra.NewStubDateProvider()
 	app := test.InitializeApp(sessionRepository, dateProvider)
 
+	billable := true
+	rate := 100.0
+
 	tt := []struct {
 		error         error
 		name          string
@@ -51,7 +67,7 @@ func TestReportCommand(t *testing.T) {
 		{
 			name:  "Invalid format flag",
 			args:  []string{"--format", "invalid"},
-			error: errors.New("invalid format flag. possible values: by-day, by-project"),
+			error: errors.New("invalid format flag. possible values: by-day, by-project, by-client, earnings, gaps"),
 		},
 		{
 			name: "By day",
@@ -209,6 +225,33 @@ func TestReportCommand(t *testing.T) {
 			},
 			want: "Sessions Report\n\nMon, 15 Apr 2024 - 1h0m0s\n    3 16:12:00 to 17:12:00 1h0m0s Flow [start-usecase]",
 		},
+		{
+			name:     "Range flag",
+			args:     []string{"--range", "-1d"},
+			givenNow: time.Date(2024, time.April, 16, 18, 0, 0, 0, time.UTC),
+			givenSessions: []session.Session{
+				{
+					Id:        "1",
+					StartTime: time.Date(2024, time.April, 14, 10, 12, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.April, 14, 13, 10, 0, 0, time.UTC),
+					Project:   "MyTodo",
+					Tags:      []string{"add-todo"},
+				},
+				{
+					Id:        "3",
+					StartTime: time.Date(2024, time.April, 15, 16, 12, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.April, 15, 17, 12, 0, 0, time.UTC),
+					Project:   "Flow",
+					Tags:      []string{"start-usecase"},
+				},
+			},
+			want: "Sessions Report\n\nMon, 15 Apr 2024 - 1h0m0s\n    3 16:12:00 to 17:12:00 1h0m0s Flow [start-usecase]",
+		},
+		{
+			name:  "Invalid range flag",
+			args:  []string{"--range", "soon"},
+			error: timerange.ErrInvalidRange,
+		},
 		{
 			name: "Since flag",
 			args: []string{"--since", "2024-04-15"},
@@ -310,13 +353,277 @@ func TestReportCommand(t *testing.T) {
 			},
 			want: "Sessions Report\n\nSun, 14 Apr 2024 - 3h58m0s\n    1 10:12:00 to 13:10:00 2h58m0s MyTodo [add-todo]\n    2 14:12:00 to 15:12:00 1h0m0s Flow [start-usecase]\n\nMon, 15 Apr 2024 - 1h0m0s\n    3 16:12:00 to 17:12:00 1h0m0s Flow [start-usecase]",
 		},
+		{
+			name: "Tag flag",
+			args: []string{"--tag", "add-todo"},
+			givenSessions: []session.Session{
+				{
+					Id:        "1",
+					StartTime: time.Date(2024, time.April, 14, 10, 12, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.April, 14, 13, 10, 0, 0, time.UTC),
+					Project:   "MyTodo",
+					Tags:      []string{"add-todo"},
+				},
+				{
+					Id:        "2",
+					StartTime: time.Date(2024, time.April, 14, 14, 12, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.April, 14, 15, 12, 0, 0, time.UTC),
+					Project:   "Flow",
+					Tags:      []string{"start-usecase"},
+				},
+			},
+			want: "Sessions Report\n\nSun, 14 Apr 2024 - 2h58m0s\n    1 10:12:00 to 13:10:00 2h58m0s MyTodo [add-todo]",
+		},
+		{
+			name: "Where flag",
+			args: []string{"--where", `project = "Flow" or (duration > 2h and tag in (add-todo, review))`},
+			givenSessions: []session.Session{
+				{
+					Id:        "1",
+					StartTime: time.Date(2024, time.April, 14, 10, 12, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.April, 14, 13, 10, 0, 0, time.UTC),
+					Project:   "MyTodo",
+					Tags:      []string{"add-todo"},
+				},
+				{
+					Id:        "2",
+					StartTime: time.Date(2024, time.April, 14, 14, 12, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.April, 14, 15, 12, 0, 0, time.UTC),
+					Project:   "MyTodo",
+					Tags:      []string{"add-todo"},
+				},
+				{
+					Id:        "3",
+					StartTime: time.Date(2024, time.April, 14, 16, 12, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.April, 14, 16, 42, 0, 0, time.UTC),
+					Project:   "Flow",
+				},
+			},
+			want: "Sessions Report\n\nSun, 14 Apr 2024 - 3h28m0s\n    1 10:12:00 to 13:10:00 2h58m0s MyTodo [add-todo]\n    3 16:12:00 to 16:42:00 30m0s Flow []",
+		},
+		{
+			name:  "Invalid where flag",
+			args:  []string{"--where", "length > 1h"},
+			error: fmt.Errorf(`%w: unknown field "length". possible values: project, tag, note, duration, date, status`, application.ErrInvalidWhere),
+		},
+		{
+			name: "By client",
+			args: []string{"--format", "by-client", "--client", "Acme"},
+			givenSessions: []session.Session{
+				{
+					Id:        "1",
+					StartTime: time.Date(2024, time.April, 14, 10, 0, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.April, 14, 12, 0, 0, 0, time.UTC),
+					Project:   "Flow",
+					Client:    "Acme",
+				},
+				{
+					Id:        "2",
+					StartTime: time.Date(2024, time.April, 14, 14, 0, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.April, 14, 15, 0, 0, 0, time.UTC),
+					Project:   "MyTodo",
+				},
+			},
+			want: "Sessions Report\n\nAcme - 2h0m0s\n    Flow - 2h0m0s",
+		},
+		{
+			name: "Earnings",
+			args: []string{"--format", "earnings"},
+			givenSessions: []session.Session{
+				{
+					Id:         "1",
+					StartTime:  time.Date(2024, time.April, 14, 10, 0, 0, 0, time.UTC),
+					EndTime:    time.Date(2024, time.April, 14, 12, 0, 0, 0, time.UTC),
+					Project:    "Flow",
+					Billable:   &billable,
+					HourlyRate: &rate,
+				},
+				{
+					Id:        "2",
+					StartTime: time.Date(2024, time.April, 14, 14, 0, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.April, 14, 15, 0, 0, 0, time.UTC),
+					Project:   "MyTodo",
+				},
+			},
+			want: "Earnings Report\n\nNo client - 2h0m0s - 200.00\n    Flow 2h0m0s -> 200.00\n\nTotal - 2h0m0s - 200.00",
+		},
+		{
+			name:     "Gaps",
+			args:     []string{"--format", "gaps", "--day"},
+			givenNow: time.Date(2024, time.April, 15, 18, 30, 0, 0, time.UTC),
+			givenSessions: []session.Session{
+				{
+					Id:        "1",
+					StartTime: time.Date(2024, time.April, 15, 9, 0, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.April, 15, 12, 0, 0, 0, time.UTC),
+					Project:   "Flow",
+				},
+				{
+					Id:        "2",
+					StartTime: time.Date(2024, time.April, 15, 12, 10, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.April, 15, 17, 0, 0, 0, time.UTC),
+					Project:   "Flow",
+				},
+			},
+			want: "Gaps Report\n\nMon, 15 Apr 2024\n    17:00 to 18:00 1h0m0s\n\nUntracked - 1h0m0s of 9h0m0s of working hours",
+		},
+		{
+			name:     "Gaps in the given working hours",
+			args:     []string{"--format", "gaps", "--day", "--working-hours", "mon-fri after 08:00 before 13:00", "--gap-threshold", "5m", "--porcelain"},
+			givenNow: time.Date(2024, time.April, 15, 18, 30, 0, 0, time.UTC),
+			givenSessions: []session.Session{
+				{
+					Id:        "1",
+					StartTime: time.Date(2024, time.April, 15, 9, 0, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.April, 15, 12, 0, 0, 0, time.UTC),
+					Project:   "Flow",
+				},
+				{
+					Id:        "2",
+					StartTime: time.Date(2024, time.April, 15, 12, 10, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.April, 15, 17, 0, 0, 0, time.UTC),
+					Project:   "Flow",
+				},
+			},
+			want: "2024-04-15\t2024-04-15T08:00:00Z\t2024-04-15T09:00:00Z\t3600\n2024-04-15\t2024-04-15T12:00:00Z\t2024-04-15T12:10:00Z\t600",
+		},
+		{
+			name:  "Gaps without period",
+			args:  []string{"--format", "gaps"},
+			error: viewsessionsreport.ErrGapsWithoutSince,
+		},
+		{
+			name:     "Comparison with last week",
+			args:     []string{"--range", "this-week", "--compare", "last-week"},
+			givenNow: time.Date(2024, time.April, 17, 12, 0, 0, 0, time.UTC),
+			givenSessions: []session.Session{
+				{Id: "1", StartTime: time.Date(2024, time.April, 9, 9, 0, 0, 0, time.UTC), EndTime: time.Date(2024, time.April, 9, 11, 0, 0, 0, time.UTC), Project: "Flow", Tags: []string{"cli"}},
+				{Id: "2", StartTime: time.Date(2024, time.April, 10, 9, 0, 0, 0, time.UTC), EndTime: time.Date(2024, time.April, 10, 10, 0, 0, 0, time.UTC), Project: "MyTodo", Tags: []string{"docs"}},
+				{Id: "3", StartTime: time.Date(2024, time.April, 15, 9, 0, 0, 0, time.UTC), EndTime: time.Date(2024, time.April, 15, 12, 0, 0, 0, time.UTC), Project: "Flow", Tags: []string{"cli"}},
+				{Id: "4", StartTime: time.Date(2024, time.April, 16, 9, 0, 0, 0, time.UTC), EndTime: time.Date(2024, time.April, 16, 10, 0, 0, 0, time.UTC), Project: "Pomodoro"},
+			},
+			want: "Comparison Report\n\nTotal: 3h0m0s -> 4h0m0s (+1h0m0s, +33%)\n\nProjects\n    Flow: 2h0m0s -> 3h0m0s (+1h0m0s, +50%)\n    Pomodoro: 0s -> 1h0m0s (+1h0m0s, new)\n    MyTodo: 1h0m0s -> 0s (-1h0m0s, -100%)\n\nTags\n    cli: 2h0m0s -> 3h0m0s (+1h0m0s, +50%)\n    docs: 1h0m0s -> 0s (-1h0m0s, -100%)",
+		},
+		{
+			name:     "Porcelain comparison",
+			args:     []string{"--range", "this-week", "--compare", "last-week", "--porcelain"},
+			givenNow: time.Date(2024, time.April, 17, 12, 0, 0, 0, time.UTC),
+			givenSessions: []session.Session{
+				{Id: "1", StartTime: time.Date(2024, time.April, 9, 9, 0, 0, 0, time.UTC), EndTime: time.Date(2024, time.April, 9, 11, 0, 0, 0, time.UTC), Project: "Flow", Tags: []string{"cli"}},
+				{Id: "2", StartTime: time.Date(2024, time.April, 10, 9, 0, 0, 0, time.UTC), EndTime: time.Date(2024, time.April, 10, 10, 0, 0, 0, time.UTC), Project: "MyTodo", Tags: []string{"docs"}},
+				{Id: "3", StartTime: time.Date(2024, time.April, 15, 9, 0, 0, 0, time.UTC), EndTime: time.Date(2024, time.April, 15, 12, 0, 0, 0, time.UTC), Project: "Flow", Tags: []string{"cli"}},
+				{Id: "4", StartTime: time.Date(2024, time.April, 16, 9, 0, 0, 0, time.UTC), EndTime: time.Date(2024, time.April, 16, 10, 0, 0, 0, time.UTC), Project: "Pomodoro"},
+			},
+			want: "total\t\t10800\t14400\t3600\t33.3\nproject\tFlow\t7200\t10800\t3600\t50.0\nproject\tPomodoro\t0\t3600\t3600\t\nproject\tMyTodo\t3600\t0\t-3600\t-100.0\ntag\tcli\t7200\t10800\t3600\t50.0\ntag\tdocs\t3600\t0\t-3600\t-100.0",
+		},
+		{
+			name:     "Comparison with a format",
+			args:     []string{"--range", "this-week", "--compare", "last-week", "--format", "by-project"},
+			givenNow: time.Date(2024, time.April, 17, 12, 0, 0, 0, time.UTC),
+			error:    viewsessionsreport.ErrCompareWithFormat,
+		},
+		{
+			name: "JSON output",
+			args: []string{"--output", "json", "--format", "by-project"},
+			givenSessions: []session.Session{
+				{
+					Id:        "1",
+					StartTime: time.Date(2024, time.April, 14, 10, 12, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.April, 14, 13, 10, 0, 0, time.UTC),
+					Project:   "MyTodo",
+					Tags:      []string{"add-todo"},
+				},
+			},
+			want: "{\n  \"projects\": [\n    {\n      \"duration_by_tag_seconds\": {\n        \"add-todo\": 10680\n      },\n      \"project\": \"MyTodo\",\n      \"total_duration_seconds\": 10680\n    }\n  ]\n}",
+		},
+		{
+			name: "Porcelain by project",
+			args: []string{"--porcelain", "--format", "by-project"},
+			givenSessions: []session.Session{
+				{
+					Id:        "1",
+					StartTime: time.Date(2024, time.April, 14, 10, 0, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.April, 14, 12, 0, 0, 0, time.UTC),
+					Project:   "MyTodo",
+					Tags:      []string{"review", "add-todo"},
+				},
+			},
+			want: "MyTodo\t\t7200\nMyTodo\tadd-todo\t7200\nMyTodo\treview\t7200",
+		},
+		{
+			name: "Plain output by day",
+			args: []string{"--output", "plain"},
+			givenSessions: []session.Session{
+				{
+					Id:        "1",
+					StartTime: time.Date(2024, time.April, 14, 10, 0, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.April, 14, 12, 0, 0, 0, time.UTC),
+					Project:   "MyTodo",
+					Tags:      []string{"add-todo", "review"},
+				},
+			},
+			want: "2024-04-14\t1\t2024-04-14T10:00:00Z\t2024-04-14T12:00:00Z\t7200\tMyTodo\tadd-todo,review",
+		},
+		{
+			name:  "Porcelain with another output",
+			args:  []string{"--porcelain", "--output", "json"},
+			error: presenter.ErrPorcelainOutput,
+		},
+		{
+			name:  "Invalid output flag",
+			args:  []string{"--output", "xml"},
+			error: errors.New("invalid output flag. possible values: text, json, plain, markdown"),
+		},
+		{
+			name: "Markdown timesheet",
+			args: []string{"--output", "markdown"},
+			givenSessions: []session.Session{
+				{
+					Id:        "1",
+					StartTime: time.Date(2024, time.April, 14, 10, 0, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.April, 14, 12, 30, 0, 0, time.UTC),
+					Project:   "MyTodo",
+					Tags:      []string{"add-todo", "review"},
+					Note:      "Reviewed the | todo list",
+				},
+				{
+					Id:        "2",
+					StartTime: time.Date(2024, time.April, 15, 9, 0, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.April, 15, 10, 5, 0, 0, time.UTC),
+					Project:   "Flow",
+				},
+			},
+			want: "# Timesheet 2024-04-14 to 2024-04-15\n\n" +
+				"## Sun, 14 Apr 2024 - 2h30m\n\n" +
+				"| Start | End | Duration | Project | Tags | Note |\n| --- | --- | --- | --- | --- | --- |\n" +
+				"| 10:00 | 12:30 | 2h30m | MyTodo | add-todo, review | Reviewed the \\| todo list |\n\n" +
+				"## Mon, 15 Apr 2024 - 1h05m\n\n" +
+				"| Start | End | Duration | Project | Tags | Note |\n| --- | --- | --- | --- | --- | --- |\n" +
+				"| 09:00 | 10:05 | 1h05m | Flow |  |  |\n\n" +
+				"## Totals per project\n\n" +
+				"| Project | Duration |\n| --- | --- |\n| MyTodo | 2h30m |\n| Flow | 1h05m |\n\n" +
+				"**Total: 3h35m**",
+		},
+		{
+			name: "Markdown by project",
+			args: []string{"--output", "markdown", "--format", "by-project"},
+			givenSessions: []session.Session{
+				{
+					Id:        "1",
+					StartTime: time.Date(2024, time.April, 14, 10, 0, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.April, 14, 12, 30, 0, 0, time.UTC),
+					Project:   "MyTodo",
+				},
+			},
+			want: "# Sessions Report\n\n| Project | Duration |\n| --- | --- |\n| MyTodo | 2h30m |\n\n**Total: 2h30m**",
+		},
 	}
 
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
 			sessionRepository.Sessions = tc.givenSessions
 			dateProvider.Now = tc.givenNow
-			c := report.Command(app)
+			c := report.Command(app, &infra.InMemoryClipboard{})
 
 			got, err := test.ExecuteCmd(t, c, tc.args...)
 
@@ -328,3 +635,295 @@ func TestReportCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestReportCommand_Copy(t *testing.T) {
+	sessionRepository := &infra.InMemorySessionRepository{Sessions: []session.Session{
+		{
+			Id:        "1",
+			StartTime: time.Date(2024, time.April, 14, 10, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, time.April, 14, 12, 30, 0, 0, time.UTC),
+			Project:   "My<Todo>",
+		},
+	}}
+	app := test.InitializeApp(sessionRepository, infra.NewStubDateProvider())
+	wantReport := "# Sessions Report\n\n| Project | Duration |\n| --- | --- |\n| My<Todo> | 2h30m |\n\n**Total: 2h30m**"
+
+	tt := []struct {
+		name        string
+		clipboard   *infra.InMemoryClipboard
+		want        string
+		wantCopied  application.ClipboardContent
+		args        []string
+		wantErrText string
+	}{
+		{
+			name:      "Copy",
+			args:      []string{"--output", "markdown", "--format", "by-project", "--copy"},
+			clipboard: &infra.InMemoryClipboard{},
+			want:      wantReport + "\nCopied to the clipboard",
+			wantCopied: application.ClipboardContent{
+				Text: wantReport + "\n",
+				HTML: "<pre># Sessions Report\n\n| Project | Duration |\n| --- | --- |\n| My&lt;Todo&gt; | 2h30m |\n\n**Total: 2h30m**\n</pre>",
+			},
+		},
+		{
+			name:      "Without copy",
+			args:      []string{"--output", "markdown", "--format", "by-project"},
+			clipboard: &infra.InMemoryClipboard{},
+			want:      wantReport,
+		},
+		{
+			name:        "Clipboard failing",
+			args:        []string{"--output", "markdown", "--format", "by-project", "--copy"},
+			clipboard:   &infra.InMemoryClipboard{Err: application.ErrClipboardUnsupported},
+			wantErrText: "the output can't be copied to the clipboard: the clipboard can't be written on this system",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			got, err := test.ExecuteCmd(t, report.Command(app, tc.clipboard), tc.args...)
+
+			if tc.wantErrText != "" {
+				is.True(errors.Is(err, application.ErrClipboardUnsupported))
+				is.Equal(err.Error(), tc.wantErrText)
+				return
+			}
+			is.NoErr(err)
+			is.Equal(got, tc.want)
+			is.Equal(tc.clipboard.Content, tc.wantCopied)
+		})
+	}
+}
+
+func TestReportCommand_GoalBehind(t *testing.T) {
+	is := is.New(t)
+
+	sessionRepository := &infra.InMemorySessionRepository{Sessions: []session.Session{
+		{
+			Id:        "1",
+			StartTime: time.Date(2024, time.April, 11, 10, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, time.April, 11, 11, 0, 0, 0, time.UTC),
+			Project:   "Flow",
+		},
+	}}
+	dateProvider := infra.NewStubDateProvider()
+	dateProvider.Now = time.Date(2024, time.April, 13, 12, 0, 0, 0, time.UTC)
+	app := test.InitializeApp(sessionRepository, dateProvider)
+
+	weeklyGoal := 10 * time.Hour
+	_, err := app.SetProjectUseCase.Execute(setproject.Command{Name: "Flow", WeeklyGoal: &weeklyGoal})
+	is.NoErr(err)
+	warning := "Warning: Flow is behind its goal by 6h51m0s, 1h0m0s of 10h0m0s this week"
+
+	got, err := test.ExecuteCmd(t, report.Command(app, &infra.InMemoryClipboard{}), "--week", "--format", "by-project")
+
+	is.NoErr(err)
+	is.True(strings.HasSuffix(got, "\n"+warning))
+
+	got, err = test.ExecuteCmd(t, report.Command(app, &infra.InMemoryClipboard{}), "--range", "last-week", "--format", "by-project")
+
+	is.NoErr(err)
+	is.True(!strings.Contains(got, warning))
+
+	got, err = test.ExecuteCmd(t, report.Command(app, &infra.InMemoryClipboard{}), "--week", "--output", "plain")
+
+	is.NoErr(err)
+	is.True(!strings.Contains(got, warning))
+}
+
+func TestReportCommand_Rounding(t *testing.T) {
+	is := is.New(t)
+
+	sessionRepository := &infra.InMemorySessionRepository{Sessions: []session.Session{
+		{
+			Id:        "1",
+			StartTime: time.Date(2024, time.April, 11, 10, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, time.April, 11, 10, 52, 0, 0, time.UTC),
+			Project:   "Flow",
+		},
+	}}
+	dateProvider := infra.NewStubDateProvider()
+	dateProvider.Now = time.Date(2024, time.April, 13, 12, 0, 0, 0, time.UTC)
+	app := test.InitializeApp(sessionRepository, dateProvider)
+	app.Config.Rounding = session.Rounding{Increment: 15 * time.Minute, Method: session.RoundUp}
+
+	got, err := test.ExecuteCmd(t, report.Command(app, &infra.InMemoryClipboard{}), "--week", "--format", "by-project", "--output", "plain")
+
+	is.NoErr(err)
+	is.Equal(got, "Flow\t\t3600")
+
+	got, err = test.ExecuteCmd(t, report.Command(app, &infra.InMemoryClipboard{}), "--week", "--format", "by-project", "--output", "plain", "--no-rounding")
+
+	is.NoErr(err)
+	is.Equal(got, "Flow\t\t3120")
+}
+
+func TestReportCommand_Stores(t *testing.T) {
+	sessionRepository := &infra.InMemorySessionRepository{Sessions: []session.Session{
+		{
+			Id:        "1",
+			StartTime: time.Date(2024, time.April, 11, 20, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, time.April, 11, 21, 0, 0, 0, time.UTC),
+			Project:   "Flow",
+		},
+	}}
+	workRepository := &infra.InMemorySessionRepository{Sessions: []session.Session{
+		{
+			Id:        "1",
+			StartTime: time.Date(2024, time.April, 11, 9, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, time.April, 11, 11, 0, 0, 0, time.UTC),
+			Project:   "Intranet",
+		},
+	}}
+	dateProvider := infra.NewStubDateProvider()
+	dateProvider.Now = time.Date(2024, time.April, 13, 12, 0, 0, 0, time.UTC)
+	app := test.InitializeApp(sessionRepository, dateProvider)
+	app.FederatedReportUseCase = federatedreport.NewFederatedReportUseCase([]application.ReportStore{
+		{
+			Name:              application.LocalStore,
+			SessionRepository: sessionRepository,
+			ProjectRepository: &infra.InMemoryProjectRepository{},
+			JournalRepository: &infra.InMemoryJournalRepository{},
+		},
+		{
+			Name:              "work",
+			SessionRepository: infra.NewReadOnlySessionRepository(workRepository),
+			ProjectRepository: &infra.InMemoryProjectRepository{},
+			JournalRepository: &infra.InMemoryJournalRepository{},
+		},
+	}, dateProvider)
+
+	tt := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr error
+	}{
+		{
+			name: "Every store",
+			args: []string{"--all-stores"},
+			want: "local\tFlow\t\t3600\nwork\tIntranet\t\t7200",
+		},
+		{
+			name: "Given store",
+			args: []string{"--stores", "work"},
+			want: "work\tIntranet\t\t7200",
+		},
+		{
+			name:    "Unknown store",
+			args:    []string{"--stores", "home"},
+			wantErr: federatedreport.ErrUnknownStore,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			args := append([]string{"--week", "--format", "by-project", "--output", "plain"}, tc.args...)
+			got, err := test.ExecuteCmd(t, report.Command(app, &infra.InMemoryClipboard{}), args...)
+
+			is.True(errors.Is(err, tc.wantErr))
+			if tc.wantErr == nil {
+				is.Equal(got, tc.want)
+			}
+		})
+	}
+
+	t.Run("Stores with the store of the root command", func(t *testing.T) {
+		is := is.New(t)
+
+		// the --store of any command is a persistent flag of the root
+		// command, the stores of the report must not shadow it
+		root := &cobra.Command{Use: "flow"}
+		root.PersistentFlags().String("store", "", "")
+		root.AddCommand(report.Command(app, &infra.InMemoryClipboard{}))
+
+		got, err := test.ExecuteCmd(t, root, "--store", t.TempDir(), "report", "--week", "--format", "by-project", "--output", "plain", "--stores", "work")
+
+		is.NoErr(err)
+		is.Equal(got, "work\tIntranet\t\t7200")
+	})
+}
+
+// folderContent returns the content of every file of the folder by path
+func folderContent(t *testing.T, folder string) map[string]string {
+	t.Helper()
+
+	content := map[string]string{}
+	err := filepath.WalkDir(folder, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		raw, err := os.ReadFile(path)
+		content[path] = string(raw)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return content
+}
+
+func TestReportCommand_StoresAreLeftUntouched(t *testing.T) {
+	is := is.New(t)
+
+	folder := t.TempDir()
+	writer := filesystem.NewFileSystemSessionRepository(folder)
+	is.NoErr(writer.Save(session.Session{
+		Id:        "1",
+		StartTime: time.Date(2024, time.April, 11, 9, 0, 0, 0, time.UTC),
+		Project:   "Intranet",
+		Tags:      []string{"meeting"},
+	}))
+	is.NoErr(writer.Save(session.Session{
+		Id:        "2",
+		StartTime: time.Date(2024, time.April, 12, 9, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, time.April, 12, 11, 0, 0, 0, time.UTC),
+		Project:   "Intranet",
+	}))
+	// a session file under its legacy name, next to a session never stopped
+	// and without an index, as reading a store must not rename, repair nor
+	// index anything
+	filename := filesystem.SessionFilename{Id: "2", Project: "Intranet", StartTime: time.Date(2024, time.April, 12, 9, 0, 0, 0, time.UTC)}
+	is.NoErr(os.Rename(filepath.Join(folder, filename.String()), filepath.Join(folder, filename.LegacyString())))
+	is.NoErr(os.Remove(filepath.Join(folder, "index.db")))
+	is.NoErr(os.WriteFile(filepath.Join(folder, "v3.broken1.SW50cmFuZXQ.1712908800.json"), []byte("{"), 0666))
+	// the folder must be older than the delay of the last session pointer
+	old := time.Now().Add(-time.Hour)
+	is.NoErr(os.Chtimes(folder, old, old))
+	before := folderContent(t, folder)
+
+	storeRepository := &filesystem.FileSystemSessionRepository{FlowFolderPath: folder, ReadOnly: true, AutoQuarantine: true}
+	dateProvider := infra.NewStubDateProvider()
+	dateProvider.Now = time.Date(2024, time.April, 13, 12, 0, 0, 0, time.UTC)
+	app := test.InitializeApp(&infra.InMemorySessionRepository{}, dateProvider)
+	app.FederatedReportUseCase = federatedreport.NewFederatedReportUseCase([]application.ReportStore{
+		{
+			Name:              "work",
+			SessionRepository: infra.NewReadOnlySessionRepository(storeRepository),
+			ProjectRepository: &infra.InMemoryProjectRepository{},
+			JournalRepository: &infra.InMemoryJournalRepository{},
+		},
+	}, dateProvider)
+
+	for _, format := range []string{"by-day", "by-project", "by-client"} {
+		_, err := test.ExecuteCmd(t, report.Command(app, &infra.InMemoryClipboard{}), "--week", "--format", format, "--stores", "work")
+		is.NoErr(err)
+	}
+	last, err := storeRepository.FindLastSession()
+	is.NoErr(err)
+	is.Equal(last.Id, "2")
+	is.Equal(storeRepository.FindAllProjects(), []string{"Intranet"})
+	is.Equal(len(storeRepository.FindAllSessions(&application.SessionsFilters{Tags: []string{"meeting"}})), 1)
+	found, err := storeRepository.FindById("1")
+	is.NoErr(err)
+	is.Equal(found.Status(), session.UnstoppedStatus)
+
+	is.Equal(folderContent(t, folder), before)
+}
@@ -1,26 +1,126 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"log"
+	"math/rand/v2"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/TristanShz/flow/cmd/abort"
+	"github.com/TristanShz/flow/cmd/adjust"
+	"github.com/TristanShz/flow/cmd/backup"
+	"github.com/TristanShz/flow/cmd/client"
+	"github.com/TristanShz/flow/cmd/completion"
+	"github.com/TristanShz/flow/cmd/daemon"
+	"github.com/TristanShz/flow/cmd/dashboard"
+	"github.com/TristanShz/flow/cmd/diff"
+	"github.com/TristanShz/flow/cmd/doctor"
 	"github.com/TristanShz/flow/cmd/edit"
+	"github.com/TristanShz/flow/cmd/export"
+	"github.com/TristanShz/flow/cmd/flowconfig"
+	"github.com/TristanShz/flow/cmd/flowdelete"
+	"github.com/TristanShz/flow/cmd/flowimport"
+	"github.com/TristanShz/flow/cmd/flowlog"
+	"github.com/TristanShz/flow/cmd/forecast"
+	"github.com/TristanShz/flow/cmd/goals"
+	"github.com/TristanShz/flow/cmd/heatmap"
+	"github.com/TristanShz/flow/cmd/help"
+	"github.com/TristanShz/flow/cmd/invoice"
+	"github.com/TristanShz/flow/cmd/journal"
+	"github.com/TristanShz/flow/cmd/merge"
+	"github.com/TristanShz/flow/cmd/migrate"
+	"github.com/TristanShz/flow/cmd/pomodoro"
+	"github.com/TristanShz/flow/cmd/projects"
 	"github.com/TristanShz/flow/cmd/report"
+	"github.com/TristanShz/flow/cmd/run"
+	"github.com/TristanShz/flow/cmd/sandbox"
+	"github.com/TristanShz/flow/cmd/serve"
+	"github.com/TristanShz/flow/cmd/show"
+	"github.com/TristanShz/flow/cmd/split"
 	"github.com/TristanShz/flow/cmd/start"
+	"github.com/TristanShz/flow/cmd/stats"
 	"github.com/TristanShz/flow/cmd/status"
 	"github.com/TristanShz/flow/cmd/stop"
+	"github.com/TristanShz/flow/cmd/store"
+	"github.com/TristanShz/flow/cmd/tags"
+	"github.com/TristanShz/flow/cmd/templates"
+	"github.com/TristanShz/flow/cmd/trash"
+	"github.com/TristanShz/flow/cmd/undo"
+	"github.com/TristanShz/flow/internal/application"
 	app "github.com/TristanShz/flow/internal/application/usecases"
+	"github.com/TristanShz/flow/internal/application/usecases/backup/createbackup"
+	"github.com/TristanShz/flow/internal/application/usecases/backup/listbackups"
+	"github.com/TristanShz/flow/internal/application/usecases/backup/restorebackup"
+	"github.com/TristanShz/flow/internal/application/usecases/client/listclients"
+	"github.com/TristanShz/flow/internal/application/usecases/client/setclient"
+	"github.com/TristanShz/flow/internal/application/usecases/export/exportsessions"
 	abortsession "github.com/TristanShz/flow/internal/application/usecases/flowsession/abort"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/adjustsession"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/autostop"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/deletesession"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/deletesessions"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/diffperiods"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/editsession"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/federatedreport"
+	flowheatmap "github.com/TristanShz/flow/internal/application/usecases/flowsession/heatmap"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/listsessionmetadata"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/listsessions"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/logsession"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/meetingpause"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/mergesessions"
+	pomodorosession "github.com/TristanShz/flow/internal/application/usecases/flowsession/pomodoro"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/reminders"
 	"github.com/TristanShz/flow/internal/application/usecases/flowsession/sessionstatus"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/showsession"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/splitsession"
 	startsession "github.com/TristanShz/flow/internal/application/usecases/flowsession/start"
+	flowstats "github.com/TristanShz/flow/internal/application/usecases/flowsession/stats"
 	"github.com/TristanShz/flow/internal/application/usecases/flowsession/stopsession"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/suggeststop"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/suggesttags"
 	"github.com/TristanShz/flow/internal/application/usecases/flowsession/viewsessionsreport"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/weeklytrend"
+	"github.com/TristanShz/flow/internal/application/usecases/import/importsessions"
+	"github.com/TristanShz/flow/internal/application/usecases/import/resolveconflicts"
+	"github.com/TristanShz/flow/internal/application/usecases/invoice/createinvoices"
+	"github.com/TristanShz/flow/internal/application/usecases/journal/addjournalentry"
+	"github.com/TristanShz/flow/internal/application/usecases/journal/listjournal"
+	"github.com/TristanShz/flow/internal/application/usecases/operation/listoperations"
+	undooperation "github.com/TristanShz/flow/internal/application/usecases/operation/undo"
+	forecastproject "github.com/TristanShz/flow/internal/application/usecases/project/forecast"
+	projectgoals "github.com/TristanShz/flow/internal/application/usecases/project/goals"
 	"github.com/TristanShz/flow/internal/application/usecases/project/list"
+	"github.com/TristanShz/flow/internal/application/usecases/project/listtags"
+	"github.com/TristanShz/flow/internal/application/usecases/project/renameproject"
+	"github.com/TristanShz/flow/internal/application/usecases/project/setproject"
+	"github.com/TristanShz/flow/internal/application/usecases/project/synctemplates"
+	storearchive "github.com/TristanShz/flow/internal/application/usecases/store/archive"
+	storedoctor "github.com/TristanShz/flow/internal/application/usecases/store/doctor"
+	storeinfo "github.com/TristanShz/flow/internal/application/usecases/store/info"
+	storemigrate "github.com/TristanShz/flow/internal/application/usecases/store/migrate"
+	"github.com/TristanShz/flow/internal/application/usecases/tag/deletetag"
+	"github.com/TristanShz/flow/internal/application/usecases/tag/renametag"
+	"github.com/TristanShz/flow/internal/application/usecases/tag/retagsessions"
+	"github.com/TristanShz/flow/internal/application/usecases/trash/listtrash"
+	"github.com/TristanShz/flow/internal/application/usecases/trash/purgetrash"
+	"github.com/TristanShz/flow/internal/application/usecases/trash/restoresession"
 	"github.com/TristanShz/flow/internal/infra"
+	"github.com/TristanShz/flow/internal/infra/age"
+	"github.com/TristanShz/flow/internal/infra/config"
 	"github.com/TristanShz/flow/internal/infra/filesystem"
+	"github.com/TristanShz/flow/internal/infra/hooks"
+	"github.com/TristanShz/flow/internal/infra/jira"
+	"github.com/TristanShz/flow/internal/infra/notify"
+	"github.com/TristanShz/flow/internal/infra/passphrase"
+	"github.com/TristanShz/flow/internal/infra/remote"
+	"github.com/TristanShz/flow/internal/infra/socket"
+	"github.com/TristanShz/flow/internal/infra/system"
+	"github.com/TristanShz/flow/internal/infra/webhook"
 	"github.com/spf13/cobra"
 )
 
@@ -32,23 +132,213 @@ var rootCmd = &cobra.Command{
 	},
 }
 
-func initializeApp(path string) *app.App {
-	sessionRepository := filesystem.NewFileSystemSessionRepository(path)
+// initializeApp creates the app storing its data in path, the session
+// writes fail with the faults, which are set once the flags are parsed. The
+// sessions and the active session go through the daemon client when it's
+// not nil. It also returns the server of the sessions of 'flow daemon', and
+// the publisher of the events the webhooks and the hooks are given, and the
+// recorder of the operations 'flow undo' reverts.
+func initializeApp(path string, userConfig application.Config, faults *infra.Faults, daemonClient *socket.Client) (*app.App, *socket.Server, application.EventPublisher, *infra.OperationRecorder) {
+	fileSystemSessionRepository := filesystem.NewFileSystemSessionRepository(path)
+	if userConfig.Encryption.UsesPassphrase() {
+		fileSystemSessionRepository.Cipher = passphrase.NewCipher(userConfig.Encryption.Passphrase, userConfig.Encryption.KeyFile)
+	} else if userConfig.Encryption.Enabled() || userConfig.Encryption.Identity != "" {
+		fileSystemSessionRepository.Cipher = age.NewCipher(userConfig.Encryption.Recipients, userConfig.Encryption.Identity)
+	}
+	fileSystemSessionRepository.TrashRetention = userConfig.TrashRetention()
+	// the repository is shared by the concurrent requests of 'flow serve' and
+	// of the clients of 'flow daemon'
+	localSessionRepository := infra.NewSyncSessionRepository(&fileSystemSessionRepository)
+	fileSystemActiveSessionLock := filesystem.NewFileSystemActiveSessionLock(path)
+	sessionServer := socket.NewServer(socket.Path(path), localSessionRepository, &fileSystemActiveSessionLock)
+
+	var sessionRepository application.SessionRepository = localSessionRepository
+	var activeSessionLock application.ActiveSessionLock = &fileSystemActiveSessionLock
+	if daemonClient != nil {
+		sessionRepository = daemonClient
+		activeSessionLock = daemonClient
+	}
+	sessionRepository = infra.NewFaultySessionRepository(sessionRepository, faults, rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())))
+	operationLog := filesystem.NewFileSystemOperationLog(path, userConfig.UndoLimit())
+	operationLog.Cipher = fileSystemSessionRepository.Cipher
+	operationRecorder := infra.NewOperationRecorder(&operationLog)
+	// 'flow undo' changes the sessions without being recorded
+	unrecordedSessionRepository, unrecordedActiveSessionLock := sessionRepository, activeSessionLock
+	sessionRepository = operationRecorder.SessionRepository(sessionRepository)
+	activeSessionLock = operationRecorder.ActiveSessionLock(activeSessionLock)
+	clientRepository := filesystem.NewFileSystemClientRepository(path)
+	projectRepository := filesystem.NewFileSystemProjectRepository(path)
+	journalRepository := filesystem.NewFileSystemJournalRepository(path)
+	templatesRepository := filesystem.NewFileSystemTemplatesRepository(path)
+	importConflictsRepository := filesystem.NewFileSystemImportConflictsRepository(path)
+	invoiceRepository := filesystem.NewFileSystemInvoiceRepository(path)
+	// the trash is in the flow folder, even when the sessions go through the
+	// daemon
+	sessionTrash := &fileSystemSessionRepository
+	backupStore := filesystem.NewFileSystemBackupStore(path, userConfig.Backups.BackupFolder(path))
+	backupStore.Cipher = fileSystemSessionRepository.Cipher
+	reportStores := []application.ReportStore{{
+		Name:              application.LocalStore,
+		SessionRepository: sessionRepository,
+		ProjectRepository: &projectRepository,
+		JournalRepository: &journalRepository,
+	}}
+	storeNames := []string{}
+	for name := range userConfig.Stores {
+		storeNames = append(storeNames, name)
+	}
+	slices.Sort(storeNames)
+	for _, name := range storeNames {
+		// the folder of a store isn't created when it's missing, its report
+		// is empty, and reading it never writes to it
+		storeRepository := &filesystem.FileSystemSessionRepository{FlowFolderPath: userConfig.Stores[name], ReadOnly: true}
+		if userConfig.Encryption.UsesPassphrase() {
+			storeRepository.Cipher = passphrase.NewCipher(userConfig.Encryption.Passphrase, userConfig.Encryption.KeyFile)
+		} else if userConfig.Encryption.Identity != "" {
+			storeRepository.Cipher = age.NewCipher(nil, userConfig.Encryption.Identity)
+		}
+		storeProjectRepository := filesystem.NewFileSystemProjectRepository(userConfig.Stores[name])
+		storeJournalRepository := filesystem.NewFileSystemJournalRepository(userConfig.Stores[name])
+		reportStores = append(reportStores, application.ReportStore{
+			Name:              name,
+			SessionRepository: infra.NewReadOnlySessionRepository(storeRepository),
+			ProjectRepository: &storeProjectRepository,
+			JournalRepository: &storeJournalRepository,
+		})
+	}
+	templatesFetcher := remote.NewTemplatesFetcher()
+	auditLog := filesystem.NewFileSystemAuditLog(path)
+	eventBus := &application.EventBus{}
+	// the hook scripts copied to the sandbox would reach the real services
+	if !userConfig.Sandbox {
+		eventBus.Subscribe(hooks.NewRunner(path, os.Stderr, log.New(os.Stderr, "", 0)).Handle)
+	}
+	if len(userConfig.Webhooks) > 0 {
+		notifier, err := webhook.NewNotifier(userConfig.Webhooks, log.New(os.Stderr, "", 0))
+		if err != nil {
+			log.Fatal(err)
+		}
+		eventBus.Subscribe(notifier.Handle)
+	}
+	if userConfig.Jira.URL != "" {
+		eventBus.Subscribe(jira.NewWorklogger(userConfig.Jira, log.New(os.Stderr, "", 0)).Handle)
+	}
 
 	dateProvider := &infra.RealDateProvider{}
-	idProvider := &infra.RealIDProvider{}
+	sessionIDProvider := filesystem.NewSessionIDProvider(&fileSystemSessionRepository, &infra.RealIDProvider{})
+	idProvider := &sessionIDProvider
+
+	startFlowSessionUseCase := startsession.NewStartFlowSessionUseCase(sessionRepository, dateProvider, idProvider, activeSessionLock, &projectRepository, &templatesRepository, eventBus)
+	stopFlowSessionUseCase := stopsession.NewStopSessionUseCase(sessionRepository, dateProvider, activeSessionLock, &projectRepository, idProvider, eventBus)
+	abortFlowSessionUseCase := abortsession.NewAbortFlowSessionUseCase(sessionRepository, activeSessionLock)
+	flowSessionStatusUseCase := sessionstatus.NewFlowSessionStatusUseCase(sessionRepository, dateProvider)
+
+	viewSessionsReportUseCase := viewsessionsreport.NewViewSessionsReportUseCase(sessionRepository, &projectRepository, &journalRepository, dateProvider)
+
+	listProjectsUseCase := list.NewListProjectsUseCase(sessionRepository)
+
+	weeklyTrendUseCase := weeklytrend.NewWeeklyTrendUseCase(sessionRepository, &fileSystemSessionRepository, dateProvider)
+
+	setClientUseCase := setclient.NewSetClientUseCase(&clientRepository)
+
+	listClientsUseCase := listclients.NewListClientsUseCase(&clientRepository)
+
+	doctorUseCase := storedoctor.NewDoctorUseCase(&fileSystemSessionRepository, sessionRepository)
+
+	migrateUseCase := storemigrate.NewMigrateUseCase(&fileSystemSessionRepository)
+
+	suggestTagsUseCase := suggesttags.NewSuggestTagsUseCase(sessionRepository)
+
+	exportSessionsUseCase := exportsessions.NewExportSessionsUseCase(sessionRepository, &projectRepository, &clientRepository, &journalRepository)
+
+	setProjectUseCase := setproject.NewSetProjectUseCase(&projectRepository)
+
+	autostopUseCase := autostop.NewAutostopUseCase(sessionRepository, &projectRepository, idProvider, activeSessionLock)
+
+	editSessionUseCase := editsession.NewEditSessionUseCase(sessionRepository, dateProvider, eventBus)
+
+	logSessionUseCase := logsession.NewLogSessionUseCase(sessionRepository, dateProvider, idProvider)
+
+	splitSessionUseCase := splitsession.NewSplitSessionUseCase(sessionRepository, idProvider)
+
+	mergeSessionsUseCase := mergesessions.NewMergeSessionsUseCase(sessionRepository)
+
+	renameProjectUseCase := renameproject.NewRenameProjectUseCase(sessionRepository, &projectRepository)
+
+	renameTagUseCase := renametag.NewRenameTagUseCase(sessionRepository)
+
+	deleteTagUseCase := deletetag.NewDeleteTagUseCase(sessionRepository)
+
+	retagSessionsUseCase := retagsessions.NewRetagSessionsUseCase(sessionRepository)
+
+	diffPeriodsUseCase := diffperiods.NewDiffPeriodsUseCase(sessionRepository)
+
+	infoUseCase := storeinfo.NewInfoUseCase(&fileSystemSessionRepository)
+
+	showSessionUseCase := showsession.NewShowSessionUseCase(sessionRepository)
+
+	syncTemplatesUseCase := synctemplates.NewSyncTemplatesUseCase(templatesFetcher, &templatesRepository)
+
+	adjustSessionUseCase := adjustsession.NewAdjustSessionUseCase(sessionRepository, dateProvider, &auditLog)
+
+	meetingPauseUseCase := meetingpause.NewMeetingPauseUseCase(sessionRepository, &projectRepository, idProvider, activeSessionLock, eventBus)
+
+	listProjectTagsUseCase := listtags.NewListProjectTagsUseCase(sessionRepository)
+
+	deleteSessionUseCase := deletesession.NewDeleteSessionUseCase(sessionRepository, activeSessionLock)
+
+	importSessionsUseCase := importsessions.NewImportSessionsUseCase(sessionRepository, &importConflictsRepository, idProvider)
+
+	addJournalEntryUseCase := addjournalentry.NewAddJournalEntryUseCase(&journalRepository, dateProvider)
+
+	listJournalUseCase := listjournal.NewListJournalUseCase(&journalRepository)
+
+	pomodoroUseCase := pomodorosession.NewPomodoroUseCase(sessionRepository, dateProvider, startFlowSessionUseCase, stopFlowSessionUseCase)
 
-	startFlowSessionUseCase := startsession.NewStartFlowSessionUseCase(&sessionRepository, dateProvider, idProvider)
-	stopFlowSessionUseCase := stopsession.NewStopSessionUseCase(&sessionRepository, dateProvider)
-	abortFlowSessionUseCase := abortsession.NewAbortFlowSessionUseCase(&sessionRepository)
-	flowSessionStatusUseCase := sessionstatus.NewFlowSessionStatusUseCase(&sessionRepository, dateProvider)
+	remindersUseCase := reminders.NewRemindersUseCase(sessionRepository, dateProvider)
 
-	viewSessionsReportUseCase := viewsessionsreport.NewViewSessionsReportUseCase(&sessionRepository)
+	suggestStopUseCase := suggeststop.NewSuggestStopUseCase(sessionRepository, dateProvider)
 
-	listProjectsUseCase := list.NewListProjectsUseCase(&sessionRepository)
+	forecastUseCase := forecastproject.NewForecastUseCase(sessionRepository, dateProvider)
 
-	return app.NewApp(
-		&sessionRepository,
+	statsUseCase := flowstats.NewStatsUseCase(sessionRepository, dateProvider)
+
+	heatmapUseCase := flowheatmap.NewHeatmapUseCase(sessionRepository, dateProvider)
+
+	resolveConflictsUseCase := resolveconflicts.NewResolveConflictsUseCase(sessionRepository, &importConflictsRepository, idProvider)
+
+	goalsUseCase := projectgoals.NewGoalsUseCase(sessionRepository, &projectRepository, dateProvider)
+
+	createInvoicesUseCase := createinvoices.NewCreateInvoicesUseCase(sessionRepository, &projectRepository, &clientRepository, &invoiceRepository, dateProvider)
+
+	federatedReportUseCase := federatedreport.NewFederatedReportUseCase(reportStores, dateProvider)
+
+	listTrashUseCase := listtrash.NewListTrashUseCase(sessionTrash)
+
+	restoreSessionUseCase := restoresession.NewRestoreSessionUseCase(sessionRepository, sessionTrash)
+
+	purgeTrashUseCase := purgetrash.NewPurgeTrashUseCase(sessionTrash, dateProvider)
+
+	undoUseCase := undooperation.NewUndoUseCase(unrecordedSessionRepository, unrecordedActiveSessionLock, &operationLog)
+
+	listOperationsUseCase := listoperations.NewListOperationsUseCase(&operationLog)
+
+	deleteSessionsUseCase := deletesessions.NewDeleteSessionsUseCase(sessionRepository, activeSessionLock)
+
+	archiveUseCase := storearchive.NewArchiveUseCase(&fileSystemSessionRepository, dateProvider)
+
+	createBackupUseCase := createbackup.NewCreateBackupUseCase(&backupStore, dateProvider)
+
+	restoreBackupUseCase := restorebackup.NewRestoreBackupUseCase(sessionRepository, activeSessionLock, &backupStore)
+
+	listBackupsUseCase := listbackups.NewListBackupsUseCase(&backupStore)
+
+	listSessionsUseCase := listsessions.NewListSessionsUseCase(sessionRepository)
+
+	listSessionMetadataUseCase := listsessionmetadata.NewListSessionMetadataUseCase(sessionRepository)
+
+	a := app.NewApp(
+		sessionRepository,
 		dateProvider,
 		startFlowSessionUseCase,
 		stopFlowSessionUseCase,
@@ -56,27 +346,199 @@ func initializeApp(path string) *app.App {
 		flowSessionStatusUseCase,
 		listProjectsUseCase,
 		viewSessionsReportUseCase,
+		weeklyTrendUseCase,
+		setClientUseCase,
+		listClientsUseCase,
+		doctorUseCase,
+		migrateUseCase,
+		suggestTagsUseCase,
+		exportSessionsUseCase,
+		setProjectUseCase,
+		autostopUseCase,
+		editSessionUseCase,
+		logSessionUseCase,
+		splitSessionUseCase,
+		mergeSessionsUseCase,
+		renameProjectUseCase,
+		renameTagUseCase,
+		deleteTagUseCase,
+		retagSessionsUseCase,
+		diffPeriodsUseCase,
+		infoUseCase,
+		showSessionUseCase,
+		syncTemplatesUseCase,
+		adjustSessionUseCase,
+		meetingPauseUseCase,
+		listProjectTagsUseCase,
+		deleteSessionUseCase,
+		importSessionsUseCase,
+		addJournalEntryUseCase,
+		listJournalUseCase,
+		pomodoroUseCase,
+		remindersUseCase,
+		suggestStopUseCase,
+		forecastUseCase,
+		statsUseCase,
+		heatmapUseCase,
+		resolveConflictsUseCase,
+		goalsUseCase,
+		createInvoicesUseCase,
+		federatedReportUseCase,
+		listTrashUseCase,
+		restoreSessionUseCase,
+		purgeTrashUseCase,
+		undoUseCase,
+		listOperationsUseCase,
+		deleteSessionsUseCase,
+		archiveUseCase,
+		createBackupUseCase,
+		restoreBackupUseCase,
+		listBackupsUseCase,
+		listSessionsUseCase,
+		listSessionMetadataUseCase,
 	)
+	a.Config = userConfig
+
+	return a, sessionServer, eventBus, operationRecorder
+}
+
+// storeFlag returns the path given to --store. It's read before the flags
+// are parsed, as the app is initialized with the store.
+func storeFlag(args []string) string {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+
+		if value, ok := strings.CutPrefix(arg, "--store="); ok {
+			return value
+		}
+		if arg == "--store" && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+
+	return ""
 }
 
 func Execute() {
+	configPath := config.Path(os.Getenv)
+	userConfig, err := config.Load(configPath, os.Getenv)
+	// an invalid config can still be fixed with 'flow config edit'
+	if err != nil && (len(os.Args) < 2 || os.Args[1] != "config") {
+		log.Fatalf("%v\nRun 'flow config edit' to fix it", err)
+	}
+
 	homePath, err := os.UserHomeDir()
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	sessionsPath := filepath.Join(homePath, ".flow")
+	sessionsPath := config.FlowFolder(userConfig.FlowFolder, homePath, os.Getenv)
+	if store := storeFlag(os.Args[1:]); store != "" {
+		// the daemon socket and the sandbox are found from the absolute path
+		sessionsPath, err = filepath.Abs(store)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	// the commands are clients of 'flow daemon' when it's running, they read
+	// the flow folder themselves otherwise
+	daemonClient, err := socket.Dial(socket.Path(sessionsPath), log.New(os.Stderr, "", 0))
+	if err != nil {
+		daemonClient = nil
+	}
 
-	app := initializeApp(sessionsPath)
+	faults := &infra.Faults{}
+	app, sessionServer, eventPublisher, operationRecorder := initializeApp(sessionsPath, userConfig, faults, daemonClient)
+
+	clipboard := system.NewClipboard()
 
 	rootCmd.AddCommand(start.Command(app))
-	rootCmd.AddCommand(stop.Command(app))
+	rootCmd.AddCommand(stop.Command(app, system.NewIdleDetector(), system.NewShellHistoryActivityDetector(homePath, os.Getenv)))
 	rootCmd.AddCommand(status.Command(app))
-	rootCmd.AddCommand(report.Command(app))
+	rootCmd.AddCommand(report.Command(app, clipboard))
 	rootCmd.AddCommand(edit.Command(app, sessionsPath))
 	rootCmd.AddCommand(abort.Command(app))
+	rootCmd.AddCommand(projects.Command(app, clipboard))
+	rootCmd.AddCommand(client.Command(app, clipboard))
+	rootCmd.AddCommand(doctor.Command(app))
+	rootCmd.AddCommand(run.Command(app))
+	rootCmd.AddCommand(migrate.Command(app))
+	rootCmd.AddCommand(serve.Command(app))
+	rootCmd.AddCommand(export.Command(app))
+	rootCmd.AddCommand(flowlog.Command(app))
+	rootCmd.AddCommand(split.Command(app))
+	rootCmd.AddCommand(adjust.Command(app))
+	rootCmd.AddCommand(merge.Command(app))
+	var meetingWatcher application.MeetingWatcher
+	if userConfig.Calendar != "" {
+		meetingWatcher = remote.NewCalendarMeetingWatcher(userConfig.Calendar, app.DateProvider)
+	}
+	rootCmd.AddCommand(daemon.Command(app, system.NewLockWatcher(), meetingWatcher, notify.NewNotifier(), eventPublisher, sessionServer))
+	rootCmd.AddCommand(dashboard.Command(app))
+	rootCmd.AddCommand(tags.Command(app))
+	rootCmd.AddCommand(diff.Command(app))
+	rootCmd.AddCommand(forecast.Command(app))
+	rootCmd.AddCommand(stats.Command(app))
+	rootCmd.AddCommand(heatmap.Command(app))
+	rootCmd.AddCommand(goals.Command(app))
+	rootCmd.AddCommand(invoice.Command(app))
+	rootCmd.AddCommand(store.Command(app))
+	rootCmd.AddCommand(show.Command(app))
+	rootCmd.AddCommand(trash.Command(app))
+	rootCmd.AddCommand(backup.Command(app))
+	rootCmd.AddCommand(undo.Command(app))
+	rootCmd.AddCommand(flowdelete.Command(app))
+	rootCmd.AddCommand(templates.Command(app))
+	rootCmd.AddCommand(flowimport.Command(app, filepath.Join(sessionsPath, filesystem.ImportConflictsFilename)))
+	rootCmd.AddCommand(journal.Command(app, clipboard))
+	rootCmd.AddCommand(pomodoro.Command(app, notify.NewNotifier()))
+	rootCmd.AddCommand(flowconfig.Command(configPath, system.CommandEditor{Getenv: os.Getenv}))
+	rootCmd.AddCommand(sandbox.Command(sessionsPath))
+	rootCmd.AddCommand(completion.Command())
+
+	// --store is read by storeFlag, it's declared for the help and for the
+	// flags parsing to accept it
+	rootCmd.PersistentFlags().String("store", "", "Use the store at the given path instead of the flow folder, for this command only")
+
+	// --inject-faults makes the session writes fail, to check that the
+	// commands and 'flow doctor' recover from storage failures
+	rootCmd.PersistentFlags().String("inject-faults", "", "Storage faults to inject, like errors=0.1,partial=0.05,latency=50ms")
+	rootCmd.PersistentFlags().MarkHidden("inject-faults")
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, _ []string) error {
+		// the long running commands would be undone as a whole
+		if !slices.Contains([]string{"undo", "serve", "daemon", "dashboard"}, cmd.Name()) || cmd.Parent() != rootCmd {
+			operationRecorder.Begin(application.Operation{
+				Id:      infra.RealIDProvider{}.Provide(),
+				At:      time.Now(),
+				Command: strings.TrimPrefix(cmd.CommandPath(), rootCmd.Name()+" "),
+			})
+		}
+
+		spec, _ := cmd.Flags().GetString("inject-faults")
+		if spec == "" {
+			return nil
+		}
+
+		parsed, err := infra.ParseFaults(spec)
+		if err != nil {
+			return err
+		}
+		*faults = parsed
+
+		return nil
+	}
+
+	rootCmd.SetHelpCommand(help.Command(rootCmd))
+	help.AddExamplesFlag(rootCmd)
 
 	if err := rootCmd.Execute(); err != nil {
+		var exitErr *run.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
 		os.Exit(1)
 	}
 }
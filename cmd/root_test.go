@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestStoreFlag(t *testing.T) {
+	tt := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			name: "No store",
+			args: []string{"report", "--day"},
+			want: "",
+		},
+		{
+			name: "Store before the command",
+			args: []string{"--store", "/tmp/other", "report", "--day"},
+			want: "/tmp/other",
+		},
+		{
+			name: "Store after the command",
+			args: []string{"report", "--day", "--store=/tmp/other"},
+			want: "/tmp/other",
+		},
+		{
+			name: "Stores of the report aren't the store",
+			args: []string{"report", "--stores", "local", "--day"},
+			want: "",
+		},
+		{
+			name: "Store and stores of the report",
+			args: []string{"--store", "/tmp/other", "report", "--stores", "work,local"},
+			want: "/tmp/other",
+		},
+		{
+			name: "Store after --",
+			args: []string{"run", "--", "make", "--store", "/tmp/other"},
+			want: "",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			is.Equal(storeFlag(tc.args), tc.want)
+		})
+	}
+}
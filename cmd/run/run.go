@@ -0,0 +1,114 @@
+package run
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/TristanShz/flow/cmd/completion"
+	app "github.com/TristanShz/flow/internal/application/usecases"
+	startsession "github.com/TristanShz/flow/internal/application/usecases/flowsession/start"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/stopsession"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra/process"
+	"github.com/TristanShz/flow/utils"
+	"github.com/spf13/cobra"
+)
+
+// ExitError is returned when the wrapped command fails, so that flow exits
+// with the same code
+type ExitError struct {
+	Code int
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("command exited with code %v", e.Code)
+}
+
+func Command(app *app.App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "run --project [project] -- [command]",
+		Example: "run --project my-todo --tag build -- make build",
+		Short:   "Track the time spent running a command",
+		Long:    "Start a flow session, run the command and stop the session once the command exits. The command line and its exit code are saved in the session metadata.",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return errors.New("a command to run is required")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logger := log.New(cmd.OutOrStdout(), "", 0)
+
+			projectFlag, _ := cmd.Flags().GetString("project")
+			if projectFlag == "" {
+				return errors.New("the project is required")
+			}
+			tagFlag, _ := cmd.Flags().GetStringSlice("tag")
+
+			commandLine := strings.Join(args, " ")
+
+			err := app.StartFlowSessionUseCase.Execute(startsession.Command{
+				Project:  projectFlag,
+				Tags:     tagFlag,
+				Metadata: map[string]string{session.CommandMetadata: commandLine},
+				TagRules: app.Config.TagRules,
+			})
+			if err != nil {
+				return err
+			}
+
+			logger.Printf("Running %v for the project %v", utils.TagColor(commandLine), utils.ProjectColor(projectFlag))
+
+			command := exec.Command(args[0], args[1:]...)
+			command.Stdin = os.Stdin
+			command.Stdout = cmd.OutOrStdout()
+			command.Stderr = cmd.ErrOrStderr()
+
+			var stopErr error
+			exitCode, err := process.RunAttached(command, func(exitCode int) {
+				duration, err := app.StopFlowSessionUseCase.Execute(stopsession.Command{
+					Metadata: map[string]string{session.ExitCodeMetadata: strconv.Itoa(exitCode)},
+					TagRules: app.Config.TagRules,
+				})
+				if err == stopsession.ErrClockWentBackwards {
+					logger.Printf("Warning: %v", err)
+					return
+				}
+				if err != nil {
+					stopErr = err
+					return
+				}
+
+				logger.Printf("Flow session stopped, the command ran for %v", utils.TimeColor(duration.String()))
+			})
+			if err != nil {
+				// the command never started, there is nothing to track
+				app.AbortFlowSessionUseCase.Execute()
+				return err
+			}
+
+			if stopErr != nil {
+				return stopErr
+			}
+
+			if exitCode != 0 {
+				cmd.SilenceUsage = true
+				return &ExitError{Code: exitCode}
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("project", "p", "", "Project of the session")
+	cmd.Flags().StringSliceP("tag", "t", []string{}, "Tags of the session")
+
+	completion.RegisterProjectFlag(cmd, app)
+
+	return cmd
+}
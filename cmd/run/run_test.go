@@ -0,0 +1,85 @@
+package run_test
+
+import (
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/cmd/run"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/TristanShz/flow/test"
+	"github.com/matryer/is"
+)
+
+func TestRunCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("wrapped commands rely on sh")
+	}
+
+	sessionRepository := &infra.InMemorySessionRepository{}
+	dateProvider := infra.NewStubDateProvider()
+	dateProvider.Now = time.Date(2024, time.April, 14, 10, 12, 0, 0, time.UTC)
+	app := test.InitializeApp(sessionRepository, dateProvider)
+
+	tt := []struct {
+		error        error
+		name         string
+		want         string
+		args         []string
+		wantMetadata map[string]string
+	}{
+		{
+			name:  "No command",
+			args:  []string{"--project", "Flow"},
+			error: errors.New("a command to run is required"),
+		},
+		{
+			name:  "No project",
+			args:  []string{"--", "echo", "hello"},
+			error: errors.New("the project is required"),
+		},
+		{
+			name: "Successful command",
+			args: []string{"--project", "Flow", "--", "echo", "hello"},
+			want: "Running echo hello for the project Flow\nhello\nFlow session stopped, the command ran for 0s",
+			wantMetadata: map[string]string{
+				session.CommandMetadata:  "echo hello",
+				session.ExitCodeMetadata: "0",
+			},
+		},
+		{
+			name:  "Failing command",
+			args:  []string{"--project", "Flow", "--", "sh", "-c", "exit 3"},
+			error: &run.ExitError{Code: 3},
+			wantMetadata: map[string]string{
+				session.CommandMetadata:  "sh -c exit 3",
+				session.ExitCodeMetadata: "3",
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			sessionRepository.Sessions = []session.Session{}
+
+			got, err := test.ExecuteCmd(t, run.Command(app), tc.args...)
+
+			is.Equal(tc.error, err)
+
+			if tc.error == nil {
+				is.Equal(tc.want, got)
+			}
+
+			if tc.wantMetadata != nil {
+				lastSession, err := sessionRepository.FindLastSession()
+				is.NoErr(err)
+				is.Equal(lastSession.Status(), session.EndedStatus)
+				is.Equal(lastSession.Metadata, tc.wantMetadata)
+			}
+		})
+	}
+}
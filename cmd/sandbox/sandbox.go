@@ -0,0 +1,88 @@
+package sandbox
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+
+	"github.com/TristanShz/flow/cmd/run"
+	"github.com/TristanShz/flow/internal/infra/config"
+	"github.com/TristanShz/flow/internal/infra/filesystem"
+	"github.com/TristanShz/flow/internal/infra/process"
+	"github.com/spf13/cobra"
+)
+
+// Command opens a shell, or runs the given command, with a throwaway copy of
+// the store at storePath, removed once it exits
+func Command(storePath string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "sandbox [-- command]",
+		Example: "sandbox\nsandbox --empty\nsandbox -- flow import toggl export.csv\nsandbox --keep -- flow migrate",
+		Short:   "Experiment on a throwaway copy of the store",
+		Long:    "Copy the store to a temporary folder and open a shell, or run the given command, in which flow uses the copy. The copy is removed once the shell or the command exits, so that commands, imports and migrations can be tried without changing the sessions. The hooks, webhooks, Jira worklogs, export to Toggl and scheduled backups are off in the sandbox.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logger := log.New(cmd.OutOrStdout(), "", 0)
+
+			sandboxPath, err := os.MkdirTemp("", "flow-sandbox-")
+			if err != nil {
+				return err
+			}
+
+			emptyFlag, _ := cmd.Flags().GetBool("empty")
+			if emptyFlag {
+				logger.Printf("Sandbox store at %v, empty", sandboxPath)
+			} else {
+				if err := filesystem.CopyStore(storePath, sandboxPath); err != nil {
+					os.RemoveAll(sandboxPath)
+					return fmt.Errorf("the store can't be copied to the sandbox: %w", err)
+				}
+				logger.Printf("Sandbox store at %v, a copy of %v", sandboxPath, storePath)
+			}
+
+			keepFlag, _ := cmd.Flags().GetBool("keep")
+			defer func() {
+				if keepFlag {
+					logger.Printf("Sandbox kept at %v", sandboxPath)
+					return
+				}
+
+				if err := os.RemoveAll(sandboxPath); err != nil {
+					logger.Printf("Warning: the sandbox can't be removed: %v", err)
+					return
+				}
+				logger.Printf("Sandbox removed, %v wasn't changed", storePath)
+			}()
+
+			command := process.Shell()
+			if len(args) > 0 {
+				command = exec.Command(args[0], args[1:]...)
+			} else {
+				logger.Println("Exit the shell to leave the sandbox")
+			}
+			// FLOW_SANDBOX turns the integrations off, and tells prompts the
+			// shell is in the sandbox
+			command.Env = append(os.Environ(), config.EnvDataDir+"="+sandboxPath, config.EnvSandbox+"="+sandboxPath)
+			command.Stdin = cmd.InOrStdin()
+			command.Stdout = cmd.OutOrStdout()
+			command.Stderr = cmd.ErrOrStderr()
+
+			exitCode, err := process.RunAttached(command, func(_ int) {})
+			if err != nil {
+				return err
+			}
+
+			if exitCode != 0 {
+				cmd.SilenceUsage = true
+				return &run.ExitError{Code: exitCode}
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool("empty", false, "Start from an empty store instead of a copy")
+	cmd.Flags().Bool("keep", false, "Keep the sandbox store once the shell or the command exits")
+
+	return cmd
+}
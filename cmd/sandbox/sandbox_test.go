@@ -0,0 +1,90 @@
+package sandbox_test
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/TristanShz/flow/cmd/run"
+	"github.com/TristanShz/flow/cmd/sandbox"
+	"github.com/TristanShz/flow/test"
+	"github.com/matryer/is"
+)
+
+func TestSandboxCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("sandboxed commands rely on sh")
+	}
+
+	// the sandboxed command lists the store it's given and writes into it
+	script := `echo "$FLOW_SANDBOX"; ls "$FLOW_DATA_DIR"; touch "$FLOW_DATA_DIR/2-Flow-1713089520.json"`
+
+	tt := []struct {
+		name      string
+		args      []string
+		wantLines []string
+		wantKept  bool
+		error     error
+	}{
+		{
+			name:      "Copy of the store",
+			args:      []string{"--", "sh", "-c", script},
+			wantLines: []string{`^Sandbox store at (.+), a copy of .+$`, `^(.+)$`, `^1-Flow-1713089520.json$`, `^Sandbox removed, .+ wasn't changed$`},
+		},
+		{
+			name:      "Empty store",
+			args:      []string{"--empty", "--", "sh", "-c", script},
+			wantLines: []string{`^Sandbox store at (.+), empty$`, `^(.+)$`, `^Sandbox removed, .+ wasn't changed$`},
+		},
+		{
+			name:      "Kept store",
+			args:      []string{"--keep", "--", "sh", "-c", script},
+			wantLines: []string{`^Sandbox store at (.+), a copy of .+$`, `^(.+)$`, `^1-Flow-1713089520.json$`, `^Sandbox kept at (.+)$`},
+			wantKept:  true,
+		},
+		{
+			name:      "Failing command",
+			args:      []string{"--", "sh", "-c", "exit 3"},
+			wantLines: []string{`^Sandbox store at (.+), a copy of .+$`, `^Sandbox removed, .+ wasn't changed$`, `^Error: command exited with code 3$`},
+			error:     &run.ExitError{Code: 3},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			storePath := t.TempDir()
+			os.WriteFile(filepath.Join(storePath, "1-Flow-1713089520.json"), []byte(`{"id":"1"}`), 0o600)
+
+			got, err := test.ExecuteCmd(t, sandbox.Command(storePath), tc.args...)
+
+			is.Equal(err, tc.error)
+			lines := strings.Split(got, "\n")
+			is.Equal(len(lines), len(tc.wantLines))
+
+			sandboxPaths := []string{}
+			for i, line := range lines {
+				match := regexp.MustCompile(tc.wantLines[i]).FindStringSubmatch(line)
+				is.True(match != nil) // the output line matches
+				if len(match) > 1 {
+					sandboxPaths = append(sandboxPaths, match[1])
+				}
+			}
+			for _, sandboxPath := range sandboxPaths {
+				is.Equal(sandboxPath, sandboxPaths[0]) // the command is given the sandbox store
+			}
+
+			entries, _ := os.ReadDir(storePath)
+			is.Equal(len(entries), 1) // the store isn't changed
+
+			_, err = os.Stat(sandboxPaths[0])
+			is.Equal(err == nil, tc.wantKept)
+			if tc.wantKept {
+				os.RemoveAll(sandboxPaths[0])
+			}
+		})
+	}
+}
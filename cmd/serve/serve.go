@@ -0,0 +1,49 @@
+package serve
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	app "github.com/TristanShz/flow/internal/application/usecases"
+	"github.com/TristanShz/flow/internal/infra/server"
+	"github.com/spf13/cobra"
+)
+
+const defaultPort = 4242
+
+func Command(app *app.App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "serve",
+		Example: "serve\nserve --host 0.0.0.0 --port 8080 --token my-secret\nserve --allow-origin '*'",
+		Short:   "Serve the current flow session and the flow API over HTTP",
+		Long:    "Serve the current flow session over HTTP. GET /current/stream streams the elapsed time of the current session every second as server-sent events, e.g. for a live overlay in a streaming software. The JSON API under /api starts and stops sessions, edits them and reads the projects, tags and reports",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			logger := log.New(cmd.OutOrStdout(), "", 0)
+
+			hostFlag, _ := cmd.Flags().GetString("host")
+			portFlag, _ := cmd.Flags().GetInt("port")
+
+			addr := fmt.Sprintf("%v:%v", hostFlag, portFlag)
+
+			s := server.NewServer(app)
+			s.Token, _ = cmd.Flags().GetString("token")
+			s.Members = app.Config.Members
+			s.AllowOrigin, _ = cmd.Flags().GetString("allow-origin")
+
+			logger.Printf("Listening on http://%v/current/stream and http://%v/api", addr, addr)
+			if len(s.Members) > 0 {
+				logger.Printf("The API is restricted to %v members", len(s.Members))
+			}
+
+			return http.ListenAndServe(addr, s.Handler())
+		},
+	}
+
+	cmd.Flags().String("host", "localhost", "Host to listen on")
+	cmd.Flags().IntP("port", "p", defaultPort, "Port to listen on")
+	cmd.Flags().String("token", "", "Token the API requires as a bearer token, recommended when listening on another host than localhost")
+	cmd.Flags().String("allow-origin", "", "Origin allowed to read the stream from a browser, like * for an overlay opened from a local file")
+
+	return cmd
+}
@@ -0,0 +1,107 @@
+package show
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/TristanShz/flow/cmd/completion"
+	app "github.com/TristanShz/flow/internal/application/usecases"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/showsession"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/utils"
+	"github.com/spf13/cobra"
+)
+
+// heatlineCells is the most blocks drawn in the heatline of a chain
+const heatlineCells = 60
+
+func formatSession(s session.Session) string {
+	text := fmt.Sprintf("%v %v %v %v", s.Id, utils.TimeColor(s.GetFormattedStartTime()), utils.TimeColor(s.Duration().String()), utils.ProjectColor(s.Project))
+
+	if len(s.Tags) > 0 {
+		text += fmt.Sprintf(" [%v]", utils.TagColor(strings.Join(s.Tags, ", ")))
+	}
+
+	if s.BlockedBy != "" {
+		text += utils.Faint(fmt.Sprintf(" blocked by: %v", s.BlockedBy))
+	}
+
+	return text
+}
+
+func Command(app *app.App) *cobra.Command {
+	return &cobra.Command{
+		Use:               "show [session_id (optional) (default: last session)]",
+		Example:           "show\nshow abc1234",
+		Short:             "Show a flow session and the sessions it's linked to",
+		Long:              "Show a flow session, and the chain of sessions continuing each other it belongs to, with their combined duration. The focus of a chain interrupted by breaks or idle time, spanning a day at most, is drawn as a heatline of blocks. Link sessions with 'flow edit --continues'",
+		ValidArgsFunction: completion.Sessions(app, 1),
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 1 {
+				return fmt.Errorf("too many arguments")
+			}
+			if len(args) == 1 && !utils.IsIDValid(args[0]) {
+				return fmt.Errorf("invalid ID %v", args[0])
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logger := log.New(cmd.OutOrStdout(), "", 0)
+
+			command := showsession.Command{}
+			if len(args) == 1 {
+				command.SessionId = args[0]
+			}
+
+			details, err := app.ShowSessionUseCase.Execute(command)
+			if err == showsession.ErrSessionNotFound {
+				logger.Println("Session not found")
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			s := details.Session
+
+			text := fmt.Sprintf("Session %v\n", s.Id)
+			text += fmt.Sprintf("Project: %v\n", utils.ProjectColor(s.Project))
+			if len(s.Tags) > 0 {
+				text += fmt.Sprintf("Tags: %v\n", utils.TagColor(strings.Join(s.Tags, ", ")))
+			}
+			text += fmt.Sprintf("Start: %v\n", utils.TimeColor(s.GetFormattedStartTime()))
+			text += fmt.Sprintf("End: %v\n", utils.TimeColor(s.GetFormattedEndTime()))
+			text += fmt.Sprintf("Duration: %v\n", utils.TimeColor(s.Duration().String()))
+			if s.Note != "" {
+				text += fmt.Sprintf("Note: %v\n", s.Note)
+			}
+			if s.Continues != "" {
+				text += fmt.Sprintf("Continues: %v\n", s.Continues)
+			}
+			if s.BlockedBy != "" {
+				text += fmt.Sprintf("Blocked by: %v\n", s.BlockedBy)
+			}
+
+			if len(details.Chain) > 1 {
+				text += fmt.Sprintf("\nChain of %v sessions - %v\n", len(details.Chain), utils.TimeColor(details.Chain.Duration().String()))
+				for _, chained := range details.Chain {
+					marker := "    "
+					if chained.Id == s.Id {
+						marker = "  > "
+					}
+					text += marker + formatSession(chained) + "\n"
+				}
+			}
+
+			if heatline, ok := details.Chain.Heatline(heatlineCells); ok && details.Chain.HasBreaks() {
+				text += fmt.Sprintf("\nFocus from %v to %v, a block every %v, %v for a break\n", utils.TimeColor(heatline.Start.Format("15:04")), utils.TimeColor(heatline.End.Format("15:04")), heatline.Cell, utils.Faint("░"))
+				text += "    " + utils.Heatline(heatline.Focus) + "\n"
+			}
+
+			logger.Print(text)
+
+			return nil
+		},
+	}
+}
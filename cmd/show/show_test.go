@@ -0,0 +1,92 @@
+package show_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/cmd/show"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/TristanShz/flow/test"
+	"github.com/matryer/is"
+)
+
+func TestShowCommand(t *testing.T) {
+	sessionRepository := &infra.InMemorySessionRepository{Sessions: []session.Session{
+		{
+			Id:        "3456789",
+			Project:   "Flow",
+			StartTime: time.Date(2024, 4, 10, 9, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, 4, 10, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			Id:        "4567890",
+			Project:   "Flow",
+			StartTime: time.Date(2024, 4, 10, 10, 15, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, 4, 10, 11, 0, 0, 0, time.UTC),
+			Continues: "3456789",
+			Metadata:  map[string]string{session.BreakMetadata: "15m0s"},
+		},
+		{
+			Id:        "1234567",
+			Project:   "Flow",
+			Tags:      []string{"docs"},
+			StartTime: time.Date(2024, 4, 15, 9, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, 4, 15, 11, 0, 0, 0, time.UTC),
+			BlockedBy: "waiting for the review",
+		},
+		{
+			Id:        "2345678",
+			Project:   "MyTodo",
+			StartTime: time.Date(2024, 4, 15, 14, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, 4, 15, 15, 0, 0, 0, time.UTC),
+		},
+		{
+			Id:        "7654321",
+			Project:   "Flow",
+			Tags:      []string{"docs"},
+			StartTime: time.Date(2024, 4, 16, 9, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, 4, 16, 10, 30, 0, 0, time.UTC),
+			Note:      "Finished the guides",
+			Continues: "1234567",
+		},
+	}}
+	app := test.InitializeApp(sessionRepository, infra.NewStubDateProvider())
+
+	tt := []struct {
+		name string
+		want string
+		args []string
+	}{
+		{
+			name: "Last session",
+			want: "Session 7654321\nProject: Flow\nTags: docs\nStart: 2024-04-16 09:00:00\nEnd: 2024-04-16 10:30:00\nDuration: 1h30m0s\nNote: Finished the guides\nContinues: 1234567\n\nChain of 2 sessions - 3h30m0s\n    1234567 2024-04-15 09:00:00 2h0m0s Flow [docs] blocked by: waiting for the review\n  > 7654321 2024-04-16 09:00:00 1h30m0s Flow [docs]",
+		},
+		{
+			name: "Session without links",
+			args: []string{"2345678"},
+			want: "Session 2345678\nProject: MyTodo\nStart: 2024-04-15 14:00:00\nEnd: 2024-04-15 15:00:00\nDuration: 1h0m0s",
+		},
+		{
+			name: "Heatline of a chain with breaks",
+			args: []string{"4567890"},
+			want: "Session 4567890\nProject: Flow\nStart: 2024-04-10 10:15:00\nEnd: 2024-04-10 11:00:00\nDuration: 45m0s\nContinues: 3456789\n\nChain of 2 sessions - 1h45m0s\n    3456789 2024-04-10 09:00:00 1h0m0s Flow\n  > 4567890 2024-04-10 10:15:00 45m0s Flow\n\nFocus from 09:00 to 11:00, a block every 2m0s, ░ for a break\n    ██████████████████████████████░░░░░░░▄██████████████████████",
+		},
+		{
+			name: "Session not found",
+			args: []string{"abcdefg"},
+			want: "Session not found",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			got, err := test.ExecuteCmd(t, show.Command(app), tc.args...)
+
+			is.NoErr(err)
+			is.Equal(tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package split
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/TristanShz/flow/cmd/completion"
+	"github.com/TristanShz/flow/cmd/history"
+	app "github.com/TristanShz/flow/internal/application/usecases"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/splitsession"
+	"github.com/TristanShz/flow/utils"
+	"github.com/spf13/cobra"
+)
+
+func Command(app *app.App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "split [session_id (optional) (default: last session)] --at [time]",
+		Example:           `split --at "2024-04-12 19:00" --second-note ""`,
+		Short:             "Split a flow session in two",
+		Long:              "Split a flow session in two at the given time, e.g. to fix a session that was left running overnight. Both parts keep the project, tags and note of the session",
+		ValidArgsFunction: completion.Sessions(app, 1),
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 1 {
+				return errors.New("too many arguments")
+			}
+			if len(args) == 1 && !utils.IsIDValid(args[0]) {
+				return fmt.Errorf("invalid ID %v", args[0])
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logger := log.New(cmd.OutOrStdout(), "", 0)
+
+			id := ""
+			if len(args) == 1 {
+				id = args[0]
+			} else {
+				lastSession, err := app.SessionRepository.FindLastSession()
+				if err != nil {
+					return err
+				}
+				if lastSession != nil {
+					id = lastSession.Id
+				}
+			}
+
+			atFlag, _ := cmd.Flags().GetString("at")
+			if atFlag == "" {
+				return errors.New("the split time is required")
+			}
+
+			session, err := app.SessionRepository.FindById(id)
+			if err != nil {
+				return err
+			}
+			if session == nil {
+				logger.Println("Session not found")
+				return nil
+			}
+
+			// a time without a date is on the day the session started
+			at, err := utils.ParseDateTime(atFlag, session.StartTime)
+			if err != nil {
+				return err
+			}
+			command := splitsession.Command{Id: id, At: at, LockedBefore: history.LockedBefore(cmd, app)}
+
+			if cmd.Flags().Changed("first-note") {
+				firstNote, _ := cmd.Flags().GetString("first-note")
+				command.FirstNote = &firstNote
+			}
+
+			if cmd.Flags().Changed("second-note") {
+				secondNote, _ := cmd.Flags().GetString("second-note")
+				command.SecondNote = &secondNote
+			}
+
+			parts, err := app.SplitSessionUseCase.Execute(command)
+			if err != nil {
+				return err
+			}
+
+			logger.Println("Session split in:")
+			for _, part := range parts {
+				logger.Printf("    %v %v - %v", part.Id, part.GetFormattedStartTime(), part.GetFormattedEndTime())
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("at", "", "Time to split the session at (YYYY-MM-DD HH:MM or HH:MM on the day the session started)")
+	cmd.Flags().String("first-note", "", "Note of the first part of the session")
+	cmd.Flags().String("second-note", "", "Note of the second part of the session")
+	history.AddFlag(cmd)
+
+	cmd.RegisterFlagCompletionFunc("at", completion.Times(app))
+
+	return cmd
+}
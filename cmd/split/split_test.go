@@ -0,0 +1,72 @@
+package split_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/cmd/split"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/splitsession"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/TristanShz/flow/test"
+	"github.com/matryer/is"
+)
+
+func TestSplitCommand(t *testing.T) {
+	tt := []struct {
+		error error
+		name  string
+		want  string
+		args  []string
+	}{
+		{
+			name: "Last session",
+			args: []string{"--at", "19:00"},
+			want: "Session split in:\n    1234567 2024-04-12 17:00:00 - 2024-04-12 19:00:00\n    abcdefg 2024-04-12 19:00:00 - 2024-04-13 09:00:00",
+		},
+		{
+			name: "Session by id",
+			args: []string{"1234567", "--at", "2024-04-13 08:00"},
+			want: "Session split in:\n    1234567 2024-04-12 17:00:00 - 2024-04-13 08:00:00\n    abcdefg 2024-04-13 08:00:00 - 2024-04-13 09:00:00",
+		},
+		{
+			name: "Session not found",
+			args: []string{"7654321", "--at", "19:00"},
+			want: "Session not found",
+		},
+		{
+			name:  "Outside of the session",
+			args:  []string{"--at", "10:00"},
+			error: splitsession.ErrSplitOutsideSession,
+		},
+		{
+			name:  "Missing time",
+			args:  []string{},
+			error: errors.New("the split time is required"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			sessionRepository := &infra.InMemorySessionRepository{Sessions: []session.Session{{
+				Id:        "1234567",
+				StartTime: time.Date(2024, time.April, 12, 17, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2024, time.April, 13, 9, 0, 0, 0, time.UTC),
+				Project:   "Flow",
+			}}}
+			app := test.InitializeApp(sessionRepository, infra.NewStubDateProvider())
+			app.SplitSessionUseCase = splitsession.NewSplitSessionUseCase(sessionRepository, &infra.StubIDProvider{Id: "abcdefg"})
+
+			got, err := test.ExecuteCmd(t, split.Command(app), tc.args...)
+
+			is.Equal(tc.error, err)
+
+			if tc.error == nil {
+				is.Equal(got, tc.want)
+			}
+		})
+	}
+}
@@ -1,14 +1,24 @@
 package start
 
 import (
+	"bufio"
+	"cmp"
 	"errors"
 	"fmt"
+	"io"
 	"log"
+	"os"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/TristanShz/flow/cmd/completion"
 	app "github.com/TristanShz/flow/internal/application/usecases"
 	startsession "github.com/TristanShz/flow/internal/application/usecases/flowsession/start"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/stopsession"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra/git"
+	"github.com/TristanShz/flow/internal/infra/process"
 	"github.com/TristanShz/flow/utils"
 	"github.com/spf13/cobra"
 )
@@ -17,19 +27,91 @@ func isTag(arg string) bool {
 	return strings.HasPrefix(arg, "+")
 }
 
+// confirm asks a yes/no question, anything but "y" or "yes" is a no
+func confirm(out io.Writer, in io.Reader, question string) bool {
+	fmt.Fprintf(out, "%v [y/N] ", question)
+
+	scanner := bufio.NewScanner(in)
+	if !scanner.Scan() {
+		fmt.Fprintln(out)
+		return false
+	}
+
+	answer := strings.ToLower(strings.TrimSpace(scanner.Text()))
+	return answer == "y" || answer == "yes"
+}
+
+// directoryProject returns the project of the current directory in the
+// config, else the name of its git repository when the config uses it, empty
+// when it has none
+func directoryProject(app *app.App) string {
+	dir, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+
+	if project := app.Config.ProjectOf(dir); project != "" {
+		return project
+	}
+
+	if app.Config.Git.Project {
+		if repository, ok := git.Detect(dir); ok {
+			return repository.Name
+		}
+	}
+
+	return ""
+}
+
+// branchTag returns the branch of the git repository of the current directory
+// when the config tags the sessions with it, empty otherwise
+func branchTag(app *app.App) string {
+	if !app.Config.Git.BranchTag {
+		return ""
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+
+	repository, _ := git.Detect(dir)
+	return repository.Branch
+}
+
+// warnDeprecatedTags warns about the deprecated tags of the config among the
+// tags
+func warnDeprecatedTags(logger *log.Logger, tags []string, deprecations []session.TagDeprecation) {
+	for _, deprecation := range session.DeprecatedTags(tags, deprecations) {
+		if deprecation.Replacement == "" {
+			logger.Printf("Warning: the tag %v is deprecated", utils.TagColor(deprecation.Tag))
+			continue
+		}
+
+		logger.Printf("Warning: the tag %v is deprecated, use %v instead", utils.TagColor(deprecation.Tag), utils.TagColor(deprecation.Replacement))
+	}
+}
+
 func Command(app *app.App) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:                   "start [project] [+tag1 +tag2...]",
-		Example:               "start my-todo +add-todo +update-todo",
+		Example:               "start my-todo +add-todo +update-todo\nstart +add-todo",
 		Short:                 "Start flow session",
 		DisableFlagsInUseLine: true,
+		ValidArgsFunction: completion.ProjectAndTags(app, func() string {
+			return directoryProject(app)
+		}),
 		Args: func(cmd *cobra.Command, args []string) error {
 			if len(args) == 0 {
 				return nil
 			}
 
-			if len(args) > 0 && strings.HasPrefix(args[0], "+") {
-				return errors.New("the first argument must be the project name")
+			if isTag(args[0]) {
+				if directoryProject(app) == "" {
+					return errors.New("the first argument must be the project name")
+				}
+				// the project of the directory is started with the given tags
+				args = append([]string{""}, args...)
 			}
 
 			for _, arg := range args[1:] {
@@ -42,6 +124,13 @@ func Command(app *app.App) *cobra.Command {
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			logger := log.New(cmd.OutOrStdout(), "", 0)
+			withoutArgs := len(args) == 0
+
+			if len(args) == 0 || isTag(args[0]) {
+				if project := directoryProject(app); project != "" {
+					args = append([]string{project}, args...)
+				}
+			}
 
 			// no args -> show list of existing projects
 			if len(args) == 0 {
@@ -72,18 +161,53 @@ func Command(app *app.App) *cobra.Command {
 				tagWithoutPrefix, _ := strings.CutPrefix(tag, "+")
 				tags = append(tags, tagWithoutPrefix)
 			}
+
+			if len(tags) == 0 && len(app.Config.DefaultTags) > 0 {
+				tags = append(tags, app.Config.DefaultTags...)
+			}
+			if branch := branchTag(app); withoutArgs && branch != "" && !slices.Contains(tags, branch) {
+				tags = append(tags, branch)
+			}
+			yesFlag, _ := cmd.Flags().GetBool("yes")
+			overlapFlag, _ := cmd.Flags().GetString("overlap")
+			overlap := cmp.Or(overlapFlag, app.Config.Overlap)
+			if overlap != "" && !session.IsOverlapPolicyValid(overlap) {
+				return fmt.Errorf("invalid overlap %v. possible values: %v", overlap, strings.Join(session.OverlapPolicies, ", "))
+			}
 			command := startsession.Command{
-				Project: args[0],
-				Tags:    tags,
+				Project:   args[0],
+				Tags:      tags,
+				Confirmed: yesFlag,
+				TagRules:  app.Config.TagRules,
+				Overlap:   overlap,
 			}
 
 			err := app.StartFlowSessionUseCase.Execute(command)
+			if err == startsession.ErrDoNotTrackNotConfirmed {
+				question := fmt.Sprintf("It's a do-not-track time for %v, start anyway?", utils.ProjectColor(command.Project))
+				if !confirm(cmd.OutOrStdout(), cmd.InOrStdin(), question) {
+					return nil
+				}
+
+				command.Confirmed = true
+				err = app.StartFlowSessionUseCase.Execute(command)
+			}
+			// the session is started despite the overlaps it's warned about
+			var overlapWarning *session.OverlapWarning
+			if errors.As(err, &overlapWarning) {
+				err = nil
+			}
 			if err != nil {
 				if err == startsession.ErrSessionAlreadyStarted {
 					logger.Println("There is already a session in progress")
 					return nil
 				}
 
+				if err == startsession.ErrDoNotTrackBlocked {
+					logger.Printf("It's a do-not-track time for %v, the session can't be started", utils.ProjectColor(command.Project))
+					return nil
+				}
+
 				return err
 			}
 
@@ -97,7 +221,53 @@ func Command(app *app.App) *cobra.Command {
 
 			logger.Println(text)
 
+			if overlapWarning != nil {
+				logger.Printf("Warning: %v", overlapWarning)
+			}
+			warnDeprecatedTags(logger, command.Tags, app.Config.TagDeprecations)
+
+			attachFlag, _ := cmd.Flags().GetBool("attach")
+			if attachFlag {
+				return attach(app, logger)
+			}
+
 			return nil
 		},
 	}
+
+	cmd.Flags().BoolP("attach", "a", false, "Open a new shell and stop the session when it exits, even if it's interrupted")
+	cmd.Flags().BoolP("yes", "y", false, "Start the session without confirmation during a do-not-track window of the project")
+	cmd.Flags().String("overlap", "", "What to do when the session overlaps another one: allow, warn, reject or adjust (default from the config, else allow)")
+
+	return cmd
+}
+
+func attach(app *app.App, logger *log.Logger) error {
+	logger.Println("Attached to a new shell, exit it to stop the flow session")
+
+	var stopErr error
+	_, err := process.RunAttached(process.Shell(), func(_ int) {
+		duration, err := app.StopFlowSessionUseCase.Execute(stopsession.Command{TagRules: app.Config.TagRules})
+		if err == stopsession.ErrClockWentBackwards {
+			logger.Printf("Warning: %v", err)
+			return
+		}
+		if err != nil {
+			stopErr = err
+			return
+		}
+
+		logger.Printf("Flow session stopped, you were in the flow for %v", utils.TimeColor(duration.String()))
+	})
+	if err != nil {
+		// the shell never started, the session must not keep flowing
+		app.StopFlowSessionUseCase.Execute(stopsession.Command{TagRules: app.Config.TagRules})
+		return err
+	}
+
+	if stopErr != nil && stopErr != stopsession.ErrNoCurrentSession {
+		return stopErr
+	}
+
+	return nil
 }
@@ -2,10 +2,17 @@ package start_test
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/TristanShz/flow/cmd/start"
+	"github.com/TristanShz/flow/internal/application"
+	startsession "github.com/TristanShz/flow/internal/application/usecases/flowsession/start"
+	"github.com/TristanShz/flow/internal/domain/project"
 	"github.com/TristanShz/flow/internal/domain/session"
 	"github.com/TristanShz/flow/internal/infra"
 	"github.com/TristanShz/flow/test"
@@ -25,7 +32,9 @@ func TestStartCommand(t *testing.T) {
 		args          []string
 		error         error
 		givenSessions []session.Session
+		givenProjects []project.Project
 		givenNow      time.Time
+		stdin         string
 	}{
 		{
 			name:          "No args and no existing project",
@@ -91,6 +100,40 @@ func TestStartCommand(t *testing.T) {
 			givenNow: time.Date(2024, time.April, 14, 10, 12, 0, 0, time.UTC),
 			want:     "Starting flow session for the project my-todo [add-todo, update-todo] at 10:12AM",
 		},
+		{
+			name:          "Do-not-track window confirmed",
+			args:          []string{"work"},
+			givenNow:      time.Date(2024, time.April, 14, 10, 12, 0, 0, time.UTC),
+			givenProjects: []project.Project{{Name: "work", DoNotTrack: []project.TimeWindow{{Days: []string{"sat", "sun"}}}}},
+			stdin:         "y\n",
+			want:          "It's a do-not-track time for work, start anyway? [y/N] Starting flow session for the project work at 10:12AM",
+		},
+		{
+			name:          "Do-not-track window not confirmed",
+			args:          []string{"work"},
+			givenNow:      time.Date(2024, time.April, 14, 10, 12, 0, 0, time.UTC),
+			givenProjects: []project.Project{{Name: "work", DoNotTrack: []project.TimeWindow{{Days: []string{"sat", "sun"}}}}},
+			stdin:         "n\n",
+			want:          "It's a do-not-track time for work, start anyway? [y/N]",
+		},
+		{
+			name:          "Do-not-track window confirmed with flag",
+			args:          []string{"work", "--yes"},
+			givenNow:      time.Date(2024, time.April, 14, 10, 12, 0, 0, time.UTC),
+			givenProjects: []project.Project{{Name: "work", DoNotTrack: []project.TimeWindow{{Days: []string{"sat", "sun"}}}}},
+			want:          "Starting flow session for the project work at 10:12AM",
+		},
+		{
+			name:     "Do-not-track window blocking",
+			args:     []string{"work", "--yes"},
+			givenNow: time.Date(2024, time.April, 14, 10, 12, 0, 0, time.UTC),
+			givenProjects: []project.Project{{
+				Name:         "work",
+				DoNotTrack:   []project.TimeWindow{{Days: []string{"sat", "sun"}}},
+				OnDoNotTrack: project.DoNotTrackBlock,
+			}},
+			want: "It's a do-not-track time for work, the session can't be started",
+		},
 		{
 			name:     "Session already started",
 			args:     []string{"my-todo"},
@@ -111,7 +154,18 @@ func TestStartCommand(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			sessionRepository.Sessions = tc.givenSessions
 			dateProvider.Now = tc.givenNow
+			// every case starts without an active session lock
+			app.StartFlowSessionUseCase = startsession.NewStartFlowSessionUseCase(
+				sessionRepository,
+				dateProvider,
+				&infra.StubIDProvider{},
+				&infra.InMemoryActiveSessionLock{},
+				&infra.InMemoryProjectRepository{Projects: tc.givenProjects},
+				&infra.InMemoryTemplatesRepository{},
+				&infra.InMemoryEventPublisher{},
+			)
 			c := start.Command(app)
+			c.SetIn(strings.NewReader(tc.stdin))
 
 			got, err := test.ExecuteCmd(t, c, tc.args...)
 
@@ -123,3 +177,150 @@ func TestStartCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestStartCommand_Config(t *testing.T) {
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tt := []struct {
+		name string
+		want string
+		args []string
+	}{
+		{
+			name: "Project of the directory with the default tags",
+			args: []string{},
+			want: "Starting flow session for the project flow [work] at 10:12AM",
+		},
+		{
+			name: "Project of the directory with tags",
+			args: []string{"+docs"},
+			want: "Starting flow session for the project flow [docs] at 10:12AM",
+		},
+		{
+			name: "Project given",
+			args: []string{"my-todo"},
+			want: "Starting flow session for the project my-todo [work] at 10:12AM",
+		},
+		{
+			name: "Deprecated tag",
+			args: []string{"+doc"},
+			want: "Starting flow session for the project flow [doc] at 10:12AM\nWarning: the tag doc is deprecated, use docs instead",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			dateProvider := infra.NewStubDateProvider()
+			dateProvider.Now = time.Date(2024, time.April, 14, 10, 12, 0, 0, time.UTC)
+			app := test.InitializeApp(&infra.InMemorySessionRepository{}, dateProvider)
+			app.Config = application.Config{
+				Directories:     map[string]string{filepath.Dir(dir): "flow"},
+				DefaultTags:     []string{"work"},
+				TagDeprecations: []session.TagDeprecation{{Tag: "doc", Replacement: "docs"}},
+			}
+
+			got, err := test.ExecuteCmd(t, start.Command(app), tc.args...)
+
+			is.NoErr(err)
+			is.Equal(got, tc.want)
+		})
+	}
+}
+
+func TestStartCommand_Git(t *testing.T) {
+	repository := filepath.Join(t.TempDir(), "flow")
+	if err := os.MkdirAll(filepath.Join(repository, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repository, ".git", "HEAD"), []byte("ref: refs/heads/git-start\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(repository); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	tt := []struct {
+		name   string
+		config application.Git
+		args   []string
+		want   string
+	}{
+		{
+			name:   "Repository as project with the branch as tag",
+			config: application.Git{Project: true, BranchTag: true},
+			args:   []string{},
+			want:   "Starting flow session for the project flow [work, git-start] at 10:12AM",
+		},
+		{
+			name:   "Repository as project with tags",
+			config: application.Git{Project: true, BranchTag: true},
+			args:   []string{"+docs"},
+			want:   "Starting flow session for the project flow [docs] at 10:12AM",
+		},
+		{
+			name:   "Project given",
+			config: application.Git{Project: true, BranchTag: true},
+			args:   []string{"my-todo"},
+			want:   "Starting flow session for the project my-todo [work] at 10:12AM",
+		},
+		{
+			name:   "Git not used",
+			config: application.Git{},
+			args:   []string{},
+			want:   "Please provide a project name",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			dateProvider := infra.NewStubDateProvider()
+			dateProvider.Now = time.Date(2024, time.April, 14, 10, 12, 0, 0, time.UTC)
+			app := test.InitializeApp(&infra.InMemorySessionRepository{}, dateProvider)
+			app.Config = application.Config{
+				DefaultTags: []string{"work"},
+				Git:         tc.config,
+			}
+
+			got, err := test.ExecuteCmd(t, start.Command(app), tc.args...)
+
+			is.NoErr(err)
+			is.Equal(got, tc.want)
+		})
+	}
+}
+
+func TestStartCommand_Attach(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("attached shell is not scriptable on windows")
+	}
+
+	is := is.New(t)
+
+	sessionRepository := &infra.InMemorySessionRepository{}
+	dateProvider := infra.NewStubDateProvider()
+	dateProvider.Now = time.Date(2024, time.April, 14, 10, 12, 0, 0, time.UTC)
+	app := test.InitializeApp(sessionRepository, dateProvider)
+
+	t.Setenv("SHELL", "true")
+
+	got, err := test.ExecuteCmd(t, start.Command(app), "my-todo", "--attach")
+
+	is.NoErr(err)
+	is.Equal(got, "Starting flow session for the project my-todo at 10:12AM\nAttached to a new shell, exit it to stop the flow session\nFlow session stopped, you were in the flow for 0s")
+	last, err := sessionRepository.FindLastSession()
+	is.NoErr(err)
+	is.Equal(last.Status(), session.EndedStatus)
+}
@@ -0,0 +1,68 @@
+package stats
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/TristanShz/flow/cmd/completion"
+	app "github.com/TristanShz/flow/internal/application/usecases"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/stats"
+	"github.com/TristanShz/flow/pkg/timerange"
+	"github.com/TristanShz/flow/utils"
+	"github.com/spf13/cobra"
+)
+
+func Command(app *app.App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "stats",
+		Example: "stats\nstats --range last-month\nstats --range -4w",
+		Short:   "Show statistics about the sessions of a range",
+		Long:    "Show the streaks of tracked days, the average time of a tracked day, the busiest weekday, the longest session and the share of each project over a range",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			logger := log.New(cmd.OutOrStdout(), "", 0)
+
+			rangeFlag, _ := cmd.Flags().GetString("range")
+			statsRange, err := timerange.Parse(rangeFlag, app.DateProvider.GetNow())
+			if err != nil {
+				return err
+			}
+
+			result, err := app.StatsUseCase.Execute(stats.Command{
+				Since: statsRange.Since,
+				Until: statsRange.Until,
+			})
+			if err != nil {
+				return err
+			}
+
+			if result.TrackedDays == 0 {
+				logger.Println("No sessions found")
+				return nil
+			}
+
+			text := fmt.Sprintf("Stats of %v\n\n", rangeFlag)
+			text += fmt.Sprintf("Tracked: %v on %v of %v day(s), %v a tracked day\n", utils.TimeColor(result.Total.String()), result.TrackedDays, result.Days, utils.TimeColor(result.AverageDaily.String()))
+			text += fmt.Sprintf("Streak: %v day(s), the longest is %v day(s) from %v\n", result.CurrentStreak, result.LongestStreak, result.LongestStreakStart.Format("Mon 2006-01-02"))
+			text += fmt.Sprintf("Busiest weekday: %v, %v\n", result.BusiestWeekday, utils.TimeColor(result.BusiestWeekdayTotal.String()))
+			if !result.LongestSession.StartTime.IsZero() {
+				text += fmt.Sprintf("Longest session: %v on %v, %v\n", utils.TimeColor(result.LongestSession.Duration().String()), utils.ProjectColor(result.LongestSession.Project), result.LongestSession.StartTime.Format("Mon 2006-01-02"))
+			}
+
+			text += "\n" + utils.HeaderStyle.Render("Projects")
+			for _, project := range result.Projects {
+				text += fmt.Sprintf("\n    %v %v %.0f%%", utils.ProjectColor(project.Project), utils.TimeColor(project.Duration.Round(time.Minute).String()), project.Share*100)
+			}
+
+			logger.Println(text)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("range", "r", timerange.PeriodThisMonth, "Range of the statistics, like this-month, last-week, 2024-04, -7d or \"since monday\"")
+
+	cmd.RegisterFlagCompletionFunc("range", completion.Ranges(app))
+
+	return cmd
+}
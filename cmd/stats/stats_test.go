@@ -0,0 +1,69 @@
+package stats_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/cmd/stats"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/TristanShz/flow/pkg/timerange"
+	"github.com/TristanShz/flow/test"
+	"github.com/matryer/is"
+)
+
+func TestStatsCommand(t *testing.T) {
+	sessionRepository := &infra.InMemorySessionRepository{}
+	dateProvider := infra.NewStubDateProvider()
+	dateProvider.Now = time.Date(2024, time.April, 17, 15, 0, 0, 0, time.UTC)
+	app := test.InitializeApp(sessionRepository, dateProvider)
+
+	tt := []struct {
+		name          string
+		args          []string
+		givenSessions []session.Session
+		want          string
+		error         error
+	}{
+		{
+			name: "Stats of the month",
+			args: []string{},
+			givenSessions: []session.Session{
+				{Id: "1", StartTime: time.Date(2024, time.April, 15, 9, 0, 0, 0, time.UTC), EndTime: time.Date(2024, time.April, 15, 13, 0, 0, 0, time.UTC), Project: "Flow"},
+				{Id: "2", StartTime: time.Date(2024, time.April, 16, 9, 0, 0, 0, time.UTC), EndTime: time.Date(2024, time.April, 16, 10, 0, 0, 0, time.UTC), Project: "MyTodo"},
+				{Id: "3", StartTime: time.Date(2024, time.April, 17, 9, 0, 0, 0, time.UTC), EndTime: time.Date(2024, time.April, 17, 10, 0, 0, 0, time.UTC), Project: "MyTodo"},
+			},
+			want: "Stats of this-month\n\n" +
+				"Tracked: 6h0m0s on 3 of 17 day(s), 2h0m0s a tracked day\n" +
+				"Streak: 3 day(s), the longest is 3 day(s) from Mon 2024-04-15\n" +
+				"Busiest weekday: Monday, 4h0m0s\n" +
+				"Longest session: 4h0m0s on Flow, Mon 2024-04-15\n\n" +
+				"Projects\n    Flow 4h0m0s 67%\n    MyTodo 2h0m0s 33%",
+		},
+		{
+			name:          "No sessions",
+			args:          []string{"--range", "last-week"},
+			givenSessions: []session.Session{},
+			want:          "No sessions found",
+		},
+		{
+			name:  "Invalid range",
+			args:  []string{"--range", "someday"},
+			error: timerange.ErrInvalidRange,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			sessionRepository.Sessions = tc.givenSessions
+
+			got, err := test.ExecuteCmd(t, stats.Command(app), tc.args...)
+
+			is.Equal(err, tc.error)
+			if tc.error == nil {
+				is.Equal(got, tc.want)
+			}
+		})
+	}
+}
@@ -1,45 +1,90 @@
 package status
 
 import (
+	"errors"
 	"fmt"
 	"log"
-	"strings"
+	"time"
 
+	"github.com/TristanShz/flow/cmd/goals"
+	"github.com/TristanShz/flow/internal/application"
 	app "github.com/TristanShz/flow/internal/application/usecases"
 	"github.com/TristanShz/flow/internal/application/usecases/flowsession/sessionstatus"
-	"github.com/TristanShz/flow/utils"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/weeklytrend"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra/presenter"
 	"github.com/spf13/cobra"
 )
 
+const trendWeeks = 8
+
 func Command(app *app.App) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:                   "status",
+		Example:               "status\nstatus --trend\nstatus --oneline",
 		Short:                 "Show the current flow session status",
 		DisableFlagsInUseLine: true,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			logger := log.New(cmd.OutOrStdout(), "", 0)
-			status, err := app.FlowSessionStatusUseCase.Execute()
-			if err != nil {
-				if err == sessionstatus.ErrNoCurrentSession {
-					logger.Printf("No active flow session")
-					return nil
+
+			outputFlag, _ := cmd.Flags().GetString("output")
+			if !presenter.IsOutputValid(outputFlag) {
+				return errors.New("invalid output flag. possible values: text, json, plain")
+			}
+
+			var statusPresenter application.StatusPresenter = presenter.StatusCLIPresenter{Logger: logger}
+			if outputFlag == presenter.OutputJSON {
+				statusPresenter = presenter.StatusJSONPresenter{Logger: logger}
+			}
+
+			trendFlag, _ := cmd.Flags().GetBool("trend")
+			onelineFlag, _ := cmd.Flags().GetBool("oneline")
+			if onelineFlag {
+				if trendFlag {
+					return errors.New("the trend can't be shown on one line")
 				}
-				return err
+				statusPresenter = presenter.StatusOnelinePresenter{Logger: logger}
 			}
 
-			msg := fmt.Sprintf(
-				"You're in the flow for %v on project %v",
-				utils.TimeColor(status.Duration.String()),
-				utils.ProjectColor(status.Session.Project),
-			)
+			var currentSession *session.Session
+			var duration time.Duration
 
-			if len(status.Session.Tags) > 0 {
-				msg += fmt.Sprintf(" with tags: %v", utils.TagColor(strings.Join(status.Session.Tags, ", ")))
+			status, err := app.FlowSessionStatusUseCase.Execute()
+			if err != nil && err != sessionstatus.ErrNoCurrentSession {
+				return err
+			}
+			if err == nil {
+				currentSession = &status.Session
+				duration = status.Duration
 			}
 
-			logger.Println(msg)
+			var weeklyTrend []time.Duration
+			if trendFlag {
+				weeklyTrend, err = app.WeeklyTrendUseCase.Execute(weeklytrend.Command{
+					Weeks:     trendWeeks,
+					WeekStart: app.Config.FirstDayOfWeek(),
+				})
+				if err != nil {
+					return err
+				}
+			}
+
+			statusPresenter.ShowStatus(currentSession, duration, weeklyTrend)
+
+			// the goals behind are only worth a warning to someone reading
+			if outputFlag != presenter.OutputJSON && !onelineFlag {
+				for _, warning := range goals.Warnings(app, "") {
+					logger.Println(warning)
+				}
+			}
 
 			return nil
 		},
 	}
+
+	cmd.Flags().Bool("trend", false, fmt.Sprintf("Show the total flow time of the last %v weeks", trendWeeks))
+	cmd.Flags().Bool("oneline", false, "Print the project and the elapsed time of the current session on one line, nothing when no session is flowing")
+	cmd.Flags().StringP("output", "o", presenter.DefaultOutput(app.Config.Output), "Output format. Possible values: text, json, plain")
+
+	return cmd
 }
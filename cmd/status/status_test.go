@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/TristanShz/flow/cmd/status"
+	"github.com/TristanShz/flow/internal/application/usecases/project/setproject"
 	"github.com/TristanShz/flow/internal/domain/session"
 	"github.com/TristanShz/flow/internal/infra"
 	"github.com/TristanShz/flow/test"
@@ -69,6 +70,89 @@ func TestStatusCommand(t *testing.T) {
 			givenNow: time.Date(2024, time.April, 13, 17, 30, 0, 0, time.UTC),
 			want:     "You're in the flow for 10m0s on project Flow",
 		},
+		{
+			name: "Current session with trend",
+			args: []string{"--trend"},
+			givenSessions: []session.Session{
+				{
+					Id:        "1",
+					StartTime: time.Date(2024, time.April, 2, 10, 0, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.April, 2, 12, 0, 0, 0, time.UTC),
+					Project:   "Flow",
+				},
+				{
+					Id:        "2",
+					StartTime: time.Date(2024, time.April, 11, 10, 0, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.April, 11, 11, 0, 0, 0, time.UTC),
+					Project:   "Flow",
+				},
+				{
+					Id:        "3",
+					StartTime: time.Date(2024, time.April, 13, 17, 20, 0, 0, time.UTC),
+					Project:   "Flow",
+				},
+			},
+			givenNow: time.Date(2024, time.April, 13, 17, 30, 0, 0, time.UTC),
+			want:     "You're in the flow for 10m0s on project Flow\nLast 8 weeks: ▁▁▁▁▁▁█│▄ (1h0m0s this week)",
+		},
+		{
+			name: "JSON output",
+			args: []string{"--output", "json"},
+			givenSessions: []session.Session{
+				{
+					Id:        "1",
+					StartTime: time.Date(2024, time.April, 13, 17, 20, 0, 0, time.UTC),
+					Project:   "Flow",
+					Tags:      []string{"status"},
+				},
+			},
+			givenNow: time.Date(2024, time.April, 13, 17, 30, 0, 0, time.UTC),
+			want: `{
+  "session": {
+    "end_time": null,
+    "start_time": "2024-04-13T17:20:00Z",
+    "id": "1",
+    "project": "Flow",
+    "status": "FLOWING",
+    "tags": [
+      "status"
+    ],
+    "duration_seconds": 0
+  },
+  "elapsed_seconds": 600,
+  "active": true
+}`,
+		},
+		{
+			name: "One line",
+			args: []string{"--oneline"},
+			givenSessions: []session.Session{
+				{
+					Id:        "1",
+					StartTime: time.Date(2024, time.April, 13, 16, 5, 0, 0, time.UTC),
+					Project:   "Flow",
+					Tags:      []string{"status"},
+				},
+			},
+			givenNow: time.Date(2024, time.April, 13, 17, 30, 0, 0, time.UTC),
+			want:     "Flow 1h25m",
+		},
+		{
+			name:          "One line without current session",
+			args:          []string{"--oneline"},
+			givenSessions: []session.Session{},
+			want:          "",
+		},
+		{
+			name:          "JSON output without current session",
+			args:          []string{"--output", "json"},
+			givenSessions: []session.Session{},
+			want: `{
+  "session": null,
+  "elapsed_seconds": 0,
+  "active": false
+}`,
+		},
 	}
 
 	for _, tc := range tt {
@@ -87,3 +171,38 @@ func TestStatusCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestStatusCommand_GoalBehind(t *testing.T) {
+	is := is.New(t)
+
+	sessionRepository := &infra.InMemorySessionRepository{Sessions: []session.Session{
+		{
+			Id:        "1",
+			StartTime: time.Date(2024, time.April, 11, 10, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, time.April, 11, 11, 0, 0, 0, time.UTC),
+			Project:   "Flow",
+		},
+		{
+			Id:        "2",
+			StartTime: time.Date(2024, time.April, 13, 17, 20, 0, 0, time.UTC),
+			Project:   "Flow",
+		},
+	}}
+	dateProvider := infra.NewStubDateProvider()
+	dateProvider.Now = time.Date(2024, time.April, 13, 17, 30, 0, 0, time.UTC)
+	app := test.InitializeApp(sessionRepository, dateProvider)
+
+	weeklyGoal := 10 * time.Hour
+	_, err := app.SetProjectUseCase.Execute(setproject.Command{Name: "Flow", WeeklyGoal: &weeklyGoal})
+	is.NoErr(err)
+
+	got, err := test.ExecuteCmd(t, status.Command(app))
+
+	is.NoErr(err)
+	is.Equal(got, "You're in the flow for 10m0s on project Flow\nWarning: Flow is behind its goal by 7h1m0s, 1h10m0s of 10h0m0s this week")
+
+	got, err = test.ExecuteCmd(t, status.Command(app), "--oneline")
+
+	is.NoErr(err)
+	is.Equal(got, "Flow 0h10m")
+}
@@ -1,32 +1,178 @@
 package stop
 
 import (
+	"bufio"
+	"fmt"
+	"io"
 	"log"
+	"strings"
+	"time"
 
+	"github.com/TristanShz/flow/cmd/completion"
+	"github.com/TristanShz/flow/internal/application"
 	app "github.com/TristanShz/flow/internal/application/usecases"
 	"github.com/TristanShz/flow/internal/application/usecases/flowsession/stopsession"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/suggeststop"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/suggesttags"
 	"github.com/TristanShz/flow/utils"
 	"github.com/spf13/cobra"
 )
 
-func Command(app *app.App) *cobra.Command {
-	return &cobra.Command{
-		Use:                   "stop",
-		Short:                 "Stop flow session",
-		DisableFlagsInUseLine: true,
+// currentCommandWindow is left out of the activity of the user, it's the
+// time they took to run the stop
+const currentCommandWindow = 10 * time.Second
+
+// confirm reads the answer to a question, anything but "y" or "yes" is a no.
+// The scanner is shared by the questions of the command, it reads ahead.
+func confirm(out io.Writer, scanner *bufio.Scanner) bool {
+	if !scanner.Scan() {
+		fmt.Fprintln(out)
+		return false
+	}
+
+	answer := strings.ToLower(strings.TrimSpace(scanner.Text()))
+	return answer == "y" || answer == "yes"
+}
+
+// promptTags asks to accept or reject each suggested tag and returns the
+// accepted ones
+func promptTags(out io.Writer, scanner *bufio.Scanner, suggestions []string) []string {
+	accepted := []string{}
+
+	for _, tag := range suggestions {
+		fmt.Fprintf(out, "Add tag %v? [y/N] ", utils.TagColor("#"+tag))
+
+		if confirm(out, scanner) {
+			accepted = append(accepted, tag)
+		}
+	}
+
+	return accepted
+}
+
+// promptEndTime suggests to stop the session when the user was last active,
+// if it was long ago, and returns the accepted end time. It's the zero time
+// when there's no suggestion or it's rejected.
+func promptEndTime(cmd *cobra.Command, app *app.App, activityDetector application.ActivityDetector, scanner *bufio.Scanner) time.Time {
+	now := app.DateProvider.GetNow()
+
+	lastActivity, err := activityDetector.LastActivity(cmd.Context(), now.Add(-currentCommandWindow))
+	if err != nil {
+		return time.Time{}
+	}
+
+	suggestion, err := app.SuggestStopUseCase.Execute(suggeststop.Command{LastActivity: lastActivity})
+	if err != nil || suggestion.IsZero() {
+		return time.Time{}
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "You were last active at %v, %v ago. Stop the session then? [y/N] ", utils.TimeColor(suggestion.Format(time.Kitchen)), now.Sub(suggestion).Round(time.Minute))
+	if !confirm(cmd.OutOrStdout(), scanner) {
+		return time.Time{}
+	}
+
+	return suggestion
+}
+
+// idleTime returns the time the user was away before stopping: the --idle
+// flag, or else the idle time of the system when a threshold is configured
+func idleTime(cmd *cobra.Command, app *app.App, idleDetector application.IdleDetector) (time.Duration, application.Idle, error) {
+	settings := app.Config.Idle
+
+	if cmd.Flags().Changed("idle") {
+		idle, _ := cmd.Flags().GetDuration("idle")
+		// the time given by the user is taken out as a whole
+		settings.Threshold = 0
+		return idle, settings, nil
+	}
+
+	if settings.Threshold <= 0 {
+		return 0, settings, nil
+	}
+
+	idle, err := idleDetector.IdleTime(cmd.Context())
+
+	return idle, settings, err
+}
+
+// Command stops the current session, taking the idle time of idleDetector
+// out of it when an idle threshold is configured. When the session has been
+// flowing for long, it suggests to stop it at the last activity of
+// activityDetector.
+func Command(app *app.App, idleDetector application.IdleDetector, activityDetector application.ActivityDetector) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "stop",
+		Example: "stop\nstop --note \"Added a todo list\"\nstop --idle 40m",
+		Short:   "Stop flow session",
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			logger := log.New(cmd.OutOrStdout(), "", 0)
-			duration, err := app.StopFlowSessionUseCase.Execute()
+
+			noteFlag, _ := cmd.Flags().GetString("note")
+			noSuggestFlag, _ := cmd.Flags().GetBool("no-suggest")
+
+			scanner := bufio.NewScanner(cmd.InOrStdin())
+
+			tags := []string{}
+			if noteFlag != "" && !noSuggestFlag {
+				suggestions, err := app.SuggestTagsUseCase.Execute(suggesttags.Command{Note: noteFlag})
+				if err != nil {
+					if err == suggesttags.ErrNoCurrentSession {
+						logger.Println("No flow session to stop.")
+						return nil
+					}
+					return err
+				}
+
+				tags = promptTags(cmd.OutOrStdout(), scanner, suggestions)
+			}
+
+			endTime := time.Time{}
+			if !noSuggestFlag && !cmd.Flags().Changed("idle") {
+				endTime = promptEndTime(cmd, app, activityDetector, scanner)
+			}
+
+			idle, idleSettings, err := idleTime(cmd, app, idleDetector)
+			if err != nil {
+				logger.Printf("Warning: the idle time can't be read, it's left in the session: %v", err)
+			}
+
+			duration, err := app.StopFlowSessionUseCase.Execute(stopsession.Command{
+				Note:         noteFlag,
+				Tags:         tags,
+				TagRules:     app.Config.TagRules,
+				Idle:         idle,
+				IdleSettings: idleSettings,
+				EndTime:      endTime,
+			})
 			if err != nil {
 				if err == stopsession.ErrNoCurrentSession {
 					logger.Println("No flow session to stop.")
 					return nil
 				}
+				if err == stopsession.ErrClockWentBackwards {
+					logger.Printf("Warning: %v", err)
+					return nil
+				}
 				return err
 			}
 
+			if beyond := idle - idleSettings.Threshold; beyond > 0 {
+				if idleSettings.Action == application.IdleActionBreak {
+					logger.Printf("You were idle for %v, %v was taken out as a break", idle, beyond)
+				} else {
+					logger.Printf("You were idle for %v, %v was trimmed", idle, beyond)
+				}
+			}
 			logger.Printf("Flow session stopped, you were in the flow for %v", utils.TimeColor(duration.String()))
 			return nil
 		},
 	}
+
+	cmd.Flags().StringP("note", "n", "", "Note describing what was done during the session")
+	cmd.Flags().Bool("no-suggest", false, "Don't suggest tags based on the note, nor an earlier end from your last activity")
+	cmd.Flags().Duration("idle", 0, "Time you were away before stopping, taken out of the end of the session")
+
+	cmd.RegisterFlagCompletionFunc("idle", completion.Durations(completion.CommonDurations))
+
+	return cmd
 }
@@ -1,10 +1,12 @@
 package stop_test
 
 import (
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/TristanShz/flow/cmd/stop"
+	"github.com/TristanShz/flow/internal/application"
 	"github.com/TristanShz/flow/internal/domain/session"
 	"github.com/TristanShz/flow/internal/infra"
 	"github.com/TristanShz/flow/test"
@@ -25,7 +27,13 @@ func TestStopCommand(t *testing.T) {
 		name          string
 		want          string
 		args          []string
+		stdin         string
 		givenSessions []session.Session
+		wantTags      []string
+		givenIdle     application.Idle
+		idleDetector  infra.StubIdleDetector
+		lastActivity  time.Time
+		wantEndTime   time.Time
 	}{
 		{
 			name: "No sessions",
@@ -45,6 +53,172 @@ func TestStopCommand(t *testing.T) {
 			},
 			givenNow: time.Date(2024, time.April, 13, 17, 30, 0, 0, time.UTC),
 			want:     "Flow session stopped, you were in the flow for 10m0s",
+			wantTags: []string{"stop"},
+		},
+		{
+			name: "Clock went backwards",
+			args: []string{},
+			givenSessions: []session.Session{
+				{
+					Id:        "1",
+					StartTime: time.Date(2024, time.April, 13, 17, 20, 0, 0, time.UTC),
+					Project:   "Flow",
+					Tags:      []string{"stop"},
+				},
+			},
+			givenNow: time.Date(2024, time.April, 13, 17, 10, 0, 0, time.UTC),
+			want:     "Warning: the clock went backwards during the session, it was stopped at its start time",
+			wantTags: []string{"stop"},
+		},
+		{
+			name: "Note with accepted and rejected suggestions",
+			args: []string{"--note", "Added a json output to the report"},
+			givenSessions: []session.Session{
+				{
+					Id:        "1",
+					StartTime: time.Date(2024, time.April, 12, 9, 0, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.April, 12, 10, 0, 0, 0, time.UTC),
+					Project:   "Flow",
+					Tags:      []string{"json", "report"},
+					Note:      "Json output for the report",
+				},
+				{
+					Id:        "2",
+					StartTime: time.Date(2024, time.April, 13, 17, 20, 0, 0, time.UTC),
+					Project:   "Flow",
+				},
+			},
+			stdin:    "n\ny\n",
+			givenNow: time.Date(2024, time.April, 13, 17, 30, 0, 0, time.UTC),
+			want:     "Add tag #json? [y/N] Add tag #report? [y/N] Flow session stopped, you were in the flow for 10m0s",
+			wantTags: []string{"report"},
+		},
+		{
+			name: "Note without suggestions",
+			args: []string{"--note", "Added a json output to the report", "--no-suggest"},
+			givenSessions: []session.Session{
+				{
+					Id:        "1",
+					StartTime: time.Date(2024, time.April, 12, 9, 0, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.April, 12, 10, 0, 0, 0, time.UTC),
+					Project:   "Flow",
+					Tags:      []string{"json", "report"},
+					Note:      "Json output for the report",
+				},
+				{
+					Id:        "2",
+					StartTime: time.Date(2024, time.April, 13, 17, 20, 0, 0, time.UTC),
+					Project:   "Flow",
+				},
+			},
+			givenNow: time.Date(2024, time.April, 13, 17, 30, 0, 0, time.UTC),
+			want:     "Flow session stopped, you were in the flow for 10m0s",
+		},
+		{
+			name: "Idle time beyond the threshold",
+			args: []string{},
+			givenSessions: []session.Session{
+				{
+					Id:        "1",
+					StartTime: time.Date(2024, time.April, 13, 17, 20, 0, 0, time.UTC),
+					Project:   "Flow",
+				},
+			},
+			givenNow:     time.Date(2024, time.April, 13, 18, 30, 0, 0, time.UTC),
+			givenIdle:    application.Idle{Threshold: 15 * time.Minute},
+			idleDetector: infra.StubIdleDetector{Idle: 45 * time.Minute},
+			want:         "You were idle for 45m0s, 30m0s was trimmed\nFlow session stopped, you were in the flow for 40m0s",
+		},
+		{
+			name: "Idle time taken out as a break",
+			args: []string{},
+			givenSessions: []session.Session{
+				{
+					Id:        "1",
+					StartTime: time.Date(2024, time.April, 13, 17, 20, 0, 0, time.UTC),
+					Project:   "Flow",
+				},
+			},
+			givenNow:     time.Date(2024, time.April, 13, 18, 30, 0, 0, time.UTC),
+			givenIdle:    application.Idle{Threshold: 15 * time.Minute, Action: application.IdleActionBreak},
+			idleDetector: infra.StubIdleDetector{Idle: 45 * time.Minute},
+			want:         "You were idle for 45m0s, 30m0s was taken out as a break\nFlow session stopped, you were in the flow for 40m0s",
+		},
+		{
+			name: "Idle time given by the user",
+			args: []string{"--idle", "20m"},
+			givenSessions: []session.Session{
+				{
+					Id:        "1",
+					StartTime: time.Date(2024, time.April, 13, 17, 20, 0, 0, time.UTC),
+					Project:   "Flow",
+				},
+			},
+			givenNow:     time.Date(2024, time.April, 13, 18, 30, 0, 0, time.UTC),
+			givenIdle:    application.Idle{Threshold: 15 * time.Minute},
+			idleDetector: infra.StubIdleDetector{Idle: time.Hour},
+			want:         "You were idle for 20m0s, 20m0s was trimmed\nFlow session stopped, you were in the flow for 50m0s",
+		},
+		{
+			name: "Idle time which can't be read",
+			args: []string{},
+			givenSessions: []session.Session{
+				{
+					Id:        "1",
+					StartTime: time.Date(2024, time.April, 13, 17, 20, 0, 0, time.UTC),
+					Project:   "Flow",
+				},
+			},
+			givenNow:     time.Date(2024, time.April, 13, 17, 30, 0, 0, time.UTC),
+			givenIdle:    application.Idle{Threshold: 15 * time.Minute},
+			idleDetector: infra.StubIdleDetector{Err: application.ErrIdleUnsupported},
+			want:         "Warning: the idle time can't be read, it's left in the session: the idle time can't be read on this system\nFlow session stopped, you were in the flow for 10m0s",
+		},
+		{
+			name: "Earlier end accepted",
+			args: []string{},
+			givenSessions: []session.Session{
+				{
+					Id:        "1",
+					StartTime: time.Date(2024, time.April, 13, 9, 0, 0, 0, time.UTC),
+					Project:   "Flow",
+				},
+			},
+			givenNow:     time.Date(2024, time.April, 13, 18, 0, 0, 0, time.UTC),
+			lastActivity: time.Date(2024, time.April, 13, 12, 30, 0, 0, time.UTC),
+			stdin:        "y\n",
+			want:         "You were last active at 12:30PM, 5h30m0s ago. Stop the session then? [y/N] Flow session stopped, you were in the flow for 3h30m0s",
+			wantEndTime:  time.Date(2024, time.April, 13, 12, 30, 0, 0, time.UTC),
+		},
+		{
+			name: "Earlier end rejected",
+			args: []string{},
+			givenSessions: []session.Session{
+				{
+					Id:        "1",
+					StartTime: time.Date(2024, time.April, 13, 9, 0, 0, 0, time.UTC),
+					Project:   "Flow",
+				},
+			},
+			givenNow:     time.Date(2024, time.April, 13, 18, 0, 0, 0, time.UTC),
+			lastActivity: time.Date(2024, time.April, 13, 12, 30, 0, 0, time.UTC),
+			want:         "You were last active at 12:30PM, 5h30m0s ago. Stop the session then? [y/N] \nFlow session stopped, you were in the flow for 9h0m0s",
+			wantEndTime:  time.Date(2024, time.April, 13, 18, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "Earlier end not suggested",
+			args: []string{"--no-suggest"},
+			givenSessions: []session.Session{
+				{
+					Id:        "1",
+					StartTime: time.Date(2024, time.April, 13, 9, 0, 0, 0, time.UTC),
+					Project:   "Flow",
+				},
+			},
+			givenNow:     time.Date(2024, time.April, 13, 18, 0, 0, 0, time.UTC),
+			lastActivity: time.Date(2024, time.April, 13, 12, 30, 0, 0, time.UTC),
+			want:         "Flow session stopped, you were in the flow for 9h0m0s",
+			wantEndTime:  time.Date(2024, time.April, 13, 18, 0, 0, 0, time.UTC),
 		},
 	}
 
@@ -52,7 +226,9 @@ func TestStopCommand(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			sessionRepository.Sessions = tc.givenSessions
 			dateProvider.Now = tc.givenNow
-			c := stop.Command(app)
+			app.Config.Idle = tc.givenIdle
+			c := stop.Command(app, tc.idleDetector, infra.StubActivityDetector{Last: tc.lastActivity})
+			c.SetIn(strings.NewReader(tc.stdin))
 
 			got, err := test.ExecuteCmd(t, c, tc.args...)
 
@@ -61,6 +237,18 @@ func TestStopCommand(t *testing.T) {
 			if tc.error == nil {
 				is.Equal(got, tc.want)
 			}
+
+			if len(tc.givenSessions) > 0 {
+				last, err := sessionRepository.FindLastSession()
+				is.NoErr(err)
+				is.Equal(last.Tags, tc.wantTags)
+			}
+
+			if !tc.wantEndTime.IsZero() {
+				last, err := sessionRepository.FindLastSession()
+				is.NoErr(err)
+				is.Equal(last.EndTime, tc.wantEndTime)
+			}
 		})
 	}
 }
@@ -0,0 +1,133 @@
+package store
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	app "github.com/TristanShz/flow/internal/application/usecases"
+	storearchive "github.com/TristanShz/flow/internal/application/usecases/store/archive"
+	"github.com/spf13/cobra"
+)
+
+func formatSize(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%v B", bytes)
+	}
+
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
+
+func formatSessionTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format(time.DateTime)
+}
+
+func infoCommand(app *app.App) *cobra.Command {
+	return &cobra.Command{
+		Use:     "info",
+		Example: "store info",
+		Short:   "Show statistics and the health of the session store",
+		Long:    "Show where and how sessions are stored, how many there are, and whether the index, quarantined or corrupted files need attention",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			logger := log.New(cmd.OutOrStdout(), "", 0)
+
+			info, err := app.InfoUseCase.Execute()
+			if err != nil {
+				return err
+			}
+
+			text := fmt.Sprintf("Backend: %v\n", info.Backend)
+			text += fmt.Sprintf("Location: %v\n", info.Location)
+			text += fmt.Sprintf("Session files: %v\n", info.SessionFiles)
+			text += fmt.Sprintf("Total size: %v\n", formatSize(info.TotalSize))
+			text += fmt.Sprintf("Oldest session: %v\n", formatSessionTime(info.OldestSession))
+			text += fmt.Sprintf("Newest session: %v\n", formatSessionTime(info.NewestSession))
+
+			if info.StaleIndexEntries == 0 {
+				text += fmt.Sprintf("Index: up to date, %v session(s)\n", info.IndexedSessions)
+			} else {
+				text += fmt.Sprintf("Index: %v stale entry(ies), refreshed on the next read\n", info.StaleIndexEntries)
+			}
+
+			text += fmt.Sprintf("Quarantined files: %v\n", info.QuarantinedFiles)
+			text += fmt.Sprintf("Trashed sessions: %v\n", info.TrashedFiles)
+			text += fmt.Sprintf("Corrupted files: %v\n", info.CorruptedFiles)
+
+			if info.CorruptedFiles > 0 {
+				text += "\nRun 'flow doctor' to list them\n"
+			}
+
+			logger.Print(text)
+
+			return nil
+		},
+	}
+}
+
+func archiveCommand(app *app.App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "archive",
+		Example: "store archive\nstore archive --before 2023-06-01",
+		Short:   "Pack the old sessions into compressed archives by month",
+		Long:    "Pack the ended sessions started before a date, a year ago by default, into compressed archives by month. Archived sessions are still reported, edited and deleted like the others, but they no longer slow down the reading of the recent ones",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			logger := log.New(cmd.OutOrStdout(), "", 0)
+
+			command := storearchive.Command{}
+
+			beforeFlag, _ := cmd.Flags().GetString("before")
+			if beforeFlag != "" {
+				before, err := time.ParseInLocation(time.DateOnly, beforeFlag, time.Local)
+				if err != nil {
+					return fmt.Errorf("%v is not a valid date, expected a date like 2024-01-31", beforeFlag)
+				}
+				command.Before = before
+			}
+
+			result, err := app.ArchiveUseCase.Execute(command)
+			if err != nil {
+				return err
+			}
+
+			if len(result.Months) == 0 {
+				logger.Printf("No session to archive before %v", result.Before.Format(time.DateOnly))
+				return nil
+			}
+
+			text := ""
+			for _, month := range result.Months {
+				text += fmt.Sprintf("%v: %v session(s) archived\n", month.Month, month.Sessions)
+			}
+
+			logger.Print(text)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("before", "b", "", "Archive the sessions started before the date")
+
+	return cmd
+}
+
+func Command(app *app.App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "store",
+		Short: "Inspect and archive the storage of the sessions",
+	}
+
+	cmd.AddCommand(infoCommand(app))
+	cmd.AddCommand(archiveCommand(app))
+
+	return cmd
+}
@@ -0,0 +1,123 @@
+package store_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/cmd/store"
+	"github.com/TristanShz/flow/internal/application"
+	storearchive "github.com/TristanShz/flow/internal/application/usecases/store/archive"
+	storeinfo "github.com/TristanShz/flow/internal/application/usecases/store/info"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/TristanShz/flow/test"
+	"github.com/matryer/is"
+)
+
+func TestStoreInfoCommand(t *testing.T) {
+	sessionRepository := &infra.InMemorySessionRepository{}
+	dateProvider := infra.NewStubDateProvider()
+	app := test.InitializeApp(sessionRepository, dateProvider)
+
+	healthy := application.StoreInfo{
+		Backend:         "filesystem",
+		Location:        "/home/me/.flow",
+		SessionFiles:    2,
+		TotalSize:       2048,
+		IndexedSessions: 2,
+		OldestSession:   time.Date(2024, time.April, 17, 19, 0, 0, 0, time.UTC),
+		NewestSession:   time.Date(2024, time.April, 17, 21, 0, 0, 0, time.UTC),
+	}
+
+	unhealthy := healthy
+	unhealthy.StaleIndexEntries = 1
+	unhealthy.QuarantinedFiles = 3
+	unhealthy.TrashedFiles = 4
+	unhealthy.CorruptedFiles = 1
+
+	tt := []struct {
+		name      string
+		givenInfo application.StoreInfo
+		want      string
+	}{
+		{
+			name:      "Empty store",
+			givenInfo: application.StoreInfo{Backend: "filesystem", Location: "/home/me/.flow"},
+			want:      "Backend: filesystem\nLocation: /home/me/.flow\nSession files: 0\nTotal size: 0 B\nOldest session: -\nNewest session: -\nIndex: up to date, 0 session(s)\nQuarantined files: 0\nTrashed sessions: 0\nCorrupted files: 0",
+		},
+		{
+			name:      "Healthy store",
+			givenInfo: healthy,
+			want:      "Backend: filesystem\nLocation: /home/me/.flow\nSession files: 2\nTotal size: 2.0 KiB\nOldest session: 2024-04-17 19:00:00\nNewest session: 2024-04-17 21:00:00\nIndex: up to date, 2 session(s)\nQuarantined files: 0\nTrashed sessions: 0\nCorrupted files: 0",
+		},
+		{
+			name:      "Store needing attention",
+			givenInfo: unhealthy,
+			want:      "Backend: filesystem\nLocation: /home/me/.flow\nSession files: 2\nTotal size: 2.0 KiB\nOldest session: 2024-04-17 19:00:00\nNewest session: 2024-04-17 21:00:00\nIndex: 1 stale entry(ies), refreshed on the next read\nQuarantined files: 3\nTrashed sessions: 4\nCorrupted files: 1\n\nRun 'flow doctor' to list them",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			app.InfoUseCase = storeinfo.NewInfoUseCase(&infra.StubStoreInspector{StoreInfo: tc.givenInfo})
+
+			got, err := test.ExecuteCmd(t, store.Command(app), "info")
+
+			is.NoErr(err)
+			is.Equal(tc.want, got)
+		})
+	}
+}
+
+func TestStoreArchiveCommand(t *testing.T) {
+	sessionRepository := &infra.InMemorySessionRepository{}
+	dateProvider := infra.NewStubDateProvider()
+	dateProvider.Now = time.Date(2024, time.April, 17, 19, 0, 0, 0, time.Local)
+	app := test.InitializeApp(sessionRepository, dateProvider)
+
+	tt := []struct {
+		name        string
+		args        []string
+		givenMonths []application.ArchivedMonth
+		wantBefore  time.Time
+		want        string
+		wantErr     bool
+	}{
+		{
+			name:       "Nothing to archive",
+			args:       []string{"archive"},
+			wantBefore: time.Date(2023, time.April, 1, 0, 0, 0, 0, time.Local),
+			want:       "No session to archive before 2023-04-01",
+		},
+		{
+			name:        "Archive before a date",
+			args:        []string{"archive", "--before", "2023-06-01"},
+			givenMonths: []application.ArchivedMonth{{Month: "2023-04", Sessions: 3}, {Month: "2023-05", Sessions: 1}},
+			wantBefore:  time.Date(2023, time.June, 1, 0, 0, 0, 0, time.Local),
+			want:        "2023-04: 3 session(s) archived\n2023-05: 1 session(s) archived",
+		},
+		{
+			name:    "Invalid date",
+			args:    []string{"archive", "--before", "june"},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			archiver := &infra.StubSessionArchiver{Months: tc.givenMonths}
+			app.ArchiveUseCase = storearchive.NewArchiveUseCase(archiver, dateProvider)
+
+			got, err := test.ExecuteCmd(t, store.Command(app), tc.args...)
+
+			is.Equal(err != nil, tc.wantErr)
+			if err == nil {
+				is.Equal(archiver.Before, tc.wantBefore)
+				is.Equal(got, tc.want)
+			}
+		})
+	}
+}
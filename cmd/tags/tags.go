@@ -0,0 +1,159 @@
+package tags
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/TristanShz/flow/cmd/completion"
+	app "github.com/TristanShz/flow/internal/application/usecases"
+	"github.com/TristanShz/flow/internal/application/usecases/tag/deletetag"
+	"github.com/TristanShz/flow/internal/application/usecases/tag/renametag"
+	"github.com/TristanShz/flow/internal/application/usecases/tag/retagsessions"
+	"github.com/spf13/cobra"
+)
+
+func parseDateFlag(cmd *cobra.Command, name string) (time.Time, error) {
+	flag, _ := cmd.Flags().GetString(name)
+	if flag == "" {
+		return time.Time{}, nil
+	}
+
+	parsedTime, err := time.Parse("2006-01-02", flag)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%v is not a valid time format", flag)
+	}
+
+	return parsedTime, nil
+}
+
+func renameCommand(app *app.App) *cobra.Command {
+	return &cobra.Command{
+		Use:     "rename [tag] [new-tag]",
+		Example: "tags rename doc docs",
+		Short:   "Rename a tag in all the sessions",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 2 {
+				return errors.New("the tag and its new name are required")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logger := log.New(cmd.OutOrStdout(), "", 0)
+
+			updatedSessions, err := app.RenameTagUseCase.Execute(renametag.Command{Tag: args[0], NewTag: args[1]})
+			if err != nil {
+				return err
+			}
+
+			logger.Printf("Tag %v renamed to %v in %v session(s)", args[0], args[1], updatedSessions)
+
+			return nil
+		},
+	}
+}
+
+func deleteCommand(app *app.App) *cobra.Command {
+	return &cobra.Command{
+		Use:     "delete [tag]",
+		Example: "tags delete wip",
+		Short:   "Remove a tag from all the sessions",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("the tag is required")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logger := log.New(cmd.OutOrStdout(), "", 0)
+
+			updatedSessions, err := app.DeleteTagUseCase.Execute(deletetag.Command{Tag: args[0]})
+			if err != nil {
+				return err
+			}
+
+			logger.Printf("Tag %v removed from %v session(s)", args[0], updatedSessions)
+
+			return nil
+		},
+	}
+}
+
+func retagCommand(app *app.App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "retag",
+		Example: "tags retag --project my-todo --since 2024-04-01 --add v2 --remove wip\ntags retag --rules\ntags retag --apply-deprecations",
+		Short:   "Add and remove tags on the sessions of a project or a period",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			logger := log.New(cmd.OutOrStdout(), "", 0)
+
+			projectFlag, _ := cmd.Flags().GetString("project")
+			addFlag, _ := cmd.Flags().GetStringSlice("add")
+			removeFlag, _ := cmd.Flags().GetStringSlice("remove")
+			command := retagsessions.Command{
+				Project:    projectFlag,
+				AddTags:    addFlag,
+				RemoveTags: removeFlag,
+			}
+
+			if rulesFlag, _ := cmd.Flags().GetBool("rules"); rulesFlag {
+				if len(app.Config.TagRules) == 0 {
+					return errors.New("there are no tag rules in the config file")
+				}
+				command.TagRules = app.Config.TagRules
+			}
+
+			if deprecationsFlag, _ := cmd.Flags().GetBool("apply-deprecations"); deprecationsFlag {
+				if len(app.Config.TagDeprecations) == 0 {
+					return errors.New("there are no deprecated tags in the config file")
+				}
+				command.TagDeprecations = app.Config.TagDeprecations
+			}
+
+			var err error
+			if command.Since, err = parseDateFlag(cmd, "since"); err != nil {
+				return err
+			}
+			if command.Until, err = parseDateFlag(cmd, "until"); err != nil {
+				return err
+			}
+
+			updatedSessions, err := app.RetagSessionsUseCase.Execute(command)
+			if err != nil {
+				return err
+			}
+
+			logger.Printf("%v session(s) retagged", updatedSessions)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("project", "p", "", "Only retag the sessions of the given project")
+	cmd.Flags().StringP("since", "s", "", "Only retag the sessions since the given date")
+	cmd.Flags().StringP("until", "u", "", "Only retag the sessions until the given date")
+	cmd.Flags().StringSliceP("add", "a", []string{}, "Tags to add to the sessions")
+	cmd.Flags().StringSliceP("remove", "r", []string{}, "Tags to remove from the sessions")
+	cmd.Flags().Bool("rules", false, "Apply the tag rules of the config file to the sessions")
+	cmd.Flags().Bool("apply-deprecations", false, "Replace the deprecated tags of the config file with their replacement")
+
+	completion.RegisterProjectFlag(cmd, app)
+	cmd.RegisterFlagCompletionFunc("since", completion.Dates(app))
+	cmd.RegisterFlagCompletionFunc("until", completion.Dates(app))
+
+	return cmd
+}
+
+func Command(app *app.App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tags",
+		Short: "Rename, delete and bulk edit the tags of the sessions",
+	}
+
+	cmd.AddCommand(renameCommand(app))
+	cmd.AddCommand(deleteCommand(app))
+	cmd.AddCommand(retagCommand(app))
+
+	return cmd
+}
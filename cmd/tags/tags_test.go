@@ -0,0 +1,123 @@
+package tags_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/cmd/tags"
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/application/usecases/tag/renametag"
+	"github.com/TristanShz/flow/internal/application/usecases/tag/retagsessions"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/TristanShz/flow/test"
+	"github.com/matryer/is"
+)
+
+func TestTagsCommand(t *testing.T) {
+	tt := []struct {
+		error    error
+		name     string
+		want     string
+		args     []string
+		wantTags [][]string
+		config   application.Config
+	}{
+		{
+			name:     "Rename",
+			args:     []string{"rename", "doc", "docs"},
+			want:     "Tag doc renamed to docs in 2 session(s)",
+			wantTags: [][]string{{"docs"}, {"cli", "docs"}},
+		},
+		{
+			name:  "Rename without new name",
+			args:  []string{"rename", "doc"},
+			error: errors.New("the tag and its new name are required"),
+		},
+		{
+			name:  "Rename unknown tag",
+			args:  []string{"rename", "api", "backend"},
+			error: renametag.ErrTagNotFound,
+		},
+		{
+			name:     "Delete",
+			args:     []string{"delete", "cli"},
+			want:     "Tag cli removed from 1 session(s)",
+			wantTags: [][]string{{"doc"}, {"doc"}},
+		},
+		{
+			name:     "Retag",
+			args:     []string{"retag", "--since", "2024-04-13", "--add", "v2", "--remove", "doc"},
+			want:     "1 session(s) retagged",
+			wantTags: [][]string{{"doc"}, {"cli", "v2"}},
+		},
+		{
+			name:  "Retag without filter",
+			args:  []string{"retag", "--add", "v2"},
+			error: retagsessions.ErrNoFilter,
+		},
+		{
+			name: "Retag with the tag rules",
+			args: []string{"retag", "--rules"},
+			config: application.Config{
+				TagRules: []session.TagRule{{Tag: "weekend", Days: []time.Weekday{time.Saturday, time.Sunday}}},
+			},
+			want:     "1 session(s) retagged",
+			wantTags: [][]string{{"doc"}, {"cli", "doc", "weekend"}},
+		},
+		{
+			name:  "Retag without tag rules",
+			args:  []string{"retag", "--rules"},
+			error: errors.New("there are no tag rules in the config file"),
+		},
+		{
+			name: "Retag with the tag deprecations",
+			args: []string{"retag", "--apply-deprecations"},
+			config: application.Config{
+				TagDeprecations: []session.TagDeprecation{{Tag: "doc", Replacement: "docs"}},
+			},
+			want:     "2 session(s) retagged",
+			wantTags: [][]string{{"docs"}, {"cli", "docs"}},
+		},
+		{
+			name:  "Retag without tag deprecations",
+			args:  []string{"retag", "--apply-deprecations"},
+			error: errors.New("there are no deprecated tags in the config file"),
+		},
+		{
+			name:  "Retag with invalid date",
+			args:  []string{"retag", "--since", "13/04/2024", "--add", "v2"},
+			error: errors.New("13/04/2024 is not a valid time format"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			sessionRepository := &infra.InMemorySessionRepository{
+				Sessions: []session.Session{
+					{Id: "1", StartTime: time.Date(2024, time.April, 12, 10, 0, 0, 0, time.UTC), Project: "Flow", Tags: []string{"doc"}},
+					{Id: "2", StartTime: time.Date(2024, time.April, 13, 10, 0, 0, 0, time.UTC), Project: "Flow", Tags: []string{"cli", "doc"}},
+				},
+			}
+			app := test.InitializeApp(sessionRepository, infra.NewStubDateProvider())
+			app.Config = tc.config
+
+			got, err := test.ExecuteCmd(t, tags.Command(app), tc.args...)
+
+			is.Equal(tc.error, err)
+
+			if tc.error == nil {
+				is.Equal(tc.want, got)
+
+				gotTags := [][]string{}
+				for _, s := range sessionRepository.Sessions {
+					gotTags = append(gotTags, s.Tags)
+				}
+				is.Equal(tc.wantTags, gotTags)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package templates
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	app "github.com/TristanShz/flow/internal/application/usecases"
+	"github.com/TristanShz/flow/internal/application/usecases/project/synctemplates"
+	"github.com/spf13/cobra"
+)
+
+func syncCommand(app *app.App) *cobra.Command {
+	return &cobra.Command{
+		Use:     "sync [source (optional)]",
+		Example: "templates sync\ntemplates sync git@github.com:team/flow-templates.git\ntemplates sync https://example.com/flow-templates.json",
+		Short:   "Fetch the project templates and naming rules shared by the team",
+		Long:    "Fetch the project templates and naming rules from a git repository, an URL or a file. The source defaults to templates_source of the config file. 'flow start' then checks the project names and the tags, and gives new projects the settings of their template.",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 1 {
+				return errors.New("only one source can be given")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logger := log.New(cmd.OutOrStdout(), "", 0)
+
+			source := app.Config.TemplatesSource
+			if len(args) == 1 {
+				source = args[0]
+			}
+
+			templates, err := app.SyncTemplatesUseCase.Execute(synctemplates.Command{Source: source})
+			if err != nil {
+				return err
+			}
+
+			lines := []string{fmt.Sprintf("Templates synced from %v", source)}
+			if templates.NamePattern != "" {
+				lines = append(lines, fmt.Sprintf("Project names: %v", templates.NamePattern))
+			}
+			if len(templates.Tags) > 0 {
+				lines = append(lines, fmt.Sprintf("Tags: %v", strings.Join(templates.Tags, ", ")))
+			}
+			lines = append(lines, fmt.Sprintf("Project templates: %v", len(templates.Projects)))
+
+			logger.Println(strings.Join(lines, "\n"))
+
+			return nil
+		},
+	}
+}
+
+func Command(app *app.App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "templates",
+		Short: "Share project templates and naming rules within a team",
+	}
+
+	cmd.AddCommand(syncCommand(app))
+
+	return cmd
+}
@@ -0,0 +1,70 @@
+package templates_test
+
+import (
+	"testing"
+
+	"github.com/TristanShz/flow/cmd/templates"
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/application/usecases/project/synctemplates"
+	"github.com/TristanShz/flow/internal/domain/project"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/TristanShz/flow/test"
+	"github.com/matryer/is"
+)
+
+func TestTemplatesSyncCommand(t *testing.T) {
+	sessionRepository := &infra.InMemorySessionRepository{}
+	dateProvider := infra.NewStubDateProvider()
+	app := test.InitializeApp(sessionRepository, dateProvider)
+
+	fetcher := &infra.StubTemplatesFetcher{Templates: map[string]project.Templates{
+		"git@github.com:team/flow-templates.git": {
+			NamePattern: "^[a-z-]+$",
+			Tags:        []string{"dev", "review"},
+			Projects:    []project.Project{{Name: "acme-website", Client: "Acme"}},
+		},
+		"https://example.com/flow-templates.json": {},
+	}}
+	app.SyncTemplatesUseCase = synctemplates.NewSyncTemplatesUseCase(fetcher, &infra.InMemoryTemplatesRepository{})
+
+	tt := []struct {
+		error  error
+		name   string
+		config application.Config
+		want   string
+		args   []string
+	}{
+		{
+			name:   "Configured source",
+			config: application.Config{TemplatesSource: "git@github.com:team/flow-templates.git"},
+			want:   "Templates synced from git@github.com:team/flow-templates.git\nProject names: ^[a-z-]+$\nTags: dev, review\nProject templates: 1",
+		},
+		{
+			name:   "Given source",
+			config: application.Config{TemplatesSource: "git@github.com:team/flow-templates.git"},
+			args:   []string{"https://example.com/flow-templates.json"},
+			want:   "Templates synced from https://example.com/flow-templates.json\nProject templates: 0",
+		},
+		{
+			name:  "No source",
+			error: synctemplates.ErrNoTemplatesSource,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			app.Config = tc.config
+
+			c := templates.Command(app)
+
+			got, err := test.ExecuteCmd(t, c, append([]string{"sync"}, tc.args...)...)
+
+			is.Equal(err, tc.error)
+
+			if tc.error == nil {
+				is.Equal(got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package trash
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/TristanShz/flow/cmd/history"
+	"github.com/TristanShz/flow/internal/application"
+	app "github.com/TristanShz/flow/internal/application/usecases"
+	"github.com/TristanShz/flow/internal/application/usecases/trash/purgetrash"
+	"github.com/TristanShz/flow/internal/application/usecases/trash/restoresession"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/utils"
+	"github.com/spf13/cobra"
+)
+
+func formatSession(s session.Session) string {
+	text := fmt.Sprintf("%v %v %v %v", s.Id, utils.TimeColor(s.GetFormattedStartTime()), utils.TimeColor(s.Duration().String()), utils.ProjectColor(s.Project))
+
+	if len(s.Tags) > 0 {
+		text += fmt.Sprintf(" [%v]", utils.TagColor(strings.Join(s.Tags, ", ")))
+	}
+
+	return text
+}
+
+func listCommand(app *app.App) *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Example: "trash list",
+		Short:   "List the deleted sessions, the last deleted first",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			logger := log.New(cmd.OutOrStdout(), "", 0)
+
+			trashed, err := app.ListTrashUseCase.Execute()
+			if err != nil {
+				return err
+			}
+
+			if len(trashed) == 0 {
+				logger.Println("The trash is empty")
+				return nil
+			}
+
+			for _, t := range trashed {
+				logger.Println(formatSession(t.Session) + utils.Faint(" deleted "+t.DeletedAt.Format(time.DateTime)))
+			}
+
+			return nil
+		},
+	}
+}
+
+func restoreCommand(app *app.App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "restore [session_id]",
+		Example: "trash restore abc1234",
+		Short:   "Restore a deleted session",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("the id of the session is required")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logger := log.New(cmd.OutOrStdout(), "", 0)
+
+			restored, err := app.RestoreSessionUseCase.Execute(restoresession.Command{
+				Id:           args[0],
+				LockedBefore: history.LockedBefore(cmd, app),
+			})
+			if err != nil {
+				return err
+			}
+
+			logger.Println("Restored: " + formatSession(restored))
+
+			return nil
+		},
+		ValidArgsFunction: func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+			ids := []string{}
+			trashed, _ := app.ListTrashUseCase.Execute()
+			for _, t := range trashed {
+				ids = append(ids, fmt.Sprintf("%v\t%v", t.Session.Id, t.Session.Project))
+			}
+			return ids, cobra.ShellCompDirectiveNoFileComp
+		},
+	}
+
+	history.AddFlag(cmd)
+
+	return cmd
+}
+
+func purgeCommand(app *app.App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "purge",
+		Example: "trash purge\ntrash purge --older-than 168h",
+		Short:   "Remove the deleted sessions for good",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			logger := log.New(cmd.OutOrStdout(), "", 0)
+
+			olderThanFlag, _ := cmd.Flags().GetDuration("older-than")
+			purged, err := app.PurgeTrashUseCase.Execute(purgetrash.Command{OlderThan: olderThanFlag})
+			if err != nil {
+				return err
+			}
+
+			logger.Printf("%v session(s) purged from the trash", purged)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Duration("older-than", 0, "Only purge the sessions deleted before that long ago, the whole trash is purged by default")
+
+	return cmd
+}
+
+func Command(app *app.App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "trash",
+		Short: "Manage the deleted sessions",
+		Long:  fmt.Sprintf("The deleted sessions are kept in the trash of the flow folder, from which they can be restored, for %v days by default, see trash_retention_days in the configuration", application.DefaultTrashRetentionDays),
+	}
+
+	cmd.AddCommand(listCommand(app))
+	cmd.AddCommand(restoreCommand(app))
+	cmd.AddCommand(purgeCommand(app))
+
+	return cmd
+}
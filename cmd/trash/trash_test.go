@@ -0,0 +1,86 @@
+package trash_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/cmd/trash"
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/application/usecases/trash/restoresession"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/TristanShz/flow/test"
+	"github.com/matryer/is"
+)
+
+func TestTrashCommand(t *testing.T) {
+	deleted := session.Session{
+		Id:        "abc",
+		StartTime: time.Date(2024, time.April, 15, 9, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, time.April, 15, 10, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+		Tags:      []string{"cli"},
+	}
+	sessionRepository := &infra.InMemorySessionRepository{
+		Sessions: []session.Session{{Id: "def", StartTime: deleted.EndTime, EndTime: deleted.EndTime.Add(time.Hour), Project: "Flow"}},
+		Trash: []application.TrashedSession{
+			{DeletedAt: time.Date(2024, time.April, 16, 8, 0, 0, 0, time.UTC), Session: deleted},
+			{DeletedAt: time.Date(2024, time.April, 16, 7, 0, 0, 0, time.UTC), Session: session.Session{Id: "def", StartTime: deleted.StartTime, Project: "Other"}},
+		},
+	}
+	dateProvider := infra.NewStubDateProvider()
+	dateProvider.Now = time.Date(2024, time.April, 17, 12, 0, 0, 0, time.UTC)
+	app := test.InitializeApp(sessionRepository, dateProvider)
+
+	tt := []struct {
+		error error
+		name  string
+		want  string
+		args  []string
+	}{
+		{
+			name: "List",
+			args: []string{"list"},
+			want: "abc 2024-04-15 09:00:00 1h0m0s Flow [cli] deleted 2024-04-16 08:00:00\ndef 2024-04-15 09:00:00 0s Other deleted 2024-04-16 07:00:00",
+		},
+		{
+			name: "Restore",
+			args: []string{"restore", "abc"},
+			want: "Restored: abc 2024-04-15 09:00:00 1h0m0s Flow [cli]",
+		},
+		{
+			name:  "Restore a session with the same id",
+			args:  []string{"restore", "def"},
+			error: restoresession.ErrSessionExists,
+		},
+		{
+			name:  "Restore without id",
+			args:  []string{"restore"},
+			error: errors.New("the id of the session is required"),
+		},
+		{
+			name: "Purge",
+			args: []string{"purge"},
+			want: "1 session(s) purged from the trash",
+		},
+		{
+			name: "List the empty trash",
+			args: []string{"list"},
+			want: "The trash is empty",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			got, err := test.ExecuteCmd(t, trash.Command(app), tc.args...)
+
+			is.Equal(tc.error, err)
+			if tc.error == nil {
+				is.Equal(tc.want, got)
+			}
+		})
+	}
+}
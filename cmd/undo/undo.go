@@ -0,0 +1,78 @@
+package undo
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/TristanShz/flow/cmd/history"
+	"github.com/TristanShz/flow/internal/application"
+	app "github.com/TristanShz/flow/internal/application/usecases"
+	"github.com/TristanShz/flow/internal/application/usecases/operation/undo"
+	"github.com/TristanShz/flow/utils"
+	"github.com/spf13/cobra"
+)
+
+func formatOperation(o application.Operation) string {
+	sessions := []string{}
+	for _, change := range o.Changes {
+		if !slices.Contains(sessions, change.SessionId()) {
+			sessions = append(sessions, change.SessionId())
+		}
+	}
+
+	return fmt.Sprintf("'%v' of %v, %v session(s) changed", o.Command, utils.TimeColor(o.At.Format(time.DateTime)), len(sessions))
+}
+
+func Command(app *app.App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "undo",
+		Example: "undo\nundo --list\nundo --force",
+		Short:   "Revert the last command which changed the sessions",
+		Long:    fmt.Sprintf("Put the sessions back as they were before the last command which changed them, like 'flow start', 'flow stop', 'flow edit', 'flow delete' or 'flow tags retag'. The last %v commands are kept by default, see undo_history in the configuration, and 'flow undo' reverts them one after the other. 'flow serve', 'flow daemon' and 'flow dashboard' aren't recorded.", application.DefaultUndoHistory),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			logger := log.New(cmd.OutOrStdout(), "", 0)
+
+			listFlag, _ := cmd.Flags().GetBool("list")
+			if listFlag {
+				operations, err := app.ListOperationsUseCase.Execute()
+				if err != nil {
+					return err
+				}
+
+				if len(operations) == 0 {
+					logger.Println("There is no command to undo")
+				}
+				for _, o := range operations {
+					logger.Println(formatOperation(o))
+				}
+
+				return nil
+			}
+
+			forceFlag, _ := cmd.Flags().GetBool("force")
+			operation, err := app.UndoUseCase.Execute(undo.Command{
+				Force:        forceFlag,
+				LockedBefore: history.LockedBefore(cmd, app),
+			})
+			if errors.Is(err, undo.ErrChangedSince) {
+				return fmt.Errorf("%w, use --force to undo %v anyway", err, formatOperation(operation))
+			}
+			if err != nil {
+				return err
+			}
+
+			logger.Println("Undone: " + formatOperation(operation))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolP("list", "l", false, "List the commands which can be undone, the last one first")
+	cmd.Flags().Bool("force", false, "Undo the command even when its sessions were changed since, these changes are lost")
+	history.AddFlag(cmd)
+
+	return cmd
+}
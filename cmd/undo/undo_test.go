@@ -0,0 +1,86 @@
+package undo_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/cmd/undo"
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/application/usecases/operation/listoperations"
+	undooperation "github.com/TristanShz/flow/internal/application/usecases/operation/undo"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/TristanShz/flow/test"
+	"github.com/matryer/is"
+)
+
+func TestUndoCommand(t *testing.T) {
+	tagged := session.Session{
+		Id:        "abc",
+		StartTime: time.Date(2024, time.April, 15, 9, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, time.April, 15, 10, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+		Tags:      []string{"cli"},
+	}
+	retagged := tagged
+	retagged.Tags = []string{"review"}
+	deleted := session.Session{Id: "def", StartTime: tagged.EndTime, EndTime: tagged.EndTime.Add(time.Hour), Project: "Flow"}
+
+	sessionRepository := &infra.InMemorySessionRepository{Sessions: []session.Session{tagged}}
+	dateProvider := infra.NewStubDateProvider()
+	dateProvider.Now = time.Date(2024, time.April, 17, 12, 0, 0, 0, time.UTC)
+	app := test.InitializeApp(sessionRepository, dateProvider)
+	operationLog := &infra.InMemoryOperationLog{Operations: []application.Operation{
+		{Id: "1", At: time.Date(2024, time.April, 16, 8, 0, 0, 0, time.UTC), Command: "delete def", Changes: []application.SessionChange{{Before: &deleted}}},
+		{Id: "2", At: time.Date(2024, time.April, 16, 9, 0, 0, 0, time.UTC), Command: "tags retag cli review", Changes: []application.SessionChange{{Before: &retagged, After: &tagged}}},
+	}}
+	app.UndoUseCase = undooperation.NewUndoUseCase(sessionRepository, &infra.InMemoryActiveSessionLock{}, operationLog)
+	app.ListOperationsUseCase = listoperations.NewListOperationsUseCase(operationLog)
+
+	tt := []struct {
+		error error
+		name  string
+		want  string
+		args  []string
+	}{
+		{
+			name: "List",
+			args: []string{"--list"},
+			want: "'tags retag cli review' of 2024-04-16 09:00:00, 1 session(s) changed\n'delete def' of 2024-04-16 08:00:00, 1 session(s) changed",
+		},
+		{
+			name: "Undo",
+			want: "Undone: 'tags retag cli review' of 2024-04-16 09:00:00, 1 session(s) changed",
+		},
+		{
+			name: "Undo the previous command",
+			want: "Undone: 'delete def' of 2024-04-16 08:00:00, 1 session(s) changed",
+		},
+		{
+			name: "List without commands",
+			args: []string{"--list"},
+			want: "There is no command to undo",
+		},
+		{
+			name:  "Nothing to undo",
+			error: undooperation.ErrNothingToUndo,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			got, err := test.ExecuteCmd(t, undo.Command(app), tc.args...)
+
+			is.True(errors.Is(err, tc.error))
+			if tc.error == nil {
+				is.Equal(tc.want, got)
+			}
+		})
+	}
+
+	is := is.New(t)
+	is.Equal(sessionRepository.FindAllSessions(nil), []session.Session{retagged, deleted})
+}
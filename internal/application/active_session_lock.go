@@ -0,0 +1,26 @@
+package application
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrActiveSessionLocked = errors.New("another session is already active")
+
+// ActiveSession marks the session currently flowing
+type ActiveSession struct {
+	AcquiredAt time.Time
+	SessionId  string
+}
+
+// ActiveSessionLock guarantees that only one session is flowing, even when
+// flow runs in several processes at once.
+type ActiveSessionLock interface {
+	// Acquire atomically marks the given session as the active one. When
+	// another session is already active, it returns ErrActiveSessionLocked
+	// along with that session.
+	Acquire(active ActiveSession) (ActiveSession, error)
+	// Release unmarks the given session, it does nothing when another session
+	// is active.
+	Release(sessionId string) error
+}
@@ -0,0 +1,13 @@
+package application
+
+import (
+	"context"
+	"time"
+)
+
+type ActivityDetector interface {
+	// LastActivity returns when the user was last active before the given
+	// time, e.g. from the history of their shell. It's the zero time when
+	// there's no known activity.
+	LastActivity(ctx context.Context, before time.Time) (time.Time, error)
+}
@@ -0,0 +1,24 @@
+package application
+
+import "time"
+
+// AuditChange is a field of a session changed by a command, with its value
+// before and after the change
+type AuditChange struct {
+	Field  string
+	Before string
+	After  string
+}
+
+// AuditEntry records the changes a command made to a session, so that
+// adjusted times can be traced back later
+type AuditEntry struct {
+	At        time.Time
+	Action    string
+	SessionId string
+	Changes   []AuditChange
+}
+
+type AuditLog interface {
+	Record(entry AuditEntry) error
+}
@@ -0,0 +1,44 @@
+package application
+
+import (
+	"errors"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+// BackupFormatVersion is the version of the backups made by this flow, a
+// backup of a newer version can't be restored
+const BackupFormatVersion = 1
+
+var ErrUnsupportedBackup = errors.New("the backup was made by a newer flow, update flow to restore it")
+
+// BackupManifest describes a backup, it's stored in the backup itself
+type BackupManifest struct {
+	FormatVersion int
+	CreatedAt     time.Time
+	// FlowFolder is the flow folder the backup is a snapshot of
+	FlowFolder string
+	Sessions   int
+	Files      int
+}
+
+// Backup is a snapshot of the flow folder
+type Backup struct {
+	Path     string
+	Manifest BackupManifest
+}
+
+// BackupStore snapshots the flow folder in backups, and reads the sessions
+// of the backups to restore them
+type BackupStore interface {
+	// Backup snapshots the flow folder in a new backup made at the time
+	Backup(at time.Time) (Backup, error)
+	// FindAllBackups returns the backups of the flow folder from the oldest
+	// to the newest, the ones of other flow folders are left out
+	FindAllBackups() ([]Backup, error)
+	RemoveBackup(path string) error
+	// ReadBackup returns the manifest and the sessions of the backup, it
+	// fails with ErrUnsupportedBackup for a newer format version
+	ReadBackup(path string) (BackupManifest, []session.Session, error)
+}
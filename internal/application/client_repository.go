@@ -0,0 +1,9 @@
+package application
+
+import "github.com/TristanShz/flow/internal/domain/client"
+
+type ClientRepository interface {
+	Save(client client.Client) error
+	FindByName(name string) *client.Client
+	FindAll() []client.Client
+}
@@ -0,0 +1,16 @@
+package application
+
+import "errors"
+
+var ErrClipboardUnsupported = errors.New("the clipboard can't be written on this system")
+
+// ClipboardContent is put on the clipboard as text, and as HTML too by the
+// clipboards supporting several formats
+type ClipboardContent struct {
+	Text string
+	HTML string
+}
+
+type Clipboard interface {
+	Copy(content ClipboardContent) error
+}
@@ -0,0 +1,445 @@
+package application
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/billing"
+	"github.com/TristanShz/flow/internal/domain/invoice"
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+// Config holds the preferences of the user, the zero Config keeps the
+// defaults of every command
+type Config struct {
+	// FlowFolder is where sessions are stored, ~/.flow when empty
+	FlowFolder string
+	// Output is the default output format of the commands having one
+	Output      string
+	DefaultTags []string
+	// Directories maps directories to the project started by 'flow start'
+	// without a project in them, or in one of their subdirectories
+	Directories map[string]string
+	// WeekStart is the first day of the week, monday when nil
+	WeekStart *time.Weekday
+	// TagRules tag the sessions when they start and stop, and with
+	// 'flow tags retag --rules'
+	TagRules []session.TagRule
+	// TagDeprecations are warned about when used, and replaced with
+	// 'flow tags retag --apply-deprecations', sorted by tag
+	TagDeprecations []session.TagDeprecation
+	// TemplatesSource is the git repository, URL or file 'flow templates
+	// sync' fetches the project templates of the team from
+	TemplatesSource string
+	// Calendar is the iCalendar file or URL whose meetings 'flow daemon'
+	// applies the on meeting action of the projects for
+	Calendar string
+	// HistoryLockMonths makes the sessions of the months older than the
+	// given number of months read-only, nothing is locked when it's zero
+	HistoryLockMonths int
+	// TrashRetentionDays is how long the deleted sessions are kept in the
+	// trash, see TrashRetention
+	TrashRetentionDays int
+	// UndoHistory is the number of operations 'flow undo' can revert, see
+	// UndoLimit
+	UndoHistory int
+	// Overlap is the policy of 'flow start', 'flow edit' and 'flow log' for
+	// the sessions overlapping others, see session.CheckOverlaps. Each
+	// command has its own default when it's empty.
+	Overlap string
+	// Webhooks are posted the events of the sessions, sorted by name
+	Webhooks []Webhook
+	// Encryption encrypts the session files when it has recipients
+	Encryption Encryption
+	// Git fills the project and tags of 'flow start' from the git repository
+	// of the current directory
+	Git Git
+	// Toggl is the account of Toggl Track sessions are imported from and
+	// exported to
+	Toggl Toggl
+	// Jira is the site the stopped sessions log their time to
+	Jira Jira
+	// Idle takes the idle time out of the sessions when they stop
+	Idle Idle
+	// Rounding rounds the durations of the reports and of the exports, the
+	// stored sessions are left as they are
+	Rounding session.Rounding
+	// BillingProfiles are the billing rules of the projects and clients
+	// naming them, by name
+	BillingProfiles map[string]billing.Profile
+	// Invoicing holds the issuer and the numbering of the invoices
+	Invoicing Invoicing
+	// Stores are the flow folders of other setups by name, whose sessions are
+	// reported read-only by 'flow report --stores'
+	Stores map[string]string
+	// Members are the users of the API of 'flow serve', sorted by name
+	Members []Member
+	// Pomodoro holds the lengths of the intervals of 'flow pomodoro'
+	Pomodoro Pomodoro
+	// Notifications are the desktop reminders of 'flow daemon' and the
+	// notifications of 'flow pomodoro'
+	Notifications Notifications
+	// Backups tells where 'flow backup' snapshots the flow folder and how
+	// often 'flow daemon' does it
+	Backups Backups
+	// Sandbox is set in the throwaway store of 'flow sandbox', see Sandboxed
+	Sandbox bool
+}
+
+// Sandboxed returns the config of the throwaway store of 'flow sandbox' at
+// path, whose sessions mustn't reach the real services: the webhooks are
+// dropped, the Jira worklogs are only printed and the daemon doesn't back up.
+// The backups stay in the store, and Sandbox turns the hook scripts and the
+// export to Toggl off.
+func (c Config) Sandboxed(path string) Config {
+	c.Sandbox = true
+	c.Webhooks = nil
+	c.Jira.DryRun = true
+	c.Backups.Every = 0
+	c.Backups.Folder = filepath.Join(path, SandboxBackupFolder)
+
+	return c
+}
+
+// SandboxBackupFolder is the folder of the backups in the store of
+// 'flow sandbox'
+const SandboxBackupFolder = ".backups"
+
+// What the idle time of a stopped session beyond the threshold becomes
+const (
+	IdleActionTrim  = "trim"
+	IdleActionBreak = "break"
+)
+
+var IdleActions = []string{IdleActionTrim, IdleActionBreak}
+
+// Idle tells what to do with the time a session was left flowing while the
+// user was away, it's off when Threshold is zero
+type Idle struct {
+	Threshold time.Duration
+	// Action is IdleActionTrim or IdleActionBreak, trim when empty
+	Action string
+}
+
+// Invoicing holds the settings of 'flow invoice'
+type Invoicing struct {
+	Issuer   invoice.Issuer
+	Currency string
+	// Prefix starts the numbers of the invoices, like INV-
+	Prefix string
+	// DueDays is the number of days the invoices are due after their date,
+	// they have no due date when it's zero
+	DueDays int
+}
+
+// Jira holds the account of a Jira site and the projects whose sessions log
+// a worklog to the issue in their tags or note when they stop
+type Jira struct {
+	// URL is the address of the site, like https://acme.atlassian.net
+	URL string
+	// Email authenticates the API token on Jira Cloud, the token is a
+	// personal access token of Jira Data Center when it's empty
+	Email    string
+	APIToken string
+	Projects []string
+	// DryRun prints the worklogs instead of posting them
+	DryRun bool
+}
+
+// Logs tells if the sessions of the project log their time to Jira
+func (j Jira) Logs(project string) bool {
+	return j.URL != "" && j.APIToken != "" && slices.Contains(j.Projects, project)
+}
+
+// Default lengths of the intervals of the pomodoro mode
+const (
+	DefaultPomodoroWork       = 25 * time.Minute
+	DefaultPomodoroShortBreak = 5 * time.Minute
+	DefaultPomodoroLongBreak  = 15 * time.Minute
+	DefaultLongBreakEvery     = 4
+)
+
+// Pomodoro holds the lengths of the work and break intervals of the pomodoro
+// mode, the zero values keep the defaults
+type Pomodoro struct {
+	Work       time.Duration
+	ShortBreak time.Duration
+	LongBreak  time.Duration
+	// LongBreakEvery is the number of pomodoros followed by a long break
+	LongBreakEvery int
+}
+
+func (p Pomodoro) WorkLength() time.Duration {
+	if p.Work <= 0 {
+		return DefaultPomodoroWork
+	}
+
+	return p.Work
+}
+
+// BreakAfter returns the length of the break following the pomodoro with the
+// number, counted from 1, and whether it's a long break
+func (p Pomodoro) BreakAfter(number int) (time.Duration, bool) {
+	every := p.LongBreakEvery
+	if every <= 0 {
+		every = DefaultLongBreakEvery
+	}
+
+	if number > 0 && number%every == 0 {
+		if p.LongBreak <= 0 {
+			return DefaultPomodoroLongBreak, true
+		}
+		return p.LongBreak, true
+	}
+
+	if p.ShortBreak <= 0 {
+		return DefaultPomodoroShortBreak, false
+	}
+	return p.ShortBreak, false
+}
+
+// DefaultNoSessionAfter is how long no session flows during the work hours
+// before it's reminded
+const DefaultNoSessionAfter = 15 * time.Minute
+
+// DefaultBackupKeep is the number of backups kept by default
+const DefaultBackupKeep = 7
+
+// Backups holds the settings of the backups of the flow folder
+type Backups struct {
+	// Folder is where the backups are written, see BackupFolder
+	Folder string
+	// Every is how often 'flow daemon' backs up the flow folder, off when
+	// zero
+	Every time.Duration
+	// Keep is the number of backups kept, DefaultBackupKeep when zero
+	Keep int
+}
+
+// BackupFolder returns the folder of the backups, the flow folder with a
+// -backups suffix when none is configured, next to the flow folder so that
+// a backup isn't part of the next one
+func (b Backups) BackupFolder(flowFolder string) string {
+	if b.Folder != "" {
+		return b.Folder
+	}
+
+	return filepath.Clean(flowFolder) + "-backups"
+}
+
+func (b Backups) KeepLimit() int {
+	if b.Keep <= 0 {
+		return DefaultBackupKeep
+	}
+
+	return b.Keep
+}
+
+// Notifications tells which desktop notifications are shown, the zero value
+// only notifies the intervals of the pomodoro mode
+type Notifications struct {
+	// LongSession reminds of a session flowing for longer, off when zero
+	LongSession time.Duration
+	// WorkHours reminds that no session is flowing during them, off when nil
+	WorkHours *session.TagRule
+	// NoSessionAfter is how long no session flows during the work hours
+	// before the reminder, DefaultNoSessionAfter when zero
+	NoSessionAfter time.Duration
+	// UntrackedAfter alerts once that much working time of the work hours
+	// went by without a session, off when zero
+	UntrackedAfter time.Duration
+	// MutePomodoro doesn't notify the intervals of 'flow pomodoro'
+	MutePomodoro bool
+}
+
+// Reminds tells if 'flow daemon' has reminders to show
+func (n Notifications) Reminds() bool {
+	return n.LongSession > 0 || n.WorkHours != nil
+}
+
+// Roles of the members in the projects of the team server
+const (
+	// RoleViewer reads the sessions and reports of the project
+	RoleViewer = "viewer"
+	// RoleTracker starts, stops, logs and edits the sessions of the project
+	RoleTracker = "tracker"
+	// RoleApprover approves the sessions of the project
+	RoleApprover = "approver"
+)
+
+var Roles = []string{RoleViewer, RoleTracker, RoleApprover}
+
+// AllProjects gives a role in every project
+const AllProjects = "*"
+
+// Member is a user of the API of 'flow serve', authenticated by its token
+type Member struct {
+	Name  string
+	Token string
+	// Roles maps each role to the projects the member has it in
+	Roles map[string][]string
+}
+
+// Has tells if the member has the role in the project, trackers and
+// approvers view their projects too
+func (m Member) Has(role string, project string) bool {
+	if role == RoleViewer && (m.Has(RoleTracker, project) || m.Has(RoleApprover, project)) {
+		return true
+	}
+
+	projects := m.Roles[role]
+	return slices.Contains(projects, AllProjects) || slices.Contains(projects, project)
+}
+
+// Toggl holds the API token of a Toggl Track account and how its projects and
+// tags map to the ones of flow
+type Toggl struct {
+	APIToken    string
+	WorkspaceID int64
+	// Projects maps the projects of Toggl to flow projects
+	Projects map[string]string
+	// Tags maps the tags of Toggl to flow tags
+	Tags map[string]string
+}
+
+// Git tells what 'flow start' takes from the git repository of the current
+// directory, when the directory has no project in the config
+type Git struct {
+	// Project starts the sessions for the name of the repository
+	Project bool
+	// BranchTag tags the sessions started without arguments with the branch
+	BranchTag bool
+}
+
+// Encryption lists the age recipients the session files are encrypted to, any
+// of their identities decrypts them. The files are encrypted with a
+// passphrase or a key file instead when one is set.
+type Encryption struct {
+	Recipients []string
+	// Identity is the file of the private key decrypting the session files
+	Identity string
+	// Passphrase derives the key of the session files, it's only read from
+	// the environment
+	Passphrase string
+	// KeyFile is the file whose content derives the key of the session
+	// files when there is no passphrase
+	KeyFile string
+}
+
+func (e Encryption) Enabled() bool {
+	return len(e.Recipients) > 0
+}
+
+// UsesPassphrase tells if the session files are encrypted with a passphrase
+// or a key file rather than to age recipients
+func (e Encryption) UsesPassphrase() bool {
+	return e.Passphrase != "" || e.KeyFile != ""
+}
+
+// Webhook is an URL the events of the sessions are posted to as JSON
+type Webhook struct {
+	Name string
+	URL  string
+	// Events are the types of the posted events, every event when empty
+	Events []string
+	// Payload is the template of the posted JSON, the event itself when
+	// empty
+	Payload string
+}
+
+func (w Webhook) Accepts(eventType string) bool {
+	return len(w.Events) == 0 || slices.Contains(w.Events, eventType)
+}
+
+// BillingRules returns the billing profiles, and the rounding of the sessions
+// without profile
+func (c Config) BillingRules() billing.Rules {
+	return billing.Rules{Rounding: c.Rounding, Profiles: c.BillingProfiles}
+}
+
+// CheckBillingProfile returns an error when the name isn't a profile of the
+// config file, an empty name is valid as it removes a profile
+func (c Config) CheckBillingProfile(name string) error {
+	if _, ok := c.BillingProfiles[name]; ok || name == "" {
+		return nil
+	}
+
+	names := []string{}
+	for profile := range c.BillingProfiles {
+		names = append(names, profile)
+	}
+	slices.Sort(names)
+
+	return fmt.Errorf("unknown billing profile %v. possible values: %v", name, names)
+}
+
+// DefaultTrashRetentionDays is how long the deleted sessions are kept in the
+// trash by default
+const DefaultTrashRetentionDays = 30
+
+// TrashRetention returns how long the deleted sessions are kept in the trash
+// before they're purged
+func (c Config) TrashRetention() time.Duration {
+	days := c.TrashRetentionDays
+	if days <= 0 {
+		days = DefaultTrashRetentionDays
+	}
+
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// DefaultUndoHistory is the number of operations 'flow undo' can revert by
+// default
+const DefaultUndoHistory = 20
+
+// UndoLimit returns the number of operations kept for 'flow undo'
+func (c Config) UndoLimit() int {
+	if c.UndoHistory <= 0 {
+		return DefaultUndoHistory
+	}
+
+	return c.UndoHistory
+}
+
+// LockedBefore returns the start of the first month whose sessions can be
+// changed, the zero time when the history isn't locked. With 1 month, the
+// sessions of the current and of the last month can be changed.
+func (c Config) LockedBefore(now time.Time) time.Time {
+	if c.HistoryLockMonths <= 0 {
+		return time.Time{}
+	}
+
+	return time.Date(now.Year(), now.Month()-time.Month(c.HistoryLockMonths), 1, 0, 0, 0, 0, now.Location())
+}
+
+func (c Config) FirstDayOfWeek() time.Weekday {
+	if c.WeekStart == nil {
+		return time.Monday
+	}
+
+	return *c.WeekStart
+}
+
+// ProjectOf returns the project of the directory, from the closest directory
+// of the config containing it
+func (c Config) ProjectOf(dir string) string {
+	project := ""
+	closest := ""
+
+	for configured, configuredProject := range c.Directories {
+		rel, err := filepath.Rel(configured, dir)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+
+		if len(configured) > len(closest) {
+			closest = configured
+			project = configuredProject
+		}
+	}
+
+	return project
+}
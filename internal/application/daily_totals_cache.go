@@ -0,0 +1,20 @@
+package application
+
+import (
+	"time"
+
+	"github.com/TristanShz/flow/pkg/timerange"
+)
+
+// DailyTotalsCache keeps the total flow duration of each day of a time range,
+// so that the totals aren't computed from the sessions every time. A cache
+// is only valid until a session is saved or deleted, it's up to the
+// implementation to tell when it's outdated.
+type DailyTotalsCache interface {
+	// FindDailyTotals returns the totals of the days of the time range, from
+	// the first one, false when they aren't cached or are outdated
+	FindDailyTotals(timeRange timerange.TimeRange) ([]time.Duration, bool)
+	// SaveDailyTotals caches the totals of the days of the time range,
+	// failing to cache them is ignored
+	SaveDailyTotals(timeRange timerange.TimeRange, totals []time.Duration)
+}
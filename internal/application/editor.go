@@ -0,0 +1,7 @@
+package application
+
+type Editor interface {
+	// Edit opens the file in the editor of the user and returns once it's
+	// closed
+	Edit(path string) error
+}
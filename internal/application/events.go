@@ -0,0 +1,65 @@
+package application
+
+import (
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+// Types of the events published along the lifecycle of the sessions
+const (
+	EventSessionStarted = "session.started"
+	EventSessionStopped = "session.stopped"
+	EventSessionPaused  = "session.paused"
+	EventSessionResumed = "session.resumed"
+	EventSessionEdited  = "session.edited"
+	// EventWorkingHoursUntracked is published by 'flow daemon' when the
+	// working time without session reaches the untracked_after of the
+	// notifications
+	EventWorkingHoursUntracked = "working_hours.untracked"
+)
+
+var EventTypes = []string{
+	EventSessionStarted,
+	EventSessionStopped,
+	EventSessionPaused,
+	EventSessionResumed,
+	EventSessionEdited,
+	EventWorkingHoursUntracked,
+}
+
+// Event tells that a session went through a step of its lifecycle, the
+// session is the one saved by the use case
+type Event struct {
+	Type    string
+	At      time.Time
+	Session session.Session
+	// Untracked is the working time without session of an
+	// EventWorkingHoursUntracked, whose session is the last one stopped
+	Untracked time.Duration
+}
+
+// EventPublisher is given the events of the use cases once their changes
+// are saved. Publishing never fails a use case, the subscribers handle their
+// own errors.
+type EventPublisher interface {
+	Publish(event Event)
+}
+
+type EventHandler func(event Event)
+
+// EventBus publishes the events to the handlers subscribed to them, in the
+// order of their subscription
+type EventBus struct {
+	handlers []EventHandler
+}
+
+func (b *EventBus) Subscribe(handler EventHandler) {
+	b.handlers = append(b.handlers, handler)
+}
+
+func (b *EventBus) Publish(event Event) {
+	for _, handler := range b.handlers {
+		handler(event)
+	}
+}
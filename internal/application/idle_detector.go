@@ -0,0 +1,14 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrIdleUnsupported = errors.New("the idle time can't be read on this system")
+
+type IdleDetector interface {
+	// IdleTime returns how long the keyboard and the mouse haven't been used
+	IdleTime(ctx context.Context) (time.Duration, error)
+}
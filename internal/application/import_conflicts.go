@@ -0,0 +1,41 @@
+package application
+
+import "github.com/TristanShz/flow/internal/domain/session"
+
+const (
+	// ConflictRunning is a time entry still running in the other tracker
+	ConflictRunning = "running"
+	// ConflictOverlap is a time entry overlapping sessions already in flow
+	ConflictOverlap = "overlap"
+)
+
+const (
+	// ResolutionImport saves the session as it is
+	ResolutionImport = "import"
+	// ResolutionAdjust saves the session moved out of the sessions it overlaps
+	ResolutionAdjust = "adjust"
+	// ResolutionSkip drops the session
+	ResolutionSkip = "skip"
+)
+
+var Resolutions = []string{ResolutionImport, ResolutionAdjust, ResolutionSkip}
+
+// ImportConflict is an imported session which wasn't saved, with the
+// resolution to apply when resolving the conflicts of the import. The
+// resolution is the suggested one until the user changes it.
+type ImportConflict struct {
+	Reason  string
+	Session session.Session
+	// Conflicting are the ids of the flow sessions the session overlaps
+	Conflicting []string
+	Resolution  string
+	// Suggestion explains the resolutions to the user
+	Suggestion string
+}
+
+// ImportConflictsRepository keeps the conflicts of the last import, saving
+// no conflicts forgets them
+type ImportConflictsRepository interface {
+	Save(conflicts []ImportConflict) error
+	FindAll() ([]ImportConflict, error)
+}
@@ -0,0 +1,10 @@
+package application
+
+import "github.com/TristanShz/flow/internal/domain/invoice"
+
+// InvoiceRepository keeps the issued invoices, which number the next ones
+// and keep their sessions from being invoiced twice
+type InvoiceRepository interface {
+	Save(invoice invoice.Invoice) error
+	FindAll() ([]invoice.Invoice, error)
+}
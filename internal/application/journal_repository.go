@@ -0,0 +1,13 @@
+package application
+
+import (
+	"github.com/TristanShz/flow/internal/domain/journal"
+	"github.com/TristanShz/flow/pkg/timerange"
+)
+
+type JournalRepository interface {
+	Save(entry journal.Entry) error
+	// FindAll returns the entries of the days in the time range, sorted by
+	// day, every entry for the zero time range
+	FindAll(timeRange timerange.TimeRange) []journal.Entry
+}
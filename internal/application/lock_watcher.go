@@ -0,0 +1,18 @@
+package application
+
+import (
+	"context"
+	"time"
+)
+
+// LockEvent is sent when the screen gets locked or unlocked, closing the lid
+// and going to sleep count as locking the screen
+type LockEvent struct {
+	At     time.Time
+	Locked bool
+}
+
+type LockWatcher interface {
+	// Watch sends the lock events to the channel until the context is done
+	Watch(ctx context.Context, events chan<- LockEvent) error
+}
@@ -0,0 +1,18 @@
+package application
+
+import (
+	"context"
+	"time"
+)
+
+// MeetingEvent is sent when a meeting of the calendar starts or ends
+type MeetingEvent struct {
+	At      time.Time
+	Title   string
+	Started bool
+}
+
+type MeetingWatcher interface {
+	// Watch sends the meeting events to the channel until the context is done
+	Watch(ctx context.Context, events chan<- MeetingEvent) error
+}
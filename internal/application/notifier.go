@@ -0,0 +1,15 @@
+package application
+
+import "errors"
+
+var ErrNotificationsUnsupported = errors.New("desktop notifications can't be shown on this system")
+
+// Notification is shown on the desktop, e.g. at the end of a pomodoro
+type Notification struct {
+	Title   string
+	Message string
+}
+
+type Notifier interface {
+	Notify(notification Notification) error
+}
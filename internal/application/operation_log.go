@@ -0,0 +1,47 @@
+package application
+
+import (
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+// SessionChange is a session before and after it was changed, Before is nil
+// for a created session and After is nil for a deleted one
+type SessionChange struct {
+	Before *session.Session `json:",omitempty"`
+	After  *session.Session `json:",omitempty"`
+}
+
+// SessionId returns the id of the changed session
+func (c SessionChange) SessionId() string {
+	if c.After != nil {
+		return c.After.Id
+	}
+
+	return c.Before.Id
+}
+
+// Operation records the changes a command made to the sessions and to the
+// active session, with what they were before, so that it can be undone
+type Operation struct {
+	Id      string
+	At      time.Time
+	Command string
+	Changes []SessionChange
+	// Acquired is the session the command made active, Released the one it
+	// made inactive
+	Acquired string `json:",omitempty"`
+	Released string `json:",omitempty"`
+}
+
+// OperationLog keeps the last operations which changed the sessions, for
+// 'flow undo'
+type OperationLog interface {
+	// Record adds the operation, or replaces the operation having its id
+	// with it, and forgets the oldest ones beyond the limit of the log
+	Record(operation Operation) error
+	// FindAll returns the operations from the oldest to the latest
+	FindAll() ([]Operation, error)
+	Remove(id string) error
+}
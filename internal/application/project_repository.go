@@ -0,0 +1,10 @@
+package application
+
+import "github.com/TristanShz/flow/internal/domain/project"
+
+type ProjectRepository interface {
+	Save(project project.Project) error
+	FindByName(name string) *project.Project
+	FindAll() []project.Project
+	Delete(name string) error
+}
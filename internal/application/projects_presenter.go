@@ -0,0 +1,5 @@
+package application
+
+type ProjectsPresenter interface {
+	ShowProjects(projects []string)
+}
@@ -0,0 +1,17 @@
+package application
+
+import "errors"
+
+// LocalStore is the name of the store of the flow folder in use
+const LocalStore = "local"
+
+var ErrReadOnlyStore = errors.New("the sessions of this store can't be changed")
+
+// ReportStore is a store of sessions reported on its own, along with the
+// projects and the journal its reports need
+type ReportStore struct {
+	Name              string
+	SessionRepository SessionRepository
+	ProjectRepository ProjectRepository
+	JournalRepository JournalRepository
+}
@@ -0,0 +1,18 @@
+package application
+
+import "time"
+
+// ArchivedMonth is a month of sessions packed in an archive
+type ArchivedMonth struct {
+	Month    string
+	Sessions int
+}
+
+// SessionArchiver packs the old sessions of the session repository into
+// archives by month, the archived sessions are still found by the
+// repository but they're only read when they're needed
+type SessionArchiver interface {
+	// Archive packs the ended sessions started before the given time and
+	// returns the months of the archives they were added to
+	Archive(before time.Time) ([]ArchivedMonth, error)
+}
@@ -0,0 +1,19 @@
+package application
+
+const (
+	InvalidFilenameIssue = "invalid-filename"
+	CorruptedDataIssue   = "corrupted-data"
+)
+
+type SessionFileIssue struct {
+	Filename string
+	Kind     string
+	Reason   string
+}
+
+type SessionFilesDoctor interface {
+	Diagnose() []SessionFileIssue
+	// Repair fixes the file when the session it holds can still be read and
+	// moves it to quarantine otherwise.
+	Repair(issue SessionFileIssue) (quarantined bool, err error)
+}
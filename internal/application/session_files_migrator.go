@@ -0,0 +1,10 @@
+package application
+
+type SessionFilesMigrator interface {
+	// FindLegacyFiles lists the session files still named with an outdated
+	// filename scheme or holding an outdated format
+	FindLegacyFiles() []string
+	// Migrate rewrites the session file with the current filename scheme and
+	// format
+	Migrate(filename string) error
+}
@@ -1,21 +1,97 @@
 package application
 
 import (
+	"time"
+
 	"github.com/TristanShz/flow/internal/domain/session"
 	"github.com/TristanShz/flow/pkg/timerange"
 )
 
+const (
+	TagsMatchAny = "any"
+	TagsMatchAll = "all"
+)
+
+const (
+	OrderAscending  = "asc"
+	OrderDescending = "desc"
+)
+
 type SessionsFilters struct {
 	Timerange timerange.TimeRange
 	Project   string
+	Tags      []string
+	// TagsMatch is either TagsMatchAny (default) or TagsMatchAll
+	TagsMatch string
+	// Where keeps the sessions matching a where expression, see ParseWhere
+	Where Condition
+	// Order sorts the sessions by start time, OrderAscending (default) or
+	// OrderDescending for the newest first
+	Order string
+	// Offset skips the first sessions matching the other filters, in the
+	// order of the filters
+	Offset int
+	// Limit keeps at most this number of sessions, all of them when it's zero
+	Limit int
+}
+
+func (f SessionsFilters) IsDescending() bool {
+	return f.Order == OrderDescending
+}
+
+// Page skips the Offset first sessions, which are in the order of the
+// filters, and keeps the Limit next ones
+func (f SessionsFilters) Page(sessions []session.Session) []session.Session {
+	sessions = sessions[min(max(f.Offset, 0), len(sessions)):]
+	if f.Limit > 0 && f.Limit < len(sessions) {
+		sessions = sessions[:f.Limit]
+	}
+
+	return sessions
+}
+
+func (f SessionsFilters) MatchTags(s session.Session) bool {
+	if len(f.Tags) == 0 {
+		return true
+	}
+
+	if f.TagsMatch == TagsMatchAll {
+		return s.HasAllTags(f.Tags)
+	}
+
+	return s.HasAnyTag(f.Tags)
+}
+
+func (f SessionsFilters) MatchWhere(s session.Session) bool {
+	return f.Where == nil || f.Where.Match(s)
+}
+
+// SessionMetadata is what a session is found by, without its tags, end time
+// and note
+type SessionMetadata struct {
+	Id        string
+	Project   string
+	StartTime time.Time
 }
 
 type SessionRepository interface {
 	Save(session session.Session) error
 	Delete(id string) error
-	FindById(id string) *session.Session
-	FindLastSession() *session.Session
+	// FindById returns nil without error when there is no session with the id
+	FindById(id string) (*session.Session, error)
+	// FindLastSession returns nil without error when there is no session
+	FindLastSession() (*session.Session, error)
 	FindAllSessions(filters *SessionsFilters) []session.Session
+	// ForEachSession calls fn with the sessions FindAllSessions would return,
+	// in the same order, without building the list of them. It stops at the
+	// first error of fn and returns it. fn must not change the sessions of the
+	// repository.
+	ForEachSession(filters *SessionsFilters, fn func(session.Session) error) error
+	// FindAllSessionMetadata lists the sessions matching the time range, the
+	// project, the order and the page of the filters, the other filters are
+	// ignored. It's much faster than FindAllSessions when only the ids are
+	// needed, e.g. to complete them.
+	FindAllSessionMetadata(filters *SessionsFilters) []SessionMetadata
 	FindAllProjects() []string
 	FindAllProjectTags(project string) []string
 }
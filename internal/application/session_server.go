@@ -0,0 +1,10 @@
+package application
+
+import "context"
+
+// SessionServer serves the sessions and the active session to the flow
+// commands of the machine, which then don't read the flow folder themselves
+type SessionServer interface {
+	// Serve serves until the context is done
+	Serve(ctx context.Context) error
+}
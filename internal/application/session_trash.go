@@ -0,0 +1,28 @@
+package application
+
+import (
+	"errors"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+var ErrSessionNotTrashed = errors.New("session not found in the trash")
+
+// TrashedSession is a deleted session, kept in the trash until it's purged
+type TrashedSession struct {
+	DeletedAt time.Time
+	Session   session.Session
+}
+
+// SessionTrash holds the sessions deleted from the session repository, they
+// can be restored until they're purged
+type SessionTrash interface {
+	// FindAllTrashed returns the trashed sessions, the last deleted first
+	FindAllTrashed() ([]TrashedSession, error)
+	// Restore moves the trashed session back to the session repository
+	Restore(id string) (session.Session, error)
+	// Purge removes the sessions deleted before the given time for good, or
+	// every session with the zero time, and returns how many were removed
+	Purge(deletedBefore time.Time) (int, error)
+}
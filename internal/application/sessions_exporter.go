@@ -0,0 +1,23 @@
+package application
+
+import (
+	"github.com/TristanShz/flow/internal/domain/journal"
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+type SessionsExporter interface {
+	Export(sessions []session.Session) error
+}
+
+// JournalExporter is a SessionsExporter which also writes the journal entries
+// of the exported period
+type JournalExporter interface {
+	ExportWithJournal(sessions []session.Session, entries []journal.Entry) error
+}
+
+// SessionsStreamExporter is a SessionsExporter which exports the sessions as
+// they're read, without holding all of them. forEach gives the sessions to
+// fn in the order of their start times, and stops at the first error of fn.
+type SessionsStreamExporter interface {
+	ExportEach(forEach func(fn func(session.Session) error) error) error
+}
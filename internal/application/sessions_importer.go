@@ -0,0 +1,9 @@
+package application
+
+import "github.com/TristanShz/flow/internal/domain/session"
+
+// SessionsImporter reads the sessions of another time tracker, with the
+// id they have there in their external id metadata when they have one
+type SessionsImporter interface {
+	Import() ([]session.Session, error)
+}
@@ -7,4 +7,10 @@ import (
 type SessionsReportPresenter interface {
 	ShowByProject(sessionsReport sessionsreport.SessionsReport)
 	ShowByDay(sessionsReport sessionsreport.SessionsReport)
+	ShowByClient(sessionsReport sessionsreport.SessionsReport)
+	ShowEarnings(earningsReport sessionsreport.EarningsReport)
+	ShowGaps(gapsReport sessionsreport.GapsReport)
+	// ShowComparison receives the diff of the period the report is compared
+	// to, as A, and of the period of the report, as B
+	ShowComparison(periodsDiff sessionsreport.PeriodsDiff)
 }
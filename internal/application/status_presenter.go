@@ -0,0 +1,13 @@
+package application
+
+import (
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+type StatusPresenter interface {
+	// ShowStatus receives a nil session when no session is flowing and a nil
+	// weeklyTrend when the trend was not requested.
+	ShowStatus(session *session.Session, duration time.Duration, weeklyTrend []time.Duration)
+}
@@ -0,0 +1,29 @@
+package application
+
+import "time"
+
+// StoreInfo describes where and how sessions are stored, it's the first thing
+// to look at when something goes wrong
+type StoreInfo struct {
+	OldestSession time.Time
+	NewestSession time.Time
+	Backend       string
+	Location      string
+	SessionFiles  int
+	// TotalSize is the size in bytes of all the files of the store
+	TotalSize       int64
+	IndexedSessions int
+	// StaleIndexEntries counts the session files added, changed or removed
+	// since they were indexed
+	StaleIndexEntries int
+	QuarantinedFiles  int
+	// TrashedFiles counts the deleted sessions kept in the trash
+	TrashedFiles int
+	// CorruptedFiles counts the files that can't be read, see
+	// SessionFilesDoctor
+	CorruptedFiles int
+}
+
+type StoreInspector interface {
+	Info() (StoreInfo, error)
+}
@@ -0,0 +1,16 @@
+package application
+
+import "github.com/TristanShz/flow/internal/domain/project"
+
+// TemplatesRepository keeps the last synced templates, so they apply without
+// fetching them on every command
+type TemplatesRepository interface {
+	Get() project.Templates
+	Save(templates project.Templates) error
+}
+
+// TemplatesFetcher reads the templates shared by a team, from a git
+// repository, an URL or a local file
+type TemplatesFetcher interface {
+	Fetch(source string) (project.Templates, error)
+}
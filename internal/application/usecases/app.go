@@ -2,23 +2,126 @@ package app
 
 import (
 	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/application/usecases/backup/createbackup"
+	"github.com/TristanShz/flow/internal/application/usecases/backup/listbackups"
+	"github.com/TristanShz/flow/internal/application/usecases/backup/restorebackup"
+	"github.com/TristanShz/flow/internal/application/usecases/client/listclients"
+	"github.com/TristanShz/flow/internal/application/usecases/client/setclient"
+	"github.com/TristanShz/flow/internal/application/usecases/export/exportsessions"
 	abortsession "github.com/TristanShz/flow/internal/application/usecases/flowsession/abort"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/adjustsession"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/autostop"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/deletesession"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/deletesessions"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/diffperiods"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/editsession"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/federatedreport"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/heatmap"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/listsessionmetadata"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/listsessions"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/logsession"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/meetingpause"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/mergesessions"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/pomodoro"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/reminders"
 	"github.com/TristanShz/flow/internal/application/usecases/flowsession/sessionstatus"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/showsession"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/splitsession"
 	startsession "github.com/TristanShz/flow/internal/application/usecases/flowsession/start"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/stats"
 	"github.com/TristanShz/flow/internal/application/usecases/flowsession/stopsession"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/suggeststop"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/suggesttags"
 	"github.com/TristanShz/flow/internal/application/usecases/flowsession/viewsessionsreport"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/weeklytrend"
+	"github.com/TristanShz/flow/internal/application/usecases/import/importsessions"
+	"github.com/TristanShz/flow/internal/application/usecases/import/resolveconflicts"
+	"github.com/TristanShz/flow/internal/application/usecases/invoice/createinvoices"
+	"github.com/TristanShz/flow/internal/application/usecases/journal/addjournalentry"
+	"github.com/TristanShz/flow/internal/application/usecases/journal/listjournal"
+	"github.com/TristanShz/flow/internal/application/usecases/operation/listoperations"
+	"github.com/TristanShz/flow/internal/application/usecases/operation/undo"
+	"github.com/TristanShz/flow/internal/application/usecases/project/forecast"
+	"github.com/TristanShz/flow/internal/application/usecases/project/goals"
 	"github.com/TristanShz/flow/internal/application/usecases/project/list"
+	"github.com/TristanShz/flow/internal/application/usecases/project/listtags"
+	"github.com/TristanShz/flow/internal/application/usecases/project/renameproject"
+	"github.com/TristanShz/flow/internal/application/usecases/project/setproject"
+	"github.com/TristanShz/flow/internal/application/usecases/project/synctemplates"
+	storearchive "github.com/TristanShz/flow/internal/application/usecases/store/archive"
+	storedoctor "github.com/TristanShz/flow/internal/application/usecases/store/doctor"
+	storeinfo "github.com/TristanShz/flow/internal/application/usecases/store/info"
+	storemigrate "github.com/TristanShz/flow/internal/application/usecases/store/migrate"
+	"github.com/TristanShz/flow/internal/application/usecases/tag/deletetag"
+	"github.com/TristanShz/flow/internal/application/usecases/tag/renametag"
+	"github.com/TristanShz/flow/internal/application/usecases/tag/retagsessions"
+	"github.com/TristanShz/flow/internal/application/usecases/trash/listtrash"
+	"github.com/TristanShz/flow/internal/application/usecases/trash/purgetrash"
+	"github.com/TristanShz/flow/internal/application/usecases/trash/restoresession"
 )
 
 type App struct {
-	SessionRepository         application.SessionRepository
-	DateProvider              application.DateProvider
-	StartFlowSessionUseCase   startsession.UseCase
-	StopFlowSessionUseCase    stopsession.UseCase
-	AbortFlowSessionUseCase   abortsession.UseCase
-	FlowSessionStatusUseCase  sessionstatus.UseCase
-	ListProjectsUseCase       list.UseCase
-	ViewSessionsReportUseCase viewsessionsreport.UseCase
+	// Config is set once the app is created, the zero Config keeps the
+	// defaults
+	Config                     application.Config
+	SessionRepository          application.SessionRepository
+	DateProvider               application.DateProvider
+	StartFlowSessionUseCase    startsession.UseCase
+	StopFlowSessionUseCase     stopsession.UseCase
+	AbortFlowSessionUseCase    abortsession.UseCase
+	FlowSessionStatusUseCase   sessionstatus.UseCase
+	ListProjectsUseCase        list.UseCase
+	ViewSessionsReportUseCase  viewsessionsreport.UseCase
+	WeeklyTrendUseCase         weeklytrend.UseCase
+	SetClientUseCase           setclient.UseCase
+	ListClientsUseCase         listclients.UseCase
+	DoctorUseCase              storedoctor.UseCase
+	MigrateUseCase             storemigrate.UseCase
+	SuggestTagsUseCase         suggesttags.UseCase
+	ExportSessionsUseCase      exportsessions.UseCase
+	SetProjectUseCase          setproject.UseCase
+	AutostopUseCase            autostop.UseCase
+	EditSessionUseCase         editsession.UseCase
+	LogSessionUseCase          logsession.UseCase
+	SplitSessionUseCase        splitsession.UseCase
+	MergeSessionsUseCase       mergesessions.UseCase
+	RenameProjectUseCase       renameproject.UseCase
+	RenameTagUseCase           renametag.UseCase
+	DeleteTagUseCase           deletetag.UseCase
+	RetagSessionsUseCase       retagsessions.UseCase
+	DiffPeriodsUseCase         diffperiods.UseCase
+	InfoUseCase                storeinfo.UseCase
+	ShowSessionUseCase         showsession.UseCase
+	SyncTemplatesUseCase       synctemplates.UseCase
+	AdjustSessionUseCase       adjustsession.UseCase
+	MeetingPauseUseCase        meetingpause.UseCase
+	ListProjectTagsUseCase     listtags.UseCase
+	DeleteSessionUseCase       deletesession.UseCase
+	ImportSessionsUseCase      importsessions.UseCase
+	AddJournalEntryUseCase     addjournalentry.UseCase
+	ListJournalUseCase         listjournal.UseCase
+	PomodoroUseCase            pomodoro.UseCase
+	RemindersUseCase           reminders.UseCase
+	SuggestStopUseCase         suggeststop.UseCase
+	ForecastUseCase            forecast.UseCase
+	StatsUseCase               stats.UseCase
+	HeatmapUseCase             heatmap.UseCase
+	ResolveConflictsUseCase    resolveconflicts.UseCase
+	GoalsUseCase               goals.UseCase
+	CreateInvoicesUseCase      createinvoices.UseCase
+	FederatedReportUseCase     federatedreport.UseCase
+	ListTrashUseCase           listtrash.UseCase
+	RestoreSessionUseCase      restoresession.UseCase
+	PurgeTrashUseCase          purgetrash.UseCase
+	UndoUseCase                undo.UseCase
+	ListOperationsUseCase      listoperations.UseCase
+	DeleteSessionsUseCase      deletesessions.UseCase
+	ArchiveUseCase             storearchive.UseCase
+	CreateBackupUseCase        createbackup.UseCase
+	RestoreBackupUseCase       restorebackup.UseCase
+	ListBackupsUseCase         listbackups.UseCase
+	ListSessionsUseCase        listsessions.UseCase
+	ListSessionMetadataUseCase listsessionmetadata.UseCase
 }
 
 func NewApp(
@@ -30,15 +133,115 @@ func NewApp(
 	flowSessionStatusUseCase sessionstatus.UseCase,
 	listProjectsUseCase list.UseCase,
 	viewSessionsReportUseCase viewsessionsreport.UseCase,
+	weeklyTrendUseCase weeklytrend.UseCase,
+	setClientUseCase setclient.UseCase,
+	listClientsUseCase listclients.UseCase,
+	doctorUseCase storedoctor.UseCase,
+	migrateUseCase storemigrate.UseCase,
+	suggestTagsUseCase suggesttags.UseCase,
+	exportSessionsUseCase exportsessions.UseCase,
+	setProjectUseCase setproject.UseCase,
+	autostopUseCase autostop.UseCase,
+	editSessionUseCase editsession.UseCase,
+	logSessionUseCase logsession.UseCase,
+	splitSessionUseCase splitsession.UseCase,
+	mergeSessionsUseCase mergesessions.UseCase,
+	renameProjectUseCase renameproject.UseCase,
+	renameTagUseCase renametag.UseCase,
+	deleteTagUseCase deletetag.UseCase,
+	retagSessionsUseCase retagsessions.UseCase,
+	diffPeriodsUseCase diffperiods.UseCase,
+	infoUseCase storeinfo.UseCase,
+	showSessionUseCase showsession.UseCase,
+	syncTemplatesUseCase synctemplates.UseCase,
+	adjustSessionUseCase adjustsession.UseCase,
+	meetingPauseUseCase meetingpause.UseCase,
+	listProjectTagsUseCase listtags.UseCase,
+	deleteSessionUseCase deletesession.UseCase,
+	importSessionsUseCase importsessions.UseCase,
+	addJournalEntryUseCase addjournalentry.UseCase,
+	listJournalUseCase listjournal.UseCase,
+	pomodoroUseCase pomodoro.UseCase,
+	remindersUseCase reminders.UseCase,
+	suggestStopUseCase suggeststop.UseCase,
+	forecastUseCase forecast.UseCase,
+	statsUseCase stats.UseCase,
+	heatmapUseCase heatmap.UseCase,
+	resolveConflictsUseCase resolveconflicts.UseCase,
+	goalsUseCase goals.UseCase,
+	createInvoicesUseCase createinvoices.UseCase,
+	federatedReportUseCase federatedreport.UseCase,
+	listTrashUseCase listtrash.UseCase,
+	restoreSessionUseCase restoresession.UseCase,
+	purgeTrashUseCase purgetrash.UseCase,
+	undoUseCase undo.UseCase,
+	listOperationsUseCase listoperations.UseCase,
+	deleteSessionsUseCase deletesessions.UseCase,
+	archiveUseCase storearchive.UseCase,
+	createBackupUseCase createbackup.UseCase,
+	restoreBackupUseCase restorebackup.UseCase,
+	listBackupsUseCase listbackups.UseCase,
+	listSessionsUseCase listsessions.UseCase,
+	listSessionMetadataUseCase listsessionmetadata.UseCase,
 ) *App {
 	return &App{
-		SessionRepository:         sessionRepository,
-		DateProvider:              dateProvider,
-		StartFlowSessionUseCase:   startFlowSessionUseCase,
-		StopFlowSessionUseCase:    stopFlowSessionUseCase,
-		AbortFlowSessionUseCase:   abortFlowSessionUseCase,
-		FlowSessionStatusUseCase:  flowSessionStatusUseCase,
-		ListProjectsUseCase:       listProjectsUseCase,
-		ViewSessionsReportUseCase: viewSessionsReportUseCase,
+		SessionRepository:          sessionRepository,
+		DateProvider:               dateProvider,
+		StartFlowSessionUseCase:    startFlowSessionUseCase,
+		StopFlowSessionUseCase:     stopFlowSessionUseCase,
+		AbortFlowSessionUseCase:    abortFlowSessionUseCase,
+		FlowSessionStatusUseCase:   flowSessionStatusUseCase,
+		ListProjectsUseCase:        listProjectsUseCase,
+		ViewSessionsReportUseCase:  viewSessionsReportUseCase,
+		WeeklyTrendUseCase:         weeklyTrendUseCase,
+		SetClientUseCase:           setClientUseCase,
+		ListClientsUseCase:         listClientsUseCase,
+		DoctorUseCase:              doctorUseCase,
+		MigrateUseCase:             migrateUseCase,
+		SuggestTagsUseCase:         suggestTagsUseCase,
+		ExportSessionsUseCase:      exportSessionsUseCase,
+		SetProjectUseCase:          setProjectUseCase,
+		AutostopUseCase:            autostopUseCase,
+		EditSessionUseCase:         editSessionUseCase,
+		LogSessionUseCase:          logSessionUseCase,
+		SplitSessionUseCase:        splitSessionUseCase,
+		MergeSessionsUseCase:       mergeSessionsUseCase,
+		RenameProjectUseCase:       renameProjectUseCase,
+		RenameTagUseCase:           renameTagUseCase,
+		DeleteTagUseCase:           deleteTagUseCase,
+		RetagSessionsUseCase:       retagSessionsUseCase,
+		DiffPeriodsUseCase:         diffPeriodsUseCase,
+		InfoUseCase:                infoUseCase,
+		ShowSessionUseCase:         showSessionUseCase,
+		SyncTemplatesUseCase:       syncTemplatesUseCase,
+		AdjustSessionUseCase:       adjustSessionUseCase,
+		MeetingPauseUseCase:        meetingPauseUseCase,
+		ListProjectTagsUseCase:     listProjectTagsUseCase,
+		DeleteSessionUseCase:       deleteSessionUseCase,
+		ImportSessionsUseCase:      importSessionsUseCase,
+		AddJournalEntryUseCase:     addJournalEntryUseCase,
+		ListJournalUseCase:         listJournalUseCase,
+		PomodoroUseCase:            pomodoroUseCase,
+		RemindersUseCase:           remindersUseCase,
+		SuggestStopUseCase:         suggestStopUseCase,
+		ForecastUseCase:            forecastUseCase,
+		StatsUseCase:               statsUseCase,
+		HeatmapUseCase:             heatmapUseCase,
+		ResolveConflictsUseCase:    resolveConflictsUseCase,
+		GoalsUseCase:               goalsUseCase,
+		CreateInvoicesUseCase:      createInvoicesUseCase,
+		FederatedReportUseCase:     federatedReportUseCase,
+		ListTrashUseCase:           listTrashUseCase,
+		RestoreSessionUseCase:      restoreSessionUseCase,
+		PurgeTrashUseCase:          purgeTrashUseCase,
+		UndoUseCase:                undoUseCase,
+		ListOperationsUseCase:      listOperationsUseCase,
+		DeleteSessionsUseCase:      deleteSessionsUseCase,
+		ArchiveUseCase:             archiveUseCase,
+		CreateBackupUseCase:        createBackupUseCase,
+		RestoreBackupUseCase:       restoreBackupUseCase,
+		ListBackupsUseCase:         listBackupsUseCase,
+		ListSessionsUseCase:        listSessionsUseCase,
+		ListSessionMetadataUseCase: listSessionMetadataUseCase,
 	}
 }
@@ -0,0 +1,58 @@
+package createbackup
+
+import (
+	"github.com/TristanShz/flow/internal/application"
+)
+
+type Result struct {
+	// Backup is nil when the last backup is recent enough
+	Backup *application.Backup
+	// Removed are the paths of the old backups removed beyond the limit
+	Removed []string
+}
+
+type UseCase struct {
+	backupStore  application.BackupStore
+	dateProvider application.DateProvider
+}
+
+func (s UseCase) Execute(command Command) (Result, error) {
+	result := Result{Removed: []string{}}
+
+	backups, err := s.backupStore.FindAllBackups()
+	if err != nil {
+		return result, err
+	}
+
+	now := s.dateProvider.GetNow()
+	if command.Every > 0 && len(backups) > 0 && now.Sub(backups[len(backups)-1].Manifest.CreatedAt) < command.Every {
+		return result, nil
+	}
+
+	backup, err := s.backupStore.Backup(now)
+	if err != nil {
+		return result, err
+	}
+	result.Backup = &backup
+	backups = append(backups, backup)
+
+	if command.Keep <= 0 {
+		return result, nil
+	}
+
+	for _, old := range backups[:max(len(backups)-command.Keep, 0)] {
+		if err := s.backupStore.RemoveBackup(old.Path); err != nil {
+			return result, err
+		}
+		result.Removed = append(result.Removed, old.Path)
+	}
+
+	return result, nil
+}
+
+func NewCreateBackupUseCase(backupStore application.BackupStore, dateProvider application.DateProvider) UseCase {
+	return UseCase{
+		backupStore:  backupStore,
+		dateProvider: dateProvider,
+	}
+}
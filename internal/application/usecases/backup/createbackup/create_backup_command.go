@@ -0,0 +1,13 @@
+package createbackup
+
+import "time"
+
+type Command struct {
+	// Every skips the backup when the last one is more recent, for the
+	// scheduled backups of 'flow daemon'. A backup is always made when it's
+	// zero.
+	Every time.Duration
+	// Keep is the number of backups kept, the oldest ones are removed, every
+	// backup is kept when it's zero
+	Keep int
+}
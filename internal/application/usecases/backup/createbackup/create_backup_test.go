@@ -0,0 +1,78 @@
+package createbackup_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/application/usecases/backup/createbackup"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/matryer/is"
+)
+
+func TestCreateBackup(t *testing.T) {
+	now := time.Date(2024, time.April, 17, 19, 0, 0, 0, time.UTC)
+	backupAt := func(at time.Time) application.Backup {
+		return application.Backup{
+			Path:     "flow-backup-" + at.Format("20060102-150405") + ".tar.gz",
+			Manifest: application.BackupManifest{FormatVersion: application.BackupFormatVersion, CreatedAt: at},
+		}
+	}
+	twoDaysAgo := backupAt(now.Add(-48 * time.Hour))
+	yesterday := backupAt(now.Add(-24 * time.Hour))
+	hourAgo := backupAt(now.Add(-time.Hour))
+	created := backupAt(now)
+
+	tt := []struct {
+		name         string
+		givenBackups []application.Backup
+		command      createbackup.Command
+		want         createbackup.Result
+		wantBackups  []application.Backup
+	}{
+		{
+			name:         "On demand",
+			givenBackups: []application.Backup{hourAgo},
+			want:         createbackup.Result{Backup: &created, Removed: []string{}},
+			wantBackups:  []application.Backup{hourAgo, created},
+		},
+		{
+			name:         "Scheduled and due",
+			givenBackups: []application.Backup{yesterday},
+			command:      createbackup.Command{Every: 24 * time.Hour},
+			want:         createbackup.Result{Backup: &created, Removed: []string{}},
+			wantBackups:  []application.Backup{yesterday, created},
+		},
+		{
+			name:         "Scheduled and not due",
+			givenBackups: []application.Backup{hourAgo},
+			command:      createbackup.Command{Every: 24 * time.Hour},
+			want:         createbackup.Result{Removed: []string{}},
+			wantBackups:  []application.Backup{hourAgo},
+		},
+		{
+			name:         "Oldest backups removed",
+			givenBackups: []application.Backup{twoDaysAgo, yesterday},
+			command:      createbackup.Command{Keep: 2},
+			want:         createbackup.Result{Backup: &created, Removed: []string{twoDaysAgo.Path}},
+			wantBackups:  []application.Backup{yesterday, created},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			dateProvider := infra.NewStubDateProvider()
+			dateProvider.Now = now
+			backupStore := &infra.InMemoryBackupStore{Backups: tc.givenBackups}
+			useCase := createbackup.NewCreateBackupUseCase(backupStore, dateProvider)
+
+			got, err := useCase.Execute(tc.command)
+
+			is.NoErr(err)
+			is.Equal(got, tc.want)
+			is.Equal(backupStore.Backups, tc.wantBackups)
+		})
+	}
+}
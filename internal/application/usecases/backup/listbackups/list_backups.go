@@ -0,0 +1,20 @@
+package listbackups
+
+import (
+	"github.com/TristanShz/flow/internal/application"
+)
+
+type UseCase struct {
+	backupStore application.BackupStore
+}
+
+// Execute returns the backups from the oldest to the newest
+func (s UseCase) Execute() ([]application.Backup, error) {
+	return s.backupStore.FindAllBackups()
+}
+
+func NewListBackupsUseCase(backupStore application.BackupStore) UseCase {
+	return UseCase{
+		backupStore: backupStore,
+	}
+}
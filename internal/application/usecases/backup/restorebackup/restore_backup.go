@@ -0,0 +1,169 @@
+package restorebackup
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+var ErrNoBackup = errors.New("there is no backup to restore")
+
+// Result counts the sessions of the backup by what became of them
+type Result struct {
+	Backup application.Backup
+	// Restored sessions weren't in the flow folder
+	Restored int
+	// Replaced sessions were different in the flow folder
+	Replaced int
+	// Unchanged sessions are the same in the flow folder
+	Unchanged int
+	// Skipped sessions are kept as they are in the flow folder
+	Skipped int
+	// Deleted sessions of the flow folder aren't in the backup
+	Deleted int
+}
+
+type UseCase struct {
+	sessionRepository application.SessionRepository
+	activeSessionLock application.ActiveSessionLock
+	backupStore       application.BackupStore
+}
+
+// Execute saves the sessions of the backup, the sessions already in the
+// flow folder are handled as the conflicts of the command tell. Nothing is
+// restored when one of the changed sessions is in a read-only month.
+func (s UseCase) Execute(command Command) (Result, error) {
+	conflicts := command.Conflicts
+	if conflicts == "" {
+		conflicts = ConflictsSkip
+	}
+	if !slices.Contains(Conflicts, conflicts) {
+		return Result{}, fmt.Errorf("invalid conflicts %v. possible values: %v", conflicts, strings.Join(Conflicts, ", "))
+	}
+
+	result := Result{}
+
+	backup, err := s.findBackup(command.Path)
+	if err != nil {
+		return result, err
+	}
+	result.Backup = backup
+
+	manifest, backupSessions, err := s.backupStore.ReadBackup(backup.Path)
+	if err != nil {
+		return result, err
+	}
+	result.Backup.Manifest = manifest
+
+	sessions := s.sessionRepository.FindAllSessions(nil)
+	current := map[string]session.Session{}
+	for _, existing := range sessions {
+		current[existing.Id] = existing
+	}
+
+	saved := []session.Session{}
+	changed := []session.Session{}
+	for _, restored := range backupSessions {
+		existing, ok := current[restored.Id]
+
+		switch {
+		case !ok:
+			result.Restored++
+		case sameSession(existing, restored):
+			result.Unchanged++
+			continue
+		case conflicts == ConflictsSkip:
+			result.Skipped++
+			continue
+		default:
+			result.Replaced++
+			changed = append(changed, existing)
+		}
+
+		saved = append(saved, restored)
+		changed = append(changed, restored)
+	}
+
+	deleted := []session.Session{}
+	if conflicts == ConflictsOverwrite {
+		for _, existing := range sessions {
+			if slices.ContainsFunc(backupSessions, existing.Equals) {
+				continue
+			}
+			deleted = append(deleted, existing)
+		}
+		result.Deleted = len(deleted)
+		changed = append(changed, deleted...)
+	}
+
+	if err := session.CheckLocked(command.LockedBefore, changed...); err != nil {
+		return result, err
+	}
+
+	if command.DryRun {
+		return result, nil
+	}
+
+	for _, restored := range saved {
+		if err := s.sessionRepository.Save(restored); err != nil {
+			return result, err
+		}
+	}
+
+	for _, removed := range deleted {
+		if err := s.sessionRepository.Delete(removed.Id); err != nil {
+			return result, err
+		}
+
+		// only a session in progress can be the active one
+		if !removed.EndTime.IsZero() {
+			continue
+		}
+		if err := s.activeSessionLock.Release(removed.Id); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+func (s UseCase) findBackup(path string) (application.Backup, error) {
+	if path != "" {
+		return application.Backup{Path: path}, nil
+	}
+
+	backups, err := s.backupStore.FindAllBackups()
+	if err != nil {
+		return application.Backup{}, err
+	}
+	if len(backups) == 0 {
+		return application.Backup{}, ErrNoBackup
+	}
+
+	return backups[len(backups)-1], nil
+}
+
+func sameSession(a session.Session, b session.Session) bool {
+	marshaledA, errA := json.Marshal(a)
+	marshaledB, errB := json.Marshal(b)
+
+	return errA == nil && errB == nil && bytes.Equal(marshaledA, marshaledB)
+}
+
+func NewRestoreBackupUseCase(
+	sessionRepository application.SessionRepository,
+	activeSessionLock application.ActiveSessionLock,
+	backupStore application.BackupStore,
+) UseCase {
+	return UseCase{
+		sessionRepository: sessionRepository,
+		activeSessionLock: activeSessionLock,
+		backupStore:       backupStore,
+	}
+}
@@ -0,0 +1,31 @@
+package restorebackup
+
+import "time"
+
+// What becomes of a session of the backup whose id is already in the flow
+// folder
+const (
+	// ConflictsSkip keeps the session of the flow folder
+	ConflictsSkip = "skip"
+	// ConflictsMerge replaces it with the session of the backup, the sessions
+	// which aren't in the backup are kept
+	ConflictsMerge = "merge"
+	// ConflictsOverwrite replaces it with the session of the backup, and
+	// deletes the sessions which aren't in the backup
+	ConflictsOverwrite = "overwrite"
+)
+
+var Conflicts = []string{ConflictsSkip, ConflictsMerge, ConflictsOverwrite}
+
+type Command struct {
+	// Path is the backup to restore, the latest one when it's empty
+	Path string
+	// Conflicts is ConflictsSkip (default), ConflictsMerge or
+	// ConflictsOverwrite
+	Conflicts string
+	// DryRun counts the sessions which would be restored without saving them
+	DryRun bool
+	// LockedBefore is the start of the first month whose sessions can be
+	// changed, see session.IsLocked
+	LockedBefore time.Time
+}
@@ -0,0 +1,112 @@
+package restorebackup_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/application/usecases/backup/restorebackup"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/matryer/is"
+)
+
+func TestRestoreBackup(t *testing.T) {
+	at := func(day int, hour int) time.Time {
+		return time.Date(2024, time.April, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	kept := session.Session{Id: "1", StartTime: at(15, 9), EndTime: at(15, 10), Project: "Flow"}
+	lost := session.Session{Id: "2", StartTime: at(15, 11), EndTime: at(15, 12), Project: "Flow"}
+	retagged := session.Session{Id: "3", StartTime: at(16, 9), EndTime: at(16, 10), Project: "Intranet", Tags: []string{"review"}}
+	backedUp := session.Session{Id: "3", StartTime: at(16, 9), EndTime: at(16, 10), Project: "Intranet"}
+	added := session.Session{Id: "4", StartTime: at(17, 9), EndTime: at(17, 10), Project: "Flow"}
+
+	backup := application.Backup{
+		Path:     "flow-backup-20240416-190000.tar.gz",
+		Manifest: application.BackupManifest{FormatVersion: application.BackupFormatVersion, CreatedAt: at(16, 19)},
+	}
+	newer := application.Backup{
+		Path:     "flow-backup-20240417-190000.tar.gz",
+		Manifest: application.BackupManifest{FormatVersion: application.BackupFormatVersion + 1, CreatedAt: at(17, 19)},
+	}
+	givenSessions := []session.Session{kept, retagged, added}
+
+	tt := []struct {
+		name         string
+		givenBackups []application.Backup
+		command      restorebackup.Command
+		want         restorebackup.Result
+		wantSessions []session.Session
+		wantErr      error
+	}{
+		{
+			name:         "Skip the conflicts",
+			givenBackups: []application.Backup{backup},
+			want:         restorebackup.Result{Backup: backup, Restored: 1, Unchanged: 1, Skipped: 1},
+			wantSessions: []session.Session{kept, retagged, added, lost},
+		},
+		{
+			name:         "Merge the conflicts",
+			givenBackups: []application.Backup{backup},
+			command:      restorebackup.Command{Path: backup.Path, Conflicts: restorebackup.ConflictsMerge},
+			want:         restorebackup.Result{Backup: backup, Restored: 1, Unchanged: 1, Replaced: 1},
+			wantSessions: []session.Session{kept, backedUp, added, lost},
+		},
+		{
+			name:         "Overwrite the conflicts",
+			givenBackups: []application.Backup{backup},
+			command:      restorebackup.Command{Conflicts: restorebackup.ConflictsOverwrite},
+			want:         restorebackup.Result{Backup: backup, Restored: 1, Unchanged: 1, Replaced: 1, Deleted: 1},
+			wantSessions: []session.Session{kept, backedUp, lost},
+		},
+		{
+			name:         "Dry run",
+			givenBackups: []application.Backup{backup},
+			command:      restorebackup.Command{Conflicts: restorebackup.ConflictsOverwrite, DryRun: true},
+			want:         restorebackup.Result{Backup: backup, Restored: 1, Unchanged: 1, Replaced: 1, Deleted: 1},
+			wantSessions: givenSessions,
+		},
+		{
+			name:         "Locked history",
+			givenBackups: []application.Backup{backup},
+			command:      restorebackup.Command{LockedBefore: at(1, 0).AddDate(0, 1, 0)},
+			want:         restorebackup.Result{Backup: backup, Restored: 1, Unchanged: 1, Skipped: 1},
+			wantSessions: givenSessions,
+			wantErr:      session.ErrLockedHistory,
+		},
+		{
+			name:         "Newer format",
+			givenBackups: []application.Backup{backup, newer},
+			want:         restorebackup.Result{Backup: newer},
+			wantSessions: givenSessions,
+			wantErr:      application.ErrUnsupportedBackup,
+		},
+		{
+			name:         "No backup",
+			wantSessions: givenSessions,
+			wantErr:      restorebackup.ErrNoBackup,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			repository := &infra.InMemorySessionRepository{Sessions: slices.Clone(givenSessions)}
+			lock := &infra.InMemoryActiveSessionLock{}
+			backupStore := &infra.InMemoryBackupStore{
+				Backups:  tc.givenBackups,
+				Sessions: map[string][]session.Session{backup.Path: {kept, lost, backedUp}},
+			}
+			useCase := restorebackup.NewRestoreBackupUseCase(repository, lock, backupStore)
+
+			got, err := useCase.Execute(tc.command)
+
+			is.Equal(err, tc.wantErr)
+			is.Equal(got, tc.want)
+			is.Equal(repository.FindAllSessions(nil), tc.wantSessions)
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package listclients
+
+import (
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/client"
+)
+
+type UseCase struct {
+	clientRepository application.ClientRepository
+}
+
+func (s UseCase) Execute() ([]client.Client, error) {
+	return s.clientRepository.FindAll(), nil
+}
+
+func NewListClientsUseCase(clientRepository application.ClientRepository) UseCase {
+	return UseCase{
+		clientRepository: clientRepository,
+	}
+}
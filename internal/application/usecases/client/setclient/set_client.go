@@ -0,0 +1,54 @@
+package setclient
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/client"
+)
+
+type UseCase struct {
+	clientRepository application.ClientRepository
+}
+
+func (s UseCase) Execute(command Command) (client.Client, error) {
+	if command.Name == "" {
+		return client.Client{}, ErrEmptyClientName
+	}
+
+	c := client.Client{Name: command.Name}
+	if existingClient := s.clientRepository.FindByName(command.Name); existingClient != nil {
+		c = *existingClient
+	}
+
+	if command.Contact != nil {
+		c.Contact = *command.Contact
+	}
+
+	if command.Address != nil {
+		c.Address = *command.Address
+	}
+
+	if command.PONumber != nil {
+		c.PONumber = *command.PONumber
+	}
+
+	if command.BillingProfile != nil {
+		c.BillingProfile = strings.TrimSpace(*command.BillingProfile)
+	}
+
+	if err := s.clientRepository.Save(c); err != nil {
+		return client.Client{}, err
+	}
+
+	return c, nil
+}
+
+var ErrEmptyClientName = errors.New("client name can't be empty")
+
+func NewSetClientUseCase(clientRepository application.ClientRepository) UseCase {
+	return UseCase{
+		clientRepository: clientRepository,
+	}
+}
@@ -0,0 +1,12 @@
+package setclient
+
+// Command fields left to nil keep the value already stored for the client
+type Command struct {
+	Contact  *string
+	Address  *string
+	PONumber *string
+	// BillingProfile is the name of a profile of the config file, an empty
+	// value removes it
+	BillingProfile *string
+	Name           string
+}
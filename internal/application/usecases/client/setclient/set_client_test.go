@@ -0,0 +1,72 @@
+package setclient_test
+
+import (
+	"testing"
+
+	"github.com/TristanShz/flow/internal/application/usecases/client/setclient"
+	"github.com/TristanShz/flow/internal/domain/client"
+	"github.com/TristanShz/flow/internal/tests"
+)
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestSetClient(t *testing.T) {
+	tt := []struct {
+		name         string
+		givenClients []client.Client
+		command      setclient.Command
+		want         []client.Client
+	}{
+		{
+			name:    "New client",
+			command: setclient.Command{Name: "Acme", Contact: stringPtr("jane@acme.com")},
+			want:    []client.Client{{Name: "Acme", Contact: "jane@acme.com"}},
+		},
+		{
+			name: "Existing client keeps unset metadata",
+			givenClients: []client.Client{
+				{Name: "Acme", Contact: "jane@acme.com", Address: "1 Main Street"},
+			},
+			command: setclient.Command{Name: "Acme", PONumber: stringPtr("PO-42")},
+			want: []client.Client{
+				{Name: "Acme", Contact: "jane@acme.com", Address: "1 Main Street", PONumber: "PO-42"},
+			},
+		},
+		{
+			name: "Existing client metadata can be cleared",
+			givenClients: []client.Client{
+				{Name: "Acme", Contact: "jane@acme.com"},
+			},
+			command: setclient.Command{Name: "Acme", Contact: stringPtr("")},
+			want:    []client.Client{{Name: "Acme"}},
+		},
+		{
+			name:         "Billing profile",
+			givenClients: []client.Client{{Name: "Acme", Contact: "jane@acme.com"}},
+			command:      setclient.Command{Name: "Acme", BillingProfile: stringPtr("support")},
+			want:         []client.Client{{Name: "Acme", Contact: "jane@acme.com", BillingProfile: "support"}},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			f := tests.GetClientFixture(t)
+
+			f.GivenSomeClients(tc.givenClients)
+
+			f.WhenSettingClient(tc.command)
+
+			f.ThenClientsShouldBe(tc.want)
+		})
+	}
+}
+
+func TestSetClient_EmptyName(t *testing.T) {
+	f := tests.GetClientFixture(t)
+
+	f.WhenSettingClient(setclient.Command{})
+
+	f.ThenErrorShouldBe(setclient.ErrEmptyClientName)
+}
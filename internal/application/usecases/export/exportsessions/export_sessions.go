@@ -0,0 +1,79 @@
+package exportsessions
+
+import (
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/pkg/timerange"
+)
+
+type UseCase struct {
+	sessionRepository application.SessionRepository
+	projectRepository application.ProjectRepository
+	clientRepository  application.ClientRepository
+	journalRepository application.JournalRepository
+}
+
+func (s UseCase) Execute(
+	command Command,
+	exporter application.SessionsExporter,
+) error {
+	filters := &application.SessionsFilters{
+		Project:   command.Project,
+		Tags:      command.Tags,
+		TagsMatch: command.TagsMatch,
+		Where:     command.Where,
+	}
+
+	if !command.Since.IsZero() || !command.Until.IsZero() {
+		filters.Timerange = timerange.TimeRange{
+			Since: command.Since,
+			Until: command.Until,
+		}
+	}
+
+	projects := s.projectRepository.FindAll()
+	clients := s.clientRepository.FindAll()
+	bill := func(sessions []session.Session) []session.Session {
+		return command.Billing.Apply(sessions, projects, clients)
+	}
+
+	_, withJournal := exporter.(application.JournalExporter)
+	if streamExporter, ok := exporter.(application.SessionsStreamExporter); ok && !withJournal {
+		// the sessions are billed a few days at a time, the rounding per
+		// day and the daily caps need all the sessions of a day
+		return streamExporter.ExportEach(func(fn func(session.Session) error) error {
+			billed := session.NewDaysBuffer(bill, fn)
+			if err := s.sessionRepository.ForEachSession(filters, billed.Add); err != nil {
+				return err
+			}
+
+			return billed.Flush()
+		})
+	}
+
+	sessions := bill(s.sessionRepository.FindAllSessions(filters))
+
+	if journalExporter, ok := exporter.(application.JournalExporter); ok {
+		entries := s.journalRepository.FindAll(timerange.TimeRange{
+			Since: command.Since,
+			Until: command.Until,
+		})
+		return journalExporter.ExportWithJournal(sessions, entries)
+	}
+
+	return exporter.Export(sessions)
+}
+
+func NewExportSessionsUseCase(
+	sessionRepository application.SessionRepository,
+	projectRepository application.ProjectRepository,
+	clientRepository application.ClientRepository,
+	journalRepository application.JournalRepository,
+) UseCase {
+	return UseCase{
+		sessionRepository: sessionRepository,
+		projectRepository: projectRepository,
+		clientRepository:  clientRepository,
+		journalRepository: journalRepository,
+	}
+}
@@ -0,0 +1,21 @@
+package exportsessions
+
+import (
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/billing"
+)
+
+type Command struct {
+	Since     time.Time
+	Until     time.Time
+	Project   string
+	Tags      []string
+	TagsMatch string
+	// Where keeps the sessions matching a where expression, see application.ParseWhere
+	Where application.Condition
+	// Billing moves the end times of the sessions exported to the time
+	// billed by their billing profiles
+	Billing billing.Rules
+}
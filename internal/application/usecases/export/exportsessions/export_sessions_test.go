@@ -0,0 +1,216 @@
+package exportsessions_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/application/usecases/export/exportsessions"
+	"github.com/TristanShz/flow/internal/domain/billing"
+	"github.com/TristanShz/flow/internal/domain/journal"
+	"github.com/TristanShz/flow/internal/domain/project"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/matryer/is"
+)
+
+type testExporter struct {
+	sessions []session.Session
+}
+
+func (e *testExporter) Export(sessions []session.Session) error {
+	e.sessions = sessions
+	return nil
+}
+
+// testStreamExporter keeps the sessions it's given one after the other
+type testStreamExporter struct {
+	testExporter
+	streamed bool
+}
+
+func (e *testStreamExporter) ExportEach(forEach func(fn func(session.Session) error) error) error {
+	e.streamed = true
+	return forEach(func(s session.Session) error {
+		e.sessions = append(e.sessions, s)
+		return nil
+	})
+}
+
+type testJournalExporter struct {
+	testExporter
+	entries []journal.Entry
+}
+
+func (e *testJournalExporter) ExportWithJournal(sessions []session.Session, entries []journal.Entry) error {
+	e.sessions = sessions
+	e.entries = entries
+	return nil
+}
+
+func TestExportSessions(t *testing.T) {
+	givenSessions := []session.Session{
+		{
+			Id:        "1",
+			StartTime: time.Date(2024, 4, 16, 9, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, 4, 16, 10, 0, 0, 0, time.UTC),
+			Project:   "Flow",
+			Tags:      []string{"export"},
+		},
+		{
+			Id:        "2",
+			StartTime: time.Date(2024, 4, 17, 9, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, 4, 17, 10, 0, 0, 0, time.UTC),
+			Project:   "Flow",
+		},
+		{
+			Id:        "3",
+			StartTime: time.Date(2024, 4, 18, 9, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, 4, 18, 10, 0, 0, 0, time.UTC),
+			Project:   "Other",
+			Tags:      []string{"export"},
+		},
+	}
+
+	tt := []struct {
+		name    string
+		command exportsessions.Command
+		wantIds []string
+	}{
+		{
+			name:    "All sessions",
+			command: exportsessions.Command{},
+			wantIds: []string{"1", "2", "3"},
+		},
+		{
+			name:    "By project",
+			command: exportsessions.Command{Project: "Flow"},
+			wantIds: []string{"1", "2"},
+		},
+		{
+			name:    "By tag",
+			command: exportsessions.Command{Tags: []string{"export"}, TagsMatch: application.TagsMatchAll},
+			wantIds: []string{"1", "3"},
+		},
+		{
+			name: "By time range",
+			command: exportsessions.Command{
+				Since: time.Date(2024, 4, 17, 0, 0, 0, 0, time.UTC),
+				Until: time.Date(2024, 4, 18, 0, 0, 0, 0, time.UTC),
+			},
+			wantIds: []string{"2"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			sessionRepository := &infra.InMemorySessionRepository{Sessions: givenSessions}
+			useCase := exportsessions.NewExportSessionsUseCase(sessionRepository, &infra.InMemoryProjectRepository{}, &infra.InMemoryClientRepository{}, &infra.InMemoryJournalRepository{})
+			exporter := &testExporter{}
+
+			is.NoErr(useCase.Execute(tc.command, exporter))
+
+			gotIds := []string{}
+			for _, s := range exporter.sessions {
+				gotIds = append(gotIds, s.Id)
+			}
+			is.Equal(gotIds, tc.wantIds)
+		})
+	}
+}
+
+func TestExportSessions_Journal(t *testing.T) {
+	is := is.New(t)
+
+	givenSessions := []session.Session{
+		{
+			Id:        "1",
+			StartTime: time.Date(2024, 4, 16, 9, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, 4, 16, 10, 0, 0, 0, time.UTC),
+			Project:   "Flow",
+		},
+	}
+	givenEntries := []journal.Entry{
+		{Day: time.Date(2024, 4, 16, 0, 0, 0, 0, time.UTC), Note: "Shipped the export"},
+		{Day: time.Date(2024, 4, 18, 0, 0, 0, 0, time.UTC), Note: "Out of the range"},
+	}
+
+	useCase := exportsessions.NewExportSessionsUseCase(
+		&infra.InMemorySessionRepository{Sessions: givenSessions},
+		&infra.InMemoryProjectRepository{},
+		&infra.InMemoryClientRepository{},
+		&infra.InMemoryJournalRepository{Entries: givenEntries},
+	)
+	exporter := &testJournalExporter{}
+
+	is.NoErr(useCase.Execute(exportsessions.Command{
+		Since: time.Date(2024, 4, 16, 0, 0, 0, 0, time.UTC),
+		Until: time.Date(2024, 4, 17, 0, 0, 0, 0, time.UTC),
+	}, exporter))
+
+	is.Equal(exporter.sessions, givenSessions)
+	is.Equal(exporter.entries, givenEntries[:1])
+}
+
+func TestExportSessions_Billing(t *testing.T) {
+	is := is.New(t)
+
+	givenSessions := []session.Session{
+		{
+			Id:        "1",
+			StartTime: time.Date(2024, 4, 16, 9, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, 4, 16, 9, 52, 0, 0, time.UTC),
+			Project:   "Flow",
+		},
+		{
+			Id:        "2",
+			StartTime: time.Date(2024, 4, 16, 10, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, 4, 16, 10, 10, 0, 0, time.UTC),
+			Project:   "Support",
+		},
+	}
+	sessionRepository := &infra.InMemorySessionRepository{Sessions: givenSessions}
+	projectRepository := &infra.InMemoryProjectRepository{Projects: []project.Project{{Name: "Support", BillingProfile: "support"}}}
+	useCase := exportsessions.NewExportSessionsUseCase(sessionRepository, projectRepository, &infra.InMemoryClientRepository{}, &infra.InMemoryJournalRepository{})
+	exporter := &testExporter{}
+
+	is.NoErr(useCase.Execute(exportsessions.Command{
+		Billing: billing.Rules{
+			Rounding: session.Rounding{Increment: 30 * time.Minute},
+			Profiles: map[string]billing.Profile{"support": {Minimum: time.Hour}},
+		},
+	}, exporter))
+
+	is.Equal(exporter.sessions[0].EndTime, time.Date(2024, 4, 16, 10, 0, 0, 0, time.UTC))
+	is.Equal(exporter.sessions[1].EndTime, time.Date(2024, 4, 16, 11, 0, 0, 0, time.UTC))
+	is.Equal(sessionRepository.Sessions[0].EndTime, time.Date(2024, 4, 16, 9, 52, 0, 0, time.UTC))
+}
+
+func TestExportSessions_Stream(t *testing.T) {
+	is := is.New(t)
+
+	givenSessions := []session.Session{}
+	for day := 1; day <= 10; day++ {
+		for hour := 9; hour < 18; hour += 4 {
+			start := time.Date(2024, 4, day, hour, 0, 0, 0, time.UTC)
+			givenSessions = append(givenSessions, session.Session{Id: start.Format(time.RFC3339), StartTime: start, EndTime: start.Add(3 * time.Hour), Project: "Flow"})
+		}
+	}
+	command := exportsessions.Command{
+		Billing: billing.Rules{Rounding: session.Rounding{Increment: time.Hour, Per: session.RoundPerDay}, Profiles: map[string]billing.Profile{"capped": {DailyCap: 7 * time.Hour}}},
+	}
+	projectRepository := &infra.InMemoryProjectRepository{Projects: []project.Project{{Name: "Flow", BillingProfile: "capped"}}}
+	useCase := exportsessions.NewExportSessionsUseCase(&infra.InMemorySessionRepository{Sessions: givenSessions}, projectRepository, &infra.InMemoryClientRepository{}, &infra.InMemoryJournalRepository{})
+
+	streamed := &testStreamExporter{}
+	is.NoErr(useCase.Execute(command, streamed))
+	exported := &testExporter{}
+	is.NoErr(useCase.Execute(command, exported))
+
+	// the daily caps of the streamed sessions are the ones of the whole list
+	is.True(streamed.streamed)
+	is.Equal(streamed.sessions, exported.sessions)
+	is.Equal(streamed.sessions[2].EndTime, time.Date(2024, 4, 1, 18, 0, 0, 0, time.UTC))
+}
@@ -8,10 +8,14 @@ import (
 
 type UseCase struct {
 	sessionRepository application.SessionRepository
+	activeSessionLock application.ActiveSessionLock
 }
 
 func (s UseCase) Execute() error {
-	lastSession := s.sessionRepository.FindLastSession()
+	lastSession, err := s.sessionRepository.FindLastSession()
+	if err != nil {
+		return err
+	}
 
 	if lastSession == nil || !lastSession.EndTime.IsZero() {
 		return ErrNoActiveSession
@@ -19,13 +23,17 @@ func (s UseCase) Execute() error {
 
 	s.sessionRepository.Delete(lastSession.Id)
 
-	return nil
+	return s.activeSessionLock.Release(lastSession.Id)
 }
 
 var ErrNoActiveSession = errors.New("no active session")
 
-func NewAbortFlowSessionUseCase(sessionRepository application.SessionRepository) UseCase {
+func NewAbortFlowSessionUseCase(
+	sessionRepository application.SessionRepository,
+	activeSessionLock application.ActiveSessionLock,
+) UseCase {
 	return UseCase{
 		sessionRepository: sessionRepository,
+		activeSessionLock: activeSessionLock,
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/TristanShz/flow/internal/application"
 	abortsession "github.com/TristanShz/flow/internal/application/usecases/flowsession/abort"
 	"github.com/TristanShz/flow/internal/domain/session"
 	"github.com/TristanShz/flow/internal/tests"
@@ -51,3 +52,18 @@ func TestAbort(t *testing.T) {
 		})
 	}
 }
+
+func TestAbort_ReleasesActiveSession(t *testing.T) {
+	f := tests.GetSessionFixture(t)
+
+	f.GivenSomeSessions([]session.Session{{
+		Id:        "1",
+		StartTime: time.Date(2024, time.April, 13, 17, 20, 0, 0, time.UTC),
+		Project:   "Flow",
+	}})
+	f.GivenActiveSession(application.ActiveSession{SessionId: "1"})
+
+	f.WhenAbortingFlowSession()
+
+	f.ThenActiveSessionShouldBe("")
+}
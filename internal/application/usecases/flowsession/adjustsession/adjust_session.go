@@ -0,0 +1,136 @@
+package adjustsession
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+const AuditAction = "adjust"
+
+type UseCase struct {
+	sessionRepository application.SessionRepository
+	dateProvider      application.DateProvider
+	auditLog          application.AuditLog
+}
+
+func (s UseCase) Execute(command Command) (session.Session, error) {
+	if command.StartOffset == 0 && command.EndOffset == 0 {
+		return session.Session{}, ErrNoAdjustment
+	}
+
+	existingSession, err := s.findSession(command.SessionId)
+	if err != nil {
+		return session.Session{}, err
+	}
+	if existingSession == nil {
+		return session.Session{}, ErrSessionNotFound
+	}
+
+	now := s.dateProvider.GetNow()
+	original := *existingSession
+	adjusted := original
+	adjusted.StartTime = adjusted.StartTime.Add(command.StartOffset)
+
+	if command.EndOffset != 0 {
+		if original.Status() == session.FlowingStatus {
+			return session.Session{}, ErrSessionFlowing
+		}
+		adjusted.EndTime = adjusted.EndTime.Add(command.EndOffset)
+	}
+
+	end := adjusted.EndTime
+	if end.IsZero() {
+		end = now
+	}
+	if end.Before(adjusted.StartTime) {
+		return session.Session{}, ErrNegativeDuration
+	}
+
+	if err := session.CheckLocked(command.LockedBefore, original, adjusted); err != nil {
+		return session.Session{}, err
+	}
+
+	if adjusted.EndTime.After(now) {
+		return session.Session{}, ErrEndInFuture
+	}
+
+	if s.overlapsAnotherSession(adjusted) {
+		return session.Session{}, ErrOverlap
+	}
+
+	if err := s.sessionRepository.Save(adjusted); err != nil {
+		return session.Session{}, err
+	}
+
+	entry := application.AuditEntry{
+		At:        now,
+		Action:    AuditAction,
+		SessionId: adjusted.Id,
+	}
+	if command.StartOffset != 0 {
+		entry.Changes = append(entry.Changes, timeChange("start", original.StartTime, adjusted.StartTime))
+	}
+	if command.EndOffset != 0 {
+		entry.Changes = append(entry.Changes, timeChange("end", original.EndTime, adjusted.EndTime))
+	}
+
+	// the session is already saved, a failing audit log is still reported so
+	// that the change isn't silently untraced
+	if err := s.auditLog.Record(entry); err != nil {
+		return adjusted, fmt.Errorf("%w: %w", ErrAuditLog, err)
+	}
+
+	return adjusted, nil
+}
+
+func (s UseCase) findSession(id string) (*session.Session, error) {
+	if id == "" {
+		return s.sessionRepository.FindLastSession()
+	}
+
+	return s.sessionRepository.FindById(id)
+}
+
+func (s UseCase) overlapsAnotherSession(adjusted session.Session) bool {
+	for _, other := range s.sessionRepository.FindAllSessions(nil) {
+		if other.Id != adjusted.Id && adjusted.Overlaps(other) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func timeChange(field string, before time.Time, after time.Time) application.AuditChange {
+	return application.AuditChange{
+		Field:  field,
+		Before: before.Format(time.RFC3339),
+		After:  after.Format(time.RFC3339),
+	}
+}
+
+var (
+	ErrSessionNotFound  = errors.New("session not found")
+	ErrNoAdjustment     = errors.New("nothing to adjust, use --start or --end")
+	ErrSessionFlowing   = errors.New("the session is still flowing, use 'flow stop' to end it")
+	ErrNegativeDuration = errors.New("the session can't end before it starts")
+	ErrEndInFuture      = errors.New("the session can't end in the future")
+	ErrOverlap          = errors.New("the session would overlap another session")
+	ErrAuditLog         = errors.New("the session was adjusted but the audit log couldn't be written")
+)
+
+func NewAdjustSessionUseCase(
+	sessionRepository application.SessionRepository,
+	dateProvider application.DateProvider,
+	auditLog application.AuditLog,
+) UseCase {
+	return UseCase{
+		sessionRepository: sessionRepository,
+		dateProvider:      dateProvider,
+		auditLog:          auditLog,
+	}
+}
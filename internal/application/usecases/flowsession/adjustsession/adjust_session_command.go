@@ -0,0 +1,15 @@
+package adjustsession
+
+import "time"
+
+type Command struct {
+	// SessionId is the session to adjust, the last session when empty
+	SessionId string
+	// StartOffset and EndOffset are added to the start and end times, a
+	// negative offset moves the time backward
+	StartOffset time.Duration
+	EndOffset   time.Duration
+	// LockedBefore is the start of the first month whose sessions can be
+	// changed, see session.IsLocked
+	LockedBefore time.Time
+}
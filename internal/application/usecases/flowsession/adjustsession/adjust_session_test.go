@@ -0,0 +1,154 @@
+package adjustsession_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/adjustsession"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/tests"
+)
+
+func TestAdjustSession(t *testing.T) {
+	now := time.Date(2024, time.April, 13, 15, 0, 0, 0, time.UTC)
+	morning := session.Session{
+		Id:        "1",
+		StartTime: time.Date(2024, time.April, 13, 9, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, time.April, 13, 10, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+	}
+	lunch := session.Session{
+		Id:        "2",
+		StartTime: time.Date(2024, time.April, 13, 12, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, time.April, 13, 13, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+	}
+	flowing := session.Session{
+		Id:        "3",
+		StartTime: time.Date(2024, time.April, 13, 14, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+	}
+
+	tt := []struct {
+		error         error
+		name          string
+		givenSessions []session.Session
+		command       adjustsession.Command
+		want          []session.Session
+		wantAudit     []application.AuditEntry
+	}{
+		{
+			name:          "Start and end of an ended session",
+			givenSessions: []session.Session{morning, lunch},
+			command:       adjustsession.Command{SessionId: "1", StartOffset: 10 * time.Minute, EndOffset: -5 * time.Minute},
+			want: []session.Session{
+				{
+					Id:        "1",
+					StartTime: time.Date(2024, time.April, 13, 9, 10, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.April, 13, 9, 55, 0, 0, time.UTC),
+					Project:   "Flow",
+				},
+				lunch,
+			},
+			wantAudit: []application.AuditEntry{
+				{
+					At:        now,
+					Action:    "adjust",
+					SessionId: "1",
+					Changes: []application.AuditChange{
+						{Field: "start", Before: "2024-04-13T09:00:00Z", After: "2024-04-13T09:10:00Z"},
+						{Field: "end", Before: "2024-04-13T10:00:00Z", After: "2024-04-13T09:55:00Z"},
+					},
+				},
+			},
+		},
+		{
+			name:          "Start of the flowing session by default",
+			givenSessions: []session.Session{morning, flowing},
+			command:       adjustsession.Command{StartOffset: -15 * time.Minute},
+			want: []session.Session{
+				morning,
+				{
+					Id:        "3",
+					StartTime: time.Date(2024, time.April, 13, 13, 45, 0, 0, time.UTC),
+					Project:   "Flow",
+				},
+			},
+			wantAudit: []application.AuditEntry{
+				{
+					At:        now,
+					Action:    "adjust",
+					SessionId: "3",
+					Changes: []application.AuditChange{
+						{Field: "start", Before: "2024-04-13T14:00:00Z", After: "2024-04-13T13:45:00Z"},
+					},
+				},
+			},
+		},
+		{
+			name:          "Overlapping another session",
+			givenSessions: []session.Session{morning, lunch},
+			command:       adjustsession.Command{SessionId: "2", StartOffset: -2*time.Hour - 30*time.Minute},
+			want:          []session.Session{morning, lunch},
+			error:         adjustsession.ErrOverlap,
+		},
+		{
+			name:          "End before start",
+			givenSessions: []session.Session{morning},
+			command:       adjustsession.Command{SessionId: "1", EndOffset: -2 * time.Hour},
+			want:          []session.Session{morning},
+			error:         adjustsession.ErrNegativeDuration,
+		},
+		{
+			name:          "Flowing session starting in the future",
+			givenSessions: []session.Session{flowing},
+			command:       adjustsession.Command{StartOffset: 2 * time.Hour},
+			want:          []session.Session{flowing},
+			error:         adjustsession.ErrNegativeDuration,
+		},
+		{
+			name:          "End in the future",
+			givenSessions: []session.Session{lunch},
+			command:       adjustsession.Command{SessionId: "2", EndOffset: 3 * time.Hour},
+			want:          []session.Session{lunch},
+			error:         adjustsession.ErrEndInFuture,
+		},
+		{
+			name:          "End of the flowing session",
+			givenSessions: []session.Session{flowing},
+			command:       adjustsession.Command{SessionId: "3", EndOffset: time.Minute},
+			want:          []session.Session{flowing},
+			error:         adjustsession.ErrSessionFlowing,
+		},
+		{
+			name:          "No adjustment",
+			givenSessions: []session.Session{morning},
+			command:       adjustsession.Command{SessionId: "1"},
+			want:          []session.Session{morning},
+			error:         adjustsession.ErrNoAdjustment,
+		},
+		{
+			name:          "Session not found",
+			givenSessions: []session.Session{morning},
+			command:       adjustsession.Command{SessionId: "4", StartOffset: time.Minute},
+			want:          []session.Session{morning},
+			error:         adjustsession.ErrSessionNotFound,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			f := tests.GetSessionFixture(t)
+
+			f.GivenNowIs(now)
+			f.GivenSomeSessions(append([]session.Session{}, tc.givenSessions...))
+
+			f.WhenAdjustingSession(tc.command)
+
+			f.ThenErrorShouldBe(tc.error)
+			f.ThenSessionsShouldBe(tc.want)
+			f.ThenAuditEntriesShouldBe(tc.wantAudit)
+		})
+	}
+}
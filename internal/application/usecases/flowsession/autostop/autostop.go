@@ -0,0 +1,130 @@
+package autostop
+
+import (
+	"errors"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/project"
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+// MetadataKey is the session metadata holding the on lock action that ended
+// the session
+const MetadataKey = "autostop"
+
+const (
+	ActionNone    = ""
+	ActionStopped = "stopped"
+	ActionPaused  = "paused"
+	ActionResumed = "resumed"
+)
+
+type UseCase struct {
+	sessionRepository application.SessionRepository
+	projectRepository application.ProjectRepository
+	idProvider        application.IDProvider
+	activeSessionLock application.ActiveSessionLock
+}
+
+// Execute applies the on lock action of the project of the current session
+// and returns the action that was taken, if any
+func (s UseCase) Execute(command Command) (string, error) {
+	if command.Locked {
+		return s.lock(command)
+	}
+
+	return s.unlock(command)
+}
+
+func (s UseCase) lock(command Command) (string, error) {
+	lastSession, err := s.sessionRepository.FindLastSession()
+	if err != nil {
+		return ActionNone, err
+	}
+	if lastSession == nil || lastSession.Status() != session.FlowingStatus {
+		return ActionNone, nil
+	}
+
+	onLock := project.OnLockNone
+	if p := s.projectRepository.FindByName(lastSession.Project); p != nil {
+		onLock = p.OnLockAction()
+	}
+
+	if onLock == project.OnLockNone {
+		return ActionNone, nil
+	}
+
+	lastSession.EndTime = command.At
+	if lastSession.EndTime.Before(lastSession.StartTime) {
+		lastSession.EndTime = lastSession.StartTime
+	}
+
+	if lastSession.Metadata == nil {
+		lastSession.Metadata = map[string]string{}
+	}
+	lastSession.Metadata[MetadataKey] = onLock
+
+	if err := s.sessionRepository.Save(*lastSession); err != nil {
+		return ActionNone, err
+	}
+
+	if err := s.activeSessionLock.Release(lastSession.Id); err != nil {
+		return ActionNone, err
+	}
+
+	if onLock == project.OnLockPause {
+		return ActionPaused, nil
+	}
+
+	return ActionStopped, nil
+}
+
+// unlock starts a new session with the project and tags of the session that
+// was paused by the last lock
+func (s UseCase) unlock(command Command) (string, error) {
+	lastSession, err := s.sessionRepository.FindLastSession()
+	if err != nil {
+		return ActionNone, err
+	}
+	if lastSession == nil || lastSession.Status() != session.EndedStatus || lastSession.Metadata[MetadataKey] != project.OnLockPause {
+		return ActionNone, nil
+	}
+
+	resumed := session.Session{
+		Id:        s.idProvider.Provide(),
+		StartTime: command.At,
+		Project:   lastSession.Project,
+		Tags:      lastSession.Tags,
+	}
+
+	_, err = s.activeSessionLock.Acquire(application.ActiveSession{SessionId: resumed.Id, AcquiredAt: resumed.StartTime})
+	if err == application.ErrActiveSessionLocked {
+		return ActionNone, ErrSessionAlreadyStarted
+	}
+	if err != nil {
+		return ActionNone, err
+	}
+
+	if err := s.sessionRepository.Save(resumed); err != nil {
+		s.activeSessionLock.Release(resumed.Id)
+		return ActionNone, err
+	}
+
+	return ActionResumed, nil
+}
+
+var ErrSessionAlreadyStarted = errors.New("there is already a session in progress")
+
+func NewAutostopUseCase(
+	sessionRepository application.SessionRepository,
+	projectRepository application.ProjectRepository,
+	idProvider application.IDProvider,
+	activeSessionLock application.ActiveSessionLock,
+) UseCase {
+	return UseCase{
+		sessionRepository: sessionRepository,
+		projectRepository: projectRepository,
+		idProvider:        idProvider,
+		activeSessionLock: activeSessionLock,
+	}
+}
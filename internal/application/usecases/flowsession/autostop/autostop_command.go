@@ -0,0 +1,10 @@
+package autostop
+
+import "time"
+
+// Command is sent when the screen gets locked or unlocked, closing the lid
+// or going to sleep counts as locking the screen
+type Command struct {
+	At     time.Time
+	Locked bool
+}
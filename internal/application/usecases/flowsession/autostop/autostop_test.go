@@ -0,0 +1,126 @@
+package autostop_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/autostop"
+	"github.com/TristanShz/flow/internal/domain/project"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/tests"
+)
+
+func TestAutostop(t *testing.T) {
+	startTime := time.Date(2024, time.April, 13, 9, 0, 0, 0, time.UTC)
+	lockTime := time.Date(2024, time.April, 13, 10, 0, 0, 0, time.UTC)
+	unlockTime := time.Date(2024, time.April, 13, 10, 30, 0, 0, time.UTC)
+
+	flowing := session.Session{Id: "1", StartTime: startTime, Project: "Flow", Tags: []string{"cli"}}
+
+	stopped := flowing
+	stopped.EndTime = lockTime
+	stopped.Metadata = map[string]string{autostop.MetadataKey: project.OnLockStop}
+
+	paused := flowing
+	paused.EndTime = lockTime
+	paused.Metadata = map[string]string{autostop.MetadataKey: project.OnLockPause}
+
+	resumed := session.Session{Id: "2", StartTime: unlockTime, Project: "Flow", Tags: []string{"cli"}}
+
+	tt := []struct {
+		error         error
+		name          string
+		wantAction    string
+		wantActive    string
+		givenProjects []project.Project
+		givenSessions []session.Session
+		wantSessions  []session.Session
+		command       autostop.Command
+		givenActive   string
+	}{
+		{
+			name:          "Project without settings keeps flowing",
+			givenSessions: []session.Session{flowing},
+			givenActive:   "1",
+			command:       autostop.Command{Locked: true, At: lockTime},
+			wantAction:    autostop.ActionNone,
+			wantSessions:  []session.Session{flowing},
+			wantActive:    "1",
+		},
+		{
+			name:          "Project stopping on lock",
+			givenProjects: []project.Project{{Name: "Flow", OnLock: project.OnLockStop}},
+			givenSessions: []session.Session{flowing},
+			givenActive:   "1",
+			command:       autostop.Command{Locked: true, At: lockTime},
+			wantAction:    autostop.ActionStopped,
+			wantSessions:  []session.Session{stopped},
+		},
+		{
+			name:          "Project pausing on lock",
+			givenProjects: []project.Project{{Name: "Flow", OnLock: project.OnLockPause}},
+			givenSessions: []session.Session{flowing},
+			givenActive:   "1",
+			command:       autostop.Command{Locked: true, At: lockTime},
+			wantAction:    autostop.ActionPaused,
+			wantSessions:  []session.Session{paused},
+		},
+		{
+			name:          "Lock without flowing session",
+			givenProjects: []project.Project{{Name: "Flow", OnLock: project.OnLockStop}},
+			givenSessions: []session.Session{stopped},
+			command:       autostop.Command{Locked: true, At: lockTime},
+			wantAction:    autostop.ActionNone,
+			wantSessions:  []session.Session{stopped},
+		},
+		{
+			name:          "Unlock resumes paused session",
+			givenProjects: []project.Project{{Name: "Flow", OnLock: project.OnLockPause}},
+			givenSessions: []session.Session{paused},
+			command:       autostop.Command{Locked: false, At: unlockTime},
+			wantAction:    autostop.ActionResumed,
+			wantSessions:  []session.Session{paused, resumed},
+			wantActive:    "2",
+		},
+		{
+			name:          "Unlock doesn't resume stopped session",
+			givenProjects: []project.Project{{Name: "Flow", OnLock: project.OnLockStop}},
+			givenSessions: []session.Session{stopped},
+			command:       autostop.Command{Locked: false, At: unlockTime},
+			wantAction:    autostop.ActionNone,
+			wantSessions:  []session.Session{stopped},
+		},
+		{
+			name:          "Unlock while another session is active",
+			givenProjects: []project.Project{{Name: "Flow", OnLock: project.OnLockPause}},
+			givenSessions: []session.Session{paused},
+			givenActive:   "3",
+			command:       autostop.Command{Locked: false, At: unlockTime},
+			wantAction:    autostop.ActionNone,
+			wantSessions:  []session.Session{paused},
+			wantActive:    "3",
+			error:         autostop.ErrSessionAlreadyStarted,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			f := tests.GetSessionFixture(t)
+
+			f.GivenSomeProjects(tc.givenProjects)
+			f.GivenSomeSessions(append([]session.Session{}, tc.givenSessions...))
+			f.GivenPredefinedIdentifier("2")
+			if tc.givenActive != "" {
+				f.GivenActiveSession(application.ActiveSession{SessionId: tc.givenActive})
+			}
+
+			f.WhenScreenLockChanges(tc.command)
+
+			f.ThenErrorShouldBe(tc.error)
+			f.ThenAutostopActionShouldBe(tc.wantAction)
+			f.ThenSessionsShouldBe(tc.wantSessions)
+			f.ThenActiveSessionShouldBe(tc.wantActive)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package deletesession
+
+import (
+	"errors"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+type UseCase struct {
+	sessionRepository application.SessionRepository
+	activeSessionLock application.ActiveSessionLock
+}
+
+// Execute deletes the session, deleting the current session aborts it
+func (s UseCase) Execute(command Command) (session.Session, error) {
+	existingSession, err := s.sessionRepository.FindById(command.Id)
+	if err != nil {
+		return session.Session{}, err
+	}
+	if existingSession == nil {
+		return session.Session{}, ErrSessionNotFound
+	}
+	deleted := *existingSession
+
+	if err := session.CheckLocked(command.LockedBefore, deleted); err != nil {
+		return session.Session{}, err
+	}
+
+	if err := s.sessionRepository.Delete(deleted.Id); err != nil {
+		return session.Session{}, err
+	}
+
+	if err := s.activeSessionLock.Release(deleted.Id); err != nil {
+		return session.Session{}, err
+	}
+
+	return deleted, nil
+}
+
+var ErrSessionNotFound = errors.New("session not found")
+
+func NewDeleteSessionUseCase(
+	sessionRepository application.SessionRepository,
+	activeSessionLock application.ActiveSessionLock,
+) UseCase {
+	return UseCase{
+		sessionRepository: sessionRepository,
+		activeSessionLock: activeSessionLock,
+	}
+}
@@ -0,0 +1,71 @@
+package deletesession_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/deletesession"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/tests"
+)
+
+func TestDeleteSession(t *testing.T) {
+	sessions := func() []session.Session {
+		return []session.Session{
+			{
+				Id:        "1",
+				StartTime: time.Date(2024, time.April, 15, 9, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2024, time.April, 15, 10, 0, 0, 0, time.UTC),
+				Project:   "Flow",
+			},
+			{
+				Id:        "2",
+				StartTime: time.Date(2024, time.April, 15, 11, 0, 0, 0, time.UTC),
+				Project:   "Flow",
+			},
+		}
+	}
+
+	tt := []struct {
+		error        error
+		name         string
+		command      deletesession.Command
+		wantIds      []string
+		wantActiveId string
+	}{
+		{
+			name:         "Ended session",
+			command:      deletesession.Command{Id: "1"},
+			wantIds:      []string{"2"},
+			wantActiveId: "2",
+		},
+		{
+			name:    "Current session",
+			command: deletesession.Command{Id: "2"},
+			wantIds: []string{"1"},
+		},
+		{
+			name:         "Unknown session",
+			command:      deletesession.Command{Id: "3"},
+			error:        deletesession.ErrSessionNotFound,
+			wantIds:      []string{"1", "2"},
+			wantActiveId: "2",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			f := tests.GetSessionFixture(t)
+
+			f.GivenSomeSessions(sessions())
+			f.GivenActiveSession(application.ActiveSession{SessionId: "2"})
+
+			f.WhenDeletingSession(tc.command)
+
+			f.ThenErrorShouldBe(tc.error)
+			f.ThenSessionIdsShouldBe(tc.wantIds)
+			f.ThenActiveSessionShouldBe(tc.wantActiveId)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package deletesessions
+
+import (
+	"errors"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/pkg/timerange"
+)
+
+var ErrNoFilter = errors.New("a project, tags or a date is required, not to delete every session")
+
+type Result struct {
+	// Sessions are the sessions matching the filters, from the oldest
+	Sessions []session.Session
+	// Deleted is the number of sessions deleted, zero for a dry run
+	Deleted int
+}
+
+type UseCase struct {
+	sessionRepository application.SessionRepository
+	activeSessionLock application.ActiveSessionLock
+}
+
+// Execute deletes the sessions matching all the filters of the command,
+// deleting the current session aborts it. No session is deleted when one of
+// them is in a read-only month.
+func (s UseCase) Execute(command Command) (Result, error) {
+	if command.Project == "" && len(command.Tags) == 0 && command.Before.IsZero() {
+		return Result{}, ErrNoFilter
+	}
+
+	filters := &application.SessionsFilters{
+		Project:   command.Project,
+		Tags:      command.Tags,
+		TagsMatch: command.TagsMatch,
+	}
+	if !command.Before.IsZero() {
+		filters.Timerange = timerange.TimeRange{Until: command.Before, ExcludeUntil: true}
+	}
+
+	result := Result{Sessions: s.sessionRepository.FindAllSessions(filters)}
+	if err := session.CheckLocked(command.LockedBefore, result.Sessions...); err != nil {
+		return result, err
+	}
+
+	if command.DryRun {
+		return result, nil
+	}
+
+	for _, deleted := range result.Sessions {
+		if err := s.sessionRepository.Delete(deleted.Id); err != nil {
+			return result, err
+		}
+		result.Deleted++
+
+		// only a session in progress can be the active one
+		if !deleted.EndTime.IsZero() {
+			continue
+		}
+		if err := s.activeSessionLock.Release(deleted.Id); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+func NewDeleteSessionsUseCase(
+	sessionRepository application.SessionRepository,
+	activeSessionLock application.ActiveSessionLock,
+) UseCase {
+	return UseCase{
+		sessionRepository: sessionRepository,
+		activeSessionLock: activeSessionLock,
+	}
+}
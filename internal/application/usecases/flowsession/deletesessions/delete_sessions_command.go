@@ -0,0 +1,18 @@
+package deletesessions
+
+import "time"
+
+type Command struct {
+	Project string
+	Tags    []string
+	// TagsMatch is either application.TagsMatchAny (default) or
+	// application.TagsMatchAll
+	TagsMatch string
+	// Before keeps the sessions started before it
+	Before time.Time
+	// DryRun finds the sessions which would be deleted without deleting them
+	DryRun bool
+	// LockedBefore is the start of the first month whose sessions can be
+	// changed, see session.IsLocked
+	LockedBefore time.Time
+}
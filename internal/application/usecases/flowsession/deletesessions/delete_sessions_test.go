@@ -0,0 +1,93 @@
+package deletesessions_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/deletesessions"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/matryer/is"
+)
+
+func TestDeleteSessions(t *testing.T) {
+	at := func(month time.Month, day int, hour int) time.Time {
+		return time.Date(2024, month, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	old := session.Session{Id: "1", StartTime: at(time.March, 15, 9), EndTime: at(time.March, 15, 10), Project: "Flow", Tags: []string{"test"}}
+	oldOther := session.Session{Id: "2", StartTime: at(time.March, 16, 9), EndTime: at(time.March, 16, 10), Project: "Intranet"}
+	recent := session.Session{Id: "3", StartTime: at(time.April, 15, 9), EndTime: at(time.April, 15, 10), Project: "Flow", Tags: []string{"test", "cli"}}
+	flowing := session.Session{Id: "4", StartTime: at(time.April, 16, 9), Project: "Flow", Tags: []string{"cli"}}
+	sessions := []session.Session{old, oldOther, recent, flowing}
+
+	tt := []struct {
+		name       string
+		command    deletesessions.Command
+		want       deletesessions.Result
+		wantLeft   []session.Session
+		wantActive bool
+		wantErr    error
+	}{
+		{
+			name:       "Before a date",
+			command:    deletesessions.Command{Before: at(time.April, 1, 0)},
+			want:       deletesessions.Result{Sessions: []session.Session{old, oldOther}, Deleted: 2},
+			wantLeft:   []session.Session{recent, flowing},
+			wantActive: true,
+		},
+		{
+			name:       "Project and tags",
+			command:    deletesessions.Command{Project: "Flow", Tags: []string{"test"}},
+			want:       deletesessions.Result{Sessions: []session.Session{old, recent}, Deleted: 2},
+			wantLeft:   []session.Session{oldOther, flowing},
+			wantActive: true,
+		},
+		{
+			name:     "Session in progress",
+			command:  deletesessions.Command{Tags: []string{"cli"}},
+			want:     deletesessions.Result{Sessions: []session.Session{recent, flowing}, Deleted: 2},
+			wantLeft: []session.Session{old, oldOther},
+		},
+		{
+			name:       "Dry run",
+			command:    deletesessions.Command{Project: "Flow", DryRun: true},
+			want:       deletesessions.Result{Sessions: []session.Session{old, recent, flowing}},
+			wantLeft:   sessions,
+			wantActive: true,
+		},
+		{
+			name:       "Read-only month",
+			command:    deletesessions.Command{Project: "Flow", LockedBefore: at(time.April, 1, 0)},
+			want:       deletesessions.Result{Sessions: []session.Session{old, recent, flowing}},
+			wantLeft:   sessions,
+			wantActive: true,
+			wantErr:    session.ErrLockedHistory,
+		},
+		{
+			name:       "No filter",
+			wantLeft:   sessions,
+			wantActive: true,
+			wantErr:    deletesessions.ErrNoFilter,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			repository := &infra.InMemorySessionRepository{Sessions: slices.Clone(sessions)}
+			lock := &infra.InMemoryActiveSessionLock{Active: &application.ActiveSession{AcquiredAt: flowing.StartTime, SessionId: "4"}}
+			useCase := deletesessions.NewDeleteSessionsUseCase(repository, lock)
+
+			got, err := useCase.Execute(tc.command)
+
+			is.Equal(err, tc.wantErr)
+			is.Equal(got, tc.want)
+			is.Equal(repository.FindAllSessions(nil), tc.wantLeft)
+			is.Equal(lock.Active != nil, tc.wantActive)
+		})
+	}
+}
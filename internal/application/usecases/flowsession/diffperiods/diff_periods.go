@@ -0,0 +1,40 @@
+package diffperiods
+
+import (
+	"errors"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/domain/sessionsreport"
+	"github.com/TristanShz/flow/pkg/timerange"
+)
+
+type UseCase struct {
+	sessionRepository application.SessionRepository
+}
+
+func (s UseCase) Execute(command Command) (sessionsreport.PeriodsDiff, error) {
+	if !command.A.SinceAndUntil() || !command.B.SinceAndUntil() {
+		return sessionsreport.PeriodsDiff{}, ErrMissingPeriod
+	}
+
+	return sessionsreport.NewPeriodsDiff(
+		s.findSessions(command.Project, command.A),
+		s.findSessions(command.Project, command.B),
+	), nil
+}
+
+func (s UseCase) findSessions(project string, period timerange.TimeRange) []session.Session {
+	return s.sessionRepository.FindAllSessions(&application.SessionsFilters{
+		Project:   project,
+		Timerange: period,
+	})
+}
+
+var ErrMissingPeriod = errors.New("both periods need a start and an end")
+
+func NewDiffPeriodsUseCase(sessionRepository application.SessionRepository) UseCase {
+	return UseCase{
+		sessionRepository: sessionRepository,
+	}
+}
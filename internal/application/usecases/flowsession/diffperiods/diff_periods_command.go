@@ -0,0 +1,10 @@
+package diffperiods
+
+import "github.com/TristanShz/flow/pkg/timerange"
+
+type Command struct {
+	// Project is optional, all the projects are compared when it's empty
+	Project string
+	A       timerange.TimeRange
+	B       timerange.TimeRange
+}
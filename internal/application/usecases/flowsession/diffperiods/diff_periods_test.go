@@ -0,0 +1,105 @@
+package diffperiods_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/diffperiods"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/domain/sessionsreport"
+	"github.com/TristanShz/flow/internal/tests"
+	"github.com/TristanShz/flow/pkg/timerange"
+)
+
+func TestDiffPeriods(t *testing.T) {
+	march := timerange.NewMonthTimeRange(time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC))
+	april := timerange.NewMonthTimeRange(time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC))
+
+	givenSessions := []session.Session{
+		{
+			Id:        "1",
+			StartTime: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, time.March, 1, 2, 0, 0, 0, time.UTC),
+			Project:   "Flow",
+			Tags:      []string{"cli"},
+		},
+		{
+			Id:        "2",
+			StartTime: time.Date(2024, time.March, 12, 9, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, time.March, 12, 10, 0, 0, 0, time.UTC),
+			Project:   "MyTodo",
+			Tags:      []string{"cli"},
+		},
+		{
+			Id:        "3",
+			StartTime: time.Date(2024, time.April, 2, 9, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, time.April, 2, 10, 0, 0, 0, time.UTC),
+			Project:   "Flow",
+			Tags:      []string{"docs"},
+		},
+		{
+			Id:        "4",
+			StartTime: time.Date(2024, time.May, 1, 9, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, time.May, 1, 10, 0, 0, 0, time.UTC),
+			Project:   "Flow",
+			Tags:      []string{"docs"},
+		},
+	}
+
+	tt := []struct {
+		error   error
+		name    string
+		command diffperiods.Command
+		want    sessionsreport.PeriodsDiff
+	}{
+		{
+			name:    "Sessions of a project",
+			command: diffperiods.Command{Project: "Flow", A: march, B: april},
+			want: sessionsreport.PeriodsDiff{
+				Projects: []sessionsreport.ProjectDiff{
+					{Project: "Flow", A: 2 * time.Hour, B: time.Hour},
+				},
+				Tags: []sessionsreport.TagDiff{
+					{Tag: "docs", B: time.Hour},
+					{Tag: "cli", A: 2 * time.Hour},
+				},
+				A: sessionsreport.PeriodStats{Sessions: 1, Duration: 2 * time.Hour},
+				B: sessionsreport.PeriodStats{Sessions: 1, Duration: time.Hour},
+			},
+		},
+		{
+			name:    "Sessions of all projects",
+			command: diffperiods.Command{A: march, B: april},
+			want: sessionsreport.PeriodsDiff{
+				Projects: []sessionsreport.ProjectDiff{
+					{Project: "Flow", A: 2 * time.Hour, B: time.Hour},
+					{Project: "MyTodo", A: time.Hour},
+				},
+				Tags: []sessionsreport.TagDiff{
+					{Tag: "docs", B: time.Hour},
+					{Tag: "cli", A: 3 * time.Hour},
+				},
+				A: sessionsreport.PeriodStats{Sessions: 2, Duration: 3 * time.Hour},
+				B: sessionsreport.PeriodStats{Sessions: 1, Duration: time.Hour},
+			},
+		},
+		{
+			name:    "Missing period",
+			command: diffperiods.Command{Project: "Flow", A: march},
+			error:   diffperiods.ErrMissingPeriod,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			f := tests.GetSessionFixture(t)
+
+			f.GivenSomeSessions(givenSessions)
+
+			f.WhenDiffingPeriods(tc.command)
+
+			f.ThenErrorShouldBe(tc.error)
+			f.ThenPeriodsDiffShouldBe(tc.want)
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package editsession
+
+import (
+	"cmp"
+	"errors"
+	"maps"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+type UseCase struct {
+	sessionRepository application.SessionRepository
+	dateProvider      application.DateProvider
+	eventPublisher    application.EventPublisher
+}
+
+func (s UseCase) Execute(command Command) (session.Session, error) {
+	existingSession, err := s.sessionRepository.FindById(command.Id)
+	if err != nil {
+		return session.Session{}, err
+	}
+	if existingSession == nil {
+		return session.Session{}, ErrSessionNotFound
+	}
+
+	edited := *existingSession
+
+	if command.Project != nil {
+		if *command.Project == "" {
+			return session.Session{}, ErrEmptyProject
+		}
+		edited.Project = *command.Project
+	}
+
+	if command.Tags != nil {
+		edited.Tags = *command.Tags
+	}
+
+	if command.Note != nil {
+		edited.Note = *command.Note
+	}
+
+	if command.Billable != nil {
+		edited.Billable = command.Billable
+	}
+
+	if command.HourlyRate != nil {
+		if *command.HourlyRate < 0 {
+			return session.Session{}, ErrNegativeRate
+		}
+		edited.HourlyRate = command.HourlyRate
+	}
+
+	if command.Client != nil {
+		edited.Client = *command.Client
+	}
+
+	if command.Continues != nil {
+		if err := s.checkContinues(edited.Id, *command.Continues); err != nil {
+			return session.Session{}, err
+		}
+		edited.Continues = *command.Continues
+	}
+
+	if command.BlockedBy != nil {
+		edited.BlockedBy = *command.BlockedBy
+	}
+
+	if command.StartTime != nil {
+		edited.StartTime = *command.StartTime
+	}
+
+	if command.EndTime != nil {
+		if existingSession.Status() == session.FlowingStatus {
+			return session.Session{}, ErrSessionFlowing
+		}
+		edited.EndTime = *command.EndTime
+
+		// an unstopped session is repaired once it has an end time
+		if _, ok := edited.Metadata[session.UnstoppedMetadata]; ok {
+			edited.Metadata = maps.Clone(edited.Metadata)
+			delete(edited.Metadata, session.UnstoppedMetadata)
+			if len(edited.Metadata) == 0 {
+				edited.Metadata = nil
+			}
+		}
+	}
+
+	// the approval covers the time and the project the session had, they
+	// have to be approved again once changed
+	approvedBy := edited.Metadata[session.ApprovedByMetadata]
+	if edited.Project != existingSession.Project || !edited.StartTime.Equal(existingSession.StartTime) || !edited.EndTime.Equal(existingSession.EndTime) {
+		approvedBy = ""
+	}
+	if command.ApprovedBy != nil {
+		approvedBy = *command.ApprovedBy
+	}
+	edited.Metadata = withMetadata(edited.Metadata, session.ApprovedByMetadata, approvedBy)
+
+	if !edited.EndTime.IsZero() && edited.EndTime.Before(edited.StartTime) {
+		return session.Session{}, ErrNegativeDuration
+	}
+
+	// a session can neither be changed in a read-only month nor be moved to one
+	if err := session.CheckLocked(command.LockedBefore, *existingSession, edited); err != nil {
+		return session.Session{}, err
+	}
+
+	// a warning about the overlaps is returned once the session is saved
+	edited, overlapErr := s.checkOverlaps(edited, cmp.Or(command.Overlap, session.OverlapAllow))
+	var warning *session.OverlapWarning
+	if overlapErr != nil && !errors.As(overlapErr, &warning) {
+		return session.Session{}, overlapErr
+	}
+
+	// the repository renames the session file when the project or the start
+	// time changed
+	if err := s.sessionRepository.Save(edited); err != nil {
+		return session.Session{}, err
+	}
+
+	s.eventPublisher.Publish(application.Event{Type: application.EventSessionEdited, At: s.dateProvider.GetNow(), Session: edited})
+
+	return edited, overlapErr
+}
+
+func (s UseCase) checkOverlaps(edited session.Session, policy string) (session.Session, error) {
+	if policy == session.OverlapAllow {
+		return edited, nil
+	}
+
+	return edited.CheckOverlaps(s.sessionRepository.FindAllSessions(nil), policy)
+}
+
+// withMetadata returns a copy of the metadata with the value of the key, an
+// empty value removes the key
+func withMetadata(metadata map[string]string, key string, value string) map[string]string {
+	if metadata[key] == value {
+		return metadata
+	}
+
+	metadata = maps.Clone(metadata)
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+	if value == "" {
+		delete(metadata, key)
+	} else {
+		metadata[key] = value
+	}
+
+	if len(metadata) == 0 {
+		return nil
+	}
+
+	return metadata
+}
+
+// checkContinues refuses links to unknown sessions and links making the
+// session continue itself
+func (s UseCase) checkContinues(id string, continues string) error {
+	for current := continues; current != ""; {
+		if current == id {
+			return ErrContinuesItself
+		}
+
+		continued, err := s.sessionRepository.FindById(current)
+		if err != nil {
+			return err
+		}
+		if continued == nil {
+			if current == continues {
+				return ErrContinuedSessionNotFound
+			}
+			// the chain was broken by a deleted session
+			return nil
+		}
+
+		current = continued.Continues
+	}
+
+	return nil
+}
+
+var (
+	ErrSessionNotFound          = errors.New("session not found")
+	ErrEmptyProject             = errors.New("project can't be empty")
+	ErrNegativeDuration         = errors.New("the session can't end before it starts")
+	ErrSessionFlowing           = errors.New("the session is still flowing, use 'flow stop' to end it")
+	ErrOverlap                  = session.ErrOverlap
+	ErrNegativeRate             = errors.New("hourly rate can't be negative")
+	ErrContinuedSessionNotFound = errors.New("the continued session can't be found")
+	ErrContinuesItself          = errors.New("a session can't continue itself, even through other sessions")
+)
+
+func NewEditSessionUseCase(
+	sessionRepository application.SessionRepository,
+	dateProvider application.DateProvider,
+	eventPublisher application.EventPublisher,
+) UseCase {
+	return UseCase{
+		sessionRepository: sessionRepository,
+		dateProvider:      dateProvider,
+		eventPublisher:    eventPublisher,
+	}
+}
@@ -0,0 +1,34 @@
+package editsession
+
+import "time"
+
+// Command fields left to nil keep the value of the session
+type Command struct {
+	StartTime *time.Time
+	EndTime   *time.Time
+	Project   *string
+	Tags      *[]string
+	Note      *string
+	// Billable and HourlyRate override the billing settings of the project
+	Billable   *bool
+	HourlyRate *float64
+	// Client overrides the client of the project, an empty client removes
+	// the override
+	Client *string
+	// Continues links the session to the session it continues, an empty id
+	// removes the link
+	Continues *string
+	// BlockedBy describes the external event blocking the session, an empty
+	// value removes it
+	BlockedBy *string
+	// ApprovedBy approves the session in the name of a member of the team
+	// server, an empty name withdraws the approval
+	ApprovedBy *string
+	Id         string
+	// Overlap is the policy applied when the session would overlap another
+	// one, see session.CheckOverlaps. Overlaps are allowed when it's empty.
+	Overlap string
+	// LockedBefore is the start of the first month whose sessions can be
+	// changed, see session.IsLocked
+	LockedBefore time.Time
+}
@@ -0,0 +1,356 @@
+package editsession_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/editsession"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/tests"
+)
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
+
+func TestEditSession(t *testing.T) {
+	ended := session.Session{
+		Id:        "1",
+		StartTime: time.Date(2024, time.April, 13, 9, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, time.April, 13, 10, 0, 0, 0, time.UTC),
+		Project:   "Flwo",
+		Tags:      []string{"cli"},
+	}
+	flowing := session.Session{
+		Id:        "2",
+		StartTime: time.Date(2024, time.April, 13, 11, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+	}
+
+	tt := []struct {
+		error         error
+		name          string
+		givenSessions []session.Session
+		command       editsession.Command
+		want          []session.Session
+	}{
+		{
+			name:          "Project, tags and note",
+			givenSessions: []session.Session{ended, flowing},
+			command: editsession.Command{
+				Id:      "1",
+				Project: stringPtr("Flow"),
+				Tags:    &[]string{"cli", "docs"},
+				Note:    stringPtr("Wrote the docs"),
+			},
+			want: []session.Session{
+				{
+					Id:        "1",
+					StartTime: ended.StartTime,
+					EndTime:   ended.EndTime,
+					Project:   "Flow",
+					Tags:      []string{"cli", "docs"},
+					Note:      "Wrote the docs",
+				},
+				flowing,
+			},
+		},
+		{
+			name:          "Billing overrides",
+			givenSessions: []session.Session{ended},
+			command: editsession.Command{
+				Id:         "1",
+				Billable:   boolPtr(false),
+				HourlyRate: floatPtr(120),
+				Client:     stringPtr("Globex"),
+			},
+			want: []session.Session{
+				{
+					Id:         "1",
+					StartTime:  ended.StartTime,
+					EndTime:    ended.EndTime,
+					Project:    "Flwo",
+					Tags:       []string{"cli"},
+					Billable:   boolPtr(false),
+					HourlyRate: floatPtr(120),
+					Client:     "Globex",
+				},
+			},
+		},
+		{
+			name:          "Negative hourly rate",
+			givenSessions: []session.Session{ended},
+			command:       editsession.Command{Id: "1", HourlyRate: floatPtr(-1)},
+			want:          []session.Session{ended},
+			error:         editsession.ErrNegativeRate,
+		},
+		{
+			name: "End time of an unstopped session",
+			givenSessions: []session.Session{
+				{
+					Id:        "3",
+					StartTime: time.Date(2024, time.April, 12, 9, 0, 0, 0, time.UTC),
+					Project:   "Flow",
+					Metadata:  map[string]string{session.UnstoppedMetadata: "true"},
+				},
+				flowing,
+			},
+			command: editsession.Command{
+				Id:      "3",
+				EndTime: timePtr(time.Date(2024, time.April, 12, 18, 0, 0, 0, time.UTC)),
+			},
+			want: []session.Session{
+				{
+					Id:        "3",
+					StartTime: time.Date(2024, time.April, 12, 9, 0, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.April, 12, 18, 0, 0, 0, time.UTC),
+					Project:   "Flow",
+				},
+				flowing,
+			},
+		},
+		{
+			name:          "Approval",
+			givenSessions: []session.Session{ended},
+			command:       editsession.Command{Id: "1", ApprovedBy: stringPtr("alice")},
+			want: []session.Session{
+				{
+					Id:        "1",
+					StartTime: ended.StartTime,
+					EndTime:   ended.EndTime,
+					Project:   "Flwo",
+					Tags:      []string{"cli"},
+					Metadata:  map[string]string{session.ApprovedByMetadata: "alice"},
+				},
+			},
+		},
+		{
+			name: "Approval withdrawn by a change of the time",
+			givenSessions: []session.Session{
+				{
+					Id:        "1",
+					StartTime: ended.StartTime,
+					EndTime:   ended.EndTime,
+					Project:   "Flwo",
+					Metadata:  map[string]string{session.ApprovedByMetadata: "alice"},
+				},
+			},
+			command: editsession.Command{Id: "1", EndTime: timePtr(ended.EndTime.Add(time.Hour))},
+			want: []session.Session{
+				{
+					Id:        "1",
+					StartTime: ended.StartTime,
+					EndTime:   ended.EndTime.Add(time.Hour),
+					Project:   "Flwo",
+				},
+			},
+		},
+		{
+			name:          "Start and end time",
+			givenSessions: []session.Session{ended},
+			command: editsession.Command{
+				Id:        "1",
+				StartTime: timePtr(time.Date(2024, time.April, 13, 8, 0, 0, 0, time.UTC)),
+				EndTime:   timePtr(time.Date(2024, time.April, 13, 9, 30, 0, 0, time.UTC)),
+			},
+			want: []session.Session{
+				{
+					Id:        "1",
+					StartTime: time.Date(2024, time.April, 13, 8, 0, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.April, 13, 9, 30, 0, 0, time.UTC),
+					Project:   "Flwo",
+					Tags:      []string{"cli"},
+				},
+			},
+		},
+		{
+			name:          "Start time of a flowing session",
+			givenSessions: []session.Session{flowing},
+			command: editsession.Command{
+				Id:        "2",
+				StartTime: timePtr(time.Date(2024, time.April, 13, 10, 30, 0, 0, time.UTC)),
+			},
+			want: []session.Session{
+				{
+					Id:        "2",
+					StartTime: time.Date(2024, time.April, 13, 10, 30, 0, 0, time.UTC),
+					Project:   "Flow",
+				},
+			},
+		},
+		{
+			name:          "Overlap allowed when not checked",
+			givenSessions: []session.Session{ended, flowing},
+			command: editsession.Command{
+				Id:      "1",
+				EndTime: timePtr(time.Date(2024, time.April, 13, 12, 0, 0, 0, time.UTC)),
+			},
+			want: []session.Session{
+				{
+					Id:        "1",
+					StartTime: ended.StartTime,
+					EndTime:   time.Date(2024, time.April, 13, 12, 0, 0, 0, time.UTC),
+					Project:   "Flwo",
+					Tags:      []string{"cli"},
+				},
+				flowing,
+			},
+		},
+		{
+			name:          "Overlap",
+			givenSessions: []session.Session{ended, flowing},
+			command: editsession.Command{
+				Id:      "1",
+				EndTime: timePtr(time.Date(2024, time.April, 13, 12, 0, 0, 0, time.UTC)),
+				Overlap: session.OverlapReject,
+			},
+			want:  []session.Session{ended, flowing},
+			error: editsession.ErrOverlap,
+		},
+		{
+			name:          "Overlap adjusted",
+			givenSessions: []session.Session{ended, flowing},
+			command: editsession.Command{
+				Id:      "1",
+				EndTime: timePtr(time.Date(2024, time.April, 13, 12, 0, 0, 0, time.UTC)),
+				Overlap: session.OverlapAdjust,
+			},
+			want: []session.Session{
+				{
+					Id:        "1",
+					StartTime: ended.StartTime,
+					EndTime:   flowing.StartTime,
+					Project:   "Flwo",
+					Tags:      []string{"cli"},
+				},
+				flowing,
+			},
+		},
+		{
+			name:          "Negative duration",
+			givenSessions: []session.Session{ended},
+			command: editsession.Command{
+				Id:        "1",
+				StartTime: timePtr(time.Date(2024, time.April, 13, 11, 0, 0, 0, time.UTC)),
+			},
+			want:  []session.Session{ended},
+			error: editsession.ErrNegativeDuration,
+		},
+		{
+			name:          "Session of a read-only month",
+			givenSessions: []session.Session{ended},
+			command: editsession.Command{
+				Id:           "1",
+				Project:      stringPtr("Flow"),
+				LockedBefore: time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC),
+			},
+			want:  []session.Session{ended},
+			error: session.ErrLockedHistory,
+		},
+		{
+			name:          "Moved to a read-only month",
+			givenSessions: []session.Session{ended},
+			command: editsession.Command{
+				Id:           "1",
+				StartTime:    timePtr(time.Date(2024, time.March, 31, 9, 0, 0, 0, time.UTC)),
+				LockedBefore: time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC),
+			},
+			want:  []session.Session{ended},
+			error: session.ErrLockedHistory,
+		},
+		{
+			name:          "End time of a flowing session",
+			givenSessions: []session.Session{flowing},
+			command: editsession.Command{
+				Id:      "2",
+				EndTime: timePtr(time.Date(2024, time.April, 13, 12, 0, 0, 0, time.UTC)),
+			},
+			want:  []session.Session{flowing},
+			error: editsession.ErrSessionFlowing,
+		},
+		{
+			name:          "Links",
+			givenSessions: []session.Session{ended, flowing},
+			command: editsession.Command{
+				Id:        "2",
+				Continues: stringPtr("1"),
+				BlockedBy: stringPtr("Waiting for the API keys"),
+			},
+			want: []session.Session{
+				ended,
+				{
+					Id:        "2",
+					StartTime: flowing.StartTime,
+					Project:   "Flow",
+					Continues: "1",
+					BlockedBy: "Waiting for the API keys",
+				},
+			},
+		},
+		{
+			name:          "Continued session not found",
+			givenSessions: []session.Session{ended, flowing},
+			command:       editsession.Command{Id: "2", Continues: stringPtr("3")},
+			want:          []session.Session{ended, flowing},
+			error:         editsession.ErrContinuedSessionNotFound,
+		},
+		{
+			name: "Session continuing itself",
+			givenSessions: []session.Session{
+				{Id: "1", StartTime: ended.StartTime, EndTime: ended.EndTime, Project: "Flwo", Continues: "2"},
+				flowing,
+			},
+			command: editsession.Command{Id: "2", Continues: stringPtr("1")},
+			want: []session.Session{
+				{Id: "1", StartTime: ended.StartTime, EndTime: ended.EndTime, Project: "Flwo", Continues: "2"},
+				flowing,
+			},
+			error: editsession.ErrContinuesItself,
+		},
+		{
+			name:          "Empty project",
+			givenSessions: []session.Session{ended},
+			command:       editsession.Command{Id: "1", Project: stringPtr("")},
+			want:          []session.Session{ended},
+			error:         editsession.ErrEmptyProject,
+		},
+		{
+			name:          "Session not found",
+			givenSessions: []session.Session{ended},
+			command:       editsession.Command{Id: "3", Project: stringPtr("Flow")},
+			want:          []session.Session{ended},
+			error:         editsession.ErrSessionNotFound,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			f := tests.GetSessionFixture(t)
+
+			f.GivenSomeSessions(append([]session.Session{}, tc.givenSessions...))
+
+			f.WhenEditingSession(tc.command)
+
+			f.ThenErrorShouldBe(tc.error)
+			f.ThenSessionsShouldBe(tc.want)
+			if tc.error == nil {
+				f.ThenPublishedEventsShouldBe([]string{application.EventSessionEdited})
+			} else {
+				f.ThenPublishedEventsShouldBe(nil)
+			}
+		})
+	}
+}
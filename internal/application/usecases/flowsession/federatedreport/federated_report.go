@@ -0,0 +1,85 @@
+package federatedreport
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/viewsessionsreport"
+)
+
+var ErrUnknownStore = errors.New("unknown store")
+
+type UseCase struct {
+	stores       []application.ReportStore
+	dateProvider application.DateProvider
+}
+
+// Execute reports each store on its own, the sessions of the stores aren't
+// merged. The stores are read concurrently, each one showing its report to
+// the presenter newPresenter returns for it, which is called in the order of
+// the stores before any of them is read.
+func (s UseCase) Execute(command Command, newPresenter func(store string) application.SessionsReportPresenter) error {
+	stores := s.stores
+	if len(command.Stores) > 0 {
+		stores = []application.ReportStore{}
+		for _, name := range command.Stores {
+			index := slices.IndexFunc(s.stores, func(store application.ReportStore) bool {
+				return store.Name == name
+			})
+			if index == -1 {
+				return fmt.Errorf("%w %v. possible values: %v", ErrUnknownStore, name, s.Names())
+			}
+
+			stores = append(stores, s.stores[index])
+		}
+	}
+
+	presenters := make([]application.SessionsReportPresenter, len(stores))
+	for i, store := range stores {
+		presenters[i] = newPresenter(store.Name)
+	}
+
+	errs := make([]error, len(stores))
+	var wg sync.WaitGroup
+	for i, store := range stores {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			useCase := viewsessionsreport.NewViewSessionsReportUseCase(store.SessionRepository, store.ProjectRepository, store.JournalRepository, s.dateProvider)
+			if err := useCase.Execute(command.Report, presenters[i]); err != nil {
+				errs[i] = fmt.Errorf("store %v: %w", store.Name, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// the stores fail alike on an invalid command, its error is returned once
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Names returns the names of the stores, in the order they're reported
+func (s UseCase) Names() []string {
+	names := []string{}
+	for _, store := range s.stores {
+		names = append(names, store.Name)
+	}
+
+	return names
+}
+
+func NewFederatedReportUseCase(stores []application.ReportStore, dateProvider application.DateProvider) UseCase {
+	return UseCase{
+		stores:       stores,
+		dateProvider: dateProvider,
+	}
+}
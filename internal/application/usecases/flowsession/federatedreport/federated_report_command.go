@@ -0,0 +1,11 @@
+package federatedreport
+
+import "github.com/TristanShz/flow/internal/application/usecases/flowsession/viewsessionsreport"
+
+type Command struct {
+	// Report is the report made of each store
+	Report viewsessionsreport.Command
+	// Stores are the names of the stores reported, in this order, every
+	// store is reported when it's empty
+	Stores []string
+}
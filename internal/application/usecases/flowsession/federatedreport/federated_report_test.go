@@ -0,0 +1,119 @@
+package federatedreport_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/federatedreport"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/viewsessionsreport"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/domain/sessionsreport"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/TristanShz/flow/internal/tests"
+	"github.com/matryer/is"
+)
+
+func newStore(name string, sessions ...session.Session) application.ReportStore {
+	return application.ReportStore{
+		Name:              name,
+		SessionRepository: &infra.InMemorySessionRepository{Sessions: sessions},
+		ProjectRepository: &infra.InMemoryProjectRepository{},
+		JournalRepository: &infra.InMemoryJournalRepository{},
+	}
+}
+
+func TestFederatedReport(t *testing.T) {
+	personal := session.Session{
+		Id:        "1",
+		StartTime: time.Date(2024, time.April, 15, 20, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, time.April, 15, 21, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+	}
+	work := session.Session{
+		Id:        "1",
+		StartTime: time.Date(2024, time.April, 15, 9, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, time.April, 15, 12, 0, 0, 0, time.UTC),
+		Project:   "Intranet",
+	}
+	stores := []application.ReportStore{
+		newStore(application.LocalStore, personal),
+		newStore("work", work),
+	}
+
+	tt := []struct {
+		name       string
+		stores     []string
+		wantStores []string
+		want       map[string][]session.Session
+		wantErr    error
+	}{
+		{
+			name:       "Every store",
+			wantStores: []string{application.LocalStore, "work"},
+			want: map[string][]session.Session{
+				application.LocalStore: {personal},
+				"work":                 {work},
+			},
+		},
+		{
+			name:       "Given stores in their order",
+			stores:     []string{"work", application.LocalStore},
+			wantStores: []string{"work", application.LocalStore},
+			want: map[string][]session.Session{
+				application.LocalStore: {personal},
+				"work":                 {work},
+			},
+		},
+		{
+			name:    "Unknown store",
+			stores:  []string{"home"},
+			want:    map[string][]session.Session{},
+			wantErr: federatedreport.ErrUnknownStore,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			useCase := federatedreport.NewFederatedReportUseCase(stores, infra.NewStubDateProvider())
+			presenters := map[string]*tests.TestPresenter{}
+			gotStores := []string{}
+
+			err := useCase.Execute(federatedreport.Command{
+				Report: viewsessionsreport.Command{Format: sessionsreport.FormatByProject},
+				Stores: tc.stores,
+			}, func(store string) application.SessionsReportPresenter {
+				gotStores = append(gotStores, store)
+				presenters[store] = &tests.TestPresenter{}
+				return presenters[store]
+			})
+
+			is.True(errors.Is(err, tc.wantErr))
+			if tc.wantErr != nil {
+				return
+			}
+			is.Equal(gotStores, tc.wantStores)
+			for store, sessions := range tc.want {
+				want := sessionsreport.SessionsReport{Sessions: sessions}
+				is.Equal(presenters[store].SessionsReportByProject.GetByProjectReport(), want.GetByProjectReport())
+			}
+		})
+	}
+}
+
+func TestFederatedReport_InvalidReport(t *testing.T) {
+	is := is.New(t)
+
+	useCase := federatedreport.NewFederatedReportUseCase([]application.ReportStore{newStore(application.LocalStore), newStore("work")}, infra.NewStubDateProvider())
+
+	err := useCase.Execute(federatedreport.Command{
+		Report: viewsessionsreport.Command{Format: sessionsreport.FormatGaps},
+	}, func(string) application.SessionsReportPresenter {
+		return &tests.TestPresenter{}
+	})
+
+	is.True(errors.Is(err, viewsessionsreport.ErrGapsWithoutSince))
+}
@@ -0,0 +1,47 @@
+package heatmap
+
+import (
+	"fmt"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/sessionsreport"
+	"github.com/TristanShz/flow/pkg/timerange"
+)
+
+// MaxWeeks is a year of weeks
+const MaxWeeks = 53
+
+var ErrInvalidWeeks = fmt.Errorf("the number of weeks must be between 1 and %v", MaxWeeks)
+
+type UseCase struct {
+	sessionRepository application.SessionRepository
+	dateProvider      application.DateProvider
+}
+
+// Execute returns the time tracked each day of the last weeks, the first
+// week starting on the week start
+func (s UseCase) Execute(command Command) (sessionsreport.Heatmap, error) {
+	if command.Weeks < 1 || command.Weeks > MaxWeeks {
+		return sessionsreport.Heatmap{}, ErrInvalidWeeks
+	}
+
+	now := s.dateProvider.GetNow()
+	since := timerange.StartOf(now, timerange.ByWeek, command.WeekStart).AddDate(0, 0, -7*(command.Weeks-1))
+
+	sessions := s.sessionRepository.FindAllSessions(&application.SessionsFilters{
+		Timerange: timerange.TimeRange{Since: since, Until: now},
+		Project:   command.Project,
+	})
+
+	return sessionsreport.NewHeatmap(sessions, since, now), nil
+}
+
+func NewHeatmapUseCase(
+	sessionRepository application.SessionRepository,
+	dateProvider application.DateProvider,
+) UseCase {
+	return UseCase{
+		sessionRepository: sessionRepository,
+		dateProvider:      dateProvider,
+	}
+}
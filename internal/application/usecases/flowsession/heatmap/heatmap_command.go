@@ -0,0 +1,12 @@
+package heatmap
+
+import "time"
+
+type Command struct {
+	// Weeks is the number of weeks up to the current one, between 1 and
+	// MaxWeeks
+	Weeks     int
+	WeekStart time.Weekday
+	// Project keeps the sessions of the project, every session when empty
+	Project string
+}
@@ -0,0 +1,74 @@
+package heatmap_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/heatmap"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/domain/sessionsreport"
+	"github.com/TristanShz/flow/internal/tests"
+)
+
+func day(d int) time.Time {
+	return time.Date(2024, time.April, d, 0, 0, 0, 0, time.UTC)
+}
+
+var sessionsForTest = []session.Session{
+	{Id: "1", StartTime: day(5).Add(9 * time.Hour), EndTime: day(5).Add(17 * time.Hour), Project: "Flow"},
+	{Id: "2", StartTime: day(9).Add(9 * time.Hour), EndTime: day(9).Add(11 * time.Hour), Project: "Flow"},
+	{Id: "3", StartTime: day(9).Add(14 * time.Hour), EndTime: day(9).Add(15 * time.Hour), Project: "MyTodo"},
+	{Id: "4", StartTime: day(16).Add(9 * time.Hour), EndTime: day(16).Add(13 * time.Hour), Project: "Flow"},
+}
+
+func TestHeatmap(t *testing.T) {
+	tt := []struct {
+		name    string
+		command heatmap.Command
+		want    sessionsreport.Heatmap
+	}{
+		{
+			name:    "Last two weeks",
+			command: heatmap.Command{Weeks: 2, WeekStart: time.Monday},
+			want: sessionsreport.Heatmap{
+				Weeks: [][]sessionsreport.DailyTotal{
+					{{Day: day(8)}, {Day: day(9), Duration: 3 * time.Hour}, {Day: day(10)}, {Day: day(11)}, {Day: day(12)}, {Day: day(13)}, {Day: day(14)}},
+					{{Day: day(15)}, {Day: day(16), Duration: 4 * time.Hour}, {Day: day(17)}},
+				},
+				Busiest: sessionsreport.DailyTotal{Day: day(16), Duration: 4 * time.Hour},
+			},
+		},
+		{
+			name:    "Sessions of a project in weeks starting on sunday",
+			command: heatmap.Command{Weeks: 1, WeekStart: time.Sunday, Project: "MyTodo"},
+			want: sessionsreport.Heatmap{
+				Weeks: [][]sessionsreport.DailyTotal{
+					{{Day: day(14)}, {Day: day(15)}, {Day: day(16)}, {Day: day(17)}},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			f := tests.GetSessionFixture(t)
+
+			f.GivenSomeSessions(sessionsForTest)
+			f.GivenNowIs(day(17).Add(12 * time.Hour))
+
+			f.WhenComputingHeatmap(tc.command)
+
+			f.ThenHeatmapShouldBe(tc.want)
+		})
+	}
+}
+
+func TestHeatmap_InvalidWeeks(t *testing.T) {
+	for _, weeks := range []int{0, heatmap.MaxWeeks + 1} {
+		f := tests.GetSessionFixture(t)
+
+		f.WhenComputingHeatmap(heatmap.Command{Weeks: weeks})
+
+		f.ThenErrorShouldBe(heatmap.ErrInvalidWeeks)
+	}
+}
@@ -0,0 +1,26 @@
+package listsessionmetadata
+
+import (
+	"github.com/TristanShz/flow/internal/application"
+)
+
+type UseCase struct {
+	sessionRepository application.SessionRepository
+}
+
+// Execute returns the ids, projects and start times of the sessions, the
+// newest first, without reading the sessions
+func (s UseCase) Execute(command Command) []application.SessionMetadata {
+	return s.sessionRepository.FindAllSessionMetadata(&application.SessionsFilters{
+		Project: command.Project,
+		Order:   application.OrderDescending,
+		Offset:  command.Skip,
+		Limit:   command.Count,
+	})
+}
+
+func NewListSessionMetadataUseCase(sessionRepository application.SessionRepository) UseCase {
+	return UseCase{
+		sessionRepository: sessionRepository,
+	}
+}
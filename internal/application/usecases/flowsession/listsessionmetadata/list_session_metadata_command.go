@@ -0,0 +1,9 @@
+package listsessionmetadata
+
+type Command struct {
+	// Count is the number of sessions listed, all of them when zero
+	Count int
+	// Skip skips the newest sessions, to list the older ones
+	Skip    int
+	Project string
+}
@@ -0,0 +1,61 @@
+package listsessionmetadata_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/listsessionmetadata"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/matryer/is"
+)
+
+func TestListSessionMetadata(t *testing.T) {
+	at := func(day int) time.Time {
+		return time.Date(2024, time.April, day, 9, 0, 0, 0, time.UTC)
+	}
+	sessions := []session.Session{
+		{Id: "1", StartTime: at(1), EndTime: at(1).Add(time.Hour), Project: "Flow", Tags: []string{"cli"}, Note: "not listed"},
+		{Id: "2", StartTime: at(2), EndTime: at(2).Add(time.Hour), Project: "Intranet"},
+		{Id: "3", StartTime: at(3), Project: "Flow"},
+	}
+
+	tt := []struct {
+		name    string
+		command listsessionmetadata.Command
+		want    []application.SessionMetadata
+	}{
+		{
+			name:    "All the sessions",
+			command: listsessionmetadata.Command{},
+			want: []application.SessionMetadata{
+				{Id: "3", Project: "Flow", StartTime: at(3)},
+				{Id: "2", Project: "Intranet", StartTime: at(2)},
+				{Id: "1", Project: "Flow", StartTime: at(1)},
+			},
+		},
+		{
+			name:    "Count and skip",
+			command: listsessionmetadata.Command{Count: 1, Skip: 1},
+			want:    []application.SessionMetadata{{Id: "2", Project: "Intranet", StartTime: at(2)}},
+		},
+		{
+			name:    "Project",
+			command: listsessionmetadata.Command{Project: "Flow", Count: 5},
+			want: []application.SessionMetadata{
+				{Id: "3", Project: "Flow", StartTime: at(3)},
+				{Id: "1", Project: "Flow", StartTime: at(1)},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			useCase := listsessionmetadata.NewListSessionMetadataUseCase(&infra.InMemorySessionRepository{Sessions: sessions})
+
+			is.Equal(useCase.Execute(tc.command), tc.want)
+		})
+	}
+}
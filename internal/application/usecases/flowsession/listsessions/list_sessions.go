@@ -0,0 +1,33 @@
+package listsessions
+
+import (
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+const DefaultCount = 10
+
+type UseCase struct {
+	sessionRepository application.SessionRepository
+}
+
+// Execute returns the last sessions, the newest first
+func (s UseCase) Execute(command Command) []session.Session {
+	count := command.Count
+	if count <= 0 {
+		count = DefaultCount
+	}
+
+	return s.sessionRepository.FindAllSessions(&application.SessionsFilters{
+		Project: command.Project,
+		Order:   application.OrderDescending,
+		Offset:  command.Skip,
+		Limit:   count,
+	})
+}
+
+func NewListSessionsUseCase(sessionRepository application.SessionRepository) UseCase {
+	return UseCase{
+		sessionRepository: sessionRepository,
+	}
+}
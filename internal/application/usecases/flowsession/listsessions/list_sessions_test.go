@@ -0,0 +1,69 @@
+package listsessions_test
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/listsessions"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/matryer/is"
+)
+
+func TestListSessions(t *testing.T) {
+	sessions := []session.Session{}
+	start := time.Date(2024, time.April, 1, 9, 0, 0, 0, time.UTC)
+	for i := 1; i <= 12; i++ {
+		project := "Flow"
+		if i%3 == 0 {
+			project = "Intranet"
+		}
+		sessions = append(sessions, session.Session{
+			Id:        strconv.Itoa(i),
+			StartTime: start.AddDate(0, 0, i),
+			EndTime:   start.AddDate(0, 0, i).Add(time.Hour),
+			Project:   project,
+		})
+	}
+
+	tt := []struct {
+		name    string
+		command listsessions.Command
+		wantIds []string
+	}{
+		{
+			name:    "Last sessions by default",
+			command: listsessions.Command{},
+			wantIds: []string{"12", "11", "10", "9", "8", "7", "6", "5", "4", "3"},
+		},
+		{
+			name:    "Count",
+			command: listsessions.Command{Count: 2},
+			wantIds: []string{"12", "11"},
+		},
+		{
+			name:    "Skipped newest sessions",
+			command: listsessions.Command{Count: 2, Skip: 2},
+			wantIds: []string{"10", "9"},
+		},
+		{
+			name:    "Project",
+			command: listsessions.Command{Count: 3, Project: "Intranet"},
+			wantIds: []string{"12", "9", "6"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			useCase := listsessions.NewListSessionsUseCase(&infra.InMemorySessionRepository{Sessions: sessions})
+
+			ids := []string{}
+			for _, s := range useCase.Execute(tc.command) {
+				ids = append(ids, s.Id)
+			}
+			is.Equal(ids, tc.wantIds)
+		})
+	}
+}
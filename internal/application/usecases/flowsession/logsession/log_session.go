@@ -0,0 +1,78 @@
+package logsession
+
+import (
+	"cmp"
+	"errors"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+type UseCase struct {
+	sessionRepository application.SessionRepository
+	dateProvider      application.DateProvider
+	idProvider        application.IDProvider
+}
+
+// Execute saves a session that already ended, for work done without starting
+// a session
+func (s UseCase) Execute(command Command) (session.Session, error) {
+	if command.Project == "" {
+		return session.Session{}, ErrEmptyProject
+	}
+
+	if !command.EndTime.After(command.StartTime) {
+		return session.Session{}, ErrNegativeDuration
+	}
+
+	if command.EndTime.After(s.dateProvider.GetNow()) {
+		return session.Session{}, ErrEndInFuture
+	}
+
+	if command.StartTime.Before(command.LockedBefore) {
+		return session.Session{}, session.ErrLockedHistory
+	}
+
+	logged := session.Session{
+		Id:        s.idProvider.Provide(),
+		StartTime: command.StartTime,
+		EndTime:   command.EndTime,
+		Project:   command.Project,
+		Tags:      command.Tags,
+		Note:      command.Note,
+	}
+
+	// the current session is considered flowing until the end of times, a
+	// session can only be logged before it started. A warning about the
+	// overlaps is returned once the session is saved.
+	logged, overlapErr := logged.CheckOverlaps(s.sessionRepository.FindAllSessions(nil), cmp.Or(command.Overlap, session.OverlapReject))
+	var warning *session.OverlapWarning
+	if overlapErr != nil && !errors.As(overlapErr, &warning) {
+		return session.Session{}, overlapErr
+	}
+
+	if err := s.sessionRepository.Save(logged); err != nil {
+		return session.Session{}, err
+	}
+
+	return logged, overlapErr
+}
+
+var (
+	ErrEmptyProject     = errors.New("project can't be empty")
+	ErrNegativeDuration = errors.New("the session must end after it starts")
+	ErrEndInFuture      = errors.New("the session can't end in the future, use 'flow start' instead")
+	ErrOverlap          = session.ErrOverlap
+)
+
+func NewLogSessionUseCase(
+	sessionRepository application.SessionRepository,
+	dateProvider application.DateProvider,
+	idProvider application.IDProvider,
+) UseCase {
+	return UseCase{
+		sessionRepository: sessionRepository,
+		dateProvider:      dateProvider,
+		idProvider:        idProvider,
+	}
+}
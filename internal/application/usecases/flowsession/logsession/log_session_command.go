@@ -0,0 +1,17 @@
+package logsession
+
+import "time"
+
+type Command struct {
+	StartTime time.Time
+	EndTime   time.Time
+	Project   string
+	Note      string
+	Tags      []string
+	// Overlap is the policy applied when the session would overlap another
+	// one, see session.CheckOverlaps. Overlaps are rejected when it's empty.
+	Overlap string
+	// LockedBefore is the start of the first month whose sessions can be
+	// changed, see session.IsLocked
+	LockedBefore time.Time
+}
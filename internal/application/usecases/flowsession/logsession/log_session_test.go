@@ -0,0 +1,147 @@
+package logsession_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/logsession"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/tests"
+)
+
+func TestLogSession(t *testing.T) {
+	now := time.Date(2024, time.April, 13, 18, 0, 0, 0, time.UTC)
+
+	ended := session.Session{
+		Id:        "1",
+		StartTime: time.Date(2024, time.April, 13, 9, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, time.April, 13, 10, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+	}
+	flowing := session.Session{
+		Id:        "2",
+		StartTime: time.Date(2024, time.April, 13, 15, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+	}
+
+	tt := []struct {
+		error         error
+		name          string
+		givenSessions []session.Session
+		command       logsession.Command
+		want          []session.Session
+	}{
+		{
+			name:          "Between sessions",
+			givenSessions: []session.Session{ended, flowing},
+			command: logsession.Command{
+				Project:   "MyTodo",
+				Tags:      []string{"docs"},
+				Note:      "Forgot to start",
+				StartTime: time.Date(2024, time.April, 13, 10, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2024, time.April, 13, 12, 0, 0, 0, time.UTC),
+			},
+			want: []session.Session{ended, flowing, {
+				Id:        "id-1",
+				StartTime: time.Date(2024, time.April, 13, 10, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2024, time.April, 13, 12, 0, 0, 0, time.UTC),
+				Project:   "MyTodo",
+				Tags:      []string{"docs"},
+				Note:      "Forgot to start",
+			}},
+		},
+		{
+			name:          "Overlapping an ended session",
+			givenSessions: []session.Session{ended},
+			command: logsession.Command{
+				Project:   "MyTodo",
+				StartTime: time.Date(2024, time.April, 13, 8, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2024, time.April, 13, 9, 30, 0, 0, time.UTC),
+			},
+			want:  []session.Session{ended},
+			error: logsession.ErrOverlap,
+		},
+		{
+			name:          "Overlapping the current session",
+			givenSessions: []session.Session{flowing},
+			command: logsession.Command{
+				Project:   "MyTodo",
+				StartTime: time.Date(2024, time.April, 13, 16, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2024, time.April, 13, 17, 0, 0, 0, time.UTC),
+			},
+			want:  []session.Session{flowing},
+			error: logsession.ErrOverlap,
+		},
+		{
+			name:          "Overlap adjusted",
+			givenSessions: []session.Session{ended, flowing},
+			command: logsession.Command{
+				Project:   "MyTodo",
+				StartTime: time.Date(2024, time.April, 13, 9, 30, 0, 0, time.UTC),
+				EndTime:   time.Date(2024, time.April, 13, 16, 0, 0, 0, time.UTC),
+				Overlap:   session.OverlapAdjust,
+			},
+			want: []session.Session{ended, flowing, {
+				Id:        "id-1",
+				StartTime: ended.EndTime,
+				EndTime:   flowing.StartTime,
+				Project:   "MyTodo",
+			}},
+		},
+		{
+			name: "Ending before it starts",
+			command: logsession.Command{
+				Project:   "MyTodo",
+				StartTime: time.Date(2024, time.April, 13, 12, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2024, time.April, 13, 11, 0, 0, 0, time.UTC),
+			},
+			want:  []session.Session{},
+			error: logsession.ErrNegativeDuration,
+		},
+		{
+			name: "Starting in a read-only month",
+			command: logsession.Command{
+				Project:      "MyTodo",
+				StartTime:    time.Date(2024, time.March, 29, 9, 0, 0, 0, time.UTC),
+				EndTime:      time.Date(2024, time.March, 29, 11, 0, 0, 0, time.UTC),
+				LockedBefore: time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC),
+			},
+			want:  []session.Session{},
+			error: session.ErrLockedHistory,
+		},
+		{
+			name: "Ending in the future",
+			command: logsession.Command{
+				Project:   "MyTodo",
+				StartTime: time.Date(2024, time.April, 13, 17, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2024, time.April, 13, 19, 0, 0, 0, time.UTC),
+			},
+			want:  []session.Session{},
+			error: logsession.ErrEndInFuture,
+		},
+		{
+			name: "Empty project",
+			command: logsession.Command{
+				StartTime: time.Date(2024, time.April, 13, 11, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2024, time.April, 13, 12, 0, 0, 0, time.UTC),
+			},
+			want:  []session.Session{},
+			error: logsession.ErrEmptyProject,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			f := tests.GetSessionFixture(t)
+
+			f.GivenNowIs(now)
+			f.GivenPredefinedIdentifier("id-1")
+			f.GivenSomeSessions(append([]session.Session{}, tc.givenSessions...))
+
+			f.WhenLoggingSession(tc.command)
+
+			f.ThenErrorShouldBe(tc.error)
+			f.ThenSessionsShouldBe(tc.want)
+		})
+	}
+}
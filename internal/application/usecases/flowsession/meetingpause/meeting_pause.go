@@ -0,0 +1,199 @@
+package meetingpause
+
+import (
+	"errors"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/project"
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+// MetadataKey is the session metadata holding the on meeting action that
+// ended the session
+const MetadataKey = "meeting"
+
+// ResumesMetadataKey is the metadata of a meeting session holding the id of
+// the session to resume once the meeting ends
+const ResumesMetadataKey = "meeting_resumes"
+
+const (
+	ActionNone     = ""
+	ActionPaused   = "paused"
+	ActionSwitched = "switched to the meeting"
+	ActionResumed  = "resumed"
+)
+
+type UseCase struct {
+	sessionRepository application.SessionRepository
+	projectRepository application.ProjectRepository
+	idProvider        application.IDProvider
+	activeSessionLock application.ActiveSessionLock
+	eventPublisher    application.EventPublisher
+}
+
+// Execute applies the on meeting action of the project of the current
+// session and returns the action that was taken, if any
+func (s UseCase) Execute(command Command) (string, error) {
+	if command.Started {
+		return s.meetingStarted(command)
+	}
+
+	return s.meetingEnded(command)
+}
+
+func (s UseCase) meetingStarted(command Command) (string, error) {
+	lastSession, err := s.sessionRepository.FindLastSession()
+	if err != nil {
+		return ActionNone, err
+	}
+	if lastSession == nil || lastSession.Status() != session.FlowingStatus {
+		return ActionNone, nil
+	}
+
+	// a meeting during a meeting keeps the session of the first one
+	if _, ok := lastSession.Metadata[ResumesMetadataKey]; ok {
+		return ActionNone, nil
+	}
+
+	p := project.Project{Name: lastSession.Project}
+	if settings := s.projectRepository.FindByName(lastSession.Project); settings != nil {
+		p = *settings
+	}
+
+	onMeeting := p.OnMeetingAction()
+	if onMeeting == project.OnMeetingNone {
+		return ActionNone, nil
+	}
+
+	paused := *lastSession
+	paused.EndTime = command.At
+	if paused.EndTime.Before(paused.StartTime) {
+		paused.EndTime = paused.StartTime
+	}
+	paused.Metadata = map[string]string{}
+	for key, value := range lastSession.Metadata {
+		paused.Metadata[key] = value
+	}
+	paused.Metadata[MetadataKey] = onMeeting
+
+	if err := s.sessionRepository.Save(paused); err != nil {
+		return ActionNone, err
+	}
+
+	if err := s.activeSessionLock.Release(paused.Id); err != nil {
+		return ActionNone, err
+	}
+
+	s.eventPublisher.Publish(application.Event{Type: application.EventSessionPaused, At: command.At, Session: paused})
+
+	if onMeeting == project.OnMeetingPause {
+		return ActionPaused, nil
+	}
+
+	meeting := session.Session{
+		Id:        s.idProvider.Provide(),
+		StartTime: paused.EndTime,
+		Project:   p.MeetingProjectName(),
+		Note:      command.Title,
+		Metadata:  map[string]string{ResumesMetadataKey: paused.Id},
+	}
+	if err := s.startSession(meeting); err != nil {
+		return ActionNone, err
+	}
+
+	s.eventPublisher.Publish(application.Event{Type: application.EventSessionStarted, At: command.At, Session: meeting})
+
+	return ActionSwitched, nil
+}
+
+// meetingEnded stops the meeting session, if any, and starts a new session
+// with the project and tags of the session paused by the meeting
+func (s UseCase) meetingEnded(command Command) (string, error) {
+	lastSession, err := s.sessionRepository.FindLastSession()
+	if err != nil {
+		return ActionNone, err
+	}
+	if lastSession == nil {
+		return ActionNone, nil
+	}
+
+	paused := lastSession
+	if pausedId, ok := lastSession.Metadata[ResumesMetadataKey]; ok {
+		if lastSession.Status() != session.FlowingStatus {
+			return ActionNone, nil
+		}
+
+		meeting := *lastSession
+		meeting.EndTime = command.At
+		if meeting.EndTime.Before(meeting.StartTime) {
+			meeting.EndTime = meeting.StartTime
+		}
+		if err := s.sessionRepository.Save(meeting); err != nil {
+			return ActionNone, err
+		}
+		if err := s.activeSessionLock.Release(meeting.Id); err != nil {
+			return ActionNone, err
+		}
+
+		s.eventPublisher.Publish(application.Event{Type: application.EventSessionStopped, At: command.At, Session: meeting})
+
+		paused, err = s.sessionRepository.FindById(pausedId)
+		if err != nil {
+			return ActionNone, err
+		}
+		if paused == nil {
+			return ActionNone, nil
+		}
+	} else if lastSession.Status() != session.EndedStatus || lastSession.Metadata[MetadataKey] != project.OnMeetingPause {
+		return ActionNone, nil
+	}
+
+	resumed := session.Session{
+		Id:        s.idProvider.Provide(),
+		StartTime: command.At,
+		Project:   paused.Project,
+		Tags:      paused.Tags,
+	}
+	if err := s.startSession(resumed); err != nil {
+		return ActionNone, err
+	}
+
+	s.eventPublisher.Publish(application.Event{Type: application.EventSessionResumed, At: command.At, Session: resumed})
+
+	return ActionResumed, nil
+}
+
+func (s UseCase) startSession(started session.Session) error {
+	_, err := s.activeSessionLock.Acquire(application.ActiveSession{SessionId: started.Id, AcquiredAt: started.StartTime})
+	if err == application.ErrActiveSessionLocked {
+		return ErrSessionAlreadyStarted
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := s.sessionRepository.Save(started); err != nil {
+		s.activeSessionLock.Release(started.Id)
+		return err
+	}
+
+	return nil
+}
+
+var ErrSessionAlreadyStarted = errors.New("there is already a session in progress")
+
+func NewMeetingPauseUseCase(
+	sessionRepository application.SessionRepository,
+	projectRepository application.ProjectRepository,
+	idProvider application.IDProvider,
+	activeSessionLock application.ActiveSessionLock,
+	eventPublisher application.EventPublisher,
+) UseCase {
+	return UseCase{
+		sessionRepository: sessionRepository,
+		projectRepository: projectRepository,
+		idProvider:        idProvider,
+		activeSessionLock: activeSessionLock,
+		eventPublisher:    eventPublisher,
+	}
+}
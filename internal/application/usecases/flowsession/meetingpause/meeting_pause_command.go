@@ -0,0 +1,11 @@
+package meetingpause
+
+import "time"
+
+// Command is sent when a meeting of the calendar starts or ends
+type Command struct {
+	At    time.Time
+	Title string
+	// Started is false once the meeting ended
+	Started bool
+}
@@ -0,0 +1,159 @@
+package meetingpause_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/meetingpause"
+	"github.com/TristanShz/flow/internal/domain/project"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/tests"
+)
+
+func TestMeetingPause(t *testing.T) {
+	startTime := time.Date(2024, time.April, 13, 9, 0, 0, 0, time.UTC)
+	meetingStart := time.Date(2024, time.April, 13, 10, 0, 0, 0, time.UTC)
+	meetingEnd := time.Date(2024, time.April, 13, 10, 30, 0, 0, time.UTC)
+
+	flowing := session.Session{Id: "1", StartTime: startTime, Project: "Flow", Tags: []string{"cli"}}
+	paused := flowing
+	paused.EndTime = meetingStart
+	paused.Metadata = map[string]string{meetingpause.MetadataKey: project.OnMeetingPause}
+	switched := flowing
+	switched.EndTime = meetingStart
+	switched.Metadata = map[string]string{meetingpause.MetadataKey: project.OnMeetingSwitch}
+	meeting := session.Session{
+		Id:        "2",
+		StartTime: meetingStart,
+		Project:   "standups",
+		Note:      "Daily standup",
+		Metadata:  map[string]string{meetingpause.ResumesMetadataKey: "1"},
+	}
+	endedMeeting := meeting
+	endedMeeting.EndTime = meetingEnd
+	resumed := session.Session{Id: "2", StartTime: meetingEnd, Project: "Flow", Tags: []string{"cli"}}
+	resumedAfterMeeting := resumed
+	resumedAfterMeeting.Id = "3"
+
+	tt := []struct {
+		error         error
+		name          string
+		wantAction    string
+		wantActive    string
+		givenActive   string
+		givenId       string
+		givenProjects []project.Project
+		givenSessions []session.Session
+		wantSessions  []session.Session
+		wantEvents    []string
+		command       meetingpause.Command
+	}{
+		{
+			name:          "Project without settings keeps flowing",
+			givenSessions: []session.Session{flowing},
+			givenActive:   "1",
+			command:       meetingpause.Command{Started: true, At: meetingStart, Title: "Daily standup"},
+			wantAction:    meetingpause.ActionNone,
+			wantSessions:  []session.Session{flowing},
+			wantActive:    "1",
+		},
+		{
+			name:          "Project pausing on meetings",
+			givenProjects: []project.Project{{Name: "Flow", OnMeeting: project.OnMeetingPause}},
+			givenSessions: []session.Session{flowing},
+			givenActive:   "1",
+			command:       meetingpause.Command{Started: true, At: meetingStart, Title: "Daily standup"},
+			wantAction:    meetingpause.ActionPaused,
+			wantSessions:  []session.Session{paused},
+			wantEvents:    []string{application.EventSessionPaused},
+		},
+		{
+			name:          "Project switching to meetings",
+			givenProjects: []project.Project{{Name: "Flow", OnMeeting: project.OnMeetingSwitch, MeetingProject: "standups"}},
+			givenSessions: []session.Session{flowing},
+			givenActive:   "1",
+			command:       meetingpause.Command{Started: true, At: meetingStart, Title: "Daily standup"},
+			wantAction:    meetingpause.ActionSwitched,
+			wantSessions:  []session.Session{switched, meeting},
+			wantActive:    "2",
+			wantEvents:    []string{application.EventSessionPaused, application.EventSessionStarted},
+		},
+		{
+			name:          "Meeting during a meeting",
+			givenProjects: []project.Project{{Name: "Flow", OnMeeting: project.OnMeetingSwitch, MeetingProject: "standups"}},
+			givenSessions: []session.Session{switched, meeting},
+			givenActive:   "2",
+			command:       meetingpause.Command{Started: true, At: meetingStart.Add(10 * time.Minute), Title: "Call"},
+			wantAction:    meetingpause.ActionNone,
+			wantSessions:  []session.Session{switched, meeting},
+			wantActive:    "2",
+		},
+		{
+			name:          "Meeting end resumes paused session",
+			givenProjects: []project.Project{{Name: "Flow", OnMeeting: project.OnMeetingPause}},
+			givenSessions: []session.Session{paused},
+			command:       meetingpause.Command{Started: false, At: meetingEnd},
+			wantAction:    meetingpause.ActionResumed,
+			wantSessions:  []session.Session{paused, resumed},
+			wantActive:    "2",
+			wantEvents:    []string{application.EventSessionResumed},
+		},
+		{
+			name:          "Meeting end stops the meeting session and resumes",
+			givenProjects: []project.Project{{Name: "Flow", OnMeeting: project.OnMeetingSwitch, MeetingProject: "standups"}},
+			givenSessions: []session.Session{switched, meeting},
+			givenActive:   "2",
+			givenId:       "3",
+			command:       meetingpause.Command{Started: false, At: meetingEnd},
+			wantAction:    meetingpause.ActionResumed,
+			wantSessions:  []session.Session{switched, endedMeeting, resumedAfterMeeting},
+			wantActive:    "3",
+			wantEvents:    []string{application.EventSessionStopped, application.EventSessionResumed},
+		},
+		{
+			name:          "Meeting end without paused session",
+			givenSessions: []session.Session{flowing},
+			givenActive:   "1",
+			command:       meetingpause.Command{Started: false, At: meetingEnd},
+			wantAction:    meetingpause.ActionNone,
+			wantSessions:  []session.Session{flowing},
+			wantActive:    "1",
+		},
+		{
+			name:          "Meeting end while another session is active",
+			givenProjects: []project.Project{{Name: "Flow", OnMeeting: project.OnMeetingPause}},
+			givenSessions: []session.Session{paused},
+			givenActive:   "3",
+			command:       meetingpause.Command{Started: false, At: meetingEnd},
+			wantAction:    meetingpause.ActionNone,
+			wantSessions:  []session.Session{paused},
+			wantActive:    "3",
+			error:         meetingpause.ErrSessionAlreadyStarted,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			f := tests.GetSessionFixture(t)
+
+			f.GivenSomeProjects(tc.givenProjects)
+			f.GivenSomeSessions(append([]session.Session{}, tc.givenSessions...))
+			f.GivenPredefinedIdentifier("2")
+			if tc.givenId != "" {
+				f.GivenPredefinedIdentifier(tc.givenId)
+			}
+			if tc.givenActive != "" {
+				f.GivenActiveSession(application.ActiveSession{SessionId: tc.givenActive})
+			}
+
+			f.WhenMeetingChanges(tc.command)
+
+			f.ThenErrorShouldBe(tc.error)
+			f.ThenMeetingActionShouldBe(tc.wantAction)
+			f.ThenSessionsShouldBe(tc.wantSessions)
+			f.ThenActiveSessionShouldBe(tc.wantActive)
+			f.ThenPublishedEventsShouldBe(tc.wantEvents)
+		})
+	}
+}
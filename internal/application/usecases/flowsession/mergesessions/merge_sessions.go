@@ -0,0 +1,130 @@
+package mergesessions
+
+import (
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+type UseCase struct {
+	sessionRepository application.SessionRepository
+}
+
+// Execute merges adjacent sessions of the same project into the first one,
+// the other sessions are deleted
+func (s UseCase) Execute(command Command) (session.Session, error) {
+	if len(command.Ids) < 2 {
+		return session.Session{}, ErrNotEnoughSessions
+	}
+
+	sessions := []session.Session{}
+	for _, id := range command.Ids {
+		found, err := s.sessionRepository.FindById(id)
+		if err != nil {
+			return session.Session{}, err
+		}
+		if found == nil {
+			return session.Session{}, ErrSessionNotFound
+		}
+
+		if found.Status() == session.FlowingStatus {
+			return session.Session{}, ErrSessionFlowing
+		}
+
+		if len(sessions) > 0 && found.Project != sessions[0].Project {
+			return session.Session{}, ErrDifferentProjects
+		}
+
+		sessions = append(sessions, *found)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].StartTime.Before(sessions[j].StartTime)
+	})
+
+	if !s.areAdjacent(sessions) {
+		return session.Session{}, ErrNotAdjacent
+	}
+
+	if err := session.CheckLocked(command.LockedBefore, sessions...); err != nil {
+		return session.Session{}, err
+	}
+
+	merged := sessions[0]
+	merged.Tags = slices.Clone(merged.Tags)
+	notes := []string{}
+
+	for _, other := range sessions {
+		if other.EndTime.After(merged.EndTime) {
+			merged.EndTime = other.EndTime
+		}
+
+		for _, tag := range other.Tags {
+			if !merged.HasTag(tag) {
+				merged.Tags = append(merged.Tags, tag)
+			}
+		}
+
+		if other.Note != "" {
+			notes = append(notes, other.Note)
+		}
+
+		for key, value := range other.Metadata {
+			if merged.Metadata == nil {
+				merged.Metadata = map[string]string{}
+			}
+			if _, ok := merged.Metadata[key]; !ok {
+				merged.Metadata[key] = value
+			}
+		}
+	}
+	merged.Note = strings.Join(notes, "\n")
+
+	if err := s.sessionRepository.Save(merged); err != nil {
+		return session.Session{}, err
+	}
+
+	for _, other := range sessions[1:] {
+		if err := s.sessionRepository.Delete(other.Id); err != nil {
+			return session.Session{}, err
+		}
+	}
+
+	return merged, nil
+}
+
+// areAdjacent tells if no other session starts between the sorted sessions
+func (s UseCase) areAdjacent(sessions []session.Session) bool {
+	first := sessions[0]
+	last := sessions[len(sessions)-1]
+
+	for _, other := range s.sessionRepository.FindAllSessions(nil) {
+		if slices.ContainsFunc(sessions, other.Equals) {
+			continue
+		}
+
+		if other.StartTime.After(first.StartTime) && other.StartTime.Before(last.StartTime) {
+			return false
+		}
+	}
+
+	return true
+}
+
+var (
+	ErrNotEnoughSessions = errors.New("at least two sessions are needed to merge")
+	ErrSessionNotFound   = errors.New("session not found")
+	ErrSessionFlowing    = errors.New("the session is still flowing, stop it before merging it")
+	ErrDifferentProjects = errors.New("only sessions of the same project can be merged")
+	ErrNotAdjacent       = errors.New("only adjacent sessions can be merged")
+)
+
+func NewMergeSessionsUseCase(sessionRepository application.SessionRepository) UseCase {
+	return UseCase{
+		sessionRepository: sessionRepository,
+	}
+}
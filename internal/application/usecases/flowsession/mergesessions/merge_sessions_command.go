@@ -0,0 +1,10 @@
+package mergesessions
+
+import "time"
+
+type Command struct {
+	Ids []string
+	// LockedBefore is the start of the first month whose sessions can be
+	// changed, see session.IsLocked
+	LockedBefore time.Time
+}
@@ -0,0 +1,107 @@
+package mergesessions_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/mergesessions"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/tests"
+)
+
+func TestMergeSessions(t *testing.T) {
+	morning := session.Session{
+		Id:        "1",
+		StartTime: time.Date(2024, time.April, 13, 9, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, time.April, 13, 10, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+		Tags:      []string{"cli"},
+		Note:      "Started the cli",
+	}
+	beforeLunch := session.Session{
+		Id:        "2",
+		StartTime: time.Date(2024, time.April, 13, 10, 15, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, time.April, 13, 12, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+		Tags:      []string{"cli", "docs"},
+		Note:      "Wrote the docs",
+	}
+	afternoon := session.Session{
+		Id:        "3",
+		StartTime: time.Date(2024, time.April, 13, 14, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, time.April, 13, 15, 0, 0, 0, time.UTC),
+		Project:   "MyTodo",
+	}
+	evening := session.Session{
+		Id:        "4",
+		StartTime: time.Date(2024, time.April, 13, 18, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, time.April, 13, 19, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+	}
+
+	tt := []struct {
+		error         error
+		name          string
+		givenSessions []session.Session
+		command       mergesessions.Command
+		want          []session.Session
+	}{
+		{
+			name:          "Adjacent sessions",
+			givenSessions: []session.Session{morning, beforeLunch, afternoon},
+			command:       mergesessions.Command{Ids: []string{"2", "1"}},
+			want: []session.Session{
+				{
+					Id:        "1",
+					StartTime: morning.StartTime,
+					EndTime:   beforeLunch.EndTime,
+					Project:   "Flow",
+					Tags:      []string{"cli", "docs"},
+					Note:      "Started the cli\nWrote the docs",
+				},
+				afternoon,
+			},
+		},
+		{
+			name:          "Session in between",
+			givenSessions: []session.Session{morning, afternoon, evening},
+			command:       mergesessions.Command{Ids: []string{"1", "4"}},
+			want:          []session.Session{morning, afternoon, evening},
+			error:         mergesessions.ErrNotAdjacent,
+		},
+		{
+			name:          "Different projects",
+			givenSessions: []session.Session{beforeLunch, afternoon},
+			command:       mergesessions.Command{Ids: []string{"2", "3"}},
+			want:          []session.Session{beforeLunch, afternoon},
+			error:         mergesessions.ErrDifferentProjects,
+		},
+		{
+			name:          "Single session",
+			givenSessions: []session.Session{morning},
+			command:       mergesessions.Command{Ids: []string{"1"}},
+			want:          []session.Session{morning},
+			error:         mergesessions.ErrNotEnoughSessions,
+		},
+		{
+			name:          "Session not found",
+			givenSessions: []session.Session{morning},
+			command:       mergesessions.Command{Ids: []string{"1", "5"}},
+			want:          []session.Session{morning},
+			error:         mergesessions.ErrSessionNotFound,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			f := tests.GetSessionFixture(t)
+
+			f.GivenSomeSessions(append([]session.Session{}, tc.givenSessions...))
+
+			f.WhenMergingSessions(tc.command)
+
+			f.ThenErrorShouldBe(tc.error)
+			f.ThenSessionsShouldBe(tc.want)
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package pomodoro
+
+import (
+	"errors"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	startsession "github.com/TristanShz/flow/internal/application/usecases/flowsession/start"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/stopsession"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/pkg/timerange"
+)
+
+const (
+	IntervalWork  = "work"
+	IntervalBreak = "break"
+)
+
+// Interval is the current interval of the pomodoro mode
+type Interval struct {
+	Ends time.Time
+	Kind string
+	// Number is the number of the pomodoro of the day, the one being worked
+	// or the one before the break
+	Number int
+	// LongBreak tells if the break is a long one
+	LongBreak bool
+}
+
+// UseCase drives the pomodoro mode: the work intervals are sessions, started
+// and stopped like with 'flow start' and 'flow stop', and the breaks are the
+// time between them
+type UseCase struct {
+	sessionRepository application.SessionRepository
+	dateProvider      application.DateProvider
+	startSession      startsession.UseCase
+	stopSession       stopsession.UseCase
+}
+
+// Execute moves the pomodoro mode forward and returns the current interval.
+// Without a flowing session, it starts a work interval. The flowing work
+// interval is stopped once its length elapsed, and the break following it is
+// returned.
+func (s UseCase) Execute(command Command) (Interval, error) {
+	now := s.dateProvider.GetNow()
+	lastSession, err := s.sessionRepository.FindLastSession()
+	if err != nil {
+		return Interval{}, err
+	}
+
+	if lastSession != nil && lastSession.Status() == session.FlowingStatus {
+		length, ok := lastSession.PomodoroLength()
+		if !ok {
+			return Interval{}, ErrSessionAlreadyStarted
+		}
+
+		work := Interval{
+			Kind:   IntervalWork,
+			Number: s.pomodorosOfDay(now) + 1,
+			Ends:   lastSession.StartTime.Add(length),
+		}
+		if now.Before(work.Ends) {
+			return work, nil
+		}
+
+		if _, err := s.stopSession.Execute(stopsession.Command{TagRules: command.TagRules}); err != nil && err != stopsession.ErrClockWentBackwards {
+			return Interval{}, err
+		}
+
+		breakLength, long := command.Settings.BreakAfter(work.Number)
+
+		return Interval{
+			Kind:      IntervalBreak,
+			Number:    work.Number,
+			Ends:      now.Add(breakLength),
+			LongBreak: long,
+		}, nil
+	}
+
+	metadata := map[string]string{session.PomodoroMetadata: command.Settings.WorkLength().String()}
+	// the break is the time since the previous pomodoro of the day
+	if lastSession != nil && lastSession.IsCompletedPomodoro() && lastSession.EndTime.Before(now) && timerange.NewDayTimeRange(now).Contains(lastSession.EndTime) {
+		metadata[session.BreakMetadata] = now.Sub(lastSession.EndTime).Round(time.Second).String()
+	}
+
+	err = s.startSession.Execute(startsession.Command{
+		Project:  command.Project,
+		Tags:     command.Tags,
+		Metadata: metadata,
+		TagRules: command.TagRules,
+	})
+	if err != nil {
+		return Interval{}, err
+	}
+
+	started, err := s.sessionRepository.FindLastSession()
+	if err != nil {
+		return Interval{}, err
+	}
+
+	return Interval{
+		Kind:   IntervalWork,
+		Number: s.pomodorosOfDay(now) + 1,
+		Ends:   started.StartTime.Add(command.Settings.WorkLength()),
+	}, nil
+}
+
+// pomodorosOfDay counts the pomodoros completed on the day
+func (s UseCase) pomodorosOfDay(day time.Time) int {
+	return session.CountPomodoros(s.sessionRepository.FindAllSessions(&application.SessionsFilters{
+		Timerange: timerange.NewDayTimeRange(day),
+	}))
+}
+
+var ErrSessionAlreadyStarted = errors.New("a session out of the pomodoro mode is in progress, stop it with 'flow stop' first")
+
+func NewPomodoroUseCase(
+	sessionRepository application.SessionRepository,
+	dateProvider application.DateProvider,
+	startSession startsession.UseCase,
+	stopSession stopsession.UseCase,
+) UseCase {
+	return UseCase{
+		sessionRepository: sessionRepository,
+		dateProvider:      dateProvider,
+		startSession:      startSession,
+		stopSession:       stopSession,
+	}
+}
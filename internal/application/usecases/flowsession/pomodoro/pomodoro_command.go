@@ -0,0 +1,16 @@
+package pomodoro
+
+import (
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+type Command struct {
+	// Project and Tags are the ones of the work intervals
+	Project string
+	Tags    []string
+	// Settings are the lengths of the intervals
+	Settings application.Pomodoro
+	// TagRules add their tags to the work intervals when they start and stop
+	TagRules []session.TagRule
+}
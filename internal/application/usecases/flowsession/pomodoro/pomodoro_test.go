@@ -0,0 +1,115 @@
+package pomodoro_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/pomodoro"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/tests"
+)
+
+func TestPomodoro(t *testing.T) {
+	at := func(hour int, minute int) time.Time {
+		return time.Date(2024, time.April, 13, hour, minute, 0, 0, time.UTC)
+	}
+	work := map[string]string{session.PomodoroMetadata: "25m0s"}
+	completed := func(id string, start time.Time) session.Session {
+		return session.Session{Id: id, StartTime: start, EndTime: start.Add(25 * time.Minute), Project: "Flow", Metadata: work}
+	}
+	command := pomodoro.Command{Project: "Flow"}
+
+	tt := []struct {
+		error         error
+		name          string
+		now           time.Time
+		givenSessions []session.Session
+		command       pomodoro.Command
+		wantInterval  pomodoro.Interval
+		wantLast      session.Session
+		wantActive    string
+	}{
+		{
+			name:         "First pomodoro",
+			now:          at(9, 0),
+			command:      command,
+			wantInterval: pomodoro.Interval{Kind: pomodoro.IntervalWork, Number: 1, Ends: at(9, 25)},
+			wantLast:     session.Session{Id: "new", StartTime: at(9, 0), Project: "Flow", Metadata: work},
+			wantActive:   "new",
+		},
+		{
+			name:          "Work interval in progress",
+			now:           at(9, 10),
+			givenSessions: []session.Session{{Id: "1", StartTime: at(9, 0), Project: "Flow", Metadata: work}},
+			command:       command,
+			wantInterval:  pomodoro.Interval{Kind: pomodoro.IntervalWork, Number: 1, Ends: at(9, 25)},
+			wantLast:      session.Session{Id: "1", StartTime: at(9, 0), Project: "Flow", Metadata: work},
+			wantActive:    "1",
+		},
+		{
+			name:          "End of a work interval",
+			now:           at(9, 25),
+			givenSessions: []session.Session{{Id: "1", StartTime: at(9, 0), Project: "Flow", Metadata: work}},
+			command:       command,
+			wantInterval:  pomodoro.Interval{Kind: pomodoro.IntervalBreak, Number: 1, Ends: at(9, 30)},
+			wantLast:      completed("1", at(9, 0)),
+		},
+		{
+			name: "Long break",
+			now:  at(11, 10),
+			givenSessions: []session.Session{
+				completed("1", at(9, 0)),
+				completed("2", at(9, 30)),
+				completed("3", at(10, 0)),
+				{Id: "4", StartTime: at(10, 45), Project: "Flow", Metadata: work},
+			},
+			command:      command,
+			wantInterval: pomodoro.Interval{Kind: pomodoro.IntervalBreak, Number: 4, Ends: at(11, 25), LongBreak: true},
+			wantLast:     completed("4", at(10, 45)),
+		},
+		{
+			name:          "Pomodoro after a break",
+			now:           at(9, 31),
+			givenSessions: []session.Session{completed("1", at(9, 0))},
+			command:       pomodoro.Command{Project: "Flow", Settings: application.Pomodoro{Work: 50 * time.Minute}},
+			wantInterval:  pomodoro.Interval{Kind: pomodoro.IntervalWork, Number: 2, Ends: at(10, 21)},
+			wantLast: session.Session{
+				Id:        "new",
+				StartTime: at(9, 31),
+				Project:   "Flow",
+				Metadata:  map[string]string{session.PomodoroMetadata: "50m0s", session.BreakMetadata: "6m0s"},
+			},
+			wantActive: "new",
+		},
+		{
+			name:          "Session out of the pomodoro mode",
+			now:           at(9, 10),
+			givenSessions: []session.Session{{Id: "1", StartTime: at(9, 0), Project: "Flow"}},
+			command:       command,
+			error:         pomodoro.ErrSessionAlreadyStarted,
+			wantLast:      session.Session{Id: "1", StartTime: at(9, 0), Project: "Flow"},
+			wantActive:    "1",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			f := tests.GetSessionFixture(t)
+
+			f.GivenNowIs(tc.now)
+			f.GivenPredefinedIdentifier("new")
+			f.GivenSomeSessions(tc.givenSessions)
+			if len(tc.givenSessions) > 0 && tc.givenSessions[len(tc.givenSessions)-1].EndTime.IsZero() {
+				f.GivenActiveSession(application.ActiveSession{SessionId: tc.givenSessions[len(tc.givenSessions)-1].Id})
+			}
+
+			f.WhenRunningPomodoro(tc.command)
+
+			f.ThenErrorShouldBe(tc.error)
+			f.ThenPomodoroIntervalShouldBe(tc.wantInterval)
+			f.ThenLastSessionShouldBe(tc.wantLast)
+			f.ThenActiveSessionShouldBe(tc.wantActive)
+		})
+	}
+}
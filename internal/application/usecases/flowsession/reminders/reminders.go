@@ -0,0 +1,171 @@
+package reminders
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/domain/sessionsreport"
+	"github.com/TristanShz/flow/pkg/timerange"
+)
+
+const (
+	KindLongSession = "long_session"
+	KindNoSession   = "no_session"
+	KindUntracked   = "untracked"
+)
+
+// untrackedLookbackDays bounds the working time counted as untracked, from
+// the start of the day a week ago, when the last session is older
+const untrackedLookbackDays = 7
+
+// Reminder is due until it's shown, its Key is the same for as long as the
+// situation it reminds of lasts, so that it's shown once
+type Reminder struct {
+	Key          string
+	Kind         string
+	Notification application.Notification
+	// Event is published along with the notification, nil when the reminder
+	// is only shown
+	Event *application.Event
+}
+
+type UseCase struct {
+	sessionRepository application.SessionRepository
+	dateProvider      application.DateProvider
+}
+
+// Execute returns the reminders due now: the current session flowing for
+// longer than the long session setting, or no session flowing for a while
+// during the work hours, and the working time gone untracked
+func (u UseCase) Execute(command Command) []Reminder {
+	now := u.dateProvider.GetNow()
+	settings := command.Settings
+	lastSession, err := u.sessionRepository.FindLastSession()
+	if err != nil {
+		// nothing is reminded until the sessions can be read again
+		return nil
+	}
+
+	if lastSession != nil && lastSession.Status() == session.FlowingStatus {
+		flowing := now.Sub(lastSession.StartTime)
+		if settings.LongSession <= 0 || flowing < settings.LongSession {
+			return nil
+		}
+
+		return []Reminder{{
+			Key:  fmt.Sprintf("%v:%v:%v", KindLongSession, lastSession.Id, lastSession.StartTime.Unix()),
+			Kind: KindLongSession,
+			Notification: application.Notification{
+				Title:   fmt.Sprintf("Session flowing for %v", flowing.Truncate(time.Minute)),
+				Message: fmt.Sprintf("%v since %v, stop it with 'flow stop' if it's over", lastSession.Project, lastSession.StartTime.Format(time.Kitchen)),
+			},
+		}}
+	}
+
+	if settings.WorkHours == nil {
+		return nil
+	}
+
+	var due []Reminder
+	if reminder, ok := noSessionReminder(settings, lastSession, now); ok {
+		due = append(due, reminder)
+	}
+	if reminder, ok := untrackedReminder(settings, lastSession, now); ok {
+		due = append(due, reminder)
+	}
+
+	return due
+}
+
+// noSessionReminder reminds that no session flowed for a while during the
+// work hours
+func noSessionReminder(settings application.Notifications, lastSession *session.Session, now time.Time) (Reminder, bool) {
+	if !settings.WorkHours.Matches(now) {
+		return Reminder{}, false
+	}
+
+	idleSince := workHoursStart(*settings.WorkHours, now)
+	if lastSession != nil && lastSession.EndTime.After(idleSince) {
+		idleSince = lastSession.EndTime
+	}
+
+	noSessionAfter := settings.NoSessionAfter
+	if noSessionAfter <= 0 {
+		noSessionAfter = application.DefaultNoSessionAfter
+	}
+
+	if now.Sub(idleSince) < noSessionAfter {
+		return Reminder{}, false
+	}
+
+	return Reminder{
+		Key:  fmt.Sprintf("%v:%v", KindNoSession, idleSince.Unix()),
+		Kind: KindNoSession,
+		Notification: application.Notification{
+			Title:   fmt.Sprintf("No session flowing since %v", idleSince.Format(time.Kitchen)),
+			Message: "Start one with 'flow start' if you're working",
+		},
+	}, true
+}
+
+// untrackedReminder alerts that the working time of the work hours since the
+// last session reached the untracked after setting, like a whole morning
+// forgotten. Unlike the no session reminder, it counts the working time of the
+// previous days too, and is published as an event for the webhooks.
+func untrackedReminder(settings application.Notifications, lastSession *session.Session, now time.Time) (Reminder, bool) {
+	if settings.UntrackedAfter <= 0 {
+		return Reminder{}, false
+	}
+
+	since := timerange.StartOf(now, timerange.ByDay, time.Monday).AddDate(0, 0, -untrackedLookbackDays)
+	last := session.Session{}
+	if lastSession != nil {
+		last = *lastSession
+		if last.EndTime.After(since) {
+			since = last.EndTime
+		}
+	}
+
+	untracked := sessionsreport.NewGapsReport(nil, timerange.TimeRange{Since: since, Until: now}, *settings.WorkHours, 0, now).WorkingTime
+	if untracked < settings.UntrackedAfter {
+		return Reminder{}, false
+	}
+
+	return Reminder{
+		Key:  fmt.Sprintf("%v:%v", KindUntracked, since.Unix()),
+		Kind: KindUntracked,
+		Notification: application.Notification{
+			Title:   fmt.Sprintf("%v of working hours untracked", untracked.Truncate(time.Minute)),
+			Message: fmt.Sprintf("No session since %v, backfill it with 'flow log add'", since.Format("Mon 3:04PM")),
+		},
+		Event: &application.Event{
+			Type:      application.EventWorkingHoursUntracked,
+			At:        now,
+			Session:   last,
+			Untracked: untracked,
+		},
+	}, true
+}
+
+// workHoursStart returns when the work hours matching now started, the work
+// hours of a rule ending after midnight started the day before
+func workHoursStart(rule session.TagRule, now time.Time) time.Time {
+	start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).Add(rule.After)
+	if start.After(now) {
+		start = start.AddDate(0, 0, -1)
+	}
+
+	return start
+}
+
+func NewRemindersUseCase(
+	sessionRepository application.SessionRepository,
+	dateProvider application.DateProvider,
+) UseCase {
+	return UseCase{
+		sessionRepository: sessionRepository,
+		dateProvider:      dateProvider,
+	}
+}
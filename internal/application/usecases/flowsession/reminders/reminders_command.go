@@ -0,0 +1,9 @@
+package reminders
+
+import "github.com/TristanShz/flow/internal/application"
+
+// Command asks for the reminders due now with the settings of the
+// notifications
+type Command struct {
+	Settings application.Notifications
+}
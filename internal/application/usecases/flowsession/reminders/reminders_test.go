@@ -0,0 +1,171 @@
+package reminders_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/reminders"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/tests"
+)
+
+func TestReminders(t *testing.T) {
+	monday := func(hour int, minute int) time.Time {
+		return time.Date(2024, time.April, 15, hour, minute, 0, 0, time.UTC)
+	}
+
+	workHours, err := session.ParseTagRule("", "mon-fri after 09:00 before 18:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	settings := application.Notifications{LongSession: 3 * time.Hour, WorkHours: &workHours}
+
+	flowing := session.Session{Id: "1", StartTime: monday(9, 0), Project: "Flow"}
+	stopped := flowing
+	stopped.EndTime = monday(12, 0)
+	friday := session.Session{Id: "1", StartTime: monday(9, 0).AddDate(0, 0, -3), EndTime: monday(17, 0).AddDate(0, 0, -3), Project: "Flow"}
+
+	tt := []struct {
+		now           time.Time
+		name          string
+		settings      application.Notifications
+		givenSessions []session.Session
+		want          []reminders.Reminder
+	}{
+		{
+			name:          "Session flowing for longer than the long session",
+			now:           monday(12, 10),
+			settings:      settings,
+			givenSessions: []session.Session{flowing},
+			want: []reminders.Reminder{{
+				Key:  "long_session:1:1713171600",
+				Kind: reminders.KindLongSession,
+				Notification: application.Notification{
+					Title:   "Session flowing for 3h10m0s",
+					Message: "Flow since 9:00AM, stop it with 'flow stop' if it's over",
+				},
+			}},
+		},
+		{
+			name:          "Session flowing for less than the long session",
+			now:           monday(11, 0),
+			settings:      settings,
+			givenSessions: []session.Session{flowing},
+		},
+		{
+			name:          "Long sessions not reminded",
+			now:           monday(17, 0),
+			settings:      application.Notifications{WorkHours: &workHours},
+			givenSessions: []session.Session{flowing},
+		},
+		{
+			name:          "No session since the last one stopped",
+			now:           monday(12, 30),
+			settings:      settings,
+			givenSessions: []session.Session{stopped},
+			want: []reminders.Reminder{{
+				Key:  "no_session:1713182400",
+				Kind: reminders.KindNoSession,
+				Notification: application.Notification{
+					Title:   "No session flowing since 12:00PM",
+					Message: "Start one with 'flow start' if you're working",
+				},
+			}},
+		},
+		{
+			name:          "Session stopped a moment ago",
+			now:           monday(12, 10),
+			settings:      settings,
+			givenSessions: []session.Session{stopped},
+		},
+		{
+			name:          "No session since the start of the work hours",
+			now:           monday(9, 20),
+			settings:      application.Notifications{WorkHours: &workHours, NoSessionAfter: 20 * time.Minute},
+			givenSessions: []session.Session{friday},
+			want: []reminders.Reminder{{
+				Key:  "no_session:1713171600",
+				Kind: reminders.KindNoSession,
+				Notification: application.Notification{
+					Title:   "No session flowing since 9:00AM",
+					Message: "Start one with 'flow start' if you're working",
+				},
+			}},
+		},
+		{
+			name:          "Working hours untracked since the last session",
+			now:           monday(12, 30),
+			settings:      application.Notifications{WorkHours: &workHours, UntrackedAfter: 3 * time.Hour},
+			givenSessions: []session.Session{friday},
+			want: []reminders.Reminder{
+				{
+					Key:  "no_session:1713171600",
+					Kind: reminders.KindNoSession,
+					Notification: application.Notification{
+						Title:   "No session flowing since 9:00AM",
+						Message: "Start one with 'flow start' if you're working",
+					},
+				},
+				{
+					Key:  "untracked:1712941200",
+					Kind: reminders.KindUntracked,
+					Notification: application.Notification{
+						Title:   "4h30m0s of working hours untracked",
+						Message: "No session since Fri 5:00PM, backfill it with 'flow log add'",
+					},
+					Event: &application.Event{
+						Type:      application.EventWorkingHoursUntracked,
+						At:        monday(12, 30),
+						Session:   friday,
+						Untracked: 4*time.Hour + 30*time.Minute,
+					},
+				},
+			},
+		},
+		{
+			name:          "Less working time untracked than the setting",
+			now:           monday(9, 10),
+			settings:      application.Notifications{WorkHours: &workHours, UntrackedAfter: 3 * time.Hour},
+			givenSessions: []session.Session{friday},
+		},
+		{
+			name:          "Working hours untracked alerted out of the work hours",
+			now:           time.Date(2024, time.April, 13, 14, 0, 0, 0, time.UTC),
+			settings:      application.Notifications{WorkHours: &workHours, UntrackedAfter: time.Hour},
+			givenSessions: []session.Session{friday},
+			want: []reminders.Reminder{{
+				Key:  "untracked:1712941200",
+				Kind: reminders.KindUntracked,
+				Notification: application.Notification{
+					Title:   "1h0m0s of working hours untracked",
+					Message: "No session since Fri 5:00PM, backfill it with 'flow log add'",
+				},
+				Event: &application.Event{
+					Type:      application.EventWorkingHoursUntracked,
+					At:        time.Date(2024, time.April, 13, 14, 0, 0, 0, time.UTC),
+					Session:   friday,
+					Untracked: time.Hour,
+				},
+			}},
+		},
+		{
+			name:     "Out of the work hours",
+			now:      time.Date(2024, time.April, 13, 14, 0, 0, 0, time.UTC),
+			settings: settings,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			f := tests.GetSessionFixture(t)
+
+			f.GivenNowIs(tc.now)
+			f.GivenSomeSessions(tc.givenSessions)
+
+			f.WhenCheckingReminders(reminders.Command{Settings: tc.settings})
+
+			f.ThenRemindersShouldBe(tc.want)
+		})
+	}
+}
@@ -19,7 +19,10 @@ type UseCase struct {
 }
 
 func (s *UseCase) Execute() (SessionStatus, error) {
-	lastSession := s.sessionRepository.FindLastSession()
+	lastSession, err := s.sessionRepository.FindLastSession()
+	if err != nil {
+		return SessionStatus{}, err
+	}
 
 	if lastSession == nil || lastSession.Status() != session.FlowingStatus {
 		return SessionStatus{}, ErrNoCurrentSession
@@ -0,0 +1,49 @@
+package showsession
+
+import (
+	"errors"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+type SessionDetails struct {
+	Session session.Session
+	// Chain holds the session and the sessions linked to it, it only holds
+	// the session when it has no links
+	Chain session.Chain
+}
+
+type UseCase struct {
+	sessionRepository application.SessionRepository
+}
+
+func (s UseCase) Execute(command Command) (SessionDetails, error) {
+	var shown *session.Session
+	var err error
+	if command.SessionId == "" {
+		shown, err = s.sessionRepository.FindLastSession()
+	} else {
+		shown, err = s.sessionRepository.FindById(command.SessionId)
+	}
+	if err != nil {
+		return SessionDetails{}, err
+	}
+
+	if shown == nil {
+		return SessionDetails{}, ErrSessionNotFound
+	}
+
+	return SessionDetails{
+		Session: *shown,
+		Chain:   session.FindChain(s.sessionRepository.FindAllSessions(nil), shown.Id),
+	}, nil
+}
+
+var ErrSessionNotFound = errors.New("session not found")
+
+func NewShowSessionUseCase(sessionRepository application.SessionRepository) UseCase {
+	return UseCase{
+		sessionRepository: sessionRepository,
+	}
+}
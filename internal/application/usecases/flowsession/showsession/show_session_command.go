@@ -0,0 +1,6 @@
+package showsession
+
+type Command struct {
+	// SessionId is the session to show, the last session when empty
+	SessionId string
+}
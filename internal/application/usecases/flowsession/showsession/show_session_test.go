@@ -0,0 +1,74 @@
+package showsession_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/showsession"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/tests"
+)
+
+func TestShowSession(t *testing.T) {
+	first := session.Session{
+		Id:        "1",
+		StartTime: time.Date(2024, time.April, 15, 9, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, time.April, 15, 11, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+		BlockedBy: "Waiting for the review",
+	}
+	unrelated := session.Session{
+		Id:        "2",
+		StartTime: time.Date(2024, time.April, 15, 14, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, time.April, 15, 15, 0, 0, 0, time.UTC),
+		Project:   "MyTodo",
+	}
+	second := session.Session{
+		Id:        "3",
+		StartTime: time.Date(2024, time.April, 16, 9, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, time.April, 16, 10, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+		Continues: "1",
+	}
+
+	tt := []struct {
+		error   error
+		name    string
+		command showsession.Command
+		want    showsession.SessionDetails
+	}{
+		{
+			name:    "Session of a chain",
+			command: showsession.Command{SessionId: "1"},
+			want:    showsession.SessionDetails{Session: first, Chain: session.Chain{first, second}},
+		},
+		{
+			name:    "Last session",
+			command: showsession.Command{},
+			want:    showsession.SessionDetails{Session: second, Chain: session.Chain{first, second}},
+		},
+		{
+			name:    "Session without links",
+			command: showsession.Command{SessionId: "2"},
+			want:    showsession.SessionDetails{Session: unrelated, Chain: session.Chain{unrelated}},
+		},
+		{
+			name:    "Session not found",
+			command: showsession.Command{SessionId: "4"},
+			error:   showsession.ErrSessionNotFound,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			f := tests.GetSessionFixture(t)
+
+			f.GivenSomeSessions([]session.Session{first, unrelated, second})
+
+			f.WhenShowingSession(tc.command)
+
+			f.ThenErrorShouldBe(tc.error)
+			f.ThenSessionDetailsShouldBe(tc.want)
+		})
+	}
+}
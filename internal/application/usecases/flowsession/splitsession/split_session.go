@@ -0,0 +1,81 @@
+package splitsession
+
+import (
+	"errors"
+	"maps"
+	"slices"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+type UseCase struct {
+	sessionRepository application.SessionRepository
+	idProvider        application.IDProvider
+}
+
+// Execute splits the session in two at the given time, the first part keeps
+// the id of the session
+func (s UseCase) Execute(command Command) ([2]session.Session, error) {
+	existingSession, err := s.sessionRepository.FindById(command.Id)
+	if err != nil {
+		return [2]session.Session{}, err
+	}
+	if existingSession == nil {
+		return [2]session.Session{}, ErrSessionNotFound
+	}
+
+	if existingSession.Status() == session.FlowingStatus {
+		return [2]session.Session{}, ErrSessionFlowing
+	}
+
+	if err := session.CheckLocked(command.LockedBefore, *existingSession); err != nil {
+		return [2]session.Session{}, err
+	}
+
+	if !command.At.After(existingSession.StartTime) || !command.At.Before(existingSession.EndTime) {
+		return [2]session.Session{}, ErrSplitOutsideSession
+	}
+
+	first := *existingSession
+	first.EndTime = command.At
+
+	second := *existingSession
+	second.Id = s.idProvider.Provide()
+	second.StartTime = command.At
+	second.Tags = slices.Clone(existingSession.Tags)
+	second.Metadata = maps.Clone(existingSession.Metadata)
+
+	if command.FirstNote != nil {
+		first.Note = *command.FirstNote
+	}
+	if command.SecondNote != nil {
+		second.Note = *command.SecondNote
+	}
+
+	if err := s.sessionRepository.Save(first); err != nil {
+		return [2]session.Session{}, err
+	}
+
+	if err := s.sessionRepository.Save(second); err != nil {
+		return [2]session.Session{}, err
+	}
+
+	return [2]session.Session{first, second}, nil
+}
+
+var (
+	ErrSessionNotFound     = errors.New("session not found")
+	ErrSessionFlowing      = errors.New("the session is still flowing, stop it before splitting it")
+	ErrSplitOutsideSession = errors.New("the split time must be between the start and the end of the session")
+)
+
+func NewSplitSessionUseCase(
+	sessionRepository application.SessionRepository,
+	idProvider application.IDProvider,
+) UseCase {
+	return UseCase{
+		sessionRepository: sessionRepository,
+		idProvider:        idProvider,
+	}
+}
@@ -0,0 +1,15 @@
+package splitsession
+
+import "time"
+
+type Command struct {
+	At time.Time
+	// FirstNote and SecondNote are the notes of each part of the session,
+	// both keep the note of the session when nil
+	FirstNote  *string
+	SecondNote *string
+	Id         string
+	// LockedBefore is the start of the first month whose sessions can be
+	// changed, see session.IsLocked
+	LockedBefore time.Time
+}
@@ -0,0 +1,124 @@
+package splitsession_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/splitsession"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/tests"
+)
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestSplitSession(t *testing.T) {
+	overnight := session.Session{
+		Id:        "1",
+		StartTime: time.Date(2024, time.April, 12, 17, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, time.April, 13, 9, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+		Tags:      []string{"cli"},
+		Note:      "Worked on the cli",
+	}
+	flowing := session.Session{
+		Id:        "1",
+		StartTime: time.Date(2024, time.April, 13, 17, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+	}
+
+	tt := []struct {
+		error         error
+		name          string
+		givenSessions []session.Session
+		command       splitsession.Command
+		want          []session.Session
+	}{
+		{
+			name:          "Split keeping the note",
+			givenSessions: []session.Session{overnight},
+			command:       splitsession.Command{Id: "1", At: time.Date(2024, time.April, 12, 19, 0, 0, 0, time.UTC)},
+			want: []session.Session{
+				{
+					Id:        "1",
+					StartTime: overnight.StartTime,
+					EndTime:   time.Date(2024, time.April, 12, 19, 0, 0, 0, time.UTC),
+					Project:   "Flow",
+					Tags:      []string{"cli"},
+					Note:      "Worked on the cli",
+				},
+				{
+					Id:        "id-2",
+					StartTime: time.Date(2024, time.April, 12, 19, 0, 0, 0, time.UTC),
+					EndTime:   overnight.EndTime,
+					Project:   "Flow",
+					Tags:      []string{"cli"},
+					Note:      "Worked on the cli",
+				},
+			},
+		},
+		{
+			name:          "Split dividing the note",
+			givenSessions: []session.Session{overnight},
+			command: splitsession.Command{
+				Id:         "1",
+				At:         time.Date(2024, time.April, 12, 19, 0, 0, 0, time.UTC),
+				FirstNote:  stringPtr("Worked on the cli"),
+				SecondNote: stringPtr(""),
+			},
+			want: []session.Session{
+				{
+					Id:        "1",
+					StartTime: overnight.StartTime,
+					EndTime:   time.Date(2024, time.April, 12, 19, 0, 0, 0, time.UTC),
+					Project:   "Flow",
+					Tags:      []string{"cli"},
+					Note:      "Worked on the cli",
+				},
+				{
+					Id:        "id-2",
+					StartTime: time.Date(2024, time.April, 12, 19, 0, 0, 0, time.UTC),
+					EndTime:   overnight.EndTime,
+					Project:   "Flow",
+					Tags:      []string{"cli"},
+				},
+			},
+		},
+		{
+			name:          "Split outside of the session",
+			givenSessions: []session.Session{overnight},
+			command:       splitsession.Command{Id: "1", At: overnight.EndTime},
+			want:          []session.Session{overnight},
+			error:         splitsession.ErrSplitOutsideSession,
+		},
+		{
+			name:          "Split a flowing session",
+			givenSessions: []session.Session{flowing},
+			command:       splitsession.Command{Id: "1", At: time.Date(2024, time.April, 13, 18, 0, 0, 0, time.UTC)},
+			want:          []session.Session{flowing},
+			error:         splitsession.ErrSessionFlowing,
+		},
+		{
+			name:          "Session not found",
+			givenSessions: []session.Session{overnight},
+			command:       splitsession.Command{Id: "2", At: time.Date(2024, time.April, 12, 19, 0, 0, 0, time.UTC)},
+			want:          []session.Session{overnight},
+			error:         splitsession.ErrSessionNotFound,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			f := tests.GetSessionFixture(t)
+
+			f.GivenPredefinedIdentifier("id-2")
+			f.GivenSomeSessions(append([]session.Session{}, tc.givenSessions...))
+
+			f.WhenSplittingSession(tc.command)
+
+			f.ThenErrorShouldBe(tc.error)
+			f.ThenSessionsShouldBe(tc.want)
+		})
+	}
+}
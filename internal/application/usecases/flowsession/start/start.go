@@ -1,48 +1,199 @@
 package startsession
 
 import (
+	"cmp"
 	"errors"
+	"time"
 
 	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/project"
 	"github.com/TristanShz/flow/internal/domain/session"
 )
 
+// staleLockDelay is the time after which a lock whose session can't be found
+// is considered left over by a process that crashed before saving it
+const staleLockDelay = time.Minute
+
 type UseCase struct {
-	sessionRepository application.SessionRepository
-	dateProvider      application.DateProvider
-	idProvider        application.IDProvider
+	sessionRepository   application.SessionRepository
+	dateProvider        application.DateProvider
+	idProvider          application.IDProvider
+	activeSessionLock   application.ActiveSessionLock
+	projectRepository   application.ProjectRepository
+	templatesRepository application.TemplatesRepository
+	eventPublisher      application.EventPublisher
 }
 
 func (s UseCase) Execute(command Command) error {
-	lastSession := s.sessionRepository.FindLastSession()
+	lastSession, err := s.sessionRepository.FindLastSession()
+	if err != nil {
+		return err
+	}
 
 	if lastSession != nil && lastSession.EndTime.IsZero() {
 		return ErrSessionAlreadyStarted
 	}
 
 	startTime := s.dateProvider.GetNow()
-	session := session.Session{
+
+	if err := s.applyTemplates(command); err != nil {
+		return err
+	}
+
+	if err := s.checkDoNotTrack(command, startTime); err != nil {
+		return err
+	}
+
+	started := session.Session{
 		Id:        s.idProvider.Provide(),
 		StartTime: startTime,
 		Project:   command.Project,
 		Tags:      command.Tags,
+		Metadata:  command.Metadata,
+	}.WithTagRules(command.TagRules)
+
+	// a warning about the overlaps is returned once the session is started
+	started, overlapErr := s.checkOverlaps(started, cmp.Or(command.Overlap, session.OverlapAllow))
+	var warning *session.OverlapWarning
+	if overlapErr != nil && !errors.As(overlapErr, &warning) {
+		return overlapErr
 	}
 
-	s.sessionRepository.Save(session)
+	if err := s.acquireLock(started); err != nil {
+		return err
+	}
+
+	if err := s.sessionRepository.Save(started); err != nil {
+		s.activeSessionLock.Release(started.Id)
+		return err
+	}
+
+	s.eventPublisher.Publish(application.Event{Type: application.EventSessionStarted, At: startTime, Session: started})
+
+	return overlapErr
+}
+
+// checkOverlaps checks the session against the sessions ending after it
+// starts, like sessions logged or synced with a clock ahead. The start can't
+// be adjusted to a time in the future.
+func (s UseCase) checkOverlaps(started session.Session, policy string) (session.Session, error) {
+	if policy == session.OverlapAllow {
+		return started, nil
+	}
+
+	checked, err := started.CheckOverlaps(s.sessionRepository.FindAllSessions(nil), policy)
+	if err == nil && checked.StartTime.After(started.StartTime) {
+		return session.Session{}, ErrOverlap
+	}
+
+	return checked, err
+}
+
+// applyTemplates checks the project name and the tags against the synced
+// templates, and gives a project without settings the settings of its
+// template
+func (s UseCase) applyTemplates(command Command) error {
+	templates := s.templatesRepository.Get()
+
+	if err := templates.Validate(command.Project, command.Tags); err != nil {
+		return err
+	}
+
+	if s.projectRepository.FindByName(command.Project) != nil {
+		return nil
+	}
+
+	if defaults := templates.DefaultsOf(command.Project); defaults != nil {
+		return s.projectRepository.Save(*defaults)
+	}
 
 	return nil
 }
 
-var ErrSessionAlreadyStarted = errors.New("there is already a session in progress")
+func (s UseCase) checkDoNotTrack(command Command, startTime time.Time) error {
+	p := s.projectRepository.FindByName(command.Project)
+	if p == nil {
+		return nil
+	}
+
+	if _, ok := p.DoNotTrackWindow(startTime); !ok {
+		return nil
+	}
+
+	if p.OnDoNotTrackAction() == project.DoNotTrackBlock {
+		return ErrDoNotTrackBlocked
+	}
+
+	if !command.Confirmed {
+		return ErrDoNotTrackNotConfirmed
+	}
+
+	return nil
+}
+
+// acquireLock marks the session as the active one, taking over the lock when
+// the session holding it isn't flowing anymore.
+func (s UseCase) acquireLock(newSession session.Session) error {
+	active := application.ActiveSession{SessionId: newSession.Id, AcquiredAt: newSession.StartTime}
+
+	holder, err := s.activeSessionLock.Acquire(active)
+	if err != application.ErrActiveSessionLocked {
+		return err
+	}
+
+	if !s.isStale(holder) {
+		return ErrSessionAlreadyStarted
+	}
+
+	if err := s.activeSessionLock.Release(holder.SessionId); err != nil {
+		return err
+	}
+
+	_, err = s.activeSessionLock.Acquire(active)
+	if err == application.ErrActiveSessionLocked {
+		return ErrSessionAlreadyStarted
+	}
+
+	return err
+}
+
+func (s UseCase) isStale(holder application.ActiveSession) bool {
+	holderSession, err := s.sessionRepository.FindById(holder.SessionId)
+	if err != nil {
+		// the lock is only taken over from a holder known to be gone
+		return false
+	}
+	if holderSession != nil {
+		return holderSession.Status() != session.FlowingStatus
+	}
+
+	// the holder may have acquired the lock without having saved its session yet
+	return s.dateProvider.GetNow().Sub(holder.AcquiredAt) > staleLockDelay
+}
+
+var (
+	ErrSessionAlreadyStarted  = errors.New("there is already a session in progress")
+	ErrDoNotTrackBlocked      = errors.New("sessions of the project can't be started during its do-not-track windows")
+	ErrDoNotTrackNotConfirmed = errors.New("sessions of the project must be confirmed during its do-not-track windows")
+	ErrOverlap                = session.ErrOverlap
+)
 
 func NewStartFlowSessionUseCase(
 	sessionRepository application.SessionRepository,
 	dateProvider application.DateProvider,
 	idProvider application.IDProvider,
+	activeSessionLock application.ActiveSessionLock,
+	projectRepository application.ProjectRepository,
+	templatesRepository application.TemplatesRepository,
+	eventPublisher application.EventPublisher,
 ) UseCase {
 	return UseCase{
-		sessionRepository: sessionRepository,
-		dateProvider:      dateProvider,
-		idProvider:        idProvider,
+		sessionRepository:   sessionRepository,
+		dateProvider:        dateProvider,
+		idProvider:          idProvider,
+		activeSessionLock:   activeSessionLock,
+		projectRepository:   projectRepository,
+		templatesRepository: templatesRepository,
+		eventPublisher:      eventPublisher,
 	}
 }
@@ -1,6 +1,18 @@
 package startsession
 
+import "github.com/TristanShz/flow/internal/domain/session"
+
 type Command struct {
-	Project string
-	Tags    []string
+	Metadata map[string]string
+	Project  string
+	Tags     []string
+	// Confirmed starts the session during a do-not-track window of the
+	// project asking for a confirmation
+	Confirmed bool
+	// TagRules add their tags to the session when it starts on their days
+	// and hours
+	TagRules []session.TagRule
+	// Overlap is the policy applied when the session would overlap another
+	// one, see session.CheckOverlaps. Overlaps are allowed when it's empty.
+	Overlap string
 }
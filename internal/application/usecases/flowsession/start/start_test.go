@@ -1,10 +1,13 @@
 package startsession_test
 
 import (
+	"errors"
 	"testing"
 	"time"
 
+	"github.com/TristanShz/flow/internal/application"
 	startsession "github.com/TristanShz/flow/internal/application/usecases/flowsession/start"
+	"github.com/TristanShz/flow/internal/domain/project"
 	"github.com/TristanShz/flow/internal/domain/session"
 	"github.com/TristanShz/flow/internal/tests"
 )
@@ -29,6 +32,31 @@ func TestStartFlowSession_Success(t *testing.T) {
 		Project:   "Flow",
 		Tags:      []string{"start"},
 	})
+	f.ThenActiveSessionShouldBe("id-1")
+	f.ThenPublishedEventsShouldBe([]string{application.EventSessionStarted})
+}
+
+func TestStartFlowSession_TagRules(t *testing.T) {
+	f := tests.GetSessionFixture(t)
+
+	f.GivenNowIs(time.Date(2024, time.April, 13, 17, 20, 0, 0, time.UTC))
+	f.GivenPredefinedIdentifier("id-1")
+
+	f.WhenStartingFlowSession(startsession.Command{
+		Project: "Flow",
+		Tags:    []string{"start"},
+		TagRules: []session.TagRule{
+			{Tag: "weekend", Days: []time.Weekday{time.Saturday, time.Sunday}},
+			{Tag: "overtime", After: 19 * time.Hour},
+		},
+	})
+
+	f.ThenSessionShouldBeSaved(session.Session{
+		Id:        "id-1",
+		StartTime: time.Date(2024, time.April, 13, 17, 20, 0, 0, time.UTC),
+		Project:   "Flow",
+		Tags:      []string{"start", "weekend"},
+	})
 }
 
 func TestStartFlowSession_AlreadyStarted(t *testing.T) {
@@ -50,3 +78,245 @@ func TestStartFlowSession_AlreadyStarted(t *testing.T) {
 
 	f.ThenErrorShouldBe(startsession.ErrSessionAlreadyStarted)
 }
+
+func TestStartFlowSession_LockedByAnotherProcess(t *testing.T) {
+	f := tests.GetSessionFixture(t)
+
+	f.GivenNowIs(time.Date(2024, time.April, 13, 17, 20, 30, 0, time.UTC))
+	f.GivenPredefinedIdentifier("id-2")
+	// the other process acquired the lock but didn't save its session yet
+	f.GivenActiveSession(application.ActiveSession{
+		SessionId:  "id-1",
+		AcquiredAt: time.Date(2024, time.April, 13, 17, 20, 0, 0, time.UTC),
+	})
+
+	f.WhenStartingFlowSession(startsession.Command{Project: "Flow"})
+
+	f.ThenErrorShouldBe(startsession.ErrSessionAlreadyStarted)
+	f.ThenActiveSessionShouldBe("id-1")
+}
+
+func TestStartFlowSession_StaleLock(t *testing.T) {
+	tt := []struct {
+		name          string
+		givenSessions []session.Session
+	}{
+		{
+			name: "Lock of an ended session",
+			givenSessions: []session.Session{{
+				Id:        "id-1",
+				StartTime: time.Date(2024, time.April, 13, 16, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2024, time.April, 13, 17, 0, 0, 0, time.UTC),
+				Project:   "Flow",
+			}},
+		},
+		{
+			name:          "Lock of a session never saved",
+			givenSessions: []session.Session{},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			f := tests.GetSessionFixture(t)
+
+			f.GivenNowIs(time.Date(2024, time.April, 13, 17, 20, 0, 0, time.UTC))
+			f.GivenPredefinedIdentifier("id-2")
+			f.GivenSomeSessions(tc.givenSessions)
+			f.GivenActiveSession(application.ActiveSession{
+				SessionId:  "id-1",
+				AcquiredAt: time.Date(2024, time.April, 13, 16, 0, 0, 0, time.UTC),
+			})
+
+			f.WhenStartingFlowSession(startsession.Command{Project: "Flow"})
+
+			f.ThenErrorShouldBe(nil)
+			f.ThenActiveSessionShouldBe("id-2")
+		})
+	}
+}
+
+func TestStartFlowSession_DoNotTrack(t *testing.T) {
+	weekends := []project.TimeWindow{{Days: []string{"sat", "sun"}}}
+
+	tt := []struct {
+		error          error
+		name           string
+		givenProjects  []project.Project
+		command        startsession.Command
+		wantSessionIds []string
+	}{
+		{
+			name:           "Outside of windows",
+			givenProjects:  []project.Project{{Name: "Flow", DoNotTrack: []project.TimeWindow{{Days: []string{"mon"}}}}},
+			command:        startsession.Command{Project: "Flow"},
+			wantSessionIds: []string{"id-1"},
+		},
+		{
+			name:          "Not confirmed",
+			givenProjects: []project.Project{{Name: "Flow", DoNotTrack: weekends}},
+			command:       startsession.Command{Project: "Flow"},
+			error:         startsession.ErrDoNotTrackNotConfirmed,
+		},
+		{
+			name:           "Confirmed",
+			givenProjects:  []project.Project{{Name: "Flow", DoNotTrack: weekends}},
+			command:        startsession.Command{Project: "Flow", Confirmed: true},
+			wantSessionIds: []string{"id-1"},
+		},
+		{
+			name:          "Blocked",
+			givenProjects: []project.Project{{Name: "Flow", DoNotTrack: weekends, OnDoNotTrack: project.DoNotTrackBlock}},
+			command:       startsession.Command{Project: "Flow", Confirmed: true},
+			error:         startsession.ErrDoNotTrackBlocked,
+		},
+		{
+			name:           "Other project",
+			givenProjects:  []project.Project{{Name: "Flow", DoNotTrack: weekends, OnDoNotTrack: project.DoNotTrackBlock}},
+			command:        startsession.Command{Project: "Hobby"},
+			wantSessionIds: []string{"id-1"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			f := tests.GetSessionFixture(t)
+
+			// a saturday
+			f.GivenNowIs(time.Date(2024, time.April, 13, 17, 20, 0, 0, time.UTC))
+			f.GivenPredefinedIdentifier("id-1")
+			f.GivenSomeProjects(tc.givenProjects)
+
+			f.WhenStartingFlowSession(tc.command)
+
+			f.ThenErrorShouldBe(tc.error)
+			f.ThenSessionIdsShouldBe(tc.wantSessionIds)
+		})
+	}
+}
+
+func TestStartFlowSession_Templates(t *testing.T) {
+	templates := project.Templates{
+		NamePattern: "^[a-z]+(-[a-z]+)*$",
+		Tags:        []string{"dev", "review"},
+		Projects:    []project.Project{{Name: "acme-website", Client: "Acme", Billable: true, HourlyRate: 80}},
+	}
+
+	tt := []struct {
+		error          error
+		name           string
+		givenProjects  []project.Project
+		command        startsession.Command
+		wantSessionIds []string
+		wantProjects   []project.Project
+	}{
+		{
+			name:           "Template settings given to a new project",
+			command:        startsession.Command{Project: "acme-website", Tags: []string{"dev"}},
+			wantSessionIds: []string{"id-1"},
+			wantProjects:   []project.Project{{Name: "acme-website", Client: "Acme", Billable: true, HourlyRate: 80}},
+		},
+		{
+			name:           "Existing settings kept",
+			givenProjects:  []project.Project{{Name: "acme-website", OnLock: project.OnLockStop}},
+			command:        startsession.Command{Project: "acme-website"},
+			wantSessionIds: []string{"id-1"},
+			wantProjects:   []project.Project{{Name: "acme-website", OnLock: project.OnLockStop}},
+		},
+		{
+			name:           "Project without template",
+			command:        startsession.Command{Project: "side-project"},
+			wantSessionIds: []string{"id-1"},
+		},
+		{
+			name:    "Invalid project name",
+			command: startsession.Command{Project: "AcmeWebsite"},
+			error:   project.ErrInvalidProjectName,
+		},
+		{
+			name:    "Unknown tag",
+			command: startsession.Command{Project: "acme-website", Tags: []string{"meeting"}},
+			error:   project.ErrUnknownTag,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			f := tests.GetSessionFixture(t)
+
+			f.GivenNowIs(time.Date(2024, time.April, 15, 9, 0, 0, 0, time.UTC))
+			f.GivenPredefinedIdentifier("id-1")
+			f.GivenSomeProjects(tc.givenProjects)
+			f.GivenSomeTemplates(templates)
+
+			f.WhenStartingFlowSession(tc.command)
+
+			f.ThenErrorShouldBe(tc.error)
+			f.ThenSessionIdsShouldBe(tc.wantSessionIds)
+			f.ThenProjectSettingsShouldBe(tc.wantProjects)
+		})
+	}
+}
+
+func TestStartFlowSession_Overlap(t *testing.T) {
+	now := time.Date(2024, time.April, 13, 17, 20, 0, 0, time.UTC)
+	// logged from a machine whose clock is ahead
+	ahead := session.Session{
+		Id:        "1",
+		StartTime: time.Date(2024, time.April, 13, 16, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, time.April, 13, 17, 30, 0, 0, time.UTC),
+		Project:   "Flow",
+	}
+	started := session.Session{Id: "id-1", StartTime: now, Project: "MyTodo"}
+
+	tt := []struct {
+		name        string
+		policy      string
+		wantError   error
+		wantWarning bool
+		want        []session.Session
+	}{
+		{
+			name: "Allowed by default",
+			want: []session.Session{ahead, started},
+		},
+		{
+			name:        "Warned",
+			policy:      session.OverlapWarn,
+			wantWarning: true,
+			want:        []session.Session{ahead, started},
+		},
+		{
+			name:      "Rejected",
+			policy:    session.OverlapReject,
+			wantError: startsession.ErrOverlap,
+			want:      []session.Session{ahead},
+		},
+		{
+			name:      "Not adjusted to the future",
+			policy:    session.OverlapAdjust,
+			wantError: startsession.ErrOverlap,
+			want:      []session.Session{ahead},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			f := tests.GetSessionFixture(t)
+			f.GivenNowIs(now)
+			f.GivenPredefinedIdentifier("id-1")
+			f.GivenSomeSessions([]session.Session{ahead})
+
+			f.WhenStartingFlowSession(startsession.Command{Project: "MyTodo", Overlap: tc.policy})
+
+			var warning *session.OverlapWarning
+			if tc.wantWarning != errors.As(f.ThrownError, &warning) {
+				t.Errorf("Expected a warning: %v, but got '%v'", tc.wantWarning, f.ThrownError)
+			}
+			if !tc.wantWarning {
+				f.ThenErrorShouldBe(tc.wantError)
+			}
+			f.ThenSessionsShouldBe(tc.want)
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package stats
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/pkg/timerange"
+)
+
+// ProjectShare is the part of the tracked time spent on a project
+type ProjectShare struct {
+	Project  string
+	Duration time.Duration
+	// Share is between 0 and 1
+	Share float64
+}
+
+// Stats are statistics about the sessions of a range, a session counts on
+// the day it started
+type Stats struct {
+	// Days is the number of days of the range, up to today
+	Days int
+	// TrackedDays is the number of days having sessions
+	TrackedDays int
+	Total       time.Duration
+	// AverageDaily is the average time tracked on the tracked days
+	AverageDaily time.Duration
+	// CurrentStreak is the number of tracked days in a row up to the last day
+	// of the range, today doesn't break it while it has no sessions yet
+	CurrentStreak int
+	// LongestStreak is the largest number of tracked days in a row, since
+	// LongestStreakStart
+	LongestStreak      int
+	LongestStreakStart time.Time
+	// BusiestWeekday is the weekday with the most tracked time, its total is
+	// zero when nothing was tracked
+	BusiestWeekday      time.Weekday
+	BusiestWeekdayTotal time.Duration
+	// LongestSession is the zero session when no session ended in the range
+	LongestSession session.Session
+	// Projects are sorted by duration, the longest first
+	Projects []ProjectShare
+}
+
+type UseCase struct {
+	sessionRepository application.SessionRepository
+	dateProvider      application.DateProvider
+}
+
+func (s UseCase) Execute(command Command) (Stats, error) {
+	if command.Since.IsZero() || command.Until.IsZero() {
+		return Stats{}, ErrMissingRange
+	}
+
+	now := s.dateProvider.GetNow()
+	statsRange := timerange.TimeRange{Since: command.Since, Until: command.Until}
+	if statsRange.Until.After(now) {
+		statsRange.Until = now
+	}
+	if statsRange.Until.Before(statsRange.Since) {
+		return Stats{}, ErrRangeInFuture
+	}
+
+	sessions := s.sessionRepository.FindAllSessions(&application.SessionsFilters{Timerange: statsRange})
+
+	days := timerange.Buckets(statsRange, timerange.ByDay, time.Monday)
+	stats := Stats{Days: len(days), Projects: []ProjectShare{}}
+
+	byDay := make([]time.Duration, len(days))
+	tracked := make([]bool, len(days))
+	byWeekday := map[time.Weekday]time.Duration{}
+	byProject := map[string]time.Duration{}
+	for _, sess := range sessions {
+		for index, day := range days {
+			if day.Contains(sess.StartTime) {
+				byDay[index] += sess.Duration()
+				tracked[index] = true
+				break
+			}
+		}
+
+		stats.Total += sess.Duration()
+		byWeekday[sess.StartTime.Weekday()] += sess.Duration()
+		byProject[sess.Project] += sess.Duration()
+
+		if sess.Duration() > stats.LongestSession.Duration() {
+			stats.LongestSession = sess
+		}
+	}
+
+	streak := 0
+	for index, isTracked := range tracked {
+		if !isTracked {
+			streak = 0
+			continue
+		}
+
+		stats.TrackedDays++
+		streak++
+		if streak > stats.LongestStreak {
+			stats.LongestStreak = streak
+			stats.LongestStreakStart = days[index-streak+1].Since
+		}
+	}
+	stats.CurrentStreak = currentStreak(tracked, days, now)
+
+	if stats.TrackedDays > 0 {
+		stats.AverageDaily = stats.Total / time.Duration(stats.TrackedDays)
+	}
+
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if byWeekday[weekday] > stats.BusiestWeekdayTotal {
+			stats.BusiestWeekday = weekday
+			stats.BusiestWeekdayTotal = byWeekday[weekday]
+		}
+	}
+
+	for project, duration := range byProject {
+		share := ProjectShare{Project: project, Duration: duration}
+		if stats.Total > 0 {
+			share.Share = float64(duration) / float64(stats.Total)
+		}
+		stats.Projects = append(stats.Projects, share)
+	}
+	sort.Slice(stats.Projects, func(i, j int) bool {
+		if stats.Projects[i].Duration != stats.Projects[j].Duration {
+			return stats.Projects[i].Duration > stats.Projects[j].Duration
+		}
+		return stats.Projects[i].Project < stats.Projects[j].Project
+	})
+
+	return stats, nil
+}
+
+// currentStreak counts the tracked days in a row ending on the last day, the
+// last day is skipped when it's today and nothing was tracked yet
+func currentStreak(tracked []bool, days []timerange.TimeRange, now time.Time) int {
+	last := len(tracked) - 1
+	if last >= 0 && !tracked[last] && days[last].Contains(now) {
+		last--
+	}
+
+	streak := 0
+	for index := last; index >= 0 && tracked[index]; index-- {
+		streak++
+	}
+
+	return streak
+}
+
+var (
+	ErrMissingRange  = errors.New("the statistics need a range")
+	ErrRangeInFuture = errors.New("the range of the statistics is in the future")
+)
+
+func NewStatsUseCase(sessionRepository application.SessionRepository, dateProvider application.DateProvider) UseCase {
+	return UseCase{
+		sessionRepository: sessionRepository,
+		dateProvider:      dateProvider,
+	}
+}
@@ -0,0 +1,9 @@
+package stats
+
+import "time"
+
+type Command struct {
+	// Since and Until are the range of the statistics, both are required
+	Since time.Time
+	Until time.Time
+}
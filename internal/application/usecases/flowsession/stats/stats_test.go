@@ -0,0 +1,118 @@
+package stats_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/stats"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/tests"
+)
+
+func at(day int, hour int) time.Time {
+	return time.Date(2024, time.April, day, hour, 0, 0, 0, time.UTC)
+}
+
+var sessionsForTest = []session.Session{
+	{Id: "0", StartTime: at(5, 9), EndTime: at(5, 11), Project: "Flow"},
+	{Id: "1", StartTime: at(8, 9), EndTime: at(8, 11), Project: "Flow"},
+	{Id: "2", StartTime: at(9, 9), EndTime: at(9, 12), Project: "Flow"},
+	{Id: "3", StartTime: at(9, 14), EndTime: at(9, 15), Project: "MyTodo"},
+	{Id: "4", StartTime: at(10, 10), EndTime: at(10, 11), Project: "MyTodo"},
+	{Id: "5", StartTime: at(15, 9), EndTime: at(15, 13), Project: "Flow"},
+	{Id: "6", StartTime: at(16, 9), EndTime: at(16, 10), Project: "MyTodo"},
+	{Id: "7", StartTime: at(17, 14), Project: "Flow"},
+}
+
+func TestStats(t *testing.T) {
+	twoWeeks := stats.Command{Since: at(8, 0), Until: time.Date(2024, time.April, 21, 23, 59, 59, 0, time.UTC)}
+
+	tt := []struct {
+		name          string
+		command       stats.Command
+		now           time.Time
+		givenSessions []session.Session
+		want          stats.Stats
+		wantErr       error
+	}{
+		{
+			name:          "Sessions of the range up to now",
+			command:       twoWeeks,
+			now:           at(17, 15),
+			givenSessions: sessionsForTest,
+			want: stats.Stats{
+				Days:                10,
+				TrackedDays:         6,
+				Total:               12 * time.Hour,
+				AverageDaily:        2 * time.Hour,
+				CurrentStreak:       3,
+				LongestStreak:       3,
+				LongestStreakStart:  at(8, 0),
+				BusiestWeekday:      time.Monday,
+				BusiestWeekdayTotal: 6 * time.Hour,
+				LongestSession:      sessionsForTest[5],
+				Projects: []stats.ProjectShare{
+					{Project: "Flow", Duration: 9 * time.Hour, Share: 0.75},
+					{Project: "MyTodo", Duration: 3 * time.Hour, Share: 0.25},
+				},
+			},
+		},
+		{
+			name:          "Nothing tracked yet today",
+			command:       twoWeeks,
+			now:           at(18, 10),
+			givenSessions: sessionsForTest,
+			want: stats.Stats{
+				Days:                11,
+				TrackedDays:         6,
+				Total:               12 * time.Hour,
+				AverageDaily:        2 * time.Hour,
+				CurrentStreak:       3,
+				LongestStreak:       3,
+				LongestStreakStart:  at(8, 0),
+				BusiestWeekday:      time.Monday,
+				BusiestWeekdayTotal: 6 * time.Hour,
+				LongestSession:      sessionsForTest[5],
+				Projects: []stats.ProjectShare{
+					{Project: "Flow", Duration: 9 * time.Hour, Share: 0.75},
+					{Project: "MyTodo", Duration: 3 * time.Hour, Share: 0.25},
+				},
+			},
+		},
+		{
+			name:          "No sessions",
+			command:       twoWeeks,
+			now:           at(17, 15),
+			givenSessions: []session.Session{},
+			want:          stats.Stats{Days: 10, Projects: []stats.ProjectShare{}},
+		},
+		{
+			name:    "Missing range",
+			command: stats.Command{Since: at(8, 0)},
+			now:     at(17, 15),
+			wantErr: stats.ErrMissingRange,
+		},
+		{
+			name:    "Range in the future",
+			command: stats.Command{Since: at(20, 0), Until: at(21, 0)},
+			now:     at(17, 15),
+			wantErr: stats.ErrRangeInFuture,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			f := tests.GetSessionFixture(t)
+			f.GivenSomeSessions(tc.givenSessions)
+			f.GivenNowIs(tc.now)
+
+			f.WhenComputingStats(tc.command)
+
+			if tc.wantErr != nil {
+				f.ThenErrorShouldBe(tc.wantErr)
+				return
+			}
+			f.ThenStatsShouldBe(tc.want)
+		})
+	}
+}
@@ -11,27 +11,123 @@ import (
 type UseCase struct {
 	sessionRepository application.SessionRepository
 	dateProvider      application.DateProvider
+	activeSessionLock application.ActiveSessionLock
+	projectRepository application.ProjectRepository
+	idProvider        application.IDProvider
+	eventPublisher    application.EventPublisher
 }
 
-func (s UseCase) Execute() (time.Duration, error) {
-	lastSession := s.sessionRepository.FindLastSession()
+// Execute stops the current session and returns the time worked, the breaks
+// of its project being taken out of it
+func (s UseCase) Execute(command Command) (time.Duration, error) {
+	lastSession, err := s.sessionRepository.FindLastSession()
+	if err != nil {
+		return 0, err
+	}
 
 	if lastSession == nil || lastSession.Status() != session.FlowingStatus {
 		return 0, ErrNoCurrentSession
 	}
 
-	lastSession.EndTime = s.dateProvider.GetNow()
+	// the clock may go backwards during the session, because of an NTP
+	// correction or a manual change, the session then ends when it started
+	// rather than having a negative duration
+	now := s.dateProvider.GetNow()
+	clockSkew := lastSession.StartTime.Sub(now)
+	clockWentBackwards := clockSkew > 0
+	if clockWentBackwards {
+		now = lastSession.StartTime
+	}
+	lastSession.EndTime = now
+	if command.EndTime.After(lastSession.StartTime) && command.EndTime.Before(now) {
+		lastSession.EndTime = command.EndTime
+	}
+
+	if command.Note != "" {
+		lastSession.Note = command.Note
+	}
+
+	for _, tag := range command.Tags {
+		if !lastSession.HasTag(tag) {
+			lastSession.Tags = append(lastSession.Tags, tag)
+		}
+	}
+
+	if len(command.Metadata) > 0 && lastSession.Metadata == nil {
+		lastSession.Metadata = map[string]string{}
+	}
+	for key, value := range command.Metadata {
+		lastSession.Metadata[key] = value
+	}
+
+	if clockWentBackwards {
+		if lastSession.Metadata == nil {
+			lastSession.Metadata = map[string]string{}
+		}
+		lastSession.Metadata[session.ClockSkewMetadata] = clockSkew.Round(time.Second).String()
+	}
+
+	*lastSession = lastSession.WithTagRules(command.TagRules)
+
+	trimmed, idle := lastSession.WithoutIdle(command.Idle, command.IdleSettings.Threshold)
+	if idle > 0 {
+		*lastSession = trimmed
+		if lastSession.Metadata == nil {
+			lastSession.Metadata = map[string]string{}
+		}
+		idleMetadata := session.IdleTrimmedMetadata
+		if command.IdleSettings.Action == application.IdleActionBreak {
+			idleMetadata = session.IdleBreakMetadata
+		}
+		lastSession.Metadata[idleMetadata] = idle.Round(time.Second).String()
+	}
+
+	parts := []session.Session{*lastSession}
+	if p := s.projectRepository.FindByName(lastSession.Project); p != nil && p.HasBreaks() {
+		parts = lastSession.WithBreaks(p.BreakEvery, p.BreakDuration, s.idProvider.Provide)
+	}
+
+	var duration time.Duration
+	for _, part := range parts {
+		if err := s.sessionRepository.Save(part); err != nil {
+			return 0, err
+		}
+		duration += part.Duration()
+	}
+
+	if err := s.activeSessionLock.Release(lastSession.Id); err != nil {
+		return 0, err
+	}
 
-	s.sessionRepository.Save(*lastSession)
+	s.eventPublisher.Publish(application.Event{Type: application.EventSessionStopped, At: now, Session: *lastSession})
 
-	return lastSession.Duration(), nil
+	if clockWentBackwards {
+		return duration, ErrClockWentBackwards
+	}
+
+	return duration, nil
 }
 
 var ErrNoCurrentSession = errors.New("there is no flow session in progress")
 
-func NewStopSessionUseCase(sessionRepository application.SessionRepository, dateProvider application.DateProvider) UseCase {
+// ErrClockWentBackwards is returned once the session is stopped, when the
+// clock is before its start time
+var ErrClockWentBackwards = errors.New("the clock went backwards during the session, it was stopped at its start time")
+
+func NewStopSessionUseCase(
+	sessionRepository application.SessionRepository,
+	dateProvider application.DateProvider,
+	activeSessionLock application.ActiveSessionLock,
+	projectRepository application.ProjectRepository,
+	idProvider application.IDProvider,
+	eventPublisher application.EventPublisher,
+) UseCase {
 	return UseCase{
 		sessionRepository: sessionRepository,
 		dateProvider:      dateProvider,
+		activeSessionLock: activeSessionLock,
+		projectRepository: projectRepository,
+		idProvider:        idProvider,
+		eventPublisher:    eventPublisher,
 	}
 }
@@ -0,0 +1,28 @@
+package stopsession
+
+import (
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+type Command struct {
+	// Note describes what was done during the session
+	Note string
+	// Tags are added to the tags of the stopped session
+	Tags []string
+	// Metadata is merged into the metadata of the stopped session
+	Metadata map[string]string
+	// TagRules add their tags to the session when it starts or stops on
+	// their days and hours
+	TagRules []session.TagRule
+	// Idle is how long the user has been idle when stopping, the idle time
+	// beyond the threshold of IdleSettings is taken out of the session
+	Idle         time.Duration
+	IdleSettings application.Idle
+	// EndTime stops the session earlier than now, e.g. when the user was
+	// last active. It's ignored when it isn't between the start of the
+	// session and now.
+	EndTime time.Time
+}
@@ -1,11 +1,15 @@
 package stopsession_test
 
 import (
+	"math/rand/v2"
 	"testing"
 	"time"
 
+	"github.com/TristanShz/flow/internal/application"
 	"github.com/TristanShz/flow/internal/application/usecases/flowsession/stopsession"
+	"github.com/TristanShz/flow/internal/domain/project"
 	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
 	"github.com/TristanShz/flow/internal/tests"
 )
 
@@ -13,20 +17,286 @@ func TestStopFlowSession_Success(t *testing.T) {
 	f := tests.GetSessionFixture(t)
 
 	f.GivenSomeSessions([]session.Session{{
+		Id:        "1",
 		StartTime: time.Date(2024, time.April, 13, 17, 20, 0, 0, time.UTC),
 		Project:   "Flow",
 		Tags:      []string{"stop"},
 	}})
+	f.GivenActiveSession(application.ActiveSession{SessionId: "1"})
 
-	f.WhenStoppingFlowSession()
+	f.WhenStoppingFlowSession(stopsession.Command{})
 
 	f.ThenSessionShouldBeStopped()
+	f.ThenActiveSessionShouldBe("")
+	f.ThenPublishedEventsShouldBe([]string{application.EventSessionStopped})
 }
 
 func TestStopFlowSession_NoCurrentSession(t *testing.T) {
 	f := tests.GetSessionFixture(t)
 
-	f.WhenStoppingFlowSession()
+	f.WhenStoppingFlowSession(stopsession.Command{})
 
 	f.ThenErrorShouldBe(stopsession.ErrNoCurrentSession)
+	f.ThenPublishedEventsShouldBe(nil)
+}
+
+func TestStopFlowSession_WithMetadata(t *testing.T) {
+	f := tests.GetSessionFixture(t)
+
+	f.GivenSomeSessions([]session.Session{{
+		StartTime: time.Date(2024, time.April, 13, 17, 20, 0, 0, time.UTC),
+		Project:   "Flow",
+		Metadata:  map[string]string{session.CommandMetadata: "make test"},
+	}})
+
+	f.WhenStoppingFlowSession(stopsession.Command{
+		Metadata: map[string]string{session.ExitCodeMetadata: "2"},
+	})
+
+	f.ThenSessionShouldBeStopped()
+	f.ThenLastSessionMetadataShouldBe(map[string]string{
+		session.CommandMetadata:  "make test",
+		session.ExitCodeMetadata: "2",
+	})
+}
+
+func TestStopFlowSession_WithNoteAndTags(t *testing.T) {
+	f := tests.GetSessionFixture(t)
+
+	f.GivenSomeSessions([]session.Session{{
+		StartTime: time.Date(2024, time.April, 13, 17, 20, 0, 0, time.UTC),
+		Project:   "Flow",
+		Tags:      []string{"stop"},
+	}})
+
+	f.WhenStoppingFlowSession(stopsession.Command{
+		Note: "Fixed the report alignment",
+		Tags: []string{"stop", "report"},
+	})
+
+	f.ThenSessionShouldBeStopped()
+	f.ThenLastSessionShouldBe(session.Session{
+		Note: "Fixed the report alignment",
+		Tags: []string{"stop", "report"},
+	})
+}
+
+func TestStopFlowSession_TagRules(t *testing.T) {
+	f := tests.GetSessionFixture(t)
+
+	f.GivenNowIs(time.Date(2024, time.April, 15, 19, 30, 0, 0, time.UTC))
+	f.GivenSomeSessions([]session.Session{{
+		StartTime: time.Date(2024, time.April, 15, 17, 20, 0, 0, time.UTC),
+		Project:   "Flow",
+		Tags:      []string{"stop"},
+	}})
+
+	f.WhenStoppingFlowSession(stopsession.Command{
+		TagRules: []session.TagRule{
+			{Tag: "weekend", Days: []time.Weekday{time.Saturday, time.Sunday}},
+			{Tag: "overtime", After: 19 * time.Hour},
+		},
+	})
+
+	f.ThenSessionShouldBeStopped()
+	f.ThenLastSessionShouldBe(session.Session{
+		Tags: []string{"stop", "overtime"},
+	})
+}
+
+func TestStopFlowSession_ClockWentBackwards(t *testing.T) {
+	f := tests.GetSessionFixture(t)
+
+	f.GivenNowIs(time.Date(2024, time.April, 13, 17, 5, 0, 0, time.UTC))
+	f.GivenSomeSessions([]session.Session{{
+		Id:        "1",
+		StartTime: time.Date(2024, time.April, 13, 17, 20, 0, 0, time.UTC),
+		Project:   "Flow",
+	}})
+	f.GivenActiveSession(application.ActiveSession{SessionId: "1"})
+
+	f.WhenStoppingFlowSession(stopsession.Command{})
+
+	f.ThenErrorShouldBe(stopsession.ErrClockWentBackwards)
+	f.ThenSessionsShouldBe([]session.Session{{
+		Id:        "1",
+		StartTime: time.Date(2024, time.April, 13, 17, 20, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, time.April, 13, 17, 20, 0, 0, time.UTC),
+		Project:   "Flow",
+		Metadata:  map[string]string{session.ClockSkewMetadata: "15m0s"},
+	}})
+	f.ThenActiveSessionShouldBe("")
+}
+
+func TestStopFlowSession_BreakSchedule(t *testing.T) {
+	f := tests.GetSessionFixture(t)
+
+	f.GivenNowIs(time.Date(2024, time.April, 15, 12, 0, 0, 0, time.UTC))
+	f.GivenSomeProjects([]project.Project{{Name: "Flow", BreakEvery: 2 * time.Hour, BreakDuration: 10 * time.Minute}})
+	f.GivenPredefinedIdentifier("2")
+	f.GivenSomeSessions([]session.Session{{
+		Id:        "1",
+		StartTime: time.Date(2024, time.April, 15, 9, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+		Tags:      []string{"stop"},
+	}})
+	f.GivenActiveSession(application.ActiveSession{SessionId: "1"})
+
+	f.WhenStoppingFlowSession(stopsession.Command{})
+
+	f.ThenSessionsShouldBe([]session.Session{
+		{
+			Id:        "1",
+			StartTime: time.Date(2024, time.April, 15, 9, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, time.April, 15, 11, 0, 0, 0, time.UTC),
+			Project:   "Flow",
+			Tags:      []string{"stop"},
+		},
+		{
+			Id:        "2",
+			StartTime: time.Date(2024, time.April, 15, 11, 10, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, time.April, 15, 12, 0, 0, 0, time.UTC),
+			Project:   "Flow",
+			Tags:      []string{"stop"},
+			Continues: "1",
+			Metadata:  map[string]string{session.BreakMetadata: "10m0s"},
+		},
+	})
+	f.ThenActiveSessionShouldBe("")
+}
+
+func TestStopFlowSession_Idle(t *testing.T) {
+	tt := []struct {
+		name         string
+		command      stopsession.Command
+		wantEndTime  time.Time
+		wantMetadata map[string]string
+	}{
+		{
+			name: "Idle time trimmed",
+			command: stopsession.Command{
+				Idle:         45 * time.Minute,
+				IdleSettings: application.Idle{Threshold: 15 * time.Minute},
+			},
+			wantEndTime:  time.Date(2024, time.April, 13, 11, 30, 0, 0, time.UTC),
+			wantMetadata: map[string]string{session.IdleTrimmedMetadata: "30m0s"},
+		},
+		{
+			name: "Idle time as a break",
+			command: stopsession.Command{
+				Idle:         45 * time.Minute,
+				IdleSettings: application.Idle{Threshold: 15 * time.Minute, Action: application.IdleActionBreak},
+			},
+			wantEndTime:  time.Date(2024, time.April, 13, 11, 30, 0, 0, time.UTC),
+			wantMetadata: map[string]string{session.IdleBreakMetadata: "30m0s"},
+		},
+		{
+			name: "Idle below the threshold",
+			command: stopsession.Command{
+				Idle:         10 * time.Minute,
+				IdleSettings: application.Idle{Threshold: 15 * time.Minute},
+			},
+			wantEndTime: time.Date(2024, time.April, 13, 12, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			f := tests.GetSessionFixture(t)
+			f.GivenNowIs(time.Date(2024, time.April, 13, 12, 0, 0, 0, time.UTC))
+			f.GivenSomeSessions([]session.Session{{
+				Id:        "1",
+				StartTime: time.Date(2024, time.April, 13, 9, 0, 0, 0, time.UTC),
+				Project:   "Flow",
+			}})
+
+			f.WhenStoppingFlowSession(tc.command)
+
+			f.ThenSessionsShouldBe([]session.Session{{
+				Id:        "1",
+				StartTime: time.Date(2024, time.April, 13, 9, 0, 0, 0, time.UTC),
+				EndTime:   tc.wantEndTime,
+				Project:   "Flow",
+				Metadata:  tc.wantMetadata,
+			}})
+		})
+	}
+}
+
+func TestStopFlowSession_StorageFailure(t *testing.T) {
+	f := tests.GetSessionFixture(t)
+
+	f.GivenSomeSessions([]session.Session{{
+		Id:        "1",
+		StartTime: time.Date(2024, time.April, 13, 17, 20, 0, 0, time.UTC),
+		Project:   "Flow",
+	}})
+	f.GivenActiveSession(application.ActiveSession{SessionId: "1"})
+
+	// the in-memory sessions are cloned like the ones read from files, so
+	// that the failed stop doesn't change them
+	faults := &infra.Faults{ErrorRate: 1}
+	f.StopFlowSessionUseCase = stopsession.NewStopSessionUseCase(
+		infra.NewSyncSessionRepository(infra.NewFaultySessionRepository(f.SessionRepository, faults, rand.New(rand.NewPCG(1, 2)))),
+		f.DateProvider,
+		f.ActiveSessionLock,
+		f.ProjectRepository,
+		f.IdProvider,
+		f.EventPublisher,
+	)
+
+	f.WhenStoppingFlowSession(stopsession.Command{})
+
+	f.ThenErrorShouldBe(infra.ErrInjectedFault)
+	f.ThenActiveSessionShouldBe("1") // still flowing, stopping it again works
+	f.ThenPublishedEventsShouldBe(nil)
+
+	faults.ErrorRate = 0
+	f.ThrownError = nil
+	f.WhenStoppingFlowSession(stopsession.Command{})
+
+	f.ThenErrorShouldBe(nil)
+	f.ThenSessionShouldBeStopped()
+	f.ThenActiveSessionShouldBe("")
+}
+
+func TestStopFlowSession_EndTime(t *testing.T) {
+	startTime := time.Date(2024, time.April, 13, 9, 0, 0, 0, time.UTC)
+	now := time.Date(2024, time.April, 13, 18, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		endTime time.Time
+		want    time.Time
+		name    string
+	}{
+		{
+			name:    "Earlier end",
+			endTime: time.Date(2024, time.April, 13, 12, 30, 0, 0, time.UTC),
+			want:    time.Date(2024, time.April, 13, 12, 30, 0, 0, time.UTC),
+		},
+		{
+			name:    "End before the start",
+			endTime: startTime.Add(-time.Hour),
+			want:    now,
+		},
+		{
+			name:    "End in the future",
+			endTime: now.Add(time.Hour),
+			want:    now,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			f := tests.GetSessionFixture(t)
+
+			f.GivenSomeSessions([]session.Session{{Id: "1", StartTime: startTime, Project: "Flow"}})
+			f.GivenNowIs(now)
+
+			f.WhenStoppingFlowSession(stopsession.Command{EndTime: tc.endTime})
+
+			f.ThenErrorShouldBe(nil)
+			f.ThenLastSessionShouldBe(session.Session{Id: "1", StartTime: startTime, EndTime: tc.want, Project: "Flow"})
+		})
+	}
 }
@@ -0,0 +1,56 @@
+package suggeststop
+
+import (
+	"errors"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+// MinimumSession is how long the current session must have been flowing for
+// an earlier end to be suggested
+const MinimumSession = time.Hour
+
+// MinimumGap is how long before now the last activity must be to be
+// suggested as the end of the session
+const MinimumGap = 30 * time.Minute
+
+type UseCase struct {
+	sessionRepository application.SessionRepository
+	dateProvider      application.DateProvider
+}
+
+// Execute suggests the last activity of the user as the end of the current
+// session, when the session has been flowing for long and the user wasn't
+// active for a while, e.g. because they forgot to stop it. It returns the
+// zero time when there's no suggestion.
+func (s UseCase) Execute(command Command) (time.Time, error) {
+	currentSession, err := s.sessionRepository.FindLastSession()
+	if err != nil {
+		return time.Time{}, err
+	}
+	if currentSession == nil || currentSession.Status() != session.FlowingStatus {
+		return time.Time{}, ErrNoCurrentSession
+	}
+
+	now := s.dateProvider.GetNow()
+	if now.Sub(currentSession.StartTime) < MinimumSession {
+		return time.Time{}, nil
+	}
+
+	if !command.LastActivity.After(currentSession.StartTime) || now.Sub(command.LastActivity) < MinimumGap {
+		return time.Time{}, nil
+	}
+
+	return command.LastActivity, nil
+}
+
+var ErrNoCurrentSession = errors.New("there is no flow session in progress")
+
+func NewSuggestStopUseCase(sessionRepository application.SessionRepository, dateProvider application.DateProvider) UseCase {
+	return UseCase{
+		sessionRepository: sessionRepository,
+		dateProvider:      dateProvider,
+	}
+}
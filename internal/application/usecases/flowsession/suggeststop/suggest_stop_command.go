@@ -0,0 +1,8 @@
+package suggeststop
+
+import "time"
+
+type Command struct {
+	// LastActivity is when the user was last active, before running the stop
+	LastActivity time.Time
+}
@@ -0,0 +1,81 @@
+package suggeststop_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/suggeststop"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/tests"
+)
+
+func TestSuggestStop(t *testing.T) {
+	at := func(hour int, minute int) time.Time {
+		return time.Date(2024, time.April, 13, hour, minute, 0, 0, time.UTC)
+	}
+
+	flowing := session.Session{Id: "1", StartTime: at(9, 0), Project: "Flow"}
+	stopped := flowing
+	stopped.EndTime = at(12, 0)
+
+	tt := []struct {
+		error         error
+		now           time.Time
+		lastActivity  time.Time
+		want          time.Time
+		name          string
+		givenSessions []session.Session
+	}{
+		{
+			name:          "Inactive for long",
+			givenSessions: []session.Session{flowing},
+			now:           at(18, 0),
+			lastActivity:  at(12, 30),
+			want:          at(12, 30),
+		},
+		{
+			name:          "Active a moment ago",
+			givenSessions: []session.Session{flowing},
+			now:           at(18, 0),
+			lastActivity:  at(17, 45),
+		},
+		{
+			name:          "Short session",
+			givenSessions: []session.Session{flowing},
+			now:           at(9, 50),
+			lastActivity:  at(9, 5),
+		},
+		{
+			name:          "Last activity before the session",
+			givenSessions: []session.Session{flowing},
+			now:           at(18, 0),
+			lastActivity:  at(8, 30),
+		},
+		{
+			name:          "No known activity",
+			givenSessions: []session.Session{flowing},
+			now:           at(18, 0),
+		},
+		{
+			name:          "No current session",
+			givenSessions: []session.Session{stopped},
+			now:           at(18, 0),
+			lastActivity:  at(12, 30),
+			error:         suggeststop.ErrNoCurrentSession,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			f := tests.GetSessionFixture(t)
+
+			f.GivenSomeSessions(tc.givenSessions)
+			f.GivenNowIs(tc.now)
+
+			f.WhenSuggestingStop(suggeststop.Command{LastActivity: tc.lastActivity})
+
+			f.ThenErrorShouldBe(tc.error)
+			f.ThenSuggestedStopShouldBe(tc.want)
+		})
+	}
+}
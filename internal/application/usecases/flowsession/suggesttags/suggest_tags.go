@@ -0,0 +1,97 @@
+package suggesttags
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+// MaxSuggestions is the maximum number of tags suggested for a note
+const MaxSuggestions = 3
+
+type UseCase struct {
+	sessionRepository application.SessionRepository
+}
+
+// Execute suggests tags for the current session from the past sessions whose
+// notes are the most similar to the given note. Tags already on the current
+// session are left out.
+func (s UseCase) Execute(command Command) ([]string, error) {
+	suggestions := []string{}
+
+	currentSession, err := s.sessionRepository.FindLastSession()
+	if err != nil {
+		return suggestions, err
+	}
+	if currentSession == nil || currentSession.Status() != session.FlowingStatus {
+		return suggestions, ErrNoCurrentSession
+	}
+
+	noteTerms := terms(command.Note)
+	if len(noteTerms) == 0 {
+		return suggestions, nil
+	}
+
+	pastSessions := []session.Session{}
+	documents := [][]string{}
+	for _, pastSession := range s.sessionRepository.FindAllSessions(nil) {
+		if pastSession.Note == "" || len(pastSession.Tags) == 0 {
+			continue
+		}
+		if pastSession.Equals(*currentSession) {
+			continue
+		}
+
+		pastSessions = append(pastSessions, pastSession)
+		documents = append(documents, terms(pastSession.Note))
+	}
+
+	idf := inverseDocumentFrequencies(documents)
+	noteVector := tfidfVector(noteTerms, idf)
+
+	scores := map[string]float64{}
+	for i, pastSession := range pastSessions {
+		if len(documents[i]) == 0 {
+			continue
+		}
+
+		similarity := cosineSimilarity(noteVector, tfidfVector(documents[i], idf))
+		if similarity == 0 {
+			continue
+		}
+
+		for _, tag := range pastSession.Tags {
+			if currentSession.HasTag(tag) {
+				continue
+			}
+			scores[tag] += similarity
+		}
+	}
+
+	for tag := range scores {
+		suggestions = append(suggestions, tag)
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		if scores[suggestions[i]] == scores[suggestions[j]] {
+			return suggestions[i] < suggestions[j]
+		}
+		return scores[suggestions[i]] > scores[suggestions[j]]
+	})
+
+	if len(suggestions) > MaxSuggestions {
+		suggestions = suggestions[:MaxSuggestions]
+	}
+
+	return suggestions, nil
+}
+
+var ErrNoCurrentSession = errors.New("there is no flow session in progress")
+
+func NewSuggestTagsUseCase(sessionRepository application.SessionRepository) UseCase {
+	return UseCase{
+		sessionRepository: sessionRepository,
+	}
+}
@@ -0,0 +1,6 @@
+package suggesttags
+
+type Command struct {
+	// Note is compared to the notes of the past sessions
+	Note string
+}
@@ -0,0 +1,102 @@
+package suggesttags_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/suggesttags"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/tests"
+)
+
+func TestSuggestTags(t *testing.T) {
+	currentSession := session.Session{
+		Id:        "4",
+		StartTime: time.Date(2024, time.April, 13, 9, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+	}
+
+	pastSessions := []session.Session{
+		{
+			Id:        "1",
+			StartTime: time.Date(2024, time.April, 10, 9, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, time.April, 10, 10, 0, 0, 0, time.UTC),
+			Project:   "Flow",
+			Tags:      []string{"report"},
+			Note:      "Fixed the alignment of the report table",
+		},
+		{
+			Id:        "2",
+			StartTime: time.Date(2024, time.April, 11, 9, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, time.April, 11, 10, 0, 0, 0, time.UTC),
+			Project:   "Flow",
+			Tags:      []string{"report", "json"},
+			Note:      "Added a json output to the report",
+		},
+		{
+			Id:        "3",
+			StartTime: time.Date(2024, time.April, 12, 9, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, time.April, 12, 10, 0, 0, 0, time.UTC),
+			Project:   "Flow",
+			Tags:      []string{"docs"},
+			Note:      "Documented the commands",
+		},
+	}
+
+	tt := []struct {
+		name          string
+		givenSessions []session.Session
+		note          string
+		want          []string
+	}{
+		{
+			name:          "Empty note",
+			givenSessions: append(pastSessions, currentSession),
+			note:          "",
+			want:          []string{},
+		},
+		{
+			name:          "No similar note",
+			givenSessions: append(pastSessions, currentSession),
+			note:          "Refactored the lockfile",
+			want:          []string{},
+		},
+		{
+			name:          "Most similar notes first",
+			givenSessions: append(pastSessions, currentSession),
+			note:          "Report json output",
+			want:          []string{"report", "json"},
+		},
+		{
+			name: "Leaves out the tags of the current session",
+			givenSessions: append(pastSessions, session.Session{
+				Id:        currentSession.Id,
+				StartTime: currentSession.StartTime,
+				Project:   currentSession.Project,
+				Tags:      []string{"report"},
+			}),
+			note: "Report json output",
+			want: []string{"json"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			f := tests.GetSessionFixture(t)
+
+			f.GivenSomeSessions(tc.givenSessions)
+
+			f.WhenSuggestingTags(suggesttags.Command{Note: tc.note})
+
+			f.ThenSuggestedTagsShouldBe(tc.want)
+		})
+	}
+}
+
+func TestSuggestTags_NoCurrentSession(t *testing.T) {
+	f := tests.GetSessionFixture(t)
+
+	f.WhenSuggestingTags(suggesttags.Command{Note: "Report json output"})
+
+	f.ThenErrorShouldBe(suggesttags.ErrNoCurrentSession)
+}
@@ -0,0 +1,87 @@
+package suggesttags
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// minTermLength drops short words like "a" or "to" that carry no meaning
+const minTermLength = 3
+
+// stopWords are frequent words that would make unrelated notes look similar
+var stopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "from": true,
+	"that": true, "this": true, "into": true, "was": true, "were": true,
+	"are": true, "has": true, "have": true, "not": true, "but": true,
+	"some": true, "more": true, "when": true, "then": true, "its": true,
+}
+
+type vector map[string]float64
+
+func terms(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	terms := []string{}
+	for _, word := range words {
+		if len([]rune(word)) >= minTermLength && !stopWords[word] {
+			terms = append(terms, word)
+		}
+	}
+
+	return terms
+}
+
+// inverseDocumentFrequencies weights each term by how rare it is among the
+// documents, so words used in every note don't make them look similar.
+func inverseDocumentFrequencies(documents [][]string) map[string]float64 {
+	frequencies := map[string]int{}
+	for _, document := range documents {
+		seen := map[string]bool{}
+		for _, term := range document {
+			if !seen[term] {
+				frequencies[term]++
+				seen[term] = true
+			}
+		}
+	}
+
+	idf := map[string]float64{}
+	for term, frequency := range frequencies {
+		idf[term] = math.Log(float64(1+len(documents))/float64(1+frequency)) + 1
+	}
+
+	return idf
+}
+
+func tfidfVector(document []string, idf map[string]float64) vector {
+	v := vector{}
+	for _, term := range document {
+		v[term]++
+	}
+
+	for term, count := range v {
+		v[term] = count / float64(len(document)) * idf[term]
+	}
+
+	return v
+}
+
+func cosineSimilarity(a vector, b vector) float64 {
+	var dot, normA, normB float64
+	for term, weight := range a {
+		dot += weight * b[term]
+		normA += weight * weight
+	}
+	for _, weight := range b {
+		normB += weight * weight
+	}
+
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
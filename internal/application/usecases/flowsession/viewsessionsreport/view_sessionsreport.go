@@ -1,49 +1,187 @@
 package viewsessionsreport
 
 import (
+	"errors"
+	"time"
+
 	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/project"
+	"github.com/TristanShz/flow/internal/domain/session"
 	"github.com/TristanShz/flow/internal/domain/sessionsreport"
 	"github.com/TristanShz/flow/pkg/timerange"
 )
 
+var (
+	ErrGapsWithoutSince    = errors.New("the gaps report needs the start of its period")
+	ErrCompareWithoutSince = errors.New("a comparison needs the start of both periods")
+	ErrCompareWithFormat   = errors.New("a comparison has no format")
+)
+
 type UseCase struct {
 	sessionRepository application.SessionRepository
+	projectRepository application.ProjectRepository
+	journalRepository application.JournalRepository
+	dateProvider      application.DateProvider
 }
 
 func (s UseCase) Execute(
 	command Command,
 	presenter application.SessionsReportPresenter,
 ) error {
-	filters := &application.SessionsFilters{}
+	if command.Format == sessionsreport.FormatGaps && command.Since.IsZero() {
+		return ErrGapsWithoutSince
+	}
+
+	comparing := !command.CompareSince.IsZero() || !command.CompareUntil.IsZero()
+	if comparing && (command.Since.IsZero() || command.CompareSince.IsZero()) {
+		return ErrCompareWithoutSince
+	}
+	if comparing && command.Format != "" {
+		return ErrCompareWithFormat
+	}
+
+	if comparing {
+		sessions, err := s.findSessions(command, command.Since, command.Until)
+		if err != nil {
+			return err
+		}
+		previous, err := s.findSessions(command, command.CompareSince, command.CompareUntil)
+		if err != nil {
+			return err
+		}
+		presenter.ShowComparison(sessionsreport.NewPeriodsDiff(previous, sessions))
+		return nil
+	}
+
+	switch command.Format {
+	case sessionsreport.FormatByProject, sessionsreport.FormatByClient:
+		totals := sessionsreport.NewProjectTotals(s.projectRepository.FindAll())
+		err := s.forEachSession(command, command.Since, command.Until, func(sess session.Session) error {
+			totals.Add(sess)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		sessionsReport := sessionsreport.SessionsReport{Projects: totals.Projects, Totals: totals}
+		if command.Format == sessionsreport.FormatByProject {
+			presenter.ShowByProject(sessionsReport)
+		} else {
+			presenter.ShowByClient(sessionsReport)
+		}
+	case sessionsreport.FormatEarnings:
+		totals := sessionsreport.NewEarningsTotals(s.projectRepository.FindAll())
+		err := s.forEachSession(command, command.Since, command.Until, func(sess session.Session) error {
+			totals.Add(sess)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		presenter.ShowEarnings(totals.Report())
+	case sessionsreport.FormatGaps:
+		sessions, err := s.findSessions(command, command.Since, command.Until)
+		if err != nil {
+			return err
+		}
+
+		presenter.ShowGaps(sessionsreport.NewGapsReport(
+			sessions,
+			timerange.TimeRange{Since: command.Since, Until: command.Until},
+			command.WorkingHours,
+			command.GapThreshold,
+			s.dateProvider.GetNow(),
+		))
+	default:
+		sessions, err := s.findSessions(command, command.Since, command.Until)
+		if err != nil {
+			return err
+		}
+
+		presenter.ShowByDay(sessionsreport.SessionsReport{
+			Sessions: sessions,
+			Journal: s.journalRepository.FindAll(timerange.TimeRange{
+				Since: command.Since,
+				Until: command.Until,
+			}),
+		})
+	}
+
+	return nil
+}
+
+// findSessions returns the sessions of the period matching the filters of
+// the command, for the reports which need all of them at once
+func (s UseCase) findSessions(command Command, since time.Time, until time.Time) ([]session.Session, error) {
+	sessions := []session.Session{}
+	err := s.forEachSession(command, since, until, func(sess session.Session) error {
+		sessions = append(sessions, sess)
+		return nil
+	})
+
+	return sessions, err
+}
+
+// forEachSession passes fn the sessions of the period matching the filters
+// of the command one after the other, rounded a few days at a time so that
+// the totals reports don't hold every session. The client of a session comes
+// from the settings of its project unless the session has its own client.
+func (s UseCase) forEachSession(command Command, since time.Time, until time.Time, fn func(session.Session) error) error {
+	filters := &application.SessionsFilters{Where: command.Where}
 
 	if command.Project != "" {
 		filters.Project = command.Project
 	}
 
-	if !command.Since.IsZero() || !command.Until.IsZero() {
+	if len(command.Tags) > 0 {
+		filters.Tags = command.Tags
+		filters.TagsMatch = command.TagsMatch
+	}
+
+	if !since.IsZero() || !until.IsZero() {
 		filters.Timerange = timerange.TimeRange{
-			Since: command.Since,
-			Until: command.Until,
+			Since: since,
+			Until: until,
 		}
 	}
 
-	sessions := s.sessionRepository.FindAllSessions(filters)
+	var projects []project.Project
+	if command.Client != "" {
+		projects = s.projectRepository.FindAll()
+	}
 
-	sessionsReport := sessionsreport.SessionsReport{
-		Sessions: sessions,
+	rounding := command.Rounding
+	if command.Format == sessionsreport.FormatGaps {
+		rounding = session.Rounding{}
 	}
 
-	if command.Format == sessionsreport.FormatByProject {
-		presenter.ShowByProject(sessionsReport)
-	} else {
-		presenter.ShowByDay(sessionsReport)
+	buffer := session.NewDaysBuffer(rounding.Apply, fn)
+	err := s.sessionRepository.ForEachSession(filters, func(sess session.Session) error {
+		if command.Client != "" && project.Find(projects, sess.Project).ClientOf(sess) != command.Client {
+			return nil
+		}
+
+		return buffer.Add(sess)
+	})
+	if err != nil {
+		return err
 	}
 
-	return nil
+	return buffer.Flush()
 }
 
-func NewViewSessionsReportUseCase(sessionRepository application.SessionRepository) UseCase {
+func NewViewSessionsReportUseCase(
+	sessionRepository application.SessionRepository,
+	projectRepository application.ProjectRepository,
+	journalRepository application.JournalRepository,
+	dateProvider application.DateProvider,
+) UseCase {
 	return UseCase{
 		sessionRepository: sessionRepository,
+		projectRepository: projectRepository,
+		journalRepository: journalRepository,
+		dateProvider:      dateProvider,
 	}
 }
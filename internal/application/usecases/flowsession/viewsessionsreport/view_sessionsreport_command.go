@@ -1,10 +1,32 @@
 package viewsessionsreport
 
-import "time"
+import (
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+)
 
 type Command struct {
 	Since   time.Time
 	Until   time.Time
 	Project string
-	Format  string
+	// Client keeps the sessions billed to the client, see project.ClientOf
+	Client    string
+	Format    string
+	Tags      []string
+	TagsMatch string
+	// Where keeps the sessions matching a where expression, see application.ParseWhere
+	Where application.Condition
+	// WorkingHours and GapThreshold are the hours in which the gaps report
+	// looks for untracked time and the shortest gap it lists
+	WorkingHours session.TagRule
+	GapThreshold time.Duration
+	// CompareSince and CompareUntil are the period the report is compared to,
+	// the report then shows the time gained or lost by each project and tag
+	CompareSince time.Time
+	CompareUntil time.Time
+	// Rounding rounds the durations of the sessions reported, the gaps
+	// report isn't rounded
+	Rounding session.Rounding
 }
@@ -4,7 +4,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/TristanShz/flow/internal/application"
 	"github.com/TristanShz/flow/internal/application/usecases/flowsession/viewsessionsreport"
+	"github.com/TristanShz/flow/internal/domain/journal"
+	"github.com/TristanShz/flow/internal/domain/project"
 	"github.com/TristanShz/flow/internal/domain/session"
 	"github.com/TristanShz/flow/internal/domain/sessionsreport"
 	"github.com/TristanShz/flow/internal/tests"
@@ -295,6 +298,94 @@ func TestViewSessionsReport(t *testing.T) {
 			}),
 			expectedFormat: sessionsreport.FormatByDay,
 		},
+		{
+			name: "View sessions having all the given tags",
+			command: viewsessionsreport.Command{
+				Tags:      []string{"start-usecase"},
+				TagsMatch: application.TagsMatchAll,
+			},
+			givenSessions: sessionsForTest,
+			want: sessionsreport.NewSessionsReport([]session.Session{
+				{
+					Id:        "2",
+					StartTime: time.Date(2024, time.April, 14, 14, 12, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.April, 14, 15, 12, 0, 0, time.UTC),
+					Project:   "Flow",
+					Tags:      []string{"start-usecase"},
+				},
+				{
+					Id:        "5",
+					StartTime: time.Date(2024, time.April, 15, 14, 0, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.April, 15, 16, 0, 0, 0, time.UTC),
+					Project:   "Flow",
+					Tags:      []string{"start-usecase"},
+				},
+			}),
+			expectedFormat: sessionsreport.FormatByDay,
+		},
+		{
+			name: "View sessions having one of the given tags",
+			command: viewsessionsreport.Command{
+				Tags: []string{"deploy", "report-pomodoro"},
+			},
+			givenSessions: sessionsForTest,
+			want: sessionsreport.NewSessionsReport([]session.Session{
+				{
+					Id:        "8",
+					StartTime: time.Date(2024, time.April, 18, 16, 24, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.April, 18, 18, 24, 30, 0, time.UTC),
+					Project:   "Pomodoro",
+					Tags:      []string{"report-pomodoro"},
+				},
+				{
+					Id:        "9",
+					StartTime: time.Date(2024, time.April, 20, 8, 0, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.April, 20, 12, 0, 0, 0, time.UTC),
+					Project:   "MyTodo",
+					Tags:      []string{"deploy"},
+				},
+			}),
+			expectedFormat: sessionsreport.FormatByDay,
+		},
+		{
+			name: "Rounded durations",
+			command: viewsessionsreport.Command{
+				Tags:     []string{"report-pomodoro"},
+				Rounding: session.Rounding{Increment: 15 * time.Minute, Method: session.RoundUp},
+			},
+			givenSessions: sessionsForTest,
+			want: sessionsreport.NewSessionsReport([]session.Session{
+				{
+					Id:        "8",
+					StartTime: time.Date(2024, time.April, 18, 16, 24, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.April, 18, 18, 39, 0, 0, time.UTC),
+					Project:   "Pomodoro",
+					Tags:      []string{"report-pomodoro"},
+				},
+			}),
+			expectedFormat: sessionsreport.FormatByDay,
+		},
+		{
+			name: "Durations rounded per day by project",
+			command: viewsessionsreport.Command{
+				Format:   sessionsreport.FormatByProject,
+				Project:  "Flow",
+				Rounding: session.Rounding{Increment: time.Hour, Per: session.RoundPerDay},
+			},
+			givenSessions: sessionsForTest,
+			want: sessionsreport.NewSessionsReport([]session.Session{
+				sessionsForTest[1],
+				{
+					Id:        "3",
+					StartTime: time.Date(2024, time.April, 14, 16, 24, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.April, 14, 18, 24, 0, 0, time.UTC),
+					Project:   "Flow",
+					Tags:      []string{"report-usecase"},
+				},
+				sessionsForTest[4],
+			}),
+			expectedFormat: sessionsreport.FormatByProject,
+		},
 	}
 
 	for _, tc := range tt {
@@ -309,3 +400,174 @@ func TestViewSessionsReport(t *testing.T) {
 		})
 	}
 }
+
+func TestViewEarningsReport(t *testing.T) {
+	f := tests.GetSessionFixture(t)
+
+	f.GivenSomeSessions(sessionsForTest)
+	f.GivenSomeProjects([]project.Project{
+		{Name: "Flow", Client: "Acme", Billable: true, HourlyRate: 60},
+	})
+
+	f.WhenUserSeesSessionsReport(viewsessionsreport.Command{
+		Format: sessionsreport.FormatEarnings,
+		Since:  time.Date(2024, time.April, 15, 0, 0, 0, 0, time.UTC),
+		Until:  time.Date(2024, time.April, 16, 0, 0, 0, 0, time.UTC),
+	})
+
+	f.ThenUserShouldSeeEarningsReport(sessionsreport.EarningsReport{
+		Clients: []sessionsreport.ClientEarnings{
+			{
+				Client:           "Acme",
+				Projects:         []sessionsreport.ProjectEarnings{{Project: "Flow", BillableDuration: 2 * time.Hour, Earnings: 120}},
+				BillableDuration: 2 * time.Hour,
+				Earnings:         120,
+			},
+		},
+		BillableDuration: 2 * time.Hour,
+		Earnings:         120,
+	})
+}
+
+func TestViewSessionsReportByClient(t *testing.T) {
+	projects := []project.Project{
+		{Name: "Flow", Client: "Acme"},
+		{Name: "MyTodo", Client: "Globex"},
+	}
+
+	f := tests.GetSessionFixture(t)
+
+	f.GivenSomeSessions(sessionsForTest)
+	f.GivenSomeProjects(projects)
+
+	f.WhenUserSeesSessionsReport(viewsessionsreport.Command{
+		Format: sessionsreport.FormatByClient,
+		Client: "Acme",
+	})
+
+	f.ThenUserShouldSeeSessionsReport(sessionsreport.SessionsReport{
+		Sessions: []session.Session{sessionsForTest[1], sessionsForTest[2], sessionsForTest[4]},
+		Projects: projects,
+	}, sessionsreport.FormatByClient)
+}
+
+func TestViewSessionsReport_Journal(t *testing.T) {
+	f := tests.GetSessionFixture(t)
+
+	release := journal.Entry{Day: time.Date(2024, time.April, 14, 0, 0, 0, 0, time.UTC), Note: "shipped the release"}
+	outage := journal.Entry{Day: time.Date(2024, time.April, 21, 0, 0, 0, 0, time.UTC), Note: "outage"}
+
+	f.GivenSomeSessions(sessionsForTest)
+	f.GivenSomeJournalEntries([]journal.Entry{release, outage})
+
+	f.WhenUserSeesSessionsReport(viewsessionsreport.Command{
+		Since: time.Date(2024, time.April, 14, 0, 0, 0, 0, time.UTC),
+		Until: time.Date(2024, time.April, 14, 23, 59, 59, 0, time.UTC),
+	})
+
+	want := sessionsreport.NewSessionsReport(sessionsForTest[:3:3])
+	want.Journal = []journal.Entry{release}
+	f.ThenUserShouldSeeSessionsReport(want, sessionsreport.FormatByDay)
+}
+
+func TestViewGapsReport(t *testing.T) {
+	f := tests.GetSessionFixture(t)
+
+	f.GivenSomeSessions(sessionsForTest)
+	f.GivenNowIs(time.Date(2024, time.April, 20, 16, 0, 0, 0, time.UTC))
+
+	f.WhenUserSeesSessionsReport(viewsessionsreport.Command{
+		Format:       sessionsreport.FormatGaps,
+		Since:        time.Date(2024, time.April, 15, 0, 0, 0, 0, time.UTC),
+		Until:        time.Date(2024, time.April, 16, 23, 59, 59, 0, time.UTC),
+		WorkingHours: session.TagRule{After: 9 * time.Hour, Before: 17 * time.Hour},
+		GapThreshold: time.Hour,
+	})
+
+	f.ThenUserShouldSeeGapsReport(sessionsreport.GapsReport{
+		Gaps: []sessionsreport.Gap{
+			{Start: time.Date(2024, time.April, 15, 12, 0, 0, 0, time.UTC), End: time.Date(2024, time.April, 15, 14, 0, 0, 0, time.UTC)},
+			{Start: time.Date(2024, time.April, 15, 16, 0, 0, 0, time.UTC), End: time.Date(2024, time.April, 15, 17, 0, 0, 0, time.UTC)},
+			{Start: time.Date(2024, time.April, 16, 9, 0, 0, 0, time.UTC), End: time.Date(2024, time.April, 16, 10, 12, 0, 0, time.UTC)},
+			{Start: time.Date(2024, time.April, 16, 13, 12, 0, 0, time.UTC), End: time.Date(2024, time.April, 16, 14, 12, 0, 0, time.UTC)},
+			{Start: time.Date(2024, time.April, 16, 15, 12, 0, 0, time.UTC), End: time.Date(2024, time.April, 16, 17, 0, 0, 0, time.UTC)},
+		},
+		WorkingTime: 16 * time.Hour,
+		Untracked:   7 * time.Hour,
+	})
+}
+
+func TestViewGapsReport_WithoutSince(t *testing.T) {
+	f := tests.GetSessionFixture(t)
+
+	f.WhenUserSeesSessionsReport(viewsessionsreport.Command{Format: sessionsreport.FormatGaps})
+
+	f.ThenErrorShouldBe(viewsessionsreport.ErrGapsWithoutSince)
+}
+
+func TestViewComparison(t *testing.T) {
+	f := tests.GetSessionFixture(t)
+
+	f.GivenSomeSessions(sessionsForTest)
+
+	f.WhenUserSeesSessionsReport(viewsessionsreport.Command{
+		Since:        time.Date(2024, time.April, 16, 0, 0, 0, 0, time.UTC),
+		Until:        time.Date(2024, time.April, 16, 23, 59, 59, 0, time.UTC),
+		CompareSince: time.Date(2024, time.April, 15, 0, 0, 0, 0, time.UTC),
+		CompareUntil: time.Date(2024, time.April, 15, 23, 59, 59, 0, time.UTC),
+	})
+
+	f.ThenUserShouldSeeComparison(sessionsreport.PeriodsDiff{
+		Projects: []sessionsreport.ProjectDiff{
+			{Project: "Pomodoro", B: time.Hour},
+			{Project: "MyTodo", A: 4 * time.Hour, B: 3 * time.Hour},
+			{Project: "Flow", A: 2 * time.Hour},
+		},
+		Tags: []sessionsreport.TagDiff{
+			{Tag: "delete-todo", B: 3 * time.Hour},
+			{Tag: "start-pomodoro", B: time.Hour},
+			{Tag: "start-usecase", A: 2 * time.Hour},
+			{Tag: "edit-todo", A: 4 * time.Hour},
+		},
+		A: sessionsreport.PeriodStats{Sessions: 2, Duration: 6 * time.Hour},
+		B: sessionsreport.PeriodStats{Sessions: 2, Duration: 4 * time.Hour},
+	})
+}
+
+func TestViewComparison_Errors(t *testing.T) {
+	tt := []struct {
+		name    string
+		command viewsessionsreport.Command
+		want    error
+	}{
+		{
+			name:    "Without the start of the compared period",
+			command: viewsessionsreport.Command{Since: time.Date(2024, time.April, 16, 0, 0, 0, 0, time.UTC), CompareUntil: time.Date(2024, time.April, 15, 0, 0, 0, 0, time.UTC)},
+			want:    viewsessionsreport.ErrCompareWithoutSince,
+		},
+		{
+			name:    "Without the start of the period",
+			command: viewsessionsreport.Command{CompareSince: time.Date(2024, time.April, 15, 0, 0, 0, 0, time.UTC)},
+			want:    viewsessionsreport.ErrCompareWithoutSince,
+		},
+		{
+			name: "With a format",
+			command: viewsessionsreport.Command{
+				Since:        time.Date(2024, time.April, 16, 0, 0, 0, 0, time.UTC),
+				CompareSince: time.Date(2024, time.April, 15, 0, 0, 0, 0, time.UTC),
+				Format:       sessionsreport.FormatByProject,
+			},
+			want: viewsessionsreport.ErrCompareWithFormat,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			f := tests.GetSessionFixture(t)
+
+			f.WhenUserSeesSessionsReport(tc.command)
+
+			f.ThenErrorShouldBe(tc.want)
+		})
+	}
+}
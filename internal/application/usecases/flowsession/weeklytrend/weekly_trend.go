@@ -0,0 +1,86 @@
+package weeklytrend
+
+import (
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/pkg/timerange"
+)
+
+type UseCase struct {
+	sessionRepository application.SessionRepository
+	dailyTotalsCache  application.DailyTotalsCache
+	dateProvider      application.DateProvider
+}
+
+// Execute returns the total flow duration of each of the last given weeks,
+// from the oldest one to the current week. The weeks are summed from the
+// daily totals, which are only computed from the sessions when they aren't
+// cached, see BenchmarkWeeklyTrend.
+func (s UseCase) Execute(command Command) ([]time.Duration, error) {
+	if command.Weeks <= 0 {
+		return []time.Duration{}, nil
+	}
+
+	currentWeek := timerange.NewWeekTimeRangeFrom(s.dateProvider.GetNow(), command.WeekStart)
+	trendRange := timerange.TimeRange{
+		Since: currentWeek.Since.AddDate(0, 0, -7*(command.Weeks-1)),
+		Until: currentWeek.Until,
+	}
+
+	days := timerange.Buckets(trendRange, timerange.ByDay, command.WeekStart)
+	dailyTotals, ok := s.dailyTotalsCache.FindDailyTotals(trendRange)
+	if !ok || len(dailyTotals) != len(days) {
+		var err error
+		dailyTotals, err = s.computeDailyTotals(trendRange, days)
+		if err != nil {
+			return nil, err
+		}
+
+		s.dailyTotalsCache.SaveDailyTotals(trendRange, dailyTotals)
+	}
+
+	weeks := timerange.Buckets(trendRange, timerange.ByWeek, command.WeekStart)
+	totals := make([]time.Duration, len(weeks))
+	for dayIndex, day := range days {
+		for index, week := range weeks {
+			if week.Contains(day.Since) {
+				totals[index] += dailyTotals[dayIndex]
+				break
+			}
+		}
+	}
+
+	return totals, nil
+}
+
+// computeDailyTotals reads the sessions of the time range, the session in
+// progress has no duration yet so the totals stay valid while it flows
+func (s UseCase) computeDailyTotals(trendRange timerange.TimeRange, days []timerange.TimeRange) ([]time.Duration, error) {
+	totals := make([]time.Duration, len(days))
+	err := s.sessionRepository.ForEachSession(&application.SessionsFilters{
+		Timerange: trendRange,
+	}, func(session session.Session) error {
+		for index, day := range days {
+			if day.Contains(session.StartTime) {
+				totals[index] += session.Duration()
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return totals, nil
+}
+
+func NewWeeklyTrendUseCase(sessionRepository application.SessionRepository, dailyTotalsCache application.DailyTotalsCache, dateProvider application.DateProvider) UseCase {
+	return UseCase{
+		sessionRepository: sessionRepository,
+		dailyTotalsCache:  dailyTotalsCache,
+		dateProvider:      dateProvider,
+	}
+}
@@ -0,0 +1,9 @@
+package weeklytrend
+
+import "time"
+
+type Command struct {
+	// Weeks is the number of weeks of the trend, the current one included
+	Weeks     int
+	WeekStart time.Weekday
+}
@@ -0,0 +1,199 @@
+package weeklytrend_test
+
+import (
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/weeklytrend"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/TristanShz/flow/internal/infra/filesystem"
+	"github.com/TristanShz/flow/internal/tests"
+	"github.com/TristanShz/flow/pkg/timerange"
+)
+
+func TestWeeklyTrend(t *testing.T) {
+	tt := []struct {
+		name          string
+		weeks         int
+		weekStart     time.Weekday
+		givenSessions []session.Session
+		want          []time.Duration
+	}{
+		{
+			name:          "No sessions",
+			weeks:         3,
+			givenSessions: []session.Session{},
+			want:          []time.Duration{0, 0, 0},
+		},
+		{
+			name:      "Sessions spread over weeks",
+			weeks:     3,
+			weekStart: time.Monday,
+			givenSessions: []session.Session{
+				{
+					Id:        "1",
+					StartTime: time.Date(2024, time.March, 20, 10, 0, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.March, 20, 12, 0, 0, 0, time.UTC),
+					Project:   "Flow",
+				},
+				{
+					Id:        "2",
+					StartTime: time.Date(2024, time.April, 2, 10, 0, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.April, 2, 11, 0, 0, 0, time.UTC),
+					Project:   "Flow",
+				},
+				{
+					Id:        "3",
+					StartTime: time.Date(2024, time.April, 15, 9, 0, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.April, 15, 12, 0, 0, 0, time.UTC),
+					Project:   "MyTodo",
+				},
+				{
+					Id:        "4",
+					StartTime: time.Date(2024, time.April, 17, 9, 0, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.April, 17, 9, 30, 0, 0, time.UTC),
+					Project:   "Flow",
+				},
+			},
+			want: []time.Duration{time.Hour, 0, 3*time.Hour + 30*time.Minute},
+		},
+		{
+			name:      "Sunday session in a week starting on monday",
+			weeks:     2,
+			weekStart: time.Monday,
+			givenSessions: []session.Session{
+				{
+					Id:        "1",
+					StartTime: time.Date(2024, time.April, 14, 10, 0, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.April, 14, 12, 0, 0, 0, time.UTC),
+					Project:   "Flow",
+				},
+			},
+			want: []time.Duration{2 * time.Hour, 0},
+		},
+		{
+			name:      "Sunday session in a week starting on sunday",
+			weeks:     2,
+			weekStart: time.Sunday,
+			givenSessions: []session.Session{
+				{
+					Id:        "1",
+					StartTime: time.Date(2024, time.April, 14, 10, 0, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.April, 14, 12, 0, 0, 0, time.UTC),
+					Project:   "Flow",
+				},
+			},
+			want: []time.Duration{0, 2 * time.Hour},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			f := tests.GetSessionFixture(t)
+
+			f.GivenNowIs(time.Date(2024, time.April, 17, 18, 0, 0, 0, time.UTC))
+			f.GivenSomeSessions(tc.givenSessions)
+
+			f.WhenUserSeesWeeklyTrend(weeklytrend.Command{Weeks: tc.weeks, WeekStart: tc.weekStart})
+
+			f.ThenWeeklyTrendShouldBe(tc.want)
+		})
+	}
+}
+
+func TestWeeklyTrend_DailyTotalsCache(t *testing.T) {
+	f := tests.GetSessionFixture(t)
+
+	f.GivenNowIs(time.Date(2024, time.April, 17, 18, 0, 0, 0, time.UTC))
+	f.GivenSomeSessions([]session.Session{
+		{
+			Id:        "1",
+			StartTime: time.Date(2024, time.April, 15, 9, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, time.April, 15, 12, 0, 0, 0, time.UTC),
+			Project:   "Flow",
+		},
+	})
+
+	f.WhenUserSeesWeeklyTrend(weeklytrend.Command{Weeks: 2, WeekStart: time.Monday})
+	f.ThenWeeklyTrendShouldBe([]time.Duration{0, 3 * time.Hour})
+	f.Is.Equal(len(f.DailyTotalsCache.Totals), 14) // the days of the two weeks
+
+	// the cached totals are summed without reading the sessions
+	f.DailyTotalsCache.Totals[0] = time.Hour
+	f.WhenUserSeesWeeklyTrend(weeklytrend.Command{Weeks: 2, WeekStart: time.Monday})
+	f.ThenWeeklyTrendShouldBe([]time.Duration{time.Hour, 3 * time.Hour})
+	f.Is.Equal(f.DailyTotalsCache.Saves, 1)
+
+	// the totals of another range are computed again
+	f.WhenUserSeesWeeklyTrend(weeklytrend.Command{Weeks: 3, WeekStart: time.Monday})
+	f.ThenWeeklyTrendShouldBe([]time.Duration{0, 0, 3 * time.Hour})
+	f.Is.Equal(f.DailyTotalsCache.Saves, 2)
+}
+
+// noDailyTotalsCache never holds the totals, they're computed every time
+type noDailyTotalsCache struct{}
+
+func (noDailyTotalsCache) FindDailyTotals(timerange.TimeRange) ([]time.Duration, bool) {
+	return nil, false
+}
+
+func (noDailyTotalsCache) SaveDailyTotals(timerange.TimeRange, []time.Duration) {}
+
+// BenchmarkWeeklyTrend measures the trend of 'flow status --trend' over a
+// flow folder holding two years of sessions, 6 a day
+func BenchmarkWeeklyTrend(b *testing.B) {
+	repository := filesystem.NewFileSystemSessionRepository(b.TempDir())
+
+	now := time.Date(2024, time.April, 17, 18, 0, 0, 0, time.UTC)
+	for day := 0; day < 2*365; day++ {
+		for i := 0; i < 6; i++ {
+			start := time.Date(2022, time.April, 18, 9+i, 0, 0, 0, time.UTC).AddDate(0, 0, day)
+			if err := repository.Save(session.Session{
+				Id:        strconv.Itoa(day*6 + i),
+				StartTime: start,
+				EndTime:   start.Add(45 * time.Minute),
+				Project:   "Flow",
+			}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+
+	// the sub-benchmarks save the sessions once only
+	b.Run("8 weeks", func(b *testing.B) {
+		useCase := weeklytrend.NewWeeklyTrendUseCase(&repository, &repository, &infra.StubDateProvider{Now: now})
+
+		// the totals are only cached for a folder left unchanged for a while,
+		// the first time creates the cache file which changes the folder again
+		unchanged := time.Now().Add(-time.Minute)
+		for range 2 {
+			if err := os.Chtimes(repository.FlowFolderPath, unchanged, unchanged); err != nil {
+				b.Fatal(err)
+			}
+			if _, err := useCase.Execute(weeklytrend.Command{Weeks: 8, WeekStart: time.Monday}); err != nil {
+				b.Fatal(err)
+			}
+		}
+
+		b.ResetTimer()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := useCase.Execute(weeklytrend.Command{Weeks: 8, WeekStart: time.Monday}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("8 weeks without the cache", func(b *testing.B) {
+		useCase := weeklytrend.NewWeeklyTrendUseCase(&repository, noDailyTotalsCache{}, &infra.StubDateProvider{Now: now})
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := useCase.Execute(weeklytrend.Command{Weeks: 8, WeekStart: time.Monday}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
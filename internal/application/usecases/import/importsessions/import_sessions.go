@@ -0,0 +1,192 @@
+package importsessions
+
+import (
+	"errors"
+	"maps"
+	"slices"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+type UseCase struct {
+	sessionRepository         application.SessionRepository
+	importConflictsRepository application.ImportConflictsRepository
+	idProvider                application.IDProvider
+}
+
+// Execute saves the sessions of the importer. A session imported before, with
+// the same external id or the same start time, is updated instead of being
+// imported twice, so that importing again keeps flow in sync with the tracker.
+// The running sessions and the new sessions overlapping flow sessions aren't
+// saved, they're kept as the conflicts of the import to be resolved later.
+func (s UseCase) Execute(command Command, importer application.SessionsImporter) (Result, error) {
+	imported, err := importer.Import()
+	if err != nil {
+		return Result{}, err
+	}
+
+	existing := s.sessionRepository.FindAllSessions(nil)
+	byExternalID := map[string]session.Session{}
+	byStartTime := map[int64]session.Session{}
+	for _, e := range existing {
+		if externalID := e.Metadata[session.ExternalIDMetadata]; externalID != "" {
+			byExternalID[externalID] = e
+		}
+		byStartTime[e.StartTime.Unix()] = e
+	}
+
+	result := Result{}
+	for _, i := range imported {
+		if i.EndTime.IsZero() {
+			if mapped, err := mapSession(command, i); err == nil {
+				i = mapped
+			}
+			result.Skipped++
+			result.Conflicts = append(result.Conflicts, runningConflict(i))
+			continue
+		}
+
+		i, err = mapSession(command, i)
+		if err != nil {
+			return result, err
+		}
+
+		previous, found := byExternalID[i.Metadata[session.ExternalIDMetadata]]
+		if !found || i.Metadata[session.ExternalIDMetadata] == "" {
+			previous, found = byStartTime[i.StartTime.Unix()]
+		}
+
+		if !found {
+			if overlapping := i.Overlapping(existing); len(overlapping) > 0 {
+				result.Conflicts = append(result.Conflicts, overlapConflict(i, existing, overlapping))
+				continue
+			}
+			i.Id = s.idProvider.Provide()
+			result.Imported++
+		} else {
+			updated := update(previous, i)
+			if sameSession(updated, previous) {
+				result.Unchanged++
+				continue
+			}
+			i = updated
+			result.Updated++
+		}
+
+		if command.DryRun {
+			continue
+		}
+		if err := s.sessionRepository.Save(i); err != nil {
+			return result, err
+		}
+		byStartTime[i.StartTime.Unix()] = i
+	}
+
+	if command.DryRun {
+		return result, nil
+	}
+
+	return result, s.importConflictsRepository.Save(result.Conflicts)
+}
+
+func runningConflict(s session.Session) application.ImportConflict {
+	return application.ImportConflict{
+		Reason:     application.ConflictRunning,
+		Session:    s,
+		Resolution: application.ResolutionSkip,
+		Suggestion: "the session is still running in the tracker, import again once it's stopped, or set its end time and resolve it with import",
+	}
+}
+
+// overlapConflict suggests to adjust the session when some of it isn't
+// tracked in flow yet, and to skip it otherwise
+func overlapConflict(s session.Session, existing []session.Session, overlapping []session.Session) application.ImportConflict {
+	conflict := application.ImportConflict{
+		Reason:  application.ConflictOverlap,
+		Session: s,
+	}
+	for _, o := range overlapping {
+		conflict.Conflicting = append(conflict.Conflicting, o.Id)
+	}
+
+	if _, err := s.CheckOverlaps(existing, session.OverlapAdjust); err != nil {
+		conflict.Resolution = application.ResolutionSkip
+		conflict.Suggestion = "the time of the session is already tracked in flow, skip it, or import it to keep both sessions"
+	} else {
+		conflict.Resolution = application.ResolutionAdjust
+		conflict.Suggestion = "adjust it to only import the time not tracked in flow yet, import it to keep both sessions, or skip it"
+	}
+
+	return conflict
+}
+
+// mapSession renames the project and the tags of an imported session
+func mapSession(command Command, s session.Session) (session.Session, error) {
+	if project, ok := command.Projects[s.Project]; ok {
+		s.Project = project
+	}
+	if s.Project == "" {
+		s.Project = command.DefaultProject
+	}
+	if s.Project == "" {
+		return session.Session{}, ErrEmptyProject
+	}
+
+	tags := []string{}
+	for _, tag := range s.Tags {
+		if mapped, ok := command.Tags[tag]; ok {
+			tag = mapped
+		}
+		if tag != "" && !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	s.Tags = tags
+
+	return s, nil
+}
+
+// update applies what the tracker knows about a session to the flow session,
+// keeping what only flow knows like its id or its billing overrides
+func update(previous session.Session, imported session.Session) session.Session {
+	updated := previous
+	updated.StartTime = imported.StartTime
+	updated.EndTime = imported.EndTime
+	updated.Project = imported.Project
+	updated.Tags = imported.Tags
+	updated.Note = imported.Note
+
+	if externalID := imported.Metadata[session.ExternalIDMetadata]; externalID != "" {
+		updated.Metadata = maps.Clone(previous.Metadata)
+		if updated.Metadata == nil {
+			updated.Metadata = map[string]string{}
+		}
+		updated.Metadata[session.ExternalIDMetadata] = externalID
+	}
+
+	return updated
+}
+
+func sameSession(a session.Session, b session.Session) bool {
+	return a.StartTime.Equal(b.StartTime) &&
+		a.EndTime.Equal(b.EndTime) &&
+		a.Project == b.Project &&
+		slices.Equal(a.Tags, b.Tags) &&
+		a.Note == b.Note &&
+		maps.Equal(a.Metadata, b.Metadata)
+}
+
+var ErrEmptyProject = errors.New("an imported session has no project, set a default project")
+
+func NewImportSessionsUseCase(
+	sessionRepository application.SessionRepository,
+	importConflictsRepository application.ImportConflictsRepository,
+	idProvider application.IDProvider,
+) UseCase {
+	return UseCase{
+		sessionRepository:         sessionRepository,
+		importConflictsRepository: importConflictsRepository,
+		idProvider:                idProvider,
+	}
+}
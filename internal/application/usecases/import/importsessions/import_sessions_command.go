@@ -0,0 +1,31 @@
+package importsessions
+
+import "github.com/TristanShz/flow/internal/application"
+
+// Command maps the projects and tags of the other time tracker to the ones of
+// flow, the names which aren't mapped are kept
+type Command struct {
+	// Projects maps the projects of the tracker to flow projects
+	Projects map[string]string
+	// Tags maps the tags of the tracker to flow tags, a tag mapped to an
+	// empty tag is dropped
+	Tags map[string]string
+	// DefaultProject is the project of the sessions without one
+	DefaultProject string
+	// DryRun counts the sessions which would be imported without saving them
+	DryRun bool
+}
+
+// Result counts the imported sessions
+type Result struct {
+	Imported int
+	// Updated sessions were imported before and changed since
+	Updated int
+	// Unchanged sessions were imported before, or are already in flow
+	Unchanged int
+	// Skipped sessions are still running in the tracker
+	Skipped int
+	// Conflicts are the sessions which weren't saved, the skipped ones and
+	// the new ones overlapping flow sessions
+	Conflicts []application.ImportConflict
+}
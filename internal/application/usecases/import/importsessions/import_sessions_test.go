@@ -0,0 +1,222 @@
+package importsessions_test
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/application/usecases/import/importsessions"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/matryer/is"
+)
+
+type testImporter struct {
+	sessions []session.Session
+}
+
+func (i testImporter) Import() ([]session.Session, error) {
+	return i.sessions, nil
+}
+
+type sequenceIDProvider struct {
+	next int
+}
+
+func (p *sequenceIDProvider) Provide() string {
+	p.next++
+	return "imported" + strconv.Itoa(p.next)
+}
+
+func at(day int, hour int) time.Time {
+	return time.Date(2024, time.April, day, hour, 0, 0, 0, time.UTC)
+}
+
+func TestImportSessions(t *testing.T) {
+	givenSessions := []session.Session{
+		{
+			Id:        "flow1",
+			StartTime: at(12, 9),
+			EndTime:   at(12, 10),
+			Project:   "flow",
+			Tags:      []string{"cli"},
+		},
+		{
+			Id:        "flow2",
+			StartTime: at(13, 9),
+			EndTime:   at(13, 10),
+			Project:   "flow",
+			Metadata:  map[string]string{session.ExternalIDMetadata: "toggl:2"},
+		},
+	}
+
+	tt := []struct {
+		name         string
+		imported     []session.Session
+		command      importsessions.Command
+		want         importsessions.Result
+		wantSessions []session.Session
+		wantErr      error
+	}{
+		{
+			name: "New session with mapped project and tags",
+			imported: []session.Session{{
+				StartTime: at(14, 9),
+				EndTime:   at(14, 11),
+				Project:   "Flow CLI",
+				Tags:      []string{"Deep Work", "billable"},
+				Note:      "import",
+				Metadata:  map[string]string{session.ExternalIDMetadata: "toggl:3"},
+			}},
+			command: importsessions.Command{
+				Projects: map[string]string{"Flow CLI": "flow"},
+				Tags:     map[string]string{"Deep Work": "deep", "billable": ""},
+			},
+			want: importsessions.Result{Imported: 1},
+			wantSessions: append(givenSessions[:2:2], session.Session{
+				Id:        "imported1",
+				StartTime: at(14, 9),
+				EndTime:   at(14, 11),
+				Project:   "flow",
+				Tags:      []string{"deep"},
+				Note:      "import",
+				Metadata:  map[string]string{session.ExternalIDMetadata: "toggl:3"},
+			}),
+		},
+		{
+			name: "Session imported before, changed in the tracker",
+			imported: []session.Session{{
+				StartTime: at(13, 8),
+				EndTime:   at(13, 10),
+				Project:   "flow",
+				Metadata:  map[string]string{session.ExternalIDMetadata: "toggl:2"},
+			}},
+			want: importsessions.Result{Updated: 1},
+			wantSessions: []session.Session{givenSessions[0], {
+				Id:        "flow2",
+				StartTime: at(13, 8),
+				EndTime:   at(13, 10),
+				Project:   "flow",
+				Tags:      []string{},
+				Metadata:  map[string]string{session.ExternalIDMetadata: "toggl:2"},
+			}},
+		},
+		{
+			name: "Session already in flow",
+			imported: []session.Session{{
+				StartTime: at(12, 9),
+				EndTime:   at(12, 10),
+				Project:   "flow",
+				Tags:      []string{"cli"},
+			}},
+			want:         importsessions.Result{Unchanged: 1},
+			wantSessions: givenSessions,
+		},
+		{
+			name: "Running session",
+			imported: []session.Session{{
+				StartTime: at(14, 9),
+				Project:   "flow",
+			}},
+			want: importsessions.Result{Skipped: 1, Conflicts: []application.ImportConflict{{
+				Reason: application.ConflictRunning,
+				Session: session.Session{
+					StartTime: at(14, 9),
+					Project:   "flow",
+					Tags:      []string{},
+				},
+				Resolution: application.ResolutionSkip,
+				Suggestion: "the session is still running in the tracker, import again once it's stopped, or set its end time and resolve it with import",
+			}}},
+			wantSessions: givenSessions,
+		},
+		{
+			name: "New session overlapping a flow session",
+			imported: []session.Session{{
+				StartTime: at(12, 8),
+				EndTime:   at(12, 11),
+				Project:   "flow",
+			}},
+			want: importsessions.Result{Conflicts: []application.ImportConflict{{
+				Reason:      application.ConflictOverlap,
+				Session:     session.Session{StartTime: at(12, 8), EndTime: at(12, 11), Project: "flow", Tags: []string{}},
+				Conflicting: []string{"flow1"},
+				Resolution:  application.ResolutionAdjust,
+				Suggestion:  "adjust it to only import the time not tracked in flow yet, import it to keep both sessions, or skip it",
+			}}},
+			wantSessions: givenSessions,
+		},
+		{
+			name: "New session already tracked in flow",
+			imported: []session.Session{{
+				StartTime: at(12, 9).Add(15 * time.Minute),
+				EndTime:   at(12, 10),
+				Project:   "flow",
+			}},
+			want: importsessions.Result{Conflicts: []application.ImportConflict{{
+				Reason:      application.ConflictOverlap,
+				Session:     session.Session{StartTime: at(12, 9).Add(15 * time.Minute), EndTime: at(12, 10), Project: "flow", Tags: []string{}},
+				Conflicting: []string{"flow1"},
+				Resolution:  application.ResolutionSkip,
+				Suggestion:  "the time of the session is already tracked in flow, skip it, or import it to keep both sessions",
+			}}},
+			wantSessions: givenSessions,
+		},
+		{
+			name: "Default project",
+			imported: []session.Session{{
+				StartTime: at(14, 9),
+				EndTime:   at(14, 10),
+			}},
+			command: importsessions.Command{DefaultProject: "inbox"},
+			want:    importsessions.Result{Imported: 1},
+			wantSessions: append(givenSessions[:2:2], session.Session{
+				Id:        "imported1",
+				StartTime: at(14, 9),
+				EndTime:   at(14, 10),
+				Project:   "inbox",
+				Tags:      []string{},
+			}),
+		},
+		{
+			name: "Dry run",
+			imported: []session.Session{{
+				StartTime: at(14, 9),
+				EndTime:   at(14, 10),
+				Project:   "flow",
+			}},
+			command:      importsessions.Command{DryRun: true},
+			want:         importsessions.Result{Imported: 1},
+			wantSessions: givenSessions,
+		},
+		{
+			name: "No project",
+			imported: []session.Session{{
+				StartTime: at(14, 9),
+				EndTime:   at(14, 10),
+			}},
+			wantErr:      importsessions.ErrEmptyProject,
+			wantSessions: givenSessions,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			sessionRepository := &infra.InMemorySessionRepository{Sessions: append([]session.Session{}, givenSessions...)}
+			importConflictsRepository := &infra.InMemoryImportConflictsRepository{}
+			useCase := importsessions.NewImportSessionsUseCase(sessionRepository, importConflictsRepository, &sequenceIDProvider{})
+
+			got, err := useCase.Execute(tc.command, testImporter{sessions: tc.imported})
+
+			is.Equal(err, tc.wantErr)
+			is.Equal(got, tc.want)
+			is.Equal(sessionRepository.Sessions, tc.wantSessions)
+			if tc.wantErr == nil && !tc.command.DryRun {
+				is.Equal(importConflictsRepository.Conflicts, tc.want.Conflicts)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package resolveconflicts
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+type UseCase struct {
+	sessionRepository         application.SessionRepository
+	importConflictsRepository application.ImportConflictsRepository
+	idProvider                application.IDProvider
+}
+
+// Execute applies the resolutions of the conflicts of the last import, the
+// conflicts which can't be resolved are kept for another try
+func (s UseCase) Execute(command Command) (Result, error) {
+	conflicts, err := s.importConflictsRepository.FindAll()
+	if err != nil {
+		return Result{}, err
+	}
+	if len(conflicts) == 0 {
+		return Result{}, ErrNoConflicts
+	}
+
+	for _, c := range conflicts {
+		resolution := cmp.Or(command.Resolution, c.Resolution)
+		if !slices.Contains(application.Resolutions, resolution) {
+			return Result{}, fmt.Errorf("%w: %q, possible values: %v", ErrInvalidResolution, resolution, strings.Join(application.Resolutions, ", "))
+		}
+	}
+
+	existing := s.sessionRepository.FindAllSessions(nil)
+	result := Result{}
+	remaining := []application.ImportConflict{}
+	for _, c := range conflicts {
+		resolution := cmp.Or(command.Resolution, c.Resolution)
+		if resolution == application.ResolutionSkip {
+			result.Skipped++
+			continue
+		}
+
+		imported := c.Session
+		if imported.EndTime.IsZero() || !imported.EndTime.After(imported.StartTime) {
+			remaining = append(remaining, c)
+			continue
+		}
+
+		policy := session.OverlapAllow
+		if resolution == application.ResolutionAdjust {
+			policy = session.OverlapAdjust
+		}
+		imported, err := imported.CheckOverlaps(existing, policy)
+		if err != nil {
+			remaining = append(remaining, c)
+			continue
+		}
+
+		imported.Id = s.idProvider.Provide()
+		if err := s.sessionRepository.Save(imported); err != nil {
+			return result, err
+		}
+		existing = append(existing, imported)
+
+		if resolution == application.ResolutionAdjust {
+			result.Adjusted++
+		} else {
+			result.Imported++
+		}
+	}
+	result.Remaining = len(remaining)
+
+	return result, s.importConflictsRepository.Save(remaining)
+}
+
+var (
+	ErrNoConflicts       = errors.New("the last import has no conflicts to resolve")
+	ErrInvalidResolution = errors.New("invalid resolution")
+)
+
+func NewResolveConflictsUseCase(
+	sessionRepository application.SessionRepository,
+	importConflictsRepository application.ImportConflictsRepository,
+	idProvider application.IDProvider,
+) UseCase {
+	return UseCase{
+		sessionRepository:         sessionRepository,
+		importConflictsRepository: importConflictsRepository,
+		idProvider:                idProvider,
+	}
+}
@@ -0,0 +1,18 @@
+package resolveconflicts
+
+type Command struct {
+	// Resolution applies to every conflict instead of their own resolution
+	Resolution string
+}
+
+// Result counts the resolved conflicts
+type Result struct {
+	Imported int
+	// Adjusted sessions were imported moved out of the sessions they overlap
+	Adjusted int
+	Skipped  int
+	// Remaining conflicts couldn't be resolved and stay in the report, like
+	// a running session without end time or a session with nothing left
+	// once adjusted
+	Remaining int
+}
@@ -0,0 +1,130 @@
+package resolveconflicts_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/application/usecases/import/resolveconflicts"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/matryer/is"
+)
+
+type sequenceIDProvider struct {
+	next int
+}
+
+func (p *sequenceIDProvider) Provide() string {
+	p.next++
+	return "resolved" + strconv.Itoa(p.next)
+}
+
+func at(hour int) time.Time {
+	return time.Date(2024, time.April, 12, hour, 0, 0, 0, time.UTC)
+}
+
+func TestResolveConflicts(t *testing.T) {
+	givenSessions := []session.Session{{
+		Id:        "flow1",
+		StartTime: at(9),
+		EndTime:   at(10),
+		Project:   "flow",
+	}}
+	overlap := application.ImportConflict{
+		Reason:      application.ConflictOverlap,
+		Session:     session.Session{StartTime: at(8), EndTime: at(11), Project: "flow"},
+		Conflicting: []string{"flow1"},
+		Resolution:  application.ResolutionAdjust,
+	}
+	running := application.ImportConflict{
+		Reason:     application.ConflictRunning,
+		Session:    session.Session{StartTime: at(14), Project: "flow"},
+		Resolution: application.ResolutionSkip,
+	}
+	withResolution := func(c application.ImportConflict, resolution string) application.ImportConflict {
+		c.Resolution = resolution
+		return c
+	}
+
+	tt := []struct {
+		name          string
+		conflicts     []application.ImportConflict
+		command       resolveconflicts.Command
+		want          resolveconflicts.Result
+		wantSessions  []session.Session
+		wantConflicts []application.ImportConflict
+		wantErr       error
+	}{
+		{
+			name:      "Suggested resolutions",
+			conflicts: []application.ImportConflict{overlap, running},
+			want:      resolveconflicts.Result{Adjusted: 1, Skipped: 1},
+			wantSessions: append(givenSessions[:1:1], session.Session{
+				Id:        "resolved1",
+				StartTime: at(8),
+				EndTime:   at(9),
+				Project:   "flow",
+			}),
+			wantConflicts: []application.ImportConflict{},
+		},
+		{
+			name:      "Import keeps both sessions",
+			conflicts: []application.ImportConflict{withResolution(overlap, application.ResolutionImport)},
+			want:      resolveconflicts.Result{Imported: 1},
+			wantSessions: append(givenSessions[:1:1], session.Session{
+				Id:        "resolved1",
+				StartTime: at(8),
+				EndTime:   at(11),
+				Project:   "flow",
+			}),
+			wantConflicts: []application.ImportConflict{},
+		},
+		{
+			name:          "Running session can't be imported",
+			conflicts:     []application.ImportConflict{withResolution(running, application.ResolutionImport)},
+			want:          resolveconflicts.Result{Remaining: 1},
+			wantSessions:  givenSessions,
+			wantConflicts: []application.ImportConflict{withResolution(running, application.ResolutionImport)},
+		},
+		{
+			name:          "Resolution of every conflict",
+			conflicts:     []application.ImportConflict{overlap, withResolution(running, application.ResolutionImport)},
+			command:       resolveconflicts.Command{Resolution: application.ResolutionSkip},
+			want:          resolveconflicts.Result{Skipped: 2},
+			wantSessions:  givenSessions,
+			wantConflicts: []application.ImportConflict{},
+		},
+		{
+			name:          "Invalid resolution",
+			conflicts:     []application.ImportConflict{withResolution(overlap, "merge")},
+			wantErr:       resolveconflicts.ErrInvalidResolution,
+			wantSessions:  givenSessions,
+			wantConflicts: []application.ImportConflict{withResolution(overlap, "merge")},
+		},
+		{
+			name:         "No conflicts",
+			wantErr:      resolveconflicts.ErrNoConflicts,
+			wantSessions: givenSessions,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			sessionRepository := &infra.InMemorySessionRepository{Sessions: append([]session.Session{}, givenSessions...)}
+			importConflictsRepository := &infra.InMemoryImportConflictsRepository{Conflicts: tc.conflicts}
+			useCase := resolveconflicts.NewResolveConflictsUseCase(sessionRepository, importConflictsRepository, &sequenceIDProvider{})
+
+			got, err := useCase.Execute(tc.command)
+
+			is.True(errors.Is(err, tc.wantErr))
+			is.Equal(got, tc.want)
+			is.Equal(sessionRepository.Sessions, tc.wantSessions)
+			is.Equal(importConflictsRepository.Conflicts, tc.wantConflicts)
+		})
+	}
+}
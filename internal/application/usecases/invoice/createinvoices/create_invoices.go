@@ -0,0 +1,131 @@
+package createinvoices
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/client"
+	"github.com/TristanShz/flow/internal/domain/invoice"
+	"github.com/TristanShz/flow/internal/domain/project"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/pkg/timerange"
+)
+
+var ErrNothingToInvoice = errors.New("no billable session left to invoice")
+
+type Result struct {
+	Invoices []invoice.Invoice
+	// WithoutClient is the number of billable sessions left out because
+	// they're billed to no client
+	WithoutClient int
+}
+
+type UseCase struct {
+	sessionRepository application.SessionRepository
+	projectRepository application.ProjectRepository
+	clientRepository  application.ClientRepository
+	invoiceRepository application.InvoiceRepository
+	dateProvider      application.DateProvider
+}
+
+func (s UseCase) Execute(command Command) (Result, error) {
+	issued, err := s.invoiceRepository.FindAll()
+	if err != nil {
+		return Result{}, err
+	}
+	invoiced := invoice.InvoicedSessions(issued)
+
+	filters := &application.SessionsFilters{}
+	if !command.Since.IsZero() || !command.Until.IsZero() {
+		filters.Timerange = timerange.TimeRange{
+			Since: command.Since,
+			Until: command.Until,
+		}
+	}
+
+	sessions := []session.Session{}
+	for _, sess := range s.sessionRepository.FindAllSessions(filters) {
+		if _, ok := invoiced[sess.Id]; !ok {
+			sessions = append(sessions, sess)
+		}
+	}
+	projects := s.projectRepository.FindAll()
+	sessions = command.Billing.Apply(sessions, projects, s.clientRepository.FindAll())
+
+	result := Result{Invoices: []invoice.Invoice{}}
+	clients := []string{}
+	for _, sess := range sessions {
+		p := project.Find(projects, sess.Project)
+		if !p.IsBillable(sess) || sess.Status() != session.EndedStatus {
+			continue
+		}
+
+		name := p.ClientOf(sess)
+		if name == "" {
+			result.WithoutClient++
+			continue
+		}
+
+		if !slices.Contains(clients, name) && (command.Client == "" || command.Client == name) {
+			clients = append(clients, name)
+		}
+	}
+	slices.Sort(clients)
+
+	now := s.dateProvider.GetNow()
+	date := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	sequence := invoice.NextSequence(issued, date.Year())
+
+	for _, name := range clients {
+		c := client.Client{Name: name}
+		if existingClient := s.clientRepository.FindByName(name); existingClient != nil {
+			c = *existingClient
+		}
+
+		i := invoice.New(c, sessions, projects)
+		i.Date = date
+		if command.Invoicing.DueDays > 0 {
+			i.DueDate = date.AddDate(0, 0, command.Invoicing.DueDays)
+		}
+		i.Issuer = command.Invoicing.Issuer
+		i.Currency = command.Invoicing.Currency
+
+		if command.Draft {
+			i.Number = invoice.DraftNumber
+		} else {
+			i.Sequence = sequence
+			i.Number = invoice.FormatNumber(command.Invoicing.Prefix, date.Year(), sequence)
+			sequence++
+
+			if err := s.invoiceRepository.Save(i); err != nil {
+				return Result{}, err
+			}
+		}
+
+		result.Invoices = append(result.Invoices, i)
+	}
+
+	if len(result.Invoices) == 0 {
+		return result, ErrNothingToInvoice
+	}
+
+	return result, nil
+}
+
+func NewCreateInvoicesUseCase(
+	sessionRepository application.SessionRepository,
+	projectRepository application.ProjectRepository,
+	clientRepository application.ClientRepository,
+	invoiceRepository application.InvoiceRepository,
+	dateProvider application.DateProvider,
+) UseCase {
+	return UseCase{
+		sessionRepository: sessionRepository,
+		projectRepository: projectRepository,
+		clientRepository:  clientRepository,
+		invoiceRepository: invoiceRepository,
+		dateProvider:      dateProvider,
+	}
+}
@@ -0,0 +1,22 @@
+package createinvoices
+
+import (
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/billing"
+)
+
+type Command struct {
+	Since time.Time
+	Until time.Time
+	// Client only invoices the sessions billed to the client, every client
+	// gets its invoice when empty
+	Client string
+	// Billing moves the end times of the invoiced sessions to the time
+	// billed by their billing profiles
+	Billing   billing.Rules
+	Invoicing application.Invoicing
+	// Draft computes the invoices without numbering nor saving them
+	Draft bool
+}
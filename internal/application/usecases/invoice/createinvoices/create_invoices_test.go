@@ -0,0 +1,150 @@
+package createinvoices_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/application/usecases/invoice/createinvoices"
+	"github.com/TristanShz/flow/internal/domain/billing"
+	"github.com/TristanShz/flow/internal/domain/client"
+	"github.com/TristanShz/flow/internal/domain/invoice"
+	"github.com/TristanShz/flow/internal/domain/project"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/matryer/is"
+)
+
+func TestCreateInvoices(t *testing.T) {
+	at := func(day int, hour int, minute int) time.Time {
+		return time.Date(2024, time.April, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	givenSessions := []session.Session{
+		{Id: "1", StartTime: at(15, 9, 0), EndTime: at(15, 10, 50), Project: "Website"},
+		{Id: "2", StartTime: at(16, 9, 0), EndTime: at(16, 10, 0), Project: "Intranet"},
+		{Id: "3", StartTime: at(17, 9, 0), EndTime: at(17, 10, 0), Project: "Side"},
+		{Id: "4", StartTime: at(18, 9, 0), EndTime: at(18, 10, 0), Project: "Website"},
+	}
+	givenProjects := []project.Project{
+		{Name: "Website", Client: "Acme", Billable: true, HourlyRate: 60},
+		{Name: "Intranet", Client: "Globex", Billable: true, HourlyRate: 90},
+		{Name: "Side", Billable: true, HourlyRate: 50},
+	}
+	acme := client.Client{Name: "Acme", Address: "1 Main Street"}
+	invoicing := application.Invoicing{
+		Issuer:   invoice.Issuer{Name: "Jane Doe"},
+		Currency: "EUR",
+		Prefix:   "INV-",
+		DueDays:  30,
+	}
+	date := time.Date(2024, time.May, 2, 0, 0, 0, 0, time.UTC)
+	issued := invoice.Invoice{Sequence: 4, Number: "INV-2024-004", Date: at(1, 0, 0), Sessions: []string{"4"}}
+
+	tt := []struct {
+		name    string
+		command createinvoices.Command
+		want    createinvoices.Result
+		wantErr error
+	}{
+		{
+			name: "Invoice of each client",
+			command: createinvoices.Command{
+				Since:     at(1, 0, 0),
+				Invoicing: invoicing,
+			},
+			want: createinvoices.Result{
+				Invoices: []invoice.Invoice{
+					{
+						Sequence: 5,
+						Number:   "INV-2024-005",
+						Date:     date,
+						DueDate:  date.AddDate(0, 0, 30),
+						Since:    at(15, 9, 0),
+						Until:    at(15, 10, 50),
+						Issuer:   invoicing.Issuer,
+						Client:   acme,
+						Currency: "EUR",
+						Lines:    []invoice.Line{{Project: "Website", Duration: 110 * time.Minute, HourlyRate: 60, Amount: 110}},
+						Duration: 110 * time.Minute,
+						Total:    110,
+						Sessions: []string{"1"},
+					},
+					{
+						Sequence: 6,
+						Number:   "INV-2024-006",
+						Date:     date,
+						DueDate:  date.AddDate(0, 0, 30),
+						Since:    at(16, 9, 0),
+						Until:    at(16, 10, 0),
+						Issuer:   invoicing.Issuer,
+						Client:   client.Client{Name: "Globex"},
+						Currency: "EUR",
+						Lines:    []invoice.Line{{Project: "Intranet", Duration: time.Hour, HourlyRate: 90, Amount: 90}},
+						Duration: time.Hour,
+						Total:    90,
+						Sessions: []string{"2"},
+					},
+				},
+				WithoutClient: 1,
+			},
+		},
+		{
+			name: "Draft of a client with rounding",
+			command: createinvoices.Command{
+				Client:  "Acme",
+				Billing: billing.Rules{Rounding: session.Rounding{Increment: time.Hour, Method: session.RoundUp}},
+				Draft:   true,
+			},
+			want: createinvoices.Result{
+				Invoices: []invoice.Invoice{
+					{
+						Number:   invoice.DraftNumber,
+						Date:     date,
+						Since:    at(15, 9, 0),
+						Until:    at(15, 11, 0),
+						Client:   acme,
+						Lines:    []invoice.Line{{Project: "Website", Duration: 2 * time.Hour, HourlyRate: 60, Amount: 120}},
+						Duration: 2 * time.Hour,
+						Total:    120,
+						Sessions: []string{"1"},
+					},
+				},
+				WithoutClient: 1,
+			},
+		},
+		{
+			name:    "Nothing to invoice",
+			command: createinvoices.Command{Client: "Initech"},
+			want:    createinvoices.Result{Invoices: []invoice.Invoice{}, WithoutClient: 1},
+			wantErr: createinvoices.ErrNothingToInvoice,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			invoiceRepository := &infra.InMemoryInvoiceRepository{Invoices: []invoice.Invoice{issued}}
+			dateProvider := infra.NewStubDateProvider()
+			dateProvider.Now = time.Date(2024, time.May, 2, 15, 30, 0, 0, time.UTC)
+			useCase := createinvoices.NewCreateInvoicesUseCase(
+				&infra.InMemorySessionRepository{Sessions: givenSessions},
+				&infra.InMemoryProjectRepository{Projects: givenProjects},
+				&infra.InMemoryClientRepository{Clients: []client.Client{acme}},
+				invoiceRepository,
+				dateProvider,
+			)
+
+			got, err := useCase.Execute(tc.command)
+
+			is.Equal(err, tc.wantErr)
+			is.Equal(got, tc.want)
+			if tc.command.Draft || tc.wantErr != nil {
+				is.Equal(invoiceRepository.Invoices, []invoice.Invoice{issued})
+			} else {
+				is.Equal(invoiceRepository.Invoices, append([]invoice.Invoice{issued}, tc.want.Invoices...))
+			}
+		})
+	}
+}
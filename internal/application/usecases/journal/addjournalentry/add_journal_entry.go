@@ -0,0 +1,53 @@
+package addjournalentry
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/journal"
+)
+
+type UseCase struct {
+	journalRepository application.JournalRepository
+	dateProvider      application.DateProvider
+}
+
+func (s UseCase) Execute(command Command) (journal.Entry, error) {
+	note := strings.TrimSpace(command.Note)
+	if note == "" {
+		return journal.Entry{}, ErrEmptyNote
+	}
+
+	now := s.dateProvider.GetNow()
+	day := command.Day
+	if day.IsZero() {
+		day = now
+	}
+	if day.After(now) {
+		return journal.Entry{}, ErrFutureDay
+	}
+
+	entry := journal.Entry{
+		Day:       journal.DayOf(day),
+		Note:      note,
+		CreatedAt: now,
+	}
+	if err := s.journalRepository.Save(entry); err != nil {
+		return journal.Entry{}, err
+	}
+
+	return entry, nil
+}
+
+var (
+	ErrEmptyNote = errors.New("the journal note can't be empty")
+	ErrFutureDay = errors.New("a journal note can't be added to a day in the future")
+)
+
+func NewAddJournalEntryUseCase(journalRepository application.JournalRepository, dateProvider application.DateProvider) UseCase {
+	return UseCase{
+		journalRepository: journalRepository,
+		dateProvider:      dateProvider,
+	}
+}
@@ -0,0 +1,9 @@
+package addjournalentry
+
+import "time"
+
+type Command struct {
+	Note string
+	// Day is any time of the day the note is about, today when it's zero
+	Day time.Time
+}
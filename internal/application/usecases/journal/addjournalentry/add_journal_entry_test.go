@@ -0,0 +1,67 @@
+package addjournalentry_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application/usecases/journal/addjournalentry"
+	"github.com/TristanShz/flow/internal/domain/journal"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/matryer/is"
+)
+
+func TestAddJournalEntry(t *testing.T) {
+	now := time.Date(2024, 4, 12, 18, 30, 0, 0, time.UTC)
+
+	tt := []struct {
+		name    string
+		command addjournalentry.Command
+		want    []journal.Entry
+		wantErr error
+	}{
+		{
+			name:    "Today",
+			command: addjournalentry.Command{Note: " shipped the release "},
+			want: []journal.Entry{{
+				Day:       time.Date(2024, 4, 12, 0, 0, 0, 0, time.UTC),
+				Note:      "shipped the release",
+				CreatedAt: now,
+			}},
+		},
+		{
+			name:    "Past day",
+			command: addjournalentry.Command{Note: "outage", Day: time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC)},
+			want: []journal.Entry{{
+				Day:       time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC),
+				Note:      "outage",
+				CreatedAt: now,
+			}},
+		},
+		{
+			name:    "Empty note",
+			command: addjournalentry.Command{Note: "  "},
+			wantErr: addjournalentry.ErrEmptyNote,
+		},
+		{
+			name:    "Future day",
+			command: addjournalentry.Command{Note: "holidays", Day: time.Date(2024, 4, 13, 0, 0, 0, 0, time.UTC)},
+			wantErr: addjournalentry.ErrFutureDay,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			journalRepository := &infra.InMemoryJournalRepository{}
+			dateProvider := infra.NewStubDateProvider()
+			dateProvider.Now = now
+			useCase := addjournalentry.NewAddJournalEntryUseCase(journalRepository, dateProvider)
+
+			_, err := useCase.Execute(tc.command)
+
+			is.Equal(err, tc.wantErr)
+			is.Equal(journalRepository.Entries, tc.want)
+		})
+	}
+}
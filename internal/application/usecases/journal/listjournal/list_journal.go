@@ -0,0 +1,21 @@
+package listjournal
+
+import (
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/journal"
+	"github.com/TristanShz/flow/pkg/timerange"
+)
+
+type UseCase struct {
+	journalRepository application.JournalRepository
+}
+
+func (s UseCase) Execute(command Command) ([]journal.Entry, error) {
+	return s.journalRepository.FindAll(timerange.TimeRange{Since: command.Since, Until: command.Until}), nil
+}
+
+func NewListJournalUseCase(journalRepository application.JournalRepository) UseCase {
+	return UseCase{
+		journalRepository: journalRepository,
+	}
+}
@@ -0,0 +1,10 @@
+package listjournal
+
+import "time"
+
+// Command keeps the entries of the days between Since and Until, every entry
+// when both are zero
+type Command struct {
+	Since time.Time
+	Until time.Time
+}
@@ -0,0 +1,29 @@
+package listoperations
+
+import (
+	"slices"
+
+	"github.com/TristanShz/flow/internal/application"
+)
+
+type UseCase struct {
+	operationLog application.OperationLog
+}
+
+// Execute returns the operations 'flow undo' can revert, the latest first
+func (s UseCase) Execute() ([]application.Operation, error) {
+	operations, err := s.operationLog.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	slices.Reverse(operations)
+
+	return operations, nil
+}
+
+func NewListOperationsUseCase(operationLog application.OperationLog) UseCase {
+	return UseCase{
+		operationLog: operationLog,
+	}
+}
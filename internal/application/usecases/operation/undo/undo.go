@@ -0,0 +1,126 @@
+package undo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"slices"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+var (
+	ErrNothingToUndo = errors.New("there is no operation to undo")
+	ErrChangedSince  = errors.New("the sessions of the operation were changed since, undoing it would lose these changes")
+)
+
+type UseCase struct {
+	sessionRepository application.SessionRepository
+	activeSessionLock application.ActiveSessionLock
+	operationLog      application.OperationLog
+}
+
+// Execute reverts the latest operation of the log: its sessions are put
+// back as they were before it, the session it made active is made inactive
+// and the other way around, then the operation is removed from the log
+func (s UseCase) Execute(command Command) (application.Operation, error) {
+	operations, err := s.operationLog.FindAll()
+	if err != nil {
+		return application.Operation{}, err
+	}
+	if len(operations) == 0 {
+		return application.Operation{}, ErrNothingToUndo
+	}
+	operation := operations[len(operations)-1]
+
+	changed := []session.Session{}
+	checked := []string{}
+	for i := len(operation.Changes) - 1; i >= 0; i-- {
+		change := operation.Changes[i]
+		if change.Before != nil {
+			changed = append(changed, *change.Before)
+		}
+
+		// a session changed several times is checked against its last change
+		if slices.Contains(checked, change.SessionId()) {
+			continue
+		}
+		checked = append(checked, change.SessionId())
+
+		current, err := s.sessionRepository.FindById(change.SessionId())
+		if err != nil {
+			return operation, err
+		}
+		if !command.Force && !sameSession(current, change.After) {
+			return operation, ErrChangedSince
+		}
+		if current != nil {
+			changed = append(changed, *current)
+		}
+	}
+	if err := session.CheckLocked(command.LockedBefore, changed...); err != nil {
+		return operation, err
+	}
+
+	// the changes are reverted from the last one, so that the sessions end
+	// as they were before the first one
+	for i := len(operation.Changes) - 1; i >= 0; i-- {
+		change := operation.Changes[i]
+		if change.Before == nil {
+			// the created session may be deleted since when forcing
+			var created *session.Session
+			if created, err = s.sessionRepository.FindById(change.After.Id); err == nil && created != nil {
+				err = s.sessionRepository.Delete(change.After.Id)
+			}
+		} else {
+			err = s.sessionRepository.Save(*change.Before)
+		}
+		if err != nil {
+			return operation, err
+		}
+	}
+
+	if operation.Acquired != "" {
+		if err := s.activeSessionLock.Release(operation.Acquired); err != nil {
+			return operation, err
+		}
+	}
+	released, err := s.sessionRepository.FindById(operation.Released)
+	if err != nil {
+		return operation, err
+	}
+	if released != nil && released.EndTime.IsZero() {
+		_, err := s.activeSessionLock.Acquire(application.ActiveSession{AcquiredAt: released.StartTime, SessionId: released.Id})
+		if err != nil {
+			return operation, err
+		}
+	}
+
+	return operation, s.operationLog.Remove(operation.Id)
+}
+
+// sameSession compares the sessions as they're stored, the sessions of the
+// log are read back from JSON
+func sameSession(a *session.Session, b *session.Session) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	marshaledA, errA := json.Marshal(a)
+	marshaledB, errB := json.Marshal(b)
+
+	return errA == nil && errB == nil && bytes.Equal(marshaledA, marshaledB)
+}
+
+func NewUndoUseCase(
+	sessionRepository application.SessionRepository,
+	activeSessionLock application.ActiveSessionLock,
+	operationLog application.OperationLog,
+) UseCase {
+	return UseCase{
+		sessionRepository: sessionRepository,
+		activeSessionLock: activeSessionLock,
+		operationLog:      operationLog,
+	}
+}
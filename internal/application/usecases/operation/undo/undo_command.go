@@ -0,0 +1,11 @@
+package undo
+
+import "time"
+
+type Command struct {
+	// Force reverts the operation even when its sessions were changed since
+	Force bool
+	// LockedBefore is the start of the first month whose sessions can be
+	// changed, see session.IsLocked
+	LockedBefore time.Time
+}
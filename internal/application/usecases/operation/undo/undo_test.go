@@ -0,0 +1,130 @@
+package undo_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/application/usecases/operation/undo"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/matryer/is"
+)
+
+func TestUndo(t *testing.T) {
+	at := func(day int, hour int) time.Time {
+		return time.Date(2024, time.April, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	flowing := session.Session{Id: "1", StartTime: at(15, 9), Project: "Flow"}
+	stopped := session.Session{Id: "1", StartTime: at(15, 9), EndTime: at(15, 10), Project: "Flow"}
+	retagged := session.Session{Id: "1", StartTime: at(15, 9), EndTime: at(15, 10), Project: "Flow", Tags: []string{"review"}}
+	other := session.Session{Id: "2", StartTime: at(16, 9), EndTime: at(16, 10), Project: "Intranet"}
+
+	tt := []struct {
+		name          string
+		givenSessions []session.Session
+		givenActive   *application.ActiveSession
+		operations    []application.Operation
+		command       undo.Command
+		want          []session.Session
+		wantActive    *application.ActiveSession
+		wantLeft      int
+		wantErr       error
+	}{
+		{
+			name:          "Start",
+			givenSessions: []session.Session{other, flowing},
+			givenActive:   &application.ActiveSession{AcquiredAt: at(15, 9), SessionId: "1"},
+			operations: []application.Operation{
+				{Id: "a", Command: "start", Changes: []application.SessionChange{{After: &flowing}}, Acquired: "1"},
+			},
+			want: []session.Session{other},
+		},
+		{
+			name:          "Stop",
+			givenSessions: []session.Session{stopped},
+			operations: []application.Operation{
+				{Id: "a", Command: "start", Changes: []application.SessionChange{{After: &flowing}}, Acquired: "1"},
+				{Id: "b", Command: "stop", Changes: []application.SessionChange{{Before: &flowing, After: &stopped}}, Released: "1"},
+			},
+			want:       []session.Session{flowing},
+			wantActive: &application.ActiveSession{AcquiredAt: at(15, 9), SessionId: "1"},
+			wantLeft:   1,
+		},
+		{
+			name:          "Session changed twice",
+			givenSessions: []session.Session{retagged},
+			operations: []application.Operation{
+				{Id: "a", Command: "stop", Changes: []application.SessionChange{
+					{Before: &flowing, After: &stopped},
+					{Before: &stopped, After: &retagged},
+				}, Released: "1"},
+			},
+			want:       []session.Session{flowing},
+			wantActive: &application.ActiveSession{AcquiredAt: at(15, 9), SessionId: "1"},
+		},
+		{
+			name: "Delete",
+			operations: []application.Operation{
+				{Id: "a", Command: "delete", Changes: []application.SessionChange{{Before: &other}}},
+			},
+			want: []session.Session{other},
+		},
+		{
+			name:          "Session changed since",
+			givenSessions: []session.Session{retagged},
+			operations: []application.Operation{
+				{Id: "a", Command: "stop", Changes: []application.SessionChange{{Before: &flowing, After: &stopped}}, Released: "1"},
+			},
+			want:     []session.Session{retagged},
+			wantLeft: 1,
+			wantErr:  undo.ErrChangedSince,
+		},
+		{
+			name:          "Session changed since with force",
+			givenSessions: []session.Session{retagged},
+			operations: []application.Operation{
+				{Id: "a", Command: "stop", Changes: []application.SessionChange{{Before: &flowing, After: &stopped}}, Released: "1"},
+			},
+			command:    undo.Command{Force: true},
+			want:       []session.Session{flowing},
+			wantActive: &application.ActiveSession{AcquiredAt: at(15, 9), SessionId: "1"},
+		},
+		{
+			name:          "Locked history",
+			givenSessions: []session.Session{stopped},
+			operations: []application.Operation{
+				{Id: "a", Command: "stop", Changes: []application.SessionChange{{Before: &flowing, After: &stopped}}, Released: "1"},
+			},
+			command:  undo.Command{LockedBefore: at(1, 0).AddDate(0, 1, 0)},
+			want:     []session.Session{stopped},
+			wantLeft: 1,
+			wantErr:  session.ErrLockedHistory,
+		},
+		{
+			name:          "Nothing to undo",
+			givenSessions: []session.Session{other},
+			want:          []session.Session{other},
+			wantErr:       undo.ErrNothingToUndo,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			repository := &infra.InMemorySessionRepository{Sessions: tc.givenSessions}
+			lock := &infra.InMemoryActiveSessionLock{Active: tc.givenActive}
+			operationLog := &infra.InMemoryOperationLog{Operations: tc.operations}
+			useCase := undo.NewUndoUseCase(repository, lock, operationLog)
+
+			_, err := useCase.Execute(tc.command)
+
+			is.Equal(err, tc.wantErr)
+			is.Equal(repository.FindAllSessions(nil), tc.want)
+			is.Equal(lock.Active, tc.wantActive)
+			is.Equal(len(operationLog.Operations), tc.wantLeft)
+		})
+	}
+}
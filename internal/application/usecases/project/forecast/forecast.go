@@ -0,0 +1,142 @@
+package forecast
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/pkg/timerange"
+)
+
+// DefaultWeeks is the history of a forecast, about a quarter
+const DefaultWeeks = 12
+
+const week = 7 * 24 * time.Hour
+
+// Forecast tells when the remaining time of a project will be spent at the
+// pace of the last weeks. The optimistic and pessimistic bounds are the paces
+// of the best and worst quarters of the weeks, a bound is the zero time when
+// its pace is zero.
+type Forecast struct {
+	// Weeks is the number of weeks the velocity is measured on, the weeks
+	// before the first session of the project are left out
+	Weeks               int
+	Velocity            time.Duration
+	OptimisticVelocity  time.Duration
+	PessimisticVelocity time.Duration
+	Expected            time.Time
+	Optimistic          time.Time
+	Pessimistic         time.Time
+}
+
+type UseCase struct {
+	sessionRepository application.SessionRepository
+	dateProvider      application.DateProvider
+}
+
+func (s UseCase) Execute(command Command) (Forecast, error) {
+	if command.Project == "" {
+		return Forecast{}, ErrEmptyProject
+	}
+	if command.Remaining <= 0 {
+		return Forecast{}, ErrNoRemainingTime
+	}
+
+	weeks := command.Weeks
+	if weeks <= 0 {
+		weeks = DefaultWeeks
+	}
+
+	sessions := s.sessionRepository.FindAllSessions(&application.SessionsFilters{Project: command.Project})
+	if len(sessions) == 0 {
+		return Forecast{}, ErrNoHistory
+	}
+	firstStart := slices.MinFunc(sessions, func(a, b session.Session) int {
+		return a.StartTime.Compare(b.StartTime)
+	}).StartTime
+
+	// the current week isn't over, it would lower the velocity
+	now := s.dateProvider.GetNow()
+	currentWeek := timerange.StartOf(now, timerange.ByWeek, command.WeekStart)
+	history := timerange.TimeRange{
+		Since:        currentWeek.AddDate(0, 0, -7*weeks),
+		Until:        currentWeek,
+		ExcludeUntil: true,
+	}
+	// the weeks before the project started don't slow it down
+	if projectStart := timerange.StartOf(firstStart, timerange.ByWeek, command.WeekStart); projectStart.After(history.Since) {
+		history.Since = projectStart
+	}
+
+	totals := []time.Duration{}
+	for _, bucket := range timerange.Buckets(history, timerange.ByWeek, command.WeekStart) {
+		total := time.Duration(0)
+		for _, projectSession := range sessions {
+			if bucket.Contains(projectSession.StartTime) {
+				total += projectSession.Duration()
+			}
+		}
+		totals = append(totals, total)
+	}
+	if len(totals) == 0 {
+		return Forecast{}, ErrNoHistory
+	}
+
+	sum := time.Duration(0)
+	for _, total := range totals {
+		sum += total
+	}
+	if sum == 0 {
+		return Forecast{}, ErrNoHistory
+	}
+	slices.Sort(totals)
+
+	forecast := Forecast{
+		Weeks:               len(totals),
+		Velocity:            sum / time.Duration(len(totals)),
+		OptimisticVelocity:  quantile(totals, 0.75),
+		PessimisticVelocity: quantile(totals, 0.25),
+	}
+	forecast.Expected = completion(now, command.Remaining, forecast.Velocity)
+	forecast.Optimistic = completion(now, command.Remaining, forecast.OptimisticVelocity)
+	forecast.Pessimistic = completion(now, command.Remaining, forecast.PessimisticVelocity)
+
+	return forecast, nil
+}
+
+// quantile interpolates between the sorted durations
+func quantile(sorted []time.Duration, q float64) time.Duration {
+	position := q * float64(len(sorted)-1)
+	lower := int(position)
+	if lower == len(sorted)-1 {
+		return sorted[lower]
+	}
+
+	fraction := position - float64(lower)
+	return sorted[lower] + time.Duration(fraction*float64(sorted[lower+1]-sorted[lower]))
+}
+
+// completion returns when the remaining time is spent at the velocity, the
+// zero time when it never is
+func completion(now time.Time, remaining time.Duration, velocity time.Duration) time.Time {
+	if velocity <= 0 {
+		return time.Time{}
+	}
+
+	return now.Add(time.Duration(float64(remaining) / float64(velocity) * float64(week)))
+}
+
+var (
+	ErrEmptyProject    = errors.New("project can't be empty")
+	ErrNoRemainingTime = errors.New("the remaining time must be positive")
+	ErrNoHistory       = errors.New("no time was spent on the project in the last weeks, there's no pace to forecast from")
+)
+
+func NewForecastUseCase(sessionRepository application.SessionRepository, dateProvider application.DateProvider) UseCase {
+	return UseCase{
+		sessionRepository: sessionRepository,
+		dateProvider:      dateProvider,
+	}
+}
@@ -0,0 +1,13 @@
+package forecast
+
+import "time"
+
+type Command struct {
+	Project string
+	// Remaining is the time left to spend on the project
+	Remaining time.Duration
+	// Weeks is the number of complete weeks of history the velocity is
+	// measured on, DefaultWeeks when zero
+	Weeks     int
+	WeekStart time.Weekday
+}
@@ -0,0 +1,113 @@
+package forecast_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application/usecases/project/forecast"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/tests"
+)
+
+func TestForecast(t *testing.T) {
+	now := time.Date(2024, time.April, 17, 12, 0, 0, 0, time.UTC)
+	sessionOf := func(id string, project string, start time.Time, duration time.Duration) session.Session {
+		return session.Session{Id: id, Project: project, StartTime: start, EndTime: start.Add(duration)}
+	}
+	inWeeks := func(weeks float64) time.Time {
+		return now.Add(time.Duration(weeks * float64(7*24*time.Hour)))
+	}
+	// weeks starting on monday, from march 11 to april 8
+	history := []session.Session{
+		sessionOf("1", "Flow", time.Date(2024, time.March, 12, 9, 0, 0, 0, time.UTC), 2*time.Hour),
+		sessionOf("2", "Flow", time.Date(2024, time.March, 18, 9, 0, 0, 0, time.UTC), 3*time.Hour),
+		sessionOf("3", "Flow", time.Date(2024, time.March, 29, 9, 0, 0, 0, time.UTC), 6*time.Hour),
+		sessionOf("4", "Flow", time.Date(2024, time.April, 2, 9, 0, 0, 0, time.UTC), 4*time.Hour),
+		sessionOf("5", "Flow", time.Date(2024, time.April, 9, 9, 0, 0, 0, time.UTC), 3*time.Hour),
+		sessionOf("6", "Flow", time.Date(2024, time.April, 11, 9, 0, 0, 0, time.UTC), 2*time.Hour),
+		// the current week isn't over
+		sessionOf("7", "Flow", time.Date(2024, time.April, 15, 9, 0, 0, 0, time.UTC), 8*time.Hour),
+		sessionOf("8", "MyTodo", time.Date(2024, time.April, 3, 9, 0, 0, 0, time.UTC), 8*time.Hour),
+	}
+
+	tt := []struct {
+		name          string
+		givenSessions []session.Session
+		command       forecast.Command
+		want          forecast.Forecast
+		error         error
+	}{
+		{
+			name:          "Since the first session",
+			givenSessions: history,
+			command:       forecast.Command{Project: "Flow", Remaining: 15 * time.Hour, WeekStart: time.Monday},
+			want: forecast.Forecast{
+				Weeks:               5,
+				Velocity:            4 * time.Hour,
+				OptimisticVelocity:  5 * time.Hour,
+				PessimisticVelocity: 3 * time.Hour,
+				Expected:            time.Date(2024, time.May, 13, 18, 0, 0, 0, time.UTC),
+				Optimistic:          time.Date(2024, time.May, 8, 12, 0, 0, 0, time.UTC),
+				Pessimistic:         time.Date(2024, time.May, 22, 12, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:          "Last weeks",
+			givenSessions: history,
+			command:       forecast.Command{Project: "Flow", Remaining: 9 * time.Hour, Weeks: 2, WeekStart: time.Monday},
+			want: forecast.Forecast{
+				Weeks:               2,
+				Velocity:            4*time.Hour + 30*time.Minute,
+				OptimisticVelocity:  4*time.Hour + 45*time.Minute,
+				PessimisticVelocity: 4*time.Hour + 15*time.Minute,
+				Expected:            time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC),
+				Optimistic:          inWeeks(9.0 / 4.75),
+				Pessimistic:         inWeeks(9.0 / 4.25),
+			},
+		},
+		{
+			name:          "Weeks without sessions",
+			givenSessions: []session.Session{sessionOf("1", "Flow", time.Date(2024, time.April, 1, 9, 0, 0, 0, time.UTC), 4*time.Hour), history[5]},
+			command:       forecast.Command{Project: "Flow", Remaining: 6 * time.Hour, WeekStart: time.Monday},
+			want: forecast.Forecast{
+				Weeks:               2,
+				Velocity:            3 * time.Hour,
+				OptimisticVelocity:  3*time.Hour + 30*time.Minute,
+				PessimisticVelocity: 2*time.Hour + 30*time.Minute,
+				Expected:            time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC),
+				Optimistic:          inWeeks(6.0 / 3.5),
+				Pessimistic:         inWeeks(6.0 / 2.5),
+			},
+		},
+		{
+			name:          "No time in the history",
+			givenSessions: []session.Session{history[6]},
+			command:       forecast.Command{Project: "Flow", Remaining: 10 * time.Hour},
+			error:         forecast.ErrNoHistory,
+		},
+		{
+			name:          "Unknown project",
+			givenSessions: history,
+			command:       forecast.Command{Project: "Unknown", Remaining: 10 * time.Hour},
+			error:         forecast.ErrNoHistory,
+		},
+		{
+			name:    "No remaining time",
+			command: forecast.Command{Project: "Flow"},
+			error:   forecast.ErrNoRemainingTime,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			f := tests.GetSessionFixture(t)
+			f.GivenNowIs(now)
+			f.GivenSomeSessions(tc.givenSessions)
+
+			f.WhenForecasting(tc.command)
+
+			f.ThenErrorShouldBe(tc.error)
+			f.ThenForecastShouldBe(tc.want)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package goals
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/project"
+	"github.com/TristanShz/flow/pkg/timerange"
+)
+
+type UseCase struct {
+	sessionRepository application.SessionRepository
+	projectRepository application.ProjectRepository
+	dateProvider      application.DateProvider
+}
+
+// Execute returns the progress of the goals of the current week and month,
+// sorted by project, the weekly goal first
+func (s UseCase) Execute(command Command) ([]project.GoalProgress, error) {
+	now := s.dateProvider.GetNow()
+	weekStart := timerange.StartOf(now, timerange.ByWeek, command.WeekStart)
+	monthStart := timerange.StartOf(now, timerange.ByMonth, command.WeekStart)
+	since := weekStart
+	if monthStart.Before(since) {
+		since = monthStart
+	}
+
+	projects := slices.Clone(s.projectRepository.FindAll())
+	slices.SortFunc(projects, func(a, b project.Project) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	progress := []project.GoalProgress{}
+	for _, p := range projects {
+		if !p.HasGoals() || (command.Project != "" && p.Name != command.Project) {
+			continue
+		}
+
+		sessions := s.sessionRepository.FindAllSessions(&application.SessionsFilters{
+			Project:   p.Name,
+			Timerange: timerange.TimeRange{Since: since},
+		})
+		if p.WeeklyGoal > 0 {
+			progress = append(progress, project.NewGoalProgress(p.Name, project.GoalWeek, p.WeeklyGoal, weekStart, weekStart.AddDate(0, 0, 7), sessions, now))
+		}
+		if p.MonthlyGoal > 0 {
+			progress = append(progress, project.NewGoalProgress(p.Name, project.GoalMonth, p.MonthlyGoal, monthStart, monthStart.AddDate(0, 1, 0), sessions, now))
+		}
+	}
+
+	if len(progress) == 0 {
+		return nil, ErrNoGoals
+	}
+
+	return progress, nil
+}
+
+var ErrNoGoals = errors.New("no goals, set them with 'flow projects set [project] --weekly-goal 10h'")
+
+func NewGoalsUseCase(
+	sessionRepository application.SessionRepository,
+	projectRepository application.ProjectRepository,
+	dateProvider application.DateProvider,
+) UseCase {
+	return UseCase{
+		sessionRepository: sessionRepository,
+		projectRepository: projectRepository,
+		dateProvider:      dateProvider,
+	}
+}
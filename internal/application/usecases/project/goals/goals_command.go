@@ -0,0 +1,10 @@
+package goals
+
+import "time"
+
+type Command struct {
+	// Project only keeps the goals of the project, every project with goals
+	// when empty
+	Project   string
+	WeekStart time.Weekday
+}
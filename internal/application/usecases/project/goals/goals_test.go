@@ -0,0 +1,80 @@
+package goals_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application/usecases/project/goals"
+	"github.com/TristanShz/flow/internal/domain/project"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/tests"
+)
+
+func day(d int) time.Time {
+	return time.Date(2024, time.April, d, 0, 0, 0, 0, time.UTC)
+}
+
+var sessionsForTest = []session.Session{
+	{Id: "1", StartTime: day(5).Add(9 * time.Hour), EndTime: day(5).Add(17 * time.Hour), Project: "Flow"},
+	{Id: "2", StartTime: day(15).Add(9 * time.Hour), EndTime: day(15).Add(11 * time.Hour), Project: "Flow"},
+	{Id: "3", StartTime: day(16).Add(14 * time.Hour), EndTime: day(16).Add(15 * time.Hour), Project: "MyTodo"},
+}
+
+var projectsForTest = []project.Project{
+	{Name: "MyTodo", MonthlyGoal: 20 * time.Hour},
+	{Name: "Flow", WeeklyGoal: 10 * time.Hour, MonthlyGoal: 40 * time.Hour},
+	{Name: "Other", Billable: true},
+}
+
+func TestGoals(t *testing.T) {
+	now := day(17).Add(12 * time.Hour)
+	weekSince, weekUntil := day(15), day(22)
+	monthSince, monthUntil := day(1), time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		name    string
+		command goals.Command
+		want    []project.GoalProgress
+	}{
+		{
+			name:    "Every project",
+			command: goals.Command{WeekStart: time.Monday},
+			want: []project.GoalProgress{
+				{Project: "Flow", Period: project.GoalWeek, Since: weekSince, Until: weekUntil, Goal: 10 * time.Hour, Tracked: 2 * time.Hour, Expected: 3*time.Hour + 34*time.Minute},
+				{Project: "Flow", Period: project.GoalMonth, Since: monthSince, Until: monthUntil, Goal: 40 * time.Hour, Tracked: 10 * time.Hour, Expected: 22 * time.Hour},
+				{Project: "MyTodo", Period: project.GoalMonth, Since: monthSince, Until: monthUntil, Goal: 20 * time.Hour, Tracked: time.Hour, Expected: 11 * time.Hour},
+			},
+		},
+		{
+			name:    "One project",
+			command: goals.Command{Project: "MyTodo", WeekStart: time.Monday},
+			want: []project.GoalProgress{
+				{Project: "MyTodo", Period: project.GoalMonth, Since: monthSince, Until: monthUntil, Goal: 20 * time.Hour, Tracked: time.Hour, Expected: 11 * time.Hour},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			f := tests.GetSessionFixture(t)
+
+			f.GivenSomeSessions(sessionsForTest)
+			f.GivenSomeProjects(projectsForTest)
+			f.GivenNowIs(now)
+
+			f.WhenComputingGoals(tc.command)
+
+			f.ThenGoalsShouldBe(tc.want)
+		})
+	}
+}
+
+func TestGoals_NoGoals(t *testing.T) {
+	f := tests.GetSessionFixture(t)
+
+	f.GivenSomeProjects([]project.Project{{Name: "Flow", Billable: true}})
+
+	f.WhenComputingGoals(goals.Command{Project: "Flow"})
+
+	f.ThenErrorShouldBe(goals.ErrNoGoals)
+}
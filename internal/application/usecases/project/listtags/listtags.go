@@ -0,0 +1,39 @@
+package listtags
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/TristanShz/flow/internal/application"
+)
+
+type UseCase struct {
+	sessionRepository application.SessionRepository
+}
+
+// Execute returns the tags used by the sessions of the project, sorted
+// alphabetically
+func (s UseCase) Execute(command Command) ([]string, error) {
+	projects := []string{command.Project}
+	if command.Project == "" {
+		projects = s.sessionRepository.FindAllProjects()
+	}
+
+	tags := []string{}
+	for _, project := range projects {
+		for _, tag := range s.sessionRepository.FindAllProjectTags(project) {
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	sort.Strings(tags)
+
+	return tags, nil
+}
+
+func NewListProjectTagsUseCase(sessionRepository application.SessionRepository) UseCase {
+	return UseCase{
+		sessionRepository: sessionRepository,
+	}
+}
@@ -0,0 +1,7 @@
+package listtags
+
+type Command struct {
+	// Project is the project whose tags are listed, the tags of all the
+	// projects are listed when it's empty
+	Project string
+}
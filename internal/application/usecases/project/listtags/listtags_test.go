@@ -0,0 +1,67 @@
+package listtags_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application/usecases/project/listtags"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/tests"
+)
+
+func TestListProjectTags_Success(t *testing.T) {
+	f := tests.GetSessionFixture(t)
+	sessions := []session.Session{
+		{
+			Id:        "1",
+			StartTime: time.Date(2024, time.April, 14, 10, 12, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, time.April, 14, 13, 10, 0, 0, time.UTC),
+			Project:   "Flow",
+			Tags:      []string{"start-usecase", "cli"},
+		}, {
+			Id:        "2",
+			StartTime: time.Date(2024, time.April, 14, 14, 12, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, time.April, 14, 15, 12, 0, 0, time.UTC),
+			Project:   "Flow",
+			Tags:      []string{"cli", "completion"},
+		}, {
+			Id:        "3",
+			StartTime: time.Date(2024, time.April, 14, 16, 12, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, time.April, 14, 17, 12, 0, 0, time.UTC),
+			Project:   "MyTodo",
+			Tags:      []string{"add-todo"},
+		},
+	}
+
+	tt := []struct {
+		name    string
+		command listtags.Command
+		want    []string
+	}{
+		{
+			name:    "Tags of a project",
+			command: listtags.Command{Project: "Flow"},
+			want:    []string{"cli", "completion", "start-usecase"},
+		},
+		{
+			name:    "Tags of all the projects",
+			command: listtags.Command{},
+			want:    []string{"add-todo", "cli", "completion", "start-usecase"},
+		},
+		{
+			name:    "Unknown project",
+			command: listtags.Command{Project: "Unknown"},
+			want:    []string{},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			f.GivenSomeSessions(sessions)
+
+			f.WhenGettingListOfProjectTags(tc.command)
+
+			f.ThenProjectTagsShouldBe(tc.want)
+		})
+	}
+}
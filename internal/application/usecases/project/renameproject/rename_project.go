@@ -0,0 +1,73 @@
+package renameproject
+
+import (
+	"errors"
+
+	"github.com/TristanShz/flow/internal/application"
+)
+
+type UseCase struct {
+	sessionRepository application.SessionRepository
+	projectRepository application.ProjectRepository
+}
+
+// Execute renames the project of every session of the project and returns the
+// number of updated sessions. The settings of the project are moved to the new
+// name, unless the new name already has settings which are then kept.
+func (s UseCase) Execute(command Command) (int, error) {
+	if command.Name == "" || command.NewName == "" {
+		return 0, ErrEmptyProjectName
+	}
+
+	if command.Name == command.NewName {
+		return 0, ErrSameName
+	}
+
+	sessions := s.sessionRepository.FindAllSessions(&application.SessionsFilters{Project: command.Name})
+	settings := s.projectRepository.FindByName(command.Name)
+	if len(sessions) == 0 && settings == nil {
+		return 0, ErrProjectNotFound
+	}
+
+	newNameSessions := s.sessionRepository.FindAllSessions(&application.SessionsFilters{Project: command.NewName})
+	newNameSettings := s.projectRepository.FindByName(command.NewName)
+	if !command.Merge && (len(newNameSessions) > 0 || newNameSettings != nil) {
+		return 0, ErrProjectExists
+	}
+
+	for _, session := range sessions {
+		session.Project = command.NewName
+		if err := s.sessionRepository.Save(session); err != nil {
+			return 0, err
+		}
+	}
+
+	if settings != nil {
+		if newNameSettings == nil {
+			settings.Name = command.NewName
+			if err := s.projectRepository.Save(*settings); err != nil {
+				return len(sessions), err
+			}
+		}
+
+		if err := s.projectRepository.Delete(command.Name); err != nil {
+			return len(sessions), err
+		}
+	}
+
+	return len(sessions), nil
+}
+
+var (
+	ErrEmptyProjectName = errors.New("project name can't be empty")
+	ErrSameName         = errors.New("the new name of the project is the same as its current name")
+	ErrProjectNotFound  = errors.New("project not found")
+	ErrProjectExists    = errors.New("a project already has this name, merge the projects to rename it anyway")
+)
+
+func NewRenameProjectUseCase(sessionRepository application.SessionRepository, projectRepository application.ProjectRepository) UseCase {
+	return UseCase{
+		sessionRepository: sessionRepository,
+		projectRepository: projectRepository,
+	}
+}
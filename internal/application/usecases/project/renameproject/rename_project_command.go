@@ -0,0 +1,9 @@
+package renameproject
+
+type Command struct {
+	Name    string
+	NewName string
+	// Merge allows renaming to a project that already has sessions, the
+	// sessions of both projects then belong to the new name
+	Merge bool
+}
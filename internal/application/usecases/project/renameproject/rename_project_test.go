@@ -0,0 +1,114 @@
+package renameproject_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application/usecases/project/renameproject"
+	"github.com/TristanShz/flow/internal/domain/project"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/tests"
+)
+
+func TestRenameProject(t *testing.T) {
+	givenSessions := []session.Session{
+		{
+			Id:        "1",
+			StartTime: time.Date(2024, time.April, 14, 10, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, time.April, 14, 11, 0, 0, 0, time.UTC),
+			Project:   "flow",
+		},
+		{
+			Id:        "2",
+			StartTime: time.Date(2024, time.April, 14, 12, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, time.April, 14, 13, 0, 0, 0, time.UTC),
+			Project:   "Flow",
+		},
+		{
+			Id:        "3",
+			StartTime: time.Date(2024, time.April, 14, 14, 0, 0, 0, time.UTC),
+			Project:   "flow",
+		},
+	}
+
+	tt := []struct {
+		error                error
+		name                 string
+		givenProjects        []project.Project
+		command              renameproject.Command
+		wantSessionsProjects []string
+		wantProjects         []project.Project
+		wantUpdatedSessions  int
+	}{
+		{
+			name:                 "Rename",
+			givenProjects:        []project.Project{{Name: "flow", OnLock: project.OnLockStop}},
+			command:              renameproject.Command{Name: "flow", NewName: "flow-cli"},
+			wantSessionsProjects: []string{"flow-cli", "Flow", "flow-cli"},
+			wantProjects:         []project.Project{{Name: "flow-cli", OnLock: project.OnLockStop}},
+			wantUpdatedSessions:  2,
+		},
+		{
+			name:                 "Rename to an existing project",
+			givenProjects:        []project.Project{},
+			command:              renameproject.Command{Name: "flow", NewName: "Flow"},
+			error:                renameproject.ErrProjectExists,
+			wantSessionsProjects: []string{"flow", "Flow", "flow"},
+			wantProjects:         []project.Project{},
+		},
+		{
+			name:                 "Merge projects",
+			givenProjects:        []project.Project{{Name: "flow", OnLock: project.OnLockStop}, {Name: "Flow", OnLock: project.OnLockPause}},
+			command:              renameproject.Command{Name: "flow", NewName: "Flow", Merge: true},
+			wantSessionsProjects: []string{"Flow", "Flow", "Flow"},
+			wantProjects:         []project.Project{{Name: "Flow", OnLock: project.OnLockPause}},
+			wantUpdatedSessions:  2,
+		},
+		{
+			name:                 "Project not found",
+			givenProjects:        []project.Project{},
+			command:              renameproject.Command{Name: "MyTodo", NewName: "Todo"},
+			error:                renameproject.ErrProjectNotFound,
+			wantSessionsProjects: []string{"flow", "Flow", "flow"},
+			wantProjects:         []project.Project{},
+		},
+		{
+			name:                 "Same name",
+			givenProjects:        []project.Project{},
+			command:              renameproject.Command{Name: "flow", NewName: "flow"},
+			error:                renameproject.ErrSameName,
+			wantSessionsProjects: []string{"flow", "Flow", "flow"},
+			wantProjects:         []project.Project{},
+		},
+		{
+			name:                 "Empty new name",
+			givenProjects:        []project.Project{},
+			command:              renameproject.Command{Name: "flow"},
+			error:                renameproject.ErrEmptyProjectName,
+			wantSessionsProjects: []string{"flow", "Flow", "flow"},
+			wantProjects:         []project.Project{},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			f := tests.GetProjectFixture(t)
+
+			f.GivenSomeSessions(append([]session.Session{}, givenSessions...))
+			f.GivenSomeProjects(tc.givenProjects)
+
+			f.WhenRenamingProject(tc.command)
+
+			f.ThenErrorShouldBe(tc.error)
+			f.ThenUpdatedSessionsShouldBe(tc.wantUpdatedSessions)
+			f.ThenProjectsShouldBe(tc.wantProjects)
+
+			wantSessions := []session.Session{}
+			for i, s := range givenSessions {
+				s.Project = tc.wantSessionsProjects[i]
+				wantSessions = append(wantSessions, s)
+			}
+			f.ThenSessionsShouldBe(wantSessions)
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package setproject
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/project"
+)
+
+type UseCase struct {
+	projectRepository application.ProjectRepository
+}
+
+func (s UseCase) Execute(command Command) (project.Project, error) {
+	if command.Name == "" {
+		return project.Project{}, ErrEmptyProjectName
+	}
+
+	p := project.Project{Name: command.Name}
+	if existingProject := s.projectRepository.FindByName(command.Name); existingProject != nil {
+		p = *existingProject
+	}
+
+	if command.OnLock != nil {
+		if !project.IsOnLockValid(*command.OnLock) {
+			return project.Project{}, ErrInvalidOnLock
+		}
+		p.OnLock = *command.OnLock
+	}
+
+	if command.DoNotTrack != nil {
+		windows := []project.TimeWindow{}
+		for _, value := range *command.DoNotTrack {
+			// an empty window removes the windows of the project
+			if strings.TrimSpace(value) == "" {
+				continue
+			}
+
+			window, err := project.ParseTimeWindow(value)
+			if err != nil {
+				return project.Project{}, err
+			}
+			windows = append(windows, window)
+		}
+		p.DoNotTrack = windows
+	}
+
+	if command.OnDoNotTrack != nil {
+		if !project.IsOnDoNotTrackValid(*command.OnDoNotTrack) {
+			return project.Project{}, ErrInvalidOnDoNotTrack
+		}
+		p.OnDoNotTrack = *command.OnDoNotTrack
+	}
+
+	if command.OnMeeting != nil {
+		if !project.IsOnMeetingValid(*command.OnMeeting) {
+			return project.Project{}, ErrInvalidOnMeeting
+		}
+		p.OnMeeting = *command.OnMeeting
+	}
+
+	if command.MeetingProject != nil {
+		p.MeetingProject = strings.TrimSpace(*command.MeetingProject)
+	}
+
+	if command.Client != nil {
+		p.Client = strings.TrimSpace(*command.Client)
+	}
+
+	if command.Billable != nil {
+		p.Billable = *command.Billable
+	}
+
+	if command.HourlyRate != nil {
+		if *command.HourlyRate < 0 {
+			return project.Project{}, ErrNegativeRate
+		}
+		p.HourlyRate = *command.HourlyRate
+	}
+
+	if command.BillingProfile != nil {
+		p.BillingProfile = strings.TrimSpace(*command.BillingProfile)
+	}
+
+	if command.BreakEvery != nil {
+		p.BreakEvery = *command.BreakEvery
+	}
+
+	if command.BreakDuration != nil {
+		p.BreakDuration = *command.BreakDuration
+	}
+
+	if p.BreakEvery < 0 || p.BreakDuration < 0 {
+		return project.Project{}, ErrNegativeBreak
+	}
+
+	if command.WeeklyGoal != nil {
+		p.WeeklyGoal = *command.WeeklyGoal
+	}
+
+	if command.MonthlyGoal != nil {
+		p.MonthlyGoal = *command.MonthlyGoal
+	}
+
+	if p.WeeklyGoal < 0 || p.MonthlyGoal < 0 {
+		return project.Project{}, ErrNegativeGoal
+	}
+
+	if err := s.projectRepository.Save(p); err != nil {
+		return project.Project{}, err
+	}
+
+	return p, nil
+}
+
+var (
+	ErrEmptyProjectName    = errors.New("project name can't be empty")
+	ErrInvalidOnLock       = errors.New("invalid on lock action. possible values: none, stop, pause")
+	ErrInvalidOnDoNotTrack = errors.New("invalid do-not-track action. possible values: confirm, block")
+	ErrInvalidOnMeeting    = errors.New("invalid on meeting action. possible values: none, pause, switch")
+	ErrNegativeRate        = errors.New("hourly rate can't be negative")
+	ErrNegativeBreak       = errors.New("break durations can't be negative")
+	ErrNegativeGoal        = errors.New("goals can't be negative")
+)
+
+func NewSetProjectUseCase(projectRepository application.ProjectRepository) UseCase {
+	return UseCase{
+		projectRepository: projectRepository,
+	}
+}
@@ -0,0 +1,28 @@
+package setproject
+
+import "time"
+
+// Command fields left to nil keep the value already stored for the project
+type Command struct {
+	OnLock *string
+	// DoNotTrack are windows as read by project.ParseTimeWindow
+	DoNotTrack   *[]string
+	OnDoNotTrack *string
+	OnMeeting    *string
+	// MeetingProject is the project of the meeting sessions, an empty value
+	// uses the default meetings project
+	MeetingProject *string
+	Client         *string
+	Billable       *bool
+	HourlyRate     *float64
+	// BillingProfile is the name of a profile of the config file, an empty
+	// value removes it
+	BillingProfile *string
+	// BreakEvery and BreakDuration set to 0 remove the breaks of the project
+	BreakEvery    *time.Duration
+	BreakDuration *time.Duration
+	// WeeklyGoal and MonthlyGoal set to 0 remove the goals of the project
+	WeeklyGoal  *time.Duration
+	MonthlyGoal *time.Duration
+	Name        string
+}
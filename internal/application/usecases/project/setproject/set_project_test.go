@@ -0,0 +1,163 @@
+package setproject_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application/usecases/project/setproject"
+	"github.com/TristanShz/flow/internal/domain/project"
+	"github.com/TristanShz/flow/internal/tests"
+)
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
+
+func durationPtr(d time.Duration) *time.Duration {
+	return &d
+}
+
+func TestSetProject(t *testing.T) {
+	tt := []struct {
+		error         error
+		name          string
+		givenProjects []project.Project
+		command       setproject.Command
+		want          []project.Project
+	}{
+		{
+			name:    "New project",
+			command: setproject.Command{Name: "Flow", OnLock: stringPtr(project.OnLockPause)},
+			want:    []project.Project{{Name: "Flow", OnLock: project.OnLockPause}},
+		},
+		{
+			name:          "Existing project",
+			givenProjects: []project.Project{{Name: "Flow", OnLock: project.OnLockPause}},
+			command:       setproject.Command{Name: "Flow", OnLock: stringPtr(project.OnLockStop)},
+			want:          []project.Project{{Name: "Flow", OnLock: project.OnLockStop}},
+		},
+		{
+			name:          "Existing project keeps unset settings",
+			givenProjects: []project.Project{{Name: "Flow", OnLock: project.OnLockPause}},
+			command:       setproject.Command{Name: "Flow"},
+			want:          []project.Project{{Name: "Flow", OnLock: project.OnLockPause}},
+		},
+		{
+			name:    "Switch to meetings",
+			command: setproject.Command{Name: "Flow", OnMeeting: stringPtr(project.OnMeetingSwitch), MeetingProject: stringPtr(" standups ")},
+			want:    []project.Project{{Name: "Flow", OnMeeting: project.OnMeetingSwitch, MeetingProject: "standups"}},
+		},
+		{
+			name:    "Invalid on meeting action",
+			command: setproject.Command{Name: "Flow", OnMeeting: stringPtr("leave")},
+			error:   setproject.ErrInvalidOnMeeting,
+		},
+		{
+			name:    "Do-not-track windows",
+			command: setproject.Command{Name: "Flow", DoNotTrack: &[]string{"sat,sun", "mon-fri 22:00-07:00"}, OnDoNotTrack: stringPtr(project.DoNotTrackBlock)},
+			want: []project.Project{{
+				Name: "Flow",
+				DoNotTrack: []project.TimeWindow{
+					{Days: []string{"sat", "sun"}},
+					{Days: []string{"mon", "tue", "wed", "thu", "fri"}, From: "22:00", To: "07:00"},
+				},
+				OnDoNotTrack: project.DoNotTrackBlock,
+			}},
+		},
+		{
+			name:          "Do-not-track windows removed",
+			givenProjects: []project.Project{{Name: "Flow", DoNotTrack: []project.TimeWindow{{Days: []string{"sun"}}}}},
+			command:       setproject.Command{Name: "Flow", DoNotTrack: &[]string{""}},
+			want:          []project.Project{{Name: "Flow", DoNotTrack: []project.TimeWindow{}}},
+		},
+		{
+			name:    "Invalid do-not-track window",
+			command: setproject.Command{Name: "Flow", DoNotTrack: &[]string{"weekends"}},
+			error:   project.ErrInvalidTimeWindow,
+		},
+		{
+			name:    "Invalid do-not-track action",
+			command: setproject.Command{Name: "Flow", OnDoNotTrack: stringPtr("warn")},
+			error:   setproject.ErrInvalidOnDoNotTrack,
+		},
+		{
+			name:    "Invalid on lock action",
+			command: setproject.Command{Name: "Flow", OnLock: stringPtr("hibernate")},
+			error:   setproject.ErrInvalidOnLock,
+		},
+		{
+			name:    "Billing settings",
+			command: setproject.Command{Name: "Flow", Client: stringPtr("Acme"), Billable: boolPtr(true), HourlyRate: floatPtr(80)},
+			want:    []project.Project{{Name: "Flow", Client: "Acme", Billable: true, HourlyRate: 80}},
+		},
+		{
+			name:          "Billing settings keep the other settings",
+			givenProjects: []project.Project{{Name: "Flow", OnLock: project.OnLockStop, Client: "Acme", Billable: true, HourlyRate: 80}},
+			command:       setproject.Command{Name: "Flow", Billable: boolPtr(false)},
+			want:          []project.Project{{Name: "Flow", OnLock: project.OnLockStop, Client: "Acme", HourlyRate: 80}},
+		},
+		{
+			name:          "Billing profile can be removed",
+			givenProjects: []project.Project{{Name: "Flow", Client: "Acme", BillingProfile: "retainer"}},
+			command:       setproject.Command{Name: "Flow", BillingProfile: stringPtr("")},
+			want:          []project.Project{{Name: "Flow", Client: "Acme"}},
+		},
+		{
+			name:    "Negative hourly rate",
+			command: setproject.Command{Name: "Flow", HourlyRate: floatPtr(-10)},
+			error:   setproject.ErrNegativeRate,
+		},
+		{
+			name:    "Break schedule",
+			command: setproject.Command{Name: "Flow", BreakEvery: durationPtr(2 * time.Hour), BreakDuration: durationPtr(10 * time.Minute)},
+			want:    []project.Project{{Name: "Flow", BreakEvery: 2 * time.Hour, BreakDuration: 10 * time.Minute}},
+		},
+		{
+			name:          "Break schedule removed",
+			givenProjects: []project.Project{{Name: "Flow", BreakEvery: 2 * time.Hour, BreakDuration: 10 * time.Minute}},
+			command:       setproject.Command{Name: "Flow", BreakEvery: durationPtr(0)},
+			want:          []project.Project{{Name: "Flow", BreakDuration: 10 * time.Minute}},
+		},
+		{
+			name:    "Negative break",
+			command: setproject.Command{Name: "Flow", BreakDuration: durationPtr(-10 * time.Minute)},
+			error:   setproject.ErrNegativeBreak,
+		},
+		{
+			name:    "Goals",
+			command: setproject.Command{Name: "Flow", WeeklyGoal: durationPtr(10 * time.Hour), MonthlyGoal: durationPtr(40 * time.Hour)},
+			want:    []project.Project{{Name: "Flow", WeeklyGoal: 10 * time.Hour, MonthlyGoal: 40 * time.Hour}},
+		},
+		{
+			name:    "Negative goal",
+			command: setproject.Command{Name: "Flow", WeeklyGoal: durationPtr(-time.Hour)},
+			error:   setproject.ErrNegativeGoal,
+		},
+		{
+			name:    "Empty name",
+			command: setproject.Command{OnLock: stringPtr(project.OnLockStop)},
+			error:   setproject.ErrEmptyProjectName,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			f := tests.GetProjectFixture(t)
+
+			f.GivenSomeProjects(tc.givenProjects)
+
+			f.WhenSettingProject(tc.command)
+
+			f.ThenErrorShouldBe(tc.error)
+			f.ThenProjectsShouldBe(tc.want)
+		})
+	}
+}
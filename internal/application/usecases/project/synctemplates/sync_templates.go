@@ -0,0 +1,45 @@
+package synctemplates
+
+import (
+	"errors"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/project"
+)
+
+type UseCase struct {
+	templatesFetcher    application.TemplatesFetcher
+	templatesRepository application.TemplatesRepository
+}
+
+// Execute fetches the templates and stores them, the stored templates are
+// kept when the fetched ones are invalid
+func (s UseCase) Execute(command Command) (project.Templates, error) {
+	if command.Source == "" {
+		return project.Templates{}, ErrNoTemplatesSource
+	}
+
+	templates, err := s.templatesFetcher.Fetch(command.Source)
+	if err != nil {
+		return project.Templates{}, err
+	}
+
+	if err := templates.Check(); err != nil {
+		return project.Templates{}, err
+	}
+
+	if err := s.templatesRepository.Save(templates); err != nil {
+		return project.Templates{}, err
+	}
+
+	return templates, nil
+}
+
+var ErrNoTemplatesSource = errors.New("no templates source given nor configured")
+
+func NewSyncTemplatesUseCase(templatesFetcher application.TemplatesFetcher, templatesRepository application.TemplatesRepository) UseCase {
+	return UseCase{
+		templatesFetcher:    templatesFetcher,
+		templatesRepository: templatesRepository,
+	}
+}
@@ -0,0 +1,6 @@
+package synctemplates
+
+type Command struct {
+	// Source is the git repository, URL or file to fetch the templates from
+	Source string
+}
@@ -0,0 +1,73 @@
+package synctemplates_test
+
+import (
+	"testing"
+
+	"github.com/TristanShz/flow/internal/application/usecases/project/synctemplates"
+	"github.com/TristanShz/flow/internal/domain/project"
+	"github.com/TristanShz/flow/internal/tests"
+)
+
+func TestSyncTemplates(t *testing.T) {
+	teamTemplates := project.Templates{
+		NamePattern: "^[a-z-]+$",
+		Tags:        []string{"dev", "review"},
+		Projects:    []project.Project{{Name: "acme-website", Client: "Acme", Billable: true}},
+	}
+	syncedTemplates := project.Templates{Tags: []string{"dev"}}
+
+	tt := []struct {
+		error          error
+		name           string
+		command        synctemplates.Command
+		givenTemplates project.Templates
+		want           project.Templates
+		wantError      bool
+	}{
+		{
+			name:           "Templates synced",
+			command:        synctemplates.Command{Source: "git@example.com:team/templates.git"},
+			givenTemplates: syncedTemplates,
+			want:           teamTemplates,
+		},
+		{
+			name:           "No source",
+			givenTemplates: syncedTemplates,
+			error:          synctemplates.ErrNoTemplatesSource,
+			want:           syncedTemplates,
+		},
+		{
+			name:           "Unreachable source",
+			command:        synctemplates.Command{Source: "https://example.com/missing.json"},
+			givenTemplates: syncedTemplates,
+			wantError:      true,
+			want:           syncedTemplates,
+		},
+		{
+			name:           "Invalid templates keep the synced ones",
+			command:        synctemplates.Command{Source: "https://example.com/invalid.json"},
+			givenTemplates: syncedTemplates,
+			wantError:      true,
+			want:           syncedTemplates,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			f := tests.GetProjectFixture(t)
+			f.GivenSomeTemplates(tc.givenTemplates)
+			f.GivenTemplatesSource("git@example.com:team/templates.git", teamTemplates)
+			f.GivenTemplatesSource("https://example.com/invalid.json", project.Templates{NamePattern: "[a-z"})
+
+			f.WhenSyncingTemplates(tc.command)
+
+			if tc.wantError && f.ThrownError == nil {
+				t.Errorf("Expected an error")
+			}
+			if tc.error != nil {
+				f.ThenErrorShouldBe(tc.error)
+			}
+			f.ThenTemplatesShouldBe(tc.want)
+		})
+	}
+}
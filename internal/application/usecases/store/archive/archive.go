@@ -0,0 +1,43 @@
+package storearchive
+
+import (
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+)
+
+// DefaultMonths is how many months of sessions are kept out of the archives
+// by default
+const DefaultMonths = 12
+
+type Result struct {
+	Before time.Time
+	Months []application.ArchivedMonth
+}
+
+type UseCase struct {
+	sessionArchiver application.SessionArchiver
+	dateProvider    application.DateProvider
+}
+
+func (s UseCase) Execute(command Command) (Result, error) {
+	before := command.Before
+	if before.IsZero() {
+		now := s.dateProvider.GetNow()
+		before = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()).AddDate(0, -DefaultMonths, 0)
+	}
+
+	months, err := s.sessionArchiver.Archive(before)
+	if err != nil {
+		return Result{}, err
+	}
+
+	return Result{Before: before, Months: months}, nil
+}
+
+func NewArchiveUseCase(sessionArchiver application.SessionArchiver, dateProvider application.DateProvider) UseCase {
+	return UseCase{
+		sessionArchiver: sessionArchiver,
+		dateProvider:    dateProvider,
+	}
+}
@@ -0,0 +1,9 @@
+package storearchive
+
+import "time"
+
+type Command struct {
+	// Before is the time before which the sessions are archived, the start
+	// of the month a year ago when it's zero
+	Before time.Time
+}
@@ -0,0 +1,49 @@
+package storearchive_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	storearchive "github.com/TristanShz/flow/internal/application/usecases/store/archive"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/matryer/is"
+)
+
+func TestArchive(t *testing.T) {
+	months := []application.ArchivedMonth{{Month: "2023-05", Sessions: 2}}
+
+	tt := []struct {
+		name       string
+		command    storearchive.Command
+		wantBefore time.Time
+	}{
+		{
+			name:       "A year ago by default",
+			command:    storearchive.Command{},
+			wantBefore: time.Date(2023, time.April, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "Before the given time",
+			command:    storearchive.Command{Before: time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC)},
+			wantBefore: time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			dateProvider := infra.NewStubDateProvider()
+			dateProvider.Now = time.Date(2024, time.April, 17, 19, 0, 0, 0, time.UTC)
+			archiver := &infra.StubSessionArchiver{Months: months}
+			useCase := storearchive.NewArchiveUseCase(archiver, dateProvider)
+
+			got, err := useCase.Execute(tc.command)
+
+			is.NoErr(err)
+			is.Equal(archiver.Before, tc.wantBefore)
+			is.Equal(got, storearchive.Result{Before: tc.wantBefore, Months: months})
+		})
+	}
+}
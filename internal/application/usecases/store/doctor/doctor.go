@@ -0,0 +1,77 @@
+package storedoctor
+
+import (
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+type Report struct {
+	Issues      []application.SessionFileIssue
+	Repaired    []string
+	Quarantined []string
+	// Unstopped are the sessions that were never stopped, they can't be
+	// repaired as only the user knows when they ended
+	Unstopped []session.Session
+}
+
+type UseCase struct {
+	sessionFilesDoctor application.SessionFilesDoctor
+	sessionRepository  application.SessionRepository
+}
+
+func (s UseCase) Execute(command Command) (Report, error) {
+	unstopped, err := s.findUnstoppedSessions()
+	if err != nil {
+		return Report{}, err
+	}
+
+	report := Report{
+		Issues:      s.sessionFilesDoctor.Diagnose(),
+		Repaired:    []string{},
+		Quarantined: []string{},
+		Unstopped:   unstopped,
+	}
+
+	if !command.Repair {
+		return report, nil
+	}
+
+	for _, issue := range report.Issues {
+		quarantined, err := s.sessionFilesDoctor.Repair(issue)
+		if err != nil {
+			return report, err
+		}
+
+		if quarantined {
+			report.Quarantined = append(report.Quarantined, issue.Filename)
+		} else {
+			report.Repaired = append(report.Repaired, issue.Filename)
+		}
+	}
+
+	return report, nil
+}
+
+func (s UseCase) findUnstoppedSessions() ([]session.Session, error) {
+	unstopped := []session.Session{}
+
+	lastSession, err := s.sessionRepository.FindLastSession()
+	if err != nil || lastSession == nil {
+		return unstopped, err
+	}
+
+	for _, found := range s.sessionRepository.FindAllSessions(nil) {
+		if found.Status() == session.UnstoppedStatus || found.WasNeverStopped(lastSession.StartTime) {
+			unstopped = append(unstopped, found)
+		}
+	}
+
+	return unstopped, nil
+}
+
+func NewDoctorUseCase(sessionFilesDoctor application.SessionFilesDoctor, sessionRepository application.SessionRepository) UseCase {
+	return UseCase{
+		sessionFilesDoctor: sessionFilesDoctor,
+		sessionRepository:  sessionRepository,
+	}
+}
@@ -0,0 +1,5 @@
+package storedoctor
+
+type Command struct {
+	Repair bool
+}
@@ -0,0 +1,82 @@
+package storedoctor_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	storedoctor "github.com/TristanShz/flow/internal/application/usecases/store/doctor"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/matryer/is"
+)
+
+func TestDoctor(t *testing.T) {
+	issues := []application.SessionFileIssue{
+		{Filename: "1-my-project-1713380400.json", Kind: application.InvalidFilenameIssue},
+		{Filename: "2-Flow-1713387600.json", Kind: application.CorruptedDataIssue},
+	}
+
+	unstopped := session.Session{
+		Id:        "1",
+		StartTime: time.Date(2024, time.April, 13, 9, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+	}
+	sessions := []session.Session{
+		unstopped,
+		{
+			Id:        "2",
+			StartTime: time.Date(2024, time.April, 14, 9, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, time.April, 14, 10, 0, 0, 0, time.UTC),
+			Project:   "Flow",
+		},
+		{
+			Id:        "3",
+			StartTime: time.Date(2024, time.April, 14, 11, 0, 0, 0, time.UTC),
+			Project:   "Flow",
+		},
+	}
+
+	tt := []struct {
+		name    string
+		command storedoctor.Command
+		want    storedoctor.Report
+	}{
+		{
+			name:    "Diagnose only",
+			command: storedoctor.Command{},
+			want: storedoctor.Report{
+				Issues:      issues,
+				Repaired:    []string{},
+				Quarantined: []string{},
+				Unstopped:   []session.Session{unstopped},
+			},
+		},
+		{
+			name:    "Repair",
+			command: storedoctor.Command{Repair: true},
+			want: storedoctor.Report{
+				Issues:      issues,
+				Repaired:    []string{"1-my-project-1713380400.json"},
+				Quarantined: []string{"2-Flow-1713387600.json"},
+				Unstopped:   []session.Session{unstopped},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			useCase := storedoctor.NewDoctorUseCase(
+				&infra.StubSessionFilesDoctor{Issues: issues},
+				&infra.InMemorySessionRepository{Sessions: sessions},
+			)
+
+			got, err := useCase.Execute(tc.command)
+
+			is.NoErr(err)
+			is.Equal(got, tc.want)
+		})
+	}
+}
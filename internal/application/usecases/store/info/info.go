@@ -0,0 +1,19 @@
+package storeinfo
+
+import (
+	"github.com/TristanShz/flow/internal/application"
+)
+
+type UseCase struct {
+	storeInspector application.StoreInspector
+}
+
+func (s UseCase) Execute() (application.StoreInfo, error) {
+	return s.storeInspector.Info()
+}
+
+func NewInfoUseCase(storeInspector application.StoreInspector) UseCase {
+	return UseCase{
+		storeInspector: storeInspector,
+	}
+}
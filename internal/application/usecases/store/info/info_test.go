@@ -0,0 +1,31 @@
+package storeinfo_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	storeinfo "github.com/TristanShz/flow/internal/application/usecases/store/info"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/matryer/is"
+)
+
+func TestInfo(t *testing.T) {
+	want := application.StoreInfo{
+		Backend:         "filesystem",
+		Location:        "/home/me/.flow",
+		SessionFiles:    2,
+		TotalSize:       512,
+		IndexedSessions: 2,
+		OldestSession:   time.Date(2024, time.April, 17, 19, 0, 0, 0, time.UTC),
+		NewestSession:   time.Date(2024, time.April, 17, 21, 0, 0, 0, time.UTC),
+	}
+
+	is := is.New(t)
+
+	useCase := storeinfo.NewInfoUseCase(&infra.StubStoreInspector{StoreInfo: want})
+
+	got, err := useCase.Execute()
+	is.NoErr(err)
+	is.Equal(got, want)
+}
@@ -0,0 +1,41 @@
+package storemigrate
+
+import (
+	"github.com/TristanShz/flow/internal/application"
+)
+
+type Report struct {
+	LegacyFiles []string
+	Migrated    []string
+}
+
+type UseCase struct {
+	sessionFilesMigrator application.SessionFilesMigrator
+}
+
+func (s UseCase) Execute(command Command) (Report, error) {
+	report := Report{
+		LegacyFiles: s.sessionFilesMigrator.FindLegacyFiles(),
+		Migrated:    []string{},
+	}
+
+	if command.DryRun {
+		return report, nil
+	}
+
+	for _, legacyFile := range report.LegacyFiles {
+		if err := s.sessionFilesMigrator.Migrate(legacyFile); err != nil {
+			return report, err
+		}
+
+		report.Migrated = append(report.Migrated, legacyFile)
+	}
+
+	return report, nil
+}
+
+func NewMigrateUseCase(sessionFilesMigrator application.SessionFilesMigrator) UseCase {
+	return UseCase{
+		sessionFilesMigrator: sessionFilesMigrator,
+	}
+}
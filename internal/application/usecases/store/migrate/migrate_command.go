@@ -0,0 +1,5 @@
+package storemigrate
+
+type Command struct {
+	DryRun bool
+}
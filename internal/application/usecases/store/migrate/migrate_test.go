@@ -0,0 +1,49 @@
+package storemigrate_test
+
+import (
+	"testing"
+
+	storemigrate "github.com/TristanShz/flow/internal/application/usecases/store/migrate"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/matryer/is"
+)
+
+func TestMigrate(t *testing.T) {
+	legacyFiles := []string{"1-Flow-1713380400.json", "2-myproject-1713387600.json"}
+
+	tt := []struct {
+		name    string
+		command storemigrate.Command
+		want    storemigrate.Report
+	}{
+		{
+			name:    "Dry run",
+			command: storemigrate.Command{DryRun: true},
+			want: storemigrate.Report{
+				LegacyFiles: legacyFiles,
+				Migrated:    []string{},
+			},
+		},
+		{
+			name:    "Migrate",
+			command: storemigrate.Command{},
+			want: storemigrate.Report{
+				LegacyFiles: legacyFiles,
+				Migrated:    legacyFiles,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			useCase := storemigrate.NewMigrateUseCase(&infra.StubSessionFilesMigrator{LegacyFiles: legacyFiles})
+
+			got, err := useCase.Execute(tc.command)
+
+			is.NoErr(err)
+			is.Equal(got, tc.want)
+		})
+	}
+}
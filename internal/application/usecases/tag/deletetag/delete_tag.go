@@ -0,0 +1,43 @@
+package deletetag
+
+import (
+	"errors"
+
+	"github.com/TristanShz/flow/internal/application"
+)
+
+type UseCase struct {
+	sessionRepository application.SessionRepository
+}
+
+// Execute removes the tag from every session having it and returns the number
+// of updated sessions
+func (s UseCase) Execute(command Command) (int, error) {
+	if command.Tag == "" {
+		return 0, ErrEmptyTag
+	}
+
+	sessions := s.sessionRepository.FindAllSessions(&application.SessionsFilters{Tags: []string{command.Tag}})
+	if len(sessions) == 0 {
+		return 0, ErrTagNotFound
+	}
+
+	for _, session := range sessions {
+		if err := s.sessionRepository.Save(session.WithTags(nil, []string{command.Tag})); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(sessions), nil
+}
+
+var (
+	ErrEmptyTag    = errors.New("tag can't be empty")
+	ErrTagNotFound = errors.New("no session has this tag")
+)
+
+func NewDeleteTagUseCase(sessionRepository application.SessionRepository) UseCase {
+	return UseCase{
+		sessionRepository: sessionRepository,
+	}
+}
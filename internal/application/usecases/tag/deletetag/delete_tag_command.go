@@ -0,0 +1,5 @@
+package deletetag
+
+type Command struct {
+	Tag string
+}
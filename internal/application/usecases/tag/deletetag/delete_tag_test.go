@@ -0,0 +1,80 @@
+package deletetag_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application/usecases/tag/deletetag"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/tests"
+)
+
+func TestDeleteTag(t *testing.T) {
+	givenSessions := []session.Session{
+		{
+			Id:        "1",
+			StartTime: time.Date(2024, time.April, 13, 9, 0, 0, 0, time.UTC),
+			Project:   "Flow",
+			Tags:      []string{"wip", "cli"},
+		},
+		{
+			Id:        "2",
+			StartTime: time.Date(2024, time.April, 13, 11, 0, 0, 0, time.UTC),
+			Project:   "MyTodo",
+			Tags:      []string{"wip"},
+		},
+		{
+			Id:        "3",
+			StartTime: time.Date(2024, time.April, 13, 14, 0, 0, 0, time.UTC),
+			Project:   "Flow",
+			Tags:      []string{"cli"},
+		},
+	}
+
+	tt := []struct {
+		error               error
+		name                string
+		command             deletetag.Command
+		wantTags            [][]string
+		wantUpdatedSessions int
+	}{
+		{
+			name:                "Delete",
+			command:             deletetag.Command{Tag: "wip"},
+			wantTags:            [][]string{{"cli"}, {}, {"cli"}},
+			wantUpdatedSessions: 2,
+		},
+		{
+			name:     "Tag not found",
+			command:  deletetag.Command{Tag: "api"},
+			error:    deletetag.ErrTagNotFound,
+			wantTags: [][]string{{"wip", "cli"}, {"wip"}, {"cli"}},
+		},
+		{
+			name:     "Empty tag",
+			command:  deletetag.Command{},
+			error:    deletetag.ErrEmptyTag,
+			wantTags: [][]string{{"wip", "cli"}, {"wip"}, {"cli"}},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			f := tests.GetSessionFixture(t)
+
+			f.GivenSomeSessions(append([]session.Session{}, givenSessions...))
+
+			f.WhenDeletingTag(tc.command)
+
+			f.ThenErrorShouldBe(tc.error)
+			f.ThenUpdatedSessionsShouldBe(tc.wantUpdatedSessions)
+
+			want := []session.Session{}
+			for i, s := range givenSessions {
+				s.Tags = tc.wantTags[i]
+				want = append(want, s)
+			}
+			f.ThenSessionsShouldBe(want)
+		})
+	}
+}
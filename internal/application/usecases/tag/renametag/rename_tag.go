@@ -0,0 +1,49 @@
+package renametag
+
+import (
+	"errors"
+
+	"github.com/TristanShz/flow/internal/application"
+)
+
+type UseCase struct {
+	sessionRepository application.SessionRepository
+}
+
+// Execute renames the tag in every session having it and returns the number
+// of updated sessions
+func (s UseCase) Execute(command Command) (int, error) {
+	if command.Tag == "" || command.NewTag == "" {
+		return 0, ErrEmptyTag
+	}
+
+	if command.Tag == command.NewTag {
+		return 0, ErrSameTag
+	}
+
+	sessions := s.sessionRepository.FindAllSessions(&application.SessionsFilters{Tags: []string{command.Tag}})
+	if len(sessions) == 0 {
+		return 0, ErrTagNotFound
+	}
+
+	for _, session := range sessions {
+		renamed := session.WithTags([]string{command.NewTag}, []string{command.Tag})
+		if err := s.sessionRepository.Save(renamed); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(sessions), nil
+}
+
+var (
+	ErrEmptyTag    = errors.New("tag can't be empty")
+	ErrSameTag     = errors.New("the new name of the tag is the same as its current name")
+	ErrTagNotFound = errors.New("no session has this tag")
+)
+
+func NewRenameTagUseCase(sessionRepository application.SessionRepository) UseCase {
+	return UseCase{
+		sessionRepository: sessionRepository,
+	}
+}
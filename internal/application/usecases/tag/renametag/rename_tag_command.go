@@ -0,0 +1,6 @@
+package renametag
+
+type Command struct {
+	Tag    string
+	NewTag string
+}
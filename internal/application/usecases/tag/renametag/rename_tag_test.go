@@ -0,0 +1,86 @@
+package renametag_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application/usecases/tag/renametag"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/tests"
+)
+
+func TestRenameTag(t *testing.T) {
+	givenSessions := []session.Session{
+		{
+			Id:        "1",
+			StartTime: time.Date(2024, time.April, 13, 9, 0, 0, 0, time.UTC),
+			Project:   "Flow",
+			Tags:      []string{"doc", "cli"},
+		},
+		{
+			Id:        "2",
+			StartTime: time.Date(2024, time.April, 13, 11, 0, 0, 0, time.UTC),
+			Project:   "MyTodo",
+			Tags:      []string{"doc", "docs"},
+		},
+		{
+			Id:        "3",
+			StartTime: time.Date(2024, time.April, 13, 14, 0, 0, 0, time.UTC),
+			Project:   "Flow",
+			Tags:      []string{"cli"},
+		},
+	}
+
+	tt := []struct {
+		error               error
+		name                string
+		command             renametag.Command
+		wantTags            [][]string
+		wantUpdatedSessions int
+	}{
+		{
+			name:                "Rename",
+			command:             renametag.Command{Tag: "doc", NewTag: "docs"},
+			wantTags:            [][]string{{"cli", "docs"}, {"docs"}, {"cli"}},
+			wantUpdatedSessions: 2,
+		},
+		{
+			name:     "Tag not found",
+			command:  renametag.Command{Tag: "api", NewTag: "backend"},
+			error:    renametag.ErrTagNotFound,
+			wantTags: [][]string{{"doc", "cli"}, {"doc", "docs"}, {"cli"}},
+		},
+		{
+			name:     "Same tag",
+			command:  renametag.Command{Tag: "doc", NewTag: "doc"},
+			error:    renametag.ErrSameTag,
+			wantTags: [][]string{{"doc", "cli"}, {"doc", "docs"}, {"cli"}},
+		},
+		{
+			name:     "Empty tag",
+			command:  renametag.Command{Tag: "doc"},
+			error:    renametag.ErrEmptyTag,
+			wantTags: [][]string{{"doc", "cli"}, {"doc", "docs"}, {"cli"}},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			f := tests.GetSessionFixture(t)
+
+			f.GivenSomeSessions(append([]session.Session{}, givenSessions...))
+
+			f.WhenRenamingTag(tc.command)
+
+			f.ThenErrorShouldBe(tc.error)
+			f.ThenUpdatedSessionsShouldBe(tc.wantUpdatedSessions)
+
+			want := []session.Session{}
+			for i, s := range givenSessions {
+				s.Tags = tc.wantTags[i]
+				want = append(want, s)
+			}
+			f.ThenSessionsShouldBe(want)
+		})
+	}
+}
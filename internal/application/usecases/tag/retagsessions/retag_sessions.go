@@ -0,0 +1,62 @@
+package retagsessions
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/pkg/timerange"
+)
+
+type UseCase struct {
+	sessionRepository application.SessionRepository
+}
+
+// Execute adds and removes tags on the matching sessions and returns the
+// number of sessions that changed
+func (s UseCase) Execute(command Command) (int, error) {
+	onlyRules := len(command.AddTags) == 0 && len(command.RemoveTags) == 0
+	if onlyRules && len(command.TagRules) == 0 && len(command.TagDeprecations) == 0 {
+		return 0, ErrNoTags
+	}
+
+	if !onlyRules && command.Project == "" && command.Since.IsZero() && command.Until.IsZero() {
+		return 0, ErrNoFilter
+	}
+
+	filters := &application.SessionsFilters{Project: command.Project}
+	if !command.Since.IsZero() || !command.Until.IsZero() {
+		filters.Timerange = timerange.TimeRange{
+			Since: command.Since,
+			Until: command.Until,
+		}
+	}
+
+	updated := 0
+	for _, session := range s.sessionRepository.FindAllSessions(filters) {
+		retagged := session.WithTags(command.AddTags, command.RemoveTags).
+			WithTagRules(command.TagRules).
+			WithTagDeprecations(command.TagDeprecations)
+		if slices.Equal(retagged.Tags, session.Tags) {
+			continue
+		}
+
+		if err := s.sessionRepository.Save(retagged); err != nil {
+			return updated, err
+		}
+		updated++
+	}
+
+	return updated, nil
+}
+
+var (
+	ErrNoTags   = errors.New("no tag to add or remove and no tag rule or deprecation to apply")
+	ErrNoFilter = errors.New("a project or a time range is needed to select the sessions to retag")
+)
+
+func NewRetagSessionsUseCase(sessionRepository application.SessionRepository) UseCase {
+	return UseCase{
+		sessionRepository: sessionRepository,
+	}
+}
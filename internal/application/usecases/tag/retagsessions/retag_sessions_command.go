@@ -0,0 +1,23 @@
+package retagsessions
+
+import (
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+// Command selects the sessions to retag by project and time range, at least
+// one of them must be given unless only tag rules or deprecations are applied
+type Command struct {
+	Since      time.Time
+	Until      time.Time
+	Project    string
+	AddTags    []string
+	RemoveTags []string
+	// TagRules are applied again to the sessions, e.g. to tag the sessions
+	// saved before the rules were added to the config
+	TagRules []session.TagRule
+	// TagDeprecations replace the deprecated tags of the sessions with their
+	// replacement
+	TagDeprecations []session.TagDeprecation
+}
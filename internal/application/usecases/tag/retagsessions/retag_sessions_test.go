@@ -0,0 +1,124 @@
+package retagsessions_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application/usecases/tag/retagsessions"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/tests"
+)
+
+func TestRetagSessions(t *testing.T) {
+	givenSessions := []session.Session{
+		{
+			Id:        "1",
+			StartTime: time.Date(2024, time.April, 12, 9, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, time.April, 12, 10, 0, 0, 0, time.UTC),
+			Project:   "Flow",
+			Tags:      []string{"cli"},
+		},
+		{
+			Id:        "2",
+			StartTime: time.Date(2024, time.April, 13, 11, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, time.April, 13, 12, 0, 0, 0, time.UTC),
+			Project:   "Flow",
+			Tags:      []string{"cli", "wip"},
+		},
+		{
+			Id:        "3",
+			StartTime: time.Date(2024, time.April, 13, 14, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, time.April, 13, 15, 0, 0, 0, time.UTC),
+			Project:   "MyTodo",
+			Tags:      []string{"wip"},
+		},
+	}
+
+	tt := []struct {
+		error               error
+		name                string
+		command             retagsessions.Command
+		wantTags            [][]string
+		wantUpdatedSessions int
+	}{
+		{
+			name:                "Project",
+			command:             retagsessions.Command{Project: "Flow", AddTags: []string{"v2"}, RemoveTags: []string{"wip"}},
+			wantTags:            [][]string{{"cli", "v2"}, {"cli", "v2"}, {"wip"}},
+			wantUpdatedSessions: 2,
+		},
+		{
+			name: "Time range",
+			command: retagsessions.Command{
+				Since:      time.Date(2024, time.April, 13, 0, 0, 0, 0, time.UTC),
+				Until:      time.Date(2024, time.April, 14, 0, 0, 0, 0, time.UTC),
+				RemoveTags: []string{"wip"},
+			},
+			wantTags:            [][]string{{"cli"}, {"cli"}, {}},
+			wantUpdatedSessions: 2,
+		},
+		{
+			name:                "Unchanged sessions aren't counted",
+			command:             retagsessions.Command{Project: "Flow", AddTags: []string{"cli"}},
+			wantTags:            [][]string{{"cli"}, {"cli", "wip"}, {"wip"}},
+			wantUpdatedSessions: 0,
+		},
+		{
+			name: "Tag rules without filter",
+			command: retagsessions.Command{
+				TagRules: []session.TagRule{{Tag: "weekend", Days: []time.Weekday{time.Saturday, time.Sunday}}},
+			},
+			wantTags:            [][]string{{"cli"}, {"cli", "wip", "weekend"}, {"wip", "weekend"}},
+			wantUpdatedSessions: 2,
+		},
+		{
+			name: "Tag deprecations without filter",
+			command: retagsessions.Command{
+				TagDeprecations: []session.TagDeprecation{{Tag: "wip", Replacement: "in-progress"}, {Tag: "cli", Replacement: ""}},
+			},
+			wantTags:            [][]string{{}, {"in-progress"}, {"in-progress"}},
+			wantUpdatedSessions: 3,
+		},
+		{
+			name: "Tag deprecation replaced with a tag of the session",
+			command: retagsessions.Command{
+				Project:         "Flow",
+				TagDeprecations: []session.TagDeprecation{{Tag: "wip", Replacement: "cli"}},
+			},
+			wantTags:            [][]string{{"cli"}, {"cli"}, {"wip"}},
+			wantUpdatedSessions: 1,
+		},
+		{
+			name:     "No tags",
+			command:  retagsessions.Command{Project: "Flow"},
+			error:    retagsessions.ErrNoTags,
+			wantTags: [][]string{{"cli"}, {"cli", "wip"}, {"wip"}},
+		},
+		{
+			name:     "No filter",
+			command:  retagsessions.Command{AddTags: []string{"v2"}},
+			error:    retagsessions.ErrNoFilter,
+			wantTags: [][]string{{"cli"}, {"cli", "wip"}, {"wip"}},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			f := tests.GetSessionFixture(t)
+
+			f.GivenSomeSessions(append([]session.Session{}, givenSessions...))
+
+			f.WhenRetaggingSessions(tc.command)
+
+			f.ThenErrorShouldBe(tc.error)
+			f.ThenUpdatedSessionsShouldBe(tc.wantUpdatedSessions)
+
+			want := []session.Session{}
+			for i, s := range givenSessions {
+				s.Tags = tc.wantTags[i]
+				want = append(want, s)
+			}
+			f.ThenSessionsShouldBe(want)
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package listtrash
+
+import (
+	"github.com/TristanShz/flow/internal/application"
+)
+
+type UseCase struct {
+	sessionTrash application.SessionTrash
+}
+
+// Execute returns the trashed sessions, the last deleted first
+func (s UseCase) Execute() ([]application.TrashedSession, error) {
+	return s.sessionTrash.FindAllTrashed()
+}
+
+func NewListTrashUseCase(sessionTrash application.SessionTrash) UseCase {
+	return UseCase{
+		sessionTrash: sessionTrash,
+	}
+}
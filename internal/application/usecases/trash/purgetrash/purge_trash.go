@@ -0,0 +1,36 @@
+package purgetrash
+
+import (
+	"errors"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+)
+
+var ErrNegativeAge = errors.New("the age of the purged sessions can't be negative")
+
+type UseCase struct {
+	sessionTrash application.SessionTrash
+	dateProvider application.DateProvider
+}
+
+// Execute removes the trashed sessions for good, and returns how many were
+// removed
+func (s UseCase) Execute(command Command) (int, error) {
+	if command.OlderThan < 0 {
+		return 0, ErrNegativeAge
+	}
+
+	if command.OlderThan == 0 {
+		return s.sessionTrash.Purge(time.Time{})
+	}
+
+	return s.sessionTrash.Purge(s.dateProvider.GetNow().Add(-command.OlderThan))
+}
+
+func NewPurgeTrashUseCase(sessionTrash application.SessionTrash, dateProvider application.DateProvider) UseCase {
+	return UseCase{
+		sessionTrash: sessionTrash,
+		dateProvider: dateProvider,
+	}
+}
@@ -0,0 +1,9 @@
+package purgetrash
+
+import "time"
+
+type Command struct {
+	// OlderThan keeps the sessions deleted since then, the whole trash is
+	// purged when it's zero
+	OlderThan time.Duration
+}
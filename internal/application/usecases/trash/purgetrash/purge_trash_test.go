@@ -0,0 +1,61 @@
+package purgetrash_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/application/usecases/trash/purgetrash"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/matryer/is"
+)
+
+func TestPurgeTrash(t *testing.T) {
+	now := time.Date(2024, time.April, 20, 12, 0, 0, 0, time.UTC)
+	recent := application.TrashedSession{DeletedAt: now.Add(-time.Hour), Session: session.Session{Id: "2", Project: "Flow"}}
+	old := application.TrashedSession{DeletedAt: now.Add(-72 * time.Hour), Session: session.Session{Id: "1", Project: "Flow"}}
+
+	tt := []struct {
+		name      string
+		command   purgetrash.Command
+		want      int
+		wantTrash []application.TrashedSession
+		wantErr   error
+	}{
+		{
+			name:      "Whole trash",
+			want:      2,
+			wantTrash: []application.TrashedSession{},
+		},
+		{
+			name:      "Sessions deleted long ago",
+			command:   purgetrash.Command{OlderThan: 24 * time.Hour},
+			want:      1,
+			wantTrash: []application.TrashedSession{recent},
+		},
+		{
+			name:      "Negative age",
+			command:   purgetrash.Command{OlderThan: -time.Hour},
+			wantTrash: []application.TrashedSession{recent, old},
+			wantErr:   purgetrash.ErrNegativeAge,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			repository := &infra.InMemorySessionRepository{Trash: []application.TrashedSession{recent, old}}
+			dateProvider := infra.NewStubDateProvider()
+			dateProvider.Now = now
+			useCase := purgetrash.NewPurgeTrashUseCase(repository, dateProvider)
+
+			got, err := useCase.Execute(tc.command)
+
+			is.Equal(err, tc.wantErr)
+			is.Equal(got, tc.want)
+			is.Equal(repository.Trash, tc.wantTrash)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package restoresession
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+var ErrSessionExists = errors.New("a session with the same id exists, it must be deleted before the trashed one is restored")
+
+type UseCase struct {
+	sessionRepository application.SessionRepository
+	sessionTrash      application.SessionTrash
+}
+
+// Execute moves the trashed session back with the other sessions
+func (s UseCase) Execute(command Command) (session.Session, error) {
+	trashed, err := s.sessionTrash.FindAllTrashed()
+	if err != nil {
+		return session.Session{}, err
+	}
+
+	index := slices.IndexFunc(trashed, func(trashed application.TrashedSession) bool {
+		return trashed.Session.Id == command.Id
+	})
+	if index == -1 {
+		return session.Session{}, application.ErrSessionNotTrashed
+	}
+
+	if err := session.CheckLocked(command.LockedBefore, trashed[index].Session); err != nil {
+		return session.Session{}, err
+	}
+
+	existing, err := s.sessionRepository.FindById(command.Id)
+	if err != nil {
+		return session.Session{}, err
+	}
+	if existing != nil {
+		return session.Session{}, ErrSessionExists
+	}
+
+	return s.sessionTrash.Restore(command.Id)
+}
+
+func NewRestoreSessionUseCase(
+	sessionRepository application.SessionRepository,
+	sessionTrash application.SessionTrash,
+) UseCase {
+	return UseCase{
+		sessionRepository: sessionRepository,
+		sessionTrash:      sessionTrash,
+	}
+}
@@ -0,0 +1,10 @@
+package restoresession
+
+import "time"
+
+type Command struct {
+	Id string
+	// LockedBefore is the start of the first month whose sessions can be
+	// changed, see session.IsLocked
+	LockedBefore time.Time
+}
@@ -0,0 +1,78 @@
+package restoresession_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/application/usecases/trash/restoresession"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/matryer/is"
+)
+
+func TestRestoreSession(t *testing.T) {
+	trashed := session.Session{
+		Id:        "1",
+		StartTime: time.Date(2024, time.April, 15, 9, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, time.April, 15, 10, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+	}
+	deletedAt := time.Date(2024, time.April, 16, 9, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		name          string
+		givenSessions []session.Session
+		command       restoresession.Command
+		want          []session.Session
+		wantTrash     int
+		wantErr       error
+	}{
+		{
+			name:    "Trashed session",
+			command: restoresession.Command{Id: "1"},
+			want:    []session.Session{trashed},
+		},
+		{
+			name:      "Session not in the trash",
+			command:   restoresession.Command{Id: "2"},
+			wantTrash: 1,
+			wantErr:   application.ErrSessionNotTrashed,
+		},
+		{
+			name:          "Session with the same id",
+			givenSessions: []session.Session{{Id: "1", StartTime: deletedAt, Project: "Other"}},
+			command:       restoresession.Command{Id: "1"},
+			want:          []session.Session{{Id: "1", StartTime: deletedAt, Project: "Other"}},
+			wantTrash:     1,
+			wantErr:       restoresession.ErrSessionExists,
+		},
+		{
+			name:      "Session of a read-only month",
+			command:   restoresession.Command{Id: "1", LockedBefore: time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC)},
+			wantTrash: 1,
+			wantErr:   session.ErrLockedHistory,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			repository := &infra.InMemorySessionRepository{
+				Sessions: tc.givenSessions,
+				Trash:    []application.TrashedSession{{DeletedAt: deletedAt, Session: trashed}},
+			}
+			useCase := restoresession.NewRestoreSessionUseCase(repository, repository)
+
+			got, err := useCase.Execute(tc.command)
+
+			is.Equal(err, tc.wantErr)
+			if tc.wantErr == nil {
+				is.Equal(got, trashed)
+			}
+			is.Equal(repository.Sessions, tc.want)
+			is.Equal(len(repository.Trash), tc.wantTrash)
+		})
+	}
+}
@@ -0,0 +1,409 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+// WhereFields are the fields of a session a where expression compares
+var WhereFields = []string{"project", "tag", "note", "duration", "date", "status"}
+
+var ErrInvalidWhere = errors.New("invalid where expression")
+
+// Condition keeps the sessions matching a where expression
+type Condition interface {
+	Match(s session.Session) bool
+}
+
+type andCondition struct{ left, right Condition }
+
+func (c andCondition) Match(s session.Session) bool {
+	return c.left.Match(s) && c.right.Match(s)
+}
+
+type orCondition struct{ left, right Condition }
+
+func (c orCondition) Match(s session.Session) bool {
+	return c.left.Match(s) || c.right.Match(s)
+}
+
+type notCondition struct{ condition Condition }
+
+func (c notCondition) Match(s session.Session) bool {
+	return !c.condition.Match(s)
+}
+
+// comparison compares a field of the session to one or several values, the
+// values are parsed once by the parser
+type comparison struct {
+	field     string
+	operator  string
+	values    []string
+	durations []time.Duration
+}
+
+func (c comparison) Match(s session.Session) bool {
+	switch c.field {
+	case "project":
+		return c.matchString(s.Project)
+	case "note":
+		return c.matchString(s.Note)
+	case "status":
+		return c.matchString(strings.ToLower(s.Status()))
+	case "date":
+		return compare(c.operator, strings.Compare(s.StartTime.Local().Format(time.DateOnly), c.values[0]))
+	case "duration":
+		return compare(c.operator, int(s.Duration()-c.durations[0]))
+	case "tag":
+		switch c.operator {
+		case "=", "in":
+			return s.HasAnyTag(c.values)
+		case "!=", "not in":
+			return !s.HasAnyTag(c.values)
+		case "~":
+			return slices.ContainsFunc(s.Tags, func(tag string) bool { return contains(tag, c.values[0]) })
+		}
+	}
+
+	return false
+}
+
+func (c comparison) matchString(value string) bool {
+	switch c.operator {
+	case "=", "in":
+		return slices.Contains(c.values, value)
+	case "!=", "not in":
+		return !slices.Contains(c.values, value)
+	case "~":
+		return contains(value, c.values[0])
+	}
+
+	return false
+}
+
+// contains is a case insensitive strings.Contains
+func contains(value string, substring string) bool {
+	return strings.Contains(strings.ToLower(value), strings.ToLower(substring))
+}
+
+// compare tells if the result of a comparison of the session to the value
+// satisfies the operator
+func compare(operator string, result int) bool {
+	switch operator {
+	case "=":
+		return result == 0
+	case "!=":
+		return result != 0
+	case "<":
+		return result < 0
+	case "<=":
+		return result <= 0
+	case ">":
+		return result > 0
+	case ">=":
+		return result >= 0
+	}
+
+	return false
+}
+
+// operators of each field, "in" and "not in" take a list of values
+var whereOperators = map[string][]string{
+	"project":  {"=", "!=", "~", "in", "not in"},
+	"tag":      {"=", "!=", "~", "in", "not in"},
+	"note":     {"=", "!=", "~"},
+	"status":   {"=", "!="},
+	"duration": {"=", "!=", "<", "<=", ">", ">="},
+	"date":     {"=", "!=", "<", "<=", ">", ">="},
+}
+
+// ParseWhere parses a where expression like
+//
+//	project = "Flow" and duration > 1h and tag in (deep, review)
+//
+// comparisons are combined with and, or, not and parentheses. Strings with
+// spaces are quoted, durations are Go durations and dates are YYYY-MM-DD.
+func ParseWhere(expression string) (Condition, error) {
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &whereParser{tokens: tokens}
+	condition, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.done() {
+		return nil, p.errorf("unexpected %q", p.peek().text)
+	}
+
+	return condition, nil
+}
+
+type tokenKind int
+
+const (
+	wordToken tokenKind = iota
+	stringToken
+	symbolToken
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+func tokenize(expression string) ([]token, error) {
+	tokens := []token{}
+	runes := []rune(expression)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, fmt.Errorf("%w: unterminated string", ErrInvalidWhere)
+			}
+			tokens = append(tokens, token{kind: stringToken, text: string(runes[i+1 : end])})
+			i = end + 1
+		case strings.ContainsRune("(),~", r):
+			tokens = append(tokens, token{kind: symbolToken, text: string(r)})
+			i++
+		case strings.ContainsRune("=!<>", r):
+			end := i + 1
+			if end < len(runes) && runes[end] == '=' {
+				end++
+			}
+			symbol := string(runes[i:end])
+			if symbol == "!" {
+				return nil, fmt.Errorf("%w: unexpected \"!\"", ErrInvalidWhere)
+			}
+			tokens = append(tokens, token{kind: symbolToken, text: symbol})
+			i = end
+		default:
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && !strings.ContainsRune("(),~=!<>\"", runes[end]) {
+				end++
+			}
+			tokens = append(tokens, token{kind: wordToken, text: string(runes[i:end])})
+			i = end
+		}
+	}
+
+	return tokens, nil
+}
+
+type whereParser struct {
+	tokens   []token
+	position int
+}
+
+func (p *whereParser) done() bool {
+	return p.position >= len(p.tokens)
+}
+
+func (p *whereParser) peek() token {
+	if p.done() {
+		return token{}
+	}
+	return p.tokens[p.position]
+}
+
+func (p *whereParser) next() token {
+	t := p.peek()
+	p.position++
+	return t
+}
+
+// isKeyword tells if the next token is the given keyword, keywords aren't
+// case sensitive and quoted strings are never keywords
+func (p *whereParser) isKeyword(keyword string) bool {
+	t := p.peek()
+	return !p.done() && t.kind == wordToken && strings.EqualFold(t.text, keyword)
+}
+
+func (p *whereParser) isSymbol(symbol string) bool {
+	t := p.peek()
+	return !p.done() && t.kind == symbolToken && t.text == symbol
+}
+
+func (p *whereParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("%w: %v", ErrInvalidWhere, fmt.Sprintf(format, args...))
+}
+
+func (p *whereParser) parseOr() (Condition, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.isKeyword("or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orCondition{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *whereParser) parseAnd() (Condition, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.isKeyword("and") {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = andCondition{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *whereParser) parseNot() (Condition, error) {
+	if p.isKeyword("not") {
+		p.next()
+		condition, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notCondition{condition: condition}, nil
+	}
+
+	if p.isSymbol("(") {
+		p.next()
+		condition, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.isSymbol(")") {
+			return nil, p.errorf("missing \")\"")
+		}
+		p.next()
+		return condition, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *whereParser) parseComparison() (Condition, error) {
+	if p.done() {
+		return nil, p.errorf("missing comparison")
+	}
+
+	field := p.next()
+	operators, ok := whereOperators[strings.ToLower(field.text)]
+	if field.kind != wordToken || !ok {
+		return nil, p.errorf("unknown field %q. possible values: %v", field.text, strings.Join(WhereFields, ", "))
+	}
+	c := comparison{field: strings.ToLower(field.text)}
+
+	switch {
+	case p.isKeyword("in"):
+		p.next()
+		c.operator = "in"
+	case p.isKeyword("not"):
+		p.next()
+		if !p.isKeyword("in") {
+			return nil, p.errorf("expected \"in\" after \"%v not\"", c.field)
+		}
+		p.next()
+		c.operator = "not in"
+	case !p.done() && p.peek().kind == symbolToken:
+		c.operator = p.next().text
+	default:
+		return nil, p.errorf("missing operator after %q", c.field)
+	}
+
+	if !slices.Contains(operators, c.operator) {
+		return nil, p.errorf("%q can't be used with %v. possible operators: %v", c.operator, c.field, strings.Join(operators, ", "))
+	}
+
+	if c.operator == "in" || c.operator == "not in" {
+		values, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		c.values = values
+	} else {
+		value := p.next()
+		if value.kind == symbolToken || value.text == "" && value.kind != stringToken {
+			return nil, p.errorf("missing value after \"%v %v\"", c.field, c.operator)
+		}
+		c.values = []string{value.text}
+	}
+
+	if err := c.parseValues(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (p *whereParser) parseList() ([]string, error) {
+	if !p.isSymbol("(") {
+		return nil, p.errorf("expected a list of values like (a, b)")
+	}
+	p.next()
+
+	values := []string{}
+	for {
+		value := p.next()
+		if value.kind == symbolToken || value.text == "" && value.kind != stringToken {
+			return nil, p.errorf("expected a value in the list")
+		}
+		values = append(values, value.text)
+
+		if p.isSymbol(")") {
+			p.next()
+			return values, nil
+		}
+		if !p.isSymbol(",") {
+			return nil, p.errorf("expected \",\" or \")\" in the list")
+		}
+		p.next()
+	}
+}
+
+// parseValues checks the values of the durations and the dates, and
+// normalizes the statuses
+func (c *comparison) parseValues() error {
+	switch c.field {
+	case "duration":
+		duration, err := time.ParseDuration(c.values[0])
+		if err != nil {
+			return fmt.Errorf("%w: invalid duration %q, e.g. 1h30m", ErrInvalidWhere, c.values[0])
+		}
+		c.durations = []time.Duration{duration}
+	case "date":
+		if _, err := time.Parse(time.DateOnly, c.values[0]); err != nil {
+			return fmt.Errorf("%w: invalid date %q, expected YYYY-MM-DD", ErrInvalidWhere, c.values[0])
+		}
+	case "status":
+		c.values[0] = strings.ToLower(c.values[0])
+	}
+
+	return nil
+}
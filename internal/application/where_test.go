@@ -0,0 +1,113 @@
+package application_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/matryer/is"
+)
+
+func TestParseWhere(t *testing.T) {
+	deepWork := session.Session{
+		Id:        "1",
+		StartTime: time.Date(2024, time.April, 14, 9, 0, 0, 0, time.Local),
+		EndTime:   time.Date(2024, time.April, 14, 11, 30, 0, 0, time.Local),
+		Project:   "Flow",
+		Tags:      []string{"deep", "backend"},
+		Note:      "Wrote the Release notes",
+	}
+	shortReview := session.Session{
+		Id:        "2",
+		StartTime: time.Date(2024, time.April, 15, 14, 0, 0, 0, time.Local),
+		EndTime:   time.Date(2024, time.April, 15, 14, 20, 0, 0, time.Local),
+		Project:   "My Todo",
+		Tags:      []string{"review"},
+	}
+	flowing := session.Session{
+		Id:        "3",
+		StartTime: time.Date(2024, time.April, 16, 8, 0, 0, 0, time.Local),
+		Project:   "Flow",
+	}
+	sessions := []session.Session{deepWork, shortReview, flowing}
+
+	tt := []struct {
+		expression string
+		want       []string
+	}{
+		{expression: `project = "Flow"`, want: []string{"1", "3"}},
+		{expression: `project = "My Todo"`, want: []string{"2"}},
+		{expression: `project != Flow`, want: []string{"2"}},
+		{expression: `project in ("My Todo", Other)`, want: []string{"2"}},
+		{expression: `project ~ todo`, want: []string{"2"}},
+		{expression: `tag = deep`, want: []string{"1"}},
+		{expression: `tag in (deep, review)`, want: []string{"1", "2"}},
+		{expression: `tag not in (deep, review)`, want: []string{"3"}},
+		{expression: `tag ~ back`, want: []string{"1"}},
+		{expression: `note ~ "release notes"`, want: []string{"1"}},
+		{expression: `duration > 1h`, want: []string{"1"}},
+		{expression: `duration <= 30m`, want: []string{"2", "3"}},
+		{expression: `date = 2024-04-15`, want: []string{"2"}},
+		{expression: `date >= 2024-04-15 and date < 2024-04-16`, want: []string{"2"}},
+		{expression: `status = flowing`, want: []string{"3"}},
+		{expression: `project = "Flow" and duration > 1h and tag in (deep, review)`, want: []string{"1"}},
+		{expression: `tag = review or duration > 2h`, want: []string{"1", "2"}},
+		{expression: `project = Flow and (duration > 2h or status = FLOWING)`, want: []string{"1", "3"}},
+		{expression: `not project = Flow`, want: []string{"2"}},
+		{expression: `PROJECT = Flow AND NOT tag = deep`, want: []string{"3"}},
+		{expression: `project = "and"`, want: []string{}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.expression, func(t *testing.T) {
+			is := is.New(t)
+
+			condition, err := application.ParseWhere(tc.expression)
+			is.NoErr(err)
+
+			got := []string{}
+			for _, s := range sessions {
+				if condition.Match(s) {
+					got = append(got, s.Id)
+				}
+			}
+
+			is.Equal(got, tc.want)
+		})
+	}
+}
+
+func TestParseWhereErrors(t *testing.T) {
+	tt := []struct {
+		expression string
+		want       string
+	}{
+		{expression: ``, want: "invalid where expression: missing comparison"},
+		{expression: `length > 1h`, want: `invalid where expression: unknown field "length". possible values: project, tag, note, duration, date, status`},
+		{expression: `project`, want: `invalid where expression: missing operator after "project"`},
+		{expression: `project =`, want: `invalid where expression: missing value after "project ="`},
+		{expression: `project > Flow`, want: `invalid where expression: ">" can't be used with project. possible operators: =, !=, ~, in, not in`},
+		{expression: `project = "Flow`, want: "invalid where expression: unterminated string"},
+		{expression: `project not Flow`, want: `invalid where expression: expected "in" after "project not"`},
+		{expression: `tag in deep`, want: "invalid where expression: expected a list of values like (a, b)"},
+		{expression: `tag in (deep review)`, want: `invalid where expression: expected "," or ")" in the list`},
+		{expression: `duration > long`, want: `invalid where expression: invalid duration "long", e.g. 1h30m`},
+		{expression: `date = 14/04/2024`, want: `invalid where expression: invalid date "14/04/2024", expected YYYY-MM-DD`},
+		{expression: `(tag = deep`, want: `invalid where expression: missing ")"`},
+		{expression: `tag = deep review`, want: `invalid where expression: unexpected "review"`},
+		{expression: `tag ! deep`, want: `invalid where expression: unexpected "!"`},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.expression, func(t *testing.T) {
+			is := is.New(t)
+
+			_, err := application.ParseWhere(tc.expression)
+
+			is.True(errors.Is(err, application.ErrInvalidWhere))
+			is.Equal(err.Error(), tc.want)
+		})
+	}
+}
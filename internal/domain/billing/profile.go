@@ -0,0 +1,142 @@
+package billing
+
+import (
+	"slices"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/client"
+	"github.com/TristanShz/flow/internal/domain/project"
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+// Profile holds the contractual rules of the time billed for the sessions of
+// a project or of a client
+type Profile struct {
+	Rounding session.Rounding
+	// Minimum is the shortest time billed for a session
+	Minimum time.Duration
+	// DailyCap is the most time billed for a project each day, there is no
+	// cap when it's zero
+	DailyCap time.Duration
+}
+
+// Apply returns a copy of the sessions whose end times are moved so that
+// they're billed by the profile: each session is extended to the minimum,
+// rounded, then the last sessions of a day are shortened down to the cap
+func (p Profile) Apply(sessions []session.Session) []session.Session {
+	billed := slices.Clone(sessions)
+
+	for i, s := range billed {
+		if !s.EndTime.IsZero() && s.EndTime.Sub(s.StartTime) < p.Minimum {
+			billed[i].EndTime = s.StartTime.Add(p.Minimum)
+		}
+	}
+
+	billed = p.Rounding.Apply(billed)
+
+	if p.DailyCap > 0 {
+		p.capDays(billed)
+	}
+
+	return billed
+}
+
+func (p Profile) capDays(sessions []session.Session) {
+	type projectDay struct {
+		day     string
+		project string
+	}
+
+	days := map[projectDay][]int{}
+	for i, s := range sessions {
+		if s.EndTime.IsZero() {
+			continue
+		}
+
+		key := projectDay{day: s.StartTime.Format(time.DateOnly), project: s.Project}
+		days[key] = append(days[key], i)
+	}
+
+	for _, indexes := range days {
+		slices.SortFunc(indexes, func(a int, b int) int {
+			return sessions[a].StartTime.Compare(sessions[b].StartTime)
+		})
+
+		var billed time.Duration
+		for _, i := range indexes {
+			billed += sessions[i].EndTime.Sub(sessions[i].StartTime)
+		}
+
+		excess := billed - p.DailyCap
+		for j := len(indexes) - 1; j >= 0 && excess > 0; j-- {
+			i := indexes[j]
+			taken := min(excess, sessions[i].EndTime.Sub(sessions[i].StartTime))
+			sessions[i].EndTime = sessions[i].EndTime.Add(-taken)
+			excess -= taken
+		}
+	}
+}
+
+// Rules are the billing profiles by name, and the rounding of the sessions
+// having no profile
+type Rules struct {
+	Rounding session.Rounding
+	Profiles map[string]Profile
+}
+
+// ProfileOf returns the profile of the session: the profile of its project,
+// or the profile of its client, or the rounding of the rules
+func (r Rules) ProfileOf(s session.Session, projects []project.Project, clients []client.Client) Profile {
+	p := project.Find(projects, s.Project)
+	name := p.BillingProfile
+
+	if name == "" {
+		clientName := p.ClientOf(s)
+		for _, c := range clients {
+			if c.Name == clientName {
+				name = c.BillingProfile
+			}
+		}
+	}
+
+	if profile, ok := r.Profiles[name]; ok && name != "" {
+		return profile
+	}
+
+	return Profile{Rounding: r.Rounding}
+}
+
+// Apply returns a copy of the sessions billed by their profiles, see
+// Profile.Apply, in the same order
+func (r Rules) Apply(sessions []session.Session, projects []project.Project, clients []client.Client) []session.Session {
+	type group struct {
+		profile  Profile
+		indexes  []int
+		sessions []session.Session
+	}
+
+	groups := []*group{}
+	for i, s := range sessions {
+		profile := r.ProfileOf(s, projects, clients)
+
+		index := slices.IndexFunc(groups, func(g *group) bool {
+			return g.profile == profile
+		})
+		if index == -1 {
+			groups = append(groups, &group{profile: profile})
+			index = len(groups) - 1
+		}
+
+		groups[index].indexes = append(groups[index].indexes, i)
+		groups[index].sessions = append(groups[index].sessions, s)
+	}
+
+	billed := slices.Clone(sessions)
+	for _, g := range groups {
+		for j, s := range g.profile.Apply(g.sessions) {
+			billed[g.indexes[j]] = s
+		}
+	}
+
+	return billed
+}
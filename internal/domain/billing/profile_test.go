@@ -0,0 +1,91 @@
+package billing_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/billing"
+	"github.com/TristanShz/flow/internal/domain/client"
+	"github.com/TristanShz/flow/internal/domain/project"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/matryer/is"
+)
+
+func at(day int, hour int, minute int) time.Time {
+	return time.Date(2024, time.April, day, hour, minute, 0, 0, time.UTC)
+}
+
+func ends(sessions []session.Session) []time.Time {
+	got := []time.Time{}
+	for _, s := range sessions {
+		got = append(got, s.EndTime)
+	}
+
+	return got
+}
+
+func TestProfile_Apply(t *testing.T) {
+	sessions := []session.Session{
+		{Id: "1", StartTime: at(15, 9, 0), EndTime: at(15, 9, 5), Project: "Flow"},
+		{Id: "2", StartTime: at(15, 10, 0), EndTime: at(15, 14, 0), Project: "Flow"},
+		{Id: "3", StartTime: at(15, 15, 0), EndTime: at(15, 18, 20), Project: "Flow"},
+		{Id: "4", StartTime: at(16, 9, 0), EndTime: at(16, 9, 40), Project: "Flow"},
+	}
+
+	tt := []struct {
+		name     string
+		profile  billing.Profile
+		wantEnds []time.Time
+	}{
+		{
+			name:     "Minimum",
+			profile:  billing.Profile{Minimum: 15 * time.Minute},
+			wantEnds: []time.Time{at(15, 9, 15), at(15, 14, 0), at(15, 18, 20), at(16, 9, 40)},
+		},
+		{
+			name:     "Minimum then rounding",
+			profile:  billing.Profile{Minimum: 15 * time.Minute, Rounding: session.Rounding{Increment: 30 * time.Minute, Method: session.RoundUp}},
+			wantEnds: []time.Time{at(15, 9, 30), at(15, 14, 0), at(15, 18, 30), at(16, 10, 0)},
+		},
+		{
+			name:     "Daily cap taken from the last sessions",
+			profile:  billing.Profile{DailyCap: 4 * time.Hour},
+			wantEnds: []time.Time{at(15, 9, 5), at(15, 13, 55), at(15, 15, 0), at(16, 9, 40)},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			is.Equal(ends(tc.profile.Apply(sessions)), tc.wantEnds)
+			is.Equal(sessions[0].EndTime, at(15, 9, 5)) // the sessions aren't changed
+		})
+	}
+}
+
+func TestRules_Apply(t *testing.T) {
+	is := is.New(t)
+
+	rules := billing.Rules{
+		Rounding: session.Rounding{Increment: 15 * time.Minute, Method: session.RoundUp},
+		Profiles: map[string]billing.Profile{
+			"retainer": {DailyCap: time.Hour},
+			"support":  {Minimum: 30 * time.Minute},
+		},
+	}
+	projects := []project.Project{
+		{Name: "Website", Client: "Acme", BillingProfile: "retainer"},
+		{Name: "Helpdesk", Client: "Acme"},
+		{Name: "Intranet", Client: "Globex"},
+	}
+	clients := []client.Client{{Name: "Acme", BillingProfile: "support"}}
+	sessions := []session.Session{
+		{Id: "1", StartTime: at(15, 9, 0), EndTime: at(15, 11, 0), Project: "Website"},
+		{Id: "2", StartTime: at(15, 11, 0), EndTime: at(15, 11, 10), Project: "Helpdesk"},
+		{Id: "3", StartTime: at(15, 12, 0), EndTime: at(15, 12, 10), Project: "Intranet"},
+		{Id: "4", StartTime: at(15, 13, 0), EndTime: at(15, 13, 10), Project: "Website", Client: "Globex"},
+	}
+
+	is.Equal(ends(rules.Apply(sessions, projects, clients)), []time.Time{at(15, 10, 0), at(15, 11, 30), at(15, 12, 15), at(15, 13, 0)})
+}
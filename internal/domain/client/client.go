@@ -0,0 +1,31 @@
+package client
+
+type Client struct {
+	Name     string
+	Contact  string
+	Address  string
+	PONumber string
+	// BillingProfile is the name of the billing profile of the sessions
+	// billed to the client, unless their project has its own
+	BillingProfile string `json:",omitempty"`
+}
+
+// HeaderLines returns the lines to print at the top of exports made for the
+// client, empty metadata are left out.
+func (c Client) HeaderLines() []string {
+	lines := []string{"Client: " + c.Name}
+
+	if c.Contact != "" {
+		lines = append(lines, "Contact: "+c.Contact)
+	}
+
+	if c.Address != "" {
+		lines = append(lines, "Address: "+c.Address)
+	}
+
+	if c.PONumber != "" {
+		lines = append(lines, "PO Number: "+c.PONumber)
+	}
+
+	return lines
+}
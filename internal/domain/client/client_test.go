@@ -0,0 +1,45 @@
+package client_test
+
+import (
+	"testing"
+
+	"github.com/TristanShz/flow/internal/domain/client"
+	"github.com/matryer/is"
+)
+
+func TestClient_HeaderLines(t *testing.T) {
+	tt := []struct {
+		name   string
+		client client.Client
+		want   []string
+	}{
+		{
+			name:   "Only name",
+			client: client.Client{Name: "Acme"},
+			want:   []string{"Client: Acme"},
+		},
+		{
+			name: "All metadata",
+			client: client.Client{
+				Name:     "Acme",
+				Contact:  "jane@acme.com",
+				Address:  "1 Main Street, Springfield",
+				PONumber: "PO-42",
+			},
+			want: []string{
+				"Client: Acme",
+				"Contact: jane@acme.com",
+				"Address: 1 Main Street, Springfield",
+				"PO Number: PO-42",
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			is.Equal(tc.client.HeaderLines(), tc.want)
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package invoice
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/client"
+	"github.com/TristanShz/flow/internal/domain/project"
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+// DraftNumber is the number of the invoices computed without being issued
+const DraftNumber = "DRAFT"
+
+// Issuer is who sends the invoices
+type Issuer struct {
+	Name    string
+	Address string
+	TaxID   string
+}
+
+// Line is the time spent on a project at one hourly rate
+type Line struct {
+	Project    string
+	Duration   time.Duration
+	HourlyRate float64
+	Amount     float64
+}
+
+type Invoice struct {
+	// Sequence is the number of the invoice among the invoices of its year,
+	// Number is its formatted number, see FormatNumber
+	Sequence int
+	Number   string
+	Date     time.Time
+	// DueDate is the zero time when the invoice has no due date
+	DueDate time.Time
+	// Since and Until span the sessions of the invoice
+	Since    time.Time
+	Until    time.Time
+	Issuer   Issuer
+	Client   client.Client
+	Currency string
+	Lines    []Line
+	Duration time.Duration
+	Total    float64
+	// Sessions are the ids of the invoiced sessions, a session is invoiced
+	// once
+	Sessions []string
+}
+
+// New returns the invoice of the billable sessions of the client, a line per
+// project and hourly rate. The projects settings give the billable default
+// and the rate of each project unless a session overrides them.
+func New(c client.Client, sessions []session.Session, projects []project.Project) Invoice {
+	invoice := Invoice{Client: c, Lines: []Line{}, Sessions: []string{}}
+
+	type projectRate struct {
+		project string
+		rate    float64
+	}
+
+	byRate := map[projectRate]*Line{}
+	for _, s := range sessions {
+		p := project.Find(projects, s.Project)
+		if !p.IsBillable(s) || s.Status() != session.EndedStatus || p.ClientOf(s) != c.Name {
+			continue
+		}
+
+		key := projectRate{project: s.Project, rate: p.HourlyRateOf(s)}
+		if _, ok := byRate[key]; !ok {
+			byRate[key] = &Line{Project: key.project, HourlyRate: key.rate}
+		}
+		byRate[key].Duration += s.Duration()
+
+		if invoice.Since.IsZero() || s.StartTime.Before(invoice.Since) {
+			invoice.Since = s.StartTime
+		}
+		if s.EndTime.After(invoice.Until) {
+			invoice.Until = s.EndTime
+		}
+		invoice.Sessions = append(invoice.Sessions, s.Id)
+	}
+
+	for _, line := range byRate {
+		line.Amount = roundCents(line.Duration.Hours() * line.HourlyRate)
+		invoice.Lines = append(invoice.Lines, *line)
+		invoice.Duration += line.Duration
+		invoice.Total += line.Amount
+	}
+	invoice.Total = roundCents(invoice.Total)
+
+	sort.Slice(invoice.Lines, func(i, j int) bool {
+		if invoice.Lines[i].Project != invoice.Lines[j].Project {
+			return invoice.Lines[i].Project < invoice.Lines[j].Project
+		}
+		return invoice.Lines[i].HourlyRate < invoice.Lines[j].HourlyRate
+	})
+	slices.Sort(invoice.Sessions)
+
+	return invoice
+}
+
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+// IsEmpty tells if the invoice has no session
+func (i Invoice) IsEmpty() bool {
+	return len(i.Sessions) == 0
+}
+
+// FormatNumber formats the sequence of an invoice of the year, like
+// INV-2024-007 with the INV- prefix
+func FormatNumber(prefix string, year int, sequence int) string {
+	return fmt.Sprintf("%v%v-%03d", prefix, year, sequence)
+}
+
+// NextSequence returns the sequence of the next invoice of the year
+func NextSequence(invoices []Invoice, year int) int {
+	sequence := 1
+	for _, i := range invoices {
+		if i.Date.Year() == year && i.Sequence >= sequence {
+			sequence = i.Sequence + 1
+		}
+	}
+
+	return sequence
+}
+
+// InvoicedSessions maps the ids of the invoiced sessions to the number of
+// their invoice
+func InvoicedSessions(invoices []Invoice) map[string]string {
+	invoiced := map[string]string{}
+	for _, i := range invoices {
+		for _, id := range i.Sessions {
+			invoiced[id] = i.Number
+		}
+	}
+
+	return invoiced
+}
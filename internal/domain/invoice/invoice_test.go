@@ -0,0 +1,66 @@
+package invoice_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/client"
+	"github.com/TristanShz/flow/internal/domain/invoice"
+	"github.com/TristanShz/flow/internal/domain/project"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/matryer/is"
+)
+
+func TestNew(t *testing.T) {
+	is := is.New(t)
+
+	at := func(day int, hour int, minute int) time.Time {
+		return time.Date(2024, time.April, day, hour, minute, 0, 0, time.UTC)
+	}
+	rate := 120.0
+	notBillable := false
+
+	sessions := []session.Session{
+		{Id: "1", StartTime: at(15, 9, 0), EndTime: at(15, 11, 30), Project: "Website"},
+		{Id: "2", StartTime: at(16, 9, 0), EndTime: at(16, 10, 0), Project: "Website", HourlyRate: &rate},
+		{Id: "3", StartTime: at(17, 9, 0), EndTime: at(17, 10, 0), Project: "Website", Billable: &notBillable},
+		{Id: "4", StartTime: at(18, 9, 0), EndTime: at(18, 9, 20), Project: "App"},
+		{Id: "5", StartTime: at(18, 14, 0), EndTime: at(18, 15, 0), Project: "Intranet"},
+		{Id: "6", StartTime: at(19, 9, 0), Project: "App"},
+	}
+	projects := []project.Project{
+		{Name: "Website", Client: "Acme", Billable: true, HourlyRate: 80},
+		{Name: "App", Client: "Acme", Billable: true, HourlyRate: 100},
+		{Name: "Intranet", Client: "Globex", Billable: true, HourlyRate: 90},
+	}
+
+	got := invoice.New(client.Client{Name: "Acme"}, sessions, projects)
+
+	is.Equal(got, invoice.Invoice{
+		Client: client.Client{Name: "Acme"},
+		Since:  at(15, 9, 0),
+		Until:  at(18, 9, 20),
+		Lines: []invoice.Line{
+			{Project: "App", Duration: 20 * time.Minute, HourlyRate: 100, Amount: 33.33},
+			{Project: "Website", Duration: 150 * time.Minute, HourlyRate: 80, Amount: 200},
+			{Project: "Website", Duration: time.Hour, HourlyRate: 120, Amount: 120},
+		},
+		Duration: 230 * time.Minute,
+		Total:    353.33,
+		Sessions: []string{"1", "2", "4"},
+	})
+}
+
+func TestNextSequence(t *testing.T) {
+	is := is.New(t)
+
+	invoices := []invoice.Invoice{
+		{Sequence: 11, Date: time.Date(2023, time.December, 20, 0, 0, 0, 0, time.UTC)},
+		{Sequence: 1, Date: time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC)},
+		{Sequence: 2, Date: time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+	}
+
+	is.Equal(invoice.NextSequence(invoices, 2024), 3)
+	is.Equal(invoice.NextSequence(invoices, 2025), 1)
+	is.Equal(invoice.FormatNumber("INV-", 2024, 3), "INV-2024-003")
+}
@@ -0,0 +1,51 @@
+package journal
+
+import (
+	"sort"
+	"time"
+)
+
+// Entry is a note attached to a day rather than to one of its sessions, for
+// the context that doesn't belong to any single session like a release
+// shipped or a day lost to an outage
+type Entry struct {
+	// Day is the midnight of the day, in the local time zone
+	Day  time.Time
+	Note string
+	// CreatedAt orders the entries of a same day
+	CreatedAt time.Time
+}
+
+// DayOf returns the midnight of the day of the given time
+func DayOf(at time.Time) time.Time {
+	year, month, day := at.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, at.Location())
+}
+
+// SameDay tells if the entry is about the day of the given time
+func (e Entry) SameDay(at time.Time) bool {
+	return e.Day.Format(time.DateOnly) == at.Format(time.DateOnly)
+}
+
+// OfDay returns the entries of the day of the given time, nil when there is
+// none
+func OfDay(entries []Entry, at time.Time) []Entry {
+	var dayEntries []Entry
+	for _, entry := range entries {
+		if entry.SameDay(at) {
+			dayEntries = append(dayEntries, entry)
+		}
+	}
+
+	return dayEntries
+}
+
+// Sort orders the entries by day, then by creation
+func Sort(entries []Entry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if !entries[i].SameDay(entries[j].Day) {
+			return entries[i].Day.Before(entries[j].Day)
+		}
+		return entries[i].CreatedAt.Before(entries[j].CreatedAt)
+	})
+}
@@ -0,0 +1,43 @@
+package journal_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/journal"
+	"github.com/matryer/is"
+)
+
+func TestOfDay(t *testing.T) {
+	is := is.New(t)
+
+	entries := []journal.Entry{
+		{Day: time.Date(2024, 4, 12, 0, 0, 0, 0, time.UTC), Note: "shipped the release"},
+		{Day: time.Date(2024, 4, 13, 0, 0, 0, 0, time.UTC), Note: "outage"},
+	}
+
+	is.Equal(journal.OfDay(entries, time.Date(2024, 4, 13, 18, 30, 0, 0, time.UTC)), entries[1:])
+	is.Equal(journal.OfDay(entries, time.Date(2024, 4, 14, 9, 0, 0, 0, time.UTC)), nil)
+}
+
+func TestSort(t *testing.T) {
+	is := is.New(t)
+
+	entries := []journal.Entry{
+		{Day: time.Date(2024, 4, 13, 0, 0, 0, 0, time.UTC), Note: "outage", CreatedAt: time.Date(2024, 4, 13, 9, 0, 0, 0, time.UTC)},
+		{Day: time.Date(2024, 4, 12, 0, 0, 0, 0, time.UTC), Note: "retro", CreatedAt: time.Date(2024, 4, 12, 17, 0, 0, 0, time.UTC)},
+		{Day: time.Date(2024, 4, 12, 0, 0, 0, 0, time.UTC), Note: "shipped the release", CreatedAt: time.Date(2024, 4, 12, 11, 0, 0, 0, time.UTC)},
+	}
+
+	journal.Sort(entries)
+
+	is.Equal(entries[0].Note, "shipped the release")
+	is.Equal(entries[1].Note, "retro")
+	is.Equal(entries[2].Note, "outage")
+}
+
+func TestDayOf(t *testing.T) {
+	is := is.New(t)
+
+	is.Equal(journal.DayOf(time.Date(2024, 4, 12, 18, 30, 0, 0, time.UTC)), time.Date(2024, 4, 12, 0, 0, 0, 0, time.UTC))
+}
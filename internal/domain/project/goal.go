@@ -0,0 +1,81 @@
+package project
+
+import (
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+const (
+	GoalWeek  = "week"
+	GoalMonth = "month"
+)
+
+// HasGoals tells if time is to be spent on the project every week or month
+func (p Project) HasGoals() bool {
+	return p.WeeklyGoal > 0 || p.MonthlyGoal > 0
+}
+
+// GoalProgress is the time tracked on a project during the current week or
+// month against its goal. The goal is spread evenly over the period, so that
+// Expected is the part of it which should be tracked by now.
+type GoalProgress struct {
+	Project  string
+	Period   string
+	Since    time.Time
+	Until    time.Time
+	Goal     time.Duration
+	Tracked  time.Duration
+	Expected time.Duration
+}
+
+// NewGoalProgress sums the sessions of the project started during the
+// period, the running session counting until now
+func NewGoalProgress(project string, period string, goal time.Duration, since time.Time, until time.Time, sessions []session.Session, now time.Time) GoalProgress {
+	progress := GoalProgress{
+		Project: project,
+		Period:  period,
+		Since:   since,
+		Until:   until,
+		Goal:    goal,
+	}
+
+	for _, s := range sessions {
+		if s.Project != project || s.StartTime.Before(since) || !s.StartTime.Before(until) {
+			continue
+		}
+		if s.EndTime.IsZero() {
+			progress.Tracked += now.Sub(s.StartTime).Round(time.Second)
+		} else {
+			progress.Tracked += s.Duration()
+		}
+	}
+
+	elapsed := min(max(now.Sub(since), 0), until.Sub(since))
+	progress.Expected = time.Duration(float64(goal) * float64(elapsed) / float64(until.Sub(since))).Round(time.Minute)
+
+	return progress
+}
+
+// Remaining is the time left to reach the goal
+func (g GoalProgress) Remaining() time.Duration {
+	return max(g.Goal-g.Tracked, 0)
+}
+
+// Behind is the time missing to be on track, zero when on track
+func (g GoalProgress) Behind() time.Duration {
+	return max(g.Expected-g.Tracked, 0)
+}
+
+func (g GoalProgress) Reached() bool {
+	return g.Tracked >= g.Goal
+}
+
+// Percent is the part of the goal tracked, over 100 once exceeded
+func (g GoalProgress) Percent() int {
+	if g.Goal <= 0 {
+		return 0
+	}
+
+	return int(g.Tracked * 100 / g.Goal)
+}
@@ -0,0 +1,77 @@
+package project_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/project"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/matryer/is"
+)
+
+func TestNewGoalProgress(t *testing.T) {
+	since := time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC)
+	until := since.AddDate(0, 0, 7)
+	sessions := []session.Session{
+		{Project: "flow", StartTime: since.Add(-time.Hour), EndTime: since.Add(time.Hour)},
+		{Project: "flow", StartTime: since.Add(9 * time.Hour), EndTime: since.Add(12 * time.Hour)},
+		{Project: "other", StartTime: since.Add(13 * time.Hour), EndTime: since.Add(15 * time.Hour)},
+	}
+	running := session.Session{Project: "flow", StartTime: since.Add(57 * time.Hour)}
+
+	tt := []struct {
+		name          string
+		sessions      []session.Session
+		now           time.Time
+		wantTracked   time.Duration
+		wantExpected  time.Duration
+		wantBehind    time.Duration
+		wantRemaining time.Duration
+		wantPercent   int
+		wantReached   bool
+	}{
+		{
+			name:          "On track with the running session",
+			sessions:      append(sessions[:3:3], running),
+			now:           since.Add(58 * time.Hour),
+			wantTracked:   4 * time.Hour,
+			wantExpected:  3*time.Hour + 27*time.Minute,
+			wantRemaining: 6 * time.Hour,
+			wantPercent:   40,
+		},
+		{
+			name:          "Behind",
+			sessions:      sessions,
+			now:           since.Add(120 * time.Hour),
+			wantTracked:   3 * time.Hour,
+			wantExpected:  7*time.Hour + 9*time.Minute,
+			wantBehind:    4*time.Hour + 9*time.Minute,
+			wantRemaining: 7 * time.Hour,
+			wantPercent:   30,
+		},
+		{
+			name:         "Reached after the period",
+			sessions:     append(sessions[:3:3], session.Session{Project: "flow", StartTime: since.Add(24 * time.Hour), EndTime: since.Add(32 * time.Hour)}),
+			now:          until.Add(time.Hour),
+			wantTracked:  11 * time.Hour,
+			wantExpected: 10 * time.Hour,
+			wantPercent:  110,
+			wantReached:  true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			got := project.NewGoalProgress("flow", project.GoalWeek, 10*time.Hour, since, until, tc.sessions, tc.now)
+
+			is.Equal(got.Tracked, tc.wantTracked)
+			is.Equal(got.Expected, tc.wantExpected)
+			is.Equal(got.Behind(), tc.wantBehind)
+			is.Equal(got.Remaining(), tc.wantRemaining)
+			is.Equal(got.Percent(), tc.wantPercent)
+			is.Equal(got.Reached(), tc.wantReached)
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package project
+
+import (
+	"slices"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+const (
+	// OnLockNone keeps the session running when the screen is locked
+	OnLockNone = "none"
+	// OnLockStop stops the session when the screen is locked
+	OnLockStop = "stop"
+	// OnLockPause stops the session when the screen is locked and starts a
+	// new one with the same project and tags once it's unlocked
+	OnLockPause = "pause"
+)
+
+var OnLockActions = []string{OnLockNone, OnLockStop, OnLockPause}
+
+const (
+	// DoNotTrackConfirm asks for a confirmation to start a session of the
+	// project during its do-not-track windows
+	DoNotTrackConfirm = "confirm"
+	// DoNotTrackBlock refuses to start a session of the project during its
+	// do-not-track windows
+	DoNotTrackBlock = "block"
+)
+
+var DoNotTrackActions = []string{DoNotTrackConfirm, DoNotTrackBlock}
+
+const (
+	// OnMeetingNone keeps the session running during the meetings
+	OnMeetingNone = "none"
+	// OnMeetingPause stops the session when a meeting starts and starts a new
+	// one with the same project and tags once it ends
+	OnMeetingPause = "pause"
+	// OnMeetingSwitch pauses the session like OnMeetingPause, and tracks the
+	// meeting in a session of the meeting project in the meantime
+	OnMeetingSwitch = "switch"
+)
+
+var OnMeetingActions = []string{OnMeetingNone, OnMeetingPause, OnMeetingSwitch}
+
+// DefaultMeetingProject is the project of the meeting sessions of the
+// projects switching to meetings without a meeting project
+const DefaultMeetingProject = "meetings"
+
+func IsOnLockValid(onLock string) bool {
+	return slices.Contains(OnLockActions, onLock)
+}
+
+func IsOnDoNotTrackValid(onDoNotTrack string) bool {
+	return slices.Contains(DoNotTrackActions, onDoNotTrack)
+}
+
+func IsOnMeetingValid(onMeeting string) bool {
+	return slices.Contains(OnMeetingActions, onMeeting)
+}
+
+// Project holds the settings of a project, a project without settings
+// doesn't need to be stored
+type Project struct {
+	Name string
+	// OnLock is what happens to a session of the project when the screen is
+	// locked, the lid is closed or the system goes to sleep
+	OnLock string `json:",omitempty"`
+	// DoNotTrack are the time windows when sessions of the project shouldn't
+	// be started
+	DoNotTrack []TimeWindow `json:",omitempty"`
+	// OnDoNotTrack is what happens when a session of the project is started
+	// during one of its do-not-track windows
+	OnDoNotTrack string `json:",omitempty"`
+	// OnMeeting is what happens to a session of the project when a meeting
+	// of the calendar starts, see 'flow daemon'
+	OnMeeting string `json:",omitempty"`
+	// MeetingProject is the project of the sessions tracking the meetings
+	// when OnMeeting is OnMeetingSwitch
+	MeetingProject string `json:",omitempty"`
+	// Client is the name of the client the project is billed to
+	Client string `json:",omitempty"`
+	// Billable tells if the sessions of the project are billed, unless a
+	// session says otherwise
+	Billable bool `json:",omitempty"`
+	// HourlyRate is the rate of the billable sessions of the project, unless
+	// a session has its own rate
+	HourlyRate float64 `json:",omitempty"`
+	// BillingProfile is the name of the billing profile of the sessions of
+	// the project, before the profile of its client
+	BillingProfile string `json:",omitempty"`
+	// BreakEvery is the time worked before a break of BreakDuration is
+	// taken out of the sessions of the project, when they're stopped
+	BreakEvery    time.Duration `json:",omitempty"`
+	BreakDuration time.Duration `json:",omitempty"`
+	// WeeklyGoal and MonthlyGoal are the time to spend on the project every
+	// week and every month, see GoalProgress
+	WeeklyGoal  time.Duration `json:",omitempty"`
+	MonthlyGoal time.Duration `json:",omitempty"`
+}
+
+func (p Project) OnLockAction() string {
+	if p.OnLock == "" {
+		return OnLockNone
+	}
+
+	return p.OnLock
+}
+
+func (p Project) OnDoNotTrackAction() string {
+	if p.OnDoNotTrack == "" {
+		return DoNotTrackConfirm
+	}
+
+	return p.OnDoNotTrack
+}
+
+func (p Project) OnMeetingAction() string {
+	if p.OnMeeting == "" {
+		return OnMeetingNone
+	}
+
+	return p.OnMeeting
+}
+
+func (p Project) MeetingProjectName() string {
+	if p.MeetingProject == "" {
+		return DefaultMeetingProject
+	}
+
+	return p.MeetingProject
+}
+
+// HasBreaks tells if breaks are taken out of the sessions of the project
+func (p Project) HasBreaks() bool {
+	return p.BreakEvery > 0 && p.BreakDuration > 0
+}
+
+// DoNotTrackWindow returns the do-not-track window containing the time
+func (p Project) DoNotTrackWindow(t time.Time) (TimeWindow, bool) {
+	for _, window := range p.DoNotTrack {
+		if window.Contains(t) {
+			return window, true
+		}
+	}
+
+	return TimeWindow{}, false
+}
+
+// Find returns the settings of the project with the given name, or a
+// project without settings
+func Find(projects []Project, name string) Project {
+	for _, p := range projects {
+		if p.Name == name {
+			return p
+		}
+	}
+
+	return Project{Name: name}
+}
+
+// ClientOf returns the client of the session of the project, empty when it
+// has none
+func (p Project) ClientOf(s session.Session) string {
+	if s.Client != "" {
+		return s.Client
+	}
+
+	return p.Client
+}
+
+// IsBillable tells if the session of the project is billed
+func (p Project) IsBillable(s session.Session) bool {
+	if s.Billable != nil {
+		return *s.Billable
+	}
+
+	return p.Billable
+}
+
+// HourlyRateOf returns the rate of the session of the project
+func (p Project) HourlyRateOf(s session.Session) float64 {
+	if s.HourlyRate != nil {
+		return *s.HourlyRate
+	}
+
+	return p.HourlyRate
+}
+
+// Earnings returns what the session of the project earned, nothing when it
+// isn't billable
+func (p Project) Earnings(s session.Session) float64 {
+	if !p.IsBillable(s) {
+		return 0
+	}
+
+	return s.Duration().Hours() * p.HourlyRateOf(s)
+}
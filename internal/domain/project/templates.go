@@ -0,0 +1,86 @@
+package project
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+)
+
+// Templates are the project conventions shared by a team, synced from a git
+// repository or an URL
+type Templates struct {
+	// NamePattern is a regular expression the names of the projects must
+	// match, names aren't checked when it's empty
+	NamePattern string `json:",omitempty"`
+	// Tags are the tags sessions can have, any tag is allowed when it's empty
+	Tags []string `json:",omitempty"`
+	// Projects are the settings given to the projects without settings when
+	// their first session is started
+	Projects []Project `json:",omitempty"`
+}
+
+var (
+	ErrInvalidProjectName = errors.New("the project name doesn't follow the naming rule of the templates")
+	ErrUnknownTag         = errors.New("the tag isn't one of the tags of the templates")
+)
+
+// Check tells if the templates are usable, before storing them
+func (t Templates) Check() error {
+	if _, err := regexp.Compile(t.NamePattern); err != nil {
+		return fmt.Errorf("invalid name pattern %v: %w", t.NamePattern, err)
+	}
+
+	for _, p := range t.Projects {
+		if p.Name == "" {
+			return errors.New("a project of the templates has no name")
+		}
+		if p.OnLock != "" && !IsOnLockValid(p.OnLock) {
+			return fmt.Errorf("invalid on lock action %v for the project %v", p.OnLock, p.Name)
+		}
+		if p.OnDoNotTrack != "" && !IsOnDoNotTrackValid(p.OnDoNotTrack) {
+			return fmt.Errorf("invalid do-not-track action %v for the project %v", p.OnDoNotTrack, p.Name)
+		}
+	}
+
+	return nil
+}
+
+// Validate checks the project name and the tags of a session against the
+// rules of the templates
+func (t Templates) Validate(projectName string, tags []string) error {
+	if t.NamePattern != "" {
+		pattern, err := regexp.Compile(t.NamePattern)
+		if err != nil {
+			return err
+		}
+
+		if !pattern.MatchString(projectName) {
+			return fmt.Errorf("%w: %v doesn't match %v", ErrInvalidProjectName, projectName, t.NamePattern)
+		}
+	}
+
+	if len(t.Tags) == 0 {
+		return nil
+	}
+
+	for _, tag := range tags {
+		if !slices.Contains(t.Tags, tag) {
+			return fmt.Errorf("%w: %v", ErrUnknownTag, tag)
+		}
+	}
+
+	return nil
+}
+
+// DefaultsOf returns the settings the templates give to the project, nil
+// when the templates don't have it
+func (t Templates) DefaultsOf(projectName string) *Project {
+	for _, p := range t.Projects {
+		if p.Name == projectName {
+			return &p
+		}
+	}
+
+	return nil
+}
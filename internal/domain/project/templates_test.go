@@ -0,0 +1,100 @@
+package project_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/TristanShz/flow/internal/domain/project"
+	"github.com/matryer/is"
+)
+
+func TestTemplates_Validate(t *testing.T) {
+	templates := project.Templates{
+		NamePattern: "^[a-z]+(-[a-z]+)*$",
+		Tags:        []string{"dev", "review"},
+	}
+
+	tt := []struct {
+		error       error
+		name        string
+		projectName string
+		templates   project.Templates
+		tags        []string
+	}{
+		{
+			name:        "Valid name and tags",
+			templates:   templates,
+			projectName: "acme-website",
+			tags:        []string{"dev"},
+		},
+		{
+			name:        "Invalid name",
+			templates:   templates,
+			projectName: "AcmeWebsite",
+			error:       project.ErrInvalidProjectName,
+		},
+		{
+			name:        "Unknown tag",
+			templates:   templates,
+			projectName: "acme-website",
+			tags:        []string{"dev", "meeting"},
+			error:       project.ErrUnknownTag,
+		},
+		{
+			name:        "No rules",
+			projectName: "AcmeWebsite",
+			tags:        []string{"meeting"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			err := tc.templates.Validate(tc.projectName, tc.tags)
+
+			is.True(errors.Is(err, tc.error))
+		})
+	}
+}
+
+func TestTemplates_Check(t *testing.T) {
+	tt := []struct {
+		name      string
+		templates project.Templates
+		wantError bool
+	}{
+		{
+			name: "Valid templates",
+			templates: project.Templates{
+				NamePattern: "^[a-z-]+$",
+				Projects:    []project.Project{{Name: "acme-website", OnLock: project.OnLockPause}},
+			},
+		},
+		{
+			name:      "Invalid name pattern",
+			templates: project.Templates{NamePattern: "[a-z"},
+			wantError: true,
+		},
+		{
+			name:      "Project without name",
+			templates: project.Templates{Projects: []project.Project{{Client: "acme"}}},
+			wantError: true,
+		},
+		{
+			name:      "Invalid on lock action",
+			templates: project.Templates{Projects: []project.Project{{Name: "acme-website", OnLock: "sleep"}}},
+			wantError: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			err := tc.templates.Check()
+
+			is.Equal(err != nil, tc.wantError)
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package project
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+var weekdays = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+var ErrInvalidTimeWindow = errors.New("invalid time window. expected format: [days] [HH:MM-HH:MM], e.g. 'sat,sun', 'mon-fri 22:00-07:00' or '12:00-13:30'")
+
+// TimeWindow is a recurring period of the week, like the weekends or every
+// night between 22:00 and 07:00
+type TimeWindow struct {
+	// Days are the weekdays of the window as in "mon", every day when empty
+	Days []string `json:",omitempty"`
+	// From and To are "HH:MM" times, the whole day when empty. The window
+	// spans midnight when To is before From.
+	From string `json:",omitempty"`
+	To   string `json:",omitempty"`
+}
+
+// ParseTimeWindow reads a window like "sat,sun", "mon-fri 22:00-07:00" or
+// "22:00-07:00"
+func ParseTimeWindow(value string) (TimeWindow, error) {
+	window := TimeWindow{}
+
+	for _, part := range strings.Fields(strings.ToLower(value)) {
+		if strings.Contains(part, ":") {
+			if window.From != "" {
+				return TimeWindow{}, ErrInvalidTimeWindow
+			}
+
+			from, to, found := strings.Cut(part, "-")
+			if !found || !isClockValid(from) || !isClockValid(to) {
+				return TimeWindow{}, ErrInvalidTimeWindow
+			}
+			window.From, window.To = from, to
+			continue
+		}
+
+		if len(window.Days) > 0 {
+			return TimeWindow{}, ErrInvalidTimeWindow
+		}
+
+		days, err := parseDays(part)
+		if err != nil {
+			return TimeWindow{}, err
+		}
+		window.Days = days
+	}
+
+	if len(window.Days) == 0 && window.From == "" {
+		return TimeWindow{}, ErrInvalidTimeWindow
+	}
+
+	return window, nil
+}
+
+func parseDays(value string) ([]string, error) {
+	days := []string{}
+
+	for _, dayRange := range strings.Split(value, ",") {
+		first, last, isRange := strings.Cut(dayRange, "-")
+		firstIndex := slices.Index(weekdays, first)
+		lastIndex := slices.Index(weekdays, last)
+		if !isRange {
+			lastIndex = firstIndex
+		}
+
+		if firstIndex == -1 || lastIndex == -1 {
+			return nil, ErrInvalidTimeWindow
+		}
+
+		for i := firstIndex; ; i = (i + 1) % len(weekdays) {
+			if !slices.Contains(days, weekdays[i]) {
+				days = append(days, weekdays[i])
+			}
+			if i == lastIndex {
+				break
+			}
+		}
+	}
+
+	return days, nil
+}
+
+func isClockValid(clock string) bool {
+	_, err := time.Parse("15:04", clock)
+	return err == nil
+}
+
+func (w TimeWindow) String() string {
+	parts := []string{}
+	if len(w.Days) > 0 {
+		parts = append(parts, strings.Join(w.Days, ","))
+	}
+	if w.From != "" {
+		parts = append(parts, fmt.Sprintf("%v-%v", w.From, w.To))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// Contains tells if the time is in the window. The days of a window spanning
+// midnight are the days it starts, "fri 22:00-07:00" ends on saturday morning.
+func (w TimeWindow) Contains(t time.Time) bool {
+	minutes := t.Hour()*60 + t.Minute()
+	day := t.Weekday()
+
+	if w.From != "" {
+		from := clockMinutes(w.From)
+		to := clockMinutes(w.To)
+
+		switch {
+		case from <= to:
+			if minutes < from || minutes >= to {
+				return false
+			}
+		case minutes >= from:
+		case minutes < to:
+			day = (day + 6) % 7
+		default:
+			return false
+		}
+	}
+
+	return len(w.Days) == 0 || slices.Contains(w.Days, weekdays[day])
+}
+
+func clockMinutes(clock string) int {
+	parsed, _ := time.Parse("15:04", clock)
+	return parsed.Hour()*60 + parsed.Minute()
+}
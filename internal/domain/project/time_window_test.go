@@ -0,0 +1,106 @@
+package project_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/project"
+)
+
+func TestParseTimeWindow(t *testing.T) {
+	tt := []struct {
+		error error
+		name  string
+		value string
+		want  project.TimeWindow
+	}{
+		{
+			name:  "Days",
+			value: "sat,sun",
+			want:  project.TimeWindow{Days: []string{"sat", "sun"}},
+		},
+		{
+			name:  "Days range over the week end",
+			value: "fri-mon",
+			want:  project.TimeWindow{Days: []string{"fri", "sat", "sun", "mon"}},
+		},
+		{
+			name:  "Hours",
+			value: "22:00-07:00",
+			want:  project.TimeWindow{From: "22:00", To: "07:00"},
+		},
+		{
+			name:  "Days and hours",
+			value: "Mon-Wed,fri 12:00-13:30",
+			want:  project.TimeWindow{Days: []string{"mon", "tue", "wed", "fri"}, From: "12:00", To: "13:30"},
+		},
+		{
+			name:  "Invalid day",
+			value: "weekends",
+			error: project.ErrInvalidTimeWindow,
+		},
+		{
+			name:  "Invalid hours",
+			value: "22:00-25:00",
+			error: project.ErrInvalidTimeWindow,
+		},
+		{
+			name:  "Empty",
+			value: "",
+			error: project.ErrInvalidTimeWindow,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := project.ParseTimeWindow(tc.value)
+			if err != tc.error {
+				t.Fatalf("ParseTimeWindow() error = %v, want %v", err, tc.error)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("ParseTimeWindow() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTimeWindow_Contains(t *testing.T) {
+	// 2024-04-12 is a friday
+	friday := func(hour, minute int) time.Time {
+		return time.Date(2024, time.April, 12, hour, minute, 0, 0, time.UTC)
+	}
+	saturday := func(hour, minute int) time.Time {
+		return time.Date(2024, time.April, 13, hour, minute, 0, 0, time.UTC)
+	}
+
+	tt := []struct {
+		name   string
+		window string
+		time   time.Time
+		want   bool
+	}{
+		{name: "In days", window: "sat,sun", time: saturday(10, 0), want: true},
+		{name: "Out of days", window: "sat,sun", time: friday(23, 0), want: false},
+		{name: "In hours", window: "12:00-13:30", time: friday(13, 29), want: true},
+		{name: "End of hours", window: "12:00-13:30", time: friday(13, 30), want: false},
+		{name: "Overnight before midnight", window: "22:00-07:00", time: friday(23, 0), want: true},
+		{name: "Overnight after midnight", window: "22:00-07:00", time: saturday(6, 0), want: true},
+		{name: "Out of overnight", window: "22:00-07:00", time: saturday(8, 0), want: false},
+		{name: "Overnight continues the day before", window: "fri 22:00-07:00", time: saturday(6, 0), want: true},
+		{name: "Overnight doesn't start the day after", window: "sat 22:00-07:00", time: saturday(6, 0), want: false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			window, err := project.ParseTimeWindow(tc.window)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := window.Contains(tc.time); got != tc.want {
+				t.Errorf("TimeWindow.Contains() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package session
+
+import (
+	"maps"
+	"slices"
+	"time"
+)
+
+// WithBreaks splits the ended session in parts of the given length separated
+// by breaks, the breaks being taken out of the time of the session. The first
+// part keeps the id of the session, the next ones get an id of newId,
+// continue the previous part and hold the break metadata. The metadata about
+// the end of the session, like the idle time trimmed or the clock skew, is
+// only kept by the last part. No break is taken when nothing of the session
+// would remain after it.
+func (s Session) WithBreaks(every time.Duration, breakDuration time.Duration, newId func() string) []Session {
+	parts := []Session{}
+	current := s
+
+	for every > 0 && breakDuration > 0 && s.Status() == EndedStatus {
+		breakStart := current.StartTime.Add(every)
+		resumeAt := breakStart.Add(breakDuration)
+		if !resumeAt.Before(current.EndTime) {
+			break
+		}
+
+		next := current
+		next.Id = newId()
+		next.StartTime = resumeAt
+		next.Continues = current.Id
+		next.Tags = slices.Clone(current.Tags)
+		next.Metadata = maps.Clone(current.Metadata)
+		if next.Metadata == nil {
+			next.Metadata = map[string]string{}
+		}
+		next.Metadata[BreakMetadata] = breakDuration.String()
+
+		current.EndTime = breakStart
+		current.Metadata = withoutEndMetadata(current.Metadata)
+		parts = append(parts, current)
+		current = next
+	}
+
+	return append(parts, current)
+}
+
+// endMetadata is the metadata about how the session ended
+var endMetadata = []string{
+	ClockSkewMetadata,
+	IdleTrimmedMetadata,
+	IdleBreakMetadata,
+	ExitCodeMetadata,
+	UnstoppedMetadata,
+}
+
+func withoutEndMetadata(metadata map[string]string) map[string]string {
+	kept := maps.Clone(metadata)
+	for _, key := range endMetadata {
+		delete(kept, key)
+	}
+	if len(kept) == 0 {
+		return nil
+	}
+	return kept
+}
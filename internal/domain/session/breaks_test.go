@@ -0,0 +1,121 @@
+package session_test
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+func TestSession_WithBreaks(t *testing.T) {
+	at := func(hour int, minute int) time.Time {
+		return time.Date(2024, time.April, 15, hour, minute, 0, 0, time.UTC)
+	}
+
+	tt := []struct {
+		name    string
+		session session.Session
+		want    []session.Session
+	}{
+		{
+			name:    "Shorter than the schedule",
+			session: session.Session{Id: "1", StartTime: at(9, 0), EndTime: at(10, 30), Project: "Flow"},
+			want: []session.Session{
+				{Id: "1", StartTime: at(9, 0), EndTime: at(10, 30), Project: "Flow"},
+			},
+		},
+		{
+			name:    "Nothing left after the break",
+			session: session.Session{Id: "1", StartTime: at(9, 0), EndTime: at(11, 10), Project: "Flow"},
+			want: []session.Session{
+				{Id: "1", StartTime: at(9, 0), EndTime: at(11, 10), Project: "Flow"},
+			},
+		},
+		{
+			name:    "Two breaks",
+			session: session.Session{Id: "1", StartTime: at(9, 0), EndTime: at(14, 0), Project: "Flow", Tags: []string{"compliance"}},
+			want: []session.Session{
+				{Id: "1", StartTime: at(9, 0), EndTime: at(11, 0), Project: "Flow", Tags: []string{"compliance"}},
+				{
+					Id:        "b1",
+					StartTime: at(11, 10),
+					EndTime:   at(13, 10),
+					Project:   "Flow",
+					Tags:      []string{"compliance"},
+					Continues: "1",
+					Metadata:  map[string]string{session.BreakMetadata: "10m0s"},
+				},
+				{
+					Id:        "b2",
+					StartTime: at(13, 20),
+					EndTime:   at(14, 0),
+					Project:   "Flow",
+					Tags:      []string{"compliance"},
+					Continues: "b1",
+					Metadata:  map[string]string{session.BreakMetadata: "10m0s"},
+				},
+			},
+		},
+		{
+			name: "End metadata on the last part",
+			session: session.Session{
+				Id:        "1",
+				StartTime: at(9, 0),
+				EndTime:   at(11, 30),
+				Project:   "Flow",
+				Metadata: map[string]string{
+					session.CommandMetadata:     "make",
+					session.IdleTrimmedMetadata: "15m0s",
+					session.ClockSkewMetadata:   "5s",
+				},
+			},
+			want: []session.Session{
+				{
+					Id:        "1",
+					StartTime: at(9, 0),
+					EndTime:   at(11, 0),
+					Project:   "Flow",
+					Metadata:  map[string]string{session.CommandMetadata: "make"},
+				},
+				{
+					Id:        "b1",
+					StartTime: at(11, 10),
+					EndTime:   at(11, 30),
+					Project:   "Flow",
+					Continues: "1",
+					Metadata: map[string]string{
+						session.CommandMetadata:     "make",
+						session.IdleTrimmedMetadata: "15m0s",
+						session.ClockSkewMetadata:   "5s",
+						session.BreakMetadata:       "10m0s",
+					},
+				},
+			},
+		},
+		{
+			name:    "Flowing session",
+			session: session.Session{Id: "1", StartTime: at(9, 0), Project: "Flow"},
+			want: []session.Session{
+				{Id: "1", StartTime: at(9, 0), Project: "Flow"},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ids := 0
+			newId := func() string {
+				ids++
+				return "b" + strconv.Itoa(ids)
+			}
+
+			got := tc.session.WithBreaks(2*time.Hour, 10*time.Minute, newId)
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Session.WithBreaks() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
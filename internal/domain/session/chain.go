@@ -0,0 +1,64 @@
+package session
+
+import (
+	"sort"
+	"time"
+)
+
+// Chain is the sessions of one logical effort, linked by Continues, by start
+// time
+type Chain []Session
+
+// FindChain returns the chain of the session among the given sessions. A
+// session continued by several sessions brings all of them in the chain.
+func FindChain(sessions []Session, id string) Chain {
+	linked := map[string][]string{}
+	byId := map[string]Session{}
+	for _, s := range sessions {
+		byId[s.Id] = s
+		if s.Continues != "" {
+			linked[s.Id] = append(linked[s.Id], s.Continues)
+			linked[s.Continues] = append(linked[s.Continues], s.Id)
+		}
+	}
+
+	if _, ok := byId[id]; !ok {
+		return Chain{}
+	}
+
+	chain := Chain{}
+	visited := map[string]bool{id: true}
+	toVisit := []string{id}
+	for len(toVisit) > 0 {
+		current := toVisit[0]
+		toVisit = toVisit[1:]
+
+		// a continued session may have been deleted
+		if s, ok := byId[current]; ok {
+			chain = append(chain, s)
+		}
+
+		for _, next := range linked[current] {
+			if !visited[next] {
+				visited[next] = true
+				toVisit = append(toVisit, next)
+			}
+		}
+	}
+
+	sort.Slice(chain, func(i, j int) bool {
+		return chain[i].StartTime.Before(chain[j].StartTime)
+	})
+
+	return chain
+}
+
+// Duration sums the durations of the sessions of the chain
+func (c Chain) Duration() time.Duration {
+	var duration time.Duration
+	for _, s := range c {
+		duration += s.Duration()
+	}
+
+	return duration
+}
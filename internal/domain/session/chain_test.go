@@ -0,0 +1,64 @@
+package session_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+func TestFindChain(t *testing.T) {
+	sessions := []session.Session{
+		{Id: "3", StartTime: time.Date(2024, 4, 17, 9, 0, 0, 0, time.UTC), Continues: "2"},
+		{Id: "1", StartTime: time.Date(2024, 4, 15, 9, 0, 0, 0, time.UTC)},
+		{Id: "2", StartTime: time.Date(2024, 4, 16, 9, 0, 0, 0, time.UTC), Continues: "1"},
+		{Id: "4", StartTime: time.Date(2024, 4, 17, 14, 0, 0, 0, time.UTC), Continues: "1"},
+		{Id: "5", StartTime: time.Date(2024, 4, 18, 9, 0, 0, 0, time.UTC)},
+		{Id: "6", StartTime: time.Date(2024, 4, 19, 9, 0, 0, 0, time.UTC), Continues: "deleted"},
+	}
+
+	tt := []struct {
+		name string
+		id   string
+		want []string
+	}{
+		{name: "from the first session", id: "1", want: []string{"1", "2", "3", "4"}},
+		{name: "from the middle of the chain", id: "3", want: []string{"1", "2", "3", "4"}},
+		{name: "session without links", id: "5", want: []string{"5"}},
+		{name: "continued session deleted", id: "6", want: []string{"6"}},
+		{name: "unknown session", id: "7", want: []string{}},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got := []string{}
+			for _, s := range session.FindChain(sessions, tc.id) {
+				got = append(got, s.Id)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("FindChain() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestChain_Duration(t *testing.T) {
+	chain := session.Chain{
+		{
+			StartTime: time.Date(2024, 4, 15, 9, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, 4, 15, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			StartTime: time.Date(2024, 4, 16, 9, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, 4, 16, 9, 30, 0, 0, time.UTC),
+		},
+		{
+			StartTime: time.Date(2024, 4, 17, 9, 0, 0, 0, time.UTC),
+		},
+	}
+
+	if got := chain.Duration(); got != 2*time.Hour+30*time.Minute {
+		t.Errorf("Chain.Duration() = %v, want %v", got, 2*time.Hour+30*time.Minute)
+	}
+}
@@ -0,0 +1,100 @@
+package session
+
+import "time"
+
+// daysBufferDelay is the number of days after which no session of a day can
+// come anymore. The day of a session is the date it started on in its own
+// location, so a day spans 50 hours of the sessions added, whatever their
+// time zones.
+const daysBufferDelay = 3
+
+type bufferedSession struct {
+	session Session
+	applied bool
+}
+
+// DaysBuffer gives the sessions added in the order of their start times to
+// apply a few days at a time, then passes the sessions returned by apply to
+// fn, in the same order. The sessions of a day are always given to apply
+// together, so that the rules of the days of a project, like the rounding
+// per day and the daily caps, see all of them without every session being
+// held.
+type DaysBuffer struct {
+	apply   func(sessions []Session) []Session
+	fn      func(s Session) error
+	pending []bufferedSession
+}
+
+func NewDaysBuffer(apply func(sessions []Session) []Session, fn func(s Session) error) *DaysBuffer {
+	return &DaysBuffer{
+		apply: apply,
+		fn:    fn,
+	}
+}
+
+// startDay is the date the session started on in its location, like the days
+// of Rounding.Apply
+func startDay(s Session) time.Time {
+	year, month, day := s.StartTime.Date()
+
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// Add buffers the session, then applies the sessions of the days which are
+// over and passes them on. It returns the first error of fn.
+func (b *DaysBuffer) Add(s Session) error {
+	b.pending = append(b.pending, bufferedSession{session: s})
+
+	over := startDay(s).AddDate(0, 0, -daysBufferDelay)
+	b.applyTo(func(buffered Session) bool {
+		return !startDay(buffered).After(over)
+	})
+
+	return b.passOn()
+}
+
+// Flush applies the sessions left and passes them on, once every session was
+// added
+func (b *DaysBuffer) Flush() error {
+	b.applyTo(func(Session) bool { return true })
+
+	return b.passOn()
+}
+
+func (b *DaysBuffer) applyTo(match func(s Session) bool) {
+	indexes := []int{}
+	sessions := []Session{}
+	for i, buffered := range b.pending {
+		if !buffered.applied && match(buffered.session) {
+			indexes = append(indexes, i)
+			sessions = append(sessions, buffered.session)
+		}
+	}
+	if len(sessions) == 0 {
+		return
+	}
+
+	for j, applied := range b.apply(sessions) {
+		b.pending[indexes[j]] = bufferedSession{session: applied, applied: true}
+	}
+}
+
+// passOn gives fn the applied sessions up to the first one which isn't, so
+// that they keep their order
+func (b *DaysBuffer) passOn() error {
+	passed := 0
+	for _, buffered := range b.pending {
+		if !buffered.applied {
+			break
+		}
+
+		if err := b.fn(buffered.session); err != nil {
+			return err
+		}
+		passed++
+	}
+
+	b.pending = b.pending[passed:]
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package session_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/matryer/is"
+)
+
+func TestDaysBuffer(t *testing.T) {
+	is := is.New(t)
+
+	newYork := time.FixedZone("EDT", -4*60*60)
+	sessions := []session.Session{}
+	for day := 1; day <= 20; day++ {
+		for hour := 9; hour < 17; hour += 3 {
+			start := time.Date(2024, time.April, day, hour, 0, 0, 0, time.UTC)
+			sessions = append(sessions, session.Session{Id: start.Format(time.RFC3339), StartTime: start, EndTime: start.Add(52 * time.Minute), Project: "Flow"})
+		}
+	}
+	// started on the 10th in its own location, after a session of the 11th
+	night := time.Date(2024, time.April, 11, 1, 0, 0, 0, time.UTC)
+	travel := time.Date(2024, time.April, 10, 23, 30, 0, 0, newYork)
+	sessions = append(sessions,
+		session.Session{Id: "night", StartTime: night, EndTime: night.Add(52 * time.Minute), Project: "Flow"},
+		session.Session{Id: "travel", StartTime: travel, EndTime: travel.Add(52 * time.Minute), Project: "Flow"},
+	)
+	slices.SortFunc(sessions, func(a session.Session, b session.Session) int {
+		return a.StartTime.Compare(b.StartTime)
+	})
+
+	rounding := session.Rounding{Increment: time.Hour, Per: session.RoundPerDay}
+
+	got := []session.Session{}
+	biggestBatch := 0
+	buffer := session.NewDaysBuffer(func(batch []session.Session) []session.Session {
+		biggestBatch = max(biggestBatch, len(batch))
+		return rounding.Apply(batch)
+	}, func(s session.Session) error {
+		got = append(got, s)
+		return nil
+	})
+	for _, s := range sessions {
+		is.NoErr(buffer.Add(s))
+	}
+	is.NoErr(buffer.Flush())
+
+	// the sessions of a day are rounded together, a few days at a time
+	is.Equal(got, rounding.Apply(sessions))
+	is.True(biggestBatch < len(sessions)/2)
+}
+
+func TestDaysBuffer_StopsAtTheFirstError(t *testing.T) {
+	is := is.New(t)
+
+	errStop := errors.New("stop")
+	given := 0
+	buffer := session.NewDaysBuffer(func(batch []session.Session) []session.Session { return batch }, func(session.Session) error {
+		given++
+		return errStop
+	})
+
+	for day := 1; day <= 10; day++ {
+		start := time.Date(2024, time.April, day, 9, 0, 0, 0, time.UTC)
+		if err := buffer.Add(session.Session{Id: start.Format(time.RFC3339), StartTime: start}); err != nil {
+			is.True(errors.Is(err, errStop))
+			break
+		}
+	}
+
+	is.Equal(given, 1)
+}
@@ -0,0 +1,101 @@
+package session
+
+import (
+	"time"
+)
+
+// HeatlineMaxSpan is the longest chain a heatline is drawn for, the breaks
+// of a chain spread over several days aren't worth drawing
+const HeatlineMaxSpan = 24 * time.Hour
+
+// Heatline splits the time from the start of a chain to its end in cells of
+// whole minutes. Focus holds, for each cell, the part of it spent in the
+// sessions of the chain, from 0 for a break to 1.
+type Heatline struct {
+	Start time.Time
+	End   time.Time
+	Cell  time.Duration
+	Focus []float64
+}
+
+// HasBreaks tells if the chain was interrupted: a session of it was taken
+// after a break, or had idle time taken out of it
+func (c Chain) HasBreaks() bool {
+	for _, s := range c {
+		for _, key := range []string{BreakMetadata, IdleTrimmedMetadata, IdleBreakMetadata} {
+			if _, ok := s.Metadata[key]; ok {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// idleAfter returns the idle time taken out of the end of the session
+func (s Session) idleAfter() time.Duration {
+	for _, key := range []string{IdleTrimmedMetadata, IdleBreakMetadata} {
+		if idle, err := time.ParseDuration(s.Metadata[key]); err == nil {
+			return idle
+		}
+	}
+
+	return 0
+}
+
+// Heatline returns the heatline of the chain in at most maxCells cells. The
+// idle time taken out of the end of a session is drawn as a break after it.
+// ok is false when a session of the chain isn't ended, or when the chain
+// spans more than HeatlineMaxSpan.
+func (c Chain) Heatline(maxCells int) (heatline Heatline, ok bool) {
+	if len(c) == 0 || maxCells <= 0 {
+		return Heatline{}, false
+	}
+
+	heatline.Start = c[0].StartTime
+	for _, s := range c {
+		if s.Status() != EndedStatus {
+			return Heatline{}, false
+		}
+
+		if s.StartTime.Before(heatline.Start) {
+			heatline.Start = s.StartTime
+		}
+		if end := s.EndTime.Add(s.idleAfter()); end.After(heatline.End) {
+			heatline.End = end
+		}
+	}
+
+	span := heatline.End.Sub(heatline.Start)
+	if span <= 0 || span > HeatlineMaxSpan {
+		return Heatline{}, false
+	}
+
+	minutes := (span + time.Minute - 1) / time.Minute
+	heatline.Cell = ((minutes + time.Duration(maxCells) - 1) / time.Duration(maxCells)) * time.Minute
+
+	for cellStart := heatline.Start; cellStart.Before(heatline.End); cellStart = cellStart.Add(heatline.Cell) {
+		cellEnd := cellStart.Add(heatline.Cell)
+		if cellEnd.After(heatline.End) {
+			cellEnd = heatline.End
+		}
+
+		focused := time.Duration(0)
+		for _, s := range c {
+			start, end := s.StartTime, s.EndTime
+			if start.Before(cellStart) {
+				start = cellStart
+			}
+			if end.After(cellEnd) {
+				end = cellEnd
+			}
+			if end.After(start) {
+				focused += end.Sub(start)
+			}
+		}
+
+		heatline.Focus = append(heatline.Focus, min(float64(focused)/float64(cellEnd.Sub(cellStart)), 1))
+	}
+
+	return heatline, true
+}
@@ -0,0 +1,141 @@
+package session_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+func TestChain_Heatline(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2024, 4, 15, hour, minute, 0, 0, time.UTC)
+	}
+	cells := func(focus ...float64) []float64 {
+		return focus
+	}
+	repeat := func(value float64, n int) []float64 {
+		values := make([]float64, n)
+		for i := range values {
+			values[i] = value
+		}
+		return values
+	}
+
+	tt := []struct {
+		name   string
+		chain  session.Chain
+		max    int
+		want   session.Heatline
+		wantOk bool
+	}{
+		{
+			name: "Break between two sessions",
+			chain: session.Chain{
+				{StartTime: at(9, 0), EndTime: at(10, 0)},
+				{StartTime: at(10, 10), EndTime: at(11, 0), Metadata: map[string]string{session.BreakMetadata: "10m0s"}},
+			},
+			max: 60,
+			want: session.Heatline{
+				Start: at(9, 0),
+				End:   at(11, 0),
+				Cell:  2 * time.Minute,
+				Focus: append(append(repeat(1, 30), repeat(0, 5)...), repeat(1, 25)...),
+			},
+			wantOk: true,
+		},
+		{
+			name: "Cells partly spent in a break",
+			chain: session.Chain{
+				{StartTime: at(9, 0), EndTime: at(9, 25)},
+				{StartTime: at(9, 35), EndTime: at(10, 0)},
+			},
+			max: 4,
+			want: session.Heatline{
+				Start: at(9, 0),
+				End:   at(10, 0),
+				Cell:  15 * time.Minute,
+				Focus: cells(1, 10.0/15, 10.0/15, 1),
+			},
+			wantOk: true,
+		},
+		{
+			name: "Idle time trimmed drawn as a break after the session",
+			chain: session.Chain{
+				{StartTime: at(9, 0), EndTime: at(9, 30), Metadata: map[string]string{session.IdleTrimmedMetadata: "30m0s"}},
+			},
+			max: 4,
+			want: session.Heatline{
+				Start: at(9, 0),
+				End:   at(10, 0),
+				Cell:  15 * time.Minute,
+				Focus: cells(1, 1, 0, 0),
+			},
+			wantOk: true,
+		},
+		{
+			name: "Last cell shorter than the others",
+			chain: session.Chain{
+				{StartTime: at(9, 0), EndTime: at(9, 50)},
+			},
+			max: 2,
+			want: session.Heatline{
+				Start: at(9, 0),
+				End:   at(9, 50),
+				Cell:  25 * time.Minute,
+				Focus: cells(1, 1),
+			},
+			wantOk: true,
+		},
+		{
+			name: "Session still flowing",
+			chain: session.Chain{
+				{StartTime: at(9, 0), EndTime: at(10, 0)},
+				{StartTime: at(10, 10)},
+			},
+			max: 60,
+		},
+		{
+			name: "Chain spread over several days",
+			chain: session.Chain{
+				{StartTime: at(9, 0), EndTime: at(10, 0)},
+				{StartTime: at(9, 0).AddDate(0, 0, 1), EndTime: at(10, 0).AddDate(0, 0, 1)},
+			},
+			max: 60,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := tc.chain.Heatline(tc.max)
+
+			if ok != tc.wantOk {
+				t.Fatalf("Heatline() ok = %v, want %v", ok, tc.wantOk)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Heatline() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestChain_HasBreaks(t *testing.T) {
+	tt := []struct {
+		name  string
+		chain session.Chain
+		want  bool
+	}{
+		{name: "Without breaks", chain: session.Chain{{Id: "1"}, {Id: "2"}}, want: false},
+		{name: "Session taken after a break", chain: session.Chain{{Id: "1"}, {Id: "2", Metadata: map[string]string{session.BreakMetadata: "5m0s"}}}, want: true},
+		{name: "Idle time taken out", chain: session.Chain{{Id: "1", Metadata: map[string]string{session.IdleBreakMetadata: "5m0s"}}}, want: true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.chain.HasBreaks(); got != tc.want {
+				t.Errorf("HasBreaks() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package session
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrLockedHistory = errors.New("the session belongs to a read-only month, use --unlock-history to change it")
+
+// IsLocked tells if the session started before lockedBefore, the start of
+// the first month whose sessions can still be changed. Nothing is locked for
+// the zero time.
+func (s Session) IsLocked(lockedBefore time.Time) bool {
+	return !lockedBefore.IsZero() && s.StartTime.Before(lockedBefore)
+}
+
+// CheckLocked returns ErrLockedHistory when one of the sessions is locked
+func CheckLocked(lockedBefore time.Time, sessions ...Session) error {
+	for _, s := range sessions {
+		if s.IsLocked(lockedBefore) {
+			return ErrLockedHistory
+		}
+	}
+
+	return nil
+}
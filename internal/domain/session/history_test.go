@@ -0,0 +1,59 @@
+package session_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/matryer/is"
+)
+
+func TestSession_IsLocked(t *testing.T) {
+	march := session.Session{StartTime: time.Date(2024, time.March, 31, 23, 0, 0, 0, time.UTC)}
+	april := session.Session{StartTime: time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)}
+	lockedBefore := time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		name         string
+		session      session.Session
+		lockedBefore time.Time
+		want         bool
+	}{
+		{
+			name:         "Nothing locked",
+			session:      march,
+			lockedBefore: time.Time{},
+			want:         false,
+		},
+		{
+			name:         "Session of a locked month",
+			session:      march,
+			lockedBefore: lockedBefore,
+			want:         true,
+		},
+		{
+			name:         "Session of the first month unlocked",
+			session:      april,
+			lockedBefore: lockedBefore,
+			want:         false,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			is.Equal(tc.session.IsLocked(tc.lockedBefore), tc.want)
+		})
+	}
+}
+
+func TestCheckLocked(t *testing.T) {
+	is := is.New(t)
+	lockedBefore := time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)
+	march := session.Session{StartTime: time.Date(2024, time.March, 31, 23, 0, 0, 0, time.UTC)}
+	april := session.Session{StartTime: time.Date(2024, time.April, 2, 9, 0, 0, 0, time.UTC)}
+
+	is.NoErr(session.CheckLocked(lockedBefore, april))
+	is.Equal(session.CheckLocked(lockedBefore, april, march), session.ErrLockedHistory)
+}
@@ -0,0 +1,25 @@
+package session
+
+import "time"
+
+// WithoutIdle ends the ended session earlier when the user had been idle for
+// longer than the threshold before it ended, the idle time beyond the
+// threshold being taken out, all of it when the threshold is zero. It returns
+// the session and the time taken out, which never goes past the start of the
+// session.
+func (s Session) WithoutIdle(idle time.Duration, threshold time.Duration) (Session, time.Duration) {
+	beyond := idle - threshold
+	if beyond <= 0 || s.Status() != EndedStatus {
+		return s, 0
+	}
+
+	end := s.EndTime.Add(-beyond)
+	if end.Before(s.StartTime) {
+		end = s.StartTime
+	}
+
+	removed := s.EndTime.Sub(end)
+	s.EndTime = end
+
+	return s, removed
+}
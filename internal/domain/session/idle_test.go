@@ -0,0 +1,72 @@
+package session_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/matryer/is"
+)
+
+func TestSession_WithoutIdle(t *testing.T) {
+	at := func(hour int, minute int) time.Time {
+		return time.Date(2024, time.April, 15, hour, minute, 0, 0, time.UTC)
+	}
+
+	tt := []struct {
+		name        string
+		session     session.Session
+		idle        time.Duration
+		threshold   time.Duration
+		wantEnd     time.Time
+		wantRemoved time.Duration
+	}{
+		{
+			name:      "Idle below the threshold",
+			session:   session.Session{StartTime: at(9, 0), EndTime: at(11, 0)},
+			idle:      5 * time.Minute,
+			threshold: 10 * time.Minute,
+			wantEnd:   at(11, 0),
+		},
+		{
+			name:        "Idle beyond the threshold",
+			session:     session.Session{StartTime: at(9, 0), EndTime: at(11, 0)},
+			idle:        40 * time.Minute,
+			threshold:   10 * time.Minute,
+			wantEnd:     at(10, 30),
+			wantRemoved: 30 * time.Minute,
+		},
+		{
+			name:        "Idle since before the start",
+			session:     session.Session{StartTime: at(10, 45), EndTime: at(11, 0)},
+			idle:        2 * time.Hour,
+			threshold:   10 * time.Minute,
+			wantEnd:     at(10, 45),
+			wantRemoved: 15 * time.Minute,
+		},
+		{
+			name:        "No threshold",
+			session:     session.Session{StartTime: at(9, 0), EndTime: at(11, 0)},
+			idle:        40 * time.Minute,
+			wantEnd:     at(10, 20),
+			wantRemoved: 40 * time.Minute,
+		},
+		{
+			name:      "Flowing session",
+			session:   session.Session{StartTime: at(9, 0)},
+			idle:      40 * time.Minute,
+			threshold: 10 * time.Minute,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			got, removed := tc.session.WithoutIdle(tc.idle, tc.threshold)
+
+			is.Equal(got.EndTime, tc.wantEnd)
+			is.Equal(removed, tc.wantRemoved)
+		})
+	}
+}
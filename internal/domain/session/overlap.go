@@ -0,0 +1,121 @@
+package session
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// What happens when a new or edited session overlaps other sessions
+const (
+	// OverlapAllow saves the session without checking it
+	OverlapAllow = "allow"
+	// OverlapWarn saves the session and reports the overlapped sessions with
+	// an OverlapWarning
+	OverlapWarn = "warn"
+	// OverlapReject refuses to save the session with ErrOverlap
+	OverlapReject = "reject"
+	// OverlapAdjust moves the boundaries of the session out of the
+	// overlapped sessions
+	OverlapAdjust = "adjust"
+)
+
+var OverlapPolicies = []string{OverlapAllow, OverlapWarn, OverlapReject, OverlapAdjust}
+
+var ErrOverlap = errors.New("the session would overlap another session")
+
+func IsOverlapPolicyValid(policy string) bool {
+	return slices.Contains(OverlapPolicies, policy)
+}
+
+// OverlapWarning is returned along with the saved session by the OverlapWarn
+// policy, it isn't a failure
+type OverlapWarning struct {
+	Overlapped []Session
+}
+
+func (w *OverlapWarning) Error() string {
+	ids := []string{}
+	for _, s := range w.Overlapped {
+		ids = append(ids, s.Id)
+	}
+
+	return fmt.Sprintf("the session overlaps %v other session(s): %v", len(w.Overlapped), strings.Join(ids, ", "))
+}
+
+// Overlapping returns the other sessions flowing at the same time as the
+// session, leaving the session itself out when it's already saved
+func (s Session) Overlapping(others []Session) []Session {
+	overlapping := []Session{}
+	for _, other := range others {
+		if other.Id != s.Id && s.Overlaps(other) {
+			overlapping = append(overlapping, other)
+		}
+	}
+
+	slices.SortFunc(overlapping, func(a, b Session) int {
+		return a.StartTime.Compare(b.StartTime)
+	})
+
+	return overlapping
+}
+
+// CheckOverlaps applies the overlap policy to the session, checked against
+// the other sessions, and returns the session to save. With OverlapWarn the
+// error is an OverlapWarning and the session is still to be saved.
+// OverlapAdjust starts the session when the sessions overlapping its start
+// end and ends it when the next overlapped session starts, the session is
+// rejected when nothing of it would remain or when it would have to end
+// while flowing.
+func (s Session) CheckOverlaps(others []Session, policy string) (Session, error) {
+	if policy == OverlapAllow {
+		return s, nil
+	}
+
+	overlapping := s.Overlapping(others)
+	if len(overlapping) == 0 {
+		return s, nil
+	}
+
+	switch policy {
+	case OverlapWarn:
+		return s, &OverlapWarning{Overlapped: overlapping}
+	case OverlapAdjust:
+		return s.adjustedAround(overlapping)
+	default:
+		return Session{}, ErrOverlap
+	}
+}
+
+// adjustedAround moves the boundaries of the session out of the sessions it
+// overlaps, sorted by start time
+func (s Session) adjustedAround(overlapping []Session) (Session, error) {
+	adjusted := s
+
+	for _, other := range overlapping {
+		if !adjusted.Overlaps(other) {
+			continue
+		}
+
+		if !other.StartTime.After(adjusted.StartTime) {
+			// a flowing session overlaps everything after its start
+			if other.EndTime.IsZero() {
+				return Session{}, ErrOverlap
+			}
+			adjusted.StartTime = other.EndTime
+			continue
+		}
+
+		if adjusted.EndTime.IsZero() {
+			return Session{}, ErrOverlap
+		}
+		adjusted.EndTime = other.StartTime
+	}
+
+	if !adjusted.EndTime.IsZero() && !adjusted.EndTime.After(adjusted.StartTime) {
+		return Session{}, ErrOverlap
+	}
+
+	return adjusted, nil
+}
@@ -0,0 +1,102 @@
+package session_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/matryer/is"
+)
+
+func TestSession_CheckOverlaps(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2024, time.April, 13, hour, minute, 0, 0, time.UTC)
+	}
+	morning := session.Session{Id: "1", StartTime: at(9, 0), EndTime: at(10, 0)}
+	noon := session.Session{Id: "2", StartTime: at(12, 0), EndTime: at(13, 0)}
+	flowing := session.Session{Id: "3", StartTime: at(15, 0)}
+	others := []session.Session{flowing, noon, morning}
+
+	tt := []struct {
+		name        string
+		checked     session.Session
+		policy      string
+		want        session.Session
+		wantErr     error
+		wantWarning []session.Session
+	}{
+		{
+			name:    "No overlap",
+			checked: session.Session{Id: "4", StartTime: at(10, 0), EndTime: at(12, 0)},
+			policy:  session.OverlapReject,
+			want:    session.Session{Id: "4", StartTime: at(10, 0), EndTime: at(12, 0)},
+		},
+		{
+			name:    "Itself",
+			checked: session.Session{Id: "1", StartTime: at(9, 30), EndTime: at(10, 30)},
+			policy:  session.OverlapReject,
+			want:    session.Session{Id: "1", StartTime: at(9, 30), EndTime: at(10, 30)},
+		},
+		{
+			name:    "Allowed",
+			checked: session.Session{Id: "4", StartTime: at(9, 30), EndTime: at(12, 30)},
+			policy:  session.OverlapAllow,
+			want:    session.Session{Id: "4", StartTime: at(9, 30), EndTime: at(12, 30)},
+		},
+		{
+			name:        "Warned",
+			checked:     session.Session{Id: "4", StartTime: at(9, 30), EndTime: at(12, 30)},
+			policy:      session.OverlapWarn,
+			want:        session.Session{Id: "4", StartTime: at(9, 30), EndTime: at(12, 30)},
+			wantWarning: []session.Session{morning, noon},
+		},
+		{
+			name:    "Rejected",
+			checked: session.Session{Id: "4", StartTime: at(9, 30), EndTime: at(11, 0)},
+			policy:  session.OverlapReject,
+			wantErr: session.ErrOverlap,
+		},
+		{
+			name:    "Adjusted between sessions",
+			checked: session.Session{Id: "4", StartTime: at(9, 30), EndTime: at(12, 30)},
+			policy:  session.OverlapAdjust,
+			want:    session.Session{Id: "4", StartTime: at(10, 0), EndTime: at(12, 0)},
+		},
+		{
+			name:    "Adjusted before the flowing session",
+			checked: session.Session{Id: "4", StartTime: at(14, 0), EndTime: at(16, 0)},
+			policy:  session.OverlapAdjust,
+			want:    session.Session{Id: "4", StartTime: at(14, 0), EndTime: at(15, 0)},
+		},
+		{
+			name:    "Nothing left once adjusted",
+			checked: session.Session{Id: "4", StartTime: at(12, 15), EndTime: at(12, 45)},
+			policy:  session.OverlapAdjust,
+			wantErr: session.ErrOverlap,
+		},
+		{
+			name:    "Flowing session not adjusted",
+			checked: session.Session{Id: "4", StartTime: at(11, 0)},
+			policy:  session.OverlapAdjust,
+			wantErr: session.ErrOverlap,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			got, err := tc.checked.CheckOverlaps(others, tc.policy)
+
+			var warning *session.OverlapWarning
+			if errors.As(err, &warning) {
+				is.Equal(warning.Overlapped, tc.wantWarning)
+			} else {
+				is.True(tc.wantWarning == nil)
+				is.True(errors.Is(err, tc.wantErr))
+			}
+			is.Equal(got, tc.want)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package session
+
+import "time"
+
+// PomodoroLength returns the length of the work interval of a session
+// started by the pomodoro mode, ok is false for the other sessions
+func (s Session) PomodoroLength() (length time.Duration, ok bool) {
+	value, ok := s.Metadata[PomodoroMetadata]
+	if !ok {
+		return 0, false
+	}
+
+	length, err := time.ParseDuration(value)
+	if err != nil || length <= 0 {
+		return 0, false
+	}
+
+	return length, true
+}
+
+// IsCompletedPomodoro tells if the session is a work interval of the
+// pomodoro mode which lasted its whole length, and wasn't stopped before
+func (s Session) IsCompletedPomodoro() bool {
+	length, ok := s.PomodoroLength()
+
+	return ok && s.Status() == EndedStatus && s.EndTime.Sub(s.StartTime) >= length
+}
+
+// CountPomodoros returns the number of completed pomodoros of the sessions
+func CountPomodoros(sessions []Session) int {
+	count := 0
+	for _, s := range sessions {
+		if s.IsCompletedPomodoro() {
+			count++
+		}
+	}
+
+	return count
+}
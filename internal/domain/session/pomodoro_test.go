@@ -0,0 +1,52 @@
+package session_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/matryer/is"
+)
+
+func TestSession_IsCompletedPomodoro(t *testing.T) {
+	at := func(hour int, minute int) time.Time {
+		return time.Date(2024, time.April, 15, hour, minute, 0, 0, time.UTC)
+	}
+	pomodoro := map[string]string{session.PomodoroMetadata: "25m0s"}
+
+	tt := []struct {
+		name    string
+		session session.Session
+		want    bool
+	}{
+		{
+			name:    "Whole work interval",
+			session: session.Session{StartTime: at(9, 0), EndTime: at(9, 25), Metadata: pomodoro},
+			want:    true,
+		},
+		{
+			name:    "Work interval stopped before its end",
+			session: session.Session{StartTime: at(9, 0), EndTime: at(9, 20), Metadata: pomodoro},
+		},
+		{
+			name:    "Flowing work interval",
+			session: session.Session{StartTime: at(9, 0), Metadata: pomodoro},
+		},
+		{
+			name:    "Session out of the pomodoro mode",
+			session: session.Session{StartTime: at(9, 0), EndTime: at(10, 0)},
+		},
+		{
+			name:    "Invalid length",
+			session: session.Session{StartTime: at(9, 0), EndTime: at(10, 0), Metadata: map[string]string{session.PomodoroMetadata: "long"}},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			is.Equal(tc.session.IsCompletedPomodoro(), tc.want)
+		})
+	}
+}
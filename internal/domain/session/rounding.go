@@ -0,0 +1,121 @@
+package session
+
+import (
+	"slices"
+	"time"
+)
+
+// Directions the durations are rounded in
+const (
+	RoundNearest = "nearest"
+	RoundUp      = "up"
+)
+
+var RoundingMethods = []string{RoundNearest, RoundUp}
+
+// What the durations are rounded for
+const (
+	RoundPerSession = "session"
+	RoundPerDay     = "day"
+)
+
+var RoundingScopes = []string{RoundPerSession, RoundPerDay}
+
+// Rounding rounds the durations of the sessions to the billing convention of
+// a client, like quarters of an hour, it's off when Increment is zero
+type Rounding struct {
+	Increment time.Duration
+	// Method is RoundNearest or RoundUp, nearest when empty
+	Method string
+	// Per is RoundPerSession, or RoundPerDay to round the time spent on each
+	// project each day, per session when empty
+	Per string
+}
+
+// Round rounds the duration to a multiple of the increment
+func (r Rounding) Round(d time.Duration) time.Duration {
+	if r.Increment <= 0 {
+		return d
+	}
+
+	if r.Method == RoundUp {
+		rounded := d.Truncate(r.Increment)
+		if rounded < d {
+			rounded += r.Increment
+		}
+		return rounded
+	}
+
+	return d.Round(r.Increment)
+}
+
+// Apply returns a copy of the sessions whose end times are moved so their
+// durations are rounded, the flowing sessions are left as they are. Per day,
+// the difference between the rounded and the tracked time of a project is
+// taken from its last sessions of the day.
+func (r Rounding) Apply(sessions []Session) []Session {
+	if r.Increment <= 0 {
+		return sessions
+	}
+
+	rounded := slices.Clone(sessions)
+
+	if r.Per == RoundPerDay {
+		r.applyPerDay(rounded)
+		return rounded
+	}
+
+	for i, s := range rounded {
+		if s.EndTime.IsZero() {
+			continue
+		}
+
+		rounded[i].EndTime = s.StartTime.Add(r.Round(s.EndTime.Sub(s.StartTime)))
+	}
+
+	return rounded
+}
+
+func (r Rounding) applyPerDay(sessions []Session) {
+	type projectDay struct {
+		day     string
+		project string
+	}
+
+	days := map[projectDay][]int{}
+	for i, s := range sessions {
+		if s.EndTime.IsZero() {
+			continue
+		}
+
+		key := projectDay{day: s.StartTime.Format(time.DateOnly), project: s.Project}
+		days[key] = append(days[key], i)
+	}
+
+	for _, indexes := range days {
+		slices.SortFunc(indexes, func(a int, b int) int {
+			return sessions[a].StartTime.Compare(sessions[b].StartTime)
+		})
+
+		var tracked time.Duration
+		for _, i := range indexes {
+			tracked += sessions[i].EndTime.Sub(sessions[i].StartTime)
+		}
+
+		difference := r.Round(tracked) - tracked
+		if difference >= 0 {
+			last := indexes[len(indexes)-1]
+			sessions[last].EndTime = sessions[last].EndTime.Add(difference)
+			continue
+		}
+
+		// rounded down, the last sessions are shortened until the difference
+		// is taken out
+		for j := len(indexes) - 1; j >= 0 && difference < 0; j-- {
+			i := indexes[j]
+			taken := max(difference, -sessions[i].EndTime.Sub(sessions[i].StartTime))
+			sessions[i].EndTime = sessions[i].EndTime.Add(taken)
+			difference -= taken
+		}
+	}
+}
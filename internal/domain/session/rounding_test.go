@@ -0,0 +1,75 @@
+package session_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/matryer/is"
+)
+
+func TestRounding_Apply(t *testing.T) {
+	at := func(day int, hour int, minute int) time.Time {
+		return time.Date(2024, time.April, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	sessions := []session.Session{
+		{Id: "1", StartTime: at(15, 9, 0), EndTime: at(15, 9, 52), Project: "Flow"},
+		{Id: "2", StartTime: at(15, 10, 0), EndTime: at(15, 10, 7), Project: "Flow"},
+		{Id: "3", StartTime: at(15, 11, 0), EndTime: at(15, 11, 20), Project: "MyTodo"},
+		{Id: "4", StartTime: at(16, 9, 0), EndTime: at(16, 9, 5), Project: "Flow"},
+		{Id: "5", StartTime: at(16, 14, 0), Project: "Flow"},
+	}
+
+	tt := []struct {
+		name     string
+		rounding session.Rounding
+		wantEnds []time.Time
+	}{
+		{
+			name:     "Off",
+			rounding: session.Rounding{},
+			wantEnds: []time.Time{at(15, 9, 52), at(15, 10, 7), at(15, 11, 20), at(16, 9, 5), {}},
+		},
+		{
+			name:     "Nearest quarter of each session",
+			rounding: session.Rounding{Increment: 15 * time.Minute},
+			wantEnds: []time.Time{at(15, 9, 45), at(15, 10, 0), at(15, 11, 15), at(16, 9, 0), {}},
+		},
+		{
+			name:     "Each session rounded up",
+			rounding: session.Rounding{Increment: 15 * time.Minute, Method: session.RoundUp},
+			wantEnds: []time.Time{at(15, 9, 60), at(15, 10, 15), at(15, 11, 30), at(16, 9, 15), {}},
+		},
+		{
+			name:     "Each day rounded up",
+			rounding: session.Rounding{Increment: 30 * time.Minute, Method: session.RoundUp, Per: session.RoundPerDay},
+			wantEnds: []time.Time{at(15, 9, 52), at(15, 10, 8), at(15, 11, 30), at(16, 9, 30), {}},
+		},
+		{
+			name:     "Nearest half hour of each day",
+			rounding: session.Rounding{Increment: 30 * time.Minute, Per: session.RoundPerDay},
+			wantEnds: []time.Time{at(15, 9, 52), at(15, 10, 8), at(15, 11, 30), at(16, 9, 0), {}},
+		},
+		{
+			name:     "Rounded down time taken from the last sessions of the day",
+			rounding: session.Rounding{Increment: 45 * time.Minute, Per: session.RoundPerDay},
+			wantEnds: []time.Time{at(15, 9, 45), at(15, 10, 0), at(15, 11, 0), at(16, 9, 0), {}},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			rounded := tc.rounding.Apply(sessions)
+
+			gotEnds := []time.Time{}
+			for _, s := range rounded {
+				gotEnds = append(gotEnds, s.EndTime)
+			}
+			is.Equal(gotEnds, tc.wantEnds)
+			is.Equal(sessions[0].EndTime, at(15, 9, 52)) // the sessions aren't changed
+		})
+	}
+}
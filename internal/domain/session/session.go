@@ -1,12 +1,44 @@
 package session
 
 import (
+	"maps"
+	"slices"
 	"time"
 )
 
 const (
 	FlowingStatus = "FLOWING"
 	EndedStatus   = "ENDED"
+	// UnstoppedStatus is the status of a session that was never stopped: it
+	// has no end time but it isn't the current session anymore
+	UnstoppedStatus = "UNSTOPPED"
+)
+
+const (
+	CommandMetadata   = "command"
+	ExitCodeMetadata  = "exit_code"
+	UnstoppedMetadata = "unstopped"
+	// ClockSkewMetadata is how far the clock went backwards before the
+	// session was stopped
+	ClockSkewMetadata = "clock_skew"
+	// BreakMetadata is the duration of the scheduled break taken before the
+	// session, see WithBreaks
+	BreakMetadata = "break"
+	// IdleTrimmedMetadata is the idle time taken out of the end of the
+	// session, see WithoutIdle
+	IdleTrimmedMetadata = "idle_trimmed"
+	// IdleBreakMetadata is the idle time taken out of the end of the session
+	// as a break taken after it
+	IdleBreakMetadata = "idle_break"
+	// ExternalIDMetadata is the id of an imported session in the time tracker
+	// it comes from, prefixed with the tracker like "toggl:123"
+	ExternalIDMetadata = "external_id"
+	// ApprovedByMetadata is the member of the team server who approved the
+	// session
+	ApprovedByMetadata = "approved_by"
+	// PomodoroMetadata is the length of the work interval of a session started
+	// by the pomodoro mode
+	PomodoroMetadata = "pomodoro"
 )
 
 type Session struct {
@@ -15,6 +47,19 @@ type Session struct {
 	EndTime   time.Time
 	Project   string
 	Tags      []string
+	Note      string            `json:",omitempty"`
+	Metadata  map[string]string `json:",omitempty"`
+	// Billable overrides the billable setting of the project of the session
+	Billable *bool `json:",omitempty"`
+	// HourlyRate overrides the hourly rate of the project of the session
+	HourlyRate *float64 `json:",omitempty"`
+	// Client overrides the client of the project of the session
+	Client string `json:",omitempty"`
+	// Continues is the id of the session this one continues, sessions
+	// continuing each other form a chain
+	Continues string `json:",omitempty"`
+	// BlockedBy describes the external event the session was blocked by
+	BlockedBy string `json:",omitempty"`
 }
 
 func (s Session) GetFormattedStartTime() string {
@@ -38,12 +83,33 @@ func (s Session) Duration() time.Duration {
 
 func (s Session) Status() string {
 	if s.EndTime.IsZero() {
+		if _, ok := s.Metadata[UnstoppedMetadata]; ok {
+			return UnstoppedStatus
+		}
 		return FlowingStatus
 	}
 
 	return EndedStatus
 }
 
+// WasNeverStopped tells if the session has no end time although it started
+// before the last session, given its start time
+func (s Session) WasNeverStopped(lastStartTime time.Time) bool {
+	return s.EndTime.IsZero() && s.StartTime.Before(lastStartTime)
+}
+
+// MarkUnstopped returns a copy of the session holding the unstopped metadata
+func (s Session) MarkUnstopped() Session {
+	metadata := maps.Clone(s.Metadata)
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+	metadata[UnstoppedMetadata] = "true"
+	s.Metadata = metadata
+
+	return s
+}
+
 func (s Session) Equals(session Session) bool {
 	return s.Id == session.Id
 }
@@ -56,3 +122,51 @@ func (s Session) HasTag(tag string) bool {
 	}
 	return false
 }
+
+func (s Session) HasAnyTag(tags []string) bool {
+	for _, tag := range tags {
+		if s.HasTag(tag) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s Session) HasAllTags(tags []string) bool {
+	for _, tag := range tags {
+		if !s.HasTag(tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// WithTags returns a copy of the session without the removed tags and with
+// the added tags it doesn't have yet, the other tags keep their order
+func (s Session) WithTags(added []string, removed []string) Session {
+	tags := []string{}
+	for _, tag := range s.Tags {
+		if !slices.Contains(removed, tag) {
+			tags = append(tags, tag)
+		}
+	}
+
+	for _, tag := range added {
+		if !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+
+	s.Tags = tags
+
+	return s
+}
+
+// Overlaps tells if both sessions were flowing at the same time, a session
+// still flowing is considered to never end
+func (s Session) Overlaps(other Session) bool {
+	startsBeforeOtherEnds := other.EndTime.IsZero() || s.StartTime.Before(other.EndTime)
+	endsAfterOtherStarts := s.EndTime.IsZero() || s.EndTime.After(other.StartTime)
+
+	return startsBeforeOtherEnds && endsAfterOtherStarts
+}
@@ -1,6 +1,7 @@
 package session_test
 
 import (
+	"reflect"
 	"testing"
 	"time"
 
@@ -61,6 +62,24 @@ func TestSession_Status(t *testing.T) {
 			},
 			want: session.FlowingStatus,
 		},
+		{
+			name: "Session marked as unstopped",
+			e: session.Session{
+				Id:        "1",
+				StartTime: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			}.MarkUnstopped(),
+			want: session.UnstoppedStatus,
+		},
+		{
+			name: "Session marked as unstopped with end time",
+			e: session.Session{
+				Id:        "1",
+				StartTime: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2020, 1, 1, 0, 0, 1, 0, time.UTC),
+				Metadata:  map[string]string{session.UnstoppedMetadata: "true"},
+			},
+			want: session.EndedStatus,
+		},
 	}
 
 	for _, tc := range tt {
@@ -164,6 +183,52 @@ func TestSession_HasTag(t *testing.T) {
 	}
 }
 
+func TestSession_HasAnyTagAndHasAllTags(t *testing.T) {
+	s := session.Session{
+		Id:        "1",
+		StartTime: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		Project:   "my-todo",
+		Tags:      []string{"add-todo", "remove-todo"},
+	}
+
+	tt := []struct {
+		name    string
+		tags    []string
+		wantAny bool
+		wantAll bool
+	}{
+		{
+			name:    "All tags present",
+			tags:    []string{"add-todo", "remove-todo"},
+			wantAny: true,
+			wantAll: true,
+		},
+		{
+			name:    "Some tags present",
+			tags:    []string{"add-todo", "update-todo"},
+			wantAny: true,
+			wantAll: false,
+		},
+		{
+			name:    "No tags present",
+			tags:    []string{"update-todo"},
+			wantAny: false,
+			wantAll: false,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := s.HasAnyTag(tc.tags); got != tc.wantAny {
+				t.Errorf("Entry.HasAnyTag() = %v, want %v", got, tc.wantAny)
+			}
+			if got := s.HasAllTags(tc.tags); got != tc.wantAll {
+				t.Errorf("Entry.HasAllTags() = %v, want %v", got, tc.wantAll)
+			}
+		})
+	}
+}
+
 func TestSession_Equals(t *testing.T) {
 	tt := []struct {
 		name  string
@@ -209,3 +274,155 @@ func TestSession_Equals(t *testing.T) {
 		})
 	}
 }
+
+func TestSession_Overlaps(t *testing.T) {
+	s := session.Session{
+		Id:        "1",
+		StartTime: time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC),
+		Project:   "my-todo",
+	}
+
+	tt := []struct {
+		name  string
+		given session.Session
+		want  bool
+	}{
+		{
+			name: "Before",
+			given: session.Session{
+				StartTime: time.Date(2020, 1, 1, 8, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC),
+			},
+			want: false,
+		},
+		{
+			name: "After",
+			given: session.Session{
+				StartTime: time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2020, 1, 1, 13, 0, 0, 0, time.UTC),
+			},
+			want: false,
+		},
+		{
+			name: "Crossing the start",
+			given: session.Session{
+				StartTime: time.Date(2020, 1, 1, 9, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2020, 1, 1, 10, 30, 0, 0, time.UTC),
+			},
+			want: true,
+		},
+		{
+			name: "Inside",
+			given: session.Session{
+				StartTime: time.Date(2020, 1, 1, 10, 30, 0, 0, time.UTC),
+				EndTime:   time.Date(2020, 1, 1, 11, 0, 0, 0, time.UTC),
+			},
+			want: true,
+		},
+		{
+			name: "Flowing since before the end",
+			given: session.Session{
+				StartTime: time.Date(2020, 1, 1, 11, 0, 0, 0, time.UTC),
+			},
+			want: true,
+		},
+		{
+			name: "Flowing since after the end",
+			given: session.Session{
+				StartTime: time.Date(2020, 1, 1, 13, 0, 0, 0, time.UTC),
+			},
+			want: false,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := s.Overlaps(tc.given); got != tc.want {
+				t.Errorf("Entry.Overlaps() = %v, want %v", got, tc.want)
+			}
+			if got := tc.given.Overlaps(s); got != tc.want {
+				t.Errorf("Entry.Overlaps() reversed = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSession_WasNeverStopped(t *testing.T) {
+	lastStartTime := time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		name string
+		e    session.Session
+		want bool
+	}{
+		{
+			name: "Without end time before the last session",
+			e:    session.Session{StartTime: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+			want: true,
+		},
+		{
+			name: "With end time before the last session",
+			e: session.Session{
+				StartTime: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2020, 1, 1, 1, 0, 0, 0, time.UTC),
+			},
+			want: false,
+		},
+		{
+			name: "Last session",
+			e:    session.Session{StartTime: lastStartTime},
+			want: false,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.e.WasNeverStopped(lastStartTime); got != tc.want {
+				t.Errorf("Session.WasNeverStopped() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSession_WithTags(t *testing.T) {
+	tt := []struct {
+		name    string
+		tags    []string
+		added   []string
+		removed []string
+		want    []string
+	}{
+		{
+			name:  "Added tags",
+			tags:  []string{"cli"},
+			added: []string{"docs", "cli"},
+			want:  []string{"cli", "docs"},
+		},
+		{
+			name:    "Removed tags",
+			tags:    []string{"cli", "wip", "docs"},
+			removed: []string{"wip"},
+			want:    []string{"cli", "docs"},
+		},
+		{
+			name:    "Renamed tag",
+			tags:    []string{"doc", "cli", "docs"},
+			added:   []string{"docs"},
+			removed: []string{"doc"},
+			want:    []string{"cli", "docs"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			s := session.Session{Id: "1", Tags: tc.tags}
+
+			got := s.WithTags(tc.added, tc.removed)
+
+			if !reflect.DeepEqual(got.Tags, tc.want) {
+				t.Errorf("Session.WithTags() = %v, want %v", got.Tags, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package session
+
+import "slices"
+
+// TagDeprecation marks a tag which shouldn't be used anymore, in favor of its
+// replacement when it has one
+type TagDeprecation struct {
+	Tag string
+	// Replacement is the tag to use instead, the deprecated tag is only
+	// removed when it's empty
+	Replacement string
+}
+
+// DeprecatedTags returns the deprecations of the tags
+func DeprecatedTags(tags []string, deprecations []TagDeprecation) []TagDeprecation {
+	used := []TagDeprecation{}
+	for _, deprecation := range deprecations {
+		if slices.Contains(tags, deprecation.Tag) {
+			used = append(used, deprecation)
+		}
+	}
+
+	return used
+}
+
+// WithTagDeprecations replaces the deprecated tags of the session with their
+// replacement
+func (s Session) WithTagDeprecations(deprecations []TagDeprecation) Session {
+	removed := []string{}
+	added := []string{}
+
+	for _, deprecation := range DeprecatedTags(s.Tags, deprecations) {
+		removed = append(removed, deprecation.Tag)
+		if deprecation.Replacement != "" {
+			added = append(added, deprecation.Replacement)
+		}
+	}
+
+	if len(removed) == 0 {
+		return s
+	}
+
+	return s.WithTags(added, removed)
+}
@@ -0,0 +1,52 @@
+package session_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+func TestSession_WithTagDeprecations(t *testing.T) {
+	deprecations := []session.TagDeprecation{
+		{Tag: "wip", Replacement: "in-progress"},
+		{Tag: "todo"},
+	}
+
+	tt := []struct {
+		name string
+		tags []string
+		want []string
+	}{
+		{
+			name: "replaced",
+			tags: []string{"cli", "wip"},
+			want: []string{"cli", "in-progress"},
+		},
+		{
+			name: "removed without replacement",
+			tags: []string{"todo", "cli"},
+			want: []string{"cli"},
+		},
+		{
+			name: "replacement already there",
+			tags: []string{"in-progress", "wip"},
+			want: []string{"in-progress"},
+		},
+		{
+			name: "nothing deprecated",
+			tags: []string{"cli"},
+			want: []string{"cli"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got := session.Session{Tags: tc.tags}.WithTagDeprecations(deprecations)
+
+			if !reflect.DeepEqual(got.Tags, tc.want) {
+				t.Errorf("got %v, want %v", got.Tags, tc.want)
+			}
+		})
+	}
+}
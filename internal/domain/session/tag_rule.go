@@ -0,0 +1,129 @@
+package session
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+var ruleWeekdays = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// TagRule adds its tag to the sessions started or stopped on its days and
+// during its hours, like sessions of the weekend or after 19:00
+type TagRule struct {
+	Tag string
+	// Days are the days of the rule, every day when empty
+	Days []time.Weekday
+	// After and Before are times of the day, since midnight. They are ignored
+	// when zero, and the rule spans midnight when After is later than Before.
+	After  time.Duration
+	Before time.Duration
+}
+
+// ParseTagRule reads a rule like "sat,sun", "mon-fri after 19:00" or
+// "after 22:00 before 06:00"
+func ParseTagRule(tag string, rule string) (TagRule, error) {
+	tagRule := TagRule{Tag: tag}
+
+	if strings.TrimSpace(rule) == "" {
+		return TagRule{}, ErrEmptyTagRule
+	}
+
+	fields := strings.Fields(strings.ToLower(rule))
+	for i := 0; i < len(fields); i++ {
+		switch fields[i] {
+		case "after", "before":
+			if i+1 == len(fields) {
+				return TagRule{}, fmt.Errorf("a time is expected after %v", fields[i])
+			}
+
+			timeOfDay, err := time.Parse("15:04", fields[i+1])
+			if err != nil {
+				return TagRule{}, fmt.Errorf("%v is not a valid time, expected a time like 19:00", fields[i+1])
+			}
+			sinceMidnight := time.Duration(timeOfDay.Hour())*time.Hour + time.Duration(timeOfDay.Minute())*time.Minute
+
+			if fields[i] == "after" {
+				tagRule.After = sinceMidnight
+			} else {
+				tagRule.Before = sinceMidnight
+			}
+			i++
+		default:
+			days, err := parseRuleDays(fields[i])
+			if err != nil {
+				return TagRule{}, err
+			}
+			tagRule.Days = append(tagRule.Days, days...)
+		}
+	}
+
+	return tagRule, nil
+}
+
+// parseRuleDays reads a list of days or of ranges of days, like "sat,sun" or
+// "mon-fri"
+func parseRuleDays(days string) ([]time.Weekday, error) {
+	weekdays := []time.Weekday{}
+
+	for _, day := range strings.Split(days, ",") {
+		first, last, isRange := strings.Cut(day, "-")
+		if !isRange {
+			last = first
+		}
+
+		firstIndex := slices.Index(ruleWeekdays, first)
+		lastIndex := slices.Index(ruleWeekdays, last)
+		if firstIndex == -1 || lastIndex == -1 {
+			return nil, fmt.Errorf("%v is not a valid day, expected days like mon-fri or sat,sun", day)
+		}
+
+		for i := firstIndex; ; i = (i + 1) % len(ruleWeekdays) {
+			weekdays = append(weekdays, time.Weekday(i))
+			if i == lastIndex {
+				break
+			}
+		}
+	}
+
+	return weekdays, nil
+}
+
+// Matches tells if the time is on the days and during the hours of the rule
+func (r TagRule) Matches(t time.Time) bool {
+	if len(r.Days) > 0 && !slices.Contains(r.Days, t.Weekday()) {
+		return false
+	}
+
+	sinceMidnight := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+	isAfter := r.After == 0 || sinceMidnight >= r.After
+	isBefore := r.Before == 0 || sinceMidnight < r.Before
+
+	if r.After != 0 && r.Before != 0 && r.After > r.Before {
+		return isAfter || isBefore
+	}
+
+	return isAfter && isBefore
+}
+
+// WithTagRules adds the tags of the rules matching the start time of the
+// session, or its end time once it's stopped
+func (s Session) WithTagRules(rules []TagRule) Session {
+	tags := []string{}
+
+	for _, rule := range rules {
+		if rule.Matches(s.StartTime) || (!s.EndTime.IsZero() && rule.Matches(s.EndTime)) {
+			tags = append(tags, rule.Tag)
+		}
+	}
+
+	if len(tags) == 0 {
+		return s
+	}
+
+	return s.WithTags(tags, nil)
+}
+
+var ErrEmptyTagRule = errors.New("the tag rule is empty")
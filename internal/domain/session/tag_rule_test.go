@@ -0,0 +1,158 @@
+package session_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+func TestParseTagRule(t *testing.T) {
+	tt := []struct {
+		name    string
+		rule    string
+		want    session.TagRule
+		wantErr bool
+	}{
+		{
+			name: "days",
+			rule: "sat,sun",
+			want: session.TagRule{Tag: "tag", Days: []time.Weekday{time.Saturday, time.Sunday}},
+		},
+		{
+			name: "range of days and time",
+			rule: "Mon-Wed after 19:00",
+			want: session.TagRule{Tag: "tag", Days: []time.Weekday{time.Monday, time.Tuesday, time.Wednesday}, After: 19 * time.Hour},
+		},
+		{
+			name: "range of days over the week end",
+			rule: "fri-mon",
+			want: session.TagRule{Tag: "tag", Days: []time.Weekday{time.Friday, time.Saturday, time.Sunday, time.Monday}},
+		},
+		{
+			name: "hours",
+			rule: "after 22:00 before 06:30",
+			want: session.TagRule{Tag: "tag", After: 22 * time.Hour, Before: 6*time.Hour + 30*time.Minute},
+		},
+		{
+			name:    "empty",
+			rule:    " ",
+			wantErr: true,
+		},
+		{
+			name:    "invalid day",
+			rule:    "saturday",
+			wantErr: true,
+		},
+		{
+			name:    "invalid time",
+			rule:    "after 7pm",
+			wantErr: true,
+		},
+		{
+			name:    "missing time",
+			rule:    "before",
+			wantErr: true,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := session.ParseTagRule("tag", tc.rule)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ParseTagRule() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if !tc.wantErr && !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("ParseTagRule() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTagRule_Matches(t *testing.T) {
+	saturday := time.Date(2024, 4, 20, 10, 0, 0, 0, time.UTC)
+	mondayEvening := time.Date(2024, 4, 22, 20, 0, 0, 0, time.UTC)
+	mondayMorning := time.Date(2024, 4, 22, 5, 0, 0, 0, time.UTC)
+	mondayNoon := time.Date(2024, 4, 22, 12, 0, 0, 0, time.UTC)
+
+	weekend := session.TagRule{Tag: "weekend", Days: []time.Weekday{time.Saturday, time.Sunday}}
+	overtime := session.TagRule{Tag: "overtime", After: 19 * time.Hour}
+	night := session.TagRule{Tag: "night", After: 22 * time.Hour, Before: 6 * time.Hour}
+	workingHours := session.TagRule{Tag: "work", Days: []time.Weekday{time.Monday}, After: 9 * time.Hour, Before: 18 * time.Hour}
+
+	tt := []struct {
+		name string
+		rule session.TagRule
+		t    time.Time
+		want bool
+	}{
+		{name: "on one of the days", rule: weekend, t: saturday, want: true},
+		{name: "on another day", rule: weekend, t: mondayEvening, want: false},
+		{name: "after the time", rule: overtime, t: mondayEvening, want: true},
+		{name: "before the time", rule: overtime, t: mondayNoon, want: false},
+		{name: "spanning midnight", rule: night, t: mondayMorning, want: true},
+		{name: "outside of a window spanning midnight", rule: night, t: mondayEvening, want: false},
+		{name: "in the window of the day", rule: workingHours, t: mondayNoon, want: true},
+		{name: "outside of the window of the day", rule: workingHours, t: mondayEvening, want: false},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.rule.Matches(tc.t); got != tc.want {
+				t.Errorf("TagRule.Matches() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSession_WithTagRules(t *testing.T) {
+	rules := []session.TagRule{
+		{Tag: "weekend", Days: []time.Weekday{time.Saturday, time.Sunday}},
+		{Tag: "overtime", After: 19 * time.Hour},
+	}
+
+	tt := []struct {
+		name string
+		s    session.Session
+		want []string
+	}{
+		{
+			name: "no matching rule",
+			s: session.Session{
+				StartTime: time.Date(2024, 4, 22, 9, 0, 0, 0, time.UTC),
+				Tags:      []string{"docs"},
+			},
+			want: []string{"docs"},
+		},
+		{
+			name: "matching start time",
+			s: session.Session{
+				StartTime: time.Date(2024, 4, 20, 20, 0, 0, 0, time.UTC),
+				Tags:      []string{"docs"},
+			},
+			want: []string{"docs", "weekend", "overtime"},
+		},
+		{
+			name: "matching end time",
+			s: session.Session{
+				StartTime: time.Date(2024, 4, 22, 18, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2024, 4, 22, 19, 30, 0, 0, time.UTC),
+			},
+			want: []string{"overtime"},
+		},
+		{
+			name: "tag already set",
+			s: session.Session{
+				StartTime: time.Date(2024, 4, 21, 9, 0, 0, 0, time.UTC),
+				Tags:      []string{"weekend"},
+			},
+			want: []string{"weekend"},
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.s.WithTagRules(rules).Tags; !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Session.WithTagRules() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package sessionsreport
+
+import (
+	"sort"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/project"
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+type ProjectEarnings struct {
+	Project          string
+	BillableDuration time.Duration
+	Earnings         float64
+}
+
+type ClientEarnings struct {
+	// Client is empty for the projects billed to no client
+	Client           string
+	Projects         []ProjectEarnings
+	BillableDuration time.Duration
+	Earnings         float64
+}
+
+type EarningsReport struct {
+	Clients          []ClientEarnings
+	BillableDuration time.Duration
+	Earnings         float64
+}
+
+// clientLess sorts clients by name, the sessions without client come last
+func clientLess(a string, b string) bool {
+	if (a == "") != (b == "") {
+		return b == ""
+	}
+	return a < b
+}
+
+// a project can have sessions billed to several clients
+type clientProject struct {
+	client  string
+	project string
+}
+
+// EarningsTotals sums the billable sessions added one after the other, see
+// NewEarningsReport
+type EarningsTotals struct {
+	settings  map[string]project.Project
+	byProject map[clientProject]*ProjectEarnings
+}
+
+func NewEarningsTotals(projects []project.Project) *EarningsTotals {
+	settings := map[string]project.Project{}
+	for _, p := range projects {
+		settings[p.Name] = p
+	}
+
+	return &EarningsTotals{
+		settings:  settings,
+		byProject: map[clientProject]*ProjectEarnings{},
+	}
+}
+
+// Add counts the session when it's ended and billable
+func (t *EarningsTotals) Add(s session.Session) {
+	p, ok := t.settings[s.Project]
+	if !ok {
+		p = project.Project{Name: s.Project}
+	}
+
+	if !p.IsBillable(s) || s.Status() != session.EndedStatus {
+		return
+	}
+
+	key := clientProject{client: p.ClientOf(s), project: s.Project}
+	if _, ok := t.byProject[key]; !ok {
+		t.byProject[key] = &ProjectEarnings{Project: s.Project}
+	}
+	t.byProject[key].BillableDuration += s.Duration()
+	t.byProject[key].Earnings += p.Earnings(s)
+}
+
+// Report returns the earnings of the sessions added by client and by project
+func (t *EarningsTotals) Report() EarningsReport {
+	byClient := map[string]*ClientEarnings{}
+	report := EarningsReport{Clients: []ClientEarnings{}}
+	for key, projectEarnings := range t.byProject {
+		client := key.client
+		if _, ok := byClient[client]; !ok {
+			byClient[client] = &ClientEarnings{Client: client, Projects: []ProjectEarnings{}}
+		}
+
+		byClient[client].Projects = append(byClient[client].Projects, *projectEarnings)
+		byClient[client].BillableDuration += projectEarnings.BillableDuration
+		byClient[client].Earnings += projectEarnings.Earnings
+
+		report.BillableDuration += projectEarnings.BillableDuration
+		report.Earnings += projectEarnings.Earnings
+	}
+
+	for _, clientEarnings := range byClient {
+		sort.Slice(clientEarnings.Projects, func(i, j int) bool {
+			return clientEarnings.Projects[i].Project < clientEarnings.Projects[j].Project
+		})
+		report.Clients = append(report.Clients, *clientEarnings)
+	}
+
+	sort.Slice(report.Clients, func(i, j int) bool {
+		return clientLess(report.Clients[i].Client, report.Clients[j].Client)
+	})
+
+	return report
+}
+
+// NewEarningsReport sums the billable sessions by client and by project, the
+// projects settings give the client, the billable default and the rate of
+// each project unless a session overrides them
+func NewEarningsReport(sessions []session.Session, projects []project.Project) EarningsReport {
+	totals := NewEarningsTotals(projects)
+	for _, s := range sessions {
+		totals.Add(s)
+	}
+
+	return totals.Report()
+}
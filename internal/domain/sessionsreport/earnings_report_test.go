@@ -0,0 +1,94 @@
+package sessionsreport_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/project"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/domain/sessionsreport"
+	"github.com/matryer/is"
+)
+
+func TestNewEarningsReport(t *testing.T) {
+	notBillable := false
+	rushRate := 150.0
+
+	tt := []struct {
+		name     string
+		sessions []session.Session
+		projects []project.Project
+		want     sessionsreport.EarningsReport
+	}{
+		{
+			name: "Sessions of billable projects by client",
+			sessions: []session.Session{
+				{Id: "1", Project: "website", StartTime: time.Date(2024, 4, 1, 8, 0, 0, 0, time.UTC), EndTime: time.Date(2024, 4, 1, 10, 0, 0, 0, time.UTC)},
+				{Id: "2", Project: "api", StartTime: time.Date(2024, 4, 1, 14, 0, 0, 0, time.UTC), EndTime: time.Date(2024, 4, 1, 14, 30, 0, 0, time.UTC)},
+				{Id: "3", Project: "side", StartTime: time.Date(2024, 4, 2, 8, 0, 0, 0, time.UTC), EndTime: time.Date(2024, 4, 2, 9, 0, 0, 0, time.UTC)},
+				{Id: "4", Project: "flow", StartTime: time.Date(2024, 4, 2, 10, 0, 0, 0, time.UTC), EndTime: time.Date(2024, 4, 2, 12, 0, 0, 0, time.UTC)},
+			},
+			projects: []project.Project{
+				{Name: "website", Client: "Acme", Billable: true, HourlyRate: 80},
+				{Name: "api", Client: "Acme", Billable: true, HourlyRate: 100},
+				{Name: "side", Billable: true, HourlyRate: 50},
+			},
+			want: sessionsreport.EarningsReport{
+				Clients: []sessionsreport.ClientEarnings{
+					{
+						Client: "Acme",
+						Projects: []sessionsreport.ProjectEarnings{
+							{Project: "api", BillableDuration: 30 * time.Minute, Earnings: 50},
+							{Project: "website", BillableDuration: 2 * time.Hour, Earnings: 160},
+						},
+						BillableDuration: 2*time.Hour + 30*time.Minute,
+						Earnings:         210,
+					},
+					{
+						Projects:         []sessionsreport.ProjectEarnings{{Project: "side", BillableDuration: time.Hour, Earnings: 50}},
+						BillableDuration: time.Hour,
+						Earnings:         50,
+					},
+				},
+				BillableDuration: 3*time.Hour + 30*time.Minute,
+				Earnings:         260,
+			},
+		},
+		{
+			name: "Sessions overriding the project settings",
+			sessions: []session.Session{
+				{Id: "1", Project: "website", StartTime: time.Date(2024, 4, 1, 8, 0, 0, 0, time.UTC), EndTime: time.Date(2024, 4, 1, 10, 0, 0, 0, time.UTC), Billable: &notBillable},
+				{Id: "2", Project: "website", StartTime: time.Date(2024, 4, 1, 14, 0, 0, 0, time.UTC), EndTime: time.Date(2024, 4, 1, 15, 0, 0, 0, time.UTC), HourlyRate: &rushRate},
+			},
+			projects: []project.Project{{Name: "website", Client: "Acme", Billable: true, HourlyRate: 80}},
+			want: sessionsreport.EarningsReport{
+				Clients: []sessionsreport.ClientEarnings{
+					{
+						Client:           "Acme",
+						Projects:         []sessionsreport.ProjectEarnings{{Project: "website", BillableDuration: time.Hour, Earnings: 150}},
+						BillableDuration: time.Hour,
+						Earnings:         150,
+					},
+				},
+				BillableDuration: time.Hour,
+				Earnings:         150,
+			},
+		},
+		{
+			name: "Flowing sessions aren't billed yet",
+			sessions: []session.Session{
+				{Id: "1", Project: "website", StartTime: time.Date(2024, 4, 1, 8, 0, 0, 0, time.UTC)},
+			},
+			projects: []project.Project{{Name: "website", Billable: true, HourlyRate: 80}},
+			want:     sessionsreport.EarningsReport{Clients: []sessionsreport.ClientEarnings{}},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			is.Equal(sessionsreport.NewEarningsReport(tc.sessions, tc.projects), tc.want)
+		})
+	}
+}
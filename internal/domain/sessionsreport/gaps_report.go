@@ -0,0 +1,139 @@
+package sessionsreport
+
+import (
+	"slices"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/pkg/timerange"
+)
+
+// Gap is a period of the working hours during which no session was flowing
+type Gap struct {
+	Start time.Time
+	End   time.Time
+}
+
+func (g Gap) Duration() time.Duration {
+	return g.End.Sub(g.Start)
+}
+
+type GapsReport struct {
+	Gaps []Gap
+	// WorkingTime is the time of the working hours within the period
+	WorkingTime time.Duration
+	// Untracked is the total duration of the gaps
+	Untracked time.Duration
+}
+
+// NewGapsReport lists the gaps between the sessions during the working hours
+// of the period lasting at least the threshold. The period ends at now at the
+// latest, and a flowing session counts until now.
+func NewGapsReport(
+	sessions []session.Session,
+	period timerange.TimeRange,
+	workingHours session.TagRule,
+	threshold time.Duration,
+	now time.Time,
+) GapsReport {
+	report := GapsReport{Gaps: []Gap{}}
+
+	end := period.Until
+	if end.IsZero() || end.After(now) {
+		end = now
+	}
+
+	tracked := trackedPeriods(sessions, now)
+
+	// a window spanning midnight starts the day before the period
+	day := timerange.StartOf(period.Since, timerange.ByDay, time.Monday).AddDate(0, 0, -1)
+	for ; day.Before(end); day = day.AddDate(0, 0, 1) {
+		window, ok := workingWindow(day, workingHours)
+		if !ok {
+			continue
+		}
+
+		window, ok = window.Intersect(timerange.TimeRange{Since: period.Since, Until: end})
+		if !ok || !window.Until.After(window.Since) {
+			continue
+		}
+
+		report.WorkingTime += window.Until.Sub(window.Since)
+
+		for _, gap := range untrackedGaps(window, tracked) {
+			if gap.Duration() >= threshold {
+				report.Gaps = append(report.Gaps, gap)
+				report.Untracked += gap.Duration()
+			}
+		}
+	}
+
+	return report
+}
+
+// workingWindow returns the working hours starting on the day, the window
+// ends the next day when the hours span midnight
+func workingWindow(day time.Time, workingHours session.TagRule) (timerange.TimeRange, bool) {
+	if len(workingHours.Days) > 0 && !slices.Contains(workingHours.Days, day.Weekday()) {
+		return timerange.TimeRange{}, false
+	}
+
+	window := timerange.TimeRange{
+		Since: day.Add(workingHours.After),
+		Until: day.AddDate(0, 0, 1),
+	}
+
+	if workingHours.Before != 0 {
+		window.Until = day.Add(workingHours.Before)
+		if workingHours.Before <= workingHours.After {
+			window.Until = day.AddDate(0, 0, 1).Add(workingHours.Before)
+		}
+	}
+
+	return window, true
+}
+
+// trackedPeriods returns the periods of the sessions sorted by start
+func trackedPeriods(sessions []session.Session, now time.Time) []timerange.TimeRange {
+	periods := []timerange.TimeRange{}
+	for _, s := range sessions {
+		end := s.EndTime
+		if end.IsZero() {
+			end = now
+		}
+
+		periods = append(periods, timerange.TimeRange{Since: s.StartTime, Until: end})
+	}
+
+	slices.SortFunc(periods, func(a, b timerange.TimeRange) int {
+		return a.Since.Compare(b.Since)
+	})
+
+	return periods
+}
+
+// untrackedGaps returns the parts of the window out of the tracked periods
+func untrackedGaps(window timerange.TimeRange, tracked []timerange.TimeRange) []Gap {
+	gaps := []Gap{}
+
+	cursor := window.Since
+	for _, period := range tracked {
+		if !period.Until.After(cursor) {
+			continue
+		}
+		if !period.Since.Before(window.Until) {
+			break
+		}
+
+		if period.Since.After(cursor) {
+			gaps = append(gaps, Gap{Start: cursor, End: period.Since})
+		}
+		cursor = period.Until
+	}
+
+	if cursor.Before(window.Until) {
+		gaps = append(gaps, Gap{Start: cursor, End: window.Until})
+	}
+
+	return gaps
+}
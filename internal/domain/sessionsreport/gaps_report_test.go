@@ -0,0 +1,157 @@
+package sessionsreport_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/domain/sessionsreport"
+	"github.com/TristanShz/flow/pkg/timerange"
+	"github.com/matryer/is"
+)
+
+func at(day int, hour int, minute int) time.Time {
+	return time.Date(2024, time.April, day, hour, minute, 0, 0, time.UTC)
+}
+
+func TestNewGapsReport(t *testing.T) {
+	officeHours := session.TagRule{
+		Days:   []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+		After:  9 * time.Hour,
+		Before: 18 * time.Hour,
+	}
+	nightShift := session.TagRule{After: 22 * time.Hour, Before: 6 * time.Hour}
+
+	tt := []struct {
+		name         string
+		sessions     []session.Session
+		period       timerange.TimeRange
+		workingHours session.TagRule
+		threshold    time.Duration
+		now          time.Time
+		want         sessionsreport.GapsReport
+	}{
+		{
+			name: "Gaps between the sessions of the working hours",
+			sessions: []session.Session{
+				{Id: "1", StartTime: at(5, 9, 10), EndTime: at(5, 12, 0)},
+				{Id: "2", StartTime: at(5, 13, 30), EndTime: at(5, 17, 0)},
+			},
+			period:       timerange.TimeRange{Since: at(5, 0, 0), Until: at(5, 23, 59)},
+			workingHours: officeHours,
+			threshold:    30 * time.Minute,
+			now:          at(10, 0, 0),
+			want: sessionsreport.GapsReport{
+				Gaps: []sessionsreport.Gap{
+					{Start: at(5, 12, 0), End: at(5, 13, 30)},
+					{Start: at(5, 17, 0), End: at(5, 18, 0)},
+				},
+				WorkingTime: 9 * time.Hour,
+				Untracked:   150 * time.Minute,
+			},
+		},
+		{
+			name: "Sessions out of the working hours and days",
+			sessions: []session.Session{
+				{Id: "1", StartTime: at(5, 7, 0), EndTime: at(5, 10, 0)},
+				{Id: "2", StartTime: at(5, 17, 0), EndTime: at(5, 20, 0)},
+				{Id: "3", StartTime: at(6, 10, 0), EndTime: at(6, 12, 0)},
+			},
+			period:       timerange.TimeRange{Since: at(5, 0, 0), Until: at(7, 23, 59)},
+			workingHours: officeHours,
+			threshold:    30 * time.Minute,
+			now:          at(10, 0, 0),
+			want: sessionsreport.GapsReport{
+				Gaps:        []sessionsreport.Gap{{Start: at(5, 10, 0), End: at(5, 17, 0)}},
+				WorkingTime: 9 * time.Hour,
+				Untracked:   7 * time.Hour,
+			},
+		},
+		{
+			name: "Overlapping sessions",
+			sessions: []session.Session{
+				{Id: "1", StartTime: at(5, 9, 0), EndTime: at(5, 14, 0)},
+				{Id: "2", StartTime: at(5, 10, 0), EndTime: at(5, 11, 0)},
+				{Id: "3", StartTime: at(5, 15, 0), EndTime: at(5, 18, 0)},
+			},
+			period:       timerange.TimeRange{Since: at(5, 0, 0), Until: at(5, 23, 59)},
+			workingHours: officeHours,
+			threshold:    30 * time.Minute,
+			now:          at(10, 0, 0),
+			want: sessionsreport.GapsReport{
+				Gaps:        []sessionsreport.Gap{{Start: at(5, 14, 0), End: at(5, 15, 0)}},
+				WorkingTime: 9 * time.Hour,
+				Untracked:   time.Hour,
+			},
+		},
+		{
+			name: "Gaps shorter than the threshold are left out",
+			sessions: []session.Session{
+				{Id: "1", StartTime: at(5, 9, 0), EndTime: at(5, 12, 0)},
+				{Id: "2", StartTime: at(5, 12, 20), EndTime: at(5, 17, 30)},
+			},
+			period:       timerange.TimeRange{Since: at(5, 0, 0), Until: at(5, 23, 59)},
+			workingHours: officeHours,
+			threshold:    30 * time.Minute,
+			now:          at(10, 0, 0),
+			want: sessionsreport.GapsReport{
+				Gaps:        []sessionsreport.Gap{{Start: at(5, 17, 30), End: at(5, 18, 0)}},
+				WorkingTime: 9 * time.Hour,
+				Untracked:   30 * time.Minute,
+			},
+		},
+		{
+			name: "The period ends now and a flowing session counts until now",
+			sessions: []session.Session{
+				{Id: "1", StartTime: at(5, 9, 0), EndTime: at(5, 10, 0)},
+				{Id: "2", StartTime: at(5, 11, 0)},
+			},
+			period:       timerange.TimeRange{Since: at(5, 0, 0), Until: at(5, 23, 59)},
+			workingHours: officeHours,
+			threshold:    30 * time.Minute,
+			now:          at(5, 15, 0),
+			want: sessionsreport.GapsReport{
+				Gaps:        []sessionsreport.Gap{{Start: at(5, 10, 0), End: at(5, 11, 0)}},
+				WorkingTime: 6 * time.Hour,
+				Untracked:   time.Hour,
+			},
+		},
+		{
+			name: "Working hours spanning midnight",
+			sessions: []session.Session{
+				{Id: "1", StartTime: at(5, 23, 0), EndTime: at(6, 5, 0)},
+			},
+			period:       timerange.TimeRange{Since: at(5, 0, 0), Until: at(5, 23, 59)},
+			workingHours: nightShift,
+			threshold:    30 * time.Minute,
+			now:          at(10, 0, 0),
+			want: sessionsreport.GapsReport{
+				Gaps: []sessionsreport.Gap{
+					{Start: at(5, 0, 0), End: at(5, 6, 0)},
+					{Start: at(5, 22, 0), End: at(5, 23, 0)},
+				},
+				WorkingTime: 7*time.Hour + 59*time.Minute,
+				Untracked:   7 * time.Hour,
+			},
+		},
+		{
+			name:         "No sessions",
+			sessions:     []session.Session{},
+			period:       timerange.TimeRange{Since: at(6, 0, 0), Until: at(7, 23, 59)},
+			workingHours: officeHours,
+			threshold:    30 * time.Minute,
+			now:          at(10, 0, 0),
+			want:         sessionsreport.GapsReport{Gaps: []sessionsreport.Gap{}},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			report := sessionsreport.NewGapsReport(tc.sessions, tc.period, tc.workingHours, tc.threshold, tc.now)
+
+			is.Equal(report, tc.want)
+		})
+	}
+}
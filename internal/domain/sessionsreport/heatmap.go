@@ -0,0 +1,82 @@
+package sessionsreport
+
+import (
+	"math"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/pkg/timerange"
+)
+
+// DailyTotal is the time tracked on a day, Day is the start of the day
+type DailyTotal struct {
+	Day      time.Time
+	Duration time.Duration
+}
+
+// Heatmap is the time tracked each day of consecutive weeks, Weeks[i][j] is
+// the j-th day of the i-th week. The last week stops at today.
+type Heatmap struct {
+	Weeks [][]DailyTotal
+	// Busiest is the day with the most tracked time, its duration is zero
+	// when nothing was tracked
+	Busiest DailyTotal
+}
+
+// NewHeatmap sums the ended sessions by day from the start of the first week
+// until today, a session counts on the day it started
+func NewHeatmap(sessions []session.Session, firstWeek time.Time, now time.Time) Heatmap {
+	heatmap := Heatmap{Weeks: [][]DailyTotal{}}
+
+	durations := map[time.Time]time.Duration{}
+	for _, sess := range sessions {
+		if sess.Status() != session.EndedStatus {
+			continue
+		}
+
+		durations[timerange.StartOf(sess.StartTime, timerange.ByDay, time.Monday)] += sess.Duration()
+	}
+
+	for day, index := timerange.StartOf(firstWeek, timerange.ByDay, time.Monday), 0; !day.After(now); day, index = day.AddDate(0, 0, 1), index+1 {
+		if index%7 == 0 {
+			heatmap.Weeks = append(heatmap.Weeks, []DailyTotal{})
+		}
+
+		total := DailyTotal{Day: day, Duration: durations[day]}
+		heatmap.Weeks[len(heatmap.Weeks)-1] = append(heatmap.Weeks[len(heatmap.Weeks)-1], total)
+
+		if total.Duration > heatmap.Busiest.Duration {
+			heatmap.Busiest = total
+		}
+	}
+
+	return heatmap
+}
+
+// Total returns the time tracked over the heatmap and the number of days
+// having sessions
+func (h Heatmap) Total() (total time.Duration, trackedDays int) {
+	for _, week := range h.Weeks {
+		for _, day := range week {
+			total += day.Duration
+			if day.Duration > 0 {
+				trackedDays++
+			}
+		}
+	}
+
+	return total, trackedDays
+}
+
+// Level returns the intensity of a day, from 0 when nothing was tracked to
+// levels-1 for the busiest day, the levels in between splitting the time of
+// the busiest day evenly
+func (h Heatmap) Level(day DailyTotal, levels int) int {
+	if day.Duration <= 0 || h.Busiest.Duration <= 0 || levels < 2 {
+		return 0
+	}
+
+	level := int(math.Ceil(float64(day.Duration) / float64(h.Busiest.Duration) * float64(levels-1)))
+
+	return min(level, levels-1)
+}
@@ -0,0 +1,63 @@
+package sessionsreport_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/domain/sessionsreport"
+	"github.com/matryer/is"
+)
+
+func TestNewHeatmap(t *testing.T) {
+	is := is.New(t)
+
+	day := func(d int) time.Time {
+		return time.Date(2024, 4, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	heatmap := sessionsreport.NewHeatmap([]session.Session{
+		{Id: "1", StartTime: day(9).Add(9 * time.Hour), EndTime: day(9).Add(11 * time.Hour)},
+		{Id: "2", StartTime: day(9).Add(14 * time.Hour), EndTime: day(9).Add(15 * time.Hour)},
+		{Id: "3", StartTime: day(15).Add(9 * time.Hour), EndTime: day(15).Add(13 * time.Hour)},
+		{Id: "4", StartTime: day(17).Add(9 * time.Hour)},
+	}, day(8), day(17).Add(12*time.Hour))
+
+	is.Equal(len(heatmap.Weeks), 2)
+	is.Equal(len(heatmap.Weeks[0]), 7)
+	is.Equal(heatmap.Weeks[0][1], sessionsreport.DailyTotal{Day: day(9), Duration: 3 * time.Hour})
+	is.Equal(heatmap.Weeks[1], []sessionsreport.DailyTotal{
+		{Day: day(15), Duration: 4 * time.Hour},
+		{Day: day(16)},
+		{Day: day(17)},
+	})
+	is.Equal(heatmap.Busiest, sessionsreport.DailyTotal{Day: day(15), Duration: 4 * time.Hour})
+
+	total, trackedDays := heatmap.Total()
+	is.Equal(total, 7*time.Hour)
+	is.Equal(trackedDays, 2)
+}
+
+func TestHeatmap_Level(t *testing.T) {
+	heatmap := sessionsreport.Heatmap{Busiest: sessionsreport.DailyTotal{Duration: 4 * time.Hour}}
+
+	tt := []struct {
+		name     string
+		duration time.Duration
+		want     int
+	}{
+		{name: "Nothing tracked", duration: 0, want: 0},
+		{name: "A few minutes", duration: 5 * time.Minute, want: 1},
+		{name: "Half of the busiest day", duration: 2 * time.Hour, want: 2},
+		{name: "Most of the busiest day", duration: 3*time.Hour + time.Minute, want: 4},
+		{name: "Busiest day", duration: 4 * time.Hour, want: 4},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			is.Equal(heatmap.Level(sessionsreport.DailyTotal{Duration: tc.duration}, 5), tc.want)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package sessionsreport
+
+import (
+	"sort"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/pkg/timerange"
+)
+
+// MonthlyTotal is the time spent on a project during a month, Month is the
+// start of the month
+type MonthlyTotal struct {
+	Month    time.Time
+	Project  string
+	Duration time.Duration
+}
+
+type monthlyTotalKey struct {
+	month   time.Time
+	project string
+}
+
+// MonthlyTotals sums the sessions added one after the other, see
+// NewMonthlyTotals. The zero value has no session.
+type MonthlyTotals struct {
+	durations map[monthlyTotalKey]time.Duration
+}
+
+// Add counts the session when it's ended, in the month it started in, in
+// its own location
+func (t *MonthlyTotals) Add(sess session.Session) {
+	if sess.Status() != session.EndedStatus {
+		return
+	}
+
+	if t.durations == nil {
+		t.durations = map[monthlyTotalKey]time.Duration{}
+	}
+
+	month := timerange.StartOf(sess.StartTime, timerange.ByMonth, time.Monday)
+	t.durations[monthlyTotalKey{month: month, project: sess.Project}] += sess.Duration()
+}
+
+// Totals returns the totals sorted by month then by project
+func (t *MonthlyTotals) Totals() []MonthlyTotal {
+	totals := []MonthlyTotal{}
+	for k, duration := range t.durations {
+		totals = append(totals, MonthlyTotal{Month: k.month, Project: k.project, Duration: duration})
+	}
+
+	sort.Slice(totals, func(i, j int) bool {
+		if !totals[i].Month.Equal(totals[j].Month) {
+			return totals[i].Month.Before(totals[j].Month)
+		}
+		return totals[i].Project < totals[j].Project
+	})
+
+	return totals
+}
+
+// NewMonthlyTotals sums the ended sessions by month and by project, sorted by
+// month then by project. A session counts in the month it started in, in its
+// own location.
+func NewMonthlyTotals(sessions []session.Session) []MonthlyTotal {
+	totals := MonthlyTotals{}
+	for _, sess := range sessions {
+		totals.Add(sess)
+	}
+
+	return totals.Totals()
+}
@@ -0,0 +1,53 @@
+package sessionsreport_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/domain/sessionsreport"
+	"github.com/matryer/is"
+)
+
+func TestNewMonthlyTotals(t *testing.T) {
+	is := is.New(t)
+
+	totals := sessionsreport.NewMonthlyTotals([]session.Session{
+		{
+			Id:        "1",
+			StartTime: time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, 4, 1, 11, 0, 0, 0, time.UTC),
+			Project:   "Flow",
+			Note:      "Private note",
+		},
+		{
+			Id:        "2",
+			StartTime: time.Date(2024, 4, 30, 23, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, 5, 1, 1, 0, 0, 0, time.UTC),
+			Project:   "Flow",
+		},
+		{
+			Id:        "3",
+			StartTime: time.Date(2024, 4, 12, 9, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, 4, 12, 10, 0, 0, 0, time.UTC),
+			Project:   "Acme",
+		},
+		{
+			Id:        "4",
+			StartTime: time.Date(2024, 5, 2, 9, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, 5, 2, 9, 30, 0, 0, time.UTC),
+			Project:   "Flow",
+		},
+		{
+			Id:        "5",
+			StartTime: time.Date(2024, 5, 3, 9, 0, 0, 0, time.UTC),
+			Project:   "Flow",
+		},
+	})
+
+	is.Equal(totals, []sessionsreport.MonthlyTotal{
+		{Month: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), Project: "Acme", Duration: time.Hour},
+		{Month: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), Project: "Flow", Duration: 4 * time.Hour},
+		{Month: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), Project: "Flow", Duration: 30 * time.Minute},
+	})
+}
@@ -0,0 +1,155 @@
+package sessionsreport
+
+import (
+	"sort"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+type PeriodStats struct {
+	Sessions int
+	Duration time.Duration
+}
+
+// AverageDuration returns the average length of the sessions of the period
+func (p PeriodStats) AverageDuration() time.Duration {
+	if p.Sessions == 0 {
+		return 0
+	}
+
+	return (p.Duration / time.Duration(p.Sessions)).Round(time.Second)
+}
+
+// TagDiff holds the time spent on a tag during each period
+type TagDiff struct {
+	Tag string
+	A   time.Duration
+	B   time.Duration
+}
+
+// Delta returns the time gained by the tag in the second period, it is
+// negative when the tag lost time
+func (t TagDiff) Delta() time.Duration {
+	return t.B - t.A
+}
+
+// Change returns the delta of the tag as a percentage of its time in the
+// first period, ok is false when the tag had no time in the first period
+func (t TagDiff) Change() (percent float64, ok bool) {
+	return change(t.A, t.B)
+}
+
+// ProjectDiff holds the time spent on a project during each period
+type ProjectDiff struct {
+	Project string
+	A       time.Duration
+	B       time.Duration
+}
+
+// Delta returns the time gained by the project in the second period, it is
+// negative when the project lost time
+func (p ProjectDiff) Delta() time.Duration {
+	return p.B - p.A
+}
+
+// Change returns the delta of the project as a percentage of its time in the
+// first period, ok is false when the project had no time in the first period
+func (p ProjectDiff) Change() (percent float64, ok bool) {
+	return change(p.A, p.B)
+}
+
+// Change returns the delta of the total time as a percentage of the total of
+// the first period, ok is false when the first period has no sessions
+func (d PeriodsDiff) Change() (percent float64, ok bool) {
+	return change(d.A.Duration, d.B.Duration)
+}
+
+func change(a time.Duration, b time.Duration) (float64, bool) {
+	if a == 0 {
+		return 0, false
+	}
+
+	return float64(b-a) / float64(a) * 100, true
+}
+
+type PeriodsDiff struct {
+	// Projects and Tags are sorted from the one that gained the most time to
+	// the one that lost the most
+	Projects []ProjectDiff
+	Tags     []TagDiff
+	A        PeriodStats
+	B        PeriodStats
+}
+
+// NewPeriodsDiff compares the ended sessions of two periods by project and by
+// tag, a session with several tags counts for each of them
+func NewPeriodsDiff(a []session.Session, b []session.Session) PeriodsDiff {
+	diff := PeriodsDiff{Projects: []ProjectDiff{}, Tags: []TagDiff{}}
+	projects := map[string]*ProjectDiff{}
+	tags := map[string]*TagDiff{}
+
+	projectDiff := func(project string) *ProjectDiff {
+		if _, ok := projects[project]; !ok {
+			projects[project] = &ProjectDiff{Project: project}
+		}
+		return projects[project]
+	}
+
+	tagDiff := func(tag string) *TagDiff {
+		if _, ok := tags[tag]; !ok {
+			tags[tag] = &TagDiff{Tag: tag}
+		}
+		return tags[tag]
+	}
+
+	for _, s := range a {
+		if s.Status() != session.EndedStatus {
+			continue
+		}
+
+		diff.A.Sessions++
+		diff.A.Duration += s.Duration()
+		projectDiff(s.Project).A += s.Duration()
+		for _, tag := range s.Tags {
+			tagDiff(tag).A += s.Duration()
+		}
+	}
+
+	for _, s := range b {
+		if s.Status() != session.EndedStatus {
+			continue
+		}
+
+		diff.B.Sessions++
+		diff.B.Duration += s.Duration()
+		projectDiff(s.Project).B += s.Duration()
+		for _, tag := range s.Tags {
+			tagDiff(tag).B += s.Duration()
+		}
+	}
+
+	for _, p := range projects {
+		diff.Projects = append(diff.Projects, *p)
+	}
+
+	sort.Slice(diff.Projects, func(i, j int) bool {
+		if diff.Projects[i].Delta() != diff.Projects[j].Delta() {
+			return diff.Projects[i].Delta() > diff.Projects[j].Delta()
+		}
+		return diff.Projects[i].Project < diff.Projects[j].Project
+	})
+
+	for _, t := range tags {
+		diff.Tags = append(diff.Tags, *t)
+	}
+
+	sort.Slice(diff.Tags, func(i, j int) bool {
+		if diff.Tags[i].Delta() != diff.Tags[j].Delta() {
+			return diff.Tags[i].Delta() > diff.Tags[j].Delta()
+		}
+		return diff.Tags[i].Tag < diff.Tags[j].Tag
+	})
+
+	return diff
+}
@@ -0,0 +1,57 @@
+package sessionsreport_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/domain/sessionsreport"
+	"github.com/matryer/is"
+)
+
+func TestNewPeriodsDiff(t *testing.T) {
+	is := is.New(t)
+
+	a := []session.Session{
+		{Id: "1", Project: "flow", StartTime: time.Date(2024, 3, 4, 8, 0, 0, 0, time.UTC), EndTime: time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC), Tags: []string{"cli"}},
+		{Id: "2", Project: "flow", StartTime: time.Date(2024, 3, 5, 8, 0, 0, 0, time.UTC), EndTime: time.Date(2024, 3, 5, 9, 0, 0, 0, time.UTC), Tags: []string{"cli", "docs"}},
+	}
+	b := []session.Session{
+		{Id: "3", Project: "flow", StartTime: time.Date(2024, 4, 2, 8, 0, 0, 0, time.UTC), EndTime: time.Date(2024, 4, 2, 9, 0, 0, 0, time.UTC), Tags: []string{"docs"}},
+		{Id: "4", Project: "flow", StartTime: time.Date(2024, 4, 3, 8, 0, 0, 0, time.UTC), EndTime: time.Date(2024, 4, 3, 8, 30, 0, 0, time.UTC), Tags: []string{"docs"}},
+		{Id: "5", Project: "my-todo", StartTime: time.Date(2024, 4, 4, 8, 0, 0, 0, time.UTC), EndTime: time.Date(2024, 4, 4, 8, 30, 0, 0, time.UTC), Tags: []string{"release"}},
+		{Id: "6", Project: "flow", StartTime: time.Date(2024, 4, 5, 8, 0, 0, 0, time.UTC), Tags: []string{"cli"}},
+	}
+
+	got := sessionsreport.NewPeriodsDiff(a, b)
+
+	is.Equal(got, sessionsreport.PeriodsDiff{
+		Projects: []sessionsreport.ProjectDiff{
+			{Project: "my-todo", B: 30 * time.Minute},
+			{Project: "flow", A: 3 * time.Hour, B: time.Hour + 30*time.Minute},
+		},
+		Tags: []sessionsreport.TagDiff{
+			{Tag: "docs", A: time.Hour, B: time.Hour + 30*time.Minute},
+			{Tag: "release", B: 30 * time.Minute},
+			{Tag: "cli", A: 3 * time.Hour},
+		},
+		A: sessionsreport.PeriodStats{Sessions: 2, Duration: 3 * time.Hour},
+		B: sessionsreport.PeriodStats{Sessions: 3, Duration: 2 * time.Hour},
+	})
+	is.Equal(got.A.AverageDuration(), time.Hour+30*time.Minute)
+	is.Equal(got.B.AverageDuration(), 40*time.Minute)
+	is.Equal(got.Tags[2].Delta(), -3*time.Hour)
+
+	percent, ok := got.Tags[0].Change()
+	is.True(ok)
+	is.Equal(percent, 50.0)
+	_, ok = got.Tags[1].Change()
+	is.True(!ok) // release had no time in the first period
+	percent, ok = got.Projects[1].Change()
+	is.True(ok)
+	is.Equal(percent, -50.0)
+	percent, ok = got.Change()
+	is.True(ok)
+	is.Equal(math.Round(percent), -33.0)
+}
@@ -0,0 +1,136 @@
+package sessionsreport
+
+import (
+	"sort"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/project"
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+type projectTotal struct {
+	report        ProjectReport
+	lastStartTime time.Time
+}
+
+// ProjectTotals sums the sessions added one after the other by project and
+// by client, so that the reports by project and by client don't need every
+// session to be held, see SessionsReport.Totals
+type ProjectTotals struct {
+	// Projects are the settings of the projects, needed to group the
+	// sessions by client
+	Projects []project.Project
+
+	count     int
+	duration  time.Duration
+	byProject map[string]*projectTotal
+	byClient  map[string]map[string]*projectTotal
+}
+
+func NewProjectTotals(projects []project.Project) *ProjectTotals {
+	return &ProjectTotals{
+		Projects:  projects,
+		byProject: map[string]*projectTotal{},
+		byClient:  map[string]map[string]*projectTotal{},
+	}
+}
+
+// Add counts the session in its project and in the project of its client.
+// The sessions that were never stopped only count in the durations of their
+// tags as their duration is unknown.
+func (t *ProjectTotals) Add(sess session.Session) {
+	t.count++
+	if sess.Status() != session.UnstoppedStatus {
+		t.duration += sess.Duration()
+	}
+
+	addTo(t.byProject, sess)
+
+	client := project.Find(t.Projects, sess.Project).ClientOf(sess)
+	if _, ok := t.byClient[client]; !ok {
+		t.byClient[client] = map[string]*projectTotal{}
+	}
+	addTo(t.byClient[client], sess)
+}
+
+func addTo(totals map[string]*projectTotal, sess session.Session) {
+	total, ok := totals[sess.Project]
+	if !ok {
+		total = &projectTotal{report: ProjectReport{Project: sess.Project, DurationByTag: map[string]time.Duration{}}}
+		totals[sess.Project] = total
+	}
+
+	counted := map[string]bool{}
+	for _, tag := range sess.Tags {
+		if counted[tag] {
+			continue
+		}
+		counted[tag] = true
+		total.report.DurationByTag[tag] += sess.Duration()
+	}
+	if sess.Status() != session.UnstoppedStatus {
+		total.report.TotalDuration += sess.Duration()
+	}
+
+	// the last session is the one which started last
+	if ok && sess.StartTime.Before(total.lastStartTime) {
+		return
+	}
+	total.lastStartTime = sess.StartTime
+	total.report.LastSessionEndTime = sess.EndTime
+}
+
+// Count is the number of sessions added
+func (t *ProjectTotals) Count() int {
+	return t.count
+}
+
+// Duration is the total duration of the sessions added, see
+// SessionsReport.Duration
+func (t *ProjectTotals) Duration() time.Duration {
+	return t.duration
+}
+
+// ByProject returns the reports of the projects sorted by the end time of
+// their last session
+func (t *ProjectTotals) ByProject() []ProjectReport {
+	return sortedProjectReports(t.byProject)
+}
+
+// ByClient returns the reports of the clients, the sessions without client
+// come last
+func (t *ProjectTotals) ByClient() []ClientReport {
+	clientReports := []ClientReport{}
+	for client, totals := range t.byClient {
+		clientReport := ClientReport{
+			Client:   client,
+			Projects: sortedProjectReports(totals),
+		}
+		for _, projectReport := range clientReport.Projects {
+			clientReport.TotalDuration += projectReport.TotalDuration
+		}
+		clientReports = append(clientReports, clientReport)
+	}
+
+	sort.Slice(clientReports, func(i, j int) bool {
+		return clientLess(clientReports[i].Client, clientReports[j].Client)
+	})
+
+	return clientReports
+}
+
+func sortedProjectReports(totals map[string]*projectTotal) []ProjectReport {
+	projectReports := make([]ProjectReport, 0, len(totals))
+	for _, total := range totals {
+		projectReports = append(projectReports, total.report)
+	}
+
+	sort.Slice(projectReports, func(i, j int) bool {
+		if !projectReports[i].LastSessionEndTime.Equal(projectReports[j].LastSessionEndTime) {
+			return projectReports[i].LastSessionEndTime.Before(projectReports[j].LastSessionEndTime)
+		}
+		return projectReports[i].Project < projectReports[j].Project
+	})
+
+	return projectReports
+}
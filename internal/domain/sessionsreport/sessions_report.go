@@ -1,21 +1,33 @@
 package sessionsreport
 
 import (
+	"slices"
 	"sort"
 	"time"
 
+	"github.com/TristanShz/flow/internal/domain/journal"
+	"github.com/TristanShz/flow/internal/domain/project"
 	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/pkg/timerange"
 )
 
 const (
 	FormatByDay     = "by-day"
 	FormatByProject = "by-project"
+	FormatByClient  = "by-client"
+	FormatEarnings  = "earnings"
+	FormatGaps      = "gaps"
 )
 
 type DayReport struct {
 	Day           time.Time
 	Sessions      []session.Session
 	TotalDuration time.Duration
+	// Journal holds the notes about the day, see journal.Entry
+	Journal []journal.Entry
+	// Pomodoros is the number of pomodoros completed on the day, see
+	// session.IsCompletedPomodoro
+	Pomodoros int
 }
 
 type ProjectReport struct {
@@ -25,8 +37,25 @@ type ProjectReport struct {
 	LastSessionEndTime time.Time
 }
 
+// ClientReport holds the reports of the projects of a client, Client is
+// empty for the sessions billed to no client
+type ClientReport struct {
+	Client        string
+	Projects      []ProjectReport
+	TotalDuration time.Duration
+}
+
 type SessionsReport struct {
 	Sessions []session.Session
+	// Projects are the settings of the projects, needed to group the
+	// sessions by client
+	Projects []project.Project
+	// Journal holds the notes about the days of the report, a day with notes
+	// but no session still gets a day report
+	Journal []journal.Entry
+	// Totals holds the sums of the sessions by project and by client when
+	// they were added one after the other instead of being held in Sessions
+	Totals *ProjectTotals
 }
 
 func NewSessionsReport(sessions []session.Session) SessionsReport {
@@ -40,7 +69,25 @@ func (s SessionsReport) GetByDayReport() []DayReport {
 	dayReports := []DayReport{}
 	sessionsByDay := s.splitSessionsByDay()
 	for day, sessions := range sessionsByDay {
-		dayReports = append(dayReports, DayReport{Day: day, Sessions: sessions, TotalDuration: s.Duration(sessions)})
+		dayReports = append(dayReports, DayReport{
+			Day:           day,
+			Sessions:      sessions,
+			TotalDuration: s.Duration(sessions),
+			Journal:       journal.OfDay(s.Journal, day),
+			Pomodoros:     session.CountPomodoros(sessions),
+		})
+	}
+	for _, entry := range s.Journal {
+		hasDay := slices.ContainsFunc(dayReports, func(dayReport DayReport) bool {
+			return entry.SameDay(dayReport.Day)
+		})
+		if !hasDay {
+			dayReports = append(dayReports, DayReport{
+				Day:      entry.Day,
+				Sessions: []session.Session{},
+				Journal:  journal.OfDay(s.Journal, entry.Day),
+			})
+		}
 	}
 	sort.Slice(dayReports, func(i, j int) bool {
 		return dayReports[i].Day.Before(dayReports[j].Day)
@@ -49,81 +96,64 @@ func (s SessionsReport) GetByDayReport() []DayReport {
 }
 
 func (s SessionsReport) GetByProjectReport() []ProjectReport {
-	projectReports := []ProjectReport{}
-
-	sessionsByProject := s.splitSessionsByProject()
-	for project, sessions := range sessionsByProject {
-		lastSession := sessions[len(sessions)-1]
-		projectReports = append(projectReports, ProjectReport{
-			Project:            project,
-			DurationByTag:      s.durationByTag(sessions),
-			TotalDuration:      s.Duration(sessions),
-			LastSessionEndTime: lastSession.EndTime,
-		})
-	}
-
-	sort.Slice(projectReports, func(i, j int) bool {
-		return projectReports[i].LastSessionEndTime.Before(projectReports[j].LastSessionEndTime)
-	})
-
-	return projectReports
+	return s.totals().ByProject()
 }
 
-func (s SessionsReport) Duration(sessions []session.Session) time.Duration {
-	totalDuration := time.Second * 0
-	for _, session := range sessions {
-		totalDuration += session.Duration()
-	}
-	return totalDuration
+func (s SessionsReport) GetByClientReport() []ClientReport {
+	return s.totals().ByClient()
 }
 
-func (s SessionsReport) splitSessionsByProject() map[string][]session.Session {
-	projectsReport := make(map[string][]session.Session)
-	for _, session := range s.Sessions {
-		projectsReport[session.Project] = append(projectsReport[session.Project], session)
+func (s SessionsReport) totals() *ProjectTotals {
+	if s.Totals != nil {
+		return s.Totals
 	}
 
-	return projectsReport
+	totals := NewProjectTotals(s.Projects)
+	for _, sess := range s.Sessions {
+		totals.Add(sess)
+	}
+	return totals
 }
 
-func (s SessionsReport) splitSessionsByDay() map[time.Time][]session.Session {
-	sessionMap := make(map[time.Time][]session.Session)
-
-	for _, session := range s.Sessions {
-		day := session.StartTime.Truncate(24 * time.Hour)
-		sessionMap[day] = append(sessionMap[day], session)
+// Count is the number of sessions of the report
+func (s SessionsReport) Count() int {
+	if s.Totals != nil {
+		return s.Totals.Count()
 	}
+	return len(s.Sessions)
+}
 
-	return sessionMap
+// TotalDuration is the duration of all the sessions of the report, see
+// Duration
+func (s SessionsReport) TotalDuration() time.Duration {
+	if s.Totals != nil {
+		return s.Totals.Duration()
+	}
+	return s.Duration(s.Sessions)
 }
 
-func (s SessionsReport) findUniqueTags(sessions []session.Session) []string {
-	tags := make(map[string]bool)
-	for _, session := range sessions {
-		for _, tag := range session.Tags {
-			tags[tag] = true
+// Duration is the total duration of the given sessions, the sessions that
+// were never stopped are left out as their duration is unknown
+func (s SessionsReport) Duration(sessions []session.Session) time.Duration {
+	totalDuration := time.Second * 0
+	for _, sess := range sessions {
+		if sess.Status() == session.UnstoppedStatus {
+			continue
 		}
+		totalDuration += sess.Duration()
 	}
-	uniqueTags := make([]string, 0, len(tags))
-	for tag := range tags {
-		uniqueTags = append(uniqueTags, tag)
-	}
-	return uniqueTags
+	return totalDuration
 }
 
-func (s SessionsReport) durationByTag(sessions []session.Session) map[string]time.Duration {
-	tags := s.findUniqueTags(sessions)
+// splitSessionsByDay groups the sessions by the day they started on, in
+// their location
+func (s SessionsReport) splitSessionsByDay() map[time.Time][]session.Session {
+	sessionMap := make(map[time.Time][]session.Session)
 
-	tagsDuration := make(map[string]time.Duration)
-	for _, tag := range tags {
-		tagDuration := time.Second * 0
-		for _, session := range sessions {
-			if session.HasTag(tag) {
-				tagDuration += session.Duration()
-			}
-		}
-		tagsDuration[tag] = tagDuration
+	for _, session := range s.Sessions {
+		day := timerange.StartOf(session.StartTime, timerange.ByDay, time.Monday)
+		sessionMap[day] = append(sessionMap[day], session)
 	}
 
-	return tagsDuration
+	return sessionMap
 }
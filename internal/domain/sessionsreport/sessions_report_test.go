@@ -4,6 +4,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/TristanShz/flow/internal/domain/journal"
+	"github.com/TristanShz/flow/internal/domain/project"
 	"github.com/TristanShz/flow/internal/domain/session"
 	"github.com/TristanShz/flow/internal/domain/sessionsreport"
 	"github.com/matryer/is"
@@ -260,3 +262,192 @@ func TestSessionsReport_Formats(t *testing.T) {
 		})
 	}
 }
+
+func TestSessionsReport_GetByClientReport(t *testing.T) {
+	is := is.New(t)
+
+	report := sessionsreport.SessionsReport{
+		Sessions: []session.Session{
+			{
+				Id:        "1",
+				StartTime: time.Date(2020, 1, 1, 8, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC),
+				Project:   "website",
+				Tags:      []string{"design"},
+			},
+			{
+				Id:        "2",
+				StartTime: time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2020, 1, 1, 13, 0, 0, 0, time.UTC),
+				Project:   "website",
+				Tags:      []string{"design"},
+				Client:    "Globex",
+			},
+			{
+				Id:        "3",
+				StartTime: time.Date(2020, 1, 2, 8, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2020, 1, 2, 9, 0, 0, 0, time.UTC),
+				Project:   "flow",
+				Tags:      []string{},
+			},
+		},
+		Projects: []project.Project{{Name: "website", Client: "Acme"}},
+	}
+
+	is.Equal(report.GetByClientReport(), []sessionsreport.ClientReport{
+		{
+			Client: "Acme",
+			Projects: []sessionsreport.ProjectReport{
+				{
+					Project:            "website",
+					DurationByTag:      map[string]time.Duration{"design": 2 * time.Hour},
+					TotalDuration:      2 * time.Hour,
+					LastSessionEndTime: time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC),
+				},
+			},
+			TotalDuration: 2 * time.Hour,
+		},
+		{
+			Client: "Globex",
+			Projects: []sessionsreport.ProjectReport{
+				{
+					Project:            "website",
+					DurationByTag:      map[string]time.Duration{"design": time.Hour},
+					TotalDuration:      time.Hour,
+					LastSessionEndTime: time.Date(2020, 1, 1, 13, 0, 0, 0, time.UTC),
+				},
+			},
+			TotalDuration: time.Hour,
+		},
+		{
+			Projects: []sessionsreport.ProjectReport{
+				{
+					Project:            "flow",
+					DurationByTag:      map[string]time.Duration{},
+					TotalDuration:      time.Hour,
+					LastSessionEndTime: time.Date(2020, 1, 2, 9, 0, 0, 0, time.UTC),
+				},
+			},
+			TotalDuration: time.Hour,
+		},
+	})
+}
+
+func TestSessionsReport_GetByDayReportInLocation(t *testing.T) {
+	is := is.New(t)
+	paris := time.FixedZone("CEST", 2*60*60)
+
+	// the session starts on the 2nd in Paris, but on the 1st in UTC
+	sess := session.Session{
+		Id:        "1",
+		StartTime: time.Date(2020, 6, 2, 1, 0, 0, 0, paris),
+		EndTime:   time.Date(2020, 6, 2, 2, 0, 0, 0, paris),
+		Project:   "flow",
+	}
+
+	got := sessionsreport.NewSessionsReport([]session.Session{sess}).GetByDayReport()
+
+	is.Equal(got, []sessionsreport.DayReport{
+		{
+			Day:           time.Date(2020, 6, 2, 0, 0, 0, 0, paris),
+			Sessions:      []session.Session{sess},
+			TotalDuration: time.Hour,
+		},
+	})
+}
+
+func TestSessionsReport_GetByDayReportWithJournal(t *testing.T) {
+	is := is.New(t)
+
+	sess := session.Session{
+		Id:        "1",
+		StartTime: time.Date(2020, 6, 2, 9, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2020, 6, 2, 10, 0, 0, 0, time.UTC),
+		Project:   "flow",
+	}
+	release := journal.Entry{Day: time.Date(2020, 6, 2, 0, 0, 0, 0, time.UTC), Note: "shipped the release"}
+	outage := journal.Entry{Day: time.Date(2020, 6, 3, 0, 0, 0, 0, time.UTC), Note: "outage"}
+
+	report := sessionsreport.NewSessionsReport([]session.Session{sess})
+	report.Journal = []journal.Entry{release, outage}
+
+	is.Equal(report.GetByDayReport(), []sessionsreport.DayReport{
+		{
+			Day:           time.Date(2020, 6, 2, 0, 0, 0, 0, time.UTC),
+			Sessions:      []session.Session{sess},
+			TotalDuration: time.Hour,
+			Journal:       []journal.Entry{release},
+		},
+		{
+			Day:      time.Date(2020, 6, 3, 0, 0, 0, 0, time.UTC),
+			Sessions: []session.Session{},
+			Journal:  []journal.Entry{outage},
+		},
+	})
+}
+
+func TestSessionsReport_GetByDayReportWithPomodoros(t *testing.T) {
+	is := is.New(t)
+
+	pomodoro := map[string]string{session.PomodoroMetadata: "25m0s"}
+	completed := session.Session{
+		Id:        "1",
+		StartTime: time.Date(2020, 6, 2, 9, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2020, 6, 2, 9, 25, 0, 0, time.UTC),
+		Project:   "flow",
+		Metadata:  pomodoro,
+	}
+	interrupted := session.Session{
+		Id:        "2",
+		StartTime: time.Date(2020, 6, 2, 9, 30, 0, 0, time.UTC),
+		EndTime:   time.Date(2020, 6, 2, 9, 40, 0, 0, time.UTC),
+		Project:   "flow",
+		Metadata:  pomodoro,
+	}
+
+	report := sessionsreport.NewSessionsReport([]session.Session{completed, interrupted})
+
+	is.Equal(report.GetByDayReport()[0].Pomodoros, 1) // the interrupted pomodoro isn't counted
+}
+
+func TestSessionsReport_Totals(t *testing.T) {
+	is := is.New(t)
+
+	sessions := []session.Session{
+		{
+			Id:        "1",
+			StartTime: time.Date(2020, 1, 1, 8, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC),
+			Project:   "website",
+			Tags:      []string{"design", "design"},
+		},
+		{
+			Id:        "2",
+			StartTime: time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2020, 1, 1, 13, 0, 0, 0, time.UTC),
+			Project:   "flow",
+			Client:    "Globex",
+		},
+		{
+			Id:        "3",
+			StartTime: time.Date(2020, 1, 2, 8, 0, 0, 0, time.UTC),
+			Project:   "website",
+			Tags:      []string{"code"},
+		},
+	}
+	projects := []project.Project{{Name: "website", Client: "Acme"}}
+
+	totals := sessionsreport.NewProjectTotals(projects)
+	for _, sess := range sessions {
+		totals.Add(sess)
+	}
+	streamed := sessionsreport.SessionsReport{Projects: projects, Totals: totals}
+	held := sessionsreport.SessionsReport{Sessions: sessions, Projects: projects}
+
+	is.Equal(streamed.Count(), 3)
+	is.Equal(streamed.TotalDuration(), 3*time.Hour)
+	is.Equal(streamed.TotalDuration(), held.TotalDuration())
+	is.Equal(streamed.GetByProjectReport(), held.GetByProjectReport())
+	is.Equal(streamed.GetByClientReport(), held.GetByClientReport())
+	is.Equal(streamed.GetByProjectReport()[0].DurationByTag["design"], 2*time.Hour)
+}
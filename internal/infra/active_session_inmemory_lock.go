@@ -0,0 +1,25 @@
+package infra
+
+import "github.com/TristanShz/flow/internal/application"
+
+type InMemoryActiveSessionLock struct {
+	Active *application.ActiveSession
+}
+
+func (l *InMemoryActiveSessionLock) Acquire(active application.ActiveSession) (application.ActiveSession, error) {
+	if l.Active != nil {
+		return *l.Active, application.ErrActiveSessionLocked
+	}
+
+	l.Active = &active
+
+	return active, nil
+}
+
+func (l *InMemoryActiveSessionLock) Release(sessionId string) error {
+	if l.Active != nil && l.Active.SessionId == sessionId {
+		l.Active = nil
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package age
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// DefaultBinary is the age command, rage works too as it takes the same
+// flags, see https://age-encryption.org
+const DefaultBinary = "age"
+
+var ErrNoIdentity = errors.New("no identity to decrypt the session, set identity in the [encryption] table of the config")
+
+// Cipher encrypts the session files to several recipients, e.g. a personal
+// key and the escrow key of the team, with the age command. Any of their
+// identities decrypts the files.
+type Cipher struct {
+	Binary     string
+	Recipients []string
+	// Identity is the file of the private key decrypting the files
+	Identity string
+}
+
+func NewCipher(recipients []string, identity string) Cipher {
+	return Cipher{
+		Binary:     DefaultBinary,
+		Recipients: recipients,
+		Identity:   identity,
+	}
+}
+
+// Encrypt returns the plaintext armored, so that the files stay text in the
+// synced folders. Without recipients the plaintext is returned as is, the
+// identity then only decrypts the files, e.g. once the encryption is turned
+// off.
+func (c Cipher) Encrypt(plaintext []byte) ([]byte, error) {
+	if len(c.Recipients) == 0 {
+		return plaintext, nil
+	}
+
+	args := []string{"--encrypt", "--armor"}
+	for _, recipient := range c.Recipients {
+		args = append(args, "--recipient", recipient)
+	}
+
+	return c.run(plaintext, args...)
+}
+
+func (c Cipher) Decrypt(ciphertext []byte) ([]byte, error) {
+	if c.Identity == "" {
+		return nil, ErrNoIdentity
+	}
+
+	return c.run(ciphertext, "--decrypt", "--identity", c.Identity)
+}
+
+func (c Cipher) run(input []byte, args ...string) ([]byte, error) {
+	stderr := &bytes.Buffer{}
+
+	command := exec.Command(c.Binary, args...)
+	command.Stdin = bytes.NewReader(input)
+	command.Stderr = stderr
+
+	output, err := command.Output()
+	if errors.Is(err, exec.ErrNotFound) {
+		return nil, fmt.Errorf("%v isn't installed, see https://age-encryption.org", c.Binary)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w: %v", c.Binary, err, strings.TrimSpace(stderr.String()))
+	}
+
+	return output, nil
+}
@@ -0,0 +1,99 @@
+//go:build !windows
+
+package age_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TristanShz/flow/internal/infra/age"
+	"github.com/matryer/is"
+)
+
+// fakeAge writes a script standing for age, which copies its input and
+// records its arguments
+func fakeAge(t *testing.T) (binary string, argsFile string) {
+	dir := t.TempDir()
+	binary = filepath.Join(dir, "age")
+	argsFile = filepath.Join(dir, "args")
+
+	script := "#!/bin/sh\necho \"$@\" > " + argsFile + "\ncat\n"
+	if err := os.WriteFile(binary, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	return binary, argsFile
+}
+
+func TestCipher(t *testing.T) {
+	tt := []struct {
+		name       string
+		recipients []string
+		identity   string
+		decrypt    bool
+		wantArgs   string
+		wantErr    error
+	}{
+		{
+			name:       "Encrypt to every recipient",
+			recipients: []string{"age1personal", "age1escrow"},
+			wantArgs:   "--encrypt --armor --recipient age1personal --recipient age1escrow",
+		},
+		{
+			name:     "Encrypt without recipients",
+			identity: "/keys/age.key",
+		},
+		{
+			name:       "Decrypt with the identity",
+			recipients: []string{"age1personal"},
+			identity:   "/keys/age.key",
+			decrypt:    true,
+			wantArgs:   "--decrypt --identity /keys/age.key",
+		},
+		{
+			name:       "Decrypt without identity",
+			recipients: []string{"age1personal"},
+			decrypt:    true,
+			wantErr:    age.ErrNoIdentity,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			binary, argsFile := fakeAge(t)
+			cipher := age.NewCipher(tc.recipients, tc.identity)
+			cipher.Binary = binary
+
+			run := cipher.Encrypt
+			if tc.decrypt {
+				run = cipher.Decrypt
+			}
+			output, err := run([]byte(`{"id":"1"}`))
+
+			is.Equal(err, tc.wantErr)
+			if tc.wantErr != nil {
+				return
+			}
+			is.Equal(string(output), `{"id":"1"}`)
+
+			args, _ := os.ReadFile(argsFile)
+			is.Equal(strings.TrimSpace(string(args)), tc.wantArgs)
+		})
+	}
+}
+
+func TestCipher_NotInstalled(t *testing.T) {
+	is := is.New(t)
+
+	cipher := age.NewCipher([]string{"age1personal"}, "")
+	cipher.Binary = "flow-missing-age"
+
+	_, err := cipher.Encrypt([]byte(`{"id":"1"}`))
+
+	is.True(err != nil)
+	is.True(strings.Contains(err.Error(), "isn't installed"))
+}
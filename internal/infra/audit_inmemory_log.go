@@ -0,0 +1,12 @@
+package infra
+
+import "github.com/TristanShz/flow/internal/application"
+
+type InMemoryAuditLog struct {
+	Entries []application.AuditEntry
+}
+
+func (l *InMemoryAuditLog) Record(entry application.AuditEntry) error {
+	l.Entries = append(l.Entries, entry)
+	return nil
+}
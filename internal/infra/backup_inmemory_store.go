@@ -0,0 +1,58 @@
+package infra
+
+import (
+	"os"
+	"slices"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+// InMemoryBackupStore keeps the backups, named after the time they're made
+// at, with the sessions of each one by path
+type InMemoryBackupStore struct {
+	Backups  []application.Backup
+	Sessions map[string][]session.Session
+}
+
+func (s *InMemoryBackupStore) Backup(at time.Time) (application.Backup, error) {
+	backup := application.Backup{
+		Path: "flow-backup-" + at.Format("20060102-150405") + ".tar.gz",
+		Manifest: application.BackupManifest{
+			FormatVersion: application.BackupFormatVersion,
+			CreatedAt:     at,
+		},
+	}
+	s.Backups = append(s.Backups, backup)
+
+	return backup, nil
+}
+
+func (s *InMemoryBackupStore) FindAllBackups() ([]application.Backup, error) {
+	return slices.Clone(s.Backups), nil
+}
+
+func (s *InMemoryBackupStore) RemoveBackup(path string) error {
+	s.Backups = slices.DeleteFunc(s.Backups, func(b application.Backup) bool {
+		return b.Path == path
+	})
+
+	return nil
+}
+
+func (s *InMemoryBackupStore) ReadBackup(path string) (application.BackupManifest, []session.Session, error) {
+	index := slices.IndexFunc(s.Backups, func(b application.Backup) bool {
+		return b.Path == path
+	})
+	if index == -1 {
+		return application.BackupManifest{}, nil, os.ErrNotExist
+	}
+
+	manifest := s.Backups[index].Manifest
+	if manifest.FormatVersion > application.BackupFormatVersion {
+		return manifest, nil, application.ErrUnsupportedBackup
+	}
+
+	return manifest, slices.Clone(s.Sessions[path]), nil
+}
@@ -0,0 +1,38 @@
+package infra
+
+import (
+	"slices"
+
+	"github.com/TristanShz/flow/internal/domain/client"
+)
+
+type InMemoryClientRepository struct {
+	Clients []client.Client
+}
+
+func (r *InMemoryClientRepository) Save(c client.Client) error {
+	clientIndex := slices.IndexFunc(r.Clients, func(existing client.Client) bool {
+		return existing.Name == c.Name
+	})
+
+	if clientIndex == -1 {
+		r.Clients = append(r.Clients, c)
+	} else {
+		r.Clients[clientIndex] = c
+	}
+
+	return nil
+}
+
+func (r *InMemoryClientRepository) FindByName(name string) *client.Client {
+	for _, c := range r.Clients {
+		if c.Name == name {
+			return &c
+		}
+	}
+	return nil
+}
+
+func (r *InMemoryClientRepository) FindAll() []client.Client {
+	return r.Clients
+}
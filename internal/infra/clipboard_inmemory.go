@@ -0,0 +1,18 @@
+package infra
+
+import "github.com/TristanShz/flow/internal/application"
+
+// InMemoryClipboard keeps the last content copied, or returns its error
+type InMemoryClipboard struct {
+	Content application.ClipboardContent
+	Err     error
+}
+
+func (c *InMemoryClipboard) Copy(content application.ClipboardContent) error {
+	if c.Err != nil {
+		return c.Err
+	}
+
+	c.Content = content
+	return nil
+}
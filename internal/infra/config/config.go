@@ -0,0 +1,877 @@
+package config
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/billing"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra/presenter"
+)
+
+// Environment variables override the values of the config file, and flags
+// override both
+const (
+	EnvConfig      = "FLOW_CONFIG"
+	EnvFlowFolder  = "FLOW_FOLDER"
+	EnvDataDir     = "FLOW_DATA_DIR"
+	EnvOutput      = "FLOW_OUTPUT"
+	EnvDefaultTags = "FLOW_DEFAULT_TAGS"
+	EnvWeekStart   = "FLOW_WEEK_START"
+	// EnvTogglAPIToken keeps the token of Toggl Track out of the config file
+	EnvTogglAPIToken = "FLOW_TOGGL_API_TOKEN"
+	EnvJiraAPIToken  = "FLOW_JIRA_API_TOKEN"
+	// EnvPassphrase encrypts the session files, it's never read from the
+	// config file
+	EnvPassphrase = "FLOW_PASSPHRASE"
+	// EnvSandbox is set to the path of the store in the shell of
+	// 'flow sandbox', see application.Config.Sandboxed
+	EnvSandbox = "FLOW_SANDBOX"
+)
+
+// XDG Base Directory variables, see
+// https://specifications.freedesktop.org/basedir-spec/latest/
+const (
+	EnvXDGConfigHome = "XDG_CONFIG_HOME"
+	EnvXDGDataHome   = "XDG_DATA_HOME"
+)
+
+// legacyFlowFolder is the folder of the home directory used before the XDG
+// directories, it's still used when it exists
+const legacyFlowFolder = ".flow"
+
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// Path returns the path of the config file, ~/.config/flow/config.toml on
+// Linux unless FLOW_CONFIG or XDG_CONFIG_HOME is set
+func Path(getenv func(string) string) string {
+	if path := getenv(EnvConfig); path != "" {
+		return path
+	}
+
+	// UserConfigDir only follows XDG_CONFIG_HOME on Linux and BSDs
+	if configHome := getenv(EnvXDGConfigHome); filepath.IsAbs(configHome) {
+		return filepath.Join(configHome, "flow", "config.toml")
+	}
+
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(configDir, "flow", "config.toml")
+}
+
+// Load reads the config file at path, a missing file is an empty config, then
+// applies the environment variables
+func Load(path string, getenv func(string) string) (application.Config, error) {
+	values := map[string]tomlValue{}
+
+	if path != "" {
+		file, err := os.Open(path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return application.Config{}, err
+		}
+
+		if err == nil {
+			defer file.Close()
+			if values, err = parseTOML(file); err != nil {
+				return application.Config{}, fmt.Errorf("invalid config file %v: %w", path, err)
+			}
+		}
+	}
+
+	for env, key := range map[string]string{
+		EnvFlowFolder: "flow_folder",
+		EnvOutput:     "output",
+		EnvWeekStart:  "week_start",
+	} {
+		if value := getenv(env); value != "" {
+			values[key] = tomlValue{String: value}
+		}
+	}
+	// FLOW_DATA_DIR is the newer name of FLOW_FOLDER and wins over it
+	if value := getenv(EnvDataDir); value != "" {
+		values["flow_folder"] = tomlValue{String: value}
+	}
+	if value := getenv(EnvTogglAPIToken); value != "" {
+		values["toggl.api_token"] = tomlValue{String: value}
+	}
+	if value := getenv(EnvJiraAPIToken); value != "" {
+		values["jira.api_token"] = tomlValue{String: value}
+	}
+	if value := getenv(EnvDefaultTags); value != "" {
+		values["default_tags"] = tomlValue{List: strings.Split(value, ","), IsList: true}
+	}
+
+	config, err := newConfig(values)
+	if passphrase := getenv(EnvPassphrase); err == nil && passphrase != "" {
+		if config.Encryption.Enabled() {
+			return application.Config{}, fmt.Errorf("%v encrypts the sessions, they can't be encrypted to the recipients of the config too", EnvPassphrase)
+		}
+		config.Encryption.Passphrase = passphrase
+	}
+
+	var lineErr *lineError
+	if errors.As(err, &lineErr) {
+		return application.Config{}, fmt.Errorf("invalid config file %v: %w", path, err)
+	}
+
+	if sandbox := getenv(EnvSandbox); sandbox != "" {
+		config = config.Sandboxed(sandbox)
+	}
+
+	return config, err
+}
+
+// Parse reads a config file without applying the environment variables, to
+// validate it. The invalid values are reported with their line.
+func Parse(r io.Reader) (application.Config, error) {
+	values, err := parseTOML(r)
+	if err != nil {
+		return application.Config{}, err
+	}
+
+	return newConfig(values)
+}
+
+func newConfig(values map[string]tomlValue) (application.Config, error) {
+	config := application.Config{Directories: map[string]string{}}
+	webhooks := map[string]*application.Webhook{}
+	members := map[string]*application.Member{}
+
+	// the settings are set in the order of the file, so that the first
+	// invalid line is reported
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(a, b string) int {
+		return cmp.Or(values[a].Line-values[b].Line, strings.Compare(a, b))
+	})
+	for _, key := range keys {
+		if err := setValue(&config, webhooks, members, key, values[key]); err != nil {
+			return application.Config{}, atLine(values[key].Line, err)
+		}
+	}
+
+	slices.SortFunc(config.TagRules, func(a, b session.TagRule) int {
+		return strings.Compare(a.Tag, b.Tag)
+	})
+
+	slices.SortFunc(config.TagDeprecations, func(a, b session.TagDeprecation) int {
+		return strings.Compare(a.Tag, b.Tag)
+	})
+	// replacing a tag with a deprecated one would need another retag
+	for _, deprecation := range config.TagDeprecations {
+		if len(session.DeprecatedTags([]string{deprecation.Replacement}, config.TagDeprecations)) > 0 {
+			err := fmt.Errorf("the deprecated tag %v is replaced with the deprecated tag %v", deprecation.Tag, deprecation.Replacement)
+			return application.Config{}, atLine(values["deprecated_tags."+deprecation.Tag].Line, err)
+		}
+	}
+
+	if config.Encryption.KeyFile != "" && config.Encryption.Enabled() {
+		return application.Config{}, atLine(values["encryption.key_file"].Line, fmt.Errorf("the sessions are encrypted either with the key file or to the recipients, not both"))
+	}
+
+	if config.Jira.URL != "" && !strings.HasPrefix(config.Jira.URL, "http://") && !strings.HasPrefix(config.Jira.URL, "https://") {
+		return application.Config{}, atLine(values["jira.url"].Line, fmt.Errorf("the url of jira must be an http(s) url"))
+	}
+
+	for _, webhook := range webhooks {
+		config.Webhooks = append(config.Webhooks, *webhook)
+	}
+	slices.SortFunc(config.Webhooks, func(a, b application.Webhook) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	for _, webhook := range config.Webhooks {
+		if !strings.HasPrefix(webhook.URL, "http://") && !strings.HasPrefix(webhook.URL, "https://") {
+			err := fmt.Errorf("the webhook %v must have an http(s) url", webhook.Name)
+			return application.Config{}, atLine(tableLine(values, "webhooks."+webhook.Name, "url"), err)
+		}
+	}
+
+	for _, member := range members {
+		config.Members = append(config.Members, *member)
+	}
+	slices.SortFunc(config.Members, func(a, b application.Member) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	tokens := map[string]string{}
+	for _, member := range config.Members {
+		if member.Token == "" {
+			err := fmt.Errorf("the member %v must have a token", member.Name)
+			return application.Config{}, atLine(tableLine(values, "members."+member.Name, "token"), err)
+		}
+		if other, ok := tokens[member.Token]; ok {
+			err := fmt.Errorf("the members %v and %v have the same token", other, member.Name)
+			return application.Config{}, atLine(tableLine(values, "members."+member.Name, "token"), err)
+		}
+		tokens[member.Token] = member.Name
+	}
+
+	return config, nil
+}
+
+// lineError is an invalid value of the config file, reported with its line
+// so that it can be fixed before it fails a command
+type lineError struct {
+	line int
+	err  error
+}
+
+func (e *lineError) Error() string {
+	return fmt.Sprintf("line %v: %v", e.line, e.err)
+}
+
+func (e *lineError) Unwrap() error {
+	return e.err
+}
+
+// atLine reports the error at the line of the file, unless the value comes
+// from the environment
+func atLine(line int, err error) error {
+	if line == 0 {
+		return err
+	}
+
+	return &lineError{line: line, err: err}
+}
+
+// tableLine returns the line of a setting of a table, or else of its first
+// setting when it's missing
+func tableLine(values map[string]tomlValue, table string, setting string) int {
+	if value, ok := values[table+"."+setting]; ok {
+		return value.Line
+	}
+
+	line := 0
+	for key, value := range values {
+		if strings.HasPrefix(key, table+".") && (line == 0 || value.Line < line) {
+			line = value.Line
+		}
+	}
+
+	return line
+}
+
+// setValue sets a setting of the config, or of one of its tables
+func setValue(config *application.Config, webhooks map[string]*application.Webhook, members map[string]*application.Member, key string, value tomlValue) error {
+	if directory, ok := strings.CutPrefix(key, "directories."); ok {
+		if value.IsList || value.IsBool {
+			return fmt.Errorf("the project of %v must be a string", directory)
+		}
+		config.Directories[expandHome(directory)] = value.String
+		return nil
+	}
+
+	if tag, ok := strings.CutPrefix(key, "tag_rules."); ok {
+		if value.IsList || value.IsBool {
+			return fmt.Errorf("the rule of the tag %v must be a string", tag)
+		}
+		rule, err := session.ParseTagRule(tag, value.String)
+		if err != nil {
+			return fmt.Errorf("invalid rule for the tag %v: %w", tag, err)
+		}
+		config.TagRules = append(config.TagRules, rule)
+		return nil
+	}
+
+	if tag, ok := strings.CutPrefix(key, "deprecated_tags."); ok {
+		if value.IsList || value.IsBool {
+			return fmt.Errorf("the replacement of the deprecated tag %v must be a string", tag)
+		}
+		config.TagDeprecations = append(config.TagDeprecations, session.TagDeprecation{
+			Tag:         tag,
+			Replacement: strings.TrimPrefix(strings.TrimSpace(value.String), "+"),
+		})
+		return nil
+	}
+
+	if webhook, ok := strings.CutPrefix(key, "webhooks."); ok {
+		return setWebhook(webhooks, webhook, value)
+	}
+
+	if member, ok := strings.CutPrefix(key, "members."); ok {
+		return setMember(members, member, value)
+	}
+
+	if setting, ok := strings.CutPrefix(key, "encryption."); ok {
+		return setEncryption(&config.Encryption, setting, value)
+	}
+
+	if setting, ok := strings.CutPrefix(key, "toggl."); ok {
+		return setToggl(&config.Toggl, setting, value)
+	}
+
+	if setting, ok := strings.CutPrefix(key, "idle."); ok {
+		return setIdle(&config.Idle, setting, value)
+	}
+
+	if setting, ok := strings.CutPrefix(key, "invoice."); ok {
+		return setInvoicing(&config.Invoicing, setting, value)
+	}
+
+	if profile, ok := strings.CutPrefix(key, "billing_profiles."); ok {
+		return setBillingProfile(config, profile, value)
+	}
+
+	if store, ok := strings.CutPrefix(key, "stores."); ok {
+		return setStore(config, store, value)
+	}
+
+	if setting, ok := strings.CutPrefix(key, "rounding."); ok {
+		return setRounding(&config.Rounding, "the rounding", setting, value)
+	}
+
+	if setting, ok := strings.CutPrefix(key, "pomodoro."); ok {
+		return setPomodoro(&config.Pomodoro, setting, value)
+	}
+
+	if setting, ok := strings.CutPrefix(key, "backup."); ok {
+		return setBackups(&config.Backups, setting, value)
+	}
+
+	if setting, ok := strings.CutPrefix(key, "notifications."); ok {
+		return setNotifications(&config.Notifications, setting, value)
+	}
+
+	if setting, ok := strings.CutPrefix(key, "jira."); ok {
+		return setJira(&config.Jira, setting, value)
+	}
+
+	if setting, ok := strings.CutPrefix(key, "git."); ok {
+		return setGit(&config.Git, setting, value)
+	}
+
+	if (key == "default_tags") != value.IsList || value.IsBool {
+		return fmt.Errorf("invalid type for %v", key)
+	}
+
+	switch key {
+	case "flow_folder":
+		config.FlowFolder = expandHome(value.String)
+	case "output":
+		if !presenter.IsOutputValid(value.String) {
+			return fmt.Errorf("invalid output %v. possible values: text, json, plain", value.String)
+		}
+		config.Output = value.String
+	case "week_start":
+		weekday, ok := weekdays[strings.ToLower(value.String)]
+		if !ok {
+			return fmt.Errorf("invalid week start %v, expected a day like monday", value.String)
+		}
+		config.WeekStart = &weekday
+	case "templates_source":
+		config.TemplatesSource = expandHome(value.String)
+	case "calendar":
+		config.Calendar = expandHome(value.String)
+	case "history_lock_months":
+		months, err := strconv.Atoi(value.String)
+		if err != nil || months < 0 {
+			return fmt.Errorf("invalid history_lock_months %v, expected a number of months", value.String)
+		}
+		config.HistoryLockMonths = months
+	case "trash_retention_days":
+		days, err := strconv.Atoi(value.String)
+		if err != nil || days <= 0 {
+			return fmt.Errorf("invalid trash_retention_days %v, expected a number of days", value.String)
+		}
+		config.TrashRetentionDays = days
+	case "undo_history":
+		operations, err := strconv.Atoi(value.String)
+		if err != nil || operations <= 0 {
+			return fmt.Errorf("invalid undo_history %v, expected a number of operations", value.String)
+		}
+		config.UndoHistory = operations
+	case "overlap":
+		if !session.IsOverlapPolicyValid(value.String) {
+			return fmt.Errorf("invalid overlap %v. possible values: %v", value.String, strings.Join(session.OverlapPolicies, ", "))
+		}
+		config.Overlap = value.String
+	case "default_tags":
+		for _, tag := range value.List {
+			if tag = strings.TrimPrefix(strings.TrimSpace(tag), "+"); tag != "" {
+				config.DefaultTags = append(config.DefaultTags, tag)
+			}
+		}
+	default:
+		return fmt.Errorf("unknown setting %v", key)
+	}
+
+	return nil
+}
+
+// setMember sets the token or the projects of a role of a member of the
+// [members.<name>] table
+func setMember(members map[string]*application.Member, key string, value tomlValue) error {
+	dot := strings.LastIndex(key, ".")
+	if dot == -1 {
+		return fmt.Errorf("the member %v must be a table", key)
+	}
+	name, setting := key[:dot], key[dot+1:]
+
+	member, ok := members[name]
+	if !ok {
+		member = &application.Member{Name: name, Roles: map[string][]string{}}
+		members[name] = member
+	}
+
+	if (setting != "token") != value.IsList || value.IsBool {
+		return fmt.Errorf("invalid type for %v of the member %v", setting, name)
+	}
+
+	switch {
+	case setting == "token":
+		member.Token = value.String
+	case slices.Contains(application.Roles, setting):
+		member.Roles[setting] = value.List
+	default:
+		return fmt.Errorf("unknown setting %v of the member %v. possible values: token, %v", setting, name, strings.Join(application.Roles, ", "))
+	}
+
+	return nil
+}
+
+// setWebhook sets a setting of a webhook of the [webhooks.<name>] table
+func setWebhook(webhooks map[string]*application.Webhook, key string, value tomlValue) error {
+	dot := strings.LastIndex(key, ".")
+	if dot == -1 {
+		return fmt.Errorf("the webhook %v must be a table", key)
+	}
+	name, setting := key[:dot], key[dot+1:]
+
+	webhook, ok := webhooks[name]
+	if !ok {
+		webhook = &application.Webhook{Name: name}
+		webhooks[name] = webhook
+	}
+
+	if (setting == "events") != value.IsList || value.IsBool {
+		return fmt.Errorf("invalid type for %v of the webhook %v", setting, name)
+	}
+
+	switch setting {
+	case "url":
+		webhook.URL = value.String
+	case "payload":
+		webhook.Payload = value.String
+	case "events":
+		for _, event := range value.List {
+			if !slices.Contains(application.EventTypes, event) {
+				return fmt.Errorf("invalid event %v of the webhook %v. possible values: %v", event, name, strings.Join(application.EventTypes, ", "))
+			}
+		}
+		webhook.Events = value.List
+	default:
+		return fmt.Errorf("unknown setting %v of the webhook %v", setting, name)
+	}
+
+	return nil
+}
+
+// setEncryption sets a setting of the [encryption] table
+func setEncryption(encryption *application.Encryption, setting string, value tomlValue) error {
+	if (setting == "recipients") != value.IsList || value.IsBool {
+		return fmt.Errorf("invalid type for %v of the encryption", setting)
+	}
+
+	switch setting {
+	case "recipients":
+		for _, recipient := range value.List {
+			if !strings.HasPrefix(recipient, "age1") && !strings.HasPrefix(recipient, "ssh-") {
+				return fmt.Errorf("invalid recipient %v of the encryption, expected an age1... or ssh-... public key", recipient)
+			}
+		}
+		encryption.Recipients = value.List
+	case "identity":
+		encryption.Identity = expandHome(value.String)
+	case "key_file":
+		encryption.KeyFile = expandHome(value.String)
+	default:
+		return fmt.Errorf("unknown setting %v of the encryption", setting)
+	}
+
+	return nil
+}
+
+// setIdle sets a setting of the [idle] table
+func setIdle(idle *application.Idle, setting string, value tomlValue) error {
+	if value.IsList || value.IsBool {
+		return fmt.Errorf("invalid type for %v of idle", setting)
+	}
+
+	switch setting {
+	case "threshold":
+		threshold, err := time.ParseDuration(value.String)
+		if err != nil || threshold <= 0 {
+			return fmt.Errorf("invalid threshold %v of idle, expected a duration like 10m", value.String)
+		}
+		idle.Threshold = threshold
+	case "action":
+		if !slices.Contains(application.IdleActions, value.String) {
+			return fmt.Errorf("invalid action %v of idle. possible values: %v", value.String, strings.Join(application.IdleActions, ", "))
+		}
+		idle.Action = value.String
+	default:
+		return fmt.Errorf("unknown setting %v of idle", setting)
+	}
+
+	return nil
+}
+
+// setBillingProfile sets a setting of a profile of the
+// [billing_profiles.<name>] table
+func setBillingProfile(config *application.Config, key string, value tomlValue) error {
+	dot := strings.LastIndex(key, ".")
+	if dot == -1 {
+		return fmt.Errorf("the billing profile %v must be a table", key)
+	}
+	name, setting := key[:dot], key[dot+1:]
+
+	if config.BillingProfiles == nil {
+		config.BillingProfiles = map[string]billing.Profile{}
+	}
+	profile := config.BillingProfiles[name]
+
+	if value.IsList || value.IsBool {
+		return fmt.Errorf("invalid type for %v of the billing profile %v", setting, name)
+	}
+
+	switch setting {
+	case "increment", "method", "per":
+		if err := setRounding(&profile.Rounding, "the billing profile "+name, setting, value); err != nil {
+			return err
+		}
+	case "minimum", "daily_cap":
+		duration, err := time.ParseDuration(value.String)
+		if err != nil || duration <= 0 {
+			return fmt.Errorf("invalid %v %v of the billing profile %v, expected a duration like 15m", setting, value.String, name)
+		}
+		if setting == "minimum" {
+			profile.Minimum = duration
+		} else {
+			profile.DailyCap = duration
+		}
+	default:
+		return fmt.Errorf("unknown setting %v of the billing profile %v", setting, name)
+	}
+
+	config.BillingProfiles[name] = profile
+
+	return nil
+}
+
+// setStore sets a setting of a [stores.<name>] table
+func setStore(config *application.Config, key string, value tomlValue) error {
+	name, setting, ok := strings.Cut(key, ".")
+	if !ok {
+		return fmt.Errorf("the store %v must be a table", key)
+	}
+	if name == application.LocalStore {
+		return fmt.Errorf("the store %v is the flow folder in use, it can't be configured", name)
+	}
+
+	if setting != "path" {
+		return fmt.Errorf("unknown setting %v of the store %v", setting, name)
+	}
+	if value.IsList || value.IsBool || value.String == "" {
+		return fmt.Errorf("the path of the store %v must be a string", name)
+	}
+
+	if config.Stores == nil {
+		config.Stores = map[string]string{}
+	}
+	config.Stores[name] = expandHome(value.String)
+
+	return nil
+}
+
+// setInvoicing sets a setting of the [invoice] table
+func setInvoicing(invoicing *application.Invoicing, setting string, value tomlValue) error {
+	if value.IsList || value.IsBool {
+		return fmt.Errorf("invalid type for %v of the invoices", setting)
+	}
+
+	switch setting {
+	case "name":
+		invoicing.Issuer.Name = value.String
+	case "address":
+		invoicing.Issuer.Address = value.String
+	case "tax_id":
+		invoicing.Issuer.TaxID = value.String
+	case "currency":
+		invoicing.Currency = value.String
+	case "prefix":
+		invoicing.Prefix = value.String
+	case "due_days":
+		days, err := strconv.Atoi(value.String)
+		if err != nil || days < 0 {
+			return fmt.Errorf("invalid due_days %v of the invoices, expected a number of days", value.String)
+		}
+		invoicing.DueDays = days
+	default:
+		return fmt.Errorf("unknown setting %v of the invoices", setting)
+	}
+
+	return nil
+}
+
+// setRounding sets a setting of the [rounding] table, or of the rounding of
+// another table
+func setRounding(rounding *session.Rounding, table string, setting string, value tomlValue) error {
+	if value.IsList || value.IsBool {
+		return fmt.Errorf("invalid type for %v of %v", setting, table)
+	}
+
+	switch setting {
+	case "increment":
+		increment, err := time.ParseDuration(value.String)
+		if err != nil || increment <= 0 {
+			return fmt.Errorf("invalid increment %v of %v, expected a duration like 15m", value.String, table)
+		}
+		rounding.Increment = increment
+	case "method":
+		if !slices.Contains(session.RoundingMethods, value.String) {
+			return fmt.Errorf("invalid method %v of %v. possible values: %v", value.String, table, strings.Join(session.RoundingMethods, ", "))
+		}
+		rounding.Method = value.String
+	case "per":
+		if !slices.Contains(session.RoundingScopes, value.String) {
+			return fmt.Errorf("invalid per %v of %v. possible values: %v", value.String, table, strings.Join(session.RoundingScopes, ", "))
+		}
+		rounding.Per = value.String
+	default:
+		return fmt.Errorf("unknown setting %v of %v", setting, table)
+	}
+
+	return nil
+}
+
+// setBackups sets a setting of the [backup] table
+func setBackups(backups *application.Backups, setting string, value tomlValue) error {
+	if value.IsList || value.IsBool {
+		return fmt.Errorf("invalid type for %v of the backup", setting)
+	}
+
+	switch setting {
+	case "folder":
+		backups.Folder = expandHome(value.String)
+	case "every":
+		every, err := time.ParseDuration(value.String)
+		if err != nil || every <= 0 {
+			return fmt.Errorf("invalid every %v of the backup, expected a duration like 24h", value.String)
+		}
+		backups.Every = every
+	case "keep":
+		keep, err := strconv.Atoi(value.String)
+		if err != nil || keep <= 0 {
+			return fmt.Errorf("invalid keep %v of the backup, expected a number of backups", value.String)
+		}
+		backups.Keep = keep
+	default:
+		return fmt.Errorf("unknown setting %v of the backup", setting)
+	}
+
+	return nil
+}
+
+// setPomodoro sets a setting of the [pomodoro] table
+func setPomodoro(pomodoro *application.Pomodoro, setting string, value tomlValue) error {
+	if value.IsList || value.IsBool {
+		return fmt.Errorf("invalid type for %v of the pomodoro", setting)
+	}
+
+	if setting == "long_break_every" {
+		every, err := strconv.Atoi(value.String)
+		if err != nil || every <= 0 {
+			return fmt.Errorf("invalid long_break_every %v of the pomodoro, expected a number of pomodoros", value.String)
+		}
+		pomodoro.LongBreakEvery = every
+		return nil
+	}
+
+	lengths := map[string]*time.Duration{
+		"work":        &pomodoro.Work,
+		"short_break": &pomodoro.ShortBreak,
+		"long_break":  &pomodoro.LongBreak,
+	}
+	length, ok := lengths[setting]
+	if !ok {
+		return fmt.Errorf("unknown setting %v of the pomodoro", setting)
+	}
+
+	var err error
+	if *length, err = time.ParseDuration(value.String); err != nil || *length <= 0 {
+		return fmt.Errorf("invalid %v %v of the pomodoro, expected a duration like 25m", setting, value.String)
+	}
+
+	return nil
+}
+
+// setNotifications sets a setting of the [notifications] table
+func setNotifications(notifications *application.Notifications, setting string, value tomlValue) error {
+	if value.IsList || (setting == "pomodoro") != value.IsBool {
+		return fmt.Errorf("invalid type for %v of the notifications", setting)
+	}
+
+	switch setting {
+	case "pomodoro":
+		notifications.MutePomodoro = !value.Bool
+	case "work_hours":
+		rule, err := session.ParseTagRule("", value.String)
+		if err != nil {
+			return fmt.Errorf("invalid work_hours of the notifications: %w", err)
+		}
+		notifications.WorkHours = &rule
+	case "long_session", "no_session_after", "untracked_after":
+		length, err := time.ParseDuration(value.String)
+		if err != nil || length <= 0 {
+			return fmt.Errorf("invalid %v %v of the notifications, expected a duration like 3h", setting, value.String)
+		}
+		switch setting {
+		case "long_session":
+			notifications.LongSession = length
+		case "no_session_after":
+			notifications.NoSessionAfter = length
+		default:
+			notifications.UntrackedAfter = length
+		}
+	default:
+		return fmt.Errorf("unknown setting %v of the notifications", setting)
+	}
+
+	return nil
+}
+
+// setJira sets a setting of the [jira] table
+func setJira(jira *application.Jira, setting string, value tomlValue) error {
+	if (setting == "projects") != value.IsList || (setting == "dry_run") != value.IsBool {
+		return fmt.Errorf("invalid type for %v of jira", setting)
+	}
+
+	switch setting {
+	case "url":
+		jira.URL = strings.TrimSuffix(value.String, "/")
+	case "email":
+		jira.Email = value.String
+	case "api_token":
+		jira.APIToken = value.String
+	case "projects":
+		jira.Projects = value.List
+	case "dry_run":
+		jira.DryRun = value.Bool
+	default:
+		return fmt.Errorf("unknown setting %v of jira", setting)
+	}
+
+	return nil
+}
+
+// setToggl sets a setting of the [toggl] table, or a mapping of its
+// [toggl.projects] and [toggl.tags] tables
+func setToggl(toggl *application.Toggl, setting string, value tomlValue) error {
+	if value.IsList || value.IsBool {
+		return fmt.Errorf("invalid type for %v of toggl", setting)
+	}
+
+	if name, ok := strings.CutPrefix(setting, "projects."); ok {
+		if toggl.Projects == nil {
+			toggl.Projects = map[string]string{}
+		}
+		toggl.Projects[name] = value.String
+		return nil
+	}
+
+	if name, ok := strings.CutPrefix(setting, "tags."); ok {
+		if toggl.Tags == nil {
+			toggl.Tags = map[string]string{}
+		}
+		toggl.Tags[name] = strings.TrimPrefix(value.String, "+")
+		return nil
+	}
+
+	switch setting {
+	case "api_token":
+		toggl.APIToken = value.String
+	case "workspace_id":
+		workspaceID, err := strconv.ParseInt(value.String, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid workspace_id %v of toggl, expected the number in the URLs of the workspace", value.String)
+		}
+		toggl.WorkspaceID = workspaceID
+	default:
+		return fmt.Errorf("unknown setting %v of toggl", setting)
+	}
+
+	return nil
+}
+
+// setGit sets a setting of the [git] table
+func setGit(git *application.Git, setting string, value tomlValue) error {
+	if !value.IsBool {
+		return fmt.Errorf("%v of git must be true or false", setting)
+	}
+
+	switch setting {
+	case "project":
+		git.Project = value.Bool
+	case "branch_tag":
+		git.BranchTag = value.Bool
+	default:
+		return fmt.Errorf("unknown setting %v of git", setting)
+	}
+
+	return nil
+}
+
+// FlowFolder returns where sessions are stored: the configured folder, else
+// ~/.flow when it exists, else the flow folder of XDG_DATA_HOME, else ~/.flow
+func FlowFolder(configured string, home string, getenv func(string) string) string {
+	if configured != "" {
+		return configured
+	}
+
+	legacy := filepath.Join(home, legacyFlowFolder)
+	if _, err := os.Stat(legacy); err == nil {
+		return legacy
+	}
+
+	// relative paths are invalid and must be ignored, as per the spec
+	if dataHome := getenv(EnvXDGDataHome); filepath.IsAbs(dataHome) {
+		return filepath.Join(dataHome, "flow")
+	}
+
+	return legacy
+}
+
+func expandHome(path string) string {
+	rest, ok := strings.CutPrefix(path, "~")
+	if !ok || (rest != "" && !strings.HasPrefix(rest, "/")) {
+		return path
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+
+	return filepath.Join(home, rest)
+}
@@ -0,0 +1,695 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/billing"
+	"github.com/TristanShz/flow/internal/domain/invoice"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra/config"
+	"github.com/matryer/is"
+)
+
+const configFile = `# flow config
+flow_folder = "/data/flow"
+output = "json"
+week_start = "sunday"
+default_tags = ["work", "+deep", ] # trailing comma
+
+[directories]
+"/home/me/code/flow" = "flow"
+"/home/me/code" = "side-projects"
+`
+
+func TestLoad(t *testing.T) {
+	sunday := time.Sunday
+	saturday := time.Saturday
+
+	tt := []struct {
+		name    string
+		file    string
+		env     map[string]string
+		want    application.Config
+		wantErr bool
+	}{
+		{
+			name: "Missing file",
+			want: application.Config{Directories: map[string]string{}},
+		},
+		{
+			name: "Config file",
+			file: configFile,
+			want: application.Config{
+				FlowFolder:  "/data/flow",
+				Output:      "json",
+				WeekStart:   &sunday,
+				DefaultTags: []string{"work", "deep"},
+				Directories: map[string]string{
+					"/home/me/code/flow": "flow",
+					"/home/me/code":      "side-projects",
+				},
+			},
+		},
+		{
+			name: "Environment overrides the file",
+			file: configFile,
+			env: map[string]string{
+				config.EnvFlowFolder:  "/tmp/flow",
+				config.EnvOutput:      "text",
+				config.EnvWeekStart:   "Saturday",
+				config.EnvDefaultTags: "client,meeting",
+			},
+			want: application.Config{
+				FlowFolder:  "/tmp/flow",
+				Output:      "text",
+				WeekStart:   &saturday,
+				DefaultTags: []string{"client", "meeting"},
+				Directories: map[string]string{
+					"/home/me/code/flow": "flow",
+					"/home/me/code":      "side-projects",
+				},
+			},
+		},
+		{
+			name: "Tag rules",
+			file: "[tag_rules]\nweekend = \"sat,sun\"\novertime = \"mon-fri after 19:00\"\n",
+			want: application.Config{
+				Directories: map[string]string{},
+				TagRules: []session.TagRule{
+					{Tag: "overtime", Days: []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}, After: 19 * time.Hour},
+					{Tag: "weekend", Days: []time.Weekday{time.Saturday, time.Sunday}},
+				},
+			},
+		},
+		{
+			name: "Templates source",
+			file: `templates_source = "git@github.com:team/flow-templates.git"`,
+			want: application.Config{
+				Directories:     map[string]string{},
+				TemplatesSource: "git@github.com:team/flow-templates.git",
+			},
+		},
+		{
+			name: "Calendar",
+			file: `calendar = "https://calendar.example.com/work.ics"`,
+			want: application.Config{
+				Directories: map[string]string{},
+				Calendar:    "https://calendar.example.com/work.ics",
+			},
+		},
+		{
+			name: "Overlap",
+			file: `overlap = "adjust"`,
+			want: application.Config{
+				Directories: map[string]string{},
+				Overlap:     session.OverlapAdjust,
+			},
+		},
+		{
+			name:    "Invalid overlap",
+			file:    `overlap = "merge"`,
+			wantErr: true,
+		},
+		{
+			name: "History lock",
+			file: `history_lock_months = "2"`,
+			want: application.Config{
+				Directories:       map[string]string{},
+				HistoryLockMonths: 2,
+			},
+		},
+		{
+			name:    "Invalid history lock",
+			file:    `history_lock_months = "-1"`,
+			wantErr: true,
+		},
+		{
+			name: "Trash retention",
+			file: `trash_retention_days = "7"`,
+			want: application.Config{
+				Directories:        map[string]string{},
+				TrashRetentionDays: 7,
+			},
+		},
+		{
+			name:    "Invalid trash retention",
+			file:    `trash_retention_days = "0"`,
+			wantErr: true,
+		},
+		{
+			name: "Undo history",
+			file: `undo_history = "50"`,
+			want: application.Config{
+				Directories: map[string]string{},
+				UndoHistory: 50,
+			},
+		},
+		{
+			name:    "Invalid undo history",
+			file:    `undo_history = "none"`,
+			wantErr: true,
+		},
+		{
+			name: "Webhooks",
+			file: `[webhooks.slack]
+url = "https://hooks.slack.com/services/T000/B000/XXXX"
+events = ["session.started", "session.stopped"]
+payload = '{"text": "{{.Event}} {{json .Session.Project}}"}' # literal string
+
+[webhooks.home]
+url = "http://192.168.1.10:8123/api/webhook/flow"
+`,
+			want: application.Config{
+				Directories: map[string]string{},
+				Webhooks: []application.Webhook{
+					{Name: "home", URL: "http://192.168.1.10:8123/api/webhook/flow"},
+					{
+						Name:    "slack",
+						URL:     "https://hooks.slack.com/services/T000/B000/XXXX",
+						Events:  []string{application.EventSessionStarted, application.EventSessionStopped},
+						Payload: `{"text": "{{.Event}} {{json .Session.Project}}"}`,
+					},
+				},
+			},
+		},
+		{
+			name:    "Webhook without url",
+			file:    "[webhooks.slack]\nevents = [\"session.started\"]\n",
+			wantErr: true,
+		},
+		{
+			name:    "Webhook with an unknown event",
+			file:    "[webhooks.slack]\nurl = \"https://example.com\"\nevents = [\"session.deleted\"]\n",
+			wantErr: true,
+		},
+		{
+			name:    "Webhook with an unknown setting",
+			file:    "[webhooks.slack]\nurl = \"https://example.com\"\nmethod = \"PUT\"\n",
+			wantErr: true,
+		},
+		{
+			name: "Encryption",
+			file: `[encryption]
+recipients = ["age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p", "age1lggyhqrw2nlhcxprm67z43rta597azn8gknawjehu9d9dl0jq3yqqvfafg"]
+identity = "/home/tristan/.config/flow/age.key"
+`,
+			want: application.Config{
+				Directories: map[string]string{},
+				Encryption: application.Encryption{
+					Recipients: []string{
+						"age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p",
+						"age1lggyhqrw2nlhcxprm67z43rta597azn8gknawjehu9d9dl0jq3yqqvfafg",
+					},
+					Identity: "/home/tristan/.config/flow/age.key",
+				},
+			},
+		},
+		{
+			name: "Encryption with a key file",
+			file: "[encryption]\nkey_file = \"/home/tristan/.config/flow/flow.key\"\n",
+			want: application.Config{
+				Directories: map[string]string{},
+				Encryption:  application.Encryption{KeyFile: "/home/tristan/.config/flow/flow.key"},
+			},
+		},
+		{
+			name: "Encryption with a passphrase",
+			env:  map[string]string{config.EnvPassphrase: "correct horse battery staple"},
+			want: application.Config{
+				Directories: map[string]string{},
+				Encryption:  application.Encryption{Passphrase: "correct horse battery staple"},
+			},
+		},
+		{
+			name:    "Encryption with a key file and recipients",
+			file:    "[encryption]\nrecipients = [\"age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p\"]\nkey_file = \"/home/tristan/.config/flow/flow.key\"\n",
+			wantErr: true,
+		},
+		{
+			name:    "Encryption with a passphrase and recipients",
+			file:    "[encryption]\nrecipients = [\"age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p\"]\n",
+			env:     map[string]string{config.EnvPassphrase: "correct horse battery staple"},
+			wantErr: true,
+		},
+		{
+			name:    "Encryption with an invalid recipient",
+			file:    "[encryption]\nrecipients = [\"tristan@example.com\"]\n",
+			wantErr: true,
+		},
+		{
+			name: "Git",
+			file: "[git]\nproject = true\nbranch_tag = false\n",
+			want: application.Config{
+				Directories: map[string]string{},
+				Git:         application.Git{Project: true},
+			},
+		},
+		{
+			name:    "Git setting not a boolean",
+			file:    "[git]\nproject = \"yes\"\n",
+			wantErr: true,
+		},
+		{
+			name:    "Boolean instead of a string",
+			file:    "output = true",
+			wantErr: true,
+		},
+		{
+			name: "Deprecated tags",
+			file: "[deprecated_tags]\nwip = \"in-progress\"\ntodo = \"\"\n",
+			want: application.Config{
+				Directories: map[string]string{},
+				TagDeprecations: []session.TagDeprecation{
+					{Tag: "todo"},
+					{Tag: "wip", Replacement: "in-progress"},
+				},
+			},
+		},
+		{
+			name:    "Deprecated tag replaced with a deprecated tag",
+			file:    "[deprecated_tags]\nwip = \"todo\"\ntodo = \"backlog\"\n",
+			wantErr: true,
+		},
+		{
+			name: "Toggl",
+			file: "[toggl]\nworkspace_id = \"1234\"\n\n[toggl.projects]\n\"Flow CLI\" = \"flow\"\n\n[toggl.tags]\nbillable = \"\"\n",
+			env:  map[string]string{config.EnvTogglAPIToken: "token"},
+			want: application.Config{
+				Directories: map[string]string{},
+				Toggl: application.Toggl{
+					APIToken:    "token",
+					WorkspaceID: 1234,
+					Projects:    map[string]string{"Flow CLI": "flow"},
+					Tags:        map[string]string{"billable": ""},
+				},
+			},
+		},
+		{
+			name: "Idle",
+			file: "[idle]\nthreshold = \"10m\"\naction = \"break\"\n",
+			want: application.Config{
+				Directories: map[string]string{},
+				Idle:        application.Idle{Threshold: 10 * time.Minute, Action: application.IdleActionBreak},
+			},
+		},
+		{
+			name:    "Invalid idle action",
+			file:    "[idle]\nthreshold = \"10m\"\naction = \"delete\"\n",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid idle threshold",
+			file:    "[idle]\nthreshold = \"ten minutes\"\n",
+			wantErr: true,
+		},
+		{
+			name: "Backup",
+			file: "[backup]\nfolder = \"/mnt/backups/flow\"\nevery = \"24h\"\nkeep = \"30\"\n",
+			want: application.Config{
+				Directories: map[string]string{},
+				Backups:     application.Backups{Folder: "/mnt/backups/flow", Every: 24 * time.Hour, Keep: 30},
+			},
+		},
+		{
+			name:    "Invalid backup keep",
+			file:    "[backup]\nkeep = \"all\"\n",
+			wantErr: true,
+		},
+		{
+			name: "Invoice",
+			file: "[invoice]\nname = \"Jane Doe\"\naddress = \"1 Main Street, Springfield\"\ntax_id = \"FR123\"\ncurrency = \"EUR\"\nprefix = \"INV-\"\ndue_days = \"30\"\n",
+			want: application.Config{
+				Directories: map[string]string{},
+				Invoicing: application.Invoicing{
+					Issuer:   invoice.Issuer{Name: "Jane Doe", Address: "1 Main Street, Springfield", TaxID: "FR123"},
+					Currency: "EUR",
+					Prefix:   "INV-",
+					DueDays:  30,
+				},
+			},
+		},
+		{
+			name:    "Invalid invoice due days",
+			file:    "[invoice]\ndue_days = \"a month\"\n",
+			wantErr: true,
+		},
+		{
+			name: "Billing profiles",
+			file: "[billing_profiles.retainer]\nincrement = \"30m\"\nmethod = \"up\"\ndaily_cap = \"8h\"\n\n[billing_profiles.support]\nminimum = \"15m\"\n",
+			want: application.Config{
+				Directories: map[string]string{},
+				BillingProfiles: map[string]billing.Profile{
+					"retainer": {Rounding: session.Rounding{Increment: 30 * time.Minute, Method: session.RoundUp}, DailyCap: 8 * time.Hour},
+					"support":  {Minimum: 15 * time.Minute},
+				},
+			},
+		},
+		{
+			name:    "Invalid billing profile cap",
+			file:    "[billing_profiles.retainer]\ndaily_cap = \"a day\"\n",
+			wantErr: true,
+		},
+		{
+			name: "Stores",
+			file: "[stores.work]\npath = \"/mnt/work/.flow\"\n",
+			want: application.Config{
+				Directories: map[string]string{},
+				Stores:      map[string]string{"work": "/mnt/work/.flow"},
+			},
+		},
+		{
+			name:    "Local store",
+			file:    "[stores.local]\npath = \"/mnt/work/.flow\"\n",
+			wantErr: true,
+		},
+		{
+			name: "Rounding",
+			file: "[rounding]\nincrement = \"15m\"\nmethod = \"up\"\nper = \"day\"\n",
+			want: application.Config{
+				Directories: map[string]string{},
+				Rounding:    session.Rounding{Increment: 15 * time.Minute, Method: session.RoundUp, Per: session.RoundPerDay},
+			},
+		},
+		{
+			name:    "Invalid rounding method",
+			file:    "[rounding]\nincrement = \"15m\"\nmethod = \"down\"\n",
+			wantErr: true,
+		},
+		{
+			name: "Jira",
+			file: "[jira]\nurl = \"https://acme.atlassian.net/\"\nemail = \"me@acme.com\"\nprojects = [\"flow\", \"acme-website\"]\ndry_run = true\n",
+			env:  map[string]string{config.EnvJiraAPIToken: "token"},
+			want: application.Config{
+				Directories: map[string]string{},
+				Jira: application.Jira{
+					URL:      "https://acme.atlassian.net",
+					Email:    "me@acme.com",
+					APIToken: "token",
+					Projects: []string{"flow", "acme-website"},
+					DryRun:   true,
+				},
+			},
+		},
+		{
+			name: "Sandbox",
+			file: "[jira]\nurl = \"https://acme.atlassian.net/\"\nprojects = [\"flow\"]\n\n[webhooks.slack]\nurl = \"https://hooks.slack.com/services/T000/B000/XXXX\"\n\n[backup]\nfolder = \"/home/tristan/flow-backups\"\nevery = \"24h\"\n",
+			env:  map[string]string{config.EnvJiraAPIToken: "token", config.EnvSandbox: "/tmp/flow-sandbox-1"},
+			want: application.Config{
+				Directories: map[string]string{},
+				Jira: application.Jira{
+					URL:      "https://acme.atlassian.net",
+					APIToken: "token",
+					Projects: []string{"flow"},
+					DryRun:   true,
+				},
+				Backups: application.Backups{Folder: filepath.Join("/tmp/flow-sandbox-1", application.SandboxBackupFolder)},
+				Sandbox: true,
+			},
+		},
+		{
+			name:    "Invalid Jira url",
+			file:    "[jira]\nurl = \"acme.atlassian.net\"\n",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid Jira projects",
+			file:    "[jira]\nprojects = \"flow\"\n",
+			wantErr: true,
+		},
+		{
+			name: "Members",
+			file: "[members.bob]\ntoken = \"b0b\"\nviewer = [\"*\"]\n\n[members.alice]\ntoken = \"a1ice\"\ntracker = [\"flow\"]\napprover = [\"flow\", \"acme-website\"]\n",
+			want: application.Config{
+				Directories: map[string]string{},
+				Members: []application.Member{
+					{Name: "alice", Token: "a1ice", Roles: map[string][]string{"tracker": {"flow"}, "approver": {"flow", "acme-website"}}},
+					{Name: "bob", Token: "b0b", Roles: map[string][]string{"viewer": {"*"}}},
+				},
+			},
+		},
+		{
+			name:    "Member without a token",
+			file:    "[members.alice]\ntracker = [\"flow\"]\n",
+			wantErr: true,
+		},
+		{
+			name:    "Members with the same token",
+			file:    "[members.alice]\ntoken = \"secret\"\n\n[members.bob]\ntoken = \"secret\"\n",
+			wantErr: true,
+		},
+		{
+			name:    "Unknown role",
+			file:    "[members.alice]\ntoken = \"secret\"\nowner = [\"flow\"]\n",
+			wantErr: true,
+		},
+		{
+			name: "Pomodoro",
+			file: "[pomodoro]\nwork = \"50m\"\nshort_break = \"10m\"\nlong_break_every = \"3\"\n",
+			want: application.Config{
+				Directories: map[string]string{},
+				Pomodoro:    application.Pomodoro{Work: 50 * time.Minute, ShortBreak: 10 * time.Minute, LongBreakEvery: 3},
+			},
+		},
+		{
+			name:    "Invalid pomodoro length",
+			file:    "[pomodoro]\nwork = \"25\"\n",
+			wantErr: true,
+		},
+		{
+			name: "Notifications",
+			file: "[notifications]\nlong_session = \"3h\"\nwork_hours = \"mon-fri after 09:00 before 18:00\"\nuntracked_after = \"4h\"\npomodoro = false\n",
+			want: application.Config{
+				Directories: map[string]string{},
+				Notifications: application.Notifications{
+					LongSession: 3 * time.Hour,
+					WorkHours: &session.TagRule{
+						Days:   []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+						After:  9 * time.Hour,
+						Before: 18 * time.Hour,
+					},
+					UntrackedAfter: 4 * time.Hour,
+					MutePomodoro:   true,
+				},
+			},
+		},
+		{
+			name:    "Invalid work hours",
+			file:    "[notifications]\nwork_hours = \"weekdays\"\n",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid type of the pomodoro notifications",
+			file:    "[notifications]\npomodoro = \"off\"\n",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid Toggl workspace",
+			file:    "[toggl]\nworkspace_id = \"acme\"\n",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid tag rule",
+			file:    "[tag_rules]\nweekend = \"saturday\"\n",
+			wantErr: true,
+		},
+		{
+			name: "Data dir overrides the flow folder",
+			file: `flow_folder = "/data/flow"`,
+			env: map[string]string{
+				config.EnvFlowFolder: "/tmp/flow",
+				config.EnvDataDir:    "/mnt/drive/flow",
+			},
+			want: application.Config{
+				FlowFolder:  "/mnt/drive/flow",
+				Directories: map[string]string{},
+			},
+		},
+		{
+			name:    "Invalid week start",
+			file:    `week_start = "someday"`,
+			wantErr: true,
+		},
+		{
+			name:    "Invalid output",
+			env:     map[string]string{config.EnvOutput: "xml"},
+			wantErr: true,
+		},
+		{
+			name:    "Unknown setting",
+			file:    `editor = "vim"`,
+			wantErr: true,
+		},
+		{
+			name:    "Invalid type",
+			file:    `default_tags = "work"`,
+			wantErr: true,
+		},
+		{
+			name:    "Invalid syntax",
+			file:    `output = json`,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			path := filepath.Join(t.TempDir(), "config.toml")
+			if tc.file != "" {
+				is.NoErr(os.WriteFile(path, []byte(tc.file), 0644))
+			}
+
+			got, err := config.Load(path, func(key string) string { return tc.env[key] })
+
+			if tc.wantErr {
+				is.True(err != nil)
+				return
+			}
+
+			is.NoErr(err)
+			is.Equal(got, tc.want)
+		})
+	}
+}
+
+func TestParse_InvalidLines(t *testing.T) {
+	tt := []struct {
+		name    string
+		file    string
+		wantErr string
+	}{
+		{
+			name:    "Invalid value",
+			file:    "output = \"json\"\nweek_start = \"someday\"\n",
+			wantErr: "line 2: invalid week start someday, expected a day like monday",
+		},
+		{
+			name:    "First invalid line",
+			file:    "[idle]\nthreshold = \"soon\"\naction = \"nothing\"\n",
+			wantErr: "line 2: invalid threshold soon of idle, expected a duration like 10m",
+		},
+		{
+			name:    "Missing setting of a table",
+			file:    "output = \"json\"\n\n[webhooks.chat]\nevents = [\"session.started\"]\n",
+			wantErr: "line 4: the webhook chat must have an http(s) url",
+		},
+		{
+			name:    "Invalid syntax",
+			file:    "output = json\n",
+			wantErr: "line 1: invalid string json",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			_, err := config.Parse(strings.NewReader(tc.file))
+
+			is.True(err != nil)
+			is.Equal(err.Error(), tc.wantErr)
+		})
+	}
+}
+
+func TestConfig_ProjectOf(t *testing.T) {
+	is := is.New(t)
+
+	c := application.Config{Directories: map[string]string{
+		"/home/me/code/flow": "flow",
+		"/home/me/code":      "side-projects",
+	}}
+
+	is.Equal(c.ProjectOf("/home/me/code/flow/cmd"), "flow")
+	is.Equal(c.ProjectOf("/home/me/code/todo"), "side-projects")
+	is.Equal(c.ProjectOf("/home/me/code-backup"), "")
+	is.Equal(c.ProjectOf("/home/me"), "")
+}
+
+func TestPath(t *testing.T) {
+	tt := []struct {
+		name string
+		env  map[string]string
+		want string
+	}{
+		{
+			name: "Flow config",
+			env:  map[string]string{config.EnvConfig: "/etc/flow.toml", config.EnvXDGConfigHome: "/home/me/.xdg"},
+			want: "/etc/flow.toml",
+		},
+		{
+			name: "XDG config home",
+			env:  map[string]string{config.EnvXDGConfigHome: "/home/me/.xdg"},
+			want: filepath.Join("/home/me/.xdg", "flow", "config.toml"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			is.Equal(config.Path(func(key string) string { return tc.env[key] }), tc.want)
+		})
+	}
+}
+
+func TestFlowFolder(t *testing.T) {
+	tt := []struct {
+		name         string
+		configured   string
+		env          map[string]string
+		legacyExists bool
+		want         string
+	}{
+		{
+			name:       "Configured",
+			configured: "/mnt/drive/flow",
+			env:        map[string]string{config.EnvXDGDataHome: "/home/me/.local/share"},
+			want:       "/mnt/drive/flow",
+		},
+		{
+			name:         "Existing home folder",
+			env:          map[string]string{config.EnvXDGDataHome: "/home/me/.local/share"},
+			legacyExists: true,
+			want:         ".flow",
+		},
+		{
+			name: "XDG data home",
+			env:  map[string]string{config.EnvXDGDataHome: "/home/me/.local/share"},
+			want: filepath.Join("/home/me/.local/share", "flow"),
+		},
+		{
+			name: "Relative XDG data home",
+			env:  map[string]string{config.EnvXDGDataHome: "share"},
+			want: ".flow",
+		},
+		{
+			name: "Default",
+			want: ".flow",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			home := t.TempDir()
+			if tc.legacyExists {
+				is.NoErr(os.Mkdir(filepath.Join(home, ".flow"), 0755))
+			}
+
+			want := tc.want
+			if want == ".flow" {
+				want = filepath.Join(home, ".flow")
+			}
+
+			is.Equal(config.FlowFolder(tc.configured, home, func(key string) string { return tc.env[key] }), want)
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// tomlValue is either a string, a boolean or a list of strings, the only
+// values of the config file
+type tomlValue struct {
+	List   []string
+	String string
+	Bool   bool
+	IsList bool
+	IsBool bool
+	// Line is the line of the value in the file, 0 for the values of the
+	// environment
+	Line int
+}
+
+// parseTOML reads the subset of TOML used by the config file: comments,
+// tables, bare or quoted keys, strings, booleans and arrays of strings on a
+// single line. Keys of tables are prefixed with the table name and a dot.
+func parseTOML(r io.Reader) (map[string]tomlValue, error) {
+	values := map[string]tomlValue{}
+	table := ""
+
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(stripComment(scanner.Text()))
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("line %v: invalid table %v", lineNumber, line)
+			}
+			table = strings.TrimSpace(line[1 : len(line)-1])
+			continue
+		}
+
+		rawKey, rawValue, found := strings.Cut(line, "=")
+		if !found {
+			return nil, fmt.Errorf("line %v: expected key = value", lineNumber)
+		}
+
+		key, err := parseKey(strings.TrimSpace(rawKey))
+		if err != nil {
+			return nil, fmt.Errorf("line %v: %w", lineNumber, err)
+		}
+
+		value, err := parseValue(strings.TrimSpace(rawValue))
+		if err != nil {
+			return nil, fmt.Errorf("line %v: %w", lineNumber, err)
+		}
+
+		if table != "" {
+			key = table + "." + key
+		}
+		value.Line = lineNumber
+		values[key] = value
+	}
+
+	return values, scanner.Err()
+}
+
+// stripComment removes a comment, unless the # is inside a string
+func stripComment(line string) string {
+	var quote rune
+	for i, c := range line {
+		switch {
+		case quote == 0 && (c == '"' || c == '\''):
+			quote = c
+		case c == quote && (c == '\'' || line[i-1] != '\\'):
+			quote = 0
+		case c == '#' && quote == 0:
+			return line[:i]
+		}
+	}
+
+	return line
+}
+
+// splitArray splits the items of an array on the commas outside of strings
+func splitArray(items string) []string {
+	split := []string{}
+	var quote rune
+	start := 0
+
+	for i, c := range items {
+		switch {
+		case quote == 0 && (c == '"' || c == '\''):
+			quote = c
+		case c == quote && (c == '\'' || items[i-1] != '\\'):
+			quote = 0
+		case c == ',' && quote == 0:
+			split = append(split, items[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(split, items[start:])
+}
+
+func parseKey(key string) (string, error) {
+	if strings.HasPrefix(key, `"`) {
+		return strconv.Unquote(key)
+	}
+
+	if key == "" || strings.ContainsAny(key, " \t\"'") {
+		return "", fmt.Errorf("invalid key %v", key)
+	}
+
+	return key, nil
+}
+
+func parseValue(value string) (tomlValue, error) {
+	if strings.HasPrefix(value, "[") {
+		if !strings.HasSuffix(value, "]") {
+			return tomlValue{}, fmt.Errorf("invalid array %v", value)
+		}
+
+		list := []string{}
+		for _, item := range splitArray(value[1 : len(value)-1]) {
+			item = strings.TrimSpace(item)
+			// arrays can end with a comma
+			if item == "" {
+				continue
+			}
+
+			unquoted, err := unquote(item)
+			if err != nil {
+				return tomlValue{}, err
+			}
+			list = append(list, unquoted)
+		}
+
+		return tomlValue{List: list, IsList: true}, nil
+	}
+
+	if value == "true" || value == "false" {
+		return tomlValue{Bool: value == "true", IsBool: true}, nil
+	}
+
+	unquoted, err := unquote(value)
+	if err != nil {
+		return tomlValue{}, err
+	}
+
+	return tomlValue{String: unquoted}, nil
+}
+
+// unquote reads a basic string, with escapes, or a literal string between
+// single quotes, without escapes, which suits the JSON payloads of webhooks
+func unquote(value string) (string, error) {
+	if literal, ok := strings.CutPrefix(value, "'"); ok {
+		if literal, ok = strings.CutSuffix(literal, "'"); ok && !strings.Contains(literal, "'") {
+			return literal, nil
+		}
+		return "", fmt.Errorf("invalid string %v", value)
+	}
+
+	unquoted, err := strconv.Unquote(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid string %v", value)
+	}
+
+	return unquoted, nil
+}
@@ -0,0 +1,30 @@
+package infra
+
+import (
+	"slices"
+	"time"
+
+	"github.com/TristanShz/flow/pkg/timerange"
+)
+
+// InMemoryDailyTotalsCache keeps the totals of a single time range, Saves
+// counts the times they were cached
+type InMemoryDailyTotalsCache struct {
+	TimeRange timerange.TimeRange
+	Totals    []time.Duration
+	Saves     int
+}
+
+func (c *InMemoryDailyTotalsCache) FindDailyTotals(timeRange timerange.TimeRange) ([]time.Duration, bool) {
+	if c.Totals == nil || !c.TimeRange.Since.Equal(timeRange.Since) || !c.TimeRange.Until.Equal(timeRange.Until) {
+		return nil, false
+	}
+
+	return slices.Clone(c.Totals), true
+}
+
+func (c *InMemoryDailyTotalsCache) SaveDailyTotals(timeRange timerange.TimeRange, totals []time.Duration) {
+	c.TimeRange = timeRange
+	c.Totals = slices.Clone(totals)
+	c.Saves++
+}
@@ -0,0 +1,11 @@
+package infra
+
+import "github.com/TristanShz/flow/internal/application"
+
+type InMemoryEventPublisher struct {
+	Events []application.Event
+}
+
+func (p *InMemoryEventPublisher) Publish(event application.Event) {
+	p.Events = append(p.Events, event)
+}
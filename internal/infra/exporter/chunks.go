@@ -0,0 +1,50 @@
+package exporter
+
+import (
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+// eachOf gives the sessions one after the other, like the repositories give
+// them to a SessionsStreamExporter
+func eachOf(sessions []session.Session) func(fn func(session.Session) error) error {
+	return func(fn func(session.Session) error) error {
+		for _, s := range sessions {
+			if err := fn(s); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// eachChunkRow encodes the sessions given by forEach and gives each row the index of the chunk
+// it belongs to. A new chunk starts when the row would make the current one,
+// header and footer included, exceed maxChunkSize. A row bigger than
+// maxChunkSize still gets a chunk of its own, and maxChunkSize <= 0 disables
+// chunking.
+func eachChunkRow(encoder Encoder, forEach func(fn func(session.Session) error) error, maxChunkSize int64, onRow func(chunk int, row []byte) error) error {
+	fixedSize := int64(len(encoder.Header()) + len(encoder.Footer()))
+
+	chunk := 0
+	chunkSize := fixedSize
+	chunkRows := 0
+
+	return forEach(func(s session.Session) error {
+		row, err := encoder.Encode(s)
+		if err != nil {
+			return err
+		}
+
+		if maxChunkSize > 0 && chunkRows > 0 && chunkSize+int64(len(row)) > maxChunkSize {
+			chunk++
+			chunkSize = fixedSize
+			chunkRows = 0
+		}
+
+		chunkSize += int64(len(row))
+		chunkRows++
+
+		return onRow(chunk, row)
+	})
+}
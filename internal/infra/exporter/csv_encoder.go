@@ -0,0 +1,53 @@
+package exporter
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+var csvColumns = []string{"id", "project", "tags", "start_time", "end_time", "duration_seconds", "note"}
+
+type CSVEncoder struct{}
+
+func (e CSVEncoder) Extension() string {
+	return ".csv"
+}
+
+func (e CSVEncoder) Header() []byte {
+	return e.encodeRecord(csvColumns)
+}
+
+func (e CSVEncoder) Encode(s session.Session) ([]byte, error) {
+	endTime := ""
+	if !s.EndTime.IsZero() {
+		endTime = s.EndTime.Format(time.RFC3339)
+	}
+
+	return e.encodeRecord([]string{
+		s.Id,
+		s.Project,
+		strings.Join(s.Tags, ";"),
+		s.StartTime.Format(time.RFC3339),
+		endTime,
+		strconv.FormatInt(int64(s.Duration().Seconds()), 10),
+		s.Note,
+	}), nil
+}
+
+func (e CSVEncoder) Footer() []byte {
+	return nil
+}
+
+func (e CSVEncoder) encodeRecord(record []string) []byte {
+	buf := new(bytes.Buffer)
+	w := csv.NewWriter(buf)
+	w.Write(record)
+	w.Flush()
+
+	return buf.Bytes()
+}
@@ -0,0 +1,45 @@
+package exporter
+
+import (
+	"fmt"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra/toggl"
+)
+
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+	FormatICS   = "ics"
+	// FormatToggl is the CSV file of the import of Toggl Track
+	FormatToggl = "toggl"
+	// FormatHTML isn't made of rows, see HTMLExporter
+	FormatHTML = "html"
+)
+
+var Formats = []string{FormatCSV, FormatJSONL, FormatICS, FormatToggl, FormatHTML}
+
+// Encoder turns sessions into the rows of an export file. Header and Footer
+// are written at the start and the end of every file, so that each chunk of
+// a large export is a valid file on its own.
+type Encoder interface {
+	Extension() string
+	Header() []byte
+	Encode(s session.Session) ([]byte, error)
+	Footer() []byte
+}
+
+func NewEncoder(format string) (Encoder, error) {
+	switch format {
+	case FormatCSV:
+		return CSVEncoder{}, nil
+	case FormatJSONL:
+		return JSONLEncoder{}, nil
+	case FormatICS:
+		return ICSEncoder{}, nil
+	case FormatToggl:
+		return toggl.CSVEncoder{}, nil
+	}
+
+	return nil, fmt.Errorf("invalid export format %v. possible values: %v", format, Formats)
+}
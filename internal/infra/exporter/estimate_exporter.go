@@ -0,0 +1,37 @@
+package exporter
+
+import (
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+// EstimateExporter computes the size of an export without writing anything
+type EstimateExporter struct {
+	Encoder      Encoder
+	MaxChunkSize int64
+	Rows         int
+	Bytes        int64
+	Files        int
+}
+
+func (e *EstimateExporter) Export(sessions []session.Session) error {
+	return e.ExportEach(eachOf(sessions))
+}
+
+// ExportEach sums the size of the rows as the sessions are given
+func (e *EstimateExporter) ExportEach(forEach func(fn func(session.Session) error) error) error {
+	fixedSize := int64(len(e.Encoder.Header()) + len(e.Encoder.Footer()))
+
+	e.Rows = 0
+	e.Files = 1
+	e.Bytes = fixedSize
+
+	return eachChunkRow(e.Encoder, forEach, e.MaxChunkSize, func(chunk int, row []byte) error {
+		if chunk+1 > e.Files {
+			e.Files = chunk + 1
+			e.Bytes += fixedSize
+		}
+		e.Bytes += int64(len(row))
+		e.Rows++
+		return nil
+	})
+}
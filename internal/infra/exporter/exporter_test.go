@@ -0,0 +1,271 @@
+package exporter_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"html"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/journal"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra/exporter"
+	"github.com/TristanShz/flow/pkg/passwordcrypt"
+	"github.com/matryer/is"
+)
+
+var sessions = []session.Session{
+	{
+		Id:        "1",
+		StartTime: time.Date(2024, 4, 17, 9, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, 4, 17, 10, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+		Tags:      []string{"export", "csv"},
+		Note:      "Exported, with a comma",
+	},
+	{
+		Id:        "2",
+		StartTime: time.Date(2024, 4, 17, 11, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, 4, 17, 11, 30, 0, 0, time.UTC),
+		Project:   "my-project",
+	},
+	{
+		Id:        "3",
+		StartTime: time.Date(2024, 4, 17, 14, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+	},
+}
+
+func TestWriterExporter(t *testing.T) {
+	tt := []struct {
+		format string
+		want   string
+	}{
+		{
+			format: exporter.FormatCSV,
+			want: "id,project,tags,start_time,end_time,duration_seconds,note\n" +
+				"1,Flow,export;csv,2024-04-17T09:00:00Z,2024-04-17T10:00:00Z,3600,\"Exported, with a comma\"\n" +
+				"2,my-project,,2024-04-17T11:00:00Z,2024-04-17T11:30:00Z,1800,\n" +
+				"3,Flow,,2024-04-17T14:00:00Z,,0,\n",
+		},
+		{
+			format: exporter.FormatJSONL,
+			want: `{"end_time":"2024-04-17T10:00:00Z","start_time":"2024-04-17T09:00:00Z","id":"1","project":"Flow","status":"ENDED","tags":["export","csv"],"note":"Exported, with a comma","duration_seconds":3600}` + "\n" +
+				`{"end_time":"2024-04-17T11:30:00Z","start_time":"2024-04-17T11:00:00Z","id":"2","project":"my-project","status":"ENDED","tags":[],"duration_seconds":1800}` + "\n" +
+				`{"end_time":null,"start_time":"2024-04-17T14:00:00Z","id":"3","project":"Flow","status":"FLOWING","tags":[],"duration_seconds":0}` + "\n",
+		},
+		{
+			format: exporter.FormatICS,
+			want: "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//flow//flow//EN\r\nCALSCALE:GREGORIAN\r\n" +
+				"BEGIN:VEVENT\r\nUID:1@flow\r\nDTSTAMP:20240417T090000Z\r\nDTSTART:20240417T090000Z\r\nDTEND:20240417T100000Z\r\n" +
+				"SUMMARY:Flow\r\nDESCRIPTION:Tags: export\\, csv\\nExported\\, with a comma\r\nCATEGORIES:export,csv\r\nEND:VEVENT\r\n" +
+				"BEGIN:VEVENT\r\nUID:2@flow\r\nDTSTAMP:20240417T110000Z\r\nDTSTART:20240417T110000Z\r\nDTEND:20240417T113000Z\r\n" +
+				"SUMMARY:my-project\r\nEND:VEVENT\r\n" +
+				"BEGIN:VEVENT\r\nUID:3@flow\r\nDTSTAMP:20240417T140000Z\r\nDTSTART:20240417T140000Z\r\n" +
+				"SUMMARY:Flow\r\nEND:VEVENT\r\n" +
+				"END:VCALENDAR\r\n",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.format, func(t *testing.T) {
+			is := is.New(t)
+
+			encoder, err := exporter.NewEncoder(tc.format)
+			is.NoErr(err)
+
+			buf := new(bytes.Buffer)
+			is.NoErr(exporter.WriterExporter{Writer: buf, Encoder: encoder}.Export(sessions))
+
+			is.Equal(buf.String(), tc.want)
+		})
+	}
+}
+
+func TestRollupExporter(t *testing.T) {
+	tt := []struct {
+		format string
+		want   string
+	}{
+		{
+			format: exporter.FormatCSV,
+			want: "month,project,duration_seconds,duration_hours\n" +
+				"2024-04,Flow,3600,1.00\n" +
+				"2024-04,my-project,1800,0.50\n",
+		},
+		{
+			format: exporter.FormatJSONL,
+			want: `{"month":"2024-04","project":"Flow","duration_seconds":3600,"duration_hours":1}` + "\n" +
+				`{"month":"2024-04","project":"my-project","duration_seconds":1800,"duration_hours":0.5}` + "\n",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.format, func(t *testing.T) {
+			is := is.New(t)
+
+			buf := new(bytes.Buffer)
+			rollupExporter, err := exporter.NewRollupExporter(buf, tc.format)
+			is.NoErr(err)
+
+			is.NoErr(rollupExporter.Export(sessions))
+
+			is.Equal(buf.String(), tc.want)
+		})
+	}
+}
+
+func TestRollupExporter_InvalidFormat(t *testing.T) {
+	is := is.New(t)
+
+	_, err := exporter.NewRollupExporter(new(bytes.Buffer), exporter.FormatICS)
+
+	is.True(err != nil)
+}
+
+func TestICSEncoder_FoldsLongLines(t *testing.T) {
+	is := is.New(t)
+
+	row, err := exporter.ICSEncoder{}.Encode(session.Session{
+		Id:        "1",
+		StartTime: time.Date(2024, 4, 17, 9, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+		Note:      strings.Repeat("é", 80),
+	})
+	is.NoErr(err)
+
+	for _, line := range strings.Split(strings.TrimSuffix(string(row), "\r\n"), "\r\n") {
+		is.True(len(line) <= 75)
+	}
+
+	unfolded := strings.ReplaceAll(string(row), "\r\n ", "")
+	is.True(strings.Contains(unfolded, "DESCRIPTION:"+strings.Repeat("é", 80)+"\r\n"))
+}
+
+func TestNewEncoder_InvalidFormat(t *testing.T) {
+	is := is.New(t)
+
+	_, err := exporter.NewEncoder("xlsx")
+
+	is.True(err != nil)
+}
+
+func TestFileExporter(t *testing.T) {
+	tt := []struct {
+		name         string
+		path         string
+		maxChunkSize int64
+		wantFiles    []string
+	}{
+		{
+			name:      "Single file",
+			path:      "sessions.csv",
+			wantFiles: []string{"sessions.csv"},
+		},
+		{
+			name:      "Extension added",
+			path:      "sessions",
+			wantFiles: []string{"sessions.csv"},
+		},
+		{
+			name:         "Chunked files",
+			path:         "sessions.csv",
+			maxChunkSize: 150,
+			wantFiles:    []string{"sessions-1.csv", "sessions-2.csv", "sessions-3.csv"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			folderPath := t.TempDir()
+
+			fileExporter := &exporter.FileExporter{
+				Path:         filepath.Join(folderPath, tc.path),
+				Encoder:      exporter.CSVEncoder{},
+				MaxChunkSize: tc.maxChunkSize,
+			}
+			estimate := &exporter.EstimateExporter{
+				Encoder:      exporter.CSVEncoder{},
+				MaxChunkSize: tc.maxChunkSize,
+			}
+
+			is.NoErr(fileExporter.Export(sessions))
+			is.NoErr(estimate.Export(sessions))
+
+			var totalSize int64
+			rows := 0
+			for i, file := range fileExporter.Files {
+				is.Equal(file, filepath.Join(folderPath, tc.wantFiles[i]))
+
+				content, err := os.ReadFile(file)
+				is.NoErr(err)
+				is.True(strings.HasPrefix(string(content), "id,project,")) // every chunk has the header
+				if tc.maxChunkSize > 0 {
+					is.True(int64(len(content)) <= tc.maxChunkSize)
+				}
+
+				totalSize += int64(len(content))
+				rows += strings.Count(string(content), "\n") - 1
+			}
+
+			is.Equal(len(fileExporter.Files), len(tc.wantFiles))
+			is.Equal(rows, len(sessions))
+			is.Equal(estimate.Rows, len(sessions))
+			is.Equal(estimate.Files, len(tc.wantFiles))
+			is.Equal(estimate.Bytes, totalSize)
+		})
+	}
+}
+
+func TestHTMLExporter(t *testing.T) {
+	is := is.New(t)
+
+	buf := new(bytes.Buffer)
+	is.NoErr(exporter.HTMLExporter{Writer: buf, Title: "Timesheet"}.Export(sessions))
+
+	page := buf.String()
+	is.True(strings.Contains(page, "<title>Timesheet</title>"))
+	is.True(strings.Contains(page, "<td>Flow</td><td>1h0m0s</td>"))
+	is.True(strings.Contains(page, "<td>2024-04-17</td><td>my-project</td><td></td><td>11:00:00</td><td>11:30:00</td><td>30m0s</td><td></td>"))
+	is.True(!strings.Contains(page, "data-payload"))
+}
+
+func TestHTMLExporter_Journal(t *testing.T) {
+	is := is.New(t)
+
+	entries := []journal.Entry{
+		{Day: time.Date(2024, 4, 17, 0, 0, 0, 0, time.UTC), Note: "Demo <b>day</b>"},
+	}
+
+	buf := new(bytes.Buffer)
+	is.NoErr(exporter.HTMLExporter{Writer: buf, Title: "Timesheet"}.ExportWithJournal(sessions, entries))
+
+	page := buf.String()
+	is.True(strings.Contains(page, "<h2>Journal</h2>"))
+	is.True(strings.Contains(page, "<td>2024-04-17</td><td>Demo &lt;b&gt;day&lt;/b&gt;</td>"))
+}
+
+func TestHTMLExporter_Password(t *testing.T) {
+	is := is.New(t)
+
+	buf := new(bytes.Buffer)
+	is.NoErr(exporter.HTMLExporter{Writer: buf, Title: "Timesheet", Password: "secret"}.Export(sessions))
+
+	page := buf.String()
+	is.True(!strings.Contains(page, "my-project"))
+
+	payload := regexp.MustCompile(`data-payload="([^"]+)"`).FindStringSubmatch(page)
+	is.Equal(len(payload), 2)
+
+	sealed, err := base64.StdEncoding.DecodeString(html.UnescapeString(payload[1]))
+	is.NoErr(err)
+
+	report, err := passwordcrypt.Open(sealed, "secret")
+	is.NoErr(err)
+	is.True(strings.Contains(string(report), "<td>my-project</td>"))
+}
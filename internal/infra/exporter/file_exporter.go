@@ -0,0 +1,145 @@
+package exporter
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/utils"
+)
+
+// progressThreshold is the number of rows from which the progress is shown
+const progressThreshold = 1000
+
+// FileExporter writes the sessions to Path. When the export exceeds
+// MaxChunkSize, it is split in numbered files next to Path, e.g.
+// sessions-1.csv, sessions-2.csv.
+type FileExporter struct {
+	// Progress receives a progress bar for large exports, nil disables it
+	Progress     io.Writer
+	Encoder      Encoder
+	Path         string
+	Files        []string
+	MaxChunkSize int64
+}
+
+func (e *FileExporter) path() string {
+	if filepath.Ext(e.Path) == "" {
+		return e.Path + e.Encoder.Extension()
+	}
+
+	return e.Path
+}
+
+func (e *FileExporter) chunkPath(chunk int) string {
+	path := e.path()
+	ext := filepath.Ext(path)
+
+	return fmt.Sprintf("%v-%v%v", strings.TrimSuffix(path, ext), chunk+1, ext)
+}
+
+func (e *FileExporter) Export(sessions []session.Session) error {
+	return e.export(len(sessions), eachOf(sessions))
+}
+
+// ExportEach writes each session as it's given, the progress shows the
+// number of sessions written as their total isn't known
+func (e *FileExporter) ExportEach(forEach func(fn func(session.Session) error) error) error {
+	return e.export(0, forEach)
+}
+
+// export writes the sessions given by forEach, total is their number when
+// it's known, zero otherwise
+func (e *FileExporter) export(total int, forEach func(fn func(session.Session) error) error) (err error) {
+	var progress *utils.ProgressBar
+	defer func() {
+		if progress != nil {
+			progress.Done()
+		}
+	}()
+
+	e.Files = []string{e.path()}
+	file, err := os.Create(e.path())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := e.closeChunk(file); err == nil {
+			err = closeErr
+		}
+	}()
+
+	if _, err := file.Write(e.Encoder.Header()); err != nil {
+		return err
+	}
+
+	done := 0
+	return eachChunkRow(e.Encoder, forEach, e.MaxChunkSize, func(chunk int, row []byte) error {
+		if chunk+1 > len(e.Files) {
+			next, err := e.nextChunk(file, chunk)
+			// the previous chunk is closed even when the next one can't be created
+			file = next
+			if err != nil {
+				return err
+			}
+		}
+
+		if _, err := file.Write(row); err != nil {
+			return err
+		}
+
+		done++
+		if progress == nil && e.Progress != nil && max(total, done) >= progressThreshold {
+			progress = utils.NewProgressBar(e.Progress, "Exporting", total)
+		}
+		if progress != nil {
+			progress.Update(done)
+		}
+
+		return nil
+	})
+}
+
+func (e *FileExporter) closeChunk(file *os.File) error {
+	if file == nil {
+		return nil
+	}
+
+	if _, err := file.Write(e.Encoder.Footer()); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// nextChunk closes the current file and creates the next one. The single file
+// written so far is renamed as the first chunk once a second one is needed.
+func (e *FileExporter) nextChunk(file *os.File, chunk int) (*os.File, error) {
+	if err := e.closeChunk(file); err != nil {
+		return nil, err
+	}
+
+	if chunk == 1 {
+		if err := os.Rename(e.path(), e.chunkPath(0)); err != nil {
+			return nil, err
+		}
+		e.Files[0] = e.chunkPath(0)
+	}
+
+	next, err := os.Create(e.chunkPath(chunk))
+	if err != nil {
+		return nil, err
+	}
+	e.Files = append(e.Files, e.chunkPath(chunk))
+
+	if _, err := next.Write(e.Encoder.Header()); err != nil {
+		next.Close()
+		return nil, err
+	}
+
+	return next, nil
+}
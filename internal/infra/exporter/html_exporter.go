@@ -0,0 +1,202 @@
+package exporter
+
+import (
+	"bytes"
+	"encoding/base64"
+	"html/template"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/journal"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/pkg/passwordcrypt"
+)
+
+var reportTemplate = template.Must(template.New("report").Parse(`<h1>{{.Title}}</h1>
+{{if .Sessions}}<p>{{.Since}} to {{.Until}}, {{.Total}} in total</p>
+<h2>Projects</h2>
+<table>
+<tr><th>Project</th><th>Duration</th></tr>
+{{range .Projects}}<tr><td>{{.Project}}</td><td>{{.Duration}}</td></tr>
+{{end}}</table>
+<h2>Sessions</h2>
+<table>
+<tr><th>Day</th><th>Project</th><th>Tags</th><th>Start</th><th>End</th><th>Duration</th><th>Note</th></tr>
+{{range .Sessions}}<tr><td>{{.Day}}</td><td>{{.Project}}</td><td>{{.Tags}}</td><td>{{.Start}}</td><td>{{.End}}</td><td>{{.Duration}}</td><td>{{.Note}}</td></tr>
+{{end}}</table>
+{{else}}<p>No sessions</p>
+{{end}}{{if .Journal}}<h2>Journal</h2>
+<table>
+<tr><th>Day</th><th>Note</th></tr>
+{{range .Journal}}<tr><td>{{.Day}}</td><td>{{.Note}}</td></tr>
+{{end}}</table>
+{{end}}`))
+
+// pageTemplate holds the report, or its encrypted payload along with the
+// script decrypting it with the Web Crypto API of the browser
+var pageTemplate = template.Must(template.New("page").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 2rem auto; max-width: 60rem; color: #222; }
+table { border-collapse: collapse; width: 100%; margin-bottom: 2rem; }
+th, td { border-bottom: 1px solid #ddd; padding: .4rem; text-align: left; }
+#error { color: #b00; }
+</style>
+</head>
+<body>
+{{if .Payload}}<form id="unlock" data-payload="{{.Payload}}">
+<p>This report is protected by a password.</p>
+<input id="password" type="password" placeholder="Password" autofocus>
+<button type="submit">Open</button>
+<p id="error"></p>
+</form>
+<div id="report"></div>
+<script>
+document.getElementById("unlock").addEventListener("submit", async (event) => {
+  event.preventDefault();
+  const form = event.target;
+  const sealed = Uint8Array.from(atob(form.dataset.payload), (c) => c.charCodeAt(0));
+  const salt = sealed.slice(0, {{.SaltSize}});
+  const iv = sealed.slice({{.SaltSize}}, {{.SaltSize}} + {{.NonceSize}});
+  const password = new TextEncoder().encode(document.getElementById("password").value);
+  try {
+    const material = await crypto.subtle.importKey("raw", password, "PBKDF2", false, ["deriveKey"]);
+    const key = await crypto.subtle.deriveKey(
+      { name: "PBKDF2", salt, iterations: {{.Iterations}}, hash: "SHA-256" },
+      material,
+      { name: "AES-GCM", length: 256 },
+      false,
+      ["decrypt"],
+    );
+    const report = await crypto.subtle.decrypt({ name: "AES-GCM", iv }, key, sealed.slice({{.SaltSize}} + {{.NonceSize}}));
+    document.getElementById("report").innerHTML = new TextDecoder().decode(report);
+    form.remove();
+  } catch {
+    document.getElementById("error").textContent = "Wrong password";
+  }
+});
+</script>
+{{else}}{{.Report}}{{end}}
+</body>
+</html>
+`))
+
+type htmlSession struct {
+	Day      string
+	Project  string
+	Tags     string
+	Start    string
+	End      string
+	Duration time.Duration
+	Note     string
+}
+
+type htmlJournalEntry struct {
+	Day  string
+	Note string
+}
+
+type htmlProject struct {
+	Project  string
+	Duration time.Duration
+}
+
+// HTMLExporter writes a single self-contained HTML report of the sessions,
+// which can be sent as is to a client. When Password isn't empty, the report
+// is encrypted and the page asks for the password to show it.
+type HTMLExporter struct {
+	Writer   io.Writer
+	Title    string
+	Password string
+}
+
+func (e HTMLExporter) Export(sessions []session.Session) error {
+	return e.ExportWithJournal(sessions, nil)
+}
+
+// ExportWithJournal adds the journal entries of the period after the sessions
+func (e HTMLExporter) ExportWithJournal(sessions []session.Session, entries []journal.Entry) error {
+	report := new(bytes.Buffer)
+	if err := reportTemplate.Execute(report, e.reportData(sessions, entries)); err != nil {
+		return err
+	}
+
+	page := map[string]any{
+		"Title":      e.Title,
+		"Report":     template.HTML(report.String()),
+		"SaltSize":   passwordcrypt.SaltSize,
+		"NonceSize":  passwordcrypt.NonceSize,
+		"Iterations": passwordcrypt.Iterations,
+	}
+
+	if e.Password != "" {
+		sealed, err := passwordcrypt.Seal(report.Bytes(), e.Password)
+		if err != nil {
+			return err
+		}
+		page["Payload"] = base64.StdEncoding.EncodeToString(sealed)
+	}
+
+	return pageTemplate.Execute(e.Writer, page)
+}
+
+func (e HTMLExporter) reportData(sessions []session.Session, entries []journal.Entry) map[string]any {
+	rows := []htmlSession{}
+	durationByProject := map[string]time.Duration{}
+	total := time.Duration(0)
+
+	for _, s := range sessions {
+		end := ""
+		if !s.EndTime.IsZero() {
+			end = s.EndTime.Format(time.TimeOnly)
+		}
+
+		rows = append(rows, htmlSession{
+			Day:      s.StartTime.Format(time.DateOnly),
+			Project:  s.Project,
+			Tags:     strings.Join(s.Tags, ", "),
+			Start:    s.StartTime.Format(time.TimeOnly),
+			End:      end,
+			Duration: s.Duration(),
+			Note:     s.Note,
+		})
+		durationByProject[s.Project] += s.Duration()
+		total += s.Duration()
+	}
+
+	projects := []htmlProject{}
+	for project, duration := range durationByProject {
+		projects = append(projects, htmlProject{Project: project, Duration: duration})
+	}
+	sort.Slice(projects, func(i, j int) bool {
+		return projects[i].Project < projects[j].Project
+	})
+
+	journalRows := []htmlJournalEntry{}
+	for _, entry := range entries {
+		journalRows = append(journalRows, htmlJournalEntry{
+			Day:  entry.Day.Format(time.DateOnly),
+			Note: entry.Note,
+		})
+	}
+
+	data := map[string]any{
+		"Title":    e.Title,
+		"Sessions": rows,
+		"Projects": projects,
+		"Total":    total,
+		"Journal":  journalRows,
+	}
+
+	if len(sessions) > 0 {
+		data["Since"] = sessions[0].StartTime.Format(time.DateOnly)
+		data["Until"] = sessions[len(sessions)-1].StartTime.Format(time.DateOnly)
+	}
+
+	return data
+}
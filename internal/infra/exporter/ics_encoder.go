@@ -0,0 +1,99 @@
+package exporter
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+const icsTimeLayout = "20060102T150405Z"
+
+// icsMaxLineLength is the length in bytes lines are folded at, see RFC 5545
+const icsMaxLineLength = 75
+
+var icsTextEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// ICSEncoder writes a calendar event per session, to overlay the tracked time
+// on a calendar app. The summary is the project, the description holds the
+// tags and the note. Sessions still flowing have no end.
+type ICSEncoder struct{}
+
+func (e ICSEncoder) Extension() string {
+	return ".ics"
+}
+
+func (e ICSEncoder) Header() []byte {
+	return e.encodeLines([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//flow//flow//EN",
+		"CALSCALE:GREGORIAN",
+	})
+}
+
+func (e ICSEncoder) Encode(s session.Session) ([]byte, error) {
+	lines := []string{
+		"BEGIN:VEVENT",
+		"UID:" + icsTextEscaper.Replace(s.Id) + "@flow",
+		// the start time keeps the export of a session the same across runs
+		"DTSTAMP:" + s.StartTime.UTC().Format(icsTimeLayout),
+		"DTSTART:" + s.StartTime.UTC().Format(icsTimeLayout),
+	}
+
+	if !s.EndTime.IsZero() {
+		lines = append(lines, "DTEND:"+s.EndTime.UTC().Format(icsTimeLayout))
+	}
+
+	lines = append(lines, "SUMMARY:"+icsTextEscaper.Replace(s.Project))
+
+	description := []string{}
+	if len(s.Tags) > 0 {
+		description = append(description, "Tags: "+strings.Join(s.Tags, ", "))
+	}
+	if s.Note != "" {
+		description = append(description, s.Note)
+	}
+	if len(description) > 0 {
+		lines = append(lines, "DESCRIPTION:"+icsTextEscaper.Replace(strings.Join(description, "\n")))
+	}
+
+	if len(s.Tags) > 0 {
+		categories := []string{}
+		for _, tag := range s.Tags {
+			categories = append(categories, icsTextEscaper.Replace(tag))
+		}
+		lines = append(lines, "CATEGORIES:"+strings.Join(categories, ","))
+	}
+
+	lines = append(lines, "END:VEVENT")
+
+	return e.encodeLines(lines), nil
+}
+
+func (e ICSEncoder) Footer() []byte {
+	return e.encodeLines([]string{"END:VCALENDAR"})
+}
+
+// encodeLines ends the lines with CRLF and folds the long ones, without
+// splitting multi-byte characters
+func (e ICSEncoder) encodeLines(lines []string) []byte {
+	buf := new(bytes.Buffer)
+
+	for _, line := range lines {
+		length := 0
+		for _, r := range line {
+			size := len(string(r))
+			if length+size > icsMaxLineLength {
+				buf.WriteString("\r\n ")
+				// the leading space of the continuation line counts
+				length = 1
+			}
+			buf.WriteRune(r)
+			length += size
+		}
+		buf.WriteString("\r\n")
+	}
+
+	return buf.Bytes()
+}
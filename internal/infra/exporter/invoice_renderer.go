@@ -0,0 +1,197 @@
+package exporter
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/invoice"
+	"github.com/TristanShz/flow/pkg/pdf"
+)
+
+const (
+	InvoiceFormatMarkdown = "markdown"
+	InvoiceFormatHTML     = "html"
+	InvoiceFormatPDF      = "pdf"
+)
+
+var InvoiceFormats = []string{InvoiceFormatMarkdown, InvoiceFormatHTML, InvoiceFormatPDF}
+
+// InvoiceExtension returns the extension of the files of the invoice format
+func InvoiceExtension(format string) string {
+	if format == InvoiceFormatMarkdown {
+		return ".md"
+	}
+
+	return "." + format
+}
+
+type invoiceLineView struct {
+	Project string
+	Hours   string
+	Rate    string
+	Amount  string
+}
+
+// invoiceView is the invoice as printed, the same in every format
+type invoiceView struct {
+	Title   string
+	Details []string
+	From    []string
+	To      []string
+	Lines   []invoiceLineView
+	Total   string
+	Time    string
+}
+
+func newInvoiceView(i invoice.Invoice) invoiceView {
+	money := func(amount float64) string {
+		if i.Currency == "" {
+			return fmt.Sprintf("%.2f", amount)
+		}
+		return fmt.Sprintf("%.2f %v", amount, i.Currency)
+	}
+
+	view := invoiceView{
+		Title: "Invoice " + i.Number,
+		Details: []string{
+			"Date: " + i.Date.Format(time.DateOnly),
+			fmt.Sprintf("Period: %v to %v", i.Since.Format(time.DateOnly), i.Until.Format(time.DateOnly)),
+		},
+		To:    i.Client.HeaderLines(),
+		Total: money(i.Total),
+		Time:  fmt.Sprintf("%.2f hours", i.Duration.Hours()),
+	}
+	if !i.DueDate.IsZero() {
+		view.Details = append(view.Details, "Due date: "+i.DueDate.Format(time.DateOnly))
+	}
+
+	for _, line := range []string{i.Issuer.Name, i.Issuer.Address} {
+		if line != "" {
+			view.From = append(view.From, line)
+		}
+	}
+	if i.Issuer.TaxID != "" {
+		view.From = append(view.From, "Tax ID: "+i.Issuer.TaxID)
+	}
+
+	for _, line := range i.Lines {
+		view.Lines = append(view.Lines, invoiceLineView{
+			Project: line.Project,
+			Hours:   fmt.Sprintf("%.2f", line.Duration.Hours()),
+			Rate:    money(line.HourlyRate),
+			Amount:  money(line.Amount),
+		})
+	}
+
+	return view
+}
+
+// RenderInvoice writes the invoice in the given format
+func RenderInvoice(w io.Writer, format string, i invoice.Invoice) error {
+	view := newInvoiceView(i)
+
+	switch format {
+	case InvoiceFormatMarkdown:
+		return renderInvoiceMarkdown(w, view)
+	case InvoiceFormatHTML:
+		return invoiceTemplate.Execute(w, view)
+	case InvoiceFormatPDF:
+		return renderInvoicePDF(w, view)
+	}
+
+	return fmt.Errorf("invalid invoice format %v. possible values: %v", format, InvoiceFormats)
+}
+
+func renderInvoiceMarkdown(w io.Writer, view invoiceView) error {
+	text := fmt.Sprintf("# %v\n\n%v\n", view.Title, strings.Join(view.Details, "  \n"))
+	if len(view.From) > 0 {
+		text += fmt.Sprintf("\n## From\n\n%v\n", strings.Join(view.From, "  \n"))
+	}
+	text += fmt.Sprintf("\n## To\n\n%v\n", strings.Join(view.To, "  \n"))
+
+	text += "\n| Project | Hours | Rate | Amount |\n| ------- | ----: | ---: | -----: |\n"
+	for _, line := range view.Lines {
+		text += fmt.Sprintf("| %v | %v | %v | %v |\n", line.Project, line.Hours, line.Rate, line.Amount)
+	}
+	text += fmt.Sprintf("\n**Total: %v** for %v\n", view.Total, view.Time)
+
+	_, err := io.WriteString(w, text)
+	return err
+}
+
+var invoiceTemplate = template.Must(template.New("invoice").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 2rem auto; max-width: 50rem; color: #222; }
+table { border-collapse: collapse; width: 100%; margin: 2rem 0; }
+th, td { border-bottom: 1px solid #ddd; padding: .4rem; text-align: right; }
+th:first-child, td:first-child { text-align: left; }
+.parties { display: flex; gap: 4rem; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p>{{range $i, $d := .Details}}{{if $i}}<br>{{end}}{{$d}}{{end}}</p>
+<div class="parties">
+{{if .From}}<div><h2>From</h2><p>{{range $i, $l := .From}}{{if $i}}<br>{{end}}{{$l}}{{end}}</p></div>
+{{end}}<div><h2>To</h2><p>{{range $i, $l := .To}}{{if $i}}<br>{{end}}{{$l}}{{end}}</p></div>
+</div>
+<table>
+<tr><th>Project</th><th>Hours</th><th>Rate</th><th>Amount</th></tr>
+{{range .Lines}}<tr><td>{{.Project}}</td><td>{{.Hours}}</td><td>{{.Rate}}</td><td>{{.Amount}}</td></tr>
+{{end}}</table>
+<p><strong>Total: {{.Total}}</strong> for {{.Time}}</p>
+</body>
+</html>
+`))
+
+func renderInvoicePDF(w io.Writer, view invoiceView) error {
+	const size = 10
+
+	document := pdf.New()
+	document.Text(view.Title, 16, true)
+	document.Space(size)
+	for _, detail := range view.Details {
+		document.Text(detail, size, false)
+	}
+
+	for _, party := range []struct {
+		title string
+		lines []string
+	}{{"From", view.From}, {"To", view.To}} {
+		if len(party.lines) == 0 {
+			continue
+		}
+		document.Space(size)
+		document.Text(party.title, size, true)
+		for _, line := range party.lines {
+			document.Text(line, size, false)
+		}
+	}
+
+	// the project takes the width the hours, the rate and the amount leave
+	projectWidth := pdf.Columns(size) - 3*16
+	row := func(project string, hours string, rate string, amount string) string {
+		if runes := []rune(project); len(runes) > projectWidth {
+			project = string(runes[:projectWidth-1]) + "."
+		}
+		return fmt.Sprintf("%-*v%16v%16v%16v", projectWidth, project, hours, rate, amount)
+	}
+
+	document.Space(size)
+	document.Text(row("Project", "Hours", "Rate", "Amount"), size, true)
+	for _, line := range view.Lines {
+		document.Text(row(line.Project, line.Hours, line.Rate, line.Amount), size, false)
+	}
+	document.Space(size)
+	document.Text(fmt.Sprintf("Total: %v for %v", view.Total, view.Time), size, true)
+
+	_, err := document.WriteTo(w)
+	return err
+}
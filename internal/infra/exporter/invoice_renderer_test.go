@@ -0,0 +1,91 @@
+package exporter_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/client"
+	"github.com/TristanShz/flow/internal/domain/invoice"
+	"github.com/TristanShz/flow/internal/infra/exporter"
+	"github.com/matryer/is"
+)
+
+var invoiceForTest = invoice.Invoice{
+	Sequence: 5,
+	Number:   "INV-2024-005",
+	Date:     time.Date(2024, time.May, 2, 0, 0, 0, 0, time.UTC),
+	DueDate:  time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC),
+	Since:    time.Date(2024, time.April, 15, 9, 0, 0, 0, time.UTC),
+	Until:    time.Date(2024, time.April, 29, 18, 0, 0, 0, time.UTC),
+	Issuer:   invoice.Issuer{Name: "Jane Doe", TaxID: "FR123"},
+	Client:   client.Client{Name: "Acme", Address: "1 Main Street"},
+	Currency: "EUR",
+	Lines: []invoice.Line{
+		{Project: "Website", Duration: 150 * time.Minute, HourlyRate: 80, Amount: 200},
+		{Project: "Website <beta>", Duration: time.Hour, HourlyRate: 120, Amount: 120},
+	},
+	Duration: 210 * time.Minute,
+	Total:    320,
+}
+
+func TestRenderInvoice_Markdown(t *testing.T) {
+	is := is.New(t)
+	buf := &bytes.Buffer{}
+
+	is.NoErr(exporter.RenderInvoice(buf, exporter.InvoiceFormatMarkdown, invoiceForTest))
+
+	is.Equal(buf.String(), `# Invoice INV-2024-005
+
+Date: 2024-05-02  
+Period: 2024-04-15 to 2024-04-29  
+Due date: 2024-06-01
+
+## From
+
+Jane Doe  
+Tax ID: FR123
+
+## To
+
+Client: Acme  
+Address: 1 Main Street
+
+| Project | Hours | Rate | Amount |
+| ------- | ----: | ---: | -----: |
+| Website | 2.50 | 80.00 EUR | 200.00 EUR |
+| Website <beta> | 1.00 | 120.00 EUR | 120.00 EUR |
+
+**Total: 320.00 EUR** for 3.50 hours
+`)
+}
+
+func TestRenderInvoice_HTML(t *testing.T) {
+	is := is.New(t)
+	buf := &bytes.Buffer{}
+
+	is.NoErr(exporter.RenderInvoice(buf, exporter.InvoiceFormatHTML, invoiceForTest))
+
+	is.True(strings.Contains(buf.String(), "<h1>Invoice INV-2024-005</h1>"))
+	is.True(strings.Contains(buf.String(), "<tr><td>Website &lt;beta&gt;</td><td>1.00</td><td>120.00 EUR</td><td>120.00 EUR</td></tr>"))
+	is.True(strings.Contains(buf.String(), "<strong>Total: 320.00 EUR</strong>"))
+}
+
+func TestRenderInvoice_PDF(t *testing.T) {
+	is := is.New(t)
+	buf := &bytes.Buffer{}
+
+	is.NoErr(exporter.RenderInvoice(buf, exporter.InvoiceFormatPDF, invoiceForTest))
+
+	is.True(strings.HasPrefix(buf.String(), "%PDF-"))
+	is.True(strings.Contains(buf.String(), "(Total: 320.00 EUR for 3.50 hours) Tj"))
+}
+
+func TestRenderInvoice_InvalidFormat(t *testing.T) {
+	is := is.New(t)
+
+	err := exporter.RenderInvoice(&bytes.Buffer{}, "docx", invoiceForTest)
+
+	is.Equal(err.Error(), "invalid invoice format docx. possible values: [markdown html pdf]")
+}
@@ -0,0 +1,33 @@
+package exporter
+
+import (
+	"encoding/json"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra/presenter"
+)
+
+// JSONLEncoder writes one JSON session per line, using the same schema as the
+// JSON output of the other commands
+type JSONLEncoder struct{}
+
+func (e JSONLEncoder) Extension() string {
+	return ".jsonl"
+}
+
+func (e JSONLEncoder) Header() []byte {
+	return nil
+}
+
+func (e JSONLEncoder) Encode(s session.Session) ([]byte, error) {
+	row, err := json.Marshal(presenter.NewSessionJSON(s))
+	if err != nil {
+		return nil, err
+	}
+
+	return append(row, '\n'), nil
+}
+
+func (e JSONLEncoder) Footer() []byte {
+	return nil
+}
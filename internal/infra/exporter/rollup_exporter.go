@@ -0,0 +1,111 @@
+package exporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/domain/sessionsreport"
+)
+
+// PresetAccountant exports the monthly totals of each project, without the
+// sessions nor their notes and tags
+const PresetAccountant = "accountant"
+
+var Presets = []string{PresetAccountant}
+
+// RollupFormats are the formats of the accountant preset
+var RollupFormats = []string{FormatCSV, FormatJSONL}
+
+func IsPresetValid(preset string) bool {
+	return slices.Contains(Presets, preset)
+}
+
+var rollupColumns = []string{"month", "project", "duration_seconds", "duration_hours"}
+
+type monthlyTotalJSON struct {
+	Month           string  `json:"month"`
+	Project         string  `json:"project"`
+	DurationSeconds int64   `json:"duration_seconds"`
+	DurationHours   float64 `json:"duration_hours"`
+}
+
+// RollupExporter writes the monthly totals of the projects instead of the
+// sessions, hours are rounded to the hundredth
+type RollupExporter struct {
+	Writer io.Writer
+	Format string
+}
+
+func NewRollupExporter(writer io.Writer, format string) (RollupExporter, error) {
+	if !slices.Contains(RollupFormats, format) {
+		return RollupExporter{}, fmt.Errorf("invalid format %v for the %v preset. possible values: %v", format, PresetAccountant, RollupFormats)
+	}
+
+	return RollupExporter{Writer: writer, Format: format}, nil
+}
+
+func (e RollupExporter) Extension() string {
+	if e.Format == FormatJSONL {
+		return JSONLEncoder{}.Extension()
+	}
+
+	return CSVEncoder{}.Extension()
+}
+
+func (e RollupExporter) Export(sessions []session.Session) error {
+	return e.ExportEach(eachOf(sessions))
+}
+
+// ExportEach sums the sessions as they're given, then writes the totals
+func (e RollupExporter) ExportEach(forEach func(fn func(session.Session) error) error) error {
+	totals := sessionsreport.MonthlyTotals{}
+	err := forEach(func(s session.Session) error {
+		totals.Add(s)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	csvEncoder := CSVEncoder{}
+	if e.Format == FormatCSV {
+		if _, err := e.Writer.Write(csvEncoder.encodeRecord(rollupColumns)); err != nil {
+			return err
+		}
+	}
+
+	for _, total := range totals.Totals() {
+		row := monthlyTotalJSON{
+			Month:           total.Month.Format("2006-01"),
+			Project:         total.Project,
+			DurationSeconds: int64(total.Duration.Seconds()),
+			DurationHours:   float64(int64(total.Duration.Hours()*100+0.5)) / 100,
+		}
+
+		var data []byte
+		if e.Format == FormatJSONL {
+			marshaled, err := json.Marshal(row)
+			if err != nil {
+				return err
+			}
+			data = append(marshaled, '\n')
+		} else {
+			data = csvEncoder.encodeRecord([]string{
+				row.Month,
+				row.Project,
+				strconv.FormatInt(row.DurationSeconds, 10),
+				strconv.FormatFloat(row.DurationHours, 'f', 2, 64),
+			})
+		}
+
+		if _, err := e.Writer.Write(data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package exporter
+
+import (
+	"io"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+// WriterExporter writes all the sessions to a single writer, like stdout
+type WriterExporter struct {
+	Writer  io.Writer
+	Encoder Encoder
+}
+
+func (e WriterExporter) Export(sessions []session.Session) error {
+	return e.ExportEach(eachOf(sessions))
+}
+
+// ExportEach writes each session as it's given
+func (e WriterExporter) ExportEach(forEach func(fn func(session.Session) error) error) error {
+	if _, err := e.Writer.Write(e.Encoder.Header()); err != nil {
+		return err
+	}
+
+	err := eachChunkRow(e.Encoder, forEach, 0, func(_ int, row []byte) error {
+		_, err := e.Writer.Write(row)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = e.Writer.Write(e.Encoder.Footer())
+	return err
+}
@@ -0,0 +1,104 @@
+package filesystem
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/TristanShz/flow/internal/application"
+)
+
+const activeSessionLockFilename = "active.lock"
+
+type FileSystemActiveSessionLock struct {
+	FlowFolderPath string
+}
+
+func NewFileSystemActiveSessionLock(flowFolderPath string) FileSystemActiveSessionLock {
+	return FileSystemActiveSessionLock{
+		FlowFolderPath: flowFolderPath,
+	}
+}
+
+func (l *FileSystemActiveSessionLock) filePath() string {
+	return filepath.Join(l.FlowFolderPath, activeSessionLockFilename)
+}
+
+// read returns the active session of the lock file. A lock file that can't be
+// read yet, because it's being written by another process, is held by a
+// session without id acquired when the file was created.
+func (l *FileSystemActiveSessionLock) read() (application.ActiveSession, error) {
+	fileInfo, err := os.Stat(l.filePath())
+	if err != nil {
+		return application.ActiveSession{}, err
+	}
+
+	raw, err := os.ReadFile(l.filePath())
+	if err != nil {
+		return application.ActiveSession{}, err
+	}
+
+	active := application.ActiveSession{}
+	if err := json.Unmarshal(raw, &active); err != nil {
+		return application.ActiveSession{AcquiredAt: fileInfo.ModTime()}, nil
+	}
+
+	return active, nil
+}
+
+func (l *FileSystemActiveSessionLock) Acquire(active application.ActiveSession) (application.ActiveSession, error) {
+	marshaled, err := json.Marshal(active)
+	if err != nil {
+		return application.ActiveSession{}, err
+	}
+
+	// O_EXCL makes the creation fail if the file exists, so only one process
+	// can ever create the lock file
+	file, err := os.OpenFile(l.filePath(), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0666)
+	if errors.Is(err, fs.ErrExist) {
+		holder, readErr := l.read()
+		if readErr != nil {
+			return application.ActiveSession{}, readErr
+		}
+		return holder, application.ErrActiveSessionLocked
+	}
+	if err != nil {
+		return application.ActiveSession{}, err
+	}
+
+	if _, err := file.Write(marshaled); err != nil {
+		file.Close()
+		os.Remove(l.filePath())
+		return application.ActiveSession{}, err
+	}
+
+	if err := file.Close(); err != nil {
+		os.Remove(l.filePath())
+		return application.ActiveSession{}, err
+	}
+
+	return active, nil
+}
+
+func (l *FileSystemActiveSessionLock) Release(sessionId string) error {
+	holder, err := l.read()
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if holder.SessionId != sessionId {
+		return nil
+	}
+
+	err = os.Remove(l.filePath())
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package filesystem_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/infra/filesystem"
+	"github.com/matryer/is"
+)
+
+func TestFileSystemActiveSessionLock_AcquireAndRelease(t *testing.T) {
+	is := is.New(t)
+	lock := filesystem.NewFileSystemActiveSessionLock(t.TempDir())
+
+	active := application.ActiveSession{
+		SessionId:  "1",
+		AcquiredAt: time.Date(2024, 4, 17, 19, 0, 0, 0, time.UTC),
+	}
+
+	_, err := lock.Acquire(active)
+	is.NoErr(err)
+
+	holder, err := lock.Acquire(application.ActiveSession{SessionId: "2"})
+	is.Equal(err, application.ErrActiveSessionLocked)
+	is.Equal(holder, active)
+
+	// releasing another session keeps the lock
+	is.NoErr(lock.Release("2"))
+	_, err = lock.Acquire(application.ActiveSession{SessionId: "2"})
+	is.Equal(err, application.ErrActiveSessionLocked)
+
+	is.NoErr(lock.Release("1"))
+	_, err = lock.Acquire(application.ActiveSession{SessionId: "2"})
+	is.NoErr(err)
+}
+
+func TestFileSystemActiveSessionLock_ConcurrentAcquire(t *testing.T) {
+	is := is.New(t)
+	lock := filesystem.NewFileSystemActiveSessionLock(t.TempDir())
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	acquired := 0
+
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			_, err := lock.Acquire(application.ActiveSession{SessionId: fmt.Sprint(i)})
+			if err == nil {
+				mu.Lock()
+				acquired++
+				mu.Unlock()
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	is.Equal(acquired, 1)
+}
+
+func TestFileSystemActiveSessionLock_IsNotASessionFile(t *testing.T) {
+	is := is.New(t)
+	folderPath := t.TempDir()
+	repository := filesystem.NewFileSystemSessionRepository(folderPath)
+	lock := filesystem.NewFileSystemActiveSessionLock(folderPath)
+
+	_, err := lock.Acquire(application.ActiveSession{SessionId: "1"})
+	is.NoErr(err)
+
+	_, err = os.Stat(filepath.Join(folderPath, "active.lock"))
+	is.NoErr(err)
+	is.Equal(len(repository.FindAllSessions(nil)), 0)
+	is.Equal(len(repository.Diagnose()), 0)
+}
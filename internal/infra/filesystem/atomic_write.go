@@ -0,0 +1,67 @@
+package filesystem
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// tmpFilePattern starts with a dot so that an interrupted write never shows up
+// as a session file in the flow folder
+const tmpFilePattern = ".flow-*.tmp"
+
+// writeFileAtomic writes data to a temporary file of the same directory, syncs
+// it and renames it over the target so the target is either fully written or
+// left untouched. When syncDir is true the directory is synced too, making the
+// rename itself durable.
+func writeFileAtomic(path string, data []byte, perm os.FileMode, syncDir bool) (err error) {
+	dir := filepath.Dir(path)
+
+	tmpFile, err := os.CreateTemp(dir, tmpFilePattern)
+	if err != nil {
+		return err
+	}
+	tmpPath := tmpFile.Name()
+
+	defer func() {
+		if err != nil {
+			tmpFile.Close()
+			os.Remove(tmpPath)
+		}
+	}()
+
+	if _, err = tmpFile.Write(data); err != nil {
+		return err
+	}
+
+	if err = tmpFile.Chmod(perm); err != nil {
+		return err
+	}
+
+	if err = tmpFile.Sync(); err != nil {
+		return err
+	}
+
+	if err = tmpFile.Close(); err != nil {
+		return err
+	}
+
+	if err = os.Rename(tmpPath, path); err != nil {
+		return err
+	}
+
+	if syncDir {
+		return syncDirectory(dir)
+	}
+
+	return nil
+}
+
+func syncDirectory(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	return d.Sync()
+}
@@ -0,0 +1,65 @@
+package filesystem_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra/filesystem"
+	"github.com/matryer/is"
+)
+
+func TestFileSystemSessionRepository_AtomicSave(t *testing.T) {
+	tt := []struct {
+		name    string
+		syncDir bool
+	}{
+		{
+			name: "Without directory sync",
+		},
+		{
+			name:    "With directory sync",
+			syncDir: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			folderPath := t.TempDir()
+
+			repository := filesystem.NewFileSystemSessionRepository(folderPath)
+			repository.SyncDir = tc.syncDir
+
+			s := session.Session{
+				Id:        "1",
+				StartTime: time.Date(2024, 4, 17, 19, 0, 0, 0, time.UTC),
+				Project:   "Flow",
+			}
+			is.NoErr(repository.Save(s))
+
+			s.EndTime = time.Date(2024, 4, 17, 20, 0, 0, 0, time.UTC)
+			is.NoErr(repository.Save(s))
+
+			entries, err := os.ReadDir(folderPath)
+			is.NoErr(err)
+			is.Equal(len(entries), 2) // the session and the index, no temporary file left behind
+
+			found, err := repository.FindById("1")
+			is.NoErr(err)
+			is.Equal(found.EndTime, s.EndTime)
+		})
+	}
+}
+
+func TestFileSystemSessionRepository_IgnoresInterruptedWrites(t *testing.T) {
+	is := is.New(t)
+	folderPath := t.TempDir()
+
+	repository := filesystem.NewFileSystemSessionRepository(folderPath)
+	is.NoErr(os.WriteFile(filepath.Join(folderPath, ".flow-123.tmp"), []byte("{\"Id\":"), 0666))
+
+	is.Equal(len(repository.FindAllSessions(nil)), 0)
+}
@@ -0,0 +1,46 @@
+package filesystem
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/TristanShz/flow/internal/application"
+)
+
+const auditLogFilename = "audit.log"
+
+// FileSystemAuditLog appends the entries to a JSON lines file of the flow
+// folder, entries are never rewritten
+type FileSystemAuditLog struct {
+	FlowFolderPath string
+}
+
+func NewFileSystemAuditLog(flowFolderPath string) FileSystemAuditLog {
+	return FileSystemAuditLog{
+		FlowFolderPath: flowFolderPath,
+	}
+}
+
+func (l *FileSystemAuditLog) Record(entry application.AuditEntry) error {
+	marshaled, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(l.FlowFolderPath, 0755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(filepath.Join(l.FlowFolderPath, auditLogFilename), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0666)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(append(marshaled, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
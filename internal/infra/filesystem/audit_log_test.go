@@ -0,0 +1,63 @@
+package filesystem_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra/filesystem"
+	"github.com/matryer/is"
+)
+
+func TestFileSystemAuditLog(t *testing.T) {
+	is := is.New(t)
+	folderPath := t.TempDir()
+
+	auditLog := filesystem.NewFileSystemAuditLog(folderPath)
+	sessionRepository := filesystem.NewFileSystemSessionRepository(folderPath)
+
+	entries := []application.AuditEntry{
+		{
+			At:        time.Date(2024, 4, 17, 19, 0, 0, 0, time.UTC),
+			Action:    "adjust",
+			SessionId: "1",
+			Changes:   []application.AuditChange{{Field: "start", Before: "2024-04-17T09:00:00Z", After: "2024-04-17T08:50:00Z"}},
+		},
+		{
+			At:        time.Date(2024, 4, 17, 20, 0, 0, 0, time.UTC),
+			Action:    "adjust",
+			SessionId: "2",
+			Changes:   []application.AuditChange{{Field: "end", Before: "2024-04-17T12:00:00Z", After: "2024-04-17T11:55:00Z"}},
+		},
+	}
+	for _, entry := range entries {
+		is.NoErr(auditLog.Record(entry))
+	}
+
+	file, err := os.Open(filepath.Join(folderPath, "audit.log"))
+	is.NoErr(err)
+	defer file.Close()
+
+	got := []application.AuditEntry{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		entry := application.AuditEntry{}
+		is.NoErr(json.Unmarshal(scanner.Bytes(), &entry))
+		got = append(got, entry)
+	}
+	is.Equal(got, entries)
+
+	// the audit log isn't a session file
+	is.NoErr(sessionRepository.Save(session.Session{
+		Id:        "1",
+		StartTime: time.Date(2024, 4, 17, 9, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+	}))
+	is.Equal(len(sessionRepository.FindAllSessions(nil)), 1)
+	is.Equal(len(sessionRepository.Diagnose()), 0)
+}
@@ -0,0 +1,282 @@
+package filesystem
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+// backupManifestFilename is the first entry of a backup, the files of the
+// flow folder follow it under backupFlowFolder
+const (
+	backupManifestFilename = "manifest.json"
+	backupFlowFolder       = "flow"
+)
+
+// backups are named after the time they're made at, so that their names sort
+// like their times
+const (
+	backupPrefix     = "flow-backup-"
+	backupTimeFormat = "20060102-150405"
+	backupExtension  = ".tar.gz"
+)
+
+// FileSystemBackupStore writes the backups of the flow folder as gzipped tar
+// files of the backup folder. The session files are copied as they are,
+// they stay encrypted in the backups when there is a cipher.
+type FileSystemBackupStore struct {
+	FlowFolderPath   string
+	BackupFolderPath string
+	Cipher           SessionCipher
+}
+
+func NewFileSystemBackupStore(flowFolderPath string, backupFolderPath string) FileSystemBackupStore {
+	return FileSystemBackupStore{
+		FlowFolderPath:   flowFolderPath,
+		BackupFolderPath: backupFolderPath,
+	}
+}
+
+// sessionRepository reads the sessions of a flow folder with the cipher of
+// the store
+func (s *FileSystemBackupStore) sessionRepository(flowFolderPath string) FileSystemSessionRepository {
+	repository := NewFileSystemSessionRepository(flowFolderPath)
+	repository.Cipher = s.Cipher
+
+	return repository
+}
+
+func (s *FileSystemBackupStore) Backup(at time.Time) (application.Backup, error) {
+	files := []string{}
+	err := filepath.WalkDir(s.FlowFolderPath, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		// the backup folder may be configured inside the flow folder
+		if entry.IsDir() && path == filepath.Clean(s.BackupFolderPath) {
+			return filepath.SkipDir
+		}
+
+		// sockets and interrupted writes aren't data
+		if !entry.Type().IsRegular() || strings.HasSuffix(entry.Name(), ".tmp") {
+			return nil
+		}
+
+		files = append(files, path)
+		return nil
+	})
+	if err != nil {
+		return application.Backup{}, err
+	}
+
+	repository := s.sessionRepository(s.FlowFolderPath)
+	manifest := application.BackupManifest{
+		FormatVersion: application.BackupFormatVersion,
+		CreatedAt:     at,
+		FlowFolder:    s.FlowFolderPath,
+		Sessions:      len(repository.FindAllSessions(nil)),
+		Files:         len(files),
+	}
+	marshaledManifest, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return application.Backup{}, err
+	}
+
+	buffer := &bytes.Buffer{}
+	gzipWriter := gzip.NewWriter(buffer)
+	tarWriter := tar.NewWriter(gzipWriter)
+	if err := writeTarFile(tarWriter, backupManifestFilename, marshaledManifest, at); err != nil {
+		return application.Backup{}, err
+	}
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if errors.Is(err, os.ErrNotExist) {
+			// e.g. a temporary file renamed since the folder was walked
+			continue
+		}
+		if err != nil {
+			return application.Backup{}, err
+		}
+
+		relativePath, err := filepath.Rel(s.FlowFolderPath, file)
+		if err != nil {
+			return application.Backup{}, err
+		}
+
+		if err := writeTarFile(tarWriter, backupFlowFolder+"/"+filepath.ToSlash(relativePath), content, at); err != nil {
+			return application.Backup{}, err
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		return application.Backup{}, err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return application.Backup{}, err
+	}
+
+	if err := os.MkdirAll(s.BackupFolderPath, 0700); err != nil {
+		return application.Backup{}, err
+	}
+
+	path := filepath.Join(s.BackupFolderPath, backupPrefix+at.Format(backupTimeFormat)+backupExtension)
+	if err := writeFileAtomic(path, buffer.Bytes(), 0600, true); err != nil {
+		return application.Backup{}, err
+	}
+
+	return application.Backup{Path: path, Manifest: manifest}, nil
+}
+
+func writeTarFile(tarWriter *tar.Writer, name string, content []byte, modTime time.Time) error {
+	header := &tar.Header{Name: name, Mode: 0600, Size: int64(len(content)), ModTime: modTime}
+	if err := tarWriter.WriteHeader(header); err != nil {
+		return err
+	}
+
+	_, err := tarWriter.Write(content)
+	return err
+}
+
+func (s *FileSystemBackupStore) FindAllBackups() ([]application.Backup, error) {
+	entries, err := os.ReadDir(s.BackupFolderPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return []application.Backup{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	backups := []application.Backup{}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), backupPrefix) || !strings.HasSuffix(entry.Name(), backupExtension) {
+			continue
+		}
+
+		path := filepath.Join(s.BackupFolderPath, entry.Name())
+		manifest, err := readBackup(path, nil)
+		if err != nil {
+			log.Printf("warning: skipping unreadable backup %v (%v)", path, err)
+			continue
+		}
+
+		// the backup folder may be shared with other flow folders, like the
+		// one of 'flow sandbox', their backups are neither listed nor pruned
+		if filepath.Clean(manifest.FlowFolder) != filepath.Clean(s.FlowFolderPath) {
+			continue
+		}
+
+		backups = append(backups, application.Backup{Path: path, Manifest: manifest})
+	}
+
+	slices.SortFunc(backups, func(a application.Backup, b application.Backup) int {
+		return a.Manifest.CreatedAt.Compare(b.Manifest.CreatedAt)
+	})
+
+	return backups, nil
+}
+
+func (s *FileSystemBackupStore) RemoveBackup(path string) error {
+	return os.Remove(path)
+}
+
+// ReadBackup extracts the flow folder of the backup to a temporary folder,
+// and reads its sessions like the ones of the flow folder, the archived ones
+// included
+func (s *FileSystemBackupStore) ReadBackup(path string) (application.BackupManifest, []session.Session, error) {
+	extracted, err := os.MkdirTemp("", "flow-restore-*")
+	if err != nil {
+		return application.BackupManifest{}, nil, err
+	}
+	defer os.RemoveAll(extracted)
+
+	manifest, err := readBackup(path, func(name string, content []byte) error {
+		relativePath, ok := strings.CutPrefix(name, backupFlowFolder+"/")
+		if !ok || !filepath.IsLocal(relativePath) {
+			return nil
+		}
+
+		extractedPath := filepath.Join(extracted, filepath.FromSlash(relativePath))
+		if err := os.MkdirAll(filepath.Dir(extractedPath), 0700); err != nil {
+			return err
+		}
+
+		return os.WriteFile(extractedPath, content, 0600)
+	})
+	if err != nil {
+		return application.BackupManifest{}, nil, err
+	}
+
+	repository := s.sessionRepository(extracted)
+
+	return manifest, repository.FindAllSessions(nil), nil
+}
+
+// readBackup reads the manifest of the backup, then gives the files of the
+// backup to extract, which are only read for a supported format version and
+// when extract isn't nil
+func readBackup(path string, extract func(name string, content []byte) error) (application.BackupManifest, error) {
+	manifest := application.BackupManifest{}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return manifest, err
+	}
+	defer file.Close()
+
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return manifest, fmt.Errorf("invalid backup %v: %w", path, err)
+	}
+
+	tarReader := tar.NewReader(gzipReader)
+	for first := true; ; first = false {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return manifest, fmt.Errorf("invalid backup %v: %w", path, err)
+		}
+
+		content, err := io.ReadAll(tarReader)
+		if err != nil {
+			return manifest, fmt.Errorf("invalid backup %v: %w", path, err)
+		}
+
+		if first {
+			if header.Name != backupManifestFilename {
+				return manifest, fmt.Errorf("invalid backup %v: no manifest", path)
+			}
+			if err := json.Unmarshal(content, &manifest); err != nil {
+				return manifest, fmt.Errorf("invalid backup %v: %w", path, err)
+			}
+			if manifest.FormatVersion > application.BackupFormatVersion {
+				return manifest, application.ErrUnsupportedBackup
+			}
+			if extract == nil {
+				break
+			}
+			continue
+		}
+
+		if err := extract(header.Name, content); err != nil {
+			return manifest, err
+		}
+	}
+
+	return manifest, nil
+}
@@ -0,0 +1,99 @@
+package filesystem_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application/usecases/backup/createbackup"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/TristanShz/flow/internal/infra/filesystem"
+	"github.com/matryer/is"
+)
+
+func TestFileSystemBackupStore(t *testing.T) {
+	is := is.New(t)
+
+	flowFolder := t.TempDir()
+	repository := filesystem.NewFileSystemSessionRepository(flowFolder)
+	old := session.Session{
+		Id:        "1",
+		StartTime: time.Date(2023, 5, 2, 9, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2023, 5, 2, 10, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+	}
+	recent := session.Session{
+		Id:        "2",
+		StartTime: time.Date(2024, 4, 17, 9, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, 4, 17, 10, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+		Tags:      []string{"review"},
+	}
+	is.NoErr(repository.Save(old))
+	is.NoErr(repository.Save(recent))
+	_, err := repository.Archive(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	is.NoErr(err)
+	is.NoErr(os.WriteFile(filepath.Join(flowFolder, "projects.json"), []byte("{}"), 0666))
+
+	// the backup folder inside the flow folder isn't backed up
+	store := filesystem.NewFileSystemBackupStore(flowFolder, filepath.Join(flowFolder, "backups"))
+
+	first, err := store.Backup(time.Date(2024, 4, 17, 19, 0, 0, 0, time.UTC))
+	is.NoErr(err)
+	is.Equal(filepath.Base(first.Path), "flow-backup-20240417-190000.tar.gz")
+	is.Equal(first.Manifest.Sessions, 2)
+
+	second, err := store.Backup(time.Date(2024, 4, 18, 19, 0, 0, 0, time.UTC))
+	is.NoErr(err)
+	is.Equal(second.Manifest.Files, first.Manifest.Files)
+
+	backups, err := store.FindAllBackups()
+	is.NoErr(err)
+	is.Equal(len(backups), 2)
+	is.Equal(backups[0].Path, first.Path)
+	is.Equal(backups[0].Manifest.CreatedAt.Equal(first.Manifest.CreatedAt), true)
+
+	// the sessions are read back, the archived one included
+	manifest, sessions, err := store.ReadBackup(second.Path)
+	is.NoErr(err)
+	is.Equal(manifest.Sessions, 2)
+	is.Equal(sessions, []session.Session{old, recent})
+
+	is.NoErr(store.RemoveBackup(first.Path))
+	backups, err = store.FindAllBackups()
+	is.NoErr(err)
+	is.Equal(len(backups), 1)
+}
+
+func TestFileSystemBackupStore_SharedBackupFolder(t *testing.T) {
+	is := is.New(t)
+
+	backupFolder := t.TempDir()
+	store := filesystem.NewFileSystemBackupStore(t.TempDir(), backupFolder)
+	sandboxStore := filesystem.NewFileSystemBackupStore(t.TempDir(), backupFolder)
+	dateProvider := infra.NewStubDateProvider()
+	dateProvider.Now = time.Date(2024, 4, 18, 19, 0, 0, 0, time.UTC)
+	createBackup := createbackup.NewCreateBackupUseCase(&store, dateProvider)
+
+	first, err := store.Backup(time.Date(2024, 4, 17, 19, 0, 0, 0, time.UTC))
+	is.NoErr(err)
+	sandboxBackup, err := sandboxStore.Backup(time.Date(2024, 4, 18, 9, 0, 0, 0, time.UTC))
+	is.NoErr(err)
+
+	// the backups of the other flow folder are neither listed nor pruned
+	backups, err := store.FindAllBackups()
+	is.NoErr(err)
+	is.Equal(len(backups), 1)
+	is.Equal(backups[0].Path, first.Path)
+
+	result, err := createBackup.Execute(createbackup.Command{Keep: 1})
+	is.NoErr(err)
+	is.Equal(result.Removed, []string{first.Path})
+
+	sandboxBackups, err := sandboxStore.FindAllBackups()
+	is.NoErr(err)
+	is.Equal(len(sandboxBackups), 1)
+	is.Equal(sandboxBackups[0].Path, sandboxBackup.Path)
+}
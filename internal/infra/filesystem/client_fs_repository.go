@@ -0,0 +1,104 @@
+package filesystem
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"github.com/TristanShz/flow/internal/domain/client"
+)
+
+const clientsFilename = "clients.json"
+
+type FileSystemClientRepository struct {
+	FlowFolderPath string
+}
+
+func NewFileSystemClientRepository(flowFolderPath string) FileSystemClientRepository {
+	return FileSystemClientRepository{
+		FlowFolderPath: flowFolderPath,
+	}
+}
+
+func (r *FileSystemClientRepository) filePath() string {
+	return filepath.Join(r.FlowFolderPath, clientsFilename)
+}
+
+// readClients returns the clients of the clients file, an unreadable or
+// corrupted file is an error so that it's never overwritten
+func (r *FileSystemClientRepository) readClients() ([]client.Client, error) {
+	clients := []client.Client{}
+
+	file, err := os.ReadFile(r.filePath())
+	if errors.Is(err, os.ErrNotExist) {
+		return clients, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error while reading file %v: %w", clientsFilename, err)
+	}
+
+	if err := json.Unmarshal(file, &clients); err != nil {
+		return nil, fmt.Errorf("invalid clients data for file %v: %w", clientsFilename, err)
+	}
+
+	return clients, nil
+}
+
+// findClients returns the clients to look them up, they're skipped with a
+// warning when the clients file can't be read
+func (r *FileSystemClientRepository) findClients() []client.Client {
+	clients, err := r.readClients()
+	if err != nil {
+		log.Printf("warning: skipping the clients (%v)", err)
+		return []client.Client{}
+	}
+
+	return clients
+}
+
+func (r *FileSystemClientRepository) Save(c client.Client) error {
+	clients, err := r.readClients()
+	if err != nil {
+		return err
+	}
+
+	clientIndex := slices.IndexFunc(clients, func(existing client.Client) bool {
+		return existing.Name == c.Name
+	})
+
+	if clientIndex == -1 {
+		clients = append(clients, c)
+	} else {
+		clients[clientIndex] = c
+	}
+
+	sort.Slice(clients, func(i, j int) bool {
+		return clients[i].Name < clients[j].Name
+	})
+
+	marshaled, err := json.MarshalIndent(clients, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(r.filePath(), marshaled, 0666, false)
+}
+
+func (r *FileSystemClientRepository) FindByName(name string) *client.Client {
+	for _, c := range r.findClients() {
+		if c.Name == name {
+			return &c
+		}
+	}
+
+	return nil
+}
+
+func (r *FileSystemClientRepository) FindAll() []client.Client {
+	return r.findClients()
+}
@@ -0,0 +1,69 @@
+package filesystem_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/client"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra/filesystem"
+	"github.com/matryer/is"
+)
+
+func TestFileSystemClientRepository(t *testing.T) {
+	is := is.New(t)
+	folderPath := t.TempDir()
+
+	repository := filesystem.NewFileSystemClientRepository(folderPath)
+
+	is.Equal(repository.FindAll(), []client.Client{})
+	is.Equal(repository.FindByName("Acme"), nil)
+
+	is.NoErr(repository.Save(client.Client{Name: "Globex", Contact: "hank@globex.com"}))
+	is.NoErr(repository.Save(client.Client{Name: "Acme", Contact: "jane@acme.com"}))
+	is.NoErr(repository.Save(client.Client{Name: "Acme", Contact: "john@acme.com", PONumber: "PO-42"}))
+
+	is.Equal(repository.FindAll(), []client.Client{
+		{Name: "Acme", Contact: "john@acme.com", PONumber: "PO-42"},
+		{Name: "Globex", Contact: "hank@globex.com"},
+	})
+	is.Equal(*repository.FindByName("Globex"), client.Client{Name: "Globex", Contact: "hank@globex.com"})
+}
+
+func TestFileSystemClientRepository_IgnoredBySessionRepository(t *testing.T) {
+	is := is.New(t)
+	folderPath := t.TempDir()
+
+	clientRepository := filesystem.NewFileSystemClientRepository(folderPath)
+	sessionRepository := filesystem.NewFileSystemSessionRepository(folderPath)
+
+	is.NoErr(clientRepository.Save(client.Client{Name: "Acme"}))
+	is.NoErr(sessionRepository.Save(session.Session{
+		Id:        "1",
+		StartTime: time.Date(2024, 4, 17, 19, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+	}))
+
+	is.Equal(len(sessionRepository.FindAllSessions(nil)), 1)
+	last, err := sessionRepository.FindLastSession()
+	is.NoErr(err)
+	is.Equal(last.Id, "1")
+}
+
+func TestFileSystemClientRepository_CorruptedFile(t *testing.T) {
+	is := is.New(t)
+	folderPath := t.TempDir()
+	is.NoErr(os.WriteFile(filepath.Join(folderPath, "clients.json"), []byte("[{"), 0666))
+
+	repository := filesystem.NewFileSystemClientRepository(folderPath)
+
+	is.Equal(repository.FindAll(), []client.Client{}) // skipped with a warning
+	is.Equal(repository.FindByName("Acme"), nil)
+	is.True(repository.Save(client.Client{Name: "Acme"}) != nil) // the file isn't overwritten
+
+	content, err := os.ReadFile(filepath.Join(folderPath, "clients.json"))
+	is.NoErr(err)
+	is.Equal(string(content), "[{")
+}
@@ -0,0 +1,86 @@
+package filesystem
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/TristanShz/flow/pkg/timerange"
+)
+
+// dailyTotalsCacheFilename holds the daily totals of the last time range
+// asked for, along with the modification time of the flow folder they were
+// computed at, like the last session pointer. Saving or deleting a session
+// renames or removes a file of the folder, which outdates them.
+const dailyTotalsCacheFilename = "daily_totals"
+
+type dailyTotalsCache struct {
+	FolderModTime int64           `json:"folder_mod_time"`
+	Since         time.Time       `json:"since"`
+	Until         time.Time       `json:"until"`
+	Totals        []time.Duration `json:"totals"`
+}
+
+func (r *FileSystemSessionRepository) dailyTotalsCachePath() string {
+	return filepath.Join(r.FlowFolderPath, dailyTotalsCacheFilename)
+}
+
+// FindDailyTotals returns the cached totals of the time range, false when the
+// flow folder changed since they were cached. There's no cache of encrypted
+// sessions, it would tell how long was worked each day.
+func (r *FileSystemSessionRepository) FindDailyTotals(timeRange timerange.TimeRange) ([]time.Duration, bool) {
+	if r.Cipher != nil {
+		return nil, false
+	}
+
+	folderInfo, err := os.Stat(r.FlowFolderPath)
+	if err != nil {
+		return nil, false
+	}
+
+	raw, err := os.ReadFile(r.dailyTotalsCachePath())
+	if err != nil {
+		return nil, false
+	}
+
+	// a cache being written in place doesn't unmarshal
+	cache := dailyTotalsCache{}
+	if err := json.Unmarshal(raw, &cache); err != nil {
+		return nil, false
+	}
+
+	if cache.FolderModTime != folderInfo.ModTime().UnixNano() || !cache.Since.Equal(timeRange.Since) || !cache.Until.Equal(timeRange.Until) {
+		return nil, false
+	}
+
+	return cache.Totals, true
+}
+
+// SaveDailyTotals caches the totals of the time range. They aren't cached
+// when the flow folder changed lately, as a session saved while they were
+// computed may be missing from them. The cache is only a cache: failing to
+// write it is ignored.
+func (r *FileSystemSessionRepository) SaveDailyTotals(timeRange timerange.TimeRange, totals []time.Duration) {
+	if r.ReadOnly || r.Cipher != nil {
+		return
+	}
+
+	folderInfo, err := os.Stat(r.FlowFolderPath)
+	if err != nil || time.Since(folderInfo.ModTime()) < racyFolderDelay {
+		return
+	}
+
+	content, err := json.Marshal(dailyTotalsCache{
+		FolderModTime: folderInfo.ModTime().UnixNano(),
+		Since:         timeRange.Since,
+		Until:         timeRange.Until,
+		Totals:        totals,
+	})
+	if err != nil {
+		return
+	}
+
+	// the cache is written in place, like the last session pointer
+	os.WriteFile(r.dailyTotalsCachePath(), content, 0666)
+}
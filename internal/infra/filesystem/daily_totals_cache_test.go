@@ -0,0 +1,96 @@
+package filesystem_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra/filesystem"
+	"github.com/TristanShz/flow/pkg/timerange"
+	"github.com/matryer/is"
+)
+
+func TestFileSystemSessionRepository_DailyTotalsCache(t *testing.T) {
+	is := is.New(t)
+
+	folder := t.TempDir()
+	repository := filesystem.NewFileSystemSessionRepository(folder)
+	start := time.Date(2024, time.April, 15, 9, 0, 0, 0, time.UTC)
+	is.NoErr(repository.Save(session.Session{Id: "1", StartTime: start, EndTime: start.Add(time.Hour), Project: "Flow"}))
+
+	week := timerange.TimeRange{Since: start.Add(-9 * time.Hour), Until: start.AddDate(0, 0, 7).Add(-9 * time.Hour)}
+	totals := []time.Duration{time.Hour, 0, 0, 0, 0, 0, 0}
+
+	// the totals aren't cached while the folder has just changed
+	repository.SaveDailyTotals(week, totals)
+	_, ok := repository.FindDailyTotals(week)
+	is.True(!ok)
+
+	// the first save creates the cache file, which changes the folder again
+	unchanged := time.Now().Add(-time.Minute)
+	for range 2 {
+		is.NoErr(os.Chtimes(folder, unchanged, unchanged))
+		repository.SaveDailyTotals(week, totals)
+	}
+
+	cached, ok := repository.FindDailyTotals(week)
+	is.True(ok)
+	is.Equal(cached, totals)
+
+	// the cache holds a single time range
+	_, ok = repository.FindDailyTotals(timerange.TimeRange{Since: week.Since.AddDate(0, 0, -7), Until: week.Until})
+	is.True(!ok)
+
+	// saving a session outdates the totals
+	is.NoErr(repository.Save(session.Session{Id: "2", StartTime: start.Add(2 * time.Hour), EndTime: start.Add(3 * time.Hour), Project: "Flow"}))
+	_, ok = repository.FindDailyTotals(week)
+	is.True(!ok)
+
+	// the daily totals file isn't taken for a session
+	is.Equal(len(repository.FindAllSessions(nil)), 2)
+	is.Equal(len(repository.Diagnose()), 0)
+}
+
+func TestFileSystemSessionRepository_DailyTotalsCacheNotWritten(t *testing.T) {
+	tt := []struct {
+		name       string
+		repository func(folder string) filesystem.FileSystemSessionRepository
+	}{
+		{
+			name: "Read-only repository",
+			repository: func(folder string) filesystem.FileSystemSessionRepository {
+				return filesystem.FileSystemSessionRepository{FlowFolderPath: folder, ReadOnly: true}
+			},
+		},
+		{
+			// the totals would tell how long was worked each day
+			name: "Encrypted sessions",
+			repository: func(folder string) filesystem.FileSystemSessionRepository {
+				repository := filesystem.NewFileSystemSessionRepository(folder)
+				repository.Cipher = base64Cipher{}
+				return repository
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			folder := t.TempDir()
+			repository := tc.repository(folder)
+			unchanged := time.Now().Add(-time.Minute)
+			is.NoErr(os.Chtimes(folder, unchanged, unchanged))
+
+			week := timerange.TimeRange{Since: time.Date(2024, time.April, 15, 0, 0, 0, 0, time.UTC), Until: time.Date(2024, time.April, 22, 0, 0, 0, 0, time.UTC)}
+			repository.SaveDailyTotals(week, []time.Duration{time.Hour, 0, 0, 0, 0, 0, 0})
+
+			_, err := os.Stat(filepath.Join(folder, "daily_totals"))
+			is.True(os.IsNotExist(err))
+			_, ok := repository.FindDailyTotals(week)
+			is.True(!ok)
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package filesystem
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// FilenameStartTime exposes filenameStartTime to the tests of the hot path of
+// 'flow status'
+var FilenameStartTime = filenameStartTime
+
+// AppendSessionMigration upgrades the format of the session files for the
+// test, like a change of the domain would
+func AppendSessionMigration(t *testing.T, migration func(fields map[string]json.RawMessage) error) {
+	migrations := sessionMigrations
+	sessionMigrations = append(sessionMigrations[:len(sessionMigrations):len(sessionMigrations)], migration)
+	t.Cleanup(func() { sessionMigrations = migrations })
+}
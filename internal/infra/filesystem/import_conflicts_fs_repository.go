@@ -0,0 +1,133 @@
+package filesystem
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+// ImportConflictsFilename is the report of the conflicts of the last import,
+// edited by the user to choose their resolutions
+const ImportConflictsFilename = "import-conflicts.json"
+
+type FileSystemImportConflictsRepository struct {
+	FlowFolderPath string
+}
+
+type importConflictsJSON struct {
+	Resolutions []string             `json:"resolutions"`
+	Conflicts   []importConflictJSON `json:"conflicts"`
+}
+
+type importConflictJSON struct {
+	Reason      string              `json:"reason"`
+	Session     importedSessionJSON `json:"session"`
+	Conflicting []string            `json:"conflicting,omitempty"`
+	Resolution  string              `json:"resolution"`
+	Suggestion  string              `json:"suggestion"`
+}
+
+// importedSessionJSON leaves the end time out of a running session, so that
+// the user can set it
+type importedSessionJSON struct {
+	StartTime time.Time         `json:"startTime"`
+	EndTime   *time.Time        `json:"endTime,omitempty"`
+	Project   string            `json:"project"`
+	Tags      []string          `json:"tags"`
+	Note      string            `json:"note,omitempty"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+}
+
+func NewFileSystemImportConflictsRepository(flowFolderPath string) FileSystemImportConflictsRepository {
+	return FileSystemImportConflictsRepository{
+		FlowFolderPath: flowFolderPath,
+	}
+}
+
+func (r *FileSystemImportConflictsRepository) filePath() string {
+	return filepath.Join(r.FlowFolderPath, ImportConflictsFilename)
+}
+
+func (r *FileSystemImportConflictsRepository) Save(conflicts []application.ImportConflict) error {
+	if len(conflicts) == 0 {
+		err := os.Remove(r.filePath())
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	raw := importConflictsJSON{Resolutions: application.Resolutions}
+	for _, c := range conflicts {
+		s := importedSessionJSON{
+			StartTime: c.Session.StartTime,
+			Project:   c.Session.Project,
+			Tags:      c.Session.Tags,
+			Note:      c.Session.Note,
+			Metadata:  c.Session.Metadata,
+		}
+		if !c.Session.EndTime.IsZero() {
+			s.EndTime = &c.Session.EndTime
+		}
+		raw.Conflicts = append(raw.Conflicts, importConflictJSON{
+			Reason:      c.Reason,
+			Session:     s,
+			Conflicting: c.Conflicting,
+			Resolution:  c.Resolution,
+			Suggestion:  c.Suggestion,
+		})
+	}
+
+	marshaled, err := json.MarshalIndent(raw, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(r.filePath(), marshaled, 0666, false)
+}
+
+// FindAll returns an error rather than exiting on invalid data, the report
+// being edited by hand
+func (r *FileSystemImportConflictsRepository) FindAll() ([]application.ImportConflict, error) {
+	file, err := os.ReadFile(r.filePath())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	raw := importConflictsJSON{}
+	if err := json.Unmarshal(file, &raw); err != nil {
+		return nil, fmt.Errorf("invalid import conflicts in %v: %w", r.filePath(), err)
+	}
+
+	conflicts := []application.ImportConflict{}
+	for _, c := range raw.Conflicts {
+		s := session.Session{
+			StartTime: c.Session.StartTime,
+			Project:   c.Session.Project,
+			Tags:      c.Session.Tags,
+			Note:      c.Session.Note,
+			Metadata:  c.Session.Metadata,
+		}
+		if c.Session.EndTime != nil {
+			s.EndTime = *c.Session.EndTime
+		}
+		conflicts = append(conflicts, application.ImportConflict{
+			Reason:      c.Reason,
+			Session:     s,
+			Conflicting: c.Conflicting,
+			Resolution:  c.Resolution,
+			Suggestion:  c.Suggestion,
+		})
+	}
+
+	return conflicts, nil
+}
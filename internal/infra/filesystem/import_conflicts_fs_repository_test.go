@@ -0,0 +1,78 @@
+package filesystem_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra/filesystem"
+	"github.com/matryer/is"
+)
+
+func TestFileSystemImportConflictsRepository(t *testing.T) {
+	is := is.New(t)
+	folderPath := t.TempDir()
+
+	repository := filesystem.NewFileSystemImportConflictsRepository(folderPath)
+
+	conflicts, err := repository.FindAll()
+	is.NoErr(err)
+	is.Equal(len(conflicts), 0)
+
+	given := []application.ImportConflict{
+		{
+			Reason: application.ConflictOverlap,
+			Session: session.Session{
+				StartTime: time.Date(2024, 4, 17, 9, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2024, 4, 17, 11, 0, 0, 0, time.UTC),
+				Project:   "flow",
+				Tags:      []string{"cli"},
+				Metadata:  map[string]string{session.ExternalIDMetadata: "toggl:3"},
+			},
+			Conflicting: []string{"abc"},
+			Resolution:  application.ResolutionAdjust,
+			Suggestion:  "adjust it",
+		},
+		{
+			Reason:     application.ConflictRunning,
+			Session:    session.Session{StartTime: time.Date(2024, 4, 17, 14, 0, 0, 0, time.UTC), Project: "flow", Tags: []string{}},
+			Resolution: application.ResolutionSkip,
+		},
+	}
+	is.NoErr(repository.Save(given))
+
+	conflicts, err = repository.FindAll()
+	is.NoErr(err)
+	is.Equal(conflicts, given)
+
+	is.NoErr(repository.Save(nil))
+	_, err = os.Stat(filepath.Join(folderPath, filesystem.ImportConflictsFilename))
+	is.True(os.IsNotExist(err))
+}
+
+func TestFileSystemImportConflictsRepository_InvalidReport(t *testing.T) {
+	is := is.New(t)
+	folderPath := t.TempDir()
+
+	repository := filesystem.NewFileSystemImportConflictsRepository(folderPath)
+	is.NoErr(os.WriteFile(filepath.Join(folderPath, filesystem.ImportConflictsFilename), []byte("{"), 0666))
+
+	_, err := repository.FindAll()
+	is.True(err != nil)
+}
+
+func TestFileSystemImportConflictsRepository_IgnoredBySessionRepository(t *testing.T) {
+	is := is.New(t)
+	folderPath := t.TempDir()
+
+	importConflictsRepository := filesystem.NewFileSystemImportConflictsRepository(folderPath)
+	sessionRepository := filesystem.NewFileSystemSessionRepository(folderPath)
+
+	is.NoErr(importConflictsRepository.Save([]application.ImportConflict{{Reason: application.ConflictRunning}}))
+
+	is.Equal(len(sessionRepository.FindAllSessions(nil)), 0)
+	is.Equal(len(sessionRepository.Diagnose()), 0)
+}
@@ -0,0 +1,61 @@
+package filesystem
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/TristanShz/flow/internal/domain/invoice"
+)
+
+// invoicesFilename holds the issued invoices, numbering the next ones
+const invoicesFilename = "invoices.json"
+
+type FileSystemInvoiceRepository struct {
+	FlowFolderPath string
+}
+
+func NewFileSystemInvoiceRepository(flowFolderPath string) FileSystemInvoiceRepository {
+	return FileSystemInvoiceRepository{
+		FlowFolderPath: flowFolderPath,
+	}
+}
+
+func (r *FileSystemInvoiceRepository) filePath() string {
+	return filepath.Join(r.FlowFolderPath, invoicesFilename)
+}
+
+func (r *FileSystemInvoiceRepository) Save(i invoice.Invoice) error {
+	invoices, err := r.FindAll()
+	if err != nil {
+		return err
+	}
+
+	marshaled, err := json.MarshalIndent(append(invoices, i), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(r.filePath(), marshaled, 0666, false)
+}
+
+// FindAll returns the invoices in the order they were issued
+func (r *FileSystemInvoiceRepository) FindAll() ([]invoice.Invoice, error) {
+	invoices := []invoice.Invoice{}
+
+	file, err := os.ReadFile(r.filePath())
+	if errors.Is(err, os.ErrNotExist) {
+		return invoices, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(file, &invoices); err != nil {
+		return nil, fmt.Errorf("invalid invoices in %v: %w", r.filePath(), err)
+	}
+
+	return invoices, nil
+}
@@ -0,0 +1,41 @@
+package filesystem_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/client"
+	"github.com/TristanShz/flow/internal/domain/invoice"
+	"github.com/TristanShz/flow/internal/infra/filesystem"
+	"github.com/matryer/is"
+)
+
+func TestFileSystemInvoiceRepository(t *testing.T) {
+	is := is.New(t)
+
+	repository := filesystem.NewFileSystemInvoiceRepository(t.TempDir())
+
+	invoices, err := repository.FindAll()
+	is.NoErr(err)
+	is.Equal(len(invoices), 0)
+
+	first := invoice.Invoice{
+		Sequence: 1,
+		Number:   "2024-001",
+		Date:     time.Date(2024, 4, 30, 0, 0, 0, 0, time.UTC),
+		Since:    time.Date(2024, 4, 2, 9, 0, 0, 0, time.UTC),
+		Until:    time.Date(2024, 4, 29, 18, 0, 0, 0, time.UTC),
+		Client:   client.Client{Name: "Acme"},
+		Lines:    []invoice.Line{{Project: "Website", Duration: 2 * time.Hour, HourlyRate: 80, Amount: 160}},
+		Duration: 2 * time.Hour,
+		Total:    160,
+		Sessions: []string{"1", "2"},
+	}
+	second := invoice.Invoice{Sequence: 2, Number: "2024-002", Client: client.Client{Name: "Globex"}, Lines: []invoice.Line{}, Sessions: []string{"3"}}
+	is.NoErr(repository.Save(first))
+	is.NoErr(repository.Save(second))
+
+	invoices, err = repository.FindAll()
+	is.NoErr(err)
+	is.Equal(invoices, []invoice.Invoice{first, second})
+}
@@ -0,0 +1,112 @@
+package filesystem
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/journal"
+	"github.com/TristanShz/flow/pkg/timerange"
+)
+
+const journalFilename = "journal.json"
+
+type FileSystemJournalRepository struct {
+	FlowFolderPath string
+}
+
+// journalEntryJSON stores the day as a date, so that an entry stays on its
+// day whatever the time zone it's read in
+type journalEntryJSON struct {
+	Day       string    `json:"day"`
+	Note      string    `json:"note"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func NewFileSystemJournalRepository(flowFolderPath string) FileSystemJournalRepository {
+	return FileSystemJournalRepository{
+		FlowFolderPath: flowFolderPath,
+	}
+}
+
+func (r *FileSystemJournalRepository) filePath() string {
+	return filepath.Join(r.FlowFolderPath, journalFilename)
+}
+
+// readEntries returns the entries of the journal file. An unreadable or
+// corrupted file is an error so that it's never overwritten, the entries with
+// a valid day are still returned along the error of an invalid one.
+func (r *FileSystemJournalRepository) readEntries() ([]journal.Entry, error) {
+	entries := []journal.Entry{}
+
+	file, err := os.ReadFile(r.filePath())
+	if errors.Is(err, os.ErrNotExist) {
+		return entries, nil
+	}
+	if err != nil {
+		return entries, fmt.Errorf("error while reading file %v: %w", journalFilename, err)
+	}
+
+	rawEntries := []journalEntryJSON{}
+	if err := json.Unmarshal(file, &rawEntries); err != nil {
+		return entries, fmt.Errorf("invalid journal data for file %v: %w", journalFilename, err)
+	}
+
+	invalid := []error{}
+	for _, raw := range rawEntries {
+		day, err := time.ParseInLocation(time.DateOnly, raw.Day, time.Local)
+		if err != nil {
+			invalid = append(invalid, fmt.Errorf("invalid day %v in file %v", raw.Day, journalFilename))
+			continue
+		}
+
+		entries = append(entries, journal.Entry{Day: day, Note: raw.Note, CreatedAt: raw.CreatedAt})
+	}
+
+	return entries, errors.Join(invalid...)
+}
+
+func (r *FileSystemJournalRepository) Save(entry journal.Entry) error {
+	entries, err := r.readEntries()
+	if err != nil {
+		return err
+	}
+	entries = append(entries, entry)
+	journal.Sort(entries)
+
+	rawEntries := []journalEntryJSON{}
+	for _, e := range entries {
+		rawEntries = append(rawEntries, journalEntryJSON{
+			Day:       e.Day.Format(time.DateOnly),
+			Note:      e.Note,
+			CreatedAt: e.CreatedAt,
+		})
+	}
+
+	marshaled, err := json.MarshalIndent(rawEntries, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(r.filePath(), marshaled, 0666, false)
+}
+
+func (r *FileSystemJournalRepository) FindAll(timeRange timerange.TimeRange) []journal.Entry {
+	all, err := r.readEntries()
+	if err != nil {
+		log.Printf("warning: skipping the journal entries which can't be read (%v)", err)
+	}
+
+	entries := []journal.Entry{}
+	for _, entry := range all {
+		if timeRange.Contains(entry.Day) {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries
+}
@@ -0,0 +1,82 @@
+package filesystem_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/journal"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra/filesystem"
+	"github.com/TristanShz/flow/pkg/timerange"
+	"github.com/matryer/is"
+)
+
+func TestFileSystemJournalRepository(t *testing.T) {
+	is := is.New(t)
+	folderPath := t.TempDir()
+
+	repository := filesystem.NewFileSystemJournalRepository(folderPath)
+
+	is.Equal(repository.FindAll(timerange.TimeRange{}), []journal.Entry{})
+
+	outage := journal.Entry{
+		Day:       time.Date(2024, 4, 13, 0, 0, 0, 0, time.Local),
+		Note:      "outage",
+		CreatedAt: time.Date(2024, 4, 13, 9, 0, 0, 0, time.UTC),
+	}
+	release := journal.Entry{
+		Day:       time.Date(2024, 4, 12, 0, 0, 0, 0, time.Local),
+		Note:      "shipped the release",
+		CreatedAt: time.Date(2024, 4, 12, 17, 0, 0, 0, time.UTC),
+	}
+	is.NoErr(repository.Save(outage))
+	is.NoErr(repository.Save(release))
+
+	is.Equal(repository.FindAll(timerange.TimeRange{}), []journal.Entry{release, outage})
+	is.Equal(repository.FindAll(timerange.NewDayTimeRange(time.Date(2024, 4, 13, 12, 0, 0, 0, time.Local))), []journal.Entry{outage})
+}
+
+func TestFileSystemJournalRepository_IgnoredBySessionRepository(t *testing.T) {
+	is := is.New(t)
+	folderPath := t.TempDir()
+
+	journalRepository := filesystem.NewFileSystemJournalRepository(folderPath)
+	sessionRepository := filesystem.NewFileSystemSessionRepository(folderPath)
+
+	is.NoErr(journalRepository.Save(journal.Entry{Day: time.Date(2024, 4, 17, 0, 0, 0, 0, time.Local), Note: "retro"}))
+	is.NoErr(sessionRepository.Save(session.Session{
+		Id:        "1",
+		StartTime: time.Date(2024, 4, 17, 19, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+	}))
+
+	is.Equal(len(sessionRepository.FindAllSessions(nil)), 1)
+	last, err := sessionRepository.FindLastSession()
+	is.NoErr(err)
+	is.Equal(last.Id, "1")
+}
+
+func TestFileSystemJournalRepository_CorruptedFile(t *testing.T) {
+	is := is.New(t)
+	folderPath := t.TempDir()
+	content := `[{"day": "2024-04-12", "note": "shipped the release"}, {"day": "someday", "note": "outage"}]`
+	is.NoErr(os.WriteFile(filepath.Join(folderPath, "journal.json"), []byte(content), 0666))
+
+	repository := filesystem.NewFileSystemJournalRepository(folderPath)
+
+	// the entry with an invalid day is skipped with a warning
+	is.Equal(repository.FindAll(timerange.TimeRange{}), []journal.Entry{
+		{Day: time.Date(2024, 4, 12, 0, 0, 0, 0, time.Local), Note: "shipped the release"},
+	})
+
+	// the file isn't overwritten without it
+	is.True(repository.Save(journal.Entry{Day: time.Date(2024, 4, 13, 0, 0, 0, 0, time.Local), Note: "retro"}) != nil)
+	written, err := os.ReadFile(filepath.Join(folderPath, "journal.json"))
+	is.NoErr(err)
+	is.Equal(string(written), content)
+
+	is.NoErr(os.WriteFile(filepath.Join(folderPath, "journal.json"), []byte("[{"), 0666))
+	is.Equal(repository.FindAll(timerange.TimeRange{}), []journal.Entry{})
+}
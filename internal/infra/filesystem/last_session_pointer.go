@@ -0,0 +1,60 @@
+package filesystem
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// lastSessionPointerFilename holds the filename of the newest session along
+// with the modification time of the flow folder it was found at, so that
+// 'flow status' reads a single session file instead of listing the folder.
+// Adding, removing or renaming a session file changes the modification time
+// of the folder, which makes the pointer stale.
+const lastSessionPointerFilename = "last_session"
+
+// racyFolderDelay is how long a flow folder must be left unchanged before it's
+// pointed to: a folder changed twice within the granularity of its
+// modification time would keep the same one
+const racyFolderDelay = time.Second
+
+func (r *FileSystemSessionRepository) lastSessionPointerPath() string {
+	return filepath.Join(r.FlowFolderPath, lastSessionPointerFilename)
+}
+
+// readLastSessionPointer returns the filename of the newest session, false
+// when there is no pointer or when the flow folder changed since it was written
+func (r *FileSystemSessionRepository) readLastSessionPointer() (string, bool) {
+	folderInfo, err := os.Stat(r.FlowFolderPath)
+	if err != nil {
+		return "", false
+	}
+
+	raw, err := os.ReadFile(r.lastSessionPointerPath())
+	if err != nil {
+		return "", false
+	}
+
+	modTime, fileName, ok := bytes.Cut(bytes.TrimSpace(raw), []byte(" "))
+	if !ok || len(fileName) == 0 || string(modTime) != strconv.FormatInt(folderInfo.ModTime().UnixNano(), 10) {
+		return "", false
+	}
+
+	return string(fileName), true
+}
+
+// writeLastSessionPointer points to the newest session of the flow folder as
+// it was at folderModTime. The pointer is only a cache: failing to write it is
+// ignored.
+func (r *FileSystemSessionRepository) writeLastSessionPointer(folderModTime time.Time, fileName string) {
+	if r.ReadOnly || time.Since(folderModTime) < racyFolderDelay {
+		return
+	}
+
+	// the pointer is written in place, renaming it over the previous one would
+	// change the modification time of the folder
+	content := strconv.FormatInt(folderModTime.UnixNano(), 10) + " " + fileName + "\n"
+	os.WriteFile(r.lastSessionPointerPath(), []byte(content), 0666)
+}
@@ -0,0 +1,123 @@
+package filesystem
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/TristanShz/flow/internal/application"
+)
+
+// operationsFilename holds the last operations which changed the sessions
+const operationsFilename = "operations.json"
+
+// FileSystemOperationLog keeps the operations in a JSON file of the flow
+// folder. The operations hold copies of the sessions, the file is encrypted
+// like the session files when there is a cipher.
+type FileSystemOperationLog struct {
+	FlowFolderPath string
+	// Limit is the number of operations kept
+	Limit  int
+	Cipher SessionCipher
+}
+
+func NewFileSystemOperationLog(flowFolderPath string, limit int) FileSystemOperationLog {
+	return FileSystemOperationLog{
+		FlowFolderPath: flowFolderPath,
+		Limit:          limit,
+	}
+}
+
+func (l *FileSystemOperationLog) filePath() string {
+	return filepath.Join(l.FlowFolderPath, operationsFilename)
+}
+
+func (l *FileSystemOperationLog) Record(operation application.Operation) error {
+	operations, err := l.FindAll()
+	if err != nil {
+		return err
+	}
+
+	index := slices.IndexFunc(operations, func(o application.Operation) bool {
+		return o.Id == operation.Id
+	})
+	if index == -1 {
+		operations = append(operations, operation)
+	} else {
+		operations[index] = operation
+	}
+
+	if len(operations) > l.Limit {
+		operations = operations[len(operations)-l.Limit:]
+	}
+
+	return l.write(operations)
+}
+
+func (l *FileSystemOperationLog) FindAll() ([]application.Operation, error) {
+	operations := []application.Operation{}
+
+	content, err := os.ReadFile(l.filePath())
+	if errors.Is(err, os.ErrNotExist) {
+		return operations, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if IsEncrypted(content) {
+		if l.Cipher == nil {
+			return nil, fmt.Errorf("%w: no encryption configured", ErrCantDecrypt)
+		}
+		if content, err = l.Cipher.Decrypt(content); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrCantDecrypt, err)
+		}
+	}
+
+	if err := json.Unmarshal(content, &operations); err != nil {
+		return nil, fmt.Errorf("invalid operations in %v: %w", l.filePath(), err)
+	}
+
+	return operations, nil
+}
+
+func (l *FileSystemOperationLog) Remove(id string) error {
+	operations, err := l.FindAll()
+	if err != nil {
+		return err
+	}
+
+	return l.write(slices.DeleteFunc(operations, func(o application.Operation) bool {
+		return o.Id == id
+	}))
+}
+
+func (l *FileSystemOperationLog) write(operations []application.Operation) error {
+	if len(operations) == 0 {
+		err := os.Remove(l.filePath())
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	content, err := json.MarshalIndent(operations, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if l.Cipher != nil {
+		if content, err = l.Cipher.Encrypt(content); err != nil {
+			return err
+		}
+	}
+
+	if err := os.MkdirAll(l.FlowFolderPath, 0755); err != nil {
+		return err
+	}
+
+	return writeFileAtomic(l.filePath(), content, 0600, false)
+}
@@ -0,0 +1,48 @@
+package filesystem_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra/filesystem"
+	"github.com/matryer/is"
+)
+
+func TestFileSystemOperationLog(t *testing.T) {
+	is := is.New(t)
+
+	folder := t.TempDir()
+	operationLog := filesystem.NewFileSystemOperationLog(folder, 2)
+	operationLog.Cipher = base64Cipher{}
+
+	operations, err := operationLog.FindAll()
+	is.NoErr(err)
+	is.Equal(len(operations), 0)
+
+	s := session.Session{Id: "1", StartTime: time.Date(2024, time.April, 15, 9, 0, 0, 0, time.UTC), Project: "Flow", Note: "confidential"}
+	first := application.Operation{Id: "a", At: time.Date(2024, time.April, 15, 9, 0, 0, 0, time.UTC), Command: "start", Acquired: "1"}
+	second := application.Operation{Id: "b", At: time.Date(2024, time.April, 15, 10, 0, 0, 0, time.UTC), Command: "stop"}
+	third := application.Operation{Id: "c", At: time.Date(2024, time.April, 15, 11, 0, 0, 0, time.UTC), Command: "delete"}
+	is.NoErr(operationLog.Record(first))
+	is.NoErr(operationLog.Record(second))
+	second.Changes = []application.SessionChange{{Before: &s}}
+	is.NoErr(operationLog.Record(second)) // replaces the operation
+	is.NoErr(operationLog.Record(third))  // forgets the oldest one
+
+	operations, err = operationLog.FindAll()
+	is.NoErr(err)
+	is.Equal(operations, []application.Operation{second, third})
+
+	content, err := os.ReadFile(filepath.Join(folder, "operations.json"))
+	is.NoErr(err)
+	is.True(filesystem.IsEncrypted(content))
+
+	is.NoErr(operationLog.Remove("b"))
+	is.NoErr(operationLog.Remove("c"))
+	_, err = os.Stat(filepath.Join(folder, "operations.json"))
+	is.True(os.IsNotExist(err))
+}
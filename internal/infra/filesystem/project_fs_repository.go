@@ -0,0 +1,121 @@
+package filesystem
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"github.com/TristanShz/flow/internal/domain/project"
+)
+
+const projectsFilename = "projects.json"
+
+type FileSystemProjectRepository struct {
+	FlowFolderPath string
+}
+
+func NewFileSystemProjectRepository(flowFolderPath string) FileSystemProjectRepository {
+	return FileSystemProjectRepository{
+		FlowFolderPath: flowFolderPath,
+	}
+}
+
+func (r *FileSystemProjectRepository) filePath() string {
+	return filepath.Join(r.FlowFolderPath, projectsFilename)
+}
+
+// readProjects returns the projects of the projects file, an unreadable or
+// corrupted file is an error so that it's never overwritten
+func (r *FileSystemProjectRepository) readProjects() ([]project.Project, error) {
+	projects := []project.Project{}
+
+	file, err := os.ReadFile(r.filePath())
+	if errors.Is(err, os.ErrNotExist) {
+		return projects, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error while reading file %v: %w", projectsFilename, err)
+	}
+
+	if err := json.Unmarshal(file, &projects); err != nil {
+		return nil, fmt.Errorf("invalid projects data for file %v: %w", projectsFilename, err)
+	}
+
+	return projects, nil
+}
+
+// findProjects returns the projects to look them up, the settings of the
+// projects are skipped with a warning when the projects file can't be read
+func (r *FileSystemProjectRepository) findProjects() []project.Project {
+	projects, err := r.readProjects()
+	if err != nil {
+		log.Printf("warning: skipping the settings of the projects (%v)", err)
+		return []project.Project{}
+	}
+
+	return projects
+}
+
+func (r *FileSystemProjectRepository) Save(p project.Project) error {
+	projects, err := r.readProjects()
+	if err != nil {
+		return err
+	}
+
+	projectIndex := slices.IndexFunc(projects, func(existing project.Project) bool {
+		return existing.Name == p.Name
+	})
+
+	if projectIndex == -1 {
+		projects = append(projects, p)
+	} else {
+		projects[projectIndex] = p
+	}
+
+	return r.writeProjects(projects)
+}
+
+func (r *FileSystemProjectRepository) writeProjects(projects []project.Project) error {
+	sort.Slice(projects, func(i, j int) bool {
+		return projects[i].Name < projects[j].Name
+	})
+
+	marshaled, err := json.MarshalIndent(projects, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(r.filePath(), marshaled, 0666, false)
+}
+
+func (r *FileSystemProjectRepository) FindByName(name string) *project.Project {
+	for _, p := range r.findProjects() {
+		if p.Name == name {
+			return &p
+		}
+	}
+
+	return nil
+}
+
+func (r *FileSystemProjectRepository) FindAll() []project.Project {
+	return r.findProjects()
+}
+
+func (r *FileSystemProjectRepository) Delete(name string) error {
+	projects, err := r.readProjects()
+	if err != nil {
+		return err
+	}
+
+	projects = slices.DeleteFunc(projects, func(p project.Project) bool {
+		return p.Name == name
+	})
+
+	return r.writeProjects(projects)
+}
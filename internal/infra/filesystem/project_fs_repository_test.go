@@ -0,0 +1,63 @@
+package filesystem_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/project"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra/filesystem"
+	"github.com/matryer/is"
+)
+
+func TestFileSystemProjectRepository(t *testing.T) {
+	is := is.New(t)
+	folderPath := t.TempDir()
+
+	repository := filesystem.NewFileSystemProjectRepository(folderPath)
+
+	is.Equal(repository.FindAll(), []project.Project{})
+	is.Equal(repository.FindByName("Flow"), nil)
+
+	is.NoErr(repository.Save(project.Project{Name: "MyTodo", OnLock: project.OnLockStop}))
+	is.NoErr(repository.Save(project.Project{Name: "Flow", OnLock: project.OnLockStop}))
+	is.NoErr(repository.Save(project.Project{Name: "Flow", OnLock: project.OnLockPause}))
+
+	is.Equal(repository.FindAll(), []project.Project{
+		{Name: "Flow", OnLock: project.OnLockPause},
+		{Name: "MyTodo", OnLock: project.OnLockStop},
+	})
+	is.Equal(*repository.FindByName("MyTodo"), project.Project{Name: "MyTodo", OnLock: project.OnLockStop})
+
+	is.NoErr(repository.Delete("MyTodo"))
+	is.NoErr(repository.Delete("Unknown"))
+	is.Equal(repository.FindAll(), []project.Project{{Name: "Flow", OnLock: project.OnLockPause}})
+
+	sessionRepository := filesystem.NewFileSystemSessionRepository(folderPath)
+	is.NoErr(sessionRepository.Save(session.Session{
+		Id:        "1",
+		StartTime: time.Date(2024, 4, 17, 19, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+	}))
+
+	is.Equal(len(sessionRepository.FindAllSessions(nil)), 1) // projects file isn't read as a session
+}
+
+func TestFileSystemProjectRepository_CorruptedFile(t *testing.T) {
+	is := is.New(t)
+	folderPath := t.TempDir()
+	is.NoErr(os.WriteFile(filepath.Join(folderPath, "projects.json"), []byte("[{"), 0666))
+
+	repository := filesystem.NewFileSystemProjectRepository(folderPath)
+
+	is.Equal(repository.FindAll(), []project.Project{}) // skipped with a warning
+	is.Equal(repository.FindByName("Flow"), nil)
+	is.True(repository.Save(project.Project{Name: "Flow"}) != nil) // the file isn't overwritten
+	is.True(repository.Delete("Flow") != nil)
+
+	content, err := os.ReadFile(filepath.Join(folderPath, "projects.json"))
+	is.NoErr(err)
+	is.Equal(string(content), "[{")
+}
@@ -0,0 +1,66 @@
+package filesystem
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// SessionCipher encrypts the session files before they are written and
+// decrypts them once read, e.g. to the age recipients of the config
+type SessionCipher interface {
+	Encrypt(plaintext []byte) ([]byte, error)
+	Decrypt(ciphertext []byte) ([]byte, error)
+}
+
+// ErrCantDecrypt is returned for an encrypted session file that can't be
+// decrypted. Unlike a corrupted file, it's never quarantined: it may belong to
+// someone else sharing the flow folder, or the identity may be missing.
+var ErrCantDecrypt = errors.New("can't decrypt the session file")
+
+// encryptedHeaders start the binary and armored age files, and the files
+// encrypted with a passphrase
+var encryptedHeaders = [][]byte{
+	[]byte("age-encryption.org/"),
+	[]byte("-----BEGIN AGE ENCRYPTED FILE-----"),
+	[]byte("-----BEGIN FLOW ENCRYPTED FILE-----"),
+}
+
+// IsEncrypted tells if the content of a session file is encrypted
+func IsEncrypted(content []byte) bool {
+	content = bytes.TrimSpace(content)
+	for _, header := range encryptedHeaders {
+		if bytes.HasPrefix(content, header) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// decrypt returns the plaintext of an encrypted session file, plaintext files
+// are returned as they are so that a store can be encrypted progressively
+func (r *FileSystemSessionRepository) decrypt(content []byte) ([]byte, error) {
+	if !IsEncrypted(content) {
+		return content, nil
+	}
+
+	if r.Cipher == nil {
+		return nil, fmt.Errorf("%w: no encryption configured", ErrCantDecrypt)
+	}
+
+	plaintext, err := r.Cipher.Decrypt(content)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCantDecrypt, err)
+	}
+
+	return plaintext, nil
+}
+
+func (r *FileSystemSessionRepository) encrypt(plaintext []byte) ([]byte, error) {
+	if r.Cipher == nil {
+		return plaintext, nil
+	}
+
+	return r.Cipher.Encrypt(plaintext)
+}
@@ -0,0 +1,155 @@
+package filesystem_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra/filesystem"
+	"github.com/matryer/is"
+)
+
+const fakeArmorHeader = "-----BEGIN AGE ENCRYPTED FILE-----\n"
+
+// base64Cipher stands for age, it only hides the session behind the armor
+// header of age files
+type base64Cipher struct {
+	failDecrypt bool
+}
+
+func (c base64Cipher) Encrypt(plaintext []byte) ([]byte, error) {
+	return []byte(fakeArmorHeader + base64.StdEncoding.EncodeToString(plaintext)), nil
+}
+
+func (c base64Cipher) Decrypt(ciphertext []byte) ([]byte, error) {
+	if c.failDecrypt {
+		return nil, errors.New("no identity matched any of the recipients")
+	}
+
+	return base64.StdEncoding.DecodeString(string(bytes.TrimPrefix(ciphertext, []byte(fakeArmorHeader))))
+}
+
+func TestFileSystemSessionRepository_Encryption(t *testing.T) {
+	is := is.New(t)
+
+	folder := t.TempDir()
+	plain := session.Session{
+		Id:        "plain1",
+		StartTime: time.Date(2024, time.April, 13, 9, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, time.April, 13, 10, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+	}
+	encrypted := session.Session{
+		Id:        "secret1",
+		StartTime: time.Date(2024, time.April, 14, 9, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, time.April, 14, 10, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+		Note:      "confidential",
+	}
+
+	repository := filesystem.NewFileSystemSessionRepository(folder)
+	is.NoErr(repository.Save(plain))
+
+	repository.Cipher = base64Cipher{}
+	is.NoErr(repository.Save(encrypted))
+
+	// the project is left out of the filename
+	filename := filesystem.SessionFilename{Id: encrypted.Id, StartTime: encrypted.StartTime}
+	content, err := os.ReadFile(filepath.Join(folder, filename.String()))
+	is.NoErr(err)
+	is.True(filesystem.IsEncrypted(content))
+	is.True(!bytes.Contains(content, []byte("confidential"))) // the note isn't stored in plaintext
+
+	// plaintext sessions are still read along the encrypted ones
+	is.Equal(len(repository.FindAllSessions(nil)), 2)
+	is.Equal(len(repository.FindAllSessions(&application.SessionsFilters{Project: "Flow"})), 2)
+	is.Equal(repository.FindAllSessions(&application.SessionsFilters{Project: "Other"}), []session.Session{})
+	found, err := repository.FindById(encrypted.Id)
+	is.NoErr(err)
+	is.Equal(found.Note, "confidential")
+	is.Equal(repository.FindAllSessionMetadata(&application.SessionsFilters{Project: "Flow", Order: application.OrderDescending, Limit: 1}), []application.SessionMetadata{
+		{Id: encrypted.Id, Project: "Flow", StartTime: time.Unix(encrypted.StartTime.Unix(), 0)},
+	})
+
+	// without the identity, the encrypted sessions are skipped but kept
+	repository.Cipher = base64Cipher{failDecrypt: true}
+	repository.AutoQuarantine = true
+	is.Equal(repository.FindAllSessions(nil), []session.Session{plain})
+	_, err = os.Stat(filepath.Join(folder, filename.String()))
+	is.NoErr(err)
+
+	repository.Cipher = nil
+	found, err = repository.FindById(encrypted.Id)
+	is.NoErr(err)
+	is.Equal(found, nil)
+	is.Equal(len(repository.Diagnose()), 0) // encrypted files aren't reported as corrupted
+}
+
+func TestIsEncrypted(t *testing.T) {
+	tt := []struct {
+		content string
+		want    bool
+	}{
+		{content: "-----BEGIN AGE ENCRYPTED FILE-----\nYWdlLWVuY3J5cHRpb24ub3JnL3YxCg==\n", want: true},
+		{content: "age-encryption.org/v1\n-> X25519 abc\n", want: true},
+		{content: "-----BEGIN FLOW ENCRYPTED FILE-----\nc2FsdA==\n-----END FLOW ENCRYPTED FILE-----\n", want: true},
+		{content: `{"id": "1"}`, want: false},
+		{content: "", want: false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.content, func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(filesystem.IsEncrypted([]byte(tc.content)), tc.want)
+		})
+	}
+}
+
+func TestFileSystemSessionRepository_EncryptedIndex(t *testing.T) {
+	is := is.New(t)
+
+	folder := t.TempDir()
+	repository := filesystem.NewFileSystemSessionRepository(folder)
+	is.NoErr(repository.Save(session.Session{
+		Id:        "plain1",
+		StartTime: time.Date(2024, time.April, 13, 9, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, time.April, 13, 10, 0, 0, 0, time.UTC),
+		Project:   "Initech",
+		Tags:      []string{"layoffs"},
+	}))
+	is.Equal(repository.FindAllProjects(), []string{"Initech"})
+
+	repository.Cipher = base64Cipher{}
+	secret := session.Session{
+		Id:        "secret1",
+		StartTime: time.Date(2024, time.April, 14, 9, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, time.April, 14, 10, 0, 0, 0, time.UTC),
+		Project:   "Globex",
+		Tags:      []string{"merger"},
+	}
+	is.NoErr(repository.Save(secret))
+
+	// the projects and the tags are read from the session files
+	is.Equal(repository.FindAllProjects(), []string{"Initech", "Globex"})
+	is.Equal(repository.FindAllProjectTags("Globex"), []string{"merger"})
+	is.Equal(repository.FindAllSessions(&application.SessionsFilters{Tags: []string{"merger"}}), []session.Session{secret})
+
+	content, err := os.ReadFile(filepath.Join(folder, "index.db"))
+	is.NoErr(err)
+	for _, label := range []string{"Initech", "layoffs", "Globex", "merger"} {
+		is.True(!bytes.Contains(content, []byte(label))) // the index holds no project nor tag
+	}
+
+	// the index holds them again once the sessions aren't encrypted
+	repository.Cipher = nil
+	repository.FindAllSessions(&application.SessionsFilters{Tags: []string{"layoffs"}})
+	content, err = os.ReadFile(filepath.Join(folder, "index.db"))
+	is.NoErr(err)
+	is.True(bytes.Contains(content, []byte("layoffs")))
+}
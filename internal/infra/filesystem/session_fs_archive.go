@@ -0,0 +1,446 @@
+package filesystem
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/pkg/timerange"
+)
+
+// ArchiveFolder is the sub folder of the flow folder where the old session
+// files are packed, in a gzipped tar file by month like 2023-05.tar.gz
+const ArchiveFolder = "archive"
+
+const archiveExtension = ".tar.gz"
+
+// archiveManifestFilename lists the archived sessions, so that a session is
+// found without opening every archive
+const archiveManifestFilename = "manifest.json"
+
+type archiveEntry struct {
+	Month   string
+	Project string
+	Tags    []string `json:",omitempty"`
+}
+
+// archiveFile is a session file packed in an archive
+type archiveFile struct {
+	name    string
+	content []byte
+}
+
+// archiveMonth returns the month of the archive of a session started at the
+// given time
+func archiveMonth(startTime time.Time) string {
+	return startTime.Local().Format("2006-01")
+}
+
+func (r *FileSystemSessionRepository) archivePath(name string) string {
+	return filepath.Join(r.FlowFolderPath, ArchiveFolder, name)
+}
+
+// readManifest returns the archive entries by session id, it's encrypted
+// like the session files as it holds their tags
+func (r *FileSystemSessionRepository) readManifest() (map[string]archiveEntry, error) {
+	manifest := map[string]archiveEntry{}
+
+	content, err := os.ReadFile(r.archivePath(archiveManifestFilename))
+	if errors.Is(err, os.ErrNotExist) {
+		return manifest, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if content, err = r.decrypt(content); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid archive manifest %v: %w", r.archivePath(archiveManifestFilename), err)
+	}
+
+	return manifest, nil
+}
+
+func (r *FileSystemSessionRepository) writeManifest(manifest map[string]archiveEntry) error {
+	content, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if content, err = r.encrypt(content); err != nil {
+		return err
+	}
+
+	return writeFileAtomic(r.archivePath(archiveManifestFilename), content, 0600, r.SyncDir)
+}
+
+// readArchive returns the session files of the archive of the month, in the
+// order they were packed
+func (r *FileSystemSessionRepository) readArchive(month string) ([]archiveFile, error) {
+	file, err := os.Open(r.archivePath(month + archiveExtension))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("invalid archive %v: %w", month+archiveExtension, err)
+	}
+
+	files := []archiveFile{}
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid archive %v: %w", month+archiveExtension, err)
+		}
+
+		content, err := io.ReadAll(tarReader)
+		if err != nil {
+			return nil, fmt.Errorf("invalid archive %v: %w", month+archiveExtension, err)
+		}
+
+		files = append(files, archiveFile{name: header.Name, content: content})
+	}
+
+	return files, nil
+}
+
+// writeArchive replaces the archive of the month with the files, the archive
+// is removed when there are none
+func (r *FileSystemSessionRepository) writeArchive(month string, files []archiveFile) error {
+	path := r.archivePath(month + archiveExtension)
+	if len(files) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	buffer := &bytes.Buffer{}
+	gzipWriter := gzip.NewWriter(buffer)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for _, file := range files {
+		header := &tar.Header{Name: file.name, Mode: 0666, Size: int64(len(file.content)), ModTime: time.Now()}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := tarWriter.Write(file.content); err != nil {
+			return err
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		return err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return err
+	}
+
+	return writeFileAtomic(path, buffer.Bytes(), 0666, r.SyncDir)
+}
+
+// Archive packs the ended sessions started before the given time into the
+// archives of their month, then removes their files from the flow folder
+func (r *FileSystemSessionRepository) Archive(before time.Time) ([]application.ArchivedMonth, error) {
+	fileInfos, err := r.readFlowFolder()
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, err := r.readManifest()
+	if err != nil {
+		return nil, err
+	}
+
+	packed := map[string][]archiveFile{}
+	months := []string{}
+	for _, fileInfo := range fileInfos {
+		sessionFilename, _ := r.parseSessionFileName(fileInfo.Name())
+		if !sessionFilename.StartTime.Before(before) {
+			continue
+		}
+
+		content, err := os.ReadFile(filepath.Join(r.FlowFolderPath, fileInfo.Name()))
+		if err != nil {
+			return nil, err
+		}
+		s, err := r.readSessionFile(fileInfo.Name())
+		if err != nil {
+			r.skipCorruptedFile(fileInfo.Name(), err)
+			continue
+		}
+		// the sessions in progress and the unstopped ones may still change
+		if s.EndTime.IsZero() {
+			continue
+		}
+
+		month := archiveMonth(s.StartTime)
+		if !slices.Contains(months, month) {
+			months = append(months, month)
+		}
+		packed[month] = append(packed[month], archiveFile{name: fileInfo.Name(), content: content})
+		manifest[s.Id] = archiveEntry{Month: month, Project: s.Project, Tags: s.Tags}
+	}
+
+	if len(months) == 0 {
+		return []application.ArchivedMonth{}, nil
+	}
+
+	if err := os.MkdirAll(filepath.Join(r.FlowFolderPath, ArchiveFolder), 0777); err != nil {
+		return nil, err
+	}
+
+	slices.Sort(months)
+	archived := []application.ArchivedMonth{}
+	for _, month := range months {
+		files, err := r.readArchive(month)
+		if err != nil {
+			return nil, err
+		}
+
+		// a session archived again, e.g. after a crash, replaces its copy
+		for _, file := range packed[month] {
+			files = slices.DeleteFunc(files, func(f archiveFile) bool {
+				return f.name == file.name
+			})
+		}
+
+		if err := r.writeArchive(month, append(files, packed[month]...)); err != nil {
+			return nil, err
+		}
+		archived = append(archived, application.ArchivedMonth{Month: month, Sessions: len(packed[month])})
+	}
+
+	if err := r.writeManifest(manifest); err != nil {
+		return nil, err
+	}
+
+	// the session files are only removed once they're archived, a crash
+	// leaves them in both places and the flow folder wins
+	for _, month := range months {
+		for _, file := range packed[month] {
+			if err := os.Remove(filepath.Join(r.FlowFolderPath, file.name)); err != nil && !os.IsNotExist(err) {
+				return nil, err
+			}
+			r.unindexSession(file.name)
+		}
+	}
+
+	return archived, nil
+}
+
+// archivedMonths returns the months of the archives which may hold sessions
+// of the time range
+func (r *FileSystemSessionRepository) archivedMonths(timeRange timerange.TimeRange) []string {
+	entries, err := os.ReadDir(filepath.Join(r.FlowFolderPath, ArchiveFolder))
+	if err != nil {
+		return nil
+	}
+
+	months := []string{}
+	for _, entry := range entries {
+		month, ok := strings.CutSuffix(entry.Name(), archiveExtension)
+		if !ok {
+			continue
+		}
+
+		monthStart, err := time.ParseInLocation("2006-01", month, time.Local)
+		if err != nil {
+			continue
+		}
+		monthEnd := monthStart.AddDate(0, 1, 0)
+		if (!timeRange.Until.IsZero() && monthStart.After(timeRange.Until)) || (!timeRange.Since.IsZero() && !monthEnd.After(timeRange.Since)) {
+			continue
+		}
+
+		months = append(months, month)
+	}
+
+	return months
+}
+
+// readArchiveNames returns the names of the session files of the archive of
+// the month, without holding their content
+func (r *FileSystemSessionRepository) readArchiveNames(month string) ([]string, error) {
+	file, err := os.Open(r.archivePath(month + archiveExtension))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("invalid archive %v: %w", month+archiveExtension, err)
+	}
+
+	names := []string{}
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid archive %v: %w", month+archiveExtension, err)
+		}
+
+		names = append(names, header.Name)
+	}
+
+	return names, nil
+}
+
+// archivedSessionFiles returns the files of the archived sessions whose
+// filenames match the filters, except the ones whose id is in skipped. Their
+// tags are only checked once they're read, and their content is only read
+// then, see archiveCache.
+func (r *FileSystemSessionRepository) archivedSessionFiles(filters *application.SessionsFilters, skipped map[string]bool) []sessionFile {
+	timeRange := timerange.TimeRange{}
+	if filters != nil {
+		timeRange = filters.Timerange
+	}
+
+	sessionFiles := []sessionFile{}
+	for _, month := range r.archivedMonths(timeRange) {
+		names, err := r.readArchiveNames(month)
+		if err != nil {
+			r.skipCorruptedFile(filepath.Join(ArchiveFolder, month+archiveExtension), err)
+			continue
+		}
+
+		for _, name := range names {
+			sessionFilename, err := r.parseSessionFileName(name)
+			if err != nil || skipped[sessionFilename.Id] {
+				continue
+			}
+
+			if filters != nil && !filters.Timerange.IsZero() && !filters.Timerange.Contains(sessionFilename.StartTime) {
+				continue
+			}
+			if filters != nil && filters.Project != "" && !sessionFilename.Sealed() && !sessionFilename.MatchProject(filters.Project) {
+				continue
+			}
+
+			sessionFiles = append(sessionFiles, sessionFile{name: name, startTime: sessionFilename.StartTime, month: month})
+		}
+	}
+
+	return sessionFiles
+}
+
+func (r *FileSystemSessionRepository) archivedSession(file archiveFile) (*session.Session, error) {
+	content, err := r.decrypt(file.content)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.rawFileToSession(content)
+}
+
+// findArchivedSession returns the archived session having the id
+func (r *FileSystemSessionRepository) findArchivedSession(id string) (*session.Session, archiveFile, bool) {
+	manifest, err := r.readManifest()
+	if err != nil {
+		return nil, archiveFile{}, false
+	}
+
+	entry, ok := manifest[id]
+	if !ok {
+		return nil, archiveFile{}, false
+	}
+
+	files, err := r.readArchive(entry.Month)
+	if err != nil {
+		return nil, archiveFile{}, false
+	}
+
+	for _, file := range files {
+		if sessionFilename, err := r.parseSessionFileName(file.name); err != nil || sessionFilename.Id != id {
+			continue
+		}
+
+		s, err := r.archivedSession(file)
+		if err != nil {
+			r.skipCorruptedFile(filepath.Join(ArchiveFolder, file.name), err)
+			return nil, archiveFile{}, false
+		}
+
+		return s, file, true
+	}
+
+	return nil, archiveFile{}, false
+}
+
+// unarchive removes the session having the id from its archive, it does
+// nothing when the session isn't archived
+func (r *FileSystemSessionRepository) unarchive(id string) error {
+	manifest, err := r.readManifest()
+	if err != nil {
+		return err
+	}
+
+	entry, ok := manifest[id]
+	if !ok {
+		return nil
+	}
+
+	files, err := r.readArchive(entry.Month)
+	if err != nil {
+		return err
+	}
+
+	files = slices.DeleteFunc(files, func(file archiveFile) bool {
+		sessionFilename, err := r.parseSessionFileName(file.name)
+		return err == nil && sessionFilename.Id == id
+	})
+	if err := r.writeArchive(entry.Month, files); err != nil {
+		return err
+	}
+
+	delete(manifest, id)
+
+	return r.writeManifest(manifest)
+}
+
+// trashArchived moves the archived session having the id to the trash, it
+// returns false when the session isn't archived
+func (r *FileSystemSessionRepository) trashArchived(id string) (bool, error) {
+	_, file, ok := r.findArchivedSession(id)
+	if !ok {
+		return false, nil
+	}
+
+	// the file goes back to the flow folder to be trashed like the others
+	if err := writeFileAtomic(filepath.Join(r.FlowFolderPath, file.name), file.content, 0666, r.SyncDir); err != nil {
+		return false, err
+	}
+	if err := r.trash(file.name); err != nil {
+		return false, err
+	}
+
+	return true, r.unarchive(id)
+}
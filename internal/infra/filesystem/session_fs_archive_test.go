@@ -0,0 +1,78 @@
+package filesystem_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra/filesystem"
+	"github.com/TristanShz/flow/pkg/timerange"
+	"github.com/matryer/is"
+)
+
+func TestFileSystemSessionRepository_Archive(t *testing.T) {
+	is := is.New(t)
+
+	at := func(month time.Month, day int, hour int) time.Time {
+		return time.Date(2023, month, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	repository := filesystem.NewFileSystemSessionRepository(t.TempDir())
+	may := session.Session{Id: "1", StartTime: at(time.May, 2, 9), EndTime: at(time.May, 2, 10), Project: "Flow", Tags: []string{"review"}}
+	lateMay := session.Session{Id: "2", StartTime: at(time.May, 25, 9), EndTime: at(time.May, 25, 10), Project: "Intranet"}
+	june := session.Session{Id: "3", StartTime: at(time.June, 5, 9), EndTime: at(time.June, 5, 10), Project: "Flow"}
+	unstopped := session.Session{Id: "4", StartTime: at(time.April, 3, 9), Project: "Flow"}
+	recent := session.Session{Id: "5", StartTime: at(time.September, 1, 9), EndTime: at(time.September, 1, 10), Project: "Flow"}
+	for _, s := range []session.Session{may, lateMay, june, unstopped, recent} {
+		is.NoErr(repository.Save(s))
+	}
+	is.NoErr(repository.Delete("4"))
+
+	archived, err := repository.Archive(at(time.July, 1, 0))
+	is.NoErr(err)
+	is.Equal(archived, []application.ArchivedMonth{{Month: "2023-05", Sessions: 2}, {Month: "2023-06", Sessions: 1}})
+
+	entries, err := os.ReadDir(filepath.Join(repository.FlowFolderPath, filesystem.ArchiveFolder))
+	is.NoErr(err)
+	is.Equal(len(entries), 3) // 2023-05.tar.gz, 2023-06.tar.gz and manifest.json
+
+	// archiving again finds nothing left to archive
+	archived, err = repository.Archive(at(time.July, 1, 0))
+	is.NoErr(err)
+	is.Equal(archived, []application.ArchivedMonth{})
+
+	is.Equal(repository.FindAllSessions(nil), []session.Session{may, lateMay, june, recent})
+	is.Equal(repository.FindAllSessions(&application.SessionsFilters{
+		Timerange: timerange.TimeRange{Since: at(time.May, 15, 0), Until: at(time.August, 1, 0)},
+	}), []session.Session{lateMay, june})
+	is.Equal(repository.FindAllSessions(&application.SessionsFilters{Project: "Flow", Tags: []string{"review"}}), []session.Session{may})
+	found, err := repository.FindById("2")
+	is.NoErr(err)
+	is.Equal(*found, lateMay)
+	is.Equal(repository.FindAllProjects(), []string{"Flow", "Intranet"})
+	is.Equal(repository.FindAllProjectTags("Flow"), []string{"review"})
+
+	// a saved archived session is back in the flow folder
+	edited := may
+	edited.Tags = []string{"meeting"}
+	is.NoErr(repository.Save(edited))
+	is.Equal(repository.FindAllSessions(nil), []session.Session{edited, lateMay, june, recent})
+	is.Equal(repository.FindAllProjectTags("Flow"), []string{"meeting"})
+
+	// a deleted archived session goes to the trash, the emptied archive is
+	// removed
+	is.NoErr(repository.Delete("3"))
+	found, err = repository.FindById("3")
+	is.NoErr(err)
+	is.Equal(found, nil)
+	is.Equal(repository.FindAllSessions(nil), []session.Session{edited, lateMay, recent})
+	_, err = os.Stat(filepath.Join(repository.FlowFolderPath, filesystem.ArchiveFolder, "2023-06.tar.gz"))
+	is.True(os.IsNotExist(err))
+
+	restored, err := repository.Restore("3")
+	is.NoErr(err)
+	is.Equal(restored, june)
+}
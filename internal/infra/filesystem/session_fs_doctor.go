@@ -0,0 +1,102 @@
+package filesystem
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+func (r *FileSystemSessionRepository) Diagnose() []application.SessionFileIssue {
+	issues := []application.SessionFileIssue{}
+
+	entries, err := os.ReadDir(r.FlowFolderPath)
+	if err != nil {
+		return []application.SessionFileIssue{{Filename: r.FlowFolderPath, Kind: application.CorruptedDataIssue, Reason: err.Error()}}
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || slices.Contains(reservedFilenames, entry.Name()) || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		if _, err := r.parseSessionFileName(entry.Name()); err != nil {
+			issues = append(issues, application.SessionFileIssue{
+				Filename: entry.Name(),
+				Kind:     application.InvalidFilenameIssue,
+				Reason:   err.Error(),
+			})
+			continue
+		}
+
+		// files encrypted for someone else or written by a newer flow aren't
+		// corrupted
+		if _, err := r.readSessionFile(entry.Name()); err != nil && !errors.Is(err, ErrCantDecrypt) && !errors.Is(err, ErrUnsupportedSessionVersion) {
+			issues = append(issues, application.SessionFileIssue{
+				Filename: entry.Name(),
+				Kind:     application.CorruptedDataIssue,
+				Reason:   err.Error(),
+			})
+		}
+	}
+
+	return issues
+}
+
+func (r *FileSystemSessionRepository) Repair(issue application.SessionFileIssue) (bool, error) {
+	session, err := r.readSessionFile(issue.Filename)
+	if errors.Is(err, ErrCantDecrypt) || errors.Is(err, ErrUnsupportedSessionVersion) {
+		return false, err
+	}
+	if err != nil || session.Id == "" || session.StartTime.IsZero() {
+		return true, r.quarantine(issue.Filename)
+	}
+
+	// the content is fine, saving it again writes it under a valid filename
+	if err := r.Save(*session); err != nil {
+		return false, err
+	}
+
+	if r.getSessionFileName(*session) == issue.Filename {
+		return false, nil
+	}
+
+	// Save already removes the file when it was the legacy name of the session
+	err = os.Remove(filepath.Join(r.FlowFolderPath, issue.Filename))
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+
+	return false, nil
+}
+
+// lastStartTime returns the start time of the most recent of the given session
+// files, read from their filenames
+func (r *FileSystemSessionRepository) lastStartTime(fileInfos []fs.FileInfo) time.Time {
+	lastStartTime := time.Time{}
+	for _, fileInfo := range fileInfos {
+		sessionFilename, _ := r.parseSessionFileName(fileInfo.Name())
+		if sessionFilename.StartTime.After(lastStartTime) {
+			lastStartTime = sessionFilename.StartTime
+		}
+	}
+
+	return lastStartTime
+}
+
+// markMissingEndTime marks a session that was never stopped as unstopped
+// when it's read, so that it isn't taken for the current session anymore.
+// The mark isn't saved, reading the sessions never writes to the flow folder.
+func markMissingEndTime(s *session.Session, lastStartTime time.Time) {
+	if s.Status() != session.FlowingStatus || !s.WasNeverStopped(lastStartTime) {
+		return
+	}
+
+	*s = s.MarkUnstopped()
+}
@@ -0,0 +1,134 @@
+package filesystem_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra/filesystem"
+	"github.com/matryer/is"
+)
+
+func givenCorruptedFlowFolder(t *testing.T) (string, filesystem.FileSystemSessionRepository) {
+	t.Helper()
+
+	folderPath := t.TempDir()
+	repository := filesystem.NewFileSystemSessionRepository(folderPath)
+
+	repository.Save(session.Session{
+		Id:        "1",
+		StartTime: time.Date(2024, 4, 17, 19, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, 4, 17, 20, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+	})
+
+	os.WriteFile(filepath.Join(folderPath, "2-Flow-1713387600.json"), []byte("{\"Id\": \"2\""), 0666)
+	os.WriteFile(
+		filepath.Join(folderPath, "3-my-project-1713394800.json"),
+		[]byte(`{"Id": "3", "StartTime": "2024-04-17T23:00:00Z", "Project": "my-project"}`),
+		0666,
+	)
+
+	return folderPath, repository
+}
+
+func TestFileSystemSessionRepository_SkipsCorruptedFiles(t *testing.T) {
+	is := is.New(t)
+	_, repository := givenCorruptedFlowFolder(t)
+
+	sessions := repository.FindAllSessions(nil)
+
+	is.Equal(len(sessions), 1)
+	is.Equal(sessions[0].Id, "1")
+	last, err := repository.FindLastSession()
+	is.NoErr(err)
+	is.Equal(last.Id, "1")
+	found, err := repository.FindById("2")
+	is.NoErr(err)
+	is.Equal(found, nil)
+}
+
+func TestFileSystemSessionRepository_AutoQuarantine(t *testing.T) {
+	is := is.New(t)
+	folderPath, repository := givenCorruptedFlowFolder(t)
+	repository.AutoQuarantine = true
+
+	repository.FindAllSessions(nil)
+
+	quarantined, err := os.ReadDir(filepath.Join(folderPath, filesystem.QuarantineFolder))
+	is.NoErr(err)
+	is.Equal(len(quarantined), 2)
+	is.Equal(len(repository.Diagnose()), 0)
+}
+
+func TestFileSystemSessionRepository_DiagnoseAndRepair(t *testing.T) {
+	is := is.New(t)
+	folderPath, repository := givenCorruptedFlowFolder(t)
+
+	issues := repository.Diagnose()
+
+	is.Equal(len(issues), 2)
+	is.Equal(issues[0].Filename, "2-Flow-1713387600.json")
+	is.Equal(issues[0].Kind, application.CorruptedDataIssue)
+	is.Equal(issues[1].Filename, "3-my-project-1713394800.json")
+	is.Equal(issues[1].Kind, application.InvalidFilenameIssue)
+
+	quarantined, err := repository.Repair(issues[0])
+	is.NoErr(err)
+	is.True(quarantined)
+	_, err = os.Stat(filepath.Join(folderPath, filesystem.QuarantineFolder, "2-Flow-1713387600.json"))
+	is.NoErr(err)
+
+	quarantined, err = repository.Repair(issues[1])
+	is.NoErr(err)
+	is.True(!quarantined)
+	found, err := repository.FindById("3")
+	is.NoErr(err)
+	is.Equal(found.Project, "my-project")
+
+	is.Equal(len(repository.Diagnose()), 0)
+}
+
+func TestFileSystemSessionRepository_MarksUnstoppedSessions(t *testing.T) {
+	is := is.New(t)
+	folderPath := t.TempDir()
+	repository := filesystem.NewFileSystemSessionRepository(folderPath)
+
+	is.NoErr(repository.Save(session.Session{
+		Id:        "1",
+		StartTime: time.Date(2024, 4, 16, 19, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+	}))
+	found, err := repository.FindById("1")
+	is.NoErr(err)
+	is.Equal(found.Status(), session.FlowingStatus)
+
+	is.NoErr(repository.Save(session.Session{
+		Id:        "2",
+		StartTime: time.Date(2024, 4, 17, 19, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+	}))
+
+	sessions := repository.FindAllSessions(nil)
+	is.Equal(sessions[0].Status(), session.UnstoppedStatus)
+	is.Equal(sessions[1].Status(), session.FlowingStatus)
+
+	found, err = repository.FindById("1")
+	is.NoErr(err)
+	is.Equal(found.Metadata[session.UnstoppedMetadata], "true")
+	last, err := repository.FindLastSession()
+	is.NoErr(err)
+	is.Equal(last.Id, "2")
+
+	// the mark isn't saved in the session file
+	files, err := filepath.Glob(filepath.Join(folderPath, "v3.1.*"))
+	is.NoErr(err)
+	is.Equal(len(files), 1)
+	content, err := os.ReadFile(files[0])
+	is.NoErr(err)
+	is.True(!strings.Contains(string(content), session.UnstoppedMetadata))
+}
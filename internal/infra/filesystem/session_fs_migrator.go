@@ -0,0 +1,42 @@
+package filesystem
+
+import "github.com/TristanShz/flow/internal/application"
+
+var _ application.SessionFilesMigrator = &FileSystemSessionRepository{}
+
+func (r *FileSystemSessionRepository) FindLegacyFiles() []string {
+	legacyFiles := []string{}
+
+	fileInfos, err := r.readFlowFolder()
+	if err != nil {
+		return legacyFiles
+	}
+
+	for _, fileInfo := range fileInfos {
+		// a file is outdated when it doesn't have the name it would get if it
+		// was saved now
+		sessionFilename, _ := r.parseSessionFileName(fileInfo.Name())
+		if fileInfo.Name() != sessionFilename.String() {
+			legacyFiles = append(legacyFiles, fileInfo.Name())
+			continue
+		}
+
+		// or when its content has an older format, which Save upgrades
+		if version, err := r.sessionFileVersion(fileInfo.Name()); err == nil && version < SessionSchemaVersion() {
+			legacyFiles = append(legacyFiles, fileInfo.Name())
+		}
+	}
+
+	return legacyFiles
+}
+
+func (r *FileSystemSessionRepository) Migrate(filename string) error {
+	session, err := r.readSessionFile(filename)
+	if err != nil {
+		return err
+	}
+
+	// Save writes the session under the current scheme and format and removes
+	// its outdated file
+	return r.Save(*session)
+}
@@ -0,0 +1,143 @@
+package filesystem_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra/filesystem"
+	"github.com/matryer/is"
+)
+
+func givenLegacySessionFile(t *testing.T, folderPath string, s session.Session) string {
+	t.Helper()
+
+	sessionFilename := filesystem.SessionFilename{
+		Id:        s.Id,
+		Project:   s.Project,
+		StartTime: s.StartTime,
+	}
+
+	os.WriteFile(
+		filepath.Join(folderPath, sessionFilename.LegacyString()),
+		[]byte(`{"Id": "`+s.Id+`", "StartTime": "`+s.StartTime.Format(time.RFC3339)+`", "Project": "`+s.Project+`"}`),
+		0666,
+	)
+
+	return sessionFilename.LegacyString()
+}
+
+func TestFileSystemSessionRepository_HyphensInProjectAndId(t *testing.T) {
+	is := is.New(t)
+	repository := filesystem.NewFileSystemSessionRepository(t.TempDir())
+
+	s := session.Session{
+		Id:        "6f1c-42ab",
+		StartTime: time.Date(2024, 4, 17, 19, 0, 0, 0, time.UTC),
+		Project:   "my-project",
+	}
+
+	is.NoErr(repository.Save(s))
+
+	found, err := repository.FindById("6f1c-42ab")
+	is.NoErr(err)
+	is.Equal(found.Project, "my-project")
+	is.Equal(len(repository.FindAllSessions(&application.SessionsFilters{Project: "my-project"})), 1)
+	is.Equal(len(repository.Diagnose()), 0)
+}
+
+func TestFileSystemSessionRepository_ReadsLegacyFilenames(t *testing.T) {
+	is := is.New(t)
+	folderPath := t.TempDir()
+	repository := filesystem.NewFileSystemSessionRepository(folderPath)
+
+	givenLegacySessionFile(t, folderPath, session.Session{
+		Id:        "1",
+		StartTime: time.Date(2024, 4, 17, 19, 0, 0, 0, time.UTC),
+		Project:   "my-project",
+	})
+
+	found, err := repository.FindById("1")
+	is.NoErr(err)
+	is.Equal(found.Project, "my-project")
+	is.Equal(len(repository.FindAllSessions(&application.SessionsFilters{Project: "my-project"})), 1)
+	is.Equal(repository.FindAllProjects(), []string{"my-project"})
+}
+
+func TestFileSystemSessionRepository_SaveReplacesLegacyFile(t *testing.T) {
+	is := is.New(t)
+	folderPath := t.TempDir()
+	repository := filesystem.NewFileSystemSessionRepository(folderPath)
+
+	givenLegacySessionFile(t, folderPath, session.Session{
+		Id:        "1",
+		StartTime: time.Date(2024, 4, 17, 19, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+	})
+
+	lastSession, err := repository.FindLastSession()
+	is.NoErr(err)
+	lastSession.EndTime = time.Date(2024, 4, 17, 20, 0, 0, 0, time.UTC)
+	is.NoErr(repository.Save(*lastSession))
+
+	sessions := repository.FindAllSessions(nil)
+	is.Equal(len(sessions), 1)
+	is.Equal(sessions[0].EndTime, time.Date(2024, 4, 17, 20, 0, 0, 0, time.UTC))
+}
+
+func TestFileSystemSessionRepository_Migrate(t *testing.T) {
+	is := is.New(t)
+	folderPath := t.TempDir()
+	repository := filesystem.NewFileSystemSessionRepository(folderPath)
+
+	repository.Save(session.Session{
+		Id:        "1",
+		StartTime: time.Date(2024, 4, 17, 19, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+	})
+	legacyFile := givenLegacySessionFile(t, folderPath, session.Session{
+		Id:        "2",
+		StartTime: time.Date(2024, 4, 17, 21, 0, 0, 0, time.UTC),
+		Project:   "my-project",
+	})
+
+	is.Equal(repository.FindLegacyFiles(), []string{legacyFile})
+
+	is.NoErr(repository.Migrate(legacyFile))
+
+	is.Equal(len(repository.FindLegacyFiles()), 0)
+	_, err := os.Stat(filepath.Join(folderPath, legacyFile))
+	is.True(os.IsNotExist(err))
+	is.Equal(len(repository.FindAllSessions(nil)), 2)
+}
+
+func TestFileSystemSessionRepository_MigrateEncodedFilenames(t *testing.T) {
+	is := is.New(t)
+	folderPath := t.TempDir()
+	repository := filesystem.NewFileSystemSessionRepository(folderPath)
+
+	sessionFilename := filesystem.SessionFilename{
+		Id:        "abc2345",
+		Project:   "my-project",
+		StartTime: time.Date(2024, 4, 17, 19, 0, 0, 0, time.UTC),
+	}
+	os.WriteFile(
+		filepath.Join(folderPath, sessionFilename.EncodedString()),
+		[]byte(`{"Id": "abc2345", "StartTime": "2024-04-17T19:00:00Z", "Project": "my-project"}`),
+		0666,
+	)
+
+	is.Equal(repository.FindLegacyFiles(), []string{sessionFilename.EncodedString()})
+
+	is.NoErr(repository.Migrate(sessionFilename.EncodedString()))
+
+	_, err := os.Stat(filepath.Join(folderPath, "v3.abc2345.bXktcHJvamVjdA.1713380400.json"))
+	is.NoErr(err)
+	is.Equal(len(repository.FindLegacyFiles()), 0)
+	found, err := repository.FindById("abc2345")
+	is.NoErr(err)
+	is.Equal(found.Project, "my-project")
+}
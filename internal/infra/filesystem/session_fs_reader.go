@@ -0,0 +1,206 @@
+package filesystem
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+// sessionFile is a session file of the flow folder or of an archive, with the
+// start time of its filename
+type sessionFile struct {
+	name      string
+	startTime time.Time
+	// month is the month of the archive of the file, empty for the files of
+	// the flow folder
+	month string
+}
+
+// path is the path of the file relative to the flow folder, for the warnings
+func (f sessionFile) path() string {
+	if f.month != "" {
+		return filepath.Join(ArchiveFolder, f.name)
+	}
+
+	return f.name
+}
+
+func (r *FileSystemSessionRepository) readSession(file sessionFile, archives *archiveCache) (*session.Session, error) {
+	if file.month != "" {
+		archived, err := archives.file(file.month, file.name)
+		if err != nil {
+			return nil, err
+		}
+
+		return r.archivedSession(archived)
+	}
+
+	return r.readSessionFile(file.name)
+}
+
+// archiveCacheSize is the number of archives whose files are held while the
+// sessions are read, two so that the reads going on at the end of a month
+// don't read its archive again once the next one is read
+const archiveCacheSize = 2
+
+// archiveCache holds the files of the last archives read. The sessions are
+// read in the order of their start times, so the files of an archive are
+// read one after the other and only a couple of archives are held at once.
+type archiveCache struct {
+	repository *FileSystemSessionRepository
+	mu         sync.Mutex
+	// months are the months of the archives held, the last read last
+	months []string
+	// files are the files of the archives held by month, then by name
+	files map[string]map[string]archiveFile
+}
+
+func newArchiveCache(repository *FileSystemSessionRepository) *archiveCache {
+	return &archiveCache{
+		repository: repository,
+		files:      map[string]map[string]archiveFile{},
+	}
+}
+
+func (c *archiveCache) file(month string, name string) (archiveFile, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	files, ok := c.files[month]
+	if !ok {
+		read, err := c.repository.readArchive(month)
+		if err != nil {
+			return archiveFile{}, err
+		}
+
+		files = make(map[string]archiveFile, len(read))
+		for _, file := range read {
+			files[file.name] = file
+		}
+
+		if len(c.months) == archiveCacheSize {
+			delete(c.files, c.months[0])
+			c.months = c.months[1:]
+		}
+		c.months = append(c.months, month)
+		c.files[month] = files
+	}
+
+	file, ok := files[name]
+	if !ok {
+		return archiveFile{}, fmt.Errorf("%v is no longer in the archive %v", name, month+archiveExtension)
+	}
+
+	return file, nil
+}
+
+type sessionFileRead struct {
+	session *session.Session
+	err     error
+}
+
+// readBatchSize is the number of files a worker reads before handing their
+// sessions over, so that the files aren't handed over one at a time
+const readBatchSize = 64
+
+// minDecryptWorkers is the least number of workers reading encrypted files,
+// their decryption mostly waits for age even on a single CPU
+const minDecryptWorkers = 4
+
+// readWorkers is the number of workers reading the session files. A worker
+// only pays off when the files are decrypted, a plaintext file is parsed
+// quicker than it's handed over, see BenchmarkFileSystemSessionRepository_FindAllSessions.
+func (r *FileSystemSessionRepository) readWorkers() int {
+	if r.ReadWorkers > 0 {
+		return r.ReadWorkers
+	}
+
+	if r.Cipher == nil {
+		return 1
+	}
+
+	return max(runtime.GOMAXPROCS(0), minDecryptWorkers)
+}
+
+// readSessionFiles reads and parses the session files by batches with a
+// bounded pool of workers, and gives them to fn in the order of files. Only
+// a few batches are read ahead of fn, so that the sessions aren't all held in
+// memory. It stops at the first error of fn and returns it.
+func (r *FileSystemSessionRepository) readSessionFiles(files []sessionFile, fn func(file sessionFile, s *session.Session, err error) error) error {
+	archives := newArchiveCache(r)
+
+	workers := r.readWorkers()
+	if workers == 1 || len(files) < 2 {
+		for _, file := range files {
+			s, err := r.readSession(file, archives)
+			if err := fn(file, s, err); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	// the batches are queued in the order of the files, each one gets its
+	// sessions once a worker is done with it
+	queue := make(chan chan []sessionFileRead, 2*workers)
+	slots := make(chan struct{}, workers)
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		defer close(queue)
+
+		// fewer files give smaller batches, so that every worker gets some
+		batchSize := min(readBatchSize, max(1, len(files)/(2*workers)))
+		for start := 0; start < len(files); start += batchSize {
+			batch := files[start:min(start+batchSize, len(files))]
+
+			read := make(chan []sessionFileRead, 1)
+			select {
+			case queue <- read:
+			case <-done:
+				return
+			}
+
+			select {
+			case slots <- struct{}{}:
+			case <-done:
+				return
+			}
+
+			go func(batch []sessionFile) {
+				defer func() { <-slots }()
+
+				reads := make([]sessionFileRead, 0, len(batch))
+				for _, file := range batch {
+					select {
+					case <-done:
+						return
+					default:
+					}
+
+					s, err := r.readSession(file, archives)
+					reads = append(reads, sessionFileRead{session: s, err: err})
+				}
+				read <- reads
+			}(batch)
+		}
+	}()
+
+	i := 0
+	for read := range queue {
+		for _, result := range <-read {
+			if err := fn(files[i], result.session, result.err); err != nil {
+				return err
+			}
+			i++
+		}
+	}
+
+	return nil
+}
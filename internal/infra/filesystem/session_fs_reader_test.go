@@ -0,0 +1,135 @@
+package filesystem_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra/filesystem"
+	"github.com/matryer/is"
+)
+
+// givenSessionFiles writes the session files directly, saving thousands of
+// sessions with the repository would take a while
+func givenSessionFiles(tb testing.TB, folder string, count int) []session.Session {
+	tb.Helper()
+
+	start := time.Date(2020, 1, 1, 9, 0, 0, 0, time.UTC)
+	sessions := make([]session.Session, count)
+	for i := range sessions {
+		sessions[i] = session.Session{
+			Id:        strconv.Itoa(i),
+			StartTime: start.Add(time.Duration(i) * time.Hour),
+			EndTime:   start.Add(time.Duration(i)*time.Hour + 30*time.Minute),
+			Project:   "Flow",
+			Tags:      []string{"benchmark"},
+		}
+
+		content, err := json.Marshal(struct {
+			Version int
+			session.Session
+		}{filesystem.SessionSchemaVersion(), sessions[i]})
+		if err != nil {
+			tb.Fatal(err)
+		}
+		filename := filesystem.SessionFilename{Id: sessions[i].Id, Project: sessions[i].Project, StartTime: sessions[i].StartTime}
+		if err := os.WriteFile(filepath.Join(folder, filename.String()), content, 0666); err != nil {
+			tb.Fatal(err)
+		}
+	}
+
+	return sessions
+}
+
+func TestFileSystemSessionRepository_ReadWorkers(t *testing.T) {
+	folder := t.TempDir()
+	want := givenSessionFiles(t, folder, 200)
+
+	// a corrupted file in the middle of the others is skipped
+	is.New(t).NoErr(os.WriteFile(filepath.Join(folder, "v3.corrupt1.Rmxvdw.1577869200.json"), []byte("{"), 0666))
+
+	for _, workers := range []int{1, 4, 64} {
+		t.Run(fmt.Sprintf("%v workers", workers), func(t *testing.T) {
+			is := is.New(t)
+			repository := filesystem.NewFileSystemSessionRepository(folder)
+			repository.ReadWorkers = workers
+
+			is.Equal(repository.FindAllSessions(nil), want)
+		})
+	}
+}
+
+// latencyCipher stands for age, which decrypts each file in a process of its
+// own
+type latencyCipher struct {
+	base64Cipher
+}
+
+func (c latencyCipher) Decrypt(ciphertext []byte) ([]byte, error) {
+	time.Sleep(time.Millisecond)
+	return c.base64Cipher.Decrypt(ciphertext)
+}
+
+func givenEncryptedSessionFiles(b *testing.B, folder string, count int) {
+	b.Helper()
+
+	givenSessionFiles(b, folder, count)
+	entries, err := os.ReadDir(folder)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	for _, entry := range entries {
+		path := filepath.Join(folder, entry.Name())
+		content, err := os.ReadFile(path)
+		if err != nil {
+			b.Fatal(err)
+		}
+		encrypted, _ := base64Cipher{}.Encrypt(content)
+		if err := os.WriteFile(path, encrypted, 0666); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkFileSystemSessionRepository_FindAllSessions compares reading the
+// files one after the other and with 4 workers: the workers are slower for
+// the plaintext files, which are parsed quicker than they're handed over, and
+// much quicker for the encrypted ones, which wait for their decryption
+func BenchmarkFileSystemSessionRepository_FindAllSessions(b *testing.B) {
+	plaintext := b.TempDir()
+	givenSessionFiles(b, plaintext, 10000)
+	encrypted := b.TempDir()
+	givenEncryptedSessionFiles(b, encrypted, 500)
+
+	benchmarks := []struct {
+		name    string
+		folder  string
+		cipher  filesystem.SessionCipher
+		workers int
+	}{
+		{name: "plaintext sequential", folder: plaintext, workers: 1},
+		{name: "plaintext worker pool", folder: plaintext, workers: 4},
+		{name: "encrypted sequential", folder: encrypted, cipher: latencyCipher{}, workers: 1},
+		{name: "encrypted worker pool", folder: encrypted, cipher: latencyCipher{}, workers: 4},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			repository := filesystem.NewFileSystemSessionRepository(bm.folder)
+			repository.Cipher = bm.cipher
+			repository.ReadWorkers = bm.workers
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				repository.FindAllSessions(nil)
+			}
+		})
+	}
+}
@@ -1,15 +1,17 @@
 package filesystem
 
 import (
-	"encoding/json"
+	"cmp"
+	"container/heap"
+	"encoding/base64"
 	"errors"
+	"fmt"
 	"io/fs"
 	"log"
 	"os"
 	"path/filepath"
 	"regexp"
 	"slices"
-	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -17,6 +19,7 @@ import (
 	"github.com/TristanShz/flow/internal/application"
 	"github.com/TristanShz/flow/internal/domain/session"
 	"github.com/TristanShz/flow/pkg/timerange"
+	"github.com/TristanShz/flow/utils"
 )
 
 type Sessions []session.Session
@@ -33,8 +36,39 @@ func (s Sessions) Swap(i, j int) {
 	s[i], s[j] = s[j], s[i]
 }
 
+// reservedFilenames are files of the flow folder that don't hold a session
+var reservedFilenames = []string{clientsFilename, projectsFilename, indexFilename, legacyIndexFilename, templatesFilename, auditLogFilename, activeSessionLockFilename, lastSessionPointerFilename, dailyTotalsCacheFilename, journalFilename, ImportConflictsFilename, invoicesFilename, operationsFilename}
+
+// QuarantineFolder is the sub folder of the flow folder where corrupted session
+// files are moved
+const QuarantineFolder = "quarantine"
+
 type FileSystemSessionRepository struct {
 	FlowFolderPath string
+	// AutoQuarantine moves corrupted session files to the quarantine folder as
+	// soon as they are encountered instead of just skipping them.
+	AutoQuarantine bool
+	// SyncDir makes Save sync the flow folder after each write, trading speed
+	// for durability of the written session on power loss.
+	SyncDir bool
+	// Cipher encrypts the session files when set and leaves their project out
+	// of their filenames, plaintext files are still read and get encrypted
+	// when saved again
+	Cipher SessionCipher
+	// TrashRetention is how long the deleted sessions are kept in the trash,
+	// they're kept until they're purged when it's zero
+	TrashRetention time.Duration
+	// ReadOnly makes the reads leave the flow folder as it is, e.g. for the
+	// stores of other setups: the index isn't built nor updated, the last
+	// session pointer isn't written and the corrupted files aren't
+	// quarantined. Save and Delete still write, see
+	// infra.ReadOnlySessionRepository.
+	ReadOnly bool
+	// ReadWorkers is the number of batches of session files read at once
+	// when listing the sessions. When zero, the plaintext files are read one
+	// after the other, parsing them is quicker than handing them over, and
+	// the encrypted ones by runtime.GOMAXPROCS(0) workers, 4 at least.
+	ReadWorkers int
 }
 
 func NewFileSystemSessionRepository(flowFolderPath string) FileSystemSessionRepository {
@@ -53,10 +87,35 @@ func NotFoundError(id string) error {
 	return errors.New("session with id " + id + " not found")
 }
 
+const (
+	// LegacyFilenameVersion is the original "id-project-unix.json" scheme, it
+	// breaks when the id contains a hyphen and strips the project of anything
+	// that is not alphanumeric.
+	LegacyFilenameVersion = 1
+	// EncodedFilenameVersion is the "v2.id.project.unix.json" scheme, where id
+	// and project are url-safe base64 encoded so they can hold any character.
+	// It's still used for the ids that aren't short ids.
+	EncodedFilenameVersion = 2
+	// FilenameVersion is the "v3.id.project.unix.json" scheme, where the short
+	// id is kept as is so that filenames can be scanned by a human, and the
+	// project is url-safe base64 encoded.
+	FilenameVersion = 3
+)
+
+const (
+	filenameV2Prefix = "v2."
+	filenameV3Prefix = "v3."
+)
+
+var filenameEncoding = base64.RawURLEncoding
+
+var ErrInvalidSessionFilename = errors.New("invalid session file name")
+
 type SessionFilename struct {
 	StartTime time.Time
 	Id        string
 	Project   string
+	Version   int
 }
 
 func (s *SessionFilename) StrippedProject() string {
@@ -65,23 +124,72 @@ func (s *SessionFilename) StrippedProject() string {
 }
 
 func (s *SessionFilename) String() string {
+	if !utils.IsShortID(s.Id) {
+		return s.EncodedString()
+	}
+
+	return filenameV3Prefix +
+		s.Id + "." +
+		filenameEncoding.EncodeToString([]byte(s.Project)) + "." +
+		strconv.FormatInt(s.StartTime.Unix(), 10) + ".json"
+}
+
+// EncodedString returns the filename of the session under the v2 scheme
+func (s *SessionFilename) EncodedString() string {
+	return filenameV2Prefix +
+		filenameEncoding.EncodeToString([]byte(s.Id)) + "." +
+		filenameEncoding.EncodeToString([]byte(s.Project)) + "." +
+		strconv.FormatInt(s.StartTime.Unix(), 10) + ".json"
+}
+
+// LegacyString returns the filename the session had before the v2 scheme
+func (s *SessionFilename) LegacyString() string {
 	return s.Id + "-" + s.StrippedProject() + "-" + strconv.FormatInt(s.StartTime.Unix(), 10) + ".json"
 }
 
+// Sealed tells if the project was left out of the filename, like for the
+// encrypted sessions, it's then only known once the file is read
+func (s *SessionFilename) Sealed() bool {
+	return s.Project == ""
+}
+
+// MatchProject tells if the filename belongs to the given project, legacy
+// filenames only hold the stripped project so it is compared stripped too.
+func (s *SessionFilename) MatchProject(project string) bool {
+	if s.Version == LegacyFilenameVersion {
+		other := SessionFilename{Project: project}
+		return s.Project == other.StrippedProject()
+	}
+
+	return s.Project == project
+}
+
 func (r *FileSystemSessionRepository) getSessionFileName(s session.Session) string {
 	sessionFilename := SessionFilename{
 		Id:        s.Id,
 		Project:   s.Project,
 		StartTime: s.StartTime,
 	}
+	// the project of an encrypted session must not show in its filename
+	if r.Cipher != nil {
+		sessionFilename.Project = ""
+	}
 
 	return sessionFilename.String()
 }
 
 func (r *FileSystemSessionRepository) parseSessionFileName(fileName string) (SessionFilename, error) {
+	if strings.HasPrefix(fileName, filenameV3Prefix) {
+		return parseSessionFileNameV3(fileName)
+	}
+
+	if strings.HasPrefix(fileName, filenameV2Prefix) {
+		return parseSessionFileNameV2(fileName)
+	}
+
 	parts := strings.Split(fileName, "-")
 	if len(parts) != 3 {
-		return SessionFilename{}, errors.New("invalid session file name")
+		return SessionFilename{}, ErrInvalidSessionFilename
 	}
 	id := parts[0]
 	project := parts[1]
@@ -93,6 +201,68 @@ func (r *FileSystemSessionRepository) parseSessionFileName(fileName string) (Ses
 		Id:        id,
 		Project:   project,
 		StartTime: time.Unix(startTimeUnix, 0),
+		Version:   LegacyFilenameVersion,
+	}, nil
+}
+
+func parseSessionFileNameV2(fileName string) (SessionFilename, error) {
+	if !strings.HasSuffix(fileName, ".json") {
+		return SessionFilename{}, ErrInvalidSessionFilename
+	}
+
+	parts := strings.Split(strings.TrimSuffix(strings.TrimPrefix(fileName, filenameV2Prefix), ".json"), ".")
+	if len(parts) != 3 {
+		return SessionFilename{}, ErrInvalidSessionFilename
+	}
+
+	id, err := filenameEncoding.DecodeString(parts[0])
+	if err != nil || len(id) == 0 {
+		return SessionFilename{}, ErrInvalidSessionFilename
+	}
+
+	project, err := filenameEncoding.DecodeString(parts[1])
+	if err != nil {
+		return SessionFilename{}, ErrInvalidSessionFilename
+	}
+
+	startTimeUnix, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return SessionFilename{}, err
+	}
+
+	return SessionFilename{
+		Id:        string(id),
+		Project:   string(project),
+		StartTime: time.Unix(startTimeUnix, 0),
+		Version:   EncodedFilenameVersion,
+	}, nil
+}
+
+func parseSessionFileNameV3(fileName string) (SessionFilename, error) {
+	if !strings.HasSuffix(fileName, ".json") {
+		return SessionFilename{}, ErrInvalidSessionFilename
+	}
+
+	parts := strings.Split(strings.TrimSuffix(strings.TrimPrefix(fileName, filenameV3Prefix), ".json"), ".")
+	if len(parts) != 3 || !utils.IsShortID(parts[0]) {
+		return SessionFilename{}, ErrInvalidSessionFilename
+	}
+
+	project, err := filenameEncoding.DecodeString(parts[1])
+	if err != nil {
+		return SessionFilename{}, ErrInvalidSessionFilename
+	}
+
+	startTimeUnix, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return SessionFilename{}, err
+	}
+
+	return SessionFilename{
+		Id:        parts[0],
+		Project:   string(project),
+		StartTime: time.Unix(startTimeUnix, 0),
+		Version:   FilenameVersion,
 	}, nil
 }
 
@@ -108,13 +278,72 @@ func (r *FileSystemSessionRepository) readFlowFolder() ([]fs.FileInfo, error) {
 		return nil, err
 	}
 
-	return fileInfos, nil
+	sessionFileInfos := []fs.FileInfo{}
+	for _, fileInfo := range fileInfos {
+		if fileInfo.IsDir() || slices.Contains(reservedFilenames, fileInfo.Name()) || strings.HasPrefix(fileInfo.Name(), ".") {
+			continue
+		}
+
+		if _, err := r.parseSessionFileName(fileInfo.Name()); err != nil {
+			r.skipCorruptedFile(fileInfo.Name(), err)
+			continue
+		}
+
+		sessionFileInfos = append(sessionFileInfos, fileInfo)
+	}
+
+	return sessionFileInfos, nil
 }
 
-func (r *FileSystemSessionRepository) FindById(id string) *session.Session {
+// skipCorruptedFile warns about a session file that can't be read so that one
+// bad file never prevents the other sessions from being used.
+func (r *FileSystemSessionRepository) skipCorruptedFile(fileName string, reason error) {
+	if errors.Is(reason, ErrCantDecrypt) {
+		log.Printf("warning: skipping encrypted session file %v (%v)", fileName, reason)
+		return
+	}
+	if errors.Is(reason, ErrUnsupportedSessionVersion) {
+		log.Printf("warning: skipping session file %v (%v), upgrade flow to read it", fileName, reason)
+		return
+	}
+
+	if r.AutoQuarantine && !r.ReadOnly {
+		if err := r.quarantine(fileName); err == nil {
+			log.Printf("warning: corrupted session file %v moved to quarantine (%v)", fileName, reason)
+			return
+		}
+	}
+
+	log.Printf("warning: skipping corrupted session file %v (%v), run 'flow doctor' to repair it", fileName, reason)
+}
+
+func (r *FileSystemSessionRepository) quarantine(fileName string) error {
+	quarantinePath := filepath.Join(r.FlowFolderPath, QuarantineFolder)
+	if err := os.MkdirAll(quarantinePath, 0777); err != nil {
+		return err
+	}
+
+	return os.Rename(filepath.Join(r.FlowFolderPath, fileName), filepath.Join(quarantinePath, fileName))
+}
+
+func (r *FileSystemSessionRepository) readSessionFile(fileName string) (*session.Session, error) {
+	file, err := os.ReadFile(filepath.Join(r.FlowFolderPath, fileName))
+	if err != nil {
+		return nil, err
+	}
+
+	file, err = r.decrypt(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.rawFileToSession(file)
+}
+
+func (r *FileSystemSessionRepository) FindById(id string) (*session.Session, error) {
 	fileInfos, err := r.readFlowFolder()
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 
 	for _, fileInfo := range fileInfos {
@@ -122,89 +351,174 @@ func (r *FileSystemSessionRepository) FindById(id string) *session.Session {
 			continue
 		}
 
-		sessionFilename, err := r.parseSessionFileName(fileInfo.Name())
-		if err != nil {
-			log.Fatalf("error while parsing file name %v : '%v'", fileInfo.Name(), err)
-		}
+		sessionFilename, _ := r.parseSessionFileName(fileInfo.Name())
 
 		if sessionFilename.Id == id {
-			filePath := filepath.Join(r.FlowFolderPath, fileInfo.Name())
-			file, err := os.ReadFile(filePath)
+			session, err := r.readSessionFile(fileInfo.Name())
 			if err != nil {
-				log.Fatalf("Error while reading file %v : '%v'", fileInfo.Name(), err)
+				r.skipCorruptedFile(fileInfo.Name(), err)
+				return nil, nil
 			}
 
-			session, convertErr := r.rawFileToSession(file)
-			if convertErr != nil {
-				log.Fatalf("Invalid session data for file : %v", fileInfo.Name())
-			}
+			markMissingEndTime(session, r.lastStartTime(fileInfos))
 
-			return session
+			return session, nil
 		}
 	}
 
-	return nil
+	if session, _, ok := r.findArchivedSession(id); ok {
+		return session, nil
+	}
+
+	return nil, nil
 }
 
 func (r *FileSystemSessionRepository) Save(sessionToSave session.Session) error {
-	marshaled, marshaledErr := json.MarshalIndent(sessionToSave, "", "  ")
+	marshaled, marshaledErr := marshalSession(sessionToSave)
 
 	if marshaledErr != nil {
 		return marshaledErr
 	}
 
-	fullPath := filepath.Join(r.FlowFolderPath, r.getSessionFileName(sessionToSave))
-	saveErr := os.WriteFile(fullPath, marshaled, 0666)
+	marshaled, err := r.encrypt(marshaled)
+	if err != nil {
+		return err
+	}
+
+	filename := r.getSessionFileName(sessionToSave)
+	saveErr := writeFileAtomic(filepath.Join(r.FlowFolderPath, filename), marshaled, 0666, r.SyncDir)
 
 	if saveErr != nil {
 		return saveErr
 	}
 
+	// the session may be stored under another filename, either a legacy one or
+	// one with its previous project or start time, which must go away to not
+	// end up with the session twice
+	if err := r.removeOtherFiles(sessionToSave, filename); err != nil {
+		return err
+	}
+
+	// a changed archived session is back in the flow folder
+	if err := r.unarchive(sessionToSave.Id); err != nil {
+		return err
+	}
+
+	r.indexSavedSession(sessionToSave, filename)
+
+	return nil
+}
+
+// SavePartially writes the first fraction of the session file in place, like
+// a write cut short by a crash without the atomic rename of Save. It's the
+// partial write of the fault injection, see infra.FaultySessionRepository.
+func (r *FileSystemSessionRepository) SavePartially(sessionToSave session.Session, fraction float64) error {
+	marshaled, err := marshalSession(sessionToSave)
+	if err != nil {
+		return err
+	}
+
+	marshaled, err = r.encrypt(marshaled)
+	if err != nil {
+		return err
+	}
+
+	size := int(float64(len(marshaled)) * min(max(fraction, 0), 1))
+
+	return os.WriteFile(filepath.Join(r.FlowFolderPath, r.getSessionFileName(sessionToSave)), marshaled[:size], 0666)
+}
+
+func (r *FileSystemSessionRepository) removeOtherFiles(s session.Session, filename string) error {
+	fileInfos, err := r.readFlowFolder()
+	if err != nil {
+		return err
+	}
+
+	for _, fileInfo := range fileInfos {
+		if fileInfo.Name() == filename {
+			continue
+		}
+
+		sessionFilename, _ := r.parseSessionFileName(fileInfo.Name())
+		if sessionFilename.Id != s.Id {
+			continue
+		}
+
+		err := os.Remove(filepath.Join(r.FlowFolderPath, fileInfo.Name()))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
 	return nil
 }
 
 func (r *FileSystemSessionRepository) Delete(id string) error {
 	fileInfos, err := r.readFlowFolder()
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
 	for _, fileInfo := range fileInfos {
 		if fileInfo.IsDir() {
 			continue
 		}
 
-		filenameInfo, err := r.parseSessionFileName(fileInfo.Name())
-		if err != nil {
-			log.Fatalf("error while parsing file name %v : '%v'", fileInfo.Name(), err)
-		}
+		filenameInfo, _ := r.parseSessionFileName(fileInfo.Name())
 		if filenameInfo.Id == id {
-			filepath := filepath.Join(r.FlowFolderPath, fileInfo.Name())
-			deleteErr := os.Remove(filepath)
-			if deleteErr != nil {
-				log.Fatalf("error while deleting file %v : '%v'", fileInfo.Name(), deleteErr)
+			if err := r.trash(fileInfo.Name()); err != nil {
+				return fmt.Errorf("error while deleting file %v: %w", fileInfo.Name(), err)
 			}
+			r.unindexSession(fileInfo.Name())
 			return nil
 		}
 	}
 
+	if deleted, err := r.trashArchived(id); err != nil || deleted {
+		return err
+	}
+
 	return NotFoundError(id)
 }
 
 func (r *FileSystemSessionRepository) rawFileToSession(raw []byte) (*session.Session, error) {
-	var sessionData session.Session
-	if err := json.Unmarshal(raw, &sessionData); err != nil {
-		return nil, err
-	}
-
-	return &sessionData, nil
+	return unmarshalSession(raw)
 }
 
 func (r *FileSystemSessionRepository) FindAllSessions(filters *application.SessionsFilters) []session.Session {
+	sessions := []session.Session{}
+	r.ForEachSession(filters, func(s session.Session) error {
+		sessions = append(sessions, s)
+		return nil
+	})
+
+	return sessions
+}
+
+// errLimitReached stops reading the session files once the limit of the
+// filters is reached
+var errLimitReached = errors.New("limit reached")
+
+// ForEachSession reads the session files in the order of the start times of
+// their filenames, a few at a time ahead of fn, so that only these sessions
+// are held in memory. The files past the offset and the limit of the filters
+// aren't even read, e.g. only the newest files are read for the last
+// sessions. The archived sessions are read with their monthly archives.
+func (r *FileSystemSessionRepository) ForEachSession(filters *application.SessionsFilters, fn func(session.Session) error) error {
 	fileInfos, err := r.readFlowFolder()
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	lastStartTime := r.lastStartTime(fileInfos)
+
+	// a session left in the flow folder by an interrupted archiving wins
+	// over its archived copy
+	ids := map[string]bool{}
+	for _, fileInfo := range fileInfos {
+		sessionFilename, _ := r.parseSessionFileName(fileInfo.Name())
+		ids[sessionFilename.Id] = true
+	}
+
 	if filters != nil {
 		if !filters.Timerange.IsZero() {
 			fileInfos = r.filterByTimeRange(fileInfos, filters.Timerange)
@@ -213,41 +527,91 @@ func (r *FileSystemSessionRepository) FindAllSessions(filters *application.Sessi
 		if filters.Project != "" {
 			fileInfos = r.filterByProject(fileInfos, filters.Project)
 		}
-	}
 
-	sessions := Sessions{}
+		if len(filters.Tags) > 0 {
+			fileInfos = r.filterByTags(fileInfos, filters)
+		}
+	}
 
+	sessionFiles := make([]sessionFile, 0, len(fileInfos))
 	for _, fileInfo := range fileInfos {
-		if fileInfo.IsDir() {
-			continue
-		}
+		sessionFilename, _ := r.parseSessionFileName(fileInfo.Name())
+		sessionFiles = append(sessionFiles, sessionFile{name: fileInfo.Name(), startTime: sessionFilename.StartTime})
+	}
+	sessionFiles = append(sessionFiles, r.archivedSessionFiles(filters, ids)...)
 
-		filePath := filepath.Join(r.FlowFolderPath, fileInfo.Name())
-		file, err := os.ReadFile(filePath)
+	slices.SortStableFunc(sessionFiles, func(a sessionFile, b sessionFile) int {
+		return a.startTime.Compare(b.startTime)
+	})
+	if filters != nil && filters.IsDescending() {
+		slices.Reverse(sessionFiles)
+	}
+
+	skipped, given := 0, 0
+	err = r.readSessionFiles(sessionFiles, func(file sessionFile, session *session.Session, err error) error {
 		if err != nil {
-			log.Fatalf("error while reading file %v : '%v'", fileInfo.Name(), err)
+			r.skipCorruptedFile(file.path(), err)
+			return nil
 		}
 
-		session, convertErr := r.rawFileToSession(file)
-		if convertErr != nil {
-			log.Fatalf("invalid session data for file : %v", fileInfo.Name())
+		// tags are not part of the filename, so they can only be checked once
+		// the file is parsed, like the project of the sealed filenames
+		if filters != nil && !filters.MatchTags(*session) {
+			return nil
+		}
+		if filters != nil && filters.Project != "" && session.Project != filters.Project {
+			return nil
 		}
-		sessions = append(sessions, *session)
-	}
 
-	sort.Sort(sessions)
+		markMissingEndTime(session, lastStartTime)
 
-	return sessions
+		// the where expression can compare the duration, which needs the unstopped mark
+		if filters != nil && !filters.MatchWhere(*session) {
+			return nil
+		}
+
+		if filters != nil && skipped < filters.Offset {
+			skipped++
+			return nil
+		}
+
+		if err := fn(*session); err != nil {
+			return err
+		}
+
+		given++
+		if filters != nil && filters.Limit > 0 && given >= filters.Limit {
+			return errLimitReached
+		}
+
+		return nil
+	})
+	if errors.Is(err, errLimitReached) {
+		return nil
+	}
+
+	return err
 }
 
 func (r *FileSystemSessionRepository) filterByProject(fileInfos []fs.FileInfo, project string) []fs.FileInfo {
 	filteredFileInfos := []fs.FileInfo{}
 	for _, fileInfo := range fileInfos {
-		sessionFilename, err := r.parseSessionFileName(fileInfo.Name())
-		if err != nil {
-			log.Fatalf("error while parsing file name %v : '%v'", fileInfo.Name(), err)
+		sessionFilename, _ := r.parseSessionFileName(fileInfo.Name())
+		if sessionFilename.Sealed() || sessionFilename.MatchProject(project) {
+			filteredFileInfos = append(filteredFileInfos, fileInfo)
 		}
-		if sessionFilename.Project == project {
+	}
+	return filteredFileInfos
+}
+
+// filterByTags uses the index as tags are not part of the filename
+func (r *FileSystemSessionRepository) filterByTags(fileInfos []fs.FileInfo, filters *application.SessionsFilters) []fs.FileInfo {
+	index := r.labeledIndex(fileInfos)
+
+	filteredFileInfos := []fs.FileInfo{}
+	for _, fileInfo := range fileInfos {
+		entry, ok := index.Sessions[fileInfo.Name()]
+		if ok && filters.MatchTags(entry.session()) {
 			filteredFileInfos = append(filteredFileInfos, fileInfo)
 		}
 	}
@@ -257,103 +621,277 @@ func (r *FileSystemSessionRepository) filterByProject(fileInfos []fs.FileInfo, p
 func (r *FileSystemSessionRepository) filterByTimeRange(fileInfos []fs.FileInfo, timeRange timerange.TimeRange) []fs.FileInfo {
 	filteredFileInfos := []fs.FileInfo{}
 	for _, fileInfo := range fileInfos {
-		sessionFilename, err := r.parseSessionFileName(fileInfo.Name())
-		if err != nil {
-			log.Fatalf("error while parsing file name %v : '%v'", fileInfo.Name(), err)
-		}
-		if timeRange.JustUntil() {
-			if sessionFilename.StartTime.Before(timeRange.Until) {
-				filteredFileInfos = append(filteredFileInfos, fileInfo)
-			}
-		} else if timeRange.JustSince() {
-			if sessionFilename.StartTime.After(timeRange.Since) {
-				filteredFileInfos = append(filteredFileInfos, fileInfo)
-			}
-		} else if timeRange.SinceAndUntil() {
-			if sessionFilename.StartTime.After(timeRange.Since) && sessionFilename.StartTime.Before(timeRange.Until) {
-				filteredFileInfos = append(filteredFileInfos, fileInfo)
-			}
-		} else {
+		sessionFilename, _ := r.parseSessionFileName(fileInfo.Name())
+		if timeRange.Contains(sessionFilename.StartTime) {
 			filteredFileInfos = append(filteredFileInfos, fileInfo)
 		}
 	}
 	return filteredFileInfos
 }
 
-func (r *FileSystemSessionRepository) FindLastSession() *session.Session {
-	fileInfos, err := r.readFlowFolder()
+// sessionFileNames lists the session files of the flow folder from their
+// directory entries only, which is much faster than reading their info on
+// big flow folders
+func (r *FileSystemSessionRepository) sessionFileNames() ([]string, error) {
+	dir, err := os.Open(r.FlowFolderPath)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
+	defer dir.Close()
 
-	fileNames := []SessionFilename{}
+	entries, err := dir.ReadDir(-1)
+	if err != nil {
+		return nil, err
+	}
 
-	for _, fileInfo := range fileInfos {
-		if !fileInfo.IsDir() {
-			filenameInfo, err := r.parseSessionFileName(fileInfo.Name())
-			if err != nil {
-				log.Fatalf("error while parsing file name %v : '%v'", fileInfo.Name(), err)
-			}
-			fileNames = append(fileNames, filenameInfo)
+	fileNames := []string{}
+	for _, entry := range entries {
+		if entry.IsDir() || slices.Contains(reservedFilenames, entry.Name()) || strings.HasPrefix(entry.Name(), ".") {
+			continue
 		}
+
+		fileNames = append(fileNames, entry.Name())
 	}
 
-	if len(fileNames) == 0 {
-		return nil
+	return fileNames, nil
+}
+
+// filenameStartTime reads the start time at the end of a session filename
+// without allocating, the filename isn't validated. It's the hot path of
+// 'flow status', which runs in shell prompts and status bars.
+func filenameStartTime(fileName string) (int64, bool) {
+	name, ok := strings.CutSuffix(fileName, ".json")
+	if !ok {
+		return 0, false
 	}
 
-	sort.Slice(fileNames, func(i, j int) bool {
-		return fileNames[j].StartTime.Before(fileNames[i].StartTime)
-	})
+	// v2 and v3 filenames end with ".unix", legacy ones with "-unix"
+	separator := strings.LastIndexAny(name, ".-")
+	digits := name[separator+1:]
+	if separator == -1 || digits == "" || len(digits) > 18 {
+		return 0, false
+	}
+
+	var unix int64
+	for i := 0; i < len(digits); i++ {
+		if digits[i] < '0' || digits[i] > '9' {
+			return 0, false
+		}
+		unix = unix*10 + int64(digits[i]-'0')
+	}
+
+	return unix, true
+}
+
+// FindLastSession only reads the newest session file, the other ones are only
+// read when it's corrupted. The flow folder isn't even listed when the last
+// session pointer is fresh.
+func (r *FileSystemSessionRepository) FindLastSession() (*session.Session, error) {
+	if fileName, ok := r.readLastSessionPointer(); ok {
+		if session, err := r.readSessionFile(fileName); err == nil {
+			return session, nil
+		}
+	}
+
+	folderInfo, err := os.Stat(r.FlowFolderPath)
+	if err != nil {
+		return nil, err
+	}
 
-	lastSessionFile := fileNames[0].String()
+	newest, err := r.findSessionFiles(&application.SessionsFilters{Order: application.OrderDescending, Limit: 1})
+	if err != nil || len(newest) == 0 {
+		return nil, err
+	}
+	if session, err := r.readSessionFile(newest[0].name); err == nil {
+		r.writeLastSessionPointer(folderInfo.ModTime(), newest[0].name)
+		return session, nil
+	}
 
-	lastSessionFilePath := filepath.Join(r.FlowFolderPath, lastSessionFile)
+	return r.findLastReadableSession()
+}
 
-	fileData, err := os.ReadFile(lastSessionFilePath)
+// findLastReadableSession returns the most recent session that can be read,
+// skipping the corrupted files
+func (r *FileSystemSessionRepository) findLastReadableSession() (*session.Session, error) {
+	files, err := r.findSessionFiles(&application.SessionsFilters{Order: application.OrderDescending})
 	if err != nil {
-		log.Fatalf("error while reading file %v", lastSessionFilePath)
+		return nil, err
 	}
 
-	session, convertErr := r.rawFileToSession(fileData)
+	for _, file := range files {
+		session, err := r.readSessionFile(file.name)
+		if err != nil {
+			r.skipCorruptedFile(file.name, err)
+			continue
+		}
 
-	if convertErr != nil {
-		log.Fatalf("invalid session data for file : %v", lastSessionFilePath)
+		return session, nil
 	}
 
-	return session
+	return nil, nil
 }
 
-func (r *FileSystemSessionRepository) FindAllProjects() []string {
-	sessions := r.FindAllSessions(nil)
+// FindAllSessionMetadata only reads the names of the session files of the
+// flow folder, the archived sessions aren't listed
+func (r *FileSystemSessionRepository) FindAllSessionMetadata(filters *application.SessionsFilters) []application.SessionMetadata {
+	files, err := r.findSessionFiles(filters)
+	if err != nil {
+		log.Printf("warning: the session files can't be listed (%v)", err)
+	}
 
-	projects := []string{}
+	metadata := []application.SessionMetadata{}
+	for _, file := range files {
+		metadata = append(metadata, application.SessionMetadata{
+			Id:        file.filename.Id,
+			Project:   file.filename.Project,
+			StartTime: file.filename.StartTime,
+		})
+	}
+
+	return metadata
+}
+
+type namedSessionFile struct {
+	name     string
+	filename SessionFilename
+}
+
+// sessionFileCandidate is a file of the flow folder with the start time at
+// the end of its name, which isn't parsed yet
+type sessionFileCandidate struct {
+	name      string
+	startTime int64
+}
+
+// sessionFileCandidates is a heap of the candidates in the order of their
+// start times, so that the first ones of a page are found without sorting
+// all of them. The candidates starting at the same second are ordered by
+// name, to always list them in the same order.
+type sessionFileCandidates struct {
+	candidates []sessionFileCandidate
+	descending bool
+}
+
+func (c *sessionFileCandidates) Len() int {
+	return len(c.candidates)
+}
 
-	for _, session := range sessions {
-		if slices.Contains(projects, session.Project) {
+func (c *sessionFileCandidates) Less(i, j int) bool {
+	a, b := c.candidates[i], c.candidates[j]
+	if c.descending {
+		a, b = b, a
+	}
+
+	return cmp.Or(cmp.Compare(a.startTime, b.startTime), strings.Compare(a.name, b.name)) < 0
+}
+
+func (c *sessionFileCandidates) Swap(i, j int) {
+	c.candidates[i], c.candidates[j] = c.candidates[j], c.candidates[i]
+}
+
+func (c *sessionFileCandidates) Push(x any) {
+	c.candidates = append(c.candidates, x.(sessionFileCandidate))
+}
+
+func (c *sessionFileCandidates) Pop() any {
+	last := c.candidates[len(c.candidates)-1]
+	c.candidates = c.candidates[:len(c.candidates)-1]
+
+	return last
+}
+
+// findSessionFiles lists the session files of the flow folder matching the
+// time range, the project, the order and the page of the filters. The start
+// times are read without parsing the filenames, so that only the filenames
+// of the page are parsed, e.g. only the newest one for the last session. The
+// sealed files are read for their project.
+func (r *FileSystemSessionRepository) findSessionFiles(filters *application.SessionsFilters) ([]namedSessionFile, error) {
+	if filters == nil {
+		filters = &application.SessionsFilters{}
+	}
+
+	fileNames, err := r.sessionFileNames()
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := &sessionFileCandidates{
+		candidates: make([]sessionFileCandidate, 0, len(fileNames)),
+		descending: filters.IsDescending(),
+	}
+	for _, fileName := range fileNames {
+		startTime, ok := filenameStartTime(fileName)
+		if !ok {
+			continue
+		}
+		if !filters.Timerange.IsZero() && !filters.Timerange.Contains(time.Unix(startTime, 0)) {
 			continue
 		}
 
-		projects = append(projects, session.Project)
+		candidates.candidates = append(candidates.candidates, sessionFileCandidate{name: fileName, startTime: startTime})
 	}
+	heap.Init(candidates)
+
+	files := []namedSessionFile{}
+	skipped := 0
+	for candidates.Len() > 0 {
+		if filters.Limit > 0 && len(files) == filters.Limit {
+			break
+		}
+
+		candidate := heap.Pop(candidates).(sessionFileCandidate)
+		sessionFilename, err := r.parseSessionFileName(candidate.name)
+		if err != nil {
+			continue
+		}
+		if sessionFilename.Sealed() {
+			s, err := r.readSessionFile(candidate.name)
+			if err != nil {
+				r.skipCorruptedFile(candidate.name, err)
+				continue
+			}
+			sessionFilename.Project = s.Project
+		}
+		if filters.Project != "" && !sessionFilename.MatchProject(filters.Project) {
+			continue
+		}
+		if skipped < filters.Offset {
+			skipped++
+			continue
+		}
+
+		files = append(files, namedSessionFile{name: candidate.name, filename: sessionFilename})
+	}
+
+	return files, nil
+}
+
+// FindAllProjects reads the sessions one after the other, the projects are in
+// the order of their first session
+func (r *FileSystemSessionRepository) FindAllProjects() []string {
+	projects := []string{}
+	r.ForEachSession(nil, func(s session.Session) error {
+		if !slices.Contains(projects, s.Project) {
+			projects = append(projects, s.Project)
+		}
+		return nil
+	})
 
 	return projects
 }
 
+// FindAllProjectTags only reads the sessions of the project, the tags are in
+// the order of their first session
 func (r *FileSystemSessionRepository) FindAllProjectTags(project string) []string {
-	sessionsForProject := r.FindAllSessions(&application.SessionsFilters{Project: project})
-
 	tags := []string{}
-
-	for _, session := range sessionsForProject {
-		for _, tag := range session.Tags {
-			if slices.Contains(tags, tag) {
-				continue
+	r.ForEachSession(&application.SessionsFilters{Project: project}, func(s session.Session) error {
+		for _, tag := range s.Tags {
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
 			}
-
-			tags = append(tags, tag)
 		}
-	}
+		return nil
+	})
 
 	return tags
 }
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"sort"
+	"strconv"
 	"testing"
 	"time"
 
@@ -16,20 +17,18 @@ import (
 	"github.com/matryer/is"
 )
 
-const (
-	TestFolderPath = "./.flow"
-)
-
-func setup() {
-	os.RemoveAll("./.flow")
+// setup returns the path of a folder which doesn't exist yet, in a temporary
+// folder removed at the end of the test
+func setup(t *testing.T) string {
+	return filepath.Join(t.TempDir(), ".flow")
 }
 
 func TestConstructorCreateFolder_Success(t *testing.T) {
-	setup()
+	testFolderPath := setup(t)
 
-	filesystem.NewFileSystemSessionRepository(TestFolderPath)
+	filesystem.NewFileSystemSessionRepository(testFolderPath)
 
-	path := filepath.Join(TestFolderPath)
+	path := filepath.Join(testFolderPath)
 	if _, err := os.Stat(path); os.IsNotExist(err) {
 		t.Errorf("File not found at location %v", path)
 	}
@@ -37,9 +36,9 @@ func TestConstructorCreateFolder_Success(t *testing.T) {
 
 func TestFileSystemSessionRepository_Save(t *testing.T) {
 	is := is.New(t)
-	setup()
+	testFolderPath := setup(t)
 
-	repository := filesystem.NewFileSystemSessionRepository(TestFolderPath)
+	repository := filesystem.NewFileSystemSessionRepository(testFolderPath)
 
 	tt := []struct {
 		name    string
@@ -67,9 +66,9 @@ func TestFileSystemSessionRepository_Save(t *testing.T) {
 }
 
 func TestFileSystemSessionRepository_FindAllSessions(t *testing.T) {
-	setup()
+	testFolderPath := setup(t)
 
-	repository := filesystem.NewFileSystemSessionRepository(TestFolderPath)
+	repository := filesystem.NewFileSystemSessionRepository(testFolderPath)
 
 	repository.Save(session.Session{
 		Id:        "1",
@@ -107,10 +106,161 @@ func TestFileSystemSessionRepository_FindAllSessions(t *testing.T) {
 	}
 }
 
+func TestFileSystemSessionRepository_ForEachSession(t *testing.T) {
+	is := is.New(t)
+
+	at := func(month time.Month, day int) time.Time {
+		return time.Date(2023, month, day, 9, 0, 0, 0, time.UTC)
+	}
+	newSession := func(id string, startTime time.Time) session.Session {
+		return session.Session{Id: id, StartTime: startTime, EndTime: startTime.Add(time.Hour), Project: "Flow"}
+	}
+
+	repository := filesystem.NewFileSystemSessionRepository(t.TempDir())
+	march, may, june, september := newSession("1", at(time.March, 6)), newSession("2", at(time.May, 2)), newSession("3", at(time.June, 5)), newSession("4", at(time.September, 1))
+	for _, s := range []session.Session{september, may, march} {
+		is.NoErr(repository.Save(s))
+	}
+	_, err := repository.Archive(at(time.April, 1))
+	is.NoErr(err)
+	is.NoErr(repository.Save(june))
+
+	// the archived sessions are given in order with the ones of the flow folder
+	ids := []string{}
+	is.NoErr(repository.ForEachSession(nil, func(s session.Session) error {
+		ids = append(ids, s.Id)
+		return nil
+	}))
+	is.Equal(ids, []string{"1", "2", "3", "4"})
+
+	// the first error stops the iteration
+	errStop := errors.New("stop")
+	ids = []string{}
+	err = repository.ForEachSession(nil, func(s session.Session) error {
+		ids = append(ids, s.Id)
+		if len(ids) == 2 {
+			return errStop
+		}
+		return nil
+	})
+	is.Equal(err, errStop)
+	is.Equal(ids, []string{"1", "2"})
+}
+
+func TestFileSystemSessionRepository_FindAllSessions_Page(t *testing.T) {
+	at := func(month time.Month, day int) time.Time {
+		return time.Date(2023, month, day, 9, 0, 0, 0, time.UTC)
+	}
+
+	repository := filesystem.NewFileSystemSessionRepository(t.TempDir())
+	for i, startTime := range []time.Time{at(time.March, 6), at(time.May, 2), at(time.June, 5), at(time.September, 1)} {
+		is.New(t).NoErr(repository.Save(session.Session{Id: strconv.Itoa(i + 1), StartTime: startTime, EndTime: startTime.Add(time.Hour), Project: "Flow"}))
+	}
+	_, err := repository.Archive(at(time.April, 1))
+	is.New(t).NoErr(err)
+
+	tt := []struct {
+		name    string
+		filters application.SessionsFilters
+		wantIds []string
+	}{
+		{
+			name:    "Last sessions",
+			filters: application.SessionsFilters{Order: application.OrderDescending, Limit: 2},
+			wantIds: []string{"4", "3"},
+		},
+		{
+			name:    "Newest first with an archived session",
+			filters: application.SessionsFilters{Order: application.OrderDescending},
+			wantIds: []string{"4", "3", "2", "1"},
+		},
+		{
+			name:    "Offset and limit",
+			filters: application.SessionsFilters{Offset: 1, Limit: 2},
+			wantIds: []string{"2", "3"},
+		},
+		{
+			name:    "Offset past the sessions",
+			filters: application.SessionsFilters{Offset: 10},
+			wantIds: []string{},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			ids := []string{}
+			for _, s := range repository.FindAllSessions(&tc.filters) {
+				ids = append(ids, s.Id)
+			}
+			is.Equal(ids, tc.wantIds)
+		})
+	}
+}
+
+func TestFileSystemSessionRepository_FindAllSessionMetadata(t *testing.T) {
+	at := func(day int) time.Time {
+		return time.Date(2024, time.April, day, 9, 0, 0, 0, time.Local)
+	}
+
+	folder := t.TempDir()
+	repository := filesystem.NewFileSystemSessionRepository(folder)
+	is.New(t).NoErr(repository.Save(session.Session{Id: "k3x7a2q", StartTime: at(1), EndTime: at(1).Add(time.Hour), Project: "Flow", Tags: []string{"cli"}}))
+	is.New(t).NoErr(repository.Save(session.Session{Id: "p4m8z0x", StartTime: at(3), Project: "my-todo"}))
+	givenLegacySessionFile(t, folder, session.Session{Id: "legacy", StartTime: at(2), Project: "Flow"})
+	// the files which aren't sessions are left out
+	is.New(t).NoErr(os.WriteFile(filepath.Join(folder, "notes.txt"), []byte("todo"), 0666))
+
+	tt := []struct {
+		name    string
+		filters *application.SessionsFilters
+		want    []application.SessionMetadata
+	}{
+		{
+			name:    "All the sessions",
+			filters: nil,
+			want: []application.SessionMetadata{
+				{Id: "k3x7a2q", Project: "Flow", StartTime: at(1)},
+				{Id: "legacy", Project: "Flow", StartTime: at(2)},
+				{Id: "p4m8z0x", Project: "my-todo", StartTime: at(3)},
+			},
+		},
+		{
+			name:    "Newest first",
+			filters: &application.SessionsFilters{Order: application.OrderDescending, Limit: 2},
+			want: []application.SessionMetadata{
+				{Id: "p4m8z0x", Project: "my-todo", StartTime: at(3)},
+				{Id: "legacy", Project: "Flow", StartTime: at(2)},
+			},
+		},
+		{
+			name:    "Project and offset",
+			filters: &application.SessionsFilters{Project: "Flow", Offset: 1},
+			want:    []application.SessionMetadata{{Id: "legacy", Project: "Flow", StartTime: at(2)}},
+		},
+		{
+			name:    "Time range",
+			filters: &application.SessionsFilters{Timerange: timerange.TimeRange{Since: at(2), Until: at(4)}},
+			want: []application.SessionMetadata{
+				{Id: "legacy", Project: "Flow", StartTime: at(2)},
+				{Id: "p4m8z0x", Project: "my-todo", StartTime: at(3)},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(repository.FindAllSessionMetadata(tc.filters), tc.want)
+		})
+	}
+}
+
 func TestFindAllSessions_NoSessions_Success(t *testing.T) {
-	setup()
+	testFolderPath := setup(t)
 
-	repository := filesystem.NewFileSystemSessionRepository(TestFolderPath)
+	repository := filesystem.NewFileSystemSessionRepository(testFolderPath)
 
 	got := repository.FindAllSessions(nil)
 
@@ -122,9 +272,9 @@ func TestFindAllSessions_NoSessions_Success(t *testing.T) {
 }
 
 func TestFileSystemSessionRepository_FindLastSession(t *testing.T) {
-	setup()
+	testFolderPath := setup(t)
 
-	repository := filesystem.NewFileSystemSessionRepository(TestFolderPath)
+	repository := filesystem.NewFileSystemSessionRepository(testFolderPath)
 
 	repository.Save(session.Session{
 		Id:        "1",
@@ -140,7 +290,10 @@ func TestFileSystemSessionRepository_FindLastSession(t *testing.T) {
 		Project:   "Flow",
 	})
 
-	got := repository.FindLastSession()
+	got, err := repository.FindLastSession()
+	if err != nil {
+		t.Fatalf("FileSystemSessionRepository.FindLastSession() error = %v", err)
+	}
 
 	want := session.Session{
 		Id:        "2",
@@ -153,10 +306,141 @@ func TestFileSystemSessionRepository_FindLastSession(t *testing.T) {
 	}
 }
 
+func BenchmarkFileSystemSessionRepository_FindLastSession(b *testing.B) {
+	repository := filesystem.NewFileSystemSessionRepository(b.TempDir())
+
+	start := time.Date(2020, 1, 1, 9, 0, 0, 0, time.UTC)
+	for i := 0; i < 1000; i++ {
+		repository.Save(session.Session{
+			Id:        strconv.Itoa(i),
+			StartTime: start.Add(time.Duration(i) * time.Hour),
+			EndTime:   start.Add(time.Duration(i)*time.Hour + 30*time.Minute),
+			Project:   "Flow",
+		})
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		repository.FindLastSession()
+	}
+}
+
+func TestFileSystemSessionRepository_FindLastSession_Pointer(t *testing.T) {
+	is := is.New(t)
+
+	folder := t.TempDir()
+	repository := filesystem.NewFileSystemSessionRepository(folder)
+	start := time.Date(2024, 4, 17, 9, 0, 0, 0, time.UTC)
+	for _, id := range []string{"1", "2"} {
+		is.NoErr(repository.Save(session.Session{
+			Id:        id,
+			StartTime: start,
+			EndTime:   start.Add(time.Hour),
+			Project:   "Flow",
+		}))
+		start = start.Add(2 * time.Hour)
+	}
+
+	// the pointer is only written for a folder left unchanged for a while,
+	// the first call creates it which changes the folder again
+	unchanged := time.Now().Add(-time.Minute)
+	for range 2 {
+		is.NoErr(os.Chtimes(folder, unchanged, unchanged))
+		last, err := repository.FindLastSession()
+		is.NoErr(err)
+		is.Equal(last.Id, "2")
+	}
+
+	pointerPath := filepath.Join(folder, "last_session")
+	pointer, err := os.ReadFile(pointerPath)
+	is.NoErr(err)
+	is.Equal(string(pointer), strconv.FormatInt(unchanged.UnixNano(), 10)+" v3.2.Rmxvdw.1713351600.json\n")
+
+	// a fresh pointer is trusted without listing the folder
+	is.NoErr(os.WriteFile(pointerPath, []byte(strconv.FormatInt(unchanged.UnixNano(), 10)+" v3.1.Rmxvdw.1713344400.json\n"), 0666))
+	is.NoErr(os.Chtimes(folder, unchanged, unchanged))
+	last, err := repository.FindLastSession()
+	is.NoErr(err)
+	is.Equal(last.Id, "1")
+
+	// saving a session changes the folder, which makes the pointer stale
+	is.NoErr(repository.Save(session.Session{Id: "3", StartTime: start, Project: "Flow"}))
+	last, err = repository.FindLastSession()
+	is.NoErr(err)
+	is.Equal(last.Id, "3")
+}
+
+func TestFilenameStartTime(t *testing.T) {
+	tt := []struct {
+		fileName string
+		want     int64
+		wantOk   bool
+	}{
+		{fileName: "v3.1.Rmxvdw.1713340800.json", want: 1713340800, wantOk: true},
+		{fileName: "v2.MQ.Rmxvdw.1713340800.json", want: 1713340800, wantOk: true},
+		{fileName: "1-Flow-1713340800.json", want: 1713340800, wantOk: true},
+		{fileName: "v3.1.Rmxvdw.1713340800.txt"},
+		{fileName: "v3.1.Rmxvdw.17133a0800.json"},
+		{fileName: "v3.1.Rmxvdw..json"},
+		{fileName: "1713340800.json"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.fileName, func(t *testing.T) {
+			is := is.New(t)
+
+			got, ok := filesystem.FilenameStartTime(tc.fileName)
+
+			is.Equal(ok, tc.wantOk)
+			is.Equal(got, tc.want)
+		})
+	}
+}
+
+// TestFilenameStartTime_NoAllocation guards the hot path of 'flow status',
+// which scans every filename of the flow folder when the pointer is stale
+func TestFilenameStartTime_NoAllocation(t *testing.T) {
+	is := is.New(t)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		filesystem.FilenameStartTime("v3.1.Rmxvdw.1713340800.json")
+	})
+
+	is.Equal(allocs, float64(0))
+}
+
+func BenchmarkFileSystemSessionRepository_FindLastSession_Pointer(b *testing.B) {
+	folder := b.TempDir()
+	repository := filesystem.NewFileSystemSessionRepository(folder)
+
+	start := time.Date(2020, 1, 1, 9, 0, 0, 0, time.UTC)
+	for i := 0; i < 1000; i++ {
+		repository.Save(session.Session{
+			Id:        strconv.Itoa(i),
+			StartTime: start.Add(time.Duration(i) * time.Hour),
+			EndTime:   start.Add(time.Duration(i)*time.Hour + 30*time.Minute),
+			Project:   "Flow",
+		})
+	}
+
+	unchanged := time.Now().Add(-time.Minute)
+	for range 2 {
+		os.Chtimes(folder, unchanged, unchanged)
+		repository.FindLastSession()
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		repository.FindLastSession()
+	}
+}
+
 func TestFileSystemSessionRepository_FindAllProjects(t *testing.T) {
-	setup()
+	testFolderPath := setup(t)
 
-	repository := filesystem.NewFileSystemSessionRepository(TestFolderPath)
+	repository := filesystem.NewFileSystemSessionRepository(testFolderPath)
 
 	repository.Save(session.Session{
 		Id:        "1",
@@ -189,9 +473,9 @@ func TestFileSystemSessionRepository_FindAllProjects(t *testing.T) {
 
 func TestFileSystemSessionRepository_FindAllProjectTags(t *testing.T) {
 	is := is.New(t)
-	setup()
+	testFolderPath := setup(t)
 
-	repository := filesystem.NewFileSystemSessionRepository(TestFolderPath)
+	repository := filesystem.NewFileSystemSessionRepository(testFolderPath)
 
 	repository.Save(session.Session{
 		Id:        "1",
@@ -240,8 +524,8 @@ func TestFileSystemSessionRepository_FindAllProjectTags(t *testing.T) {
 }
 
 func TestFileSystemSessionRepository_FindInTimeRange(t *testing.T) {
-	setup()
-	repository := filesystem.NewFileSystemSessionRepository(TestFolderPath)
+	testFolderPath := setup(t)
+	repository := filesystem.NewFileSystemSessionRepository(testFolderPath)
 	repository.Save(session.Session{
 		Id:        "1",
 		StartTime: time.Date(2024, 4, 17, 19, 0, 0, 0, time.UTC),
@@ -353,6 +637,39 @@ func TestFileSystemSessionRepository_FindInTimeRange(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Sessions starting on the bounds",
+			args: timerange.TimeRange{
+				Since: time.Date(2024, 4, 17, 19, 0, 0, 0, time.UTC),
+				Until: time.Date(2024, 4, 17, 21, 0, 0, 0, time.UTC),
+			},
+			want: []session.Session{
+				{
+					Id:        "1",
+					StartTime: time.Date(2024, 4, 17, 19, 0, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, 4, 17, 20, 0, 0, 0, time.UTC),
+					Project:   "Flow",
+					Tags:      []string{"tests", "integration"},
+				},
+				{
+					Id:        "2",
+					StartTime: time.Date(2024, 4, 17, 21, 0, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, 4, 17, 23, 0, 0, 0, time.UTC),
+					Project:   "MyTodo",
+					Tags:      []string{"add-todo", "update-todo"},
+				},
+			},
+		},
+		{
+			name: "Exclusive bounds",
+			args: timerange.TimeRange{
+				Since:        time.Date(2024, 4, 17, 19, 0, 0, 0, time.UTC),
+				Until:        time.Date(2024, 4, 17, 21, 0, 0, 0, time.UTC),
+				ExcludeSince: true,
+				ExcludeUntil: true,
+			},
+			want: []session.Session{},
+		},
 	}
 
 	for _, tc := range tt {
@@ -364,9 +681,74 @@ func TestFileSystemSessionRepository_FindInTimeRange(t *testing.T) {
 	}
 }
 
+func TestFileSystemSessionRepository_FindByTags(t *testing.T) {
+	testFolderPath := setup(t)
+	repository := filesystem.NewFileSystemSessionRepository(testFolderPath)
+	repository.Save(session.Session{
+		Id:        "1",
+		StartTime: time.Date(2024, 4, 17, 19, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, 4, 17, 20, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+		Tags:      []string{"tests", "integration"},
+	})
+	repository.Save(session.Session{
+		Id:        "2",
+		StartTime: time.Date(2024, 4, 17, 21, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, 4, 17, 23, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+		Tags:      []string{"tests"},
+	})
+	repository.Save(session.Session{
+		Id:        "3",
+		StartTime: time.Date(2024, 4, 18, 21, 0, 0, 0, time.UTC),
+		Project:   "MyTodo",
+		Tags:      []string{"delete-todo"},
+	})
+
+	tt := []struct {
+		name    string
+		filters application.SessionsFilters
+		want    []string
+	}{
+		{
+			name:    "Match any",
+			filters: application.SessionsFilters{Tags: []string{"integration", "delete-todo"}},
+			want:    []string{"1", "3"},
+		},
+		{
+			name:    "Match all",
+			filters: application.SessionsFilters{Tags: []string{"tests", "integration"}, TagsMatch: application.TagsMatchAll},
+			want:    []string{"1"},
+		},
+		{
+			name:    "Match with project",
+			filters: application.SessionsFilters{Tags: []string{"tests", "delete-todo"}, Project: "MyTodo"},
+			want:    []string{"3"},
+		},
+		{
+			name:    "Unknown tag",
+			filters: application.SessionsFilters{Tags: []string{"unknown"}},
+			want:    []string{},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			got := []string{}
+			for _, s := range repository.FindAllSessions(&tc.filters) {
+				got = append(got, s.Id)
+			}
+
+			is.Equal(got, tc.want)
+		})
+	}
+}
+
 func TestFileSystemSessionRepository_FindById(t *testing.T) {
-	setup()
-	repository := filesystem.NewFileSystemSessionRepository(TestFolderPath)
+	testFolderPath := setup(t)
+	repository := filesystem.NewFileSystemSessionRepository(testFolderPath)
 	repository.Save(session.Session{
 		Id:        "1",
 		StartTime: time.Date(2024, 4, 17, 19, 0, 0, 0, time.UTC),
@@ -405,7 +787,7 @@ func TestFileSystemSessionRepository_FindById(t *testing.T) {
 
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
-			if got := repository.FindById(tc.id); !reflect.DeepEqual(got, tc.want) {
+			if got, err := repository.FindById(tc.id); err != nil || !reflect.DeepEqual(got, tc.want) {
 				t.Errorf("FileSystemSessionRepository.FindById() = %v, want %v", got, tc.want)
 			}
 		})
@@ -414,8 +796,8 @@ func TestFileSystemSessionRepository_FindById(t *testing.T) {
 
 func TestFileSystemSessionRepository_Delete(t *testing.T) {
 	is := is.New(t)
-	setup()
-	repository := filesystem.NewFileSystemSessionRepository(TestFolderPath)
+	testFolderPath := setup(t)
+	repository := filesystem.NewFileSystemSessionRepository(testFolderPath)
 
 	tt := []struct {
 		error         error
@@ -498,3 +880,26 @@ func TestFileSystemSessionRepository_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestFileSystemSessionRepository_SaveRenamesFile(t *testing.T) {
+	is := is.New(t)
+	repository := filesystem.NewFileSystemSessionRepository(t.TempDir())
+
+	s := session.Session{
+		Id:        "1",
+		StartTime: time.Date(2024, 4, 17, 19, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, 4, 17, 20, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+	}
+	is.NoErr(repository.Save(s))
+
+	s.Project = "MyTodo"
+	s.StartTime = time.Date(2024, 4, 17, 18, 0, 0, 0, time.UTC)
+	is.NoErr(repository.Save(s))
+
+	sessions := repository.FindAllSessions(nil)
+	is.Equal(len(sessions), 1)
+	is.Equal(sessions[0].Project, "MyTodo")
+	is.True(sessions[0].StartTime.Equal(s.StartTime))
+	is.Equal(repository.FindAllProjects(), []string{"MyTodo"})
+}
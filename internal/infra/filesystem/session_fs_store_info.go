@@ -0,0 +1,95 @@
+package filesystem
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/TristanShz/flow/internal/application"
+)
+
+const FileSystemBackend = "filesystem"
+
+// Info inspects the flow folder without changing it: the index isn't
+// refreshed and corrupted files aren't quarantined
+func (r *FileSystemSessionRepository) Info() (application.StoreInfo, error) {
+	info := application.StoreInfo{
+		Backend:  FileSystemBackend,
+		Location: r.FlowFolderPath,
+	}
+
+	err := filepath.WalkDir(r.FlowFolderPath, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		fileInfo, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		info.TotalSize += fileInfo.Size()
+
+		switch filepath.Base(filepath.Dir(path)) {
+		case QuarantineFolder:
+			info.QuarantinedFiles++
+		case TrashFolder:
+			info.TrashedFiles++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return application.StoreInfo{}, err
+	}
+
+	entries, err := os.ReadDir(r.FlowFolderPath)
+	if err != nil {
+		return application.StoreInfo{}, err
+	}
+
+	index := r.readIndex()
+	info.IndexedSessions = len(index.Sessions)
+
+	sessionFilenames := map[string]bool{}
+	for _, entry := range entries {
+		if entry.IsDir() || slices.Contains(reservedFilenames, entry.Name()) || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		info.SessionFiles++
+		sessionFilenames[entry.Name()] = true
+
+		fileInfo, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		if indexEntry, ok := index.Sessions[entry.Name()]; !ok || indexEntry.isStale(fileInfo) {
+			info.StaleIndexEntries++
+		}
+
+		// files with an invalid name are reported by Diagnose
+		sessionFilename, err := r.parseSessionFileName(entry.Name())
+		if err != nil {
+			continue
+		}
+
+		if info.OldestSession.IsZero() || sessionFilename.StartTime.Before(info.OldestSession) {
+			info.OldestSession = sessionFilename.StartTime
+		}
+		if sessionFilename.StartTime.After(info.NewestSession) {
+			info.NewestSession = sessionFilename.StartTime
+		}
+	}
+
+	for filename := range index.Sessions {
+		if !sessionFilenames[filename] {
+			info.StaleIndexEntries++
+		}
+	}
+
+	info.CorruptedFiles = len(r.Diagnose())
+
+	return info, nil
+}
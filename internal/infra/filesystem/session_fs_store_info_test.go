@@ -0,0 +1,63 @@
+package filesystem_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra/filesystem"
+	"github.com/matryer/is"
+)
+
+func TestFileSystemSessionRepository_Info(t *testing.T) {
+	is := is.New(t)
+	folderPath, repository := givenCorruptedFlowFolder(t)
+
+	os.Mkdir(filepath.Join(folderPath, filesystem.QuarantineFolder), 0755)
+	os.WriteFile(filepath.Join(folderPath, filesystem.QuarantineFolder, "4-Flow-1713301200.json"), []byte("{"), 0666)
+	os.Mkdir(filepath.Join(folderPath, filesystem.TrashFolder), 0755)
+	os.WriteFile(filepath.Join(folderPath, filesystem.TrashFolder, "5-Flow-1713301200.json"), []byte("{}"), 0666)
+
+	info, err := repository.Info()
+
+	is.NoErr(err)
+	is.Equal(info.Backend, filesystem.FileSystemBackend)
+	is.Equal(info.Location, folderPath)
+	is.Equal(info.SessionFiles, 3)
+	is.True(info.TotalSize > 0)
+	is.True(info.OldestSession.Equal(time.Date(2024, 4, 17, 19, 0, 0, 0, time.UTC)))
+	is.True(info.NewestSession.Equal(time.Date(2024, 4, 17, 21, 0, 0, 0, time.UTC)))
+	is.Equal(info.QuarantinedFiles, 1)
+	is.Equal(info.TrashedFiles, 1)
+	is.Equal(info.CorruptedFiles, 2)
+	// the info is read only, corrupted files stay where they are
+	_, err = os.Stat(filepath.Join(folderPath, "2-Flow-1713387600.json"))
+	is.NoErr(err)
+}
+
+func TestFileSystemSessionRepository_InfoIndex(t *testing.T) {
+	is := is.New(t)
+	repository := filesystem.NewFileSystemSessionRepository(t.TempDir())
+
+	repository.Save(session.Session{
+		Id:        "1",
+		StartTime: time.Date(2024, 4, 17, 19, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, 4, 17, 20, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+	})
+	repository.FindAllSessions(nil)
+
+	info, err := repository.Info()
+	is.NoErr(err)
+	is.Equal(info.IndexedSessions, 1)
+	is.Equal(info.StaleIndexEntries, 0)
+
+	os.Remove(filepath.Join(repository.FlowFolderPath, "index.db"))
+
+	info, err = repository.Info()
+	is.NoErr(err)
+	is.Equal(info.IndexedSessions, 0)
+	is.Equal(info.StaleIndexEntries, 1)
+}
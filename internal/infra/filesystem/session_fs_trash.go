@@ -0,0 +1,146 @@
+package filesystem
+
+import (
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+// TrashFolder is the sub folder of the flow folder where deleted session
+// files are kept until they're purged
+const TrashFolder = "trash"
+
+// trash moves the session file to the trash, its modification time becomes
+// the time of the deletion. The sessions trashed for longer than the
+// retention are purged on the way.
+func (r *FileSystemSessionRepository) trash(fileName string) error {
+	trashPath := filepath.Join(r.FlowFolderPath, TrashFolder)
+	if err := os.MkdirAll(trashPath, 0777); err != nil {
+		return err
+	}
+
+	trashedPath := filepath.Join(trashPath, fileName)
+	if err := os.Rename(filepath.Join(r.FlowFolderPath, fileName), trashedPath); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if err := os.Chtimes(trashedPath, now, now); err != nil {
+		return err
+	}
+
+	if r.TrashRetention > 0 {
+		if _, err := r.Purge(now.Add(-r.TrashRetention)); err != nil {
+			log.Printf("warning: the trash couldn't be purged (%v)", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *FileSystemSessionRepository) readTrash() ([]fs.FileInfo, error) {
+	entries, err := os.ReadDir(filepath.Join(r.FlowFolderPath, TrashFolder))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	fileInfos := []fs.FileInfo{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if _, err := r.parseSessionFileName(entry.Name()); err != nil {
+			continue
+		}
+
+		fileInfo, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		fileInfos = append(fileInfos, fileInfo)
+	}
+
+	return fileInfos, nil
+}
+
+func (r *FileSystemSessionRepository) FindAllTrashed() ([]application.TrashedSession, error) {
+	fileInfos, err := r.readTrash()
+	if err != nil {
+		return nil, err
+	}
+
+	trashed := []application.TrashedSession{}
+	for _, fileInfo := range fileInfos {
+		s, err := r.readSessionFile(filepath.Join(TrashFolder, fileInfo.Name()))
+		if err != nil {
+			log.Printf("warning: skipping unreadable trashed session file %v (%v)", fileInfo.Name(), err)
+			continue
+		}
+
+		trashed = append(trashed, application.TrashedSession{DeletedAt: fileInfo.ModTime(), Session: *s})
+	}
+
+	slices.SortStableFunc(trashed, func(a, b application.TrashedSession) int {
+		return b.DeletedAt.Compare(a.DeletedAt)
+	})
+
+	return trashed, nil
+}
+
+func (r *FileSystemSessionRepository) Restore(id string) (session.Session, error) {
+	fileInfos, err := r.readTrash()
+	if err != nil {
+		return session.Session{}, err
+	}
+
+	for _, fileInfo := range fileInfos {
+		sessionFilename, _ := r.parseSessionFileName(fileInfo.Name())
+		if sessionFilename.Id != id {
+			continue
+		}
+
+		trashedFile := filepath.Join(TrashFolder, fileInfo.Name())
+		s, err := r.readSessionFile(trashedFile)
+		if err != nil {
+			return session.Session{}, err
+		}
+
+		if err := r.Save(*s); err != nil {
+			return session.Session{}, err
+		}
+
+		return *s, os.Remove(filepath.Join(r.FlowFolderPath, trashedFile))
+	}
+
+	return session.Session{}, application.ErrSessionNotTrashed
+}
+
+func (r *FileSystemSessionRepository) Purge(deletedBefore time.Time) (int, error) {
+	fileInfos, err := r.readTrash()
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, fileInfo := range fileInfos {
+		if !deletedBefore.IsZero() && !fileInfo.ModTime().Before(deletedBefore) {
+			continue
+		}
+
+		if err := os.Remove(filepath.Join(r.FlowFolderPath, TrashFolder, fileInfo.Name())); err != nil && !os.IsNotExist(err) {
+			return purged, err
+		}
+		purged++
+	}
+
+	return purged, nil
+}
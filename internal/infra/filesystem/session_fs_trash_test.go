@@ -0,0 +1,118 @@
+package filesystem_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra/filesystem"
+	"github.com/matryer/is"
+)
+
+func TestFileSystemSessionRepository_Trash(t *testing.T) {
+	is := is.New(t)
+
+	repository := filesystem.NewFileSystemSessionRepository(t.TempDir())
+	first := session.Session{
+		Id:        "1",
+		StartTime: time.Date(2024, 4, 17, 9, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, 4, 17, 10, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+	}
+	second := session.Session{
+		Id:        "2",
+		StartTime: time.Date(2024, 4, 17, 11, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, 4, 17, 12, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+	}
+	is.NoErr(repository.Save(first))
+	is.NoErr(repository.Save(second))
+
+	is.NoErr(repository.Delete("1"))
+	is.NoErr(repository.Delete("2"))
+
+	is.Equal(repository.FindAllSessions(nil), []session.Session{})
+	trashed, err := repository.FindAllTrashed()
+	is.NoErr(err)
+	is.Equal(len(trashed), 2)
+
+	restored, err := repository.Restore("1")
+	is.NoErr(err)
+	is.Equal(restored, first)
+	is.Equal(repository.FindAllSessions(nil), []session.Session{first})
+	trashed, err = repository.FindAllTrashed()
+	is.NoErr(err)
+	is.Equal(len(trashed), 1)
+
+	_, err = repository.Restore("1")
+	is.Equal(err, application.ErrSessionNotTrashed)
+
+	// the deletion time is the modification time of the trashed file
+	entries, err := os.ReadDir(filepath.Join(repository.FlowFolderPath, filesystem.TrashFolder))
+	is.NoErr(err)
+	is.Equal(len(entries), 1)
+	deletedAt := time.Now().Add(-48 * time.Hour)
+	is.NoErr(os.Chtimes(filepath.Join(repository.FlowFolderPath, filesystem.TrashFolder, entries[0].Name()), deletedAt, deletedAt))
+
+	purged, err := repository.Purge(time.Now().Add(-72 * time.Hour))
+	is.NoErr(err)
+	is.Equal(purged, 0)
+
+	purged, err = repository.Purge(time.Now().Add(-24 * time.Hour))
+	is.NoErr(err)
+	is.Equal(purged, 1)
+	trashed, err = repository.FindAllTrashed()
+	is.NoErr(err)
+	is.Equal(trashed, []application.TrashedSession{})
+}
+
+func TestFileSystemSessionRepository_TrashRetention(t *testing.T) {
+	is := is.New(t)
+
+	repository := filesystem.NewFileSystemSessionRepository(t.TempDir())
+	repository.TrashRetention = 24 * time.Hour
+	for _, id := range []string{"1", "2"} {
+		is.NoErr(repository.Save(session.Session{
+			Id:        id,
+			StartTime: time.Date(2024, 4, 17, 9, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, 4, 17, 10, 0, 0, 0, time.UTC),
+			Project:   "Flow" + id,
+		}))
+	}
+
+	is.NoErr(repository.Delete("1"))
+	entries, err := os.ReadDir(filepath.Join(repository.FlowFolderPath, filesystem.TrashFolder))
+	is.NoErr(err)
+	deletedAt := time.Now().Add(-48 * time.Hour)
+	is.NoErr(os.Chtimes(filepath.Join(repository.FlowFolderPath, filesystem.TrashFolder, entries[0].Name()), deletedAt, deletedAt))
+
+	is.NoErr(repository.Delete("2"))
+
+	trashed, err := repository.FindAllTrashed()
+	is.NoErr(err)
+	is.Equal(len(trashed), 1)
+	is.Equal(trashed[0].Session.Id, "2")
+}
+
+func TestFileSystemSessionRepository_UnreadableTrash(t *testing.T) {
+	is := is.New(t)
+
+	repository := filesystem.NewFileSystemSessionRepository(t.TempDir())
+	is.NoErr(repository.Save(session.Session{
+		Id:        "1",
+		StartTime: time.Date(2024, 4, 17, 9, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, 4, 17, 10, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+	}))
+	// a file where the trash folder should be
+	is.NoErr(os.WriteFile(filepath.Join(repository.FlowFolderPath, filesystem.TrashFolder), []byte{}, 0644))
+
+	is.True(repository.Delete("1") != nil)
+	is.Equal(len(repository.FindAllSessions(nil)), 1)
+
+	_, err := repository.FindAllTrashed()
+	is.True(err != nil)
+}
@@ -0,0 +1,62 @@
+package filesystem
+
+import (
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/utils"
+)
+
+var _ application.IDProvider = &SessionIDProvider{}
+
+// SessionIDProvider makes sure that no session of the flow folder, of the
+// archives or of the trash already uses the ids of the given provider, a
+// colliding id is replaced by the next id derived from it
+type SessionIDProvider struct {
+	repository *FileSystemSessionRepository
+	idProvider application.IDProvider
+}
+
+func NewSessionIDProvider(repository *FileSystemSessionRepository, idProvider application.IDProvider) SessionIDProvider {
+	return SessionIDProvider{
+		repository: repository,
+		idProvider: idProvider,
+	}
+}
+
+func (p *SessionIDProvider) Provide() string {
+	id := p.idProvider.Provide()
+
+	usedIds := p.usedIds()
+	for usedIds[id] {
+		id = utils.NextID(id)
+	}
+
+	return id
+}
+
+// usedIds returns the ids of the indexed sessions, of the archived sessions
+// and of the trashed ones, so that restoring a session never collides with a
+// new one
+func (p *SessionIDProvider) usedIds() map[string]bool {
+	usedIds := map[string]bool{}
+
+	if fileInfos, err := p.repository.readFlowFolder(); err == nil {
+		for _, entry := range p.repository.index(fileInfos).Sessions {
+			usedIds[entry.Id] = true
+		}
+	}
+
+	if manifest, err := p.repository.readManifest(); err == nil {
+		for id := range manifest {
+			usedIds[id] = true
+		}
+	}
+
+	if fileInfos, err := p.repository.readTrash(); err == nil {
+		for _, fileInfo := range fileInfos {
+			sessionFilename, _ := p.repository.parseSessionFileName(fileInfo.Name())
+			usedIds[sessionFilename.Id] = true
+		}
+	}
+
+	return usedIds
+}
@@ -0,0 +1,67 @@
+package filesystem_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/TristanShz/flow/internal/infra/filesystem"
+	"github.com/TristanShz/flow/utils"
+	"github.com/matryer/is"
+)
+
+func TestSessionIDProvider(t *testing.T) {
+	is := is.New(t)
+	repository := filesystem.NewFileSystemSessionRepository(t.TempDir())
+	idProvider := filesystem.NewSessionIDProvider(&repository, &infra.StubIDProvider{Id: "abc2345"})
+
+	is.Equal(idProvider.Provide(), "abc2345")
+
+	is.NoErr(repository.Save(session.Session{
+		Id:        "abc2345",
+		StartTime: time.Date(2024, 4, 17, 19, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+	}))
+
+	next := utils.NextID("abc2345")
+	is.Equal(idProvider.Provide(), next)
+	is.True(utils.IsIDValid(next))
+
+	is.NoErr(repository.Save(session.Session{
+		Id:        next,
+		StartTime: time.Date(2024, 4, 17, 21, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+	}))
+
+	is.Equal(idProvider.Provide(), utils.NextID(next))
+}
+
+func TestSessionIDProvider_ArchivedAndTrashedSessions(t *testing.T) {
+	is := is.New(t)
+	repository := filesystem.NewFileSystemSessionRepository(t.TempDir())
+	stub := &infra.StubIDProvider{Id: "abc2345"}
+	idProvider := filesystem.NewSessionIDProvider(&repository, stub)
+
+	is.NoErr(repository.Save(session.Session{
+		Id:        "abc2345",
+		StartTime: time.Date(2023, 5, 2, 9, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2023, 5, 2, 10, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+	}))
+	_, err := repository.Archive(time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC))
+	is.NoErr(err)
+
+	is.Equal(idProvider.Provide(), utils.NextID("abc2345")) // archived
+
+	stub.Id = "def2345"
+	is.NoErr(repository.Save(session.Session{
+		Id:        "def2345",
+		StartTime: time.Date(2024, 4, 17, 19, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, 4, 17, 20, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+	}))
+	is.NoErr(repository.Delete("def2345"))
+
+	is.Equal(idProvider.Provide(), utils.NextID("def2345")) // trashed
+}
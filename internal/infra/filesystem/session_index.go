@@ -0,0 +1,419 @@
+package filesystem
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+	bolt "go.etcd.io/bbolt"
+)
+
+const indexFilename = "index.db"
+
+// legacyIndexFilename is the JSON index of older versions, it's removed once
+// the index is written
+const legacyIndexFilename = "index.json"
+
+// indexVersion is bumped whenever the index format changes, an index with
+// another version is rebuilt from the session files
+const indexVersion = 2
+
+// indexLockTimeout bounds the wait for another flow process writing the
+// index, the index is skipped past it
+const indexLockTimeout = time.Second
+
+// The index is a bbolt database: writes are transactional, so a crash never
+// leaves a partial index, and several processes can read it at once.
+var (
+	metaBucket = []byte("meta")
+	// filesBucket maps session filenames to their index entry
+	filesBucket = []byte("files")
+	// idsBucket maps session ids to their filename
+	idsBucket = []byte("ids")
+	// projectsBucket holds a bucket per project mapping its session ids to
+	// their filename
+	projectsBucket = []byte("projects")
+	// startsBucket maps start times followed by filenames to filenames, its
+	// keys are ordered by start time
+	startsBucket = []byte("starts")
+	versionKey   = []byte("version")
+	// sealedKey tells that the index leaves the projects and the tags out,
+	// as the sessions are encrypted
+	sealedKey = []byte("sealed")
+)
+
+// sessionIndexEntry holds what is needed to list projects and tags and to
+// filter sessions without reading their files. ModTime and Size tell if the
+// session file was changed since it was indexed, e.g. with 'flow edit'. When
+// the sessions are encrypted, Project and Tags are left out of the index file
+// and read from the session files, see labeledIndex.
+type sessionIndexEntry struct {
+	ModTime   time.Time
+	StartTime time.Time
+	Id        string
+	Project   string
+	Tags      []string
+	Size      int64
+}
+
+// sessionIndex maps session filenames to their index entry. It is only a
+// cache of the session files: it is refreshed from them when it's stale and
+// failing to write it never fails an operation.
+type sessionIndex struct {
+	Sessions map[string]sessionIndexEntry
+	Version  int
+}
+
+func (r *FileSystemSessionRepository) newSessionIndexEntry(s session.Session, fileInfo fs.FileInfo) sessionIndexEntry {
+	entry := sessionIndexEntry{
+		ModTime:   fileInfo.ModTime(),
+		Size:      fileInfo.Size(),
+		Id:        s.Id,
+		StartTime: s.StartTime,
+	}
+
+	if !r.sealedIndex() {
+		entry.Project = s.Project
+		entry.Tags = s.Tags
+	}
+
+	return entry
+}
+
+// sealedIndex tells if the projects and the tags are left out of the index,
+// so that it doesn't hold them in plaintext next to the encrypted sessions
+func (r *FileSystemSessionRepository) sealedIndex() bool {
+	return r.Cipher != nil
+}
+
+func (e sessionIndexEntry) isStale(fileInfo fs.FileInfo) bool {
+	return !e.ModTime.Equal(fileInfo.ModTime()) || e.Size != fileInfo.Size()
+}
+
+// session returns a session holding the indexed fields only
+func (e sessionIndexEntry) session() session.Session {
+	return session.Session{
+		Id:        e.Id,
+		Project:   e.Project,
+		Tags:      e.Tags,
+		StartTime: e.StartTime,
+	}
+}
+
+func (r *FileSystemSessionRepository) indexPath() string {
+	return filepath.Join(r.FlowFolderPath, indexFilename)
+}
+
+func (r *FileSystemSessionRepository) openIndex(readOnly bool) (*bolt.DB, error) {
+	return bolt.Open(r.indexPath(), 0666, &bolt.Options{Timeout: indexLockTimeout, ReadOnly: readOnly})
+}
+
+func emptyIndex() sessionIndex {
+	return sessionIndex{Version: indexVersion, Sessions: map[string]sessionIndexEntry{}}
+}
+
+func (r *FileSystemSessionRepository) readIndex() sessionIndex {
+	if _, err := os.Stat(r.indexPath()); err != nil {
+		return emptyIndex()
+	}
+
+	db, err := r.openIndex(true)
+	if err != nil {
+		return emptyIndex()
+	}
+	defer db.Close()
+
+	index := emptyIndex()
+	err = db.View(func(tx *bolt.Tx) error {
+		if !isIndexUpToDate(tx, r.sealedIndex()) {
+			return errIndexOutdated
+		}
+
+		return tx.Bucket(filesBucket).ForEach(func(filename, value []byte) error {
+			entry := sessionIndexEntry{}
+			if err := json.Unmarshal(value, &entry); err != nil {
+				return err
+			}
+			index.Sessions[string(filename)] = entry
+			return nil
+		})
+	})
+	if err != nil {
+		return emptyIndex()
+	}
+
+	return index
+}
+
+// updateIndex runs the update in a write transaction, the index is reset
+// first when it's outdated. A corrupted index is removed and created again.
+// The index of a read-only repository is never written.
+func (r *FileSystemSessionRepository) updateIndex(update func(tx *bolt.Tx) error) {
+	if r.ReadOnly {
+		return
+	}
+
+	db, err := r.openIndex(false)
+	if err != nil && !errors.Is(err, bolt.ErrTimeout) {
+		os.Remove(r.indexPath())
+		db, err = r.openIndex(false)
+	}
+	// a sealed index gets a new file, so that no page freed from the index
+	// of the unencrypted sessions still holds their projects and tags
+	if err == nil && r.sealedIndex() && hasUnsealedIndex(db) {
+		db.Close()
+		os.Remove(r.indexPath())
+		db, err = r.openIndex(false)
+	}
+	if err != nil {
+		return
+	}
+	defer db.Close()
+
+	db.Update(func(tx *bolt.Tx) error {
+		if !isIndexUpToDate(tx, r.sealedIndex()) {
+			if err := resetIndex(tx, r.sealedIndex()); err != nil {
+				return err
+			}
+		}
+
+		return update(tx)
+	})
+}
+
+func (r *FileSystemSessionRepository) writeIndex(index sessionIndex) {
+	if r.ReadOnly {
+		return
+	}
+
+	r.updateIndex(func(tx *bolt.Tx) error {
+		if err := resetIndex(tx, r.sealedIndex()); err != nil {
+			return err
+		}
+
+		for filename, entry := range index.Sessions {
+			if err := putIndexEntry(tx, filename, entry); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	os.Remove(filepath.Join(r.FlowFolderPath, legacyIndexFilename))
+}
+
+// isIndexUpToDate tells if the index has the current version and was written
+// sealed or not like it's expected, an index written before the sessions were
+// encrypted is rebuilt without their projects and tags
+func isIndexUpToDate(tx *bolt.Tx, sealed bool) bool {
+	meta := tx.Bucket(metaBucket)
+	if meta == nil {
+		return false
+	}
+
+	version, err := strconv.Atoi(string(meta.Get(versionKey)))
+	return err == nil && version == indexVersion && (meta.Get(sealedKey) != nil) == sealed
+}
+
+func hasUnsealedIndex(db *bolt.DB) bool {
+	unsealed := false
+	db.View(func(tx *bolt.Tx) error {
+		meta := tx.Bucket(metaBucket)
+		unsealed = meta != nil && meta.Get(sealedKey) == nil
+		return nil
+	})
+
+	return unsealed
+}
+
+func resetIndex(tx *bolt.Tx, sealed bool) error {
+	for _, name := range [][]byte{metaBucket, filesBucket, idsBucket, projectsBucket, startsBucket} {
+		if tx.Bucket(name) != nil {
+			if err := tx.DeleteBucket(name); err != nil {
+				return err
+			}
+		}
+
+		if _, err := tx.CreateBucket(name); err != nil {
+			return err
+		}
+	}
+
+	if sealed {
+		if err := tx.Bucket(metaBucket).Put(sealedKey, []byte("true")); err != nil {
+			return err
+		}
+	}
+
+	return tx.Bucket(metaBucket).Put(versionKey, []byte(strconv.Itoa(indexVersion)))
+}
+
+// startKey orders the keys of the starts bucket by start time, the filename
+// keeps the keys of sessions starting at the same time unique
+func startKey(startTime time.Time, filename string) []byte {
+	key := binary.BigEndian.AppendUint64(nil, uint64(startTime.UnixNano()))
+	return append(key, filename...)
+}
+
+func putIndexEntry(tx *bolt.Tx, filename string, entry sessionIndexEntry) error {
+	marshaled, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Bucket(filesBucket).Put([]byte(filename), marshaled); err != nil {
+		return err
+	}
+
+	if err := tx.Bucket(idsBucket).Put([]byte(entry.Id), []byte(filename)); err != nil {
+		return err
+	}
+
+	// a sealed index has no project
+	if entry.Project != "" {
+		project, err := tx.Bucket(projectsBucket).CreateBucketIfNotExists([]byte(entry.Project))
+		if err != nil {
+			return err
+		}
+		if err := project.Put([]byte(entry.Id), []byte(filename)); err != nil {
+			return err
+		}
+	}
+
+	return tx.Bucket(startsBucket).Put(startKey(entry.StartTime, filename), []byte(filename))
+}
+
+func deleteIndexEntry(tx *bolt.Tx, filename string) error {
+	value := tx.Bucket(filesBucket).Get([]byte(filename))
+	if value == nil {
+		return nil
+	}
+
+	entry := sessionIndexEntry{}
+	if err := json.Unmarshal(value, &entry); err != nil {
+		return err
+	}
+
+	if err := tx.Bucket(filesBucket).Delete([]byte(filename)); err != nil {
+		return err
+	}
+
+	// the id may already point to the new filename of the session
+	if string(tx.Bucket(idsBucket).Get([]byte(entry.Id))) == filename {
+		if err := tx.Bucket(idsBucket).Delete([]byte(entry.Id)); err != nil {
+			return err
+		}
+	}
+
+	if project := tx.Bucket(projectsBucket).Bucket([]byte(entry.Project)); project != nil && string(project.Get([]byte(entry.Id))) == filename {
+		if err := project.Delete([]byte(entry.Id)); err != nil {
+			return err
+		}
+	}
+
+	return tx.Bucket(startsBucket).Delete(startKey(entry.StartTime, filename))
+}
+
+// index returns the index of the given session files, only the files that
+// changed since they were indexed are read.
+func (r *FileSystemSessionRepository) index(fileInfos []fs.FileInfo) sessionIndex {
+	index := r.readIndex()
+	changed := false
+
+	sessionFilenames := map[string]bool{}
+	for _, fileInfo := range fileInfos {
+		sessionFilenames[fileInfo.Name()] = true
+
+		if entry, ok := index.Sessions[fileInfo.Name()]; ok && !entry.isStale(fileInfo) {
+			continue
+		}
+
+		changed = true
+
+		session, err := r.readSessionFile(fileInfo.Name())
+		if err != nil {
+			r.skipCorruptedFile(fileInfo.Name(), err)
+			delete(index.Sessions, fileInfo.Name())
+			continue
+		}
+
+		index.Sessions[fileInfo.Name()] = r.newSessionIndexEntry(*session, fileInfo)
+	}
+
+	for filename := range index.Sessions {
+		if !sessionFilenames[filename] {
+			delete(index.Sessions, filename)
+			changed = true
+		}
+	}
+
+	if changed {
+		r.writeIndex(index)
+	}
+
+	return index
+}
+
+// labeledIndex returns the index of the given session files with their
+// projects and tags, they're read from the session files when the index is
+// sealed
+func (r *FileSystemSessionRepository) labeledIndex(fileInfos []fs.FileInfo) sessionIndex {
+	index := r.index(fileInfos)
+	if !r.sealedIndex() {
+		return index
+	}
+
+	files := make([]sessionFile, 0, len(index.Sessions))
+	for filename, entry := range index.Sessions {
+		files = append(files, sessionFile{name: filename, startTime: entry.StartTime})
+	}
+
+	r.readSessionFiles(files, func(file sessionFile, s *session.Session, err error) error {
+		if err != nil {
+			r.skipCorruptedFile(file.name, err)
+			delete(index.Sessions, file.name)
+			return nil
+		}
+
+		entry := index.Sessions[file.name]
+		entry.Project = s.Project
+		entry.Tags = s.Tags
+		index.Sessions[file.name] = entry
+		return nil
+	})
+
+	return index
+}
+
+func (r *FileSystemSessionRepository) indexSavedSession(s session.Session, filename string) {
+	fileInfo, err := os.Stat(filepath.Join(r.FlowFolderPath, filename))
+	if err != nil {
+		return
+	}
+
+	r.updateIndex(func(tx *bolt.Tx) error {
+		// the session may have been indexed under another filename, like its legacy one
+		if indexedFilename := tx.Bucket(idsBucket).Get([]byte(s.Id)); indexedFilename != nil {
+			if err := deleteIndexEntry(tx, string(indexedFilename)); err != nil {
+				return err
+			}
+		}
+
+		return putIndexEntry(tx, filename, r.newSessionIndexEntry(s, fileInfo))
+	})
+}
+
+func (r *FileSystemSessionRepository) unindexSession(filename string) {
+	r.updateIndex(func(tx *bolt.Tx) error {
+		return deleteIndexEntry(tx, filename)
+	})
+}
+
+var errIndexOutdated = errors.New("the index is outdated")
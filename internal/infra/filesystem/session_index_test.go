@@ -0,0 +1,117 @@
+package filesystem_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra/filesystem"
+	"github.com/matryer/is"
+)
+
+func givenIndexedFlowFolder(t *testing.T) (string, filesystem.FileSystemSessionRepository) {
+	t.Helper()
+
+	folderPath := t.TempDir()
+	repository := filesystem.NewFileSystemSessionRepository(folderPath)
+
+	repository.Save(session.Session{
+		Id:        "1",
+		StartTime: time.Date(2024, 4, 17, 19, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, 4, 17, 20, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+		Tags:      []string{"index"},
+	})
+	repository.Save(session.Session{
+		Id:        "2",
+		StartTime: time.Date(2024, 4, 17, 21, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, 4, 17, 22, 0, 0, 0, time.UTC),
+		Project:   "my-project",
+		Tags:      []string{"docs"},
+	})
+
+	return folderPath, repository
+}
+
+func TestFileSystemSessionRepository_IndexIsUpdatedOnSaveAndDelete(t *testing.T) {
+	is := is.New(t)
+	folderPath, repository := givenIndexedFlowFolder(t)
+
+	_, err := os.Stat(filepath.Join(folderPath, "index.db"))
+	is.NoErr(err)
+
+	is.Equal(repository.FindAllProjects(), []string{"Flow", "my-project"})
+	is.Equal(repository.FindAllProjectTags("Flow"), []string{"index"})
+
+	is.NoErr(repository.Delete("2"))
+
+	is.Equal(repository.FindAllProjects(), []string{"Flow"})
+}
+
+func TestFileSystemSessionRepository_IndexIsRefreshedWhenStale(t *testing.T) {
+	is := is.New(t)
+	folderPath, repository := givenIndexedFlowFolder(t)
+
+	// a session file edited by hand, like with 'flow edit'
+	edited := session.Session{
+		Id:        "1",
+		StartTime: time.Date(2024, 4, 17, 19, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, 4, 17, 20, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+		Tags:      []string{"index", "edited"},
+	}
+	sessionFilename := filesystem.SessionFilename{Id: edited.Id, Project: edited.Project, StartTime: edited.StartTime}
+	marshaled, _ := json.Marshal(edited)
+	is.NoErr(os.WriteFile(filepath.Join(folderPath, sessionFilename.String()), marshaled, 0666))
+
+	// a session file added without the repository
+	added := session.Session{
+		Id:        "3",
+		StartTime: time.Date(2024, 4, 18, 9, 0, 0, 0, time.UTC),
+		Project:   "Other",
+	}
+	sessionFilename = filesystem.SessionFilename{Id: added.Id, Project: added.Project, StartTime: added.StartTime}
+	marshaled, _ = json.Marshal(added)
+	is.NoErr(os.WriteFile(filepath.Join(folderPath, sessionFilename.String()), marshaled, 0666))
+
+	is.Equal(repository.FindAllProjectTags("Flow"), []string{"index", "edited"})
+	is.Equal(repository.FindAllProjects(), []string{"Flow", "my-project", "Other"})
+
+	sessions := repository.FindAllSessions(&application.SessionsFilters{Tags: []string{"edited"}})
+	is.Equal(len(sessions), 1)
+	is.Equal(sessions[0].Id, "1")
+}
+
+func TestFileSystemSessionRepository_CorruptedIndexIsRebuilt(t *testing.T) {
+	is := is.New(t)
+	folderPath, repository := givenIndexedFlowFolder(t)
+
+	is.NoErr(os.WriteFile(filepath.Join(folderPath, "index.db"), []byte("not a bbolt database"), 0666))
+
+	sessions := repository.FindAllSessions(&application.SessionsFilters{Tags: []string{"docs"}})
+	is.Equal(len(sessions), 1)
+	is.Equal(sessions[0].Id, "2")
+	is.Equal(len(repository.Diagnose()), 0)
+}
+
+func TestFileSystemSessionRepository_LegacyIndexIsReplaced(t *testing.T) {
+	is := is.New(t)
+	folderPath, repository := givenIndexedFlowFolder(t)
+
+	is.NoErr(os.Remove(filepath.Join(folderPath, "index.db")))
+	is.NoErr(os.WriteFile(filepath.Join(folderPath, "index.json"), []byte("{\"Sessions\":{}}"), 0666))
+
+	sessions := repository.FindAllSessions(&application.SessionsFilters{Tags: []string{"docs"}})
+	is.Equal(len(sessions), 1)
+	is.Equal(sessions[0].Id, "2")
+
+	_, err := os.Stat(filepath.Join(folderPath, "index.json"))
+	is.True(os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(folderPath, "index.db"))
+	is.NoErr(err)
+	is.Equal(len(repository.Diagnose()), 0)
+}
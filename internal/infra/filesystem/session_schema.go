@@ -0,0 +1,105 @@
+package filesystem
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+// ErrUnsupportedSessionVersion is returned for a session file written by a
+// newer flow, which is skipped but never quarantined
+var ErrUnsupportedSessionVersion = errors.New("the session file was written by a newer version of flow")
+
+// sessionMigration upgrades the fields of a session file to the next version
+// of the format
+type sessionMigration func(fields map[string]json.RawMessage) error
+
+// sessionMigrations upgrade the session files when they're read, the
+// migration at index i turns a file of version i into one of version i+1.
+// Changing the format of the sessions appends a migration, which makes the
+// files written by Save one version newer.
+var sessionMigrations = []sessionMigration{
+	// version 1 only adds the version to the files
+	func(fields map[string]json.RawMessage) error { return nil },
+}
+
+// SessionSchemaVersion returns the version of the format of the session files
+// written by Save
+func SessionSchemaVersion() int {
+	return len(sessionMigrations)
+}
+
+// persistedSession is the content of a session file, the files written
+// before the version existed are of version 0
+type persistedSession struct {
+	Version int
+	session.Session
+}
+
+func marshalSession(s session.Session) ([]byte, error) {
+	return json.MarshalIndent(persistedSession{Version: SessionSchemaVersion(), Session: s}, "", "  ")
+}
+
+// unmarshalSession reads a session file of any version up to the current one
+func unmarshalSession(raw []byte) (*session.Session, error) {
+	var persisted persistedSession
+	if err := json.Unmarshal(raw, &persisted); err != nil {
+		return nil, err
+	}
+
+	if persisted.Version < 0 {
+		return nil, fmt.Errorf("invalid session file version %v", persisted.Version)
+	}
+	if persisted.Version > SessionSchemaVersion() {
+		return nil, fmt.Errorf("%w (version %v)", ErrUnsupportedSessionVersion, persisted.Version)
+	}
+	if persisted.Version == SessionSchemaVersion() {
+		return &persisted.Session, nil
+	}
+
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	for version := persisted.Version; version < SessionSchemaVersion(); version++ {
+		if err := sessionMigrations[version](fields); err != nil {
+			return nil, fmt.Errorf("can't upgrade the session file from version %v: %w", version, err)
+		}
+	}
+
+	migrated, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	var sessionData session.Session
+	if err := json.Unmarshal(migrated, &sessionData); err != nil {
+		return nil, err
+	}
+
+	return &sessionData, nil
+}
+
+// sessionFileVersion returns the version of the format of the session file
+func (r *FileSystemSessionRepository) sessionFileVersion(fileName string) (int, error) {
+	file, err := os.ReadFile(filepath.Join(r.FlowFolderPath, fileName))
+	if err != nil {
+		return 0, err
+	}
+
+	file, err = r.decrypt(file)
+	if err != nil {
+		return 0, err
+	}
+
+	var persisted struct{ Version int }
+	if err := json.Unmarshal(file, &persisted); err != nil {
+		return 0, err
+	}
+
+	return persisted.Version, nil
+}
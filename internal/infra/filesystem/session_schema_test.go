@@ -0,0 +1,137 @@
+package filesystem_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra/filesystem"
+	"github.com/matryer/is"
+)
+
+func writeSessionFile(t *testing.T, folderPath string, s session.Session, content string) string {
+	t.Helper()
+
+	sessionFilename := filesystem.SessionFilename{Id: s.Id, Project: s.Project, StartTime: s.StartTime}
+	if err := os.WriteFile(filepath.Join(folderPath, sessionFilename.String()), []byte(content), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	return sessionFilename.String()
+}
+
+func TestFileSystemSessionRepository_SchemaVersion(t *testing.T) {
+	s := session.Session{
+		Id:        "k3x7a2q",
+		StartTime: time.Date(2024, time.April, 17, 9, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, time.April, 17, 10, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+		Tags:      []string{"api"},
+	}
+	fields := `"Id": "k3x7a2q", "StartTime": "2024-04-17T09:00:00Z", "EndTime": "2024-04-17T10:00:00Z", "Project": "Flow", "Tags": ["api"]`
+
+	tt := []struct {
+		name    string
+		content string
+		want    *session.Session
+		legacy  bool
+	}{
+		{
+			name:    "file written before the versions",
+			content: `{` + fields + `}`,
+			want:    &s,
+			legacy:  true,
+		},
+		{
+			name:    "current version",
+			content: `{"Version": 1, ` + fields + `}`,
+			want:    &s,
+		},
+		{
+			name:    "newer version",
+			content: `{"Version": 99, ` + fields + `}`,
+			want:    nil,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			folder := t.TempDir()
+			repository := filesystem.NewFileSystemSessionRepository(folder)
+			repository.AutoQuarantine = true
+			filename := writeSessionFile(t, folder, s, tc.content)
+
+			found, err := repository.FindById(s.Id)
+			is.NoErr(err)
+			is.Equal(found, tc.want)
+			is.Equal(len(repository.FindLegacyFiles()) == 1, tc.legacy)
+
+			// files written by a newer flow are neither quarantined nor
+			// reported by the doctor
+			_, err = os.Stat(filepath.Join(folder, filename))
+			is.NoErr(err)
+			is.Equal(len(repository.Diagnose()), 0)
+		})
+	}
+}
+
+func TestFileSystemSessionRepository_SaveWritesTheVersion(t *testing.T) {
+	is := is.New(t)
+	folder := t.TempDir()
+	repository := filesystem.NewFileSystemSessionRepository(folder)
+
+	s := session.Session{
+		Id:        "k3x7a2q",
+		StartTime: time.Date(2024, time.April, 17, 9, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+	}
+	filename := writeSessionFile(t, folder, s, `{"Id": "k3x7a2q", "StartTime": "2024-04-17T09:00:00Z", "Project": "Flow"}`)
+
+	is.NoErr(repository.Migrate(filename))
+
+	content, err := os.ReadFile(filepath.Join(folder, filename))
+	is.NoErr(err)
+	var persisted struct{ Version int }
+	is.NoErr(json.Unmarshal(content, &persisted))
+	is.Equal(persisted.Version, filesystem.SessionSchemaVersion())
+	is.Equal(len(repository.FindLegacyFiles()), 0)
+}
+
+func TestFileSystemSessionRepository_Migrations(t *testing.T) {
+	is := is.New(t)
+	folder := t.TempDir()
+	repository := filesystem.NewFileSystemSessionRepository(folder)
+
+	s := session.Session{
+		Id:        "k3x7a2q",
+		StartTime: time.Date(2024, time.April, 17, 9, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+		Note:      "Reviewed the PR",
+	}
+	writeSessionFile(t, folder, s, `{"Version": 1, "Id": "k3x7a2q", "StartTime": "2024-04-17T09:00:00Z", "Project": "Flow", "Comment": "reviewed the PR"}`)
+
+	// version 2 renames the comment of the sessions to their note, and
+	// capitalizes it
+	filesystem.AppendSessionMigration(t, func(fields map[string]json.RawMessage) error {
+		var comment string
+		if err := json.Unmarshal(fields["Comment"], &comment); err != nil {
+			return err
+		}
+		note, err := json.Marshal(strings.ToUpper(comment[:1]) + comment[1:])
+		if err != nil {
+			return err
+		}
+
+		fields["Note"] = note
+		delete(fields, "Comment")
+		return nil
+	})
+
+	is.Equal(repository.FindAllSessions(nil), []session.Session{s})
+	is.Equal(repository.FindLegacyFiles(), []string{"v3.k3x7a2q.Rmxvdw.1713344400.json"})
+}
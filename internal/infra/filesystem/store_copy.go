@@ -0,0 +1,69 @@
+package filesystem
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// CopyStore copies the files of the flow folder at from into the folder at
+// to, keeping their modification times so the caches validated by them stay
+// valid. Anything that isn't a regular file, like the socket of 'flow
+// daemon', is left out. A missing flow folder is copied as an empty one.
+func CopyStore(from string, to string) error {
+	if _, err := os.Stat(from); os.IsNotExist(err) {
+		return os.MkdirAll(to, 0o755)
+	}
+
+	return filepath.WalkDir(from, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relativePath, err := filepath.Rel(from, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(to, relativePath)
+
+		if entry.IsDir() {
+			return os.MkdirAll(target, 0o755)
+		}
+
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+
+		return copyFile(path, target)
+	})
+}
+
+func copyFile(from string, to string) error {
+	info, err := os.Stat(from)
+	if err != nil {
+		return err
+	}
+
+	source, err := os.Open(from)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+
+	target, err := os.OpenFile(to, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(target, source); err != nil {
+		target.Close()
+		return err
+	}
+
+	if err := target.Close(); err != nil {
+		return err
+	}
+
+	return os.Chtimes(to, info.ModTime(), info.ModTime())
+}
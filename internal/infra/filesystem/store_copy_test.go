@@ -0,0 +1,56 @@
+package filesystem_test
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/infra/filesystem"
+	"github.com/matryer/is"
+)
+
+func TestCopyStore(t *testing.T) {
+	is := is.New(t)
+	from := t.TempDir()
+	to := filepath.Join(t.TempDir(), "sandbox")
+
+	modTime := time.Date(2024, time.April, 14, 10, 0, 0, 0, time.UTC)
+	os.WriteFile(filepath.Join(from, "1-Flow-1713089520.json"), []byte(`{"id":"1"}`), 0o600)
+	os.Chtimes(filepath.Join(from, "1-Flow-1713089520.json"), modTime, modTime)
+	os.Mkdir(filepath.Join(from, filesystem.QuarantineFolder), 0o755)
+	os.WriteFile(filepath.Join(from, filesystem.QuarantineFolder, "2-Flow-1713089520.json"), []byte("{"), 0o600)
+
+	if runtime.GOOS != "windows" {
+		listener, err := net.Listen("unix", filepath.Join(from, ".daemon.sock"))
+		is.NoErr(err)
+		defer listener.Close()
+	}
+
+	err := filesystem.CopyStore(from, to)
+
+	is.NoErr(err)
+	content, err := os.ReadFile(filepath.Join(to, "1-Flow-1713089520.json"))
+	is.NoErr(err)
+	is.Equal(string(content), `{"id":"1"}`)
+	info, _ := os.Stat(filepath.Join(to, "1-Flow-1713089520.json"))
+	is.True(info.ModTime().Equal(modTime))
+	_, err = os.Stat(filepath.Join(to, filesystem.QuarantineFolder, "2-Flow-1713089520.json"))
+	is.NoErr(err)
+	_, err = os.Stat(filepath.Join(to, ".daemon.sock"))
+	is.True(os.IsNotExist(err)) // the socket of the daemon isn't copied
+}
+
+func TestCopyStore_MissingFlowFolder(t *testing.T) {
+	is := is.New(t)
+	to := filepath.Join(t.TempDir(), "sandbox")
+
+	err := filesystem.CopyStore(filepath.Join(t.TempDir(), "missing"), to)
+
+	is.NoErr(err)
+	entries, err := os.ReadDir(to)
+	is.NoErr(err)
+	is.Equal(len(entries), 0)
+}
@@ -0,0 +1,58 @@
+package filesystem
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/TristanShz/flow/internal/domain/project"
+)
+
+const templatesFilename = "templates.json"
+
+type FileSystemTemplatesRepository struct {
+	FlowFolderPath string
+}
+
+func NewFileSystemTemplatesRepository(flowFolderPath string) FileSystemTemplatesRepository {
+	return FileSystemTemplatesRepository{
+		FlowFolderPath: flowFolderPath,
+	}
+}
+
+func (r *FileSystemTemplatesRepository) filePath() string {
+	return filepath.Join(r.FlowFolderPath, templatesFilename)
+}
+
+// Get returns the templates, they're skipped with a warning when the
+// templates file can't be read until they're synced again
+func (r *FileSystemTemplatesRepository) Get() project.Templates {
+	templates := project.Templates{}
+
+	file, err := os.ReadFile(r.filePath())
+	if errors.Is(err, os.ErrNotExist) {
+		return templates
+	}
+	if err != nil {
+		log.Printf("warning: skipping the templates (error while reading file %v: %v)", templatesFilename, err)
+		return templates
+	}
+
+	if err := json.Unmarshal(file, &templates); err != nil {
+		log.Printf("warning: skipping the templates (invalid templates data for file %v: %v)", templatesFilename, err)
+		return project.Templates{}
+	}
+
+	return templates
+}
+
+func (r *FileSystemTemplatesRepository) Save(templates project.Templates) error {
+	marshaled, err := json.MarshalIndent(templates, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(r.filePath(), marshaled, 0666, false)
+}
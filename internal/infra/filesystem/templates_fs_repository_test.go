@@ -0,0 +1,59 @@
+package filesystem_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/project"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra/filesystem"
+	"github.com/matryer/is"
+)
+
+func TestFileSystemTemplatesRepository(t *testing.T) {
+	is := is.New(t)
+	folderPath := t.TempDir()
+
+	repository := filesystem.NewFileSystemTemplatesRepository(folderPath)
+
+	is.Equal(repository.Get(), project.Templates{})
+
+	templates := project.Templates{
+		NamePattern: "^[a-z-]+$",
+		Tags:        []string{"dev", "review"},
+		Projects:    []project.Project{{Name: "acme-website", Client: "Acme", Billable: true, HourlyRate: 80}},
+	}
+	is.NoErr(repository.Save(templates))
+
+	is.Equal(repository.Get(), templates)
+}
+
+func TestFileSystemTemplatesRepository_IgnoredBySessionRepository(t *testing.T) {
+	is := is.New(t)
+	folderPath := t.TempDir()
+
+	templatesRepository := filesystem.NewFileSystemTemplatesRepository(folderPath)
+	sessionRepository := filesystem.NewFileSystemSessionRepository(folderPath)
+
+	is.NoErr(templatesRepository.Save(project.Templates{Tags: []string{"dev"}}))
+	is.NoErr(sessionRepository.Save(session.Session{
+		Id:        "1",
+		StartTime: time.Date(2024, 4, 17, 19, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+	}))
+
+	is.Equal(len(sessionRepository.FindAllSessions(nil)), 1)
+	is.Equal(len(sessionRepository.Diagnose()), 0)
+}
+
+func TestFileSystemTemplatesRepository_CorruptedFile(t *testing.T) {
+	is := is.New(t)
+	folderPath := t.TempDir()
+	is.NoErr(os.WriteFile(filepath.Join(folderPath, "templates.json"), []byte(`{"Tags": ["dev"`), 0666))
+
+	repository := filesystem.NewFileSystemTemplatesRepository(folderPath)
+
+	is.Equal(repository.Get(), project.Templates{}) // skipped with a warning
+}
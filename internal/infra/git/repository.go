@@ -0,0 +1,97 @@
+package git
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Repository is the git repository of a directory
+type Repository struct {
+	// Name is the name of the folder of the repository
+	Name string
+	// Branch is the checked out branch, empty when HEAD is detached
+	Branch string
+}
+
+// Detect returns the repository containing dir. It reads the .git folder
+// instead of running git, which keeps 'flow start' fast and works without git
+// installed.
+func Detect(dir string) (Repository, bool) {
+	root, gitDir, ok := findGitDir(dir)
+	if !ok {
+		return Repository{}, false
+	}
+
+	return Repository{
+		Name:   filepath.Base(root),
+		Branch: readBranch(gitDir),
+	}, true
+}
+
+// findGitDir walks up from dir to the root of the repository. .git is a file
+// pointing to the git folder in worktrees and submodules.
+func findGitDir(dir string) (root string, gitDir string, ok bool) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", "", false
+	}
+
+	for {
+		gitPath := filepath.Join(dir, ".git")
+		if info, err := os.Stat(gitPath); err == nil {
+			if info.IsDir() {
+				return dir, gitPath, true
+			}
+			if linked, ok := readGitFile(gitPath); ok {
+				return dir, linked, true
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", "", false
+		}
+		dir = parent
+	}
+}
+
+// readGitFile reads the "gitdir: <path>" line of a .git file
+func readGitFile(path string) (string, bool) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", false
+	}
+
+	gitDir, ok := strings.CutPrefix(strings.TrimSpace(string(content)), "gitdir: ")
+	if !ok {
+		return "", false
+	}
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(filepath.Dir(path), gitDir)
+	}
+
+	return gitDir, true
+}
+
+func readBranch(gitDir string) string {
+	file, err := os.Open(filepath.Join(gitDir, "HEAD"))
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	if !scanner.Scan() {
+		return ""
+	}
+
+	// a detached HEAD holds a commit hash instead of a ref
+	branch, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "ref: refs/heads/")
+	if !ok {
+		return ""
+	}
+
+	return branch
+}
@@ -0,0 +1,82 @@
+package git_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/TristanShz/flow/internal/infra/git"
+	"github.com/matryer/is"
+)
+
+func writeFile(t *testing.T, path string, content string) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDetect(t *testing.T) {
+	tt := []struct {
+		name   string
+		files  map[string]string
+		dir    string
+		want   git.Repository
+		wantOk bool
+	}{
+		{
+			name:   "Root of the repository",
+			files:  map[string]string{"flow/.git/HEAD": "ref: refs/heads/main\n"},
+			dir:    "flow",
+			want:   git.Repository{Name: "flow", Branch: "main"},
+			wantOk: true,
+		},
+		{
+			name:   "Subdirectory of the repository",
+			files:  map[string]string{"flow/.git/HEAD": "ref: refs/heads/feature/git-start\n", "flow/cmd/start/start.go": ""},
+			dir:    "flow/cmd/start",
+			want:   git.Repository{Name: "flow", Branch: "feature/git-start"},
+			wantOk: true,
+		},
+		{
+			name:   "Detached HEAD",
+			files:  map[string]string{"flow/.git/HEAD": "4b825dc642cb6eb9a060e54bf8d69288fbee4904\n"},
+			dir:    "flow",
+			want:   git.Repository{Name: "flow"},
+			wantOk: true,
+		},
+		{
+			name: "Worktree",
+			files: map[string]string{
+				"flow/.git/worktrees/flow-fix/HEAD": "ref: refs/heads/fix\n",
+				"flow-fix/.git":                     "gitdir: ../flow/.git/worktrees/flow-fix\n",
+			},
+			dir:    "flow-fix",
+			want:   git.Repository{Name: "flow-fix", Branch: "fix"},
+			wantOk: true,
+		},
+		{
+			name:  "Not a repository",
+			files: map[string]string{"notes/todo.md": ""},
+			dir:   "notes",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			root := t.TempDir()
+			for path, content := range tc.files {
+				writeFile(t, filepath.Join(root, path), content)
+			}
+
+			got, ok := git.Detect(filepath.Join(root, tc.dir))
+
+			is.Equal(ok, tc.wantOk)
+			is.Equal(got, tc.want)
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/infra/presenter"
+)
+
+// Folder is the folder of the hook scripts in the flow folder
+const Folder = "hooks"
+
+// DefaultTimeout bounds each script, the commands wait for the hooks before
+// exiting
+const DefaultTimeout = 10 * time.Second
+
+// Scripts are the names of the scripts run for each event
+var Scripts = map[string]string{
+	application.EventSessionStarted:        "on-start",
+	application.EventSessionStopped:        "on-stop",
+	application.EventSessionPaused:         "on-pause",
+	application.EventSessionResumed:        "on-resume",
+	application.EventSessionEdited:         "on-edit",
+	application.EventWorkingHoursUntracked: "on-untracked",
+}
+
+// Runner runs the script of the hooks folder named after an event, if any,
+// once the event happened. The script is given the event as JSON on its
+// standard input and as environment variables. A failing script is reported
+// to the error log, it never fails the command which published the event.
+type Runner struct {
+	Folder   string
+	Timeout  time.Duration
+	Output   io.Writer
+	ErrorLog *log.Logger
+}
+
+// NewRunner returns a runner of the scripts of the hooks folder of the flow
+// folder, writing their output to output
+func NewRunner(flowFolder string, output io.Writer, errorLog *log.Logger) Runner {
+	return Runner{
+		Folder:   filepath.Join(flowFolder, Folder),
+		Timeout:  DefaultTimeout,
+		Output:   output,
+		ErrorLog: errorLog,
+	}
+}
+
+// Handle runs the script of the event, it's the application.EventHandler of
+// the runner
+func (r Runner) Handle(event application.Event) {
+	name, ok := Scripts[event.Type]
+	if !ok {
+		return
+	}
+
+	path := filepath.Join(r.Folder, name)
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return
+	}
+
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0111 == 0 {
+		r.ErrorLog.Printf("Warning: hook %v isn't executable, run 'chmod +x %v'", name, path)
+		return
+	}
+
+	if err := r.run(path, event); err != nil {
+		r.ErrorLog.Printf("Warning: hook %v: %v", name, err)
+	}
+}
+
+func (r Runner) run(path string, event application.Event) error {
+	payload, err := json.Marshal(presenter.NewEventJSON(event))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.Timeout)
+	defer cancel()
+
+	command := exec.CommandContext(ctx, path)
+	command.Env = append(os.Environ(), Environment(event)...)
+	command.Stdin = bytes.NewReader(payload)
+	command.Stdout = r.Output
+	command.Stderr = r.Output
+
+	err = command.Run()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("killed after %v", r.Timeout)
+	}
+
+	return err
+}
+
+// Environment returns the environment variables describing the event, the
+// end time and the duration are empty until the session is stopped
+func Environment(event application.Event) []string {
+	s := event.Session
+
+	endTime, durationSeconds := "", ""
+	if !s.EndTime.IsZero() {
+		endTime = s.EndTime.Format(time.RFC3339)
+		durationSeconds = strconv.FormatInt(int64(s.Duration().Seconds()), 10)
+	}
+
+	return []string{
+		"FLOW_EVENT=" + event.Type,
+		"FLOW_SESSION_ID=" + s.Id,
+		"FLOW_PROJECT=" + s.Project,
+		"FLOW_TAGS=" + strings.Join(s.Tags, ","),
+		"FLOW_NOTE=" + s.Note,
+		"FLOW_START_TIME=" + s.StartTime.Format(time.RFC3339),
+		"FLOW_END_TIME=" + endTime,
+		"FLOW_DURATION_SECONDS=" + durationSeconds,
+	}
+}
@@ -0,0 +1,118 @@
+//go:build !windows
+
+package hooks_test
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra/hooks"
+	"github.com/matryer/is"
+)
+
+func TestRunner(t *testing.T) {
+	stopped := application.Event{
+		Type: application.EventSessionStopped,
+		At:   time.Date(2024, time.April, 13, 10, 30, 0, 0, time.UTC),
+		Session: session.Session{
+			Id:        "1",
+			StartTime: time.Date(2024, time.April, 13, 9, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, time.April, 13, 10, 30, 0, 0, time.UTC),
+			Project:   "Flow",
+			Tags:      []string{"deep", "cli"},
+		},
+	}
+
+	tt := []struct {
+		name         string
+		script       string
+		scriptName   string
+		mode         os.FileMode
+		timeout      time.Duration
+		wantOutput   string
+		wantErrorLog string
+	}{
+		{
+			name:       "Environment variables",
+			scriptName: "on-stop",
+			script:     "#!/bin/sh\necho \"$FLOW_EVENT $FLOW_PROJECT $FLOW_TAGS $FLOW_START_TIME $FLOW_END_TIME $FLOW_DURATION_SECONDS\"\n",
+			mode:       0755,
+			wantOutput: "session.stopped Flow deep,cli 2024-04-13T09:00:00Z 2024-04-13T10:30:00Z 5400\n",
+		},
+		{
+			name:       "Event as JSON on the standard input",
+			scriptName: "on-stop",
+			script:     "#!/bin/sh\ncat\n",
+			mode:       0755,
+			wantOutput: `{"event":"session.stopped","at":"2024-04-13T10:30:00Z","session":{"end_time":"2024-04-13T10:30:00Z","start_time":"2024-04-13T09:00:00Z","id":"1","project":"Flow","status":"ENDED","tags":["deep","cli"],"duration_seconds":5400},"duration":"1h30m0s"}`,
+		},
+		{
+			name:       "No script for the event",
+			scriptName: "on-start",
+			script:     "#!/bin/sh\necho started\n",
+			mode:       0755,
+		},
+		{
+			name:         "Script not executable",
+			scriptName:   "on-stop",
+			script:       "#!/bin/sh\necho stopped\n",
+			mode:         0644,
+			wantErrorLog: "Warning: hook on-stop isn't executable",
+		},
+		{
+			name:         "Script failing",
+			scriptName:   "on-stop",
+			script:       "#!/bin/sh\necho failed\nexit 3\n",
+			mode:         0755,
+			wantOutput:   "failed\n",
+			wantErrorLog: "Warning: hook on-stop: exit status 3\n",
+		},
+		{
+			name:         "Script too long",
+			scriptName:   "on-stop",
+			script:       "#!/bin/sh\nexec sleep 5\n",
+			mode:         0755,
+			timeout:      100 * time.Millisecond,
+			wantErrorLog: "Warning: hook on-stop: killed after 100ms\n",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			flowFolder := t.TempDir()
+			is.NoErr(os.Mkdir(filepath.Join(flowFolder, hooks.Folder), 0755))
+			is.NoErr(os.WriteFile(filepath.Join(flowFolder, hooks.Folder, tc.scriptName), []byte(tc.script), tc.mode))
+
+			output := &bytes.Buffer{}
+			errorLog := &bytes.Buffer{}
+			runner := hooks.NewRunner(flowFolder, output, log.New(errorLog, "", 0))
+			if tc.timeout != 0 {
+				runner.Timeout = tc.timeout
+			}
+
+			runner.Handle(stopped)
+
+			is.Equal(output.String(), tc.wantOutput)
+			is.True(bytes.HasPrefix(errorLog.Bytes(), []byte(tc.wantErrorLog))) // error log starts with the expected warning
+		})
+	}
+}
+
+func TestRunner_NoHooksFolder(t *testing.T) {
+	is := is.New(t)
+
+	errorLog := &bytes.Buffer{}
+	runner := hooks.NewRunner(t.TempDir(), &bytes.Buffer{}, log.New(errorLog, "", 0))
+
+	runner.Handle(application.Event{Type: application.EventSessionStarted})
+
+	is.Equal(errorLog.String(), "")
+}
@@ -0,0 +1,16 @@
+package infra
+
+import "github.com/TristanShz/flow/internal/application"
+
+type InMemoryImportConflictsRepository struct {
+	Conflicts []application.ImportConflict
+}
+
+func (r *InMemoryImportConflictsRepository) Save(conflicts []application.ImportConflict) error {
+	r.Conflicts = conflicts
+	return nil
+}
+
+func (r *InMemoryImportConflictsRepository) FindAll() ([]application.ImportConflict, error) {
+	return r.Conflicts, nil
+}
@@ -0,0 +1,16 @@
+package infra
+
+import "github.com/TristanShz/flow/internal/domain/invoice"
+
+type InMemoryInvoiceRepository struct {
+	Invoices []invoice.Invoice
+}
+
+func (r *InMemoryInvoiceRepository) Save(i invoice.Invoice) error {
+	r.Invoices = append(r.Invoices, i)
+	return nil
+}
+
+func (r *InMemoryInvoiceRepository) FindAll() ([]invoice.Invoice, error) {
+	return r.Invoices, nil
+}
@@ -0,0 +1,146 @@
+package jira
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+// DefaultTimeout bounds each post, 'flow stop' waits for the worklog before
+// exiting
+const DefaultTimeout = 10 * time.Second
+
+// minimumTimeSpent is the shortest worklog Jira accepts
+const minimumTimeSpent = time.Minute
+
+// startedLayout is the time format of the worklogs of the REST API
+const startedLayout = "2006-01-02T15:04:05.000-0700"
+
+// issueKeyPattern matches the keys of the issues, a project key and a number
+// like FLOW-123
+var issueKeyPattern = regexp.MustCompile(`\b[A-Z][A-Z0-9_]+-[1-9][0-9]*\b`)
+
+// IssueKey returns the key of the issue a session is about: the first tag
+// being an issue key, or else the first issue key of the note. Keys are
+// uppercase like in Jira, so that tags like covid-19 aren't taken for one.
+// It's empty when the session has none.
+func IssueKey(s session.Session) string {
+	for _, tag := range s.Tags {
+		if issueKeyPattern.FindString(tag) == tag {
+			return tag
+		}
+	}
+
+	return issueKeyPattern.FindString(s.Note)
+}
+
+type worklog struct {
+	Comment          string `json:"comment"`
+	Started          string `json:"started"`
+	TimeSpentSeconds int64  `json:"timeSpentSeconds"`
+}
+
+// Worklogger posts a worklog to the issue of each stopped session of the
+// projects logging their time to Jira. A failing post is reported to the log,
+// it never fails the command which stopped the session.
+type Worklogger struct {
+	HTTPClient *http.Client
+	Config     application.Jira
+	Log        *log.Logger
+}
+
+func NewWorklogger(config application.Jira, logger *log.Logger) *Worklogger {
+	return &Worklogger{
+		HTTPClient: &http.Client{Timeout: DefaultTimeout},
+		Config:     config,
+		Log:        logger,
+	}
+}
+
+// Handle logs the time of a stopped session, it's the
+// application.EventHandler of the worklogger
+func (w *Worklogger) Handle(event application.Event) {
+	s := event.Session
+	if event.Type != application.EventSessionStopped || s.EndTime.IsZero() || !w.Config.Logs(s.Project) {
+		return
+	}
+
+	key := IssueKey(s)
+	if key == "" {
+		return
+	}
+
+	if s.Duration() < minimumTimeSpent {
+		w.Log.Printf("Jira: %v not logged to %v, Jira needs at least %v", s.Duration(), key, minimumTimeSpent)
+		return
+	}
+
+	if w.Config.DryRun {
+		w.Log.Printf("Jira (dry run): %v would be logged to %v", s.Duration().Truncate(time.Second), key)
+		return
+	}
+
+	if err := w.post(key, newWorklog(s)); err != nil {
+		w.Log.Printf("Warning: jira worklog of %v: %v", key, err)
+		return
+	}
+
+	w.Log.Printf("Jira: %v logged to %v", s.Duration().Truncate(time.Second), key)
+}
+
+func newWorklog(s session.Session) worklog {
+	comment := s.Note
+	if comment == "" {
+		comment = s.Project
+	}
+
+	return worklog{
+		Comment:          comment,
+		Started:          s.StartTime.Format(startedLayout),
+		TimeSpentSeconds: int64(s.Duration().Seconds()),
+	}
+}
+
+// post adds the worklog with the version 2 of the REST API, which is the same
+// on Jira Cloud and Jira Data Center and takes the comment as plain text
+func (w *Worklogger) post(key string, entry worklog) error {
+	body, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequest(http.MethodPost, w.Config.URL+"/rest/api/2/issue/"+url.PathEscape(key)+"/worklog", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	if w.Config.Email != "" {
+		request.SetBasicAuth(w.Config.Email, w.Config.APIToken)
+	} else {
+		request.Header.Set("Authorization", "Bearer "+w.Config.APIToken)
+	}
+
+	response, err := w.HTTPClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		message, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return fmt.Errorf("jira answered %v: %v", response.Status, strings.TrimSpace(string(message)))
+	}
+	io.Copy(io.Discard, response.Body)
+
+	return nil
+}
@@ -0,0 +1,180 @@
+package jira_test
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra/jira"
+	"github.com/matryer/is"
+)
+
+func TestIssueKey(t *testing.T) {
+	tt := []struct {
+		name    string
+		session session.Session
+		want    string
+	}{
+		{
+			name:    "Tag",
+			session: session.Session{Tags: []string{"deep", "FLOW-123"}, Note: "Fix ACME-4"},
+			want:    "FLOW-123",
+		},
+		{
+			name:    "Note",
+			session: session.Session{Tags: []string{"deep"}, Note: "Review of ACME-42 with the team"},
+			want:    "ACME-42",
+		},
+		{
+			name:    "Not an issue key",
+			session: session.Session{Tags: []string{"covid-19", "FLOW-", "FLOW-123x"}, Note: "Release 2024-04"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			is.Equal(jira.IssueKey(tc.session), tc.want)
+		})
+	}
+}
+
+func TestWorklogger(t *testing.T) {
+	stopped := application.Event{
+		Type: application.EventSessionStopped,
+		At:   time.Date(2024, time.April, 13, 10, 30, 0, 0, time.UTC),
+		Session: session.Session{
+			Id:        "1",
+			StartTime: time.Date(2024, time.April, 13, 9, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, time.April, 13, 10, 30, 0, 0, time.UTC),
+			Project:   "flow",
+			Tags:      []string{"FLOW-123"},
+			Note:      "Worklog sync",
+		},
+	}
+	short := stopped
+	short.Session.EndTime = short.Session.StartTime.Add(30 * time.Second)
+	started := stopped
+	started.Type = application.EventSessionStarted
+	otherProject := stopped
+	otherProject.Session.Project = "acme-website"
+
+	tt := []struct {
+		name       string
+		config     application.Jira
+		event      application.Event
+		status     int
+		wantBody   string
+		wantLog    string
+		wantPosted bool
+	}{
+		{
+			name:       "Stopped session",
+			config:     application.Jira{Email: "me@acme.com"},
+			event:      stopped,
+			status:     http.StatusCreated,
+			wantBody:   `{"comment":"Worklog sync","started":"2024-04-13T09:00:00.000+0000","timeSpentSeconds":5400}`,
+			wantLog:    "Jira: 1h30m0s logged to FLOW-123\n",
+			wantPosted: true,
+		},
+		{
+			name:    "Dry run",
+			config:  application.Jira{Email: "me@acme.com", DryRun: true},
+			event:   stopped,
+			wantLog: "Jira (dry run): 1h30m0s would be logged to FLOW-123\n",
+		},
+		{
+			name:   "Project not logging to Jira",
+			config: application.Jira{Email: "me@acme.com"},
+			event:  otherProject,
+		},
+		{
+			name:   "Started session",
+			config: application.Jira{Email: "me@acme.com"},
+			event:  started,
+		},
+		{
+			name:    "Session too short",
+			config:  application.Jira{Email: "me@acme.com"},
+			event:   short,
+			wantLog: "Jira: 30s not logged to FLOW-123, Jira needs at least 1m0s\n",
+		},
+		{
+			name:       "Jira failing",
+			config:     application.Jira{Email: "me@acme.com"},
+			event:      stopped,
+			status:     http.StatusNotFound,
+			wantLog:    "Warning: jira worklog of FLOW-123: jira answered 404 Not Found: Issue does not exist\n",
+			wantPosted: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			posted := false
+			var body []byte
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				posted = true
+				body, _ = io.ReadAll(r.Body)
+				is.Equal(r.Method, http.MethodPost)
+				is.Equal(r.URL.Path, "/rest/api/2/issue/FLOW-123/worklog")
+				email, token, _ := r.BasicAuth()
+				is.Equal(email, "me@acme.com")
+				is.Equal(token, "token")
+				w.WriteHeader(tc.status)
+				if tc.status == http.StatusNotFound {
+					io.WriteString(w, "Issue does not exist")
+				}
+			}))
+			defer server.Close()
+
+			tc.config.URL = server.URL
+			tc.config.APIToken = "token"
+			tc.config.Projects = []string{"flow"}
+			logs := &bytes.Buffer{}
+			worklogger := jira.NewWorklogger(tc.config, log.New(logs, "", 0))
+
+			worklogger.Handle(tc.event)
+
+			is.Equal(posted, tc.wantPosted)
+			if tc.wantBody != "" {
+				is.Equal(string(body), tc.wantBody)
+			}
+			is.Equal(logs.String(), tc.wantLog)
+		})
+	}
+}
+
+func TestWorklogger_PersonalAccessToken(t *testing.T) {
+	is := is.New(t)
+
+	authorization := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	worklogger := jira.NewWorklogger(application.Jira{URL: server.URL, APIToken: "token", Projects: []string{"flow"}}, log.New(io.Discard, "", 0))
+
+	worklogger.Handle(application.Event{
+		Type: application.EventSessionStopped,
+		Session: session.Session{
+			StartTime: time.Date(2024, time.April, 13, 9, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, time.April, 13, 10, 0, 0, 0, time.UTC),
+			Project:   "flow",
+			Note:      "Review of FLOW-7",
+		},
+	})
+
+	is.Equal(authorization, "Bearer token")
+}
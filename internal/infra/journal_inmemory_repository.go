@@ -0,0 +1,27 @@
+package infra
+
+import (
+	"github.com/TristanShz/flow/internal/domain/journal"
+	"github.com/TristanShz/flow/pkg/timerange"
+)
+
+type InMemoryJournalRepository struct {
+	Entries []journal.Entry
+}
+
+func (r *InMemoryJournalRepository) Save(entry journal.Entry) error {
+	r.Entries = append(r.Entries, entry)
+	return nil
+}
+
+func (r *InMemoryJournalRepository) FindAll(timeRange timerange.TimeRange) []journal.Entry {
+	var entries []journal.Entry
+	for _, entry := range r.Entries {
+		if timeRange.Contains(entry.Day) {
+			entries = append(entries, entry)
+		}
+	}
+	journal.Sort(entries)
+
+	return entries
+}
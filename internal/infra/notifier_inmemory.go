@@ -0,0 +1,18 @@
+package infra
+
+import "github.com/TristanShz/flow/internal/application"
+
+// InMemoryNotifier keeps the notifications shown, or returns its error
+type InMemoryNotifier struct {
+	Notifications []application.Notification
+	Err           error
+}
+
+func (n *InMemoryNotifier) Notify(notification application.Notification) error {
+	if n.Err != nil {
+		return n.Err
+	}
+
+	n.Notifications = append(n.Notifications, notification)
+	return nil
+}
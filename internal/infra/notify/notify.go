@@ -0,0 +1,60 @@
+package notify
+
+import (
+	"os/exec"
+	"strings"
+
+	"github.com/TristanShz/flow/internal/application"
+)
+
+// CommandNotifier shows the notifications by running a command, with the
+// arguments built by Args
+type CommandNotifier struct {
+	Name string
+	Args func(notification application.Notification) []string
+}
+
+func (c CommandNotifier) Notify(notification application.Notification) error {
+	return exec.Command(c.Name, c.Args(notification)...).Run()
+}
+
+// UnsupportedNotifier is the notifier of the systems flow can't show
+// notifications on
+type UnsupportedNotifier struct{}
+
+func (n UnsupportedNotifier) Notify(notification application.Notification) error {
+	return application.ErrNotificationsUnsupported
+}
+
+// NotifySendArgs are the arguments of notify-send, available on most Linux
+// desktops
+func NotifySendArgs(notification application.Notification) []string {
+	return []string{"--app-name=flow", "--", notification.Title, notification.Message}
+}
+
+// OsascriptArgs are the arguments of osascript, the title and the message
+// are given to the script as arguments so that nothing needs escaping
+func OsascriptArgs(notification application.Notification) []string {
+	return []string{
+		"-e", "on run argv",
+		"-e", "display notification (item 2 of argv) with title (item 1 of argv)",
+		"-e", "end run",
+		notification.Title, notification.Message,
+	}
+}
+
+// PowerShellToast returns the PowerShell script showing the notification as
+// a toast of Windows
+func PowerShellToast(notification application.Notification) string {
+	return "[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] > $null; " +
+		"$template = [Windows.UI.Notifications.ToastNotificationManager]::GetTemplateContent([Windows.UI.Notifications.ToastTemplateType]::ToastText02); " +
+		"$texts = $template.GetElementsByTagName('text'); " +
+		"$texts.Item(0).AppendChild($template.CreateTextNode(" + powerShellString(notification.Title) + ")) > $null; " +
+		"$texts.Item(1).AppendChild($template.CreateTextNode(" + powerShellString(notification.Message) + ")) > $null; " +
+		"[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier('flow').Show([Windows.UI.Notifications.ToastNotification]::new($template))"
+}
+
+// powerShellString quotes the text as a verbatim string of PowerShell
+func powerShellString(text string) string {
+	return "'" + strings.ReplaceAll(text, "'", "''") + "'"
+}
@@ -0,0 +1,9 @@
+//go:build darwin
+
+package notify
+
+import "github.com/TristanShz/flow/internal/application"
+
+func NewNotifier() application.Notifier {
+	return CommandNotifier{Name: "osascript", Args: OsascriptArgs}
+}
@@ -0,0 +1,9 @@
+//go:build linux
+
+package notify
+
+import "github.com/TristanShz/flow/internal/application"
+
+func NewNotifier() application.Notifier {
+	return CommandNotifier{Name: "notify-send", Args: NotifySendArgs}
+}
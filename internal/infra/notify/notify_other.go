@@ -0,0 +1,9 @@
+//go:build !linux && !darwin && !windows
+
+package notify
+
+import "github.com/TristanShz/flow/internal/application"
+
+func NewNotifier() application.Notifier {
+	return UnsupportedNotifier{}
+}
@@ -0,0 +1,19 @@
+package notify_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/infra/notify"
+	"github.com/matryer/is"
+)
+
+func TestPowerShellToast(t *testing.T) {
+	is := is.New(t)
+
+	script := notify.PowerShellToast(application.Notification{Title: "Break time", Message: "Pomodoro #2 of 'flow' done"})
+
+	is.True(strings.Contains(script, "CreateTextNode('Break time')"))                   // title as a string
+	is.True(strings.Contains(script, "CreateTextNode('Pomodoro #2 of ''flow'' done')")) // quotes escaped
+}
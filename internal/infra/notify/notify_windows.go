@@ -0,0 +1,14 @@
+//go:build windows
+
+package notify
+
+import "github.com/TristanShz/flow/internal/application"
+
+func NewNotifier() application.Notifier {
+	return CommandNotifier{
+		Name: "powershell",
+		Args: func(notification application.Notification) []string {
+			return []string{"-NoProfile", "-Command", PowerShellToast(notification)}
+		},
+	}
+}
@@ -0,0 +1,36 @@
+package infra
+
+import (
+	"slices"
+
+	"github.com/TristanShz/flow/internal/application"
+)
+
+type InMemoryOperationLog struct {
+	Operations []application.Operation
+}
+
+func (l *InMemoryOperationLog) Record(operation application.Operation) error {
+	index := slices.IndexFunc(l.Operations, func(o application.Operation) bool {
+		return o.Id == operation.Id
+	})
+	if index == -1 {
+		l.Operations = append(l.Operations, operation)
+	} else {
+		l.Operations[index] = operation
+	}
+
+	return nil
+}
+
+func (l *InMemoryOperationLog) FindAll() ([]application.Operation, error) {
+	return slices.Clone(l.Operations), nil
+}
+
+func (l *InMemoryOperationLog) Remove(id string) error {
+	l.Operations = slices.DeleteFunc(l.Operations, func(o application.Operation) bool {
+		return o.Id == id
+	})
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package infra
+
+import (
+	"log"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+// OperationRecorder records the changes made through its session repository
+// and its active session lock in the operation log, under the operation
+// begun last. Nothing is recorded before an operation is begun. A change
+// that can't be recorded doesn't fail, it just can't be undone.
+type OperationRecorder struct {
+	log       application.OperationLog
+	operation *application.Operation
+}
+
+func NewOperationRecorder(log application.OperationLog) *OperationRecorder {
+	return &OperationRecorder{log: log}
+}
+
+// Begin records the next changes under the operation, it's only added to
+// the log once it changes something
+func (r *OperationRecorder) Begin(operation application.Operation) {
+	r.operation = &operation
+}
+
+func (r *OperationRecorder) record(change func(operation *application.Operation)) {
+	if r.operation == nil {
+		return
+	}
+
+	change(r.operation)
+
+	var err error
+	if len(r.operation.Changes) == 0 && r.operation.Acquired == "" && r.operation.Released == "" {
+		// e.g. a session made active then inactive, there is nothing to undo
+		err = r.log.Remove(r.operation.Id)
+	} else {
+		err = r.log.Record(*r.operation)
+	}
+	if err != nil {
+		log.Printf("warning: the operation couldn't be recorded, it can't be undone (%v)", err)
+	}
+}
+
+// RecordedSessionRepository is a session repository whose writes are
+// recorded by the recorder
+type RecordedSessionRepository struct {
+	application.SessionRepository
+	recorder *OperationRecorder
+}
+
+func (r *OperationRecorder) SessionRepository(repository application.SessionRepository) RecordedSessionRepository {
+	return RecordedSessionRepository{SessionRepository: repository, recorder: r}
+}
+
+func (r RecordedSessionRepository) Save(s session.Session) error {
+	before, err := r.FindById(s.Id)
+	if err != nil {
+		return err
+	}
+	if err := r.SessionRepository.Save(s); err != nil {
+		return err
+	}
+
+	r.recorder.record(func(operation *application.Operation) {
+		operation.Changes = append(operation.Changes, application.SessionChange{Before: before, After: &s})
+	})
+
+	return nil
+}
+
+func (r RecordedSessionRepository) Delete(id string) error {
+	before, err := r.FindById(id)
+	if err != nil {
+		return err
+	}
+	if err := r.SessionRepository.Delete(id); err != nil {
+		return err
+	}
+
+	if before != nil {
+		r.recorder.record(func(operation *application.Operation) {
+			operation.Changes = append(operation.Changes, application.SessionChange{Before: before})
+		})
+	}
+
+	return nil
+}
+
+// RecordedActiveSessionLock is an active session lock whose changes are
+// recorded by the recorder
+type RecordedActiveSessionLock struct {
+	lock     application.ActiveSessionLock
+	recorder *OperationRecorder
+}
+
+func (r *OperationRecorder) ActiveSessionLock(lock application.ActiveSessionLock) RecordedActiveSessionLock {
+	return RecordedActiveSessionLock{lock: lock, recorder: r}
+}
+
+func (l RecordedActiveSessionLock) Acquire(active application.ActiveSession) (application.ActiveSession, error) {
+	acquired, err := l.lock.Acquire(active)
+	if err != nil {
+		return acquired, err
+	}
+
+	l.recorder.record(func(operation *application.Operation) {
+		operation.Acquired = active.SessionId
+	})
+
+	return acquired, nil
+}
+
+func (l RecordedActiveSessionLock) Release(sessionId string) error {
+	if err := l.lock.Release(sessionId); err != nil {
+		return err
+	}
+
+	l.recorder.record(func(operation *application.Operation) {
+		// a session made active then inactive by the same operation leaves
+		// the lock as it was
+		if operation.Acquired == sessionId {
+			operation.Acquired = ""
+			return
+		}
+		operation.Released = sessionId
+	})
+
+	return nil
+}
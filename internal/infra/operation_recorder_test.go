@@ -0,0 +1,52 @@
+package infra_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/matryer/is"
+)
+
+func TestOperationRecorder(t *testing.T) {
+	is := is.New(t)
+
+	flowing := session.Session{Id: "1", StartTime: time.Date(2024, time.April, 15, 9, 0, 0, 0, time.UTC), Project: "Flow"}
+	stopped := flowing
+	stopped.EndTime = time.Date(2024, time.April, 15, 10, 0, 0, 0, time.UTC)
+
+	operationLog := &infra.InMemoryOperationLog{}
+	recorder := infra.NewOperationRecorder(operationLog)
+	repository := recorder.SessionRepository(&infra.InMemorySessionRepository{})
+	lock := recorder.ActiveSessionLock(&infra.InMemoryActiveSessionLock{})
+
+	// nothing is recorded before an operation is begun
+	is.NoErr(repository.Save(flowing))
+	is.NoErr(repository.Delete("1"))
+	is.Equal(len(operationLog.Operations), 0)
+
+	recorder.Begin(application.Operation{Id: "a", Command: "start"})
+	is.NoErr(repository.Save(flowing))
+	_, err := lock.Acquire(application.ActiveSession{AcquiredAt: flowing.StartTime, SessionId: "1"})
+	is.NoErr(err)
+
+	recorder.Begin(application.Operation{Id: "b", Command: "stop"})
+	is.NoErr(repository.Save(stopped))
+	is.NoErr(lock.Release("1"))
+
+	recorder.Begin(application.Operation{Id: "c", Command: "delete"})
+	is.NoErr(repository.Delete("1"))
+
+	recorder.Begin(application.Operation{Id: "d", Command: "run"})
+	_, err = lock.Acquire(application.ActiveSession{AcquiredAt: flowing.StartTime, SessionId: "2"})
+	is.NoErr(err)
+	is.NoErr(lock.Release("2"))
+
+	is.Equal(operationLog.Operations, []application.Operation{
+		{Id: "a", Command: "start", Changes: []application.SessionChange{{After: &flowing}}, Acquired: "1"},
+		{Id: "b", Command: "stop", Changes: []application.SessionChange{{Before: &flowing, After: &stopped}}, Released: "1"},
+		{Id: "c", Command: "delete", Changes: []application.SessionChange{{Before: &stopped}}},
+	})
+}
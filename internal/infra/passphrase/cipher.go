@@ -0,0 +1,203 @@
+package passphrase
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/TristanShz/flow/pkg/passwordcrypt"
+)
+
+// Header and Footer armor the encrypted files, so that they stay text in
+// the synced folders
+const (
+	Header = "-----BEGIN FLOW ENCRYPTED FILE-----"
+	Footer = "-----END FLOW ENCRYPTED FILE-----"
+)
+
+var ErrNoPassphrase = errors.New("no passphrase to encrypt the sessions, set FLOW_PASSPHRASE or key_file in the [encryption] table of the config")
+
+// Cipher encrypts the session files like passwordcrypt, with a key derived
+// from a passphrase or from the content of a key file. Each file holds the
+// salt of its key, the keys are derived once per salt and the files written
+// reuse the salt of the last file read, so that reading a flow folder
+// derives few keys.
+type Cipher struct {
+	Passphrase string
+	// KeyFile is read when there is no passphrase, its content is the
+	// passphrase, e.g. random bytes
+	KeyFile string
+	// Iterations of PBKDF2, passwordcrypt.Iterations when zero
+	Iterations int
+
+	mutex sync.Mutex
+	salt  []byte
+	keys  map[string][]byte
+}
+
+func NewCipher(passphrase string, keyFile string) *Cipher {
+	return &Cipher{
+		Passphrase: passphrase,
+		KeyFile:    keyFile,
+		Iterations: passwordcrypt.Iterations,
+	}
+}
+
+func (c *Cipher) passphrase() (string, error) {
+	if c.Passphrase != "" {
+		return c.Passphrase, nil
+	}
+
+	if c.KeyFile == "" {
+		return "", ErrNoPassphrase
+	}
+
+	content, err := os.ReadFile(c.KeyFile)
+	if err != nil {
+		return "", fmt.Errorf("the key file can't be read: %w", err)
+	}
+
+	// the trailing newline of a key file written by an editor isn't part of
+	// the key
+	content = bytes.TrimRight(content, "\r\n")
+	if len(content) == 0 {
+		return "", fmt.Errorf("the key file %v is empty", c.KeyFile)
+	}
+
+	return string(content), nil
+}
+
+// key returns the key of the salt, which is derived on the first call
+func (c *Cipher) key(salt []byte) ([]byte, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if key, ok := c.keys[string(salt)]; ok {
+		return key, nil
+	}
+
+	passphrase, err := c.passphrase()
+	if err != nil {
+		return nil, err
+	}
+
+	iterations := c.Iterations
+	if iterations <= 0 {
+		iterations = passwordcrypt.Iterations
+	}
+
+	if c.keys == nil {
+		c.keys = map[string][]byte{}
+	}
+	key := passwordcrypt.DeriveKey(passphrase, salt, iterations)
+	c.keys[string(salt)] = key
+
+	return key, nil
+}
+
+// encryptionSalt returns the salt of the last file read, or a new one
+func (c *Cipher) encryptionSalt() ([]byte, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.salt == nil {
+		salt := make([]byte, passwordcrypt.SaltSize)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, err
+		}
+		c.salt = salt
+	}
+
+	return c.salt, nil
+}
+
+func (c *Cipher) Encrypt(plaintext []byte) ([]byte, error) {
+	salt, err := c.encryptionSalt()
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := c.aead(salt)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	sealed := append(append(bytes.Clone(salt), nonce...), aead.Seal(nil, nonce, plaintext, nil)...)
+
+	armored := &strings.Builder{}
+	armored.WriteString(Header + "\n")
+	encoded := base64.StdEncoding.EncodeToString(sealed)
+	for len(encoded) > 64 {
+		armored.WriteString(encoded[:64] + "\n")
+		encoded = encoded[64:]
+	}
+	armored.WriteString(encoded + "\n" + Footer + "\n")
+
+	return []byte(armored.String()), nil
+}
+
+func (c *Cipher) Decrypt(ciphertext []byte) ([]byte, error) {
+	body, ok := bytes.CutPrefix(bytes.TrimSpace(ciphertext), []byte(Header))
+	if !ok {
+		return nil, errors.New("not a flow encrypted file")
+	}
+	body, ok = bytes.CutSuffix(body, []byte(Footer))
+	if !ok {
+		return nil, errors.New("truncated flow encrypted file")
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(string(body)), ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid flow encrypted file: %w", err)
+	}
+	if len(sealed) < passwordcrypt.SaltSize {
+		return nil, errors.New("truncated flow encrypted file")
+	}
+	salt, sealed := sealed[:passwordcrypt.SaltSize], sealed[passwordcrypt.SaltSize:]
+
+	aead, err := c.aead(salt)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("truncated flow encrypted file")
+	}
+	nonce, sealed := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+
+	plaintext, err := aead.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return nil, passwordcrypt.ErrWrongPassword
+	}
+
+	c.mutex.Lock()
+	c.salt = bytes.Clone(salt)
+	c.mutex.Unlock()
+
+	return plaintext, nil
+}
+
+func (c *Cipher) aead(salt []byte) (cipher.AEAD, error) {
+	key, err := c.key(salt)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,78 @@
+package passphrase_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TristanShz/flow/internal/infra/passphrase"
+	"github.com/matryer/is"
+)
+
+func TestCipher(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "flow.key")
+	if err := os.WriteFile(keyFile, []byte("correct horse battery staple\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tt := []struct {
+		name       string
+		encrypting *passphrase.Cipher
+		decrypting *passphrase.Cipher
+		wantErr    bool
+	}{
+		{
+			name:       "Passphrase",
+			encrypting: passphrase.NewCipher("correct horse battery staple", ""),
+			decrypting: passphrase.NewCipher("correct horse battery staple", ""),
+		},
+		{
+			name:       "Key file",
+			encrypting: passphrase.NewCipher("", keyFile),
+			decrypting: passphrase.NewCipher("correct horse battery staple", ""),
+		},
+		{
+			name:       "Wrong passphrase",
+			encrypting: passphrase.NewCipher("correct horse battery staple", ""),
+			decrypting: passphrase.NewCipher("wrong horse", ""),
+			wantErr:    true,
+		},
+		{
+			name:       "Missing key file",
+			encrypting: passphrase.NewCipher("correct horse battery staple", ""),
+			decrypting: passphrase.NewCipher("", filepath.Join(t.TempDir(), "missing.key")),
+			wantErr:    true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			tc.encrypting.Iterations = 1000
+			tc.decrypting.Iterations = 1000
+			plaintext := []byte(`{"Id":"1","Project":"Confidential client"}`)
+
+			ciphertext, err := tc.encrypting.Encrypt(plaintext)
+			is.NoErr(err)
+			is.True(strings.HasPrefix(string(ciphertext), passphrase.Header))
+			is.True(!strings.Contains(string(ciphertext), "Confidential"))
+
+			got, err := tc.decrypting.Decrypt(ciphertext)
+
+			is.Equal(err != nil, tc.wantErr)
+			if err == nil {
+				is.Equal(got, plaintext)
+			}
+		})
+	}
+}
+
+func TestCipher_NoPassphrase(t *testing.T) {
+	is := is.New(t)
+
+	_, err := passphrase.NewCipher("", "").Encrypt([]byte("{}"))
+
+	is.Equal(err, passphrase.ErrNoPassphrase)
+}
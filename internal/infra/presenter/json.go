@@ -0,0 +1,117 @@
+package presenter
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+	// OutputPlain is tab-separated and uncolored, its columns never change
+	OutputPlain = "plain"
+	// OutputMarkdown is only available for the reports
+	OutputMarkdown = "markdown"
+)
+
+func IsOutputValid(output string) bool {
+	return output == OutputText || output == OutputJSON || output == OutputPlain
+}
+
+func IsReportOutputValid(output string) bool {
+	return IsOutputValid(output) || output == OutputMarkdown
+}
+
+// DefaultOutput returns the output format of the config, text when it has
+// none
+func DefaultOutput(configured string) string {
+	if configured == "" {
+		return OutputText
+	}
+
+	return configured
+}
+
+// SessionJSON is the stable JSON schema of a session, fields must never be
+// renamed or removed as scripts rely on them.
+type SessionJSON struct {
+	EndTime         *time.Time        `json:"end_time"`
+	Metadata        map[string]string `json:"metadata,omitempty"`
+	StartTime       time.Time         `json:"start_time"`
+	Id              string            `json:"id"`
+	Project         string            `json:"project"`
+	Status          string            `json:"status"`
+	Tags            []string          `json:"tags"`
+	Note            string            `json:"note,omitempty"`
+	DurationSeconds int64             `json:"duration_seconds"`
+}
+
+func NewSessionJSON(s session.Session) SessionJSON {
+	sessionJSON := SessionJSON{
+		Id:              s.Id,
+		Project:         s.Project,
+		Tags:            s.Tags,
+		Note:            s.Note,
+		Metadata:        s.Metadata,
+		StartTime:       s.StartTime,
+		Status:          s.Status(),
+		DurationSeconds: int64(s.Duration().Seconds()),
+	}
+
+	if sessionJSON.Tags == nil {
+		sessionJSON.Tags = []string{}
+	}
+
+	if !s.EndTime.IsZero() {
+		endTime := s.EndTime
+		sessionJSON.EndTime = &endTime
+	}
+
+	return sessionJSON
+}
+
+// EventJSON is the stable JSON schema of an event of the sessions, posted to
+// the webhooks and given to the hook scripts
+type EventJSON struct {
+	Event   string      `json:"event"`
+	At      time.Time   `json:"at"`
+	Session SessionJSON `json:"session"`
+	// Duration is the duration of the session like 1h25m0s, empty until
+	// the session is stopped
+	Duration string `json:"duration,omitempty"`
+	// Untracked is the working time without session like 3h0m0s, only set
+	// for the untracked working hours
+	Untracked string `json:"untracked,omitempty"`
+}
+
+func NewEventJSON(event application.Event) EventJSON {
+	eventJSON := EventJSON{
+		Event:   event.Type,
+		At:      event.At,
+		Session: NewSessionJSON(event.Session),
+	}
+
+	if !event.Session.EndTime.IsZero() {
+		eventJSON.Duration = event.Session.Duration().String()
+	}
+
+	if event.Untracked > 0 {
+		eventJSON.Untracked = event.Untracked.String()
+	}
+
+	return eventJSON
+}
+
+func printJSON(logger *log.Logger, v any) {
+	marshaled, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		logger.Printf("error while encoding JSON output: %v", err)
+		return
+	}
+
+	logger.Println(string(marshaled))
+}
@@ -0,0 +1,40 @@
+package presenter
+
+import (
+	"errors"
+	"io"
+	"os"
+)
+
+var ErrPorcelainOutput = errors.New("--porcelain can't be used with another output than plain")
+
+// ResolveOutput returns the output to print to out, outputChanged tells if
+// the output flag was set. Porcelain always gives plain, so scripts get the
+// same format whatever the config. Text is replaced by plain when out is a
+// pipe or a file, unless it was set with the flag. Writers that aren't
+// files, like the buffers of the tests, keep the given output.
+func ResolveOutput(out io.Writer, output string, outputChanged bool, porcelain bool) (string, error) {
+	if porcelain {
+		if outputChanged && output != OutputPlain {
+			return "", ErrPorcelainOutput
+		}
+
+		return OutputPlain, nil
+	}
+
+	if outputChanged || output != OutputText {
+		return output, nil
+	}
+
+	file, ok := out.(*os.File)
+	if !ok {
+		return output, nil
+	}
+
+	fileInfo, err := file.Stat()
+	if err != nil || fileInfo.Mode()&os.ModeCharDevice != 0 {
+		return output, nil
+	}
+
+	return OutputPlain, nil
+}
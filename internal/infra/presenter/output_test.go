@@ -0,0 +1,82 @@
+package presenter_test
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/TristanShz/flow/internal/infra/presenter"
+	"github.com/matryer/is"
+)
+
+func TestResolveOutput(t *testing.T) {
+	pipeReader, pipeWriter, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pipeReader.Close()
+	defer pipeWriter.Close()
+
+	tt := []struct {
+		out           io.Writer
+		error         error
+		name          string
+		output        string
+		want          string
+		outputChanged bool
+		porcelain     bool
+	}{
+		{
+			name:   "Text to a buffer",
+			out:    &bytes.Buffer{},
+			output: presenter.OutputText,
+			want:   presenter.OutputText,
+		},
+		{
+			name:   "Text to a pipe",
+			out:    pipeWriter,
+			output: presenter.OutputText,
+			want:   presenter.OutputPlain,
+		},
+		{
+			name:          "Text set with the flag to a pipe",
+			out:           pipeWriter,
+			output:        presenter.OutputText,
+			outputChanged: true,
+			want:          presenter.OutputText,
+		},
+		{
+			name:   "JSON to a pipe",
+			out:    pipeWriter,
+			output: presenter.OutputJSON,
+			want:   presenter.OutputJSON,
+		},
+		{
+			name:      "Porcelain",
+			out:       &bytes.Buffer{},
+			output:    presenter.OutputJSON,
+			porcelain: true,
+			want:      presenter.OutputPlain,
+		},
+		{
+			name:          "Porcelain with another output",
+			out:           &bytes.Buffer{},
+			output:        presenter.OutputJSON,
+			outputChanged: true,
+			porcelain:     true,
+			error:         presenter.ErrPorcelainOutput,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			got, err := presenter.ResolveOutput(tc.out, tc.output, tc.outputChanged, tc.porcelain)
+
+			is.Equal(err, tc.error)
+			is.Equal(got, tc.want)
+		})
+	}
+}
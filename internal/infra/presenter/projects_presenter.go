@@ -0,0 +1,45 @@
+package presenter
+
+import (
+	"log"
+
+	"github.com/TristanShz/flow/utils"
+)
+
+type ProjectsCLIPresenter struct {
+	Logger *log.Logger
+}
+
+func (p ProjectsCLIPresenter) ShowProjects(projects []string) {
+	if len(projects) == 0 {
+		p.Logger.Println("No projects found")
+		return
+	}
+
+	for _, project := range projects {
+		p.Logger.Println(utils.ProjectColor(project))
+	}
+}
+
+type ProjectsJSONPresenter struct {
+	Logger *log.Logger
+}
+
+func (p ProjectsJSONPresenter) ShowProjects(projects []string) {
+	if projects == nil {
+		projects = []string{}
+	}
+
+	printJSON(p.Logger, map[string]any{"projects": projects})
+}
+
+// ProjectsPlainPresenter prints a project per line, without colors
+type ProjectsPlainPresenter struct {
+	Logger *log.Logger
+}
+
+func (p ProjectsPlainPresenter) ShowProjects(projects []string) {
+	for _, project := range projects {
+		p.Logger.Println(project)
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
+	"github.com/TristanShz/flow/internal/domain/session"
 	"github.com/TristanShz/flow/internal/domain/sessionsreport"
 	"github.com/TristanShz/flow/utils"
 )
@@ -14,7 +16,7 @@ type SessionsReportCLIPresenter struct {
 }
 
 func (s SessionsReportCLIPresenter) ShowByDay(sessionsReport sessionsreport.SessionsReport) {
-	if len(sessionsReport.Sessions) == 0 {
+	if len(sessionsReport.Sessions) == 0 && len(sessionsReport.Journal) == 0 {
 		s.Logger.Println("No sessions found")
 		return
 	}
@@ -23,28 +25,44 @@ func (s SessionsReportCLIPresenter) ShowByDay(sessionsReport sessionsreport.Sess
 	text := "Sessions Report\n\n"
 
 	for _, dayReport := range byDayReport {
-		text += fmt.Sprintf("%v - %v\n", utils.HeaderStyle.Render(dayReport.Day.Format("Mon, 02 Jan 2006")), utils.TimeColor(dayReport.TotalDuration.String()))
-		for _, session := range dayReport.Sessions {
-			if session.EndTime.IsZero() {
+		text += fmt.Sprintf("%v - %v", utils.HeaderStyle.Render(dayReport.Day.Format("Mon, 02 Jan 2006")), utils.TimeColor(dayReport.TotalDuration.String()))
+		if dayReport.Pomodoros > 0 {
+			text += fmt.Sprintf(" - %v", pomodoros(dayReport.Pomodoros))
+		}
+		text += "\n"
+		for _, sess := range dayReport.Sessions {
+			if sess.Status() == session.UnstoppedStatus {
+				text += fmt.Sprintf(
+					"    %v %v %v %v [%v]\n",
+					sess.Id,
+					utils.TimeColor(sess.StartTime.Format("15:04:05")),
+					utils.Faint("never stopped"),
+					utils.ProjectColor(sess.Project),
+					utils.TagColor(strings.Join(sess.Tags, ", ")),
+				)
+			} else if sess.EndTime.IsZero() {
 				text += fmt.Sprintf(
 					"    %v %v %v [%v]\n",
-					session.Id,
-					utils.TimeColor(session.StartTime.Format("15:04:05")),
-					utils.ProjectColor(session.Project),
-					utils.TagColor(strings.Join(session.Tags, ", ")),
+					sess.Id,
+					utils.TimeColor(sess.StartTime.Format("15:04:05")),
+					utils.ProjectColor(sess.Project),
+					utils.TagColor(strings.Join(sess.Tags, ", ")),
 				)
 			} else {
 				text += fmt.Sprintf(
 					"    %v %v to %v %v %v [%v]\n",
-					utils.Faint(session.Id),
-					utils.TimeColor(session.StartTime.Format("15:04:05")),
-					utils.TimeColor(session.EndTime.Format("15:04:05")),
-					session.Duration().String(),
-					utils.ProjectColor(session.Project),
-					utils.TagColor(strings.Join(session.Tags, ", ")),
+					utils.Faint(sess.Id),
+					utils.TimeColor(sess.StartTime.Format("15:04:05")),
+					utils.TimeColor(sess.EndTime.Format("15:04:05")),
+					sess.Duration().String(),
+					utils.ProjectColor(sess.Project),
+					utils.TagColor(strings.Join(sess.Tags, ", ")),
 				)
 			}
 		}
+		for _, entry := range dayReport.Journal {
+			text += fmt.Sprintf("    %v %v\n", utils.Faint("journal"), entry.Note)
+		}
 
 		text += "\n"
 	}
@@ -53,7 +71,7 @@ func (s SessionsReportCLIPresenter) ShowByDay(sessionsReport sessionsreport.Sess
 }
 
 func (s SessionsReportCLIPresenter) ShowByProject(sessionsReport sessionsreport.SessionsReport) {
-	if len(sessionsReport.Sessions) == 0 {
+	if sessionsReport.Count() == 0 {
 		s.Logger.Println("No sessions found")
 		return
 	}
@@ -72,3 +90,140 @@ func (s SessionsReportCLIPresenter) ShowByProject(sessionsReport sessionsreport.
 
 	s.Logger.Println(text)
 }
+
+func (s SessionsReportCLIPresenter) ShowByClient(sessionsReport sessionsreport.SessionsReport) {
+	if sessionsReport.Count() == 0 {
+		s.Logger.Println("No sessions found")
+		return
+	}
+
+	text := "Sessions Report\n\n"
+
+	for _, clientReport := range sessionsReport.GetByClientReport() {
+		client := clientReport.Client
+		if client == "" {
+			client = "No client"
+		}
+
+		text += fmt.Sprintf("%v - %v\n", utils.HeaderStyle.Render(client), utils.TimeColor(clientReport.TotalDuration.String()))
+		for _, report := range clientReport.Projects {
+			text += fmt.Sprintf("    %v - %v\n", utils.ProjectColor(report.Project), utils.TimeColor(report.TotalDuration.String()))
+			for tag, duration := range report.DurationByTag {
+				text += fmt.Sprintf("        [%v] -> %v\n", utils.TagColor(tag), utils.TimeColor(duration.String()))
+			}
+		}
+
+		text += "\n"
+	}
+
+	s.Logger.Println(text)
+}
+
+func (s SessionsReportCLIPresenter) ShowEarnings(earningsReport sessionsreport.EarningsReport) {
+	if len(earningsReport.Clients) == 0 {
+		s.Logger.Println("No billable sessions found")
+		return
+	}
+
+	text := "Earnings Report\n\n"
+
+	for _, client := range earningsReport.Clients {
+		name := client.Client
+		if name == "" {
+			name = "No client"
+		}
+
+		text += fmt.Sprintf("%v - %v - %.2f\n", utils.HeaderStyle.Render(name), utils.TimeColor(client.BillableDuration.String()), client.Earnings)
+		for _, project := range client.Projects {
+			text += fmt.Sprintf("    %v %v -> %.2f\n", utils.ProjectColor(project.Project), utils.TimeColor(project.BillableDuration.String()), project.Earnings)
+		}
+
+		text += "\n"
+	}
+
+	text += fmt.Sprintf("Total - %v - %.2f", utils.TimeColor(earningsReport.BillableDuration.String()), earningsReport.Earnings)
+
+	s.Logger.Println(text)
+}
+
+func (s SessionsReportCLIPresenter) ShowGaps(gapsReport sessionsreport.GapsReport) {
+	if len(gapsReport.Gaps) == 0 {
+		s.Logger.Println("No gaps found")
+		return
+	}
+
+	text := "Gaps Report\n\n"
+
+	var day time.Time
+	for _, gap := range gapsReport.Gaps {
+		gapDay := time.Date(gap.Start.Year(), gap.Start.Month(), gap.Start.Day(), 0, 0, 0, 0, gap.Start.Location())
+		if !gapDay.Equal(day) {
+			if !day.IsZero() {
+				text += "\n"
+			}
+			text += utils.HeaderStyle.Render(gap.Start.Format("Mon, 02 Jan 2006")) + "\n"
+			day = gapDay
+		}
+
+		text += fmt.Sprintf(
+			"    %v to %v %v\n",
+			utils.TimeColor(gap.Start.Format("15:04")),
+			utils.TimeColor(gap.End.Format("15:04")),
+			gap.Duration().String(),
+		)
+	}
+
+	text += fmt.Sprintf("\nUntracked - %v of %v of working hours", utils.TimeColor(gapsReport.Untracked.String()), gapsReport.WorkingTime.String())
+
+	s.Logger.Println(text)
+}
+
+// formatChange prints the delta of a diff with its sign, followed by its
+// change in percent, or by "new" when nothing was tracked in the first period
+func formatChange(delta time.Duration, percent float64, ok bool) string {
+	sign := ""
+	if delta >= 0 {
+		sign = "+"
+	}
+
+	if !ok {
+		if delta == 0 {
+			return sign + delta.String()
+		}
+		return fmt.Sprintf("%v%v, new", sign, delta)
+	}
+
+	return fmt.Sprintf("%v%v, %+.0f%%", sign, delta, percent)
+}
+
+func (s SessionsReportCLIPresenter) ShowComparison(periodsDiff sessionsreport.PeriodsDiff) {
+	if len(periodsDiff.Projects) == 0 {
+		s.Logger.Println("No sessions found")
+		return
+	}
+
+	percent, ok := periodsDiff.Change()
+	text := "Comparison Report\n\n"
+	text += fmt.Sprintf(
+		"Total: %v -> %v (%v)\n",
+		periodsDiff.A.Duration,
+		utils.TimeColor(periodsDiff.B.Duration.String()),
+		formatChange(periodsDiff.B.Duration-periodsDiff.A.Duration, percent, ok),
+	)
+
+	text += "\n" + utils.HeaderStyle.Render("Projects") + "\n"
+	for _, project := range periodsDiff.Projects {
+		percent, ok := project.Change()
+		text += fmt.Sprintf("    %v: %v -> %v (%v)\n", utils.ProjectColor(project.Project), project.A, utils.TimeColor(project.B.String()), formatChange(project.Delta(), percent, ok))
+	}
+
+	if len(periodsDiff.Tags) > 0 {
+		text += "\n" + utils.HeaderStyle.Render("Tags") + "\n"
+		for _, tag := range periodsDiff.Tags {
+			percent, ok := tag.Change()
+			text += fmt.Sprintf("    %v: %v -> %v (%v)\n", utils.TagColor(tag.Tag), tag.A, utils.TimeColor(tag.B.String()), formatChange(tag.Delta(), percent, ok))
+		}
+	}
+
+	s.Logger.Print(text)
+}
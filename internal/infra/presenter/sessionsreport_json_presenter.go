@@ -0,0 +1,204 @@
+package presenter
+
+import (
+	"log"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/sessionsreport"
+)
+
+type dayReportJSON struct {
+	Day                  string        `json:"day"`
+	Sessions             []SessionJSON `json:"sessions"`
+	TotalDurationSeconds int64         `json:"total_duration_seconds"`
+	Journal              []string      `json:"journal,omitempty"`
+	Pomodoros            int           `json:"pomodoros,omitempty"`
+}
+
+type projectReportJSON struct {
+	DurationByTagSeconds map[string]int64 `json:"duration_by_tag_seconds"`
+	Project              string           `json:"project"`
+	TotalDurationSeconds int64            `json:"total_duration_seconds"`
+}
+
+type clientReportJSON struct {
+	Client               string              `json:"client"`
+	Projects             []projectReportJSON `json:"projects"`
+	TotalDurationSeconds int64               `json:"total_duration_seconds"`
+}
+
+type projectEarningsJSON struct {
+	Project                 string  `json:"project"`
+	BillableDurationSeconds int64   `json:"billable_duration_seconds"`
+	Earnings                float64 `json:"earnings"`
+}
+
+type clientEarningsJSON struct {
+	Client                  string                `json:"client"`
+	Projects                []projectEarningsJSON `json:"projects"`
+	BillableDurationSeconds int64                 `json:"billable_duration_seconds"`
+	Earnings                float64               `json:"earnings"`
+}
+
+type gapJSON struct {
+	Start           time.Time `json:"start"`
+	End             time.Time `json:"end"`
+	DurationSeconds int64     `json:"duration_seconds"`
+}
+
+// diffJSON is the time of a project or a tag in each period, ChangePercent
+// is null when nothing was tracked in the first period
+type diffJSON struct {
+	Name          string   `json:"name"`
+	ASeconds      int64    `json:"a_seconds"`
+	BSeconds      int64    `json:"b_seconds"`
+	DeltaSeconds  int64    `json:"delta_seconds"`
+	ChangePercent *float64 `json:"change_percent"`
+}
+
+func newDiffJSON(name string, a time.Duration, b time.Duration, percent float64, ok bool) diffJSON {
+	diff := diffJSON{
+		Name:         name,
+		ASeconds:     int64(a.Seconds()),
+		BSeconds:     int64(b.Seconds()),
+		DeltaSeconds: int64((b - a).Seconds()),
+	}
+	if ok {
+		diff.ChangePercent = &percent
+	}
+
+	return diff
+}
+
+type SessionsReportJSONPresenter struct {
+	Logger *log.Logger
+}
+
+func (s SessionsReportJSONPresenter) ShowByDay(sessionsReport sessionsreport.SessionsReport) {
+	days := []dayReportJSON{}
+
+	for _, dayReport := range sessionsReport.GetByDayReport() {
+		sessions := []SessionJSON{}
+		for _, session := range dayReport.Sessions {
+			sessions = append(sessions, NewSessionJSON(session))
+		}
+
+		notes := []string{}
+		for _, entry := range dayReport.Journal {
+			notes = append(notes, entry.Note)
+		}
+
+		days = append(days, dayReportJSON{
+			Day:                  dayReport.Day.Format("2006-01-02"),
+			Sessions:             sessions,
+			TotalDurationSeconds: int64(dayReport.TotalDuration.Seconds()),
+			Journal:              notes,
+			Pomodoros:            dayReport.Pomodoros,
+		})
+	}
+
+	printJSON(s.Logger, map[string]any{"days": days})
+}
+
+func (s SessionsReportJSONPresenter) ShowByProject(sessionsReport sessionsreport.SessionsReport) {
+	printJSON(s.Logger, map[string]any{"projects": newProjectReportsJSON(sessionsReport.GetByProjectReport())})
+}
+
+func (s SessionsReportJSONPresenter) ShowByClient(sessionsReport sessionsreport.SessionsReport) {
+	clients := []clientReportJSON{}
+
+	for _, report := range sessionsReport.GetByClientReport() {
+		clients = append(clients, clientReportJSON{
+			Client:               report.Client,
+			Projects:             newProjectReportsJSON(report.Projects),
+			TotalDurationSeconds: int64(report.TotalDuration.Seconds()),
+		})
+	}
+
+	printJSON(s.Logger, map[string]any{"clients": clients})
+}
+
+func newProjectReportsJSON(projectReports []sessionsreport.ProjectReport) []projectReportJSON {
+	projects := []projectReportJSON{}
+
+	for _, report := range projectReports {
+		durationByTag := map[string]int64{}
+		for tag, duration := range report.DurationByTag {
+			durationByTag[tag] = int64(duration.Seconds())
+		}
+
+		projects = append(projects, projectReportJSON{
+			Project:              report.Project,
+			DurationByTagSeconds: durationByTag,
+			TotalDurationSeconds: int64(report.TotalDuration.Seconds()),
+		})
+	}
+
+	return projects
+}
+
+func (s SessionsReportJSONPresenter) ShowEarnings(earningsReport sessionsreport.EarningsReport) {
+	clients := []clientEarningsJSON{}
+
+	for _, client := range earningsReport.Clients {
+		projects := []projectEarningsJSON{}
+		for _, project := range client.Projects {
+			projects = append(projects, projectEarningsJSON{
+				Project:                 project.Project,
+				BillableDurationSeconds: int64(project.BillableDuration.Seconds()),
+				Earnings:                project.Earnings,
+			})
+		}
+
+		clients = append(clients, clientEarningsJSON{
+			Client:                  client.Client,
+			Projects:                projects,
+			BillableDurationSeconds: int64(client.BillableDuration.Seconds()),
+			Earnings:                client.Earnings,
+		})
+	}
+
+	printJSON(s.Logger, map[string]any{
+		"clients":                   clients,
+		"billable_duration_seconds": int64(earningsReport.BillableDuration.Seconds()),
+		"earnings":                  earningsReport.Earnings,
+	})
+}
+
+func (s SessionsReportJSONPresenter) ShowGaps(gapsReport sessionsreport.GapsReport) {
+	gaps := []gapJSON{}
+	for _, gap := range gapsReport.Gaps {
+		gaps = append(gaps, gapJSON{
+			Start:           gap.Start,
+			End:             gap.End,
+			DurationSeconds: int64(gap.Duration().Seconds()),
+		})
+	}
+
+	printJSON(s.Logger, map[string]any{
+		"gaps":                 gaps,
+		"working_time_seconds": int64(gapsReport.WorkingTime.Seconds()),
+		"untracked_seconds":    int64(gapsReport.Untracked.Seconds()),
+	})
+}
+
+func (s SessionsReportJSONPresenter) ShowComparison(periodsDiff sessionsreport.PeriodsDiff) {
+	projects := []diffJSON{}
+	for _, project := range periodsDiff.Projects {
+		percent, ok := project.Change()
+		projects = append(projects, newDiffJSON(project.Project, project.A, project.B, percent, ok))
+	}
+
+	tags := []diffJSON{}
+	for _, tag := range periodsDiff.Tags {
+		percent, ok := tag.Change()
+		tags = append(tags, newDiffJSON(tag.Tag, tag.A, tag.B, percent, ok))
+	}
+
+	percent, ok := periodsDiff.Change()
+	printJSON(s.Logger, map[string]any{
+		"total":    newDiffJSON("", periodsDiff.A.Duration, periodsDiff.B.Duration, percent, ok),
+		"projects": projects,
+		"tags":     tags,
+	})
+}
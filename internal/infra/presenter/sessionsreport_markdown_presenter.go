@@ -0,0 +1,247 @@
+package presenter
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/domain/sessionsreport"
+)
+
+// SessionsReportMarkdownPresenter prints the reports as Markdown tables, to
+// be pasted in client updates or standup notes. The by-day report is a
+// timesheet: a table per day, the totals per project and the grand total.
+type SessionsReportMarkdownPresenter struct {
+	Logger *log.Logger
+}
+
+// hoursMinutes formats a duration rounded to the minute, like 1h05m
+func hoursMinutes(d time.Duration) string {
+	d = d.Round(time.Minute)
+
+	return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+// signedHoursMinutes formats a delta like +1h30m or -0h45m
+func signedHoursMinutes(d time.Duration) string {
+	if d < 0 {
+		return "-" + hoursMinutes(-d)
+	}
+
+	return "+" + hoursMinutes(d)
+}
+
+// percentChange formats a change in percent, a dash standing for a project or
+// a tag nothing was tracked on in the first period
+func percentChange(percent float64, ok bool) string {
+	if !ok {
+		return "-"
+	}
+
+	return fmt.Sprintf("%+.0f%%", percent)
+}
+
+// pomodoros formats a number of pomodoros, like 1 pomodoro or 4 pomodoros
+func pomodoros(count int) string {
+	if count == 1 {
+		return "1 pomodoro"
+	}
+
+	return fmt.Sprintf("%v pomodoros", count)
+}
+
+// escapeCell keeps the pipes and new lines of a value from breaking the
+// table
+func escapeCell(value string) string {
+	value = strings.ReplaceAll(value, "|", `\|`)
+
+	return strings.Join(strings.Fields(value), " ")
+}
+
+func tableRow(cells ...string) string {
+	return "| " + strings.Join(cells, " | ") + " |\n"
+}
+
+func tableHeader(cells ...string) string {
+	separators := []string{}
+	for range cells {
+		separators = append(separators, "---")
+	}
+
+	return tableRow(cells...) + tableRow(separators...)
+}
+
+func projectTotalsTable(reports []sessionsreport.ProjectReport) string {
+	text := tableHeader("Project", "Duration")
+	for _, report := range reports {
+		text += tableRow(escapeCell(report.Project), hoursMinutes(report.TotalDuration))
+	}
+
+	return text
+}
+
+func grandTotal(d time.Duration) string {
+	return fmt.Sprintf("**Total: %v**", hoursMinutes(d))
+}
+
+func (s SessionsReportMarkdownPresenter) ShowByDay(sessionsReport sessionsreport.SessionsReport) {
+	if len(sessionsReport.Sessions) == 0 && len(sessionsReport.Journal) == 0 {
+		s.Logger.Println("No sessions found")
+		return
+	}
+
+	byDayReport := sessionsReport.GetByDayReport()
+	text := "# Timesheet"
+	first, last := byDayReport[0].Day, byDayReport[len(byDayReport)-1].Day
+	if first.Equal(last) {
+		text += fmt.Sprintf(" %v\n\n", first.Format(time.DateOnly))
+	} else {
+		text += fmt.Sprintf(" %v to %v\n\n", first.Format(time.DateOnly), last.Format(time.DateOnly))
+	}
+
+	for _, dayReport := range byDayReport {
+		text += fmt.Sprintf("## %v - %v", dayReport.Day.Format("Mon, 02 Jan 2006"), hoursMinutes(dayReport.TotalDuration))
+		if dayReport.Pomodoros > 0 {
+			text += fmt.Sprintf(" - %v", pomodoros(dayReport.Pomodoros))
+		}
+		text += "\n\n"
+		for _, entry := range dayReport.Journal {
+			text += fmt.Sprintf("> %v\n\n", entry.Note)
+		}
+		if len(dayReport.Sessions) == 0 {
+			continue
+		}
+		text += tableHeader("Start", "End", "Duration", "Project", "Tags", "Note")
+		for _, sess := range dayReport.Sessions {
+			end, duration := sess.EndTime.Format("15:04"), hoursMinutes(sess.Duration())
+			switch sess.Status() {
+			case session.UnstoppedStatus:
+				end, duration = "never stopped", "-"
+			case session.FlowingStatus:
+				end = "flowing"
+			}
+
+			text += tableRow(
+				sess.StartTime.Format("15:04"),
+				end,
+				duration,
+				escapeCell(sess.Project),
+				escapeCell(strings.Join(sess.Tags, ", ")),
+				escapeCell(sess.Note),
+			)
+		}
+		text += "\n"
+	}
+
+	text += "## Totals per project\n\n"
+	text += projectTotalsTable(sessionsReport.GetByProjectReport())
+	text += "\n" + grandTotal(sessionsReport.Duration(sessionsReport.Sessions))
+
+	s.Logger.Println(text)
+}
+
+func (s SessionsReportMarkdownPresenter) ShowByProject(sessionsReport sessionsreport.SessionsReport) {
+	if sessionsReport.Count() == 0 {
+		s.Logger.Println("No sessions found")
+		return
+	}
+
+	text := "# Sessions Report\n\n"
+	text += projectTotalsTable(sessionsReport.GetByProjectReport())
+	text += "\n" + grandTotal(sessionsReport.TotalDuration())
+
+	s.Logger.Println(text)
+}
+
+func (s SessionsReportMarkdownPresenter) ShowByClient(sessionsReport sessionsreport.SessionsReport) {
+	if sessionsReport.Count() == 0 {
+		s.Logger.Println("No sessions found")
+		return
+	}
+
+	text := "# Sessions Report\n\n"
+	for _, clientReport := range sessionsReport.GetByClientReport() {
+		client := clientReport.Client
+		if client == "" {
+			client = "No client"
+		}
+
+		text += fmt.Sprintf("## %v - %v\n\n", escapeCell(client), hoursMinutes(clientReport.TotalDuration))
+		text += projectTotalsTable(clientReport.Projects)
+		text += "\n"
+	}
+	text += grandTotal(sessionsReport.TotalDuration())
+
+	s.Logger.Println(text)
+}
+
+func (s SessionsReportMarkdownPresenter) ShowEarnings(earningsReport sessionsreport.EarningsReport) {
+	if len(earningsReport.Clients) == 0 {
+		s.Logger.Println("No billable sessions found")
+		return
+	}
+
+	text := "# Earnings Report\n\n"
+	text += tableHeader("Client", "Project", "Billable", "Earnings")
+	for _, client := range earningsReport.Clients {
+		name := client.Client
+		if name == "" {
+			name = "No client"
+		}
+
+		for _, project := range client.Projects {
+			text += tableRow(escapeCell(name), escapeCell(project.Project), hoursMinutes(project.BillableDuration), fmt.Sprintf("%.2f", project.Earnings))
+		}
+	}
+	text += fmt.Sprintf("\n**Total: %v - %.2f**", hoursMinutes(earningsReport.BillableDuration), earningsReport.Earnings)
+
+	s.Logger.Println(text)
+}
+
+func (s SessionsReportMarkdownPresenter) ShowGaps(gapsReport sessionsreport.GapsReport) {
+	if len(gapsReport.Gaps) == 0 {
+		s.Logger.Println("No gaps found")
+		return
+	}
+
+	text := "# Gaps Report\n\n"
+	text += tableHeader("Day", "From", "To", "Duration")
+	for _, gap := range gapsReport.Gaps {
+		text += tableRow(gap.Start.Format("Mon 2006-01-02"), gap.Start.Format("15:04"), gap.End.Format("15:04"), hoursMinutes(gap.Duration()))
+	}
+	text += fmt.Sprintf("\n**Untracked: %v of %v of working hours**", hoursMinutes(gapsReport.Untracked), hoursMinutes(gapsReport.WorkingTime))
+
+	s.Logger.Println(text)
+}
+
+func (s SessionsReportMarkdownPresenter) ShowComparison(periodsDiff sessionsreport.PeriodsDiff) {
+	if len(periodsDiff.Projects) == 0 {
+		s.Logger.Println("No sessions found")
+		return
+	}
+
+	text := "# Comparison Report\n\n"
+	text += tableHeader("Project", "Before", "After", "Delta", "Change")
+	for _, project := range periodsDiff.Projects {
+		text += tableRow(project.Project, hoursMinutes(project.A), hoursMinutes(project.B), signedHoursMinutes(project.Delta()), percentChange(project.Change()))
+	}
+
+	if len(periodsDiff.Tags) > 0 {
+		text += "\n" + tableHeader("Tag", "Before", "After", "Delta", "Change")
+		for _, tag := range periodsDiff.Tags {
+			text += tableRow(tag.Tag, hoursMinutes(tag.A), hoursMinutes(tag.B), signedHoursMinutes(tag.Delta()), percentChange(tag.Change()))
+		}
+	}
+
+	text += fmt.Sprintf(
+		"\n**Total: %v -> %v (%v, %v)**",
+		hoursMinutes(periodsDiff.A.Duration),
+		hoursMinutes(periodsDiff.B.Duration),
+		signedHoursMinutes(periodsDiff.B.Duration-periodsDiff.A.Duration),
+		percentChange(periodsDiff.Change()),
+	)
+
+	s.Logger.Println(text)
+}
@@ -0,0 +1,128 @@
+package presenter
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/sessionsreport"
+)
+
+// SessionsReportPlainPresenter prints a tab-separated row per line, without
+// header nor colors. Columns are only ever added at the end, durations are
+// in seconds and times in RFC 3339. Empty reports print nothing.
+type SessionsReportPlainPresenter struct {
+	Logger *log.Logger
+}
+
+func printRow(logger *log.Logger, columns ...any) {
+	values := []string{}
+	for _, column := range columns {
+		values = append(values, fmt.Sprint(column))
+	}
+
+	logger.Println(strings.Join(values, "\t"))
+}
+
+func seconds(d time.Duration) int64 {
+	return int64(d.Seconds())
+}
+
+// printProjectRows prints the total of the project with an empty tag, then
+// a row per tag sorted by name
+func printProjectRows(logger *log.Logger, prefix []any, report sessionsreport.ProjectReport) {
+	printRow(logger, append(prefix, report.Project, "", seconds(report.TotalDuration))...)
+
+	tags := []string{}
+	for tag := range report.DurationByTag {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	for _, tag := range tags {
+		printRow(logger, append(prefix, report.Project, tag, seconds(report.DurationByTag[tag]))...)
+	}
+}
+
+// ShowByDay prints a row per session: day, id, start, end, duration,
+// project and tags. The end is empty for the unstopped sessions.
+func (s SessionsReportPlainPresenter) ShowByDay(sessionsReport sessionsreport.SessionsReport) {
+	for _, dayReport := range sessionsReport.GetByDayReport() {
+		for _, sess := range dayReport.Sessions {
+			endTime := ""
+			if !sess.EndTime.IsZero() {
+				endTime = sess.EndTime.Format(time.RFC3339)
+			}
+
+			printRow(
+				s.Logger,
+				dayReport.Day.Format("2006-01-02"),
+				sess.Id,
+				sess.StartTime.Format(time.RFC3339),
+				endTime,
+				seconds(sess.Duration()),
+				sess.Project,
+				strings.Join(sess.Tags, ","),
+			)
+		}
+	}
+}
+
+// ShowByProject prints project, tag and duration rows
+func (s SessionsReportPlainPresenter) ShowByProject(sessionsReport sessionsreport.SessionsReport) {
+	for _, report := range sessionsReport.GetByProjectReport() {
+		printProjectRows(s.Logger, []any{}, report)
+	}
+}
+
+// ShowByClient prints client, project, tag and duration rows, the client is
+// empty for the projects billed to no client
+func (s SessionsReportPlainPresenter) ShowByClient(sessionsReport sessionsreport.SessionsReport) {
+	for _, clientReport := range sessionsReport.GetByClientReport() {
+		for _, report := range clientReport.Projects {
+			printProjectRows(s.Logger, []any{clientReport.Client}, report)
+		}
+	}
+}
+
+// ShowEarnings prints client, project, billable duration and earnings rows
+func (s SessionsReportPlainPresenter) ShowEarnings(earningsReport sessionsreport.EarningsReport) {
+	for _, client := range earningsReport.Clients {
+		for _, project := range client.Projects {
+			printRow(s.Logger, client.Client, project.Project, seconds(project.BillableDuration), fmt.Sprintf("%.2f", project.Earnings))
+		}
+	}
+}
+
+// ShowGaps prints a row per gap: day, start, end and duration
+func (s SessionsReportPlainPresenter) ShowGaps(gapsReport sessionsreport.GapsReport) {
+	for _, gap := range gapsReport.Gaps {
+		printRow(s.Logger, gap.Start.Format("2006-01-02"), gap.Start.Format(time.RFC3339), gap.End.Format(time.RFC3339), seconds(gap.Duration()))
+	}
+}
+
+// ShowComparison prints a row for the total, then for each project and tag,
+// with the seconds of each period and the change in percent, empty when
+// nothing was tracked in the first period
+func (s SessionsReportPlainPresenter) ShowComparison(periodsDiff sessionsreport.PeriodsDiff) {
+	printDiffRow := func(kind string, name string, a time.Duration, b time.Duration, percent float64, ok bool) {
+		change := ""
+		if ok {
+			change = fmt.Sprintf("%.1f", percent)
+		}
+		printRow(s.Logger, kind, name, seconds(a), seconds(b), seconds(b-a), change)
+	}
+
+	percent, ok := periodsDiff.Change()
+	printDiffRow("total", "", periodsDiff.A.Duration, periodsDiff.B.Duration, percent, ok)
+	for _, project := range periodsDiff.Projects {
+		percent, ok := project.Change()
+		printDiffRow("project", project.Project, project.A, project.B, percent, ok)
+	}
+	for _, tag := range periodsDiff.Tags {
+		percent, ok := tag.Change()
+		printDiffRow("tag", tag.Tag, tag.A, tag.B, percent, ok)
+	}
+}
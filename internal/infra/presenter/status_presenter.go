@@ -0,0 +1,93 @@
+package presenter
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/utils"
+)
+
+type StatusCLIPresenter struct {
+	Logger *log.Logger
+}
+
+func (s StatusCLIPresenter) ShowStatus(session *session.Session, duration time.Duration, weeklyTrend []time.Duration) {
+	msg := "No active flow session"
+
+	if session != nil {
+		msg = fmt.Sprintf(
+			"You're in the flow for %v on project %v",
+			utils.TimeColor(duration.String()),
+			utils.ProjectColor(session.Project),
+		)
+
+		if len(session.Tags) > 0 {
+			msg += fmt.Sprintf(" with tags: %v", utils.TagColor(strings.Join(session.Tags, ", ")))
+		}
+	}
+
+	if len(weeklyTrend) > 0 {
+		hours := make([]float64, len(weeklyTrend))
+		for i, weekDuration := range weeklyTrend {
+			hours[i] = weekDuration.Hours()
+		}
+
+		msg += fmt.Sprintf(
+			"\nLast %v weeks: %v (%v this week)",
+			len(weeklyTrend),
+			utils.Sparkline(hours),
+			utils.TimeColor(weeklyTrend[len(weeklyTrend)-1].Round(time.Minute).String()),
+		)
+	}
+
+	s.Logger.Println(msg)
+}
+
+type statusJSON struct {
+	Session            *SessionJSON `json:"session"`
+	WeeklyTrendSeconds []int64      `json:"weekly_trend_seconds,omitempty"`
+	ElapsedSeconds     int64        `json:"elapsed_seconds"`
+	Active             bool         `json:"active"`
+}
+
+type StatusJSONPresenter struct {
+	Logger *log.Logger
+}
+
+func (s StatusJSONPresenter) ShowStatus(session *session.Session, duration time.Duration, weeklyTrend []time.Duration) {
+	status := statusJSON{}
+
+	if session != nil {
+		sessionJSON := NewSessionJSON(*session)
+		status.Session = &sessionJSON
+		status.Active = true
+		status.ElapsedSeconds = int64(duration.Seconds())
+	}
+
+	if weeklyTrend != nil {
+		status.WeeklyTrendSeconds = make([]int64, len(weeklyTrend))
+		for i, weekDuration := range weeklyTrend {
+			status.WeeklyTrendSeconds[i] = int64(weekDuration.Seconds())
+		}
+	}
+
+	printJSON(s.Logger, status)
+}
+
+// StatusOnelinePresenter prints the project and the elapsed time of the
+// current session on a single uncolored line, to embed in a shell prompt or
+// a status bar. Nothing is printed when no session is flowing.
+type StatusOnelinePresenter struct {
+	Logger *log.Logger
+}
+
+func (s StatusOnelinePresenter) ShowStatus(session *session.Session, duration time.Duration, _ []time.Duration) {
+	if session == nil {
+		return
+	}
+
+	s.Logger.Printf("%v %v", session.Project, hoursMinutes(duration))
+}
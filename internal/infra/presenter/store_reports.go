@@ -0,0 +1,86 @@
+package presenter
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"strings"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/utils"
+)
+
+// NewSessionsReportPresenter returns the presenter of the reports in the
+// output format
+func NewSessionsReportPresenter(output string, logger *log.Logger) application.SessionsReportPresenter {
+	switch output {
+	case OutputJSON:
+		return SessionsReportJSONPresenter{Logger: logger}
+	case OutputPlain:
+		return SessionsReportPlainPresenter{Logger: logger}
+	case OutputMarkdown:
+		return SessionsReportMarkdownPresenter{Logger: logger}
+	}
+
+	return SessionsReportCLIPresenter{Logger: logger}
+}
+
+// StoreReports keeps the report of each store apart while the stores are
+// read, then prints them one after the other with the name of their store
+type StoreReports struct {
+	Output  string
+	stores  []string
+	buffers []*bytes.Buffer
+}
+
+// NewPresenter returns the presenter of the report of the store
+func (r *StoreReports) NewPresenter(store string) application.SessionsReportPresenter {
+	buffer := &bytes.Buffer{}
+	r.stores = append(r.stores, store)
+	r.buffers = append(r.buffers, buffer)
+
+	return NewSessionsReportPresenter(r.Output, log.New(buffer, "", 0))
+}
+
+type storeReportJSON struct {
+	Store  string          `json:"store"`
+	Report json.RawMessage `json:"report"`
+}
+
+// Print prints the reports: under a heading with the text and markdown
+// outputs, after the store on each line with the plain output, and in a list
+// of objects having the store and its report with the json output
+func (r *StoreReports) Print(logger *log.Logger) {
+	switch r.Output {
+	case OutputJSON:
+		reports := []storeReportJSON{}
+		for i, store := range r.stores {
+			report := bytes.TrimSpace(r.buffers[i].Bytes())
+			if len(report) == 0 {
+				report = []byte("null")
+			}
+			reports = append(reports, storeReportJSON{Store: store, Report: report})
+		}
+		printJSON(logger, reports)
+	case OutputPlain:
+		for i, store := range r.stores {
+			report := strings.TrimRight(r.buffers[i].String(), "\n")
+			if report == "" {
+				continue
+			}
+			for _, line := range strings.Split(report, "\n") {
+				logger.Println(store + "\t" + line)
+			}
+		}
+	default:
+		texts := []string{}
+		for i, store := range r.stores {
+			heading := "Store: " + utils.ProjectColor(store)
+			if r.Output == OutputMarkdown {
+				heading = "# Store: " + store
+			}
+			texts = append(texts, heading+"\n\n"+strings.TrimRight(r.buffers[i].String(), "\n"))
+		}
+		logger.Println(strings.Join(texts, "\n\n"))
+	}
+}
@@ -0,0 +1,68 @@
+package process
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+)
+
+var attachSignals = []os.Signal{os.Interrupt, syscall.SIGTERM, syscall.SIGHUP}
+
+// RunAttached runs the command with the flow process attached to it: the
+// interruption signals received by flow are forwarded to the command instead
+// of killing flow, so that onExit is always called with the exit code once the
+// command is over, however it ended. It returns the exit code of the command.
+func RunAttached(command *exec.Cmd, onExit func(exitCode int)) (int, error) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, attachSignals...)
+	defer signal.Stop(signals)
+
+	if err := command.Start(); err != nil {
+		return -1, err
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		for {
+			select {
+			case sig := <-signals:
+				// best effort, the command may already be gone
+				command.Process.Signal(sig)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	err := command.Wait()
+
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		onExit(-1)
+		return -1, err
+	}
+
+	exitCode := command.ProcessState.ExitCode()
+	onExit(exitCode)
+
+	return exitCode, nil
+}
+
+// Shell returns the command starting an interactive shell of the user
+func Shell() *exec.Cmd {
+	shell := os.Getenv("SHELL")
+	if shell == "" {
+		shell = defaultShell
+	}
+
+	command := exec.Command(shell)
+	command.Stdin = os.Stdin
+	command.Stdout = os.Stdout
+	command.Stderr = os.Stderr
+
+	return command
+}
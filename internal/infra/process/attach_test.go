@@ -0,0 +1,56 @@
+//go:build !windows
+
+package process_test
+
+import (
+	"os/exec"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/infra/process"
+	"github.com/matryer/is"
+)
+
+func TestRunAttached_ExitCode(t *testing.T) {
+	is := is.New(t)
+
+	exits := 0
+	exitCode, err := process.RunAttached(exec.Command("sh", "-c", "exit 3"), func(_ int) {
+		exits++
+	})
+
+	is.NoErr(err)
+	is.Equal(exitCode, 3)
+	is.Equal(exits, 1)
+}
+
+func TestRunAttached_Interrupted(t *testing.T) {
+	is := is.New(t)
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		syscall.Kill(syscall.Getpid(), syscall.SIGTERM)
+	}()
+
+	exits := 0
+	exitCode, err := process.RunAttached(exec.Command("sleep", "5"), func(_ int) {
+		exits++
+	})
+
+	is.NoErr(err)
+	is.Equal(exitCode, -1) // killed by the forwarded signal
+	is.Equal(exits, 1)
+}
+
+func TestRunAttached_UnknownCommand(t *testing.T) {
+	is := is.New(t)
+
+	exits := 0
+	_, err := process.RunAttached(exec.Command("flow-unknown-command"), func(_ int) {
+		exits++
+	})
+
+	is.True(err != nil)
+	is.Equal(exits, 0)
+}
@@ -0,0 +1,45 @@
+package infra
+
+import (
+	"slices"
+
+	"github.com/TristanShz/flow/internal/domain/project"
+)
+
+type InMemoryProjectRepository struct {
+	Projects []project.Project
+}
+
+func (r *InMemoryProjectRepository) Save(p project.Project) error {
+	projectIndex := slices.IndexFunc(r.Projects, func(existing project.Project) bool {
+		return existing.Name == p.Name
+	})
+
+	if projectIndex == -1 {
+		r.Projects = append(r.Projects, p)
+	} else {
+		r.Projects[projectIndex] = p
+	}
+
+	return nil
+}
+
+func (r *InMemoryProjectRepository) FindByName(name string) *project.Project {
+	for _, p := range r.Projects {
+		if p.Name == name {
+			return &p
+		}
+	}
+	return nil
+}
+
+func (r *InMemoryProjectRepository) FindAll() []project.Project {
+	return r.Projects
+}
+
+func (r *InMemoryProjectRepository) Delete(name string) error {
+	r.Projects = slices.DeleteFunc(r.Projects, func(p project.Project) bool {
+		return p.Name == name
+	})
+	return nil
+}
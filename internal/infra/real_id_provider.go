@@ -5,5 +5,5 @@ import "github.com/TristanShz/flow/utils"
 type RealIDProvider struct{}
 
 func (s RealIDProvider) Provide() string {
-	return utils.GenerateID(7)
+	return utils.GenerateID(utils.IDLength)
 }
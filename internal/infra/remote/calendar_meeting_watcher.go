@@ -0,0 +1,214 @@
+package remote
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+)
+
+// DefaultCalendarPollInterval is the time between two reads of the calendar
+const DefaultCalendarPollInterval = time.Minute
+
+// maxCalendarSize bounds the calendar read from an URL
+const maxCalendarSize = 10 << 20
+
+type meeting struct {
+	start time.Time
+	end   time.Time
+	title string
+}
+
+func (m meeting) contains(t time.Time) bool {
+	return !t.Before(m.start) && t.Before(m.end)
+}
+
+// CalendarMeetingWatcher reads the events of an iCalendar file or http(s)
+// URL at every poll, and sends a meeting event when the first of
+// overlapping events starts and when the last one ends. All-day events
+// aren't meetings.
+type CalendarMeetingWatcher struct {
+	HTTPClient   *http.Client
+	DateProvider application.DateProvider
+	Source       string
+	PollInterval time.Duration
+}
+
+func NewCalendarMeetingWatcher(source string, dateProvider application.DateProvider) CalendarMeetingWatcher {
+	return CalendarMeetingWatcher{
+		HTTPClient:   &http.Client{Timeout: 30 * time.Second},
+		DateProvider: dateProvider,
+		Source:       source,
+		PollInterval: DefaultCalendarPollInterval,
+	}
+}
+
+// Watch returns the error of the first read of the calendar, the calendar
+// of the previous poll is kept when a later read fails
+func (w CalendarMeetingWatcher) Watch(ctx context.Context, events chan<- application.MeetingEvent) error {
+	meetings, err := w.read()
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(w.PollInterval)
+	defer ticker.Stop()
+
+	var current *meeting
+	lastPoll := w.DateProvider.GetNow()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		if read, err := w.read(); err == nil {
+			meetings = read
+		}
+
+		now := w.DateProvider.GetNow()
+		next := currentMeeting(meetings, now)
+
+		var event *application.MeetingEvent
+		switch {
+		case current == nil && next != nil:
+			// the meeting started between the two polls
+			at := next.start
+			if at.Before(lastPoll) {
+				at = now
+			}
+			event = &application.MeetingEvent{At: at, Title: next.title, Started: true}
+		case current != nil && next == nil:
+			at := current.end
+			if at.After(now) {
+				at = now
+			}
+			event = &application.MeetingEvent{At: at, Title: current.title}
+		}
+
+		current, lastPoll = next, now
+
+		if event == nil {
+			continue
+		}
+
+		select {
+		case events <- *event:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// currentMeeting returns the meeting containing the time ending the latest
+func currentMeeting(meetings []meeting, t time.Time) *meeting {
+	var current *meeting
+	for i, m := range meetings {
+		if m.contains(t) && (current == nil || m.end.After(current.end)) {
+			current = &meetings[i]
+		}
+	}
+
+	return current
+}
+
+func (w CalendarMeetingWatcher) read() ([]meeting, error) {
+	if !strings.HasPrefix(w.Source, "http://") && !strings.HasPrefix(w.Source, "https://") {
+		data, err := os.ReadFile(w.Source)
+		if err != nil {
+			return nil, err
+		}
+
+		return parseMeetings(string(data)), nil
+	}
+
+	response, err := w.HTTPClient.Get(w.Source)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("can't read the calendar %v: %v", w.Source, response.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(response.Body, maxCalendarSize))
+	if err != nil {
+		return nil, err
+	}
+
+	return parseMeetings(string(data)), nil
+}
+
+// parseMeetings reads the VEVENT components having a start and an end time,
+// the unreadable ones are skipped
+func parseMeetings(data string) []meeting {
+	// long lines are folded with a line break followed by a space or a tab
+	data = strings.ReplaceAll(data, "\r\n", "\n")
+	data = strings.ReplaceAll(data, "\n ", "")
+	data = strings.ReplaceAll(data, "\n\t", "")
+
+	meetings := []meeting{}
+	var event *meeting
+	for _, line := range strings.Split(data, "\n") {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		name, params, _ := strings.Cut(name, ";")
+
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			event = &meeting{}
+		case event == nil:
+			continue
+		case name == "END" && value == "VEVENT":
+			if !event.start.IsZero() && event.end.After(event.start) {
+				meetings = append(meetings, *event)
+			}
+			event = nil
+		case name == "DTSTART":
+			event.start = parseICSTime(params, value)
+		case name == "DTEND":
+			event.end = parseICSTime(params, value)
+		case name == "SUMMARY":
+			event.title = unescapeICSText(value)
+		}
+	}
+
+	return meetings
+}
+
+// parseICSTime reads a UTC time, a time of the TZID parameter or a local
+// time, dates of all-day events give the zero time
+func parseICSTime(params string, value string) time.Time {
+	location := time.Local
+	for _, param := range strings.Split(params, ";") {
+		if tzid, ok := strings.CutPrefix(param, "TZID="); ok {
+			if loaded, err := time.LoadLocation(strings.Trim(tzid, `"`)); err == nil {
+				location = loaded
+			}
+		}
+	}
+
+	if t, err := time.Parse("20060102T150405Z", value); err == nil {
+		return t
+	}
+
+	if t, err := time.ParseInLocation("20060102T150405", value, location); err == nil {
+		return t
+	}
+
+	return time.Time{}
+}
+
+func unescapeICSText(value string) string {
+	return strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(value)
+}
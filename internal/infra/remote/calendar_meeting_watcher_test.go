@@ -0,0 +1,150 @@
+package remote_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/infra/remote"
+	"github.com/matryer/is"
+)
+
+const calendarICS = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART:20240413T100000Z\r\n" +
+	"DTEND:20240413T103000Z\r\n" +
+	"SUMMARY:Daily standup\\, team\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;TZID=Europe/Paris:20240413T140000\r\n" +
+	"DTEND;TZID=Europe/Paris:20240413T144000\r\n" +
+	"SUMMARY:Back to\r\n" +
+	"  back\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART:20240413T105000Z\r\n" +
+	"DTEND:20240413T112000Z\r\n" +
+	"SUMMARY:Review\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;VALUE=DATE:20240413\r\n" +
+	"DTEND;VALUE=DATE:20240414\r\n" +
+	"SUMMARY:Holidays\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+// sequenceDateProvider returns its times one after the other, then the last
+// one
+type sequenceDateProvider struct {
+	mu    sync.Mutex
+	times []time.Time
+}
+
+func (p *sequenceDateProvider) GetNow() time.Time {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.times[0]
+	if len(p.times) > 1 {
+		p.times = p.times[1:]
+	}
+
+	return now
+}
+
+func at(hour int, min int) time.Time {
+	return time.Date(2024, time.April, 13, hour, min, 0, 0, time.UTC)
+}
+
+func watchMeetings(t *testing.T, source string, times []time.Time, count int) []application.MeetingEvent {
+	t.Helper()
+	is := is.New(t)
+
+	watcher := remote.NewCalendarMeetingWatcher(source, &sequenceDateProvider{times: times})
+	watcher.PollInterval = time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := make(chan application.MeetingEvent)
+	watchErr := make(chan error, 1)
+	go func() {
+		watchErr <- watcher.Watch(ctx, events)
+	}()
+
+	got := []application.MeetingEvent{}
+	for len(got) < count {
+		select {
+		case event := <-events:
+			// the times of the events are in the location of the calendar
+			event.At = event.At.UTC()
+			got = append(got, event)
+		case err := <-watchErr:
+			is.NoErr(err)
+			return got
+		case <-time.After(5 * time.Second):
+			t.Fatalf("expected %v meeting events, got %v", count, got)
+		}
+	}
+	cancel()
+	is.NoErr(<-watchErr)
+
+	return got
+}
+
+func TestCalendarMeetingWatcher(t *testing.T) {
+	is := is.New(t)
+
+	source := filepath.Join(t.TempDir(), "calendar.ics")
+	is.NoErr(os.WriteFile(source, []byte(calendarICS), 0644))
+
+	got := watchMeetings(t, source, []time.Time{
+		at(9, 58),
+		at(10, 1),
+		// no poll saw the break between the standup and the review, they're
+		// one meeting
+		at(10, 29),
+		at(10, 55),
+		at(11, 25),
+		at(12, 10),
+		at(12, 50),
+	}, 4)
+
+	is.Equal(got, []application.MeetingEvent{
+		{At: at(10, 0), Title: "Daily standup, team", Started: true},
+		{At: at(11, 20), Title: "Review"},
+		{At: at(12, 0), Title: "Back to back", Started: true},
+		{At: at(12, 40), Title: "Back to back"},
+	})
+}
+
+func TestCalendarMeetingWatcher_URL(t *testing.T) {
+	is := is.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(strings.ReplaceAll(calendarICS, "\r\n", "\n")))
+	}))
+	defer server.Close()
+
+	got := watchMeetings(t, server.URL, []time.Time{at(9, 58), at(10, 1)}, 1)
+
+	is.Equal(got, []application.MeetingEvent{{At: at(10, 0), Title: "Daily standup, team", Started: true}})
+}
+
+func TestCalendarMeetingWatcher_MissingCalendar(t *testing.T) {
+	is := is.New(t)
+
+	watcher := remote.NewCalendarMeetingWatcher(filepath.Join(t.TempDir(), "missing.ics"), &sequenceDateProvider{times: []time.Time{at(9, 0)}})
+
+	err := watcher.Watch(context.Background(), make(chan application.MeetingEvent))
+
+	is.True(os.IsNotExist(err))
+}
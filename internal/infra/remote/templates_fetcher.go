@@ -0,0 +1,107 @@
+package remote
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/project"
+)
+
+// TemplatesFilename is the file read at the root of a git repository or of
+// a directory
+const TemplatesFilename = "flow-templates.json"
+
+// maxTemplatesSize bounds the templates read from an URL
+const maxTemplatesSize = 1 << 20
+
+// TemplatesFetcher reads the templates from a git repository, cloned with the
+// git command, an http(s) URL or a local file or directory
+type TemplatesFetcher struct {
+	HTTPClient *http.Client
+}
+
+func NewTemplatesFetcher() TemplatesFetcher {
+	return TemplatesFetcher{
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (f TemplatesFetcher) Fetch(source string) (project.Templates, error) {
+	data, err := f.read(source)
+	if err != nil {
+		return project.Templates{}, err
+	}
+
+	templates := project.Templates{}
+	if err := json.Unmarshal(data, &templates); err != nil {
+		return project.Templates{}, fmt.Errorf("invalid templates in %v: %w", source, err)
+	}
+
+	return templates, nil
+}
+
+// isGitSource tells if the source is a git repository, like
+// git@github.com:team/flow-templates.git or
+// https://github.com/team/flow-templates.git
+func isGitSource(source string) bool {
+	return strings.HasSuffix(source, ".git") ||
+		strings.HasPrefix(source, "git@") ||
+		strings.HasPrefix(source, "git://") ||
+		strings.HasPrefix(source, "ssh://")
+}
+
+func (f TemplatesFetcher) read(source string) ([]byte, error) {
+	if isGitSource(source) {
+		return f.readGit(source)
+	}
+
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		return f.readURL(source)
+	}
+
+	fileInfo, err := os.Stat(source)
+	if err != nil {
+		return nil, err
+	}
+	if fileInfo.IsDir() {
+		source = filepath.Join(source, TemplatesFilename)
+	}
+
+	return os.ReadFile(source)
+}
+
+func (f TemplatesFetcher) readGit(source string) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "flow-templates-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	output, err := exec.Command("git", "clone", "--depth", "1", "--quiet", source, dir).CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("can't clone %v: %w: %v", source, err, strings.TrimSpace(string(output)))
+	}
+
+	return os.ReadFile(filepath.Join(dir, TemplatesFilename))
+}
+
+func (f TemplatesFetcher) readURL(source string) ([]byte, error) {
+	response, err := f.HTTPClient.Get(source)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("can't fetch %v: %v", source, response.Status)
+	}
+
+	return io.ReadAll(io.LimitReader(response.Body, maxTemplatesSize))
+}
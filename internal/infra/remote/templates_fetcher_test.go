@@ -0,0 +1,96 @@
+package remote_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/TristanShz/flow/internal/domain/project"
+	"github.com/TristanShz/flow/internal/infra/remote"
+	"github.com/matryer/is"
+)
+
+const templatesJSON = `{"NamePattern": "^[a-z-]+$", "Tags": ["dev"], "Projects": [{"Name": "acme-website", "Client": "Acme"}]}`
+
+var wantTemplates = project.Templates{
+	NamePattern: "^[a-z-]+$",
+	Tags:        []string{"dev"},
+	Projects:    []project.Project{{Name: "acme-website", Client: "Acme"}},
+}
+
+func TestTemplatesFetcher_URL(t *testing.T) {
+	is := is.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/templates.json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(templatesJSON))
+	}))
+	defer server.Close()
+
+	fetcher := remote.NewTemplatesFetcher()
+
+	templates, err := fetcher.Fetch(server.URL + "/templates.json")
+	is.NoErr(err)
+	is.Equal(templates, wantTemplates)
+
+	_, err = fetcher.Fetch(server.URL + "/missing.json")
+	is.True(err != nil)
+}
+
+func TestTemplatesFetcher_Directory(t *testing.T) {
+	is := is.New(t)
+	dir := t.TempDir()
+	is.NoErr(os.WriteFile(filepath.Join(dir, remote.TemplatesFilename), []byte(templatesJSON), 0666))
+
+	templates, err := remote.NewTemplatesFetcher().Fetch(dir)
+
+	is.NoErr(err)
+	is.Equal(templates, wantTemplates)
+}
+
+func TestTemplatesFetcher_InvalidTemplates(t *testing.T) {
+	is := is.New(t)
+	path := filepath.Join(t.TempDir(), "templates.json")
+	is.NoErr(os.WriteFile(path, []byte("{"), 0666))
+
+	_, err := remote.NewTemplatesFetcher().Fetch(path)
+
+	is.True(err != nil)
+}
+
+func TestTemplatesFetcher_Git(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	is := is.New(t)
+	workDir := t.TempDir()
+	repository := filepath.Join(t.TempDir(), "templates.git")
+
+	git := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=flow", "GIT_AUTHOR_EMAIL=flow@example.com", "GIT_COMMITTER_NAME=flow", "GIT_COMMITTER_EMAIL=flow@example.com")
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, output)
+		}
+	}
+
+	git(workDir, "init", "--quiet")
+	is.NoErr(os.WriteFile(filepath.Join(workDir, remote.TemplatesFilename), []byte(templatesJSON), 0666))
+	git(workDir, "add", remote.TemplatesFilename)
+	git(workDir, "commit", "--quiet", "-m", "Add the templates")
+	git(workDir, "clone", "--quiet", "--bare", workDir, repository)
+
+	templates, err := remote.NewTemplatesFetcher().Fetch(repository)
+
+	is.NoErr(err)
+	is.Equal(templates, wantTemplates)
+}
@@ -0,0 +1,569 @@
+package server
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"log"
+	"mime"
+	"net/http"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/deletesession"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/editsession"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/logsession"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/sessionstatus"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/showsession"
+	startsession "github.com/TristanShz/flow/internal/application/usecases/flowsession/start"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/stopsession"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/viewsessionsreport"
+	"github.com/TristanShz/flow/internal/application/usecases/project/listtags"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/domain/sessionsreport"
+	"github.com/TristanShz/flow/internal/infra/presenter"
+	"github.com/TristanShz/flow/pkg/timerange"
+)
+
+// maxRequestSize bounds the JSON bodies of the API
+const maxRequestSize = 1 << 20
+
+var (
+	errJSONRequired    = errors.New("the body must be JSON, with the application/json content type")
+	errProjectRequired = errors.New("project is required")
+	errUnauthorized    = errors.New("missing or invalid token")
+)
+
+var notFoundErrors = []error{
+	showsession.ErrSessionNotFound,
+	editsession.ErrSessionNotFound,
+	deletesession.ErrSessionNotFound,
+	stopsession.ErrNoCurrentSession,
+}
+
+var conflictErrors = []error{
+	startsession.ErrSessionAlreadyStarted,
+	startsession.ErrDoNotTrackBlocked,
+	startsession.ErrDoNotTrackNotConfirmed,
+	editsession.ErrSessionFlowing,
+	editsession.ErrOverlap,
+	logsession.ErrOverlap,
+	session.ErrLockedHistory,
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type startRequest struct {
+	Project string   `json:"project"`
+	Tags    []string `json:"tags"`
+	// Confirmed starts the session during a do-not-track window asking for
+	// a confirmation
+	Confirmed bool `json:"confirmed"`
+}
+
+type stopRequest struct {
+	Note string   `json:"note"`
+	Tags []string `json:"tags"`
+}
+
+type stopResponse struct {
+	Warning         string `json:"warning,omitempty"`
+	DurationSeconds int64  `json:"duration_seconds"`
+}
+
+// sessionRequest holds the fields of a session to log or to edit, the
+// fields left out of an edit keep their value
+type sessionRequest struct {
+	StartTime *time.Time `json:"start_time"`
+	EndTime   *time.Time `json:"end_time"`
+	Project   *string    `json:"project"`
+	Tags      *[]string  `json:"tags"`
+	Note      *string    `json:"note"`
+}
+
+type sessionsResponse struct {
+	Sessions []presenter.SessionJSON `json:"sessions"`
+}
+
+type tagsResponse struct {
+	Tags []string `json:"tags"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(v)
+}
+
+// writeError answers with the status matching the error of a use case, the
+// errors which aren't a missing session or a conflict are invalid requests
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusBadRequest
+	switch {
+	case errors.Is(err, errForbidden):
+		status = http.StatusForbidden
+	case slices.ContainsFunc(notFoundErrors, func(target error) bool { return errors.Is(err, target) }):
+		status = http.StatusNotFound
+	case slices.ContainsFunc(conflictErrors, func(target error) bool { return errors.Is(err, target) }):
+		status = http.StatusConflict
+	}
+
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+// decodeJSON reads the JSON body of the request. Requiring the JSON content
+// type keeps web pages from sending requests to the API without the consent
+// of the browser, as it isn't allowed by CORS.
+func decodeJSON(w http.ResponseWriter, r *http.Request, v any) error {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "application/json" {
+		return errJSONRequired
+	}
+
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize))
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// jsonLogger writes the output of the JSON presenters to the response, with
+// the given status
+func jsonLogger(w http.ResponseWriter, status int) *log.Logger {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	return log.New(w, "", 0)
+}
+
+// authorize requires the token of the server or the token of a member as a
+// bearer token, when the server has a token or members
+func (s *Server) authorize(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.Token == "" && len(s.Members) == 0 {
+			next(w, r)
+			return
+		}
+
+		authorization := []byte(r.Header.Get("Authorization"))
+		if s.Token != "" && subtle.ConstantTimeCompare(authorization, []byte("Bearer "+s.Token)) == 1 {
+			next(w, r)
+			return
+		}
+
+		for i := range s.Members {
+			if subtle.ConstantTimeCompare(authorization, []byte("Bearer "+s.Members[i].Token)) == 1 {
+				next(w, withMember(r, &s.Members[i]))
+				return
+			}
+		}
+
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: errUnauthorized.Error()})
+	}
+}
+
+// sessionsFilters reads the project, tag and range query parameters
+func (s *Server) sessionsFilters(r *http.Request) (*application.SessionsFilters, error) {
+	query := r.URL.Query()
+	filters := &application.SessionsFilters{
+		Project: query.Get("project"),
+		Tags:    query["tag"],
+	}
+
+	if rangeParam := query.Get("range"); rangeParam != "" {
+		timeRange, err := timerange.Parse(rangeParam, s.app.DateProvider.GetNow())
+		if err != nil {
+			return nil, err
+		}
+		filters.Timerange = timeRange
+	}
+
+	if whereParam := query.Get("where"); whereParam != "" {
+		where, err := application.ParseWhere(whereParam)
+		if err != nil {
+			return nil, err
+		}
+		filters.Where = where
+	}
+
+	if member := memberOf(r); member != nil {
+		filters.Where = visibleCondition{member: member, where: filters.Where}
+	}
+
+	return filters, nil
+}
+
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	s.writeStatus(w, r, http.StatusOK)
+}
+
+// writeStatus shows the current session, as no session to the members not
+// viewing its project
+func (s *Server) writeStatus(w http.ResponseWriter, r *http.Request, code int) {
+	status, err := s.app.FlowSessionStatusUseCase.Execute()
+	if err != nil && err != sessionstatus.ErrNoCurrentSession {
+		writeError(w, err)
+		return
+	}
+
+	var current *session.Session
+	if err == nil && has(r, application.RoleViewer, status.Session.Project) {
+		current = &status.Session
+	}
+
+	presenter.StatusJSONPresenter{Logger: jsonLogger(w, code)}.ShowStatus(current, status.Duration, nil)
+}
+
+func (s *Server) handleStart(w http.ResponseWriter, r *http.Request) {
+	request := startRequest{}
+	if err := decodeJSON(w, r, &request); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if request.Project == "" {
+		writeError(w, errProjectRequired)
+		return
+	}
+
+	if err := requireRole(r, application.RoleTracker, request.Project); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	tags := request.Tags
+	if len(tags) == 0 {
+		tags = s.app.Config.DefaultTags
+	}
+
+	err := s.app.StartFlowSessionUseCase.Execute(startsession.Command{
+		Project:   request.Project,
+		Tags:      tags,
+		Confirmed: request.Confirmed,
+		TagRules:  s.app.Config.TagRules,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	s.writeStatus(w, r, http.StatusCreated)
+}
+
+func (s *Server) handleStop(w http.ResponseWriter, r *http.Request) {
+	request := stopRequest{}
+	if err := decodeJSON(w, r, &request); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	status, err := s.app.FlowSessionStatusUseCase.Execute()
+	if err == sessionstatus.ErrNoCurrentSession {
+		writeError(w, stopsession.ErrNoCurrentSession)
+		return
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if !has(r, application.RoleViewer, status.Session.Project) {
+		writeError(w, stopsession.ErrNoCurrentSession)
+		return
+	}
+	if err := requireRole(r, application.RoleTracker, status.Session.Project); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	duration, err := s.app.StopFlowSessionUseCase.Execute(stopsession.Command{
+		Note:     request.Note,
+		Tags:     request.Tags,
+		TagRules: s.app.Config.TagRules,
+	})
+
+	response := stopResponse{DurationSeconds: int64(duration.Seconds())}
+	if err == stopsession.ErrClockWentBackwards {
+		response.Warning = err.Error()
+	} else if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) handleListSessions(w http.ResponseWriter, r *http.Request) {
+	filters, err := s.sessionsFilters(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	sessions := append([]session.Session{}, s.app.SessionRepository.FindAllSessions(filters)...)
+	sort.SliceStable(sessions, func(i, j int) bool {
+		return sessions[i].StartTime.Before(sessions[j].StartTime)
+	})
+
+	response := sessionsResponse{Sessions: []presenter.SessionJSON{}}
+	for _, sess := range sessions {
+		response.Sessions = append(response.Sessions, presenter.NewSessionJSON(sess))
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) handleLogSession(w http.ResponseWriter, r *http.Request) {
+	request := sessionRequest{}
+	if err := decodeJSON(w, r, &request); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if request.StartTime == nil || request.EndTime == nil {
+		writeError(w, errors.New("start_time and end_time are required"))
+		return
+	}
+
+	command := logsession.Command{
+		StartTime:    *request.StartTime,
+		EndTime:      *request.EndTime,
+		LockedBefore: s.lockedBefore(),
+	}
+	if request.Project != nil {
+		command.Project = *request.Project
+	}
+	if request.Tags != nil {
+		command.Tags = *request.Tags
+	}
+	if request.Note != nil {
+		command.Note = *request.Note
+	}
+
+	if err := requireRole(r, application.RoleTracker, command.Project); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	logged, err := s.app.LogSessionUseCase.Execute(command)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, presenter.NewSessionJSON(logged))
+}
+
+func (s *Server) handleGetSession(w http.ResponseWriter, r *http.Request) {
+	details, err := s.app.ShowSessionUseCase.Execute(showsession.Command{SessionId: r.PathValue("id")})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if err := requireSessionRole(r, application.RoleViewer, details.Session); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, presenter.NewSessionJSON(details.Session))
+}
+
+func (s *Server) handleEditSession(w http.ResponseWriter, r *http.Request) {
+	request := sessionRequest{}
+	if err := decodeJSON(w, r, &request); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if err := s.requireSessionRole(r, application.RoleTracker, r.PathValue("id")); err != nil {
+		writeError(w, err)
+		return
+	}
+	if request.Project != nil {
+		if err := requireRole(r, application.RoleTracker, *request.Project); err != nil {
+			writeError(w, err)
+			return
+		}
+	}
+
+	edited, err := s.app.EditSessionUseCase.Execute(editsession.Command{
+		Id:           r.PathValue("id"),
+		StartTime:    request.StartTime,
+		EndTime:      request.EndTime,
+		Project:      request.Project,
+		Tags:         request.Tags,
+		Note:         request.Note,
+		Overlap:      session.OverlapReject,
+		LockedBefore: s.lockedBefore(),
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, presenter.NewSessionJSON(edited))
+}
+
+func (s *Server) handleDeleteSession(w http.ResponseWriter, r *http.Request) {
+	if err := s.requireSessionRole(r, application.RoleTracker, r.PathValue("id")); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if _, err := s.app.DeleteSessionUseCase.Execute(deletesession.Command{Id: r.PathValue("id"), LockedBefore: s.lockedBefore()}); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleApproveSession(w http.ResponseWriter, r *http.Request) {
+	s.setApproval(w, r, nameOf(r))
+}
+
+func (s *Server) handleWithdrawApproval(w http.ResponseWriter, r *http.Request) {
+	s.setApproval(w, r, "")
+}
+
+// setApproval approves the session in the name of the caller, or withdraws
+// the approval with an empty name
+func (s *Server) setApproval(w http.ResponseWriter, r *http.Request, approvedBy string) {
+	if err := s.requireSessionRole(r, application.RoleApprover, r.PathValue("id")); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	approved, err := s.app.EditSessionUseCase.Execute(editsession.Command{
+		Id:         r.PathValue("id"),
+		ApprovedBy: &approvedBy,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, presenter.NewSessionJSON(approved))
+}
+
+// lockedBefore keeps the read-only months of the configuration out of reach
+// of the API, the approvals aside as they don't change the tracked time
+func (s *Server) lockedBefore() time.Time {
+	return s.app.Config.LockedBefore(s.app.DateProvider.GetNow())
+}
+
+// requireSessionRole checks the role of the caller in the project of the
+// session with the id, an unknown session is left to the use cases
+func (s *Server) requireSessionRole(r *http.Request, role string, id string) error {
+	existing, err := s.app.SessionRepository.FindById(id)
+	if err != nil || existing == nil {
+		return err
+	}
+
+	return requireSessionRole(r, role, *existing)
+}
+
+func (s *Server) handleProjects(w http.ResponseWriter, r *http.Request) {
+	projects, err := s.visibleProjects(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	presenter.ProjectsJSONPresenter{Logger: jsonLogger(w, http.StatusOK)}.ShowProjects(projects)
+}
+
+// visibleProjects lists the projects the caller views
+func (s *Server) visibleProjects(r *http.Request) ([]string, error) {
+	projects, err := s.app.ListProjectsUseCase.Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(projects, func(project string) bool {
+		return !has(r, application.RoleViewer, project)
+	}), nil
+}
+
+func (s *Server) handleTags(w http.ResponseWriter, r *http.Request) {
+	project := r.URL.Query().Get("project")
+
+	// the tags of every project are the tags of the projects the member
+	// views
+	projects := []string{project}
+	if member := memberOf(r); member != nil && project == "" && !member.Has(application.RoleViewer, application.AllProjects) {
+		var err error
+		if projects, err = s.visibleProjects(r); err != nil {
+			writeError(w, err)
+			return
+		}
+	} else if project != "" {
+		if err := requireRole(r, application.RoleViewer, project); err != nil {
+			writeError(w, err)
+			return
+		}
+	}
+
+	tags := []string{}
+	for _, project := range projects {
+		projectTags, err := s.app.ListProjectTagsUseCase.Execute(listtags.Command{Project: project})
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		tags = append(tags, projectTags...)
+	}
+	slices.Sort(tags)
+
+	writeJSON(w, http.StatusOK, tagsResponse{Tags: slices.Compact(tags)})
+}
+
+func (s *Server) handleReport(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	format := query.Get("format")
+	if format == "" {
+		format = sessionsreport.FormatByDay
+	}
+	if !slices.Contains([]string{sessionsreport.FormatByDay, sessionsreport.FormatByProject, sessionsreport.FormatByClient, sessionsreport.FormatEarnings}, format) {
+		writeError(w, errors.New("invalid format. possible values: by-day, by-project, by-client, earnings"))
+		return
+	}
+
+	filters, err := s.sessionsFilters(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	command := viewsessionsreport.Command{
+		Project:  filters.Project,
+		Client:   query.Get("client"),
+		Tags:     filters.Tags,
+		Since:    filters.Timerange.Since,
+		Until:    filters.Timerange.Until,
+		Format:   format,
+		Where:    filters.Where,
+		Rounding: s.app.Config.Rounding,
+	}
+
+	// the report is only written once it's computed, an error is answered
+	// before anything was written
+	report := &bytes.Buffer{}
+	if err := s.app.ViewSessionsReportUseCase.Execute(command, presenter.SessionsReportJSONPresenter{Logger: log.New(report, "", 0)}); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	report.WriteTo(w)
+}
@@ -0,0 +1,485 @@
+package server_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/TristanShz/flow/internal/infra/server"
+	"github.com/TristanShz/flow/test"
+	"github.com/matryer/is"
+)
+
+func TestAPI(t *testing.T) {
+	finishedSession := session.Session{
+		Id:        "1",
+		StartTime: time.Date(2024, time.April, 13, 9, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, time.April, 13, 10, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+		Tags:      []string{"review"},
+	}
+	currentSession := session.Session{
+		Id:        "2",
+		StartTime: time.Date(2024, time.April, 13, 16, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+	}
+
+	tt := []struct {
+		name          string
+		givenSessions []session.Session
+		token         string
+		method        string
+		path          string
+		body          string
+		contentType   string
+		authorization string
+		wantStatus    int
+		wantBody      string
+		wantSessions  int
+	}{
+		{
+			name:          "Status of the current session",
+			givenSessions: []session.Session{currentSession},
+			method:        http.MethodGet,
+			path:          "/api/status",
+			wantStatus:    http.StatusOK,
+			wantBody:      `"project":"Flow"`,
+			wantSessions:  1,
+		},
+		{
+			name:         "Start a session",
+			method:       http.MethodPost,
+			path:         "/api/start",
+			body:         `{"project":"Flow","tags":["deep"]}`,
+			contentType:  "application/json",
+			wantStatus:   http.StatusCreated,
+			wantBody:     `"tags":["deep"]`,
+			wantSessions: 1,
+		},
+		{
+			name:          "Start a session while another one is flowing",
+			givenSessions: []session.Session{currentSession},
+			method:        http.MethodPost,
+			path:          "/api/start",
+			body:          `{"project":"Flow"}`,
+			contentType:   "application/json",
+			wantStatus:    http.StatusConflict,
+			wantBody:      `"error":`,
+			wantSessions:  1,
+		},
+		{
+			name:        "Start a session without a project",
+			method:      http.MethodPost,
+			path:        "/api/start",
+			body:        `{}`,
+			contentType: "application/json",
+			wantStatus:  http.StatusBadRequest,
+			wantBody:    `{"error":"project is required"}`,
+		},
+		{
+			name:          "Body without the JSON content type",
+			givenSessions: []session.Session{currentSession},
+			method:        http.MethodPost,
+			path:          "/api/stop",
+			body:          `{}`,
+			contentType:   "text/plain",
+			wantStatus:    http.StatusBadRequest,
+			wantBody:      `{"error":"the body must be JSON, with the application/json content type"}`,
+			wantSessions:  1,
+		},
+		{
+			name:          "Stop the current session",
+			givenSessions: []session.Session{currentSession},
+			method:        http.MethodPost,
+			path:          "/api/stop",
+			body:          `{"note":"done"}`,
+			contentType:   "application/json",
+			wantStatus:    http.StatusOK,
+			wantBody:      `{"duration_seconds":5400}`,
+			wantSessions:  1,
+		},
+		{
+			name:        "Stop without a current session",
+			method:      http.MethodPost,
+			path:        "/api/stop",
+			body:        `{}`,
+			contentType: "application/json",
+			wantStatus:  http.StatusNotFound,
+		},
+		{
+			name:          "List the sessions of a project",
+			givenSessions: []session.Session{finishedSession, currentSession},
+			method:        http.MethodGet,
+			path:          "/api/sessions?project=Flow&tag=review",
+			wantStatus:    http.StatusOK,
+			wantBody:      `"id":"1"`,
+			wantSessions:  2,
+		},
+		{
+			name:         "Log a session",
+			method:       http.MethodPost,
+			path:         "/api/sessions",
+			body:         `{"project":"Flow","start_time":"2024-04-13T09:00:00Z","end_time":"2024-04-13T10:00:00Z"}`,
+			contentType:  "application/json",
+			wantStatus:   http.StatusCreated,
+			wantBody:     `"project":"Flow"`,
+			wantSessions: 1,
+		},
+		{
+			name:          "Log a session overlapping another one",
+			givenSessions: []session.Session{finishedSession},
+			method:        http.MethodPost,
+			path:          "/api/sessions",
+			body:          `{"project":"Flow","start_time":"2024-04-13T09:30:00Z","end_time":"2024-04-13T11:00:00Z"}`,
+			contentType:   "application/json",
+			wantStatus:    http.StatusConflict,
+			wantSessions:  1,
+		},
+		{
+			name:          "Get a session",
+			givenSessions: []session.Session{finishedSession},
+			method:        http.MethodGet,
+			path:          "/api/sessions/1",
+			wantStatus:    http.StatusOK,
+			wantBody:      `"id":"1"`,
+			wantSessions:  1,
+		},
+		{
+			name:       "Get an unknown session",
+			method:     http.MethodGet,
+			path:       "/api/sessions/nope",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:          "Edit a session",
+			givenSessions: []session.Session{finishedSession},
+			method:        http.MethodPatch,
+			path:          "/api/sessions/1",
+			body:          `{"note":"edited"}`,
+			contentType:   "application/json",
+			wantStatus:    http.StatusOK,
+			wantBody:      `"note":"edited"`,
+			wantSessions:  1,
+		},
+		{
+			name:          "Delete a session",
+			givenSessions: []session.Session{finishedSession},
+			method:        http.MethodDelete,
+			path:          "/api/sessions/1",
+			wantStatus:    http.StatusNoContent,
+		},
+		{
+			name:          "Tags of a project",
+			givenSessions: []session.Session{finishedSession},
+			method:        http.MethodGet,
+			path:          "/api/tags?project=Flow",
+			wantStatus:    http.StatusOK,
+			wantBody:      `{"tags":["review"]}`,
+			wantSessions:  1,
+		},
+		{
+			name:          "Report by project",
+			givenSessions: []session.Session{finishedSession},
+			method:        http.MethodGet,
+			path:          "/api/report?format=by-project",
+			wantStatus:    http.StatusOK,
+			wantBody:      `"Flow"`,
+			wantSessions:  1,
+		},
+		{
+			name:       "Report with an invalid format",
+			method:     http.MethodGet,
+			path:       "/api/report?format=nope",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:          "Missing token",
+			givenSessions: []session.Session{currentSession},
+			token:         "secret",
+			method:        http.MethodGet,
+			path:          "/api/status",
+			wantStatus:    http.StatusUnauthorized,
+			wantBody:      `{"error":"missing or invalid token"}`,
+			wantSessions:  1,
+		},
+		{
+			name:          "Valid token",
+			givenSessions: []session.Session{currentSession},
+			token:         "secret",
+			method:        http.MethodGet,
+			path:          "/api/status",
+			authorization: "Bearer secret",
+			wantStatus:    http.StatusOK,
+			wantBody:      `"project":"Flow"`,
+			wantSessions:  1,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			sessionRepository := &infra.InMemorySessionRepository{Sessions: append([]session.Session{}, tc.givenSessions...)}
+			dateProvider := infra.NewStubDateProvider()
+			dateProvider.Now = time.Date(2024, time.April, 13, 17, 30, 0, 0, time.UTC)
+
+			s := server.NewServer(test.InitializeApp(sessionRepository, dateProvider))
+			s.Token = tc.token
+
+			request := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			if tc.contentType != "" {
+				request.Header.Set("Content-Type", tc.contentType)
+			}
+			if tc.authorization != "" {
+				request.Header.Set("Authorization", tc.authorization)
+			}
+			recorder := httptest.NewRecorder()
+
+			s.Handler().ServeHTTP(recorder, request)
+
+			body := &bytes.Buffer{}
+			if recorder.Body.Len() > 0 {
+				is.NoErr(json.Compact(body, recorder.Body.Bytes()))
+			}
+
+			is.Equal(recorder.Code, tc.wantStatus)
+			is.True(strings.Contains(body.String(), tc.wantBody)) // body holds the expected content
+			is.Equal(len(sessionRepository.Sessions), tc.wantSessions)
+		})
+	}
+}
+
+func TestAPI_Members(t *testing.T) {
+	flowSession := session.Session{
+		Id:        "1",
+		StartTime: time.Date(2024, time.April, 13, 9, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, time.April, 13, 10, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+		Tags:      []string{"review"},
+	}
+	acmeSession := session.Session{
+		Id:        "2",
+		StartTime: time.Date(2024, time.April, 13, 11, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, time.April, 13, 12, 0, 0, 0, time.UTC),
+		Project:   "Acme",
+		Tags:      []string{"meeting"},
+	}
+	acmeCurrentSession := session.Session{
+		Id:        "3",
+		StartTime: time.Date(2024, time.April, 13, 16, 0, 0, 0, time.UTC),
+		Project:   "Acme",
+	}
+
+	members := []application.Member{
+		{Name: "alice", Token: "alice-token", Roles: map[string][]string{application.RoleTracker: {"Flow"}}},
+		{Name: "bob", Token: "bob-token", Roles: map[string][]string{application.RoleViewer: {"Acme"}, application.RoleApprover: {"Flow"}}},
+	}
+
+	tt := []struct {
+		name          string
+		givenSessions []session.Session
+		method        string
+		path          string
+		body          string
+		authorization string
+		wantStatus    int
+		wantBody      string
+		wantApprover  string
+	}{
+		{
+			name:          "Unknown token",
+			method:        http.MethodGet,
+			path:          "/api/status",
+			authorization: "Bearer nope",
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			name:          "Token of the server",
+			givenSessions: []session.Session{flowSession, acmeSession},
+			method:        http.MethodGet,
+			path:          "/api/projects",
+			authorization: "Bearer secret",
+			wantStatus:    http.StatusOK,
+			wantBody:      `"Acme"`,
+		},
+		{
+			name:          "Projects of a member",
+			givenSessions: []session.Session{flowSession, acmeSession},
+			method:        http.MethodGet,
+			path:          "/api/projects",
+			authorization: "Bearer alice-token",
+			wantStatus:    http.StatusOK,
+			wantBody:      `{"projects":["Flow"]}`,
+		},
+		{
+			name:          "Sessions of the projects of a member",
+			givenSessions: []session.Session{flowSession, acmeSession},
+			method:        http.MethodGet,
+			path:          "/api/sessions",
+			authorization: "Bearer alice-token",
+			wantStatus:    http.StatusOK,
+			wantBody:      `{"sessions":[{"end_time":"2024-04-13T10:00:00Z","start_time":"2024-04-13T09:00:00Z","id":"1","project":"Flow","status":"ENDED","tags":["review"],"duration_seconds":3600}]}`,
+		},
+		{
+			name:          "Tags of the projects of a member",
+			givenSessions: []session.Session{flowSession, acmeSession},
+			method:        http.MethodGet,
+			path:          "/api/tags",
+			authorization: "Bearer alice-token",
+			wantStatus:    http.StatusOK,
+			wantBody:      `{"tags":["review"]}`,
+		},
+		{
+			name:          "Tags of a project the member isn't in",
+			givenSessions: []session.Session{flowSession, acmeSession},
+			method:        http.MethodGet,
+			path:          "/api/tags?project=Acme",
+			authorization: "Bearer alice-token",
+			wantStatus:    http.StatusForbidden,
+		},
+		{
+			name:          "Report of the projects of a member",
+			givenSessions: []session.Session{flowSession, acmeSession},
+			method:        http.MethodGet,
+			path:          "/api/report?format=by-project",
+			authorization: "Bearer alice-token",
+			wantStatus:    http.StatusOK,
+			wantBody:      `{"projects":[{"duration_by_tag_seconds":{"review":3600},"project":"Flow","total_duration_seconds":3600}]}`,
+		},
+		{
+			name:          "Current session of a project the member doesn't view",
+			givenSessions: []session.Session{acmeCurrentSession},
+			method:        http.MethodGet,
+			path:          "/api/status",
+			authorization: "Bearer alice-token",
+			wantStatus:    http.StatusOK,
+			wantBody:      `{"session":null,`,
+		},
+		{
+			name:          "Start a session as a tracker",
+			method:        http.MethodPost,
+			path:          "/api/start",
+			body:          `{"project":"Flow"}`,
+			authorization: "Bearer alice-token",
+			wantStatus:    http.StatusCreated,
+		},
+		{
+			name:          "Start a session as a viewer",
+			method:        http.MethodPost,
+			path:          "/api/start",
+			body:          `{"project":"Acme"}`,
+			authorization: "Bearer bob-token",
+			wantStatus:    http.StatusForbidden,
+			wantBody:      `{"error":"forbidden: the tracker role in the project Acme is required"}`,
+		},
+		{
+			name:          "Stop the session of a project the member doesn't view",
+			givenSessions: []session.Session{acmeCurrentSession},
+			method:        http.MethodPost,
+			path:          "/api/stop",
+			body:          `{}`,
+			authorization: "Bearer alice-token",
+			wantStatus:    http.StatusNotFound,
+		},
+		{
+			name:          "Get a session of a project the member doesn't view",
+			givenSessions: []session.Session{acmeSession},
+			method:        http.MethodGet,
+			path:          "/api/sessions/2",
+			authorization: "Bearer alice-token",
+			wantStatus:    http.StatusNotFound,
+		},
+		{
+			name:          "Move a session to a project the member doesn't track",
+			givenSessions: []session.Session{flowSession},
+			method:        http.MethodPatch,
+			path:          "/api/sessions/1",
+			body:          `{"project":"Acme"}`,
+			authorization: "Bearer alice-token",
+			wantStatus:    http.StatusForbidden,
+		},
+		{
+			name:          "Edit a session as an approver",
+			givenSessions: []session.Session{flowSession},
+			method:        http.MethodPatch,
+			path:          "/api/sessions/1",
+			body:          `{"note":"edited"}`,
+			authorization: "Bearer bob-token",
+			wantStatus:    http.StatusForbidden,
+		},
+		{
+			name:          "Approve a session",
+			givenSessions: []session.Session{flowSession},
+			method:        http.MethodPost,
+			path:          "/api/sessions/1/approval",
+			authorization: "Bearer bob-token",
+			wantStatus:    http.StatusOK,
+			wantApprover:  "bob",
+		},
+		{
+			name:          "Approve a session as a tracker",
+			givenSessions: []session.Session{flowSession},
+			method:        http.MethodPost,
+			path:          "/api/sessions/1/approval",
+			authorization: "Bearer alice-token",
+			wantStatus:    http.StatusForbidden,
+		},
+		{
+			name: "Withdraw an approval",
+			givenSessions: []session.Session{{
+				Id:        "1",
+				StartTime: flowSession.StartTime,
+				EndTime:   flowSession.EndTime,
+				Project:   "Flow",
+				Metadata:  map[string]string{session.ApprovedByMetadata: "bob"},
+			}},
+			method:        http.MethodDelete,
+			path:          "/api/sessions/1/approval",
+			authorization: "Bearer bob-token",
+			wantStatus:    http.StatusOK,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			sessionRepository := &infra.InMemorySessionRepository{Sessions: append([]session.Session{}, tc.givenSessions...)}
+			dateProvider := infra.NewStubDateProvider()
+			dateProvider.Now = time.Date(2024, time.April, 13, 17, 30, 0, 0, time.UTC)
+
+			s := server.NewServer(test.InitializeApp(sessionRepository, dateProvider))
+			s.Token = "secret"
+			s.Members = members
+
+			request := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			if tc.body != "" {
+				request.Header.Set("Content-Type", "application/json")
+			}
+			request.Header.Set("Authorization", tc.authorization)
+			recorder := httptest.NewRecorder()
+
+			s.Handler().ServeHTTP(recorder, request)
+
+			body := &bytes.Buffer{}
+			if recorder.Body.Len() > 0 {
+				is.NoErr(json.Compact(body, recorder.Body.Bytes()))
+			}
+
+			is.Equal(recorder.Code, tc.wantStatus)
+			is.True(strings.Contains(body.String(), tc.wantBody)) // body holds the expected content
+			if tc.method != http.MethodGet && len(tc.givenSessions) > 0 && tc.givenSessions[0].Id == "1" {
+				found, err := sessionRepository.FindById("1")
+				is.NoErr(err)
+				is.Equal(found.Metadata[session.ApprovedByMetadata], tc.wantApprover)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/sessionstatus"
+)
+
+// CurrentSessionEvent is the data of each event of the current session stream
+type CurrentSessionEvent struct {
+	Project        string   `json:"project,omitempty"`
+	Elapsed        string   `json:"elapsed"`
+	Tags           []string `json:"tags,omitempty"`
+	ElapsedSeconds int64    `json:"elapsed_seconds"`
+	Flowing        bool     `json:"flowing"`
+}
+
+// FormatElapsed formats a duration as a clock, like "1:23:45"
+func FormatElapsed(duration time.Duration) string {
+	seconds := int64(duration.Round(time.Second).Seconds())
+
+	return fmt.Sprintf("%d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60)
+}
+
+// currentSessionEvent is the current session, as no session to the members
+// not viewing its project
+func (s *Server) currentSessionEvent(r *http.Request) (CurrentSessionEvent, error) {
+	status, err := s.app.FlowSessionStatusUseCase.Execute()
+	if err == sessionstatus.ErrNoCurrentSession {
+		return CurrentSessionEvent{Elapsed: FormatElapsed(0)}, nil
+	}
+	if err != nil {
+		return CurrentSessionEvent{}, err
+	}
+	if !has(r, application.RoleViewer, status.Session.Project) {
+		return CurrentSessionEvent{Elapsed: FormatElapsed(0)}, nil
+	}
+
+	return CurrentSessionEvent{
+		Flowing:        true,
+		Project:        status.Session.Project,
+		Tags:           status.Session.Tags,
+		Elapsed:        FormatElapsed(status.Duration),
+		ElapsedSeconds: int64(status.Duration.Seconds()),
+	}, nil
+}
+
+// handleCurrentStream sends the elapsed time of the current session as
+// server-sent events until the client disconnects.
+func (s *Server) handleCurrentStream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	if s.AllowOrigin != "" {
+		w.Header().Set("Access-Control-Allow-Origin", s.AllowOrigin)
+	}
+
+	ticker := time.NewTicker(s.StreamInterval)
+	defer ticker.Stop()
+
+	for {
+		event, err := s.currentSessionEvent(r)
+		if err != nil {
+			fmt.Fprintf(w, "event: error\ndata: %v\n\n", err)
+			flusher.Flush()
+			return
+		}
+
+		data, _ := json.Marshal(event)
+		fmt.Fprintf(w, "data: %s\n\n", data)
+		flusher.Flush()
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package server_test
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/TristanShz/flow/internal/infra/server"
+	"github.com/TristanShz/flow/test"
+	"github.com/matryer/is"
+)
+
+func TestFormatElapsed(t *testing.T) {
+	tt := []struct {
+		duration time.Duration
+		want     string
+	}{
+		{duration: 0, want: "0:00:00"},
+		{duration: 45 * time.Second, want: "0:00:45"},
+		{duration: time.Hour + 23*time.Minute + 45*time.Second, want: "1:23:45"},
+		{duration: 26 * time.Hour, want: "26:00:00"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.want, func(t *testing.T) {
+			is := is.New(t)
+
+			is.Equal(server.FormatElapsed(tc.duration), tc.want)
+		})
+	}
+}
+
+func TestCurrentStream(t *testing.T) {
+	tt := []struct {
+		name          string
+		givenSessions []session.Session
+		members       []application.Member
+		authorization string
+		allowOrigin   string
+		wantStatus    int
+		want          []string
+	}{
+		{
+			name: "No current session",
+			want: []string{
+				`data: {"elapsed":"0:00:00","elapsed_seconds":0,"flowing":false}`,
+				`data: {"elapsed":"0:00:00","elapsed_seconds":0,"flowing":false}`,
+			},
+		},
+		{
+			name: "Current session",
+			givenSessions: []session.Session{
+				{
+					Id:        "1",
+					StartTime: time.Date(2024, time.April, 13, 16, 6, 15, 0, time.UTC),
+					Project:   "Flow",
+					Tags:      []string{"stream"},
+				},
+			},
+			want: []string{
+				`data: {"project":"Flow","elapsed":"1:23:45","tags":["stream"],"elapsed_seconds":5025,"flowing":true}`,
+				`data: {"project":"Flow","elapsed":"1:23:45","tags":["stream"],"elapsed_seconds":5025,"flowing":true}`,
+			},
+		},
+		{
+			name:        "Allowed origin",
+			allowOrigin: "*",
+			want: []string{
+				`data: {"elapsed":"0:00:00","elapsed_seconds":0,"flowing":false}`,
+			},
+		},
+		{
+			name:       "Without token",
+			members:    []application.Member{{Name: "alice", Token: "alice-token", Roles: map[string][]string{application.RoleViewer: {"Flow"}}}},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "Member not viewing the project",
+			givenSessions: []session.Session{
+				{Id: "1", StartTime: time.Date(2024, time.April, 13, 16, 6, 15, 0, time.UTC), Project: "Flow"},
+			},
+			members:       []application.Member{{Name: "alice", Token: "alice-token", Roles: map[string][]string{application.RoleViewer: {"Acme"}}}},
+			authorization: "Bearer alice-token",
+			want: []string{
+				`data: {"elapsed":"0:00:00","elapsed_seconds":0,"flowing":false}`,
+			},
+		},
+		{
+			name: "Member viewing the project",
+			givenSessions: []session.Session{
+				{Id: "1", StartTime: time.Date(2024, time.April, 13, 16, 6, 15, 0, time.UTC), Project: "Flow"},
+			},
+			members:       []application.Member{{Name: "alice", Token: "alice-token", Roles: map[string][]string{application.RoleViewer: {"Flow"}}}},
+			authorization: "Bearer alice-token",
+			want: []string{
+				`data: {"project":"Flow","elapsed":"1:23:45","elapsed_seconds":5025,"flowing":true}`,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			sessionRepository := &infra.InMemorySessionRepository{Sessions: tc.givenSessions}
+			dateProvider := infra.NewStubDateProvider()
+			dateProvider.Now = time.Date(2024, time.April, 13, 17, 30, 0, 0, time.UTC)
+
+			s := server.NewServer(test.InitializeApp(sessionRepository, dateProvider))
+			s.StreamInterval = 10 * time.Millisecond
+			s.Members = tc.members
+			s.AllowOrigin = tc.allowOrigin
+			httpServer := httptest.NewServer(s.Handler())
+			defer httpServer.Close()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, httpServer.URL+"/current/stream", nil)
+			if tc.authorization != "" {
+				req.Header.Set("Authorization", tc.authorization)
+			}
+			res, err := http.DefaultClient.Do(req)
+			is.NoErr(err)
+			defer res.Body.Close()
+
+			if tc.wantStatus != 0 {
+				is.Equal(res.StatusCode, tc.wantStatus)
+				return
+			}
+			is.Equal(res.Header.Get("Content-Type"), "text/event-stream")
+			is.Equal(res.Header.Get("Access-Control-Allow-Origin"), tc.allowOrigin)
+
+			got := []string{}
+			scanner := bufio.NewScanner(res.Body)
+			for len(got) < len(tc.want) && scanner.Scan() {
+				if strings.HasPrefix(scanner.Text(), "data: ") {
+					got = append(got, scanner.Text())
+				}
+			}
+
+			is.Equal(got, tc.want)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/showsession"
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+// ownerName approves the sessions approved with the token of the server
+const ownerName = "owner"
+
+var errForbidden = errors.New("forbidden")
+
+type memberKey struct{}
+
+// memberOf returns the member calling the API, it's nil for the owner of the
+// server, who has every role in every project
+func memberOf(r *http.Request) *application.Member {
+	member, _ := r.Context().Value(memberKey{}).(*application.Member)
+	return member
+}
+
+func withMember(r *http.Request, member *application.Member) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), memberKey{}, member))
+}
+
+// nameOf returns the name of the member calling the API
+func nameOf(r *http.Request) string {
+	if member := memberOf(r); member != nil {
+		return member.Name
+	}
+
+	return ownerName
+}
+
+// has tells if the caller of the API has the role in the project
+func has(r *http.Request, role string, project string) bool {
+	member := memberOf(r)
+	return member == nil || member.Has(role, project)
+}
+
+// requireRole answers with errForbidden when the caller lacks the role in
+// the project
+func requireRole(r *http.Request, role string, project string) error {
+	if !has(r, role, project) {
+		return fmt.Errorf("%w: the %v role in the project %v is required", errForbidden, role, project)
+	}
+
+	return nil
+}
+
+// requireSessionRole is requireRole for a session, whose existence isn't
+// told to the members not viewing its project
+func requireSessionRole(r *http.Request, role string, s session.Session) error {
+	if !has(r, application.RoleViewer, s.Project) {
+		return showsession.ErrSessionNotFound
+	}
+
+	return requireRole(r, role, s.Project)
+}
+
+// visibleCondition keeps the sessions of the projects a member views, on top
+// of the where expression of the request
+type visibleCondition struct {
+	member *application.Member
+	where  application.Condition
+}
+
+func (c visibleCondition) Match(s session.Session) bool {
+	return c.member.Has(application.RoleViewer, s.Project) && (c.where == nil || c.where.Match(s))
+}
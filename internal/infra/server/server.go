@@ -0,0 +1,56 @@
+package server
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	app "github.com/TristanShz/flow/internal/application/usecases"
+)
+
+// DefaultStreamInterval is the time between two events of a stream
+const DefaultStreamInterval = time.Second
+
+// Server exposes the flow sessions over HTTP, e.g. for streaming overlays,
+// and the API driving flow under /api
+type Server struct {
+	app            *app.App
+	StreamInterval time.Duration
+	// Token is required by the API as a bearer token, when it's set. It
+	// gives every role in every project.
+	Token string
+	// Members call the API with their own token, they only see and change
+	// the sessions of the projects they have a role in
+	Members []application.Member
+	// AllowOrigin is sent as the Access-Control-Allow-Origin header of the
+	// stream when it's set, for the overlays served from another origin
+	AllowOrigin string
+}
+
+func NewServer(app *app.App) *Server {
+	return &Server{
+		app:            app,
+		StreamInterval: DefaultStreamInterval,
+	}
+}
+
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /current/stream", s.authorize(s.handleCurrentStream))
+
+	mux.HandleFunc("GET /api/status", s.authorize(s.handleStatus))
+	mux.HandleFunc("POST /api/start", s.authorize(s.handleStart))
+	mux.HandleFunc("POST /api/stop", s.authorize(s.handleStop))
+	mux.HandleFunc("GET /api/sessions", s.authorize(s.handleListSessions))
+	mux.HandleFunc("POST /api/sessions", s.authorize(s.handleLogSession))
+	mux.HandleFunc("GET /api/sessions/{id}", s.authorize(s.handleGetSession))
+	mux.HandleFunc("PATCH /api/sessions/{id}", s.authorize(s.handleEditSession))
+	mux.HandleFunc("DELETE /api/sessions/{id}", s.authorize(s.handleDeleteSession))
+	mux.HandleFunc("POST /api/sessions/{id}/approval", s.authorize(s.handleApproveSession))
+	mux.HandleFunc("DELETE /api/sessions/{id}/approval", s.authorize(s.handleWithdrawApproval))
+	mux.HandleFunc("GET /api/projects", s.authorize(s.handleProjects))
+	mux.HandleFunc("GET /api/tags", s.authorize(s.handleTags))
+	mux.HandleFunc("GET /api/report", s.authorize(s.handleReport))
+
+	return mux
+}
@@ -0,0 +1,173 @@
+package infra
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+// ErrInjectedFault is returned by the writes FaultySessionRepository makes
+// fail
+var ErrInjectedFault = errors.New("injected storage fault")
+
+// Faults are the storage failures injected by FaultySessionRepository, the
+// zero Faults injects none
+type Faults struct {
+	// ErrorRate is the probability of a write failing without writing
+	// anything
+	ErrorRate float64
+	// PartialWriteRate is the probability of a write being cut short, see
+	// PartialWriter
+	PartialWriteRate float64
+	// Latency delays every call to the repository
+	Latency time.Duration
+}
+
+// ParseFaults reads faults like "errors=0.1,partial=0.05,latency=50ms", the
+// rates are probabilities between 0 and 1
+func ParseFaults(spec string) (Faults, error) {
+	faults := Faults{}
+
+	for _, fault := range strings.Split(spec, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(fault), "=")
+		if !ok {
+			return Faults{}, fmt.Errorf("invalid fault %v, expected name=value", fault)
+		}
+
+		switch name {
+		case "errors", "partial":
+			rate, err := strconv.ParseFloat(value, 64)
+			if err != nil || rate < 0 || rate > 1 {
+				return Faults{}, fmt.Errorf("invalid rate %v of %v, expected a probability between 0 and 1", value, name)
+			}
+			if name == "errors" {
+				faults.ErrorRate = rate
+			} else {
+				faults.PartialWriteRate = rate
+			}
+		case "latency":
+			latency, err := time.ParseDuration(value)
+			if err != nil || latency < 0 {
+				return Faults{}, fmt.Errorf("invalid latency %v, expected a duration like 50ms", value)
+			}
+			faults.Latency = latency
+		default:
+			return Faults{}, fmt.Errorf("unknown fault %v. possible values: errors, partial, latency", name)
+		}
+	}
+
+	return faults, nil
+}
+
+// PartialWriter is a repository whose writes can be cut short, leaving the
+// first fraction of the session written, like the files of the filesystem
+type PartialWriter interface {
+	SavePartially(s session.Session, fraction float64) error
+}
+
+// FaultySessionRepository injects storage failures into the writes of a
+// session repository, to check that the use cases and 'flow doctor' recover
+// from them. A partial write cuts the write short when the repository is a
+// PartialWriter, otherwise the session is saved but the write still fails,
+// like a write whose acknowledgement was lost. The reads return no errors,
+// they are only delayed.
+type FaultySessionRepository struct {
+	repository application.SessionRepository
+	// faults is shared with the caller, which may change it before the
+	// repository is used, e.g. once the flags are parsed
+	faults *Faults
+	random *rand.Rand
+	mutex  sync.Mutex
+}
+
+func NewFaultySessionRepository(repository application.SessionRepository, faults *Faults, random *rand.Rand) *FaultySessionRepository {
+	return &FaultySessionRepository{repository: repository, faults: faults, random: random}
+}
+
+// roll delays the call and tells which fault to inject into a write
+func (r *FaultySessionRepository) roll() (failed bool, partial bool, fraction float64) {
+	time.Sleep(r.faults.Latency)
+
+	if r.faults.ErrorRate == 0 && r.faults.PartialWriteRate == 0 {
+		return false, false, 0
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	failed = r.random.Float64() < r.faults.ErrorRate
+	partial = !failed && r.random.Float64() < r.faults.PartialWriteRate
+
+	return failed, partial, r.random.Float64()
+}
+
+func (r *FaultySessionRepository) Save(s session.Session) error {
+	failed, partial, fraction := r.roll()
+	if failed {
+		return ErrInjectedFault
+	}
+
+	if !partial {
+		return r.repository.Save(s)
+	}
+
+	if partialWriter, ok := r.repository.(PartialWriter); ok {
+		if err := partialWriter.SavePartially(s, fraction); err != nil {
+			return err
+		}
+	} else if err := r.repository.Save(s); err != nil {
+		return err
+	}
+
+	return fmt.Errorf("%w: partial write", ErrInjectedFault)
+}
+
+func (r *FaultySessionRepository) Delete(id string) error {
+	if failed, _, _ := r.roll(); failed {
+		return ErrInjectedFault
+	}
+
+	return r.repository.Delete(id)
+}
+
+func (r *FaultySessionRepository) FindById(id string) (*session.Session, error) {
+	time.Sleep(r.faults.Latency)
+	return r.repository.FindById(id)
+}
+
+func (r *FaultySessionRepository) FindLastSession() (*session.Session, error) {
+	time.Sleep(r.faults.Latency)
+	return r.repository.FindLastSession()
+}
+
+func (r *FaultySessionRepository) FindAllSessions(filters *application.SessionsFilters) []session.Session {
+	time.Sleep(r.faults.Latency)
+	return r.repository.FindAllSessions(filters)
+}
+
+func (r *FaultySessionRepository) ForEachSession(filters *application.SessionsFilters, fn func(session.Session) error) error {
+	time.Sleep(r.faults.Latency)
+	return r.repository.ForEachSession(filters, fn)
+}
+
+func (r *FaultySessionRepository) FindAllSessionMetadata(filters *application.SessionsFilters) []application.SessionMetadata {
+	time.Sleep(r.faults.Latency)
+	return r.repository.FindAllSessionMetadata(filters)
+}
+
+func (r *FaultySessionRepository) FindAllProjects() []string {
+	time.Sleep(r.faults.Latency)
+	return r.repository.FindAllProjects()
+}
+
+func (r *FaultySessionRepository) FindAllProjectTags(project string) []string {
+	time.Sleep(r.faults.Latency)
+	return r.repository.FindAllProjectTags(project)
+}
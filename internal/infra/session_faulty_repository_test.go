@@ -0,0 +1,123 @@
+package infra_test
+
+import (
+	"errors"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/TristanShz/flow/internal/infra/filesystem"
+	"github.com/matryer/is"
+)
+
+func TestParseFaults(t *testing.T) {
+	tt := []struct {
+		name    string
+		spec    string
+		want    infra.Faults
+		wantErr bool
+	}{
+		{
+			name: "Every fault",
+			spec: "errors=0.1, partial=0.05,latency=50ms",
+			want: infra.Faults{ErrorRate: 0.1, PartialWriteRate: 0.05, Latency: 50 * time.Millisecond},
+		},
+		{
+			name:    "Rate above 1",
+			spec:    "errors=10",
+			wantErr: true,
+		},
+		{
+			name:    "Unknown fault",
+			spec:    "disk_full=1",
+			wantErr: true,
+		},
+		{
+			name:    "Missing value",
+			spec:    "latency",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			got, err := infra.ParseFaults(tc.spec)
+
+			is.Equal(err != nil, tc.wantErr)
+			is.Equal(got, tc.want)
+		})
+	}
+}
+
+func TestFaultySessionRepository(t *testing.T) {
+	flowing := session.Session{Id: "1", StartTime: time.Date(2024, time.April, 13, 9, 0, 0, 0, time.UTC), Project: "Flow"}
+
+	tt := []struct {
+		name         string
+		faults       infra.Faults
+		wantErr      error
+		wantSessions []session.Session
+	}{
+		{
+			name:         "No faults",
+			wantSessions: []session.Session{flowing},
+		},
+		{
+			name:    "Failing write",
+			faults:  infra.Faults{ErrorRate: 1},
+			wantErr: infra.ErrInjectedFault,
+		},
+		{
+			name:         "Partial write of a repository without partial writes",
+			faults:       infra.Faults{PartialWriteRate: 1},
+			wantErr:      infra.ErrInjectedFault,
+			wantSessions: []session.Session{flowing},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			inMemory := &infra.InMemorySessionRepository{}
+			repository := infra.NewFaultySessionRepository(inMemory, &tc.faults, rand.New(rand.NewPCG(1, 2)))
+
+			err := repository.Save(flowing)
+
+			is.True(errors.Is(err, tc.wantErr))
+			is.Equal(inMemory.Sessions, tc.wantSessions)
+		})
+	}
+}
+
+func TestFaultySessionRepository_PartialWrite(t *testing.T) {
+	is := is.New(t)
+
+	fileSystem := filesystem.NewFileSystemSessionRepository(t.TempDir())
+	faults := &infra.Faults{}
+	repository := infra.NewFaultySessionRepository(&fileSystem, faults, rand.New(rand.NewPCG(1, 2)))
+
+	flowing := session.Session{Id: "1", StartTime: time.Date(2024, time.April, 13, 9, 0, 0, 0, time.UTC), Project: "Flow"}
+	is.NoErr(repository.Save(flowing))
+
+	faults.PartialWriteRate = 1
+	stopped := flowing
+	stopped.EndTime = flowing.StartTime.Add(time.Hour)
+	err := repository.Save(stopped)
+
+	is.True(errors.Is(err, infra.ErrInjectedFault))
+	found, err := repository.FindById("1")
+	is.NoErr(err)
+	is.Equal(found, nil)                    // the cut short file can't be read
+	is.Equal(len(fileSystem.Diagnose()), 1) // and is found by the doctor
+
+	faults.PartialWriteRate = 0
+	is.NoErr(repository.Save(stopped)) // saving again repairs it
+	found, err = repository.FindById("1")
+	is.NoErr(err)
+	is.Equal(found.EndTime, stopped.EndTime)
+}
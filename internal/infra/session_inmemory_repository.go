@@ -2,6 +2,7 @@ package infra
 
 import (
 	"slices"
+	"time"
 
 	"github.com/TristanShz/flow/internal/application"
 	"github.com/TristanShz/flow/internal/domain/session"
@@ -10,26 +11,28 @@ import (
 
 type InMemorySessionRepository struct {
 	Sessions []session.Session
+	// Trash holds the deleted sessions, the last deleted first
+	Trash []application.TrashedSession
 }
 
-func (r *InMemorySessionRepository) FindById(id string) *session.Session {
+func (r *InMemorySessionRepository) FindById(id string) (*session.Session, error) {
 	for _, session := range r.Sessions {
 		if session.Id == id {
-			return &session
+			return &session, nil
 		}
 	}
-	return nil
+	return nil, nil
 }
 
 func (r *InMemorySessionRepository) Save(s session.Session) error {
-	startedSessionIndex := slices.IndexFunc(r.Sessions, func(s session.Session) bool {
-		return s.StartTime.Equal(s.StartTime)
+	sessionIndex := slices.IndexFunc(r.Sessions, func(existing session.Session) bool {
+		return existing.Id == s.Id
 	})
 
-	if startedSessionIndex == -1 {
+	if sessionIndex == -1 {
 		r.Sessions = append(r.Sessions, s)
 	} else {
-		r.Sessions[startedSessionIndex] = s
+		r.Sessions[sessionIndex] = s
 	}
 
 	return nil
@@ -42,16 +45,49 @@ func (r *InMemorySessionRepository) Delete(id string) error {
 	if sessionIndex == -1 {
 		return nil
 	}
+	r.Trash = slices.Insert(r.Trash, 0, application.TrashedSession{DeletedAt: time.Now(), Session: r.Sessions[sessionIndex]})
 	r.Sessions = append(r.Sessions[:sessionIndex], r.Sessions[sessionIndex+1:]...)
 	return nil
 }
 
-func (r *InMemorySessionRepository) FindLastSession() *session.Session {
+func (r *InMemorySessionRepository) FindAllTrashed() ([]application.TrashedSession, error) {
+	return r.Trash, nil
+}
+
+func (r *InMemorySessionRepository) Restore(id string) (session.Session, error) {
+	trashIndex := slices.IndexFunc(r.Trash, func(trashed application.TrashedSession) bool {
+		return trashed.Session.Id == id
+	})
+	if trashIndex == -1 {
+		return session.Session{}, application.ErrSessionNotTrashed
+	}
+
+	restored := r.Trash[trashIndex].Session
+	r.Trash = slices.Delete(r.Trash, trashIndex, trashIndex+1)
+
+	return restored, r.Save(restored)
+}
+
+func (r *InMemorySessionRepository) Purge(deletedBefore time.Time) (int, error) {
+	kept := []application.TrashedSession{}
+	for _, trashed := range r.Trash {
+		if !deletedBefore.IsZero() && !trashed.DeletedAt.Before(deletedBefore) {
+			kept = append(kept, trashed)
+		}
+	}
+
+	purged := len(r.Trash) - len(kept)
+	r.Trash = kept
+
+	return purged, nil
+}
+
+func (r *InMemorySessionRepository) FindLastSession() (*session.Session, error) {
 	if len(r.Sessions) == 0 {
-		return nil
+		return nil, nil
 	}
 
-	return &r.Sessions[len(r.Sessions)-1]
+	return &r.Sessions[len(r.Sessions)-1], nil
 }
 
 func (r *InMemorySessionRepository) FindAllSessions(filters *application.SessionsFilters) []session.Session {
@@ -65,41 +101,81 @@ func (r *InMemorySessionRepository) FindAllSessions(filters *application.Session
 		if filters.Project != "" {
 			filteredSessions = r.filterByProject(filteredSessions, filters.Project)
 		}
+
+		if len(filters.Tags) > 0 {
+			filteredSessions = r.filterByTags(filteredSessions, *filters)
+		}
+
+		if filters.Where != nil {
+			filteredSessions = r.filterByWhere(filteredSessions, *filters)
+		}
+
+		if filters.IsDescending() {
+			filteredSessions = slices.Clone(filteredSessions)
+			slices.SortStableFunc(filteredSessions, func(a session.Session, b session.Session) int {
+				return b.StartTime.Compare(a.StartTime)
+			})
+		}
+
+		filteredSessions = filters.Page(filteredSessions)
 	}
 
 	return filteredSessions
 }
 
-func (r *InMemorySessionRepository) FindAllProjects() []string {
-	sessions := r.FindAllSessions(nil)
+func (r *InMemorySessionRepository) ForEachSession(filters *application.SessionsFilters, fn func(session.Session) error) error {
+	for _, session := range r.FindAllSessions(filters) {
+		if err := fn(session); err != nil {
+			return err
+		}
+	}
 
-	projects := []string{}
+	return nil
+}
 
-	for _, session := range sessions {
-		if slices.Contains(projects, session.Project) {
-			continue
+func (r *InMemorySessionRepository) FindAllSessionMetadata(filters *application.SessionsFilters) []application.SessionMetadata {
+	if filters != nil {
+		filters = &application.SessionsFilters{
+			Timerange: filters.Timerange,
+			Project:   filters.Project,
+			Order:     filters.Order,
+			Offset:    filters.Offset,
+			Limit:     filters.Limit,
 		}
+	}
 
-		projects = append(projects, session.Project)
+	metadata := []application.SessionMetadata{}
+	for _, session := range r.FindAllSessions(filters) {
+		metadata = append(metadata, application.SessionMetadata{Id: session.Id, Project: session.Project, StartTime: session.StartTime})
 	}
 
+	return metadata
+}
+
+func (r *InMemorySessionRepository) FindAllProjects() []string {
+	projects := []string{}
+
+	r.ForEachSession(nil, func(session session.Session) error {
+		if !slices.Contains(projects, session.Project) {
+			projects = append(projects, session.Project)
+		}
+		return nil
+	})
+
 	return projects
 }
 
 func (r *InMemorySessionRepository) FindAllProjectTags(project string) []string {
-	sessionsForProject := r.FindAllSessions(&application.SessionsFilters{Project: project})
-
 	tags := []string{}
 
-	for _, session := range sessionsForProject {
+	r.ForEachSession(&application.SessionsFilters{Project: project}, func(session session.Session) error {
 		for _, tag := range session.Tags {
-			if slices.Contains(tags, tag) {
-				continue
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
 			}
-
-			tags = append(tags, tag)
 		}
-	}
+		return nil
+	})
 
 	return tags
 }
@@ -108,19 +184,7 @@ func (r *InMemorySessionRepository) filterByTimeRange(sessions []session.Session
 	filteredSessions := []session.Session{}
 
 	for _, session := range sessions {
-		if timeRange.Since.IsZero() && !timeRange.Until.IsZero() {
-			if session.StartTime.Before(timeRange.Until) {
-				filteredSessions = append(filteredSessions, session)
-			}
-		} else if !timeRange.Since.IsZero() && timeRange.Until.IsZero() {
-			if session.StartTime.After(timeRange.Since) {
-				filteredSessions = append(filteredSessions, session)
-			}
-		} else if !timeRange.Since.IsZero() && !timeRange.Until.IsZero() {
-			if session.StartTime.After(timeRange.Since) && session.StartTime.Before(timeRange.Until) {
-				filteredSessions = append(filteredSessions, session)
-			}
-		} else {
+		if timeRange.Contains(session.StartTime) {
 			filteredSessions = append(filteredSessions, session)
 		}
 	}
@@ -138,3 +202,27 @@ func (r *InMemorySessionRepository) filterByProject(sessions []session.Session,
 
 	return filteredSessions
 }
+
+func (r *InMemorySessionRepository) filterByTags(sessions []session.Session, filters application.SessionsFilters) []session.Session {
+	filteredSessions := []session.Session{}
+
+	for _, session := range sessions {
+		if filters.MatchTags(session) {
+			filteredSessions = append(filteredSessions, session)
+		}
+	}
+
+	return filteredSessions
+}
+
+func (r *InMemorySessionRepository) filterByWhere(sessions []session.Session, filters application.SessionsFilters) []session.Session {
+	filteredSessions := []session.Session{}
+
+	for _, session := range sessions {
+		if filters.MatchWhere(session) {
+			filteredSessions = append(filteredSessions, session)
+		}
+	}
+
+	return filteredSessions
+}
@@ -0,0 +1,25 @@
+package infra
+
+import (
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+// ReadOnlySessionRepository reads the sessions of a repository and refuses
+// to change them, e.g. for the stores of other setups reported by
+// 'flow report --stores'
+type ReadOnlySessionRepository struct {
+	application.SessionRepository
+}
+
+func NewReadOnlySessionRepository(repository application.SessionRepository) ReadOnlySessionRepository {
+	return ReadOnlySessionRepository{SessionRepository: repository}
+}
+
+func (r ReadOnlySessionRepository) Save(session.Session) error {
+	return application.ErrReadOnlyStore
+}
+
+func (r ReadOnlySessionRepository) Delete(string) error {
+	return application.ErrReadOnlyStore
+}
@@ -0,0 +1,29 @@
+package infra_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/matryer/is"
+)
+
+func TestReadOnlySessionRepository(t *testing.T) {
+	is := is.New(t)
+
+	s := session.Session{
+		Id:        "1",
+		StartTime: time.Date(2024, time.April, 15, 9, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, time.April, 15, 10, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+	}
+	repository := &infra.InMemorySessionRepository{Sessions: []session.Session{s}}
+	readOnly := infra.NewReadOnlySessionRepository(repository)
+
+	is.Equal(readOnly.FindAllSessions(&application.SessionsFilters{}), []session.Session{s})
+	is.Equal(readOnly.Save(session.Session{Id: "2", StartTime: s.EndTime, Project: "Flow"}), application.ErrReadOnlyStore)
+	is.Equal(readOnly.Delete("1"), application.ErrReadOnlyStore)
+	is.Equal(repository.Sessions, []session.Session{s})
+}
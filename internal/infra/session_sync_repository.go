@@ -0,0 +1,159 @@
+package infra
+
+import (
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+// SyncSessionRepository makes a session repository safe for concurrent use,
+// e.g. by the requests of 'flow serve'. Sessions are returned as copies, so
+// that a caller changing the current session doesn't change it under
+// another one before it's saved.
+type SyncSessionRepository struct {
+	repository application.SessionRepository
+	mutex      sync.RWMutex
+}
+
+func NewSyncSessionRepository(repository application.SessionRepository) *SyncSessionRepository {
+	return &SyncSessionRepository{repository: repository}
+}
+
+func (r *SyncSessionRepository) Save(s session.Session) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.repository.Save(s)
+}
+
+func (r *SyncSessionRepository) Delete(id string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.repository.Delete(id)
+}
+
+func (r *SyncSessionRepository) FindById(id string) (*session.Session, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	found, err := r.repository.FindById(id)
+	return clone(found), err
+}
+
+func (r *SyncSessionRepository) FindLastSession() (*session.Session, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	last, err := r.repository.FindLastSession()
+	return clone(last), err
+}
+
+func (r *SyncSessionRepository) FindAllSessions(filters *application.SessionsFilters) []session.Session {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	sessions := slices.Clone(r.repository.FindAllSessions(filters))
+	for i := range sessions {
+		sessions[i] = *clone(&sessions[i])
+	}
+
+	return sessions
+}
+
+// syncPageSize is the number of sessions ForEachSession reads under the lock
+// at once
+const syncPageSize = 256
+
+// ForEachSession reads the sessions by pages under the read lock, which is
+// released before they're given to fn, so that fn can save sessions and a
+// slow fn doesn't hold off the writers. A session saved between two pages may
+// be given twice or not at all, like with the pages of the API.
+func (r *SyncSessionRepository) ForEachSession(filters *application.SessionsFilters, fn func(session.Session) error) error {
+	page := application.SessionsFilters{}
+	if filters != nil {
+		page = *filters
+	}
+
+	given := 0
+	for {
+		page.Offset = given
+		page.Limit = syncPageSize
+		if filters != nil {
+			page.Offset += filters.Offset
+			if filters.Limit > 0 {
+				page.Limit = min(syncPageSize, filters.Limit-given)
+			}
+		}
+		if page.Limit <= 0 {
+			return nil
+		}
+
+		sessions, err := r.readPage(&page)
+		if err != nil {
+			return err
+		}
+
+		for _, s := range sessions {
+			if err := fn(s); err != nil {
+				return err
+			}
+		}
+
+		given += len(sessions)
+		if len(sessions) < page.Limit {
+			return nil
+		}
+	}
+}
+
+func (r *SyncSessionRepository) readPage(page *application.SessionsFilters) ([]session.Session, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	sessions := []session.Session{}
+	err := r.repository.ForEachSession(page, func(s session.Session) error {
+		sessions = append(sessions, *clone(&s))
+		return nil
+	})
+
+	return sessions, err
+}
+
+func (r *SyncSessionRepository) FindAllSessionMetadata(filters *application.SessionsFilters) []application.SessionMetadata {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return slices.Clone(r.repository.FindAllSessionMetadata(filters))
+}
+
+func (r *SyncSessionRepository) FindAllProjects() []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return slices.Clone(r.repository.FindAllProjects())
+}
+
+func (r *SyncSessionRepository) FindAllProjectTags(project string) []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return slices.Clone(r.repository.FindAllProjectTags(project))
+}
+
+// clone copies the session with its tags and metadata, which are shared with
+// the repository otherwise
+func clone(s *session.Session) *session.Session {
+	if s == nil {
+		return nil
+	}
+
+	cloned := *s
+	cloned.Tags = slices.Clone(s.Tags)
+	cloned.Metadata = maps.Clone(s.Metadata)
+
+	return &cloned
+}
@@ -0,0 +1,125 @@
+package infra_test
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/matryer/is"
+)
+
+// run with -race, the race detector reports the accesses the mutex misses
+func TestSyncSessionRepository_ConcurrentAccess(t *testing.T) {
+	is := is.New(t)
+
+	repository := infra.NewSyncSessionRepository(&infra.InMemorySessionRepository{})
+	startTime := time.Date(2024, time.April, 13, 9, 0, 0, 0, time.UTC)
+
+	const writers = 20
+	wg := sync.WaitGroup{}
+	for i := 0; i < writers; i++ {
+		wg.Add(2)
+
+		go func(i int) {
+			defer wg.Done()
+
+			s := session.Session{
+				Id:        strconv.Itoa(i),
+				StartTime: startTime.Add(time.Duration(i) * time.Hour),
+				Project:   "Flow",
+				Tags:      []string{"concurrent"},
+			}
+			is.NoErr(repository.Save(s))
+
+			s.EndTime = s.StartTime.Add(30 * time.Minute)
+			is.NoErr(repository.Save(s))
+		}(i)
+
+		go func() {
+			defer wg.Done()
+
+			repository.FindLastSession()
+			repository.FindAllSessions(&application.SessionsFilters{Tags: []string{"concurrent"}})
+			repository.FindAllProjects()
+			repository.FindAllProjectTags("Flow")
+		}()
+	}
+	wg.Wait()
+
+	sessions := repository.FindAllSessions(nil)
+	is.Equal(len(sessions), writers)
+	for _, s := range sessions {
+		is.Equal(s.Duration(), 30*time.Minute)
+	}
+}
+
+func TestSyncSessionRepository_ReturnsCopies(t *testing.T) {
+	is := is.New(t)
+
+	inMemory := &infra.InMemorySessionRepository{Sessions: []session.Session{{
+		Id:        "1",
+		StartTime: time.Date(2024, time.April, 13, 9, 0, 0, 0, time.UTC),
+		Project:   "Flow",
+		Tags:      []string{"cli"},
+		Metadata:  map[string]string{"origin": "cli"},
+	}}}
+	repository := infra.NewSyncSessionRepository(inMemory)
+
+	last, err := repository.FindLastSession()
+	is.NoErr(err)
+	last.EndTime = time.Date(2024, time.April, 13, 10, 0, 0, 0, time.UTC)
+	last.Tags[0] = "changed"
+	last.Metadata["origin"] = "changed"
+
+	all := repository.FindAllSessions(nil)
+	all[0].Tags[0] = "changed"
+
+	is.True(inMemory.Sessions[0].EndTime.IsZero())
+	is.Equal(inMemory.Sessions[0].Tags, []string{"cli"})
+	is.Equal(inMemory.Sessions[0].Metadata, map[string]string{"origin": "cli"})
+	found, err := repository.FindById("unknown")
+	is.NoErr(err)
+	is.Equal(found, nil)
+}
+
+func TestSyncSessionRepository_ForEachSession(t *testing.T) {
+	is := is.New(t)
+
+	inMemory := &infra.InMemorySessionRepository{}
+	startTime := time.Date(2024, time.April, 13, 9, 0, 0, 0, time.UTC)
+	for i := 0; i < 600; i++ {
+		inMemory.Sessions = append(inMemory.Sessions, session.Session{
+			Id:        strconv.Itoa(i),
+			StartTime: startTime.Add(time.Duration(i) * time.Hour),
+			EndTime:   startTime.Add(time.Duration(i)*time.Hour + 30*time.Minute),
+			Project:   "Flow",
+		})
+	}
+	repository := infra.NewSyncSessionRepository(inMemory)
+
+	// the lock isn't held while fn runs, fn can save the sessions it's given
+	ids := []string{}
+	is.NoErr(repository.ForEachSession(nil, func(s session.Session) error {
+		ids = append(ids, s.Id)
+		s.Note = "seen"
+		return repository.Save(s)
+	}))
+	is.Equal(len(ids), 600)
+	is.Equal(ids[599], "599")
+	found, err := repository.FindById("599")
+	is.NoErr(err)
+	is.Equal(found.Note, "seen")
+
+	ids = []string{}
+	is.NoErr(repository.ForEachSession(&application.SessionsFilters{Offset: 250, Limit: 300}, func(s session.Session) error {
+		ids = append(ids, s.Id)
+		return nil
+	}))
+	is.Equal(len(ids), 300)
+	is.Equal(ids[0], "250")
+	is.Equal(ids[299], "549")
+}
@@ -0,0 +1,257 @@
+package socket
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+// dialTimeout bounds the wait for the daemon, the commands read the flow
+// folder themselves when it doesn't answer
+const dialTimeout = 100 * time.Millisecond
+
+// callTimeout bounds a request to the daemon
+const callTimeout = 30 * time.Second
+
+// maxMessageSize bounds a request or a response, all the sessions of the
+// flow folder may be sent at once
+const maxMessageSize = 256 << 20
+
+// Client is the session repository and the active session lock of the flow
+// commands while 'flow daemon' runs. The errors of the daemon are returned by
+// the reads that return an error, the other ones return nothing when the
+// daemon doesn't answer and the error is reported to the log.
+type Client struct {
+	conn    net.Conn
+	scanner *bufio.Scanner
+	log     *log.Logger
+	mutex   sync.Mutex
+}
+
+// Dial connects to the daemon serving the socket
+func Dial(path string, logger *log.Logger) (*Client, error) {
+	conn, err := net.DialTimeout("unix", path, dialTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(nil, maxMessageSize)
+
+	return &Client{conn: conn, scanner: scanner, log: logger}, nil
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+func (c *Client) call(req request) (response, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err := c.conn.SetDeadline(time.Now().Add(callTimeout)); err != nil {
+		return response{}, err
+	}
+
+	if err := json.NewEncoder(c.conn).Encode(req); err != nil {
+		return response{}, fmt.Errorf("the daemon can't be reached: %w", err)
+	}
+
+	if !c.scanner.Scan() {
+		err := c.scanner.Err()
+		if err == nil {
+			err = errors.New("the connection was closed")
+		}
+		return response{}, fmt.Errorf("the daemon didn't answer: %w", err)
+	}
+
+	res := response{}
+	if err := json.Unmarshal(c.scanner.Bytes(), &res); err != nil {
+		return response{}, fmt.Errorf("invalid answer of the daemon: %w", err)
+	}
+
+	if res.Locked {
+		return res, application.ErrActiveSessionLocked
+	}
+	if res.Error != "" {
+		return res, errors.New(res.Error)
+	}
+
+	return res, nil
+}
+
+// read is call for the reads that can't fail, which report their error to
+// the log
+func (c *Client) read(req request) response {
+	res, err := c.call(req)
+	if err != nil {
+		c.log.Printf("Warning: %v", err)
+	}
+
+	return res
+}
+
+func (c *Client) Save(s session.Session) error {
+	_, err := c.call(request{Method: methodSave, Session: &s})
+	return err
+}
+
+func (c *Client) Delete(id string) error {
+	_, err := c.call(request{Method: methodDelete, Id: id})
+	return err
+}
+
+func (c *Client) FindById(id string) (*session.Session, error) {
+	res, err := c.call(request{Method: methodFindById, Id: id})
+	return res.Session, err
+}
+
+func (c *Client) FindLastSession() (*session.Session, error) {
+	res, err := c.call(request{Method: methodFindLastSession})
+	return res.Session, err
+}
+
+func (c *Client) FindAllSessions(sessionsFilters *application.SessionsFilters) []session.Session {
+	sessions := []session.Session{}
+	err := c.ForEachSession(sessionsFilters, func(s session.Session) error {
+		sessions = append(sessions, s)
+		return nil
+	})
+	if err != nil {
+		c.log.Printf("Warning: %v", err)
+	}
+
+	return sessions
+}
+
+// clientPageSize is the number of sessions ForEachSession asks the daemon
+// for at once
+const clientPageSize = 256
+
+// ForEachSession asks the daemon for the sessions by pages. The Where filter
+// is applied here, so its offset and limit are too. A session saved between
+// two pages may be given twice or not at all, like with the pages of the API.
+func (c *Client) ForEachSession(sessionsFilters *application.SessionsFilters, fn func(session.Session) error) error {
+	page := application.SessionsFilters{}
+	if sessionsFilters != nil {
+		page = *sessionsFilters
+	}
+
+	wanted := page
+	page.Where = nil
+	if wanted.Where == nil {
+		wanted.Offset = 0
+	} else {
+		page.Offset = 0
+	}
+
+	given := 0
+	for {
+		page.Limit = clientPageSize
+		if wanted.Where == nil && wanted.Limit > 0 {
+			page.Limit = min(clientPageSize, wanted.Limit-given)
+		}
+		if page.Limit <= 0 {
+			return nil
+		}
+
+		sessions, err := c.findPage(page)
+		if err != nil {
+			return err
+		}
+
+		for _, s := range sessions {
+			if wanted.Where != nil && !wanted.MatchWhere(s) {
+				continue
+			}
+			if wanted.Offset > 0 {
+				wanted.Offset--
+				continue
+			}
+
+			if err := fn(s); err != nil {
+				return err
+			}
+
+			given++
+			if wanted.Limit > 0 && given == wanted.Limit {
+				return nil
+			}
+		}
+
+		if len(sessions) < page.Limit {
+			return nil
+		}
+		page.Offset += len(sessions)
+	}
+}
+
+func (c *Client) findPage(page application.SessionsFilters) ([]session.Session, error) {
+	res, err := c.call(request{Method: methodFindAllSessions, Filters: &filters{
+		Timerange: page.Timerange,
+		Project:   page.Project,
+		Tags:      page.Tags,
+		TagsMatch: page.TagsMatch,
+		Order:     page.Order,
+		Offset:    page.Offset,
+		Limit:     page.Limit,
+	}})
+
+	return res.Sessions, err
+}
+
+func (c *Client) FindAllSessionMetadata(sessionsFilters *application.SessionsFilters) []application.SessionMetadata {
+	req := request{Method: methodFindAllSessionMetadata}
+	if sessionsFilters != nil {
+		req.Filters = &filters{
+			Timerange: sessionsFilters.Timerange,
+			Project:   sessionsFilters.Project,
+			Order:     sessionsFilters.Order,
+			Offset:    sessionsFilters.Offset,
+			Limit:     sessionsFilters.Limit,
+		}
+	}
+
+	metadata := c.read(req).Metadata
+	if metadata == nil {
+		return []application.SessionMetadata{}
+	}
+
+	return metadata
+}
+
+func (c *Client) FindAllProjects() []string {
+	return names(c.read(request{Method: methodFindAllProjects}))
+}
+
+func (c *Client) FindAllProjectTags(project string) []string {
+	return names(c.read(request{Method: methodFindAllProjectTags, Project: project}))
+}
+
+// names returns the names of the response, an empty list when there are none
+// like the other repositories
+func names(res response) []string {
+	if res.Names == nil {
+		return []string{}
+	}
+
+	return res.Names
+}
+
+func (c *Client) Acquire(active application.ActiveSession) (application.ActiveSession, error) {
+	res, err := c.call(request{Method: methodAcquire, Active: &active})
+	return res.Active, err
+}
+
+func (c *Client) Release(sessionId string) error {
+	_, err := c.call(request{Method: methodRelease, Id: sessionId})
+	return err
+}
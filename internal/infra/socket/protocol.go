@@ -0,0 +1,84 @@
+package socket
+
+import (
+	"path/filepath"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/pkg/timerange"
+)
+
+// Filename is the socket 'flow daemon' listens on, in the flow folder. It
+// starts with a dot so that it's never taken for a session file.
+const Filename = ".daemon.sock"
+
+func Path(flowFolder string) string {
+	return filepath.Join(flowFolder, Filename)
+}
+
+// The methods of the protocol, one per method of the session repository and
+// of the active session lock. A connection sends a JSON request per line and
+// gets a JSON response per line.
+const (
+	methodSave            = "save"
+	methodDelete          = "delete"
+	methodFindById        = "find_by_id"
+	methodFindLastSession = "find_last_session"
+	methodFindAllSessions = "find_all_sessions"
+	// methodFindAllSessionMetadata isn't understood by the daemons started
+	// before it, their clients get an "unknown method" error
+	methodFindAllSessionMetadata = "find_all_session_metadata"
+	methodFindAllProjects        = "find_all_projects"
+	methodFindAllProjectTags     = "find_all_project_tags"
+	methodAcquire                = "acquire"
+	methodRelease                = "release"
+)
+
+type request struct {
+	Session *session.Session           `json:"session,omitempty"`
+	Filters *filters                   `json:"filters,omitempty"`
+	Active  *application.ActiveSession `json:"active,omitempty"`
+	Method  string                     `json:"method"`
+	Id      string                     `json:"id,omitempty"`
+	Project string                     `json:"project,omitempty"`
+}
+
+// filters are the application.SessionsFilters without the where expression,
+// which the client applies to the sessions it gets, along with the offset and
+// the limit when there is one
+type filters struct {
+	Timerange timerange.TimeRange `json:"timerange"`
+	Project   string              `json:"project,omitempty"`
+	TagsMatch string              `json:"tags_match,omitempty"`
+	Tags      []string            `json:"tags,omitempty"`
+	Order     string              `json:"order,omitempty"`
+	Offset    int                 `json:"offset,omitempty"`
+	Limit     int                 `json:"limit,omitempty"`
+}
+
+func (f *filters) sessionsFilters() *application.SessionsFilters {
+	if f == nil {
+		return nil
+	}
+
+	return &application.SessionsFilters{
+		Timerange: f.Timerange,
+		Project:   f.Project,
+		Tags:      f.Tags,
+		TagsMatch: f.TagsMatch,
+		Order:     f.Order,
+		Offset:    f.Offset,
+		Limit:     f.Limit,
+	}
+}
+
+type response struct {
+	Session  *session.Session              `json:"session,omitempty"`
+	Error    string                        `json:"error,omitempty"`
+	Sessions []session.Session             `json:"sessions,omitempty"`
+	Names    []string                      `json:"names,omitempty"`
+	Metadata []application.SessionMetadata `json:"metadata,omitempty"`
+	Active   application.ActiveSession     `json:"active"`
+	// Locked tells that the error is application.ErrActiveSessionLocked
+	Locked bool `json:"locked,omitempty"`
+}
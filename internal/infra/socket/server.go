@@ -0,0 +1,157 @@
+package socket
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+
+	"github.com/TristanShz/flow/internal/application"
+)
+
+// Server owns the sessions and the active session of the flow folder while
+// 'flow daemon' runs, the flow commands reach them through its socket
+type Server struct {
+	Repository application.SessionRepository
+	Lock       application.ActiveSessionLock
+	Path       string
+	// mutex serializes the requests of the clients, like the files of the
+	// flow folder are written one at a time
+	mutex sync.Mutex
+}
+
+func NewServer(path string, repository application.SessionRepository, lock application.ActiveSessionLock) *Server {
+	return &Server{Path: path, Repository: repository, Lock: lock}
+}
+
+// Serve listens on the socket until the context is done, it fails when
+// another daemon is serving the flow folder already
+func (s *Server) Serve(ctx context.Context) error {
+	listener, err := s.listen()
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+
+	connections := sync.WaitGroup{}
+	defer connections.Wait()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		connections.Add(1)
+		go func() {
+			defer connections.Done()
+			s.serveConn(ctx, conn)
+		}()
+	}
+}
+
+// listen removes the socket left by a daemon which didn't stop cleanly,
+// unless it's still answering
+func (s *Server) listen() (net.Listener, error) {
+	if conn, err := net.DialTimeout("unix", s.Path, dialTimeout); err == nil {
+		conn.Close()
+		return nil, fmt.Errorf("a daemon is already serving the sessions on %v", s.Path)
+	}
+
+	if err := os.Remove(s.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	return net.Listen("unix", s.Path)
+}
+
+func (s *Server) serveConn(ctx context.Context, conn net.Conn) {
+	done := make(chan struct{})
+	defer close(done)
+	defer conn.Close()
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(nil, maxMessageSize)
+	encoder := json.NewEncoder(conn)
+
+	for scanner.Scan() {
+		var req request
+		res := response{}
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			res.Error = fmt.Sprintf("invalid request: %v", err)
+		} else {
+			res = s.handle(req)
+		}
+
+		if err := encoder.Encode(res); err != nil {
+			return
+		}
+	}
+}
+
+func (s *Server) handle(req request) response {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	res := response{}
+	var err error
+
+	switch req.Method {
+	case methodSave:
+		if req.Session == nil {
+			err = errors.New("the session to save is missing")
+			break
+		}
+		err = s.Repository.Save(*req.Session)
+	case methodDelete:
+		err = s.Repository.Delete(req.Id)
+	case methodFindById:
+		res.Session, err = s.Repository.FindById(req.Id)
+	case methodFindLastSession:
+		res.Session, err = s.Repository.FindLastSession()
+	case methodFindAllSessions:
+		res.Sessions = s.Repository.FindAllSessions(req.Filters.sessionsFilters())
+	case methodFindAllSessionMetadata:
+		res.Metadata = s.Repository.FindAllSessionMetadata(req.Filters.sessionsFilters())
+	case methodFindAllProjects:
+		res.Names = s.Repository.FindAllProjects()
+	case methodFindAllProjectTags:
+		res.Names = s.Repository.FindAllProjectTags(req.Project)
+	case methodAcquire:
+		if req.Active == nil {
+			err = errors.New("the active session to acquire is missing")
+			break
+		}
+		res.Active, err = s.Lock.Acquire(*req.Active)
+	case methodRelease:
+		err = s.Lock.Release(req.Id)
+	default:
+		err = fmt.Errorf("unknown method %v", req.Method)
+	}
+
+	if err != nil {
+		res.Error = err.Error()
+		res.Locked = errors.Is(err, application.ErrActiveSessionLocked)
+	}
+
+	return res
+}
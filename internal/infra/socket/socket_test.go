@@ -0,0 +1,219 @@
+package socket_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/TristanShz/flow/internal/infra/socket"
+	"github.com/matryer/is"
+)
+
+// serve runs a server of the repository and the lock until the test ends,
+// and returns a client connected to it
+func serve(t *testing.T, repository application.SessionRepository, lock application.ActiveSessionLock) *socket.Client {
+	t.Helper()
+
+	path := socket.Path(t.TempDir())
+	server := socket.NewServer(path, repository, lock)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() { served <- server.Serve(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-served; err != nil {
+			t.Errorf("serve: %v", err)
+		}
+	})
+
+	for start := time.Now(); time.Since(start) < time.Second; time.Sleep(10 * time.Millisecond) {
+		client, err := socket.Dial(path, log.New(io.Discard, "", 0))
+		if err == nil {
+			t.Cleanup(func() { client.Close() })
+			return client
+		}
+	}
+
+	t.Fatal("the server isn't listening")
+	return nil
+}
+
+func TestClient_SessionRepository(t *testing.T) {
+	is := is.New(t)
+
+	startTime := time.Date(2024, time.April, 13, 9, 0, 0, 0, time.UTC)
+	flowing := session.Session{Id: "1", StartTime: startTime, Project: "Flow", Tags: []string{"cli"}}
+	other := session.Session{Id: "2", StartTime: startTime.Add(-24 * time.Hour), EndTime: startTime.Add(-23 * time.Hour), Project: "Acme", Tags: []string{"api"}}
+
+	repository := &infra.InMemorySessionRepository{Sessions: []session.Session{other}}
+	client := serve(t, repository, &infra.InMemoryActiveSessionLock{})
+
+	is.NoErr(client.Save(flowing))
+	is.Equal(repository.Sessions, []session.Session{other, flowing}) // saved by the server
+
+	found, err := client.FindById("1")
+	is.NoErr(err)
+	is.Equal(found.Project, "Flow")
+	found, err = client.FindById("3")
+	is.NoErr(err)
+	is.Equal(found, nil)
+	found, err = client.FindLastSession()
+	is.NoErr(err)
+	is.Equal(found.Id, "1")
+	is.Equal(client.FindAllProjects(), []string{"Acme", "Flow"})
+	is.Equal(client.FindAllProjectTags("Flow"), []string{"cli"})
+	is.Equal(len(client.FindAllSessions(nil)), 2)
+
+	where, err := application.ParseWhere("project = Acme")
+	is.NoErr(err)
+	sessions := client.FindAllSessions(&application.SessionsFilters{Where: where})
+	is.Equal(len(sessions), 1) // the where expression is applied by the client
+	is.Equal(sessions[0].Id, "2")
+
+	sessions = client.FindAllSessions(&application.SessionsFilters{Order: application.OrderDescending, Limit: 1})
+	is.Equal(len(sessions), 1)
+	is.Equal(sessions[0].Id, "1") // the newest session
+
+	where, err = application.ParseWhere("duration >= 0")
+	is.NoErr(err)
+	sessions = client.FindAllSessions(&application.SessionsFilters{Where: where, Offset: 1})
+	is.Equal(len(sessions), 1) // the offset is applied after the where expression
+	is.Equal(sessions[0].Id, "1")
+
+	metadata := client.FindAllSessionMetadata(&application.SessionsFilters{Order: application.OrderDescending})
+	is.Equal(metadata, []application.SessionMetadata{{Id: "1", Project: "Flow", StartTime: startTime}, {Id: "2", Project: "Acme", StartTime: startTime.Add(-24 * time.Hour)}})
+
+	is.NoErr(client.Delete("2"))
+	is.Equal(len(repository.Sessions), 1)
+}
+
+func TestClient_EmptyRepository(t *testing.T) {
+	is := is.New(t)
+
+	client := serve(t, &infra.InMemorySessionRepository{}, &infra.InMemoryActiveSessionLock{})
+
+	is.Equal(client.FindAllSessions(nil), []session.Session{})
+	is.Equal(client.FindAllSessionMetadata(nil), []application.SessionMetadata{})
+	is.Equal(client.FindAllProjects(), []string{})
+	is.Equal(client.FindAllProjectTags("Flow"), []string{})
+}
+
+func TestClient_ForEachSession(t *testing.T) {
+	is := is.New(t)
+
+	startTime := time.Date(2024, time.April, 13, 9, 0, 0, 0, time.UTC)
+	repository := &infra.InMemorySessionRepository{}
+	for i := range 600 {
+		repository.Sessions = append(repository.Sessions, session.Session{Id: fmt.Sprint(i), StartTime: startTime.Add(time.Duration(i) * time.Minute), Project: "Flow"})
+	}
+	client := serve(t, repository, &infra.InMemoryActiveSessionLock{})
+
+	given := []string{}
+	err := client.ForEachSession(nil, func(s session.Session) error {
+		given = append(given, s.Id)
+		return nil
+	})
+	is.NoErr(err)
+	is.Equal(len(given), 600) // all the pages
+	is.Equal(given[599], "599")
+
+	given = []string{}
+	err = client.ForEachSession(&application.SessionsFilters{Offset: 250, Limit: 300}, func(s session.Session) error {
+		given = append(given, s.Id)
+		return nil
+	})
+	is.NoErr(err)
+	is.Equal(len(given), 300)
+	is.Equal(given[0], "250")
+	is.Equal(given[299], "549")
+
+	where, err := application.ParseWhere("duration >= 0")
+	is.NoErr(err)
+	given = []string{}
+	err = client.ForEachSession(&application.SessionsFilters{Where: where, Offset: 500, Limit: 50}, func(s session.Session) error {
+		given = append(given, s.Id)
+		return nil
+	})
+	is.NoErr(err)
+	is.Equal(len(given), 50) // the offset and the limit of a where expression span the pages
+	is.Equal(given[0], "500")
+}
+
+func TestClient_DeadDaemon(t *testing.T) {
+	is := is.New(t)
+
+	// a daemon which hangs up on every request
+	path := socket.Path(t.TempDir())
+	listener, err := net.Listen("unix", path)
+	is.NoErr(err)
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	client, err := socket.Dial(path, log.New(io.Discard, "", 0))
+	is.NoErr(err)
+	defer client.Close()
+
+	_, err = client.FindLastSession()
+	is.True(err != nil) // not taken for the absence of a current session
+	_, err = client.FindById("1")
+	is.True(err != nil)
+	err = client.ForEachSession(nil, func(session.Session) error { return nil })
+	is.True(err != nil)
+}
+
+func TestClient_ActiveSessionLock(t *testing.T) {
+	is := is.New(t)
+
+	lock := &infra.InMemoryActiveSessionLock{}
+	client := serve(t, &infra.InMemorySessionRepository{}, lock)
+
+	acquiredAt := time.Date(2024, time.April, 13, 9, 0, 0, 0, time.UTC)
+	active, err := client.Acquire(application.ActiveSession{SessionId: "1", AcquiredAt: acquiredAt})
+	is.NoErr(err)
+	is.Equal(active.SessionId, "1")
+
+	active, err = client.Acquire(application.ActiveSession{SessionId: "2", AcquiredAt: acquiredAt})
+	is.Equal(err, application.ErrActiveSessionLocked)
+	is.Equal(active.SessionId, "1") // the session already active
+
+	is.NoErr(client.Release("1"))
+	is.Equal(lock.Active, nil)
+}
+
+func TestServer_AlreadyServed(t *testing.T) {
+	is := is.New(t)
+
+	path := socket.Path(t.TempDir())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	first := socket.NewServer(path, &infra.InMemorySessionRepository{}, &infra.InMemoryActiveSessionLock{})
+	go first.Serve(ctx)
+	for start := time.Now(); time.Since(start) < time.Second; time.Sleep(10 * time.Millisecond) {
+		if client, err := socket.Dial(path, log.New(io.Discard, "", 0)); err == nil {
+			client.Close()
+			break
+		}
+	}
+
+	second := socket.NewServer(path, &infra.InMemorySessionRepository{}, &infra.InMemoryActiveSessionLock{})
+	err := second.Serve(ctx)
+
+	is.True(err != nil) // a second daemon doesn't take the socket over
+}
@@ -0,0 +1,16 @@
+package infra
+
+import (
+	"context"
+	"time"
+)
+
+// StubActivityDetector returns its last activity, or its error
+type StubActivityDetector struct {
+	Last time.Time
+	Err  error
+}
+
+func (d StubActivityDetector) LastActivity(ctx context.Context, before time.Time) (time.Time, error) {
+	return d.Last, d.Err
+}
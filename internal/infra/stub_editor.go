@@ -0,0 +1,28 @@
+package infra
+
+import "os"
+
+// StubEditor writes its contents to the edited file, one per edit, like a
+// user saving them in turn. The file is left unchanged once they're all
+// written.
+type StubEditor struct {
+	Contents []string
+	Err      error
+	Edits    int
+}
+
+func (e *StubEditor) Edit(path string) error {
+	if e.Err != nil {
+		return e.Err
+	}
+
+	e.Edits++
+	if len(e.Contents) == 0 {
+		return nil
+	}
+
+	content := e.Contents[0]
+	e.Contents = e.Contents[1:]
+
+	return os.WriteFile(path, []byte(content), 0644)
+}
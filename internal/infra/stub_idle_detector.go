@@ -0,0 +1,16 @@
+package infra
+
+import (
+	"context"
+	"time"
+)
+
+// StubIdleDetector returns its idle time, or its error
+type StubIdleDetector struct {
+	Idle time.Duration
+	Err  error
+}
+
+func (d StubIdleDetector) IdleTime(ctx context.Context) (time.Duration, error) {
+	return d.Idle, d.Err
+}
@@ -0,0 +1,24 @@
+package infra
+
+import (
+	"context"
+
+	"github.com/TristanShz/flow/internal/application"
+)
+
+// StubLockWatcher sends its events and returns
+type StubLockWatcher struct {
+	Events []application.LockEvent
+}
+
+func (w *StubLockWatcher) Watch(ctx context.Context, events chan<- application.LockEvent) error {
+	for _, event := range w.Events {
+		select {
+		case events <- event:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,24 @@
+package infra
+
+import (
+	"context"
+
+	"github.com/TristanShz/flow/internal/application"
+)
+
+// StubMeetingWatcher sends its events and returns
+type StubMeetingWatcher struct {
+	Events []application.MeetingEvent
+}
+
+func (w *StubMeetingWatcher) Watch(ctx context.Context, events chan<- application.MeetingEvent) error {
+	for _, event := range w.Events {
+		select {
+		case events <- event:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,18 @@
+package infra
+
+import (
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+)
+
+type StubSessionArchiver struct {
+	Months []application.ArchivedMonth
+	// Before is the time the last call to Archive was given
+	Before time.Time
+}
+
+func (s *StubSessionArchiver) Archive(before time.Time) ([]application.ArchivedMonth, error) {
+	s.Before = before
+	return s.Months, nil
+}
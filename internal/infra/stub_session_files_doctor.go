@@ -0,0 +1,17 @@
+package infra
+
+import "github.com/TristanShz/flow/internal/application"
+
+// StubSessionFilesDoctor repairs invalid filenames and quarantines corrupted
+// data, like the filesystem repository does
+type StubSessionFilesDoctor struct {
+	Issues []application.SessionFileIssue
+}
+
+func (s *StubSessionFilesDoctor) Diagnose() []application.SessionFileIssue {
+	return s.Issues
+}
+
+func (s *StubSessionFilesDoctor) Repair(issue application.SessionFileIssue) (bool, error) {
+	return issue.Kind == application.CorruptedDataIssue, nil
+}
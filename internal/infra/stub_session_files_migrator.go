@@ -0,0 +1,15 @@
+package infra
+
+type StubSessionFilesMigrator struct {
+	LegacyFiles []string
+	Migrated    []string
+}
+
+func (s *StubSessionFilesMigrator) FindLegacyFiles() []string {
+	return s.LegacyFiles
+}
+
+func (s *StubSessionFilesMigrator) Migrate(filename string) error {
+	s.Migrated = append(s.Migrated, filename)
+	return nil
+}
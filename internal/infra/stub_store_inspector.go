@@ -0,0 +1,11 @@
+package infra
+
+import "github.com/TristanShz/flow/internal/application"
+
+type StubStoreInspector struct {
+	StoreInfo application.StoreInfo
+}
+
+func (s *StubStoreInspector) Info() (application.StoreInfo, error) {
+	return s.StoreInfo, nil
+}
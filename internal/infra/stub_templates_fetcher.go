@@ -0,0 +1,21 @@
+package infra
+
+import (
+	"fmt"
+
+	"github.com/TristanShz/flow/internal/domain/project"
+)
+
+// StubTemplatesFetcher returns the templates of the known sources
+type StubTemplatesFetcher struct {
+	Templates map[string]project.Templates
+}
+
+func (s *StubTemplatesFetcher) Fetch(source string) (project.Templates, error) {
+	templates, ok := s.Templates[source]
+	if !ok {
+		return project.Templates{}, fmt.Errorf("can't fetch the templates from %v", source)
+	}
+
+	return templates, nil
+}
@@ -0,0 +1,66 @@
+package system
+
+import (
+	"encoding/hex"
+	"errors"
+	"os/exec"
+	"strings"
+
+	"github.com/TristanShz/flow/internal/application"
+)
+
+// CommandClipboard copies the text by writing it to the input of a command
+type CommandClipboard struct {
+	Name string
+	Args []string
+}
+
+func (c CommandClipboard) Copy(content application.ClipboardContent) error {
+	command := exec.Command(c.Name, c.Args...)
+	command.Stdin = strings.NewReader(content.Text)
+
+	return command.Run()
+}
+
+// FirstClipboard copies with the first of its clipboards able to, e.g. the
+// first one whose tool is installed
+type FirstClipboard []application.Clipboard
+
+func (f FirstClipboard) Copy(content application.ClipboardContent) error {
+	errs := []error{application.ErrClipboardUnsupported}
+	for _, clipboard := range f {
+		err := clipboard.Copy(content)
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// UnsupportedClipboard is the clipboard of the systems flow can't write to
+type UnsupportedClipboard struct{}
+
+func (c UnsupportedClipboard) Copy(content application.ClipboardContent) error {
+	return application.ErrClipboardUnsupported
+}
+
+// MacClipboard copies the text with pbcopy, and both the text and the HTML
+// with osascript when there is HTML
+type MacClipboard struct{}
+
+func (c MacClipboard) Copy(content application.ClipboardContent) error {
+	if content.HTML == "" {
+		return CommandClipboard{Name: "pbcopy"}.Copy(content)
+	}
+
+	return exec.Command("osascript", "-e", AppleScriptClipboard(content)).Run()
+}
+
+// AppleScriptClipboard returns the script setting the clipboard to the text
+// and the HTML, both given as hexadecimal data so that nothing needs escaping
+func AppleScriptClipboard(content application.ClipboardContent) string {
+	return "set the clipboard to {«class utf8»:«data utf8" + strings.ToUpper(hex.EncodeToString([]byte(content.Text))) + "», " +
+		"«class HTML»:«data HTML" + strings.ToUpper(hex.EncodeToString([]byte(content.HTML))) + "»}"
+}
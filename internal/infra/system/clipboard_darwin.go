@@ -0,0 +1,9 @@
+//go:build darwin
+
+package system
+
+import "github.com/TristanShz/flow/internal/application"
+
+func NewClipboard() application.Clipboard {
+	return MacClipboard{}
+}
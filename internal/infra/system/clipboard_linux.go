@@ -0,0 +1,24 @@
+//go:build linux
+
+package system
+
+import (
+	"os"
+
+	"github.com/TristanShz/flow/internal/application"
+)
+
+// NewClipboard copies the text with wl-copy on Wayland, or xclip or xsel on
+// X11. They set a single format at once, so the HTML is left out.
+func NewClipboard() application.Clipboard {
+	x11 := FirstClipboard{
+		CommandClipboard{Name: "xclip", Args: []string{"-selection", "clipboard"}},
+		CommandClipboard{Name: "xsel", Args: []string{"--clipboard", "--input"}},
+	}
+
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		return append(FirstClipboard{CommandClipboard{Name: "wl-copy"}}, x11...)
+	}
+
+	return x11
+}
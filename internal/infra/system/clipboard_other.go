@@ -0,0 +1,9 @@
+//go:build !linux && !darwin && !windows
+
+package system
+
+import "github.com/TristanShz/flow/internal/application"
+
+func NewClipboard() application.Clipboard {
+	return UnsupportedClipboard{}
+}
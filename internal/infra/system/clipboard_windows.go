@@ -0,0 +1,14 @@
+//go:build windows
+
+package system
+
+import "github.com/TristanShz/flow/internal/application"
+
+// NewClipboard copies the text with PowerShell, reading its input as UTF-8
+// unlike clip.exe
+func NewClipboard() application.Clipboard {
+	return CommandClipboard{
+		Name: "powershell",
+		Args: []string{"-NoProfile", "-Command", "[Console]::InputEncoding = [Text.Encoding]::UTF8; Set-Clipboard -Value ([Console]::In.ReadToEnd())"},
+	}
+}
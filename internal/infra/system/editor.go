@@ -0,0 +1,36 @@
+package system
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// CommandEditor edits the files with the editor of $VISUAL or $EDITOR, which
+// may have arguments like "code --wait", or else with notepad on Windows and
+// nano elsewhere
+type CommandEditor struct {
+	Getenv func(string) string
+}
+
+func (e CommandEditor) Edit(path string) error {
+	name, args := "nano", []string{}
+	if runtime.GOOS == "windows" {
+		name = "notepad"
+	}
+
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if fields := strings.Fields(e.Getenv(env)); len(fields) > 0 {
+			name, args = fields[0], fields[1:]
+			break
+		}
+	}
+
+	command := exec.Command(name, append(args, path)...)
+	command.Stdin = os.Stdin
+	command.Stdout = os.Stdout
+	command.Stderr = os.Stderr
+
+	return command.Run()
+}
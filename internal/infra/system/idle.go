@@ -0,0 +1,119 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+)
+
+// CommandIdleDetector reads the idle time from the output of a command
+type CommandIdleDetector struct {
+	Name  string
+	Args  []string
+	Parse func(output string) (time.Duration, error)
+}
+
+func (d CommandIdleDetector) IdleTime(ctx context.Context) (time.Duration, error) {
+	output, err := exec.CommandContext(ctx, d.Name, d.Args...).Output()
+	if err != nil {
+		return 0, err
+	}
+
+	return d.Parse(string(output))
+}
+
+// FirstIdleDetector returns the idle time of the first of its detectors able
+// to read it, e.g. the first one whose tool is installed
+type FirstIdleDetector []application.IdleDetector
+
+func (f FirstIdleDetector) IdleTime(ctx context.Context) (time.Duration, error) {
+	errs := []error{application.ErrIdleUnsupported}
+	for _, detector := range f {
+		idle, err := detector.IdleTime(ctx)
+		if err == nil {
+			return idle, nil
+		}
+		errs = append(errs, err)
+	}
+
+	return 0, errors.Join(errs...)
+}
+
+// UnsupportedIdleDetector is the detector of the systems whose idle time
+// can't be read
+type UnsupportedIdleDetector struct{}
+
+func (d UnsupportedIdleDetector) IdleTime(ctx context.Context) (time.Duration, error) {
+	return 0, application.ErrIdleUnsupported
+}
+
+// xprintidleDetector reads the idle time of X11
+var xprintidleDetector = CommandIdleDetector{Name: "xprintidle", Parse: ParseXprintidle}
+
+// mutterIdleDetector reads the idle time of GNOME, which works on Wayland
+var mutterIdleDetector = CommandIdleDetector{
+	Name: "gdbus",
+	Args: []string{
+		"call", "--session",
+		"--dest", "org.gnome.Mutter.IdleMonitor",
+		"--object-path", "/org/gnome/Mutter/IdleMonitor/Core",
+		"--method", "org.gnome.Mutter.IdleMonitor.GetIdletime",
+	},
+	Parse: ParseMutterIdletime,
+}
+
+// ioregIdleDetector reads the idle time of macOS from the HID system
+var ioregIdleDetector = CommandIdleDetector{Name: "ioreg", Args: []string{"-c", "IOHIDSystem", "-d", "4"}, Parse: ParseIoregIdleTime}
+
+// ParseXprintidle reads the milliseconds printed by xprintidle
+func ParseXprintidle(output string) (time.Duration, error) {
+	milliseconds, err := strconv.ParseInt(strings.TrimSpace(output), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected output of xprintidle: %v", strings.TrimSpace(output))
+	}
+
+	return time.Duration(milliseconds) * time.Millisecond, nil
+}
+
+var mutterIdletimePattern = regexp.MustCompile(`\(uint64 (\d+),\)`)
+
+// ParseMutterIdletime reads the milliseconds returned by the GetIdletime
+// method of GNOME, like (uint64 12345,)
+func ParseMutterIdletime(output string) (time.Duration, error) {
+	match := mutterIdletimePattern.FindStringSubmatch(output)
+	if match == nil {
+		return 0, fmt.Errorf("unexpected output of the idle monitor of GNOME: %v", strings.TrimSpace(output))
+	}
+
+	milliseconds, err := strconv.ParseInt(match[1], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(milliseconds) * time.Millisecond, nil
+}
+
+var ioregIdleTimePattern = regexp.MustCompile(`"HIDIdleTime" = (\d+)`)
+
+// ParseIoregIdleTime reads the nanoseconds of the HIDIdleTime property in the
+// output of ioreg -c IOHIDSystem
+func ParseIoregIdleTime(output string) (time.Duration, error) {
+	match := ioregIdleTimePattern.FindStringSubmatch(output)
+	if match == nil {
+		return 0, errors.New("the HIDIdleTime property isn't in the output of ioreg")
+	}
+
+	nanoseconds, err := strconv.ParseInt(match[1], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(nanoseconds), nil
+}
@@ -0,0 +1,19 @@
+//go:build linux
+
+package system
+
+import (
+	"os"
+
+	"github.com/TristanShz/flow/internal/application"
+)
+
+// NewIdleDetector reads the idle time of GNOME first on Wayland, where
+// xprintidle only sees the X11 applications
+func NewIdleDetector() application.IdleDetector {
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		return FirstIdleDetector{mutterIdleDetector, xprintidleDetector}
+	}
+
+	return FirstIdleDetector{xprintidleDetector, mutterIdleDetector}
+}
@@ -0,0 +1,44 @@
+//go:build windows
+
+package system
+
+import (
+	"context"
+	"syscall"
+	"time"
+	"unsafe"
+
+	"github.com/TristanShz/flow/internal/application"
+)
+
+var (
+	user32               = syscall.NewLazyDLL("user32.dll")
+	kernel32             = syscall.NewLazyDLL("kernel32.dll")
+	procGetLastInputInfo = user32.NewProc("GetLastInputInfo")
+	procGetTickCount     = kernel32.NewProc("GetTickCount")
+)
+
+type lastInputInfo struct {
+	size uint32
+	time uint32
+}
+
+// WindowsIdleDetector compares the tick count of the last input of the
+// session with the current one
+type WindowsIdleDetector struct{}
+
+func (d WindowsIdleDetector) IdleTime(ctx context.Context) (time.Duration, error) {
+	info := lastInputInfo{size: uint32(unsafe.Sizeof(lastInputInfo{}))}
+	if ok, _, err := procGetLastInputInfo.Call(uintptr(unsafe.Pointer(&info))); ok == 0 {
+		return 0, err
+	}
+
+	ticks, _, _ := procGetTickCount.Call()
+
+	// both tick counts wrap around after 49 days, the difference doesn't
+	return time.Duration(uint32(ticks)-info.time) * time.Millisecond, nil
+}
+
+func NewIdleDetector() application.IdleDetector {
+	return WindowsIdleDetector{}
+}
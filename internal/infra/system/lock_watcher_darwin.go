@@ -0,0 +1,12 @@
+//go:build darwin
+
+package system
+
+import "github.com/TristanShz/flow/internal/application"
+
+func NewLockWatcher() application.LockWatcher {
+	return MultiWatcher{
+		PollWatcher{IsLocked: IsMacScreenLocked, Interval: DefaultPollInterval},
+		SleepWatcher{Interval: DefaultSleepCheckInterval},
+	}
+}
@@ -0,0 +1,12 @@
+//go:build linux
+
+package system
+
+import "github.com/TristanShz/flow/internal/application"
+
+func NewLockWatcher() application.LockWatcher {
+	return MultiWatcher{
+		LogindWatcher{},
+		SleepWatcher{Interval: DefaultSleepCheckInterval},
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !linux && !darwin && !windows
+
+package system
+
+import "github.com/TristanShz/flow/internal/application"
+
+func NewLockWatcher() application.LockWatcher {
+	return SleepWatcher{Interval: DefaultSleepCheckInterval}
+}
@@ -0,0 +1,12 @@
+//go:build windows
+
+package system
+
+import "github.com/TristanShz/flow/internal/application"
+
+func NewLockWatcher() application.LockWatcher {
+	return MultiWatcher{
+		PollWatcher{IsLocked: IsWindowsScreenLocked, Interval: DefaultPollInterval},
+		SleepWatcher{Interval: DefaultSleepCheckInterval},
+	}
+}
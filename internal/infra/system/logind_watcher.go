@@ -0,0 +1,64 @@
+package system
+
+import (
+	"bufio"
+	"context"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+)
+
+// LogindWatcher listens to the signals of systemd-logind with gdbus, which
+// covers loginctl lock-session, desktops reporting the lock with LockedHint,
+// closing the lid and suspending.
+type LogindWatcher struct{}
+
+func (w LogindWatcher) Watch(ctx context.Context, events chan<- application.LockEvent) error {
+	command := exec.CommandContext(ctx, "gdbus", "monitor", "--system", "--dest", "org.freedesktop.login1")
+
+	stdout, err := command.StdoutPipe()
+	if err != nil {
+		return err
+	}
+
+	if err := command.Start(); err != nil {
+		return err
+	}
+
+	scanner := bufio.NewScanner(stdout)
+	for scanner.Scan() {
+		if locked, ok := ParseLogindSignal(scanner.Text()); ok {
+			send(ctx, events, application.LockEvent{Locked: locked, At: time.Now()})
+		}
+	}
+
+	err = command.Wait()
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	return err
+}
+
+// ParseLogindSignal reads a line printed by gdbus monitor, ok is false when
+// the line isn't about locking or sleeping
+func ParseLogindSignal(line string) (locked bool, ok bool) {
+	switch {
+	case strings.Contains(line, "org.freedesktop.login1.Session.Lock ()"):
+		return true, true
+	case strings.Contains(line, "org.freedesktop.login1.Session.Unlock ()"):
+		return false, true
+	case strings.Contains(line, "org.freedesktop.login1.Manager.PrepareForSleep (true"):
+		return true, true
+	case strings.Contains(line, "org.freedesktop.login1.Manager.PrepareForSleep (false"):
+		return false, true
+	case strings.Contains(line, "'LockedHint': <true>"):
+		return true, true
+	case strings.Contains(line, "'LockedHint': <false>"):
+		return false, true
+	}
+
+	return false, false
+}
@@ -0,0 +1,30 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/TristanShz/flow/internal/application"
+)
+
+// MultiWatcher sends the events of all its watchers. A watcher failing, e.g.
+// because the tool it relies on isn't installed, doesn't stop the others.
+type MultiWatcher []application.LockWatcher
+
+func (m MultiWatcher) Watch(ctx context.Context, events chan<- application.LockEvent) error {
+	var wg sync.WaitGroup
+	errs := make([]error, len(m))
+
+	for i, watcher := range m {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = watcher.Watch(ctx, events)
+		}()
+	}
+
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,44 @@
+package system
+
+import (
+	"context"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+)
+
+// DefaultPollInterval is the time between two checks of the PollWatcher
+const DefaultPollInterval = 5 * time.Second
+
+// PollWatcher checks if the screen is locked at every interval and sends an
+// event whenever it changes, for systems that don't notify it
+type PollWatcher struct {
+	IsLocked func(ctx context.Context) (bool, error)
+	Interval time.Duration
+}
+
+func (w PollWatcher) Watch(ctx context.Context, events chan<- application.LockEvent) error {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+
+	locked := false
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			isLocked, err := w.IsLocked(ctx)
+			if ctx.Err() != nil {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			if isLocked != locked {
+				locked = isLocked
+				send(ctx, events, application.LockEvent{Locked: locked, At: time.Now()})
+			}
+		}
+	}
+}
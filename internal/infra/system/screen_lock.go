@@ -0,0 +1,38 @@
+package system
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+)
+
+// IsMacScreenLocked reads the lock state of the screen from the session
+// properties of the IO registry
+func IsMacScreenLocked(ctx context.Context) (bool, error) {
+	output, err := exec.CommandContext(ctx, "ioreg", "-n", "Root", "-d1").Output()
+	if err != nil {
+		return false, err
+	}
+
+	return ParseIoregLocked(string(output)), nil
+}
+
+// IsWindowsScreenLocked tells if the lock screen process is running
+func IsWindowsScreenLocked(ctx context.Context) (bool, error) {
+	output, err := exec.CommandContext(ctx, "tasklist", "/FI", "IMAGENAME eq LogonUI.exe", "/NH").Output()
+	if err != nil {
+		return false, err
+	}
+
+	return ParseTasklistLocked(string(output)), nil
+}
+
+// ParseIoregLocked reads the output of ioreg -n Root -d1
+func ParseIoregLocked(output string) bool {
+	return strings.Contains(output, `"CGSSessionScreenIsLocked"=Yes`)
+}
+
+// ParseTasklistLocked reads the output of tasklist filtered on LogonUI.exe
+func ParseTasklistLocked(output string) bool {
+	return strings.Contains(strings.ToLower(output), "logonui.exe")
+}
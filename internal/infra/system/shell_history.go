@@ -0,0 +1,137 @@
+package system
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// historyTailSize is how much of the end of a history file is read for the
+// times of its last commands
+const historyTailSize = 64 << 10
+
+// historyTimePatterns match the times of the commands in the histories
+// keeping them: the extended history of zsh (": 1713000000:0;make"), the
+// history of fish ("  when: 1713000000") and the history of bash with
+// HISTTIMEFORMAT set ("#1713000000")
+var historyTimePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?m)^: (\d{9,}):\d+;`),
+	regexp.MustCompile(`(?m)^\s+when: (\d{9,})$`),
+	regexp.MustCompile(`(?m)^#(\d{9,})$`),
+}
+
+// ShellHistoryActivityDetector tells when the user last ran a command in a
+// terminal, from the history files of their shells: the times of the
+// commands when the history keeps them, or else when the file was last
+// written
+type ShellHistoryActivityDetector struct {
+	Files []string
+}
+
+// NewShellHistoryActivityDetector reads the history file of $HISTFILE and
+// the default history files of bash, zsh, fish and PowerShell
+func NewShellHistoryActivityDetector(home string, getenv func(string) string) ShellHistoryActivityDetector {
+	files := []string{}
+	if histfile := getenv("HISTFILE"); histfile != "" {
+		files = append(files, histfile)
+	}
+
+	files = append(files,
+		filepath.Join(home, ".bash_history"),
+		filepath.Join(home, ".zsh_history"),
+		filepath.Join(home, ".zhistory"),
+		filepath.Join(home, ".local", "share", "fish", "fish_history"),
+		filepath.Join(home, ".local", "share", "powershell", "PSReadLine", "ConsoleHost_history.txt"),
+	)
+	if appData := getenv("APPDATA"); appData != "" {
+		files = append(files, filepath.Join(appData, "Microsoft", "Windows", "PowerShell", "PSReadLine", "ConsoleHost_history.txt"))
+	}
+
+	return ShellHistoryActivityDetector{Files: files}
+}
+
+func (d ShellHistoryActivityDetector) LastActivity(ctx context.Context, before time.Time) (time.Time, error) {
+	last := time.Time{}
+
+	for _, path := range d.Files {
+		activity, err := historyActivity(path, before)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return time.Time{}, err
+		}
+
+		if activity.After(last) {
+			last = activity
+		}
+	}
+
+	return last, nil
+}
+
+// historyActivity returns the time of the last command of the history file
+// before the given time
+func historyActivity(path string, before time.Time) (time.Time, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if info.Size() > historyTailSize {
+		if _, err := file.Seek(-historyTailSize, io.SeekEnd); err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	tail, err := io.ReadAll(file)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if times := ParseHistoryTimes(string(tail)); len(times) > 0 {
+		last := time.Time{}
+		for _, t := range times {
+			if t.Before(before) && t.After(last) {
+				last = t
+			}
+		}
+		return last, nil
+	}
+
+	// the file is written by the command being run, e.g. with the
+	// INC_APPEND_HISTORY option of zsh, the previous command is unknown
+	if !info.ModTime().Before(before) {
+		return time.Time{}, nil
+	}
+
+	return info.ModTime(), nil
+}
+
+// ParseHistoryTimes returns the times of the commands of a shell history
+// keeping them
+func ParseHistoryTimes(history string) []time.Time {
+	times := []time.Time{}
+
+	for _, pattern := range historyTimePatterns {
+		for _, match := range pattern.FindAllStringSubmatch(history, -1) {
+			seconds, err := strconv.ParseInt(match[1], 10, 64)
+			if err != nil {
+				continue
+			}
+			times = append(times, time.Unix(seconds, 0))
+		}
+	}
+
+	return times
+}
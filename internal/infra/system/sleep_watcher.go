@@ -0,0 +1,53 @@
+package system
+
+import (
+	"context"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+)
+
+// DefaultSleepCheckInterval is the time between two checks of the SleepWatcher
+const DefaultSleepCheckInterval = 10 * time.Second
+
+// SleepWatcher detects that the system went to sleep from the gaps in the wall
+// clock between two ticks, as tickers don't fire while the system sleeps. It
+// works on every system but only notices the sleep once it's over, so both
+// events are sent on wake up.
+type SleepWatcher struct {
+	Interval time.Duration
+}
+
+func (w SleepWatcher) Watch(ctx context.Context, events chan<- application.LockEvent) error {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+
+	// Round strips the monotonic clock, which stops while the system sleeps
+	last := time.Now().Round(0)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			now := time.Now().Round(0)
+			if SleptBetween(last, now, w.Interval) {
+				send(ctx, events, application.LockEvent{Locked: true, At: last})
+				send(ctx, events, application.LockEvent{Locked: false, At: now})
+			}
+			last = now
+		}
+	}
+}
+
+// SleptBetween tells if two ticks are too far apart to have been delayed by
+// anything else than a sleep
+func SleptBetween(last time.Time, now time.Time, interval time.Duration) bool {
+	return now.Sub(last) > 3*interval
+}
+
+func send(ctx context.Context, events chan<- application.LockEvent, event application.LockEvent) {
+	select {
+	case events <- event:
+	case <-ctx.Done():
+	}
+}
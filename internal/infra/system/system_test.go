@@ -0,0 +1,174 @@
+package system_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/TristanShz/flow/internal/infra/system"
+	"github.com/matryer/is"
+)
+
+func TestParseLogindSignal(t *testing.T) {
+	tt := []struct {
+		name       string
+		line       string
+		wantLocked bool
+		wantOk     bool
+	}{
+		{
+			name:       "Session lock",
+			line:       "/org/freedesktop/login1/session/_32: org.freedesktop.login1.Session.Lock ()",
+			wantLocked: true,
+			wantOk:     true,
+		},
+		{
+			name:   "Session unlock",
+			line:   "/org/freedesktop/login1/session/_32: org.freedesktop.login1.Session.Unlock ()",
+			wantOk: true,
+		},
+		{
+			name:       "Going to sleep",
+			line:       "/org/freedesktop/login1: org.freedesktop.login1.Manager.PrepareForSleep (true,)",
+			wantLocked: true,
+			wantOk:     true,
+		},
+		{
+			name:   "Waking up",
+			line:   "/org/freedesktop/login1: org.freedesktop.login1.Manager.PrepareForSleep (false,)",
+			wantOk: true,
+		},
+		{
+			name:       "Locked hint",
+			line:       "/org/freedesktop/login1/session/_32: org.freedesktop.DBus.Properties.PropertiesChanged ('org.freedesktop.login1.Session', {'LockedHint': <true>}, @as [])",
+			wantLocked: true,
+			wantOk:     true,
+		},
+		{
+			name: "Other signal",
+			line: "/org/freedesktop/login1: org.freedesktop.login1.Manager.SessionNew ('3', objectpath '/org/freedesktop/login1/session/_33')",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			locked, ok := system.ParseLogindSignal(tc.line)
+
+			is.Equal(locked, tc.wantLocked)
+			is.Equal(ok, tc.wantOk)
+		})
+	}
+}
+
+func TestParseScreenLocked(t *testing.T) {
+	is := is.New(t)
+
+	is.True(system.ParseIoregLocked(`    "IOConsoleUsers" = ({"CGSSessionScreenIsLocked"=Yes,"kCGSSessionOnConsoleKey"=Yes})`))
+	is.True(!system.ParseIoregLocked(`    "IOConsoleUsers" = ({"kCGSSessionOnConsoleKey"=Yes})`))
+
+	is.True(system.ParseTasklistLocked("LogonUI.exe                  12345 Console                    1     45,012 K"))
+	is.True(!system.ParseTasklistLocked("INFO: No tasks are running which match the specified criteria."))
+}
+
+func TestSleptBetween(t *testing.T) {
+	is := is.New(t)
+	last := time.Date(2024, time.April, 13, 10, 0, 0, 0, time.UTC)
+
+	is.True(!system.SleptBetween(last, last.Add(12*time.Second), 10*time.Second))
+	is.True(system.SleptBetween(last, last.Add(time.Hour), 10*time.Second))
+}
+
+func TestParseIdleTime(t *testing.T) {
+	is := is.New(t)
+
+	idle, err := system.ParseXprintidle("93452\n")
+	is.NoErr(err)
+	is.Equal(idle, 93452*time.Millisecond)
+
+	idle, err = system.ParseMutterIdletime("(uint64 600000,)\n")
+	is.NoErr(err)
+	is.Equal(idle, 10*time.Minute)
+
+	idle, err = system.ParseIoregIdleTime(`    |   "HIDIdleTime" = 5000000000`)
+	is.NoErr(err)
+	is.Equal(idle, 5*time.Second)
+
+	_, err = system.ParseXprintidle("couldn't open display")
+	is.True(err != nil)
+	_, err = system.ParseIoregIdleTime(`    "IOConsoleUsers" = ()`)
+	is.True(err != nil)
+}
+
+func TestFirstIdleDetector(t *testing.T) {
+	is := is.New(t)
+
+	missing := system.CommandIdleDetector{Name: "flow-missing-idle-tool", Parse: system.ParseXprintidle}
+	detector := system.FirstIdleDetector{missing, infra.StubIdleDetector{Idle: time.Minute}}
+
+	idle, err := detector.IdleTime(context.Background())
+	is.NoErr(err)
+	is.Equal(idle, time.Minute)
+
+	_, err = system.FirstIdleDetector{missing}.IdleTime(context.Background())
+	is.True(errors.Is(err, application.ErrIdleUnsupported))
+}
+
+func TestAppleScriptClipboard(t *testing.T) {
+	is := is.New(t)
+
+	script := system.AppleScriptClipboard(application.ClipboardContent{Text: `a"b`, HTML: "<b>"})
+
+	is.Equal(script, "set the clipboard to {«class utf8»:«data utf8612262», «class HTML»:«data HTML3C623E»}")
+}
+
+func TestFirstClipboard(t *testing.T) {
+	is := is.New(t)
+
+	copied := &infra.InMemoryClipboard{}
+	err := system.FirstClipboard{system.CommandClipboard{Name: "flow-missing-clipboard-tool"}, copied}.Copy(application.ClipboardContent{Text: "report"})
+
+	is.NoErr(err)
+	is.Equal(copied.Content.Text, "report")
+
+	err = system.FirstClipboard{system.CommandClipboard{Name: "flow-missing-clipboard-tool"}}.Copy(application.ClipboardContent{Text: "report"})
+	is.True(errors.Is(err, application.ErrClipboardUnsupported))
+}
+
+func TestParseHistoryTimes(t *testing.T) {
+	is := is.New(t)
+
+	zsh := ": 1713000000:0;make test\n: 1713000060:12;git commit\n"
+	fish := "- cmd: make test\n  when: 1713000120\n"
+	bash := "#1713000180\nmake test\n"
+
+	is.Equal(system.ParseHistoryTimes(zsh), []time.Time{time.Unix(1713000000, 0), time.Unix(1713000060, 0)})
+	is.Equal(system.ParseHistoryTimes(fish), []time.Time{time.Unix(1713000120, 0)})
+	is.Equal(system.ParseHistoryTimes(bash), []time.Time{time.Unix(1713000180, 0)})
+	is.Equal(system.ParseHistoryTimes("make test\ngit commit\n"), []time.Time{})
+}
+
+func TestShellHistoryActivityDetector(t *testing.T) {
+	is := is.New(t)
+
+	dir := t.TempDir()
+	zsh := filepath.Join(dir, ".zsh_history")
+	// the last command is the stop being run
+	is.NoErr(os.WriteFile(zsh, []byte(": 1713000000:0;make test\n: 1713003600:0;flow stop\n"), 0600))
+	bash := filepath.Join(dir, ".bash_history")
+	is.NoErr(os.WriteFile(bash, []byte("make test\n"), 0600))
+	bashWrittenAt := time.Unix(1712990000, 0)
+	is.NoErr(os.Chtimes(bash, bashWrittenAt, bashWrittenAt))
+
+	detector := system.ShellHistoryActivityDetector{Files: []string{zsh, bash, filepath.Join(dir, "missing")}}
+	last, err := detector.LastActivity(context.Background(), time.Unix(1713003590, 0))
+
+	is.NoErr(err)
+	is.Equal(last, time.Unix(1713000000, 0))
+}
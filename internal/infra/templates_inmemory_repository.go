@@ -0,0 +1,16 @@
+package infra
+
+import "github.com/TristanShz/flow/internal/domain/project"
+
+type InMemoryTemplatesRepository struct {
+	Templates project.Templates
+}
+
+func (r *InMemoryTemplatesRepository) Get() project.Templates {
+	return r.Templates
+}
+
+func (r *InMemoryTemplatesRepository) Save(templates project.Templates) error {
+	r.Templates = templates
+	return nil
+}
@@ -0,0 +1,167 @@
+package timewarrior
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+const timeLayout = "20060102T150405Z"
+
+// DataPath returns the data folder of Timewarrior: in $TIMEWARRIORDB when it's
+// set, in ~/.timewarrior otherwise
+func DataPath(getenv func(string) string, homeDir string) string {
+	if dir := getenv("TIMEWARRIORDB"); dir != "" {
+		return filepath.Join(dir, "data")
+	}
+
+	return filepath.Join(homeDir, ".timewarrior", "data")
+}
+
+// DataImporter reads the intervals of the data files of Timewarrior, one file
+// per month like 2024-04.data. Timewarrior has no projects: the first tag of
+// an interval is the project of the session, its other tags are the tags of
+// the session, and its annotation is the note.
+type DataImporter struct {
+	// Path is a data file, or the folder of the data files
+	Path string
+}
+
+func (i DataImporter) Import() ([]session.Session, error) {
+	info, err := os.Stat(i.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{i.Path}
+	if info.IsDir() {
+		// the folder also holds undo.data and tags.data, which aren't intervals
+		if files, err = filepath.Glob(filepath.Join(i.Path, "[0-9][0-9][0-9][0-9]-[0-9][0-9].data")); err != nil {
+			return nil, err
+		}
+		slices.Sort(files)
+	}
+
+	sessions := []session.Session{}
+	for _, file := range files {
+		fileSessions, err := readDataFile(file)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, fileSessions...)
+	}
+
+	return sessions, nil
+}
+
+func readDataFile(path string) ([]session.Session, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	sessions, err := ReadIntervals(file)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", filepath.Base(path), err)
+	}
+
+	return sessions, nil
+}
+
+// ReadIntervals reads the intervals of a data file, lines like
+// inc 20240412T090000Z - 20240412T103000Z # flow "deep work" # "release"
+func ReadIntervals(reader io.Reader) ([]session.Session, error) {
+	sessions := []session.Session{}
+
+	scanner := bufio.NewScanner(reader)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		s, err := parseInterval(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %v: %w", line, err)
+		}
+		sessions = append(sessions, s)
+	}
+
+	return sessions, scanner.Err()
+}
+
+func parseInterval(line string) (session.Session, error) {
+	words, err := splitWords(line)
+	if err != nil {
+		return session.Session{}, err
+	}
+	if len(words) < 2 || words[0] != "inc" {
+		return session.Session{}, fmt.Errorf("invalid interval %v", line)
+	}
+
+	s := session.Session{Tags: []string{}}
+	if s.StartTime, err = time.Parse(timeLayout, words[1]); err != nil {
+		return session.Session{}, fmt.Errorf("invalid start %v", words[1])
+	}
+	words = words[2:]
+
+	if len(words) >= 2 && words[0] == "-" {
+		if s.EndTime, err = time.Parse(timeLayout, words[1]); err != nil {
+			return session.Session{}, fmt.Errorf("invalid end %v", words[1])
+		}
+		words = words[2:]
+	}
+
+	if len(words) > 0 && words[0] == "#" {
+		words = words[1:]
+	}
+	tags := words
+	if separator := slices.Index(words, "#"); separator != -1 {
+		tags = words[:separator]
+		s.Note = strings.Join(words[separator+1:], " ")
+	}
+
+	if len(tags) > 0 {
+		s.Project = tags[0]
+		s.Tags = append(s.Tags, tags[1:]...)
+	}
+
+	return s, nil
+}
+
+// splitWords splits a line on spaces, keeping the quoted tags and annotations
+// with their spaces
+func splitWords(line string) ([]string, error) {
+	words := []string{}
+
+	for line = strings.TrimSpace(line); line != ""; line = strings.TrimSpace(line) {
+		if line[0] != '"' {
+			word, rest, _ := strings.Cut(line, " ")
+			words = append(words, word)
+			line = rest
+			continue
+		}
+
+		quoted, err := strconv.QuotedPrefix(line)
+		if err != nil {
+			return nil, fmt.Errorf("unterminated quote in %v", line)
+		}
+		word, err := strconv.Unquote(quoted)
+		if err != nil {
+			return nil, err
+		}
+		words = append(words, word)
+		line = line[len(quoted):]
+	}
+
+	return words, nil
+}
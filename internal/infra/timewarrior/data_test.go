@@ -0,0 +1,101 @@
+package timewarrior_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra/timewarrior"
+	"github.com/matryer/is"
+)
+
+func TestReadIntervals(t *testing.T) {
+	tt := []struct {
+		name    string
+		data    string
+		want    []session.Session
+		wantErr bool
+	}{
+		{
+			name: "Interval with tags and annotation",
+			data: `inc 20240412T090000Z - 20240412T103000Z # flow "deep work" cli # "ship the \"release\""`,
+			want: []session.Session{{
+				StartTime: time.Date(2024, time.April, 12, 9, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2024, time.April, 12, 10, 30, 0, 0, time.UTC),
+				Project:   "flow",
+				Tags:      []string{"deep work", "cli"},
+				Note:      `ship the "release"`,
+			}},
+		},
+		{
+			name: "Interval without tags, open interval",
+			data: "inc 20240412T090000Z - 20240412T100000Z\n\ninc 20240412T110000Z # flow\n",
+			want: []session.Session{
+				{
+					StartTime: time.Date(2024, time.April, 12, 9, 0, 0, 0, time.UTC),
+					EndTime:   time.Date(2024, time.April, 12, 10, 0, 0, 0, time.UTC),
+					Tags:      []string{},
+				},
+				{
+					StartTime: time.Date(2024, time.April, 12, 11, 0, 0, 0, time.UTC),
+					Project:   "flow",
+					Tags:      []string{},
+				},
+			},
+		},
+		{
+			name:    "Not an interval",
+			data:    "exc monday <9:00",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid start",
+			data:    "inc 2024-04-12 # flow",
+			wantErr: true,
+		},
+		{
+			name:    "Unterminated quote",
+			data:    `inc 20240412T090000Z # "deep work`,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			got, err := timewarrior.ReadIntervals(strings.NewReader(tc.data))
+
+			is.Equal(err != nil, tc.wantErr)
+			if !tc.wantErr {
+				is.Equal(got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDataImporter(t *testing.T) {
+	is := is.New(t)
+
+	dir := t.TempDir()
+	is.NoErr(os.WriteFile(filepath.Join(dir, "2024-05.data"), []byte("inc 20240502T090000Z - 20240502T100000Z # website\n"), 0o644))
+	is.NoErr(os.WriteFile(filepath.Join(dir, "2024-04.data"), []byte("inc 20240412T090000Z - 20240412T100000Z # flow\n"), 0o644))
+	is.NoErr(os.WriteFile(filepath.Join(dir, "undo.data"), []byte("txn:\n  type: interval\n"), 0o644))
+	is.NoErr(os.WriteFile(filepath.Join(dir, "tags.data"), []byte("{\"flow\":{\"count\":1}}\n"), 0o644))
+
+	got, err := timewarrior.DataImporter{Path: dir}.Import()
+
+	is.NoErr(err)
+	is.Equal(len(got), 2)
+	is.Equal(got[0].Project, "flow")
+	is.Equal(got[1].Project, "website")
+
+	got, err = timewarrior.DataImporter{Path: filepath.Join(dir, "2024-05.data")}.Import()
+
+	is.NoErr(err)
+	is.Equal(len(got), 1)
+	is.Equal(got[0].Project, "website")
+}
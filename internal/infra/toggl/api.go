@@ -0,0 +1,286 @@
+package toggl
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+// DefaultBaseURL is the v9 API of Toggl Track, see
+// https://engineering.toggl.com/docs/
+const DefaultBaseURL = "https://api.track.toggl.com/api/v9"
+
+const DefaultTimeout = 30 * time.Second
+
+// externalIDPrefix marks the sessions imported from Toggl
+const externalIDPrefix = "toggl:"
+
+// ExternalID returns the external id of the session of a time entry
+func ExternalID(timeEntryID string) string {
+	return externalIDPrefix + timeEntryID
+}
+
+// Client calls the API of Toggl Track with the API token of the profile of
+// the user
+type Client struct {
+	HTTPClient *http.Client
+	BaseURL    string
+	Token      string
+}
+
+func NewClient(token string) Client {
+	return Client{
+		HTTPClient: &http.Client{Timeout: DefaultTimeout},
+		BaseURL:    DefaultBaseURL,
+		Token:      token,
+	}
+}
+
+type timeEntry struct {
+	ID          int64      `json:"id,omitempty"`
+	WorkspaceID int64      `json:"workspace_id"`
+	ProjectID   *int64     `json:"project_id,omitempty"`
+	ProjectName string     `json:"project_name,omitempty"`
+	Description string     `json:"description"`
+	Start       time.Time  `json:"start"`
+	Stop        *time.Time `json:"stop,omitempty"`
+	Duration    int64      `json:"duration"`
+	Tags        []string   `json:"tags"`
+	CreatedWith string     `json:"created_with,omitempty"`
+}
+
+type project struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+func (c Client) do(method string, path string, body any, result any) error {
+	var reader io.Reader
+	if body != nil {
+		marshaled, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(marshaled)
+	}
+
+	request, err := http.NewRequest(method, c.BaseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	request.SetBasicAuth(c.Token, "api_token")
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := c.HTTPClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		message, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return fmt.Errorf("toggl answered %v: %v", response.Status, strings.TrimSpace(string(message)))
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.NewDecoder(response.Body).Decode(result)
+}
+
+func (c Client) timeEntries(since time.Time, until time.Time) ([]timeEntry, error) {
+	query := url.Values{"meta": {"true"}}
+	if !since.IsZero() {
+		query.Set("start_date", since.Format(time.RFC3339))
+	}
+	if !until.IsZero() {
+		query.Set("end_date", until.Format(time.RFC3339))
+	}
+
+	entries := []timeEntry{}
+	err := c.do(http.MethodGet, "/me/time_entries?"+query.Encode(), nil, &entries)
+
+	return entries, err
+}
+
+// APIImporter reads the time entries of the user from the API, Toggl only
+// returns the last 3 months of entries without a time range
+type APIImporter struct {
+	Client Client
+	Since  time.Time
+	Until  time.Time
+}
+
+func (i APIImporter) Import() ([]session.Session, error) {
+	entries, err := i.Client.timeEntries(i.Since, i.Until)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := []session.Session{}
+	for _, entry := range entries {
+		s := session.Session{
+			StartTime: entry.Start,
+			Project:   entry.ProjectName,
+			Tags:      entry.Tags,
+			Note:      entry.Description,
+			Metadata:  map[string]string{session.ExternalIDMetadata: ExternalID(strconv.FormatInt(entry.ID, 10))},
+		}
+		// running time entries have no stop and a negative duration
+		if entry.Stop != nil && entry.Duration >= 0 {
+			s.EndTime = *entry.Stop
+		}
+		sessions = append(sessions, s)
+	}
+
+	return sessions, nil
+}
+
+// APIExporter creates a time entry for each session, it's an
+// application.SessionsExporter. The sessions imported from Toggl and the
+// sessions starting when a time entry does are skipped, so that exporting
+// again doesn't create the time entries twice.
+type APIExporter struct {
+	Client      Client
+	WorkspaceID int64
+	// Projects and Tags map the names of flow to the ones of Toggl
+	Projects map[string]string
+	Tags     map[string]string
+	Exported int
+	Skipped  int
+}
+
+func (e *APIExporter) Export(sessions []session.Session) error {
+	toExport := []session.Session{}
+	for _, s := range sessions {
+		if s.EndTime.IsZero() || strings.HasPrefix(s.Metadata[session.ExternalIDMetadata], externalIDPrefix) {
+			e.Skipped++
+			continue
+		}
+		toExport = append(toExport, s)
+	}
+	if len(toExport) == 0 {
+		return nil
+	}
+
+	existing, err := e.existingStarts(toExport)
+	if err != nil {
+		return err
+	}
+
+	projects := []project{}
+	if err := e.Client.do(http.MethodGet, e.workspacePath("/projects"), nil, &projects); err != nil {
+		return err
+	}
+	projectIDs := map[string]int64{}
+	for _, p := range projects {
+		projectIDs[p.Name] = p.ID
+	}
+
+	for _, s := range toExport {
+		if existing[s.StartTime.Unix()] {
+			e.Skipped++
+			continue
+		}
+
+		projectName := mapName(e.Projects, s.Project)
+		projectID, ok := projectIDs[projectName]
+		if !ok {
+			created := project{}
+			if err := e.Client.do(http.MethodPost, e.workspacePath("/projects"), map[string]any{"name": projectName, "active": true}, &created); err != nil {
+				return err
+			}
+			projectID = created.ID
+			projectIDs[projectName] = projectID
+		}
+
+		stop := s.EndTime.UTC()
+		entry := timeEntry{
+			WorkspaceID: e.WorkspaceID,
+			ProjectID:   &projectID,
+			Description: s.Note,
+			Start:       s.StartTime.UTC(),
+			Stop:        &stop,
+			Duration:    int64(s.Duration().Seconds()),
+			Tags:        mapNames(e.Tags, s.Tags),
+			CreatedWith: "flow",
+		}
+		if err := e.Client.do(http.MethodPost, e.workspacePath("/time_entries"), entry, nil); err != nil {
+			return err
+		}
+		e.Exported++
+	}
+
+	return nil
+}
+
+// existingStarts returns the start times of the time entries of the period of
+// the sessions
+func (e *APIExporter) existingStarts(sessions []session.Session) (map[int64]bool, error) {
+	since, until := sessions[0].StartTime, sessions[0].EndTime
+	for _, s := range sessions {
+		if s.StartTime.Before(since) {
+			since = s.StartTime
+		}
+		if s.EndTime.After(until) {
+			until = s.EndTime
+		}
+	}
+
+	entries, err := e.Client.timeEntries(since, until.Add(time.Second))
+	if err != nil {
+		return nil, err
+	}
+
+	starts := map[int64]bool{}
+	for _, entry := range entries {
+		starts[entry.Start.Unix()] = true
+	}
+
+	return starts, nil
+}
+
+func (e *APIExporter) workspacePath(path string) string {
+	return "/workspaces/" + strconv.FormatInt(e.WorkspaceID, 10) + path
+}
+
+// mapName returns the name mapped to the name, the name itself when it isn't
+// mapped
+func mapName(names map[string]string, name string) string {
+	if mapped, ok := names[name]; ok && mapped != "" {
+		return mapped
+	}
+
+	return name
+}
+
+func mapNames(names map[string]string, values []string) []string {
+	mapped := []string{}
+	for _, value := range values {
+		mapped = append(mapped, mapName(names, value))
+	}
+
+	return mapped
+}
+
+// Invert returns the mapping of the config, from Toggl names to flow names,
+// as the mapping from flow names to Toggl names the exports need
+func Invert(names map[string]string) map[string]string {
+	inverted := map[string]string{}
+	for togglName, flowName := range names {
+		if flowName != "" {
+			inverted[flowName] = togglName
+		}
+	}
+
+	return inverted
+}
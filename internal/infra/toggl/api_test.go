@@ -0,0 +1,155 @@
+package toggl_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra/toggl"
+	"github.com/matryer/is"
+)
+
+// fakeToggl serves the endpoints of the API the importer and the exporter call
+type fakeToggl struct {
+	timeEntries        []map[string]any
+	projects           []map[string]any
+	createdProjects    []map[string]any
+	createdTimeEntries []map[string]any
+}
+
+func (f *fakeToggl) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if user, password, _ := r.BasicAuth(); user != "token" || password != "api_token" {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	switch r.Method + " " + r.URL.Path {
+	case "GET /me/time_entries":
+		json.NewEncoder(w).Encode(f.timeEntries)
+	case "GET /workspaces/7/projects":
+		json.NewEncoder(w).Encode(f.projects)
+	case "POST /workspaces/7/projects":
+		project := map[string]any{}
+		json.NewDecoder(r.Body).Decode(&project)
+		f.createdProjects = append(f.createdProjects, project)
+		json.NewEncoder(w).Encode(map[string]any{"id": 99, "name": project["name"]})
+	case "POST /workspaces/7/time_entries":
+		entry := map[string]any{}
+		json.NewDecoder(r.Body).Decode(&entry)
+		f.createdTimeEntries = append(f.createdTimeEntries, entry)
+		json.NewEncoder(w).Encode(entry)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newClient(server *httptest.Server, token string) toggl.Client {
+	client := toggl.NewClient(token)
+	client.BaseURL = server.URL
+	client.HTTPClient = server.Client()
+
+	return client
+}
+
+func TestAPIImporter(t *testing.T) {
+	is := is.New(t)
+
+	fake := &fakeToggl{timeEntries: []map[string]any{
+		{"id": 1, "project_name": "Flow CLI", "description": "Import", "start": "2024-04-12T09:00:00Z", "stop": "2024-04-12T10:00:00Z", "duration": 3600, "tags": []string{"deep"}},
+		{"id": 2, "project_name": "Flow CLI", "description": "", "start": "2024-04-12T11:00:00Z", "duration": -1712919600, "tags": []string{}},
+	}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	got, err := toggl.APIImporter{Client: newClient(server, "token")}.Import()
+
+	is.NoErr(err)
+	is.Equal(got, []session.Session{
+		{
+			StartTime: time.Date(2024, time.April, 12, 9, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, time.April, 12, 10, 0, 0, 0, time.UTC),
+			Project:   "Flow CLI",
+			Tags:      []string{"deep"},
+			Note:      "Import",
+			Metadata:  map[string]string{session.ExternalIDMetadata: "toggl:1"},
+		},
+		{
+			StartTime: time.Date(2024, time.April, 12, 11, 0, 0, 0, time.UTC),
+			Project:   "Flow CLI",
+			Tags:      []string{},
+			Metadata:  map[string]string{session.ExternalIDMetadata: "toggl:2"},
+		},
+	})
+}
+
+func TestAPIImporter_InvalidToken(t *testing.T) {
+	is := is.New(t)
+
+	server := httptest.NewServer(&fakeToggl{})
+	defer server.Close()
+
+	_, err := toggl.APIImporter{Client: newClient(server, "wrong")}.Import()
+
+	is.True(err != nil)
+}
+
+func TestAPIExporter(t *testing.T) {
+	is := is.New(t)
+
+	fake := &fakeToggl{
+		timeEntries: []map[string]any{
+			{"id": 1, "start": "2024-04-12T09:00:00Z", "stop": "2024-04-12T10:00:00Z", "duration": 3600},
+		},
+		projects: []map[string]any{{"id": 10, "name": "Flow CLI"}},
+	}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	exporter := &toggl.APIExporter{
+		Client:      newClient(server, "token"),
+		WorkspaceID: 7,
+		Projects:    map[string]string{"flow": "Flow CLI"},
+	}
+
+	err := exporter.Export([]session.Session{
+		{
+			StartTime: time.Date(2024, time.April, 12, 9, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, time.April, 12, 10, 0, 0, 0, time.UTC),
+			Project:   "flow",
+		},
+		{
+			StartTime: time.Date(2024, time.April, 12, 11, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, time.April, 12, 12, 0, 0, 0, time.UTC),
+			Project:   "flow",
+			Tags:      []string{"deep"},
+			Note:      "Export",
+		},
+		{
+			StartTime: time.Date(2024, time.April, 12, 13, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, time.April, 12, 14, 0, 0, 0, time.UTC),
+			Project:   "website",
+		},
+		{
+			StartTime: time.Date(2024, time.April, 12, 15, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, time.April, 12, 16, 0, 0, 0, time.UTC),
+			Project:   "flow",
+			Metadata:  map[string]string{session.ExternalIDMetadata: "toggl:3"},
+		},
+	})
+
+	is.NoErr(err)
+	is.Equal(exporter.Exported, 2)
+	is.Equal(exporter.Skipped, 2)
+	is.Equal(len(fake.createdProjects), 1)
+	is.Equal(fake.createdProjects[0]["name"], "website")
+	is.Equal(len(fake.createdTimeEntries), 2)
+	is.Equal(fake.createdTimeEntries[0]["project_id"], float64(10))
+	is.Equal(fake.createdTimeEntries[0]["description"], "Export")
+	is.Equal(fake.createdTimeEntries[0]["duration"], float64(3600))
+	is.Equal(fake.createdTimeEntries[0]["tags"], []any{"deep"})
+	is.Equal(fake.createdTimeEntries[0]["created_with"], "flow")
+	is.Equal(fake.createdTimeEntries[1]["project_id"], float64(99))
+}
@@ -0,0 +1,208 @@
+package toggl
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+// Columns of the CSV files of Toggl Track: its detailed reports have the end
+// of the time entries, the files of its CSV import only have their duration
+const (
+	columnID          = "ID"
+	columnProject     = "Project"
+	columnDescription = "Description"
+	columnStartDate   = "Start date"
+	columnStartTime   = "Start time"
+	columnEndDate     = "End date"
+	columnEndTime     = "End time"
+	columnDuration    = "Duration"
+	columnTags        = "Tags"
+)
+
+var csvImportColumns = []string{columnProject, columnDescription, columnStartDate, columnStartTime, columnDuration, columnTags}
+
+const (
+	csvDateLayout = "2006-01-02"
+	csvTimeLayout = "15:04:05"
+)
+
+// CSVImporter reads the sessions of a detailed report of Toggl Track exported
+// as CSV, or of a file of its CSV import
+type CSVImporter struct {
+	Reader io.Reader
+	// Location is the time zone of the dates of the file, Toggl writes them
+	// in the time zone of the profile of the user
+	Location *time.Location
+}
+
+func (i CSVImporter) Import() ([]session.Session, error) {
+	reader := csv.NewReader(i.Reader)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("invalid Toggl CSV file: %w", err)
+	}
+
+	columns := map[string]int{}
+	for index, column := range header {
+		// the header starts with a byte order mark in the files of Toggl
+		columns[strings.TrimPrefix(strings.TrimSpace(column), "\ufeff")] = index
+	}
+	for _, required := range []string{columnProject, columnStartDate, columnStartTime} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("invalid Toggl CSV file: the %v column is missing", required)
+		}
+	}
+
+	sessions := []session.Session{}
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		s, err := i.recordToSession(columns, record)
+		if err != nil {
+			return nil, fmt.Errorf("line %v: %w", line, err)
+		}
+		sessions = append(sessions, s)
+	}
+
+	return sessions, nil
+}
+
+func (i CSVImporter) recordToSession(columns map[string]int, record []string) (session.Session, error) {
+	value := func(column string) string {
+		index, ok := columns[column]
+		if !ok || index >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[index])
+	}
+
+	location := i.Location
+	if location == nil {
+		location = time.Local
+	}
+
+	startTime, err := time.ParseInLocation(csvDateLayout+" "+csvTimeLayout, value(columnStartDate)+" "+value(columnStartTime), location)
+	if err != nil {
+		return session.Session{}, fmt.Errorf("invalid start %v %v", value(columnStartDate), value(columnStartTime))
+	}
+
+	var endTime time.Time
+	if value(columnEndDate) != "" {
+		endTime, err = time.ParseInLocation(csvDateLayout+" "+csvTimeLayout, value(columnEndDate)+" "+value(columnEndTime), location)
+		if err != nil {
+			return session.Session{}, fmt.Errorf("invalid end %v %v", value(columnEndDate), value(columnEndTime))
+		}
+	} else {
+		duration, err := parseDuration(value(columnDuration))
+		if err != nil {
+			return session.Session{}, err
+		}
+		endTime = startTime.Add(duration)
+	}
+
+	s := session.Session{
+		StartTime: startTime,
+		EndTime:   endTime,
+		Project:   value(columnProject),
+		Tags:      splitTags(value(columnTags)),
+		Note:      value(columnDescription),
+	}
+	if id := value(columnID); id != "" {
+		s.Metadata = map[string]string{session.ExternalIDMetadata: ExternalID(id)}
+	}
+
+	return s, nil
+}
+
+// parseDuration reads the HH:MM:SS durations of Toggl, hours can go past 24
+func parseDuration(value string) (time.Duration, error) {
+	var hours, minutes, seconds int
+	if _, err := fmt.Sscanf(value, "%d:%d:%d", &hours, &minutes, &seconds); err != nil {
+		return 0, fmt.Errorf("invalid duration %v, expected HH:MM:SS", value)
+	}
+
+	return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second, nil
+}
+
+func formatDuration(d time.Duration) string {
+	seconds := int64(d.Seconds())
+	return fmt.Sprintf("%02d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60)
+}
+
+func splitTags(value string) []string {
+	tags := []string{}
+	for _, tag := range strings.Split(value, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
+
+// CSVEncoder writes the sessions in the format of the CSV import of Toggl
+// Track, it's an exporter.Encoder
+type CSVEncoder struct {
+	// Projects and Tags map the names of flow to the ones of Toggl
+	Projects map[string]string
+	Tags     map[string]string
+	Location *time.Location
+}
+
+func (e CSVEncoder) Extension() string {
+	return ".csv"
+}
+
+func (e CSVEncoder) Header() []byte {
+	return encodeRecord(csvImportColumns)
+}
+
+func (e CSVEncoder) Encode(s session.Session) ([]byte, error) {
+	// Toggl can't import running time entries
+	if s.EndTime.IsZero() {
+		return nil, nil
+	}
+
+	location := e.Location
+	if location == nil {
+		location = time.Local
+	}
+	startTime := s.StartTime.In(location)
+
+	return encodeRecord([]string{
+		mapName(e.Projects, s.Project),
+		s.Note,
+		startTime.Format(csvDateLayout),
+		startTime.Format(csvTimeLayout),
+		formatDuration(s.Duration()),
+		strings.Join(mapNames(e.Tags, s.Tags), ", "),
+	}), nil
+}
+
+func (e CSVEncoder) Footer() []byte {
+	return nil
+}
+
+func encodeRecord(record []string) []byte {
+	buf := new(bytes.Buffer)
+	w := csv.NewWriter(buf)
+	w.Write(record)
+	w.Flush()
+
+	return buf.Bytes()
+}
@@ -0,0 +1,95 @@
+package toggl_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra/toggl"
+	"github.com/matryer/is"
+)
+
+func TestCSVImporter(t *testing.T) {
+	tt := []struct {
+		name    string
+		file    string
+		want    []session.Session
+		wantErr bool
+	}{
+		{
+			name: "Detailed report",
+			file: "\ufeffID,User,Email,Client,Project,Task,Description,Billable,Start date,Start time,End date,End time,Duration,Tags\n" +
+				"42,Me,me@example.com,Acme,Flow CLI,,Import,Yes,2024-04-12,09:00:00,2024-04-12,10:30:00,01:30:00,\"deep, billable\"\n",
+			want: []session.Session{{
+				StartTime: time.Date(2024, time.April, 12, 9, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2024, time.April, 12, 10, 30, 0, 0, time.UTC),
+				Project:   "Flow CLI",
+				Tags:      []string{"deep", "billable"},
+				Note:      "Import",
+				Metadata:  map[string]string{session.ExternalIDMetadata: "toggl:42"},
+			}},
+		},
+		{
+			name: "Import file with durations",
+			file: "Project,Description,Start date,Start time,Duration,Tags\n" +
+				"flow,,2024-04-12,23:00:00,25:00:00,\n",
+			want: []session.Session{{
+				StartTime: time.Date(2024, time.April, 12, 23, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2024, time.April, 14, 0, 0, 0, 0, time.UTC),
+				Project:   "flow",
+				Tags:      []string{},
+			}},
+		},
+		{
+			name:    "Missing column",
+			file:    "Project,Description\nflow,\n",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid duration",
+			file:    "Project,Start date,Start time,Duration\nflow,2024-04-12,09:00:00,1h\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			got, err := toggl.CSVImporter{Reader: strings.NewReader(tc.file), Location: time.UTC}.Import()
+
+			is.Equal(err != nil, tc.wantErr)
+			if !tc.wantErr {
+				is.Equal(got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCSVEncoder(t *testing.T) {
+	is := is.New(t)
+
+	encoder := toggl.CSVEncoder{
+		Projects: map[string]string{"flow": "Flow CLI"},
+		Tags:     map[string]string{"deep": "Deep Work"},
+		Location: time.UTC,
+	}
+
+	got, err := encoder.Encode(session.Session{
+		StartTime: time.Date(2024, time.April, 12, 9, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, time.April, 12, 10, 30, 5, 0, time.UTC),
+		Project:   "flow",
+		Tags:      []string{"deep", "review"},
+		Note:      "Import, export",
+	})
+
+	is.NoErr(err)
+	is.Equal(string(encoder.Header()), "Project,Description,Start date,Start time,Duration,Tags\n")
+	is.Equal(string(got), "Flow CLI,\"Import, export\",2024-04-12,09:00:00,01:30:05,\"Deep Work, review\"\n")
+
+	running, err := encoder.Encode(session.Session{StartTime: time.Now(), Project: "flow"})
+
+	is.NoErr(err)
+	is.Equal(running, nil)
+}
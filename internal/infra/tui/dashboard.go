@@ -0,0 +1,407 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	app "github.com/TristanShz/flow/internal/application/usecases"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/editsession"
+	startsession "github.com/TristanShz/flow/internal/application/usecases/flowsession/start"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/stopsession"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra/server"
+	"github.com/TristanShz/flow/pkg/timerange"
+	"github.com/TristanShz/flow/utils"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TickInterval is the time between two refreshes of the live timer
+const TickInterval = time.Second
+
+// defaultListHeight is the number of sessions shown before the size of the
+// terminal is known
+const defaultListHeight = 15
+
+// prompt is the value asked in the footer, the zero prompt means the keys
+// move in the list
+type prompt int
+
+const (
+	noPrompt prompt = iota
+	projectFilterPrompt
+	tagFilterPrompt
+	rangeFilterPrompt
+	startPrompt
+	editProjectPrompt
+	editTagsPrompt
+	editNotePrompt
+)
+
+var promptLabels = map[prompt]string{
+	projectFilterPrompt: "Filter by project",
+	tagFilterPrompt:     "Filter by tag",
+	rangeFilterPrompt:   "Filter by range",
+	startPrompt:         "Start (project +tag...)",
+	editProjectPrompt:   "Project",
+	editTagsPrompt:      "Tags",
+	editNotePrompt:      "Note",
+}
+
+type tickMsg time.Time
+
+// Dashboard is the bubbletea model of `flow dashboard`: the list of the
+// sessions matching the filters and the live timer of the current session
+type Dashboard struct {
+	app *app.App
+
+	sessions []session.Session
+	cursor   int
+	offset   int
+	height   int
+
+	projectFilter string
+	tagFilter     string
+	rangeFilter   string
+
+	prompt prompt
+	input  string
+
+	// message is the result of the last action, or its error
+	message string
+}
+
+func NewDashboard(app *app.App) Dashboard {
+	d := Dashboard{app: app}
+	d.refresh()
+
+	return d
+}
+
+func tick() tea.Cmd {
+	return tea.Tick(TickInterval, func(t time.Time) tea.Msg {
+		return tickMsg(t)
+	})
+}
+
+func (d Dashboard) Init() tea.Cmd {
+	return tick()
+}
+
+func (d Dashboard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tickMsg:
+		// sessions started or stopped from another terminal show up too
+		d.refresh()
+		return d, tick()
+	case tea.WindowSizeMsg:
+		d.height = msg.Height
+		d.scroll()
+		return d, nil
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyCtrlC {
+			return d, tea.Quit
+		}
+		if d.prompt != noPrompt {
+			return d.updatePrompt(msg), nil
+		}
+		return d.updateList(msg)
+	}
+
+	return d, nil
+}
+
+func (d Dashboard) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc":
+		return d, tea.Quit
+	case "up", "k":
+		if d.cursor > 0 {
+			d.cursor--
+		}
+	case "down", "j":
+		if d.cursor < len(d.sessions)-1 {
+			d.cursor++
+		}
+	case "p":
+		d.ask(projectFilterPrompt, d.projectFilter)
+	case "t":
+		d.ask(tagFilterPrompt, d.tagFilter)
+	case "r":
+		d.ask(rangeFilterPrompt, d.rangeFilter)
+	case "x":
+		d.projectFilter, d.tagFilter, d.rangeFilter = "", "", ""
+		d.message = "Filters cleared"
+		d.refresh()
+	case "s":
+		d.toggleSession()
+	case "e":
+		if selected, ok := d.selected(); ok {
+			d.ask(editProjectPrompt, selected.Project)
+		}
+	case "g":
+		if selected, ok := d.selected(); ok {
+			d.ask(editTagsPrompt, strings.Join(selected.Tags, " "))
+		}
+	case "n":
+		if selected, ok := d.selected(); ok {
+			d.ask(editNotePrompt, selected.Note)
+		}
+	}
+
+	d.scroll()
+
+	return d, nil
+}
+
+func (d Dashboard) updatePrompt(msg tea.KeyMsg) Dashboard {
+	switch msg.Type {
+	case tea.KeyEsc:
+		d.prompt, d.input = noPrompt, ""
+	case tea.KeyEnter:
+		d.submit()
+		d.prompt, d.input = noPrompt, ""
+		d.scroll()
+	case tea.KeyBackspace:
+		if runes := []rune(d.input); len(runes) > 0 {
+			d.input = string(runes[:len(runes)-1])
+		}
+	case tea.KeySpace:
+		d.input += " "
+	case tea.KeyRunes:
+		d.input += string(msg.Runes)
+	}
+
+	return d
+}
+
+func (d *Dashboard) ask(p prompt, value string) {
+	d.prompt, d.input, d.message = p, value, ""
+}
+
+func (d *Dashboard) submit() {
+	value := strings.TrimSpace(d.input)
+
+	switch d.prompt {
+	case projectFilterPrompt:
+		d.projectFilter = value
+	case tagFilterPrompt:
+		d.tagFilter = strings.TrimPrefix(value, "+")
+	case rangeFilterPrompt:
+		if value != "" {
+			if _, err := timerange.Parse(value, d.app.DateProvider.GetNow()); err != nil {
+				d.message = err.Error()
+				return
+			}
+		}
+		d.rangeFilter = value
+	case startPrompt:
+		d.start(value)
+	case editProjectPrompt:
+		d.edit(editsession.Command{Project: &value})
+	case editTagsPrompt:
+		tags := []string{}
+		for _, tag := range strings.Fields(value) {
+			tags = append(tags, strings.TrimPrefix(tag, "+"))
+		}
+		d.edit(editsession.Command{Tags: &tags})
+	case editNotePrompt:
+		d.edit(editsession.Command{Note: &value})
+	}
+
+	d.refresh()
+}
+
+// toggleSession stops the current session, or asks for the project of the
+// session to start
+func (d *Dashboard) toggleSession() {
+	duration, err := d.app.StopFlowSessionUseCase.Execute(stopsession.Command{TagRules: d.app.Config.TagRules})
+	if err == stopsession.ErrNoCurrentSession {
+		d.ask(startPrompt, "")
+		return
+	}
+	if err == stopsession.ErrClockWentBackwards {
+		d.message = "Warning: " + err.Error()
+		d.refresh()
+		return
+	}
+	if err != nil {
+		d.message = err.Error()
+		return
+	}
+
+	d.message = fmt.Sprintf("Session stopped after %v", duration)
+	d.refresh()
+}
+
+func (d *Dashboard) start(value string) {
+	command := startsession.Command{TagRules: d.app.Config.TagRules}
+	for _, arg := range strings.Fields(value) {
+		if strings.HasPrefix(arg, "+") {
+			command.Tags = append(command.Tags, strings.TrimPrefix(arg, "+"))
+		} else if command.Project == "" {
+			command.Project = arg
+		}
+	}
+
+	if command.Project == "" {
+		d.message = "A project is required to start a session"
+		return
+	}
+
+	if err := d.app.StartFlowSessionUseCase.Execute(command); err != nil {
+		d.message = err.Error()
+		return
+	}
+
+	d.message = fmt.Sprintf("Session started on %v", command.Project)
+}
+
+func (d *Dashboard) edit(command editsession.Command) {
+	selected, ok := d.selected()
+	if !ok {
+		return
+	}
+
+	command.Id = selected.Id
+	command.LockedBefore = d.app.Config.LockedBefore(d.app.DateProvider.GetNow())
+	if _, err := d.app.EditSessionUseCase.Execute(command); err != nil {
+		d.message = err.Error()
+		return
+	}
+
+	d.message = fmt.Sprintf("Session %v edited", selected.Id)
+}
+
+func (d Dashboard) selected() (session.Session, bool) {
+	if d.cursor >= len(d.sessions) {
+		return session.Session{}, false
+	}
+
+	return d.sessions[d.cursor], true
+}
+
+// refresh reads the sessions matching the filters, the newest first, and
+// keeps the cursor on the selected session when it's still listed
+func (d *Dashboard) refresh() {
+	filters := &application.SessionsFilters{Project: d.projectFilter}
+	if d.tagFilter != "" {
+		filters.Tags = []string{d.tagFilter}
+	}
+	if d.rangeFilter != "" {
+		if timeRange, err := timerange.Parse(d.rangeFilter, d.app.DateProvider.GetNow()); err == nil {
+			filters.Timerange = timeRange
+		}
+	}
+
+	selected, hadSelection := d.selected()
+
+	d.sessions = append([]session.Session{}, d.app.SessionRepository.FindAllSessions(filters)...)
+	sort.SliceStable(d.sessions, func(i, j int) bool {
+		return d.sessions[i].StartTime.After(d.sessions[j].StartTime)
+	})
+
+	d.cursor = 0
+	if hadSelection {
+		for i, sess := range d.sessions {
+			if sess.Id == selected.Id {
+				d.cursor = i
+			}
+		}
+	}
+	d.scroll()
+}
+
+func (d Dashboard) listHeight() int {
+	// the timer, the filters, the headers and the footer take 8 lines
+	if d.height > 8+1 {
+		return d.height - 8
+	}
+
+	return defaultListHeight
+}
+
+// scroll keeps the cursor in the visible part of the list
+func (d *Dashboard) scroll() {
+	height := d.listHeight()
+	if d.cursor < d.offset {
+		d.offset = d.cursor
+	}
+	if d.cursor >= d.offset+height {
+		d.offset = d.cursor - height + 1
+	}
+}
+
+func (d Dashboard) View() string {
+	b := strings.Builder{}
+
+	status, err := d.app.FlowSessionStatusUseCase.Execute()
+	if err == nil {
+		fmt.Fprintf(&b, "%v %v %v %v\n", utils.HeaderStyle.Render("Flowing"), utils.TimeColor(server.FormatElapsed(status.Duration)), utils.ProjectColor(status.Session.Project), utils.TagColor(strings.Join(status.Session.Tags, ", ")))
+	} else {
+		fmt.Fprintf(&b, "%v %v\n", utils.HeaderStyle.Render("No session flowing"), utils.Faint("press s to start one"))
+	}
+
+	filters := []string{}
+	if d.projectFilter != "" {
+		filters = append(filters, "project: "+d.projectFilter)
+	}
+	if d.tagFilter != "" {
+		filters = append(filters, "tag: "+d.tagFilter)
+	}
+	if d.rangeFilter != "" {
+		filters = append(filters, "range: "+d.rangeFilter)
+	}
+	if len(filters) == 0 {
+		filters = append(filters, "none")
+	}
+	fmt.Fprintf(&b, "Filters: %v\n\n", strings.Join(filters, ", "))
+
+	if len(d.sessions) == 0 {
+		b.WriteString("No sessions found\n")
+	}
+
+	end := min(d.offset+d.listHeight(), len(d.sessions))
+	for i := d.offset; i < end; i++ {
+		sess := d.sessions[i]
+
+		cursor := "  "
+		if i == d.cursor {
+			cursor = "> "
+		}
+
+		duration := sess.Duration().Round(time.Second).String()
+		if sess.Status() == session.UnstoppedStatus {
+			duration = "never stopped"
+		}
+
+		line := fmt.Sprintf(
+			"%v%v %v %v %v [%v]",
+			cursor,
+			utils.Faint(sess.Id),
+			utils.TimeColor(sess.StartTime.Format("2006-01-02 15:04")),
+			duration,
+			utils.ProjectColor(sess.Project),
+			utils.TagColor(strings.Join(sess.Tags, ", ")),
+		)
+		if sess.Note != "" {
+			line += " " + utils.Faint(sess.Note)
+		}
+		b.WriteString(line + "\n")
+	}
+
+	b.WriteString("\n")
+	if d.prompt != noPrompt {
+		fmt.Fprintf(&b, "%v: %v█\n", promptLabels[d.prompt], d.input)
+		b.WriteString(utils.Faint("enter: confirm • esc: cancel"))
+	} else {
+		if d.message != "" {
+			b.WriteString(d.message + "\n")
+		}
+		b.WriteString(utils.Faint("↑/↓: move • s: start/stop • e: project • g: tags • n: note • p/t/r: filter by project/tag/range • x: clear filters • q: quit"))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,163 @@
+package tui_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/TristanShz/flow/internal/infra/tui"
+	"github.com/TristanShz/flow/test"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/matryer/is"
+)
+
+func keys(model tea.Model, keys ...string) tea.Model {
+	for _, key := range keys {
+		var msg tea.KeyMsg
+		switch key {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		case "down":
+			msg = tea.KeyMsg{Type: tea.KeyDown}
+		case "backspace":
+			msg = tea.KeyMsg{Type: tea.KeyBackspace}
+		default:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+		}
+		model, _ = model.Update(msg)
+	}
+
+	return model
+}
+
+func givenSessions() []session.Session {
+	return []session.Session{
+		{
+			Id:        "1",
+			StartTime: time.Date(2024, time.April, 12, 9, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, time.April, 12, 10, 0, 0, 0, time.UTC),
+			Project:   "MyTodo",
+			Tags:      []string{"add-todo"},
+		},
+		{
+			Id:        "2",
+			StartTime: time.Date(2024, time.April, 13, 9, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, time.April, 13, 11, 0, 0, 0, time.UTC),
+			Project:   "Flow",
+			Tags:      []string{"tui"},
+		},
+	}
+}
+
+func TestDashboard(t *testing.T) {
+	tt := []struct {
+		name    string
+		keys    []string
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "Newest sessions first",
+			want: []string{"No session flowing", "Filters: none", "> 2 2024-04-13 09:00 2h0m0s Flow [tui]\n  1 2024-04-12 09:00 1h0m0s MyTodo [add-todo]"},
+		},
+		{
+			name:    "Filter by project",
+			keys:    []string{"p", "MyTodo", "enter"},
+			want:    []string{"Filters: project: MyTodo", "> 1 2024-04-12 09:00"},
+			notWant: []string{"Flow [tui]"},
+		},
+		{
+			name:    "Filter by tag",
+			keys:    []string{"t", "+tui", "enter"},
+			want:    []string{"Filters: tag: tui", "> 2 2024-04-13 09:00"},
+			notWant: []string{"MyTodo"},
+		},
+		{
+			name:    "Filter by range",
+			keys:    []string{"r", "todays", "backspace", "enter"},
+			want:    []string{"Filters: range: today"},
+			notWant: []string{"MyTodo"},
+		},
+		{
+			name: "Invalid range",
+			keys: []string{"r", "someday", "enter"},
+			want: []string{"Filters: none", "invalid"},
+		},
+		{
+			name: "Clear filters",
+			keys: []string{"p", "MyTodo", "enter", "x"},
+			want: []string{"Filters: none", "Filters cleared", "Flow [tui]", "MyTodo [add-todo]"},
+		},
+		{
+			name:    "Cancel a prompt",
+			keys:    []string{"p", "MyTodo", "esc"},
+			want:    []string{"Filters: none", "Flow [tui]"},
+			notWant: []string{"Filter by project:"},
+		},
+		{
+			name: "Prompt",
+			keys: []string{"n", "Wrote"},
+			want: []string{"Note: Wrote█"},
+		},
+		{
+			name: "Edit the note of the selected session",
+			keys: []string{"down", "n", "Fixed a bug", "enter"},
+			want: []string{"Session 1 edited", "> 1 2024-04-12 09:00 1h0m0s MyTodo [add-todo] Fixed a bug"},
+		},
+		{
+			name: "Edit the tags of the selected session",
+			keys: []string{"g", " +review", "enter"},
+			want: []string{"Session 2 edited", "Flow [tui, review]"},
+		},
+		{
+			name: "Start a session",
+			keys: []string{"s", "Flow +tui", "enter"},
+			want: []string{"Session started on Flow", "Flowing 0:00:00 Flow tui"},
+		},
+		{
+			name: "Start a session without project",
+			keys: []string{"s", "+tui", "enter"},
+			want: []string{"A project is required to start a session", "No session flowing"},
+		},
+		{
+			name: "Stop the current session",
+			keys: []string{"s", "Flow", "enter", "s"},
+			want: []string{"Session stopped after", "No session flowing"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			sessionRepository := &infra.InMemorySessionRepository{Sessions: givenSessions()}
+			dateProvider := &infra.StubDateProvider{Now: time.Date(2024, time.April, 13, 15, 0, 0, 0, time.UTC)}
+			app := test.InitializeApp(sessionRepository, dateProvider)
+
+			got := keys(tui.NewDashboard(app), tc.keys...).View()
+
+			for _, want := range tc.want {
+				is.True(strings.Contains(got, want)) // view should contain the wanted text
+			}
+			for _, notWant := range tc.notWant {
+				is.True(!strings.Contains(got, notWant)) // view shouldn't contain the text
+			}
+		})
+	}
+}
+
+func TestDashboardQuit(t *testing.T) {
+	is := is.New(t)
+
+	app := test.InitializeApp(&infra.InMemorySessionRepository{}, infra.NewStubDateProvider())
+
+	_, cmd := tui.NewDashboard(app).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+
+	is.True(cmd != nil)
+	_, ok := cmd().(tea.QuitMsg)
+	is.True(ok)
+}
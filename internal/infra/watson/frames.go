@@ -0,0 +1,90 @@
+package watson
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+)
+
+// externalIDPrefix marks the sessions imported from Watson
+const externalIDPrefix = "watson:"
+
+// FramesPath returns the frames file of Watson: in $WATSON_DIR when it's set,
+// in the config folder of the user otherwise
+func FramesPath(getenv func(string) string, configDir string) string {
+	if dir := getenv("WATSON_DIR"); dir != "" {
+		return filepath.Join(dir, "frames")
+	}
+
+	return filepath.Join(configDir, "watson", "frames")
+}
+
+// FramesImporter reads the sessions of the frames file of Watson, a JSON array
+// of [start, stop, project, id, tags, updated_at] frames with Unix timestamps
+type FramesImporter struct {
+	Reader io.Reader
+}
+
+func (i FramesImporter) Import() ([]session.Session, error) {
+	frames := [][]json.RawMessage{}
+	if err := json.NewDecoder(i.Reader).Decode(&frames); err != nil {
+		return nil, fmt.Errorf("invalid Watson frames file: %w", err)
+	}
+
+	sessions := []session.Session{}
+	for index, frame := range frames {
+		s, err := frameToSession(frame)
+		if err != nil {
+			return nil, fmt.Errorf("frame %v: %w", index+1, err)
+		}
+		sessions = append(sessions, s)
+	}
+
+	return sessions, nil
+}
+
+func frameToSession(frame []json.RawMessage) (session.Session, error) {
+	if len(frame) < 3 {
+		return session.Session{}, fmt.Errorf("expected at least a start, a stop and a project, got %v values", len(frame))
+	}
+
+	var start, stop int64
+	var project, id string
+	tags := []string{}
+
+	if err := json.Unmarshal(frame[0], &start); err != nil {
+		return session.Session{}, fmt.Errorf("invalid start %s", frame[0])
+	}
+	if err := json.Unmarshal(frame[1], &stop); err != nil {
+		return session.Session{}, fmt.Errorf("invalid stop %s", frame[1])
+	}
+	if err := json.Unmarshal(frame[2], &project); err != nil {
+		return session.Session{}, fmt.Errorf("invalid project %s", frame[2])
+	}
+	if len(frame) > 3 {
+		if err := json.Unmarshal(frame[3], &id); err != nil {
+			return session.Session{}, fmt.Errorf("invalid id %s", frame[3])
+		}
+	}
+	if len(frame) > 4 {
+		if err := json.Unmarshal(frame[4], &tags); err != nil {
+			return session.Session{}, fmt.Errorf("invalid tags %s", frame[4])
+		}
+	}
+
+	s := session.Session{
+		StartTime: time.Unix(start, 0),
+		EndTime:   time.Unix(stop, 0),
+		Project:   project,
+		Tags:      tags,
+	}
+	if id != "" {
+		s.Metadata = map[string]string{session.ExternalIDMetadata: externalIDPrefix + id}
+	}
+
+	return s, nil
+}
@@ -0,0 +1,75 @@
+package watson_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra/watson"
+	"github.com/matryer/is"
+)
+
+func TestFramesImporter(t *testing.T) {
+	tt := []struct {
+		name    string
+		frames  string
+		want    []session.Session
+		wantErr bool
+	}{
+		{
+			name:   "Frames",
+			frames: `[[1712912400, 1712917800, "flow", "3f2a", ["cli", "import"], 1712917800], [1712919600, 1712923200, "website"]]`,
+			want: []session.Session{
+				{
+					StartTime: time.Unix(1712912400, 0),
+					EndTime:   time.Unix(1712917800, 0),
+					Project:   "flow",
+					Tags:      []string{"cli", "import"},
+					Metadata:  map[string]string{session.ExternalIDMetadata: "watson:3f2a"},
+				},
+				{
+					StartTime: time.Unix(1712919600, 0),
+					EndTime:   time.Unix(1712923200, 0),
+					Project:   "website",
+					Tags:      []string{},
+				},
+			},
+		},
+		{
+			name:    "Not a frames file",
+			frames:  `{"project": "flow"}`,
+			wantErr: true,
+		},
+		{
+			name:    "Missing project",
+			frames:  `[[1712912400, 1712917800]]`,
+			wantErr: true,
+		},
+		{
+			name:    "Invalid tags",
+			frames:  `[[1712912400, 1712917800, "flow", "3f2a", "cli"]]`,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			got, err := watson.FramesImporter{Reader: strings.NewReader(tc.frames)}.Import()
+
+			is.Equal(err != nil, tc.wantErr)
+			if !tc.wantErr {
+				is.Equal(got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFramesPath(t *testing.T) {
+	is := is.New(t)
+
+	is.Equal(watson.FramesPath(func(string) string { return "" }, "/home/me/.config"), "/home/me/.config/watson/frames")
+	is.Equal(watson.FramesPath(func(string) string { return "/data/watson" }, "/home/me/.config"), "/data/watson/frames")
+}
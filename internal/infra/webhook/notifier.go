@@ -0,0 +1,116 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"text/template"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/infra/presenter"
+)
+
+// DefaultTimeout bounds each post, the commands wait for the webhooks
+// before exiting
+const DefaultTimeout = 5 * time.Second
+
+// templateFuncs are available to the payload templates, which are given the
+// presenter.EventJSON of the event. json quotes a value so that the payload
+// stays valid whatever the project, tags or note
+var templateFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		marshaled, err := json.Marshal(v)
+		return string(marshaled), err
+	},
+}
+
+type hook struct {
+	webhook  application.Webhook
+	template *template.Template
+}
+
+// Notifier posts the events of the sessions to the webhooks accepting them.
+// A failing webhook is reported to the error log, it never fails the
+// command which published the event.
+type Notifier struct {
+	HTTPClient *http.Client
+	ErrorLog   *log.Logger
+	hooks      []hook
+}
+
+func NewNotifier(webhooks []application.Webhook, errorLog *log.Logger) (*Notifier, error) {
+	notifier := &Notifier{
+		HTTPClient: &http.Client{Timeout: DefaultTimeout},
+		ErrorLog:   errorLog,
+	}
+
+	for _, webhook := range webhooks {
+		h := hook{webhook: webhook}
+
+		if webhook.Payload != "" {
+			t, err := template.New(webhook.Name).Funcs(templateFuncs).Option("missingkey=error").Parse(webhook.Payload)
+			if err != nil {
+				return nil, fmt.Errorf("invalid payload of the webhook %v: %w", webhook.Name, err)
+			}
+			h.template = t
+		}
+
+		notifier.hooks = append(notifier.hooks, h)
+	}
+
+	return notifier, nil
+}
+
+// Handle posts the event, it's the application.EventHandler of the notifier
+func (n *Notifier) Handle(event application.Event) {
+	for _, h := range n.hooks {
+		if !h.webhook.Accepts(event.Type) {
+			continue
+		}
+
+		if err := n.post(h, presenter.NewEventJSON(event)); err != nil {
+			n.ErrorLog.Printf("Warning: webhook %v: %v", h.webhook.Name, err)
+		}
+	}
+}
+
+func (n *Notifier) post(h hook, payload presenter.EventJSON) error {
+	body, err := render(h, payload)
+	if err != nil {
+		return err
+	}
+
+	response, err := n.HTTPClient.Post(h.webhook.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	io.Copy(io.Discard, response.Body)
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("%v answered %v", h.webhook.URL, response.Status)
+	}
+
+	return nil
+}
+
+func render(h hook, payload presenter.EventJSON) ([]byte, error) {
+	if h.template == nil {
+		return json.Marshal(payload)
+	}
+
+	body := &bytes.Buffer{}
+	if err := h.template.Execute(body, payload); err != nil {
+		return nil, err
+	}
+
+	if !json.Valid(body.Bytes()) {
+		return nil, fmt.Errorf("the payload isn't valid JSON: %v", body.String())
+	}
+
+	return body.Bytes(), nil
+}
@@ -0,0 +1,116 @@
+package webhook_test
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra/webhook"
+	"github.com/matryer/is"
+)
+
+func TestNotifier(t *testing.T) {
+	stopped := application.Event{
+		Type: application.EventSessionStopped,
+		At:   time.Date(2024, time.April, 13, 10, 30, 0, 0, time.UTC),
+		Session: session.Session{
+			Id:        "1",
+			StartTime: time.Date(2024, time.April, 13, 9, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, time.April, 13, 10, 30, 0, 0, time.UTC),
+			Project:   `Flow "CLI"`,
+			Tags:      []string{"deep"},
+		},
+	}
+
+	tt := []struct {
+		name         string
+		webhook      application.Webhook
+		event        application.Event
+		status       int
+		wantBody     string
+		wantErrorLog string
+		wantPosted   bool
+	}{
+		{
+			name:       "Event as JSON",
+			webhook:    application.Webhook{Name: "home"},
+			event:      stopped,
+			status:     http.StatusOK,
+			wantBody:   `{"event":"session.stopped","at":"2024-04-13T10:30:00Z","session":{"end_time":"2024-04-13T10:30:00Z","start_time":"2024-04-13T09:00:00Z","id":"1","project":"Flow \"CLI\"","status":"ENDED","tags":["deep"],"duration_seconds":5400},"duration":"1h30m0s"}`,
+			wantPosted: true,
+		},
+		{
+			name:       "Payload template",
+			webhook:    application.Webhook{Name: "slack", Payload: `{"text": {{json .Session.Project}}, "duration": "{{.Duration}}"}`},
+			event:      stopped,
+			status:     http.StatusOK,
+			wantBody:   `{"text": "Flow \"CLI\"", "duration": "1h30m0s"}`,
+			wantPosted: true,
+		},
+		{
+			name:    "Event not accepted by the webhook",
+			webhook: application.Webhook{Name: "slack", Events: []string{application.EventSessionStarted}},
+			event:   stopped,
+			status:  http.StatusOK,
+		},
+		{
+			name:         "Payload which isn't JSON",
+			webhook:      application.Webhook{Name: "slack", Payload: `{"text": {{.Session.Project}}}`},
+			event:        stopped,
+			status:       http.StatusOK,
+			wantErrorLog: "Warning: webhook slack: the payload isn't valid JSON: {\"text\": Flow \"CLI\"}\n",
+		},
+		{
+			name:         "Webhook failing",
+			webhook:      application.Webhook{Name: "home"},
+			event:        stopped,
+			status:       http.StatusInternalServerError,
+			wantErrorLog: "answered 500 Internal Server Error\n",
+			wantPosted:   true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			posted := false
+			var body []byte
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				posted = true
+				body, _ = io.ReadAll(r.Body)
+				is.Equal(r.Method, http.MethodPost)
+				is.Equal(r.Header.Get("Content-Type"), "application/json")
+				w.WriteHeader(tc.status)
+			}))
+			defer server.Close()
+
+			errorLog := &bytes.Buffer{}
+			tc.webhook.URL = server.URL
+			notifier, err := webhook.NewNotifier([]application.Webhook{tc.webhook}, log.New(errorLog, "", 0))
+			is.NoErr(err)
+
+			notifier.Handle(tc.event)
+
+			is.Equal(posted, tc.wantPosted)
+			if tc.wantBody != "" {
+				is.Equal(string(body), tc.wantBody)
+			}
+			is.True(bytes.HasSuffix(errorLog.Bytes(), []byte(tc.wantErrorLog))) // error log ends with the expected error
+		})
+	}
+}
+
+func TestNewNotifier_InvalidPayload(t *testing.T) {
+	is := is.New(t)
+
+	_, err := webhook.NewNotifier([]application.Webhook{{Name: "slack", URL: "https://example.com", Payload: `{"text": {{.Event}`}}, log.New(io.Discard, "", 0))
+
+	is.True(err != nil)
+}
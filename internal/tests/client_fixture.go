@@ -0,0 +1,72 @@
+package tests
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/TristanShz/flow/internal/application/usecases/client/listclients"
+	"github.com/TristanShz/flow/internal/application/usecases/client/setclient"
+	"github.com/TristanShz/flow/internal/domain/client"
+	"github.com/TristanShz/flow/internal/infra"
+)
+
+type ClientFixture struct {
+	ThrownError        error
+	T                  *testing.T
+	ClientRepository   *infra.InMemoryClientRepository
+	SetClientUseCase   setclient.UseCase
+	ListClientsUseCase listclients.UseCase
+	Clients            []client.Client
+}
+
+func (c *ClientFixture) GivenSomeClients(clients []client.Client) {
+	c.ClientRepository.Clients = clients
+}
+
+func (c *ClientFixture) WhenSettingClient(command setclient.Command) {
+	_, err := c.SetClientUseCase.Execute(command)
+	if err != nil {
+		c.ThrownError = err
+	}
+}
+
+func (c *ClientFixture) WhenListingClients() {
+	clients, err := c.ListClientsUseCase.Execute()
+	if err != nil {
+		c.ThrownError = err
+	}
+
+	c.Clients = clients
+}
+
+func (c *ClientFixture) ThenClientsShouldBe(clients []client.Client) {
+	got := c.ClientRepository.Clients
+
+	if !reflect.DeepEqual(got, clients) {
+		c.T.Errorf("Expected clients '%v', but got '%v'", clients, got)
+	}
+}
+
+func (c *ClientFixture) ThenListedClientsShouldBe(clients []client.Client) {
+	if !reflect.DeepEqual(c.Clients, clients) {
+		c.T.Errorf("Expected clients '%v', but got '%v'", clients, c.Clients)
+	}
+}
+
+func (c *ClientFixture) ThenErrorShouldBe(e error) {
+	if !errors.Is(c.ThrownError, e) {
+		c.T.Errorf("Expected error '%v', but got '%v'", e, c.ThrownError)
+	}
+}
+
+func GetClientFixture(t *testing.T) ClientFixture {
+	clientRepository := &infra.InMemoryClientRepository{}
+
+	return ClientFixture{
+		T:                  t,
+		ClientRepository:   clientRepository,
+		SetClientUseCase:   setclient.NewSetClientUseCase(clientRepository),
+		ListClientsUseCase: listclients.NewListClientsUseCase(clientRepository),
+	}
+}
@@ -0,0 +1,119 @@
+package tests
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/TristanShz/flow/internal/application/usecases/project/renameproject"
+	"github.com/TristanShz/flow/internal/application/usecases/project/setproject"
+	"github.com/TristanShz/flow/internal/application/usecases/project/synctemplates"
+	"github.com/TristanShz/flow/internal/domain/project"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/infra"
+)
+
+type ProjectFixture struct {
+	ThrownError          error
+	T                    *testing.T
+	ProjectRepository    *infra.InMemoryProjectRepository
+	SessionRepository    *infra.InMemorySessionRepository
+	SetProjectUseCase    setproject.UseCase
+	RenameProjectUseCase renameproject.UseCase
+	TemplatesRepository  *infra.InMemoryTemplatesRepository
+	TemplatesFetcher     *infra.StubTemplatesFetcher
+	SyncTemplatesUseCase synctemplates.UseCase
+	UpdatedSessions      int
+}
+
+func (p *ProjectFixture) GivenSomeProjects(projects []project.Project) {
+	p.ProjectRepository.Projects = projects
+}
+
+func (p *ProjectFixture) GivenSomeSessions(sessions []session.Session) {
+	p.SessionRepository.Sessions = sessions
+}
+
+func (p *ProjectFixture) GivenSomeTemplates(templates project.Templates) {
+	p.TemplatesRepository.Templates = templates
+}
+
+func (p *ProjectFixture) GivenTemplatesSource(source string, templates project.Templates) {
+	p.TemplatesFetcher.Templates[source] = templates
+}
+
+func (p *ProjectFixture) WhenRenamingProject(command renameproject.Command) {
+	updatedSessions, err := p.RenameProjectUseCase.Execute(command)
+	if err != nil {
+		p.ThrownError = err
+	}
+	p.UpdatedSessions = updatedSessions
+}
+
+func (p *ProjectFixture) WhenSettingProject(command setproject.Command) {
+	_, err := p.SetProjectUseCase.Execute(command)
+	if err != nil {
+		p.ThrownError = err
+	}
+}
+
+func (p *ProjectFixture) WhenSyncingTemplates(command synctemplates.Command) {
+	_, err := p.SyncTemplatesUseCase.Execute(command)
+	if err != nil {
+		p.ThrownError = err
+	}
+}
+
+func (p *ProjectFixture) ThenTemplatesShouldBe(templates project.Templates) {
+	got := p.TemplatesRepository.Templates
+
+	if !reflect.DeepEqual(got, templates) {
+		p.T.Errorf("Expected templates '%v', but got '%v'", templates, got)
+	}
+}
+
+func (p *ProjectFixture) ThenProjectsShouldBe(projects []project.Project) {
+	got := p.ProjectRepository.Projects
+
+	if !reflect.DeepEqual(got, projects) {
+		p.T.Errorf("Expected projects '%v', but got '%v'", projects, got)
+	}
+}
+
+func (p *ProjectFixture) ThenSessionsShouldBe(sessions []session.Session) {
+	got := p.SessionRepository.Sessions
+
+	if !reflect.DeepEqual(got, sessions) {
+		p.T.Errorf("Expected sessions '%v', but got '%v'", sessions, got)
+	}
+}
+
+func (p *ProjectFixture) ThenUpdatedSessionsShouldBe(count int) {
+	if p.UpdatedSessions != count {
+		p.T.Errorf("Expected %v updated sessions, but got %v", count, p.UpdatedSessions)
+	}
+}
+
+func (p *ProjectFixture) ThenErrorShouldBe(e error) {
+	if !errors.Is(p.ThrownError, e) {
+		p.T.Errorf("Expected error '%v', but got '%v'", e, p.ThrownError)
+	}
+}
+
+func GetProjectFixture(t *testing.T) ProjectFixture {
+	projectRepository := &infra.InMemoryProjectRepository{}
+	sessionRepository := &infra.InMemorySessionRepository{}
+	templatesRepository := &infra.InMemoryTemplatesRepository{}
+	templatesFetcher := &infra.StubTemplatesFetcher{Templates: map[string]project.Templates{}}
+
+	return ProjectFixture{
+		T:                    t,
+		ProjectRepository:    projectRepository,
+		SessionRepository:    sessionRepository,
+		SetProjectUseCase:    setproject.NewSetProjectUseCase(projectRepository),
+		RenameProjectUseCase: renameproject.NewRenameProjectUseCase(sessionRepository, projectRepository),
+		TemplatesRepository:  templatesRepository,
+		TemplatesFetcher:     templatesFetcher,
+		SyncTemplatesUseCase: synctemplates.NewSyncTemplatesUseCase(templatesFetcher, templatesRepository),
+	}
+}
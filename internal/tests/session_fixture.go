@@ -8,12 +8,38 @@ import (
 	"testing"
 	"time"
 
+	"github.com/TristanShz/flow/internal/application"
 	abortsession "github.com/TristanShz/flow/internal/application/usecases/flowsession/abort"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/adjustsession"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/autostop"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/deletesession"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/diffperiods"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/editsession"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/heatmap"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/logsession"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/meetingpause"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/mergesessions"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/pomodoro"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/reminders"
 	"github.com/TristanShz/flow/internal/application/usecases/flowsession/sessionstatus"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/showsession"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/splitsession"
 	startsession "github.com/TristanShz/flow/internal/application/usecases/flowsession/start"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/stats"
 	"github.com/TristanShz/flow/internal/application/usecases/flowsession/stopsession"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/suggeststop"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/suggesttags"
 	"github.com/TristanShz/flow/internal/application/usecases/flowsession/viewsessionsreport"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/weeklytrend"
+	"github.com/TristanShz/flow/internal/application/usecases/project/forecast"
+	"github.com/TristanShz/flow/internal/application/usecases/project/goals"
 	"github.com/TristanShz/flow/internal/application/usecases/project/list"
+	"github.com/TristanShz/flow/internal/application/usecases/project/listtags"
+	"github.com/TristanShz/flow/internal/application/usecases/tag/deletetag"
+	"github.com/TristanShz/flow/internal/application/usecases/tag/renametag"
+	"github.com/TristanShz/flow/internal/application/usecases/tag/retagsessions"
+	"github.com/TristanShz/flow/internal/domain/journal"
+	"github.com/TristanShz/flow/internal/domain/project"
 	"github.com/TristanShz/flow/internal/domain/session"
 	"github.com/TristanShz/flow/internal/domain/sessionsreport"
 	"github.com/TristanShz/flow/internal/infra"
@@ -23,6 +49,10 @@ import (
 type TestPresenter struct {
 	SessionsReportByDay     sessionsreport.SessionsReport
 	SessionsReportByProject sessionsreport.SessionsReport
+	SessionsReportByClient  sessionsreport.SessionsReport
+	EarningsReport          sessionsreport.EarningsReport
+	GapsReport              sessionsreport.GapsReport
+	PeriodsDiff             sessionsreport.PeriodsDiff
 }
 
 func (tp *TestPresenter) ShowByDay(sessionReport sessionsreport.SessionsReport) {
@@ -33,6 +63,22 @@ func (tp *TestPresenter) ShowByProject(sessionReport sessionsreport.SessionsRepo
 	tp.SessionsReportByProject = sessionReport
 }
 
+func (tp *TestPresenter) ShowByClient(sessionReport sessionsreport.SessionsReport) {
+	tp.SessionsReportByClient = sessionReport
+}
+
+func (tp *TestPresenter) ShowEarnings(earningsReport sessionsreport.EarningsReport) {
+	tp.EarningsReport = earningsReport
+}
+
+func (tp *TestPresenter) ShowGaps(gapsReport sessionsreport.GapsReport) {
+	tp.GapsReport = gapsReport
+}
+
+func (tp *TestPresenter) ShowComparison(periodsDiff sessionsreport.PeriodsDiff) {
+	tp.PeriodsDiff = periodsDiff
+}
+
 type SessionFixture struct {
 	StartFlowSessionUseCase   startsession.UseCase
 	FlowSessionStatusUseCase  sessionstatus.UseCase
@@ -41,15 +87,60 @@ type SessionFixture struct {
 	ThrownError               error
 	ListProjectsUseCase       list.UseCase
 	ViewSessionsReportUseCase viewsessionsreport.UseCase
+	WeeklyTrendUseCase        weeklytrend.UseCase
+	SuggestTagsUseCase        suggesttags.UseCase
+	AutostopUseCase           autostop.UseCase
+	EditSessionUseCase        editsession.UseCase
+	LogSessionUseCase         logsession.UseCase
+	SplitSessionUseCase       splitsession.UseCase
+	MergeSessionsUseCase      mergesessions.UseCase
+	RenameTagUseCase          renametag.UseCase
+	DeleteTagUseCase          deletetag.UseCase
+	RetagSessionsUseCase      retagsessions.UseCase
+	DiffPeriodsUseCase        diffperiods.UseCase
+	ShowSessionUseCase        showsession.UseCase
+	AdjustSessionUseCase      adjustsession.UseCase
+	MeetingPauseUseCase       meetingpause.UseCase
+	ListProjectTagsUseCase    listtags.UseCase
+	DeleteSessionUseCase      deletesession.UseCase
+	PomodoroUseCase           pomodoro.UseCase
+	RemindersUseCase          reminders.UseCase
+	SuggestStopUseCase        suggeststop.UseCase
+	ForecastUseCase           forecast.UseCase
+	StatsUseCase              stats.UseCase
+	HeatmapUseCase            heatmap.UseCase
+	GoalsUseCase              goals.UseCase
 	IdProvider                *infra.StubIDProvider
 	DateProvider              *infra.StubDateProvider
 	SessionRepository         *infra.InMemorySessionRepository
+	DailyTotalsCache          *infra.InMemoryDailyTotalsCache
+	ActiveSessionLock         *infra.InMemoryActiveSessionLock
+	ProjectRepository         *infra.InMemoryProjectRepository
+	JournalRepository         *infra.InMemoryJournalRepository
+	TemplatesRepository       *infra.InMemoryTemplatesRepository
+	AuditLog                  *infra.InMemoryAuditLog
+	EventPublisher            *infra.InMemoryEventPublisher
 	T                         *testing.T
 	Is                        *is.I
 	SessionsReportPresenter   TestPresenter
 	Projects                  []string
+	ProjectTags               []string
 	SessionsReport            sessionsreport.SessionsReport
 	FlowSessionStatus         sessionstatus.SessionStatus
+	WeeklyTrend               []time.Duration
+	SuggestedTags             []string
+	SuggestedStop             time.Time
+	Forecast                  forecast.Forecast
+	Stats                     stats.Stats
+	Heatmap                   sessionsreport.Heatmap
+	Goals                     []project.GoalProgress
+	AutostopAction            string
+	MeetingAction             string
+	UpdatedSessions           int
+	PeriodsDiff               sessionsreport.PeriodsDiff
+	SessionDetails            showsession.SessionDetails
+	PomodoroInterval          pomodoro.Interval
+	Reminders                 []reminders.Reminder
 }
 
 func (s *SessionFixture) GivenNowIs(t time.Time) {
@@ -64,6 +155,22 @@ func (s *SessionFixture) GivenSomeSessions(sessions []session.Session) {
 	s.SessionRepository.Sessions = sessions
 }
 
+func (s *SessionFixture) GivenActiveSession(active application.ActiveSession) {
+	s.ActiveSessionLock.Active = &active
+}
+
+func (s *SessionFixture) GivenSomeProjects(projects []project.Project) {
+	s.ProjectRepository.Projects = projects
+}
+
+func (s *SessionFixture) GivenSomeJournalEntries(entries []journal.Entry) {
+	s.JournalRepository.Entries = entries
+}
+
+func (s *SessionFixture) GivenSomeTemplates(templates project.Templates) {
+	s.TemplatesRepository.Templates = templates
+}
+
 func (s *SessionFixture) WhenStartingFlowSession(command startsession.Command) {
 	err := s.StartFlowSessionUseCase.Execute(command)
 	if err != nil {
@@ -71,8 +178,8 @@ func (s *SessionFixture) WhenStartingFlowSession(command startsession.Command) {
 	}
 }
 
-func (s *SessionFixture) WhenStoppingFlowSession() {
-	_, err := s.StopFlowSessionUseCase.Execute()
+func (s *SessionFixture) WhenStoppingFlowSession(command stopsession.Command) {
+	_, err := s.StopFlowSessionUseCase.Execute(command)
 	if err != nil {
 		s.ThrownError = err
 	}
@@ -96,6 +203,15 @@ func (s *SessionFixture) WhenGettingListOfProjects() {
 	s.Projects = projects
 }
 
+func (s *SessionFixture) WhenGettingListOfProjectTags(command listtags.Command) {
+	tags, err := s.ListProjectTagsUseCase.Execute(command)
+	if err != nil {
+		s.ThrownError = err
+	}
+
+	s.ProjectTags = tags
+}
+
 func (s *SessionFixture) WhenUserSeesSessionsReport(
 	command viewsessionsreport.Command,
 ) {
@@ -105,6 +221,182 @@ func (s *SessionFixture) WhenUserSeesSessionsReport(
 	}
 }
 
+func (s *SessionFixture) WhenUserSeesWeeklyTrend(command weeklytrend.Command) {
+	trend, err := s.WeeklyTrendUseCase.Execute(command)
+	if err != nil {
+		s.ThrownError = err
+	}
+
+	s.WeeklyTrend = trend
+}
+
+func (s *SessionFixture) WhenSuggestingTags(command suggesttags.Command) {
+	tags, err := s.SuggestTagsUseCase.Execute(command)
+	if err != nil {
+		s.ThrownError = err
+	}
+
+	s.SuggestedTags = tags
+}
+
+func (s *SessionFixture) WhenSuggestingStop(command suggeststop.Command) {
+	end, err := s.SuggestStopUseCase.Execute(command)
+	if err != nil {
+		s.ThrownError = err
+	}
+
+	s.SuggestedStop = end
+}
+
+func (s *SessionFixture) WhenComputingStats(command stats.Command) {
+	result, err := s.StatsUseCase.Execute(command)
+	if err != nil {
+		s.ThrownError = err
+	}
+
+	s.Stats = result
+}
+
+func (s *SessionFixture) WhenComputingHeatmap(command heatmap.Command) {
+	result, err := s.HeatmapUseCase.Execute(command)
+	if err != nil {
+		s.ThrownError = err
+	}
+
+	s.Heatmap = result
+}
+
+func (s *SessionFixture) WhenComputingGoals(command goals.Command) {
+	result, err := s.GoalsUseCase.Execute(command)
+	if err != nil {
+		s.ThrownError = err
+	}
+
+	s.Goals = result
+}
+
+func (s *SessionFixture) WhenForecasting(command forecast.Command) {
+	result, err := s.ForecastUseCase.Execute(command)
+	if err != nil {
+		s.ThrownError = err
+	}
+
+	s.Forecast = result
+}
+
+func (s *SessionFixture) WhenScreenLockChanges(command autostop.Command) {
+	action, err := s.AutostopUseCase.Execute(command)
+	if err != nil {
+		s.ThrownError = err
+	}
+
+	s.AutostopAction = action
+}
+
+func (s *SessionFixture) WhenMeetingChanges(command meetingpause.Command) {
+	action, err := s.MeetingPauseUseCase.Execute(command)
+	if err != nil {
+		s.ThrownError = err
+	}
+
+	s.MeetingAction = action
+}
+
+func (s *SessionFixture) WhenCheckingReminders(command reminders.Command) {
+	s.Reminders = s.RemindersUseCase.Execute(command)
+}
+
+func (s *SessionFixture) WhenRunningPomodoro(command pomodoro.Command) {
+	interval, err := s.PomodoroUseCase.Execute(command)
+	if err != nil {
+		s.ThrownError = err
+	}
+
+	s.PomodoroInterval = interval
+}
+
+func (s *SessionFixture) WhenEditingSession(command editsession.Command) {
+	_, err := s.EditSessionUseCase.Execute(command)
+	if err != nil {
+		s.ThrownError = err
+	}
+}
+
+func (s *SessionFixture) WhenAdjustingSession(command adjustsession.Command) {
+	_, err := s.AdjustSessionUseCase.Execute(command)
+	if err != nil {
+		s.ThrownError = err
+	}
+}
+
+func (s *SessionFixture) WhenLoggingSession(command logsession.Command) {
+	_, err := s.LogSessionUseCase.Execute(command)
+	if err != nil {
+		s.ThrownError = err
+	}
+}
+
+func (s *SessionFixture) WhenSplittingSession(command splitsession.Command) {
+	_, err := s.SplitSessionUseCase.Execute(command)
+	if err != nil {
+		s.ThrownError = err
+	}
+}
+
+func (s *SessionFixture) WhenDeletingSession(command deletesession.Command) {
+	_, err := s.DeleteSessionUseCase.Execute(command)
+	if err != nil {
+		s.ThrownError = err
+	}
+}
+
+func (s *SessionFixture) WhenMergingSessions(command mergesessions.Command) {
+	_, err := s.MergeSessionsUseCase.Execute(command)
+	if err != nil {
+		s.ThrownError = err
+	}
+}
+
+func (s *SessionFixture) WhenRenamingTag(command renametag.Command) {
+	updatedSessions, err := s.RenameTagUseCase.Execute(command)
+	if err != nil {
+		s.ThrownError = err
+	}
+	s.UpdatedSessions = updatedSessions
+}
+
+func (s *SessionFixture) WhenDeletingTag(command deletetag.Command) {
+	updatedSessions, err := s.DeleteTagUseCase.Execute(command)
+	if err != nil {
+		s.ThrownError = err
+	}
+	s.UpdatedSessions = updatedSessions
+}
+
+func (s *SessionFixture) WhenRetaggingSessions(command retagsessions.Command) {
+	updatedSessions, err := s.RetagSessionsUseCase.Execute(command)
+	if err != nil {
+		s.ThrownError = err
+	}
+	s.UpdatedSessions = updatedSessions
+}
+
+func (s *SessionFixture) WhenDiffingPeriods(command diffperiods.Command) {
+	diff, err := s.DiffPeriodsUseCase.Execute(command)
+	if err != nil {
+		s.ThrownError = err
+	}
+	s.PeriodsDiff = diff
+}
+
+func (s *SessionFixture) WhenShowingSession(command showsession.Command) {
+	details, err := s.ShowSessionUseCase.Execute(command)
+	if err != nil {
+		s.ThrownError = err
+	}
+	s.SessionDetails = details
+}
+
 func (s *SessionFixture) WhenAbortingFlowSession() {
 	err := s.AbortFlowSessionUseCase.Execute()
 	if err != nil {
@@ -113,7 +405,8 @@ func (s *SessionFixture) WhenAbortingFlowSession() {
 }
 
 func (s *SessionFixture) ThenNoSessionShouldBeActive() {
-	got := s.SessionRepository.FindLastSession()
+	got, err := s.SessionRepository.FindLastSession()
+	s.Is.NoErr(err)
 
 	if got != nil {
 		s.Is.Equal(got.Status(), session.EndedStatus)
@@ -128,6 +421,19 @@ func (s SessionFixture) ThenUserShouldSeeSessionsReport(expectedReport sessionsr
 	if expectedFormat == sessionsreport.FormatByProject {
 		got = s.SessionsReportPresenter.SessionsReportByProject
 	}
+	if expectedFormat == sessionsreport.FormatByClient {
+		got = s.SessionsReportPresenter.SessionsReportByClient
+	}
+
+	// the reports by project and by client only hold the totals of their
+	// sessions
+	if expectedFormat != sessionsreport.FormatByDay {
+		s.Is.Equal(got.Count(), expectedReport.Count())
+		s.Is.Equal(got.TotalDuration(), expectedReport.TotalDuration())
+		s.Is.Equal(got.GetByProjectReport(), expectedReport.GetByProjectReport())
+		s.Is.Equal(got.GetByClientReport(), expectedReport.GetByClientReport())
+		return
+	}
 
 	if !reflect.DeepEqual(got, expectedReport) {
 		s.T.Errorf("Expected report with session ids '%v', but got '%v'", s.formatReportForError(expectedReport), s.formatReportForError(got))
@@ -143,6 +449,50 @@ func (s SessionFixture) formatReportForError(expectedReport sessionsreport.Sessi
 	return strings.Join(ids, ", ")
 }
 
+func (s *SessionFixture) ThenPeriodsDiffShouldBe(expectedDiff sessionsreport.PeriodsDiff) {
+	if !reflect.DeepEqual(s.PeriodsDiff, expectedDiff) {
+		s.T.Errorf("Expected periods diff '%+v', but got '%+v'", expectedDiff, s.PeriodsDiff)
+	}
+}
+
+func (s *SessionFixture) ThenSessionDetailsShouldBe(expectedDetails showsession.SessionDetails) {
+	if !reflect.DeepEqual(s.SessionDetails, expectedDetails) {
+		s.T.Errorf("Expected session details '%+v', but got '%+v'", expectedDetails, s.SessionDetails)
+	}
+}
+
+func (s *SessionFixture) ThenUserShouldSeeEarningsReport(expectedReport sessionsreport.EarningsReport) {
+	got := s.SessionsReportPresenter.EarningsReport
+
+	if !reflect.DeepEqual(got, expectedReport) {
+		s.T.Errorf("Expected earnings report '%+v', but got '%+v'", expectedReport, got)
+	}
+}
+
+func (s *SessionFixture) ThenUserShouldSeeGapsReport(expectedReport sessionsreport.GapsReport) {
+	got := s.SessionsReportPresenter.GapsReport
+
+	if !reflect.DeepEqual(got, expectedReport) {
+		s.T.Errorf("Expected gaps report '%+v', but got '%+v'", expectedReport, got)
+	}
+}
+
+func (s *SessionFixture) ThenUserShouldSeeComparison(expectedDiff sessionsreport.PeriodsDiff) {
+	got := s.SessionsReportPresenter.PeriodsDiff
+
+	if !reflect.DeepEqual(got, expectedDiff) {
+		s.T.Errorf("Expected comparison '%+v', but got '%+v'", expectedDiff, got)
+	}
+}
+
+func (s *SessionFixture) ThenProjectSettingsShouldBe(projects []project.Project) {
+	got := s.ProjectRepository.Projects
+
+	if !reflect.DeepEqual(got, projects) {
+		s.T.Errorf("Expected project settings '%+v', but got '%+v'", projects, got)
+	}
+}
+
 func (s *SessionFixture) ThenProjectsShouldBe(projects []string) {
 	got := s.Projects
 
@@ -151,6 +501,61 @@ func (s *SessionFixture) ThenProjectsShouldBe(projects []string) {
 	}
 }
 
+func (s *SessionFixture) ThenProjectTagsShouldBe(tags []string) {
+	got := s.ProjectTags
+
+	if !slices.Equal(got, tags) {
+		s.T.Errorf("Expected project tags '%v', but got '%v'", tags, got)
+	}
+}
+
+func (s *SessionFixture) ThenWeeklyTrendShouldBe(trend []time.Duration) {
+	got := s.WeeklyTrend
+
+	if !slices.Equal(got, trend) {
+		s.T.Errorf("Expected weekly trend '%v', but got '%v'", trend, got)
+	}
+}
+
+func (s *SessionFixture) ThenSuggestedTagsShouldBe(tags []string) {
+	got := s.SuggestedTags
+
+	if !slices.Equal(got, tags) {
+		s.T.Errorf("Expected suggested tags '%v', but got '%v'", tags, got)
+	}
+}
+
+func (s *SessionFixture) ThenSuggestedStopShouldBe(end time.Time) {
+	if !s.SuggestedStop.Equal(end) {
+		s.T.Errorf("Expected suggested stop '%v', but got '%v'", end, s.SuggestedStop)
+	}
+}
+
+func (s *SessionFixture) ThenStatsShouldBe(expected stats.Stats) {
+	s.Is.Equal(s.Stats, expected)
+}
+
+func (s *SessionFixture) ThenHeatmapShouldBe(expected sessionsreport.Heatmap) {
+	s.Is.Equal(s.Heatmap, expected)
+}
+
+func (s *SessionFixture) ThenGoalsShouldBe(expected []project.GoalProgress) {
+	s.Is.Equal(s.Goals, expected)
+}
+
+func (s *SessionFixture) ThenForecastShouldBe(expected forecast.Forecast) {
+	s.Is.Equal(s.Forecast, expected)
+}
+
+func (s *SessionFixture) ThenLastSessionShouldBe(expected session.Session) {
+	got, err := s.SessionRepository.FindLastSession()
+	s.Is.NoErr(err)
+
+	if got.Note != expected.Note || !slices.Equal(got.Tags, expected.Tags) {
+		s.T.Errorf("Expected last session with note '%v' and tags '%v', but got '%v' and '%v'", expected.Note, expected.Tags, got.Note, got.Tags)
+	}
+}
+
 func (s *SessionFixture) ThenUserShouldSee(session session.Session, duration time.Duration) {
 	got := s.FlowSessionStatus
 
@@ -172,13 +577,101 @@ func (s *SessionFixture) ThenSessionShouldBeSaved(session session.Session) {
 }
 
 func (s *SessionFixture) ThenSessionShouldBeStopped() {
-	got := s.SessionRepository.FindLastSession()
+	got, err := s.SessionRepository.FindLastSession()
+	s.Is.NoErr(err)
 
 	if got.EndTime.IsZero() {
 		s.T.Errorf("Found not stopped session '%v'", got)
 	}
 }
 
+func (s *SessionFixture) ThenLastSessionMetadataShouldBe(metadata map[string]string) {
+	got, err := s.SessionRepository.FindLastSession()
+	s.Is.NoErr(err)
+
+	if !reflect.DeepEqual(got.Metadata, metadata) {
+		s.T.Errorf("Expected metadata '%v', but got '%v'", metadata, got.Metadata)
+	}
+}
+
+func (s *SessionFixture) ThenActiveSessionShouldBe(sessionId string) {
+	got := ""
+	if s.ActiveSessionLock.Active != nil {
+		got = s.ActiveSessionLock.Active.SessionId
+	}
+
+	if got != sessionId {
+		s.T.Errorf("Expected active session '%v', but got '%v'", sessionId, got)
+	}
+}
+
+func (s *SessionFixture) ThenAutostopActionShouldBe(action string) {
+	if s.AutostopAction != action {
+		s.T.Errorf("Expected autostop action '%v', but got '%v'", action, s.AutostopAction)
+	}
+}
+
+func (s *SessionFixture) ThenRemindersShouldBe(reminders []reminders.Reminder) {
+	if !reflect.DeepEqual(s.Reminders, reminders) {
+		s.T.Errorf("Expected reminders '%+v', but got '%+v'", reminders, s.Reminders)
+	}
+}
+
+func (s *SessionFixture) ThenPomodoroIntervalShouldBe(interval pomodoro.Interval) {
+	if !reflect.DeepEqual(s.PomodoroInterval, interval) {
+		s.T.Errorf("Expected pomodoro interval '%+v', but got '%+v'", interval, s.PomodoroInterval)
+	}
+}
+
+func (s *SessionFixture) ThenMeetingActionShouldBe(action string) {
+	if s.MeetingAction != action {
+		s.T.Errorf("Expected meeting action '%v', but got '%v'", action, s.MeetingAction)
+	}
+}
+
+func (s *SessionFixture) ThenSessionsShouldBe(sessions []session.Session) {
+	got := s.SessionRepository.Sessions
+
+	if !reflect.DeepEqual(got, sessions) {
+		s.T.Errorf("Expected sessions '%v', but got '%v'", sessions, got)
+	}
+}
+
+func (s *SessionFixture) ThenSessionIdsShouldBe(ids []string) {
+	got := []string{}
+	for _, session := range s.SessionRepository.Sessions {
+		got = append(got, session.Id)
+	}
+
+	if !slices.Equal(got, ids) {
+		s.T.Errorf("Expected sessions '%v', but got '%v'", ids, got)
+	}
+}
+
+func (s *SessionFixture) ThenUpdatedSessionsShouldBe(count int) {
+	if s.UpdatedSessions != count {
+		s.T.Errorf("Expected %v updated sessions, but got %v", count, s.UpdatedSessions)
+	}
+}
+
+func (s *SessionFixture) ThenAuditEntriesShouldBe(entries []application.AuditEntry) {
+	got := s.AuditLog.Entries
+	if !reflect.DeepEqual(got, entries) {
+		s.T.Errorf("Expected audit entries '%v', but got '%v'", entries, got)
+	}
+}
+
+// ThenPublishedEventsShouldBe compares the types of the published events
+func (s *SessionFixture) ThenPublishedEventsShouldBe(types []string) {
+	got := []string{}
+	for _, event := range s.EventPublisher.Events {
+		got = append(got, event.Type)
+	}
+	if !slices.Equal(got, types) {
+		s.T.Errorf("Expected published events '%v', but got '%v'", types, got)
+	}
+}
+
 func (s *SessionFixture) ThenErrorShouldBe(e error) {
 	if !errors.Is(s.ThrownError, e) {
 		s.T.Errorf("Expected error '%v', but got '%v'", e, s.ThrownError)
@@ -190,21 +683,76 @@ func GetSessionFixture(t *testing.T) SessionFixture {
 	sessionRepository := &infra.InMemorySessionRepository{}
 	dateProvider := infra.NewStubDateProvider()
 	idProvider := &infra.StubIDProvider{}
-
-	startFlowSession := startsession.NewStartFlowSessionUseCase(sessionRepository, dateProvider, idProvider)
-	stopFlowSession := stopsession.NewStopSessionUseCase(sessionRepository, dateProvider)
-	abortFlowSession := abortsession.NewAbortFlowSessionUseCase(sessionRepository)
+	activeSessionLock := &infra.InMemoryActiveSessionLock{}
+	projectRepository := &infra.InMemoryProjectRepository{}
+	journalRepository := &infra.InMemoryJournalRepository{}
+	templatesRepository := &infra.InMemoryTemplatesRepository{}
+	eventPublisher := &infra.InMemoryEventPublisher{}
+
+	startFlowSession := startsession.NewStartFlowSessionUseCase(sessionRepository, dateProvider, idProvider, activeSessionLock, projectRepository, templatesRepository, eventPublisher)
+	stopFlowSession := stopsession.NewStopSessionUseCase(sessionRepository, dateProvider, activeSessionLock, projectRepository, idProvider, eventPublisher)
+	abortFlowSession := abortsession.NewAbortFlowSessionUseCase(sessionRepository, activeSessionLock)
 	flowSessionStatus := sessionstatus.NewFlowSessionStatusUseCase(sessionRepository, dateProvider)
 
-	viewSessionsReport := viewsessionsreport.NewViewSessionsReportUseCase(sessionRepository)
+	viewSessionsReport := viewsessionsreport.NewViewSessionsReportUseCase(sessionRepository, projectRepository, journalRepository, dateProvider)
 	sessionsReportPresenter := TestPresenter{}
 
 	listProjects := list.NewListProjectsUseCase(sessionRepository)
 
+	dailyTotalsCache := &infra.InMemoryDailyTotalsCache{}
+	weeklyTrend := weeklytrend.NewWeeklyTrendUseCase(sessionRepository, dailyTotalsCache, dateProvider)
+
+	suggestTags := suggesttags.NewSuggestTagsUseCase(sessionRepository)
+
+	autostopSession := autostop.NewAutostopUseCase(sessionRepository, projectRepository, idProvider, activeSessionLock)
+
+	editSession := editsession.NewEditSessionUseCase(sessionRepository, dateProvider, eventPublisher)
+
+	logSession := logsession.NewLogSessionUseCase(sessionRepository, dateProvider, idProvider)
+
+	splitSession := splitsession.NewSplitSessionUseCase(sessionRepository, idProvider)
+
+	mergeSessions := mergesessions.NewMergeSessionsUseCase(sessionRepository)
+
+	renameTag := renametag.NewRenameTagUseCase(sessionRepository)
+
+	deleteTag := deletetag.NewDeleteTagUseCase(sessionRepository)
+
+	retagSessions := retagsessions.NewRetagSessionsUseCase(sessionRepository)
+
+	diffPeriods := diffperiods.NewDiffPeriodsUseCase(sessionRepository)
+
+	showSession := showsession.NewShowSessionUseCase(sessionRepository)
+
+	auditLog := &infra.InMemoryAuditLog{}
+	adjustSession := adjustsession.NewAdjustSessionUseCase(sessionRepository, dateProvider, auditLog)
+
+	meetingPause := meetingpause.NewMeetingPauseUseCase(sessionRepository, projectRepository, idProvider, activeSessionLock, eventPublisher)
+
+	listProjectTags := listtags.NewListProjectTagsUseCase(sessionRepository)
+
+	deleteSession := deletesession.NewDeleteSessionUseCase(sessionRepository, activeSessionLock)
+
+	pomodoro := pomodoro.NewPomodoroUseCase(sessionRepository, dateProvider, startFlowSession, stopFlowSession)
+
+	reminders := reminders.NewRemindersUseCase(sessionRepository, dateProvider)
+
+	suggestStop := suggeststop.NewSuggestStopUseCase(sessionRepository, dateProvider)
+
+	forecast := forecast.NewForecastUseCase(sessionRepository, dateProvider)
+
+	stats := stats.NewStatsUseCase(sessionRepository, dateProvider)
+
+	heatmap := heatmap.NewHeatmapUseCase(sessionRepository, dateProvider)
+
+	goals := goals.NewGoalsUseCase(sessionRepository, projectRepository, dateProvider)
+
 	return SessionFixture{
 		T:                         t,
 		Is:                        is,
 		SessionRepository:         sessionRepository,
+		DailyTotalsCache:          dailyTotalsCache,
+		ActiveSessionLock:         activeSessionLock,
 		IdProvider:                idProvider,
 		DateProvider:              dateProvider,
 		StartFlowSessionUseCase:   startFlowSession,
@@ -214,5 +762,33 @@ func GetSessionFixture(t *testing.T) SessionFixture {
 		ListProjectsUseCase:       listProjects,
 		ViewSessionsReportUseCase: viewSessionsReport,
 		SessionsReportPresenter:   sessionsReportPresenter,
+		WeeklyTrendUseCase:        weeklyTrend,
+		SuggestTagsUseCase:        suggestTags,
+		AutostopUseCase:           autostopSession,
+		ProjectRepository:         projectRepository,
+		JournalRepository:         journalRepository,
+		TemplatesRepository:       templatesRepository,
+		EditSessionUseCase:        editSession,
+		LogSessionUseCase:         logSession,
+		SplitSessionUseCase:       splitSession,
+		MergeSessionsUseCase:      mergeSessions,
+		RenameTagUseCase:          renameTag,
+		DeleteTagUseCase:          deleteTag,
+		RetagSessionsUseCase:      retagSessions,
+		DiffPeriodsUseCase:        diffPeriods,
+		ShowSessionUseCase:        showSession,
+		AdjustSessionUseCase:      adjustSession,
+		AuditLog:                  auditLog,
+		EventPublisher:            eventPublisher,
+		MeetingPauseUseCase:       meetingPause,
+		ListProjectTagsUseCase:    listProjectTags,
+		DeleteSessionUseCase:      deleteSession,
+		PomodoroUseCase:           pomodoro,
+		RemindersUseCase:          reminders,
+		SuggestStopUseCase:        suggestStop,
+		ForecastUseCase:           forecast,
+		StatsUseCase:              stats,
+		HeatmapUseCase:            heatmap,
+		GoalsUseCase:              goals,
 	}
 }
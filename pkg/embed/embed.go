@@ -0,0 +1,260 @@
+// Package embed tracks time from other Go programs, like task runners or
+// editor daemons, without running the flow command. The sessions are stored
+// in the flow folder like the flow command stores them, so that they're in
+// its reports, and the settings of the config file of flow are applied.
+//
+// The API is kept small and stable: it only exposes the types of this
+// package, never the internal ones of flow.
+package embed
+
+//go:generate go run ./internal/gen
+
+import (
+	"cmp"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/TristanShz/flow/internal/application"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/logsession"
+	startsession "github.com/TristanShz/flow/internal/application/usecases/flowsession/start"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/stopsession"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/viewsessionsreport"
+	"github.com/TristanShz/flow/internal/domain/session"
+	"github.com/TristanShz/flow/internal/domain/sessionsreport"
+	"github.com/TristanShz/flow/internal/infra"
+	"github.com/TristanShz/flow/internal/infra/age"
+	"github.com/TristanShz/flow/internal/infra/config"
+	"github.com/TristanShz/flow/internal/infra/filesystem"
+	"github.com/TristanShz/flow/internal/infra/hooks"
+	"github.com/TristanShz/flow/internal/infra/passphrase"
+	"github.com/TristanShz/flow/internal/infra/socket"
+)
+
+// The errors of the tracker, they're compared with errors.Is
+var (
+	ErrAlreadyStarted = startsession.ErrSessionAlreadyStarted
+	ErrNotStarted     = stopsession.ErrNoCurrentSession
+	ErrOverlap        = session.ErrOverlap
+)
+
+// Session is a session of work on a project, End is zero while the session
+// is in progress
+type Session struct {
+	ID      string
+	Project string
+	Tags    []string
+	Note    string
+	Start   time.Time
+	End     time.Time
+}
+
+func newSession(s session.Session) Session {
+	return Session{
+		ID:      s.Id,
+		Project: s.Project,
+		Tags:    s.Tags,
+		Note:    s.Note,
+		Start:   s.StartTime,
+		End:     s.EndTime,
+	}
+}
+
+// ProjectTotal is the time worked on a project
+type ProjectTotal struct {
+	Project  string
+	Duration time.Duration
+}
+
+// Report is the time worked between Since and Until, by project from the
+// most to the least worked
+type Report struct {
+	Since    time.Time
+	Until    time.Time
+	Total    time.Duration
+	Projects []ProjectTotal
+}
+
+// Tracker starts, stops, logs and reports the sessions of a flow folder
+type Tracker struct {
+	config            application.Config
+	sessionRepository application.SessionRepository
+	daemonClient      *socket.Client
+	start             startsession.UseCase
+	stop              stopsession.UseCase
+	log               logsession.UseCase
+	report            viewsessionsreport.UseCase
+}
+
+// Open returns the tracker of the flow folder, or of the flow folder of the
+// config file when it's empty. The sessions go through 'flow daemon' when
+// it's running, and the hooks of the flow folder are run on its events.
+func Open(flowFolder string) (*Tracker, error) {
+	userConfig, err := config.Load(config.Path(os.Getenv), os.Getenv)
+	if err != nil {
+		return nil, err
+	}
+
+	if flowFolder == "" {
+		homePath, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		flowFolder = config.FlowFolder(userConfig.FlowFolder, homePath, os.Getenv)
+	}
+
+	// the daemon socket is found from the absolute path
+	path, err := filepath.Abs(flowFolder)
+	if err != nil {
+		return nil, err
+	}
+
+	fileSystemSessionRepository := filesystem.NewFileSystemSessionRepository(path)
+	if userConfig.Encryption.UsesPassphrase() {
+		fileSystemSessionRepository.Cipher = passphrase.NewCipher(userConfig.Encryption.Passphrase, userConfig.Encryption.KeyFile)
+	} else if userConfig.Encryption.Enabled() || userConfig.Encryption.Identity != "" {
+		fileSystemSessionRepository.Cipher = age.NewCipher(userConfig.Encryption.Recipients, userConfig.Encryption.Identity)
+	}
+	fileSystemSessionRepository.TrashRetention = userConfig.TrashRetention()
+	fileSystemActiveSessionLock := filesystem.NewFileSystemActiveSessionLock(path)
+
+	tracker := &Tracker{config: userConfig}
+
+	var activeSessionLock application.ActiveSessionLock = &fileSystemActiveSessionLock
+	tracker.sessionRepository = &fileSystemSessionRepository
+	if daemonClient, err := socket.Dial(socket.Path(path), log.New(os.Stderr, "", 0)); err == nil {
+		tracker.daemonClient = daemonClient
+		tracker.sessionRepository = daemonClient
+		activeSessionLock = daemonClient
+	}
+
+	projectRepository := filesystem.NewFileSystemProjectRepository(path)
+	journalRepository := filesystem.NewFileSystemJournalRepository(path)
+	templatesRepository := filesystem.NewFileSystemTemplatesRepository(path)
+	eventBus := &application.EventBus{}
+	eventBus.Subscribe(hooks.NewRunner(path, os.Stderr, log.New(os.Stderr, "", 0)).Handle)
+
+	dateProvider := &infra.RealDateProvider{}
+	sessionIDProvider := filesystem.NewSessionIDProvider(&fileSystemSessionRepository, &infra.RealIDProvider{})
+
+	tracker.start = startsession.NewStartFlowSessionUseCase(tracker.sessionRepository, dateProvider, &sessionIDProvider, activeSessionLock, &projectRepository, &templatesRepository, eventBus)
+	tracker.stop = stopsession.NewStopSessionUseCase(tracker.sessionRepository, dateProvider, activeSessionLock, &projectRepository, &sessionIDProvider, eventBus)
+	tracker.log = logsession.NewLogSessionUseCase(tracker.sessionRepository, dateProvider, &sessionIDProvider)
+	tracker.report = viewsessionsreport.NewViewSessionsReportUseCase(tracker.sessionRepository, &projectRepository, &journalRepository, dateProvider)
+
+	return tracker, nil
+}
+
+// Close closes the connection to 'flow daemon', if any
+func (t *Tracker) Close() error {
+	if t.daemonClient == nil {
+		return nil
+	}
+
+	return t.daemonClient.Close()
+}
+
+// Start starts a session of the project with the tags, or with the default
+// tags of the config file when there are none
+func (t *Tracker) Start(project string, tags ...string) (Session, error) {
+	if len(tags) == 0 {
+		tags = t.config.DefaultTags
+	}
+
+	err := t.start.Execute(startsession.Command{
+		Project:  project,
+		Tags:     tags,
+		TagRules: t.config.TagRules,
+		Overlap:  t.config.Overlap,
+	})
+	// the session is started in spite of an overlap warning
+	var warning *session.OverlapWarning
+	if err != nil && !errors.As(err, &warning) {
+		return Session{}, err
+	}
+
+	return t.lastSession()
+}
+
+// Stop stops the session in progress and returns it
+func (t *Tracker) Stop() (Session, error) {
+	_, err := t.stop.Execute(stopsession.Command{TagRules: t.config.TagRules})
+	if err != nil && !errors.Is(err, stopsession.ErrClockWentBackwards) {
+		return Session{}, err
+	}
+
+	return t.lastSession()
+}
+
+// lastSession returns the session just started or stopped
+func (t *Tracker) lastSession() (Session, error) {
+	last, err := t.sessionRepository.FindLastSession()
+	if err != nil || last == nil {
+		return Session{}, err
+	}
+
+	return newSession(*last), nil
+}
+
+// Log adds a session of the project which took place between start and end
+func (t *Tracker) Log(project string, start time.Time, end time.Time, tags ...string) (Session, error) {
+	logged, err := t.log.Execute(logsession.Command{
+		StartTime:    start,
+		EndTime:      end,
+		Project:      project,
+		Tags:         tags,
+		Overlap:      t.config.Overlap,
+		LockedBefore: t.config.LockedBefore(time.Now()),
+	})
+	var warning *session.OverlapWarning
+	if err != nil && !errors.As(err, &warning) {
+		return Session{}, err
+	}
+
+	return newSession(logged), nil
+}
+
+// Report returns the time worked between since and until, a zero time
+// leaves the period open on its side. The durations are rounded like the
+// reports of the flow command.
+func (t *Tracker) Report(since time.Time, until time.Time) (Report, error) {
+	presenter := &reportPresenter{}
+	err := t.report.Execute(viewsessionsreport.Command{
+		Since:    since,
+		Until:    until,
+		Format:   sessionsreport.FormatByProject,
+		Rounding: t.config.Rounding,
+	}, presenter)
+	if err != nil {
+		return Report{}, err
+	}
+
+	report := Report{Since: since, Until: until, Projects: []ProjectTotal{}}
+	for _, p := range presenter.projects {
+		report.Total += p.TotalDuration
+		report.Projects = append(report.Projects, ProjectTotal{Project: p.Project, Duration: p.TotalDuration})
+	}
+	slices.SortFunc(report.Projects, func(a ProjectTotal, b ProjectTotal) int {
+		return cmp.Or(cmp.Compare(b.Duration, a.Duration), cmp.Compare(a.Project, b.Project))
+	})
+
+	return report, nil
+}
+
+// reportPresenter keeps the projects of the report instead of printing them
+type reportPresenter struct {
+	projects []sessionsreport.ProjectReport
+}
+
+func (p *reportPresenter) ShowByProject(sessionsReport sessionsreport.SessionsReport) {
+	p.projects = sessionsReport.GetByProjectReport()
+}
+
+func (p *reportPresenter) ShowByDay(sessionsreport.SessionsReport)    {}
+func (p *reportPresenter) ShowByClient(sessionsreport.SessionsReport) {}
+func (p *reportPresenter) ShowEarnings(sessionsreport.EarningsReport) {}
+func (p *reportPresenter) ShowGaps(sessionsreport.GapsReport)         {}
+func (p *reportPresenter) ShowComparison(sessionsreport.PeriodsDiff)  {}
@@ -0,0 +1,66 @@
+package embed_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/pkg/embed"
+	"github.com/matryer/is"
+)
+
+func TestMain(m *testing.M) {
+	// the config file of the user mustn't change the sessions of the tests
+	os.Setenv("FLOW_CONFIG", filepath.Join(os.TempDir(), "flow-embed-test-missing.toml"))
+
+	os.Exit(m.Run())
+}
+
+func TestTracker(t *testing.T) {
+	is := is.New(t)
+
+	tracker, err := embed.Open(t.TempDir())
+	is.NoErr(err)
+	defer tracker.Close()
+
+	_, err = tracker.Stop()
+	is.Equal(err, embed.ErrNotStarted)
+
+	started, err := tracker.Start("Flow", "embed")
+	is.NoErr(err)
+	is.Equal(started.Project, "Flow")
+	is.Equal(started.Tags, []string{"embed"})
+	is.True(started.End.IsZero())
+
+	_, err = tracker.Start("Flow")
+	is.Equal(err, embed.ErrAlreadyStarted)
+
+	stopped, err := tracker.Stop()
+	is.NoErr(err)
+	is.Equal(stopped.ID, started.ID)
+	is.True(!stopped.End.IsZero())
+
+	day := time.Date(2024, time.April, 15, 0, 0, 0, 0, time.Local)
+	logged, err := tracker.Log("Website", day.Add(9*time.Hour), day.Add(10*time.Hour), "review")
+	is.NoErr(err)
+	is.Equal(logged.Tags, []string{"review"})
+
+	_, err = tracker.Log("Intranet", day.Add(9*time.Hour+30*time.Minute), day.Add(11*time.Hour))
+	is.Equal(err, embed.ErrOverlap)
+
+	_, err = tracker.Log("Intranet", day.Add(14*time.Hour), day.Add(16*time.Hour))
+	is.NoErr(err)
+
+	report, err := tracker.Report(day, day.AddDate(0, 0, 1))
+	is.NoErr(err)
+	is.Equal(report, embed.Report{
+		Since: day,
+		Until: day.AddDate(0, 0, 1),
+		Total: 3 * time.Hour,
+		Projects: []embed.ProjectTotal{
+			{Project: "Intranet", Duration: 2 * time.Hour},
+			{Project: "Website", Duration: time.Hour},
+		},
+	})
+}
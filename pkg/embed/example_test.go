@@ -0,0 +1,62 @@
+package embed_test
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/TristanShz/flow/pkg/embed"
+)
+
+// This example tracks the time of a build from a task runner, then prints
+// the report of the day. The sessions are stored in a temporary flow folder,
+// give an empty folder to Open to use the one of the flow command.
+func Example() {
+	folder, err := os.MkdirTemp("", "flow")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer os.RemoveAll(folder)
+
+	tracker, err := embed.Open(folder)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer tracker.Close()
+
+	started, err := tracker.Start("website", "build")
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("Started", started.Project, started.Tags)
+
+	stopped, err := tracker.Stop()
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("Stopped", stopped.Project, !stopped.End.IsZero())
+
+	day := time.Date(2024, time.April, 15, 0, 0, 0, 0, time.Local)
+	if _, err := tracker.Log("website", day.Add(9*time.Hour), day.Add(11*time.Hour), "review"); err != nil {
+		log.Fatal(err)
+	}
+	if _, err := tracker.Log("intranet", day.Add(14*time.Hour), day.Add(14*time.Hour+30*time.Minute)); err != nil {
+		log.Fatal(err)
+	}
+
+	report, err := tracker.Report(day, day.AddDate(0, 0, 1))
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, p := range report.Projects {
+		fmt.Println(p.Project, p.Duration)
+	}
+	fmt.Println("Total", report.Total)
+	// Output:
+	// Started website [build]
+	// Stopped website true
+	// website 2h0m0s
+	// intranet 30m0s
+	// Total 2h30m0s
+}
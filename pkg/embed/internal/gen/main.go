@@ -0,0 +1,149 @@
+// Gen writes the example program of the embed package and its embedding
+// guide from the doc comments and the example of the package. It's run by
+// go generate in the folder of the package.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/doc"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	examplePath = "example/main.go"
+	guidePath   = "../../docs/docs/embedding.md"
+	header      = "// Code generated by go run ./internal/gen. DO NOT EDIT.\n\n"
+)
+
+func main() {
+	files, err := generate(".")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			log.Fatal(err)
+		}
+		if err := os.WriteFile(path, content, 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// generate returns the content of the generated files by their path, the
+// package is read from dir and the paths are relative to it
+func generate(dir string) (map[string][]byte, error) {
+	fset := token.NewFileSet()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []*ast.File
+	var testFiles []*ast.File
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, filepath.Join(dir, entry.Name()), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+
+		if strings.HasSuffix(entry.Name(), "_test.go") {
+			testFiles = append(testFiles, file)
+		} else {
+			files = append(files, file)
+		}
+	}
+
+	pkg, err := doc.NewFromFiles(fset, files, "github.com/TristanShz/flow/pkg/embed")
+	if err != nil {
+		return nil, err
+	}
+
+	var example *doc.Example
+	for _, e := range doc.Examples(testFiles...) {
+		if e.Name == "" && e.Play != nil {
+			example = e
+		}
+	}
+	if example == nil {
+		return nil, errors.New("the package has no runnable example")
+	}
+
+	program := &bytes.Buffer{}
+	program.WriteString(header)
+	if err := format.Node(program, fset, example.Play); err != nil {
+		return nil, err
+	}
+
+	return map[string][]byte{
+		filepath.Join(dir, examplePath): program.Bytes(),
+		filepath.Join(dir, guidePath):   guide(fset, pkg, example, program.Bytes()),
+	}, nil
+}
+
+// guide writes the embedding guide: the doc of the package, the doc of its
+// API, then the example program
+func guide(fset *token.FileSet, pkg *doc.Package, example *doc.Example, program []byte) []byte {
+	text := &bytes.Buffer{}
+	text.WriteString("---\nsidebar_position: 4\n---\n\n")
+	text.WriteString("<!-- Code generated by go run ./internal/gen in pkg/embed. DO NOT EDIT. -->\n\n")
+	text.WriteString("# Embedding\n\n")
+	text.Write(pkg.Markdown(pkg.Doc))
+	fmt.Fprintf(text, "\n```sh\ngo get %v\n```\n", pkg.ImportPath)
+
+	text.WriteString("\n## API\n")
+	declaration := func(decl ast.Decl, comment string) {
+		code := &bytes.Buffer{}
+		format.Node(code, fset, decl)
+		fmt.Fprintf(text, "\n```go\n%v\n```\n", code)
+		if comment != "" {
+			text.WriteString("\n")
+			text.Write(pkg.Markdown(comment))
+		}
+	}
+
+	for _, v := range pkg.Vars {
+		declaration(v.Decl, v.Doc)
+	}
+	for _, f := range pkg.Funcs {
+		declaration(withoutBody(f.Decl), f.Doc)
+	}
+	for _, t := range pkg.Types {
+		declaration(t.Decl, t.Doc)
+		for _, f := range t.Funcs {
+			declaration(withoutBody(f.Decl), f.Doc)
+		}
+		for _, m := range t.Methods {
+			declaration(withoutBody(m.Decl), m.Doc)
+		}
+	}
+
+	text.WriteString("\n## Example\n\n")
+	text.Write(pkg.Markdown(example.Doc))
+	fmt.Fprintf(text, "\nThe program is in `pkg/embed/%v`, run it with `go run ./pkg/embed/example`.\n", filepath.Dir(examplePath))
+	fmt.Fprintf(text, "\n```go\n%v```\n", strings.TrimPrefix(string(program), header))
+
+	return text.Bytes()
+}
+
+func withoutBody(decl *ast.FuncDecl) *ast.FuncDecl {
+	signature := *decl
+	signature.Body = nil
+	signature.Doc = nil
+
+	return &signature
+}
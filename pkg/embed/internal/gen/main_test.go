@@ -0,0 +1,21 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestGenerate(t *testing.T) {
+	is := is.New(t)
+
+	files, err := generate("../..")
+	is.NoErr(err)
+
+	for path, content := range files {
+		written, err := os.ReadFile(path)
+		is.NoErr(err)
+		is.True(string(written) == string(content)) // run go generate ./pkg/embed
+	}
+}
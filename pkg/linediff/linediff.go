@@ -0,0 +1,127 @@
+// Package linediff compares texts line by line, like diff -u
+package linediff
+
+import (
+	"fmt"
+	"strings"
+)
+
+type opKind byte
+
+const (
+	opEqual  opKind = ' '
+	opDelete opKind = '-'
+	opInsert opKind = '+'
+)
+
+type op struct {
+	kind opKind
+	line string
+	// a and b are the indexes of the line in both texts, the index of the
+	// next line of a text for the lines missing from it
+	a, b int
+}
+
+// Unified returns the changes from a to b in the unified format, with the
+// given number of unchanged lines around them, or an empty string when the
+// texts have the same lines
+func Unified(a, b string, context int) string {
+	ops := diff(splitLines(a), splitLines(b))
+
+	builder := strings.Builder{}
+	for start := 0; start < len(ops); {
+		if ops[start].kind == opEqual {
+			start++
+			continue
+		}
+
+		// a hunk goes on while the changes are less than two contexts apart
+		end := start
+		for i := start; i < len(ops) && i-end <= 2*context; i++ {
+			if ops[i].kind != opEqual {
+				end = i + 1
+			}
+		}
+
+		writeHunk(&builder, ops[max(start-context, 0):min(end+context, len(ops))])
+		start = end
+	}
+
+	return builder.String()
+}
+
+func writeHunk(builder *strings.Builder, ops []op) {
+	aLines, bLines := 0, 0
+	for _, o := range ops {
+		if o.kind != opInsert {
+			aLines++
+		}
+		if o.kind != opDelete {
+			bLines++
+		}
+	}
+
+	fmt.Fprintf(builder, "@@ -%v +%v @@\n", hunkRange(ops[0].a, aLines), hunkRange(ops[0].b, bLines))
+	for _, o := range ops {
+		fmt.Fprintf(builder, "%c%v\n", o.kind, o.line)
+	}
+}
+
+// hunkRange prints the first line and the number of lines of a hunk, the
+// lines are numbered from 1 and an empty hunk starts at the line before it
+func hunkRange(start int, lines int) string {
+	if lines == 0 {
+		return fmt.Sprintf("%v,0", start)
+	}
+	if lines == 1 {
+		return fmt.Sprint(start + 1)
+	}
+	return fmt.Sprintf("%v,%v", start+1, lines)
+}
+
+// diff returns the shortest edit from a to b, from their longest common
+// subsequence of lines
+func diff(a, b []string) []op {
+	// common[i][j] is the length of the longest common subsequence of a[i:]
+	// and b[j:]
+	common := make([][]int, len(a)+1)
+	for i := range common {
+		common[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else {
+				common[i][j] = max(common[i+1][j], common[i][j+1])
+			}
+		}
+	}
+
+	ops := []op{}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			ops = append(ops, op{kind: opEqual, line: a[i], a: i, b: j})
+			i++
+			j++
+		case j == len(b) || (i < len(a) && common[i+1][j] >= common[i][j+1]):
+			ops = append(ops, op{kind: opDelete, line: a[i], a: i, b: j})
+			i++
+		default:
+			ops = append(ops, op{kind: opInsert, line: b[j], a: i, b: j})
+			j++
+		}
+	}
+
+	return ops
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
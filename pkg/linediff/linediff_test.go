@@ -0,0 +1,61 @@
+package linediff_test
+
+import (
+	"testing"
+
+	"github.com/TristanShz/flow/pkg/linediff"
+	"github.com/matryer/is"
+)
+
+func TestUnified(t *testing.T) {
+	tt := []struct {
+		name    string
+		a       string
+		b       string
+		context int
+		want    string
+	}{
+		{
+			name: "Same lines",
+			a:    "output = \"json\"\n",
+			b:    "output = \"json\"",
+			want: "",
+		},
+		{
+			name:    "Changed line",
+			a:       "flow_folder = \"~/flow\"\noutput = \"json\"\nweek_start = \"monday\"\n",
+			b:       "flow_folder = \"~/flow\"\noutput = \"text\"\nweek_start = \"monday\"\n",
+			context: 1,
+			want:    "@@ -1,3 +1,3 @@\n flow_folder = \"~/flow\"\n-output = \"json\"\n+output = \"text\"\n week_start = \"monday\"\n",
+		},
+		{
+			name:    "Distant changes",
+			a:       "1\n2\n3\n4\n5\n6\n",
+			b:       "0\n1\n2\n3\n4\n5\n",
+			context: 1,
+			want:    "@@ -1 +1,2 @@\n+0\n 1\n@@ -5,2 +6 @@\n 5\n-6\n",
+		},
+		{
+			name:    "Close changes",
+			a:       "1\n2\n3\n",
+			b:       "0\n1\n2\n",
+			context: 1,
+			want:    "@@ -1,3 +1,3 @@\n+0\n 1\n 2\n-3\n",
+		},
+		{
+			name:    "New file",
+			a:       "",
+			b:       "output = \"json\"\n",
+			context: 3,
+			want:    "@@ -0,0 +1 @@\n+output = \"json\"\n",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			is.Equal(linediff.Unified(tc.a, tc.b, tc.context), tc.want)
+		})
+	}
+}
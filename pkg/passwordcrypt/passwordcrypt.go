@@ -0,0 +1,98 @@
+// Package passwordcrypt encrypts data with a password in a format the Web
+// Crypto API of browsers can decrypt: the key is derived with PBKDF2-SHA256,
+// the data is encrypted with AES-256-GCM and sealed data is the salt, the
+// nonce and the ciphertext one after another.
+package passwordcrypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+)
+
+const (
+	// Iterations of PBKDF2, as recommended by OWASP for PBKDF2-SHA256
+	Iterations = 600000
+	SaltSize   = 16
+	NonceSize  = 12
+	KeySize    = 32
+)
+
+var ErrWrongPassword = errors.New("wrong password or corrupted data")
+
+// DeriveKey returns the PBKDF2-SHA256 key of the password, see RFC 8018
+func DeriveKey(password string, salt []byte, iterations int) []byte {
+	prf := hmac.New(sha256.New, []byte(password))
+	blocks := (KeySize + prf.Size() - 1) / prf.Size()
+
+	key := make([]byte, 0, blocks*prf.Size())
+	blockIndex := make([]byte, 4)
+	for block := 1; block <= blocks; block++ {
+		prf.Reset()
+		prf.Write(salt)
+		binary.BigEndian.PutUint32(blockIndex, uint32(block))
+		prf.Write(blockIndex)
+		u := prf.Sum(nil)
+
+		t := append([]byte{}, u...)
+		for i := 1; i < iterations; i++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for j := range t {
+				t[j] ^= u[j]
+			}
+		}
+
+		key = append(key, t...)
+	}
+
+	return key[:KeySize]
+}
+
+func newGCM(password string, salt []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(DeriveKey(password, salt, Iterations))
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// Seal encrypts the data with the password, with a random salt and nonce
+func Seal(data []byte, password string) ([]byte, error) {
+	saltAndNonce := make([]byte, SaltSize+NonceSize)
+	if _, err := rand.Read(saltAndNonce); err != nil {
+		return nil, err
+	}
+
+	gcm, err := newGCM(password, saltAndNonce[:SaltSize])
+	if err != nil {
+		return nil, err
+	}
+
+	return gcm.Seal(saltAndNonce, saltAndNonce[SaltSize:], data, nil), nil
+}
+
+// Open decrypts data sealed with the password
+func Open(sealed []byte, password string) ([]byte, error) {
+	if len(sealed) < SaltSize+NonceSize {
+		return nil, ErrWrongPassword
+	}
+
+	gcm, err := newGCM(password, sealed[:SaltSize])
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := gcm.Open(nil, sealed[SaltSize:SaltSize+NonceSize], sealed[SaltSize+NonceSize:], nil)
+	if err != nil {
+		return nil, ErrWrongPassword
+	}
+
+	return data, nil
+}
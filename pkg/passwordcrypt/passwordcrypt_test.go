@@ -0,0 +1,65 @@
+package passwordcrypt_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/TristanShz/flow/pkg/passwordcrypt"
+)
+
+func TestDeriveKey(t *testing.T) {
+	tests := []struct {
+		name       string
+		password   string
+		salt       string
+		iterations int
+		want       string
+	}{
+		{
+			name:       "One iteration",
+			password:   "password",
+			salt:       "salt",
+			iterations: 1,
+			want:       "120fb6cffcf8b32c43e7225256c4f837a86548c92ccc35480805987cb70be17b",
+		},
+		{
+			name:       "4096 iterations",
+			password:   "password",
+			salt:       "salt",
+			iterations: 4096,
+			want:       "c5e478d59288c841aa530db6845c4c8d962893a001ce4e11a4963873aa98134a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := hex.EncodeToString(passwordcrypt.DeriveKey(tt.password, []byte(tt.salt), tt.iterations))
+			if got != tt.want {
+				t.Errorf("DeriveKey() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSealAndOpen(t *testing.T) {
+	data := []byte("Timesheet of april")
+
+	sealed, err := passwordcrypt.Seal(data, "secret")
+	if err != nil {
+		t.Fatalf("Seal() error = %v", err)
+	}
+
+	if bytes.Contains(sealed, data) {
+		t.Errorf("Seal() should not contain the data")
+	}
+
+	opened, err := passwordcrypt.Open(sealed, "secret")
+	if err != nil || !bytes.Equal(opened, data) {
+		t.Errorf("Open() = %s, %v, want %s", opened, err, data)
+	}
+
+	if _, err := passwordcrypt.Open(sealed, "guess"); err != passwordcrypt.ErrWrongPassword {
+		t.Errorf("Open() error = %v, want %v", err, passwordcrypt.ErrWrongPassword)
+	}
+}
@@ -0,0 +1,128 @@
+// Package pdf writes A4 documents of text lines in the Courier font, which
+// every PDF reader has, so that the documents need no embedded font
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	pageWidth  = 595.28
+	pageHeight = 841.89
+	margin     = 56.0
+	// charWidth is the width of the characters of Courier, for a size of 1
+	charWidth = 0.6
+	leading   = 1.4
+)
+
+type Document struct {
+	// pages are the content streams of the pages
+	pages []*bytes.Buffer
+	// y is where the baseline of the last line of the page is
+	y float64
+}
+
+func New() *Document {
+	d := &Document{}
+	d.newPage()
+
+	return d
+}
+
+func (d *Document) newPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+	d.y = pageHeight - margin
+}
+
+// Text writes a line under the last one, on a new page when the page is full
+func (d *Document) Text(text string, size float64, bold bool) {
+	if d.y-size*leading < margin {
+		d.newPage()
+	}
+	d.y -= size * leading
+
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+
+	fmt.Fprintf(d.pages[len(d.pages)-1], "BT /%v %v Tf %v %v Td (%v) Tj ET\n", font, number(size), number(margin), number(d.y), escape(text))
+}
+
+// Space leaves a blank line of the given size
+func (d *Document) Space(size float64) {
+	d.y -= size * leading
+}
+
+// Columns returns the number of characters of the given size a line holds
+func Columns(size float64) int {
+	return int((pageWidth - 2*margin) / (size * charWidth))
+}
+
+// WriteTo writes the document, its objects are the catalog, the pages, both
+// fonts, then each page followed by its content stream
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	buf := &bytes.Buffer{}
+	offsets := []int{}
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(buf, "%v 0 obj\n%v\nendobj\n", len(offsets), body)
+	}
+
+	kids := []string{}
+	for i := range d.pages {
+		kids = append(kids, fmt.Sprintf("%v 0 R", 5+2*i))
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%v] /Count %v >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Courier-Bold /Encoding /WinAnsiEncoding >>")
+	for i, page := range d.pages {
+		object(fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %v %v] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %v 0 R >>",
+			number(pageWidth), number(pageHeight), 6+2*i,
+		))
+		object(fmt.Sprintf("<< /Length %v >>\nstream\n%v\nendstream", page.Len(), page.String()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(buf, "xref\n0 %v\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(buf, "trailer\n<< /Size %v /Root 1 0 R >>\nstartxref\n%v\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return buf.WriteTo(w)
+}
+
+// number formats a coordinate or a size to the hundredth of a point
+func number(f float64) string {
+	return strconv.FormatFloat(math.Round(f*100)/100, 'f', -1, 64)
+}
+
+// escape encodes the text in WinAnsiEncoding as a string of the content
+// stream, the characters it lacks become question marks
+func escape(text string) string {
+	escaped := []byte{}
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			escaped = append(escaped, '\\', byte(r))
+		case r == '€':
+			escaped = append(escaped, 0x80)
+		case r >= ' ' && r < 0x7f, r >= 0xa0 && r <= 0xff:
+			escaped = append(escaped, byte(r))
+		default:
+			escaped = append(escaped, '?')
+		}
+	}
+
+	return string(escaped)
+}
@@ -0,0 +1,50 @@
+package pdf_test
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/TristanShz/flow/pkg/pdf"
+	"github.com/matryer/is"
+)
+
+func TestDocument(t *testing.T) {
+	is := is.New(t)
+
+	document := pdf.New()
+	document.Text("Invoice (draft)", 16, true)
+	document.Space(10)
+	for range 60 {
+		document.Text("Total: 120.00 €", 10, false)
+	}
+
+	buf := &bytes.Buffer{}
+	_, err := document.WriteTo(buf)
+	is.NoErr(err)
+	written := buf.String()
+
+	is.True(strings.HasPrefix(written, "%PDF-1.4\n"))
+	is.True(strings.HasSuffix(written, "%%EOF\n"))
+	is.True(strings.Contains(written, "/F2 16 Tf 56 763.49 Td (Invoice \\(draft\\)) Tj ET"))
+	is.True(strings.Contains(written, "(Total: 120.00 \x80) Tj"))
+	is.True(strings.Contains(written, "/Count 2"))
+
+	// the cross-reference table points at the objects
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindStringSubmatch(written)
+	is.True(startxref != nil)
+	xref, err := strconv.Atoi(startxref[1])
+	is.NoErr(err)
+	is.True(strings.HasPrefix(written[xref:], "xref\n0 9\n"))
+	offset, err := strconv.Atoi(written[xref+len("xref\n0 9\n0000000000 65535 f \n"):][:10])
+	is.NoErr(err)
+	is.True(strings.HasPrefix(written[offset:], "1 0 obj\n<< /Type /Catalog"))
+}
+
+func TestColumns(t *testing.T) {
+	is := is.New(t)
+
+	is.Equal(pdf.Columns(10), 80)
+}
@@ -0,0 +1,95 @@
+package timerange
+
+import "time"
+
+// Contains tells if the time is in the range, taking into account whether its
+// bounds are inclusive
+func (t TimeRange) Contains(at time.Time) bool {
+	if !t.Since.IsZero() && (at.Before(t.Since) || (t.ExcludeSince && at.Equal(t.Since))) {
+		return false
+	}
+
+	if !t.Until.IsZero() && (at.After(t.Until) || (t.ExcludeUntil && at.Equal(t.Until))) {
+		return false
+	}
+
+	return true
+}
+
+// Overlaps tells if both ranges share at least an instant
+func (t TimeRange) Overlaps(other TimeRange) bool {
+	_, ok := t.Intersect(other)
+	return ok
+}
+
+// Intersect returns the range shared by both ranges, ok is false when they
+// don't overlap
+func (t TimeRange) Intersect(other TimeRange) (intersection TimeRange, ok bool) {
+	intersection = t
+
+	// the latest since and the earliest until win, exclusive bounds win ties
+	if !other.Since.IsZero() && (t.Since.IsZero() || other.Since.After(t.Since)) {
+		intersection.Since, intersection.ExcludeSince = other.Since, other.ExcludeSince
+	} else if other.Since.Equal(t.Since) {
+		intersection.ExcludeSince = t.ExcludeSince || other.ExcludeSince
+	}
+
+	if !other.Until.IsZero() && (t.Until.IsZero() || other.Until.Before(t.Until)) {
+		intersection.Until, intersection.ExcludeUntil = other.Until, other.ExcludeUntil
+	} else if other.Until.Equal(t.Until) {
+		intersection.ExcludeUntil = t.ExcludeUntil || other.ExcludeUntil
+	}
+
+	if intersection.isEmpty() {
+		return TimeRange{}, false
+	}
+
+	return intersection, true
+}
+
+// Union returns the range covering both ranges, ok is false when they neither
+// overlap nor touch, as their union wouldn't be a single range
+func (t TimeRange) Union(other TimeRange) (union TimeRange, ok bool) {
+	if !t.Overlaps(other) && !t.touches(other) && !other.touches(t) {
+		return TimeRange{}, false
+	}
+
+	union = t
+
+	// the earliest since and the latest until win, inclusive bounds win ties
+	if t.Since.IsZero() || other.Since.IsZero() {
+		union.Since, union.ExcludeSince = time.Time{}, false
+	} else if other.Since.Before(t.Since) {
+		union.Since, union.ExcludeSince = other.Since, other.ExcludeSince
+	} else if other.Since.Equal(t.Since) {
+		union.ExcludeSince = t.ExcludeSince && other.ExcludeSince
+	}
+
+	if t.Until.IsZero() || other.Until.IsZero() {
+		union.Until, union.ExcludeUntil = time.Time{}, false
+	} else if other.Until.After(t.Until) {
+		union.Until, union.ExcludeUntil = other.Until, other.ExcludeUntil
+	} else if other.Until.Equal(t.Until) {
+		union.ExcludeUntil = t.ExcludeUntil && other.ExcludeUntil
+	}
+
+	return union, true
+}
+
+// touches tells if the range ends where the other one starts, with at least
+// one of both bounds inclusive
+func (t TimeRange) touches(other TimeRange) bool {
+	return !t.Until.IsZero() && t.Until.Equal(other.Since) && !(t.ExcludeUntil && other.ExcludeSince)
+}
+
+func (t TimeRange) isEmpty() bool {
+	if t.Since.IsZero() || t.Until.IsZero() {
+		return false
+	}
+
+	if t.Since.Equal(t.Until) {
+		return t.ExcludeSince || t.ExcludeUntil
+	}
+
+	return t.Since.After(t.Until)
+}
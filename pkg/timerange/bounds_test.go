@@ -0,0 +1,150 @@
+package timerange_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/pkg/timerange"
+)
+
+func hour(h int) time.Time {
+	return time.Date(2024, 4, 17, h, 0, 0, 0, time.UTC)
+}
+
+func TestTimeRange_Contains(t *testing.T) {
+	tests := []struct {
+		name string
+		tr   timerange.TimeRange
+		at   time.Time
+		want bool
+	}{
+		{name: "Inside", tr: timerange.TimeRange{Since: hour(9), Until: hour(12)}, at: hour(10), want: true},
+		{name: "Inclusive since", tr: timerange.TimeRange{Since: hour(9), Until: hour(12)}, at: hour(9), want: true},
+		{name: "Inclusive until", tr: timerange.TimeRange{Since: hour(9), Until: hour(12)}, at: hour(12), want: true},
+		{name: "Exclusive since", tr: timerange.TimeRange{Since: hour(9), ExcludeSince: true}, at: hour(9), want: false},
+		{name: "Exclusive until", tr: timerange.TimeRange{Until: hour(12), ExcludeUntil: true}, at: hour(12), want: false},
+		{name: "Before", tr: timerange.TimeRange{Since: hour(9)}, at: hour(8), want: false},
+		{name: "After", tr: timerange.TimeRange{Until: hour(12)}, at: hour(13), want: false},
+		{name: "Unbounded", tr: timerange.TimeRange{}, at: hour(13), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tr.Contains(tt.at); got != tt.want {
+				t.Errorf("Contains() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimeRange_Intersect(t *testing.T) {
+	tests := []struct {
+		name   string
+		a      timerange.TimeRange
+		b      timerange.TimeRange
+		want   timerange.TimeRange
+		wantOk bool
+	}{
+		{
+			name:   "Overlapping",
+			a:      timerange.TimeRange{Since: hour(9), Until: hour(12)},
+			b:      timerange.TimeRange{Since: hour(10), Until: hour(14), ExcludeUntil: true},
+			want:   timerange.TimeRange{Since: hour(10), Until: hour(12)},
+			wantOk: true,
+		},
+		{
+			name:   "Unbounded sides",
+			a:      timerange.TimeRange{Since: hour(9)},
+			b:      timerange.TimeRange{Until: hour(12), ExcludeUntil: true},
+			want:   timerange.TimeRange{Since: hour(9), Until: hour(12), ExcludeUntil: true},
+			wantOk: true,
+		},
+		{
+			name:   "Exclusive bound wins a tie",
+			a:      timerange.TimeRange{Since: hour(9), Until: hour(12)},
+			b:      timerange.TimeRange{Since: hour(9), ExcludeSince: true, Until: hour(12)},
+			want:   timerange.TimeRange{Since: hour(9), ExcludeSince: true, Until: hour(12)},
+			wantOk: true,
+		},
+		{
+			name:   "Touching inclusive bounds",
+			a:      timerange.TimeRange{Since: hour(9), Until: hour(12)},
+			b:      timerange.TimeRange{Since: hour(12), Until: hour(14)},
+			want:   timerange.TimeRange{Since: hour(12), Until: hour(12)},
+			wantOk: true,
+		},
+		{
+			name: "Touching exclusive bound",
+			a:    timerange.TimeRange{Since: hour(9), Until: hour(12), ExcludeUntil: true},
+			b:    timerange.TimeRange{Since: hour(12), Until: hour(14)},
+		},
+		{
+			name: "Disjoint",
+			a:    timerange.TimeRange{Since: hour(9), Until: hour(10)},
+			b:    timerange.TimeRange{Since: hour(12), Until: hour(14)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.a.Intersect(tt.b)
+			if ok != tt.wantOk || got != tt.want {
+				t.Errorf("Intersect() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+			if overlaps := tt.a.Overlaps(tt.b); overlaps != tt.wantOk {
+				t.Errorf("Overlaps() = %v, want %v", overlaps, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestTimeRange_Union(t *testing.T) {
+	tests := []struct {
+		name   string
+		a      timerange.TimeRange
+		b      timerange.TimeRange
+		want   timerange.TimeRange
+		wantOk bool
+	}{
+		{
+			name:   "Overlapping",
+			a:      timerange.TimeRange{Since: hour(9), Until: hour(12)},
+			b:      timerange.TimeRange{Since: hour(10), Until: hour(14), ExcludeUntil: true},
+			want:   timerange.TimeRange{Since: hour(9), Until: hour(14), ExcludeUntil: true},
+			wantOk: true,
+		},
+		{
+			name:   "Touching",
+			a:      timerange.TimeRange{Since: hour(12), Until: hour(14)},
+			b:      timerange.TimeRange{Since: hour(9), Until: hour(12), ExcludeUntil: true},
+			want:   timerange.TimeRange{Since: hour(9), Until: hour(14)},
+			wantOk: true,
+		},
+		{
+			name:   "Unbounded side",
+			a:      timerange.TimeRange{Since: hour(9), Until: hour(12)},
+			b:      timerange.TimeRange{Since: hour(10)},
+			want:   timerange.TimeRange{Since: hour(9)},
+			wantOk: true,
+		},
+		{
+			name: "Touching exclusive bounds",
+			a:    timerange.TimeRange{Since: hour(9), Until: hour(12), ExcludeUntil: true},
+			b:    timerange.TimeRange{Since: hour(12), ExcludeSince: true, Until: hour(14)},
+		},
+		{
+			name: "Disjoint",
+			a:    timerange.TimeRange{Since: hour(9), Until: hour(10)},
+			b:    timerange.TimeRange{Since: hour(12), Until: hour(14)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.a.Union(tt.b)
+			if ok != tt.wantOk || got != tt.want {
+				t.Errorf("Union() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package timerange
+
+import "time"
+
+// Step is the length of the buckets of Buckets
+type Step int
+
+const (
+	ByDay Step = iota
+	ByWeek
+	ByMonth
+)
+
+// StartOf returns the start of the day, week or month of the time, in its
+// location. Weeks start on the given day.
+func StartOf(at time.Time, step Step, weekStart time.Weekday) time.Time {
+	day := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, at.Location())
+
+	switch step {
+	case ByWeek:
+		offset := (int(day.Weekday()) - int(weekStart) + 7) % 7
+		return day.AddDate(0, 0, -offset)
+	case ByMonth:
+		return day.AddDate(0, 0, 1-day.Day())
+	default:
+		return day
+	}
+}
+
+func nextStart(start time.Time, step Step) time.Time {
+	switch step {
+	case ByWeek:
+		return start.AddDate(0, 0, 7)
+	case ByMonth:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
+
+// Buckets splits the range into the days, weeks or months it overlaps, in
+// the location of its since. A bucket includes its start and excludes the
+// start of the next one, so days lasting 23 or 25 hours on daylight saving
+// time changes are kept whole. The first and last buckets are cut to the
+// range. There are no buckets for an unbounded or empty range.
+func Buckets(t TimeRange, step Step, weekStart time.Weekday) []TimeRange {
+	if t.Since.IsZero() || t.Until.IsZero() || t.isEmpty() {
+		return nil
+	}
+
+	buckets := []TimeRange{}
+	for start := StartOf(t.Since, step, weekStart); !start.After(t.Until); start = nextStart(start, step) {
+		bucket := TimeRange{Since: start, Until: nextStart(start, step), ExcludeUntil: true}
+		if intersection, ok := bucket.Intersect(t); ok {
+			buckets = append(buckets, intersection)
+		}
+	}
+
+	return buckets
+}
@@ -0,0 +1,132 @@
+package timerange_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/pkg/timerange"
+	"github.com/matryer/is"
+)
+
+func TestStartOf(t *testing.T) {
+	// a wednesday
+	at := time.Date(2024, 4, 17, 19, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		step      timerange.Step
+		weekStart time.Weekday
+		want      time.Time
+	}{
+		{
+			name: "Day",
+			step: timerange.ByDay,
+			want: time.Date(2024, 4, 17, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "Week starting on monday",
+			step:      timerange.ByWeek,
+			weekStart: time.Monday,
+			want:      time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "Week starting on thursday",
+			step:      timerange.ByWeek,
+			weekStart: time.Thursday,
+			want:      time.Date(2024, 4, 11, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "Month",
+			step: timerange.ByMonth,
+			want: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(timerange.StartOf(at, tt.step, tt.weekStart), tt.want)
+		})
+	}
+}
+
+func TestBuckets(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skip("the time zone database is not available")
+	}
+
+	tests := []struct {
+		name      string
+		timeRange timerange.TimeRange
+		step      timerange.Step
+		weekStart time.Weekday
+		want      []timerange.TimeRange
+	}{
+		{
+			name: "Days cut to the range",
+			timeRange: timerange.TimeRange{
+				Since: time.Date(2024, 4, 15, 10, 0, 0, 0, time.UTC),
+				Until: time.Date(2024, 4, 17, 8, 0, 0, 0, time.UTC),
+			},
+			step: timerange.ByDay,
+			want: []timerange.TimeRange{
+				{Since: time.Date(2024, 4, 15, 10, 0, 0, 0, time.UTC), Until: time.Date(2024, 4, 16, 0, 0, 0, 0, time.UTC), ExcludeUntil: true},
+				{Since: time.Date(2024, 4, 16, 0, 0, 0, 0, time.UTC), Until: time.Date(2024, 4, 17, 0, 0, 0, 0, time.UTC), ExcludeUntil: true},
+				{Since: time.Date(2024, 4, 17, 0, 0, 0, 0, time.UTC), Until: time.Date(2024, 4, 17, 8, 0, 0, 0, time.UTC)},
+			},
+		},
+		{
+			name: "Weeks starting on sunday",
+			timeRange: timerange.TimeRange{
+				Since:        time.Date(2024, 4, 14, 0, 0, 0, 0, time.UTC),
+				Until:        time.Date(2024, 4, 28, 0, 0, 0, 0, time.UTC),
+				ExcludeUntil: true,
+			},
+			step:      timerange.ByWeek,
+			weekStart: time.Sunday,
+			want: []timerange.TimeRange{
+				{Since: time.Date(2024, 4, 14, 0, 0, 0, 0, time.UTC), Until: time.Date(2024, 4, 21, 0, 0, 0, 0, time.UTC), ExcludeUntil: true},
+				{Since: time.Date(2024, 4, 21, 0, 0, 0, 0, time.UTC), Until: time.Date(2024, 4, 28, 0, 0, 0, 0, time.UTC), ExcludeUntil: true},
+			},
+		},
+		{
+			name: "Months",
+			timeRange: timerange.TimeRange{
+				Since: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+				Until: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			},
+			step: timerange.ByMonth,
+			want: []timerange.TimeRange{
+				{Since: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), Until: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), ExcludeUntil: true},
+				{Since: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), Until: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), ExcludeUntil: true},
+				{Since: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), Until: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+			},
+		},
+		{
+			name: "Day of a daylight saving time change",
+			timeRange: timerange.TimeRange{
+				Since:        time.Date(2024, 3, 31, 0, 0, 0, 0, paris),
+				Until:        time.Date(2024, 4, 1, 0, 0, 0, 0, paris),
+				ExcludeUntil: true,
+			},
+			step: timerange.ByDay,
+			want: []timerange.TimeRange{
+				{Since: time.Date(2024, 3, 31, 0, 0, 0, 0, paris), Until: time.Date(2024, 4, 1, 0, 0, 0, 0, paris), ExcludeUntil: true},
+			},
+		},
+		{
+			name:      "Unbounded range",
+			timeRange: timerange.TimeRange{Since: time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC)},
+			step:      timerange.ByDay,
+			want:      nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(timerange.Buckets(tt.timeRange, tt.step, tt.weekStart), tt.want)
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package timerange
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidRange = errors.New("invalid range. possible values: a period like today or last-week, a month like 2024-04, a relative range like -7d, -2w or -3m, or since followed by a day like monday or 2024-04-01")
+
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// Parse returns the time range of a human friendly expression relative to
+// now:
+//   - a period accepted by ParsePeriod, like today or 2024-04
+//   - -Nd, -Nw or -Nm, from the start of the day N days, weeks or months ago
+//     until the end of today
+//   - since followed by a weekday, a date like 2024-04-01 or a period, until
+//     the end of today. A weekday is its last occurrence, today included.
+func Parse(expression string, now time.Time) (TimeRange, error) {
+	expression = strings.ToLower(strings.TrimSpace(expression))
+	today := NewDayTimeRange(now)
+
+	if since, ok := strings.CutPrefix(expression, "since "); ok {
+		start, err := parseSince(strings.TrimSpace(since), now)
+		if err != nil {
+			return TimeRange{}, err
+		}
+
+		return TimeRange{Since: start, Until: today.Until}, nil
+	}
+
+	if relative, ok := strings.CutPrefix(expression, "-"); ok && len(relative) > 1 {
+		n, err := strconv.Atoi(relative[:len(relative)-1])
+		if err != nil || n < 0 {
+			return TimeRange{}, ErrInvalidRange
+		}
+
+		since := today.Since
+		switch relative[len(relative)-1] {
+		case 'd':
+			since = since.AddDate(0, 0, -n)
+		case 'w':
+			since = since.AddDate(0, 0, -7*n)
+		case 'm':
+			since = since.AddDate(0, -n, 0)
+		default:
+			return TimeRange{}, ErrInvalidRange
+		}
+
+		return TimeRange{Since: since, Until: today.Until}, nil
+	}
+
+	timeRange, err := ParsePeriod(expression, now)
+	if err != nil {
+		return TimeRange{}, ErrInvalidRange
+	}
+
+	return timeRange, nil
+}
+
+func parseSince(since string, now time.Time) (time.Time, error) {
+	for name, weekday := range weekdays {
+		if since == name || since == name[:3] {
+			offset := (int(now.Weekday()) - int(weekday) + 7) % 7
+			return NewDayTimeRange(now.AddDate(0, 0, -offset)).Since, nil
+		}
+	}
+
+	if day, err := time.Parse("2006-01-02", since); err == nil {
+		return day, nil
+	}
+
+	period, err := ParsePeriod(since, now)
+	if err != nil {
+		return time.Time{}, ErrInvalidRange
+	}
+
+	return period.Since, nil
+}
@@ -0,0 +1,129 @@
+package timerange_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/pkg/timerange"
+)
+
+func TestParse(t *testing.T) {
+	// a wednesday
+	now := time.Date(2024, 4, 17, 19, 0, 0, 0, time.UTC)
+	endOfToday := time.Date(2024, 4, 18, 0, 0, 0, 0, time.UTC).Add(-time.Second)
+
+	tests := []struct {
+		err        error
+		name       string
+		expression string
+		want       timerange.TimeRange
+	}{
+		{
+			name:       "Period",
+			expression: "Today",
+			want: timerange.TimeRange{
+				Since: time.Date(2024, 4, 17, 0, 0, 0, 0, time.UTC),
+				Until: endOfToday,
+			},
+		},
+		{
+			name:       "Month",
+			expression: "2024-03",
+			want: timerange.TimeRange{
+				Since: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+				Until: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC).Add(-time.Second),
+			},
+		},
+		{
+			name:       "Days ago",
+			expression: "-7d",
+			want: timerange.TimeRange{
+				Since: time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC),
+				Until: endOfToday,
+			},
+		},
+		{
+			name:       "Weeks ago",
+			expression: "-2w",
+			want: timerange.TimeRange{
+				Since: time.Date(2024, 4, 3, 0, 0, 0, 0, time.UTC),
+				Until: endOfToday,
+			},
+		},
+		{
+			name:       "Months ago",
+			expression: "-3m",
+			want: timerange.TimeRange{
+				Since: time.Date(2024, 1, 17, 0, 0, 0, 0, time.UTC),
+				Until: endOfToday,
+			},
+		},
+		{
+			name:       "Since a weekday",
+			expression: "since monday",
+			want: timerange.TimeRange{
+				Since: time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC),
+				Until: endOfToday,
+			},
+		},
+		{
+			name:       "Since today's weekday",
+			expression: "since wed",
+			want: timerange.TimeRange{
+				Since: time.Date(2024, 4, 17, 0, 0, 0, 0, time.UTC),
+				Until: endOfToday,
+			},
+		},
+		{
+			name:       "Since a later weekday",
+			expression: "since friday",
+			want: timerange.TimeRange{
+				Since: time.Date(2024, 4, 12, 0, 0, 0, 0, time.UTC),
+				Until: endOfToday,
+			},
+		},
+		{
+			name:       "Since a date",
+			expression: "since 2024-04-01",
+			want: timerange.TimeRange{
+				Since: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+				Until: endOfToday,
+			},
+		},
+		{
+			name:       "Since a period",
+			expression: "since last-month",
+			want: timerange.TimeRange{
+				Since: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+				Until: endOfToday,
+			},
+		},
+		{
+			name:       "Invalid unit",
+			expression: "-7y",
+			err:        timerange.ErrInvalidRange,
+		},
+		{
+			name:       "Invalid since",
+			expression: "since forever",
+			err:        timerange.ErrInvalidRange,
+		},
+		{
+			name:       "Invalid",
+			expression: "soon",
+			err:        timerange.ErrInvalidRange,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := timerange.Parse(tt.expression, now)
+			if err != tt.err {
+				t.Fatalf("Parse() error = %v, want %v", err, tt.err)
+			}
+			if got != tt.want {
+				t.Errorf("Parse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
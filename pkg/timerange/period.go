@@ -0,0 +1,44 @@
+package timerange
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	PeriodToday     = "today"
+	PeriodYesterday = "yesterday"
+	PeriodThisWeek  = "this-week"
+	PeriodLastWeek  = "last-week"
+	PeriodThisMonth = "this-month"
+	PeriodLastMonth = "last-month"
+)
+
+var ErrInvalidPeriod = errors.New("invalid period. possible values: today, yesterday, this-week, last-week, this-month, last-month or a month like 2024-04")
+
+// ParsePeriod returns the time range of a named period relative to now, or of
+// a month given as YYYY-MM
+func ParsePeriod(period string, now time.Time) (TimeRange, error) {
+	switch period {
+	case PeriodToday:
+		return NewDayTimeRange(now), nil
+	case PeriodYesterday:
+		return NewDayTimeRange(now.AddDate(0, 0, -1)), nil
+	case PeriodThisWeek:
+		return NewWeekTimeRange(now), nil
+	case PeriodLastWeek:
+		return NewWeekTimeRange(now.AddDate(0, 0, -7)), nil
+	case PeriodThisMonth:
+		return NewMonthTimeRange(now), nil
+	case PeriodLastMonth:
+		monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return NewMonthTimeRange(monthStart.AddDate(0, -1, 0)), nil
+	}
+
+	month, err := time.Parse("2006-01", period)
+	if err != nil {
+		return TimeRange{}, ErrInvalidPeriod
+	}
+
+	return NewMonthTimeRange(month), nil
+}
@@ -0,0 +1,69 @@
+package timerange_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/pkg/timerange"
+)
+
+func TestParsePeriod(t *testing.T) {
+	now := time.Date(2024, 3, 31, 19, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		err    error
+		name   string
+		period string
+		want   timerange.TimeRange
+	}{
+		{
+			name:   "Yesterday",
+			period: timerange.PeriodYesterday,
+			want: timerange.TimeRange{
+				Since: time.Date(2024, 3, 30, 0, 0, 0, 0, time.UTC),
+				Until: time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC).Add(-time.Second),
+			},
+		},
+		{
+			name:   "This month",
+			period: timerange.PeriodThisMonth,
+			want: timerange.TimeRange{
+				Since: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+				Until: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC).Add(-time.Second),
+			},
+		},
+		{
+			name:   "Last month is february on the 31st of march",
+			period: timerange.PeriodLastMonth,
+			want: timerange.TimeRange{
+				Since: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+				Until: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC).Add(-time.Second),
+			},
+		},
+		{
+			name:   "Month",
+			period: "2023-12",
+			want: timerange.TimeRange{
+				Since: time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC),
+				Until: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Add(-time.Second),
+			},
+		},
+		{
+			name:   "Invalid period",
+			period: "next-month",
+			err:    timerange.ErrInvalidPeriod,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := timerange.ParsePeriod(tt.period, now)
+			if err != tt.err {
+				t.Errorf("ParsePeriod() error = %v, want %v", err, tt.err)
+			}
+			if got != tt.want {
+				t.Errorf("ParsePeriod() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
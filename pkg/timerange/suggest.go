@@ -0,0 +1,71 @@
+package timerange
+
+import (
+	"strings"
+	"time"
+)
+
+// relativeRanges are the relative ranges suggested by Suggest
+var relativeRanges = []string{"-7d", "-14d", "-30d", "-2w", "-4w", "-3m", "-6m"}
+
+// orderedWeekdays are the weekdays suggested after since, from monday
+var orderedWeekdays = []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+
+const (
+	// suggestedMonths is the number of months suggested, the current one
+	// included
+	suggestedMonths = 6
+	// suggestedDays is the number of days suggested after since, today
+	// included
+	suggestedDays = 7
+)
+
+// SuggestPeriods returns the periods accepted by ParsePeriod starting with
+// the prefix: the named periods then the recent months
+func SuggestPeriods(prefix string, now time.Time) []string {
+	candidates := []string{PeriodToday, PeriodYesterday, PeriodThisWeek, PeriodLastWeek, PeriodThisMonth, PeriodLastMonth}
+
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	for i := 0; i < suggestedMonths; i++ {
+		candidates = append(candidates, monthStart.AddDate(0, -i, 0).Format("2006-01"))
+	}
+
+	return accepted(candidates, prefix, func(candidate string) error {
+		_, err := ParsePeriod(candidate, now)
+		return err
+	})
+}
+
+// Suggest returns the expressions accepted by Parse starting with the
+// prefix: the periods, the relative ranges, then since a weekday, a recent
+// day or a past period
+func Suggest(prefix string, now time.Time) []string {
+	candidates := SuggestPeriods("", now)
+	candidates = append(candidates, relativeRanges...)
+
+	for _, weekday := range orderedWeekdays {
+		candidates = append(candidates, "since "+weekday)
+	}
+	for i := 0; i < suggestedDays; i++ {
+		candidates = append(candidates, "since "+now.AddDate(0, 0, -i).Format("2006-01-02"))
+	}
+	candidates = append(candidates, "since "+PeriodLastWeek, "since "+PeriodLastMonth)
+
+	return accepted(candidates, prefix, func(candidate string) error {
+		_, err := Parse(candidate, now)
+		return err
+	})
+}
+
+// accepted keeps the candidates starting with the prefix which the parser
+// accepts, so that nothing is suggested that would then be refused
+func accepted(candidates []string, prefix string, parse func(string) error) []string {
+	suggestions := []string{}
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, prefix) && parse(candidate) == nil {
+			suggestions = append(suggestions, candidate)
+		}
+	}
+
+	return suggestions
+}
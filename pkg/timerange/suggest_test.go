@@ -0,0 +1,97 @@
+package timerange_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TristanShz/flow/pkg/timerange"
+	"github.com/matryer/is"
+)
+
+func TestSuggestPeriods(t *testing.T) {
+	now := time.Date(2024, time.April, 17, 10, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		name   string
+		prefix string
+		want   []string
+	}{
+		{
+			name:   "Without prefix",
+			prefix: "",
+			want:   []string{"today", "yesterday", "this-week", "last-week", "this-month", "last-month", "2024-04", "2024-03", "2024-02", "2024-01", "2023-12", "2023-11"},
+		},
+		{
+			name:   "Named periods",
+			prefix: "la",
+			want:   []string{"last-week", "last-month"},
+		},
+		{
+			name:   "Months of a year",
+			prefix: "2023",
+			want:   []string{"2023-12", "2023-11"},
+		},
+		{
+			name:   "Nothing matching",
+			prefix: "tomorrow",
+			want:   []string{},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			is.Equal(timerange.SuggestPeriods(tc.prefix, now), tc.want)
+		})
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	now := time.Date(2024, time.April, 17, 10, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		name   string
+		prefix string
+		want   []string
+	}{
+		{
+			name:   "Relative ranges",
+			prefix: "-",
+			want:   []string{"-7d", "-14d", "-30d", "-2w", "-4w", "-3m", "-6m"},
+		},
+		{
+			name:   "Since",
+			prefix: "since ",
+			want: []string{
+				"since monday", "since tuesday", "since wednesday", "since thursday", "since friday", "since saturday", "since sunday",
+				"since 2024-04-17", "since 2024-04-16", "since 2024-04-15", "since 2024-04-14", "since 2024-04-13", "since 2024-04-12", "since 2024-04-11",
+				"since last-week", "since last-month",
+			},
+		},
+		{
+			name:   "Since a weekday",
+			prefix: "since t",
+			want:   []string{"since tuesday", "since thursday"},
+		},
+		{
+			name:   "Periods",
+			prefix: "t",
+			want:   []string{"today", "this-week", "this-month"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			got := timerange.Suggest(tc.prefix, now)
+
+			is.Equal(got, tc.want)
+			for _, expression := range got {
+				_, err := timerange.Parse(expression, now)
+				is.NoErr(err) // the suggestions are accepted by Parse
+			}
+		})
+	}
+}
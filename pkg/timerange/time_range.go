@@ -4,9 +4,14 @@ import (
 	"time"
 )
 
+// TimeRange is a range of time, a zero Since or Until leaves that side
+// unbounded. Both bounds are inclusive unless ExcludeSince or ExcludeUntil is
+// set.
 type TimeRange struct {
-	Since time.Time
-	Until time.Time
+	Since        time.Time
+	Until        time.Time
+	ExcludeSince bool
+	ExcludeUntil bool
 }
 
 func (t TimeRange) IsZero() bool {
@@ -34,13 +39,19 @@ func NewDayTimeRange(day time.Time) TimeRange {
 	}
 }
 
+// NewWeekTimeRange returns the week of the day, for weeks starting on monday
 func NewWeekTimeRange(day time.Time) TimeRange {
-	weekDay := int(day.Weekday())
-	weekStart := day.AddDate(0, 0, -(weekDay - 1))
-	weekEnd := weekStart.AddDate(0, 0, 7).Add(-time.Second)
+	return NewWeekTimeRangeFrom(day, time.Monday)
+}
+
+// NewWeekTimeRangeFrom returns the week of the day, for weeks starting on the
+// given day
+func NewWeekTimeRangeFrom(day time.Time, firstDay time.Weekday) TimeRange {
+	offset := (int(day.Weekday()) - int(firstDay) + 7) % 7
+	weekStart := time.Date(day.Year(), day.Month(), day.Day()-offset, 0, 0, 0, 0, time.UTC)
 	return TimeRange{
-		Since: time.Date(weekStart.Year(), weekStart.Month(), weekStart.Day(), 0, 0, 0, 0, time.UTC),
-		Until: time.Date(weekEnd.Year(), weekEnd.Month(), weekEnd.Day(), 0, 0, 0, 0, time.UTC).Add(-time.Second),
+		Since: weekStart,
+		Until: weekStart.AddDate(0, 0, 7).Add(-time.Second),
 	}
 }
 
@@ -107,6 +107,18 @@ func TestTimeRange_NewWeekTimeRange(t *testing.T) {
 	}
 }
 
+func TestTimeRange_NewWeekTimeRangeOnSunday(t *testing.T) {
+	day := time.Date(2024, 4, 21, 19, 0, 0, 0, time.UTC)
+	expected := timerange.TimeRange{
+		Since: time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC),
+		Until: time.Date(2024, 4, 22, 0, 0, 0, 0, time.UTC).Add(-time.Second),
+	}
+	got := timerange.NewWeekTimeRange(day)
+	if got != expected {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
 func TestTimeRange_NewMonthTimeRange(t *testing.T) {
 	day := time.Date(2024, 4, 17, 19, 0, 0, 0, time.UTC)
 	expected := timerange.TimeRange{
@@ -118,3 +130,48 @@ func TestTimeRange_NewMonthTimeRange(t *testing.T) {
 		t.Errorf("Expected %v, got %v", expected, got)
 	}
 }
+
+func TestTimeRange_NewWeekTimeRangeFrom(t *testing.T) {
+	tests := []struct {
+		name     string
+		day      time.Time
+		firstDay time.Weekday
+		want     timerange.TimeRange
+	}{
+		{
+			name:     "Week starting on monday",
+			day:      time.Date(2024, 4, 17, 19, 0, 0, 0, time.UTC),
+			firstDay: time.Monday,
+			want: timerange.TimeRange{
+				Since: time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC),
+				Until: time.Date(2024, 4, 22, 0, 0, 0, 0, time.UTC).Add(-time.Second),
+			},
+		},
+		{
+			name:     "Sunday of a week starting on monday",
+			day:      time.Date(2024, 4, 21, 19, 0, 0, 0, time.UTC),
+			firstDay: time.Monday,
+			want: timerange.TimeRange{
+				Since: time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC),
+				Until: time.Date(2024, 4, 22, 0, 0, 0, 0, time.UTC).Add(-time.Second),
+			},
+		},
+		{
+			name:     "Week starting on sunday",
+			day:      time.Date(2024, 4, 17, 19, 0, 0, 0, time.UTC),
+			firstDay: time.Sunday,
+			want: timerange.TimeRange{
+				Since: time.Date(2024, 4, 14, 0, 0, 0, 0, time.UTC),
+				Until: time.Date(2024, 4, 21, 0, 0, 0, 0, time.UTC).Add(-time.Second),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := timerange.NewWeekTimeRangeFrom(tt.day, tt.firstDay); got != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -7,12 +7,62 @@ import (
 
 	"github.com/TristanShz/flow/internal/application"
 	app "github.com/TristanShz/flow/internal/application/usecases"
+	"github.com/TristanShz/flow/internal/application/usecases/backup/createbackup"
+	"github.com/TristanShz/flow/internal/application/usecases/backup/listbackups"
+	"github.com/TristanShz/flow/internal/application/usecases/backup/restorebackup"
+	"github.com/TristanShz/flow/internal/application/usecases/client/listclients"
+	"github.com/TristanShz/flow/internal/application/usecases/client/setclient"
+	"github.com/TristanShz/flow/internal/application/usecases/export/exportsessions"
 	abortsession "github.com/TristanShz/flow/internal/application/usecases/flowsession/abort"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/adjustsession"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/autostop"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/deletesession"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/deletesessions"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/diffperiods"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/editsession"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/federatedreport"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/heatmap"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/listsessionmetadata"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/listsessions"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/logsession"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/meetingpause"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/mergesessions"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/pomodoro"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/reminders"
 	"github.com/TristanShz/flow/internal/application/usecases/flowsession/sessionstatus"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/showsession"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/splitsession"
 	startsession "github.com/TristanShz/flow/internal/application/usecases/flowsession/start"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/stats"
 	"github.com/TristanShz/flow/internal/application/usecases/flowsession/stopsession"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/suggeststop"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/suggesttags"
 	"github.com/TristanShz/flow/internal/application/usecases/flowsession/viewsessionsreport"
+	"github.com/TristanShz/flow/internal/application/usecases/flowsession/weeklytrend"
+	"github.com/TristanShz/flow/internal/application/usecases/import/importsessions"
+	"github.com/TristanShz/flow/internal/application/usecases/import/resolveconflicts"
+	"github.com/TristanShz/flow/internal/application/usecases/invoice/createinvoices"
+	"github.com/TristanShz/flow/internal/application/usecases/journal/addjournalentry"
+	"github.com/TristanShz/flow/internal/application/usecases/journal/listjournal"
+	"github.com/TristanShz/flow/internal/application/usecases/operation/listoperations"
+	"github.com/TristanShz/flow/internal/application/usecases/operation/undo"
+	"github.com/TristanShz/flow/internal/application/usecases/project/forecast"
+	"github.com/TristanShz/flow/internal/application/usecases/project/goals"
 	"github.com/TristanShz/flow/internal/application/usecases/project/list"
+	"github.com/TristanShz/flow/internal/application/usecases/project/listtags"
+	"github.com/TristanShz/flow/internal/application/usecases/project/renameproject"
+	"github.com/TristanShz/flow/internal/application/usecases/project/setproject"
+	"github.com/TristanShz/flow/internal/application/usecases/project/synctemplates"
+	storearchive "github.com/TristanShz/flow/internal/application/usecases/store/archive"
+	storedoctor "github.com/TristanShz/flow/internal/application/usecases/store/doctor"
+	storeinfo "github.com/TristanShz/flow/internal/application/usecases/store/info"
+	storemigrate "github.com/TristanShz/flow/internal/application/usecases/store/migrate"
+	"github.com/TristanShz/flow/internal/application/usecases/tag/deletetag"
+	"github.com/TristanShz/flow/internal/application/usecases/tag/renametag"
+	"github.com/TristanShz/flow/internal/application/usecases/tag/retagsessions"
+	"github.com/TristanShz/flow/internal/application/usecases/trash/listtrash"
+	"github.com/TristanShz/flow/internal/application/usecases/trash/purgetrash"
+	"github.com/TristanShz/flow/internal/application/usecases/trash/restoresession"
 	"github.com/TristanShz/flow/internal/infra"
 	"github.com/spf13/cobra"
 )
@@ -34,16 +84,139 @@ func InitializeApp(
 	dateProvider application.DateProvider,
 ) *app.App {
 	idProvider := &infra.StubIDProvider{}
+	clientRepository := &infra.InMemoryClientRepository{}
+	projectRepository := &infra.InMemoryProjectRepository{}
+	journalRepository := &infra.InMemoryJournalRepository{}
+	importConflictsRepository := &infra.InMemoryImportConflictsRepository{}
+	invoiceRepository := &infra.InMemoryInvoiceRepository{}
+	// the sessions deleted from an in-memory repository are kept in its trash
+	sessionTrash, ok := sessionRepository.(application.SessionTrash)
+	if !ok {
+		sessionTrash = &infra.InMemorySessionRepository{}
+	}
+	reportStores := []application.ReportStore{{
+		Name:              application.LocalStore,
+		SessionRepository: sessionRepository,
+		ProjectRepository: projectRepository,
+		JournalRepository: journalRepository,
+	}}
+	activeSessionLock := &infra.InMemoryActiveSessionLock{}
+	templatesRepository := &infra.InMemoryTemplatesRepository{}
+	templatesFetcher := &infra.StubTemplatesFetcher{}
+	auditLog := &infra.InMemoryAuditLog{}
+	operationLog := &infra.InMemoryOperationLog{}
+	backupStore := &infra.InMemoryBackupStore{}
+	eventPublisher := &infra.InMemoryEventPublisher{}
 
-	startFlowSessionUseCase := startsession.NewStartFlowSessionUseCase(sessionRepository, dateProvider, idProvider)
-	stopFlowSessionUseCase := stopsession.NewStopSessionUseCase(sessionRepository, dateProvider)
-	abortFlowSessionUseCase := abortsession.NewAbortFlowSessionUseCase(sessionRepository)
+	startFlowSessionUseCase := startsession.NewStartFlowSessionUseCase(sessionRepository, dateProvider, idProvider, activeSessionLock, projectRepository, templatesRepository, eventPublisher)
+	stopFlowSessionUseCase := stopsession.NewStopSessionUseCase(sessionRepository, dateProvider, activeSessionLock, projectRepository, idProvider, eventPublisher)
+	abortFlowSessionUseCase := abortsession.NewAbortFlowSessionUseCase(sessionRepository, activeSessionLock)
 	flowSessionStatusUseCase := sessionstatus.NewFlowSessionStatusUseCase(sessionRepository, dateProvider)
 
-	viewSessionsReportUseCase := viewsessionsreport.NewViewSessionsReportUseCase(sessionRepository)
+	viewSessionsReportUseCase := viewsessionsreport.NewViewSessionsReportUseCase(sessionRepository, projectRepository, journalRepository, dateProvider)
 
 	listProjectsUseCase := list.NewListProjectsUseCase(sessionRepository)
 
+	weeklyTrendUseCase := weeklytrend.NewWeeklyTrendUseCase(sessionRepository, &infra.InMemoryDailyTotalsCache{}, dateProvider)
+
+	setClientUseCase := setclient.NewSetClientUseCase(clientRepository)
+
+	listClientsUseCase := listclients.NewListClientsUseCase(clientRepository)
+
+	doctorUseCase := storedoctor.NewDoctorUseCase(&infra.StubSessionFilesDoctor{}, sessionRepository)
+
+	migrateUseCase := storemigrate.NewMigrateUseCase(&infra.StubSessionFilesMigrator{})
+
+	suggestTagsUseCase := suggesttags.NewSuggestTagsUseCase(sessionRepository)
+
+	exportSessionsUseCase := exportsessions.NewExportSessionsUseCase(sessionRepository, projectRepository, clientRepository, journalRepository)
+
+	setProjectUseCase := setproject.NewSetProjectUseCase(projectRepository)
+
+	autostopUseCase := autostop.NewAutostopUseCase(sessionRepository, projectRepository, idProvider, activeSessionLock)
+
+	editSessionUseCase := editsession.NewEditSessionUseCase(sessionRepository, dateProvider, eventPublisher)
+
+	logSessionUseCase := logsession.NewLogSessionUseCase(sessionRepository, dateProvider, idProvider)
+
+	splitSessionUseCase := splitsession.NewSplitSessionUseCase(sessionRepository, idProvider)
+
+	mergeSessionsUseCase := mergesessions.NewMergeSessionsUseCase(sessionRepository)
+
+	renameProjectUseCase := renameproject.NewRenameProjectUseCase(sessionRepository, projectRepository)
+
+	renameTagUseCase := renametag.NewRenameTagUseCase(sessionRepository)
+
+	deleteTagUseCase := deletetag.NewDeleteTagUseCase(sessionRepository)
+
+	retagSessionsUseCase := retagsessions.NewRetagSessionsUseCase(sessionRepository)
+
+	diffPeriodsUseCase := diffperiods.NewDiffPeriodsUseCase(sessionRepository)
+
+	infoUseCase := storeinfo.NewInfoUseCase(&infra.StubStoreInspector{})
+
+	showSessionUseCase := showsession.NewShowSessionUseCase(sessionRepository)
+
+	syncTemplatesUseCase := synctemplates.NewSyncTemplatesUseCase(templatesFetcher, templatesRepository)
+
+	adjustSessionUseCase := adjustsession.NewAdjustSessionUseCase(sessionRepository, dateProvider, auditLog)
+
+	meetingPauseUseCase := meetingpause.NewMeetingPauseUseCase(sessionRepository, projectRepository, idProvider, activeSessionLock, eventPublisher)
+
+	listProjectTagsUseCase := listtags.NewListProjectTagsUseCase(sessionRepository)
+
+	deleteSessionUseCase := deletesession.NewDeleteSessionUseCase(sessionRepository, activeSessionLock)
+
+	importSessionsUseCase := importsessions.NewImportSessionsUseCase(sessionRepository, importConflictsRepository, idProvider)
+
+	addJournalEntryUseCase := addjournalentry.NewAddJournalEntryUseCase(journalRepository, dateProvider)
+
+	listJournalUseCase := listjournal.NewListJournalUseCase(journalRepository)
+
+	pomodoroUseCase := pomodoro.NewPomodoroUseCase(sessionRepository, dateProvider, startFlowSessionUseCase, stopFlowSessionUseCase)
+
+	remindersUseCase := reminders.NewRemindersUseCase(sessionRepository, dateProvider)
+
+	suggestStopUseCase := suggeststop.NewSuggestStopUseCase(sessionRepository, dateProvider)
+
+	forecastUseCase := forecast.NewForecastUseCase(sessionRepository, dateProvider)
+
+	statsUseCase := stats.NewStatsUseCase(sessionRepository, dateProvider)
+
+	heatmapUseCase := heatmap.NewHeatmapUseCase(sessionRepository, dateProvider)
+
+	resolveConflictsUseCase := resolveconflicts.NewResolveConflictsUseCase(sessionRepository, importConflictsRepository, idProvider)
+
+	goalsUseCase := goals.NewGoalsUseCase(sessionRepository, projectRepository, dateProvider)
+
+	createInvoicesUseCase := createinvoices.NewCreateInvoicesUseCase(sessionRepository, projectRepository, clientRepository, invoiceRepository, dateProvider)
+
+	federatedReportUseCase := federatedreport.NewFederatedReportUseCase(reportStores, dateProvider)
+
+	listTrashUseCase := listtrash.NewListTrashUseCase(sessionTrash)
+
+	restoreSessionUseCase := restoresession.NewRestoreSessionUseCase(sessionRepository, sessionTrash)
+
+	purgeTrashUseCase := purgetrash.NewPurgeTrashUseCase(sessionTrash, dateProvider)
+
+	undoUseCase := undo.NewUndoUseCase(sessionRepository, activeSessionLock, operationLog)
+
+	listOperationsUseCase := listoperations.NewListOperationsUseCase(operationLog)
+
+	deleteSessionsUseCase := deletesessions.NewDeleteSessionsUseCase(sessionRepository, activeSessionLock)
+
+	archiveUseCase := storearchive.NewArchiveUseCase(&infra.StubSessionArchiver{}, dateProvider)
+
+	createBackupUseCase := createbackup.NewCreateBackupUseCase(backupStore, dateProvider)
+
+	restoreBackupUseCase := restorebackup.NewRestoreBackupUseCase(sessionRepository, activeSessionLock, backupStore)
+
+	listBackupsUseCase := listbackups.NewListBackupsUseCase(backupStore)
+
+	listSessionsUseCase := listsessions.NewListSessionsUseCase(sessionRepository)
+
+	listSessionMetadataUseCase := listsessionmetadata.NewListSessionMetadataUseCase(sessionRepository)
+
 	return app.NewApp(
 		sessionRepository,
 		dateProvider,
@@ -53,5 +226,55 @@ func InitializeApp(
 		flowSessionStatusUseCase,
 		listProjectsUseCase,
 		viewSessionsReportUseCase,
+		weeklyTrendUseCase,
+		setClientUseCase,
+		listClientsUseCase,
+		doctorUseCase,
+		migrateUseCase,
+		suggestTagsUseCase,
+		exportSessionsUseCase,
+		setProjectUseCase,
+		autostopUseCase,
+		editSessionUseCase,
+		logSessionUseCase,
+		splitSessionUseCase,
+		mergeSessionsUseCase,
+		renameProjectUseCase,
+		renameTagUseCase,
+		deleteTagUseCase,
+		retagSessionsUseCase,
+		diffPeriodsUseCase,
+		infoUseCase,
+		showSessionUseCase,
+		syncTemplatesUseCase,
+		adjustSessionUseCase,
+		meetingPauseUseCase,
+		listProjectTagsUseCase,
+		deleteSessionUseCase,
+		importSessionsUseCase,
+		addJournalEntryUseCase,
+		listJournalUseCase,
+		pomodoroUseCase,
+		remindersUseCase,
+		suggestStopUseCase,
+		forecastUseCase,
+		statsUseCase,
+		heatmapUseCase,
+		resolveConflictsUseCase,
+		goalsUseCase,
+		createInvoicesUseCase,
+		federatedReportUseCase,
+		listTrashUseCase,
+		restoreSessionUseCase,
+		purgeTrashUseCase,
+		undoUseCase,
+		listOperationsUseCase,
+		deleteSessionsUseCase,
+		archiveUseCase,
+		createBackupUseCase,
+		restoreBackupUseCase,
+		listBackupsUseCase,
+		listSessionsUseCase,
+		listSessionMetadataUseCase,
 	)
 }
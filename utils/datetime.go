@@ -0,0 +1,28 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+)
+
+var dateTimeLayouts = []string{"2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02T15:04"}
+
+var timeLayouts = []string{"15:04:05", "15:04"}
+
+// ParseDateTime reads a date and time in the local timezone, a time alone is
+// on the day of now, e.g. "2024-04-13 09:30" or "09:30"
+func ParseDateTime(value string, now time.Time) (time.Time, error) {
+	for _, layout := range dateTimeLayouts {
+		if parsed, err := time.ParseInLocation(layout, value, now.Location()); err == nil {
+			return parsed, nil
+		}
+	}
+
+	for _, layout := range timeLayouts {
+		if parsed, err := time.ParseInLocation(layout, value, now.Location()); err == nil {
+			return time.Date(now.Year(), now.Month(), now.Day(), parsed.Hour(), parsed.Minute(), parsed.Second(), 0, now.Location()), nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date %v. expected format: YYYY-MM-DD HH:MM or HH:MM", value)
+}
@@ -0,0 +1,23 @@
+package utils
+
+import "github.com/charmbracelet/lipgloss"
+
+// heatmapBlocks are the cells of a heatmap, from a day without sessions to
+// the busiest day
+var heatmapBlocks = []string{"·", "░", "▒", "▓", "█"}
+
+var heatmapColors = []lipgloss.Color{"", "#0E4429", "#006D32", "#26A641", "#39D353"}
+
+// HeatmapLevels is the number of intensities of the cells of a heatmap
+var HeatmapLevels = len(heatmapBlocks)
+
+// HeatmapCell renders a cell of a heatmap, from level 0 for a day without
+// sessions to HeatmapLevels-1, as blocks filling up in shades of green
+func HeatmapCell(level int) string {
+	level = max(0, min(level, HeatmapLevels-1))
+	if level == 0 {
+		return Faint(heatmapBlocks[0])
+	}
+
+	return lipgloss.NewStyle().Foreground(heatmapColors[level]).Render(heatmapBlocks[level])
+}
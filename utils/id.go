@@ -1,11 +1,22 @@
 package utils
 
-import "math/rand"
+import (
+	"crypto/sha256"
+	"encoding/base32"
+	"math/rand"
+	"strings"
+)
 
 const (
-	chars = "abcdefghijklmnopqrstuvwxyz0123456789"
+	// chars is the lowercase base32 alphabet, ids avoid the characters easily
+	// mistaken for others like 0, 1, 8 and 9
+	chars = "abcdefghijklmnopqrstuvwxyz234567"
+	// IDLength is the length of the generated ids
+	IDLength = 7
 )
 
+var idEncoding = base32.NewEncoding(chars).WithPadding(base32.NoPadding)
+
 func GenerateID(length int) string {
 	id := make([]byte, length)
 	for i := range id {
@@ -14,12 +25,23 @@ func GenerateID(length int) string {
 	return string(id)
 }
 
+// NextID derives another id from the given one, always the same, so that an
+// id colliding with an existing one is regenerated deterministically
+func NextID(id string) string {
+	hash := sha256.Sum256([]byte(id))
+	next := idEncoding.EncodeToString(hash[:])
+
+	return next[:len(id)]
+}
+
+// isCharValid accepts the characters of the ids generated before they were
+// base32 too
 func isCharValid(char rune) bool {
 	return char >= 'a' && char <= 'z' || char >= '0' && char <= '9'
 }
 
 func IsIDValid(id string) bool {
-	if len(id) != 7 {
+	if len(id) != IDLength {
 		return false
 	}
 	for _, char := range id {
@@ -29,3 +51,9 @@ func IsIDValid(id string) bool {
 	}
 	return true
 }
+
+// IsShortID tells if the id only holds characters of the generated ids, so it
+// can be used as is in a filename
+func IsShortID(id string) bool {
+	return id != "" && strings.IndexFunc(id, func(char rune) bool { return !isCharValid(char) }) == -1
+}
@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+const progressBarWidth = 30
+
+// ProgressBar renders the progress of a long operation on a single line,
+// redrawn in place on every update
+type ProgressBar struct {
+	Out     io.Writer
+	Label   string
+	Total   int
+	percent int
+}
+
+func NewProgressBar(out io.Writer, label string, total int) *ProgressBar {
+	return &ProgressBar{
+		Out:     out,
+		Label:   label,
+		Total:   total,
+		percent: -1,
+	}
+}
+
+// progressCountStep is how often the count of a progress without total is
+// redrawn
+const progressCountStep = 100
+
+// Update redraws the bar when the progress moved by at least one percent.
+// Without a total, it redraws the count every hundred.
+func (p *ProgressBar) Update(done int) {
+	if p.Total <= 0 {
+		if done%progressCountStep == 0 {
+			fmt.Fprintf(p.Out, "\r%v (%v)", p.Label, done)
+		}
+		return
+	}
+
+	percent := done * 100 / p.Total
+	if percent == p.percent {
+		return
+	}
+	p.percent = percent
+
+	filled := progressBarWidth * done / p.Total
+	fmt.Fprintf(
+		p.Out,
+		"\r%v [%v%v] %3d%% (%v/%v)",
+		p.Label,
+		strings.Repeat("█", filled),
+		strings.Repeat("░", progressBarWidth-filled),
+		percent,
+		done,
+		p.Total,
+	)
+}
+
+// Done ends the line of the bar
+func (p *ProgressBar) Done() {
+	fmt.Fprintln(p.Out)
+}
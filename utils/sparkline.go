@@ -0,0 +1,51 @@
+package utils
+
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// currentMark separates the last block from the other ones, so that it stands
+// out without colors too, e.g. with NO_COLOR
+const currentMark = "│"
+
+// Sparkline renders the given values as a line of unicode blocks scaled to the
+// highest value, the last block is set apart and highlighted.
+func Sparkline(values []float64) string {
+	highest := 0.0
+	for _, value := range values {
+		highest = max(highest, value)
+	}
+
+	line := ""
+	for i, value := range values {
+		block := sparkBlocks[0]
+		if highest > 0 {
+			block = sparkBlocks[int(value/highest*float64(len(sparkBlocks)-1))]
+		}
+
+		if i == len(values)-1 {
+			if i > 0 {
+				line += Faint(currentMark)
+			}
+			line += TimeColor(string(block))
+		} else {
+			line += string(block)
+		}
+	}
+
+	return line
+}
+
+// Heatline renders parts between 0 and 1 as a line of unicode blocks, as high
+// as the part they stand for, a part of 0 being drawn as a faint shade.
+func Heatline(parts []float64) string {
+	line := ""
+	for _, part := range parts {
+		if part <= 0 {
+			line += Faint("░")
+			continue
+		}
+
+		line += string(sparkBlocks[int(part*float64(len(sparkBlocks)-1))])
+	}
+
+	return line
+}